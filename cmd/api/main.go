@@ -19,6 +19,14 @@
 // @name Authorization
 // @description JWT token ile kimlik doğrulama
 
+// @securityDefinitions.apikey ApiKeyAuth
+// @in header
+// @name X-API-Key
+// @description Entegrasyon anahtarı (Zapier, IFTTT); yalnızca /integrations tetikleyicilerinde geçerlidir
+
+// OpenAPI dokümanı (docs paketi, /swagger ve /openapi.json) handler açıklamalarından üretilir
+//go:generate swag init -g cmd/api/main.go -o ../../docs -d ../..
+
 package main
 
 import (
@@ -27,8 +35,10 @@ import (
 
 	"agri-management-api/docs"
 	"agri-management-api/internal/database"
+	"agri-management-api/internal/handlers"
 	"agri-management-api/internal/middleware"
 	"agri-management-api/internal/routes"
+	"agri-management-api/internal/services"
 
 	"github.com/gin-gonic/gin"
 	"github.com/joho/godotenv"
@@ -54,6 +64,11 @@ import (
 // @name Authorization
 // @description JWT token ile kimlik doğrulama
 
+// @securityDefinitions.apikey ApiKeyAuth
+// @in header
+// @name X-API-Key
+// @description Entegrasyon anahtarı (Zapier, IFTTT); yalnızca /integrations tetikleyicilerinde geçerlidir
+
 func main() {
 	// Environment değişkenlerini yükle
 	if err := godotenv.Load("config.env"); err != nil {
@@ -67,6 +82,85 @@ func main() {
 	}
 	defer db.Close()
 
+	// Dashboard ve analiz okumaları için okuma replikasını başlat (DB_READ_PATH)
+	readDB := database.InitReadDB(db)
+	if readDB != db {
+		defer readDB.Close()
+	}
+
+	// Hassas alanların şifreleme anahtarlarını yükle; şifrelenmemiş değerler şifrelenir
+	if err := services.NewFieldEncryptionService(db).Init(); err != nil {
+		log.Fatal("Alan şifreleme başlatılamadı:", err)
+	}
+
+	// Dışa aktarılan belgeleri imzalayacak anahtarı yükle (EXPORT_SIGNING_KEY)
+	if err := services.NewDocumentSigningService(db).Init(); err != nil {
+		log.Fatal("Belge imzalama başlatılamadı:", err)
+	}
+
+	// Sunucu kapanırken yarım kalan yeniden hesaplama, yedekleme ve bakım işlerini kapat
+	if err := services.NewRecalculationService(db).FailInterrupted(); err != nil {
+		log.Println("Yarım kalan yeniden hesaplama işleri kapatılamadı:", err)
+	}
+	if err := services.NewBackupService(db).FailInterrupted(); err != nil {
+		log.Println("Yarım kalan yedekler kapatılamadı:", err)
+	}
+	if err := services.NewDatabaseMaintenanceService(db).FailInterrupted(); err != nil {
+		log.Println("Yarım kalan veritabanı bakımları kapatılamadı:", err)
+	}
+
+	// Arazi hava geçmişi toplayıcısını başlat
+	services.NewWeatherHistoryService(db).StartCollector()
+
+	// Sulama hatırlatmalarını başlat
+	services.NewIrrigationService(db).StartReminders()
+	services.NewWeatherLocationService(db).StartDelivery()
+
+	// Veteriner ziyaret hatırlatmalarını başlat
+	handlers.NewVetVisitHandler(db).StartReminders()
+
+	// Gecelik KPI anlık görüntülerini başlat
+	services.NewKPISnapshotService(db).StartRecorder()
+
+	// Zamanlanmış çiftlik yedeklerini başlat
+	services.NewBackupService(db).StartScheduler()
+
+	// WAL checkpoint ve gecelik VACUUM/ANALYZE bakımını başlat
+	services.NewDatabaseMaintenanceService(db).StartScheduler()
+
+	// Aylık amortisman giderlerinin finansa işlenmesini başlat
+	services.NewDepreciationService(db).StartPoster()
+
+	// Süresi yaklaşan doküman hatırlatmalarını başlat
+	handlers.NewDocumentHandler(db).StartReminders()
+
+	// Vadesi geçen ödeme bildirimlerini başlat
+	handlers.NewFinanceHandler(db).StartReminders()
+
+	// Kovan tedavi hatırlatmalarını başlat
+	handlers.NewHiveHandler(db).StartReminders()
+
+	// Metrik anomali kontrollerini başlat
+	handlers.NewAnalyticsHandler(db, db).StartAnomalyChecks()
+
+	// Yaklaşan takvim etkinliği hatırlatmalarını başlat
+	handlers.NewCalendarHandler(db).StartReminders()
+
+	// Aşı ve sağlık kontrolü hatırlatmalarını başlat
+	handlers.NewLivestockHandler(db).StartHealthReminders()
+
+	// Aşı takviminden sağlık durumu türetmeyi ve aşı takvimi hatırlatmalarını başlat
+	services.NewVaccinationService(db).StartScheduler()
+
+	// Kayıtlardaki tarihlerden otomatik takvim etkinliklerinin oluşturulmasını başlat
+	services.NewEventRuleService(db).StartGenerator()
+
+	// Sandbox hesaplarının gecelik sıfırlanmasını başlat (DEMO_RESET_HOUR)
+	services.NewDemoAccountService(db).StartScheduler()
+
+	// Rapor oluşturma gibi uzun süren işlerin kuyruk işçilerini başlat (JOB_WORKERS)
+	services.NewJobService(db).StartWorkers()
+
 	// Gin router'ı oluştur
 	gin.SetMode(gin.ReleaseMode)
 	if os.Getenv("ENV") == "development" {
@@ -74,6 +168,11 @@ func main() {
 	}
 
 	r := gin.Default()
+	// İstemci IP'si yalnızca TRUSTED_PROXIES'teki vekillerin X-Forwarded-For başlığından okunur; aksi halde
+	// başlık taklit edilerek rate limiting atlatılabilir
+	if err := r.SetTrustedProxies(middleware.TrustedProxies()); err != nil {
+		log.Fatal("Güvenilen vekil listesi geçersiz:", err)
+	}
 
 	// Middleware'leri ekle
 	r.Use(middleware.CORS())
@@ -81,7 +180,7 @@ func main() {
 	r.Use(middleware.Recovery())
 
 	// Routes'ları ayarla
-	routes.SetupRoutes(r, db)
+	routes.SetupRoutes(r, db, readDB)
 
 	// Swagger dokümantasyonu
 	docs.SwaggerInfo.Title = "Tarım Yönetim Sistemi API"
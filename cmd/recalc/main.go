@@ -0,0 +1,67 @@
+// Command recalc veri aktarımı veya hata düzeltmesinden sonra türetilmiş değerleri (ürün stok miktarları,
+// arazi verimi, KPI anlık görüntüleri) komut satırından yeniden hesaplar. İş, yönetici uç noktalarından
+// başlatılanlar gibi recalculation_jobs tablosuna kaydedilir.
+//
+// Kullanım:
+//
+//	go run ./cmd/recalc                                   # tüm çiftlikler, tüm hedefler
+//	go run ./cmd/recalc -farm <id> -targets kpi_snapshots # tek çiftlik, seçili hedefler
+package main
+
+import (
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"strings"
+
+	"agri-management-api/internal/database"
+	"agri-management-api/internal/models"
+	"agri-management-api/internal/services"
+
+	"github.com/joho/godotenv"
+)
+
+func main() {
+	farmID := flag.String("farm", "", "Yalnızca bu çiftliği yeniden hesapla (boşsa tüm çiftlikler)")
+	targets := flag.String("targets", "", "Virgülle ayrılmış hedefler: "+strings.Join(services.RecalculationTargets, ", ")+" (boşsa tümü)")
+	flag.Parse()
+
+	if err := godotenv.Load("config.env"); err != nil {
+		log.Println("config.env dosyası bulunamadı, varsayılan değerler kullanılıyor")
+	}
+
+	db, err := database.InitDB()
+	if err != nil {
+		log.Fatal("Veritabanı başlatılamadı:", err)
+	}
+	defer db.Close()
+
+	req := models.RecalculationRequest{FarmID: *farmID}
+	for _, target := range strings.Split(*targets, ",") {
+		if target = strings.TrimSpace(target); target != "" {
+			req.Targets = append(req.Targets, target)
+		}
+	}
+
+	recalculation := services.NewRecalculationService(db)
+	job, farmIDs, err := recalculation.Create("cli", req)
+	if err != nil {
+		log.Fatal("Yeniden hesaplama başlatılamadı: ", err)
+	}
+
+	log.Printf("Yeniden hesaplama başladı: iş %s, %d çiftlik, hedefler %s", job.ID, len(farmIDs), strings.Join(job.Targets, ", "))
+	job = recalculation.Execute(job, farmIDs, func(progress models.RecalculationJob) {
+		fmt.Printf("\r%d/%d adım (%%%.0f), %d kayıt güncellendi, %d hata", progress.Processed, progress.Total,
+			progress.Progress, progress.UpdatedRecords, progress.Failed)
+	})
+	fmt.Println()
+
+	for _, message := range job.Errors {
+		log.Println("Hata:", message)
+	}
+	log.Printf("Yeniden hesaplama bitti: %s", job.Status)
+	if job.Status == models.RecalculationFailed {
+		os.Exit(1)
+	}
+}
@@ -2,20 +2,18 @@ package database
 
 import (
 	"database/sql"
+	"fmt"
 	"log"
 	"os"
-
-	_ "github.com/mattn/go-sqlite3"
+	"strings"
 )
 
 // InitDB veritabanını başlatır ve gerekli tabloları oluşturur
 func InitDB() (*sql.DB, error) {
-	dbPath := os.Getenv("DB_PATH")
-	if dbPath == "" {
-		dbPath = "./agri_management.db"
-	}
-
-	db, err := sql.Open("sqlite3", dbPath)
+	// Sorgu süreleri yavaş sorgu günlüğü ve istek bazında veritabanı süresi için ölçülür; eşik config.env
+	// yüklendikten sonra okunur
+	queryMetrics.threshold = slowQueryThreshold()
+	db, err := sql.Open(metricsDriverName, primaryPath())
 	if err != nil {
 		return nil, err
 	}
@@ -25,15 +23,49 @@ func InitDB() (*sql.DB, error) {
 		return nil, err
 	}
 
+	// Günlük kipi dosyada kalıcıdır; WAL kipinde okumalar yazmaları beklemez
+	if err := setJournalMode(db); err != nil {
+		return nil, err
+	}
+
 	// Tabloları oluştur
 	if err := createTables(db); err != nil {
 		return nil, err
 	}
 
+	// Kullanıcıya ait tablolar kiracı kapsamı denetimi için şemadan okunur; mod config.env yüklendikten sonra okunur
+	tenantScope.mode = tenantScopeMode()
+	if err := loadTenantTables(db); err != nil {
+		return nil, err
+	}
+
 	log.Println("✅ Veritabanı başarıyla başlatıldı")
 	return db, nil
 }
 
+// primaryPath yazmaların yapıldığı birincil veritabanının yolu
+func primaryPath() string {
+	if dbPath := os.Getenv("DB_PATH"); dbPath != "" {
+		return dbPath
+	}
+	return "./agri_management.db"
+}
+
+// setJournalMode DB_JOURNAL_MODE (wal, delete, truncate, persist; varsayılan wal) günlük kipini ayarlar
+func setJournalMode(db *sql.DB) error {
+	mode := strings.ToLower(os.Getenv("DB_JOURNAL_MODE"))
+	if mode == "" {
+		mode = "wal"
+	}
+	switch mode {
+	case "wal", "delete", "truncate", "persist":
+	default:
+		return fmt.Errorf("geçersiz DB_JOURNAL_MODE: %s", mode)
+	}
+	_, err := db.Exec("PRAGMA journal_mode = " + mode)
+	return err
+}
+
 // createTables gerekli tabloları oluşturur
 func createTables(db *sql.DB) error {
 	tables := []string{
@@ -47,6 +79,102 @@ func createTables(db *sql.DB) error {
 		createHealthRecordsTable,
 		createMilkProductionTable,
 		createLandActivitiesTable,
+		createFeatureFlagsTable,
+		createCooperativeMembershipsTable,
+		createLivestockMovementsTable,
+		createActivityTemplatesTable,
+		createMediaAttachmentsTable,
+		createCategoriesTable,
+		createSavedViewsTable,
+		createWeatherObservationsTable,
+		createEntityChangesTable,
+		createTreatmentProtocolsTable,
+		createVeterinarianLinksTable,
+		createVetVisitsTable,
+		createSlaughterRecordsTable,
+		createLivestockCostsTable,
+		createFixedAssetsTable,
+		createDepreciationPostingsTable,
+		createUtilityMetersTable,
+		createMeterReadingsTable,
+		createCarbonFootprintsTable,
+		createComplianceChecklistsTable,
+		createComplianceStatusesTable,
+		createDocumentsTable,
+		createFarmsTable,
+		createTransactionTagsTable,
+		createInboundEmailAddressesTable,
+		createTransactionDraftsTable,
+		createBankAccountsTable,
+		createBankStatementsTable,
+		createBankStatementLinesTable,
+		createProductionSalesTable,
+		createMarketPricesTable,
+		createProductionLossesTable,
+		createGreenhousesTable,
+		createClimateSensorsTable,
+		createClimateReadingsTable,
+		createClimateAlertsTable,
+		createHivesTable,
+		createHiveInspectionsTable,
+		createHiveHarvestsTable,
+		createHiveTreatmentsTable,
+		createPondsTable,
+		createFishBatchesTable,
+		createFishBatchRecordsTable,
+		createEntityNotesTable,
+		createMetricAnomaliesTable,
+		createMetricForecastsTable,
+		createAdvisorConversationsTable,
+		createAdvisorMessagesTable,
+		createPestDiseaseObservationsTable,
+		createMessageTemplatesTable,
+		createRecordLinksTable,
+		createDataImportsTable,
+		createIntegrationKeysTable,
+		createKPISnapshotsTable,
+		createLandActivityCostItemsTable,
+		createSupportTicketsTable,
+		createSupportTicketMessagesTable,
+		createChangelogEntriesTable,
+		createChangelogSeenTable,
+		createMaintenanceWindowsTable,
+		createRecalculationJobsTable,
+		createEncryptionKeysTable,
+		createBackupsTable,
+		createDBMaintenanceRunsTable,
+		createWeatherStationsTable,
+		createWeatherStationReadingsTable,
+		createHarvestCrewEntriesTable,
+		createWaterQuotasTable,
+		createScoutingSessionsTable,
+		createScoutingPointsTable,
+		createGeneratedEventsTable,
+		createReportsTable,
+		createJobsTable,
+		createLivestockBreedsTable,
+		createWorkersTable,
+		createWorkerCertificationsTable,
+		createVaccinationsTable,
+		createBreedingRecordsTable,
+		createCostAllocationRulesTable,
+		createCostAllocationsTable,
+		createEnterprisesTable,
+		createWeightRecordsTable,
+		createAccountantAccessTable,
+		createCropPlansTable,
+		createFarmPublicProfilesTable,
+		createWeatherLocationsTable,
+		createHerdBookTemplatesTable,
+		createCustomersTable,
+		createSalesOrdersTable,
+		createDocumentSigningKeysTable,
+		createDocumentSignaturesTable,
+		createInvoicesTable,
+		createDemoAccountsTable,
+		createArchiveBatchesTable,
+		createArchiveAggregatesTable,
+		createSchemaMigrationsTable,
 	}
 
 	for _, table := range tables {
@@ -55,10 +183,366 @@ func createTables(db *sql.DB) error {
 		}
 	}
 
+	if err := addMissingColumns(db); err != nil {
+		return err
+	}
+
+	for _, index := range addedIndexes {
+		if _, err := db.Exec(index); err != nil {
+			return err
+		}
+	}
+
+	if err := seedSystemCategories(db); err != nil {
+		return err
+	}
+
+	if err := seedLivestockBreeds(db); err != nil {
+		return err
+	}
+
+	if err := seedTreatmentProtocols(db); err != nil {
+		return err
+	}
+
+	if err := seedComplianceChecklists(db); err != nil {
+		return err
+	}
+
+	if err := runDataMigrations(db); err != nil {
+		return err
+	}
+
 	log.Println("✅ Tüm tablolar başarıyla oluşturuldu")
 	return nil
 }
 
+// addedColumns mevcut tablolara sonradan eklenen sütunlar; eski veritabanlarında eksikse eklenir
+var addedColumns = []struct {
+	table, column, definition string
+}{
+	{"notifications", "topic", "TEXT"},
+	{"notifications", "related_entity_name", "TEXT"},
+	{"notifications", "actions", "TEXT"},
+	{"livestock", "acquisition_type", "TEXT DEFAULT 'born'"},
+	{"livestock", "acquisition_date", "DATE"},
+	{"livestock", "purchase_price", "REAL"},
+	{"livestock", "purchase_currency", "TEXT"},
+	{"livestock", "seller", "TEXT"},
+	{"livestock", "purchase_transaction_id", "TEXT"},
+	{"livestock", "sale_date", "DATE"},
+	{"livestock", "sale_price", "REAL"},
+	{"livestock", "buyer", "TEXT"},
+	{"livestock", "sale_transaction_id", "TEXT"},
+	{"land_activities", "fertilizer_kg", "REAL"},
+	{"land_activities", "water_volume", "REAL"},
+	{"land_activities", "nitrogen_percent", "REAL"},
+	{"lands", "parcel_province", "TEXT"},
+	{"lands", "parcel_district", "TEXT"},
+	{"lands", "parcel_neighborhood", "TEXT"},
+	{"lands", "parcel_neighborhood_code", "TEXT"},
+	{"lands", "parcel_block", "TEXT"},
+	{"lands", "parcel_number", "TEXT"},
+	{"lands", "boundary", "TEXT"},
+	{"transactions", "due_date", "DATE"},
+	{"transactions", "paid_at", "DATETIME"},
+	{"transactions", "overdue_notified_at", "DATETIME"},
+	{"production", "sold_amount", "REAL DEFAULT 0"},
+	{"production", "unit_cost", "REAL"},
+	{"production", "lost_amount", "REAL DEFAULT 0"},
+	{"lands", "land_type", "TEXT DEFAULT 'field'"},
+	{"notifications", "dedupe_key", "TEXT"},
+	{"fixed_assets", "hourly_rate", "REAL"},
+	{"lands", "weather_station_id", "TEXT"},
+	{"lands", "weather_sources", "TEXT"},
+	{"land_activities", "assigned_worker_id", "TEXT"},
+	{"livestock", "breeding_record_id", "TEXT"},
+	{"livestock", "enterprise_id", "TEXT"},
+	{"lands", "enterprise_id", "TEXT"},
+	{"transactions", "enterprise_id", "TEXT"},
+	{"production", "enterprise_id", "TEXT"},
+	{"entity_changes", "user_id", "TEXT"},
+}
+
+// addedIndexes sonradan eklenen sütunlar üzerindeki indeksler; sütunlar eklendikten sonra oluşturulur
+var addedIndexes = []string{
+	"CREATE INDEX IF NOT EXISTS idx_notifications_dedupe ON notifications (user_id, dedupe_key, created_at)",
+	"CREATE INDEX IF NOT EXISTS idx_livestock_breeding_record ON livestock (breeding_record_id) WHERE breeding_record_id IS NOT NULL",
+	"CREATE INDEX IF NOT EXISTS idx_transactions_enterprise ON transactions (user_id, enterprise_id) WHERE enterprise_id IS NOT NULL",
+	"CREATE INDEX IF NOT EXISTS idx_entity_changes_user ON entity_changes (user_id, entity_type, entity_id, changed_at)",
+}
+
+// addMissingColumns addedColumns listesindeki eksik sütunları ekler
+func addMissingColumns(db *sql.DB) error {
+	existing := map[string]map[string]bool{}
+
+	for _, column := range addedColumns {
+		if existing[column.table] == nil {
+			columns, err := tableColumnNames(db, column.table)
+			if err != nil {
+				return err
+			}
+			existing[column.table] = columns
+		}
+
+		if existing[column.table][column.column] {
+			continue
+		}
+
+		if _, err := db.Exec("ALTER TABLE " + column.table + " ADD COLUMN " + column.column + " " + column.definition); err != nil {
+			return err
+		}
+		existing[column.table][column.column] = true
+	}
+
+	return nil
+}
+
+// tableColumnNames tablonun sütun adlarını döner
+func tableColumnNames(db *sql.DB, table string) (map[string]bool, error) {
+	rows, err := db.Query("PRAGMA table_info(" + table + ")")
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	columns := map[string]bool{}
+	for rows.Next() {
+		var cid, notNull, pk int
+		var name, columnType string
+		var defaultValue sql.NullString
+		if err := rows.Scan(&cid, &name, &columnType, &notNull, &defaultValue, &pk); err != nil {
+			return nil, err
+		}
+		columns[name] = true
+	}
+
+	return columns, rows.Err()
+}
+
+// seedSystemCategories varsayılan hayvan ve üretim kategorilerini ekler
+func seedSystemCategories(db *sql.DB) error {
+	categories := []struct {
+		domain, key, label, icon, color string
+	}{
+		{"livestock", "cattle", "Sığır", "🐄", "#4CAF50"},
+		{"livestock", "sheep", "Koyun", "🐑", "#2196F3"},
+		{"livestock", "goat", "Keçi", "🐐", "#FF9800"},
+		{"livestock", "chicken", "Tavuk", "🐔", "#9C27B0"},
+		{"livestock", "other", "Diğer", "🐾", "#607D8B"},
+		{"production", "vegetables", "Sebze", "🥬", "#4CAF50"},
+		{"production", "fruits", "Meyve", "🍎", "#FF5722"},
+		{"production", "grains", "Tahıl", "🌾", "#FF9800"},
+		{"production", "dairy", "Süt Ürünleri", "🥛", "#2196F3"},
+		{"production", "meat", "Et", "🥩", "#795548"},
+		{"production", "other", "Diğer", "🌱", "#607D8B"},
+	}
+
+	for i, category := range categories {
+		_, err := db.Exec(`
+			INSERT OR IGNORE INTO categories (id, user_id, domain, key, label, icon, color, sort_order)
+			VALUES (?, NULL, ?, ?, ?, ?, ?, ?)
+		`, "system:"+category.domain+":"+category.key, category.domain, category.key,
+			category.label, category.icon, category.color, i)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// seedLivestockBreeds sistem hayvan türlerinin yaygın ırklarını ekler
+func seedLivestockBreeds(db *sql.DB) error {
+	breeds := map[string][]string{
+		"cattle":  {"Holstein", "Simental", "Montofon", "Jersey", "Angus", "Yerli Kara", "Boz Irk", "Melez"},
+		"sheep":   {"Akkaraman", "Merinos", "Kıvırcık", "İvesi", "Sakız", "Karayaka", "Morkaraman", "Melez"},
+		"goat":    {"Kıl Keçisi", "Saanen", "Ankara Keçisi", "Honamlı", "Kilis", "Malta", "Melez"},
+		"chicken": {"Leghorn", "Rhode Island Red", "Atak-S", "Sussex", "Plymouth Rock", "Brahma", "Melez"},
+	}
+
+	for species, names := range breeds {
+		for _, name := range names {
+			_, err := db.Exec(`
+				INSERT OR IGNORE INTO livestock_breeds (id, user_id, species, name) VALUES (?, NULL, ?, ?)
+			`, "system:"+species+":"+name, species, name)
+			if err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+// dataMigrations veritabanı başına yalnızca bir kez çalışması gereken veri aktarımları; uygulananlar
+// schema_migrations tablosuna adıyla kaydedilir ve sonraki açılışlarda atlanır
+var dataMigrations = []struct {
+	name string
+	run  func(tx *sql.Tx) error
+}{
+	{"weight_history_backfill", migrateWeightHistory},
+	{"entity_changes_user_id", migrateEntityChangeOwners},
+}
+
+// runDataMigrations henüz uygulanmamış veri aktarımlarını işaretleriyle birlikte tek işlemde çalıştırır
+func runDataMigrations(db *sql.DB) error {
+	for _, migration := range dataMigrations {
+		var applied int
+		err := db.QueryRow("SELECT COUNT(*) FROM schema_migrations WHERE name = ?", migration.name).Scan(&applied)
+		if err != nil {
+			return err
+		}
+		if applied > 0 {
+			continue
+		}
+
+		tx, err := db.Begin()
+		if err != nil {
+			return err
+		}
+		if err := migration.run(tx); err != nil {
+			tx.Rollback()
+			return fmt.Errorf("%s veri aktarımı başarısız: %w", migration.name, err)
+		}
+		if _, err := tx.Exec("INSERT INTO schema_migrations (name) VALUES (?)", migration.name); err != nil {
+			tx.Rollback()
+			return err
+		}
+		if err := tx.Commit(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// migrateWeightHistory tartım kaydı olmayan hayvanların ağırlık geçmişini tartım kayıtlarına aktarır: ilk
+// değişiklikten önceki ağırlık kayıt tarihine, değişiklik geçmişindeki her ağırlık değişiklik tarihine, hiç
+// değişmemiş ağırlık kayıt tarihine yazılır. Bir kez çalışır; sonradan silinen tartımlar geri gelmez
+func migrateWeightHistory(tx *sql.Tx) error {
+	_, err := tx.Exec(`
+		INSERT OR IGNORE INTO weight_records (id, livestock_id, weight, record_date, notes, created_at)
+		SELECT 'weight:' || c.id, l.id, CAST(c.new_value AS REAL), date(c.changed_at), '', c.changed_at
+		FROM entity_changes c
+		JOIN livestock l ON l.id = c.entity_id
+		WHERE c.entity_type = 'livestock' AND c.field = 'weight' AND CAST(c.new_value AS REAL) > 0
+		  AND NOT EXISTS (SELECT 1 FROM weight_records w WHERE w.livestock_id = l.id)
+		UNION ALL
+		SELECT 'weight:initial:' || l.id, l.id, CAST(c.old_value AS REAL), date(l.created_at), '', l.created_at
+		FROM entity_changes c
+		JOIN livestock l ON l.id = c.entity_id
+		WHERE c.entity_type = 'livestock' AND c.field = 'weight' AND CAST(c.old_value AS REAL) > 0
+		  AND NOT EXISTS (
+		      SELECT 1 FROM entity_changes p
+		      WHERE p.entity_type = 'livestock' AND p.entity_id = c.entity_id AND p.field = 'weight'
+		        AND (p.changed_at < c.changed_at OR p.changed_at = c.changed_at AND p.id < c.id)
+		  )
+		  AND NOT EXISTS (SELECT 1 FROM weight_records w WHERE w.livestock_id = l.id)
+		UNION ALL
+		SELECT 'weight:initial:' || l.id, l.id, l.weight, date(l.created_at), '', l.created_at
+		FROM livestock l
+		WHERE l.weight > 0
+		  AND NOT EXISTS (
+		      SELECT 1 FROM entity_changes c
+		      WHERE c.entity_type = 'livestock' AND c.entity_id = l.id AND c.field = 'weight'
+		  )
+		  AND NOT EXISTS (SELECT 1 FROM weight_records w WHERE w.livestock_id = l.id)
+	`)
+	return err
+}
+
+// migrateEntityChangeOwners user_id sütunundan önce yazılan değişiklik geçmişini varlığın çiftliğine bağlar;
+// varlığı silinmiş satırlar hiçbir çiftliğe bağlanamadığı için silinir
+func migrateEntityChangeOwners(tx *sql.Tx) error {
+	_, err := tx.Exec(`
+		UPDATE entity_changes SET user_id = CASE entity_type
+			WHEN 'livestock' THEN (SELECT l.user_id FROM livestock l WHERE l.id = entity_changes.entity_id)
+			WHEN 'land' THEN (SELECT l.user_id FROM lands l WHERE l.id = entity_changes.entity_id)
+		END
+		WHERE user_id IS NULL
+	`)
+	if err != nil {
+		return err
+	}
+	_, err = tx.Exec("DELETE FROM entity_changes WHERE user_id IS NULL")
+	return err
+}
+
+// seedTreatmentProtocols standart aşılama ve tedavi protokollerini ekler
+func seedTreatmentProtocols(db *sql.DB) error {
+	protocols := []struct {
+		key, name, species, description, steps string
+	}{
+		{"fmd_vaccination", "Şap Aşılama Serisi", "cattle", "Şap aşısı ilk doz, rapel ve 6 aylık tekrar",
+			`[{"dayOffset":0,"type":"vaccination","description":"Şap aşısı 1. doz"},` +
+				`{"dayOffset":30,"type":"vaccination","description":"Şap aşısı rapel doz"},` +
+				`{"dayOffset":180,"type":"vaccination","description":"Şap aşısı 6 aylık tekrar"}]`},
+		{"sheep_pox_vaccination", "Koyun-Keçi Çiçek Aşısı", "sheep", "Çiçek aşısı ve yıllık tekrar",
+			`[{"dayOffset":0,"type":"vaccination","description":"Koyun-keçi çiçek aşısı"},` +
+				`{"dayOffset":365,"type":"vaccination","description":"Koyun-keçi çiçek aşısı yıllık tekrar"}]`},
+		{"internal_parasite", "İç Parazit Mücadelesi", "", "Antiparaziter uygulama ve 21 gün sonra tekrar",
+			`[{"dayOffset":0,"type":"deworming","description":"İç parazit ilacı uygulaması"},` +
+				`{"dayOffset":21,"type":"deworming","description":"İç parazit ilacı tekrar uygulaması"}]`},
+		{"mastitis_treatment", "Mastitis Tedavisi", "cattle", "3 günlük meme içi tedavi ve kontrol muayenesi",
+			`[{"dayOffset":0,"type":"treatment","description":"Mastitis tedavisi 1. gün"},` +
+				`{"dayOffset":1,"type":"treatment","description":"Mastitis tedavisi 2. gün"},` +
+				`{"dayOffset":2,"type":"treatment","description":"Mastitis tedavisi 3. gün"},` +
+				`{"dayOffset":7,"type":"checkup","description":"Mastitis kontrol muayenesi ve süt testi"}]`},
+	}
+
+	for _, protocol := range protocols {
+		_, err := db.Exec(`
+			INSERT OR IGNORE INTO treatment_protocols (id, user_id, name, species, description, steps)
+			VALUES (?, NULL, ?, ?, ?, ?)
+		`, "system:protocol:"+protocol.key, protocol.name, protocol.species, protocol.description, protocol.steps)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// seedComplianceChecklists organik tarım ve GlobalGAP için standart kontrol listelerini ekler
+func seedComplianceChecklists(db *sql.DB) error {
+	checklists := []struct {
+		key, standard, name, description, requirements, logSources string
+	}{
+		{"organic", "organic", "Organik Tarım Uyum Listesi", "Organik tarım yönetmeliği kapsamında yıllık denetim hazırlığı",
+			`[{"code":"ORG-1","title":"Geçiş süreci belgeleri","description":"Arazilerin organik tarıma geçiş süresini tamamladığını gösteren kontrol kuruluşu belgeleri","category":"Arazi","evidenceRequired":true},` +
+				`{"code":"ORG-2","title":"Yasaklı girdi kullanılmaması","description":"Sentetik gübre ve kimyasal ilaç kullanılmadığına dair girdi kayıtları ve faturalar","category":"Girdiler","evidenceRequired":true},` +
+				`{"code":"ORG-3","title":"Organik tohum ve fide","description":"Kullanılan tohum ve fidelerin organik sertifikaları","category":"Girdiler","evidenceRequired":true},` +
+				`{"code":"ORG-4","title":"Gübreleme ve ilaçlama kayıtları","description":"Tüm gübreleme ve bitki koruma uygulamalarının tarih, ürün ve miktarla kaydı","category":"Kayıtlar","evidenceRequired":false},` +
+				`{"code":"ORG-5","title":"Tampon bölgeler","description":"Konvansiyonel komşu arazilerle arasında tampon bölge bulunduğunu gösteren fotoğraf veya kroki","category":"Arazi","evidenceRequired":true},` +
+				`{"code":"ORG-6","title":"Hayvan sağlığı ve ilaç kullanımı","description":"Veteriner tedavileri ve yasal bekleme sürelerinin kayıtları","category":"Hayvancılık","evidenceRequired":false},` +
+				`{"code":"ORG-7","title":"Hasat ve depolama ayrımı","description":"Organik ürünlerin konvansiyonel ürünlerden ayrı hasat ve depolandığının kanıtı","category":"Hasat","evidenceRequired":true}]`,
+			`["land_activities","health_records","transactions"]`},
+		{"globalgap", "globalgap", "GlobalGAP IFA Uyum Listesi", "GlobalGAP Entegre Çiftlik Güvencesi ana kontrol noktaları",
+			`[{"code":"AF-1","title":"Saha geçmişi ve risk değerlendirmesi","description":"Her üretim alanı için yazılı saha geçmişi ve risk değerlendirmesi","category":"Tüm Çiftlik","evidenceRequired":true},` +
+				`{"code":"AF-2","title":"Kayıt tutma ve iç denetim","description":"Son 12 aya ait kayıtlar ve yıllık iç denetim raporu","category":"Tüm Çiftlik","evidenceRequired":true},` +
+				`{"code":"AF-3","title":"İşçi sağlığı ve güvenliği eğitimi","description":"Çalışanlara verilen iş güvenliği ve hijyen eğitimlerinin kayıtları","category":"Tüm Çiftlik","evidenceRequired":true},` +
+				`{"code":"CB-1","title":"Bitki koruma ürünü uygulama kayıtları","description":"Uygulanan ürün, doz, tarih, uygulayıcı ve hasat öncesi bekleme süresi","category":"Bitkisel Üretim","evidenceRequired":false},` +
+				`{"code":"CB-2","title":"Gübre uygulama kayıtları","description":"Gübre türü, miktarı, tarihi ve uygulanan alanın kaydı","category":"Bitkisel Üretim","evidenceRequired":false},` +
+				`{"code":"CB-3","title":"Sulama suyu analizi","description":"Sulama suyunun yıllık mikrobiyolojik ve kimyasal analiz raporu","category":"Bitkisel Üretim","evidenceRequired":true},` +
+				`{"code":"LB-1","title":"Hayvan tanımlama ve hareket kayıtları","description":"Tüm hayvanların kimlik ve giriş-çıkış hareket kayıtları","category":"Hayvancılık","evidenceRequired":false},` +
+				`{"code":"LB-2","title":"Veteriner ilaç kayıtları","description":"Uygulanan veteriner ilaçları, dozları ve bekleme süreleri","category":"Hayvancılık","evidenceRequired":false}]`,
+			`["land_activities","health_records","livestock_movements"]`},
+	}
+
+	for _, checklist := range checklists {
+		_, err := db.Exec(`
+			INSERT OR IGNORE INTO compliance_checklists (id, user_id, standard, name, description, requirements, log_sources)
+			VALUES (?, NULL, ?, ?, ?, ?, ?)
+		`, "system:compliance:"+checklist.key, checklist.standard, checklist.name, checklist.description,
+			checklist.requirements, checklist.logSources)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
 // Tablo oluşturma SQL komutları
 const createUsersTable = `
 CREATE TABLE IF NOT EXISTS users (
@@ -232,3 +716,1633 @@ CREATE TABLE IF NOT EXISTS land_activities (
     created_at DATETIME DEFAULT CURRENT_TIMESTAMP,
     FOREIGN KEY (land_id) REFERENCES lands(id) ON DELETE CASCADE
 );`
+
+const createFeatureFlagsTable = `
+CREATE TABLE IF NOT EXISTS feature_flags (
+    key TEXT PRIMARY KEY,
+    description TEXT,
+    enabled BOOLEAN DEFAULT TRUE,
+    environments TEXT,
+    rollout_percentage INTEGER DEFAULT 100,
+    deprecated BOOLEAN DEFAULT FALSE,
+    deprecation_note TEXT,
+    updated_at DATETIME DEFAULT CURRENT_TIMESTAMP
+);`
+
+const createCooperativeMembershipsTable = `
+CREATE TABLE IF NOT EXISTS cooperative_memberships (
+    id TEXT PRIMARY KEY,
+    cooperative_admin_id TEXT NOT NULL,
+    member_user_id TEXT NOT NULL,
+    consent_status TEXT DEFAULT 'pending',
+    consented_at DATETIME,
+    created_at DATETIME DEFAULT CURRENT_TIMESTAMP,
+    UNIQUE (cooperative_admin_id, member_user_id),
+    FOREIGN KEY (cooperative_admin_id) REFERENCES users(id) ON DELETE CASCADE,
+    FOREIGN KEY (member_user_id) REFERENCES users(id) ON DELETE CASCADE
+);`
+
+const createLivestockMovementsTable = `
+CREATE TABLE IF NOT EXISTS livestock_movements (
+    id TEXT PRIMARY KEY,
+    livestock_id TEXT NOT NULL,
+    user_id TEXT NOT NULL,
+    movement_type TEXT NOT NULL,
+    movement_date DATE NOT NULL,
+    from_location TEXT,
+    to_location TEXT,
+    premises_number TEXT,
+    reason TEXT,
+    notes TEXT,
+    created_at DATETIME DEFAULT CURRENT_TIMESTAMP,
+    FOREIGN KEY (livestock_id) REFERENCES livestock(id) ON DELETE CASCADE,
+    FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE
+);`
+
+const createActivityTemplatesTable = `
+CREATE TABLE IF NOT EXISTS activity_templates (
+    id TEXT PRIMARY KEY,
+    user_id TEXT NOT NULL,
+    name TEXT NOT NULL,
+    target_type TEXT NOT NULL,
+    fields TEXT NOT NULL DEFAULT '{}',
+    use_count INTEGER DEFAULT 0,
+    last_used_at DATETIME,
+    created_at DATETIME DEFAULT CURRENT_TIMESTAMP,
+    updated_at DATETIME DEFAULT CURRENT_TIMESTAMP,
+    FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE
+);`
+
+const createMediaAttachmentsTable = `
+CREATE TABLE IF NOT EXISTS media_attachments (
+    id TEXT PRIMARY KEY,
+    user_id TEXT NOT NULL,
+    entity_type TEXT NOT NULL,
+    entity_id TEXT NOT NULL,
+    kind TEXT NOT NULL,
+    filename TEXT NOT NULL,
+    content_type TEXT,
+    size INTEGER DEFAULT 0,
+    storage_key TEXT NOT NULL,
+    duration_seconds REAL,
+    transcript TEXT,
+    transcript_status TEXT DEFAULT 'none',
+    created_at DATETIME DEFAULT CURRENT_TIMESTAMP,
+    FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE
+);`
+
+const createCategoriesTable = `
+CREATE TABLE IF NOT EXISTS categories (
+    id TEXT PRIMARY KEY,
+    user_id TEXT,
+    domain TEXT NOT NULL,
+    key TEXT NOT NULL,
+    label TEXT NOT NULL,
+    icon TEXT,
+    color TEXT,
+    sort_order INTEGER DEFAULT 0,
+    created_at DATETIME DEFAULT CURRENT_TIMESTAMP,
+    updated_at DATETIME DEFAULT CURRENT_TIMESTAMP,
+    UNIQUE (user_id, domain, key),
+    FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE
+);`
+
+const createSavedViewsTable = `
+CREATE TABLE IF NOT EXISTS saved_views (
+    id TEXT PRIMARY KEY,
+    user_id TEXT NOT NULL,
+    resource TEXT NOT NULL,
+    name TEXT NOT NULL,
+    filters TEXT NOT NULL DEFAULT '{}',
+    sort_by TEXT,
+    sort_order TEXT,
+    is_default BOOLEAN DEFAULT FALSE,
+    created_at DATETIME DEFAULT CURRENT_TIMESTAMP,
+    updated_at DATETIME DEFAULT CURRENT_TIMESTAMP,
+    FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE
+);`
+
+const createWeatherObservationsTable = `
+CREATE TABLE IF NOT EXISTS weather_observations (
+    id TEXT PRIMARY KEY,
+    land_id TEXT NOT NULL,
+    user_id TEXT NOT NULL,
+    observed_on DATE NOT NULL,
+    source TEXT NOT NULL DEFAULT 'provider',
+    min_temp REAL,
+    max_temp REAL,
+    avg_temp REAL,
+    rainfall REAL,
+    humidity REAL,
+    notes TEXT,
+    samples INTEGER DEFAULT 1,
+    created_at DATETIME DEFAULT CURRENT_TIMESTAMP,
+    updated_at DATETIME DEFAULT CURRENT_TIMESTAMP,
+    UNIQUE (land_id, observed_on, source),
+    FOREIGN KEY (land_id) REFERENCES lands(id) ON DELETE CASCADE,
+    FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE
+);`
+
+const createEntityChangesTable = `
+CREATE TABLE IF NOT EXISTS entity_changes (
+    id TEXT PRIMARY KEY,
+    change_set_id TEXT NOT NULL,
+    entity_type TEXT NOT NULL,
+    entity_id TEXT NOT NULL,
+    field TEXT NOT NULL,
+    old_value TEXT,
+    new_value TEXT,
+    changed_by TEXT NOT NULL,
+    changed_at DATETIME DEFAULT CURRENT_TIMESTAMP
+);
+CREATE INDEX IF NOT EXISTS idx_entity_changes_entity ON entity_changes (entity_type, entity_id, changed_at);`
+
+const createTreatmentProtocolsTable = `
+CREATE TABLE IF NOT EXISTS treatment_protocols (
+    id TEXT PRIMARY KEY,
+    user_id TEXT,
+    name TEXT NOT NULL,
+    species TEXT,
+    description TEXT,
+    steps TEXT NOT NULL DEFAULT '[]',
+    created_at DATETIME DEFAULT CURRENT_TIMESTAMP,
+    updated_at DATETIME DEFAULT CURRENT_TIMESTAMP,
+    FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE
+);`
+
+const createVeterinarianLinksTable = `
+CREATE TABLE IF NOT EXISTS veterinarian_links (
+    id TEXT PRIMARY KEY,
+    farmer_id TEXT NOT NULL,
+    veterinarian_id TEXT NOT NULL,
+    created_at DATETIME DEFAULT CURRENT_TIMESTAMP,
+    UNIQUE (farmer_id, veterinarian_id),
+    FOREIGN KEY (farmer_id) REFERENCES users(id) ON DELETE CASCADE,
+    FOREIGN KEY (veterinarian_id) REFERENCES users(id) ON DELETE CASCADE
+);`
+
+const createVetVisitsTable = `
+CREATE TABLE IF NOT EXISTS vet_visits (
+    id TEXT PRIMARY KEY,
+    farmer_id TEXT NOT NULL,
+    veterinarian_id TEXT NOT NULL,
+    reason TEXT NOT NULL,
+    livestock_ids TEXT NOT NULL DEFAULT '[]',
+    preferred_slots TEXT NOT NULL DEFAULT '[]',
+    status TEXT DEFAULT 'requested',
+    scheduled_start DATETIME,
+    scheduled_end DATETIME,
+    farmer_event_id TEXT,
+    veterinarian_event_id TEXT,
+    response_note TEXT,
+    findings TEXT,
+    health_record_ids TEXT NOT NULL DEFAULT '[]',
+    reminder_sent BOOLEAN DEFAULT FALSE,
+    created_at DATETIME DEFAULT CURRENT_TIMESTAMP,
+    updated_at DATETIME DEFAULT CURRENT_TIMESTAMP,
+    FOREIGN KEY (farmer_id) REFERENCES users(id) ON DELETE CASCADE,
+    FOREIGN KEY (veterinarian_id) REFERENCES users(id) ON DELETE CASCADE
+);
+CREATE INDEX IF NOT EXISTS idx_vet_visits_veterinarian ON vet_visits (veterinarian_id, status, scheduled_start);`
+
+const createSlaughterRecordsTable = `
+CREATE TABLE IF NOT EXISTS slaughter_records (
+    id TEXT PRIMARY KEY,
+    user_id TEXT NOT NULL,
+    livestock_id TEXT UNIQUE NOT NULL,
+    slaughter_date DATE NOT NULL,
+    live_weight REAL,
+    carcass_weight REAL NOT NULL,
+    grade TEXT,
+    buyer TEXT,
+    price REAL,
+    currency TEXT DEFAULT 'TRY',
+    movement_id TEXT,
+    transaction_id TEXT,
+    notes TEXT,
+    created_at DATETIME DEFAULT CURRENT_TIMESTAMP,
+    FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE,
+    FOREIGN KEY (livestock_id) REFERENCES livestock(id) ON DELETE CASCADE
+);`
+
+const createLivestockCostsTable = `
+CREATE TABLE IF NOT EXISTS livestock_costs (
+    id TEXT PRIMARY KEY,
+    user_id TEXT NOT NULL,
+    livestock_id TEXT NOT NULL,
+    type TEXT NOT NULL,
+    amount REAL NOT NULL,
+    date DATE NOT NULL,
+    description TEXT,
+    transaction_id TEXT,
+    created_at DATETIME DEFAULT CURRENT_TIMESTAMP,
+    FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE,
+    FOREIGN KEY (livestock_id) REFERENCES livestock(id) ON DELETE CASCADE
+);`
+
+const createFixedAssetsTable = `
+CREATE TABLE IF NOT EXISTS fixed_assets (
+    id TEXT PRIMARY KEY,
+    user_id TEXT NOT NULL,
+    name TEXT NOT NULL,
+    category TEXT NOT NULL,
+    purchase_date DATE NOT NULL,
+    cost REAL NOT NULL,
+    salvage_value REAL DEFAULT 0,
+    useful_life_months INTEGER NOT NULL,
+    method TEXT NOT NULL DEFAULT 'straight_line',
+    declining_factor REAL DEFAULT 2,
+    status TEXT DEFAULT 'active',
+    disposed_at DATE,
+    notes TEXT,
+    created_at DATETIME DEFAULT CURRENT_TIMESTAMP,
+    updated_at DATETIME DEFAULT CURRENT_TIMESTAMP,
+    FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE
+);`
+
+const createDepreciationPostingsTable = `
+CREATE TABLE IF NOT EXISTS depreciation_postings (
+    id TEXT PRIMARY KEY,
+    asset_id TEXT NOT NULL,
+    user_id TEXT NOT NULL,
+    period TEXT NOT NULL,
+    amount REAL NOT NULL,
+    transaction_id TEXT,
+    created_at DATETIME DEFAULT CURRENT_TIMESTAMP,
+    UNIQUE (asset_id, period),
+    FOREIGN KEY (asset_id) REFERENCES fixed_assets(id) ON DELETE CASCADE,
+    FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE
+);`
+
+const createUtilityMetersTable = `
+CREATE TABLE IF NOT EXISTS utility_meters (
+    id TEXT PRIMARY KEY,
+    user_id TEXT NOT NULL,
+    name TEXT NOT NULL,
+    type TEXT NOT NULL,
+    unit TEXT NOT NULL,
+    reading_mode TEXT NOT NULL DEFAULT 'cumulative',
+    facility TEXT,
+    land_id TEXT,
+    unit_price REAL DEFAULT 0,
+    spike_threshold REAL DEFAULT 50,
+    notes TEXT,
+    created_at DATETIME DEFAULT CURRENT_TIMESTAMP,
+    updated_at DATETIME DEFAULT CURRENT_TIMESTAMP,
+    FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE,
+    FOREIGN KEY (land_id) REFERENCES lands(id) ON DELETE SET NULL
+);`
+
+const createMeterReadingsTable = `
+CREATE TABLE IF NOT EXISTS meter_readings (
+    id TEXT PRIMARY KEY,
+    meter_id TEXT NOT NULL,
+    user_id TEXT NOT NULL,
+    reading_date DATETIME NOT NULL,
+    value REAL NOT NULL,
+    consumption REAL NOT NULL DEFAULT 0,
+    cost REAL DEFAULT 0,
+    daily_average REAL DEFAULT 0,
+    anomaly BOOLEAN DEFAULT FALSE,
+    transaction_id TEXT,
+    notes TEXT,
+    created_at DATETIME DEFAULT CURRENT_TIMESTAMP,
+    FOREIGN KEY (meter_id) REFERENCES utility_meters(id) ON DELETE CASCADE,
+    FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE
+);`
+
+const createCarbonFootprintsTable = `
+CREATE TABLE IF NOT EXISTS carbon_footprints (
+    id TEXT PRIMARY KEY,
+    user_id TEXT NOT NULL,
+    period TEXT NOT NULL,
+    livestock REAL DEFAULT 0,
+    fertilizer REAL DEFAULT 0,
+    fuel REAL DEFAULT 0,
+    electricity REAL DEFAULT 0,
+    total REAL DEFAULT 0,
+    sources TEXT,
+    calculated_at DATETIME DEFAULT CURRENT_TIMESTAMP,
+    UNIQUE (user_id, period),
+    FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE
+);`
+
+const createComplianceChecklistsTable = `
+CREATE TABLE IF NOT EXISTS compliance_checklists (
+    id TEXT PRIMARY KEY,
+    user_id TEXT,
+    standard TEXT NOT NULL,
+    name TEXT NOT NULL,
+    description TEXT,
+    requirements TEXT NOT NULL DEFAULT '[]',
+    log_sources TEXT NOT NULL DEFAULT '[]',
+    created_at DATETIME DEFAULT CURRENT_TIMESTAMP,
+    updated_at DATETIME DEFAULT CURRENT_TIMESTAMP,
+    FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE
+);`
+
+const createComplianceStatusesTable = `
+CREATE TABLE IF NOT EXISTS compliance_statuses (
+    id TEXT PRIMARY KEY,
+    user_id TEXT NOT NULL,
+    checklist_id TEXT NOT NULL,
+    requirement_code TEXT NOT NULL,
+    status TEXT NOT NULL DEFAULT 'pending',
+    notes TEXT,
+    reviewed_at DATETIME,
+    updated_at DATETIME DEFAULT CURRENT_TIMESTAMP,
+    UNIQUE (user_id, checklist_id, requirement_code),
+    FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE,
+    FOREIGN KEY (checklist_id) REFERENCES compliance_checklists(id) ON DELETE CASCADE
+);`
+
+const createDocumentsTable = `
+CREATE TABLE IF NOT EXISTS documents (
+    id TEXT PRIMARY KEY,
+    user_id TEXT NOT NULL,
+    title TEXT NOT NULL,
+    category TEXT NOT NULL,
+    description TEXT,
+    entity_type TEXT,
+    entity_id TEXT,
+    issue_date DATE,
+    expiry_date DATE,
+    reminder_days INTEGER DEFAULT 30,
+    reminder_sent_at DATETIME,
+    filename TEXT,
+    content_type TEXT,
+    size INTEGER DEFAULT 0,
+    storage_key TEXT,
+    extracted_text TEXT,
+    created_at DATETIME DEFAULT CURRENT_TIMESTAMP,
+    updated_at DATETIME DEFAULT CURRENT_TIMESTAMP,
+    FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE
+);`
+
+const createFarmsTable = `
+CREATE TABLE IF NOT EXISTS farms (
+    id TEXT PRIMARY KEY,
+    user_id TEXT NOT NULL,
+    name TEXT NOT NULL,
+    location TEXT,
+    description TEXT,
+    is_default BOOLEAN DEFAULT FALSE,
+    settings TEXT,
+    created_at DATETIME DEFAULT CURRENT_TIMESTAMP,
+    updated_at DATETIME DEFAULT CURRENT_TIMESTAMP,
+    FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE
+);
+CREATE INDEX IF NOT EXISTS idx_farms_user ON farms (user_id);`
+
+const createTransactionTagsTable = `
+CREATE TABLE IF NOT EXISTS transaction_tags (
+    transaction_id TEXT NOT NULL,
+    user_id TEXT NOT NULL,
+    dimension TEXT NOT NULL,
+    value TEXT NOT NULL,
+    PRIMARY KEY (transaction_id, dimension, value),
+    FOREIGN KEY (transaction_id) REFERENCES transactions(id) ON DELETE CASCADE
+);
+CREATE INDEX IF NOT EXISTS idx_transaction_tags_user ON transaction_tags (user_id, dimension, value);`
+
+const createInboundEmailAddressesTable = `
+CREATE TABLE IF NOT EXISTS inbound_email_addresses (
+    token TEXT PRIMARY KEY,
+    user_id TEXT NOT NULL UNIQUE,
+    created_at DATETIME DEFAULT CURRENT_TIMESTAMP
+);`
+
+const createTransactionDraftsTable = `
+CREATE TABLE IF NOT EXISTS transaction_drafts (
+    id TEXT PRIMARY KEY,
+    user_id TEXT NOT NULL,
+    source TEXT NOT NULL DEFAULT 'email',
+    sender TEXT,
+    subject TEXT,
+    body TEXT,
+    type TEXT NOT NULL DEFAULT 'expense',
+    category TEXT,
+    description TEXT,
+    amount DECIMAL(12,2) DEFAULT 0,
+    currency TEXT DEFAULT 'TRY',
+    date DATE NOT NULL,
+    status TEXT NOT NULL DEFAULT 'pending',
+    transaction_id TEXT,
+    filename TEXT,
+    content_type TEXT,
+    size INTEGER DEFAULT 0,
+    storage_key TEXT,
+    attachment_count INTEGER DEFAULT 0,
+    created_at DATETIME DEFAULT CURRENT_TIMESTAMP,
+    updated_at DATETIME DEFAULT CURRENT_TIMESTAMP,
+    FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE
+);
+CREATE INDEX IF NOT EXISTS idx_transaction_drafts_user ON transaction_drafts (user_id, status);`
+
+const createBankAccountsTable = `
+CREATE TABLE IF NOT EXISTS bank_accounts (
+    id TEXT PRIMARY KEY,
+    user_id TEXT NOT NULL,
+    name TEXT NOT NULL,
+    bank_name TEXT,
+    account_number TEXT,
+    currency TEXT DEFAULT 'TRY',
+    opening_balance DECIMAL(14,2) DEFAULT 0,
+    created_at DATETIME DEFAULT CURRENT_TIMESTAMP,
+    updated_at DATETIME DEFAULT CURRENT_TIMESTAMP,
+    FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE
+);
+CREATE INDEX IF NOT EXISTS idx_bank_accounts_user ON bank_accounts (user_id);`
+
+const createBankStatementsTable = `
+CREATE TABLE IF NOT EXISTS bank_statements (
+    id TEXT PRIMARY KEY,
+    user_id TEXT NOT NULL,
+    account_id TEXT NOT NULL,
+    filename TEXT,
+    format TEXT NOT NULL,
+    start_date DATE,
+    end_date DATE,
+    closing_balance DECIMAL(14,2),
+    line_count INTEGER DEFAULT 0,
+    created_at DATETIME DEFAULT CURRENT_TIMESTAMP,
+    FOREIGN KEY (account_id) REFERENCES bank_accounts(id) ON DELETE CASCADE
+);`
+
+const createBankStatementLinesTable = `
+CREATE TABLE IF NOT EXISTS bank_statement_lines (
+    id TEXT PRIMARY KEY,
+    user_id TEXT NOT NULL,
+    account_id TEXT NOT NULL,
+    statement_id TEXT NOT NULL,
+    external_id TEXT NOT NULL,
+    date DATE NOT NULL,
+    amount DECIMAL(14,2) NOT NULL,
+    description TEXT,
+    reference TEXT,
+    status TEXT NOT NULL DEFAULT 'unmatched',
+    transaction_id TEXT,
+    match_score REAL,
+    created_at DATETIME DEFAULT CURRENT_TIMESTAMP,
+    FOREIGN KEY (account_id) REFERENCES bank_accounts(id) ON DELETE CASCADE,
+    FOREIGN KEY (statement_id) REFERENCES bank_statements(id) ON DELETE CASCADE
+);
+CREATE UNIQUE INDEX IF NOT EXISTS idx_bank_statement_lines_external ON bank_statement_lines (account_id, external_id);
+CREATE INDEX IF NOT EXISTS idx_bank_statement_lines_transaction ON bank_statement_lines (transaction_id);`
+
+const createProductionSalesTable = `
+CREATE TABLE IF NOT EXISTS production_sales (
+    id TEXT PRIMARY KEY,
+    user_id TEXT NOT NULL,
+    production_id TEXT NOT NULL,
+    sale_date DATE NOT NULL,
+    quantity REAL NOT NULL,
+    unit TEXT,
+    unit_price REAL NOT NULL,
+    subtotal REAL NOT NULL,
+    tax_rate REAL DEFAULT 0,
+    tax_amount REAL DEFAULT 0,
+    total REAL NOT NULL,
+    currency TEXT DEFAULT 'TRY',
+    buyer TEXT NOT NULL,
+    buyer_tax_id TEXT,
+    buyer_address TEXT,
+    invoice_number TEXT,
+    due_date DATE,
+    transaction_id TEXT,
+    notes TEXT,
+    created_at DATETIME DEFAULT CURRENT_TIMESTAMP,
+    FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE,
+    FOREIGN KEY (production_id) REFERENCES production(id) ON DELETE CASCADE
+);
+CREATE INDEX IF NOT EXISTS idx_production_sales_production ON production_sales (production_id);
+CREATE UNIQUE INDEX IF NOT EXISTS idx_production_sales_invoice ON production_sales (user_id, invoice_number);`
+
+const createMarketPricesTable = `
+CREATE TABLE IF NOT EXISTS market_prices (
+    id TEXT PRIMARY KEY,
+    user_id TEXT NOT NULL,
+    product TEXT NOT NULL,
+    product_key TEXT NOT NULL,
+    category TEXT,
+    price REAL NOT NULL,
+    unit TEXT,
+    currency TEXT DEFAULT 'TRY',
+    price_date DATE NOT NULL,
+    source TEXT NOT NULL DEFAULT 'manual',
+    created_at DATETIME DEFAULT CURRENT_TIMESTAMP,
+    UNIQUE (user_id, product_key, price_date, source),
+    FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE
+);`
+
+const createProductionLossesTable = `
+CREATE TABLE IF NOT EXISTS production_losses (
+    id TEXT PRIMARY KEY,
+    user_id TEXT NOT NULL,
+    production_id TEXT NOT NULL,
+    loss_date DATE NOT NULL,
+    quantity REAL NOT NULL,
+    reason TEXT NOT NULL,
+    notes TEXT,
+    created_at DATETIME DEFAULT CURRENT_TIMESTAMP,
+    FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE,
+    FOREIGN KEY (production_id) REFERENCES production(id) ON DELETE CASCADE
+);
+CREATE INDEX IF NOT EXISTS idx_production_losses_production ON production_losses (production_id);`
+
+const createGreenhousesTable = `
+CREATE TABLE IF NOT EXISTS greenhouses (
+    land_id TEXT PRIMARY KEY,
+    user_id TEXT NOT NULL,
+    structure_type TEXT,
+    heated BOOLEAN DEFAULT false,
+    min_temperature REAL,
+    max_temperature REAL,
+    min_humidity REAL,
+    max_humidity REAL,
+    alert_cooldown_minutes INTEGER DEFAULT 60,
+    created_at DATETIME DEFAULT CURRENT_TIMESTAMP,
+    updated_at DATETIME DEFAULT CURRENT_TIMESTAMP,
+    FOREIGN KEY (land_id) REFERENCES lands(id) ON DELETE CASCADE,
+    FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE
+);`
+
+const createClimateSensorsTable = `
+CREATE TABLE IF NOT EXISTS climate_sensors (
+    id TEXT PRIMARY KEY,
+    user_id TEXT NOT NULL,
+    land_id TEXT NOT NULL,
+    name TEXT NOT NULL,
+    token TEXT NOT NULL UNIQUE,
+    last_reading_at DATETIME,
+    created_at DATETIME DEFAULT CURRENT_TIMESTAMP,
+    FOREIGN KEY (land_id) REFERENCES lands(id) ON DELETE CASCADE,
+    FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE
+);`
+
+const createClimateReadingsTable = `
+CREATE TABLE IF NOT EXISTS climate_readings (
+    id TEXT PRIMARY KEY,
+    user_id TEXT NOT NULL,
+    land_id TEXT NOT NULL,
+    sensor_id TEXT,
+    recorded_at DATETIME NOT NULL,
+    temperature REAL,
+    humidity REAL,
+    created_at DATETIME DEFAULT CURRENT_TIMESTAMP,
+    FOREIGN KEY (land_id) REFERENCES lands(id) ON DELETE CASCADE,
+    FOREIGN KEY (sensor_id) REFERENCES climate_sensors(id) ON DELETE SET NULL
+);
+CREATE INDEX IF NOT EXISTS idx_climate_readings_land ON climate_readings (land_id, recorded_at);`
+
+const createClimateAlertsTable = `
+CREATE TABLE IF NOT EXISTS climate_alerts (
+    id TEXT PRIMARY KEY,
+    user_id TEXT NOT NULL,
+    land_id TEXT NOT NULL,
+    reading_id TEXT,
+    metric TEXT NOT NULL,
+    value REAL NOT NULL,
+    min_value REAL,
+    max_value REAL,
+    recorded_at DATETIME NOT NULL,
+    created_at DATETIME DEFAULT CURRENT_TIMESTAMP,
+    FOREIGN KEY (land_id) REFERENCES lands(id) ON DELETE CASCADE
+);
+CREATE INDEX IF NOT EXISTS idx_climate_alerts_land ON climate_alerts (land_id, metric, created_at);`
+
+const createHivesTable = `
+CREATE TABLE IF NOT EXISTS hives (
+    id TEXT PRIMARY KEY,
+    user_id TEXT NOT NULL,
+    name TEXT NOT NULL,
+    apiary TEXT,
+    land_id TEXT,
+    hive_type TEXT,
+    queen_year INTEGER,
+    status TEXT DEFAULT 'active',
+    notes TEXT,
+    created_at DATETIME DEFAULT CURRENT_TIMESTAMP,
+    updated_at DATETIME DEFAULT CURRENT_TIMESTAMP,
+    FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE,
+    FOREIGN KEY (land_id) REFERENCES lands(id) ON DELETE SET NULL
+);`
+
+const createHiveInspectionsTable = `
+CREATE TABLE IF NOT EXISTS hive_inspections (
+    id TEXT PRIMARY KEY,
+    user_id TEXT NOT NULL,
+    hive_id TEXT NOT NULL,
+    inspection_date DATETIME NOT NULL,
+    queen_status TEXT NOT NULL,
+    brood_frames INTEGER,
+    brood_pattern TEXT,
+    honey_frames INTEGER,
+    temperament TEXT,
+    varroa_count INTEGER,
+    varroa_method TEXT,
+    varroa_sample_size INTEGER,
+    notes TEXT,
+    created_at DATETIME DEFAULT CURRENT_TIMESTAMP,
+    FOREIGN KEY (hive_id) REFERENCES hives(id) ON DELETE CASCADE
+);
+CREATE INDEX IF NOT EXISTS idx_hive_inspections_hive ON hive_inspections (hive_id, inspection_date);`
+
+const createHiveHarvestsTable = `
+CREATE TABLE IF NOT EXISTS hive_harvests (
+    id TEXT PRIMARY KEY,
+    user_id TEXT NOT NULL,
+    hive_id TEXT NOT NULL,
+    harvest_date DATETIME NOT NULL,
+    amount REAL NOT NULL,
+    honey_type TEXT,
+    moisture REAL,
+    production_id TEXT,
+    notes TEXT,
+    created_at DATETIME DEFAULT CURRENT_TIMESTAMP,
+    FOREIGN KEY (hive_id) REFERENCES hives(id) ON DELETE CASCADE,
+    FOREIGN KEY (production_id) REFERENCES production(id) ON DELETE SET NULL
+);`
+
+const createHiveTreatmentsTable = `
+CREATE TABLE IF NOT EXISTS hive_treatments (
+    id TEXT PRIMARY KEY,
+    user_id TEXT NOT NULL,
+    hive_id TEXT NOT NULL,
+    treatment_date DATETIME NOT NULL,
+    target TEXT NOT NULL,
+    product TEXT NOT NULL,
+    dose TEXT,
+    next_due_date DATETIME,
+    reminder_sent_at DATETIME,
+    notes TEXT,
+    created_at DATETIME DEFAULT CURRENT_TIMESTAMP,
+    FOREIGN KEY (hive_id) REFERENCES hives(id) ON DELETE CASCADE
+);
+CREATE INDEX IF NOT EXISTS idx_hive_treatments_due ON hive_treatments (next_due_date, reminder_sent_at);`
+
+const createPondsTable = `
+CREATE TABLE IF NOT EXISTS ponds (
+    id TEXT PRIMARY KEY,
+    user_id TEXT NOT NULL,
+    name TEXT NOT NULL,
+    land_id TEXT,
+    pond_type TEXT,
+    area REAL,
+    volume REAL,
+    water_source TEXT,
+    status TEXT DEFAULT 'active',
+    notes TEXT,
+    created_at DATETIME DEFAULT CURRENT_TIMESTAMP,
+    updated_at DATETIME DEFAULT CURRENT_TIMESTAMP,
+    FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE,
+    FOREIGN KEY (land_id) REFERENCES lands(id) ON DELETE SET NULL
+);`
+
+const createFishBatchesTable = `
+CREATE TABLE IF NOT EXISTS fish_batches (
+    id TEXT PRIMARY KEY,
+    user_id TEXT NOT NULL,
+    pond_id TEXT NOT NULL,
+    species TEXT NOT NULL,
+    stocking_date DATETIME NOT NULL,
+    initial_count INTEGER NOT NULL,
+    initial_avg_weight REAL NOT NULL,
+    status TEXT DEFAULT 'active',
+    harvest_date DATETIME,
+    harvested_count INTEGER,
+    harvested_weight REAL,
+    production_id TEXT,
+    notes TEXT,
+    created_at DATETIME DEFAULT CURRENT_TIMESTAMP,
+    updated_at DATETIME DEFAULT CURRENT_TIMESTAMP,
+    FOREIGN KEY (pond_id) REFERENCES ponds(id) ON DELETE CASCADE,
+    FOREIGN KEY (production_id) REFERENCES production(id) ON DELETE SET NULL
+);
+CREATE INDEX IF NOT EXISTS idx_fish_batches_pond ON fish_batches (pond_id, status);`
+
+const createFishBatchRecordsTable = `
+CREATE TABLE IF NOT EXISTS fish_batch_records (
+    id TEXT PRIMARY KEY,
+    user_id TEXT NOT NULL,
+    batch_id TEXT NOT NULL,
+    record_type TEXT NOT NULL,
+    record_date DATETIME NOT NULL,
+    count INTEGER,
+    feed_kg REAL,
+    feed_type TEXT,
+    cost REAL,
+    avg_weight REAL,
+    notes TEXT,
+    created_at DATETIME DEFAULT CURRENT_TIMESTAMP,
+    FOREIGN KEY (batch_id) REFERENCES fish_batches(id) ON DELETE CASCADE
+);
+CREATE INDEX IF NOT EXISTS idx_fish_batch_records_batch ON fish_batch_records (batch_id, record_type, record_date);`
+
+const createEntityNotesTable = `
+CREATE TABLE IF NOT EXISTS entity_notes (
+    id TEXT PRIMARY KEY,
+    user_id TEXT NOT NULL,
+    entity_type TEXT NOT NULL,
+    entity_id TEXT NOT NULL,
+    author_id TEXT NOT NULL,
+    text TEXT NOT NULL,
+    mentions TEXT NOT NULL DEFAULT '[]',
+    attachments TEXT NOT NULL DEFAULT '[]',
+    legacy BOOLEAN DEFAULT FALSE,
+    created_at DATETIME DEFAULT CURRENT_TIMESTAMP,
+    FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE
+);
+CREATE INDEX IF NOT EXISTS idx_entity_notes_entity ON entity_notes (entity_type, entity_id, created_at);`
+
+const createMetricAnomaliesTable = `
+CREATE TABLE IF NOT EXISTS metric_anomalies (
+    id TEXT PRIMARY KEY,
+    user_id TEXT NOT NULL,
+    check_type TEXT NOT NULL,
+    metric TEXT NOT NULL,
+    period TEXT NOT NULL,
+    value REAL NOT NULL,
+    baseline REAL NOT NULL,
+    change_percent REAL,
+    message TEXT NOT NULL,
+    link TEXT,
+    detected_at DATETIME DEFAULT CURRENT_TIMESTAMP,
+    UNIQUE (user_id, check_type, period),
+    FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE
+);
+CREATE INDEX IF NOT EXISTS idx_metric_anomalies_user ON metric_anomalies (user_id, detected_at);`
+
+const createMetricForecastsTable = `
+CREATE TABLE IF NOT EXISTS metric_forecasts (
+    id TEXT PRIMARY KEY,
+    user_id TEXT NOT NULL,
+    metric TEXT NOT NULL,
+    period TEXT NOT NULL,
+    method TEXT NOT NULL,
+    value REAL NOT NULL,
+    lower_bound REAL NOT NULL,
+    upper_bound REAL NOT NULL,
+    created_at DATETIME DEFAULT CURRENT_TIMESTAMP,
+    UNIQUE (user_id, metric, period),
+    FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE
+);`
+
+const createAdvisorConversationsTable = `
+CREATE TABLE IF NOT EXISTS advisor_conversations (
+    id TEXT PRIMARY KEY,
+    user_id TEXT NOT NULL,
+    account_id TEXT NOT NULL,
+    title TEXT NOT NULL,
+    created_at DATETIME DEFAULT CURRENT_TIMESTAMP,
+    updated_at DATETIME DEFAULT CURRENT_TIMESTAMP,
+    FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE
+);
+CREATE INDEX IF NOT EXISTS idx_advisor_conversations_user ON advisor_conversations (user_id, updated_at);`
+
+const createAdvisorMessagesTable = `
+CREATE TABLE IF NOT EXISTS advisor_messages (
+    id TEXT PRIMARY KEY,
+    conversation_id TEXT NOT NULL,
+    account_id TEXT NOT NULL,
+    role TEXT NOT NULL,
+    content TEXT NOT NULL,
+    input_tokens INTEGER DEFAULT 0,
+    output_tokens INTEGER DEFAULT 0,
+    cost REAL DEFAULT 0,
+    created_at DATETIME DEFAULT CURRENT_TIMESTAMP,
+    FOREIGN KEY (conversation_id) REFERENCES advisor_conversations(id) ON DELETE CASCADE
+);
+CREATE INDEX IF NOT EXISTS idx_advisor_messages_conversation ON advisor_messages (conversation_id, created_at);
+CREATE INDEX IF NOT EXISTS idx_advisor_messages_account ON advisor_messages (account_id, role, created_at);`
+
+const createPestDiseaseObservationsTable = `
+CREATE TABLE IF NOT EXISTS pest_disease_observations (
+    id TEXT PRIMARY KEY,
+    user_id TEXT NOT NULL,
+    subject_type TEXT NOT NULL,
+    land_id TEXT,
+    livestock_id TEXT,
+    name TEXT NOT NULL,
+    category TEXT NOT NULL,
+    confidence REAL,
+    status TEXT NOT NULL DEFAULT 'suspected',
+    source TEXT NOT NULL DEFAULT 'manual',
+    photo_id TEXT,
+    candidates TEXT,
+    observed_on DATE NOT NULL,
+    notes TEXT,
+    created_at DATETIME DEFAULT CURRENT_TIMESTAMP,
+    updated_at DATETIME DEFAULT CURRENT_TIMESTAMP,
+    FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE,
+    FOREIGN KEY (land_id) REFERENCES lands(id) ON DELETE SET NULL,
+    FOREIGN KEY (livestock_id) REFERENCES livestock(id) ON DELETE SET NULL
+);
+CREATE INDEX IF NOT EXISTS idx_pest_disease_observations_user ON pest_disease_observations (user_id, observed_on);`
+
+const createMessageTemplatesTable = `
+CREATE TABLE IF NOT EXISTS message_templates (
+    id TEXT PRIMARY KEY,
+    channel TEXT NOT NULL,
+    template_key TEXT NOT NULL,
+    language TEXT NOT NULL,
+    title TEXT NOT NULL,
+    body TEXT NOT NULL,
+    updated_by TEXT,
+    created_at DATETIME DEFAULT CURRENT_TIMESTAMP,
+    updated_at DATETIME DEFAULT CURRENT_TIMESTAMP,
+    UNIQUE (channel, template_key, language)
+);`
+
+const createRecordLinksTable = `
+CREATE TABLE IF NOT EXISTS record_links (
+    id TEXT PRIMARY KEY,
+    user_id TEXT NOT NULL,
+    source_type TEXT NOT NULL,
+    source_id TEXT NOT NULL,
+    target_type TEXT NOT NULL,
+    target_id TEXT NOT NULL,
+    relation TEXT NOT NULL DEFAULT 'related',
+    note TEXT,
+    created_by TEXT,
+    created_at DATETIME DEFAULT CURRENT_TIMESTAMP,
+    FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE
+);
+CREATE INDEX IF NOT EXISTS idx_record_links_source ON record_links (user_id, source_type, source_id);
+CREATE INDEX IF NOT EXISTS idx_record_links_target ON record_links (user_id, target_type, target_id);`
+
+const createDataImportsTable = `
+CREATE TABLE IF NOT EXISTS data_imports (
+    id TEXT PRIMARY KEY,
+    user_id TEXT NOT NULL,
+    entity TEXT NOT NULL,
+    profile TEXT NOT NULL,
+    filename TEXT,
+    status TEXT NOT NULL DEFAULT 'previewed',
+    mapping TEXT NOT NULL DEFAULT '{}',
+    unmapped_columns TEXT NOT NULL DEFAULT '[]',
+    summary TEXT NOT NULL DEFAULT '{}',
+    row_data TEXT NOT NULL DEFAULT '[]',
+    created_by TEXT,
+    committed_by TEXT,
+    created_at DATETIME DEFAULT CURRENT_TIMESTAMP,
+    committed_at DATETIME,
+    FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE
+);
+CREATE INDEX IF NOT EXISTS idx_data_imports_user ON data_imports (user_id, created_at);`
+
+const createIntegrationKeysTable = `
+CREATE TABLE IF NOT EXISTS integration_keys (
+    id TEXT PRIMARY KEY,
+    user_id TEXT NOT NULL,
+    name TEXT NOT NULL,
+    key_prefix TEXT NOT NULL,
+    key_hash TEXT UNIQUE NOT NULL,
+    created_by TEXT,
+    last_used_at DATETIME,
+    revoked_at DATETIME,
+    created_at DATETIME DEFAULT CURRENT_TIMESTAMP,
+    FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE
+);
+CREATE INDEX IF NOT EXISTS idx_integration_keys_user ON integration_keys (user_id);`
+
+const createKPISnapshotsTable = `
+CREATE TABLE IF NOT EXISTS kpi_snapshots (
+    id TEXT PRIMARY KEY,
+    user_id TEXT NOT NULL,
+    snapshot_date DATE NOT NULL,
+    herd_size INTEGER NOT NULL DEFAULT 0,
+    stock_value REAL NOT NULL DEFAULT 0,
+    cash_balance REAL NOT NULL DEFAULT 0,
+    total_area REAL NOT NULL DEFAULT 0,
+    created_at DATETIME DEFAULT CURRENT_TIMESTAMP,
+    UNIQUE (user_id, snapshot_date),
+    FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE
+);`
+
+const createLandActivityCostItemsTable = `
+CREATE TABLE IF NOT EXISTS land_activity_cost_items (
+    id TEXT PRIMARY KEY,
+    user_id TEXT NOT NULL,
+    activity_id TEXT NOT NULL,
+    category TEXT NOT NULL,
+    production_id TEXT,
+    asset_id TEXT,
+    description TEXT,
+    quantity REAL NOT NULL,
+    unit TEXT,
+    unit_rate REAL NOT NULL,
+    rate_source TEXT NOT NULL,
+    amount REAL NOT NULL,
+    created_at DATETIME DEFAULT CURRENT_TIMESTAMP,
+    FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE,
+    FOREIGN KEY (activity_id) REFERENCES land_activities(id) ON DELETE CASCADE,
+    FOREIGN KEY (production_id) REFERENCES production(id) ON DELETE SET NULL,
+    FOREIGN KEY (asset_id) REFERENCES fixed_assets(id) ON DELETE SET NULL
+);
+CREATE INDEX IF NOT EXISTS idx_land_activity_cost_items_activity ON land_activity_cost_items (activity_id);`
+
+const createSupportTicketsTable = `
+CREATE TABLE IF NOT EXISTS support_tickets (
+    id TEXT PRIMARY KEY,
+    user_id TEXT NOT NULL,
+    account_id TEXT NOT NULL,
+    subject TEXT,
+    message TEXT NOT NULL,
+    category TEXT NOT NULL DEFAULT 'other',
+    status TEXT NOT NULL DEFAULT 'open',
+    app_version TEXT,
+    device_info TEXT,
+    request_ids TEXT,
+    recent_requests TEXT,
+    screenshot_id TEXT,
+    resolved_at DATETIME,
+    created_at DATETIME DEFAULT CURRENT_TIMESTAMP,
+    updated_at DATETIME DEFAULT CURRENT_TIMESTAMP,
+    FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE,
+    FOREIGN KEY (account_id) REFERENCES users(id) ON DELETE CASCADE
+);
+CREATE INDEX IF NOT EXISTS idx_support_tickets_user ON support_tickets (user_id, created_at);
+CREATE INDEX IF NOT EXISTS idx_support_tickets_status ON support_tickets (status, updated_at);`
+
+const createSupportTicketMessagesTable = `
+CREATE TABLE IF NOT EXISTS support_ticket_messages (
+    id TEXT PRIMARY KEY,
+    ticket_id TEXT NOT NULL,
+    author_id TEXT NOT NULL,
+    author_role TEXT NOT NULL,
+    message TEXT NOT NULL,
+    created_at DATETIME DEFAULT CURRENT_TIMESTAMP,
+    FOREIGN KEY (ticket_id) REFERENCES support_tickets(id) ON DELETE CASCADE
+);
+CREATE INDEX IF NOT EXISTS idx_support_ticket_messages_ticket ON support_ticket_messages (ticket_id, created_at);`
+
+const createChangelogEntriesTable = `
+CREATE TABLE IF NOT EXISTS changelog_entries (
+    id TEXT PRIMARY KEY,
+    version TEXT NOT NULL,
+    title TEXT NOT NULL,
+    body TEXT,
+    category TEXT NOT NULL DEFAULT 'feature',
+    highlights TEXT,
+    published_at DATETIME,
+    created_at DATETIME DEFAULT CURRENT_TIMESTAMP,
+    updated_at DATETIME DEFAULT CURRENT_TIMESTAMP
+);
+CREATE INDEX IF NOT EXISTS idx_changelog_entries_published ON changelog_entries (published_at);`
+
+const createChangelogSeenTable = `
+CREATE TABLE IF NOT EXISTS changelog_seen (
+    account_id TEXT NOT NULL,
+    entry_id TEXT NOT NULL,
+    seen_at DATETIME DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (account_id, entry_id),
+    FOREIGN KEY (account_id) REFERENCES users(id) ON DELETE CASCADE,
+    FOREIGN KEY (entry_id) REFERENCES changelog_entries(id) ON DELETE CASCADE
+);`
+
+const createMaintenanceWindowsTable = `
+CREATE TABLE IF NOT EXISTS maintenance_windows (
+    id TEXT PRIMARY KEY,
+    message TEXT,
+    starts_at DATETIME NOT NULL,
+    ends_at DATETIME,
+    created_by TEXT,
+    created_at DATETIME DEFAULT CURRENT_TIMESTAMP
+);
+CREATE INDEX IF NOT EXISTS idx_maintenance_windows_ends ON maintenance_windows (ends_at);`
+
+const createRecalculationJobsTable = `
+CREATE TABLE IF NOT EXISTS recalculation_jobs (
+    id TEXT PRIMARY KEY,
+    targets TEXT NOT NULL,
+    farm_id TEXT,
+    status TEXT NOT NULL DEFAULT 'queued',
+    total INTEGER NOT NULL DEFAULT 0,
+    processed INTEGER NOT NULL DEFAULT 0,
+    failed INTEGER NOT NULL DEFAULT 0,
+    updated_records INTEGER NOT NULL DEFAULT 0,
+    errors TEXT,
+    requested_by TEXT,
+    created_at DATETIME DEFAULT CURRENT_TIMESTAMP,
+    started_at DATETIME,
+    finished_at DATETIME
+);`
+
+const createEncryptionKeysTable = `
+CREATE TABLE IF NOT EXISTS encryption_keys (
+    id TEXT PRIMARY KEY,
+    master_key_id TEXT NOT NULL,
+    wrapped_key TEXT NOT NULL,
+    created_at DATETIME DEFAULT CURRENT_TIMESTAMP,
+    retired_at DATETIME
+);`
+
+const createBackupsTable = `
+CREATE TABLE IF NOT EXISTS backups (
+    id TEXT PRIMARY KEY,
+    user_id TEXT NOT NULL,
+    trigger_type TEXT NOT NULL DEFAULT 'manual',
+    status TEXT NOT NULL DEFAULT 'running',
+    storage TEXT NOT NULL,
+    object_key TEXT NOT NULL,
+    size INTEGER NOT NULL DEFAULT 0,
+    records TEXT,
+    error TEXT,
+    created_at DATETIME DEFAULT CURRENT_TIMESTAMP,
+    completed_at DATETIME,
+    FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE
+);
+CREATE INDEX IF NOT EXISTS idx_backups_user ON backups (user_id, created_at);
+CREATE INDEX IF NOT EXISTS idx_backups_status ON backups (status, created_at);`
+
+const createDBMaintenanceRunsTable = `
+CREATE TABLE IF NOT EXISTS db_maintenance_runs (
+    id TEXT PRIMARY KEY,
+    trigger_type TEXT NOT NULL DEFAULT 'scheduled',
+    status TEXT NOT NULL DEFAULT 'running',
+    analyzed BOOLEAN NOT NULL DEFAULT FALSE,
+    vacuumed BOOLEAN NOT NULL DEFAULT FALSE,
+    checkpointed BOOLEAN NOT NULL DEFAULT FALSE,
+    free_pages_before INTEGER NOT NULL DEFAULT 0,
+    size_before INTEGER NOT NULL DEFAULT 0,
+    size_after INTEGER NOT NULL DEFAULT 0,
+    duration_ms INTEGER NOT NULL DEFAULT 0,
+    error TEXT,
+    requested_by TEXT,
+    started_at DATETIME DEFAULT CURRENT_TIMESTAMP,
+    finished_at DATETIME
+);
+CREATE INDEX IF NOT EXISTS idx_db_maintenance_runs_started ON db_maintenance_runs (started_at);`
+
+const createWeatherStationsTable = `
+CREATE TABLE IF NOT EXISTS weather_stations (
+    id TEXT PRIMARY KEY,
+    user_id TEXT NOT NULL,
+    name TEXT NOT NULL,
+    station_type TEXT NOT NULL DEFAULT 'own',
+    latitude REAL,
+    longitude REAL,
+    token TEXT NOT NULL UNIQUE,
+    last_reading_at DATETIME,
+    created_at DATETIME DEFAULT CURRENT_TIMESTAMP,
+    updated_at DATETIME DEFAULT CURRENT_TIMESTAMP,
+    FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE
+);
+CREATE INDEX IF NOT EXISTS idx_weather_stations_user ON weather_stations (user_id);`
+
+const createWeatherStationReadingsTable = `
+CREATE TABLE IF NOT EXISTS weather_station_readings (
+    id TEXT PRIMARY KEY,
+    user_id TEXT NOT NULL,
+    station_id TEXT NOT NULL,
+    recorded_at DATETIME NOT NULL,
+    temperature REAL,
+    humidity REAL,
+    rainfall REAL,
+    created_at DATETIME DEFAULT CURRENT_TIMESTAMP,
+    FOREIGN KEY (station_id) REFERENCES weather_stations(id) ON DELETE CASCADE,
+    FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE
+);
+CREATE INDEX IF NOT EXISTS idx_weather_station_readings_station ON weather_station_readings (station_id, recorded_at);`
+
+const createHarvestCrewEntriesTable = `
+CREATE TABLE IF NOT EXISTS harvest_crew_entries (
+    id TEXT PRIMARY KEY,
+    user_id TEXT NOT NULL,
+    activity_id TEXT NOT NULL,
+    worker_name TEXT NOT NULL,
+    quantity REAL NOT NULL,
+    unit TEXT NOT NULL,
+    piece_rate REAL NOT NULL,
+    amount REAL NOT NULL,
+    notes TEXT,
+    transaction_id TEXT,
+    paid_at DATETIME,
+    created_at DATETIME DEFAULT CURRENT_TIMESTAMP,
+    FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE,
+    FOREIGN KEY (activity_id) REFERENCES land_activities(id) ON DELETE CASCADE,
+    FOREIGN KEY (transaction_id) REFERENCES transactions(id) ON DELETE SET NULL
+);
+CREATE INDEX IF NOT EXISTS idx_harvest_crew_entries_activity ON harvest_crew_entries (activity_id);`
+
+const createWaterQuotasTable = `
+CREATE TABLE IF NOT EXISTS water_quotas (
+    id TEXT PRIMARY KEY,
+    user_id TEXT NOT NULL,
+    land_id TEXT,
+    name TEXT NOT NULL,
+    season_start DATE NOT NULL,
+    season_end DATE NOT NULL,
+    volume REAL NOT NULL,
+    unit_price REAL DEFAULT 0,
+    warning_percent REAL DEFAULT 80,
+    alert_level INTEGER DEFAULT 0,
+    notes TEXT,
+    created_at DATETIME DEFAULT CURRENT_TIMESTAMP,
+    updated_at DATETIME DEFAULT CURRENT_TIMESTAMP,
+    FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE,
+    FOREIGN KEY (land_id) REFERENCES lands(id) ON DELETE CASCADE
+);
+CREATE INDEX IF NOT EXISTS idx_water_quotas_user ON water_quotas (user_id, season_start);`
+
+const createScoutingSessionsTable = `
+CREATE TABLE IF NOT EXISTS scouting_sessions (
+    id TEXT PRIMARY KEY,
+    user_id TEXT NOT NULL,
+    land_id TEXT NOT NULL,
+    scouted_on DATE NOT NULL,
+    scout_name TEXT,
+    notes TEXT,
+    created_at DATETIME DEFAULT CURRENT_TIMESTAMP,
+    updated_at DATETIME DEFAULT CURRENT_TIMESTAMP,
+    FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE,
+    FOREIGN KEY (land_id) REFERENCES lands(id) ON DELETE CASCADE
+);
+CREATE INDEX IF NOT EXISTS idx_scouting_sessions_land ON scouting_sessions (land_id, scouted_on);`
+
+const createScoutingPointsTable = `
+CREATE TABLE IF NOT EXISTS scouting_points (
+    id TEXT PRIMARY KEY,
+    user_id TEXT NOT NULL,
+    session_id TEXT NOT NULL,
+    latitude REAL NOT NULL,
+    longitude REAL NOT NULL,
+    severity TEXT NOT NULL,
+    note TEXT,
+    issue_id TEXT,
+    task_id TEXT,
+    created_at DATETIME DEFAULT CURRENT_TIMESTAMP,
+    FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE,
+    FOREIGN KEY (session_id) REFERENCES scouting_sessions(id) ON DELETE CASCADE,
+    FOREIGN KEY (issue_id) REFERENCES pest_disease_observations(id) ON DELETE SET NULL,
+    FOREIGN KEY (task_id) REFERENCES land_activities(id) ON DELETE SET NULL
+);
+CREATE INDEX IF NOT EXISTS idx_scouting_points_session ON scouting_points (session_id);`
+
+const createGeneratedEventsTable = `
+CREATE TABLE IF NOT EXISTS generated_events (
+    user_id TEXT NOT NULL,
+    rule TEXT NOT NULL,
+    source_key TEXT NOT NULL,
+    event_id TEXT NOT NULL,
+    start_date DATETIME NOT NULL,
+    locked BOOLEAN DEFAULT FALSE,
+    created_at DATETIME DEFAULT CURRENT_TIMESTAMP,
+    updated_at DATETIME DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (user_id, rule, source_key),
+    FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE
+);
+CREATE INDEX IF NOT EXISTS idx_generated_events_event ON generated_events (event_id);`
+
+const createReportsTable = `
+CREATE TABLE IF NOT EXISTS reports (
+    id TEXT PRIMARY KEY,
+    user_id TEXT NOT NULL,
+    title TEXT NOT NULL,
+    type TEXT NOT NULL,
+    description TEXT,
+    period TEXT NOT NULL,
+    start_date DATE NOT NULL,
+    end_date DATE NOT NULL,
+    format TEXT NOT NULL,
+    include_charts BOOLEAN DEFAULT FALSE,
+    categories TEXT,
+    file_path TEXT,
+    status TEXT NOT NULL DEFAULT 'completed',
+    created_at DATETIME DEFAULT CURRENT_TIMESTAMP,
+    FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE
+);
+CREATE INDEX IF NOT EXISTS idx_reports_user ON reports (user_id, created_at);`
+
+const createJobsTable = `
+CREATE TABLE IF NOT EXISTS jobs (
+    id TEXT PRIMARY KEY,
+    user_id TEXT NOT NULL,
+    type TEXT NOT NULL,
+    status TEXT NOT NULL DEFAULT 'queued',
+    payload TEXT NOT NULL,
+    attempts INTEGER NOT NULL DEFAULT 0,
+    result_id TEXT,
+    error TEXT,
+    created_at DATETIME DEFAULT CURRENT_TIMESTAMP,
+    started_at DATETIME,
+    finished_at DATETIME,
+    FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE
+);
+CREATE INDEX IF NOT EXISTS idx_jobs_status ON jobs (status, created_at);`
+
+const createLivestockBreedsTable = `
+CREATE TABLE IF NOT EXISTS livestock_breeds (
+    id TEXT PRIMARY KEY,
+    user_id TEXT,
+    species TEXT NOT NULL,
+    name TEXT NOT NULL,
+    created_at DATETIME DEFAULT CURRENT_TIMESTAMP,
+    UNIQUE (user_id, species, name),
+    FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE
+);
+CREATE INDEX IF NOT EXISTS idx_livestock_breeds_species ON livestock_breeds (species);`
+
+const createWorkersTable = `
+CREATE TABLE IF NOT EXISTS workers (
+    id TEXT PRIMARY KEY,
+    user_id TEXT NOT NULL,
+    name TEXT NOT NULL,
+    role TEXT,
+    phone TEXT,
+    contract_type TEXT NOT NULL DEFAULT 'seasonal',
+    contract_start DATE NOT NULL,
+    contract_end DATE,
+    notes TEXT,
+    created_at DATETIME DEFAULT CURRENT_TIMESTAMP,
+    updated_at DATETIME DEFAULT CURRENT_TIMESTAMP,
+    FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE
+);
+CREATE INDEX IF NOT EXISTS idx_workers_user ON workers (user_id, name);`
+
+const createWorkerCertificationsTable = `
+CREATE TABLE IF NOT EXISTS worker_certifications (
+    id TEXT PRIMARY KEY,
+    user_id TEXT NOT NULL,
+    worker_id TEXT NOT NULL,
+    type TEXT NOT NULL,
+    number TEXT,
+    issued_at DATE,
+    expires_at DATE,
+    created_at DATETIME DEFAULT CURRENT_TIMESTAMP,
+    FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE,
+    FOREIGN KEY (worker_id) REFERENCES workers(id) ON DELETE CASCADE
+);
+CREATE INDEX IF NOT EXISTS idx_worker_certifications_worker ON worker_certifications (worker_id, type);`
+
+const createVaccinationsTable = `
+CREATE TABLE IF NOT EXISTS vaccinations (
+    id TEXT PRIMARY KEY,
+    livestock_id TEXT NOT NULL,
+    vaccine TEXT NOT NULL,
+    dose TEXT,
+    due_date DATE NOT NULL,
+    administered_date DATE,
+    batch_number TEXT,
+    veterinarian TEXT,
+    notes TEXT,
+    health_record_id TEXT,
+    created_at DATETIME DEFAULT CURRENT_TIMESTAMP,
+    updated_at DATETIME DEFAULT CURRENT_TIMESTAMP,
+    FOREIGN KEY (livestock_id) REFERENCES livestock(id) ON DELETE CASCADE,
+    FOREIGN KEY (health_record_id) REFERENCES health_records(id) ON DELETE SET NULL
+);
+CREATE INDEX IF NOT EXISTS idx_vaccinations_livestock ON vaccinations (livestock_id, due_date);
+CREATE INDEX IF NOT EXISTS idx_vaccinations_due ON vaccinations (due_date) WHERE administered_date IS NULL;`
+
+const createBreedingRecordsTable = `
+CREATE TABLE IF NOT EXISTS breeding_records (
+    id TEXT PRIMARY KEY,
+    livestock_id TEXT NOT NULL,
+    method TEXT NOT NULL DEFAULT 'artificial_insemination',
+    service_date DATE NOT NULL,
+    sire_id TEXT,
+    sire TEXT,
+    technician TEXT,
+    pregnancy_status TEXT NOT NULL DEFAULT 'pending',
+    pregnancy_check_date DATE,
+    expected_birth_date DATE,
+    birth_date DATE,
+    notes TEXT,
+    created_at DATETIME DEFAULT CURRENT_TIMESTAMP,
+    updated_at DATETIME DEFAULT CURRENT_TIMESTAMP,
+    FOREIGN KEY (livestock_id) REFERENCES livestock(id) ON DELETE CASCADE,
+    FOREIGN KEY (sire_id) REFERENCES livestock(id) ON DELETE SET NULL
+);
+CREATE INDEX IF NOT EXISTS idx_breeding_records_livestock ON breeding_records (livestock_id, service_date);`
+
+const createCostAllocationRulesTable = `
+CREATE TABLE IF NOT EXISTS cost_allocation_rules (
+    id TEXT PRIMARY KEY,
+    user_id TEXT NOT NULL,
+    name TEXT NOT NULL,
+    categories TEXT NOT NULL,
+    target_type TEXT NOT NULL,
+    basis TEXT NOT NULL,
+    animal_type TEXT,
+    created_at DATETIME DEFAULT CURRENT_TIMESTAMP,
+    updated_at DATETIME DEFAULT CURRENT_TIMESTAMP,
+    FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE
+);
+CREATE INDEX IF NOT EXISTS idx_cost_allocation_rules_user ON cost_allocation_rules (user_id);`
+
+const createCostAllocationsTable = `
+CREATE TABLE IF NOT EXISTS cost_allocations (
+    id TEXT PRIMARY KEY,
+    user_id TEXT NOT NULL,
+    rule_id TEXT NOT NULL,
+    period_start DATE NOT NULL,
+    period_end DATE NOT NULL,
+    target_type TEXT NOT NULL,
+    target_id TEXT NOT NULL,
+    basis_value REAL NOT NULL,
+    share REAL NOT NULL,
+    amount REAL NOT NULL,
+    created_at DATETIME DEFAULT CURRENT_TIMESTAMP,
+    FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE,
+    FOREIGN KEY (rule_id) REFERENCES cost_allocation_rules(id) ON DELETE CASCADE
+);
+CREATE INDEX IF NOT EXISTS idx_cost_allocations_rule ON cost_allocations (rule_id, period_start);
+CREATE INDEX IF NOT EXISTS idx_cost_allocations_target ON cost_allocations (user_id, target_type, target_id);`
+
+const createEnterprisesTable = `
+CREATE TABLE IF NOT EXISTS enterprises (
+    id TEXT PRIMARY KEY,
+    user_id TEXT NOT NULL,
+    name TEXT NOT NULL,
+    type TEXT NOT NULL,
+    description TEXT,
+    created_at DATETIME DEFAULT CURRENT_TIMESTAMP,
+    updated_at DATETIME DEFAULT CURRENT_TIMESTAMP,
+    FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE,
+    UNIQUE (user_id, name)
+);`
+
+const createWeightRecordsTable = `
+CREATE TABLE IF NOT EXISTS weight_records (
+    id TEXT PRIMARY KEY,
+    livestock_id TEXT NOT NULL,
+    weight REAL NOT NULL,
+    record_date DATE NOT NULL,
+    notes TEXT,
+    created_at DATETIME DEFAULT CURRENT_TIMESTAMP,
+    FOREIGN KEY (livestock_id) REFERENCES livestock(id) ON DELETE CASCADE
+);
+CREATE INDEX IF NOT EXISTS idx_weight_records_livestock ON weight_records (livestock_id, record_date);`
+
+const createAccountantAccessTable = `
+CREATE TABLE IF NOT EXISTS accountant_access (
+    id TEXT PRIMARY KEY,
+    user_id TEXT NOT NULL,
+    accountant_id TEXT NOT NULL,
+    note TEXT,
+    expires_at DATE,
+    last_access_at DATETIME,
+    revoked_at DATETIME,
+    created_at DATETIME DEFAULT CURRENT_TIMESTAMP,
+    FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE,
+    FOREIGN KEY (accountant_id) REFERENCES users(id) ON DELETE CASCADE
+);
+CREATE INDEX IF NOT EXISTS idx_accountant_access_accountant ON accountant_access (accountant_id);
+
+CREATE TABLE IF NOT EXISTS accountant_access_logs (
+    id TEXT PRIMARY KEY,
+    user_id TEXT NOT NULL,
+    access_id TEXT NOT NULL,
+    accountant_id TEXT NOT NULL,
+    method TEXT NOT NULL,
+    path TEXT NOT NULL,
+    status_code INTEGER NOT NULL,
+    ip_address TEXT,
+    created_at DATETIME DEFAULT CURRENT_TIMESTAMP,
+    FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE,
+    FOREIGN KEY (access_id) REFERENCES accountant_access(id) ON DELETE CASCADE
+);
+CREATE INDEX IF NOT EXISTS idx_accountant_access_logs_user ON accountant_access_logs (user_id, created_at);`
+
+const createCropPlansTable = `
+CREATE TABLE IF NOT EXISTS crop_plans (
+    id TEXT PRIMARY KEY,
+    user_id TEXT NOT NULL,
+    land_id TEXT NOT NULL,
+    season TEXT NOT NULL,
+    crop TEXT NOT NULL,
+    variety TEXT,
+    planting_date DATE NOT NULL,
+    harvest_date DATE,
+    status TEXT NOT NULL DEFAULT 'planned',
+    notes TEXT,
+    created_at DATETIME DEFAULT CURRENT_TIMESTAMP,
+    updated_at DATETIME DEFAULT CURRENT_TIMESTAMP,
+    FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE,
+    FOREIGN KEY (land_id) REFERENCES lands(id) ON DELETE CASCADE
+);
+CREATE INDEX IF NOT EXISTS idx_crop_plans_land ON crop_plans (land_id, planting_date);`
+
+const createFarmPublicProfilesTable = `
+CREATE TABLE IF NOT EXISTS farm_public_profiles (
+    user_id TEXT PRIMARY KEY,
+    slug TEXT NOT NULL UNIQUE,
+    enabled BOOLEAN NOT NULL DEFAULT FALSE,
+    description TEXT,
+    products TEXT,
+    location_area TEXT,
+    photo_ids TEXT,
+    published_at DATETIME,
+    created_at DATETIME DEFAULT CURRENT_TIMESTAMP,
+    updated_at DATETIME DEFAULT CURRENT_TIMESTAMP,
+    FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE
+);
+CREATE INDEX IF NOT EXISTS idx_farm_public_profiles_enabled ON farm_public_profiles (enabled, published_at);`
+
+const createWeatherLocationsTable = `
+CREATE TABLE IF NOT EXISTS weather_locations (
+    id TEXT PRIMARY KEY,
+    user_id TEXT NOT NULL,
+    name TEXT NOT NULL,
+    latitude REAL NOT NULL,
+    longitude REAL NOT NULL,
+    notes TEXT,
+    daily_summary BOOLEAN NOT NULL DEFAULT TRUE,
+    alerts BOOLEAN NOT NULL DEFAULT TRUE,
+    delivery_hour INTEGER NOT NULL DEFAULT 7,
+    timezone TEXT NOT NULL DEFAULT 'Europe/Istanbul',
+    channels TEXT,
+    last_delivered_on DATE,
+    created_at DATETIME DEFAULT CURRENT_TIMESTAMP,
+    updated_at DATETIME DEFAULT CURRENT_TIMESTAMP,
+    FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE
+);
+CREATE INDEX IF NOT EXISTS idx_weather_locations_user ON weather_locations (user_id, name);`
+
+const createHerdBookTemplatesTable = `
+CREATE TABLE IF NOT EXISTS herd_book_templates (
+    id TEXT PRIMARY KEY,
+    association TEXT NOT NULL,
+    title TEXT NOT NULL,
+    species TEXT,
+    format TEXT NOT NULL DEFAULT 'pdf',
+    generations INTEGER NOT NULL DEFAULT 3,
+    fields TEXT,
+    declaration TEXT,
+    active BOOLEAN NOT NULL DEFAULT TRUE,
+    created_at DATETIME DEFAULT CURRENT_TIMESTAMP,
+    updated_at DATETIME DEFAULT CURRENT_TIMESTAMP
+);
+CREATE INDEX IF NOT EXISTS idx_herd_book_templates_species ON herd_book_templates (species, association);`
+
+const createCustomersTable = `
+CREATE TABLE IF NOT EXISTS customers (
+    id TEXT PRIMARY KEY,
+    user_id TEXT NOT NULL,
+    name TEXT NOT NULL,
+    customer_type TEXT NOT NULL DEFAULT 'individual',
+    tax_id TEXT,
+    phone TEXT,
+    email TEXT,
+    address TEXT,
+    notes TEXT,
+    created_at DATETIME DEFAULT CURRENT_TIMESTAMP,
+    updated_at DATETIME DEFAULT CURRENT_TIMESTAMP,
+    FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE
+);
+CREATE INDEX IF NOT EXISTS idx_customers_user ON customers (user_id, name);`
+
+const createSalesOrdersTable = `
+CREATE TABLE IF NOT EXISTS sales_orders (
+    id TEXT PRIMARY KEY,
+    user_id TEXT NOT NULL,
+    order_number TEXT NOT NULL,
+    customer_id TEXT NOT NULL,
+    production_id TEXT NOT NULL,
+    order_date DATE NOT NULL,
+    quantity REAL NOT NULL,
+    unit TEXT,
+    unit_price REAL NOT NULL,
+    subtotal REAL NOT NULL,
+    tax_rate REAL DEFAULT 0,
+    tax_amount REAL DEFAULT 0,
+    total REAL NOT NULL,
+    currency TEXT DEFAULT 'TRY',
+    payment_status TEXT NOT NULL DEFAULT 'pending',
+    due_date DATE,
+    paid_at DATETIME,
+    payment_method TEXT,
+    transaction_id TEXT,
+    notes TEXT,
+    created_at DATETIME DEFAULT CURRENT_TIMESTAMP,
+    updated_at DATETIME DEFAULT CURRENT_TIMESTAMP,
+    FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE,
+    FOREIGN KEY (customer_id) REFERENCES customers(id),
+    FOREIGN KEY (production_id) REFERENCES production(id) ON DELETE CASCADE
+);
+CREATE INDEX IF NOT EXISTS idx_sales_orders_user ON sales_orders (user_id, order_date);
+CREATE INDEX IF NOT EXISTS idx_sales_orders_customer ON sales_orders (customer_id);
+CREATE INDEX IF NOT EXISTS idx_sales_orders_production ON sales_orders (production_id);
+CREATE UNIQUE INDEX IF NOT EXISTS idx_sales_orders_number ON sales_orders (user_id, order_number);`
+
+const createDocumentSigningKeysTable = `
+CREATE TABLE IF NOT EXISTS document_signing_keys (
+    id TEXT PRIMARY KEY,
+    algorithm TEXT NOT NULL DEFAULT 'Ed25519',
+    public_key TEXT NOT NULL,
+    created_at DATETIME DEFAULT CURRENT_TIMESTAMP,
+    retired_at DATETIME
+);`
+
+const createDocumentSignaturesTable = `
+CREATE TABLE IF NOT EXISTS document_signatures (
+    id TEXT PRIMARY KEY,
+    user_id TEXT NOT NULL,
+    document_type TEXT NOT NULL,
+    document_id TEXT,
+    filename TEXT NOT NULL,
+    content_type TEXT NOT NULL,
+    size INTEGER NOT NULL,
+    sha256 TEXT NOT NULL,
+    key_id TEXT NOT NULL,
+    signature TEXT NOT NULL,
+    created_at DATETIME DEFAULT CURRENT_TIMESTAMP,
+    FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE,
+    FOREIGN KEY (key_id) REFERENCES document_signing_keys(id)
+);
+CREATE INDEX IF NOT EXISTS idx_document_signatures_user ON document_signatures (user_id, created_at);
+CREATE INDEX IF NOT EXISTS idx_document_signatures_sha256 ON document_signatures (sha256);`
+
+const createInvoicesTable = `
+CREATE TABLE IF NOT EXISTS invoices (
+    id TEXT PRIMARY KEY,
+    user_id TEXT NOT NULL,
+    invoice_number TEXT NOT NULL,
+    sales_order_id TEXT,
+    customer_id TEXT,
+    buyer_name TEXT,
+    buyer_tax_id TEXT,
+    buyer_address TEXT,
+    issue_date DATE NOT NULL,
+    due_date DATE,
+    currency TEXT NOT NULL DEFAULT 'TRY',
+    subtotal REAL NOT NULL,
+    tax_amount REAL NOT NULL DEFAULT 0,
+    total REAL NOT NULL,
+    lines TEXT NOT NULL,
+    notes TEXT,
+    file_path TEXT NOT NULL,
+    created_at DATETIME DEFAULT CURRENT_TIMESTAMP,
+    FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE,
+    FOREIGN KEY (sales_order_id) REFERENCES sales_orders(id),
+    FOREIGN KEY (customer_id) REFERENCES customers(id)
+);
+CREATE INDEX IF NOT EXISTS idx_invoices_user ON invoices (user_id, issue_date);
+CREATE UNIQUE INDEX IF NOT EXISTS idx_invoices_number ON invoices (user_id, invoice_number);
+CREATE UNIQUE INDEX IF NOT EXISTS idx_invoices_sales_order ON invoices (sales_order_id);
+
+CREATE TABLE IF NOT EXISTS invoice_transactions (
+    invoice_id TEXT NOT NULL,
+    transaction_id TEXT PRIMARY KEY,
+    user_id TEXT NOT NULL,
+    FOREIGN KEY (invoice_id) REFERENCES invoices(id) ON DELETE CASCADE,
+    FOREIGN KEY (transaction_id) REFERENCES transactions(id) ON DELETE CASCADE
+);
+CREATE INDEX IF NOT EXISTS idx_invoice_transactions_invoice ON invoice_transactions (invoice_id);`
+
+const createDemoAccountsTable = `
+CREATE TABLE IF NOT EXISTS demo_accounts (
+    user_id TEXT PRIMARY KEY,
+    name TEXT NOT NULL,
+    farm_name TEXT NOT NULL,
+    location TEXT,
+    records TEXT,
+    last_reset_at DATETIME,
+    last_error TEXT,
+    created_by TEXT,
+    created_at DATETIME DEFAULT CURRENT_TIMESTAMP,
+    FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE
+);`
+
+const createArchiveBatchesTable = `
+CREATE TABLE IF NOT EXISTS archive_batches (
+    id TEXT PRIMARY KEY,
+    user_id TEXT NOT NULL,
+    job_id TEXT,
+    table_name TEXT NOT NULL,
+    cutoff DATETIME NOT NULL,
+    period_start DATETIME,
+    period_end DATETIME,
+    records TEXT NOT NULL DEFAULT '{}',
+    size_bytes INTEGER NOT NULL DEFAULT 0,
+    object_key TEXT NOT NULL,
+    status TEXT NOT NULL DEFAULT 'archived',
+    created_at DATETIME DEFAULT CURRENT_TIMESTAMP,
+    restored_at DATETIME,
+    FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE
+);
+CREATE INDEX IF NOT EXISTS idx_archive_batches_user ON archive_batches (user_id, table_name, created_at);
+CREATE INDEX IF NOT EXISTS idx_archive_batches_job ON archive_batches (job_id);`
+
+const createArchiveAggregatesTable = `
+CREATE TABLE IF NOT EXISTS archive_aggregates (
+    batch_id TEXT NOT NULL,
+    user_id TEXT NOT NULL,
+    table_name TEXT NOT NULL,
+    month TEXT NOT NULL,
+    group_key TEXT NOT NULL DEFAULT '',
+    subgroup_key TEXT NOT NULL DEFAULT '',
+    currency TEXT NOT NULL DEFAULT '',
+    record_count INTEGER NOT NULL DEFAULT 0,
+    total REAL NOT NULL DEFAULT 0,
+    FOREIGN KEY (batch_id) REFERENCES archive_batches(id) ON DELETE CASCADE
+);
+CREATE INDEX IF NOT EXISTS idx_archive_aggregates_user ON archive_aggregates (user_id, table_name, month);`
+
+const createSchemaMigrationsTable = `
+CREATE TABLE IF NOT EXISTS schema_migrations (
+    name TEXT PRIMARY KEY,
+    applied_at DATETIME DEFAULT CURRENT_TIMESTAMP
+);`
@@ -0,0 +1,422 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"log"
+	"os"
+	"runtime"
+	"strconv"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"agri-management-api/internal/models"
+	"agri-management-api/internal/utils"
+
+	sqlite3 "github.com/mattn/go-sqlite3"
+)
+
+// metricsDriverName sorgu sürelerini ölçen sqlite sürücüsünün adı
+const metricsDriverName = "sqlite3_metrics"
+
+// defaultSlowQueryThreshold SLOW_QUERY_THRESHOLD_MS verilmezse yavaş sayılan sorgu süresi
+const defaultSlowQueryThreshold = 100 * time.Millisecond
+
+// slowQueryCapacity bellekte tutulan son yavaş sorgu sayısı
+const slowQueryCapacity = 100
+
+// maxLoggedQueryLength günlüğe ve listeye yazılan sorgu metninin en fazla uzunluğu
+const maxLoggedQueryLength = 2000
+
+// sqliteDriver ölçümlü sürücünün sardığı sqlite sürücüsü
+var sqliteDriver = &sqlite3.SQLiteDriver{}
+
+func init() {
+	sql.Register(metricsDriverName, &metricsDriver{driver: sqliteDriver})
+}
+
+// queryMetrics tüm bağlantıların sorgu sayaçları, son yavaş sorgular ve istek bazında veritabanı süreleri
+var queryMetrics = struct {
+	threshold time.Duration
+	total     atomic.Int64
+	slow      atomic.Int64
+
+	mu     sync.Mutex
+	recent []models.SlowQuery
+
+	requests sync.Map // goroutine kimliği -> *RequestTiming
+	tracked  atomic.Int64
+}{threshold: defaultSlowQueryThreshold}
+
+// slowQueryThreshold yavaş sorgu eşiğini SLOW_QUERY_THRESHOLD_MS ortam değişkeninden okur
+func slowQueryThreshold() time.Duration {
+	if ms, err := strconv.Atoi(os.Getenv("SLOW_QUERY_THRESHOLD_MS")); err == nil && ms > 0 {
+		return time.Duration(ms) * time.Millisecond
+	}
+	return defaultSlowQueryThreshold
+}
+
+// RequestTiming bir HTTP isteği boyunca çalışan sorguların sayısı ve toplam süresi
+type RequestTiming struct {
+	endpoint string
+	queries  atomic.Int64
+	duration atomic.Int64
+}
+
+// Timing isteğin o ana kadarki veritabanı süresini döner
+func (t *RequestTiming) Timing() models.DBTiming {
+	return models.DBTiming{
+		Queries:    int(t.queries.Load()),
+		DurationMs: float64(time.Duration(t.duration.Load()).Microseconds()) / 1000,
+	}
+}
+
+// TrackRequest isteği işleyen goroutine'deki sorguları endpoint adına sayar; dönen fonksiyon istek bitince
+// çağrılmalıdır. Handler'ın başlattığı ayrı goroutine'lerdeki sorgular isteğe sayılmaz
+func TrackRequest(endpoint string) (*RequestTiming, func()) {
+	timing := &RequestTiming{endpoint: endpoint}
+	id := goroutineID()
+	queryMetrics.requests.Store(id, timing)
+	queryMetrics.tracked.Add(1)
+
+	return timing, func() {
+		queryMetrics.requests.Delete(id)
+		queryMetrics.tracked.Add(-1)
+	}
+}
+
+// SlowQueries yavaş sorgu eşiğini, sayaçları ve en yeniden eskiye son yavaş sorguları döner
+func SlowQueries() models.SlowQueryReport {
+	queryMetrics.mu.Lock()
+	recent := make([]models.SlowQuery, len(queryMetrics.recent))
+	for i, query := range queryMetrics.recent {
+		recent[len(recent)-1-i] = query
+	}
+	queryMetrics.mu.Unlock()
+
+	return models.SlowQueryReport{
+		ThresholdMs:  queryMetrics.threshold.Milliseconds(),
+		TotalQueries: queryMetrics.total.Load(),
+		SlowQueries:  queryMetrics.slow.Load(),
+		Recent:       recent,
+	}
+}
+
+// FindSlowQuery bellekteki yavaş sorguyu kimliğiyle döner
+func FindSlowQuery(id string) (models.SlowQuery, bool) {
+	queryMetrics.mu.Lock()
+	defer queryMetrics.mu.Unlock()
+
+	for _, query := range queryMetrics.recent {
+		if query.ID == id {
+			return query, true
+		}
+	}
+	return models.SlowQuery{}, false
+}
+
+// recordQuery sorgunun süresini sayaçlara ve isteğe ekler; eşiği aşan sorgular parametreleri gizlenerek
+// günlüğe yazılır ve son yavaş sorgulara eklenir
+func recordQuery(query string, params int, elapsed time.Duration) {
+	queryMetrics.total.Add(1)
+
+	timing := currentRequest()
+	if timing != nil {
+		timing.queries.Add(1)
+		timing.duration.Add(int64(elapsed))
+	}
+
+	if elapsed < queryMetrics.threshold {
+		return
+	}
+	queryMetrics.slow.Add(1)
+
+	slow := models.SlowQuery{
+		ID:         utils.GenerateID(),
+		Query:      normalizeQuery(query),
+		ParamCount: params,
+		DurationMs: float64(elapsed.Microseconds()) / 1000,
+		OccurredAt: time.Now(),
+	}
+	source := "arka plan"
+	if timing != nil {
+		slow.Endpoint, source = timing.endpoint, timing.endpoint
+	}
+	log.Printf("⚠️ Yavaş sorgu (%.1f ms, %s, %d parametre gizlendi): %s", slow.DurationMs, source, params, slow.Query)
+
+	queryMetrics.mu.Lock()
+	queryMetrics.recent = append(queryMetrics.recent, slow)
+	if len(queryMetrics.recent) > slowQueryCapacity {
+		queryMetrics.recent = queryMetrics.recent[len(queryMetrics.recent)-slowQueryCapacity:]
+	}
+	queryMetrics.mu.Unlock()
+}
+
+// currentRequest çalışan goroutine'in işlediği HTTP isteğini döner; arka plan işlerinde nil döner
+func currentRequest() *RequestTiming {
+	if queryMetrics.tracked.Load() == 0 {
+		return nil
+	}
+	if value, ok := queryMetrics.requests.Load(goroutineID()); ok {
+		return value.(*RequestTiming)
+	}
+	return nil
+}
+
+// normalizeQuery sorgudaki boşlukları sadeleştirir ve uzun sorguları kısaltır; parametre değerleri hiçbir
+// zaman sorgu metnine eklenmez
+func normalizeQuery(query string) string {
+	normalized := strings.Join(strings.Fields(query), " ")
+	if len(normalized) > maxLoggedQueryLength {
+		normalized = normalized[:maxLoggedQueryLength] + "…"
+	}
+	return normalized
+}
+
+// goroutineID çalışan goroutine'in kimliğini yığın başlığından ("goroutine 42 [running]:") okur
+func goroutineID() uint64 {
+	var buf [64]byte
+	n := runtime.Stack(buf[:], false)
+	header := strings.TrimPrefix(string(buf[:n]), "goroutine ")
+	if end := strings.IndexByte(header, ' '); end > 0 {
+		header = header[:end]
+	}
+	id, _ := strconv.ParseUint(header, 10, 64)
+	return id
+}
+
+// metricsDriver sqlite sürücüsünü sarar ve her sorgunun sürücüde geçen süresini ölçer
+type metricsDriver struct {
+	driver driver.Driver
+}
+
+func (d *metricsDriver) Open(name string) (driver.Conn, error) {
+	conn, err := d.driver.Open(name)
+	if err != nil {
+		return nil, err
+	}
+	return &metricsConn{conn: conn}, nil
+}
+
+// metricsConn sürücü bağlantısı; Exec ve Query çağrılarını ölçer ve kiracı kapsamını denetler, diğer
+// çağrıları olduğu gibi iletir.
+// valid verilirse false döndüğünde bağlantı havuzdan çıkarılır
+type metricsConn struct {
+	conn  driver.Conn
+	valid func() bool
+}
+
+func (c *metricsConn) Prepare(query string) (driver.Stmt, error) {
+	return c.PrepareContext(context.Background(), query)
+}
+
+func (c *metricsConn) PrepareContext(ctx context.Context, query string) (driver.Stmt, error) {
+	if err := checkTenantScope(query); err != nil {
+		return nil, err
+	}
+
+	var (
+		stmt driver.Stmt
+		err  error
+	)
+	if preparer, ok := c.conn.(driver.ConnPrepareContext); ok {
+		stmt, err = preparer.PrepareContext(ctx, query)
+	} else {
+		stmt, err = c.conn.Prepare(query)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &metricsStmt{stmt: stmt, query: query}, nil
+}
+
+func (c *metricsConn) Close() error {
+	return c.conn.Close()
+}
+
+func (c *metricsConn) Begin() (driver.Tx, error) {
+	return c.BeginTx(context.Background(), driver.TxOptions{})
+}
+
+func (c *metricsConn) BeginTx(ctx context.Context, opts driver.TxOptions) (driver.Tx, error) {
+	if beginner, ok := c.conn.(driver.ConnBeginTx); ok {
+		return beginner.BeginTx(ctx, opts)
+	}
+	return c.conn.Begin()
+}
+
+func (c *metricsConn) ExecContext(ctx context.Context, query string, args []driver.NamedValue) (driver.Result, error) {
+	execer, ok := c.conn.(driver.ExecerContext)
+	if !ok {
+		return nil, driver.ErrSkip
+	}
+	if err := checkTenantScope(query); err != nil {
+		return nil, err
+	}
+
+	start := time.Now()
+	result, err := execer.ExecContext(ctx, query, args)
+	if err != driver.ErrSkip {
+		recordQuery(query, len(args), time.Since(start))
+	}
+	return result, err
+}
+
+func (c *metricsConn) QueryContext(ctx context.Context, query string, args []driver.NamedValue) (driver.Rows, error) {
+	queryer, ok := c.conn.(driver.QueryerContext)
+	if !ok {
+		return nil, driver.ErrSkip
+	}
+	if err := checkTenantScope(query); err != nil {
+		return nil, err
+	}
+
+	start := time.Now()
+	rows, err := queryer.QueryContext(ctx, query, args)
+	if err != nil {
+		if err != driver.ErrSkip {
+			recordQuery(query, len(args), time.Since(start))
+		}
+		return nil, err
+	}
+	return &metricsRows{rows: rows, query: query, params: len(args), elapsed: time.Since(start)}, nil
+}
+
+func (c *metricsConn) Ping(ctx context.Context) error {
+	if pinger, ok := c.conn.(driver.Pinger); ok {
+		return pinger.Ping(ctx)
+	}
+	return nil
+}
+
+func (c *metricsConn) ResetSession(ctx context.Context) error {
+	if resetter, ok := c.conn.(driver.SessionResetter); ok {
+		return resetter.ResetSession(ctx)
+	}
+	return nil
+}
+
+func (c *metricsConn) IsValid() bool {
+	if c.valid != nil && !c.valid() {
+		return false
+	}
+	if validator, ok := c.conn.(driver.Validator); ok {
+		return validator.IsValid()
+	}
+	return true
+}
+
+// metricsStmt hazırlanmış ifade; çalıştırma sürelerini ölçer
+type metricsStmt struct {
+	stmt  driver.Stmt
+	query string
+}
+
+func (s *metricsStmt) Close() error {
+	return s.stmt.Close()
+}
+
+func (s *metricsStmt) NumInput() int {
+	return s.stmt.NumInput()
+}
+
+func (s *metricsStmt) Exec(args []driver.Value) (driver.Result, error) {
+	start := time.Now()
+	result, err := s.stmt.Exec(args)
+	recordQuery(s.query, len(args), time.Since(start))
+	return result, err
+}
+
+func (s *metricsStmt) Query(args []driver.Value) (driver.Rows, error) {
+	start := time.Now()
+	rows, err := s.stmt.Query(args)
+	if err != nil {
+		recordQuery(s.query, len(args), time.Since(start))
+		return nil, err
+	}
+	return &metricsRows{rows: rows, query: s.query, params: len(args), elapsed: time.Since(start)}, nil
+}
+
+func (s *metricsStmt) ExecContext(ctx context.Context, args []driver.NamedValue) (driver.Result, error) {
+	execer, ok := s.stmt.(driver.StmtExecContext)
+	if !ok {
+		values := make([]driver.Value, len(args))
+		for i, arg := range args {
+			values[i] = arg.Value
+		}
+		return s.Exec(values)
+	}
+
+	start := time.Now()
+	result, err := execer.ExecContext(ctx, args)
+	recordQuery(s.query, len(args), time.Since(start))
+	return result, err
+}
+
+func (s *metricsStmt) QueryContext(ctx context.Context, args []driver.NamedValue) (driver.Rows, error) {
+	queryer, ok := s.stmt.(driver.StmtQueryContext)
+	if !ok {
+		values := make([]driver.Value, len(args))
+		for i, arg := range args {
+			values[i] = arg.Value
+		}
+		return s.Query(values)
+	}
+
+	start := time.Now()
+	rows, err := queryer.QueryContext(ctx, args)
+	if err != nil {
+		recordQuery(s.query, len(args), time.Since(start))
+		return nil, err
+	}
+	return &metricsRows{rows: rows, query: s.query, params: len(args), elapsed: time.Since(start)}, nil
+}
+
+// metricsRows sorgu sonucu; sqlite satırları Next çağrısında ürettiğinden süre sorgu çağrısı ile Next
+// çağrılarının toplamıdır. Satırlar arasında uygulamada geçen süre sayılmaz; sorgu Close'da kaydedilir
+type metricsRows struct {
+	rows    driver.Rows
+	query   string
+	params  int
+	elapsed time.Duration
+	closed  bool
+}
+
+func (r *metricsRows) Columns() []string {
+	return r.rows.Columns()
+}
+
+func (r *metricsRows) Next(dest []driver.Value) error {
+	start := time.Now()
+	err := r.rows.Next(dest)
+	r.elapsed += time.Since(start)
+	return err
+}
+
+func (r *metricsRows) Close() error {
+	err := r.rows.Close()
+	if !r.closed {
+		r.closed = true
+		recordQuery(r.query, r.params, r.elapsed)
+	}
+	return err
+}
+
+func (r *metricsRows) ColumnTypeDatabaseTypeName(index int) string {
+	if typed, ok := r.rows.(driver.RowsColumnTypeDatabaseTypeName); ok {
+		return typed.ColumnTypeDatabaseTypeName(index)
+	}
+	return ""
+}
+
+var (
+	_ driver.ExecerContext      = (*metricsConn)(nil)
+	_ driver.QueryerContext     = (*metricsConn)(nil)
+	_ driver.ConnBeginTx        = (*metricsConn)(nil)
+	_ driver.ConnPrepareContext = (*metricsConn)(nil)
+	_ driver.StmtExecContext    = (*metricsStmt)(nil)
+	_ driver.StmtQueryContext   = (*metricsStmt)(nil)
+)
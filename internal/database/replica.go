@@ -0,0 +1,128 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"log"
+	"os"
+	"strings"
+	"sync/atomic"
+	"time"
+)
+
+// replicaCheckInterval okuma replikasının erişilebilirliğinin kontrol edildiği aralık
+const replicaCheckInterval = 30 * time.Second
+
+// replicaConnMaxLifetime okuma havuzundaki bağlantıların en uzun ömrü; replikaya dönüşte birincil
+// veritabanına açılmış bağlantıların da yenilenmesini sağlar
+const replicaConnMaxLifetime = 5 * time.Minute
+
+// InitReadDB DB_READ_PATH ile yapılandırılan okuma replikasını (ör. LiteFS veya Litestream ile çoğaltılan
+// SQLite dosyası) salt okunur açar. Dashboard ve analiz sorguları bu havuzdan okunur, yazmalar her zaman
+// birincil veritabanına gider. Replika açılamazsa veya sağlık kontrolü başarısız olursa okuma havuzu yeni
+// bağlantıları birincil veritabanına açar ve replika erişilebilir olduğunda geri döner. Replika
+// yapılandırılmamışsa birincil veritabanı döner
+func InitReadDB(primary *sql.DB) *sql.DB {
+	replicaPath := os.Getenv("DB_READ_PATH")
+	if replicaPath == "" {
+		return primary
+	}
+
+	connector := &replicaConnector{
+		replicaDSN: replicaDSN(replicaPath),
+		primaryDSN: primaryPath(),
+	}
+	connector.check()
+	if !connector.healthy.Load() {
+		log.Printf("⚠️ Okuma replikası açılamadı, okumalar birincil veritabanından yapılacak: %s", replicaPath)
+	}
+
+	db := sql.OpenDB(connector)
+	db.SetConnMaxLifetime(replicaConnMaxLifetime)
+	go connector.monitor()
+
+	return db
+}
+
+// replicaDSN replika yolunu salt okunur SQLite URI'sine çevirir; file: ile başlayan yollar olduğu gibi kullanılır
+func replicaDSN(path string) string {
+	if strings.HasPrefix(path, "file:") {
+		return path
+	}
+	return "file:" + path + "?mode=ro"
+}
+
+// replicaConnector okuma bağlantılarını replika sağlıklıysa replikaya, değilse birincil veritabanına açar
+type replicaConnector struct {
+	replicaDSN string
+	primaryDSN string
+	healthy    atomic.Bool
+}
+
+func (c *replicaConnector) Connect(ctx context.Context) (driver.Conn, error) {
+	if c.healthy.Load() {
+		conn, err := c.open(c.replicaDSN)
+		if err == nil {
+			conn.valid = c.healthy.Load
+			return conn, nil
+		}
+		c.setHealthy(false, err)
+	}
+
+	conn, err := c.open(c.primaryDSN)
+	if err != nil {
+		return nil, err
+	}
+	conn.valid = func() bool { return !c.healthy.Load() }
+	return conn, nil
+}
+
+func (c *replicaConnector) Driver() driver.Driver {
+	return &metricsDriver{driver: sqliteDriver}
+}
+
+// open ölçümlü sqlite bağlantısı açar
+func (c *replicaConnector) open(dsn string) (*metricsConn, error) {
+	conn, err := sqliteDriver.Open(dsn)
+	if err != nil {
+		return nil, err
+	}
+	return &metricsConn{conn: conn}, nil
+}
+
+// check replikayı açıp şemasını okuyarak erişilebilirliğini günceller
+func (c *replicaConnector) check() {
+	conn, err := c.open(c.replicaDSN)
+	if err == nil {
+		var rows driver.Rows
+		rows, err = conn.QueryContext(context.Background(), "SELECT name FROM sqlite_master LIMIT 1", nil)
+		if err == nil {
+			rows.Close()
+		}
+		conn.Close()
+	}
+	c.setHealthy(err == nil, err)
+}
+
+// monitor replikanın erişilebilirliğini düzenli aralıklarla kontrol eder
+func (c *replicaConnector) monitor() {
+	ticker := time.NewTicker(replicaCheckInterval)
+	defer ticker.Stop()
+
+	for range ticker.C {
+		c.check()
+	}
+}
+
+// setHealthy replika durumunu günceller ve değişiklikleri günlüğe yazar
+func (c *replicaConnector) setHealthy(healthy bool, err error) {
+	if c.healthy.Swap(healthy) == healthy {
+		return
+	}
+	if healthy {
+		log.Println("✅ Okuma replikasına bağlanıldı")
+	} else {
+		log.Printf("⚠️ Okuma replikasına erişilemiyor, okumalar birincil veritabanından yapılacak: %v", err)
+	}
+}
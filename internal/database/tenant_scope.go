@@ -0,0 +1,280 @@
+package database
+
+import (
+	"database/sql"
+	"errors"
+	"log"
+	"os"
+	"regexp"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"agri-management-api/internal/models"
+)
+
+// Kiracı kapsamı koruma modları (TENANT_SCOPE_GUARD)
+const (
+	// TenantScopeOff koruma kapalı
+	TenantScopeOff = "off"
+	// TenantScopeLog kapsamsız sorgular çalıştırılır, günlüğe yazılır ve denetim raporuna eklenir
+	TenantScopeLog = "log"
+	// TenantScopeEnforce kapsamsız sorgular çalıştırılmadan reddedilir
+	TenantScopeEnforce = "enforce"
+)
+
+// tenantScopeViolationCapacity denetim raporunda tutulan farklı kapsamsız sorgu sayısı
+const tenantScopeViolationCapacity = 200
+
+// ErrUnscopedQuery kullanıcıya ait tabloya kullanıcı kapsamı olmadan sorgu gönderildi
+var ErrUnscopedQuery = errors.New("query on tenant table without user scope predicate")
+
+var (
+	// tenantTablePattern sorgunun okuduğu veya değiştirdiği tabloları yakalar
+	tenantTablePattern = regexp.MustCompile(`(?i)\b(?:FROM|JOIN|UPDATE|INTO)\s+([a-z_][a-z0-9_]*)`)
+	// tenantScopePattern kullanıcı kapsamı koşulunu (user_id = ?, l.user_id IN (...)) yakalar
+	tenantScopePattern = regexp.MustCompile(`(?i)\buser_id\s*(?:=|IN\s*\()`)
+	// tenantScopeRootTables kiracının kendisini temsil eden tablolar; satırlar çiftlik kimliğiyle (id) okunur
+	tenantScopeRootTables = map[string]bool{"farms": true}
+	// tenantScopeCredentialTables kimlik doğrulamada anahtarla okunan tablolar; çiftlik anahtardan bulunur.
+	// Muhasebeci erişimleri muhasebecinin hesabıyla, herkese açık profiller profil adresiyle, belge imzaları
+	// belgenin özetiyle okunarak çiftlik bulunur
+	tenantScopeCredentialTables = map[string]bool{"integration_keys": true, "weather_stations": true, "accountant_access": true, "farm_public_profiles": true, "document_signatures": true}
+	// tenantScopeAccountTables hesap bazında (account_id = ?) sorgulanabilen tablolar; danışman kullanımı ve
+	// bütçesi hesabın tüm çiftliklerindeki mesajlar üzerinden hesaplanır
+	tenantScopeAccountTables = map[string]bool{"advisor_messages": true}
+	// tenantScopeAccountPattern hesap kapsamı koşulunu (account_id = ?) yakalar
+	tenantScopeAccountPattern = regexp.MustCompile(`(?i)\baccount_id\s*=`)
+	// tenantScopeExemptPaths sistem yöneticisi uç noktaları bilerek tüm çiftlikleri sorgular; sensör ölçümü ve
+	// gelen e-posta uç noktaları çiftliği isteğin taşıdığı anahtardan bulur, sonraki sorgular bu çiftlikle kapsanır
+	tenantScopeExemptPaths = []string{"/api/v1/admin/", "/api/v1/sensors/readings", "/api/v1/inbound/email"}
+)
+
+// tenantScope kullanıcıya ait tablolar ve görülen kapsamsız sorgular
+var tenantScope = struct {
+	mode string
+
+	mu         sync.RWMutex
+	tables     map[string]bool
+	violations map[string]*models.TenantScopeViolation
+}{mode: TenantScopeLog, violations: map[string]*models.TenantScopeViolation{}}
+
+// tenantScopeMode koruma modunu TENANT_SCOPE_GUARD ortam değişkeninden okur; varsayılan log
+func tenantScopeMode() string {
+	switch mode := strings.ToLower(os.Getenv("TENANT_SCOPE_GUARD")); mode {
+	case TenantScopeOff, TenantScopeEnforce:
+		return mode
+	default:
+		return TenantScopeLog
+	}
+}
+
+// loadTenantTables şemadan kullanıcıya ait tabloları belirler: user_id sütunu olan tablolar (farms ve anahtar tabloları hariç) ile
+// user_id sütunu olmayıp bu tablolara yabancı anahtarla bağlı alt tablolar (ör. milk_production → livestock).
+// Alt tablolar üst tabloyla birleştirilip üst tablonun user_id koşuluyla sorgulanmalıdır
+func loadTenantTables(db *sql.DB) error {
+	rows, err := db.Query("SELECT name FROM sqlite_master WHERE type = 'table' AND name NOT LIKE 'sqlite_%'")
+	if err != nil {
+		return err
+	}
+	var names []string
+	for rows.Next() {
+		var name string
+		if err := rows.Scan(&name); err != nil {
+			rows.Close()
+			return err
+		}
+		names = append(names, name)
+	}
+	rows.Close()
+
+	owned := map[string]bool{}
+	parents := map[string][]string{}
+	for _, name := range names {
+		if tenantScopeRootTables[name] || tenantScopeCredentialTables[name] {
+			continue
+		}
+		columns, err := tableColumns(db, name)
+		if err != nil {
+			return err
+		}
+		if columns["user_id"] {
+			owned[name] = true
+			continue
+		}
+
+		fkRows, err := db.Query("SELECT \"table\" FROM pragma_foreign_key_list(?)", name)
+		if err != nil {
+			return err
+		}
+		for fkRows.Next() {
+			var parent string
+			if err := fkRows.Scan(&parent); err != nil {
+				fkRows.Close()
+				return err
+			}
+			parents[name] = append(parents[name], parent)
+		}
+		fkRows.Close()
+	}
+
+	tables := map[string]bool{}
+	for name := range owned {
+		tables[name] = true
+	}
+	for name, refs := range parents {
+		for _, parent := range refs {
+			if owned[parent] {
+				tables[name] = true
+			}
+		}
+	}
+
+	tenantScope.mu.Lock()
+	tenantScope.tables = tables
+	tenantScope.mu.Unlock()
+	return nil
+}
+
+// tableColumns tablonun sütun adlarını döner
+func tableColumns(db *sql.DB, table string) (map[string]bool, error) {
+	rows, err := db.Query("SELECT name FROM pragma_table_info(?)", table)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	columns := map[string]bool{}
+	for rows.Next() {
+		var name string
+		if err := rows.Scan(&name); err != nil {
+			return nil, err
+		}
+		columns[name] = true
+	}
+	return columns, rows.Err()
+}
+
+// checkTenantScope HTTP isteği içinde kullanıcıya ait tabloya user_id koşulu olmadan gönderilen sorguyu
+// denetim raporuna ekler; enforce modunda ErrUnscopedQuery döner. Arka plan işleri, yönetici uç noktaları
+// ve yalnızca VALUES ile yapılan eklemeler denetlenmez
+func checkTenantScope(query string) error {
+	if tenantScope.mode == TenantScopeOff {
+		return nil
+	}
+	timing := currentRequest()
+	if timing == nil || tenantScopeExempt(timing.endpoint) {
+		return nil
+	}
+
+	tables := unscopedTenantTables(query)
+	if len(tables) == 0 {
+		return nil
+	}
+
+	recordTenantScopeViolation(timing.endpoint, query, tables)
+	if tenantScope.mode == TenantScopeEnforce {
+		return ErrUnscopedQuery
+	}
+	return nil
+}
+
+// tenantScopeExempt endpoint'in (METHOD /yol) denetim dışında olup olmadığını döner
+func tenantScopeExempt(endpoint string) bool {
+	_, path, _ := strings.Cut(endpoint, " ")
+	for _, prefix := range tenantScopeExemptPaths {
+		if strings.HasPrefix(path, prefix) {
+			return true
+		}
+	}
+	return false
+}
+
+// unscopedTenantTables sorgu kullanıcı kapsamı içermiyorsa sorgunun eriştiği kullanıcıya ait tabloları döner;
+// hesap kapsamıyla sorgulanabilen tablolar account_id koşulu varsa sayılmaz
+func unscopedTenantTables(query string) []string {
+	upper := strings.ToUpper(strings.TrimSpace(query))
+	if strings.HasPrefix(upper, "INSERT") && !strings.Contains(upper, "SELECT") {
+		return nil
+	}
+	if tenantScopePattern.MatchString(query) {
+		return nil
+	}
+
+	tenantScope.mu.RLock()
+	defer tenantScope.mu.RUnlock()
+
+	accountScoped := tenantScopeAccountPattern.MatchString(query)
+
+	var tables []string
+	seen := map[string]bool{}
+	for _, match := range tenantTablePattern.FindAllStringSubmatch(query, -1) {
+		table := strings.ToLower(match[1])
+		if accountScoped && tenantScopeAccountTables[table] {
+			continue
+		}
+		if tenantScope.tables[table] && !seen[table] {
+			seen[table] = true
+			tables = append(tables, table)
+		}
+	}
+	return tables
+}
+
+// recordTenantScopeViolation kapsamsız sorguyu endpoint ve sorgu metnine göre sayar; ilk görülüşünde günlüğe yazar
+func recordTenantScopeViolation(endpoint, query string, tables []string) {
+	normalized := normalizeQuery(query)
+	key := endpoint + "\x00" + normalized
+
+	tenantScope.mu.Lock()
+	defer tenantScope.mu.Unlock()
+
+	if violation, ok := tenantScope.violations[key]; ok {
+		violation.Count++
+		violation.LastSeenAt = time.Now()
+		return
+	}
+
+	log.Printf("🚫 Kullanıcı kapsamı olmayan sorgu (%s, tablolar: %s): %s", endpoint, strings.Join(tables, ", "), normalized)
+	if len(tenantScope.violations) >= tenantScopeViolationCapacity {
+		return
+	}
+	now := time.Now()
+	tenantScope.violations[key] = &models.TenantScopeViolation{
+		Endpoint:    endpoint,
+		Query:       normalized,
+		Tables:      tables,
+		Count:       1,
+		FirstSeenAt: now,
+		LastSeenAt:  now,
+	}
+}
+
+// TenantScopeAudit koruma modunu, kullanıcıya ait tabloları ve sunucu başladığından beri görülen kapsamsız
+// sorguları en sık görülenden başlayarak döner
+func TenantScopeAudit() models.TenantScopeReport {
+	tenantScope.mu.RLock()
+	defer tenantScope.mu.RUnlock()
+
+	report := models.TenantScopeReport{
+		Mode:         tenantScope.mode,
+		TenantTables: make([]string, 0, len(tenantScope.tables)),
+		Violations:   make([]models.TenantScopeViolation, 0, len(tenantScope.violations)),
+	}
+	for table := range tenantScope.tables {
+		report.TenantTables = append(report.TenantTables, table)
+	}
+	sort.Strings(report.TenantTables)
+
+	for _, violation := range tenantScope.violations {
+		report.Violations = append(report.Violations, *violation)
+	}
+	sort.Slice(report.Violations, func(i, j int) bool {
+		if report.Violations[i].Count != report.Violations[j].Count {
+			return report.Violations[i].Count > report.Violations[j].Count
+		}
+		return report.Violations[i].Endpoint < report.Violations[j].Endpoint
+	})
+	return report
+}
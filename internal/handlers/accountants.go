@@ -0,0 +1,201 @@
+package handlers
+
+import (
+	"database/sql"
+	"errors"
+	"net/http"
+
+	"agri-management-api/internal/models"
+	"agri-management-api/internal/services"
+	"agri-management-api/internal/utils"
+
+	"github.com/gin-gonic/gin"
+)
+
+// AccountantHandler çiftliğin dış muhasebecilere verdiği salt okunur finans erişimini yönetir
+type AccountantHandler struct {
+	accountants *services.AccountantService
+}
+
+// NewAccountantHandler yeni accountant handler oluşturur
+func NewAccountantHandler(db *sql.DB) *AccountantHandler {
+	return &AccountantHandler{accountants: services.NewAccountantService(db)}
+}
+
+// GetAccountants çiftliğin muhasebeci erişimleri
+// @Summary Muhasebeci erişimleri
+// @Description Çiftliğin muhasebecilere verdiği erişimleri durumları (active, expired, revoked) ve son kullanım zamanlarıyla listeler
+// @ID getAccountants
+// @Tags Accountants
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {object} models.APIResponse{data=[]models.AccountantAccess}
+// @Failure 401 {object} models.APIResponse
+// @Router /accountants [get]
+func (h *AccountantHandler) GetAccountants(c *gin.Context) {
+	userID, err := utils.GetUserID(c)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusUnauthorized, "UNAUTHORIZED", "Kullanıcı kimliği doğrulanamadı", nil)
+		return
+	}
+
+	accesses, err := h.accountants.List(userID)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusInternalServerError, "DB_ERROR", "Muhasebeci erişimleri alınamadı", err.Error())
+		return
+	}
+
+	utils.SuccessResponse(c, accesses, "Muhasebeci erişimleri başarıyla getirildi")
+}
+
+// InviteAccountant muhasebeci davet etme
+// @Summary Muhasebeci davet et
+// @Description E-posta adresindeki hesaba çiftliğin finans kayıtlarını okuma ve raporları indirme erişimi verir. Muhasebeci kendi hesabıyla X-Farm-ID başlığında çiftliği seçer; diğer uç noktalar ve tüm değişiklik istekleri reddedilir
+// @ID inviteAccountant
+// @Tags Accountants
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param request body models.AccountantInviteRequest true "Muhasebeci e-posta adresi, erişim bitiş tarihi ve not"
+// @Success 201 {object} models.APIResponse{data=models.AccountantAccess}
+// @Failure 400 {object} models.APIResponse
+// @Failure 401 {object} models.APIResponse
+// @Failure 403 {object} models.APIResponse
+// @Failure 404 {object} models.APIResponse
+// @Failure 409 {object} models.APIResponse
+// @Router /accountants [post]
+func (h *AccountantHandler) InviteAccountant(c *gin.Context) {
+	userID, err := utils.GetUserID(c)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusUnauthorized, "UNAUTHORIZED", "Kullanıcı kimliği doğrulanamadı", nil)
+		return
+	}
+	accountID, _ := utils.GetAccountID(c)
+
+	var req models.AccountantInviteRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		utils.ErrorResponse(c, http.StatusBadRequest, "INVALID_REQUEST", "Geçersiz istek formatı", err.Error())
+		return
+	}
+
+	access, err := h.accountants.Invite(userID, accountID, req)
+	if err != nil {
+		writeAccountantError(c, err, "Muhasebeci davet edilemedi")
+		return
+	}
+
+	utils.CreatedResponse(c, access, "Muhasebeci erişimi başarıyla verildi")
+}
+
+// RevokeAccountant muhasebeci erişimini iptal etme
+// @Summary Muhasebeci erişimini iptal et
+// @Description Muhasebecinin erişimini hemen sonlandırır; erişim kaydı ve günlüğü saklanır
+// @ID revokeAccountant
+// @Tags Accountants
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Erişim ID"
+// @Success 200 {object} models.APIResponse{data=models.AccountantAccess}
+// @Failure 401 {object} models.APIResponse
+// @Failure 404 {object} models.APIResponse
+// @Router /accountants/{id} [delete]
+func (h *AccountantHandler) RevokeAccountant(c *gin.Context) {
+	userID, err := utils.GetUserID(c)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusUnauthorized, "UNAUTHORIZED", "Kullanıcı kimliği doğrulanamadı", nil)
+		return
+	}
+
+	access, err := h.accountants.Revoke(userID, c.Param("id"))
+	if err != nil {
+		writeAccountantError(c, err, "Muhasebeci erişimi iptal edilemedi")
+		return
+	}
+
+	utils.SuccessResponse(c, access, "Muhasebeci erişimi iptal edildi")
+}
+
+// GetAccountantAccessLog muhasebeci erişim günlüğü
+// @Summary Muhasebeci erişim günlüğü
+// @Description Muhasebecilerin çiftlikte yaptığı istekleri (reddedilenler dahil) yeniden eskiye listeler
+// @ID getAccountantAccessLog
+// @Tags Accountants
+// @Produce json
+// @Security BearerAuth
+// @Param accessId query string false "Erişim ID"
+// @Param startDate query string false "Başlangıç tarihi (YYYY-MM-DD)"
+// @Param endDate query string false "Bitiş tarihi (YYYY-MM-DD)"
+// @Param page query int false "Sayfa numarası"
+// @Param limit query int false "Sayfa başına kayıt"
+// @Success 200 {object} models.APIResponse{data=models.AccountantAccessLogPage}
+// @Failure 400 {object} models.APIResponse
+// @Failure 401 {object} models.APIResponse
+// @Router /accountants/access-log [get]
+func (h *AccountantHandler) GetAccountantAccessLog(c *gin.Context) {
+	userID, err := utils.GetUserID(c)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusUnauthorized, "UNAUTHORIZED", "Kullanıcı kimliği doğrulanamadı", nil)
+		return
+	}
+
+	startDate, endDate, ok := dateRangeQuery(c)
+	if !ok {
+		return
+	}
+	page, limit := utils.ParsePagination(c)
+
+	entries, total, err := h.accountants.AccessLog(userID, c.Query("accessId"), startDate, endDate, page, limit)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusInternalServerError, "DB_ERROR", "Erişim günlüğü alınamadı", err.Error())
+		return
+	}
+
+	utils.SuccessResponse(c, models.AccountantAccessLogPage{
+		Entries:    entries,
+		Pagination: utils.CalculatePagination(page, limit, total),
+	}, "Erişim günlüğü başarıyla getirildi")
+}
+
+// GetAccountantFarms muhasebecinin erişebildiği çiftlikler
+// @Summary Muhasebeci olarak erişilen çiftlikler
+// @Description Hesabın muhasebeci olarak etkin erişimi olan çiftlikleri listeler; finans uç noktalarında X-Farm-ID başlığına farmId yazılır
+// @ID getAccountantFarms
+// @Tags Accountants
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {object} models.APIResponse{data=[]models.AccountantAccess}
+// @Failure 401 {object} models.APIResponse
+// @Router /accountants/farms [get]
+func (h *AccountantHandler) GetAccountantFarms(c *gin.Context) {
+	accountID, err := utils.GetAccountID(c)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusUnauthorized, "UNAUTHORIZED", "Kullanıcı kimliği doğrulanamadı", nil)
+		return
+	}
+
+	farms, err := h.accountants.Farms(accountID)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusInternalServerError, "DB_ERROR", "Çiftlikler alınamadı", err.Error())
+		return
+	}
+
+	utils.SuccessResponse(c, farms, "Çiftlikler başarıyla getirildi")
+}
+
+// writeAccountantError servis hatasını HTTP yanıtına çevirir
+func writeAccountantError(c *gin.Context, err error, message string) {
+	switch {
+	case errors.Is(err, services.ErrAccountantAccessNotFound):
+		utils.ErrorResponse(c, http.StatusNotFound, "ACCESS_NOT_FOUND", "Etkin muhasebeci erişimi bulunamadı", nil)
+	case errors.Is(err, services.ErrAccountantNotFound):
+		utils.ErrorResponse(c, http.StatusNotFound, "USER_NOT_FOUND", "Bu e-posta adresine ait hesap bulunamadı", nil)
+	case errors.Is(err, services.ErrAccountantSelf):
+		utils.ErrorResponse(c, http.StatusBadRequest, "INVALID_ACCOUNTANT", err.Error(), nil)
+	case errors.Is(err, services.ErrAccountantExpiry):
+		utils.ErrorResponse(c, http.StatusBadRequest, "INVALID_EXPIRY", err.Error(), nil)
+	case errors.Is(err, services.ErrAccountantExists):
+		utils.ErrorResponse(c, http.StatusConflict, "ACCOUNTANT_EXISTS", "Bu muhasebecinin zaten etkin erişimi var", nil)
+	default:
+		utils.ErrorResponse(c, http.StatusInternalServerError, "DB_ERROR", message, err.Error())
+	}
+}
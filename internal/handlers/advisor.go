@@ -0,0 +1,184 @@
+package handlers
+
+import (
+	"database/sql"
+	"errors"
+	"net/http"
+	"strings"
+	"time"
+
+	"agri-management-api/internal/models"
+	"agri-management-api/internal/services"
+	"agri-management-api/internal/utils"
+
+	"github.com/gin-gonic/gin"
+)
+
+// AdvisorHandler yapay zeka destekli çiftlik danışmanını yönetir
+type AdvisorHandler struct {
+	db      *sql.DB
+	advisor *services.AdvisorService
+}
+
+// NewAdvisorHandler yeni advisor handler oluşturur
+func NewAdvisorHandler(db *sql.DB) *AdvisorHandler {
+	return &AdvisorHandler{
+		db:      db,
+		advisor: services.NewAdvisorService(db),
+	}
+}
+
+// Ask danışmana soru sorma
+// @Summary Danışmana soru sor
+// @Description Tarım ve çiftlik yönetimi sorusunu seçili çiftliğin özetiyle (hayvan sayıları, araziler ve ürünler, son 14 günün hava gözlemleri, gelir-gider toplamları) birlikte yapılandırılmış dil modeline sorar. conversationId verilirse konuşmanın son 10 mesajı da gönderilir; verilmezse yeni konuşma başlatılır. Hesap başına saatlik soru sınırı (429) ve aylık maliyet sınırı (402) uygulanır; sağlayıcı yapılandırılmamışsa 503 döner
+// @ID askAdvisor
+// @Tags Advisor
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param request body models.AdvisorAskRequest true "Soru"
+// @Success 200 {object} models.APIResponse{data=models.AdvisorAnswer}
+// @Failure 400 {object} models.APIResponse
+// @Failure 401 {object} models.APIResponse
+// @Failure 402 {object} models.APIResponse
+// @Failure 404 {object} models.APIResponse
+// @Failure 429 {object} models.APIResponse
+// @Failure 502 {object} models.APIResponse
+// @Failure 503 {object} models.APIResponse
+// @Router /advisor/ask [post]
+func (h *AdvisorHandler) Ask(c *gin.Context) {
+	userID, err := utils.GetUserID(c)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusUnauthorized, "UNAUTHORIZED", "Kullanıcı kimliği doğrulanamadı", nil)
+		return
+	}
+	accountID, _ := utils.GetAccountID(c)
+
+	var req models.AdvisorAskRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		utils.ErrorResponse(c, http.StatusBadRequest, "INVALID_REQUEST", "Geçersiz istek formatı", err.Error())
+		return
+	}
+	req.Question = strings.TrimSpace(req.Question)
+	if req.Question == "" {
+		utils.ErrorResponse(c, http.StatusBadRequest, "MISSING_QUESTION", "Soru gerekli", nil)
+		return
+	}
+	if len([]rune(req.Question)) > services.MaxAdvisorQuestionLength {
+		utils.ErrorResponse(c, http.StatusBadRequest, "QUESTION_TOO_LONG", "Soru en fazla 2000 karakter olabilir", nil)
+		return
+	}
+
+	answer, err := h.advisor.Ask(c.Request.Context(), userID, accountID, req.ConversationID, req.Question)
+	switch {
+	case err == nil:
+	case errors.Is(err, services.ErrAdvisorDisabled):
+		utils.ErrorResponse(c, http.StatusServiceUnavailable, "ADVISOR_UNAVAILABLE", "Danışman servisi yapılandırılmamış", nil)
+		return
+	case errors.Is(err, services.ErrAdvisorRateLimited):
+		usage, _ := h.advisor.Usage(accountID, time.Now())
+		utils.ErrorResponse(c, http.StatusTooManyRequests, "ADVISOR_RATE_LIMITED", "Saatlik soru sınırına ulaşıldı", usage)
+		return
+	case errors.Is(err, services.ErrAdvisorBudgetExceeded):
+		usage, _ := h.advisor.Usage(accountID, time.Now())
+		utils.ErrorResponse(c, http.StatusPaymentRequired, "ADVISOR_BUDGET_EXCEEDED", "Aylık danışman bütçesi doldu", usage)
+		return
+	case errors.Is(err, services.ErrAdvisorConversationNotFound):
+		utils.ErrorResponse(c, http.StatusNotFound, "CONVERSATION_NOT_FOUND", "Konuşma bulunamadı", nil)
+		return
+	default:
+		utils.ErrorResponse(c, http.StatusBadGateway, "ADVISOR_FAILED", "Danışman yanıt veremedi", err.Error())
+		return
+	}
+
+	utils.SuccessResponse(c, answer, "Yanıt başarıyla oluşturuldu")
+}
+
+// GetUsage danışman kullanım durumu
+// @Summary Danışman kullanım durumu
+// @Description Hesabın son bir saatteki soru sayısını, bu ayki maliyetini (USD) ve sınırlarını döner; sınır 0 ise uygulanmaz
+// @ID getAdvisorUsage
+// @Tags Advisor
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {object} models.APIResponse{data=models.AdvisorUsage}
+// @Failure 401 {object} models.APIResponse
+// @Router /advisor/usage [get]
+func (h *AdvisorHandler) GetUsage(c *gin.Context) {
+	accountID, err := utils.GetAccountID(c)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusUnauthorized, "UNAUTHORIZED", "Kullanıcı kimliği doğrulanamadı", nil)
+		return
+	}
+
+	usage, err := h.advisor.Usage(accountID, time.Now())
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusInternalServerError, "DB_ERROR", "Kullanım bilgisi getirilemedi", err.Error())
+		return
+	}
+
+	utils.SuccessResponse(c, usage, "Kullanım bilgisi başarıyla getirildi")
+}
+
+// GetConversations danışman konuşmaları
+// @Summary Danışman konuşmaları
+// @Description Oturum açan hesabın seçili çiftlikteki konuşmalarını son güncellenene göre listeler
+// @ID getAdvisorConversations
+// @Tags Advisor
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {object} models.APIResponse{data=[]models.AdvisorConversation}
+// @Failure 401 {object} models.APIResponse
+// @Router /advisor/conversations [get]
+func (h *AdvisorHandler) GetConversations(c *gin.Context) {
+	userID, err := utils.GetUserID(c)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusUnauthorized, "UNAUTHORIZED", "Kullanıcı kimliği doğrulanamadı", nil)
+		return
+	}
+	accountID, _ := utils.GetAccountID(c)
+
+	conversations, err := h.advisor.Conversations(userID, accountID)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusInternalServerError, "DB_ERROR", "Konuşmalar getirilemedi", err.Error())
+		return
+	}
+
+	utils.SuccessResponse(c, conversations, "Konuşmalar başarıyla getirildi")
+}
+
+// GetConversation danışman konuşması
+// @Summary Danışman konuşması
+// @Description Konuşmayı soru ve yanıtlarıyla, yanıtların token ve maliyet bilgisiyle birlikte döner
+// @ID getAdvisorConversation
+// @Tags Advisor
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Konuşma ID"
+// @Success 200 {object} models.APIResponse{data=models.AdvisorConversation}
+// @Failure 401 {object} models.APIResponse
+// @Failure 404 {object} models.APIResponse
+// @Router /advisor/conversations/{id} [get]
+func (h *AdvisorHandler) GetConversation(c *gin.Context) {
+	userID, err := utils.GetUserID(c)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusUnauthorized, "UNAUTHORIZED", "Kullanıcı kimliği doğrulanamadı", nil)
+		return
+	}
+	accountID, _ := utils.GetAccountID(c)
+
+	conversation, err := h.advisor.Conversation(userID, accountID, c.Param("id"))
+	if errors.Is(err, services.ErrAdvisorConversationNotFound) {
+		utils.ErrorResponse(c, http.StatusNotFound, "CONVERSATION_NOT_FOUND", "Konuşma bulunamadı", nil)
+		return
+	}
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusInternalServerError, "DB_ERROR", "Konuşma getirilemedi", err.Error())
+		return
+	}
+
+	utils.SuccessResponse(c, conversation, "Konuşma başarıyla getirildi")
+}
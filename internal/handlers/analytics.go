@@ -0,0 +1,293 @@
+package handlers
+
+import (
+	"database/sql"
+	"errors"
+	"log"
+	"net/http"
+	"strconv"
+	"time"
+
+	"agri-management-api/internal/models"
+	"agri-management-api/internal/services"
+	"agri-management-api/internal/utils"
+
+	"github.com/gin-gonic/gin"
+)
+
+// anomalyTitles anomali kontrollerinin bildirim başlıkları
+var anomalyTitles = map[string]string{
+	models.AnomalyExpenseSpike: "Olağandışı Gider Artışı",
+	models.AnomalyMilkDrop:     "Süt Üretiminde Düşüş",
+	models.AnomalyInactivity:   "Uzun Süredir Kayıt Girilmedi",
+}
+
+// AnalyticsHandler genel metrik analizlerini yönetir
+type AnalyticsHandler struct {
+	db            *sql.DB
+	timeSeries    *services.TimeSeriesService
+	anomalies     *services.AnomalyService
+	forecasts     *services.ForecastService
+	notifications *services.NotificationService
+}
+
+// NewAnalyticsHandler yeni analytics handler oluşturur; zaman serileri okuma veritabanından okunur
+func NewAnalyticsHandler(db, readDB *sql.DB) *AnalyticsHandler {
+	return &AnalyticsHandler{
+		db:            db,
+		timeSeries:    services.NewTimeSeriesService(readDB),
+		anomalies:     services.NewAnomalyService(db),
+		forecasts:     services.NewForecastService(db),
+		notifications: services.NewNotificationService(db),
+	}
+}
+
+// GetMetrics zaman serisi metrikleri
+// @Summary Zaman serisi metrikleri
+// @Description Zaman serisi olarak sorgulanabilen metrikleri birim, toplama yöntemi ve desteklenen filtrelerle listeler
+// @ID getTimeSeriesMetrics
+// @Tags Analytics
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {object} models.APIResponse{data=[]models.TimeSeriesMetric}
+// @Failure 401 {object} models.APIResponse
+// @Router /analytics/metrics [get]
+func (h *AnalyticsHandler) GetMetrics(c *gin.Context) {
+	if _, err := utils.GetUserID(c); err != nil {
+		utils.ErrorResponse(c, http.StatusUnauthorized, "UNAUTHORIZED", "Kullanıcı kimliği doğrulanamadı", nil)
+		return
+	}
+
+	utils.SuccessResponse(c, h.timeSeries.Metrics(), "Metrikler başarıyla getirildi")
+}
+
+// GetTimeSeries metrik zaman serisi
+// @Summary Metrik zaman serisi
+// @Description Metriği gün, hafta (ISO, pazartesi başlangıçlı), ay, çeyrek veya yıl kovalarına böler. Aralıktaki her kova kayıt olmasa da döner: sum metriklerinde boş kova 0, avg metriklerinde null'dır. from verilmezse to dahil son 12 kova gösterilir; metriğe özel filtreler (ör. livestockId, landId, category) sorgu parametresi olarak verilir
+// @ID getTimeSeries
+// @Tags Analytics
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param metric query string true "Metrik" Enums(milk_total, eggs_total, income_total, expense_total, livestock_weight_avg, fish_weight_avg, rainfall_total, herd_size, stock_value, cash_balance, total_area)
+// @Param bucket query string false "Kova" Enums(day, week, month, quarter, year) default(month)
+// @Param from query string false "Başlangıç tarihi (YYYY-MM-DD)"
+// @Param to query string false "Bitiş tarihi (YYYY-MM-DD, varsayılan: bugün)"
+// @Param livestockId query string false "Hayvan ID (milk_total, livestock_weight_avg)"
+// @Param landId query string false "Arazi ID (eggs_total, rainfall_total)"
+// @Param category query string false "İşlem kategorisi (income_total, expense_total)"
+// @Param type query string false "Hayvan türü (livestock_weight_avg)"
+// @Param fishBatchId query string false "Balık partisi ID (fish_weight_avg)"
+// @Success 200 {object} models.APIResponse{data=models.TimeSeries}
+// @Failure 400 {object} models.APIResponse
+// @Failure 401 {object} models.APIResponse
+// @Router /analytics/timeseries [get]
+func (h *AnalyticsHandler) GetTimeSeries(c *gin.Context) {
+	userID, err := utils.GetUserID(c)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusUnauthorized, "UNAUTHORIZED", "Kullanıcı kimliği doğrulanamadı", nil)
+		return
+	}
+
+	metric := c.Query("metric")
+	if metric == "" {
+		utils.ErrorResponse(c, http.StatusBadRequest, "MISSING_METRIC", "Metrik gerekli", nil)
+		return
+	}
+
+	from, ok := optionalDateQuery(c, "from")
+	if !ok {
+		return
+	}
+	to, ok := optionalDateQuery(c, "to")
+	if !ok {
+		return
+	}
+
+	filters := map[string]string{}
+	for name := range c.Request.URL.Query() {
+		filters[name] = c.Query(name)
+	}
+
+	bucket := c.DefaultQuery("bucket", models.BucketMonth)
+	var series models.TimeSeries
+	start, end, err := h.timeSeries.DefaultRange(bucket, from, to)
+	if err == nil {
+		series, err = h.timeSeries.Series(userID, metric, bucket, start, end, filters)
+	}
+	switch {
+	case err == nil:
+	case errors.Is(err, services.ErrUnknownMetric):
+		utils.ErrorResponse(c, http.StatusBadRequest, "UNKNOWN_METRIC", "Tanımsız metrik", metric)
+		return
+	case errors.Is(err, services.ErrInvalidBucket):
+		utils.ErrorResponse(c, http.StatusBadRequest, "INVALID_BUCKET", "Geçersiz kova türü", bucket)
+		return
+	case errors.Is(err, services.ErrInvalidTimeSeriesRange):
+		utils.ErrorResponse(c, http.StatusBadRequest, "INVALID_DATE_RANGE", "Geçersiz tarih aralığı", err.Error())
+		return
+	default:
+		utils.ErrorResponse(c, http.StatusInternalServerError, "DB_ERROR", "Zaman serisi hesaplanamadı", err.Error())
+		return
+	}
+
+	utils.SuccessResponse(c, series, "Zaman serisi başarıyla getirildi")
+}
+
+// GetForecast metrik tahmini
+// @Summary Metrik tahmini
+// @Description İçinde bulunulan aydan başlayarak aylık gelir, gider veya süt üretimi tahmini üretir. 24 aydan uzun geçmişte Holt-Winters, daha kısa geçmişte 3 aylık hareketli ortalama kullanılır; lower/upper %95 güven aralığıdır. Her ayın ilk tahmini saklanır ve ay gerçekleştiğinde accuracy alanında gerçek değerle karşılaştırılır
+// @ID getForecast
+// @Tags Analytics
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param metric query string true "Metrik" Enums(income_total, expense_total, milk_total)
+// @Param horizon query int false "Tahmin edilecek ay sayısı (1-12)" default(3)
+// @Success 200 {object} models.APIResponse{data=models.ForecastSeries}
+// @Failure 400 {object} models.APIResponse
+// @Failure 401 {object} models.APIResponse
+// @Router /analytics/forecast [get]
+func (h *AnalyticsHandler) GetForecast(c *gin.Context) {
+	userID, err := utils.GetUserID(c)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusUnauthorized, "UNAUTHORIZED", "Kullanıcı kimliği doğrulanamadı", nil)
+		return
+	}
+
+	horizon, err := strconv.Atoi(c.DefaultQuery("horizon", "3"))
+	if err != nil || horizon < 1 || horizon > services.MaxForecastHorizon {
+		utils.ErrorResponse(c, http.StatusBadRequest, "INVALID_HORIZON", "Tahmin ayı sayısı 1-12 arasında olmalıdır", nil)
+		return
+	}
+
+	forecast, err := h.forecasts.Forecast(userID, c.Query("metric"), horizon, time.Now())
+	if errors.Is(err, services.ErrUnsupportedForecastMetric) {
+		utils.ErrorResponse(c, http.StatusBadRequest, "UNSUPPORTED_METRIC", "Bu metrik için tahmin yapılmıyor", c.Query("metric"))
+		return
+	}
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusInternalServerError, "DB_ERROR", "Tahmin hesaplanamadı", err.Error())
+		return
+	}
+
+	utils.SuccessResponse(c, forecast, "Tahmin başarıyla getirildi")
+}
+
+// GetAnomalies bulunan anomaliler
+// @Summary Metrik anomalileri
+// @Description Zamanlanmış kontrollerin bulduğu olağandışı değerleri (gider artışı, %20'den fazla süt düşüşü, 14 gün kayıt girilmemesi) yeniden eskiye listeler; link alanı ilgili zaman serisi sorgusudur
+// @ID getMetricAnomalies
+// @Tags Analytics
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param limit query int false "Limit (1-100)" default(20)
+// @Success 200 {object} models.APIResponse{data=[]models.MetricAnomaly}
+// @Failure 401 {object} models.APIResponse
+// @Router /analytics/anomalies [get]
+func (h *AnalyticsHandler) GetAnomalies(c *gin.Context) {
+	userID, err := utils.GetUserID(c)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusUnauthorized, "UNAUTHORIZED", "Kullanıcı kimliği doğrulanamadı", nil)
+		return
+	}
+
+	limit, err := strconv.Atoi(c.DefaultQuery("limit", "20"))
+	if err != nil || limit < 1 || limit > 100 {
+		limit = 20
+	}
+
+	anomalies, err := h.anomalies.List(userID, limit)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusInternalServerError, "DB_ERROR", "Anomaliler getirilemedi", err.Error())
+		return
+	}
+
+	utils.SuccessResponse(c, anomalies, "Anomaliler başarıyla getirildi")
+}
+
+// StartAnomalyChecks anomali kontrollerini saatlik olarak arka planda çalıştırır
+func (h *AnalyticsHandler) StartAnomalyChecks() {
+	go func() {
+		ticker := time.NewTicker(time.Hour)
+		defer ticker.Stop()
+
+		for {
+			if err := h.CheckAnomalies(); err != nil {
+				log.Printf("Anomali kontrolleri çalıştırılamadı: %v", err)
+			}
+			<-ticker.C
+		}
+	}()
+}
+
+// CheckAnomalies tüm çiftliklerde kontrolleri çalıştırır ve yeni anomaliler için tavsiye bildirimi gönderir
+func (h *AnalyticsHandler) CheckAnomalies() error {
+	owners, err := h.anomalies.Owners()
+	if err != nil {
+		return err
+	}
+
+	now := time.Now().UTC()
+	for _, farmID := range owners {
+		anomalies, err := h.anomalies.Detect(farmID, now)
+		if err != nil {
+			log.Printf("Anomali kontrolü başarısız (%s): %v", farmID, err)
+		}
+
+		var notifications []services.Notification
+		for _, anomaly := range anomalies {
+			var entity *models.RelatedEntity
+			if anomaly.Check != models.AnomalyInactivity {
+				entity = &models.RelatedEntity{Type: "metric", ID: anomaly.Metric, Name: anomalyTitles[anomaly.Check]}
+			}
+			notifications = append(notifications, services.Notification{
+				UserID:   farmID,
+				Title:    anomalyTitles[anomaly.Check],
+				Message:  anomaly.Message,
+				Type:     "info",
+				Priority: "medium",
+				Topic:    models.NotificationTopicMetricAnomaly,
+				Entity:   entity,
+			})
+		}
+		if _, err := h.notifications.CreateBatch(notifications); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// optionalDateQuery YYYY-MM-DD biçimindeki isteğe bağlı tarih parametresini okur; geçersizse 400 yanıtı yazar
+func optionalDateQuery(c *gin.Context, name string) (*time.Time, bool) {
+	value := c.Query(name)
+	if value == "" {
+		return nil, true
+	}
+	date, err := time.Parse("2006-01-02", value)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusBadRequest, "INVALID_DATE", "Geçersiz tarih", name)
+		return nil, false
+	}
+	return &date, true
+}
+
+// dateRangeQuery isteğe bağlı startDate ve endDate parametrelerini okur; geçersizse 400 yanıtı yazar
+func dateRangeQuery(c *gin.Context) (*time.Time, *time.Time, bool) {
+	startDate, ok := optionalDateQuery(c, "startDate")
+	if !ok {
+		return nil, nil, false
+	}
+	endDate, ok := optionalDateQuery(c, "endDate")
+	if !ok {
+		return nil, nil, false
+	}
+	if startDate != nil && endDate != nil && endDate.Before(*startDate) {
+		utils.ErrorResponse(c, http.StatusBadRequest, "INVALID_DATE_RANGE", "Bitiş tarihi başlangıç tarihinden önce olamaz", nil)
+		return nil, nil, false
+	}
+	return startDate, endDate, true
+}
@@ -0,0 +1,79 @@
+package handlers
+
+import (
+	"bytes"
+	"net/http"
+	"strings"
+	"time"
+
+	"agri-management-api/internal/models"
+	"agri-management-api/internal/services"
+	"agri-management-api/internal/utils"
+
+	"github.com/gin-gonic/gin"
+)
+
+// GetPassport hayvan pasaportu
+// @Summary Hayvan pasaportu (PDF)
+// @Description Fuar, satış ve denetimlerde kullanılmak üzere tek sayfalık, yazdırılabilir hayvan pasaportu üretir: son yüklenen fotoğraf (POST /media/photos), küpe numarası ve kimlik bilgileri, iki kuşak soy kütüğü (anne/baba küpe numaralarından sürüde bulunan atalar), aşı özeti ve sayfaya sığdığı kadar hareket geçmişi. Belge sunucu anahtarıyla imzalanır; ayrık imza X-Document-Signature başlığıyla döner ve POST /public/documents/verify ile doğrulanabilir. download=true ise dosya ek olarak indirilir
+// @ID getLivestockPassport
+// @Tags Livestock
+// @Produce application/pdf
+// @Security BearerAuth
+// @Param id path string true "Hayvan ID"
+// @Param download query bool false "Dosyayı indir (varsayılan: tarayıcıda aç)"
+// @Success 200 {file} file
+// @Failure 401 {object} models.APIResponse
+// @Failure 404 {object} models.APIResponse
+// @Router /livestock/{id}/passport.pdf [get]
+func (h *LivestockHandler) GetPassport(c *gin.Context) {
+	userID, err := utils.GetUserID(c)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusUnauthorized, "UNAUTHORIZED", "Kullanıcı kimliği doğrulanamadı", nil)
+		return
+	}
+
+	passport, err := h.passports.Passport(userID, c.Param("id"), time.Now())
+	switch err {
+	case nil:
+	case services.ErrPassportAnimalNotFound:
+		utils.ErrorResponse(c, http.StatusNotFound, "ANIMAL_NOT_FOUND", "Hayvan bulunamadı", nil)
+		return
+	default:
+		utils.ErrorResponse(c, http.StatusInternalServerError, "DB_ERROR", "Pasaport bilgileri alınamadı", err.Error())
+		return
+	}
+
+	var buf bytes.Buffer
+	if err := h.passports.WritePDF(&buf, userID, passport); err != nil {
+		utils.ErrorResponse(c, http.StatusInternalServerError, "PDF_ERROR", "Pasaport oluşturulamadı", err.Error())
+		return
+	}
+
+	filename := passportFilename(passport.Animal.TagNumber)
+	if !signExport(c, h.signatures, userID, models.DocumentTypeAnimalPassport, passport.Animal.ID, filename, "application/pdf", buf.Bytes()) {
+		return
+	}
+
+	disposition := "inline"
+	if c.Query("download") == "true" {
+		disposition = "attachment"
+	}
+	c.Header("Content-Disposition", disposition+"; filename="+filename)
+	c.Data(http.StatusOK, "application/pdf", buf.Bytes())
+}
+
+// passportFilename küpe numarasından başlığa güvenle yazılabilecek dosya adı üretir
+func passportFilename(tagNumber string) string {
+	return "pasaport-" + safeFilename(tagNumber) + ".pdf"
+}
+
+// safeFilename dosya adında güvenli olmayan karakterleri tireye çevirir
+func safeFilename(value string) string {
+	return strings.Map(func(r rune) rune {
+		if r >= 'a' && r <= 'z' || r >= 'A' && r <= 'Z' || r >= '0' && r <= '9' || r == '-' || r == '_' {
+			return r
+		}
+		return '-'
+	}, value)
+}
@@ -0,0 +1,1060 @@
+package handlers
+
+import (
+	"database/sql"
+	"net/http"
+	"sort"
+	"time"
+
+	"agri-management-api/internal/models"
+	"agri-management-api/internal/services"
+	"agri-management-api/internal/utils"
+
+	"github.com/gin-gonic/gin"
+)
+
+// AquacultureHandler havuzları, balık partilerini ve yem dönüşüm analizini yönetir
+type AquacultureHandler struct {
+	db *sql.DB
+}
+
+// NewAquacultureHandler yeni aquaculture handler oluşturur
+func NewAquacultureHandler(db *sql.DB) *AquacultureHandler {
+	return &AquacultureHandler{db: db}
+}
+
+// GetPonds havuz listesi
+// @Summary Havuz listesi
+// @Description Havuz ve kafesleri aktif parti sayısı, tahmini biyokütle ve stok yoğunluğuyla listeler
+// @ID getPonds
+// @Tags Aquaculture
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param status query string false "Havuz durumu (active, fallow, inactive)"
+// @Success 200 {object} models.APIResponse{data=[]models.Pond}
+// @Failure 401 {object} models.APIResponse
+// @Router /ponds [get]
+func (h *AquacultureHandler) GetPonds(c *gin.Context) {
+	userID, err := utils.GetUserID(c)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusUnauthorized, "UNAUTHORIZED", "Kullanıcı kimliği doğrulanamadı", nil)
+		return
+	}
+
+	query := pondSelect + " WHERE user_id = ?"
+	args := []interface{}{userID}
+	if status := c.Query("status"); status != "" {
+		query += " AND status = ?"
+		args = append(args, status)
+	}
+
+	rows, err := h.db.Query(query+" ORDER BY name", args...)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusInternalServerError, "DB_ERROR", "Havuzlar alınamadı", err.Error())
+		return
+	}
+
+	ponds := []models.Pond{}
+	for rows.Next() {
+		pond, err := scanPond(rows)
+		if err != nil {
+			continue
+		}
+		ponds = append(ponds, pond)
+	}
+	rows.Close()
+
+	for i := range ponds {
+		batches, err := h.queryBatches(" WHERE b.pond_id = ? AND b.user_id = ? AND b.status = ?", ponds[i].ID, userID, models.FishBatchActive)
+		if err != nil {
+			utils.ErrorResponse(c, http.StatusInternalServerError, "DB_ERROR", "Havuz partileri alınamadı", err.Error())
+			return
+		}
+		summarizePond(&ponds[i], batches)
+	}
+
+	utils.SuccessResponse(c, ponds, "Havuzlar başarıyla getirildi")
+}
+
+// CreatePond yeni havuz
+// @Summary Yeni havuz
+// @Description Havuz, kafes veya tank ekler; landId verilirse havuz araziye bağlanır
+// @ID createPond
+// @Tags Aquaculture
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param request body models.PondRequest true "Havuz bilgileri"
+// @Success 201 {object} models.APIResponse{data=models.Pond}
+// @Failure 400 {object} models.APIResponse
+// @Failure 401 {object} models.APIResponse
+// @Failure 404 {object} models.APIResponse
+// @Router /ponds [post]
+func (h *AquacultureHandler) CreatePond(c *gin.Context) {
+	userID, err := utils.GetUserID(c)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusUnauthorized, "UNAUTHORIZED", "Kullanıcı kimliği doğrulanamadı", nil)
+		return
+	}
+
+	var req models.PondRequest
+	if !h.bindPondRequest(c, userID, &req) {
+		return
+	}
+
+	pondID := utils.GenerateID()
+	_, err = h.db.Exec(`
+		INSERT INTO ponds (id, user_id, name, land_id, pond_type, area, volume, water_source, status, notes, created_at, updated_at)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, CURRENT_TIMESTAMP, CURRENT_TIMESTAMP)
+	`, pondID, userID, req.Name, req.LandID, req.PondType, req.Area, req.Volume, req.WaterSource, req.Status, req.Notes)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusInternalServerError, "DB_ERROR", "Havuz oluşturulamadı", err.Error())
+		return
+	}
+
+	pond, err := h.getPond(pondID, userID)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusInternalServerError, "DB_ERROR", "Oluşturulan havuz alınamadı", err.Error())
+		return
+	}
+
+	utils.CreatedResponse(c, pond, "Havuz başarıyla oluşturuldu")
+}
+
+// GetPond havuz detayları
+// @Summary Havuz detayları
+// @Description Havuzu aktif balık partileri, biyokütle ve stok yoğunluğuyla getirir
+// @ID getPond
+// @Tags Aquaculture
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Havuz ID"
+// @Success 200 {object} models.APIResponse{data=models.Pond}
+// @Failure 401 {object} models.APIResponse
+// @Failure 404 {object} models.APIResponse
+// @Router /ponds/{id} [get]
+func (h *AquacultureHandler) GetPond(c *gin.Context) {
+	userID, err := utils.GetUserID(c)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusUnauthorized, "UNAUTHORIZED", "Kullanıcı kimliği doğrulanamadı", nil)
+		return
+	}
+
+	pond, err := h.getPond(c.Param("id"), userID)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusNotFound, "POND_NOT_FOUND", "Havuz bulunamadı", nil)
+		return
+	}
+
+	links := services.EntityLinks("pond", pond.ID, services.RelatedLink{Rel: "land", EntityType: "land", ID: pond.LandID})
+	utils.DetailResponse(c, pond, links, "Havuz detayları başarıyla getirildi")
+}
+
+// UpdatePond havuz güncelleme
+// @Summary Havuz güncelle
+// @Description Havuz bilgilerini ve durumunu günceller
+// @ID updatePond
+// @Tags Aquaculture
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Havuz ID"
+// @Param request body models.PondRequest true "Havuz bilgileri"
+// @Success 200 {object} models.APIResponse{data=models.Pond}
+// @Failure 400 {object} models.APIResponse
+// @Failure 401 {object} models.APIResponse
+// @Failure 404 {object} models.APIResponse
+// @Router /ponds/{id} [put]
+func (h *AquacultureHandler) UpdatePond(c *gin.Context) {
+	userID, err := utils.GetUserID(c)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusUnauthorized, "UNAUTHORIZED", "Kullanıcı kimliği doğrulanamadı", nil)
+		return
+	}
+
+	var req models.PondRequest
+	if !h.bindPondRequest(c, userID, &req) {
+		return
+	}
+
+	pondID := c.Param("id")
+	result, err := h.db.Exec(`
+		UPDATE ponds SET name = ?, land_id = ?, pond_type = ?, area = ?, volume = ?, water_source = ?, status = ?, notes = ?,
+		                 updated_at = CURRENT_TIMESTAMP
+		WHERE id = ? AND user_id = ?
+	`, req.Name, req.LandID, req.PondType, req.Area, req.Volume, req.WaterSource, req.Status, req.Notes, pondID, userID)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusInternalServerError, "UPDATE_ERROR", "Havuz güncellenemedi", err.Error())
+		return
+	}
+	if affected, _ := result.RowsAffected(); affected == 0 {
+		utils.ErrorResponse(c, http.StatusNotFound, "POND_NOT_FOUND", "Havuz bulunamadı", nil)
+		return
+	}
+
+	pond, err := h.getPond(pondID, userID)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusInternalServerError, "DB_ERROR", "Güncellenen havuz alınamadı", err.Error())
+		return
+	}
+
+	utils.SuccessResponse(c, pond, "Havuz başarıyla güncellendi")
+}
+
+// DeletePond havuz silme
+// @Summary Havuz sil
+// @Description Aktif partisi olmayan havuzu geçmiş partileri ve kayıtlarıyla birlikte siler; hasatlardan oluşan üretim kayıtları korunur
+// @ID deletePond
+// @Tags Aquaculture
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Havuz ID"
+// @Param force query bool false "Başka kayıtlarla bağlantısı olsa da sil"
+// @Success 200 {object} models.APIResponse
+// @Failure 401 {object} models.APIResponse
+// @Failure 404 {object} models.APIResponse
+// @Failure 409 {object} models.APIResponse
+// @Router /ponds/{id} [delete]
+func (h *AquacultureHandler) DeletePond(c *gin.Context) {
+	userID, err := utils.GetUserID(c)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusUnauthorized, "UNAUTHORIZED", "Kullanıcı kimliği doğrulanamadı", nil)
+		return
+	}
+
+	pondID := c.Param("id")
+
+	var activeBatches int
+	h.db.QueryRow("SELECT COUNT(*) FROM fish_batches WHERE pond_id = ? AND user_id = ? AND status = ?",
+		pondID, userID, models.FishBatchActive).Scan(&activeBatches)
+	if activeBatches > 0 {
+		utils.ErrorResponse(c, http.StatusConflict, "POND_HAS_ACTIVE_BATCHES", "Havuzda aktif balık partisi var", activeBatches)
+		return
+	}
+
+	if !confirmLinkedDelete(c, h.db, userID, "pond", pondID) {
+		return
+	}
+
+	tx, err := h.db.Begin()
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusInternalServerError, "DB_ERROR", "Havuz silinemedi", err.Error())
+		return
+	}
+	defer tx.Rollback()
+
+	result, err := tx.Exec("DELETE FROM ponds WHERE id = ? AND user_id = ?", pondID, userID)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusInternalServerError, "DELETE_ERROR", "Havuz silinemedi", err.Error())
+		return
+	}
+	if affected, _ := result.RowsAffected(); affected == 0 {
+		utils.ErrorResponse(c, http.StatusNotFound, "POND_NOT_FOUND", "Havuz bulunamadı", nil)
+		return
+	}
+
+	if _, err := tx.Exec("DELETE FROM fish_batch_records WHERE user_id = ? AND batch_id IN (SELECT id FROM fish_batches WHERE pond_id = ? AND user_id = ?)", userID, pondID, userID); err != nil {
+		utils.ErrorResponse(c, http.StatusInternalServerError, "DELETE_ERROR", "Havuz silinemedi", err.Error())
+		return
+	}
+	if _, err := tx.Exec("DELETE FROM fish_batches WHERE pond_id = ? AND user_id = ?", pondID, userID); err != nil {
+		utils.ErrorResponse(c, http.StatusInternalServerError, "DELETE_ERROR", "Havuz silinemedi", err.Error())
+		return
+	}
+
+	if err := tx.Commit(); err != nil {
+		utils.ErrorResponse(c, http.StatusInternalServerError, "DB_ERROR", "Havuz silinemedi", err.Error())
+		return
+	}
+
+	removeRecordLinks(h.db, userID, "pond", pondID)
+
+	utils.SuccessResponse(c, nil, "Havuz başarıyla silindi")
+}
+
+// GetPondBatches havuzun balık partileri
+// @Summary Havuz balık partileri
+// @Description Havuzun aktif ve hasat edilmiş balık partilerini stoklama tarihine göre listeler
+// @ID getPondBatches
+// @Tags Aquaculture
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Havuz ID"
+// @Param status query string false "Parti durumu (active, harvested)"
+// @Success 200 {object} models.APIResponse{data=[]models.FishBatch}
+// @Failure 401 {object} models.APIResponse
+// @Failure 404 {object} models.APIResponse
+// @Router /ponds/{id}/batches [get]
+func (h *AquacultureHandler) GetPondBatches(c *gin.Context) {
+	userID, err := utils.GetUserID(c)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusUnauthorized, "UNAUTHORIZED", "Kullanıcı kimliği doğrulanamadı", nil)
+		return
+	}
+
+	pond, ok := h.ownedPond(c, userID)
+	if !ok {
+		return
+	}
+
+	condition := " WHERE b.pond_id = ? AND b.user_id = ?"
+	args := []interface{}{pond.ID, userID}
+	if status := c.Query("status"); status != "" {
+		condition += " AND b.status = ?"
+		args = append(args, status)
+	}
+
+	batches, err := h.queryBatches(condition+" ORDER BY b.stocking_date DESC", args...)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusInternalServerError, "DB_ERROR", "Partiler alınamadı", err.Error())
+		return
+	}
+
+	utils.SuccessResponse(c, batches, "Partiler başarıyla getirildi")
+}
+
+// CreateFishBatch balık stoklama
+// @Summary Balık partisi stokla
+// @Description Havuza tür, adet ve ortalama ağırlığı (g) verilen yeni balık partisi stoklar
+// @ID createFishBatch
+// @Tags Aquaculture
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Havuz ID"
+// @Param request body models.FishBatchRequest true "Parti bilgileri"
+// @Success 201 {object} models.APIResponse{data=models.FishBatch}
+// @Failure 400 {object} models.APIResponse
+// @Failure 401 {object} models.APIResponse
+// @Failure 404 {object} models.APIResponse
+// @Router /ponds/{id}/batches [post]
+func (h *AquacultureHandler) CreateFishBatch(c *gin.Context) {
+	userID, err := utils.GetUserID(c)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusUnauthorized, "UNAUTHORIZED", "Kullanıcı kimliği doğrulanamadı", nil)
+		return
+	}
+
+	var req models.FishBatchRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		utils.ErrorResponse(c, http.StatusBadRequest, "INVALID_REQUEST", "Geçersiz istek formatı", err.Error())
+		return
+	}
+
+	pond, ok := h.ownedPond(c, userID)
+	if !ok {
+		return
+	}
+
+	stockingDate := time.Now()
+	if req.StockingDate != nil {
+		stockingDate = *req.StockingDate
+	}
+
+	batchID := utils.GenerateID()
+	_, err = h.db.Exec(`
+		INSERT INTO fish_batches (id, user_id, pond_id, species, stocking_date, initial_count, initial_avg_weight,
+		                          status, notes, created_at, updated_at)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, CURRENT_TIMESTAMP, CURRENT_TIMESTAMP)
+	`, batchID, userID, pond.ID, req.Species, stockingDate, req.InitialCount, req.InitialAvgWeight, models.FishBatchActive, req.Notes)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusInternalServerError, "DB_ERROR", "Parti oluşturulamadı", err.Error())
+		return
+	}
+
+	batch, err := h.getBatch(batchID, userID)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusInternalServerError, "DB_ERROR", "Oluşturulan parti alınamadı", err.Error())
+		return
+	}
+
+	utils.CreatedResponse(c, batch, "Parti başarıyla stoklandı")
+}
+
+// GetFishBatch balık partisi detayları
+// @Summary Balık partisi detayları
+// @Description Partiyi kalan adet, ortalama ağırlık, biyokütle, verilen yem ve yem dönüşüm oranıyla getirir
+// @ID getFishBatch
+// @Tags Aquaculture
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Parti ID"
+// @Success 200 {object} models.APIResponse{data=models.FishBatch}
+// @Failure 401 {object} models.APIResponse
+// @Failure 404 {object} models.APIResponse
+// @Router /fish-batches/{id} [get]
+func (h *AquacultureHandler) GetFishBatch(c *gin.Context) {
+	userID, err := utils.GetUserID(c)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusUnauthorized, "UNAUTHORIZED", "Kullanıcı kimliği doğrulanamadı", nil)
+		return
+	}
+
+	batch, err := h.getBatch(c.Param("id"), userID)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusNotFound, "BATCH_NOT_FOUND", "Parti bulunamadı", nil)
+		return
+	}
+
+	links := services.EntityLinks("fish_batch", batch.ID,
+		services.RelatedLink{Rel: "pond", EntityType: "pond", ID: &batch.PondID},
+		services.RelatedLink{Rel: "production", EntityType: "production", ID: batch.ProductionID},
+	)
+	utils.DetailResponse(c, batch, links, "Parti detayları başarıyla getirildi")
+}
+
+// DeleteFishBatch balık partisi silme
+// @Summary Balık partisi sil
+// @Description Partiyi kayıtlarıyla birlikte siler; hasattan oluşan üretim kaydı korunur
+// @ID deleteFishBatch
+// @Tags Aquaculture
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Parti ID"
+// @Param force query bool false "Başka kayıtlarla bağlantısı olsa da sil"
+// @Success 200 {object} models.APIResponse
+// @Failure 401 {object} models.APIResponse
+// @Failure 404 {object} models.APIResponse
+// @Failure 409 {object} models.APIResponse
+// @Router /fish-batches/{id} [delete]
+func (h *AquacultureHandler) DeleteFishBatch(c *gin.Context) {
+	userID, err := utils.GetUserID(c)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusUnauthorized, "UNAUTHORIZED", "Kullanıcı kimliği doğrulanamadı", nil)
+		return
+	}
+
+	batchID := c.Param("id")
+
+	if !confirmLinkedDelete(c, h.db, userID, "fish_batch", batchID) {
+		return
+	}
+
+	tx, err := h.db.Begin()
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusInternalServerError, "DB_ERROR", "Parti silinemedi", err.Error())
+		return
+	}
+	defer tx.Rollback()
+
+	result, err := tx.Exec("DELETE FROM fish_batches WHERE id = ? AND user_id = ?", batchID, userID)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusInternalServerError, "DELETE_ERROR", "Parti silinemedi", err.Error())
+		return
+	}
+	if affected, _ := result.RowsAffected(); affected == 0 {
+		utils.ErrorResponse(c, http.StatusNotFound, "BATCH_NOT_FOUND", "Parti bulunamadı", nil)
+		return
+	}
+	if _, err := tx.Exec("DELETE FROM fish_batch_records WHERE batch_id = ? AND user_id = ?", batchID, userID); err != nil {
+		utils.ErrorResponse(c, http.StatusInternalServerError, "DELETE_ERROR", "Parti silinemedi", err.Error())
+		return
+	}
+
+	if err := tx.Commit(); err != nil {
+		utils.ErrorResponse(c, http.StatusInternalServerError, "DB_ERROR", "Parti silinemedi", err.Error())
+		return
+	}
+
+	removeRecordLinks(h.db, userID, "fish_batch", batchID)
+
+	utils.SuccessResponse(c, nil, "Parti başarıyla silindi")
+}
+
+// GetFishBatchRecords parti kayıtları
+// @Summary Balık partisi kayıtları
+// @Description Partinin ölüm, yemleme ve tartım örneklemesi kayıtlarını en yeniden eskiye listeler
+// @ID getFishBatchRecords
+// @Tags Aquaculture
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Parti ID"
+// @Param recordType query string false "Kayıt türü (mortality, feeding, sampling)"
+// @Success 200 {object} models.APIResponse{data=[]models.FishBatchRecord}
+// @Failure 401 {object} models.APIResponse
+// @Failure 404 {object} models.APIResponse
+// @Router /fish-batches/{id}/records [get]
+func (h *AquacultureHandler) GetFishBatchRecords(c *gin.Context) {
+	userID, err := utils.GetUserID(c)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusUnauthorized, "UNAUTHORIZED", "Kullanıcı kimliği doğrulanamadı", nil)
+		return
+	}
+
+	batch, err := h.getBatch(c.Param("id"), userID)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusNotFound, "BATCH_NOT_FOUND", "Parti bulunamadı", nil)
+		return
+	}
+
+	query := fishBatchRecordSelect + " WHERE batch_id = ? AND user_id = ?"
+	args := []interface{}{batch.ID, userID}
+	if recordType := c.Query("recordType"); recordType != "" {
+		query += " AND record_type = ?"
+		args = append(args, recordType)
+	}
+
+	rows, err := h.db.Query(query+" ORDER BY record_date DESC", args...)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusInternalServerError, "DB_ERROR", "Kayıtlar alınamadı", err.Error())
+		return
+	}
+	defer rows.Close()
+
+	records := []models.FishBatchRecord{}
+	for rows.Next() {
+		record, err := scanFishBatchRecord(rows)
+		if err != nil {
+			continue
+		}
+		records = append(records, record)
+	}
+
+	utils.SuccessResponse(c, records, "Kayıtlar başarıyla getirildi")
+}
+
+// CreateFishBatchRecord parti kaydı ekleme
+// @Summary Balık partisi kaydı ekle
+// @Description Aktif partiye ölüm (count), yemleme (feedKg, feedType, cost) veya tartım örneklemesi (avgWeight, g) kaydı ekler; ölüm kalan adedi aşamaz
+// @ID createFishBatchRecord
+// @Tags Aquaculture
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Parti ID"
+// @Param request body models.FishBatchRecordRequest true "Kayıt bilgileri"
+// @Success 201 {object} models.APIResponse{data=models.FishBatch}
+// @Failure 400 {object} models.APIResponse
+// @Failure 401 {object} models.APIResponse
+// @Failure 404 {object} models.APIResponse
+// @Failure 409 {object} models.APIResponse
+// @Router /fish-batches/{id}/records [post]
+func (h *AquacultureHandler) CreateFishBatchRecord(c *gin.Context) {
+	userID, err := utils.GetUserID(c)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusUnauthorized, "UNAUTHORIZED", "Kullanıcı kimliği doğrulanamadı", nil)
+		return
+	}
+
+	var req models.FishBatchRecordRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		utils.ErrorResponse(c, http.StatusBadRequest, "INVALID_REQUEST", "Geçersiz istek formatı", err.Error())
+		return
+	}
+
+	// Kayıt türüne göre gerekli alan denetimi
+	missing := (req.RecordType == models.FishRecordMortality && req.Count == nil) ||
+		(req.RecordType == models.FishRecordFeeding && req.FeedKg == nil) ||
+		(req.RecordType == models.FishRecordSampling && req.AvgWeight == nil)
+	if missing {
+		utils.ErrorResponse(c, http.StatusBadRequest, "MISSING_FIELDS", "Ölüm için count, yemleme için feedKg, örnekleme için avgWeight gerekli", nil)
+		return
+	}
+
+	batch, err := h.getBatch(c.Param("id"), userID)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusNotFound, "BATCH_NOT_FOUND", "Parti bulunamadı", nil)
+		return
+	}
+	if batch.Status != models.FishBatchActive {
+		utils.ErrorResponse(c, http.StatusConflict, "BATCH_HARVESTED", "Hasat edilmiş partiye kayıt eklenemez", nil)
+		return
+	}
+	if req.RecordType == models.FishRecordMortality && *req.Count > batch.CurrentCount {
+		utils.ErrorResponse(c, http.StatusConflict, "INSUFFICIENT_COUNT", "Ölüm sayısı partideki balık sayısını aşıyor", batch.CurrentCount)
+		return
+	}
+
+	recordDate := time.Now()
+	if req.RecordDate != nil {
+		recordDate = *req.RecordDate
+	}
+
+	_, err = h.db.Exec(`
+		INSERT INTO fish_batch_records (id, user_id, batch_id, record_type, record_date, count, feed_kg, feed_type,
+		                                cost, avg_weight, notes, created_at)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, CURRENT_TIMESTAMP)
+	`, utils.GenerateID(), userID, batch.ID, req.RecordType, recordDate, req.Count, req.FeedKg, req.FeedType,
+		req.Cost, req.AvgWeight, req.Notes)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusInternalServerError, "DB_ERROR", "Kayıt eklenemedi", err.Error())
+		return
+	}
+
+	batch, err = h.getBatch(batch.ID, userID)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusInternalServerError, "DB_ERROR", "Güncel parti alınamadı", err.Error())
+		return
+	}
+
+	utils.CreatedResponse(c, batch, "Kayıt başarıyla eklendi")
+}
+
+// DeleteFishBatchRecord parti kaydı silme
+// @Summary Balık partisi kaydını sil
+// @Description Partinin ölüm, yemleme veya örnekleme kaydını siler
+// @ID deleteFishBatchRecord
+// @Tags Aquaculture
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Parti ID"
+// @Param recordId path string true "Kayıt ID"
+// @Success 200 {object} models.APIResponse
+// @Failure 401 {object} models.APIResponse
+// @Failure 404 {object} models.APIResponse
+// @Router /fish-batches/{id}/records/{recordId} [delete]
+func (h *AquacultureHandler) DeleteFishBatchRecord(c *gin.Context) {
+	userID, err := utils.GetUserID(c)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusUnauthorized, "UNAUTHORIZED", "Kullanıcı kimliği doğrulanamadı", nil)
+		return
+	}
+
+	result, err := h.db.Exec("DELETE FROM fish_batch_records WHERE id = ? AND batch_id = ? AND user_id = ?",
+		c.Param("recordId"), c.Param("id"), userID)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusInternalServerError, "DELETE_ERROR", "Kayıt silinemedi", err.Error())
+		return
+	}
+	if affected, _ := result.RowsAffected(); affected == 0 {
+		utils.ErrorResponse(c, http.StatusNotFound, "RECORD_NOT_FOUND", "Kayıt bulunamadı", nil)
+		return
+	}
+
+	utils.SuccessResponse(c, nil, "Kayıt başarıyla silindi")
+}
+
+// HarvestFishBatch parti hasadı
+// @Summary Balık partisini hasat et
+// @Description Partiyi hasat edilmiş olarak kapatır ve hasat ağırlığı kadar üretim kaydı (kg) oluşturur; adet verilmezse kalan balık sayısı kullanılır
+// @ID harvestFishBatch
+// @Tags Aquaculture
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Parti ID"
+// @Param request body models.FishBatchHarvestRequest true "Hasat bilgileri"
+// @Success 200 {object} models.APIResponse{data=models.FishBatchHarvestResult}
+// @Failure 400 {object} models.APIResponse
+// @Failure 401 {object} models.APIResponse
+// @Failure 404 {object} models.APIResponse
+// @Failure 409 {object} models.APIResponse
+// @Router /fish-batches/{id}/harvest [post]
+func (h *AquacultureHandler) HarvestFishBatch(c *gin.Context) {
+	userID, err := utils.GetUserID(c)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusUnauthorized, "UNAUTHORIZED", "Kullanıcı kimliği doğrulanamadı", nil)
+		return
+	}
+
+	var req models.FishBatchHarvestRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		utils.ErrorResponse(c, http.StatusBadRequest, "INVALID_REQUEST", "Geçersiz istek formatı", err.Error())
+		return
+	}
+
+	batch, err := h.getBatch(c.Param("id"), userID)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusNotFound, "BATCH_NOT_FOUND", "Parti bulunamadı", nil)
+		return
+	}
+	if batch.Status != models.FishBatchActive {
+		utils.ErrorResponse(c, http.StatusConflict, "BATCH_HARVESTED", "Parti zaten hasat edilmiş", nil)
+		return
+	}
+
+	count := batch.CurrentCount
+	if req.Count != nil {
+		if *req.Count > batch.CurrentCount {
+			utils.ErrorResponse(c, http.StatusConflict, "INSUFFICIENT_COUNT", "Hasat adedi partideki balık sayısını aşıyor", batch.CurrentCount)
+			return
+		}
+		count = *req.Count
+	}
+
+	harvestDate := time.Now()
+	if req.HarvestDate != nil {
+		harvestDate = *req.HarvestDate
+	}
+
+	var landID sql.NullString
+	h.db.QueryRow("SELECT land_id FROM ponds WHERE id = ? AND user_id = ?", batch.PondID, userID).Scan(&landID)
+
+	// Hasat maliyeti olarak kg başına yem gideri işlenir
+	var unitCost *float64
+	if batch.FeedCost > 0 {
+		cost := roundTo2(batch.FeedCost / req.Weight)
+		unitCost = &cost
+	}
+
+	tx, err := h.db.Begin()
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusInternalServerError, "DB_ERROR", "Hasat kaydedilemedi", err.Error())
+		return
+	}
+	defer tx.Rollback()
+
+	productionID := utils.GenerateID()
+	_, err = tx.Exec(`
+		INSERT INTO production (id, user_id, land_id, name, category, amount, unit, harvest_date,
+		                       quality, storage_location, status, unit_cost, notes, created_at, updated_at)
+		VALUES (?, ?, ?, ?, 'Su Ürünleri', ?, 'kg', ?, ?, ?, 'active', ?, ?, CURRENT_TIMESTAMP, CURRENT_TIMESTAMP)
+	`, productionID, userID, utils.NullStringToPtr(landID), batch.Species, req.Weight, harvestDate, req.Quality,
+		req.StorageLocation, unitCost, "Havuz: "+batch.PondName)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusInternalServerError, "DB_ERROR", "Üretim kaydı oluşturulamadı", err.Error())
+		return
+	}
+
+	result, err := tx.Exec(`
+		UPDATE fish_batches SET status = ?, harvest_date = ?, harvested_count = ?, harvested_weight = ?, production_id = ?,
+		                        notes = CASE WHEN ? = '' THEN notes ELSE ? END, updated_at = CURRENT_TIMESTAMP
+		WHERE id = ? AND user_id = ? AND status = ?
+	`, models.FishBatchHarvested, harvestDate, count, req.Weight, productionID, req.Notes, req.Notes, batch.ID, userID,
+		models.FishBatchActive)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusInternalServerError, "UPDATE_ERROR", "Hasat kaydedilemedi", err.Error())
+		return
+	}
+	if affected, _ := result.RowsAffected(); affected == 0 {
+		utils.ErrorResponse(c, http.StatusConflict, "BATCH_HARVESTED", "Parti zaten hasat edilmiş", nil)
+		return
+	}
+
+	if err := tx.Commit(); err != nil {
+		utils.ErrorResponse(c, http.StatusInternalServerError, "DB_ERROR", "Hasat kaydedilemedi", err.Error())
+		return
+	}
+
+	batch, err = h.getBatch(batch.ID, userID)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusInternalServerError, "DB_ERROR", "Hasat edilen parti alınamadı", err.Error())
+		return
+	}
+	production, err := scanProduction(h.db.QueryRow(productionSelect+" WHERE id = ? AND user_id = ?", productionID, userID))
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusInternalServerError, "DB_ERROR", "Oluşturulan üretim kaydı alınamadı", err.Error())
+		return
+	}
+
+	utils.SuccessResponse(c, models.FishBatchHarvestResult{Batch: batch, Production: production}, "Parti başarıyla hasat edildi")
+}
+
+// GetFeedConversion yem dönüşüm analizi
+// @Summary Yem dönüşüm oranı analizi
+// @Description Partilerin ve türlerin verilen yem, biyokütle artışı, yem dönüşüm oranı (FCR), kg artış başına yem maliyeti ve yaşama oranını getirir; tarih aralığı stoklama tarihine uygulanır
+// @ID getFeedConversion
+// @Tags Aquaculture
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param startDate query string false "Başlangıç tarihi (YYYY-MM-DD)"
+// @Param endDate query string false "Bitiş tarihi (YYYY-MM-DD)"
+// @Param species query string false "Tür"
+// @Param status query string false "Parti durumu (active, harvested)"
+// @Success 200 {object} models.APIResponse{data=models.FeedConversionReport}
+// @Failure 400 {object} models.APIResponse
+// @Failure 401 {object} models.APIResponse
+// @Router /ponds/feed-conversion [get]
+func (h *AquacultureHandler) GetFeedConversion(c *gin.Context) {
+	userID, err := utils.GetUserID(c)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusUnauthorized, "UNAUTHORIZED", "Kullanıcı kimliği doğrulanamadı", nil)
+		return
+	}
+
+	condition := " WHERE b.user_id = ?"
+	args := []interface{}{userID}
+	for _, filter := range []struct{ param, column string }{{"startDate", "b.stocking_date >= ?"}, {"endDate", "b.stocking_date < ?"}} {
+		value := c.Query(filter.param)
+		if value == "" {
+			continue
+		}
+		date, err := time.Parse("2006-01-02", value)
+		if err != nil {
+			utils.ErrorResponse(c, http.StatusBadRequest, "INVALID_DATE", "Geçersiz tarih formatı (YYYY-MM-DD)", value)
+			return
+		}
+		if filter.param == "endDate" {
+			date = date.AddDate(0, 0, 1)
+		}
+		condition += " AND " + filter.column
+		args = append(args, date)
+	}
+	if species := c.Query("species"); species != "" {
+		condition += " AND b.species = ?"
+		args = append(args, species)
+	}
+	if status := c.Query("status"); status != "" {
+		condition += " AND b.status = ?"
+		args = append(args, status)
+	}
+
+	batches, err := h.queryBatches(condition+" ORDER BY b.stocking_date DESC", args...)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusInternalServerError, "DB_ERROR", "Partiler alınamadı", err.Error())
+		return
+	}
+
+	report := models.FeedConversionReport{Batches: batches, BySpecies: []models.FeedConversionGroup{}}
+	groups := map[string]*models.FeedConversionGroup{}
+	for _, batch := range batches {
+		if groups[batch.Species] == nil {
+			groups[batch.Species] = &models.FeedConversionGroup{Species: batch.Species}
+		}
+		addFeedConversion(groups[batch.Species], batch)
+		addFeedConversion(&report.Overall, batch)
+	}
+	for _, group := range groups {
+		finishFeedConversion(group)
+		report.BySpecies = append(report.BySpecies, *group)
+	}
+	finishFeedConversion(&report.Overall)
+	sort.Slice(report.BySpecies, func(i, j int) bool { return report.BySpecies[i].Species < report.BySpecies[j].Species })
+
+	utils.SuccessResponse(c, report, "Yem dönüşüm analizi başarıyla getirildi")
+}
+
+// bindPondRequest havuz isteğini okur, varsayılanları uygular ve araziyi doğrular; hata varsa yanıtı yazar
+func (h *AquacultureHandler) bindPondRequest(c *gin.Context, userID string, req *models.PondRequest) bool {
+	if err := c.ShouldBindJSON(req); err != nil {
+		utils.ErrorResponse(c, http.StatusBadRequest, "INVALID_REQUEST", "Geçersiz istek formatı", err.Error())
+		return false
+	}
+	if req.Status == "" {
+		req.Status = "active"
+	}
+	if req.LandID != nil && *req.LandID == "" {
+		req.LandID = nil
+	}
+
+	if req.LandID != nil {
+		var exists bool
+		h.db.QueryRow("SELECT 1 FROM lands WHERE id = ? AND user_id = ?", *req.LandID, userID).Scan(&exists)
+		if !exists {
+			utils.ErrorResponse(c, http.StatusNotFound, "LAND_NOT_FOUND", "Arazi bulunamadı", nil)
+			return false
+		}
+	}
+	return true
+}
+
+// ownedPond yol parametresindeki havuzu getirir; bulunamazsa 404 yanıtını yazar
+func (h *AquacultureHandler) ownedPond(c *gin.Context, userID string) (models.Pond, bool) {
+	pond, err := scanPond(h.db.QueryRow(pondSelect+" WHERE id = ? AND user_id = ?", c.Param("id"), userID))
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusNotFound, "POND_NOT_FOUND", "Havuz bulunamadı", nil)
+		return pond, false
+	}
+	return pond, true
+}
+
+// getPond havuzu aktif partileri ve biyokütle özetiyle getirir
+func (h *AquacultureHandler) getPond(pondID, userID string) (models.Pond, error) {
+	pond, err := scanPond(h.db.QueryRow(pondSelect+" WHERE id = ? AND user_id = ?", pondID, userID))
+	if err != nil {
+		return pond, err
+	}
+
+	batches, err := h.queryBatches(" WHERE b.pond_id = ? AND b.user_id = ? AND b.status = ? ORDER BY b.stocking_date", pond.ID, userID, models.FishBatchActive)
+	if err != nil {
+		return pond, err
+	}
+	summarizePond(&pond, batches)
+	pond.Batches = batches
+	return pond, nil
+}
+
+// getBatch partiyi hesaplanmış göstergeleriyle getirir
+func (h *AquacultureHandler) getBatch(batchID, userID string) (models.FishBatch, error) {
+	batches, err := h.queryBatches(" WHERE b.id = ? AND b.user_id = ?", batchID, userID)
+	if err != nil {
+		return models.FishBatch{}, err
+	}
+	if len(batches) == 0 {
+		return models.FishBatch{}, sql.ErrNoRows
+	}
+	return batches[0], nil
+}
+
+// queryBatches fishBatchSelect ile verilen koşuldaki partileri getirir
+func (h *AquacultureHandler) queryBatches(condition string, args ...interface{}) ([]models.FishBatch, error) {
+	rows, err := h.db.Query(fishBatchSelect+condition, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	batches := []models.FishBatch{}
+	for rows.Next() {
+		batch, err := scanFishBatch(rows)
+		if err != nil {
+			return nil, err
+		}
+		batches = append(batches, batch)
+	}
+	return batches, rows.Err()
+}
+
+// summarizePond aktif partilerden havuzun biyokütlesini ve m³ başına stok yoğunluğunu hesaplar
+func summarizePond(pond *models.Pond, activeBatches []models.FishBatch) {
+	pond.ActiveBatches = len(activeBatches)
+	for _, batch := range activeBatches {
+		pond.Biomass += batch.Biomass
+	}
+	pond.Biomass = roundTo2(pond.Biomass)
+	if pond.Volume != nil && *pond.Volume > 0 {
+		density := roundTo2(pond.Biomass / *pond.Volume)
+		pond.StockingDensity = &density
+	}
+}
+
+// addFeedConversion partinin yem ve biyokütle artışını gruba ekler
+func addFeedConversion(group *models.FeedConversionGroup, batch models.FishBatch) {
+	group.Batches++
+	group.FeedGiven += batch.FeedGiven
+	group.FeedCost += batch.FeedCost
+	group.BiomassGain += batch.BiomassGain
+	group.AvgSurvivalRate += batch.SurvivalRate
+}
+
+// finishFeedConversion grup toplamlarından yem dönüşüm oranını ve ortalamaları hesaplar
+func finishFeedConversion(group *models.FeedConversionGroup) {
+	if group.Batches > 0 {
+		group.AvgSurvivalRate = roundTo2(group.AvgSurvivalRate / float64(group.Batches))
+	}
+	if group.BiomassGain > 0 && group.FeedGiven > 0 {
+		fcr := roundTo2(group.FeedGiven / group.BiomassGain)
+		group.FCR = &fcr
+	}
+	if group.BiomassGain > 0 && group.FeedCost > 0 {
+		costPerKg := roundTo2(group.FeedCost / group.BiomassGain)
+		group.FeedCostPerKg = &costPerKg
+	}
+	group.FeedGiven = roundTo2(group.FeedGiven)
+	group.FeedCost = roundTo2(group.FeedCost)
+	group.BiomassGain = roundTo2(group.BiomassGain)
+}
+
+// pondSelect havuz sorgularının ortak sütunları
+const pondSelect = `
+	SELECT id, user_id, name, land_id, COALESCE(pond_type, ''), area, volume, COALESCE(water_source, ''),
+	       COALESCE(status, 'active'), COALESCE(notes, ''), created_at, updated_at
+	FROM ponds`
+
+// scanPond pondSelect ile seçilen satırı havuza çevirir
+func scanPond(row interface{ Scan(...interface{}) error }) (models.Pond, error) {
+	var pond models.Pond
+	var landID sql.NullString
+	var area, volume sql.NullFloat64
+
+	err := row.Scan(&pond.ID, &pond.UserID, &pond.Name, &landID, &pond.PondType, &area, &volume, &pond.WaterSource,
+		&pond.Status, &pond.Notes, &pond.CreatedAt, &pond.UpdatedAt)
+	if err != nil {
+		return pond, err
+	}
+
+	pond.LandID = utils.NullStringToPtr(landID)
+	pond.Area = utils.NullFloat64ToPtr(area)
+	pond.Volume = utils.NullFloat64ToPtr(volume)
+	return pond, nil
+}
+
+// fishBatchSelect parti sorgularının ortak sütunları; ölüm, yem toplamları ve son örnekleme ağırlığı kayıtlardan alınır
+const fishBatchSelect = `
+	SELECT b.id, b.pond_id, p.name, b.species, b.stocking_date, b.initial_count, b.initial_avg_weight,
+	       COALESCE(b.status, 'active'), b.harvest_date, COALESCE(b.harvested_count, 0), COALESCE(b.harvested_weight, 0),
+	       b.production_id, COALESCE(b.notes, ''), b.created_at, b.updated_at,
+	       (SELECT COALESCE(SUM(r.count), 0) FROM fish_batch_records r WHERE r.batch_id = b.id AND r.record_type = 'mortality'),
+	       (SELECT COALESCE(SUM(r.feed_kg), 0) FROM fish_batch_records r WHERE r.batch_id = b.id AND r.record_type = 'feeding'),
+	       (SELECT COALESCE(SUM(r.cost), 0) FROM fish_batch_records r WHERE r.batch_id = b.id AND r.record_type = 'feeding'),
+	       (SELECT r.avg_weight FROM fish_batch_records r WHERE r.batch_id = b.id AND r.record_type = 'sampling'
+	        ORDER BY r.record_date DESC LIMIT 1)
+	FROM fish_batches b
+	JOIN ponds p ON p.id = b.pond_id`
+
+// scanFishBatch fishBatchSelect ile seçilen satırı partiye çevirir; kalan adet, biyokütle ve yem dönüşüm oranı hesaplanır
+func scanFishBatch(row interface{ Scan(...interface{}) error }) (models.FishBatch, error) {
+	var batch models.FishBatch
+	var harvestDate sql.NullTime
+	var productionID sql.NullString
+	var sampleWeight sql.NullFloat64
+
+	err := row.Scan(&batch.ID, &batch.PondID, &batch.PondName, &batch.Species, &batch.StockingDate, &batch.InitialCount,
+		&batch.InitialAvgWeight, &batch.Status, &harvestDate, &batch.HarvestedCount, &batch.HarvestedWeight,
+		&productionID, &batch.Notes, &batch.CreatedAt, &batch.UpdatedAt, &batch.MortalityCount, &batch.FeedGiven,
+		&batch.FeedCost, &sampleWeight)
+	if err != nil {
+		return batch, err
+	}
+
+	batch.HarvestDate = utils.NullTimeToPtr(harvestDate)
+	batch.ProductionID = utils.NullStringToPtr(productionID)
+
+	initialBiomass := float64(batch.InitialCount) * batch.InitialAvgWeight / 1000
+	end := time.Now()
+	if batch.Status == models.FishBatchHarvested {
+		// Hasat edilen partide biyokütle artışı hasat ağırlığı üzerinden hesaplanır
+		if batch.HarvestedCount > 0 {
+			batch.AvgWeight = roundTo2(batch.HarvestedWeight * 1000 / float64(batch.HarvestedCount))
+		}
+		batch.BiomassGain = batch.HarvestedWeight - initialBiomass
+		if batch.HarvestDate != nil {
+			end = *batch.HarvestDate
+		}
+	} else {
+		batch.CurrentCount = batch.InitialCount - batch.MortalityCount
+		batch.AvgWeight = batch.InitialAvgWeight
+		if sampleWeight.Valid {
+			batch.AvgWeight = sampleWeight.Float64
+		}
+		batch.Biomass = roundTo2(float64(batch.CurrentCount) * batch.AvgWeight / 1000)
+		batch.BiomassGain = batch.Biomass - initialBiomass
+	}
+	batch.BiomassGain = roundTo2(batch.BiomassGain)
+
+	if batch.BiomassGain > 0 && batch.FeedGiven > 0 {
+		fcr := roundTo2(batch.FeedGiven / batch.BiomassGain)
+		batch.FCR = &fcr
+	}
+	if batch.InitialCount > 0 {
+		batch.SurvivalRate = roundTo2(float64(batch.InitialCount-batch.MortalityCount) / float64(batch.InitialCount) * 100)
+	}
+	batch.DaysInPond = int(end.Sub(batch.StockingDate).Hours() / 24)
+	batch.FeedGiven = roundTo2(batch.FeedGiven)
+	batch.FeedCost = roundTo2(batch.FeedCost)
+	return batch, nil
+}
+
+// fishBatchRecordSelect parti kaydı sorgularının ortak sütunları
+const fishBatchRecordSelect = `
+	SELECT id, batch_id, record_type, record_date, count, feed_kg, COALESCE(feed_type, ''), cost, avg_weight,
+	       COALESCE(notes, ''), created_at
+	FROM fish_batch_records`
+
+// scanFishBatchRecord fishBatchRecordSelect ile seçilen satırı parti kaydına çevirir
+func scanFishBatchRecord(row interface{ Scan(...interface{}) error }) (models.FishBatchRecord, error) {
+	var record models.FishBatchRecord
+	var count sql.NullInt64
+	var feedKg, cost, avgWeight sql.NullFloat64
+
+	err := row.Scan(&record.ID, &record.BatchID, &record.RecordType, &record.RecordDate, &count, &feedKg,
+		&record.FeedType, &cost, &avgWeight, &record.Notes, &record.CreatedAt)
+	if err != nil {
+		return record, err
+	}
+
+	record.Count = nullIntToPtr(count)
+	record.FeedKg = utils.NullFloat64ToPtr(feedKg)
+	record.Cost = utils.NullFloat64ToPtr(cost)
+	record.AvgWeight = utils.NullFloat64ToPtr(avgWeight)
+	return record, nil
+}
@@ -0,0 +1,269 @@
+package handlers
+
+import (
+	"database/sql"
+	"errors"
+	"io/fs"
+	"net/http"
+	"regexp"
+
+	"agri-management-api/internal/models"
+	"agri-management-api/internal/services"
+	"agri-management-api/internal/utils"
+
+	"github.com/gin-gonic/gin"
+)
+
+// archiveMonthPattern arşiv özeti filtrelerindeki ay biçimi (YYYY-MM)
+var archiveMonthPattern = regexp.MustCompile(`^\d{4}-(0[1-9]|1[0-2])$`)
+
+// ArchiveHandler eski kayıtların soğuk depolamaya arşivlenmesini ve geri alınmasını yönetir
+type ArchiveHandler struct {
+	archives *services.ArchiveService
+}
+
+// NewArchiveHandler yeni archive handler oluşturur
+func NewArchiveHandler(db *sql.DB) *ArchiveHandler {
+	return &ArchiveHandler{archives: services.NewArchiveService(db)}
+}
+
+// GetArchives arşiv paketleri
+// @Summary Arşiv paketleri
+// @Description Soğuk depolamaya taşınan arşiv paketlerini yeniden eskiye listeler. Her paket bir tablonun kesim tarihinden eski kayıtlarını içerir; records tablo başına (bağlı alt kayıtlar dahil) taşınan kayıt sayısı, periodStart/periodEnd kayıtların tarih aralığıdır
+// @ID getArchives
+// @Tags Archives
+// @Produce json
+// @Security BearerAuth
+// @Param table query string false "Tablo (transactions, events, milk_production)"
+// @Success 200 {object} models.APIResponse{data=[]models.ArchiveBatch}
+// @Failure 400 {object} models.APIResponse
+// @Failure 401 {object} models.APIResponse
+// @Router /archives [get]
+func (h *ArchiveHandler) GetArchives(c *gin.Context) {
+	userID, err := utils.GetUserID(c)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusUnauthorized, "UNAUTHORIZED", "Kullanıcı kimliği doğrulanamadı", nil)
+		return
+	}
+
+	batches, err := h.archives.Batches(userID, c.Query("table"))
+	if err != nil {
+		writeArchiveError(c, err, "Arşiv paketleri alınamadı")
+		return
+	}
+
+	utils.SuccessResponse(c, batches, "Arşiv paketleri başarıyla getirildi")
+}
+
+// CreateArchive eski kayıtları arşivleme
+// @Summary Eski kayıtları arşivle
+// @Description olderThanYears yıldan eski işlem, takvim etkinliği ve süt kayıtlarını arka planda sıcak tablolardan yedek deposundaki (BACKUP_DIR veya S3) sıkıştırılmış dosyalara taşır. Kesim tarihi o kadar yıl önceki ayın ilk günüdür. Bekleyen işlemler ile fatura, satış, hayvan alım/satımı, not, ek veya kayıt bağlantısı gibi başka kayıtlardan gösterilen işlem ve etkinlikler sıcak tabloda kalır; işlem etiketleri işlemlerle birlikte taşınır. Arşivlenen kayıtların aylık özetleri veritabanında tutulur. İşin durumu ve oluşturulan paketler /archives/jobs/{id} ile izlenir
+// @ID createArchive
+// @Tags Archives
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param request body models.ArchiveRequest true "Arşivleme isteği"
+// @Success 202 {object} models.APIResponse{data=models.ArchiveJob}
+// @Failure 400 {object} models.APIResponse
+// @Failure 401 {object} models.APIResponse
+// @Router /archives [post]
+func (h *ArchiveHandler) CreateArchive(c *gin.Context) {
+	userID, err := utils.GetUserID(c)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusUnauthorized, "UNAUTHORIZED", "Kullanıcı kimliği doğrulanamadı", nil)
+		return
+	}
+
+	var req models.ArchiveRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		utils.ErrorResponse(c, http.StatusBadRequest, "INVALID_REQUEST", "Geçersiz istek formatı", err.Error())
+		return
+	}
+
+	job, err := h.archives.Enqueue(userID, req)
+	if err != nil {
+		writeArchiveError(c, err, "Arşivleme işi oluşturulamadı")
+		return
+	}
+
+	utils.SuccessResponseWithStatus(c, http.StatusAccepted, models.ArchiveJob{Job: job}, "Arşivleme sıraya alındı")
+}
+
+// GetArchiveJob arşivleme işi durumu
+// @Summary Arşivleme işi durumu
+// @Description Arşivleme işinin durumunu (queued, running, completed, failed) getirir; iş bittiğinde oluşturulan paketler batches alanında döner. Başarısız işte hata öncesi arşivlenen tablolar paket olarak kalır
+// @ID getArchiveJob
+// @Tags Archives
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "İş ID"
+// @Success 200 {object} models.APIResponse{data=models.ArchiveJob}
+// @Failure 401 {object} models.APIResponse
+// @Failure 404 {object} models.APIResponse
+// @Router /archives/jobs/{id} [get]
+func (h *ArchiveHandler) GetArchiveJob(c *gin.Context) {
+	userID, err := utils.GetUserID(c)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusUnauthorized, "UNAUTHORIZED", "Kullanıcı kimliği doğrulanamadı", nil)
+		return
+	}
+
+	job, err := h.archives.Job(userID, c.Param("id"))
+	if err != nil {
+		writeArchiveError(c, err, "Arşivleme işi alınamadı")
+		return
+	}
+
+	utils.SuccessResponse(c, job, "Arşivleme işi başarıyla getirildi")
+}
+
+// GetArchiveAggregates arşiv özetleri
+// @Summary Arşiv özetleri
+// @Description Arşivde duran kayıtların aylık özetlerini dosyalar açılmadan döner. İşlemlerde group tür (income/expense), subgroup kategori, total tutardır ve iptal edilen işlemler özete girmez; etkinliklerde group tür, subgroup durumdur; süt kayıtlarında group hayvan ID, subgroup kalite, total litredir. Geri yüklenen paketlerin özetleri dahil edilmez
+// @ID getArchiveAggregates
+// @Tags Archives
+// @Produce json
+// @Security BearerAuth
+// @Param table query string false "Tablo (transactions, events, milk_production)"
+// @Param startMonth query string false "Başlangıç ayı (YYYY-MM)"
+// @Param endMonth query string false "Bitiş ayı (YYYY-MM)"
+// @Success 200 {object} models.APIResponse{data=[]models.ArchiveAggregate}
+// @Failure 400 {object} models.APIResponse
+// @Failure 401 {object} models.APIResponse
+// @Router /archives/aggregates [get]
+func (h *ArchiveHandler) GetArchiveAggregates(c *gin.Context) {
+	userID, err := utils.GetUserID(c)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusUnauthorized, "UNAUTHORIZED", "Kullanıcı kimliği doğrulanamadı", nil)
+		return
+	}
+
+	filter := models.ArchiveAggregateFilter{
+		Table:      c.Query("table"),
+		StartMonth: c.Query("startMonth"),
+		EndMonth:   c.Query("endMonth"),
+	}
+	for _, month := range []string{filter.StartMonth, filter.EndMonth} {
+		if month != "" && !archiveMonthPattern.MatchString(month) {
+			utils.ErrorResponse(c, http.StatusBadRequest, "INVALID_MONTH", "Ay YYYY-MM biçiminde olmalıdır", month)
+			return
+		}
+	}
+
+	aggregates, err := h.archives.Aggregates(userID, filter)
+	if err != nil {
+		writeArchiveError(c, err, "Arşiv özetleri alınamadı")
+		return
+	}
+
+	utils.SuccessResponse(c, aggregates, "Arşiv özetleri başarıyla getirildi")
+}
+
+// GetArchive arşiv paketi detayı
+// @Summary Arşiv paketi detayı
+// @ID getArchive
+// @Tags Archives
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Paket ID"
+// @Success 200 {object} models.APIResponse{data=models.ArchiveBatch}
+// @Failure 401 {object} models.APIResponse
+// @Failure 404 {object} models.APIResponse
+// @Router /archives/{id} [get]
+func (h *ArchiveHandler) GetArchive(c *gin.Context) {
+	userID, err := utils.GetUserID(c)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusUnauthorized, "UNAUTHORIZED", "Kullanıcı kimliği doğrulanamadı", nil)
+		return
+	}
+
+	batch, err := h.archives.Batch(userID, c.Param("id"))
+	if err != nil {
+		writeArchiveError(c, err, "Arşiv paketi alınamadı")
+		return
+	}
+
+	utils.SuccessResponse(c, batch, "Arşiv paketi başarıyla getirildi")
+}
+
+// GetArchiveRecords arşivlenen kayıtlar
+// @Summary Arşivlenen kayıtlar
+// @Description Paketin kayıtlarını soğuk depolamadaki dosyadan okuyup tarih sırasıyla sayfalı döner; kayıtlar tablodaki sütun adlarıyla (ör. user_id, created_at) gelir. Geri yüklenmiş paketin kayıtları tablolardadır ve 409 döner
+// @ID getArchiveRecords
+// @Tags Archives
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Paket ID"
+// @Param page query int false "Sayfa numarası" default(1)
+// @Param limit query int false "Sayfa başına kayıt" default(10)
+// @Success 200 {object} models.APIResponse{data=models.ArchiveRecordsResponse}
+// @Failure 401 {object} models.APIResponse
+// @Failure 404 {object} models.APIResponse
+// @Failure 409 {object} models.APIResponse
+// @Router /archives/{id}/records [get]
+func (h *ArchiveHandler) GetArchiveRecords(c *gin.Context) {
+	userID, err := utils.GetUserID(c)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusUnauthorized, "UNAUTHORIZED", "Kullanıcı kimliği doğrulanamadı", nil)
+		return
+	}
+
+	page, limit := utils.ParsePagination(c)
+	records, err := h.archives.Records(userID, c.Param("id"), page, limit)
+	if err != nil {
+		writeArchiveError(c, err, "Arşivlenen kayıtlar alınamadı")
+		return
+	}
+
+	utils.SuccessResponse(c, records, "Arşivlenen kayıtlar başarıyla getirildi")
+}
+
+// RestoreArchive arşiv paketini geri yükleme
+// @Summary Arşiv paketini geri yükle
+// @Description Paketin kayıtlarını alt kayıtlarıyla birlikte sıcak tablolara geri yazar, paketin özetlerini siler ve dosyayı depolamadan kaldırır. Tabloda aynı kimlikle bulunan kayıtlar ve hayvanı artık çiftlikte olmayan süt kayıtları atlanır (skipped)
+// @ID restoreArchive
+// @Tags Archives
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Paket ID"
+// @Success 200 {object} models.APIResponse{data=models.ArchiveRestoreResult}
+// @Failure 401 {object} models.APIResponse
+// @Failure 404 {object} models.APIResponse
+// @Failure 409 {object} models.APIResponse
+// @Router /archives/{id}/restore [post]
+func (h *ArchiveHandler) RestoreArchive(c *gin.Context) {
+	userID, err := utils.GetUserID(c)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusUnauthorized, "UNAUTHORIZED", "Kullanıcı kimliği doğrulanamadı", nil)
+		return
+	}
+
+	result, err := h.archives.Restore(userID, c.Param("id"))
+	if err != nil {
+		writeArchiveError(c, err, "Arşiv paketi geri yüklenemedi")
+		return
+	}
+
+	utils.SuccessResponse(c, result, "Arşiv paketi başarıyla geri yüklendi")
+}
+
+// writeArchiveError servis hatasını HTTP yanıtına çevirir
+func writeArchiveError(c *gin.Context, err error, message string) {
+	switch {
+	case errors.Is(err, services.ErrArchiveNotFound):
+		utils.ErrorResponse(c, http.StatusNotFound, "ARCHIVE_NOT_FOUND", "Arşiv paketi bulunamadı", nil)
+	case errors.Is(err, services.ErrJobNotFound):
+		utils.ErrorResponse(c, http.StatusNotFound, "JOB_NOT_FOUND", "Arşivleme işi bulunamadı", nil)
+	case errors.Is(err, services.ErrInvalidArchiveTable):
+		utils.ErrorResponse(c, http.StatusBadRequest, "INVALID_TABLE", "Geçersiz arşiv tablosu", services.ArchiveTables())
+	case errors.Is(err, services.ErrArchiveRestored):
+		utils.ErrorResponse(c, http.StatusConflict, "ARCHIVE_RESTORED", "Arşiv paketi daha önce geri yüklenmiş", nil)
+	case errors.Is(err, fs.ErrNotExist):
+		utils.ErrorResponse(c, http.StatusNotFound, "FILE_NOT_FOUND", "Arşiv dosyası bulunamadı", nil)
+	case errors.Is(err, services.ErrInvalidArchiveFile):
+		utils.ErrorResponse(c, http.StatusInternalServerError, "INVALID_ARCHIVE", "Arşiv dosyası okunamadı", nil)
+	default:
+		utils.ErrorResponse(c, http.StatusInternalServerError, "DB_ERROR", message, err.Error())
+	}
+}
@@ -0,0 +1,470 @@
+package handlers
+
+import (
+	"database/sql"
+	"net/http"
+	"time"
+
+	"agri-management-api/internal/models"
+	"agri-management-api/internal/services"
+	"agri-management-api/internal/utils"
+
+	"github.com/gin-gonic/gin"
+)
+
+// AssetHandler duran varlık ve amortisman işlemlerini yönetir
+type AssetHandler struct {
+	db           *sql.DB
+	depreciation *services.DepreciationService
+}
+
+// NewAssetHandler yeni asset handler oluşturur
+func NewAssetHandler(db *sql.DB) *AssetHandler {
+	return &AssetHandler{
+		db:           db,
+		depreciation: services.NewDepreciationService(db),
+	}
+}
+
+// GetAssets duran varlık listesi
+// @Summary Duran varlık listesi
+// @Description Ekipman, bina ve araçları birikmiş amortisman ve net defter değeriyle listeler
+// @ID getAssets
+// @Tags Assets
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param category query string false "Kategori (equipment, building, vehicle, other)"
+// @Param status query string false "Durum (active, disposed)"
+// @Success 200 {object} models.APIResponse{data=[]models.FixedAsset}
+// @Failure 401 {object} models.APIResponse
+// @Router /assets [get]
+func (h *AssetHandler) GetAssets(c *gin.Context) {
+	userID, err := utils.GetUserID(c)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusUnauthorized, "UNAUTHORIZED", "Kullanıcı kimliği doğrulanamadı", nil)
+		return
+	}
+
+	assets, err := h.depreciation.Assets(userID, c.Query("category"), c.Query("status"), time.Now())
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusInternalServerError, "DB_ERROR", "Duran varlıklar alınamadı", err.Error())
+		return
+	}
+
+	utils.SuccessResponse(c, assets, "Duran varlıklar başarıyla getirildi")
+}
+
+// GetAsset duran varlık detayı
+// @Summary Duran varlık detayı
+// @Description Duran varlığı güncel birikmiş amortisman ve net defter değeriyle getirir
+// @ID getAsset
+// @Tags Assets
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Varlık ID"
+// @Success 200 {object} models.APIResponse{data=models.FixedAsset}
+// @Failure 401 {object} models.APIResponse
+// @Failure 404 {object} models.APIResponse
+// @Router /assets/{id} [get]
+func (h *AssetHandler) GetAsset(c *gin.Context) {
+	userID, err := utils.GetUserID(c)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusUnauthorized, "UNAUTHORIZED", "Kullanıcı kimliği doğrulanamadı", nil)
+		return
+	}
+
+	asset, err := h.depreciation.Asset(userID, c.Param("id"))
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusNotFound, "ASSET_NOT_FOUND", "Duran varlık bulunamadı", nil)
+		return
+	}
+
+	utils.DetailResponse(c, asset, services.EntityLinks("asset", asset.ID), "Duran varlık başarıyla getirildi")
+}
+
+// CreateAsset duran varlık ekleme
+// @Summary Duran varlık ekleme
+// @Description Ekipman veya bina gibi bir duran varlığı maliyet, hurda değeri, faydalı ömür ve amortisman yöntemiyle kaydeder
+// @ID createAsset
+// @Tags Assets
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param request body models.FixedAsset true "Varlık bilgileri"
+// @Success 201 {object} models.APIResponse{data=models.FixedAsset}
+// @Failure 400 {object} models.APIResponse
+// @Failure 401 {object} models.APIResponse
+// @Router /assets [post]
+func (h *AssetHandler) CreateAsset(c *gin.Context) {
+	userID, err := utils.GetUserID(c)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusUnauthorized, "UNAUTHORIZED", "Kullanıcı kimliği doğrulanamadı", nil)
+		return
+	}
+
+	var req models.FixedAsset
+	if err := c.ShouldBindJSON(&req); err != nil {
+		utils.ErrorResponse(c, http.StatusBadRequest, "INVALID_REQUEST", "Geçersiz istek formatı", err.Error())
+		return
+	}
+
+	if !validateAsset(c, &req) {
+		return
+	}
+
+	assetID := utils.GenerateID()
+	_, err = h.db.Exec(`
+		INSERT INTO fixed_assets (id, user_id, name, category, purchase_date, cost, salvage_value, useful_life_months,
+		                          method, declining_factor, status, hourly_rate, notes, created_at, updated_at)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, 'active', ?, ?, CURRENT_TIMESTAMP, CURRENT_TIMESTAMP)
+	`, assetID, userID, req.Name, req.Category, req.PurchaseDate, req.Cost, req.SalvageValue, req.UsefulLifeMonths,
+		req.Method, req.DecliningFactor, req.HourlyRate, req.Notes)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusInternalServerError, "DB_ERROR", "Duran varlık oluşturulamadı", err.Error())
+		return
+	}
+
+	asset, err := h.depreciation.Asset(userID, assetID)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusInternalServerError, "FETCH_ERROR", "Oluşturulan varlık getirilemedi", err.Error())
+		return
+	}
+
+	utils.CreatedResponse(c, asset, "Duran varlık başarıyla oluşturuldu")
+}
+
+// UpdateAsset duran varlık güncelleme
+// @Summary Duran varlık güncelleme
+// @Description Duran varlığı günceller; daha önce finansa işlenmiş amortisman giderleri değişmez
+// @ID updateAsset
+// @Tags Assets
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Varlık ID"
+// @Param request body models.FixedAsset true "Varlık bilgileri"
+// @Success 200 {object} models.APIResponse{data=models.FixedAsset}
+// @Failure 400 {object} models.APIResponse
+// @Failure 401 {object} models.APIResponse
+// @Failure 404 {object} models.APIResponse
+// @Router /assets/{id} [put]
+func (h *AssetHandler) UpdateAsset(c *gin.Context) {
+	userID, err := utils.GetUserID(c)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusUnauthorized, "UNAUTHORIZED", "Kullanıcı kimliği doğrulanamadı", nil)
+		return
+	}
+
+	assetID := c.Param("id")
+
+	var req models.FixedAsset
+	if err := c.ShouldBindJSON(&req); err != nil {
+		utils.ErrorResponse(c, http.StatusBadRequest, "INVALID_REQUEST", "Geçersiz istek formatı", err.Error())
+		return
+	}
+
+	if !validateAsset(c, &req) {
+		return
+	}
+
+	result, err := h.db.Exec(`
+		UPDATE fixed_assets SET name = ?, category = ?, purchase_date = ?, cost = ?, salvage_value = ?,
+		                        useful_life_months = ?, method = ?, declining_factor = ?, hourly_rate = ?, notes = ?,
+		                        updated_at = CURRENT_TIMESTAMP
+		WHERE id = ? AND user_id = ?
+	`, req.Name, req.Category, req.PurchaseDate, req.Cost, req.SalvageValue, req.UsefulLifeMonths,
+		req.Method, req.DecliningFactor, req.HourlyRate, req.Notes, assetID, userID)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusInternalServerError, "UPDATE_ERROR", "Duran varlık güncellenemedi", err.Error())
+		return
+	}
+
+	rowsAffected, _ := result.RowsAffected()
+	if rowsAffected == 0 {
+		utils.ErrorResponse(c, http.StatusNotFound, "ASSET_NOT_FOUND", "Duran varlık bulunamadı", nil)
+		return
+	}
+
+	asset, err := h.depreciation.Asset(userID, assetID)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusInternalServerError, "FETCH_ERROR", "Güncellenen varlık getirilemedi", err.Error())
+		return
+	}
+
+	utils.SuccessResponse(c, asset, "Duran varlık başarıyla güncellendi")
+}
+
+// DisposeAsset duran varlığı elden çıkarma
+// @Summary Duran varlığı elden çıkarma
+// @Description Varlığı satış, hurda vb. nedenle elden çıkarılmış olarak işaretler; amortisman elden çıkarma ayında durur
+// @ID disposeAsset
+// @Tags Assets
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Varlık ID"
+// @Param request body map[string]string false "Elden çıkarma tarihi (date, YYYY-MM-DD; varsayılan: bugün)"
+// @Success 200 {object} models.APIResponse{data=models.FixedAsset}
+// @Failure 400 {object} models.APIResponse
+// @Failure 401 {object} models.APIResponse
+// @Failure 404 {object} models.APIResponse
+// @Router /assets/{id}/dispose [patch]
+func (h *AssetHandler) DisposeAsset(c *gin.Context) {
+	userID, err := utils.GetUserID(c)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusUnauthorized, "UNAUTHORIZED", "Kullanıcı kimliği doğrulanamadı", nil)
+		return
+	}
+
+	assetID := c.Param("id")
+
+	var req struct {
+		Date string `json:"date"`
+	}
+	c.ShouldBindJSON(&req)
+
+	disposedAt := time.Now()
+	if req.Date != "" {
+		if disposedAt, err = time.Parse("2006-01-02", req.Date); err != nil {
+			utils.ErrorResponse(c, http.StatusBadRequest, "INVALID_DATE", "Tarih YYYY-MM-DD biçiminde olmalı", nil)
+			return
+		}
+	}
+
+	result, err := h.db.Exec(`
+		UPDATE fixed_assets SET status = 'disposed', disposed_at = ?, updated_at = CURRENT_TIMESTAMP
+		WHERE id = ? AND user_id = ?
+	`, disposedAt, assetID, userID)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusInternalServerError, "UPDATE_ERROR", "Duran varlık güncellenemedi", err.Error())
+		return
+	}
+
+	rowsAffected, _ := result.RowsAffected()
+	if rowsAffected == 0 {
+		utils.ErrorResponse(c, http.StatusNotFound, "ASSET_NOT_FOUND", "Duran varlık bulunamadı", nil)
+		return
+	}
+
+	asset, _ := h.depreciation.Asset(userID, assetID)
+	utils.SuccessResponse(c, asset, "Duran varlık elden çıkarıldı")
+}
+
+// DeleteAsset duran varlık silme
+// @Summary Duran varlık silme
+// @Description Duran varlığı ve amortisman kayıtlarını siler; finansa işlenmiş gider işlemleri korunur
+// @ID deleteAsset
+// @Tags Assets
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Varlık ID"
+// @Param force query bool false "Başka kayıtlarla bağlantısı olsa da sil"
+// @Success 200 {object} models.APIResponse
+// @Failure 401 {object} models.APIResponse
+// @Failure 404 {object} models.APIResponse
+// @Failure 409 {object} models.APIResponse
+// @Router /assets/{id} [delete]
+func (h *AssetHandler) DeleteAsset(c *gin.Context) {
+	userID, err := utils.GetUserID(c)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusUnauthorized, "UNAUTHORIZED", "Kullanıcı kimliği doğrulanamadı", nil)
+		return
+	}
+
+	assetID := c.Param("id")
+
+	if !confirmLinkedDelete(c, h.db, userID, "asset", assetID) {
+		return
+	}
+
+	result, err := h.db.Exec("DELETE FROM fixed_assets WHERE id = ? AND user_id = ?", assetID, userID)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusInternalServerError, "DELETE_ERROR", "Duran varlık silinemedi", err.Error())
+		return
+	}
+
+	rowsAffected, _ := result.RowsAffected()
+	if rowsAffected == 0 {
+		utils.ErrorResponse(c, http.StatusNotFound, "ASSET_NOT_FOUND", "Duran varlık bulunamadı", nil)
+		return
+	}
+
+	h.db.Exec("DELETE FROM depreciation_postings WHERE asset_id = ? AND user_id = ?", assetID, userID)
+
+	removeRecordLinks(h.db, userID, "asset", assetID)
+
+	utils.SuccessResponse(c, nil, "Duran varlık başarıyla silindi")
+}
+
+// GetSchedule amortisman tablosu
+// @Summary Amortisman tablosu
+// @Description Varlığın faydalı ömrü boyunca aylık amortisman tutarı, birikmiş amortisman ve net defter değeri tablosunu döner
+// @ID getSchedule
+// @Tags Assets
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Varlık ID"
+// @Success 200 {object} models.APIResponse{data=models.DepreciationSchedule}
+// @Failure 401 {object} models.APIResponse
+// @Failure 404 {object} models.APIResponse
+// @Router /assets/{id}/schedule [get]
+func (h *AssetHandler) GetSchedule(c *gin.Context) {
+	userID, err := utils.GetUserID(c)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusUnauthorized, "UNAUTHORIZED", "Kullanıcı kimliği doğrulanamadı", nil)
+		return
+	}
+
+	asset, err := h.depreciation.Asset(userID, c.Param("id"))
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusNotFound, "ASSET_NOT_FOUND", "Duran varlık bulunamadı", nil)
+		return
+	}
+
+	posted, err := h.depreciation.PostedPeriods(userID, asset.ID)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusInternalServerError, "DB_ERROR", "Amortisman kayıtları alınamadı", err.Error())
+		return
+	}
+
+	entries := services.Schedule(asset)
+	for i := range entries {
+		entries[i].Posted = posted[entries[i].Period]
+	}
+
+	utils.SuccessResponse(c, models.DepreciationSchedule{Asset: asset, Entries: entries}, "Amortisman tablosu başarıyla getirildi")
+}
+
+// PostDepreciation dönem amortismanını finansa işleme
+// @Summary Amortisman giderlerini işleme
+// @Description Verilen ayın amortisman giderlerini her varlık için bir kez gider işlemi olarak kaydeder; tamamlanan ay her gün otomatik olarak da işlenir
+// @ID postDepreciation
+// @Tags Assets
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param request body map[string]string true "Dönem (period, YYYY-MM)"
+// @Success 200 {object} models.APIResponse{data=models.DepreciationPostResult}
+// @Failure 400 {object} models.APIResponse
+// @Failure 401 {object} models.APIResponse
+// @Router /assets/depreciation/post [post]
+func (h *AssetHandler) PostDepreciation(c *gin.Context) {
+	userID, err := utils.GetUserID(c)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusUnauthorized, "UNAUTHORIZED", "Kullanıcı kimliği doğrulanamadı", nil)
+		return
+	}
+
+	var req struct {
+		Period string `json:"period" binding:"required"`
+	}
+	if err := c.ShouldBindJSON(&req); err != nil {
+		utils.ErrorResponse(c, http.StatusBadRequest, "INVALID_REQUEST", "Geçersiz istek formatı", err.Error())
+		return
+	}
+
+	period, err := time.Parse("2006-01", req.Period)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusBadRequest, "INVALID_PERIOD", "Dönem YYYY-MM biçiminde olmalı", nil)
+		return
+	}
+	if !period.AddDate(0, 1, 0).Before(time.Now()) {
+		utils.ErrorResponse(c, http.StatusBadRequest, "PERIOD_NOT_CLOSED", "Yalnızca tamamlanmış aylar işlenebilir", nil)
+		return
+	}
+
+	result, err := h.depreciation.PostPeriod(userID, req.Period)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusInternalServerError, "DB_ERROR", "Amortisman giderleri işlenemedi", err.Error())
+		return
+	}
+
+	utils.SuccessResponse(c, result, "Amortisman giderleri başarıyla işlendi")
+}
+
+// GetAssetReport duran varlık raporu
+// @Summary Duran varlık raporu
+// @Description Verilen tarihteki maliyet, birikmiş amortisman ve net defter değerlerini varlık ve kategori bazında raporlar
+// @ID getAssetReport
+// @Tags Assets
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param date query string false "Rapor tarihi (YYYY-MM-DD, varsayılan: bugün)"
+// @Success 200 {object} models.APIResponse{data=models.FixedAssetReport}
+// @Failure 400 {object} models.APIResponse
+// @Failure 401 {object} models.APIResponse
+// @Router /assets/report [get]
+func (h *AssetHandler) GetAssetReport(c *gin.Context) {
+	userID, err := utils.GetUserID(c)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusUnauthorized, "UNAUTHORIZED", "Kullanıcı kimliği doğrulanamadı", nil)
+		return
+	}
+
+	date := time.Now()
+	if value := c.Query("date"); value != "" {
+		if date, err = time.Parse("2006-01-02", value); err != nil {
+			utils.ErrorResponse(c, http.StatusBadRequest, "INVALID_DATE", "Tarih YYYY-MM-DD biçiminde olmalı", nil)
+			return
+		}
+	}
+
+	assets, err := h.depreciation.Assets(userID, c.Query("category"), "", date)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusInternalServerError, "DB_ERROR", "Duran varlıklar alınamadı", err.Error())
+		return
+	}
+
+	report := models.FixedAssetReport{
+		Date:       date.Format("2006-01-02"),
+		Categories: []models.FixedAssetCategoryTotal{},
+		Assets:     []models.FixedAsset{},
+	}
+	categoryIndex := map[string]int{}
+
+	for _, asset := range assets {
+		// Rapor tarihinde henüz alınmamış veya elden çıkarılmış varlıklar dahil edilmez
+		if asset.PurchaseDate.After(date) || (asset.DisposedAt != nil && !asset.DisposedAt.After(date)) {
+			continue
+		}
+
+		i, ok := categoryIndex[asset.Category]
+		if !ok {
+			report.Categories = append(report.Categories, models.FixedAssetCategoryTotal{Category: asset.Category})
+			i = len(report.Categories) - 1
+			categoryIndex[asset.Category] = i
+		}
+
+		category := &report.Categories[i]
+		category.Count++
+		category.Cost = roundTo2(category.Cost + asset.Cost)
+		category.Accumulated = roundTo2(category.Accumulated + asset.AccumulatedDepreciation)
+		category.NetBookValue = roundTo2(category.NetBookValue + asset.NetBookValue)
+
+		report.TotalCost = roundTo2(report.TotalCost + asset.Cost)
+		report.TotalAccumulated = roundTo2(report.TotalAccumulated + asset.AccumulatedDepreciation)
+		report.TotalNetBookValue = roundTo2(report.TotalNetBookValue + asset.NetBookValue)
+		report.Assets = append(report.Assets, asset)
+	}
+
+	utils.SuccessResponse(c, report, "Duran varlık raporu başarıyla getirildi")
+}
+
+// validateAsset hurda değeri ve azalan bakiye katsayısını doğrular; hata varsa yanıtı yazar
+func validateAsset(c *gin.Context, req *models.FixedAsset) bool {
+	if req.SalvageValue >= req.Cost {
+		utils.ErrorResponse(c, http.StatusBadRequest, "INVALID_SALVAGE_VALUE", "Hurda değeri maliyetten küçük olmalı", nil)
+		return false
+	}
+
+	if req.DecliningFactor == 0 {
+		req.DecliningFactor = 2
+	}
+
+	return true
+}
@@ -28,6 +28,7 @@ func NewAuthHandler(db *sql.DB) *AuthHandler {
 // Register kullanıcı kaydı
 // @Summary Kullanıcı kaydı
 // @Description Yeni kullanıcı kaydı oluşturur
+// @ID register
 // @Tags Auth
 // @Accept json
 // @Produce json
@@ -112,6 +113,7 @@ func (h *AuthHandler) Register(c *gin.Context) {
 // Login kullanıcı girişi
 // @Summary Kullanıcı girişi
 // @Description Kullanıcı girişi yapar ve token döner
+// @ID login
 // @Tags Auth
 // @Accept json
 // @Produce json
@@ -175,11 +177,12 @@ func (h *AuthHandler) Login(c *gin.Context) {
 // Refresh token yenileme
 // @Summary Token yenileme
 // @Description Refresh token ile yeni access token oluşturur
+// @ID refresh
 // @Tags Auth
 // @Accept json
 // @Produce json
 // @Param request body map[string]string true "Refresh token"
-// @Success 200 {object} models.APIResponse{data=map[string]string}
+// @Success 200 {object} models.APIResponse{data=models.TokenResponse}
 // @Failure 400 {object} models.APIResponse
 // @Failure 401 {object} models.APIResponse
 // @Router /auth/refresh [post]
@@ -203,8 +206,8 @@ func (h *AuthHandler) Refresh(c *gin.Context) {
 		return
 	}
 
-	response := map[string]string{
-		"token": newToken,
+	response := models.TokenResponse{
+		Token: newToken,
 	}
 
 	utils.SuccessResponse(c, response, "Token başarıyla yenilendi")
@@ -213,6 +216,7 @@ func (h *AuthHandler) Refresh(c *gin.Context) {
 // GetProfile kullanıcı profili
 // @Summary Kullanıcı profili
 // @Description Mevcut kullanıcının profil bilgilerini getirir
+// @ID getProfile
 // @Tags Auth
 // @Accept json
 // @Produce json
@@ -247,6 +251,7 @@ func (h *AuthHandler) GetProfile(c *gin.Context) {
 // UpdateProfile profil güncelleme
 // @Summary Profil güncelleme
 // @Description Kullanıcı profil bilgilerini günceller
+// @ID updateProfile
 // @Tags Auth
 // @Accept json
 // @Produce json
@@ -281,6 +286,12 @@ func (h *AuthHandler) UpdateProfile(c *gin.Context) {
 		return
 	}
 
+	// Varsayılan çiftliğin adı ve konumu profille aynı tutulur
+	if !utils.IsEmptyString(req.FarmName) {
+		h.db.Exec("UPDATE farms SET name = ?, location = ?, updated_at = CURRENT_TIMESTAMP WHERE id = ? AND is_default = TRUE",
+			req.FarmName, req.Location, userID)
+	}
+
 	// Güncellenmiş profili getir
 	var user models.User
 	err = h.db.QueryRow(`
@@ -301,7 +312,8 @@ func (h *AuthHandler) UpdateProfile(c *gin.Context) {
 
 // ChangePassword şifre değiştirme
 // @Summary Şifre değiştirme
-// @Description Kullanıcı şifresini değiştirir
+// @Description Kullanıcı şifresini değiştirir. Giriş bilgileri paylaşılan sandbox hesaplarında kullanılamaz (403 DEMO_ACCOUNT)
+// @ID changePassword
 // @Tags Auth
 // @Accept json
 // @Produce json
@@ -310,6 +322,7 @@ func (h *AuthHandler) UpdateProfile(c *gin.Context) {
 // @Success 200 {object} models.APIResponse
 // @Failure 400 {object} models.APIResponse
 // @Failure 401 {object} models.APIResponse
+// @Failure 403 {object} models.APIResponse
 // @Router /auth/change-password [put]
 func (h *AuthHandler) ChangePassword(c *gin.Context) {
 	userID, err := utils.GetUserID(c)
@@ -374,6 +387,7 @@ func (h *AuthHandler) ChangePassword(c *gin.Context) {
 // Logout çıkış yapma
 // @Summary Çıkış yapma
 // @Description Kullanıcı çıkışı yapar
+// @ID logout
 // @Tags Auth
 // @Accept json
 // @Produce json
@@ -0,0 +1,771 @@
+package handlers
+
+import (
+	"database/sql"
+	"errors"
+	"io"
+	"net/http"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"agri-management-api/internal/models"
+	"agri-management-api/internal/services"
+	"agri-management-api/internal/utils"
+
+	"github.com/gin-gonic/gin"
+)
+
+// maxStatementFileSize içe aktarılabilecek en büyük ekstre dosyası boyutu
+const maxStatementFileSize = 10 << 20
+
+// defaultStatementCategory ekstre satırından oluşturulan işlemlerin kategori girilmezse kullanılan kategorisi
+const defaultStatementCategory = "Banka"
+
+// bankAccountSelect banka hesabı sorgularının ortak sütunları
+const bankAccountSelect = `
+	SELECT id, user_id, name, COALESCE(bank_name, ''), COALESCE(account_number, ''), COALESCE(NULLIF(currency, ''), 'TRY'),
+	       opening_balance, created_at, updated_at
+	FROM bank_accounts`
+
+// bankStatementLineSelect ekstre satırı sorgularının ortak sütunları
+const bankStatementLineSelect = `
+	SELECT id, account_id, statement_id, date, amount, COALESCE(description, ''), COALESCE(reference, ''), status,
+	       transaction_id, match_score, created_at
+	FROM bank_statement_lines`
+
+// GetBankAccounts banka hesapları
+// @Summary Banka hesapları
+// @Description Çiftliğin banka hesaplarını mutabakat durumlarıyla listeler
+// @ID getBankAccounts
+// @Tags Finance
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {object} models.APIResponse{data=[]models.BankAccount}
+// @Failure 401 {object} models.APIResponse
+// @Router /finance/bank-accounts [get]
+func (h *FinanceHandler) GetBankAccounts(c *gin.Context) {
+	userID, err := utils.GetUserID(c)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusUnauthorized, "UNAUTHORIZED", "Kullanıcı kimliği doğrulanamadı", nil)
+		return
+	}
+
+	rows, err := h.db.Query(bankAccountSelect+" WHERE user_id = ? ORDER BY name", userID)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusInternalServerError, "DB_ERROR", "Banka hesapları getirilemedi", err.Error())
+		return
+	}
+
+	accounts := []models.BankAccount{}
+	for rows.Next() {
+		account, err := scanBankAccount(rows)
+		if err != nil {
+			continue
+		}
+		accounts = append(accounts, account)
+	}
+	rows.Close()
+
+	for i := range accounts {
+		reconciliation, err := h.bank.Reconciliation(userID, accounts[i].ID, accounts[i].OpeningBalance)
+		if err != nil {
+			utils.ErrorResponse(c, http.StatusInternalServerError, "DB_ERROR", "Mutabakat durumu hesaplanamadı", err.Error())
+			return
+		}
+		accounts[i].Reconciliation = &reconciliation
+	}
+
+	utils.SuccessResponse(c, accounts, "Banka hesapları başarıyla getirildi")
+}
+
+// CreateBankAccount yeni banka hesabı
+// @Summary Yeni banka hesabı
+// @Description Ekstreleri içe aktarılacak banka hesabı ekler
+// @ID createBankAccount
+// @Tags Finance
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param account body models.BankAccount true "Banka hesabı"
+// @Success 201 {object} models.APIResponse{data=models.BankAccount}
+// @Failure 400 {object} models.APIResponse
+// @Failure 401 {object} models.APIResponse
+// @Router /finance/bank-accounts [post]
+func (h *FinanceHandler) CreateBankAccount(c *gin.Context) {
+	userID, err := utils.GetUserID(c)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusUnauthorized, "UNAUTHORIZED", "Kullanıcı kimliği doğrulanamadı", nil)
+		return
+	}
+
+	var req models.BankAccount
+	if err := c.ShouldBindJSON(&req); err != nil {
+		utils.ErrorResponse(c, http.StatusBadRequest, "INVALID_REQUEST", "Geçersiz istek formatı", err.Error())
+		return
+	}
+	if utils.IsEmptyString(req.Name) {
+		utils.ErrorResponse(c, http.StatusBadRequest, "MISSING_FIELDS", "Hesap adı gerekli", nil)
+		return
+	}
+	if req.Currency == "" {
+		if settings, err := h.farms.Settings(userID); err == nil {
+			req.Currency = settings.General.Currency
+		}
+	}
+
+	accountNumber, err := services.EncryptField(services.ColumnBankAccountNumber, req.AccountNumber)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusInternalServerError, "ENCRYPTION_ERROR", "Hesap numarası şifrelenemedi", err.Error())
+		return
+	}
+
+	accountID := utils.GenerateID()
+	_, err = h.db.Exec(`
+		INSERT INTO bank_accounts (id, user_id, name, bank_name, account_number, currency, opening_balance, created_at, updated_at)
+		VALUES (?, ?, ?, ?, ?, ?, ?, CURRENT_TIMESTAMP, CURRENT_TIMESTAMP)
+	`, accountID, userID, strings.TrimSpace(req.Name), req.BankName, accountNumber, strings.ToUpper(req.Currency), req.OpeningBalance)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusInternalServerError, "DB_ERROR", "Banka hesabı oluşturulamadı", err.Error())
+		return
+	}
+
+	account, err := h.getBankAccount(accountID, userID)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusInternalServerError, "FETCH_ERROR", "Oluşturulan banka hesabı getirilemedi", err.Error())
+		return
+	}
+
+	utils.CreatedResponse(c, account, "Banka hesabı başarıyla oluşturuldu")
+}
+
+// GetBankAccount banka hesabı detayı
+// @Summary Banka hesabı detayı
+// @Description Banka hesabını mutabakat durumuyla getirir
+// @ID getBankAccount
+// @Tags Finance
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Banka hesabı ID"
+// @Success 200 {object} models.APIResponse{data=models.BankAccount}
+// @Failure 401 {object} models.APIResponse
+// @Failure 404 {object} models.APIResponse
+// @Router /finance/bank-accounts/{id} [get]
+func (h *FinanceHandler) GetBankAccount(c *gin.Context) {
+	userID, err := utils.GetUserID(c)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusUnauthorized, "UNAUTHORIZED", "Kullanıcı kimliği doğrulanamadı", nil)
+		return
+	}
+
+	account, ok := h.bankAccountOr404(c, userID)
+	if !ok {
+		return
+	}
+
+	utils.SuccessResponse(c, account, "Banka hesabı başarıyla getirildi")
+}
+
+// DeleteBankAccount banka hesabı silme
+// @Summary Banka hesabı silme
+// @Description Banka hesabını, içe aktarılan ekstrelerini ve satırlarını siler; eşleştirilen veya oluşturulan işlemler korunur
+// @ID deleteBankAccount
+// @Tags Finance
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Banka hesabı ID"
+// @Success 200 {object} models.APIResponse
+// @Failure 401 {object} models.APIResponse
+// @Failure 404 {object} models.APIResponse
+// @Router /finance/bank-accounts/{id} [delete]
+func (h *FinanceHandler) DeleteBankAccount(c *gin.Context) {
+	userID, err := utils.GetUserID(c)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusUnauthorized, "UNAUTHORIZED", "Kullanıcı kimliği doğrulanamadı", nil)
+		return
+	}
+
+	result, err := h.db.Exec("DELETE FROM bank_accounts WHERE id = ? AND user_id = ?", c.Param("id"), userID)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusInternalServerError, "DELETE_ERROR", "Banka hesabı silinemedi", err.Error())
+		return
+	}
+	if rows, _ := result.RowsAffected(); rows == 0 {
+		utils.ErrorResponse(c, http.StatusNotFound, "BANK_ACCOUNT_NOT_FOUND", "Banka hesabı bulunamadı", nil)
+		return
+	}
+
+	h.db.Exec("DELETE FROM bank_statement_lines WHERE account_id = ? AND user_id = ?", c.Param("id"), userID)
+	h.db.Exec("DELETE FROM bank_statements WHERE account_id = ? AND user_id = ?", c.Param("id"), userID)
+
+	utils.SuccessResponse(c, nil, "Banka hesabı başarıyla silindi")
+}
+
+// ImportBankStatement banka ekstresi içe aktarma
+// @Summary Banka ekstresi içe aktarma
+// @Description CSV veya OFX ekstresini içe aktarır, daha önce aktarılan hareketleri atlar ve yeni satırları tutar, yön ve tarih yakınlığına göre mevcut işlemlerle otomatik eşleştirir. CSV dosyasında tarih, açıklama, tutar (veya borç/alacak) ve referans sütunları başlık adlarından tanınır
+// @ID importBankStatement
+// @Tags Finance
+// @Accept multipart/form-data
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Banka hesabı ID"
+// @Param file formData file true "Ekstre dosyası (CSV veya OFX)"
+// @Param format formData string false "Dosya formatı (csv, ofx); boşsa içerikten tespit edilir"
+// @Param toleranceDays formData int false "Eşleştirmede en fazla gün farkı" default(3)
+// @Success 201 {object} models.APIResponse{data=models.BankStatementImport}
+// @Failure 400 {object} models.APIResponse
+// @Failure 401 {object} models.APIResponse
+// @Failure 404 {object} models.APIResponse
+// @Router /finance/bank-accounts/{id}/statements [post]
+func (h *FinanceHandler) ImportBankStatement(c *gin.Context) {
+	userID, err := utils.GetUserID(c)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusUnauthorized, "UNAUTHORIZED", "Kullanıcı kimliği doğrulanamadı", nil)
+		return
+	}
+
+	account, ok := h.bankAccountOr404(c, userID)
+	if !ok {
+		return
+	}
+
+	format := strings.ToLower(c.PostForm("format"))
+	if format != "" && format != services.StatementFormatCSV && format != services.StatementFormatOFX {
+		utils.ErrorResponse(c, http.StatusBadRequest, "INVALID_FORMAT", "Geçersiz dosya formatı", []string{services.StatementFormatCSV, services.StatementFormatOFX})
+		return
+	}
+
+	toleranceDays, ok := matchToleranceDays(c, c.PostForm("toleranceDays"))
+	if !ok {
+		return
+	}
+
+	fileHeader, err := c.FormFile("file")
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusBadRequest, "MISSING_FILE", "Ekstre dosyası gerekli", nil)
+		return
+	}
+	if fileHeader.Size > maxStatementFileSize {
+		utils.ErrorResponse(c, http.StatusBadRequest, "FILE_TOO_LARGE", "Ekstre dosyası çok büyük", nil)
+		return
+	}
+
+	file, err := fileHeader.Open()
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusBadRequest, "INVALID_FILE", "Ekstre dosyası okunamadı", err.Error())
+		return
+	}
+	defer file.Close()
+
+	data, err := io.ReadAll(file)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusBadRequest, "INVALID_FILE", "Ekstre dosyası okunamadı", err.Error())
+		return
+	}
+
+	switch strings.ToLower(filepath.Ext(fileHeader.Filename)) {
+	case ".ofx", ".qfx":
+		if format == "" {
+			format = services.StatementFormatOFX
+		}
+	}
+
+	statement, err := services.ParseBankStatement(data, format)
+	if err != nil {
+		if errors.Is(err, services.ErrEmptyStatement) {
+			utils.ErrorResponse(c, http.StatusBadRequest, "EMPTY_FILE", "Ekstre dosyasında hareket bulunamadı", nil)
+			return
+		}
+		utils.ErrorResponse(c, http.StatusBadRequest, "PARSE_ERROR", "Ekstre dosyası çözümlenemedi", err.Error())
+		return
+	}
+	if statement.Currency != "" && statement.Currency != account.Currency {
+		utils.ErrorResponse(c, http.StatusBadRequest, "CURRENCY_MISMATCH", "Ekstre para birimi hesapla uyuşmuyor", map[string]string{
+			"statement": statement.Currency,
+			"account":   account.Currency,
+		})
+		return
+	}
+
+	result := models.BankStatementImport{
+		StatementID:    utils.GenerateID(),
+		AccountID:      account.ID,
+		Format:         statement.Format,
+		StartDate:      statement.StartDate,
+		EndDate:        statement.EndDate,
+		ClosingBalance: statement.ClosingBalance,
+		Lines:          []models.BankStatementLine{},
+	}
+
+	tx, err := h.db.Begin()
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusInternalServerError, "DB_ERROR", "Ekstre içe aktarılamadı", err.Error())
+		return
+	}
+	defer tx.Rollback()
+
+	var lineIDs []string
+	for _, line := range statement.Lines {
+		lineID := utils.GenerateID()
+		res, err := tx.Exec(`
+			INSERT OR IGNORE INTO bank_statement_lines (id, user_id, account_id, statement_id, external_id, date, amount,
+			                                            description, reference, status, created_at)
+			VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, CURRENT_TIMESTAMP)
+		`, lineID, userID, account.ID, result.StatementID, line.ExternalID, line.Date, line.Amount,
+			line.Description, line.Reference, models.StatementLineUnmatched)
+		if err != nil {
+			utils.ErrorResponse(c, http.StatusInternalServerError, "DB_ERROR", "Ekstre içe aktarılamadı", err.Error())
+			return
+		}
+		if rows, _ := res.RowsAffected(); rows == 0 {
+			result.Duplicates++
+			continue
+		}
+		lineIDs = append(lineIDs, lineID)
+	}
+	result.Imported = len(lineIDs)
+
+	_, err = tx.Exec(`
+		INSERT INTO bank_statements (id, user_id, account_id, filename, format, start_date, end_date, closing_balance, line_count, created_at)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, CURRENT_TIMESTAMP)
+	`, result.StatementID, userID, account.ID, filepath.Base(fileHeader.Filename), statement.Format,
+		statement.StartDate, statement.EndDate, statement.ClosingBalance, result.Imported)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusInternalServerError, "DB_ERROR", "Ekstre içe aktarılamadı", err.Error())
+		return
+	}
+	if err := tx.Commit(); err != nil {
+		utils.ErrorResponse(c, http.StatusInternalServerError, "DB_ERROR", "Ekstre içe aktarılamadı", err.Error())
+		return
+	}
+
+	if result.Matched, err = h.bank.MatchStatementLines(userID, account.ID, account.Currency, toleranceDays); err != nil {
+		utils.ErrorResponse(c, http.StatusInternalServerError, "MATCH_ERROR", "Ekstre satırları eşleştirilemedi", err.Error())
+		return
+	}
+
+	rows, err := h.db.Query(bankStatementLineSelect+" WHERE statement_id = ? ORDER BY date, created_at", result.StatementID)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusInternalServerError, "DB_ERROR", "Ekstre satırları getirilemedi", err.Error())
+		return
+	}
+	for rows.Next() {
+		line, err := scanBankStatementLine(rows)
+		if err != nil {
+			continue
+		}
+		result.Lines = append(result.Lines, line)
+	}
+	rows.Close()
+
+	if result.Reconciliation, err = h.bank.Reconciliation(userID, account.ID, account.OpeningBalance); err != nil {
+		utils.ErrorResponse(c, http.StatusInternalServerError, "DB_ERROR", "Mutabakat durumu hesaplanamadı", err.Error())
+		return
+	}
+
+	utils.CreatedResponse(c, result, "Ekstre başarıyla içe aktarıldı")
+}
+
+// GetBankStatementLines ekstre satırları
+// @Summary Ekstre satırları
+// @Description Banka hesabının içe aktarılan ekstre satırlarını listeler
+// @ID getBankStatementLines
+// @Tags Finance
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Banka hesabı ID"
+// @Param status query string false "Durum (unmatched, matched, created, ignored)"
+// @Param startDate query string false "Başlangıç tarihi"
+// @Param endDate query string false "Bitiş tarihi"
+// @Success 200 {object} models.APIResponse{data=[]models.BankStatementLine}
+// @Failure 400 {object} models.APIResponse
+// @Failure 401 {object} models.APIResponse
+// @Failure 404 {object} models.APIResponse
+// @Router /finance/bank-accounts/{id}/lines [get]
+func (h *FinanceHandler) GetBankStatementLines(c *gin.Context) {
+	userID, err := utils.GetUserID(c)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusUnauthorized, "UNAUTHORIZED", "Kullanıcı kimliği doğrulanamadı", nil)
+		return
+	}
+
+	account, ok := h.bankAccountOr404(c, userID)
+	if !ok {
+		return
+	}
+
+	query := bankStatementLineSelect + " WHERE account_id = ? AND user_id = ?"
+	args := []interface{}{account.ID, userID}
+	if status := c.Query("status"); status != "" {
+		if !isStatementLineStatus(status) {
+			utils.ErrorResponse(c, http.StatusBadRequest, "INVALID_STATUS", "Geçersiz satır durumu", statementLineStatuses)
+			return
+		}
+		query += " AND status = ?"
+		args = append(args, status)
+	}
+	if startDate := c.Query("startDate"); startDate != "" {
+		query += " AND date(date) >= ?"
+		args = append(args, startDate)
+	}
+	if endDate := c.Query("endDate"); endDate != "" {
+		query += " AND date(date) <= ?"
+		args = append(args, endDate)
+	}
+
+	rows, err := h.db.Query(query+" ORDER BY date DESC, created_at", args...)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusInternalServerError, "DB_ERROR", "Ekstre satırları getirilemedi", err.Error())
+		return
+	}
+	defer rows.Close()
+
+	lines := []models.BankStatementLine{}
+	for rows.Next() {
+		line, err := scanBankStatementLine(rows)
+		if err != nil {
+			continue
+		}
+		lines = append(lines, line)
+	}
+
+	utils.SuccessResponse(c, lines, "Ekstre satırları başarıyla getirildi")
+}
+
+// MatchBankStatementLines ekstre satırlarını yeniden eşleştirme
+// @Summary Ekstre satırlarını eşleştirme
+// @Description Eşleşmemiş ekstre satırlarını, sonradan eklenen işlemler dahil mevcut işlemlerle yeniden otomatik eşleştirir
+// @ID matchBankStatementLines
+// @Tags Finance
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Banka hesabı ID"
+// @Param toleranceDays query int false "Eşleştirmede en fazla gün farkı" default(3)
+// @Success 200 {object} models.APIResponse{data=models.BankAccount}
+// @Failure 400 {object} models.APIResponse
+// @Failure 401 {object} models.APIResponse
+// @Failure 404 {object} models.APIResponse
+// @Router /finance/bank-accounts/{id}/match [post]
+func (h *FinanceHandler) MatchBankStatementLines(c *gin.Context) {
+	userID, err := utils.GetUserID(c)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusUnauthorized, "UNAUTHORIZED", "Kullanıcı kimliği doğrulanamadı", nil)
+		return
+	}
+
+	account, ok := h.bankAccountOr404(c, userID)
+	if !ok {
+		return
+	}
+
+	toleranceDays, ok := matchToleranceDays(c, c.Query("toleranceDays"))
+	if !ok {
+		return
+	}
+
+	matched, err := h.bank.MatchStatementLines(userID, account.ID, account.Currency, toleranceDays)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusInternalServerError, "MATCH_ERROR", "Ekstre satırları eşleştirilemedi", err.Error())
+		return
+	}
+
+	account, ok = h.bankAccountOr404(c, userID)
+	if !ok {
+		return
+	}
+
+	utils.SuccessResponse(c, account, strconv.Itoa(matched)+" ekstre satırı eşleştirildi")
+}
+
+// UpdateBankStatementLine ekstre satırını elle eşleştirme
+// @Summary Ekstre satırı durumu
+// @Description Ekstre satırını belirtilen işlemle elle eşleştirir (matched), eşleşmesini kaldırır (unmatched) veya mutabakat dışı bırakır (ignored)
+// @ID updateBankStatementLine
+// @Tags Finance
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Banka hesabı ID"
+// @Param lineId path string true "Ekstre satırı ID"
+// @Param request body models.BankStatementLineRequest true "Satır durumu"
+// @Success 200 {object} models.APIResponse{data=models.BankStatementLine}
+// @Failure 400 {object} models.APIResponse
+// @Failure 401 {object} models.APIResponse
+// @Failure 404 {object} models.APIResponse
+// @Failure 409 {object} models.APIResponse
+// @Router /finance/bank-accounts/{id}/lines/{lineId} [patch]
+func (h *FinanceHandler) UpdateBankStatementLine(c *gin.Context) {
+	userID, err := utils.GetUserID(c)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusUnauthorized, "UNAUTHORIZED", "Kullanıcı kimliği doğrulanamadı", nil)
+		return
+	}
+
+	var req models.BankStatementLineRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		utils.ErrorResponse(c, http.StatusBadRequest, "INVALID_REQUEST", "Geçersiz istek formatı", err.Error())
+		return
+	}
+
+	line, err := scanBankStatementLine(h.db.QueryRow(bankStatementLineSelect+" WHERE id = ? AND account_id = ? AND user_id = ?",
+		c.Param("lineId"), c.Param("id"), userID))
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusNotFound, "STATEMENT_LINE_NOT_FOUND", "Ekstre satırı bulunamadı", nil)
+		return
+	}
+
+	var transactionID interface{}
+	switch req.Status {
+	case models.StatementLineMatched:
+		var txType string
+		var amount float64
+		err := h.db.QueryRow("SELECT type, amount FROM transactions WHERE id = ? AND user_id = ?", req.TransactionID, userID).Scan(&txType, &amount)
+		if err != nil {
+			utils.ErrorResponse(c, http.StatusNotFound, "TRANSACTION_NOT_FOUND", "İşlem bulunamadı", nil)
+			return
+		}
+		if (txType == "income") != (line.Amount > 0) {
+			utils.ErrorResponse(c, http.StatusBadRequest, "DIRECTION_MISMATCH", "İşlem türü ekstre satırının yönüyle uyuşmuyor", nil)
+			return
+		}
+
+		var otherLine string
+		err = h.db.QueryRow("SELECT id FROM bank_statement_lines WHERE transaction_id = ? AND id != ? AND user_id = ?",
+			req.TransactionID, line.ID, userID).Scan(&otherLine)
+		if err == nil {
+			utils.ErrorResponse(c, http.StatusConflict, "TRANSACTION_ALREADY_MATCHED", "İşlem başka bir ekstre satırıyla eşleşmiş", otherLine)
+			return
+		}
+		transactionID = req.TransactionID
+	case models.StatementLineUnmatched, models.StatementLineIgnored:
+		// Oluşturulan işlemin bağlantısı kaldırılırsa işlem korunur, satır yeniden eşleştirilebilir
+	default:
+		utils.ErrorResponse(c, http.StatusBadRequest, "INVALID_STATUS", "Geçersiz satır durumu",
+			[]string{models.StatementLineMatched, models.StatementLineUnmatched, models.StatementLineIgnored})
+		return
+	}
+
+	_, err = h.db.Exec("UPDATE bank_statement_lines SET status = ?, transaction_id = ?, match_score = NULL WHERE id = ? AND user_id = ?",
+		req.Status, transactionID, line.ID, userID)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusInternalServerError, "UPDATE_ERROR", "Ekstre satırı güncellenemedi", err.Error())
+		return
+	}
+
+	line, err = scanBankStatementLine(h.db.QueryRow(bankStatementLineSelect+" WHERE id = ? AND user_id = ?", line.ID, userID))
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusInternalServerError, "FETCH_ERROR", "Ekstre satırı getirilemedi", err.Error())
+		return
+	}
+
+	utils.SuccessResponse(c, line, "Ekstre satırı başarıyla güncellendi")
+}
+
+// CreateTransactionsFromStatement ekstre satırlarından işlem oluşturma
+// @Summary Eşleşmeyen satırlardan işlem oluşturma
+// @Description Eşleşmeyen ekstre satırlarını tek adımda işleme dönüştürür; giriş hareketleri gelir, çıkış hareketleri gider olarak kaydedilir. Satır seçilmezse hesabın tüm eşleşmeyen satırları kullanılır
+// @ID createTransactionsFromStatement
+// @Tags Finance
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Banka hesabı ID"
+// @Param request body models.BankStatementTransactionsRequest false "Satırlar, kategori ve etiketler"
+// @Success 201 {object} models.APIResponse{data=[]models.Transaction}
+// @Failure 400 {object} models.APIResponse
+// @Failure 401 {object} models.APIResponse
+// @Failure 404 {object} models.APIResponse
+// @Router /finance/bank-accounts/{id}/lines/create-transactions [post]
+func (h *FinanceHandler) CreateTransactionsFromStatement(c *gin.Context) {
+	userID, err := utils.GetUserID(c)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusUnauthorized, "UNAUTHORIZED", "Kullanıcı kimliği doğrulanamadı", nil)
+		return
+	}
+
+	var req models.BankStatementTransactionsRequest
+	if err := c.ShouldBindJSON(&req); err != nil && err != io.EOF {
+		utils.ErrorResponse(c, http.StatusBadRequest, "INVALID_REQUEST", "Geçersiz istek formatı", err.Error())
+		return
+	}
+
+	account, ok := h.bankAccountOr404(c, userID)
+	if !ok {
+		return
+	}
+
+	tags, err := parseTransactionTags(req.Tags)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusBadRequest, "INVALID_TAGS", err.Error(), nil)
+		return
+	}
+
+	category := strings.TrimSpace(req.Category)
+	if category == "" {
+		category = defaultStatementCategory
+	}
+
+	query := bankStatementLineSelect + " WHERE account_id = ? AND user_id = ? AND status = ?"
+	args := []interface{}{account.ID, userID, models.StatementLineUnmatched}
+	if len(req.LineIDs) > 0 {
+		query += " AND id IN (?" + strings.Repeat(", ?", len(req.LineIDs)-1) + ")"
+		for _, id := range req.LineIDs {
+			args = append(args, id)
+		}
+	}
+
+	rows, err := h.db.Query(query+" ORDER BY date", args...)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusInternalServerError, "DB_ERROR", "Ekstre satırları getirilemedi", err.Error())
+		return
+	}
+	var lines []models.BankStatementLine
+	for rows.Next() {
+		line, err := scanBankStatementLine(rows)
+		if err != nil {
+			continue
+		}
+		lines = append(lines, line)
+	}
+	rows.Close()
+
+	if len(lines) == 0 {
+		utils.ErrorResponse(c, http.StatusBadRequest, "NO_UNMATCHED_LINES", "İşleme dönüştürülecek eşleşmeyen satır bulunamadı", nil)
+		return
+	}
+
+	transactions := []models.Transaction{}
+	for _, line := range lines {
+		transaction := models.Transaction{
+			Type:          "expense",
+			Category:      category,
+			Description:   line.Description,
+			Amount:        line.Amount,
+			Currency:      account.Currency,
+			Date:          line.Date,
+			Status:        "completed",
+			PaymentMethod: "bank_transfer",
+			Notes:         "Banka ekstresinden oluşturuldu: " + account.Name,
+		}
+		if line.Amount > 0 {
+			transaction.Type = "income"
+		} else {
+			transaction.Amount = -line.Amount
+		}
+		if line.Reference != "" {
+			transaction.Notes += " (" + line.Reference + ")"
+		}
+
+		transactionID, err := h.insertTransaction(userID, transaction, tags)
+		if err != nil {
+			utils.ErrorResponse(c, http.StatusInternalServerError, "DB_ERROR", "İşlem oluşturulamadı", err.Error())
+			return
+		}
+		_, err = h.db.Exec("UPDATE bank_statement_lines SET status = ?, transaction_id = ?, match_score = NULL WHERE id = ? AND user_id = ?",
+			models.StatementLineCreated, transactionID, line.ID, userID)
+		if err != nil {
+			utils.ErrorResponse(c, http.StatusInternalServerError, "UPDATE_ERROR", "Ekstre satırı güncellenemedi", err.Error())
+			return
+		}
+
+		created, err := h.getTransaction(transactionID, userID)
+		if err != nil {
+			utils.ErrorResponse(c, http.StatusInternalServerError, "FETCH_ERROR", "Oluşturulan işlem getirilemedi", err.Error())
+			return
+		}
+		transactions = append(transactions, created)
+	}
+
+	utils.CreatedResponse(c, transactions, strconv.Itoa(len(transactions))+" işlem ekstreden oluşturuldu")
+}
+
+// statementLineStatuses geçerli ekstre satırı durumları
+var statementLineStatuses = []string{models.StatementLineUnmatched, models.StatementLineMatched, models.StatementLineCreated, models.StatementLineIgnored}
+
+// isStatementLineStatus durumun geçerli bir ekstre satırı durumu olup olmadığını döner
+func isStatementLineStatus(status string) bool {
+	for _, value := range statementLineStatuses {
+		if value == status {
+			return true
+		}
+	}
+	return false
+}
+
+// matchToleranceDays eşleştirme gün toleransını okur; geçersizse hata yanıtı yazar
+func matchToleranceDays(c *gin.Context, value string) (int, bool) {
+	if value == "" {
+		return services.DefaultMatchToleranceDays, true
+	}
+	days, err := strconv.Atoi(value)
+	if err != nil || days < 0 || days > 30 {
+		utils.ErrorResponse(c, http.StatusBadRequest, "INVALID_TOLERANCE", "Gün toleransı 0 ile 30 arasında olmalıdır", nil)
+		return 0, false
+	}
+	return days, true
+}
+
+// bankAccountOr404 hesabı mutabakat durumuyla getirir; bulunamazsa hata yanıtı yazar
+func (h *FinanceHandler) bankAccountOr404(c *gin.Context, userID string) (models.BankAccount, bool) {
+	account, err := h.getBankAccount(c.Param("id"), userID)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			utils.ErrorResponse(c, http.StatusNotFound, "BANK_ACCOUNT_NOT_FOUND", "Banka hesabı bulunamadı", nil)
+		} else {
+			utils.ErrorResponse(c, http.StatusInternalServerError, "DB_ERROR", "Banka hesabı getirilemedi", err.Error())
+		}
+		return account, false
+	}
+	return account, true
+}
+
+// getBankAccount banka hesabını mutabakat durumuyla getirir
+func (h *FinanceHandler) getBankAccount(accountID, userID string) (models.BankAccount, error) {
+	account, err := scanBankAccount(h.db.QueryRow(bankAccountSelect+" WHERE id = ? AND user_id = ?", accountID, userID))
+	if err != nil {
+		return account, err
+	}
+
+	reconciliation, err := h.bank.Reconciliation(userID, account.ID, account.OpeningBalance)
+	if err != nil {
+		return account, err
+	}
+	account.Reconciliation = &reconciliation
+	return account, nil
+}
+
+// scanBankAccount bankAccountSelect ile seçilen satırı hesaba çevirir
+func scanBankAccount(row interface{ Scan(...interface{}) error }) (models.BankAccount, error) {
+	var account models.BankAccount
+	err := row.Scan(&account.ID, &account.UserID, &account.Name, &account.BankName, &account.AccountNumber,
+		&account.Currency, &account.OpeningBalance, &account.CreatedAt, &account.UpdatedAt)
+	account.AccountNumber = services.RevealField(services.ColumnBankAccountNumber, account.AccountNumber)
+	return account, err
+}
+
+// scanBankStatementLine bankStatementLineSelect ile seçilen satırı ekstre satırına çevirir
+func scanBankStatementLine(row interface{ Scan(...interface{}) error }) (models.BankStatementLine, error) {
+	var line models.BankStatementLine
+	var transactionID sql.NullString
+	var matchScore sql.NullFloat64
+
+	err := row.Scan(&line.ID, &line.AccountID, &line.StatementID, &line.Date, &line.Amount, &line.Description,
+		&line.Reference, &line.Status, &transactionID, &matchScore, &line.CreatedAt)
+	if err != nil {
+		return line, err
+	}
+
+	if transactionID.Valid {
+		line.TransactionID = &transactionID.String
+	}
+	if matchScore.Valid {
+		line.MatchScore = &matchScore.Float64
+	}
+	return line, nil
+}
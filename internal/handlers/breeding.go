@@ -0,0 +1,210 @@
+package handlers
+
+import (
+	"database/sql"
+	"errors"
+	"net/http"
+
+	"agri-management-api/internal/models"
+	"agri-management-api/internal/services"
+	"agri-management-api/internal/utils"
+
+	"github.com/gin-gonic/gin"
+)
+
+// BreedingHandler hayvanların tohumlama, gebelik ve doğum kayıtlarını yönetir
+type BreedingHandler struct {
+	db         *sql.DB
+	breeding   *services.BreedingService
+	eventRules *services.EventRuleService
+}
+
+// NewBreedingHandler yeni breeding handler oluşturur
+func NewBreedingHandler(db *sql.DB) *BreedingHandler {
+	return &BreedingHandler{
+		db:         db,
+		breeding:   services.NewBreedingService(db),
+		eventRules: services.NewEventRuleService(db),
+	}
+}
+
+// GetBreedingRecords hayvanın üreme kayıtları
+// @Summary Hayvanın üreme kayıtları
+// @Description Dişi hayvanın tohumlama/aşım kayıtlarını gebelik durumu, beklenen doğum tarihi ve bağlı yavrularıyla en yeniden eskiye listeler
+// @ID getBreedingRecords
+// @Tags Livestock
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Hayvan ID"
+// @Success 200 {object} models.APIResponse{data=[]models.BreedingRecord}
+// @Failure 401 {object} models.APIResponse
+// @Failure 404 {object} models.APIResponse
+// @Router /livestock/{id}/breeding [get]
+func (h *BreedingHandler) GetBreedingRecords(c *gin.Context) {
+	userID, err := utils.GetUserID(c)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusUnauthorized, "UNAUTHORIZED", "Kullanıcı kimliği doğrulanamadı", nil)
+		return
+	}
+
+	records, err := h.breeding.List(userID, c.Param("id"))
+	if err != nil {
+		writeBreedingError(c, err, "Üreme kayıtları alınamadı")
+		return
+	}
+
+	utils.SuccessResponse(c, records, "Üreme kayıtları başarıyla getirildi")
+}
+
+// GetBreedingRecord üreme kaydı
+// @Summary Üreme kaydı
+// @Description Hayvanın üreme kaydını bağlı yavrularıyla getirir
+// @ID getBreedingRecord
+// @Tags Livestock
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Hayvan ID"
+// @Param breedingId path string true "Üreme kaydı ID"
+// @Success 200 {object} models.APIResponse{data=models.BreedingRecord}
+// @Failure 401 {object} models.APIResponse
+// @Failure 404 {object} models.APIResponse
+// @Router /livestock/{id}/breeding/{breedingId} [get]
+func (h *BreedingHandler) GetBreedingRecord(c *gin.Context) {
+	userID, err := utils.GetUserID(c)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusUnauthorized, "UNAUTHORIZED", "Kullanıcı kimliği doğrulanamadı", nil)
+		return
+	}
+
+	record, err := h.breeding.Get(userID, c.Param("id"), c.Param("breedingId"))
+	if err != nil {
+		writeBreedingError(c, err, "Üreme kaydı alınamadı")
+		return
+	}
+
+	utils.SuccessResponse(c, record, "Üreme kaydı başarıyla getirildi")
+}
+
+// CreateBreedingRecord üreme kaydı ekleme
+// @Summary Üreme kaydı ekle
+// @Description Dişi hayvana tohumlama/aşım kaydı ekler. expectedBirthDate verilmezse türün gebelik süresiyle hesaplanır ve takvime beklenen doğum etkinliği eklenir. Gebelik doğrulanınca (confirmed) sağlıklı hayvanın durumu pregnant olur. offspringIds ile doğan yavrular kayda bağlanır; yavruların boş anne/baba bilgisi doldurulur
+// @ID createBreedingRecord
+// @Tags Livestock
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Hayvan ID"
+// @Param request body models.BreedingRecordRequest true "Üreme kaydı bilgileri"
+// @Success 201 {object} models.APIResponse{data=models.BreedingRecord}
+// @Failure 400 {object} models.APIResponse
+// @Failure 401 {object} models.APIResponse
+// @Failure 404 {object} models.APIResponse
+// @Router /livestock/{id}/breeding [post]
+func (h *BreedingHandler) CreateBreedingRecord(c *gin.Context) {
+	userID, err := utils.GetUserID(c)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusUnauthorized, "UNAUTHORIZED", "Kullanıcı kimliği doğrulanamadı", nil)
+		return
+	}
+
+	var req models.BreedingRecordRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		utils.ErrorResponse(c, http.StatusBadRequest, "INVALID_REQUEST", "Geçersiz istek formatı", err.Error())
+		return
+	}
+
+	record, err := h.breeding.Create(userID, c.Param("id"), req)
+	if err != nil {
+		writeBreedingError(c, err, "Üreme kaydı oluşturulamadı")
+		return
+	}
+	h.eventRules.SyncQuietly(userID, models.EventRuleExpectedBirth)
+
+	utils.CreatedResponse(c, record, "Üreme kaydı başarıyla oluşturuldu")
+}
+
+// UpdateBreedingRecord üreme kaydı güncelleme
+// @Summary Üreme kaydını güncelle
+// @Description Gebelik durumu, beklenen/gerçekleşen doğum tarihi ve yavrular dahil üreme kaydını günceller; takvimdeki beklenen doğum etkinliği buna göre güncellenir veya kaldırılır. offspringIds gönderilmezse bağlı yavrular değişmez, boş liste bağlantıları kaldırır. Gebelik sona erince pregnant durumu healthy olur
+// @ID updateBreedingRecord
+// @Tags Livestock
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Hayvan ID"
+// @Param breedingId path string true "Üreme kaydı ID"
+// @Param request body models.BreedingRecordRequest true "Üreme kaydı bilgileri"
+// @Success 200 {object} models.APIResponse{data=models.BreedingRecord}
+// @Failure 400 {object} models.APIResponse
+// @Failure 401 {object} models.APIResponse
+// @Failure 404 {object} models.APIResponse
+// @Router /livestock/{id}/breeding/{breedingId} [put]
+func (h *BreedingHandler) UpdateBreedingRecord(c *gin.Context) {
+	userID, err := utils.GetUserID(c)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusUnauthorized, "UNAUTHORIZED", "Kullanıcı kimliği doğrulanamadı", nil)
+		return
+	}
+
+	var req models.BreedingRecordRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		utils.ErrorResponse(c, http.StatusBadRequest, "INVALID_REQUEST", "Geçersiz istek formatı", err.Error())
+		return
+	}
+
+	record, err := h.breeding.Update(userID, c.Param("id"), c.Param("breedingId"), req)
+	if err != nil {
+		writeBreedingError(c, err, "Üreme kaydı güncellenemedi")
+		return
+	}
+	h.eventRules.SyncQuietly(userID, models.EventRuleExpectedBirth)
+
+	utils.SuccessResponse(c, record, "Üreme kaydı başarıyla güncellendi")
+}
+
+// DeleteBreedingRecord üreme kaydı silme
+// @Summary Üreme kaydını sil
+// @Description Üreme kaydını siler; yavruların kayıtla bağlantısı kaldırılır ve beklenen doğum etkinliği takvimden çıkarılır
+// @ID deleteBreedingRecord
+// @Tags Livestock
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Hayvan ID"
+// @Param breedingId path string true "Üreme kaydı ID"
+// @Success 200 {object} models.APIResponse
+// @Failure 401 {object} models.APIResponse
+// @Failure 404 {object} models.APIResponse
+// @Router /livestock/{id}/breeding/{breedingId} [delete]
+func (h *BreedingHandler) DeleteBreedingRecord(c *gin.Context) {
+	userID, err := utils.GetUserID(c)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusUnauthorized, "UNAUTHORIZED", "Kullanıcı kimliği doğrulanamadı", nil)
+		return
+	}
+
+	if err := h.breeding.Delete(userID, c.Param("id"), c.Param("breedingId")); err != nil {
+		writeBreedingError(c, err, "Üreme kaydı silinemedi")
+		return
+	}
+	h.eventRules.SyncQuietly(userID, models.EventRuleExpectedBirth)
+
+	utils.SuccessResponse(c, nil, "Üreme kaydı başarıyla silindi")
+}
+
+// writeBreedingError servis hatasını HTTP yanıtına çevirir
+func writeBreedingError(c *gin.Context, err error, message string) {
+	switch {
+	case errors.Is(err, services.ErrLivestockNotFound):
+		utils.ErrorResponse(c, http.StatusNotFound, "ANIMAL_NOT_FOUND", "Hayvan bulunamadı", nil)
+	case errors.Is(err, services.ErrBreedingRecordNotFound):
+		utils.ErrorResponse(c, http.StatusNotFound, "BREEDING_RECORD_NOT_FOUND", "Üreme kaydı bulunamadı", nil)
+	case errors.Is(err, services.ErrBreedingMaleAnimal):
+		utils.ErrorResponse(c, http.StatusBadRequest, "INVALID_ANIMAL", "Üreme kaydı yalnızca dişi hayvanlara girilebilir", nil)
+	case errors.Is(err, services.ErrInvalidSire):
+		utils.ErrorResponse(c, http.StatusBadRequest, "INVALID_SIRE", "Baba hayvan bulunamadı veya dişi", nil)
+	case errors.Is(err, services.ErrOffspringNotFound):
+		utils.ErrorResponse(c, http.StatusBadRequest, "INVALID_OFFSPRING", "Yavru hayvan bulunamadı", nil)
+	default:
+		utils.ErrorResponse(c, http.StatusInternalServerError, "DB_ERROR", message, err.Error())
+	}
+}
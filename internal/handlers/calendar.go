@@ -1,10 +1,17 @@
 package handlers
 
 import (
+	"bytes"
 	"database/sql"
+	"fmt"
+	"log"
 	"net/http"
+	"strconv"
+	"strings"
+	"time"
 
 	"agri-management-api/internal/models"
+	"agri-management-api/internal/services"
 	"agri-management-api/internal/utils"
 
 	"github.com/gin-gonic/gin"
@@ -12,17 +19,32 @@ import (
 
 // CalendarHandler takvim işlemlerini yönetir
 type CalendarHandler struct {
-	db *sql.DB
+	db            *sql.DB
+	notifications *services.NotificationService
+	exports       *services.CalendarExportService
+	heatmap       *services.CalendarHeatmapService
+	eventRules    *services.EventRuleService
+	farms         *services.FarmService
+	formats       *services.FormattingService
 }
 
 // NewCalendarHandler yeni calendar handler oluşturur
 func NewCalendarHandler(db *sql.DB) *CalendarHandler {
-	return &CalendarHandler{db: db}
+	return &CalendarHandler{
+		db:            db,
+		notifications: services.NewNotificationService(db),
+		exports:       services.NewCalendarExportService(db),
+		heatmap:       services.NewCalendarHeatmapService(db),
+		eventRules:    services.NewEventRuleService(db),
+		farms:         services.NewFarmService(db),
+		formats:       services.NewFormattingService(db),
+	}
 }
 
 // GetEvents etkinlik listesi
 // @Summary Etkinlik listesi
 // @Description Takvim etkinliklerini listeler
+// @ID getEvents
 // @Tags Calendar
 // @Accept json
 // @Produce json
@@ -121,6 +143,7 @@ func (h *CalendarHandler) GetEvents(c *gin.Context) {
 // CreateEvent yeni etkinlik ekleme
 // @Summary Yeni etkinlik ekleme
 // @Description Yeni takvim etkinliği oluşturur
+// @ID createEvent
 // @Tags Calendar
 // @Accept json
 // @Produce json
@@ -171,8 +194,8 @@ func (h *CalendarHandler) CreateEvent(c *gin.Context) {
 	err = h.db.QueryRow(`
 		SELECT id, user_id, title, description, type, start_date, end_date, is_all_day,
 		       status, priority, location, created_at, updated_at
-		FROM events WHERE id = ?
-	`, eventID).Scan(
+		FROM events WHERE id = ? AND user_id = ?
+	`, eventID, userID).Scan(
 		&event.ID, &event.UserID, &event.Title, &event.Description, &event.Type,
 		&startDate, &endDate, &event.IsAllDay, &event.Status, &event.Priority,
 		&event.Location, &event.CreatedAt, &event.UpdatedAt,
@@ -186,16 +209,13 @@ func (h *CalendarHandler) CreateEvent(c *gin.Context) {
 	event.StartDate = utils.NullTimeToPtr(startDate)
 	event.EndDate = utils.NullTimeToPtr(endDate)
 
-	c.JSON(http.StatusCreated, models.APIResponse{
-		Success: true,
-		Data:    event,
-		Message: "Etkinlik başarıyla oluşturuldu",
-	})
+	utils.CreatedResponse(c, event, "Etkinlik başarıyla oluşturuldu")
 }
 
 // GetEvent etkinlik detayları
 // @Summary Etkinlik detayları
 // @Description Belirli bir etkinliğin detaylarını getirir
+// @ID getEvent
 // @Tags Calendar
 // @Accept json
 // @Produce json
@@ -243,12 +263,13 @@ func (h *CalendarHandler) GetEvent(c *gin.Context) {
 	event.StartDate = utils.NullTimeToPtr(startDate)
 	event.EndDate = utils.NullTimeToPtr(endDate)
 
-	utils.SuccessResponse(c, event, "Etkinlik detayları başarıyla getirildi")
+	utils.DetailResponse(c, event, services.EntityLinks("event", event.ID), "Etkinlik detayları başarıyla getirildi")
 }
 
 // UpdateEvent etkinlik güncelleme
 // @Summary Etkinlik güncelleme
-// @Description Mevcut etkinlik bilgilerini günceller
+// @Description Mevcut etkinlik bilgilerini günceller; kurallarla otomatik oluşturulmuş etkinlik düzenlenince kilitlenir ve kurallar tarafından artık değiştirilmez
+// @ID updateEvent
 // @Tags Calendar
 // @Accept json
 // @Produce json
@@ -293,6 +314,11 @@ func (h *CalendarHandler) UpdateEvent(c *gin.Context) {
 		return
 	}
 
+	// Otomatik oluşturulan etkinlik elle düzenlendiyse kurallar artık değiştirmez
+	if err := h.eventRules.Lock(userID, eventID); err != nil {
+		log.Printf("Otomatik etkinlik kilitlenemedi (%s): %v", eventID, err)
+	}
+
 	// Güncellenmiş etkinliği getir
 	h.GetEvent(c)
 }
@@ -300,14 +326,17 @@ func (h *CalendarHandler) UpdateEvent(c *gin.Context) {
 // DeleteEvent etkinlik silme
 // @Summary Etkinlik silme
 // @Description Belirli bir etkinliği siler
+// @ID deleteEvent
 // @Tags Calendar
 // @Accept json
 // @Produce json
 // @Security BearerAuth
 // @Param id path string true "Etkinlik ID"
+// @Param force query bool false "Başka kayıtlarla bağlantısı olsa da sil"
 // @Success 200 {object} models.APIResponse
 // @Failure 401 {object} models.APIResponse
 // @Failure 404 {object} models.APIResponse
+// @Failure 409 {object} models.APIResponse
 // @Router /calendar/events/{id} [delete]
 func (h *CalendarHandler) DeleteEvent(c *gin.Context) {
 	userID, err := utils.GetUserID(c)
@@ -322,6 +351,10 @@ func (h *CalendarHandler) DeleteEvent(c *gin.Context) {
 		return
 	}
 
+	if !confirmLinkedDelete(c, h.db, userID, "event", eventID) {
+		return
+	}
+
 	// Etkinliği sil
 	result, err := h.db.Exec("DELETE FROM events WHERE id = ? AND user_id = ?", eventID, userID)
 	if err != nil {
@@ -335,12 +368,15 @@ func (h *CalendarHandler) DeleteEvent(c *gin.Context) {
 		return
 	}
 
+	removeRecordLinks(h.db, userID, "event", eventID)
+
 	utils.SuccessResponse(c, nil, "Etkinlik başarıyla silindi")
 }
 
 // UpdateEventStatus etkinlik durumu güncelleme
 // @Summary Etkinlik durumu güncelleme
 // @Description Etkinlik durumunu günceller
+// @ID updateEventStatus
 // @Tags Calendar
 // @Accept json
 // @Produce json
@@ -375,7 +411,7 @@ func (h *CalendarHandler) UpdateEventStatus(c *gin.Context) {
 	}
 
 	// Etkinlik durumunu güncelle
-	_, err = h.db.Exec(`
+	result, err := h.db.Exec(`
 		UPDATE events 
 		SET status = ?, updated_at = CURRENT_TIMESTAMP
 		WHERE id = ? AND user_id = ?
@@ -386,18 +422,24 @@ func (h *CalendarHandler) UpdateEventStatus(c *gin.Context) {
 		return
 	}
 
+	if rowsAffected, _ := result.RowsAffected(); rowsAffected == 0 {
+		utils.ErrorResponse(c, http.StatusNotFound, "EVENT_NOT_FOUND", "Etkinlik bulunamadı", nil)
+		return
+	}
+
 	utils.SuccessResponse(c, nil, "Etkinlik durumu başarıyla güncellendi")
 }
 
 // GetCalendarStatistics takvim istatistikleri
 // @Summary Takvim istatistikleri
 // @Description Takvim istatistiklerini getirir
+// @ID getCalendarStatistics
 // @Tags Calendar
 // @Accept json
 // @Produce json
 // @Security BearerAuth
 // @Param period query string false "Periyot"
-// @Success 200 {object} models.APIResponse{data=map[string]interface{}}
+// @Success 200 {object} models.APIResponse{data=models.CalendarStatistics}
 // @Failure 401 {object} models.APIResponse
 // @Router /calendar/statistics [get]
 func (h *CalendarHandler) GetCalendarStatistics(c *gin.Context) {
@@ -462,7 +504,7 @@ func (h *CalendarHandler) GetCalendarStatistics(c *gin.Context) {
 	}
 	defer rows.Close()
 
-	var eventsByType []map[string]interface{}
+	eventsByType := []models.EventTypeCount{}
 	for rows.Next() {
 		var eventType string
 		var count int
@@ -472,20 +514,260 @@ func (h *CalendarHandler) GetCalendarStatistics(c *gin.Context) {
 			continue
 		}
 
-		eventsByType = append(eventsByType, map[string]interface{}{
-			"type":  eventType,
-			"count": count,
+		eventsByType = append(eventsByType, models.EventTypeCount{
+			Type:  eventType,
+			Count: count,
 		})
 	}
 
-	statistics := map[string]interface{}{
-		"totalEvents":     totalEvents,
-		"completedEvents": completedEvents,
-		"pendingEvents":   pendingEvents,
-		"todayEvents":     todayEvents,
-		"upcomingEvents":  upcomingEvents,
-		"eventsByType":    eventsByType,
+	statistics := models.CalendarStatistics{
+		TotalEvents:     totalEvents,
+		CompletedEvents: completedEvents,
+		PendingEvents:   pendingEvents,
+		TodayEvents:     todayEvents,
+		UpcomingEvents:  upcomingEvents,
+		EventsByType:    eventsByType,
 	}
 
 	utils.SuccessResponse(c, statistics, "Takvim istatistikleri başarıyla getirildi")
 }
+
+// GetCalendarHeatmap takvim ısı haritası
+// @Summary Takvim ısı haritası
+// @Description Yılın her günü için takvim etkinliği (başlangıç günü), arazi aktivitesi (yapıldığı, yoksa planlandığı gün) ve oluşturulan kayıt (hayvan, arazi, üretim, işlem, hareket, sağlık ve süt kaydı) sayılarını döner. level alanı günün en yoğun güne göre 0-4 arası yoğunluk kademesidir; GitHub tarzı iş yoğunluğu takvimi çizmek için kullanılır
+// @ID getCalendarHeatmap
+// @Tags Calendar
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param year query int false "Yıl (varsayılan: bu yıl)"
+// @Success 200 {object} models.APIResponse{data=models.CalendarHeatmap}
+// @Failure 401 {object} models.APIResponse
+// @Router /calendar/heatmap [get]
+func (h *CalendarHandler) GetCalendarHeatmap(c *gin.Context) {
+	userID, err := utils.GetUserID(c)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusUnauthorized, "UNAUTHORIZED", "Kullanıcı kimliği doğrulanamadı", nil)
+		return
+	}
+
+	year := time.Now().Year()
+	if value, err := strconv.Atoi(c.Query("year")); err == nil && value >= 1900 && value <= 9999 {
+		year = value
+	}
+
+	heatmap, err := h.heatmap.Year(userID, year)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusInternalServerError, "DB_ERROR", "Isı haritası alınamadı", err.Error())
+		return
+	}
+
+	utils.SuccessResponse(c, heatmap, "Isı haritası başarıyla getirildi")
+}
+
+// eventReminderWindow başlamasına bu süreden az kalan bekleyen etkinlikler için hatırlatma gönderilir
+const eventReminderWindow = 24 * time.Hour
+
+// ExportEvents etkinlik dışa aktarımı
+// @Summary Etkinlikleri dışa aktar
+// @Description Başlangıç tarihi aralıktaki takvim etkinliklerini durum, öncelik ve ilişkili kayıt adlarıyla CSV (noktalı virgülle ayrılmış, UTF-8 BOM) veya Excel (XLSX) dosyası olarak indirir
+// @ID exportEvents
+// @Tags Calendar
+// @Accept json
+// @Produce text/csv
+// @Produce application/vnd.openxmlformats-officedocument.spreadsheetml.sheet
+// @Security BearerAuth
+// @Param format query string false "Dosya formatı (csv, xlsx)" default(csv)
+// @Param startDate query string false "Başlangıç tarihi (YYYY-MM-DD)"
+// @Param endDate query string false "Bitiş tarihi (YYYY-MM-DD, dahil)"
+// @Param type query string false "Etkinlik türü"
+// @Param status query string false "Etkinlik durumu"
+// @Success 200 {file} file
+// @Failure 400 {object} models.APIResponse
+// @Failure 401 {object} models.APIResponse
+// @Router /calendar/events/export [get]
+func (h *CalendarHandler) ExportEvents(c *gin.Context) {
+	userID, err := utils.GetUserID(c)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusUnauthorized, "UNAUTHORIZED", "Kullanıcı kimliği doğrulanamadı", nil)
+		return
+	}
+
+	format, filter, ok := calendarExportParams(c)
+	if !ok {
+		return
+	}
+
+	events, err := h.exports.Events(userID, filter)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusInternalServerError, "DB_ERROR", "Etkinlikler alınamadı", err.Error())
+		return
+	}
+	formatter, ok := exportFormatter(c, h.formats, userID, format)
+	if !ok {
+		return
+	}
+
+	writeCalendarExport(c, format, "etkinlikler", "Etkinlikler", services.EventRecords(events, formatter))
+}
+
+// ExportTasks görev dışa aktarımı
+// @Summary Görevleri dışa aktar
+// @Description Planlanan veya gerçekleşen tarihi aralıktaki arazi aktivitelerini görev olarak arazi adı, durum (planned, overdue, completed) ve maliyetiyle CSV (noktalı virgülle ayrılmış, UTF-8 BOM) veya Excel (XLSX) dosyası olarak indirir
+// @ID exportTasks
+// @Tags Calendar
+// @Accept json
+// @Produce text/csv
+// @Produce application/vnd.openxmlformats-officedocument.spreadsheetml.sheet
+// @Security BearerAuth
+// @Param format query string false "Dosya formatı (csv, xlsx)" default(csv)
+// @Param startDate query string false "Başlangıç tarihi (YYYY-MM-DD)"
+// @Param endDate query string false "Bitiş tarihi (YYYY-MM-DD, dahil)"
+// @Param type query string false "Aktivite türü"
+// @Param status query string false "Görev durumu (planned, overdue, completed)"
+// @Success 200 {file} file
+// @Failure 400 {object} models.APIResponse
+// @Failure 401 {object} models.APIResponse
+// @Router /calendar/tasks/export [get]
+func (h *CalendarHandler) ExportTasks(c *gin.Context) {
+	userID, err := utils.GetUserID(c)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusUnauthorized, "UNAUTHORIZED", "Kullanıcı kimliği doğrulanamadı", nil)
+		return
+	}
+
+	format, filter, ok := calendarExportParams(c)
+	if !ok {
+		return
+	}
+	if filter.Status != "" && !services.IsTaskStatus(filter.Status) {
+		utils.ErrorResponse(c, http.StatusBadRequest, "INVALID_STATUS", "Geçersiz görev durumu",
+			[]string{services.TaskStatusPlanned, services.TaskStatusOverdue, services.TaskStatusCompleted})
+		return
+	}
+
+	tasks, err := h.exports.Tasks(userID, filter)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusInternalServerError, "DB_ERROR", "Görevler alınamadı", err.Error())
+		return
+	}
+	formatter, ok := exportFormatter(c, h.formats, userID, format)
+	if !ok {
+		return
+	}
+
+	writeCalendarExport(c, format, "gorevler", "Görevler", services.TaskRecords(tasks, formatter))
+}
+
+// calendarExportParams dışa aktarım formatını ve filtrelerini okur; geçersizse 400 yanıtı yazar
+func calendarExportParams(c *gin.Context) (string, models.CalendarExportFilter, bool) {
+	var filter models.CalendarExportFilter
+
+	format := strings.ToLower(c.DefaultQuery("format", services.CalendarExportCSV))
+	if format != services.CalendarExportCSV && format != services.CalendarExportXLSX {
+		utils.ErrorResponse(c, http.StatusBadRequest, "INVALID_FORMAT", "Geçersiz dosya formatı", []string{services.CalendarExportCSV, services.CalendarExportXLSX})
+		return "", filter, false
+	}
+
+	var ok bool
+	if filter.StartDate, ok = optionalDateQuery(c, "startDate"); !ok {
+		return "", filter, false
+	}
+	if filter.EndDate, ok = optionalDateQuery(c, "endDate"); !ok {
+		return "", filter, false
+	}
+	if filter.StartDate != nil && filter.EndDate != nil && filter.EndDate.Before(*filter.StartDate) {
+		utils.ErrorResponse(c, http.StatusBadRequest, "INVALID_DATE_RANGE", "Bitiş tarihi başlangıç tarihinden önce olamaz", nil)
+		return "", filter, false
+	}
+
+	filter.Type = strings.TrimSpace(c.Query("type"))
+	filter.Status = strings.TrimSpace(c.Query("status"))
+	return format, filter, true
+}
+
+// exportFormatter çiftlik ayarlarındaki tarih ve sayı biçimleriyle dışa aktarım biçimlendiricisini döner; hata
+// varsa yanıtı yazar
+func exportFormatter(c *gin.Context, formats *services.FormattingService, farmID, format string) (services.Formatter, bool) {
+	formatter, err := formats.Formatter(farmID, format)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusInternalServerError, "DB_ERROR", "Ayarlar getirilemedi", err.Error())
+		return formatter, false
+	}
+	return formatter, true
+}
+
+// writeCalendarExport satırları dosya olarak indirir; dosya adı dışa aktarım gününü içerir
+func writeCalendarExport(c *gin.Context, format, name, sheetName string, records [][]string) {
+	var buf bytes.Buffer
+	if err := services.WriteCalendarExport(&buf, format, sheetName, records); err != nil {
+		utils.ErrorResponse(c, http.StatusInternalServerError, "EXPORT_ERROR", "Dışa aktarım dosyası oluşturulamadı", err.Error())
+		return
+	}
+
+	contentType := "text/csv; charset=utf-8"
+	if format == services.CalendarExportXLSX {
+		contentType = "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet"
+	}
+
+	filename := name + "-" + time.Now().Format("20060102") + "." + format
+	c.Header("Content-Disposition", "attachment; filename="+filename)
+	c.Data(http.StatusOK, contentType, buf.Bytes())
+}
+
+// StartReminders yaklaşan etkinlik hatırlatmalarını saatlik olarak arka planda gönderir
+func (h *CalendarHandler) StartReminders() {
+	go func() {
+		ticker := time.NewTicker(time.Hour)
+		defer ticker.Stop()
+
+		for {
+			if err := h.SendEventReminders(); err != nil {
+				log.Printf("Etkinlik hatırlatmaları gönderilemedi: %v", err)
+			}
+			<-ticker.C
+		}
+	}()
+}
+
+// SendEventReminders 24 saat içinde başlayacak bekleyen etkinlikler için hatırlatma gönderir; her etkinlik
+// başlangıç zamanı için bir kez hatırlatılır
+func (h *CalendarHandler) SendEventReminders() error {
+	rows, err := h.db.Query(`
+		SELECT id, user_id, title, start_date, COALESCE(is_all_day, FALSE), COALESCE(priority, 'medium')
+		FROM events
+		WHERE status = 'pending' AND datetime(start_date) BETWEEN datetime('now') AND datetime('now', ?)
+	`, fmt.Sprintf("+%d seconds", int(eventReminderWindow.Seconds())))
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	var reminders []services.Notification
+	for rows.Next() {
+		var id, userID, title, priority string
+		var start time.Time
+		var allDay bool
+		if err := rows.Scan(&id, &userID, &title, &start, &allDay, &priority); err != nil {
+			continue
+		}
+
+		reminders = append(reminders, services.Notification{
+			UserID:       userID,
+			Template:     "event_reminder",
+			Type:         "reminder",
+			Priority:     priority,
+			Topic:        models.NotificationTopicEventReminder,
+			Entity:       &models.RelatedEntity{Type: "event", ID: id, Name: title},
+			Params:       map[string]interface{}{"start": start, "allDay": allDay},
+			DedupeKey:    "event_reminder:" + id + ":" + start.UTC().Format(time.RFC3339),
+			DedupeWindow: 2 * eventReminderWindow,
+		})
+	}
+	if err := rows.Err(); err != nil {
+		return err
+	}
+
+	_, err = h.notifications.CreateBatch(reminders)
+	return err
+}
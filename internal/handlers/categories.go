@@ -0,0 +1,235 @@
+package handlers
+
+import (
+	"database/sql"
+	"net/http"
+	"strings"
+
+	"agri-management-api/internal/models"
+	"agri-management-api/internal/services"
+	"agri-management-api/internal/utils"
+
+	"github.com/gin-gonic/gin"
+)
+
+// CategoryHandler kategori kayıt defteri işlemlerini yönetir
+type CategoryHandler struct {
+	db         *sql.DB
+	categories *services.CategoryService
+}
+
+// NewCategoryHandler yeni category handler oluşturur
+func NewCategoryHandler(db *sql.DB) *CategoryHandler {
+	return &CategoryHandler{
+		db:         db,
+		categories: services.NewCategoryService(db),
+	}
+}
+
+// GetCategories kategori listesi
+// @Summary Kategori listesi
+// @Description Sistem kategorileri ile kullanıcının tanımladığı kategorileri ikon ve renkleriyle listeler
+// @ID getCategories
+// @Tags Categories
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param domain query string false "Kategori alanı (livestock, production)"
+// @Success 200 {object} models.APIResponse{data=[]models.Category}
+// @Failure 401 {object} models.APIResponse
+// @Router /categories [get]
+func (h *CategoryHandler) GetCategories(c *gin.Context) {
+	userID, err := utils.GetUserID(c)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusUnauthorized, "UNAUTHORIZED", "Kullanıcı kimliği doğrulanamadı", nil)
+		return
+	}
+
+	categories, err := h.categories.List(userID, c.Query("domain"))
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusInternalServerError, "DB_ERROR", "Kategoriler alınamadı", err.Error())
+		return
+	}
+
+	utils.SuccessResponse(c, categories, "Kategoriler başarıyla getirildi")
+}
+
+// CreateCategory yeni kategori oluşturma
+// @Summary Kategori oluşturma
+// @Description Kullanıcıya özel kategori oluşturur (örn. ördek, mantar); sistem kategorisiyle aynı anahtar verilirse ikon ve rengini ezer
+// @ID createCategory
+// @Tags Categories
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param request body models.Category true "Kategori bilgileri"
+// @Success 201 {object} models.APIResponse{data=models.Category}
+// @Failure 400 {object} models.APIResponse
+// @Failure 401 {object} models.APIResponse
+// @Failure 409 {object} models.APIResponse
+// @Router /categories [post]
+func (h *CategoryHandler) CreateCategory(c *gin.Context) {
+	userID, err := utils.GetUserID(c)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusUnauthorized, "UNAUTHORIZED", "Kullanıcı kimliği doğrulanamadı", nil)
+		return
+	}
+
+	var req models.Category
+	if err := c.ShouldBindJSON(&req); err != nil {
+		utils.ErrorResponse(c, http.StatusBadRequest, "INVALID_REQUEST", "Geçersiz istek formatı", err.Error())
+		return
+	}
+
+	if req.Domain != models.CategoryDomainLivestock && req.Domain != models.CategoryDomainProduction {
+		utils.ErrorResponse(c, http.StatusBadRequest, "INVALID_DOMAIN", "Geçersiz kategori alanı",
+			[]string{models.CategoryDomainLivestock, models.CategoryDomainProduction})
+		return
+	}
+
+	req.Key = strings.ToLower(strings.TrimSpace(req.Key))
+
+	var exists bool
+	err = h.db.QueryRow(`
+		SELECT 1 FROM categories WHERE user_id = ? AND domain = ? AND key = ?
+	`, userID, req.Domain, req.Key).Scan(&exists)
+	if err == nil {
+		utils.ErrorResponse(c, http.StatusConflict, "CATEGORY_EXISTS", "Bu kategori zaten tanımlı", nil)
+		return
+	}
+
+	categoryID := utils.GenerateID()
+	_, err = h.db.Exec(`
+		INSERT INTO categories (id, user_id, domain, key, label, icon, color, sort_order, created_at, updated_at)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, CURRENT_TIMESTAMP, CURRENT_TIMESTAMP)
+	`, categoryID, userID, req.Domain, req.Key, req.Label, req.Icon, req.Color, req.SortOrder)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusInternalServerError, "DB_ERROR", "Kategori oluşturulamadı", err.Error())
+		return
+	}
+
+	category, err := h.getCategory(categoryID, userID)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusInternalServerError, "FETCH_ERROR", "Oluşturulan kategori getirilemedi", err.Error())
+		return
+	}
+
+	utils.CreatedResponse(c, category, "Kategori başarıyla oluşturuldu")
+}
+
+// UpdateCategory kategori güncelleme
+// @Summary Kategori güncelleme
+// @Description Kullanıcıya ait kategorinin adını, ikonunu, rengini ve sırasını günceller; sistem kategorileri değiştirilemez
+// @ID updateCategory
+// @Tags Categories
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Kategori ID"
+// @Param request body models.Category true "Kategori bilgileri"
+// @Success 200 {object} models.APIResponse{data=models.Category}
+// @Failure 400 {object} models.APIResponse
+// @Failure 401 {object} models.APIResponse
+// @Failure 404 {object} models.APIResponse
+// @Router /categories/{id} [put]
+func (h *CategoryHandler) UpdateCategory(c *gin.Context) {
+	userID, err := utils.GetUserID(c)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusUnauthorized, "UNAUTHORIZED", "Kullanıcı kimliği doğrulanamadı", nil)
+		return
+	}
+
+	categoryID := c.Param("id")
+	if utils.IsEmptyString(categoryID) {
+		utils.ErrorResponse(c, http.StatusBadRequest, "MISSING_ID", "Kategori ID gerekli", nil)
+		return
+	}
+
+	var req struct {
+		Label     string `json:"label" binding:"required"`
+		Icon      string `json:"icon"`
+		Color     string `json:"color"`
+		SortOrder int    `json:"sortOrder"`
+	}
+	if err := c.ShouldBindJSON(&req); err != nil {
+		utils.ErrorResponse(c, http.StatusBadRequest, "INVALID_REQUEST", "Geçersiz istek formatı", err.Error())
+		return
+	}
+
+	result, err := h.db.Exec(`
+		UPDATE categories SET label = ?, icon = ?, color = ?, sort_order = ?, updated_at = CURRENT_TIMESTAMP
+		WHERE id = ? AND user_id = ?
+	`, req.Label, req.Icon, req.Color, req.SortOrder, categoryID, userID)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusInternalServerError, "UPDATE_ERROR", "Kategori güncellenemedi", err.Error())
+		return
+	}
+
+	rowsAffected, _ := result.RowsAffected()
+	if rowsAffected == 0 {
+		utils.ErrorResponse(c, http.StatusNotFound, "CATEGORY_NOT_FOUND", "Kategori bulunamadı", nil)
+		return
+	}
+
+	category, err := h.getCategory(categoryID, userID)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusInternalServerError, "FETCH_ERROR", "Güncellenen kategori getirilemedi", err.Error())
+		return
+	}
+
+	utils.SuccessResponse(c, category, "Kategori başarıyla güncellendi")
+}
+
+// DeleteCategory kategori silme
+// @Summary Kategori silme
+// @Description Kullanıcıya ait kategoriyi siler; sistem kategorileri silinemez
+// @ID deleteCategory
+// @Tags Categories
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Kategori ID"
+// @Success 200 {object} models.APIResponse
+// @Failure 401 {object} models.APIResponse
+// @Failure 404 {object} models.APIResponse
+// @Router /categories/{id} [delete]
+func (h *CategoryHandler) DeleteCategory(c *gin.Context) {
+	userID, err := utils.GetUserID(c)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusUnauthorized, "UNAUTHORIZED", "Kullanıcı kimliği doğrulanamadı", nil)
+		return
+	}
+
+	categoryID := c.Param("id")
+	if utils.IsEmptyString(categoryID) {
+		utils.ErrorResponse(c, http.StatusBadRequest, "MISSING_ID", "Kategori ID gerekli", nil)
+		return
+	}
+
+	result, err := h.db.Exec("DELETE FROM categories WHERE id = ? AND user_id = ?", categoryID, userID)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusInternalServerError, "DELETE_ERROR", "Kategori silinemedi", err.Error())
+		return
+	}
+
+	rowsAffected, _ := result.RowsAffected()
+	if rowsAffected == 0 {
+		utils.ErrorResponse(c, http.StatusNotFound, "CATEGORY_NOT_FOUND", "Kategori bulunamadı", nil)
+		return
+	}
+
+	utils.SuccessResponse(c, nil, "Kategori başarıyla silindi")
+}
+
+// getCategory kullanıcıya ait kategoriyi getirir
+func (h *CategoryHandler) getCategory(categoryID, userID string) (models.Category, error) {
+	var category models.Category
+	err := h.db.QueryRow(`
+		SELECT id, domain, key, label, COALESCE(icon, ''), COALESCE(color, ''), sort_order, created_at, updated_at
+		FROM categories WHERE id = ? AND user_id = ?
+	`, categoryID, userID).Scan(
+		&category.ID, &category.Domain, &category.Key, &category.Label, &category.Icon,
+		&category.Color, &category.SortOrder, &category.CreatedAt, &category.UpdatedAt,
+	)
+	return category, err
+}
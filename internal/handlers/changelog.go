@@ -0,0 +1,214 @@
+package handlers
+
+import (
+	"database/sql"
+	"errors"
+	"net/http"
+
+	"agri-management-api/internal/models"
+	"agri-management-api/internal/services"
+	"agri-management-api/internal/utils"
+
+	"github.com/gin-gonic/gin"
+)
+
+// ChangelogHandler uygulama içi sürüm notlarını ve özellik duyurularını sunar
+type ChangelogHandler struct {
+	db        *sql.DB
+	changelog *services.ChangelogService
+}
+
+// NewChangelogHandler yeni changelog handler oluşturur
+func NewChangelogHandler(db *sql.DB) *ChangelogHandler {
+	return &ChangelogHandler{
+		db:        db,
+		changelog: services.NewChangelogService(db),
+	}
+}
+
+// GetChangelog sürüm notları
+// @Summary Sürüm notları
+// @Description Yayınlanmış sürüm notlarını en yeni sürüm önce olacak şekilde, her birinin oturum açan hesap tarafından görülüp görülmediğiyle birlikte getirir. Mobil uygulama güncellemeden sonra "Yenilikler" penceresini göstermek için appVersion ile kendi sürümünü ve unseen=true göndererek yalnızca görülmemiş notları alabilir; appVersion'dan yeni sürümlerin notları listelenmez
+// @ID getChangelog
+// @Tags Changelog
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param appVersion query string false "İstemcinin uygulama sürümü (ör. 2.1.0)"
+// @Param unseen query bool false "Yalnızca görülmemiş notlar"
+// @Success 200 {object} models.APIResponse{data=models.ChangelogResponse}
+// @Failure 401 {object} models.APIResponse
+// @Router /changelog [get]
+func (h *ChangelogHandler) GetChangelog(c *gin.Context) {
+	accountID, err := utils.GetAccountID(c)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusUnauthorized, "UNAUTHORIZED", "Kullanıcı kimliği doğrulanamadı", nil)
+		return
+	}
+
+	changelog, err := h.changelog.Published(accountID, c.Query("appVersion"), c.Query("unseen") == "true")
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusInternalServerError, "DB_ERROR", "Sürüm notları alınamadı", err.Error())
+		return
+	}
+
+	utils.SuccessResponse(c, changelog, "Sürüm notları başarıyla getirildi")
+}
+
+// MarkChangelogSeen sürüm notlarını görüldü olarak işaretleme
+// @Summary Sürüm notlarını görüldü işaretle
+// @Description Verilen sürüm notlarını oturum açan hesap için görüldü olarak işaretler; ids boş gönderilirse yayınlanmış tüm notlar işaretlenir. Görme durumu hesaba bağlıdır, çiftlik değiştirmek etkilemez
+// @ID markChangelogSeen
+// @Tags Changelog
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param request body models.ChangelogSeenRequest false "İşaretlenecek notlar"
+// @Success 200 {object} models.APIResponse{data=models.ChangelogResponse}
+// @Failure 400 {object} models.APIResponse
+// @Failure 401 {object} models.APIResponse
+// @Router /changelog/seen [post]
+func (h *ChangelogHandler) MarkChangelogSeen(c *gin.Context) {
+	accountID, err := utils.GetAccountID(c)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusUnauthorized, "UNAUTHORIZED", "Kullanıcı kimliği doğrulanamadı", nil)
+		return
+	}
+
+	var req models.ChangelogSeenRequest
+	if c.Request.ContentLength > 0 {
+		if err := c.ShouldBindJSON(&req); err != nil {
+			utils.ErrorResponse(c, http.StatusBadRequest, "INVALID_REQUEST", "Geçersiz istek formatı", err.Error())
+			return
+		}
+	}
+
+	if _, err := h.changelog.MarkSeen(accountID, req.IDs); err != nil {
+		utils.ErrorResponse(c, http.StatusInternalServerError, "DB_ERROR", "Sürüm notları işaretlenemedi", err.Error())
+		return
+	}
+
+	changelog, err := h.changelog.Published(accountID, "", false)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusInternalServerError, "DB_ERROR", "Sürüm notları alınamadı", err.Error())
+		return
+	}
+
+	utils.SuccessResponse(c, changelog, "Sürüm notları görüldü olarak işaretlendi")
+}
+
+// GetAdminChangelog tüm sürüm notları
+// @Summary Tüm sürüm notları
+// @Description Taslaklar dahil tüm sürüm notlarını en yeni sürüm önce olacak şekilde getirir; publishedAt boş olanlar taslaktır. Yönetici rolü gerektirir
+// @ID getAdminChangelog
+// @Tags Admin
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {object} models.APIResponse{data=[]models.ChangelogEntry}
+// @Failure 401 {object} models.APIResponse
+// @Failure 403 {object} models.APIResponse
+// @Router /admin/changelog [get]
+func (h *ChangelogHandler) GetAdminChangelog(c *gin.Context) {
+	entries, err := h.changelog.All()
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusInternalServerError, "DB_ERROR", "Sürüm notları alınamadı", err.Error())
+		return
+	}
+
+	utils.SuccessResponse(c, entries, "Sürüm notları başarıyla getirildi")
+}
+
+// CreateChangelogEntry sürüm notu oluşturma
+// @Summary Sürüm notu oluştur
+// @Description Yeni sürüm notu veya duyuru ekler. publishedAt verilmezse not taslak olarak kalır ve kullanıcılara gösterilmez; ileri bir tarih verilirse o tarihte yayına girer. Yönetici rolü gerektirir
+// @ID createChangelogEntry
+// @Tags Admin
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param request body models.ChangelogEntry true "Sürüm notu"
+// @Success 201 {object} models.APIResponse{data=models.ChangelogEntry}
+// @Failure 400 {object} models.APIResponse
+// @Failure 401 {object} models.APIResponse
+// @Failure 403 {object} models.APIResponse
+// @Router /admin/changelog [post]
+func (h *ChangelogHandler) CreateChangelogEntry(c *gin.Context) {
+	var req models.ChangelogEntry
+	if err := c.ShouldBindJSON(&req); err != nil {
+		utils.ErrorResponse(c, http.StatusBadRequest, "INVALID_REQUEST", "Geçersiz istek formatı", err.Error())
+		return
+	}
+
+	entry, err := h.changelog.Create(req)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusInternalServerError, "CREATE_ERROR", "Sürüm notu oluşturulamadı", err.Error())
+		return
+	}
+
+	utils.CreatedResponse(c, entry, "Sürüm notu başarıyla oluşturuldu")
+}
+
+// UpdateChangelogEntry sürüm notu güncelleme
+// @Summary Sürüm notu güncelle
+// @Description Sürüm notunu günceller; publishedAt boş gönderilirse not yayından kaldırılıp taslağa döner. Kullanıcıların görme durumu korunur. Yönetici rolü gerektirir
+// @ID updateChangelogEntry
+// @Tags Admin
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Sürüm notu ID"
+// @Param request body models.ChangelogEntry true "Sürüm notu"
+// @Success 200 {object} models.APIResponse{data=models.ChangelogEntry}
+// @Failure 400 {object} models.APIResponse
+// @Failure 401 {object} models.APIResponse
+// @Failure 403 {object} models.APIResponse
+// @Failure 404 {object} models.APIResponse
+// @Router /admin/changelog/{id} [put]
+func (h *ChangelogHandler) UpdateChangelogEntry(c *gin.Context) {
+	var req models.ChangelogEntry
+	if err := c.ShouldBindJSON(&req); err != nil {
+		utils.ErrorResponse(c, http.StatusBadRequest, "INVALID_REQUEST", "Geçersiz istek formatı", err.Error())
+		return
+	}
+
+	entry, err := h.changelog.Update(c.Param("id"), req)
+	if errors.Is(err, services.ErrChangelogEntryNotFound) {
+		utils.ErrorResponse(c, http.StatusNotFound, "CHANGELOG_NOT_FOUND", "Sürüm notu bulunamadı", nil)
+		return
+	}
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusInternalServerError, "UPDATE_ERROR", "Sürüm notu güncellenemedi", err.Error())
+		return
+	}
+
+	utils.SuccessResponse(c, entry, "Sürüm notu başarıyla güncellendi")
+}
+
+// DeleteChangelogEntry sürüm notu silme
+// @Summary Sürüm notu sil
+// @Description Sürüm notunu ve kullanıcıların görme kayıtlarını siler. Yönetici rolü gerektirir
+// @ID deleteChangelogEntry
+// @Tags Admin
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Sürüm notu ID"
+// @Success 200 {object} models.APIResponse
+// @Failure 401 {object} models.APIResponse
+// @Failure 403 {object} models.APIResponse
+// @Failure 404 {object} models.APIResponse
+// @Router /admin/changelog/{id} [delete]
+func (h *ChangelogHandler) DeleteChangelogEntry(c *gin.Context) {
+	err := h.changelog.Delete(c.Param("id"))
+	if errors.Is(err, services.ErrChangelogEntryNotFound) {
+		utils.ErrorResponse(c, http.StatusNotFound, "CHANGELOG_NOT_FOUND", "Sürüm notu bulunamadı", nil)
+		return
+	}
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusInternalServerError, "DELETE_ERROR", "Sürüm notu silinemedi", err.Error())
+		return
+	}
+
+	utils.SuccessResponse(c, nil, "Sürüm notu başarıyla silindi")
+}
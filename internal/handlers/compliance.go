@@ -0,0 +1,627 @@
+package handlers
+
+import (
+	"bytes"
+	"database/sql"
+	"net/http"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"agri-management-api/internal/models"
+	"agri-management-api/internal/services"
+	"agri-management-api/internal/utils"
+
+	"github.com/gin-gonic/gin"
+)
+
+// maxEvidenceSize yüklenebilecek en büyük kanıt dosyası boyutu
+const maxEvidenceSize = 20 << 20
+
+// ComplianceHandler sertifikasyon kontrol listeleri ve kanıt arşivini yönetir
+type ComplianceHandler struct {
+	db         *sql.DB
+	store      services.MediaStore
+	compliance *services.ComplianceService
+	signatures *services.DocumentSigningService
+}
+
+// NewComplianceHandler yeni compliance handler oluşturur
+func NewComplianceHandler(db *sql.DB) *ComplianceHandler {
+	store := services.NewMediaStore()
+	return &ComplianceHandler{
+		db:         db,
+		store:      store,
+		compliance: services.NewComplianceService(db, store),
+		signatures: services.NewDocumentSigningService(db),
+	}
+}
+
+// GetChecklists kontrol listesi listesi
+// @Summary Uyum kontrol listeleri
+// @Description Organik ve GlobalGAP sistem listeleri ile kullanıcının tanımladığı listeleri ilerleme özetiyle listeler
+// @ID getChecklists
+// @Tags Compliance
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param standard query string false "Standart (organic, globalgap, custom)"
+// @Success 200 {object} models.APIResponse{data=[]models.ComplianceChecklist}
+// @Failure 401 {object} models.APIResponse
+// @Router /compliance/checklists [get]
+func (h *ComplianceHandler) GetChecklists(c *gin.Context) {
+	userID, err := utils.GetUserID(c)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusUnauthorized, "UNAUTHORIZED", "Kullanıcı kimliği doğrulanamadı", nil)
+		return
+	}
+
+	whereClause := "WHERE (user_id IS NULL OR user_id = ?)"
+	args := []interface{}{userID}
+	if standard := c.Query("standard"); standard != "" {
+		whereClause += " AND standard = ?"
+		args = append(args, standard)
+	}
+
+	rows, err := h.db.Query(checklistSelect+whereClause+" ORDER BY user_id IS NOT NULL, name", args...)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusInternalServerError, "DB_ERROR", "Kontrol listeleri alınamadı", err.Error())
+		return
+	}
+
+	var checklists []models.ComplianceChecklist
+	for rows.Next() {
+		checklist, err := scanChecklist(rows)
+		if err != nil {
+			continue
+		}
+		checklists = append(checklists, checklist)
+	}
+	rows.Close()
+
+	for i := range checklists {
+		_, progress, err := h.compliance.Items(userID, checklists[i])
+		if err != nil {
+			utils.ErrorResponse(c, http.StatusInternalServerError, "DB_ERROR", "Uyum durumları alınamadı", err.Error())
+			return
+		}
+		checklists[i].Progress = &progress
+	}
+	if checklists == nil {
+		checklists = []models.ComplianceChecklist{}
+	}
+
+	utils.SuccessResponse(c, checklists, "Kontrol listeleri başarıyla getirildi")
+}
+
+// GetChecklist kontrol listesi detayı
+// @Summary Uyum kontrol listesi detayı
+// @Description Kontrol listesini gereksinim durumları, notlar ve kanıt dosyalarıyla getirir
+// @ID getChecklist
+// @Tags Compliance
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Kontrol listesi ID"
+// @Success 200 {object} models.APIResponse{data=models.ComplianceChecklist}
+// @Failure 401 {object} models.APIResponse
+// @Failure 404 {object} models.APIResponse
+// @Router /compliance/checklists/{id} [get]
+func (h *ComplianceHandler) GetChecklist(c *gin.Context) {
+	userID, err := utils.GetUserID(c)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusUnauthorized, "UNAUTHORIZED", "Kullanıcı kimliği doğrulanamadı", nil)
+		return
+	}
+
+	checklist, err := h.getChecklistDetail(c.Param("id"), userID)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusNotFound, "CHECKLIST_NOT_FOUND", "Kontrol listesi bulunamadı", nil)
+		return
+	}
+
+	utils.SuccessResponse(c, checklist, "Kontrol listesi başarıyla getirildi")
+}
+
+// CreateChecklist kontrol listesi oluşturma
+// @Summary Uyum kontrol listesi oluşturma
+// @Description Kullanıcıya özel gereksinimler ve denetim paketine eklenecek faaliyet kayıtlarıyla kontrol listesi oluşturur
+// @ID createChecklist
+// @Tags Compliance
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param request body models.ComplianceChecklist true "Kontrol listesi bilgileri"
+// @Success 201 {object} models.APIResponse{data=models.ComplianceChecklist}
+// @Failure 400 {object} models.APIResponse
+// @Failure 401 {object} models.APIResponse
+// @Router /compliance/checklists [post]
+func (h *ComplianceHandler) CreateChecklist(c *gin.Context) {
+	userID, err := utils.GetUserID(c)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusUnauthorized, "UNAUTHORIZED", "Kullanıcı kimliği doğrulanamadı", nil)
+		return
+	}
+
+	var req models.ComplianceChecklist
+	if err := c.ShouldBindJSON(&req); err != nil {
+		utils.ErrorResponse(c, http.StatusBadRequest, "INVALID_REQUEST", "Geçersiz istek formatı", err.Error())
+		return
+	}
+
+	if !validateRequirementCodes(c, req.Requirements) {
+		return
+	}
+
+	requirements, _ := utils.ToJSON(req.Requirements)
+	logSources, _ := utils.ToJSON(nonNilStrings(req.LogSources))
+
+	checklistID := utils.GenerateID()
+	_, err = h.db.Exec(`
+		INSERT INTO compliance_checklists (id, user_id, standard, name, description, requirements, log_sources, created_at, updated_at)
+		VALUES (?, ?, ?, ?, ?, ?, ?, CURRENT_TIMESTAMP, CURRENT_TIMESTAMP)
+	`, checklistID, userID, req.Standard, req.Name, req.Description, requirements, logSources)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusInternalServerError, "DB_ERROR", "Kontrol listesi oluşturulamadı", err.Error())
+		return
+	}
+
+	checklist, err := h.getChecklistDetail(checklistID, userID)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusInternalServerError, "FETCH_ERROR", "Oluşturulan kontrol listesi getirilemedi", err.Error())
+		return
+	}
+
+	utils.CreatedResponse(c, checklist, "Kontrol listesi başarıyla oluşturuldu")
+}
+
+// UpdateChecklist kontrol listesi güncelleme
+// @Summary Uyum kontrol listesi güncelleme
+// @Description Kullanıcıya ait kontrol listesini günceller; sistem listeleri değiştirilemez, kodu korunan gereksinimlerin durumları ve kanıtları saklanır
+// @ID updateChecklist
+// @Tags Compliance
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Kontrol listesi ID"
+// @Param request body models.ComplianceChecklist true "Kontrol listesi bilgileri"
+// @Success 200 {object} models.APIResponse{data=models.ComplianceChecklist}
+// @Failure 400 {object} models.APIResponse
+// @Failure 401 {object} models.APIResponse
+// @Failure 404 {object} models.APIResponse
+// @Router /compliance/checklists/{id} [put]
+func (h *ComplianceHandler) UpdateChecklist(c *gin.Context) {
+	userID, err := utils.GetUserID(c)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusUnauthorized, "UNAUTHORIZED", "Kullanıcı kimliği doğrulanamadı", nil)
+		return
+	}
+
+	checklistID := c.Param("id")
+
+	var req models.ComplianceChecklist
+	if err := c.ShouldBindJSON(&req); err != nil {
+		utils.ErrorResponse(c, http.StatusBadRequest, "INVALID_REQUEST", "Geçersiz istek formatı", err.Error())
+		return
+	}
+
+	if !validateRequirementCodes(c, req.Requirements) {
+		return
+	}
+
+	requirements, _ := utils.ToJSON(req.Requirements)
+	logSources, _ := utils.ToJSON(nonNilStrings(req.LogSources))
+
+	result, err := h.db.Exec(`
+		UPDATE compliance_checklists SET standard = ?, name = ?, description = ?, requirements = ?, log_sources = ?,
+		                                 updated_at = CURRENT_TIMESTAMP
+		WHERE id = ? AND user_id = ?
+	`, req.Standard, req.Name, req.Description, requirements, logSources, checklistID, userID)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusInternalServerError, "UPDATE_ERROR", "Kontrol listesi güncellenemedi", err.Error())
+		return
+	}
+
+	rowsAffected, _ := result.RowsAffected()
+	if rowsAffected == 0 {
+		utils.ErrorResponse(c, http.StatusNotFound, "CHECKLIST_NOT_FOUND", "Kontrol listesi bulunamadı", nil)
+		return
+	}
+
+	checklist, err := h.getChecklistDetail(checklistID, userID)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusInternalServerError, "FETCH_ERROR", "Güncellenen kontrol listesi getirilemedi", err.Error())
+		return
+	}
+
+	utils.SuccessResponse(c, checklist, "Kontrol listesi başarıyla güncellendi")
+}
+
+// DeleteChecklist kontrol listesi silme
+// @Summary Uyum kontrol listesi silme
+// @Description Kullanıcıya ait kontrol listesini durumları ve kanıt dosyalarıyla birlikte siler
+// @ID deleteChecklist
+// @Tags Compliance
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Kontrol listesi ID"
+// @Success 200 {object} models.APIResponse
+// @Failure 401 {object} models.APIResponse
+// @Failure 404 {object} models.APIResponse
+// @Router /compliance/checklists/{id} [delete]
+func (h *ComplianceHandler) DeleteChecklist(c *gin.Context) {
+	userID, err := utils.GetUserID(c)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusUnauthorized, "UNAUTHORIZED", "Kullanıcı kimliği doğrulanamadı", nil)
+		return
+	}
+
+	checklistID := c.Param("id")
+
+	result, err := h.db.Exec("DELETE FROM compliance_checklists WHERE id = ? AND user_id = ?", checklistID, userID)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusInternalServerError, "DELETE_ERROR", "Kontrol listesi silinemedi", err.Error())
+		return
+	}
+
+	rowsAffected, _ := result.RowsAffected()
+	if rowsAffected == 0 {
+		utils.ErrorResponse(c, http.StatusNotFound, "CHECKLIST_NOT_FOUND", "Kontrol listesi bulunamadı", nil)
+		return
+	}
+
+	h.deleteEvidence(userID, checklistID)
+	h.db.Exec("DELETE FROM compliance_statuses WHERE checklist_id = ? AND user_id = ?", checklistID, userID)
+
+	utils.SuccessResponse(c, nil, "Kontrol listesi başarıyla silindi")
+}
+
+// UpdateRequirementStatus gereksinim durumu güncelleme
+// @Summary Gereksinim durumu güncelleme
+// @Description Kontrol listesindeki gereksinimi uygun, uygun değil, uygulanamaz veya beklemede olarak işaretler
+// @ID updateRequirementStatus
+// @Tags Compliance
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Kontrol listesi ID"
+// @Param code path string true "Gereksinim kodu"
+// @Param request body models.ComplianceStatusRequest true "Durum bilgileri"
+// @Success 200 {object} models.APIResponse{data=models.ComplianceItem}
+// @Failure 400 {object} models.APIResponse
+// @Failure 401 {object} models.APIResponse
+// @Failure 404 {object} models.APIResponse
+// @Router /compliance/checklists/{id}/requirements/{code} [put]
+func (h *ComplianceHandler) UpdateRequirementStatus(c *gin.Context) {
+	userID, err := utils.GetUserID(c)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusUnauthorized, "UNAUTHORIZED", "Kullanıcı kimliği doğrulanamadı", nil)
+		return
+	}
+
+	checklist, ok := h.requirementChecklist(c, userID)
+	if !ok {
+		return
+	}
+
+	var req models.ComplianceStatusRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		utils.ErrorResponse(c, http.StatusBadRequest, "INVALID_REQUEST", "Geçersiz istek formatı", err.Error())
+		return
+	}
+
+	var reviewedAt interface{}
+	if req.Status != models.ComplianceStatusPending {
+		reviewedAt = time.Now()
+	}
+
+	_, err = h.db.Exec(`
+		INSERT INTO compliance_statuses (id, user_id, checklist_id, requirement_code, status, notes, reviewed_at, updated_at)
+		VALUES (?, ?, ?, ?, ?, ?, ?, CURRENT_TIMESTAMP)
+		ON CONFLICT (user_id, checklist_id, requirement_code) DO UPDATE SET status = excluded.status, notes = excluded.notes,
+			reviewed_at = excluded.reviewed_at, updated_at = CURRENT_TIMESTAMP
+	`, utils.GenerateID(), userID, checklist.ID, c.Param("code"), req.Status, req.Notes, reviewedAt)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusInternalServerError, "UPDATE_ERROR", "Gereksinim durumu güncellenemedi", err.Error())
+		return
+	}
+
+	item, err := h.getItem(checklist, c.Param("code"), userID)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusInternalServerError, "FETCH_ERROR", "Gereksinim durumu getirilemedi", err.Error())
+		return
+	}
+
+	utils.SuccessResponse(c, item, "Gereksinim durumu başarıyla güncellendi")
+}
+
+// UploadEvidence kanıt dosyası yükleme
+// @Summary Kanıt dosyası yükleme
+// @Description Gereksinime fotoğraf veya belge (PDF, ofis dosyası) kanıtı ekler; dosyalar medya uç noktalarıyla indirilip silinebilir
+// @ID uploadEvidence
+// @Tags Compliance
+// @Accept multipart/form-data
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Kontrol listesi ID"
+// @Param code path string true "Gereksinim kodu"
+// @Param file formData file true "Fotoğraf veya belge"
+// @Success 201 {object} models.APIResponse{data=models.ComplianceItem}
+// @Failure 400 {object} models.APIResponse
+// @Failure 401 {object} models.APIResponse
+// @Failure 404 {object} models.APIResponse
+// @Router /compliance/checklists/{id}/requirements/{code}/evidence [post]
+func (h *ComplianceHandler) UploadEvidence(c *gin.Context) {
+	userID, err := utils.GetUserID(c)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusUnauthorized, "UNAUTHORIZED", "Kullanıcı kimliği doğrulanamadı", nil)
+		return
+	}
+
+	checklist, ok := h.requirementChecklist(c, userID)
+	if !ok {
+		return
+	}
+	code := c.Param("code")
+
+	fileHeader, err := c.FormFile("file")
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusBadRequest, "MISSING_FILE", "Kanıt dosyası gerekli", nil)
+		return
+	}
+	if fileHeader.Size > maxEvidenceSize {
+		utils.ErrorResponse(c, http.StatusBadRequest, "FILE_TOO_LARGE", "Kanıt dosyası çok büyük", nil)
+		return
+	}
+
+	contentType := fileHeader.Header.Get("Content-Type")
+	kind := "document"
+	switch {
+	case strings.HasPrefix(contentType, "image/"):
+		kind = "photo"
+	case contentType == "application/pdf", strings.HasPrefix(contentType, "text/"),
+		strings.HasPrefix(contentType, "application/vnd."), contentType == "application/msword":
+	default:
+		utils.ErrorResponse(c, http.StatusBadRequest, "INVALID_FILE_TYPE", "Yalnızca fotoğraf ve belge dosyaları yüklenebilir", nil)
+		return
+	}
+
+	// Kanıtlar gereksinimin durum kaydına bağlanır; durum kaydı yoksa beklemede olarak oluşturulur
+	_, err = h.db.Exec(`
+		INSERT OR IGNORE INTO compliance_statuses (id, user_id, checklist_id, requirement_code, status, updated_at)
+		VALUES (?, ?, ?, ?, 'pending', CURRENT_TIMESTAMP)
+	`, utils.GenerateID(), userID, checklist.ID, code)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusInternalServerError, "DB_ERROR", "Gereksinim durumu oluşturulamadı", err.Error())
+		return
+	}
+
+	var statusID string
+	err = h.db.QueryRow(`
+		SELECT id FROM compliance_statuses WHERE user_id = ? AND checklist_id = ? AND requirement_code = ?
+	`, userID, checklist.ID, code).Scan(&statusID)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusInternalServerError, "DB_ERROR", "Gereksinim durumu alınamadı", err.Error())
+		return
+	}
+
+	file, err := fileHeader.Open()
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusBadRequest, "INVALID_FILE", "Kanıt dosyası okunamadı", err.Error())
+		return
+	}
+	defer file.Close()
+
+	mediaID := utils.GenerateID()
+	storageKey := filepath.Join(userID, mediaID+strings.ToLower(filepath.Ext(fileHeader.Filename)))
+
+	size, err := h.store.Save(storageKey, file)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusInternalServerError, "STORAGE_ERROR", "Kanıt dosyası kaydedilemedi", err.Error())
+		return
+	}
+
+	_, err = h.db.Exec(`
+		INSERT INTO media_attachments (id, user_id, entity_type, entity_id, kind, filename, content_type,
+		                               size, storage_key, transcript_status, created_at)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, CURRENT_TIMESTAMP)
+	`, mediaID, userID, services.ComplianceEvidenceEntity, statusID, kind, filepath.Base(fileHeader.Filename), contentType,
+		size, storageKey, models.TranscriptStatusNone)
+	if err != nil {
+		h.store.Delete(storageKey)
+		utils.ErrorResponse(c, http.StatusInternalServerError, "DB_ERROR", "Kanıt kaydı oluşturulamadı", err.Error())
+		return
+	}
+
+	item, err := h.getItem(checklist, code, userID)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusInternalServerError, "FETCH_ERROR", "Gereksinim getirilemedi", err.Error())
+		return
+	}
+
+	utils.CreatedResponse(c, item, "Kanıt dosyası başarıyla yüklendi")
+}
+
+// ExportBundle denetim paketi
+// @Summary Denetim paketi
+// @Description Gereksinim durumlarını, kanıt dosyalarını ve kontrol listesine ait faaliyet kayıtlarını denetçiye sunulmak üzere ZIP dosyası olarak indirir. Paket sunucu anahtarıyla imzalanır; ayrık imza X-Document-Signature başlığıyla döner ve denetçi paketin değiştirilmediğini POST /public/documents/verify ile doğrulayabilir
+// @ID exportBundle
+// @Tags Compliance
+// @Produce application/zip
+// @Security BearerAuth
+// @Param id path string true "Kontrol listesi ID"
+// @Param startDate query string false "Faaliyet kayıtları başlangıç tarihi (YYYY-MM-DD, varsayılan: 12 ay önce)"
+// @Param endDate query string false "Faaliyet kayıtları bitiş tarihi (YYYY-MM-DD, varsayılan: bugün)"
+// @Success 200 {file} file
+// @Failure 401 {object} models.APIResponse
+// @Failure 404 {object} models.APIResponse
+// @Router /compliance/checklists/{id}/export [get]
+func (h *ComplianceHandler) ExportBundle(c *gin.Context) {
+	userID, err := utils.GetUserID(c)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusUnauthorized, "UNAUTHORIZED", "Kullanıcı kimliği doğrulanamadı", nil)
+		return
+	}
+
+	checklist, err := h.getChecklist(c.Param("id"), userID)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusNotFound, "CHECKLIST_NOT_FOUND", "Kontrol listesi bulunamadı", nil)
+		return
+	}
+
+	now := time.Now()
+	end := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC).AddDate(0, 0, 1)
+	if endDate, err := time.Parse("2006-01-02", c.Query("endDate")); err == nil {
+		end = endDate.AddDate(0, 0, 1)
+	}
+	start := end.AddDate(-1, 0, 0)
+	if startDate, err := time.Parse("2006-01-02", c.Query("startDate")); err == nil {
+		start = startDate
+	}
+
+	var buf bytes.Buffer
+	if err := h.compliance.WriteBundle(&buf, userID, checklist, start, end); err != nil {
+		utils.ErrorResponse(c, http.StatusInternalServerError, "EXPORT_ERROR", "Denetim paketi oluşturulamadı", err.Error())
+		return
+	}
+
+	filename := "denetim-" + checklist.Standard + "-" + now.Format("20060102") + ".zip"
+	if !signExport(c, h.signatures, userID, models.DocumentTypeComplianceBundle, checklist.ID, filename, "application/zip", buf.Bytes()) {
+		return
+	}
+	c.Header("Content-Disposition", "attachment; filename="+filename)
+	c.Data(http.StatusOK, "application/zip", buf.Bytes())
+}
+
+// checklistSelect kontrol listesi sütunları
+const checklistSelect = `
+	SELECT id, user_id, standard, name, COALESCE(description, ''), requirements, COALESCE(log_sources, '[]'),
+	       created_at, updated_at
+	FROM compliance_checklists
+`
+
+// scanChecklist kontrol listesi satırını okur
+func scanChecklist(row interface{ Scan(...interface{}) error }) (models.ComplianceChecklist, error) {
+	var checklist models.ComplianceChecklist
+	var owner sql.NullString
+	var requirements, logSources string
+
+	err := row.Scan(
+		&checklist.ID, &owner, &checklist.Standard, &checklist.Name, &checklist.Description,
+		&requirements, &logSources, &checklist.CreatedAt, &checklist.UpdatedAt,
+	)
+	if err != nil {
+		return checklist, err
+	}
+
+	checklist.IsSystem = !owner.Valid
+	if err := utils.FromJSON(requirements, &checklist.Requirements); err != nil {
+		return checklist, err
+	}
+	utils.FromJSON(logSources, &checklist.LogSources)
+	checklist.LogSources = nonNilStrings(checklist.LogSources)
+	return checklist, nil
+}
+
+// getChecklist kullanıcının erişebildiği kontrol listesini getirir
+func (h *ComplianceHandler) getChecklist(checklistID, userID string) (models.ComplianceChecklist, error) {
+	return scanChecklist(h.db.QueryRow(checklistSelect+" WHERE id = ? AND (user_id IS NULL OR user_id = ?)", checklistID, userID))
+}
+
+// getChecklistDetail kontrol listesini gereksinim durumları ve ilerleme özetiyle getirir
+func (h *ComplianceHandler) getChecklistDetail(checklistID, userID string) (models.ComplianceChecklist, error) {
+	checklist, err := h.getChecklist(checklistID, userID)
+	if err != nil {
+		return checklist, err
+	}
+
+	items, progress, err := h.compliance.Items(userID, checklist)
+	if err != nil {
+		return checklist, err
+	}
+	checklist.Items = items
+	checklist.Progress = &progress
+	return checklist, nil
+}
+
+// getItem kontrol listesindeki tek gereksinimin durumunu getirir
+func (h *ComplianceHandler) getItem(checklist models.ComplianceChecklist, code, userID string) (models.ComplianceItem, error) {
+	items, _, err := h.compliance.Items(userID, checklist)
+	if err != nil {
+		return models.ComplianceItem{}, err
+	}
+	for _, item := range items {
+		if item.Code == code {
+			return item, nil
+		}
+	}
+	return models.ComplianceItem{}, sql.ErrNoRows
+}
+
+// requirementChecklist yoldaki kontrol listesini ve gereksinim kodunu doğrular; hata varsa yanıtı yazar
+func (h *ComplianceHandler) requirementChecklist(c *gin.Context, userID string) (models.ComplianceChecklist, bool) {
+	checklist, err := h.getChecklist(c.Param("id"), userID)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusNotFound, "CHECKLIST_NOT_FOUND", "Kontrol listesi bulunamadı", nil)
+		return checklist, false
+	}
+
+	for _, requirement := range checklist.Requirements {
+		if requirement.Code == c.Param("code") {
+			return checklist, true
+		}
+	}
+
+	utils.ErrorResponse(c, http.StatusNotFound, "REQUIREMENT_NOT_FOUND", "Gereksinim bulunamadı", nil)
+	return checklist, false
+}
+
+// deleteEvidence kontrol listesine yüklenen kanıt dosyalarını siler
+func (h *ComplianceHandler) deleteEvidence(userID, checklistID string) {
+	rows, err := h.db.Query(`
+		SELECT m.id, m.storage_key FROM media_attachments m
+		JOIN compliance_statuses s ON m.entity_id = s.id
+		WHERE m.user_id = ? AND m.entity_type = ? AND s.checklist_id = ?
+	`, userID, services.ComplianceEvidenceEntity, checklistID)
+	if err != nil {
+		return
+	}
+
+	var mediaIDs, storageKeys []string
+	for rows.Next() {
+		var mediaID, storageKey string
+		if err := rows.Scan(&mediaID, &storageKey); err != nil {
+			continue
+		}
+		mediaIDs = append(mediaIDs, mediaID)
+		storageKeys = append(storageKeys, storageKey)
+	}
+	rows.Close()
+
+	for i, mediaID := range mediaIDs {
+		h.db.Exec("DELETE FROM media_attachments WHERE id = ? AND user_id = ?", mediaID, userID)
+		h.store.Delete(storageKeys[i])
+	}
+}
+
+// validateRequirementCodes gereksinim kodlarının benzersiz olduğunu doğrular; hata varsa yanıtı yazar
+func validateRequirementCodes(c *gin.Context, requirements []models.ComplianceRequirement) bool {
+	seen := map[string]bool{}
+	for _, requirement := range requirements {
+		if seen[requirement.Code] {
+			utils.ErrorResponse(c, http.StatusBadRequest, "DUPLICATE_REQUIREMENT_CODE", "Gereksinim kodları benzersiz olmalı", requirement.Code)
+			return false
+		}
+		seen[requirement.Code] = true
+	}
+	return true
+}
+
+// nonNilStrings boş listeyi JSON'da [] olarak yazmak için nil yerine boş dilim döner
+func nonNilStrings(values []string) []string {
+	if values == nil {
+		return []string{}
+	}
+	return values
+}
@@ -0,0 +1,458 @@
+package handlers
+
+import (
+	"database/sql"
+	"net/http"
+	"strings"
+
+	"agri-management-api/internal/models"
+	"agri-management-api/internal/services"
+	"agri-management-api/internal/utils"
+
+	"github.com/gin-gonic/gin"
+)
+
+// CooperativeHandler kooperatif işlemlerini yönetir
+type CooperativeHandler struct {
+	db *sql.DB
+}
+
+// NewCooperativeHandler yeni cooperative handler oluşturur
+func NewCooperativeHandler(db *sql.DB) *CooperativeHandler {
+	return &CooperativeHandler{db: db}
+}
+
+// GetSummary kooperatif özet dashboard'u
+// @Summary Kooperatif özeti
+// @Description Veri paylaşımına onay veren üye çiftliklerin hayvan sayıları, üretim hacimleri ve uyarılarını toplar
+// @ID getCooperativeSummary
+// @Tags Cooperative
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {object} models.APIResponse{data=models.CooperativeSummary}
+// @Failure 401 {object} models.APIResponse
+// @Failure 403 {object} models.APIResponse
+// @Router /cooperative/summary [get]
+func (h *CooperativeHandler) GetSummary(c *gin.Context) {
+	userID, err := utils.GetUserID(c)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusUnauthorized, "UNAUTHORIZED", "Kullanıcı kimliği doğrulanamadı", nil)
+		return
+	}
+
+	// Sadece onay veren üyeler özete dahil edilir
+	rows, err := h.db.Query(`
+		SELECT u.id, u.name, COALESCE(u.farm_name, ''), COALESCE(u.location, '')
+		FROM cooperative_memberships cm
+		JOIN users u ON cm.member_user_id = u.id
+		WHERE cm.cooperative_admin_id = ? AND cm.consent_status = 'granted'
+		ORDER BY u.farm_name
+	`, userID)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusInternalServerError, "DB_ERROR", "Üye çiftlikler alınamadı", err.Error())
+		return
+	}
+	defer rows.Close()
+
+	var farms []models.CooperativeFarmSummary
+	for rows.Next() {
+		var farm models.CooperativeFarmSummary
+		if err := rows.Scan(&farm.MemberID, &farm.MemberName, &farm.FarmName, &farm.Location); err != nil {
+			continue
+		}
+		farms = append(farms, farm)
+	}
+
+	summary := models.CooperativeSummary{
+		AnimalsByType:    map[string]int{},
+		ProductionVolume: map[string]float64{},
+		Farms:            []models.CooperativeFarmSummary{},
+	}
+
+	for _, farm := range farms {
+		h.fillFarmSummary(&farm)
+
+		summary.TotalHerd += farm.HerdCount
+		summary.TotalSickAnimals += farm.SickAnimals
+		summary.TotalOpenAlerts += farm.OpenAlerts
+		for animalType, count := range farm.AnimalsByType {
+			summary.AnimalsByType[animalType] += count
+		}
+		for unit, amount := range farm.ProductionVolume {
+			summary.ProductionVolume[unit] += amount
+		}
+
+		summary.Farms = append(summary.Farms, farm)
+	}
+	summary.MemberCount = len(summary.Farms)
+
+	h.db.QueryRow(`
+		SELECT COUNT(*) FROM cooperative_memberships
+		WHERE cooperative_admin_id = ? AND consent_status = 'pending'
+	`, userID).Scan(&summary.PendingConsents)
+
+	utils.SuccessResponse(c, summary, "Kooperatif özeti başarıyla getirildi")
+}
+
+// GetMembers kooperatif üye listesi
+// @Summary Kooperatif üyeleri
+// @Description Kooperatife davet edilen üyeleri ve onay durumlarını listeler
+// @ID getMembers
+// @Tags Cooperative
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param status query string false "Onay durumu (pending, granted, revoked)"
+// @Success 200 {object} models.APIResponse{data=[]models.CooperativeMembership}
+// @Failure 401 {object} models.APIResponse
+// @Failure 403 {object} models.APIResponse
+// @Router /cooperative/members [get]
+func (h *CooperativeHandler) GetMembers(c *gin.Context) {
+	userID, err := utils.GetUserID(c)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusUnauthorized, "UNAUTHORIZED", "Kullanıcı kimliği doğrulanamadı", nil)
+		return
+	}
+
+	status := c.DefaultQuery("status", "all")
+
+	whereClause := "WHERE cm.cooperative_admin_id = ?"
+	args := []interface{}{userID}
+
+	if status != "all" {
+		whereClause += " AND cm.consent_status = ?"
+		args = append(args, status)
+	}
+
+	rows, err := h.db.Query(`
+		SELECT cm.id, cm.cooperative_admin_id, cm.member_user_id, u.name, u.email,
+		       COALESCE(u.farm_name, ''), cm.consent_status, cm.consented_at, cm.created_at
+		FROM cooperative_memberships cm
+		JOIN users u ON cm.member_user_id = u.id
+		`+whereClause+`
+		ORDER BY cm.created_at DESC
+	`, args...)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusInternalServerError, "DB_ERROR", "Üyeler alınamadı", err.Error())
+		return
+	}
+	defer rows.Close()
+
+	var members []models.CooperativeMembership
+	for rows.Next() {
+		var member models.CooperativeMembership
+		var consentedAt sql.NullTime
+
+		err := rows.Scan(
+			&member.ID, &member.CooperativeID, &member.MemberID, &member.MemberName, &member.MemberEmail,
+			&member.FarmName, &member.ConsentStatus, &consentedAt, &member.CreatedAt,
+		)
+		if err != nil {
+			continue
+		}
+
+		member.ConsentedAt = utils.NullTimeToPtr(consentedAt)
+		members = append(members, member)
+	}
+
+	utils.SuccessResponse(c, members, "Kooperatif üyeleri başarıyla getirildi")
+}
+
+// InviteMember kooperatife üye davet etme
+// @Summary Üye davet etme
+// @Description E-posta adresiyle bir çiftçiyi kooperatife davet eder; veriler üye onay verene kadar paylaşılmaz
+// @ID inviteMember
+// @Tags Cooperative
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param request body map[string]string true "Üye e-posta adresi (email)"
+// @Success 201 {object} models.APIResponse{data=models.CooperativeMembership}
+// @Failure 400 {object} models.APIResponse
+// @Failure 401 {object} models.APIResponse
+// @Failure 403 {object} models.APIResponse
+// @Failure 404 {object} models.APIResponse
+// @Failure 409 {object} models.APIResponse
+// @Router /cooperative/members [post]
+func (h *CooperativeHandler) InviteMember(c *gin.Context) {
+	userID, err := utils.GetUserID(c)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusUnauthorized, "UNAUTHORIZED", "Kullanıcı kimliği doğrulanamadı", nil)
+		return
+	}
+
+	var req struct {
+		Email string `json:"email" binding:"required,email"`
+	}
+	if err := c.ShouldBindJSON(&req); err != nil {
+		utils.ErrorResponse(c, http.StatusBadRequest, "INVALID_REQUEST", "Geçersiz istek formatı", err.Error())
+		return
+	}
+
+	// Üye kullanıcıyı bul
+	var member models.CooperativeMembership
+	err = h.db.QueryRow(`
+		SELECT id, name, email, COALESCE(farm_name, '') FROM users WHERE email = ?
+	`, strings.TrimSpace(req.Email)).Scan(&member.MemberID, &member.MemberName, &member.MemberEmail, &member.FarmName)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusNotFound, "USER_NOT_FOUND", "Kullanıcı bulunamadı", nil)
+		return
+	}
+
+	if member.MemberID == userID {
+		utils.ErrorResponse(c, http.StatusBadRequest, "INVALID_MEMBER", "Kendinizi üye olarak ekleyemezsiniz", nil)
+		return
+	}
+
+	// Daha önce davet edilmiş mi kontrol et
+	var exists bool
+	err = h.db.QueryRow(`
+		SELECT 1 FROM cooperative_memberships WHERE cooperative_admin_id = ? AND member_user_id = ?
+	`, userID, member.MemberID).Scan(&exists)
+	if err == nil {
+		utils.ErrorResponse(c, http.StatusConflict, "MEMBER_EXISTS", "Bu kullanıcı zaten davet edilmiş", nil)
+		return
+	}
+
+	member.ID = utils.GenerateID()
+	_, err = h.db.Exec(`
+		INSERT INTO cooperative_memberships (id, cooperative_admin_id, member_user_id, consent_status, created_at)
+		VALUES (?, ?, ?, 'pending', CURRENT_TIMESTAMP)
+	`, member.ID, userID, member.MemberID)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusInternalServerError, "DB_ERROR", "Davet oluşturulamadı", err.Error())
+		return
+	}
+
+	member.CooperativeID = userID
+	member.ConsentStatus = "pending"
+	h.db.QueryRow("SELECT created_at FROM cooperative_memberships WHERE id = ?", member.ID).Scan(&member.CreatedAt)
+
+	// Üyeye onay talebi bildirimi gönder
+	var cooperativeName string
+	h.db.QueryRow("SELECT COALESCE(NULLIF(farm_name, ''), name) FROM users WHERE id = ?", userID).Scan(&cooperativeName)
+	services.NewNotificationService(h.db).Create(services.Notification{
+		UserID:   member.MemberID,
+		Title:    "Kooperatif Daveti",
+		Message:  cooperativeName + " çiftlik verilerinize erişim için onayınızı bekliyor.",
+		Type:     "info",
+		Priority: "medium",
+		Topic:    models.NotificationTopicCooperativeInvitation,
+		Entity:   &models.RelatedEntity{Type: "cooperative_membership", ID: member.ID, Name: cooperativeName},
+	})
+
+	utils.CreatedResponse(c, member, "Üye daveti başarıyla oluşturuldu")
+}
+
+// RemoveMember kooperatif üyeliğini sonlandırma
+// @Summary Üyeliği sonlandırma
+// @Description Üyeyi kooperatiften çıkarır
+// @ID removeMember
+// @Tags Cooperative
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Üyelik ID"
+// @Success 200 {object} models.APIResponse
+// @Failure 401 {object} models.APIResponse
+// @Failure 403 {object} models.APIResponse
+// @Failure 404 {object} models.APIResponse
+// @Router /cooperative/members/{id} [delete]
+func (h *CooperativeHandler) RemoveMember(c *gin.Context) {
+	userID, err := utils.GetUserID(c)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusUnauthorized, "UNAUTHORIZED", "Kullanıcı kimliği doğrulanamadı", nil)
+		return
+	}
+
+	membershipID := c.Param("id")
+	if utils.IsEmptyString(membershipID) {
+		utils.ErrorResponse(c, http.StatusBadRequest, "MISSING_ID", "Üyelik ID gerekli", nil)
+		return
+	}
+
+	result, err := h.db.Exec("DELETE FROM cooperative_memberships WHERE id = ? AND cooperative_admin_id = ?", membershipID, userID)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusInternalServerError, "DELETE_ERROR", "Üyelik silinemedi", err.Error())
+		return
+	}
+
+	rowsAffected, _ := result.RowsAffected()
+	if rowsAffected == 0 {
+		utils.ErrorResponse(c, http.StatusNotFound, "MEMBERSHIP_NOT_FOUND", "Üyelik bulunamadı", nil)
+		return
+	}
+
+	utils.SuccessResponse(c, nil, "Üyelik başarıyla sonlandırıldı")
+}
+
+// GetInvitations kullanıcının kooperatif üyelikleri
+// @Summary Kooperatif davetlerim
+// @Description Kullanıcının dahil olduğu kooperatifleri ve veri paylaşım onay durumlarını listeler
+// @ID getInvitations
+// @Tags Cooperative
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {object} models.APIResponse{data=[]models.CooperativeMembership}
+// @Failure 401 {object} models.APIResponse
+// @Router /cooperative/invitations [get]
+func (h *CooperativeHandler) GetInvitations(c *gin.Context) {
+	userID, err := utils.GetUserID(c)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusUnauthorized, "UNAUTHORIZED", "Kullanıcı kimliği doğrulanamadı", nil)
+		return
+	}
+
+	rows, err := h.db.Query(`
+		SELECT cm.id, cm.cooperative_admin_id, COALESCE(NULLIF(u.farm_name, ''), u.name),
+		       cm.member_user_id, cm.consent_status, cm.consented_at, cm.created_at
+		FROM cooperative_memberships cm
+		JOIN users u ON cm.cooperative_admin_id = u.id
+		WHERE cm.member_user_id = ?
+		ORDER BY cm.created_at DESC
+	`, userID)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusInternalServerError, "DB_ERROR", "Davetler alınamadı", err.Error())
+		return
+	}
+	defer rows.Close()
+
+	var invitations []models.CooperativeMembership
+	for rows.Next() {
+		var invitation models.CooperativeMembership
+		var consentedAt sql.NullTime
+
+		err := rows.Scan(
+			&invitation.ID, &invitation.CooperativeID, &invitation.CooperativeName,
+			&invitation.MemberID, &invitation.ConsentStatus, &consentedAt, &invitation.CreatedAt,
+		)
+		if err != nil {
+			continue
+		}
+
+		invitation.ConsentedAt = utils.NullTimeToPtr(consentedAt)
+		invitations = append(invitations, invitation)
+	}
+
+	utils.SuccessResponse(c, invitations, "Kooperatif davetleri başarıyla getirildi")
+}
+
+// UpdateConsent veri paylaşım onayı verme/geri alma
+// @Summary Veri paylaşım onayı
+// @Description Üye, kooperatifin çiftlik verilerini görmesine onay verir veya onayını geri çeker
+// @ID updateConsent
+// @Tags Cooperative
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Üyelik ID"
+// @Param request body map[string]bool true "Onay durumu (consent)"
+// @Success 200 {object} models.APIResponse
+// @Failure 400 {object} models.APIResponse
+// @Failure 401 {object} models.APIResponse
+// @Failure 404 {object} models.APIResponse
+// @Router /cooperative/invitations/{id}/consent [patch]
+func (h *CooperativeHandler) UpdateConsent(c *gin.Context) {
+	userID, err := utils.GetUserID(c)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusUnauthorized, "UNAUTHORIZED", "Kullanıcı kimliği doğrulanamadı", nil)
+		return
+	}
+
+	membershipID := c.Param("id")
+	if utils.IsEmptyString(membershipID) {
+		utils.ErrorResponse(c, http.StatusBadRequest, "MISSING_ID", "Üyelik ID gerekli", nil)
+		return
+	}
+
+	var req struct {
+		Consent *bool `json:"consent" binding:"required"`
+	}
+	if err := c.ShouldBindJSON(&req); err != nil {
+		utils.ErrorResponse(c, http.StatusBadRequest, "INVALID_REQUEST", "Geçersiz istek formatı", err.Error())
+		return
+	}
+
+	var result sql.Result
+	if *req.Consent {
+		result, err = h.db.Exec(`
+			UPDATE cooperative_memberships
+			SET consent_status = 'granted', consented_at = CURRENT_TIMESTAMP
+			WHERE id = ? AND member_user_id = ?
+		`, membershipID, userID)
+	} else {
+		result, err = h.db.Exec(`
+			UPDATE cooperative_memberships
+			SET consent_status = 'revoked', consented_at = NULL
+			WHERE id = ? AND member_user_id = ?
+		`, membershipID, userID)
+	}
+
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusInternalServerError, "UPDATE_ERROR", "Onay durumu güncellenemedi", err.Error())
+		return
+	}
+
+	rowsAffected, _ := result.RowsAffected()
+	if rowsAffected == 0 {
+		utils.ErrorResponse(c, http.StatusNotFound, "MEMBERSHIP_NOT_FOUND", "Üyelik bulunamadı", nil)
+		return
+	}
+
+	if *req.Consent {
+		utils.SuccessResponse(c, nil, "Veri paylaşımına onay verildi")
+	} else {
+		utils.SuccessResponse(c, nil, "Veri paylaşım onayı geri alındı")
+	}
+}
+
+// fillFarmSummary üye çiftliğin hayvan, üretim ve uyarı verilerini doldurur
+func (h *CooperativeHandler) fillFarmSummary(farm *models.CooperativeFarmSummary) {
+	farm.AnimalsByType = map[string]int{}
+	farm.ProductionVolume = map[string]float64{}
+
+	rows, err := h.db.Query(`
+		SELECT type, COUNT(*) FROM livestock WHERE user_id = ? GROUP BY type
+	`, farm.MemberID)
+	if err == nil {
+		defer rows.Close()
+		for rows.Next() {
+			var animalType string
+			var count int
+			if err := rows.Scan(&animalType, &count); err != nil {
+				continue
+			}
+			farm.AnimalsByType[animalType] = count
+			farm.HerdCount += count
+		}
+	}
+
+	// Üretim hacimleri birim bazında toplanır (kg, litre vb. karıştırılmaz)
+	productionRows, err := h.db.Query(`
+		SELECT unit, COALESCE(SUM(amount), 0)
+		FROM production WHERE user_id = ? AND status = 'active'
+		GROUP BY unit
+	`, farm.MemberID)
+	if err == nil {
+		defer productionRows.Close()
+		for productionRows.Next() {
+			var unit string
+			var amount float64
+			if err := productionRows.Scan(&unit, &amount); err != nil {
+				continue
+			}
+			farm.ProductionVolume[unit] = amount
+		}
+	}
+
+	h.db.QueryRow(`
+		SELECT COUNT(*) FROM livestock WHERE user_id = ? AND health_status = 'sick'
+	`, farm.MemberID).Scan(&farm.SickAnimals)
+
+	h.db.QueryRow(`
+		SELECT COUNT(*) FROM notifications
+		WHERE user_id = ? AND type = 'alert' AND is_read = false
+	`, farm.MemberID).Scan(&farm.OpenAlerts)
+}
@@ -0,0 +1,272 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+
+	"agri-management-api/internal/models"
+	"agri-management-api/internal/services"
+	"agri-management-api/internal/utils"
+
+	"github.com/gin-gonic/gin"
+)
+
+// GetAllocationRules ortak gider dağıtım kuralları
+// @Summary Ortak gider dağıtım kuralları
+// @Description Sigorta, elektrik, veteriner çağrı ücreti gibi ortak giderleri arazilere veya hayvanlara paylaştıran kuralları listeler
+// @ID getAllocationRules
+// @Tags Finance
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {object} models.APIResponse{data=[]models.CostAllocationRule}
+// @Failure 401 {object} models.APIResponse
+// @Router /finance/allocation-rules [get]
+func (h *FinanceHandler) GetAllocationRules(c *gin.Context) {
+	userID, err := utils.GetUserID(c)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusUnauthorized, "UNAUTHORIZED", "Kullanıcı kimliği doğrulanamadı", nil)
+		return
+	}
+
+	rules, err := h.allocations.Rules(userID)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusInternalServerError, "DB_ERROR", "Dağıtım kuralları alınamadı", err.Error())
+		return
+	}
+
+	utils.SuccessResponse(c, rules, "Dağıtım kuralları başarıyla getirildi")
+}
+
+// CreateAllocationRule dağıtım kuralı ekleme
+// @Summary Dağıtım kuralı ekle
+// @Description Seçilen kategorilerdeki gider işlemlerini arazilere (targetType=land) veya hayvanlara (targetType=livestock) dağıtan kural ekler. Anahtar (basis): area arazi alanı (yalnızca arazi), head_count hayvanın dönemde sürüde kaldığı gün (yalnızca hayvan), revenue dönemdeki satış geliri, equal eşit pay
+// @ID createAllocationRule
+// @Tags Finance
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param request body models.CostAllocationRuleRequest true "Kural bilgileri"
+// @Success 201 {object} models.APIResponse{data=models.CostAllocationRule}
+// @Failure 400 {object} models.APIResponse
+// @Failure 401 {object} models.APIResponse
+// @Router /finance/allocation-rules [post]
+func (h *FinanceHandler) CreateAllocationRule(c *gin.Context) {
+	userID, err := utils.GetUserID(c)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusUnauthorized, "UNAUTHORIZED", "Kullanıcı kimliği doğrulanamadı", nil)
+		return
+	}
+
+	var req models.CostAllocationRuleRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		utils.ErrorResponse(c, http.StatusBadRequest, "INVALID_REQUEST", "Geçersiz istek formatı", err.Error())
+		return
+	}
+
+	rule, err := h.allocations.CreateRule(userID, req)
+	if err != nil {
+		writeAllocationError(c, err, "Dağıtım kuralı oluşturulamadı")
+		return
+	}
+
+	utils.CreatedResponse(c, rule, "Dağıtım kuralı başarıyla oluşturuldu")
+}
+
+// UpdateAllocationRule dağıtım kuralı güncelleme
+// @Summary Dağıtım kuralını güncelle
+// @Description Kuralı günceller; hesaplanmış paylar değişmez, dönem yeniden çalıştırılınca yeni kurala göre hesaplanır
+// @ID updateAllocationRule
+// @Tags Finance
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Kural ID"
+// @Param request body models.CostAllocationRuleRequest true "Kural bilgileri"
+// @Success 200 {object} models.APIResponse{data=models.CostAllocationRule}
+// @Failure 400 {object} models.APIResponse
+// @Failure 401 {object} models.APIResponse
+// @Failure 404 {object} models.APIResponse
+// @Router /finance/allocation-rules/{id} [put]
+func (h *FinanceHandler) UpdateAllocationRule(c *gin.Context) {
+	userID, err := utils.GetUserID(c)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusUnauthorized, "UNAUTHORIZED", "Kullanıcı kimliği doğrulanamadı", nil)
+		return
+	}
+
+	var req models.CostAllocationRuleRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		utils.ErrorResponse(c, http.StatusBadRequest, "INVALID_REQUEST", "Geçersiz istek formatı", err.Error())
+		return
+	}
+
+	rule, err := h.allocations.UpdateRule(userID, c.Param("id"), req)
+	if err != nil {
+		writeAllocationError(c, err, "Dağıtım kuralı güncellenemedi")
+		return
+	}
+
+	utils.SuccessResponse(c, rule, "Dağıtım kuralı başarıyla güncellendi")
+}
+
+// DeleteAllocationRule dağıtım kuralı silme
+// @Summary Dağıtım kuralını sil
+// @Description Kuralı ve hesaplanmış tüm paylarını siler; paylar karlılık raporlarından çıkar
+// @ID deleteAllocationRule
+// @Tags Finance
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Kural ID"
+// @Success 200 {object} models.APIResponse
+// @Failure 401 {object} models.APIResponse
+// @Failure 404 {object} models.APIResponse
+// @Router /finance/allocation-rules/{id} [delete]
+func (h *FinanceHandler) DeleteAllocationRule(c *gin.Context) {
+	userID, err := utils.GetUserID(c)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusUnauthorized, "UNAUTHORIZED", "Kullanıcı kimliği doğrulanamadı", nil)
+		return
+	}
+
+	if err := h.allocations.DeleteRule(userID, c.Param("id")); err != nil {
+		writeAllocationError(c, err, "Dağıtım kuralı silinemedi")
+		return
+	}
+
+	utils.SuccessResponse(c, nil, "Dağıtım kuralı başarıyla silindi")
+}
+
+// RunAllocationRule dağıtım çalıştırma
+// @Summary Dağıtımı çalıştır
+// @Description Dönemdeki kural kategorilerindeki gider işlemlerinin toplamını hedeflere anahtar değerleriyle orantılı paylaştırır ve payları kaydeder. Aynı dönem yeniden çalıştırılırsa önceki paylar değiştirilir; kesişen başka bir dönem için çalıştırılmış kural 409 döner. Paylar arazi karlılığında allocatedCost, hayvan karlılığında costBasis.overhead olarak maliyete eklenir
+// @ID runAllocationRule
+// @Tags Finance
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Kural ID"
+// @Param request body models.CostAllocationRunRequest true "Dönem"
+// @Success 200 {object} models.APIResponse{data=models.CostAllocationRun}
+// @Failure 400 {object} models.APIResponse
+// @Failure 401 {object} models.APIResponse
+// @Failure 404 {object} models.APIResponse
+// @Failure 409 {object} models.APIResponse
+// @Router /finance/allocation-rules/{id}/run [post]
+func (h *FinanceHandler) RunAllocationRule(c *gin.Context) {
+	userID, err := utils.GetUserID(c)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusUnauthorized, "UNAUTHORIZED", "Kullanıcı kimliği doğrulanamadı", nil)
+		return
+	}
+
+	var req models.CostAllocationRunRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		utils.ErrorResponse(c, http.StatusBadRequest, "INVALID_REQUEST", "Geçersiz istek formatı", err.Error())
+		return
+	}
+
+	run, err := h.allocations.Run(userID, c.Param("id"), req)
+	if err != nil {
+		writeAllocationError(c, err, "Dağıtım çalıştırılamadı")
+		return
+	}
+
+	utils.SuccessResponse(c, run, "Ortak giderler başarıyla dağıtıldı")
+}
+
+// DeleteAllocationRun dağıtım sonucunu silme
+// @Summary Dağıtım sonucunu sil
+// @Description Kuralın startDate-endDate dönemi için hesaplanan paylarını siler
+// @ID deleteAllocationRun
+// @Tags Finance
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Kural ID"
+// @Param startDate query string true "Dönem başlangıcı (YYYY-MM-DD)"
+// @Param endDate query string true "Dönem sonu (YYYY-MM-DD)"
+// @Success 200 {object} models.APIResponse
+// @Failure 400 {object} models.APIResponse
+// @Failure 401 {object} models.APIResponse
+// @Failure 404 {object} models.APIResponse
+// @Router /finance/allocation-rules/{id}/run [delete]
+func (h *FinanceHandler) DeleteAllocationRun(c *gin.Context) {
+	userID, err := utils.GetUserID(c)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusUnauthorized, "UNAUTHORIZED", "Kullanıcı kimliği doğrulanamadı", nil)
+		return
+	}
+
+	startDate, endDate, ok := dateRangeQuery(c)
+	if !ok {
+		return
+	}
+	if startDate == nil || endDate == nil {
+		utils.ErrorResponse(c, http.StatusBadRequest, "MISSING_FIELDS", "startDate ve endDate gerekli", nil)
+		return
+	}
+
+	err = h.allocations.DeleteRun(userID, c.Param("id"), startDate.Format("2006-01-02"), endDate.Format("2006-01-02"))
+	if err != nil {
+		writeAllocationError(c, err, "Dağıtım sonucu silinemedi")
+		return
+	}
+
+	utils.SuccessResponse(c, nil, "Dağıtım sonucu başarıyla silindi")
+}
+
+// GetAllocations dağıtım payları
+// @Summary Dağıtım payları
+// @Description Hesaplanmış ortak gider paylarını kural, hedef ve dönem filtreleriyle listeler; tarih filtresi dönemle kesişen payları döner
+// @ID getAllocations
+// @Tags Finance
+// @Produce json
+// @Security BearerAuth
+// @Param ruleId query string false "Kural ID"
+// @Param targetType query string false "Hedef türü" Enums(land, livestock)
+// @Param targetId query string false "Arazi veya hayvan ID"
+// @Param startDate query string false "Başlangıç tarihi (YYYY-MM-DD)"
+// @Param endDate query string false "Bitiş tarihi (YYYY-MM-DD)"
+// @Success 200 {object} models.APIResponse{data=[]models.CostAllocation}
+// @Failure 400 {object} models.APIResponse
+// @Failure 401 {object} models.APIResponse
+// @Router /finance/allocations [get]
+func (h *FinanceHandler) GetAllocations(c *gin.Context) {
+	userID, err := utils.GetUserID(c)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusUnauthorized, "UNAUTHORIZED", "Kullanıcı kimliği doğrulanamadı", nil)
+		return
+	}
+
+	startDate, endDate, ok := dateRangeQuery(c)
+	if !ok {
+		return
+	}
+
+	allocations, err := h.allocations.List(userID, c.Query("ruleId"), c.Query("targetType"), c.Query("targetId"), startDate, endDate)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusInternalServerError, "DB_ERROR", "Dağıtım payları alınamadı", err.Error())
+		return
+	}
+
+	utils.SuccessResponse(c, allocations, "Dağıtım payları başarıyla getirildi")
+}
+
+// writeAllocationError servis hatasını HTTP yanıtına çevirir
+func writeAllocationError(c *gin.Context, err error, message string) {
+	switch {
+	case errors.Is(err, services.ErrAllocationRuleNotFound):
+		utils.ErrorResponse(c, http.StatusNotFound, "ALLOCATION_RULE_NOT_FOUND", "Dağıtım kuralı bulunamadı", nil)
+	case errors.Is(err, services.ErrInvalidAllocationBasis):
+		utils.ErrorResponse(c, http.StatusBadRequest, "INVALID_ALLOCATION_BASIS", "area anahtarı yalnızca arazilerde, head_count yalnızca hayvanlarda kullanılabilir", nil)
+	case errors.Is(err, services.ErrInvalidAllocationPeriod):
+		utils.ErrorResponse(c, http.StatusBadRequest, "INVALID_DATE_RANGE", "Bitiş tarihi başlangıç tarihinden önce olamaz", nil)
+	case errors.Is(err, services.ErrNoAllocationCost):
+		utils.ErrorResponse(c, http.StatusBadRequest, "NO_ALLOCATION_COST", "Dönemde kural kategorilerinde gider işlemi yok", nil)
+	case errors.Is(err, services.ErrNoAllocationBasis):
+		utils.ErrorResponse(c, http.StatusBadRequest, "NO_ALLOCATION_BASIS", "Dağıtım anahtarına göre pay alacak arazi veya hayvan yok", nil)
+	case errors.Is(err, services.ErrAllocationPeriodOverlap):
+		utils.ErrorResponse(c, http.StatusConflict, "ALLOCATION_PERIOD_OVERLAP", "Kural bu dönemle kesişen başka bir dönem için çalıştırılmış", nil)
+	default:
+		utils.ErrorResponse(c, http.StatusInternalServerError, "DB_ERROR", message, err.Error())
+	}
+}
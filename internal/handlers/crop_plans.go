@@ -0,0 +1,245 @@
+package handlers
+
+import (
+	"database/sql"
+	"errors"
+	"net/http"
+
+	"agri-management-api/internal/models"
+	"agri-management-api/internal/services"
+	"agri-management-api/internal/utils"
+
+	"github.com/gin-gonic/gin"
+)
+
+// CropPlanHandler arazilerin sezonluk ekim planlarını ve ekim nöbeti denetimini yönetir
+type CropPlanHandler struct {
+	db         *sql.DB
+	cropPlans  *services.CropPlanService
+	eventRules *services.EventRuleService
+}
+
+// NewCropPlanHandler yeni crop plan handler oluşturur
+func NewCropPlanHandler(db *sql.DB) *CropPlanHandler {
+	return &CropPlanHandler{
+		db:         db,
+		cropPlans:  services.NewCropPlanService(db),
+		eventRules: services.NewEventRuleService(db),
+	}
+}
+
+// GetCropPlans çiftliğin ekim planları
+// @Summary Çiftliğin ekim planları
+// @Description Tüm arazilerin ekim planlarını arazi ve ekim tarihine göre ekim nöbeti uyarılarıyla listeler
+// @ID getCropPlans
+// @Tags Lands
+// @Produce json
+// @Security BearerAuth
+// @Param season query string false "Sezon"
+// @Param startDate query string false "Ekim tarihi başlangıcı (YYYY-MM-DD)"
+// @Param endDate query string false "Ekim tarihi bitişi (YYYY-MM-DD)"
+// @Success 200 {object} models.APIResponse{data=[]models.CropPlan}
+// @Failure 400 {object} models.APIResponse
+// @Failure 401 {object} models.APIResponse
+// @Router /lands/crop-plans [get]
+func (h *CropPlanHandler) GetCropPlans(c *gin.Context) {
+	userID, err := utils.GetUserID(c)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusUnauthorized, "UNAUTHORIZED", "Kullanıcı kimliği doğrulanamadı", nil)
+		return
+	}
+
+	startDate, endDate, ok := dateRangeQuery(c)
+	if !ok {
+		return
+	}
+
+	plans, err := h.cropPlans.ListFarm(userID, c.Query("season"), startDate, endDate)
+	if err != nil {
+		writeCropPlanError(c, err, "Ekim planları alınamadı")
+		return
+	}
+
+	utils.SuccessResponse(c, plans, "Ekim planları başarıyla getirildi")
+}
+
+// GetLandCropPlans arazinin ekim planları
+// @Summary Arazinin ekim planları
+// @Description Arazinin ekim planlarını ekim tarihine göre listeler. Her plan bir önceki planla (ilk plan arazideki mevcut ürünle) karşılaştırılır: aynı ürün art arda ekilirse same_crop, aynı familyadan ürün ekilirse same_family, familya araziye dönüş süresi dolmadan yeniden ekilirse return_interval uyarısı döner
+// @ID getLandCropPlans
+// @Tags Lands
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Arazi ID"
+// @Success 200 {object} models.APIResponse{data=[]models.CropPlan}
+// @Failure 401 {object} models.APIResponse
+// @Failure 404 {object} models.APIResponse
+// @Router /lands/{id}/crop-plans [get]
+func (h *CropPlanHandler) GetLandCropPlans(c *gin.Context) {
+	userID, err := utils.GetUserID(c)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusUnauthorized, "UNAUTHORIZED", "Kullanıcı kimliği doğrulanamadı", nil)
+		return
+	}
+
+	plans, err := h.cropPlans.List(userID, c.Param("id"))
+	if err != nil {
+		writeCropPlanError(c, err, "Ekim planları alınamadı")
+		return
+	}
+
+	utils.SuccessResponse(c, plans, "Ekim planları başarıyla getirildi")
+}
+
+// GetCropPlan ekim planı
+// @Summary Ekim planı
+// @Description Arazinin ekim planını ekim nöbeti uyarılarıyla getirir
+// @ID getCropPlan
+// @Tags Lands
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Arazi ID"
+// @Param planId path string true "Ekim planı ID"
+// @Success 200 {object} models.APIResponse{data=models.CropPlan}
+// @Failure 401 {object} models.APIResponse
+// @Failure 404 {object} models.APIResponse
+// @Router /lands/{id}/crop-plans/{planId} [get]
+func (h *CropPlanHandler) GetCropPlan(c *gin.Context) {
+	userID, err := utils.GetUserID(c)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusUnauthorized, "UNAUTHORIZED", "Kullanıcı kimliği doğrulanamadı", nil)
+		return
+	}
+
+	plan, err := h.cropPlans.Get(userID, c.Param("id"), c.Param("planId"))
+	if err != nil {
+		writeCropPlanError(c, err, "Ekim planı alınamadı")
+		return
+	}
+
+	utils.SuccessResponse(c, plan, "Ekim planı başarıyla getirildi")
+}
+
+// CreateCropPlan ekim planı ekleme
+// @Summary Ekim planı ekle
+// @Description Araziye sezonluk ekim planı ekler. harvestDate verilmezse ekimden hasat dönemine kadar geçen süreyle hesaplanır. Arazide aynı dönemi kapsayan başka bir plan varsa istek reddedilir; ekim nöbeti kurallarına uymayan plan kaydedilir ve uyarılar yanıtta döner. Planlanan ekim ve hasat takvime etkinlik olarak eklenir
+// @ID createCropPlan
+// @Tags Lands
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Arazi ID"
+// @Param request body models.CropPlanRequest true "Ekim planı bilgileri"
+// @Success 201 {object} models.APIResponse{data=models.CropPlan}
+// @Failure 400 {object} models.APIResponse
+// @Failure 401 {object} models.APIResponse
+// @Failure 404 {object} models.APIResponse
+// @Failure 409 {object} models.APIResponse
+// @Router /lands/{id}/crop-plans [post]
+func (h *CropPlanHandler) CreateCropPlan(c *gin.Context) {
+	userID, err := utils.GetUserID(c)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusUnauthorized, "UNAUTHORIZED", "Kullanıcı kimliği doğrulanamadı", nil)
+		return
+	}
+
+	var req models.CropPlanRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		utils.ErrorResponse(c, http.StatusBadRequest, "INVALID_REQUEST", "Geçersiz istek formatı", err.Error())
+		return
+	}
+
+	plan, err := h.cropPlans.Create(userID, c.Param("id"), req)
+	if err != nil {
+		writeCropPlanError(c, err, "Ekim planı oluşturulamadı")
+		return
+	}
+	h.eventRules.SyncQuietly(userID, models.EventRulePlannedPlanting, models.EventRuleHarvestWindow)
+
+	utils.CreatedResponse(c, plan, "Ekim planı başarıyla oluşturuldu")
+}
+
+// UpdateCropPlan ekim planı güncelleme
+// @Summary Ekim planını güncelle
+// @Description Ekim planını günceller; status ile planın ekildiği (planted), hasat edildiği (harvested) veya iptal edildiği (cancelled) işaretlenir. Takvimdeki ekim ve hasat etkinlikleri buna göre güncellenir veya kaldırılır
+// @ID updateCropPlan
+// @Tags Lands
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Arazi ID"
+// @Param planId path string true "Ekim planı ID"
+// @Param request body models.CropPlanRequest true "Ekim planı bilgileri"
+// @Success 200 {object} models.APIResponse{data=models.CropPlan}
+// @Failure 400 {object} models.APIResponse
+// @Failure 401 {object} models.APIResponse
+// @Failure 404 {object} models.APIResponse
+// @Failure 409 {object} models.APIResponse
+// @Router /lands/{id}/crop-plans/{planId} [put]
+func (h *CropPlanHandler) UpdateCropPlan(c *gin.Context) {
+	userID, err := utils.GetUserID(c)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusUnauthorized, "UNAUTHORIZED", "Kullanıcı kimliği doğrulanamadı", nil)
+		return
+	}
+
+	var req models.CropPlanRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		utils.ErrorResponse(c, http.StatusBadRequest, "INVALID_REQUEST", "Geçersiz istek formatı", err.Error())
+		return
+	}
+
+	plan, err := h.cropPlans.Update(userID, c.Param("id"), c.Param("planId"), req)
+	if err != nil {
+		writeCropPlanError(c, err, "Ekim planı güncellenemedi")
+		return
+	}
+	h.eventRules.SyncQuietly(userID, models.EventRulePlannedPlanting, models.EventRuleHarvestWindow)
+
+	utils.SuccessResponse(c, plan, "Ekim planı başarıyla güncellendi")
+}
+
+// DeleteCropPlan ekim planı silme
+// @Summary Ekim planını sil
+// @Description Ekim planını ve takvimdeki bekleyen ekim ve hasat etkinliklerini siler
+// @ID deleteCropPlan
+// @Tags Lands
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Arazi ID"
+// @Param planId path string true "Ekim planı ID"
+// @Success 200 {object} models.APIResponse
+// @Failure 401 {object} models.APIResponse
+// @Failure 404 {object} models.APIResponse
+// @Router /lands/{id}/crop-plans/{planId} [delete]
+func (h *CropPlanHandler) DeleteCropPlan(c *gin.Context) {
+	userID, err := utils.GetUserID(c)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusUnauthorized, "UNAUTHORIZED", "Kullanıcı kimliği doğrulanamadı", nil)
+		return
+	}
+
+	if err := h.cropPlans.Delete(userID, c.Param("id"), c.Param("planId")); err != nil {
+		writeCropPlanError(c, err, "Ekim planı silinemedi")
+		return
+	}
+	h.eventRules.SyncQuietly(userID, models.EventRulePlannedPlanting, models.EventRuleHarvestWindow)
+
+	utils.SuccessResponse(c, nil, "Ekim planı başarıyla silindi")
+}
+
+// writeCropPlanError servis hatasını HTTP yanıtına çevirir
+func writeCropPlanError(c *gin.Context, err error, message string) {
+	switch {
+	case errors.Is(err, services.ErrCropPlanLand):
+		utils.ErrorResponse(c, http.StatusNotFound, "LAND_NOT_FOUND", "Arazi bulunamadı", nil)
+	case errors.Is(err, services.ErrCropPlanNotFound):
+		utils.ErrorResponse(c, http.StatusNotFound, "CROP_PLAN_NOT_FOUND", "Ekim planı bulunamadı", nil)
+	case errors.Is(err, services.ErrCropPlanDates):
+		utils.ErrorResponse(c, http.StatusBadRequest, "INVALID_DATES", err.Error(), nil)
+	case errors.Is(err, services.ErrCropPlanOverlap):
+		utils.ErrorResponse(c, http.StatusConflict, "CROP_PLAN_OVERLAP", err.Error(), nil)
+	default:
+		utils.ErrorResponse(c, http.StatusInternalServerError, "DB_ERROR", message, err.Error())
+	}
+}
@@ -7,6 +7,7 @@ import (
 	"time"
 
 	"agri-management-api/internal/models"
+	"agri-management-api/internal/services"
 	"agri-management-api/internal/utils"
 
 	"github.com/gin-gonic/gin"
@@ -14,17 +15,31 @@ import (
 
 // DashboardHandler dashboard işlemlerini yönetir
 type DashboardHandler struct {
-	db *sql.DB
+	db         *sql.DB
+	charts     *services.ChartService
+	timeSeries *services.TimeSeriesService
+	forecasts  *services.ForecastService
+	kpis       *services.KPISnapshotService
+	alerts     *services.DashboardAlertService
 }
 
-// NewDashboardHandler yeni dashboard handler oluşturur
-func NewDashboardHandler(db *sql.DB) *DashboardHandler {
-	return &DashboardHandler{db: db}
+// NewDashboardHandler yeni dashboard handler oluşturur; özet ve grafik sorguları okuma veritabanından
+// (yapılandırılmışsa replika) yapılır, tahmin kayıtları birincil veritabanına yazılır
+func NewDashboardHandler(db, readDB *sql.DB) *DashboardHandler {
+	return &DashboardHandler{
+		db:         readDB,
+		charts:     services.NewChartService(db, readDB),
+		timeSeries: services.NewTimeSeriesService(readDB),
+		forecasts:  services.NewForecastService(db),
+		kpis:       services.NewKPISnapshotService(db),
+		alerts:     services.NewDashboardAlertService(db, readDB),
+	}
 }
 
 // GetSummary dashboard özet verileri
 // @Summary Dashboard özet
 // @Description Dashboard için özet istatistikleri getirir
+// @ID getDashboardSummary
 // @Tags Dashboard
 // @Accept json
 // @Produce json
@@ -136,11 +151,27 @@ func (h *DashboardHandler) GetSummary(c *gin.Context) {
 		}
 	}
 
+	// Hayvan sayısı eğilimi: son KPI anlık görüntüsü ile 30 gün önceki görüntünün sürü büyüklüğü farkı
+	animalTrend, animalPercentage := "+0", 0.0
+	now := time.Now().UTC()
+	latest, _ := h.kpis.OnOrBefore(userID, now)
+	previous, _ := h.kpis.OnOrBefore(userID, now.AddDate(0, 0, -30))
+	if latest != nil && previous != nil && latest.SnapshotDate != previous.SnapshotDate {
+		change := latest.HerdSize - previous.HerdSize
+		animalTrend = strconv.Itoa(change)
+		if change >= 0 {
+			animalTrend = "+" + animalTrend
+		}
+		if previous.HerdSize > 0 {
+			animalPercentage = roundTo2(float64(change) / float64(previous.HerdSize) * 100)
+		}
+	}
+
 	summary := models.DashboardSummary{
 		TotalAnimals: models.AnimalSummary{
 			Count:      animalCount,
-			Trend:      "+0",
-			Percentage: 0,
+			Trend:      animalTrend,
+			Percentage: animalPercentage,
 		},
 		TotalLands: models.LandSummary{
 			Area:        totalArea,
@@ -169,12 +200,13 @@ func (h *DashboardHandler) GetSummary(c *gin.Context) {
 // GetRecentActivities son aktiviteler
 // @Summary Son aktiviteler
 // @Description Son aktiviteleri listeler
+// @ID getRecentActivities
 // @Tags Dashboard
 // @Accept json
 // @Produce json
 // @Security BearerAuth
 // @Param limit query int false "Limit (default: 10)"
-// @Success 200 {object} models.APIResponse{data=[]map[string]interface{}}
+// @Success 200 {object} models.APIResponse{data=[]models.RecentActivity}
 // @Failure 401 {object} models.APIResponse
 // @Router /dashboard/recent-activities [get]
 func (h *DashboardHandler) GetRecentActivities(c *gin.Context) {
@@ -191,7 +223,7 @@ func (h *DashboardHandler) GetRecentActivities(c *gin.Context) {
 	}
 
 	// Son aktiviteleri birleştir (hayvan, arazi, üretim, finans)
-	activities := []map[string]interface{}{}
+	activities := []models.RecentActivity{}
 
 	// Hayvan aktiviteleri
 	rows, err := h.db.Query(`
@@ -206,9 +238,10 @@ func (h *DashboardHandler) GetRecentActivities(c *gin.Context) {
 	if err == nil {
 		defer rows.Close()
 		for rows.Next() {
-			var activity map[string]interface{}
-			rows.Scan(&activity)
-			activities = append(activities, activity)
+			var activity models.RecentActivity
+			if rows.Scan(&activity.Type, &activity.Title, &activity.Description, &activity.Date, &activity.Category, &activity.Icon) == nil {
+				activities = append(activities, activity)
+			}
 		}
 	}
 
@@ -225,9 +258,10 @@ func (h *DashboardHandler) GetRecentActivities(c *gin.Context) {
 	if err == nil {
 		defer rows.Close()
 		for rows.Next() {
-			var activity map[string]interface{}
-			rows.Scan(&activity)
-			activities = append(activities, activity)
+			var activity models.RecentActivity
+			if rows.Scan(&activity.Type, &activity.Title, &activity.Description, &activity.Date, &activity.Category, &activity.Icon) == nil {
+				activities = append(activities, activity)
+			}
 		}
 	}
 
@@ -243,9 +277,10 @@ func (h *DashboardHandler) GetRecentActivities(c *gin.Context) {
 	if err == nil {
 		defer rows.Close()
 		for rows.Next() {
-			var activity map[string]interface{}
-			rows.Scan(&activity)
-			activities = append(activities, activity)
+			var activity models.RecentActivity
+			if rows.Scan(&activity.Type, &activity.Title, &activity.Description, &activity.Date, &activity.Category, &activity.Icon) == nil {
+				activities = append(activities, activity)
+			}
 		}
 	}
 
@@ -261,9 +296,10 @@ func (h *DashboardHandler) GetRecentActivities(c *gin.Context) {
 	if err == nil {
 		defer rows.Close()
 		for rows.Next() {
-			var activity map[string]interface{}
-			rows.Scan(&activity)
-			activities = append(activities, activity)
+			var activity models.RecentActivity
+			if rows.Scan(&activity.Type, &activity.Title, &activity.Description, &activity.Date, &activity.Category, &activity.Icon) == nil {
+				activities = append(activities, activity)
+			}
 		}
 	}
 
@@ -277,13 +313,16 @@ func (h *DashboardHandler) GetRecentActivities(c *gin.Context) {
 
 // GetIncomeExpenseChart gelir-gider grafik verileri
 // @Summary Gelir-gider grafik
-// @Description Aylık gelir-gider grafik verilerini getirir
+// @Description Son 12 periyodun gelir-gider grafik verilerini getirir; kovalar analytics zaman serisiyle aynıdır. forecastMonths verilirse güven aralıklı gelir ve gider tahminleri eklenir
+// @ID getIncomeExpenseChart
 // @Tags Dashboard
 // @Accept json
 // @Produce json
 // @Security BearerAuth
 // @Param period query string false "Period (month/quarter/year)" Enums(month, quarter, year)
-// @Success 200 {object} models.APIResponse{data=map[string]interface{}}
+// @Param forecastMonths query int false "Gelir ve gider tahmin serilerinin ay sayısı (0-12, yalnızca period=month)" default(0)
+// @Success 200 {object} models.APIResponse{data=models.IncomeExpenseChart}
+// @Failure 400 {object} models.APIResponse
 // @Failure 401 {object} models.APIResponse
 // @Router /dashboard/charts/income-expense [get]
 func (h *DashboardHandler) GetIncomeExpenseChart(c *gin.Context) {
@@ -293,43 +332,60 @@ func (h *DashboardHandler) GetIncomeExpenseChart(c *gin.Context) {
 		return
 	}
 
-	_ = c.DefaultQuery("period", "month")
-	
-	var labels []string
-	var income []float64
-	var expense []float64
-	var profit []float64
-
-	// Son 12 ay verisi
-	for i := 11; i >= 0; i-- {
-		date := time.Now().AddDate(0, -i, 0)
-		monthStr := date.Format("2006-01")
-		labels = append(labels, date.Format("Jan 2006"))
-
-		var monthIncome, monthExpense float64
-		
-		h.db.QueryRow(`
-			SELECT COALESCE(SUM(amount), 0)
-			FROM transactions 
-			WHERE user_id = ? AND type = 'income' AND strftime('%Y-%m', date) = ?
-		`, userID, monthStr).Scan(&monthIncome)
-		
-		h.db.QueryRow(`
-			SELECT COALESCE(SUM(amount), 0)
-			FROM transactions 
-			WHERE user_id = ? AND type = 'expense' AND strftime('%Y-%m', date) = ?
-		`, userID, monthStr).Scan(&monthExpense)
-
-		income = append(income, monthIncome)
-		expense = append(expense, monthExpense)
-		profit = append(profit, monthIncome-monthExpense)
+	period := c.DefaultQuery("period", models.BucketMonth)
+	if period != models.BucketMonth && period != models.BucketQuarter && period != models.BucketYear {
+		utils.ErrorResponse(c, http.StatusBadRequest, "INVALID_PERIOD", "Geçersiz periyot", period)
+		return
 	}
 
-	chartData := map[string]interface{}{
-		"labels":  labels,
-		"income":  income,
-		"expense": expense,
-		"profit":  profit,
+	startDate, endDate, err := h.timeSeries.DefaultRange(period, nil, nil)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusBadRequest, "INVALID_PERIOD", "Geçersiz periyot", period)
+		return
+	}
+	income, err := h.timeSeries.Series(userID, "income_total", period, startDate, endDate, nil)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusInternalServerError, "DB_ERROR", "Gelir verileri alınamadı", err.Error())
+		return
+	}
+	expense, err := h.timeSeries.Series(userID, "expense_total", period, startDate, endDate, nil)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusInternalServerError, "DB_ERROR", "Gider verileri alınamadı", err.Error())
+		return
+	}
+
+	chartData := models.IncomeExpenseChart{
+		Labels:  make([]string, len(income.Points)),
+		Income:  make([]float64, len(income.Points)),
+		Expense: make([]float64, len(income.Points)),
+		Profit:  make([]float64, len(income.Points)),
+	}
+	for i, point := range income.Points {
+		chartData.Labels[i] = point.Bucket
+		if period == models.BucketMonth {
+			start, _ := time.Parse("2006-01-02", point.Start)
+			chartData.Labels[i] = start.Format("Jan 2006")
+		}
+		chartData.Income[i] = *point.Value
+		chartData.Expense[i] = *expense.Points[i].Value
+		chartData.Profit[i] = *point.Value - *expense.Points[i].Value
+	}
+
+	forecastMonths, err := strconv.Atoi(c.DefaultQuery("forecastMonths", "0"))
+	if err != nil || forecastMonths < 0 || forecastMonths > services.MaxForecastHorizon ||
+		(forecastMonths > 0 && period != models.BucketMonth) {
+		utils.ErrorResponse(c, http.StatusBadRequest, "INVALID_PARAMETER", "Geçersiz tahmin ayı sayısı", c.Query("forecastMonths"))
+		return
+	}
+	if forecastMonths > 0 {
+		if chartData.IncomeForecast, err = h.forecasts.Forecast(userID, "income_total", forecastMonths, time.Now()); err != nil {
+			utils.ErrorResponse(c, http.StatusInternalServerError, "DB_ERROR", "Gelir tahmini hesaplanamadı", err.Error())
+			return
+		}
+		if chartData.ExpenseForecast, err = h.forecasts.Forecast(userID, "expense_total", forecastMonths, time.Now()); err != nil {
+			utils.ErrorResponse(c, http.StatusInternalServerError, "DB_ERROR", "Gider tahmini hesaplanamadı", err.Error())
+			return
+		}
 	}
 
 	utils.SuccessResponse(c, chartData, "Gelir-gider grafik verileri başarıyla getirildi")
@@ -338,11 +394,12 @@ func (h *DashboardHandler) GetIncomeExpenseChart(c *gin.Context) {
 // GetProductionChart üretim grafik verileri
 // @Summary Üretim grafik
 // @Description Üretim kategorileri grafik verilerini getirir
+// @ID getProductionChart
 // @Tags Dashboard
 // @Accept json
 // @Produce json
 // @Security BearerAuth
-// @Success 200 {object} models.APIResponse{data=map[string]interface{}}
+// @Success 200 {object} models.APIResponse{data=models.ProductionChart}
 // @Failure 401 {object} models.APIResponse
 // @Router /dashboard/charts/production [get]
 func (h *DashboardHandler) GetProductionChart(c *gin.Context) {
@@ -383,10 +440,10 @@ func (h *DashboardHandler) GetProductionChart(c *gin.Context) {
 		i++
 	}
 
-	chartData := map[string]interface{}{
-		"categories": categories,
-		"values":     values,
-		"colors":     colors,
+	chartData := models.ProductionChart{
+		Categories: categories,
+		Values:     values,
+		Colors:     colors,
 	}
 
 	utils.SuccessResponse(c, chartData, "Üretim grafik verileri başarıyla getirildi")
@@ -0,0 +1,37 @@
+package handlers
+
+import (
+	"net/http"
+	"time"
+
+	"agri-management-api/internal/utils"
+
+	"github.com/gin-gonic/gin"
+)
+
+// GetAlerts ilgilenilmesi gerekenler listesi
+// @Summary Dashboard uyarıları
+// @Description Modüllerdeki güncel, aksiyon gerektiren durumları tek listede toplar: geciken aşılar (14 günden uzun gecikme kritik), süresi dolan veya hatırlatma süresi içinde dolacak dokümanlar (dolmuş kritik, 7 gün ve altı yüksek), sözleşmesi süren çalışanların süresi dolan veya 30 gün içinde dolacak sertifikaları (dolmuş veya 7 gün ve altı yüksek), partinin %10'una düşen stoklar, önümüzdeki üç ayın gelir-gider tahminiyle negatife düşen nakit bakiyesi (bakiye zaten negatifse kritik) ve son 24 saatin hava uyarıları. Uyarılar önem derecesine (critical, high, medium, low), aynı derecede tarihe göre sıralanır
+// @ID getDashboardAlerts
+// @Tags Dashboard
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {object} models.APIResponse{data=models.DashboardAlerts}
+// @Failure 401 {object} models.APIResponse
+// @Router /dashboard/alerts [get]
+func (h *DashboardHandler) GetAlerts(c *gin.Context) {
+	userID, err := utils.GetUserID(c)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusUnauthorized, "UNAUTHORIZED", "Kullanıcı kimliği doğrulanamadı", nil)
+		return
+	}
+
+	alerts, err := h.alerts.Alerts(userID, time.Now().UTC())
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusInternalServerError, "DB_ERROR", "Uyarılar alınamadı", err.Error())
+		return
+	}
+
+	utils.SuccessResponse(c, alerts, "Uyarılar başarıyla getirildi")
+}
@@ -0,0 +1,72 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+
+	"agri-management-api/internal/services"
+	"agri-management-api/internal/utils"
+
+	"github.com/gin-gonic/gin"
+)
+
+// GetChartConfig dashboard grafik tanımları
+// @Summary Grafik tanımları
+// @Description Dashboard'da gösterilebilecek grafikleri türleri, veri endpoint'leri, parametreleri ve yanıt şemalarıyla listeler; istemci yeni grafikleri bu tanımlardan çizer
+// @ID getChartConfig
+// @Tags Dashboard
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {object} models.APIResponse{data=[]models.ChartConfig}
+// @Failure 401 {object} models.APIResponse
+// @Router /dashboard/charts/config [get]
+func (h *DashboardHandler) GetChartConfig(c *gin.Context) {
+	if _, err := utils.GetUserID(c); err != nil {
+		utils.ErrorResponse(c, http.StatusUnauthorized, "UNAUTHORIZED", "Kullanıcı kimliği doğrulanamadı", nil)
+		return
+	}
+
+	utils.SuccessResponse(c, h.charts.Configs(), "Grafik tanımları başarıyla getirildi")
+}
+
+// GetChartData genel grafik verisi
+// @Summary Grafik verisi
+// @Description Şeması ChartData olan grafiğin aylık serilerini getirir; parametreler grafik tanımında listelenir
+// @ID getChartData
+// @Tags Dashboard
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param chartId path string true "Grafik ID" Enums(milk-production, livestock-count, land-activity-cost)
+// @Param months query int false "Gösterilecek ay sayısı (1-36)" default(12)
+// @Param livestockId query string false "Hayvan ID (milk-production)"
+// @Param landId query string false "Arazi ID (land-activity-cost)"
+// @Success 200 {object} models.APIResponse{data=models.ChartData}
+// @Failure 400 {object} models.APIResponse
+// @Failure 401 {object} models.APIResponse
+// @Failure 404 {object} models.APIResponse
+// @Router /dashboard/charts/{chartId} [get]
+func (h *DashboardHandler) GetChartData(c *gin.Context) {
+	userID, err := utils.GetUserID(c)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusUnauthorized, "UNAUTHORIZED", "Kullanıcı kimliği doğrulanamadı", nil)
+		return
+	}
+
+	data, err := h.charts.Data(userID, c.Param("chartId"), c.Query)
+	switch {
+	case err == nil:
+	case errors.Is(err, services.ErrChartNotFound):
+		utils.ErrorResponse(c, http.StatusNotFound, "CHART_NOT_FOUND", "Grafik bulunamadı", c.Param("chartId"))
+		return
+	case errors.Is(err, services.ErrInvalidChartParameter):
+		utils.ErrorResponse(c, http.StatusBadRequest, "INVALID_PARAMETER", "Geçersiz grafik parametresi", err.Error())
+		return
+	default:
+		utils.ErrorResponse(c, http.StatusInternalServerError, "DB_ERROR", "Grafik verisi alınamadı", err.Error())
+		return
+	}
+
+	utils.SuccessResponse(c, data, "Grafik verisi başarıyla getirildi")
+}
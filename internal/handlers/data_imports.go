@@ -0,0 +1,269 @@
+package handlers
+
+import (
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"strings"
+
+	"agri-management-api/internal/models"
+	"agri-management-api/internal/services"
+	"agri-management-api/internal/utils"
+
+	"github.com/gin-gonic/gin"
+)
+
+// maxDataImportFileSize içe aktarılabilecek en büyük dosya boyutu
+const maxDataImportFileSize = 10 << 20
+
+// DataImportHandler başka uygulamalardan geçmiş veri içe aktarımını yönetir
+type DataImportHandler struct {
+	db      *sql.DB
+	imports *services.DataImportService
+}
+
+// NewDataImportHandler yeni data import handler oluşturur
+func NewDataImportHandler(db *sql.DB) *DataImportHandler {
+	return &DataImportHandler{
+		db:      db,
+		imports: services.NewDataImportService(db),
+	}
+}
+
+// GetImportProfiles içe aktarım profilleri
+// @Summary İçe aktarım profilleri
+// @Description Desteklenen dışa aktarım formatlarını (generic, farmbrite), kayıt türü başına alan → kabul edilen sütun başlıkları eşlemesini, tarih biçimlerini ve varsayılan değerleri listeler
+// @ID getImportProfiles
+// @Tags Imports
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {object} models.APIResponse{data=[]models.DataImportProfile}
+// @Failure 401 {object} models.APIResponse
+// @Router /imports/profiles [get]
+func (h *DataImportHandler) GetImportProfiles(c *gin.Context) {
+	utils.SuccessResponse(c, services.DataImportProfiles(), "İçe aktarım profilleri başarıyla getirildi")
+}
+
+// GetImports içe aktarım geçmişi
+// @Summary İçe aktarım geçmişi
+// @Description Çiftliğin önizlenen, onaylanan ve iptal edilen içe aktarımlarını kimin yaptığı ve satır özetleriyle en yeniden başlayarak listeler
+// @ID getImports
+// @Tags Imports
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {object} models.APIResponse{data=[]models.DataImport}
+// @Failure 401 {object} models.APIResponse
+// @Router /imports [get]
+func (h *DataImportHandler) GetImports(c *gin.Context) {
+	userID, err := utils.GetUserID(c)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusUnauthorized, "UNAUTHORIZED", "Kullanıcı kimliği doğrulanamadı", nil)
+		return
+	}
+
+	imports, err := h.imports.List(userID)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusInternalServerError, "DB_ERROR", "İçe aktarımlar alınamadı", err.Error())
+		return
+	}
+
+	utils.SuccessResponse(c, imports, "İçe aktarımlar başarıyla getirildi")
+}
+
+// PreviewImport içe aktarım önizlemesi
+// @Summary İçe aktarım önizlemesi
+// @Description CSV dosyasını seçilen profil ve kayıt türüyle (animals, lands, transactions) okur; her satırı normalleştirir, doğrular ve mevcut kayıtlarla karşılaştırır (create, duplicate, invalid). Hiçbir kayıt eklenmez; önizleme onaylanmak üzere saklanır
+// @ID previewImport
+// @Tags Imports
+// @Accept multipart/form-data
+// @Produce json
+// @Security BearerAuth
+// @Param file formData file true "Dışa aktarım dosyası (CSV)"
+// @Param entity formData string true "Kayıt türü (animals, lands, transactions)"
+// @Param profile formData string false "Profil (generic, farmbrite)" default(generic)
+// @Param mapping formData string false "Özel sütun eşlemesi, JSON alan → sütun başlığı (ör. {\"tagNumber\":\"Ear Tag\"}); boş başlık alanı eşlemeden çıkarır"
+// @Success 201 {object} models.APIResponse{data=models.DataImport}
+// @Failure 400 {object} models.APIResponse
+// @Failure 401 {object} models.APIResponse
+// @Router /imports/preview [post]
+func (h *DataImportHandler) PreviewImport(c *gin.Context) {
+	userID, err := utils.GetUserID(c)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusUnauthorized, "UNAUTHORIZED", "Kullanıcı kimliği doğrulanamadı", nil)
+		return
+	}
+	accountID, _ := utils.GetAccountID(c)
+
+	entity := strings.ToLower(c.PostForm("entity"))
+	profile := strings.ToLower(c.DefaultPostForm("profile", services.DataImportProfileGeneric))
+
+	var mapping map[string]string
+	if raw := c.PostForm("mapping"); raw != "" {
+		if err := json.Unmarshal([]byte(raw), &mapping); err != nil {
+			utils.ErrorResponse(c, http.StatusBadRequest, "INVALID_MAPPING", "Sütun eşlemesi JSON nesnesi olmalı", err.Error())
+			return
+		}
+	}
+
+	fileHeader, err := c.FormFile("file")
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusBadRequest, "MISSING_FILE", "İçe aktarım dosyası gerekli", nil)
+		return
+	}
+	if fileHeader.Size > maxDataImportFileSize {
+		utils.ErrorResponse(c, http.StatusBadRequest, "FILE_TOO_LARGE", "İçe aktarım dosyası çok büyük", nil)
+		return
+	}
+
+	file, err := fileHeader.Open()
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusBadRequest, "INVALID_FILE", "İçe aktarım dosyası okunamadı", err.Error())
+		return
+	}
+	defer file.Close()
+
+	data, err := io.ReadAll(file)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusBadRequest, "INVALID_FILE", "İçe aktarım dosyası okunamadı", err.Error())
+		return
+	}
+
+	imp, err := h.imports.Preview(userID, accountID, fileHeader.Filename, profile, entity, mapping, data)
+	switch {
+	case err == nil:
+	case errors.Is(err, services.ErrDataImportProfile):
+		keys := []string{}
+		for _, profile := range services.DataImportProfiles() {
+			keys = append(keys, profile.Key)
+		}
+		utils.ErrorResponse(c, http.StatusBadRequest, "INVALID_PROFILE", "Geçersiz içe aktarım profili", keys)
+		return
+	case errors.Is(err, services.ErrDataImportEntity):
+		utils.ErrorResponse(c, http.StatusBadRequest, "INVALID_ENTITY", "Bu kayıt türü içe aktarılamaz",
+			[]string{models.DataImportEntityAnimals, models.DataImportEntityLands, models.DataImportEntityTransactions})
+		return
+	case errors.Is(err, services.ErrDataImportColumns):
+		utils.ErrorResponse(c, http.StatusBadRequest, "MISSING_COLUMNS", "Zorunlu alanların sütunları bulunamadı", err.Error())
+		return
+	case errors.Is(err, services.ErrDataImportMapping):
+		utils.ErrorResponse(c, http.StatusBadRequest, "INVALID_MAPPING", "Geçersiz sütun eşlemesi", err.Error())
+		return
+	case errors.Is(err, services.ErrEmptyDataImport):
+		utils.ErrorResponse(c, http.StatusBadRequest, "EMPTY_FILE", "Dosyada veri satırı bulunamadı", nil)
+		return
+	case errors.Is(err, services.ErrDataImportTooLarge):
+		utils.ErrorResponse(c, http.StatusBadRequest, "TOO_MANY_ROWS", "Dosyada çok fazla satır var", nil)
+		return
+	default:
+		utils.ErrorResponse(c, http.StatusBadRequest, "PARSE_ERROR", "İçe aktarım dosyası çözümlenemedi", err.Error())
+		return
+	}
+
+	utils.CreatedResponse(c, imp, "İçe aktarım önizlemesi başarıyla oluşturuldu")
+}
+
+// GetImport içe aktarım detayı
+// @Summary İçe aktarım detayı
+// @Description İçe aktarımı satır bazında sonuçlarla (normalleştirilmiş değerler, hatalar, oluşturulan kayıt kimlikleri) döner
+// @ID getImport
+// @Tags Imports
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "İçe aktarım ID"
+// @Success 200 {object} models.APIResponse{data=models.DataImport}
+// @Failure 401 {object} models.APIResponse
+// @Failure 404 {object} models.APIResponse
+// @Router /imports/{id} [get]
+func (h *DataImportHandler) GetImport(c *gin.Context) {
+	userID, err := utils.GetUserID(c)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusUnauthorized, "UNAUTHORIZED", "Kullanıcı kimliği doğrulanamadı", nil)
+		return
+	}
+
+	imp, err := h.imports.Get(userID, c.Param("id"))
+	if err != nil {
+		h.importError(c, err, "İçe aktarım getirilemedi")
+		return
+	}
+
+	utils.SuccessResponse(c, imp, "İçe aktarım başarıyla getirildi")
+}
+
+// CommitImport içe aktarımı onaylama
+// @Summary İçe aktarımı onayla
+// @Description Önizlemedeki create satırlarını tek işlemde kayıt olarak ekler. Önizlemeden sonra eklenmiş çakışan kayıtlar atlanır; satırlar oluşturulan kayıt kimlikleriyle güncellenir
+// @ID commitImport
+// @Tags Imports
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "İçe aktarım ID"
+// @Success 200 {object} models.APIResponse{data=models.DataImport}
+// @Failure 401 {object} models.APIResponse
+// @Failure 404 {object} models.APIResponse
+// @Failure 409 {object} models.APIResponse
+// @Router /imports/{id}/commit [post]
+func (h *DataImportHandler) CommitImport(c *gin.Context) {
+	userID, err := utils.GetUserID(c)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusUnauthorized, "UNAUTHORIZED", "Kullanıcı kimliği doğrulanamadı", nil)
+		return
+	}
+	accountID, _ := utils.GetAccountID(c)
+
+	imp, err := h.imports.Commit(userID, accountID, c.Param("id"))
+	if err != nil {
+		h.importError(c, err, "İçe aktarım uygulanamadı")
+		return
+	}
+
+	utils.SuccessResponse(c, imp, "İçe aktarım başarıyla uygulandı")
+}
+
+// DiscardImport içe aktarımı iptal etme
+// @Summary İçe aktarımı iptal et
+// @Description Onaylanmamış içe aktarımı kayıt eklemeden iptal eder; içe aktarım geçmişte discarded olarak kalır
+// @ID discardImport
+// @Tags Imports
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "İçe aktarım ID"
+// @Success 200 {object} models.APIResponse
+// @Failure 401 {object} models.APIResponse
+// @Failure 404 {object} models.APIResponse
+// @Failure 409 {object} models.APIResponse
+// @Router /imports/{id}/discard [post]
+func (h *DataImportHandler) DiscardImport(c *gin.Context) {
+	userID, err := utils.GetUserID(c)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusUnauthorized, "UNAUTHORIZED", "Kullanıcı kimliği doğrulanamadı", nil)
+		return
+	}
+	accountID, _ := utils.GetAccountID(c)
+
+	if err := h.imports.Discard(userID, accountID, c.Param("id")); err != nil {
+		h.importError(c, err, "İçe aktarım iptal edilemedi")
+		return
+	}
+
+	utils.SuccessResponse(c, nil, "İçe aktarım iptal edildi")
+}
+
+// importError içe aktarım servis hatalarını HTTP yanıtına çevirir
+func (h *DataImportHandler) importError(c *gin.Context, err error, message string) {
+	switch {
+	case errors.Is(err, services.ErrDataImportNotFound):
+		utils.ErrorResponse(c, http.StatusNotFound, "IMPORT_NOT_FOUND", "İçe aktarım bulunamadı", nil)
+	case errors.Is(err, services.ErrDataImportClosed):
+		utils.ErrorResponse(c, http.StatusConflict, "IMPORT_CLOSED", "İçe aktarım zaten onaylanmış veya iptal edilmiş", nil)
+	default:
+		utils.ErrorResponse(c, http.StatusInternalServerError, "DB_ERROR", message, err.Error())
+	}
+}
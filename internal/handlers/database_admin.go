@@ -0,0 +1,290 @@
+package handlers
+
+import (
+	"database/sql"
+	"errors"
+	"net/http"
+	"strings"
+
+	"agri-management-api/internal/database"
+	"agri-management-api/internal/models"
+	"agri-management-api/internal/services"
+	"agri-management-api/internal/utils"
+
+	"github.com/gin-gonic/gin"
+)
+
+// DatabaseAdminHandler yöneticiler için yavaş sorgu, sorgu planı, alan şifreleme, yedek izleme ve bakım uç noktalarını sağlar
+type DatabaseAdminHandler struct {
+	db          *sql.DB
+	encryption  *services.FieldEncryptionService
+	backups     *services.BackupService
+	maintenance *services.DatabaseMaintenanceService
+}
+
+// NewDatabaseAdminHandler yeni database admin handler oluşturur
+func NewDatabaseAdminHandler(db *sql.DB) *DatabaseAdminHandler {
+	return &DatabaseAdminHandler{
+		db:          db,
+		encryption:  services.NewFieldEncryptionService(db),
+		backups:     services.NewBackupService(db),
+		maintenance: services.NewDatabaseMaintenanceService(db),
+	}
+}
+
+// GetSlowQueries son yavaş sorgular
+// @Summary Yavaş sorgular
+// @Description Sunucu başladığından beri çalışan ve yavaş sorgu eşiğini (SLOW_QUERY_THRESHOLD_MS) aşan sorgu sayılarını ve bellekteki son 100 yavaş sorguyu en yeniden eskiye listeler. Parametre değerleri saklanmaz; endpoint sorguyu çalıştıran isteğin rotasıdır (arka plan işlerinde boş). Yönetici rolü gerektirir
+// @ID getSlowQueries
+// @Tags Admin
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {object} models.APIResponse{data=models.SlowQueryReport}
+// @Failure 401 {object} models.APIResponse
+// @Failure 403 {object} models.APIResponse
+// @Router /admin/db/slow-queries [get]
+func (h *DatabaseAdminHandler) GetSlowQueries(c *gin.Context) {
+	utils.SuccessResponse(c, database.SlowQueries(), "Yavaş sorgular başarıyla getirildi")
+}
+
+// GetTenantScopeAudit kiracı kapsamı denetimi
+// @Summary Kiracı kapsamı denetimi
+// @Description Kiracı kapsamı korumasının modunu (TENANT_SCOPE_GUARD: off, log, enforce), kullanıcıya ait tabloları (user_id sütunu olan tablolar ve bunlara bağlı alt tablolar) ve sunucu başladığından beri HTTP istekleri içinde user_id koşulu olmadan bu tablolara gönderilen sorguları en sık görülenden başlayarak listeler. Yönetici uç noktaları ve arka plan işleri denetlenmez. Yönetici rolü gerektirir
+// @ID getTenantScopeAudit
+// @Tags Admin
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {object} models.APIResponse{data=models.TenantScopeReport}
+// @Failure 401 {object} models.APIResponse
+// @Failure 403 {object} models.APIResponse
+// @Router /admin/db/tenant-scope [get]
+func (h *DatabaseAdminHandler) GetTenantScopeAudit(c *gin.Context) {
+	utils.SuccessResponse(c, database.TenantScopeAudit(), "Kiracı kapsamı denetimi başarıyla getirildi")
+}
+
+// ExplainQuery sorgu planı
+// @Summary Sorgu planı
+// @Description Tek bir SELECT (veya WITH ... SELECT) sorgusunun SQLite sorgu planını (EXPLAIN QUERY PLAN) döner; sorgu çalıştırılmaz ve ? parametreleri NULL kabul edilir. query yerine slowQueryId verilirse kayıtlı yavaş sorgunun planı döner. Yönetici rolü gerektirir
+// @ID explainQuery
+// @Tags Admin
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param request body models.ExplainQueryRequest true "Sorgu"
+// @Success 200 {object} models.APIResponse{data=models.QueryPlan}
+// @Failure 400 {object} models.APIResponse
+// @Failure 401 {object} models.APIResponse
+// @Failure 403 {object} models.APIResponse
+// @Failure 404 {object} models.APIResponse
+// @Router /admin/db/explain [post]
+func (h *DatabaseAdminHandler) ExplainQuery(c *gin.Context) {
+	var req models.ExplainQueryRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		utils.ErrorResponse(c, http.StatusBadRequest, "INVALID_REQUEST", "Geçersiz istek formatı", err.Error())
+		return
+	}
+
+	query := strings.TrimSpace(req.Query)
+	if req.SlowQueryID != "" {
+		slow, ok := database.FindSlowQuery(req.SlowQueryID)
+		if !ok {
+			utils.ErrorResponse(c, http.StatusNotFound, "SLOW_QUERY_NOT_FOUND", "Yavaş sorgu bulunamadı", nil)
+			return
+		}
+		query = slow.Query
+	}
+	query = strings.TrimSpace(strings.TrimSuffix(query, ";"))
+
+	if !explainableQuery(query) {
+		utils.ErrorResponse(c, http.StatusBadRequest, "INVALID_QUERY", "Yalnızca tek bir SELECT sorgusunun planı alınabilir", nil)
+		return
+	}
+
+	rows, err := h.db.Query("EXPLAIN QUERY PLAN "+query, make([]interface{}, countQueryParams(query))...)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusBadRequest, "INVALID_QUERY", "Sorgu planı alınamadı", err.Error())
+		return
+	}
+	defer rows.Close()
+
+	plan := models.QueryPlan{Query: query, Steps: []models.QueryPlanStep{}}
+	for rows.Next() {
+		var step models.QueryPlanStep
+		var notUsed int
+		if err := rows.Scan(&step.ID, &step.Parent, &notUsed, &step.Detail); err != nil {
+			utils.ErrorResponse(c, http.StatusInternalServerError, "DB_ERROR", "Sorgu planı okunamadı", err.Error())
+			return
+		}
+		plan.Steps = append(plan.Steps, step)
+	}
+	if err := rows.Err(); err != nil {
+		utils.ErrorResponse(c, http.StatusInternalServerError, "DB_ERROR", "Sorgu planı okunamadı", err.Error())
+		return
+	}
+
+	utils.SuccessResponse(c, plan, "Sorgu planı başarıyla getirildi")
+}
+
+// explainableQuery sorgunun ; ile ayrılmış birden fazla ifade içermeyen bir SELECT olup olmadığını döner
+func explainableQuery(query string) bool {
+	fields := strings.Fields(strings.ToUpper(query))
+	if len(fields) == 0 || (fields[0] != "SELECT" && fields[0] != "WITH") {
+		return false
+	}
+	return !strings.Contains(query, ";")
+}
+
+// countQueryParams metin sabitleri dışındaki ? parametrelerini sayar
+func countQueryParams(query string) int {
+	count := 0
+	var quote rune
+	for _, r := range query {
+		switch {
+		case quote != 0:
+			if r == quote {
+				quote = 0
+			}
+		case r == '\'' || r == '"':
+			quote = r
+		case r == '?':
+			count++
+		}
+	}
+	return count
+}
+
+// GetFieldEncryption alan şifreleme durumu
+// @Summary Alan şifreleme durumu
+// @Description Hassas sütunların (banka hesap numarası, alıcı vergi/kimlik numarası ve adresi) şifrelenmesinde kullanılan ana anahtar kimliklerini, veri anahtarlarını ve her sütundaki toplam, şifrelenmemiş ve etkin veri anahtarıyla şifrelenmiş değer sayılarını getirir. Anahtarların kendisi dönmez. Yönetici rolü gerektirir
+// @ID getFieldEncryption
+// @Tags Admin
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {object} models.APIResponse{data=models.FieldEncryptionStatus}
+// @Failure 401 {object} models.APIResponse
+// @Failure 403 {object} models.APIResponse
+// @Router /admin/db/encryption [get]
+func (h *DatabaseAdminHandler) GetFieldEncryption(c *gin.Context) {
+	status, err := h.encryption.Status()
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusInternalServerError, "DB_ERROR", "Şifreleme durumu alınamadı", err.Error())
+		return
+	}
+
+	utils.SuccessResponse(c, status, "Şifreleme durumu başarıyla getirildi")
+}
+
+// RotateFieldEncryption şifreleme anahtarlarını döndürme
+// @Summary Şifreleme anahtarlarını döndür
+// @Description Veri anahtarlarını FIELD_ENCRYPTION_KEYS içindeki ilk (etkin) ana anahtarla yeniden sarar, yeni bir veri anahtarı oluşturur ve tüm şifreli ve şifrelenmemiş değerleri bu anahtarla yeniden şifreler. Ana anahtar döndürmek için yeni anahtar listenin başına eklenip sunucu yeniden başlatılır ve bu uç nokta çağrılır; ardından eski ana anahtar listeden kaldırılabilir. Yönetici rolü gerektirir
+// @ID rotateFieldEncryption
+// @Tags Admin
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {object} models.APIResponse{data=models.FieldEncryptionRotation}
+// @Failure 401 {object} models.APIResponse
+// @Failure 403 {object} models.APIResponse
+// @Failure 409 {object} models.APIResponse
+// @Router /admin/db/encryption/rotate [post]
+func (h *DatabaseAdminHandler) RotateFieldEncryption(c *gin.Context) {
+	rotation, err := h.encryption.Rotate()
+	if errors.Is(err, services.ErrFieldEncryptionDisabled) {
+		utils.ErrorResponse(c, http.StatusConflict, "ENCRYPTION_DISABLED", err.Error(), nil)
+		return
+	}
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusInternalServerError, "ROTATION_ERROR", "Şifreleme anahtarları döndürülemedi", err.Error())
+		return
+	}
+
+	utils.SuccessResponse(c, rotation, "Şifreleme anahtarları başarıyla döndürüldü")
+}
+
+// GetBackups tüm çiftliklerin yedekleri
+// @Summary Çiftlik yedekleri
+// @Description Tüm çiftliklerin elle ve zamanlanmış yedeklerini yeniden eskiye listeler; status=failed ile başarısız yedekler ve hata mesajları izlenebilir. Yönetici rolü gerektirir
+// @ID getAdminBackups
+// @Tags Admin
+// @Produce json
+// @Security BearerAuth
+// @Param status query string false "Durum (running, completed, failed)"
+// @Param page query int false "Sayfa numarası"
+// @Param limit query int false "Sayfa başına kayıt"
+// @Success 200 {object} models.APIResponse{data=models.AdminBackupListResponse}
+// @Failure 401 {object} models.APIResponse
+// @Failure 403 {object} models.APIResponse
+// @Router /admin/db/backups [get]
+func (h *DatabaseAdminHandler) GetBackups(c *gin.Context) {
+	page, limit := utils.ParsePagination(c)
+	backups, total, err := h.backups.AdminList(c.Query("status"), page, limit)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusInternalServerError, "DB_ERROR", "Yedekler alınamadı", err.Error())
+		return
+	}
+
+	utils.SuccessResponse(c, models.AdminBackupListResponse{
+		Storage:    h.backups.StorageName(),
+		Backups:    backups,
+		Pagination: utils.CalculatePagination(page, limit, total),
+	}, "Yedekler başarıyla getirildi")
+}
+
+// GetDatabaseMaintenance veritabanı boyutu ve bakım işleri
+// @Summary Veritabanı boyutu ve bakım işleri
+// @Description SQLite dosyasının ve WAL dosyasının diskteki boyutunu, günlük kipini, sayfa ve boş sayfa sayılarını, son VACUUM ve ANALYZE zamanlarını, tüm tabloların kayıt sayılarını ve son 20 bakım işini getirir. Yönetici rolü gerektirir
+// @ID getDatabaseMaintenance
+// @Tags Admin
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {object} models.APIResponse{data=models.DatabaseMaintenanceReport}
+// @Failure 401 {object} models.APIResponse
+// @Failure 403 {object} models.APIResponse
+// @Router /admin/db/maintenance [get]
+func (h *DatabaseAdminHandler) GetDatabaseMaintenance(c *gin.Context) {
+	report, err := h.maintenance.Report()
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusInternalServerError, "DB_ERROR", "Veritabanı bilgileri alınamadı", err.Error())
+		return
+	}
+
+	utils.SuccessResponse(c, report, "Veritabanı bilgileri başarıyla getirildi")
+}
+
+// RunDatabaseMaintenance veritabanı bakımını başlatma
+// @Summary Veritabanı bakımını başlat
+// @Description ANALYZE, boş sayfa oranı %10'u aşmışsa (veya vacuum=true ise) VACUUM ve WAL kipinde TRUNCATE checkpoint çalıştıran bakım işini arka planda başlatır; iş hemen 202 ile döner, sonucu GET /admin/db/maintenance ile izlenir. VACUUM süresince yazmalar bekler. Yönetici rolü gerektirir
+// @ID runDatabaseMaintenance
+// @Tags Admin
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param request body models.DatabaseMaintenanceRequest false "Bakım seçenekleri"
+// @Success 202 {object} models.APIResponse{data=models.DatabaseMaintenanceRun}
+// @Failure 400 {object} models.APIResponse
+// @Failure 401 {object} models.APIResponse
+// @Failure 403 {object} models.APIResponse
+// @Failure 409 {object} models.APIResponse
+// @Router /admin/db/maintenance [post]
+func (h *DatabaseAdminHandler) RunDatabaseMaintenance(c *gin.Context) {
+	var req models.DatabaseMaintenanceRequest
+	if c.Request.ContentLength > 0 {
+		if err := c.ShouldBindJSON(&req); err != nil {
+			utils.ErrorResponse(c, http.StatusBadRequest, "INVALID_REQUEST", "Geçersiz istek formatı", err.Error())
+			return
+		}
+	}
+
+	run, err := h.maintenance.Start(c.GetString("user_id"), req.Vacuum)
+	if errors.Is(err, services.ErrDatabaseMaintenanceRunning) {
+		utils.ErrorResponse(c, http.StatusConflict, "DB_MAINTENANCE_RUNNING", "Sürmekte olan bir bakım işi var", nil)
+		return
+	}
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusInternalServerError, "DB_ERROR", "Veritabanı bakımı başlatılamadı", err.Error())
+		return
+	}
+
+	utils.SuccessResponseWithStatus(c, http.StatusAccepted, run, "Veritabanı bakımı başlatıldı")
+}
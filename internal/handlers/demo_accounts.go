@@ -0,0 +1,138 @@
+package handlers
+
+import (
+	"database/sql"
+	"errors"
+	"net/http"
+
+	"agri-management-api/internal/models"
+	"agri-management-api/internal/services"
+	"agri-management-api/internal/utils"
+
+	"github.com/gin-gonic/gin"
+)
+
+// DemoAccountHandler yöneticilerin sandbox (demo) hesaplarını yönetmesini sağlar
+type DemoAccountHandler struct {
+	demos *services.DemoAccountService
+}
+
+// NewDemoAccountHandler yeni demo account handler oluşturur
+func NewDemoAccountHandler(db *sql.DB) *DemoAccountHandler {
+	return &DemoAccountHandler{demos: services.NewDemoAccountService(db)}
+}
+
+// GetDemoAccounts sandbox hesapları
+// @Summary Sandbox hesapları
+// @Description Sandbox (demo) hesaplarını son sıfırlama zamanı, örnek veri üretecinin tablo başına eklediği kayıt sayıları ve varsa son sıfırlama hatasıyla listeler. Yönetici rolü gerektirir
+// @ID getDemoAccounts
+// @Tags Admin
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {object} models.APIResponse{data=[]models.DemoAccount}
+// @Failure 401 {object} models.APIResponse
+// @Failure 403 {object} models.APIResponse
+// @Router /admin/demo-accounts [get]
+func (h *DemoAccountHandler) GetDemoAccounts(c *gin.Context) {
+	accounts, err := h.demos.List()
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusInternalServerError, "DB_ERROR", "Sandbox hesapları alınamadı", err.Error())
+		return
+	}
+
+	utils.SuccessResponse(c, accounts, "Sandbox hesapları başarıyla getirildi")
+}
+
+// CreateDemoAccount sandbox hesabı oluşturma
+// @Summary Sandbox hesabı oluştur
+// @Description demo rolünde, mobil ekibin ve uygulamayı deneyecek kişilerin paylaşacağı giriş bilgileriyle hesap açar ve çiftliği örnek arazi, hayvan, üretim, finans, müşteri ve takvim kayıtlarıyla doldurur. Veriler her gece DEMO_RESET_HOUR saatinde örnek veri üreteciyle sıfırlanır; sandbox hesapları e-posta gibi gerçek bildirim alamaz, şifresini değiştiremez ve entegrasyon anahtarı oluşturamaz. Yönetici rolü gerektirir
+// @ID createDemoAccount
+// @Tags Admin
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param request body models.DemoAccountRequest true "Sandbox hesabı"
+// @Success 201 {object} models.APIResponse{data=models.DemoAccount}
+// @Failure 400 {object} models.APIResponse
+// @Failure 401 {object} models.APIResponse
+// @Failure 403 {object} models.APIResponse
+// @Failure 409 {object} models.APIResponse
+// @Router /admin/demo-accounts [post]
+func (h *DemoAccountHandler) CreateDemoAccount(c *gin.Context) {
+	adminID, err := utils.GetUserID(c)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusUnauthorized, "UNAUTHORIZED", "Kullanıcı kimliği doğrulanamadı", nil)
+		return
+	}
+
+	var req models.DemoAccountRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		utils.ErrorResponse(c, http.StatusBadRequest, "INVALID_REQUEST", "Geçersiz istek formatı", err.Error())
+		return
+	}
+
+	account, err := h.demos.Create(adminID, req)
+	if err != nil {
+		writeDemoAccountError(c, err, "Sandbox hesabı oluşturulamadı")
+		return
+	}
+
+	utils.CreatedResponse(c, account, "Sandbox hesabı başarıyla oluşturuldu")
+}
+
+// ResetDemoAccount sandbox hesabını sıfırlama
+// @Summary Sandbox hesabını sıfırla
+// @Description Gecelik sıfırlamayı beklemeden hesabın tüm çiftlik verilerini (ek çiftlikleri dahil) siler, profilini ilk haline getirir ve örnek verileri bugüne göre yeniden üretir. Yönetici rolü gerektirir
+// @ID resetDemoAccount
+// @Tags Admin
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Sandbox hesabı ID"
+// @Success 200 {object} models.APIResponse{data=models.DemoAccount}
+// @Failure 401 {object} models.APIResponse
+// @Failure 403 {object} models.APIResponse
+// @Failure 404 {object} models.APIResponse
+// @Router /admin/demo-accounts/{id}/reset [post]
+func (h *DemoAccountHandler) ResetDemoAccount(c *gin.Context) {
+	account, err := h.demos.Reset(c.Param("id"))
+	if err != nil {
+		writeDemoAccountError(c, err, "Sandbox hesabı sıfırlanamadı")
+		return
+	}
+
+	utils.SuccessResponse(c, account, "Sandbox hesabı başarıyla sıfırlandı")
+}
+
+// DeleteDemoAccount sandbox hesabını silme
+// @Summary Sandbox hesabını sil
+// @Description Sandbox hesabını tüm çiftlik verileriyle birlikte siler. Yönetici rolü gerektirir
+// @ID deleteDemoAccount
+// @Tags Admin
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Sandbox hesabı ID"
+// @Success 200 {object} models.APIResponse
+// @Failure 401 {object} models.APIResponse
+// @Failure 403 {object} models.APIResponse
+// @Failure 404 {object} models.APIResponse
+// @Router /admin/demo-accounts/{id} [delete]
+func (h *DemoAccountHandler) DeleteDemoAccount(c *gin.Context) {
+	if err := h.demos.Delete(c.Param("id")); err != nil {
+		writeDemoAccountError(c, err, "Sandbox hesabı silinemedi")
+		return
+	}
+
+	utils.SuccessResponse(c, nil, "Sandbox hesabı başarıyla silindi")
+}
+
+// writeDemoAccountError servis hatasını HTTP yanıtına çevirir
+func writeDemoAccountError(c *gin.Context, err error, message string) {
+	switch {
+	case errors.Is(err, services.ErrDemoAccountNotFound):
+		utils.ErrorResponse(c, http.StatusNotFound, "DEMO_ACCOUNT_NOT_FOUND", "Sandbox hesabı bulunamadı", nil)
+	case errors.Is(err, services.ErrDemoEmailExists):
+		utils.ErrorResponse(c, http.StatusConflict, "EMAIL_EXISTS", "Bu email adresi zaten kullanımda", nil)
+	default:
+		utils.ErrorResponse(c, http.StatusInternalServerError, "DB_ERROR", message, err.Error())
+	}
+}
@@ -0,0 +1,380 @@
+package handlers
+
+import (
+	"bytes"
+	"context"
+	"database/sql"
+	"io"
+	"net/http"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"agri-management-api/internal/models"
+	"agri-management-api/internal/services"
+	"agri-management-api/internal/utils"
+
+	"github.com/gin-gonic/gin"
+)
+
+// maxDiagnosisPhotoSize teşhis için yüklenebilecek en büyük fotoğraf boyutu
+const maxDiagnosisPhotoSize = 10 << 20
+
+// DiagnosisHandler fotoğraf teşhisi ve zararlı/hastalık gözlemlerini yönetir
+type DiagnosisHandler struct {
+	db         *sql.DB
+	store      services.MediaStore
+	identifier services.DiseaseIdentifier
+}
+
+// NewDiagnosisHandler yeni diagnosis handler oluşturur
+func NewDiagnosisHandler(db *sql.DB) *DiagnosisHandler {
+	return &DiagnosisHandler{
+		db:         db,
+		store:      services.NewMediaStore(),
+		identifier: services.NewDiseaseIdentifier(),
+	}
+}
+
+// IdentifyPhoto fotoğraftan teşhis
+// @Summary Fotoğraftan zararlı/hastalık teşhisi
+// @Description Bitki veya hayvan fotoğrafını yapılandırılmış görsel teşhis sağlayıcısına gönderir ve olası teşhisleri güven değerine (0-1) göre azalan sırada döner. createObservation=true ise en olası teşhisle ön doldurulmuş "suspected" durumunda bir gözlem kaydı oluşturulur ve fotoğraf gözleme eklenir. Sağlayıcı yapılandırılmamışsa 503 döner
+// @ID identifyDiagnosisPhoto
+// @Tags Diagnosis
+// @Accept multipart/form-data
+// @Produce json
+// @Security BearerAuth
+// @Param file formData file true "Fotoğraf (image/*, en fazla 10 MB)"
+// @Param subjectType formData string true "Konu" Enums(plant, animal)
+// @Param landId formData string false "Arazi ID"
+// @Param livestockId formData string false "Hayvan ID"
+// @Param createObservation formData bool false "Sonuçtan gözlem kaydı oluştur"
+// @Param observedOn formData string false "Gözlem tarihi (YYYY-MM-DD, varsayılan: bugün)"
+// @Param notes formData string false "Notlar"
+// @Success 200 {object} models.APIResponse{data=models.DiagnosisResult}
+// @Failure 400 {object} models.APIResponse
+// @Failure 401 {object} models.APIResponse
+// @Failure 404 {object} models.APIResponse
+// @Failure 502 {object} models.APIResponse
+// @Failure 503 {object} models.APIResponse
+// @Router /diagnosis/identify [post]
+func (h *DiagnosisHandler) IdentifyPhoto(c *gin.Context) {
+	userID, err := utils.GetUserID(c)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusUnauthorized, "UNAUTHORIZED", "Kullanıcı kimliği doğrulanamadı", nil)
+		return
+	}
+
+	if h.identifier == nil {
+		utils.ErrorResponse(c, http.StatusServiceUnavailable, "DIAGNOSIS_UNAVAILABLE", "Görsel teşhis sağlayıcısı yapılandırılmamış", services.ErrDiagnosisDisabled.Error())
+		return
+	}
+
+	subjectType := c.PostForm("subjectType")
+	if subjectType != models.DiagnosisSubjectPlant && subjectType != models.DiagnosisSubjectAnimal {
+		utils.ErrorResponse(c, http.StatusBadRequest, "INVALID_SUBJECT_TYPE", "Geçersiz konu türü", []string{"plant", "animal"})
+		return
+	}
+
+	landID := c.PostForm("landId")
+	if landID != "" && !h.owns("SELECT 1 FROM lands WHERE id = ? AND user_id = ?", landID, userID) {
+		utils.ErrorResponse(c, http.StatusNotFound, "LAND_NOT_FOUND", "Arazi bulunamadı", nil)
+		return
+	}
+	livestockID := c.PostForm("livestockId")
+	if livestockID != "" && !h.owns("SELECT 1 FROM livestock WHERE id = ? AND user_id = ?", livestockID, userID) {
+		utils.ErrorResponse(c, http.StatusNotFound, "LIVESTOCK_NOT_FOUND", "Hayvan bulunamadı", nil)
+		return
+	}
+
+	observedOn := c.PostForm("observedOn")
+	if observedOn == "" {
+		observedOn = time.Now().Format("2006-01-02")
+	} else if _, err := time.Parse("2006-01-02", observedOn); err != nil {
+		utils.ErrorResponse(c, http.StatusBadRequest, "INVALID_DATE", "Geçersiz tarih", "observedOn")
+		return
+	}
+
+	fileHeader, err := c.FormFile("file")
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusBadRequest, "MISSING_FILE", "Fotoğraf gerekli", nil)
+		return
+	}
+	if fileHeader.Size > maxDiagnosisPhotoSize {
+		utils.ErrorResponse(c, http.StatusBadRequest, "FILE_TOO_LARGE", "Fotoğraf çok büyük", nil)
+		return
+	}
+
+	contentType := fileHeader.Header.Get("Content-Type")
+	if !strings.HasPrefix(contentType, "image/") {
+		utils.ErrorResponse(c, http.StatusBadRequest, "INVALID_FILE_TYPE", "Yalnızca fotoğraf yüklenebilir", nil)
+		return
+	}
+
+	file, err := fileHeader.Open()
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusBadRequest, "INVALID_FILE", "Fotoğraf okunamadı", err.Error())
+		return
+	}
+	defer file.Close()
+
+	image, err := io.ReadAll(file)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusBadRequest, "INVALID_FILE", "Fotoğraf okunamadı", err.Error())
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(c.Request.Context(), 90*time.Second)
+	defer cancel()
+	candidates, err := h.identifier.Identify(ctx, subjectType, filepath.Base(fileHeader.Filename), contentType, image)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusBadGateway, "DIAGNOSIS_FAILED", "Teşhis yapılamadı", err.Error())
+		return
+	}
+
+	result := models.DiagnosisResult{
+		Provider:    h.identifier.Name(),
+		SubjectType: subjectType,
+		Candidates:  candidates,
+	}
+
+	if create, _ := strconv.ParseBool(c.PostForm("createObservation")); create {
+		observationID := utils.GenerateID()
+		name := "Tanımlanamadı"
+		category := models.ObservationCategoryOther
+		var confidence *float64
+		if len(candidates) > 0 {
+			name, category, confidence = candidates[0].Name, candidates[0].Category, &candidates[0].Confidence
+		}
+		candidatesJSON, _ := utils.ToJSON(candidates)
+
+		photoID := utils.GenerateID()
+		storageKey := filepath.Join(userID, photoID+strings.ToLower(filepath.Ext(fileHeader.Filename)))
+		size, err := h.store.Save(storageKey, bytes.NewReader(image))
+		if err != nil {
+			utils.ErrorResponse(c, http.StatusInternalServerError, "STORAGE_ERROR", "Fotoğraf kaydedilemedi", err.Error())
+			return
+		}
+
+		tx, err := h.db.Begin()
+		if err != nil {
+			h.store.Delete(storageKey)
+			utils.ErrorResponse(c, http.StatusInternalServerError, "DB_ERROR", "Gözlem oluşturulamadı", err.Error())
+			return
+		}
+		defer tx.Rollback()
+
+		_, err = tx.Exec(`
+			INSERT INTO pest_disease_observations (id, user_id, subject_type, land_id, livestock_id, name, category,
+			                                       confidence, status, source, photo_id, candidates, observed_on, notes)
+			VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
+		`, observationID, userID, subjectType, utils.StringToNullString(landID), utils.StringToNullString(livestockID),
+			name, category, confidence, models.ObservationStatusSuspected, models.ObservationSourcePhoto, photoID,
+			candidatesJSON, observedOn, c.PostForm("notes"))
+		if err == nil {
+			_, err = tx.Exec(`
+				INSERT INTO media_attachments (id, user_id, entity_type, entity_id, kind, filename, content_type,
+				                               size, storage_key, created_at)
+				VALUES (?, ?, 'pest_disease_observation', ?, 'image', ?, ?, ?, ?, CURRENT_TIMESTAMP)
+			`, photoID, userID, observationID, filepath.Base(fileHeader.Filename), contentType, size, storageKey)
+		}
+		if err == nil {
+			err = tx.Commit()
+		}
+		if err != nil {
+			h.store.Delete(storageKey)
+			utils.ErrorResponse(c, http.StatusInternalServerError, "DB_ERROR", "Gözlem oluşturulamadı", err.Error())
+			return
+		}
+
+		observation, err := h.getObservation(observationID, userID)
+		if err != nil {
+			utils.ErrorResponse(c, http.StatusInternalServerError, "FETCH_ERROR", "Oluşturulan gözlem getirilemedi", err.Error())
+			return
+		}
+		result.Observation = &observation
+	}
+
+	utils.SuccessResponse(c, result, "Teşhis başarıyla tamamlandı")
+}
+
+// GetObservations zararlı/hastalık gözlemleri
+// @Summary Zararlı/hastalık gözlemleri
+// @Description Gözlemleri yeniden eskiye listeler; arazi, hayvan veya duruma göre filtrelenebilir
+// @ID getPestDiseaseObservations
+// @Tags Diagnosis
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param landId query string false "Arazi ID"
+// @Param livestockId query string false "Hayvan ID"
+// @Param status query string false "Durum" Enums(suspected, confirmed, dismissed)
+// @Success 200 {object} models.APIResponse{data=[]models.PestDiseaseObservation}
+// @Failure 401 {object} models.APIResponse
+// @Router /diagnosis/observations [get]
+func (h *DiagnosisHandler) GetObservations(c *gin.Context) {
+	userID, err := utils.GetUserID(c)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusUnauthorized, "UNAUTHORIZED", "Kullanıcı kimliği doğrulanamadı", nil)
+		return
+	}
+
+	whereClause := "WHERE user_id = ?"
+	args := []interface{}{userID}
+	for param, column := range map[string]string{"landId": "land_id", "livestockId": "livestock_id", "status": "status"} {
+		if value := c.Query(param); value != "" {
+			whereClause += " AND " + column + " = ?"
+			args = append(args, value)
+		}
+	}
+
+	rows, err := h.db.Query(pestDiseaseObservationColumns+whereClause+" ORDER BY observed_on DESC, created_at DESC", args...)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusInternalServerError, "DB_ERROR", "Gözlemler getirilemedi", err.Error())
+		return
+	}
+	defer rows.Close()
+
+	observations := []models.PestDiseaseObservation{}
+	for rows.Next() {
+		observation, err := scanPestDiseaseObservation(rows)
+		if err != nil {
+			continue
+		}
+		observations = append(observations, observation)
+	}
+
+	utils.SuccessResponse(c, observations, "Gözlemler başarıyla getirildi")
+}
+
+// GetObservation zararlı/hastalık gözlemi detayı
+// @Summary Zararlı/hastalık gözlemi
+// @Description Gözlemi sağlayıcının önerdiği tüm adaylarla birlikte döner; fotoğraf /media/{photoId}/content adresinden indirilir
+// @ID getPestDiseaseObservation
+// @Tags Diagnosis
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Gözlem ID"
+// @Success 200 {object} models.APIResponse{data=models.PestDiseaseObservation}
+// @Failure 401 {object} models.APIResponse
+// @Failure 404 {object} models.APIResponse
+// @Router /diagnosis/observations/{id} [get]
+func (h *DiagnosisHandler) GetObservation(c *gin.Context) {
+	userID, err := utils.GetUserID(c)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusUnauthorized, "UNAUTHORIZED", "Kullanıcı kimliği doğrulanamadı", nil)
+		return
+	}
+
+	observation, err := h.getObservation(c.Param("id"), userID)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusNotFound, "OBSERVATION_NOT_FOUND", "Gözlem bulunamadı", nil)
+		return
+	}
+
+	utils.SuccessResponse(c, observation, "Gözlem başarıyla getirildi")
+}
+
+// UpdateObservation zararlı/hastalık gözlemi güncelleme
+// @Summary Zararlı/hastalık gözlemi güncelleme
+// @Description Ön doldurulan teşhisi doğrular, düzeltir veya reddeder
+// @ID updatePestDiseaseObservation
+// @Tags Diagnosis
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Gözlem ID"
+// @Param request body models.UpdatePestDiseaseObservationRequest true "Güncellenecek alanlar"
+// @Success 200 {object} models.APIResponse{data=models.PestDiseaseObservation}
+// @Failure 400 {object} models.APIResponse
+// @Failure 401 {object} models.APIResponse
+// @Failure 404 {object} models.APIResponse
+// @Router /diagnosis/observations/{id} [put]
+func (h *DiagnosisHandler) UpdateObservation(c *gin.Context) {
+	userID, err := utils.GetUserID(c)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusUnauthorized, "UNAUTHORIZED", "Kullanıcı kimliği doğrulanamadı", nil)
+		return
+	}
+
+	var req models.UpdatePestDiseaseObservationRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		utils.ErrorResponse(c, http.StatusBadRequest, "INVALID_REQUEST", "Geçersiz istek formatı", err.Error())
+		return
+	}
+	if req.Name != nil && utils.IsEmptyString(*req.Name) {
+		utils.ErrorResponse(c, http.StatusBadRequest, "INVALID_NAME", "Teşhis adı boş olamaz", nil)
+		return
+	}
+
+	observationID := c.Param("id")
+	result, err := h.db.Exec(`
+		UPDATE pest_disease_observations
+		SET name = COALESCE(?, name), category = COALESCE(?, category), status = COALESCE(?, status),
+		    notes = COALESCE(?, notes), updated_at = CURRENT_TIMESTAMP
+		WHERE id = ? AND user_id = ?
+	`, req.Name, req.Category, req.Status, req.Notes, observationID, userID)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusInternalServerError, "UPDATE_ERROR", "Gözlem güncellenemedi", err.Error())
+		return
+	}
+	if affected, _ := result.RowsAffected(); affected == 0 {
+		utils.ErrorResponse(c, http.StatusNotFound, "OBSERVATION_NOT_FOUND", "Gözlem bulunamadı", nil)
+		return
+	}
+
+	observation, err := h.getObservation(observationID, userID)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusInternalServerError, "FETCH_ERROR", "Güncellenen gözlem getirilemedi", err.Error())
+		return
+	}
+
+	utils.SuccessResponse(c, observation, "Gözlem başarıyla güncellendi")
+}
+
+// pestDiseaseObservationColumns gözlem sorgularının ortak SELECT kısmı
+const pestDiseaseObservationColumns = `
+	SELECT id, subject_type, land_id, livestock_id, name, category, confidence, status, source, photo_id,
+	       COALESCE(candidates, '[]'), observed_on, COALESCE(notes, ''), created_at, updated_at
+	FROM pest_disease_observations `
+
+// getObservation kullanıcıya ait gözlemi getirir
+func (h *DiagnosisHandler) getObservation(observationID, userID string) (models.PestDiseaseObservation, error) {
+	row := h.db.QueryRow(pestDiseaseObservationColumns+"WHERE id = ? AND user_id = ?", observationID, userID)
+	return scanPestDiseaseObservation(row)
+}
+
+// owns kaydın kullanıcıya ait olup olmadığını sahiplik sorgusuyla kontrol eder
+func (h *DiagnosisHandler) owns(query, id, userID string) bool {
+	var exists bool
+	return h.db.QueryRow(query, id, userID).Scan(&exists) == nil
+}
+
+// scanPestDiseaseObservation gözlem satırını okur
+func scanPestDiseaseObservation(row interface{ Scan(...interface{}) error }) (models.PestDiseaseObservation, error) {
+	var observation models.PestDiseaseObservation
+	var landID, livestockID, photoID sql.NullString
+	var confidence sql.NullFloat64
+	var candidates string
+	var observedOn time.Time
+
+	err := row.Scan(
+		&observation.ID, &observation.SubjectType, &landID, &livestockID, &observation.Name, &observation.Category,
+		&confidence, &observation.Status, &observation.Source, &photoID, &candidates, &observedOn,
+		&observation.Notes, &observation.CreatedAt, &observation.UpdatedAt,
+	)
+	if err != nil {
+		return observation, err
+	}
+
+	observation.LandID = utils.NullStringToPtr(landID)
+	observation.LivestockID = utils.NullStringToPtr(livestockID)
+	observation.PhotoID = utils.NullStringToPtr(photoID)
+	observation.Confidence = utils.NullFloat64ToPtr(confidence)
+	observation.ObservedOn = observedOn.Format("2006-01-02")
+	observation.Candidates = []models.DiagnosisCandidate{}
+	utils.FromJSON(candidates, &observation.Candidates)
+	return observation, nil
+}
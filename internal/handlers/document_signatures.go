@@ -0,0 +1,156 @@
+package handlers
+
+import (
+	"database/sql"
+	"errors"
+	"io"
+	"net/http"
+	"slices"
+	"strings"
+
+	"agri-management-api/internal/models"
+	"agri-management-api/internal/services"
+	"agri-management-api/internal/utils"
+
+	"github.com/gin-gonic/gin"
+)
+
+// maxVerifiedDocumentSize doğrulamaya gönderilebilecek en büyük belge boyutu
+const maxVerifiedDocumentSize = 100 << 20
+
+// documentTypes imzalanan belge türleri
+var documentTypes = []string{
+	models.DocumentTypeComplianceBundle, models.DocumentTypeAnimalPassport,
+	models.DocumentTypeHerdBook, models.DocumentTypeRegistryExport,
+}
+
+// DocumentSignatureHandler imzalı dışa aktarımları listeler ve alıcıların belgeleri doğrulamasını sağlar
+type DocumentSignatureHandler struct {
+	signatures *services.DocumentSigningService
+}
+
+// NewDocumentSignatureHandler yeni document signature handler oluşturur
+func NewDocumentSignatureHandler(db *sql.DB) *DocumentSignatureHandler {
+	return &DocumentSignatureHandler{signatures: services.NewDocumentSigningService(db)}
+}
+
+// signExport dışa aktarılan belgeyi imzalar ve ayrık imzayı yanıt başlıklarına yazar: X-Document-Signature
+// (belgenin baytları üzerinde base64 Ed25519 imzası), X-Document-Signature-Key-Id, X-Document-Signature-Id ve
+// X-Document-SHA256. Hata varsa yanıtı yazar
+func signExport(c *gin.Context, signer *services.DocumentSigningService, farmID, documentType, documentID, filename, contentType string, data []byte) bool {
+	signature, err := signer.Sign(farmID, documentType, documentID, filename, contentType, data)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusInternalServerError, "SIGNING_ERROR", "Belge imzalanamadı", err.Error())
+		return false
+	}
+
+	c.Header("X-Document-Signature", signature.Signature)
+	c.Header("X-Document-Signature-Id", signature.ID)
+	c.Header("X-Document-Signature-Key-Id", signature.KeyID)
+	c.Header("X-Document-SHA256", signature.SHA256)
+	return true
+}
+
+// GetDocumentSignatures imzalı belge listesi
+// @Summary İmzalı belgeler
+// @Description Çiftlik için üretilip sunucu anahtarıyla imzalanan uyum paketi, hayvan pasaportu, soy kütüğü ve resmi kayıt dışa aktarımlarını imzalarıyla birlikte yeniden eskiye listeler
+// @ID getDocumentSignatures
+// @Tags Documents
+// @Produce json
+// @Security BearerAuth
+// @Param type query string false "Belge türü (compliance_bundle, animal_passport, herd_book, registry_export)"
+// @Param page query int false "Sayfa numarası" default(1)
+// @Param limit query int false "Sayfa başına kayıt" default(10)
+// @Success 200 {object} models.APIResponse{data=models.DocumentSignatureListResponse}
+// @Failure 400 {object} models.APIResponse
+// @Failure 401 {object} models.APIResponse
+// @Router /documents/signatures [get]
+func (h *DocumentSignatureHandler) GetDocumentSignatures(c *gin.Context) {
+	userID, err := utils.GetUserID(c)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusUnauthorized, "UNAUTHORIZED", "Kullanıcı kimliği doğrulanamadı", nil)
+		return
+	}
+
+	documentType := c.Query("type")
+	if documentType != "" && !slices.Contains(documentTypes, documentType) {
+		utils.ErrorResponse(c, http.StatusBadRequest, "INVALID_TYPE", "Geçersiz belge türü", documentTypes)
+		return
+	}
+
+	page, limit := utils.ParsePagination(c)
+	response, err := h.signatures.Signatures(userID, documentType, page, limit)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusInternalServerError, "DB_ERROR", "İmzalı belgeler alınamadı", err.Error())
+		return
+	}
+
+	utils.SuccessResponse(c, response, "İmzalı belgeler başarıyla getirildi")
+}
+
+// GetDocumentSigningKeys belge imza anahtarları
+// @Summary Belge imza anahtarları
+// @Description Dışa aktarımların ayrık imzalarını çevrimdışı doğrulamak için sunucunun Ed25519 açık anahtarlarını yayımlar; emekli anahtarlar eski belgeler için listede kalır. İmza belgenin baytları üzerindedir, ör. openssl pkeyutl -verify -pubin -inkey anahtar.pem -rawin -in belge.pdf -sigfile imza.bin (imza.bin X-Document-Signature başlığının base64 çözülmüş hali). Oturum gerektirmez
+// @ID getDocumentSigningKeys
+// @Tags Documents
+// @Produce json
+// @Success 200 {object} models.APIResponse{data=[]models.DocumentSigningKey}
+// @Failure 429 {object} models.APIResponse
+// @Router /public/documents/signing-keys [get]
+func (h *DocumentSignatureHandler) GetDocumentSigningKeys(c *gin.Context) {
+	keys, err := h.signatures.Keys()
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusInternalServerError, "DB_ERROR", "İmza anahtarları alınamadı", err.Error())
+		return
+	}
+
+	utils.SuccessResponse(c, keys, "İmza anahtarları başarıyla getirildi")
+}
+
+// VerifyDocument belge doğrulama
+// @Summary Belge doğrula
+// @Description Alıcının elindeki belgenin bu sunucuda üretildiğini ve üretildikten sonra değiştirilmediğini doğrular: belgenin SHA-256 özetiyle kayıtlı imza bulunur ve sunucu açık anahtarıyla doğrulanır. signature verilirse (belgeyle gelen X-Document-Signature değeri) o imza da doğrulanır. Belge değiştirilmişse valid=false ve neden döner. Oturum gerektirmez
+// @ID verifyDocument
+// @Tags Documents
+// @Accept multipart/form-data
+// @Produce json
+// @Param file formData file true "Doğrulanacak belge"
+// @Param signature formData string false "Ayrık imza (base64)"
+// @Success 200 {object} models.APIResponse{data=models.DocumentVerification}
+// @Failure 400 {object} models.APIResponse
+// @Failure 429 {object} models.APIResponse
+// @Router /public/documents/verify [post]
+func (h *DocumentSignatureHandler) VerifyDocument(c *gin.Context) {
+	fileHeader, err := c.FormFile("file")
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusBadRequest, "MISSING_FILE", "Doğrulanacak belge gerekli", nil)
+		return
+	}
+	if fileHeader.Size > maxVerifiedDocumentSize {
+		utils.ErrorResponse(c, http.StatusBadRequest, "FILE_TOO_LARGE", "Belge çok büyük", nil)
+		return
+	}
+
+	file, err := fileHeader.Open()
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusBadRequest, "INVALID_FILE", "Belge okunamadı", err.Error())
+		return
+	}
+	defer file.Close()
+
+	data, err := io.ReadAll(file)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusBadRequest, "INVALID_FILE", "Belge okunamadı", err.Error())
+		return
+	}
+
+	result, err := h.signatures.Verify(data, strings.TrimSpace(c.PostForm("signature")))
+	switch {
+	case err == nil:
+		utils.SuccessResponse(c, result, "Belge doğrulandı")
+	case errors.Is(err, services.ErrDocumentSignatureInvalid):
+		utils.ErrorResponse(c, http.StatusBadRequest, "INVALID_SIGNATURE", err.Error(), nil)
+	default:
+		utils.ErrorResponse(c, http.StatusInternalServerError, "VERIFICATION_ERROR", "Belge doğrulanamadı", err.Error())
+	}
+}
@@ -0,0 +1,623 @@
+package handlers
+
+import (
+	"bytes"
+	"database/sql"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"agri-management-api/internal/models"
+	"agri-management-api/internal/services"
+	"agri-management-api/internal/utils"
+
+	"github.com/gin-gonic/gin"
+)
+
+// maxDocumentSize yüklenebilecek en büyük doküman boyutu
+const maxDocumentSize = 25 << 20
+
+// defaultDocumentReminderDays hatırlatma süresi belirtilmeyen dokümanlar için bitişten önceki gün sayısı
+const defaultDocumentReminderDays = 30
+
+// documentEntityNames dokümanın bağlanabileceği kayıtlar için sahiplik ve ad sorguları
+var documentEntityNames = map[string]string{
+	"land":      "SELECT name FROM lands WHERE id = ? AND user_id = ?",
+	"livestock": "SELECT tag_number FROM livestock WHERE id = ? AND user_id = ?",
+	"asset":     "SELECT name FROM fixed_assets WHERE id = ? AND user_id = ?",
+}
+
+// DocumentHandler sözleşme, tapu, izin ve sertifika gibi dokümanları yönetir
+type DocumentHandler struct {
+	db            *sql.DB
+	store         services.MediaStore
+	notifications *services.NotificationService
+}
+
+// NewDocumentHandler yeni document handler oluşturur
+func NewDocumentHandler(db *sql.DB) *DocumentHandler {
+	return &DocumentHandler{
+		db:            db,
+		store:         services.NewMediaStore(),
+		notifications: services.NewNotificationService(db),
+	}
+}
+
+// GetDocuments doküman listesi
+// @Summary Dokümanlar
+// @Description Dokümanları kategori, bağlı kayıt ve bitiş tarihine göre filtreleyerek listeler; q parametresi başlık, açıklama ve dosyadan çıkarılan metinde arar
+// @ID getDocuments
+// @Tags Documents
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param category query string false "Kategori (contract, deed, permit, certificate, insurance, other)"
+// @Param entityType query string false "Bağlı kayıt türü (land, livestock, asset)"
+// @Param entityId query string false "Bağlı kayıt ID"
+// @Param q query string false "Tam metin arama"
+// @Param expiresWithin query int false "Belirtilen gün içinde süresi dolacak (veya dolmuş) dokümanlar"
+// @Success 200 {object} models.APIResponse{data=[]models.Document}
+// @Failure 401 {object} models.APIResponse
+// @Router /documents [get]
+func (h *DocumentHandler) GetDocuments(c *gin.Context) {
+	userID, err := utils.GetUserID(c)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusUnauthorized, "UNAUTHORIZED", "Kullanıcı kimliği doğrulanamadı", nil)
+		return
+	}
+
+	whereClause := "WHERE d.user_id = ?"
+	args := []interface{}{userID}
+
+	if category := c.Query("category"); category != "" {
+		whereClause += " AND d.category = ?"
+		args = append(args, category)
+	}
+	if entityType := c.Query("entityType"); entityType != "" {
+		whereClause += " AND d.entity_type = ?"
+		args = append(args, entityType)
+	}
+	if entityID := c.Query("entityId"); entityID != "" {
+		whereClause += " AND d.entity_id = ?"
+		args = append(args, entityID)
+	}
+
+	terms := strings.Fields(c.Query("q"))
+	for _, term := range terms {
+		pattern := "%" + term + "%"
+		whereClause += " AND (d.title LIKE ? OR COALESCE(d.description, '') LIKE ? OR COALESCE(d.extracted_text, '') LIKE ?)"
+		args = append(args, pattern, pattern, pattern)
+	}
+
+	if days, err := strconv.Atoi(c.Query("expiresWithin")); err == nil && days >= 0 {
+		whereClause += " AND d.expiry_date IS NOT NULL AND d.expiry_date < ?"
+		args = append(args, today().AddDate(0, 0, days+1))
+	}
+
+	orderBy := "d.created_at DESC"
+	if c.Query("expiresWithin") != "" {
+		orderBy = "d.expiry_date"
+	}
+
+	rows, err := h.db.Query(documentSelect+whereClause+" ORDER BY "+orderBy, args...)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusInternalServerError, "DB_ERROR", "Dokümanlar alınamadı", err.Error())
+		return
+	}
+	defer rows.Close()
+
+	documents := []models.Document{}
+	for rows.Next() {
+		document, err := scanDocument(rows)
+		if err != nil {
+			continue
+		}
+		if len(terms) > 0 {
+			document.Snippet = services.DocumentSnippet(document.ExtractedText, terms)
+		}
+		documents = append(documents, document)
+	}
+
+	utils.SuccessResponse(c, documents, "Dokümanlar başarıyla getirildi")
+}
+
+// GetDocument doküman detayı
+// @Summary Doküman detayı
+// @Description Doküman bilgilerini ve bitiş durumunu getirir
+// @ID getDocument
+// @Tags Documents
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Doküman ID"
+// @Success 200 {object} models.APIResponse{data=models.Document}
+// @Failure 401 {object} models.APIResponse
+// @Failure 404 {object} models.APIResponse
+// @Router /documents/{id} [get]
+func (h *DocumentHandler) GetDocument(c *gin.Context) {
+	userID, err := utils.GetUserID(c)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusUnauthorized, "UNAUTHORIZED", "Kullanıcı kimliği doğrulanamadı", nil)
+		return
+	}
+
+	document, err := h.getDocument(c.Param("id"), userID)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusNotFound, "DOCUMENT_NOT_FOUND", "Doküman bulunamadı", nil)
+		return
+	}
+
+	var entityType string
+	if document.EntityType != nil {
+		entityType = *document.EntityType
+	}
+	links := services.EntityLinks("document", document.ID, services.RelatedLink{Rel: "entity", EntityType: entityType, ID: document.EntityID})
+	utils.DetailResponse(c, document, links, "Doküman başarıyla getirildi")
+}
+
+// CreateDocument doküman oluşturma
+// @Summary Doküman oluşturma
+// @Description Başlık, kategori, geçerlilik tarihleri ve isteğe bağlı arazi/hayvan/varlık bağlantısıyla doküman kaydı oluşturur; dosya ayrıca yüklenir
+// @ID createDocument
+// @Tags Documents
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param request body models.DocumentRequest true "Doküman bilgileri"
+// @Success 201 {object} models.APIResponse{data=models.Document}
+// @Failure 400 {object} models.APIResponse
+// @Failure 401 {object} models.APIResponse
+// @Failure 404 {object} models.APIResponse
+// @Router /documents [post]
+func (h *DocumentHandler) CreateDocument(c *gin.Context) {
+	userID, err := utils.GetUserID(c)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusUnauthorized, "UNAUTHORIZED", "Kullanıcı kimliği doğrulanamadı", nil)
+		return
+	}
+
+	var req models.DocumentRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		utils.ErrorResponse(c, http.StatusBadRequest, "INVALID_REQUEST", "Geçersiz istek formatı", err.Error())
+		return
+	}
+
+	if !h.validateDocument(c, &req, userID) {
+		return
+	}
+
+	documentID := utils.GenerateID()
+	_, err = h.db.Exec(`
+		INSERT INTO documents (id, user_id, title, category, description, entity_type, entity_id, issue_date, expiry_date,
+		                       reminder_days, created_at, updated_at)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, CURRENT_TIMESTAMP, CURRENT_TIMESTAMP)
+	`, documentID, userID, req.Title, req.Category, req.Description, req.EntityType, req.EntityID, req.IssueDate, req.ExpiryDate,
+		*req.ReminderDays)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusInternalServerError, "DB_ERROR", "Doküman oluşturulamadı", err.Error())
+		return
+	}
+
+	document, err := h.getDocument(documentID, userID)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusInternalServerError, "FETCH_ERROR", "Oluşturulan doküman getirilemedi", err.Error())
+		return
+	}
+
+	utils.CreatedResponse(c, document, "Doküman başarıyla oluşturuldu")
+}
+
+// UpdateDocument doküman güncelleme
+// @Summary Doküman güncelleme
+// @Description Doküman bilgilerini günceller; bitiş tarihi veya hatırlatma süresi değişirse hatırlatma yeniden planlanır
+// @ID updateDocument
+// @Tags Documents
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Doküman ID"
+// @Param request body models.DocumentRequest true "Doküman bilgileri"
+// @Success 200 {object} models.APIResponse{data=models.Document}
+// @Failure 400 {object} models.APIResponse
+// @Failure 401 {object} models.APIResponse
+// @Failure 404 {object} models.APIResponse
+// @Router /documents/{id} [put]
+func (h *DocumentHandler) UpdateDocument(c *gin.Context) {
+	userID, err := utils.GetUserID(c)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusUnauthorized, "UNAUTHORIZED", "Kullanıcı kimliği doğrulanamadı", nil)
+		return
+	}
+
+	existing, err := h.getDocument(c.Param("id"), userID)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusNotFound, "DOCUMENT_NOT_FOUND", "Doküman bulunamadı", nil)
+		return
+	}
+
+	var req models.DocumentRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		utils.ErrorResponse(c, http.StatusBadRequest, "INVALID_REQUEST", "Geçersiz istek formatı", err.Error())
+		return
+	}
+
+	if !h.validateDocument(c, &req, userID) {
+		return
+	}
+
+	// Bitiş tarihi veya hatırlatma süresi değiştiyse hatırlatma yeniden gönderilebilir
+	resetReminder := *req.ReminderDays != existing.ReminderDays || !sameDay(req.ExpiryDate, existing.ExpiryDate)
+
+	_, err = h.db.Exec(`
+		UPDATE documents SET title = ?, category = ?, description = ?, entity_type = ?, entity_id = ?, issue_date = ?,
+		                     expiry_date = ?, reminder_days = ?,
+		                     reminder_sent_at = CASE WHEN ? THEN NULL ELSE reminder_sent_at END,
+		                     updated_at = CURRENT_TIMESTAMP
+		WHERE id = ? AND user_id = ?
+	`, req.Title, req.Category, req.Description, req.EntityType, req.EntityID, req.IssueDate, req.ExpiryDate, *req.ReminderDays,
+		resetReminder, existing.ID, userID)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusInternalServerError, "UPDATE_ERROR", "Doküman güncellenemedi", err.Error())
+		return
+	}
+
+	document, err := h.getDocument(existing.ID, userID)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusInternalServerError, "FETCH_ERROR", "Güncellenen doküman getirilemedi", err.Error())
+		return
+	}
+
+	utils.SuccessResponse(c, document, "Doküman başarıyla güncellendi")
+}
+
+// DeleteDocument doküman silme
+// @Summary Doküman silme
+// @Description Dokümanı ve yüklenen dosyasını siler
+// @ID deleteDocument
+// @Tags Documents
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Doküman ID"
+// @Param force query bool false "Başka kayıtlarla bağlantısı olsa da sil"
+// @Success 200 {object} models.APIResponse
+// @Failure 401 {object} models.APIResponse
+// @Failure 404 {object} models.APIResponse
+// @Failure 409 {object} models.APIResponse
+// @Router /documents/{id} [delete]
+func (h *DocumentHandler) DeleteDocument(c *gin.Context) {
+	userID, err := utils.GetUserID(c)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusUnauthorized, "UNAUTHORIZED", "Kullanıcı kimliği doğrulanamadı", nil)
+		return
+	}
+
+	var storageKey sql.NullString
+	err = h.db.QueryRow("SELECT storage_key FROM documents WHERE id = ? AND user_id = ?", c.Param("id"), userID).Scan(&storageKey)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusNotFound, "DOCUMENT_NOT_FOUND", "Doküman bulunamadı", nil)
+		return
+	}
+
+	if !confirmLinkedDelete(c, h.db, userID, "document", c.Param("id")) {
+		return
+	}
+
+	if _, err := h.db.Exec("DELETE FROM documents WHERE id = ? AND user_id = ?", c.Param("id"), userID); err != nil {
+		utils.ErrorResponse(c, http.StatusInternalServerError, "DELETE_ERROR", "Doküman silinemedi", err.Error())
+		return
+	}
+
+	if storageKey.Valid {
+		h.store.Delete(storageKey.String)
+	}
+
+	removeRecordLinks(h.db, userID, "document", c.Param("id"))
+
+	utils.SuccessResponse(c, nil, "Doküman başarıyla silindi")
+}
+
+// UploadDocumentFile doküman dosyası yükleme
+// @Summary Doküman dosyası yükleme
+// @Description Dokümanın dosyasını yükler veya değiştirir; PDF, Word (docx) ve metin dosyalarının içeriği aranabilir metin olarak çıkarılır. Taranmış belgeler için metin alanıyla OCR çıktısı gönderilebilir
+// @ID uploadDocumentFile
+// @Tags Documents
+// @Accept multipart/form-data
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Doküman ID"
+// @Param file formData file true "Doküman dosyası"
+// @Param text formData string false "Aranabilir metin (dosyadan çıkarılan metnin yerine kullanılır)"
+// @Success 200 {object} models.APIResponse{data=models.Document}
+// @Failure 400 {object} models.APIResponse
+// @Failure 401 {object} models.APIResponse
+// @Failure 404 {object} models.APIResponse
+// @Router /documents/{id}/file [put]
+func (h *DocumentHandler) UploadDocumentFile(c *gin.Context) {
+	userID, err := utils.GetUserID(c)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusUnauthorized, "UNAUTHORIZED", "Kullanıcı kimliği doğrulanamadı", nil)
+		return
+	}
+
+	var previousKey sql.NullString
+	err = h.db.QueryRow("SELECT storage_key FROM documents WHERE id = ? AND user_id = ?", c.Param("id"), userID).Scan(&previousKey)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusNotFound, "DOCUMENT_NOT_FOUND", "Doküman bulunamadı", nil)
+		return
+	}
+
+	fileHeader, err := c.FormFile("file")
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusBadRequest, "MISSING_FILE", "Doküman dosyası gerekli", nil)
+		return
+	}
+	if fileHeader.Size > maxDocumentSize {
+		utils.ErrorResponse(c, http.StatusBadRequest, "FILE_TOO_LARGE", "Doküman dosyası çok büyük", nil)
+		return
+	}
+
+	file, err := fileHeader.Open()
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusBadRequest, "INVALID_FILE", "Doküman dosyası okunamadı", err.Error())
+		return
+	}
+	defer file.Close()
+
+	data, err := io.ReadAll(file)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusBadRequest, "INVALID_FILE", "Doküman dosyası okunamadı", err.Error())
+		return
+	}
+
+	contentType := fileHeader.Header.Get("Content-Type")
+	if contentType == "" {
+		contentType = http.DetectContentType(data)
+	}
+
+	text := strings.TrimSpace(c.PostForm("text"))
+	if text == "" {
+		text = services.ExtractDocumentText(contentType, fileHeader.Filename, data)
+	}
+
+	storageKey := filepath.Join(userID, "documents", utils.GenerateID()+strings.ToLower(filepath.Ext(fileHeader.Filename)))
+	size, err := h.store.Save(storageKey, bytes.NewReader(data))
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusInternalServerError, "STORAGE_ERROR", "Doküman dosyası kaydedilemedi", err.Error())
+		return
+	}
+
+	_, err = h.db.Exec(`
+		UPDATE documents SET filename = ?, content_type = ?, size = ?, storage_key = ?, extracted_text = ?,
+		                     updated_at = CURRENT_TIMESTAMP
+		WHERE id = ? AND user_id = ?
+	`, filepath.Base(fileHeader.Filename), contentType, size, storageKey, text, c.Param("id"), userID)
+	if err != nil {
+		h.store.Delete(storageKey)
+		utils.ErrorResponse(c, http.StatusInternalServerError, "DB_ERROR", "Doküman dosyası kaydedilemedi", err.Error())
+		return
+	}
+
+	if previousKey.Valid {
+		h.store.Delete(previousKey.String)
+	}
+
+	document, err := h.getDocument(c.Param("id"), userID)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusInternalServerError, "FETCH_ERROR", "Doküman getirilemedi", err.Error())
+		return
+	}
+
+	utils.SuccessResponse(c, document, "Doküman dosyası başarıyla yüklendi")
+}
+
+// GetDocumentFile doküman dosyası indirme
+// @Summary Doküman dosyası
+// @Description Dokümanın yüklenen dosyasını indirir
+// @ID getDocumentFile
+// @Tags Documents
+// @Produce application/octet-stream
+// @Security BearerAuth
+// @Param id path string true "Doküman ID"
+// @Success 200 {file} file
+// @Failure 401 {object} models.APIResponse
+// @Failure 404 {object} models.APIResponse
+// @Router /documents/{id}/file [get]
+func (h *DocumentHandler) GetDocumentFile(c *gin.Context) {
+	userID, err := utils.GetUserID(c)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusUnauthorized, "UNAUTHORIZED", "Kullanıcı kimliği doğrulanamadı", nil)
+		return
+	}
+
+	var storageKey, filename, contentType string
+	var size int64
+	err = h.db.QueryRow(`
+		SELECT storage_key, filename, COALESCE(content_type, 'application/octet-stream'), size
+		FROM documents WHERE id = ? AND user_id = ? AND storage_key IS NOT NULL
+	`, c.Param("id"), userID).Scan(&storageKey, &filename, &contentType, &size)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusNotFound, "DOCUMENT_NOT_FOUND", "Doküman dosyası bulunamadı", nil)
+		return
+	}
+
+	file, err := h.store.Open(storageKey)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusNotFound, "DOCUMENT_NOT_FOUND", "Doküman dosyası bulunamadı", nil)
+		return
+	}
+	defer file.Close()
+
+	c.DataFromReader(http.StatusOK, size, contentType, file, map[string]string{
+		"Content-Disposition": "attachment; filename=" + filename,
+	})
+}
+
+// StartReminders süresi yaklaşan dokümanlar için saatlik olarak hatırlatma gönderir
+func (h *DocumentHandler) StartReminders() {
+	go func() {
+		ticker := time.NewTicker(time.Hour)
+		defer ticker.Stop()
+
+		for {
+			if err := h.SendDueReminders(); err != nil {
+				log.Printf("Doküman hatırlatmaları gönderilemedi: %v", err)
+			}
+			<-ticker.C
+		}
+	}()
+}
+
+// SendDueReminders hatırlatma süresine girmiş dokümanlar için henüz gönderilmemiş hatırlatmaları gönderir
+func (h *DocumentHandler) SendDueReminders() error {
+	rows, err := h.db.Query(documentSelect + " WHERE d.expiry_date IS NOT NULL AND d.reminder_sent_at IS NULL")
+	if err != nil {
+		return err
+	}
+
+	var due []models.Document
+	for rows.Next() {
+		document, err := scanDocument(rows)
+		if err != nil {
+			continue
+		}
+		if document.DaysToExpiry != nil && *document.DaysToExpiry <= document.ReminderDays {
+			due = append(due, document)
+		}
+	}
+	rows.Close()
+
+	for _, document := range due {
+		title := "Doküman Süresi Doluyor"
+		message := fmt.Sprintf("%s dokümanının geçerlilik süresi %d gün içinde (%s) doluyor.",
+			document.Title, *document.DaysToExpiry, document.ExpiryDate.Format("02.01.2006"))
+		priority := "medium"
+		if document.Expired {
+			title = "Doküman Süresi Doldu"
+			message = fmt.Sprintf("%s dokümanının geçerlilik süresi %s tarihinde doldu.",
+				document.Title, document.ExpiryDate.Format("02.01.2006"))
+			priority = "high"
+		}
+
+		_, err := h.notifications.Create(services.Notification{
+			UserID:   document.UserID,
+			Title:    title,
+			Message:  message,
+			Type:     "reminder",
+			Priority: priority,
+			Topic:    models.NotificationTopicDocumentExpiry,
+			Entity:   &models.RelatedEntity{Type: "document", ID: document.ID, Name: document.Title},
+		})
+		if err != nil {
+			return err
+		}
+
+		if _, err := h.db.Exec("UPDATE documents SET reminder_sent_at = CURRENT_TIMESTAMP WHERE id = ?", document.ID); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// documentSelect doküman sütunları; bağlı kaydın adı türüne göre alınır
+const documentSelect = `
+	SELECT d.id, d.user_id, d.title, d.category, COALESCE(d.description, ''), d.entity_type, d.entity_id,
+	       CASE d.entity_type
+	           WHEN 'land' THEN (SELECT name FROM lands WHERE id = d.entity_id)
+	           WHEN 'livestock' THEN (SELECT tag_number FROM livestock WHERE id = d.entity_id)
+	           WHEN 'asset' THEN (SELECT name FROM fixed_assets WHERE id = d.entity_id)
+	       END,
+	       d.issue_date, d.expiry_date, COALESCE(d.reminder_days, 30), d.filename, d.content_type, COALESCE(d.size, 0),
+	       COALESCE(d.extracted_text, ''), d.created_at, d.updated_at
+	FROM documents d
+`
+
+// scanDocument doküman satırını okur ve bitiş durumunu hesaplar
+func scanDocument(row interface{ Scan(...interface{}) error }) (models.Document, error) {
+	var document models.Document
+	var entityType, entityID, entityName, filename, contentType sql.NullString
+	var issueDate, expiryDate sql.NullTime
+
+	err := row.Scan(
+		&document.ID, &document.UserID, &document.Title, &document.Category, &document.Description,
+		&entityType, &entityID, &entityName, &issueDate, &expiryDate, &document.ReminderDays,
+		&filename, &contentType, &document.Size, &document.ExtractedText, &document.CreatedAt, &document.UpdatedAt,
+	)
+	if err != nil {
+		return document, err
+	}
+
+	document.EntityType = utils.NullStringToPtr(entityType)
+	document.EntityID = utils.NullStringToPtr(entityID)
+	document.EntityName = utils.NullStringToPtr(entityName)
+	document.IssueDate = utils.NullTimeToPtr(issueDate)
+	document.ExpiryDate = utils.NullTimeToPtr(expiryDate)
+	document.Filename = utils.NullStringToPtr(filename)
+	document.ContentType = utils.NullStringToPtr(contentType)
+	document.HasText = document.ExtractedText != ""
+
+	if document.ExpiryDate != nil {
+		expiry := document.ExpiryDate
+		days := int(time.Date(expiry.Year(), expiry.Month(), expiry.Day(), 0, 0, 0, 0, time.UTC).Sub(today()).Hours() / 24)
+		document.DaysToExpiry = &days
+		document.Expired = days < 0
+	}
+
+	return document, nil
+}
+
+// getDocument kullanıcının dokümanını getirir
+func (h *DocumentHandler) getDocument(documentID, userID string) (models.Document, error) {
+	return scanDocument(h.db.QueryRow(documentSelect+" WHERE d.id = ? AND d.user_id = ?", documentID, userID))
+}
+
+// validateDocument bağlı kaydı ve tarihleri doğrular, varsayılan hatırlatma süresini atar; hata varsa yanıtı yazar
+func (h *DocumentHandler) validateDocument(c *gin.Context, req *models.DocumentRequest, userID string) bool {
+	if req.ReminderDays == nil {
+		days := defaultDocumentReminderDays
+		req.ReminderDays = &days
+	}
+
+	if req.IssueDate != nil && req.ExpiryDate != nil && req.ExpiryDate.Before(*req.IssueDate) {
+		utils.ErrorResponse(c, http.StatusBadRequest, "INVALID_DATES", "Bitiş tarihi düzenlenme tarihinden önce olamaz", nil)
+		return false
+	}
+
+	if req.EntityType == nil || *req.EntityType == "" {
+		req.EntityType, req.EntityID = nil, nil
+		return true
+	}
+
+	if req.EntityID == nil || utils.IsEmptyString(*req.EntityID) {
+		utils.ErrorResponse(c, http.StatusBadRequest, "MISSING_FIELDS", "Bağlı kayıt ID gerekli", nil)
+		return false
+	}
+
+	var name string
+	if err := h.db.QueryRow(documentEntityNames[*req.EntityType], *req.EntityID, userID).Scan(&name); err != nil {
+		utils.ErrorResponse(c, http.StatusNotFound, "ENTITY_NOT_FOUND", "Bağlı kayıt bulunamadı", nil)
+		return false
+	}
+
+	return true
+}
+
+// today bugünün UTC gece yarısını döner
+func today() time.Time {
+	now := time.Now()
+	return time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC)
+}
+
+// sameDay iki isteğe bağlı tarihin aynı gün olup olmadığını döner
+func sameDay(a, b *time.Time) bool {
+	if a == nil || b == nil {
+		return a == nil && b == nil
+	}
+	return a.Format("2006-01-02") == b.Format("2006-01-02")
+}
@@ -0,0 +1,306 @@
+package handlers
+
+import (
+	"database/sql"
+	"errors"
+	"net/http"
+	"strings"
+	"time"
+
+	"agri-management-api/internal/models"
+	"agri-management-api/internal/services"
+	"agri-management-api/internal/utils"
+
+	"github.com/gin-gonic/gin"
+)
+
+// EnterpriseHandler çiftliğin faaliyet kollarını ve kol bazında kâr/zararı yönetir
+type EnterpriseHandler struct {
+	db          *sql.DB
+	enterprises *services.EnterpriseService
+}
+
+// NewEnterpriseHandler yeni enterprise handler oluşturur
+func NewEnterpriseHandler(db *sql.DB) *EnterpriseHandler {
+	return &EnterpriseHandler{
+		db:          db,
+		enterprises: services.NewEnterpriseService(db),
+	}
+}
+
+// GetEnterprises faaliyet kolları
+// @Summary Faaliyet kolları
+// @Description Çiftliğin faaliyet kollarını (süt, besi, bitkisel üretim, kanatlı) atanmış arazi, sürüdeki hayvan ve üretim kaydı sayılarıyla listeler
+// @ID getEnterprises
+// @Tags Enterprises
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {object} models.APIResponse{data=[]models.Enterprise}
+// @Failure 401 {object} models.APIResponse
+// @Router /enterprises [get]
+func (h *EnterpriseHandler) GetEnterprises(c *gin.Context) {
+	userID, err := utils.GetUserID(c)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusUnauthorized, "UNAUTHORIZED", "Kullanıcı kimliği doğrulanamadı", nil)
+		return
+	}
+
+	enterprises, err := h.enterprises.List(userID)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusInternalServerError, "DB_ERROR", "Faaliyet kolları alınamadı", err.Error())
+		return
+	}
+
+	utils.SuccessResponse(c, enterprises, "Faaliyet kolları başarıyla getirildi")
+}
+
+// GetEnterprise faaliyet kolu detayı
+// @Summary Faaliyet kolu detayı
+// @Description Faaliyet kolunu atanmış kayıt sayılarıyla getirir
+// @ID getEnterprise
+// @Tags Enterprises
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Faaliyet kolu ID"
+// @Success 200 {object} models.APIResponse{data=models.Enterprise}
+// @Failure 401 {object} models.APIResponse
+// @Failure 404 {object} models.APIResponse
+// @Router /enterprises/{id} [get]
+func (h *EnterpriseHandler) GetEnterprise(c *gin.Context) {
+	userID, err := utils.GetUserID(c)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusUnauthorized, "UNAUTHORIZED", "Kullanıcı kimliği doğrulanamadı", nil)
+		return
+	}
+
+	enterprise, err := h.enterprises.Get(userID, c.Param("id"))
+	if err != nil {
+		writeEnterpriseError(c, err, "Faaliyet kolu alınamadı")
+		return
+	}
+
+	utils.SuccessResponse(c, enterprise, "Faaliyet kolu başarıyla getirildi")
+}
+
+// CreateEnterprise faaliyet kolu ekleme
+// @Summary Faaliyet kolu ekle
+// @Description Faaliyet kolu ekler. Hayvanlar, araziler, üretim kayıtları ve finansal işlemler enterpriseId alanıyla kola atanır
+// @ID createEnterprise
+// @Tags Enterprises
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param request body models.EnterpriseRequest true "Faaliyet kolu bilgileri"
+// @Success 201 {object} models.APIResponse{data=models.Enterprise}
+// @Failure 400 {object} models.APIResponse
+// @Failure 401 {object} models.APIResponse
+// @Failure 409 {object} models.APIResponse
+// @Router /enterprises [post]
+func (h *EnterpriseHandler) CreateEnterprise(c *gin.Context) {
+	userID, err := utils.GetUserID(c)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusUnauthorized, "UNAUTHORIZED", "Kullanıcı kimliği doğrulanamadı", nil)
+		return
+	}
+
+	var req models.EnterpriseRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		utils.ErrorResponse(c, http.StatusBadRequest, "INVALID_REQUEST", "Geçersiz istek formatı", err.Error())
+		return
+	}
+
+	enterprise, err := h.enterprises.Create(userID, req)
+	if err != nil {
+		writeEnterpriseError(c, err, "Faaliyet kolu eklenemedi")
+		return
+	}
+
+	utils.CreatedResponse(c, enterprise, "Faaliyet kolu başarıyla eklendi")
+}
+
+// UpdateEnterprise faaliyet kolu güncelleme
+// @Summary Faaliyet kolunu güncelle
+// @Description Faaliyet kolunun adını, türünü ve açıklamasını günceller
+// @ID updateEnterprise
+// @Tags Enterprises
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Faaliyet kolu ID"
+// @Param request body models.EnterpriseRequest true "Faaliyet kolu bilgileri"
+// @Success 200 {object} models.APIResponse{data=models.Enterprise}
+// @Failure 400 {object} models.APIResponse
+// @Failure 401 {object} models.APIResponse
+// @Failure 404 {object} models.APIResponse
+// @Failure 409 {object} models.APIResponse
+// @Router /enterprises/{id} [put]
+func (h *EnterpriseHandler) UpdateEnterprise(c *gin.Context) {
+	userID, err := utils.GetUserID(c)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusUnauthorized, "UNAUTHORIZED", "Kullanıcı kimliği doğrulanamadı", nil)
+		return
+	}
+
+	var req models.EnterpriseRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		utils.ErrorResponse(c, http.StatusBadRequest, "INVALID_REQUEST", "Geçersiz istek formatı", err.Error())
+		return
+	}
+
+	enterprise, err := h.enterprises.Update(userID, c.Param("id"), req)
+	if err != nil {
+		writeEnterpriseError(c, err, "Faaliyet kolu güncellenemedi")
+		return
+	}
+
+	utils.SuccessResponse(c, enterprise, "Faaliyet kolu başarıyla güncellendi")
+}
+
+// DeleteEnterprise faaliyet kolu silme
+// @Summary Faaliyet kolunu sil
+// @Description Faaliyet kolunu siler; kola atanmış hayvan, arazi, üretim ve işlemler silinmez, yalnızca atamaları kaldırılır
+// @ID deleteEnterprise
+// @Tags Enterprises
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Faaliyet kolu ID"
+// @Success 200 {object} models.APIResponse
+// @Failure 401 {object} models.APIResponse
+// @Failure 404 {object} models.APIResponse
+// @Router /enterprises/{id} [delete]
+func (h *EnterpriseHandler) DeleteEnterprise(c *gin.Context) {
+	userID, err := utils.GetUserID(c)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusUnauthorized, "UNAUTHORIZED", "Kullanıcı kimliği doğrulanamadı", nil)
+		return
+	}
+
+	if err := h.enterprises.Delete(userID, c.Param("id")); err != nil {
+		writeEnterpriseError(c, err, "Faaliyet kolu silinemedi")
+		return
+	}
+
+	utils.SuccessResponse(c, nil, "Faaliyet kolu başarıyla silindi")
+}
+
+// GetEnterprisePnL faaliyet kollarının kâr/zararı
+// @Summary Faaliyet kolu kâr/zarar karşılaştırması
+// @Description Dönemdeki gelir ve giderleri faaliyet kollarına göre toplar ve kolları kâra göre sıralar. İşlemin kolu, işleme atanmış kol yoksa bağlı üretim satışının ürününden veya arazisinden, hayvan alış/satış, kesim ve hayvan maliyeti kaydının hayvanından, hasat ekibi ödemesinin arazisinden türetilir. Ortak gider dağıtımlarında kolun arazi ve hayvanlarına düşen paylar allocatedCost olarak eklenir. Hiçbir kola bağlanamayan kayıtlar unassigned satırında gösterilir. Tarih verilmezse yıl başından bugüne kadar hesaplanır
+// @ID getEnterprisePnL
+// @Tags Enterprises
+// @Produce json
+// @Security BearerAuth
+// @Param startDate query string false "Başlangıç tarihi (YYYY-MM-DD)"
+// @Param endDate query string false "Bitiş tarihi (YYYY-MM-DD)"
+// @Success 200 {object} models.APIResponse{data=models.EnterprisePnLReport}
+// @Failure 400 {object} models.APIResponse
+// @Failure 401 {object} models.APIResponse
+// @Router /enterprises/pnl [get]
+func (h *EnterpriseHandler) GetEnterprisePnL(c *gin.Context) {
+	userID, err := utils.GetUserID(c)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusUnauthorized, "UNAUTHORIZED", "Kullanıcı kimliği doğrulanamadı", nil)
+		return
+	}
+
+	start, end, ok := enterprisePeriod(c)
+	if !ok {
+		return
+	}
+
+	report, err := h.enterprises.PnL(userID, start, end)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusInternalServerError, "DB_ERROR", "Faaliyet kolu kâr/zararı hesaplanamadı", err.Error())
+		return
+	}
+
+	utils.SuccessResponse(c, report, "Faaliyet kolu kâr/zararı başarıyla hesaplandı")
+}
+
+// GetEnterpriseDetailPnL faaliyet kolunun kâr/zararı
+// @Summary Faaliyet kolu kâr/zararı
+// @Description Faaliyet kolunun dönemdeki gelir, gider ve dağıtılan ortak giderlerini kategori dökümüyle getirir. Tarih verilmezse yıl başından bugüne kadar hesaplanır
+// @ID getEnterpriseDetailPnL
+// @Tags Enterprises
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Faaliyet kolu ID"
+// @Param startDate query string false "Başlangıç tarihi (YYYY-MM-DD)"
+// @Param endDate query string false "Bitiş tarihi (YYYY-MM-DD)"
+// @Success 200 {object} models.APIResponse{data=models.EnterprisePnL}
+// @Failure 400 {object} models.APIResponse
+// @Failure 401 {object} models.APIResponse
+// @Failure 404 {object} models.APIResponse
+// @Router /enterprises/{id}/pnl [get]
+func (h *EnterpriseHandler) GetEnterpriseDetailPnL(c *gin.Context) {
+	userID, err := utils.GetUserID(c)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusUnauthorized, "UNAUTHORIZED", "Kullanıcı kimliği doğrulanamadı", nil)
+		return
+	}
+
+	start, end, ok := enterprisePeriod(c)
+	if !ok {
+		return
+	}
+
+	pnl, err := h.enterprises.EnterprisePnL(userID, c.Param("id"), start, end)
+	if err != nil {
+		writeEnterpriseError(c, err, "Faaliyet kolu kâr/zararı hesaplanamadı")
+		return
+	}
+
+	utils.SuccessResponse(c, pnl, "Faaliyet kolu kâr/zararı başarıyla hesaplandı")
+}
+
+// enterprisePeriod kâr/zarar dönemini okur; başlangıç verilmezse yıl başı, bitiş verilmezse bugün kullanılır
+func enterprisePeriod(c *gin.Context) (time.Time, time.Time, bool) {
+	startDate, endDate, ok := dateRangeQuery(c)
+	if !ok {
+		return time.Time{}, time.Time{}, false
+	}
+	end := time.Now().UTC()
+	if endDate != nil {
+		end = *endDate
+	}
+	start := time.Date(end.Year(), time.January, 1, 0, 0, 0, 0, time.UTC)
+	if startDate != nil {
+		start = *startDate
+	}
+	if end.Before(start) {
+		utils.ErrorResponse(c, http.StatusBadRequest, "INVALID_DATE_RANGE", "Bitiş tarihi başlangıç tarihinden önce olamaz", nil)
+		return time.Time{}, time.Time{}, false
+	}
+	return start, end, true
+}
+
+// validateEnterprise istekteki faaliyet kolunun çiftliğe ait olduğunu denetler; boş değeri atamasız (nil) yapar.
+// Geçersizse 400 yanıtı yazar
+func validateEnterprise(c *gin.Context, enterprises *services.EnterpriseService, farmID string, enterpriseID **string) bool {
+	if *enterpriseID == nil || strings.TrimSpace(**enterpriseID) == "" {
+		*enterpriseID = nil
+		return true
+	}
+	exists, err := enterprises.Exists(farmID, **enterpriseID)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusInternalServerError, "DB_ERROR", "Faaliyet kolu kontrol edilemedi", err.Error())
+		return false
+	}
+	if !exists {
+		utils.ErrorResponse(c, http.StatusBadRequest, "INVALID_ENTERPRISE", "Faaliyet kolu bulunamadı", nil)
+		return false
+	}
+	return true
+}
+
+// writeEnterpriseError servis hatasını HTTP yanıtına çevirir
+func writeEnterpriseError(c *gin.Context, err error, message string) {
+	switch {
+	case errors.Is(err, services.ErrEnterpriseNotFound):
+		utils.ErrorResponse(c, http.StatusNotFound, "ENTERPRISE_NOT_FOUND", "Faaliyet kolu bulunamadı", nil)
+	case errors.Is(err, services.ErrEnterpriseExists):
+		utils.ErrorResponse(c, http.StatusConflict, "ENTERPRISE_EXISTS", "Bu adla faaliyet kolu zaten tanımlı", nil)
+	default:
+		utils.ErrorResponse(c, http.StatusInternalServerError, "DB_ERROR", message, err.Error())
+	}
+}
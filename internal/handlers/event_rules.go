@@ -0,0 +1,111 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+
+	"agri-management-api/internal/models"
+	"agri-management-api/internal/services"
+	"agri-management-api/internal/utils"
+
+	"github.com/gin-gonic/gin"
+)
+
+// GetEventRules otomatik etkinlik kuralları
+// @Summary Otomatik etkinlik kuralları
+// @Description Kayıtlardaki tarihlerden takvim etkinliği oluşturan kuralları (expected_birth, health_checkup, harvest_window, installment_due) çiftlikteki açık/kapalı durumu ve bekleyen yaklaşan etkinlik sayısıyla listeler
+// @ID getEventRules
+// @Tags Calendar
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {object} models.APIResponse{data=[]models.EventRule}
+// @Failure 401 {object} models.APIResponse
+// @Router /calendar/rules [get]
+func (h *CalendarHandler) GetEventRules(c *gin.Context) {
+	userID, err := utils.GetUserID(c)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusUnauthorized, "UNAUTHORIZED", "Kullanıcı kimliği doğrulanamadı", nil)
+		return
+	}
+
+	rules, err := h.eventRules.Rules(userID)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusInternalServerError, "DB_ERROR", "Etkinlik kuralları alınamadı", err.Error())
+		return
+	}
+
+	utils.SuccessResponse(c, rules, "Etkinlik kuralları başarıyla getirildi")
+}
+
+// UpdateEventRule otomatik etkinlik kuralını açma/kapatma
+// @Summary Etkinlik kuralını aç/kapat
+// @Description Kuralı çiftlik ayarlarında (settings.eventRules) açar veya kapatır ve hemen çalıştırır. Kapatılan kuralın bekleyen yaklaşan etkinlikleri kaldırılır; geçmiş, tamamlanmış veya elle düzenlenmiş etkinlikler korunur
+// @ID updateEventRule
+// @Tags Calendar
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param rule path string true "Kural anahtarı"
+// @Param request body models.EventRuleUpdateRequest true "Kural durumu"
+// @Success 200 {object} models.APIResponse{data=models.EventRuleSyncResult}
+// @Failure 400 {object} models.APIResponse
+// @Failure 401 {object} models.APIResponse
+// @Failure 404 {object} models.APIResponse
+// @Router /calendar/rules/{rule} [put]
+func (h *CalendarHandler) UpdateEventRule(c *gin.Context) {
+	userID, err := utils.GetUserID(c)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusUnauthorized, "UNAUTHORIZED", "Kullanıcı kimliği doğrulanamadı", nil)
+		return
+	}
+
+	var req models.EventRuleUpdateRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		utils.ErrorResponse(c, http.StatusBadRequest, "INVALID_REQUEST", "Geçersiz istek formatı", err.Error())
+		return
+	}
+
+	accountID, _ := utils.GetAccountID(c)
+	if err := h.farms.EnsureDefaultFarm(accountID); err != nil {
+		utils.ErrorResponse(c, http.StatusInternalServerError, "DB_ERROR", "Ayarlar getirilemedi", err.Error())
+		return
+	}
+
+	result, err := h.eventRules.SetEnabled(userID, c.Param("rule"), *req.Enabled)
+	if err != nil {
+		if errors.Is(err, services.ErrEventRuleNotFound) {
+			utils.ErrorResponse(c, http.StatusNotFound, "RULE_NOT_FOUND", "Etkinlik kuralı bulunamadı", nil)
+			return
+		}
+		utils.ErrorResponse(c, http.StatusInternalServerError, "DB_ERROR", "Etkinlik kuralı güncellenemedi", err.Error())
+		return
+	}
+
+	utils.SuccessResponse(c, result, "Etkinlik kuralı başarıyla güncellendi")
+}
+
+// SyncEventRules otomatik etkinlikleri yenileme
+// @Summary Otomatik etkinlikleri yenile
+// @Description Açık kuralları hemen çalıştırır: yeni kayıtlar için etkinlik oluşturulur, tarihi değişen bekleyen etkinlikler güncellenir ve kaynağı kalmayan yaklaşan etkinlikler kaldırılır. Kurallar kayıt değişikliklerinden sonra ve saatlik olarak ayrıca çalışır
+// @ID syncEventRules
+// @Tags Calendar
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {object} models.APIResponse{data=models.EventRuleSyncResult}
+// @Failure 401 {object} models.APIResponse
+// @Router /calendar/rules/sync [post]
+func (h *CalendarHandler) SyncEventRules(c *gin.Context) {
+	userID, err := utils.GetUserID(c)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusUnauthorized, "UNAUTHORIZED", "Kullanıcı kimliği doğrulanamadı", nil)
+		return
+	}
+
+	result, err := h.eventRules.Sync(userID)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusInternalServerError, "DB_ERROR", "Otomatik etkinlikler oluşturulamadı", err.Error())
+		return
+	}
+
+	utils.SuccessResponse(c, result, "Otomatik etkinlikler başarıyla yenilendi")
+}
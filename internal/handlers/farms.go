@@ -0,0 +1,355 @@
+package handlers
+
+import (
+	"database/sql"
+	"net/http"
+
+	"agri-management-api/internal/middleware"
+	"agri-management-api/internal/models"
+	"agri-management-api/internal/services"
+	"agri-management-api/internal/utils"
+
+	"github.com/gin-gonic/gin"
+)
+
+// farmDataTables çiftlik silinmeden önce boş olması gereken kayıt tabloları
+var farmDataTables = []string{"lands", "livestock", "production", "transactions", "transaction_drafts", "customers", "bank_accounts", "fixed_assets", "documents"}
+
+// FarmHandler hesaba bağlı çiftlikleri yönetir
+type FarmHandler struct {
+	db    *sql.DB
+	farms *services.FarmService
+}
+
+// NewFarmHandler yeni farm handler oluşturur
+func NewFarmHandler(db *sql.DB) *FarmHandler {
+	return &FarmHandler{
+		db:    db,
+		farms: services.NewFarmService(db),
+	}
+}
+
+// GetFarms çiftlik seçici
+// @Summary Çiftlikler
+// @Description Hesaba bağlı çiftlikleri özet istatistikleriyle listeler; X-Farm-ID başlığıyla seçili çiftlik isCurrent olarak işaretlenir
+// @ID getFarms
+// @Tags Farms
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param X-Farm-ID header string false "Seçili çiftlik ID"
+// @Success 200 {object} models.APIResponse{data=[]models.Farm}
+// @Failure 401 {object} models.APIResponse
+// @Router /farms [get]
+func (h *FarmHandler) GetFarms(c *gin.Context) {
+	userID, err := utils.GetUserID(c)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusUnauthorized, "UNAUTHORIZED", "Kullanıcı kimliği doğrulanamadı", nil)
+		return
+	}
+
+	if err := h.farms.EnsureDefaultFarm(userID); err != nil {
+		utils.ErrorResponse(c, http.StatusInternalServerError, "DB_ERROR", "Çiftlikler getirilemedi", err.Error())
+		return
+	}
+
+	rows, err := h.db.Query(farmSelect+" WHERE user_id = ? ORDER BY is_default DESC, name", userID)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusInternalServerError, "DB_ERROR", "Çiftlikler getirilemedi", err.Error())
+		return
+	}
+	defer rows.Close()
+
+	farms := []models.Farm{}
+	for rows.Next() {
+		farm, err := scanFarm(rows)
+		if err != nil {
+			utils.ErrorResponse(c, http.StatusInternalServerError, "DB_ERROR", "Çiftlik verisi okunamadı", err.Error())
+			return
+		}
+		farms = append(farms, farm)
+	}
+	rows.Close()
+
+	current := c.GetHeader(middleware.FarmHeader)
+	if current == "" {
+		current = userID
+	}
+	for i := range farms {
+		summary, err := h.farms.Summary(farms[i].ID)
+		if err != nil {
+			utils.ErrorResponse(c, http.StatusInternalServerError, "DB_ERROR", "Çiftlik özeti hesaplanamadı", err.Error())
+			return
+		}
+		farms[i].Summary = &summary
+		farms[i].IsCurrent = farms[i].ID == current
+	}
+
+	utils.SuccessResponse(c, farms, "Çiftlikler başarıyla getirildi")
+}
+
+// GetFarm çiftlik detayı
+// @Summary Çiftlik detayı
+// @Description Çiftliğin bilgilerini, ayarlarını ve özet istatistiklerini getirir
+// @ID getFarm
+// @Tags Farms
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Çiftlik ID"
+// @Success 200 {object} models.APIResponse{data=models.Farm}
+// @Failure 401 {object} models.APIResponse
+// @Failure 404 {object} models.APIResponse
+// @Router /farms/{id} [get]
+func (h *FarmHandler) GetFarm(c *gin.Context) {
+	userID, err := utils.GetUserID(c)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusUnauthorized, "UNAUTHORIZED", "Kullanıcı kimliği doğrulanamadı", nil)
+		return
+	}
+
+	farm, ok := h.getFarmDetail(c, userID, c.Param("id"))
+	if !ok {
+		return
+	}
+
+	utils.SuccessResponse(c, farm, "Çiftlik başarıyla getirildi")
+}
+
+// CreateFarm yeni çiftlik
+// @Summary Yeni çiftlik
+// @Description Hesaba yeni bir çiftlik ekler; çiftliğin kayıtlarına X-Farm-ID başlığıyla erişilir. Ayarlar girilmezse varsayılanlar kullanılır
+// @ID createFarm
+// @Tags Farms
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param request body models.Farm true "Çiftlik bilgileri"
+// @Success 201 {object} models.APIResponse{data=models.Farm}
+// @Failure 400 {object} models.APIResponse
+// @Failure 401 {object} models.APIResponse
+// @Router /farms [post]
+func (h *FarmHandler) CreateFarm(c *gin.Context) {
+	userID, err := utils.GetUserID(c)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusUnauthorized, "UNAUTHORIZED", "Kullanıcı kimliği doğrulanamadı", nil)
+		return
+	}
+
+	var req models.Farm
+	if err := c.ShouldBindJSON(&req); err != nil {
+		utils.ErrorResponse(c, http.StatusBadRequest, "INVALID_REQUEST", "Geçersiz istek formatı", err.Error())
+		return
+	}
+
+	// Varsayılan çiftlik, hesabın mevcut kayıtlarını karşılamak için ilk çiftlikten önce oluşturulur
+	if err := h.farms.EnsureDefaultFarm(userID); err != nil {
+		utils.ErrorResponse(c, http.StatusInternalServerError, "CREATE_ERROR", "Çiftlik oluşturulamadı", err.Error())
+		return
+	}
+
+	settings := services.DefaultSettings()
+	if req.Settings != nil {
+		settings = *req.Settings
+		if err := services.NormalizeFiscalSettings(&settings.Fiscal); err != nil {
+			utils.ErrorResponse(c, http.StatusBadRequest, "INVALID_FISCAL_SETTINGS", err.Error(), nil)
+			return
+		}
+	}
+	settingsJSON, _ := utils.ToJSON(settings)
+
+	farmID := utils.GenerateID()
+	_, err = h.db.Exec(`
+		INSERT INTO farms (id, user_id, name, location, description, is_default, settings, created_at, updated_at)
+		VALUES (?, ?, ?, ?, ?, FALSE, ?, CURRENT_TIMESTAMP, CURRENT_TIMESTAMP)
+	`, farmID, userID, req.Name, req.Location, req.Description, settingsJSON)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusInternalServerError, "CREATE_ERROR", "Çiftlik oluşturulamadı", err.Error())
+		return
+	}
+
+	farm, ok := h.getFarmDetail(c, userID, farmID)
+	if !ok {
+		return
+	}
+
+	utils.CreatedResponse(c, farm, "Çiftlik başarıyla oluşturuldu")
+}
+
+// UpdateFarm çiftlik güncelleme
+// @Summary Çiftlik güncelleme
+// @Description Çiftliğin adını, konumunu, açıklamasını ve ayarlarını günceller; ayarlar gönderilmezse mevcut ayarlar korunur. Varsayılan çiftliğin adı profildeki çiftlik adına da yansıtılır
+// @ID updateFarm
+// @Tags Farms
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Çiftlik ID"
+// @Param request body models.Farm true "Çiftlik bilgileri"
+// @Success 200 {object} models.APIResponse{data=models.Farm}
+// @Failure 400 {object} models.APIResponse
+// @Failure 401 {object} models.APIResponse
+// @Failure 404 {object} models.APIResponse
+// @Router /farms/{id} [put]
+func (h *FarmHandler) UpdateFarm(c *gin.Context) {
+	userID, err := utils.GetUserID(c)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusUnauthorized, "UNAUTHORIZED", "Kullanıcı kimliği doğrulanamadı", nil)
+		return
+	}
+
+	farmID := c.Param("id")
+	existing, ok := h.getFarm(c, userID, farmID)
+	if !ok {
+		return
+	}
+
+	var req models.Farm
+	if err := c.ShouldBindJSON(&req); err != nil {
+		utils.ErrorResponse(c, http.StatusBadRequest, "INVALID_REQUEST", "Geçersiz istek formatı", err.Error())
+		return
+	}
+	if req.Settings != nil {
+		if err := services.NormalizeFiscalSettings(&req.Settings.Fiscal); err != nil {
+			utils.ErrorResponse(c, http.StatusBadRequest, "INVALID_FISCAL_SETTINGS", err.Error(), nil)
+			return
+		}
+	}
+
+	_, err = h.db.Exec(`
+		UPDATE farms SET name = ?, location = ?, description = ?, updated_at = CURRENT_TIMESTAMP
+		WHERE id = ? AND user_id = ?
+	`, req.Name, req.Location, req.Description, farmID, userID)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusInternalServerError, "UPDATE_ERROR", "Çiftlik güncellenemedi", err.Error())
+		return
+	}
+
+	if req.Settings != nil {
+		if err := h.farms.SaveSettings(farmID, *req.Settings); err != nil {
+			utils.ErrorResponse(c, http.StatusInternalServerError, "UPDATE_ERROR", "Çiftlik ayarları kaydedilemedi", err.Error())
+			return
+		}
+	}
+
+	if existing.IsDefault {
+		h.db.Exec("UPDATE users SET farm_name = ?, updated_at = CURRENT_TIMESTAMP WHERE id = ?", req.Name, userID)
+	}
+
+	farm, ok := h.getFarmDetail(c, userID, farmID)
+	if !ok {
+		return
+	}
+
+	utils.SuccessResponse(c, farm, "Çiftlik başarıyla güncellendi")
+}
+
+// DeleteFarm çiftlik silme
+// @Summary Çiftlik silme
+// @Description Kayıt içermeyen çiftliği siler; varsayılan çiftlik silinemez
+// @ID deleteFarm
+// @Tags Farms
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Çiftlik ID"
+// @Success 200 {object} models.APIResponse
+// @Failure 401 {object} models.APIResponse
+// @Failure 404 {object} models.APIResponse
+// @Failure 409 {object} models.APIResponse
+// @Router /farms/{id} [delete]
+func (h *FarmHandler) DeleteFarm(c *gin.Context) {
+	userID, err := utils.GetUserID(c)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusUnauthorized, "UNAUTHORIZED", "Kullanıcı kimliği doğrulanamadı", nil)
+		return
+	}
+
+	farmID := c.Param("id")
+	farm, ok := h.getFarm(c, userID, farmID)
+	if !ok {
+		return
+	}
+	if farm.IsDefault {
+		utils.ErrorResponse(c, http.StatusConflict, "DEFAULT_FARM", "Varsayılan çiftlik silinemez", nil)
+		return
+	}
+
+	for _, table := range farmDataTables {
+		var count int
+		if err := h.db.QueryRow("SELECT COUNT(*) FROM "+table+" WHERE user_id = ?", farmID).Scan(&count); err != nil {
+			utils.ErrorResponse(c, http.StatusInternalServerError, "DB_ERROR", "Çiftlik kayıtları kontrol edilemedi", err.Error())
+			return
+		}
+		if count > 0 {
+			utils.ErrorResponse(c, http.StatusConflict, "FARM_NOT_EMPTY", "Kayıt içeren çiftlik silinemez", map[string]interface{}{
+				"table": table,
+				"count": count,
+			})
+			return
+		}
+	}
+
+	if _, err := h.db.Exec("DELETE FROM farms WHERE id = ? AND user_id = ?", farmID, userID); err != nil {
+		utils.ErrorResponse(c, http.StatusInternalServerError, "DELETE_ERROR", "Çiftlik silinemedi", err.Error())
+		return
+	}
+	h.db.Exec("DELETE FROM inbound_email_addresses WHERE user_id = ?", farmID)
+	h.db.Exec("DELETE FROM farm_public_profiles WHERE user_id = ?", farmID)
+	h.db.Exec("DELETE FROM weather_locations WHERE user_id = ?", farmID)
+
+	utils.SuccessResponse(c, nil, "Çiftlik başarıyla silindi")
+}
+
+// farmSelect çiftlik sorgularının ortak sütunları
+const farmSelect = `
+	SELECT id, name, COALESCE(location, ''), COALESCE(description, ''), is_default, created_at, updated_at
+	FROM farms`
+
+// scanFarm çiftlik satırını okur
+func scanFarm(row interface{ Scan(...interface{}) error }) (models.Farm, error) {
+	var farm models.Farm
+	err := row.Scan(&farm.ID, &farm.Name, &farm.Location, &farm.Description, &farm.IsDefault, &farm.CreatedAt, &farm.UpdatedAt)
+	return farm, err
+}
+
+// getFarm hesabın çiftliğini getirir; bulunamazsa yanıtı yazar
+func (h *FarmHandler) getFarm(c *gin.Context, userID, farmID string) (models.Farm, bool) {
+	if farmID == userID {
+		if err := h.farms.EnsureDefaultFarm(userID); err != nil {
+			utils.ErrorResponse(c, http.StatusInternalServerError, "DB_ERROR", "Çiftlik getirilemedi", err.Error())
+			return models.Farm{}, false
+		}
+	}
+
+	farm, err := scanFarm(h.db.QueryRow(farmSelect+" WHERE id = ? AND user_id = ?", farmID, userID))
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusNotFound, "FARM_NOT_FOUND", "Çiftlik bulunamadı", nil)
+		return farm, false
+	}
+	return farm, true
+}
+
+// getFarmDetail çiftliği ayarları ve özet istatistikleriyle getirir; hata varsa yanıtı yazar
+func (h *FarmHandler) getFarmDetail(c *gin.Context, userID, farmID string) (models.Farm, bool) {
+	farm, ok := h.getFarm(c, userID, farmID)
+	if !ok {
+		return farm, false
+	}
+
+	settings, err := h.farms.Settings(farmID)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusInternalServerError, "DB_ERROR", "Çiftlik ayarları getirilemedi", err.Error())
+		return farm, false
+	}
+	summary, err := h.farms.Summary(farmID)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusInternalServerError, "DB_ERROR", "Çiftlik özeti hesaplanamadı", err.Error())
+		return farm, false
+	}
+
+	farm.Settings = &settings
+	farm.Summary = &summary
+	farm.IsCurrent = farmID == c.GetHeader(middleware.FarmHeader) || farm.IsDefault && c.GetHeader(middleware.FarmHeader) == ""
+	return farm, true
+}
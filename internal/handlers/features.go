@@ -0,0 +1,48 @@
+package handlers
+
+import (
+	"database/sql"
+	"net/http"
+
+	"agri-management-api/internal/services"
+	"agri-management-api/internal/utils"
+
+	"github.com/gin-gonic/gin"
+)
+
+// FeatureHandler özellik bayrağı işlemlerini yönetir
+type FeatureHandler struct {
+	db    *sql.DB
+	flags *services.FeatureFlagService
+}
+
+// NewFeatureHandler yeni feature handler oluşturur
+func NewFeatureHandler(db *sql.DB) *FeatureHandler {
+	return &FeatureHandler{
+		db:    db,
+		flags: services.NewFeatureFlagService(db),
+	}
+}
+
+// GetFeatures özellik bayrakları
+// @Summary Özellik bayrakları
+// @Description Kullanıcı için çözülmüş özellik bayraklarını ve kullanımdan kaldırılacak örnek veri davranışlarını listeler
+// @ID getFeatures
+// @Tags Features
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {object} models.APIResponse{data=[]models.FeatureFlag}
+// @Failure 401 {object} models.APIResponse
+// @Router /features [get]
+func (h *FeatureHandler) GetFeatures(c *gin.Context) {
+	userID, err := utils.GetUserID(c)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusUnauthorized, "UNAUTHORIZED", "Kullanıcı kimliği doğrulanamadı", nil)
+		return
+	}
+
+	flags := h.flags.List(userID)
+
+	utils.SuccessResponse(c, flags, "Özellik bayrakları başarıyla getirildi")
+}
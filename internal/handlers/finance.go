@@ -3,33 +3,62 @@ package handlers
 import (
 	"database/sql"
 	"net/http"
+	"strconv"
 	"time"
 
 	"agri-management-api/internal/models"
+	"agri-management-api/internal/services"
 	"agri-management-api/internal/utils"
 
 	"github.com/gin-gonic/gin"
 )
 
+// transactionSortFields işlem listesinde sıralanabilen alanlar
+var transactionSortFields = map[string]string{
+	"date":      "date",
+	"amount":    "amount",
+	"category":  "category",
+	"createdAt": "created_at",
+}
+
 // FinanceHandler finans işlemlerini yönetir
 type FinanceHandler struct {
-	db *sql.DB
+	db            *sql.DB
+	farms         *services.FarmService
+	bank          *services.BankService
+	store         services.MediaStore
+	notifications *services.NotificationService
+	eventRules    *services.EventRuleService
+	allocations   *services.CostAllocationService
+	enterprises   *services.EnterpriseService
+	invoices      *services.InvoiceService
 }
 
 // NewFinanceHandler yeni finance handler oluşturur
 func NewFinanceHandler(db *sql.DB) *FinanceHandler {
-	return &FinanceHandler{db: db}
+	return &FinanceHandler{
+		db:            db,
+		farms:         services.NewFarmService(db),
+		bank:          services.NewBankService(db),
+		store:         services.NewMediaStore(),
+		notifications: services.NewNotificationService(db),
+		eventRules:    services.NewEventRuleService(db),
+		allocations:   services.NewCostAllocationService(db),
+		enterprises:   services.NewEnterpriseService(db),
+		invoices:      services.NewInvoiceService(db),
+	}
 }
 
 // GetFinanceSummary finansal özet
 // @Summary Finansal özet
-// @Description Finansal özet verileri getirir
+// @Description Mali takvime göre seçilen periyodun finansal özet verilerini getirir
+// @ID getFinanceSummary
 // @Tags Finance
 // @Accept json
 // @Produce json
 // @Security BearerAuth
-// @Param period query string false "Periyot"
-// @Success 200 {object} models.APIResponse{data=map[string]interface{}}
+// @Param period query string false "Periyot (month, quarter, year veya mali takvimde tanımlı dönem kodu)"
+// @Success 200 {object} models.APIResponse{data=models.FinancePeriodSummary}
 // @Failure 401 {object} models.APIResponse
 // @Router /finance/summary [get]
 func (h *FinanceHandler) GetFinanceSummary(c *gin.Context) {
@@ -39,34 +68,19 @@ func (h *FinanceHandler) GetFinanceSummary(c *gin.Context) {
 		return
 	}
 
-	period := c.DefaultQuery("period", "month")
-
-	// Periyoda göre tarih aralığı belirle
-	var startDate, endDate string
-	now := time.Now()
-
-	switch period {
-	case "month":
-		startDate = now.Format("2006-01") + "-01"
-		endDate = now.Format("2006-01-02")
-	case "quarter":
-		quarter := (now.Month()-1)/3 + 1
-		startDate = now.Format("2006") + "-" + time.Month((quarter-1)*3 + 1).String()[:2] + "-01"
-		endDate = now.Format("2006-01-02")
-	case "year":
-		startDate = now.Format("2006") + "-01-01"
-		endDate = now.Format("2006-01-02")
-	default:
-		startDate = now.Format("2006-01") + "-01"
-		endDate = now.Format("2006-01-02")
+	// Periyoda göre mali takvimdeki tarih aralığını belirle
+	periodRange, ok := fiscalPeriodRange(c, h.farms, c.DefaultQuery("period", "month"), 1)
+	if !ok {
+		return
 	}
+	startDate, endDate := periodRange.StartDate.Format("2006-01-02"), periodRange.EndDate.Format("2006-01-02")
 
 	// Toplam gelir
 	var totalIncome float64
 	err = h.db.QueryRow(`
 		SELECT COALESCE(SUM(amount), 0)
 		FROM transactions 
-		WHERE user_id = ? AND type = 'income' AND date >= ? AND date <= ?
+		WHERE user_id = ? AND type = 'income' AND date(date) >= ? AND date(date) < ?
 	`, userID, startDate, endDate).Scan(&totalIncome)
 	if err != nil {
 		utils.ErrorResponse(c, http.StatusInternalServerError, "DB_ERROR", "Toplam gelir alınamadı", err.Error())
@@ -78,7 +92,7 @@ func (h *FinanceHandler) GetFinanceSummary(c *gin.Context) {
 	err = h.db.QueryRow(`
 		SELECT COALESCE(SUM(amount), 0)
 		FROM transactions 
-		WHERE user_id = ? AND type = 'expense' AND date >= ? AND date <= ?
+		WHERE user_id = ? AND type = 'expense' AND date(date) >= ? AND date(date) < ?
 	`, userID, startDate, endDate).Scan(&totalExpense)
 	if err != nil {
 		utils.ErrorResponse(c, http.StatusInternalServerError, "DB_ERROR", "Toplam gider alınamadı", err.Error())
@@ -100,16 +114,30 @@ func (h *FinanceHandler) GetFinanceSummary(c *gin.Context) {
 		return
 	}
 
+	// Vadesi geçmiş bekleyen ödemeler
+	var overduePayments float64
+	err = h.db.QueryRow(`
+		SELECT COALESCE(SUM(amount), 0)
+		FROM transactions 
+		WHERE user_id = ? AND status = 'pending' AND date(COALESCE(due_date, date)) < date('now')
+	`, userID).Scan(&overduePayments)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusInternalServerError, "DB_ERROR", "Vadesi geçmiş ödemeler alınamadı", err.Error())
+		return
+	}
+
 	// Trend hesaplamaları (basit implementasyon)
-	summary := map[string]interface{}{
-		"totalIncome":     totalIncome,
-		"totalExpense":    totalExpense,
-		"netProfit":       netProfit,
-		"pendingPayments": pendingPayments,
-		"trends": map[string]float64{
-			"income":  5.2,  // Mock data
-			"expense": -3.1, // Mock data
-			"profit":  8.5,  // Mock data
+	summary := models.FinancePeriodSummary{
+		Period:          periodRange,
+		TotalIncome:     totalIncome,
+		TotalExpense:    totalExpense,
+		NetProfit:       netProfit,
+		PendingPayments: pendingPayments,
+		OverduePayments: overduePayments,
+		Trends: models.FinanceTrends{
+			Income:  5.2,  // Mock data
+			Expense: -3.1, // Mock data
+			Profit:  8.5,  // Mock data
 		},
 	}
 
@@ -119,6 +147,7 @@ func (h *FinanceHandler) GetFinanceSummary(c *gin.Context) {
 // GetTransactions işlem listesi
 // @Summary İşlem listesi
 // @Description Finansal işlemlerin listesini getirir
+// @ID getTransactions
 // @Tags Finance
 // @Accept json
 // @Produce json
@@ -127,9 +156,14 @@ func (h *FinanceHandler) GetFinanceSummary(c *gin.Context) {
 // @Param limit query int false "Sayfa başına kayıt"
 // @Param type query string false "İşlem türü"
 // @Param category query string false "Kategori"
+// @Param period query string false "Mali takvime göre içinde bulunulan periyot (month, quarter, year veya dönem kodu); startDate/endDate ile birlikte kullanılmaz"
 // @Param startDate query string false "Başlangıç tarihi"
 // @Param endDate query string false "Bitiş tarihi"
-// @Success 200 {object} models.APIResponse{data=map[string]interface{}}
+// @Param enterpriseId query string false "İşleme doğrudan atanmış faaliyet kolu ID"
+// @Param tag query []string false "Etiket filtresi (boyut:değer, tekrarlanabilir; tümü eşleşmeli)" collectionFormat(multi)
+// @Param sortBy query string false "Sıralama alanı (date, amount, category, createdAt)"
+// @Param sortOrder query string false "Sıralama yönü (asc, desc)"
+// @Success 200 {object} models.APIResponse{data=models.TransactionListResponse}
 // @Failure 401 {object} models.APIResponse
 // @Router /finance/transactions [get]
 func (h *FinanceHandler) GetTransactions(c *gin.Context) {
@@ -144,6 +178,16 @@ func (h *FinanceHandler) GetTransactions(c *gin.Context) {
 	category := c.DefaultQuery("category", "all")
 	startDate := c.DefaultQuery("startDate", "")
 	endDate := c.DefaultQuery("endDate", "")
+	orderBy := utils.ParseSort(c, transactionSortFields, "date DESC")
+
+	if period := c.Query("period"); period != "" && startDate == "" && endDate == "" {
+		periodRange, ok := fiscalPeriodRange(c, h.farms, period, 1)
+		if !ok {
+			return
+		}
+		startDate = periodRange.StartDate.Format("2006-01-02")
+		endDate = periodRange.EndDate.AddDate(0, 0, -1).Format("2006-01-02")
+	}
 
 	// Sorgu oluştur
 	whereClause := "WHERE user_id = ?"
@@ -160,15 +204,24 @@ func (h *FinanceHandler) GetTransactions(c *gin.Context) {
 	}
 
 	if startDate != "" {
-		whereClause += " AND date >= ?"
+		whereClause += " AND date(date) >= ?"
 		args = append(args, startDate)
 	}
 
 	if endDate != "" {
-		whereClause += " AND date <= ?"
+		whereClause += " AND date(date) <= ?"
 		args = append(args, endDate)
 	}
 
+	if enterpriseID := c.Query("enterpriseId"); enterpriseID != "" {
+		whereClause += " AND enterprise_id = ?"
+		args = append(args, enterpriseID)
+	}
+
+	tagClause, tagArgs := tagFilterClause(c.QueryArray("tag"))
+	whereClause += tagClause
+	args = append(args, tagArgs...)
+
 	// Toplam kayıt sayısını al
 	var total int
 	err = h.db.QueryRow("SELECT COUNT(*) FROM transactions "+whereClause, args...).Scan(&total)
@@ -182,11 +235,8 @@ func (h *FinanceHandler) GetTransactions(c *gin.Context) {
 
 	// İşlemleri getir
 	offset := (page - 1) * limit
-	query := `
-		SELECT id, user_id, type, category, description, amount, currency, date,
-		       status, payment_method, receipt, notes, created_at, updated_at
-		FROM transactions ` + whereClause + `
-		ORDER BY date DESC LIMIT ? OFFSET ?
+	query := transactionSelect + " " + whereClause + `
+		ORDER BY ` + orderBy + ` LIMIT ? OFFSET ?
 	`
 	args = append(args, limit, offset)
 
@@ -199,24 +249,21 @@ func (h *FinanceHandler) GetTransactions(c *gin.Context) {
 
 	var transactions []models.Transaction
 	for rows.Next() {
-		var transaction models.Transaction
-
-		err := rows.Scan(
-			&transaction.ID, &transaction.UserID, &transaction.Type, &transaction.Category,
-			&transaction.Description, &transaction.Amount, &transaction.Currency, &transaction.Date,
-			&transaction.Status, &transaction.PaymentMethod, &transaction.Receipt, &transaction.Notes,
-			&transaction.CreatedAt, &transaction.UpdatedAt,
-		)
+		transaction, err := scanTransaction(rows)
 		if err != nil {
 			continue
 		}
 
 		transactions = append(transactions, transaction)
 	}
+	if err := h.attachTransactionTags(userID, transactions); err != nil {
+		utils.ErrorResponse(c, http.StatusInternalServerError, "DB_ERROR", "İşlem etiketleri alınamadı", err.Error())
+		return
+	}
 
-	response := map[string]interface{}{
-		"transactions": transactions,
-		"pagination":   pagination,
+	response := models.TransactionListResponse{
+		Transactions: transactions,
+		Pagination:   pagination,
 	}
 
 	utils.SuccessResponse(c, response, "İşlemler başarıyla getirildi")
@@ -225,6 +272,7 @@ func (h *FinanceHandler) GetTransactions(c *gin.Context) {
 // CreateTransaction yeni işlem ekleme
 // @Summary Yeni işlem ekleme
 // @Description Yeni finansal işlem oluşturur
+// @ID createTransaction
 // @Tags Finance
 // @Accept json
 // @Produce json
@@ -253,49 +301,37 @@ func (h *FinanceHandler) CreateTransaction(c *gin.Context) {
 		return
 	}
 
-	transactionID := utils.GenerateID()
+	tags, err := parseTransactionTags(req.Tags)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusBadRequest, "INVALID_TAGS", err.Error(), nil)
+		return
+	}
+	if !validateEnterprise(c, h.enterprises, userID, &req.EnterpriseID) {
+		return
+	}
 
 	// İşlemi oluştur
-	_, err = h.db.Exec(`
-		INSERT INTO transactions (id, user_id, type, category, description, amount, currency,
-		                         date, status, payment_method, receipt, notes, created_at, updated_at)
-		VALUES (?, ?, ?, ?, ?, ?, ?, ?, 'completed', ?, ?, ?, CURRENT_TIMESTAMP, CURRENT_TIMESTAMP)
-	`, transactionID, userID, req.Type, req.Category, req.Description, req.Amount, req.Currency,
-		req.Date, req.PaymentMethod, req.Receipt, req.Notes)
-
+	transactionID, err := h.insertTransaction(userID, req, tags)
 	if err != nil {
 		utils.ErrorResponse(c, http.StatusInternalServerError, "DB_ERROR", "İşlem oluşturulamadı", err.Error())
 		return
 	}
 
 	// Oluşturulan işlemi getir
-	var transaction models.Transaction
-	err = h.db.QueryRow(`
-		SELECT id, user_id, type, category, description, amount, currency, date,
-		       status, payment_method, receipt, notes, created_at, updated_at
-		FROM transactions WHERE id = ?
-	`, transactionID).Scan(
-		&transaction.ID, &transaction.UserID, &transaction.Type, &transaction.Category,
-		&transaction.Description, &transaction.Amount, &transaction.Currency, &transaction.Date,
-		&transaction.Status, &transaction.PaymentMethod, &transaction.Receipt, &transaction.Notes,
-		&transaction.CreatedAt, &transaction.UpdatedAt,
-	)
-
+	transaction, err := h.getTransaction(transactionID, userID)
 	if err != nil {
 		utils.ErrorResponse(c, http.StatusInternalServerError, "FETCH_ERROR", "Oluşturulan işlem getirilemedi", err.Error())
 		return
 	}
+	h.eventRules.SyncQuietly(userID, models.EventRuleInstallmentDue)
 
-	c.JSON(http.StatusCreated, models.APIResponse{
-		Success: true,
-		Data:    transaction,
-		Message: "İşlem başarıyla oluşturuldu",
-	})
+	utils.CreatedResponse(c, transaction, "İşlem başarıyla oluşturuldu")
 }
 
 // GetTransaction işlem detayları
 // @Summary İşlem detayları
 // @Description Belirli bir işlemin detaylarını getirir
+// @ID getTransaction
 // @Tags Finance
 // @Accept json
 // @Produce json
@@ -318,18 +354,7 @@ func (h *FinanceHandler) GetTransaction(c *gin.Context) {
 		return
 	}
 
-	var transaction models.Transaction
-	err = h.db.QueryRow(`
-		SELECT id, user_id, type, category, description, amount, currency, date,
-		       status, payment_method, receipt, notes, created_at, updated_at
-		FROM transactions WHERE id = ? AND user_id = ?
-	`, transactionID, userID).Scan(
-		&transaction.ID, &transaction.UserID, &transaction.Type, &transaction.Category,
-		&transaction.Description, &transaction.Amount, &transaction.Currency, &transaction.Date,
-		&transaction.Status, &transaction.PaymentMethod, &transaction.Receipt, &transaction.Notes,
-		&transaction.CreatedAt, &transaction.UpdatedAt,
-	)
-
+	transaction, err := h.getTransaction(transactionID, userID)
 	if err != nil {
 		if err == sql.ErrNoRows {
 			utils.ErrorResponse(c, http.StatusNotFound, "TRANSACTION_NOT_FOUND", "İşlem bulunamadı", nil)
@@ -339,12 +364,13 @@ func (h *FinanceHandler) GetTransaction(c *gin.Context) {
 		return
 	}
 
-	utils.SuccessResponse(c, transaction, "İşlem detayları başarıyla getirildi")
+	utils.DetailResponse(c, transaction, services.EntityLinks("transaction", transaction.ID), "İşlem detayları başarıyla getirildi")
 }
 
 // UpdateTransaction işlem güncelleme
 // @Summary İşlem güncelleme
 // @Description Mevcut işlem bilgilerini günceller
+// @ID updateTransaction
 // @Tags Finance
 // @Accept json
 // @Produce json
@@ -375,35 +401,65 @@ func (h *FinanceHandler) UpdateTransaction(c *gin.Context) {
 		return
 	}
 
+	tags, err := parseTransactionTags(req.Tags)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusBadRequest, "INVALID_TAGS", err.Error(), nil)
+		return
+	}
+	if !validateEnterprise(c, h.enterprises, userID, &req.EnterpriseID) {
+		return
+	}
+
 	// İşlemi güncelle
-	_, err = h.db.Exec(`
+	// Vade tarihi değişirse gecikme bildirimi yeniden gönderilebilir; bekleyen işlem tamamlanınca ödeme zamanı kaydedilir
+	result, err := h.db.Exec(`
 		UPDATE transactions 
 		SET type = ?, category = ?, description = ?, amount = ?, currency = ?, date = ?,
-		    status = ?, payment_method = ?, receipt = ?, notes = ?, updated_at = CURRENT_TIMESTAMP
+		    status = ?, payment_method = ?, receipt = ?, notes = ?, due_date = ?,
+		    paid_at = CASE WHEN ? = 'completed' AND status = 'pending' THEN CURRENT_TIMESTAMP
+		                   WHEN ? = 'pending' THEN NULL ELSE paid_at END,
+		    overdue_notified_at = CASE WHEN COALESCE(date(due_date), '') = COALESCE(date(?), '') THEN overdue_notified_at END,
+		    enterprise_id = ?, updated_at = CURRENT_TIMESTAMP
 		WHERE id = ? AND user_id = ?
 	`, req.Type, req.Category, req.Description, req.Amount, req.Currency, req.Date,
-		req.Status, req.PaymentMethod, req.Receipt, req.Notes, transactionID, userID)
+		req.Status, req.PaymentMethod, req.Receipt, req.Notes, req.DueDate,
+		req.Status, req.Status, req.DueDate, req.EnterpriseID, transactionID, userID)
 
 	if err != nil {
 		utils.ErrorResponse(c, http.StatusInternalServerError, "UPDATE_ERROR", "İşlem güncellenemedi", err.Error())
 		return
 	}
 
+	// Etiketler gönderilmezse mevcut etiketler korunur
+	if req.Tags != nil {
+		if rows, _ := result.RowsAffected(); rows > 0 {
+			if err := h.saveTransactionTags(userID, transactionID, tags); err != nil {
+				utils.ErrorResponse(c, http.StatusInternalServerError, "UPDATE_ERROR", "İşlem etiketleri kaydedilemedi", err.Error())
+				return
+			}
+		}
+	}
+
+	h.eventRules.SyncQuietly(userID, models.EventRuleInstallmentDue)
+
 	// Güncellenmiş işlemi getir
 	h.GetTransaction(c)
 }
 
 // DeleteTransaction işlem silme
 // @Summary İşlem silme
-// @Description Belirli bir işlemi siler
+// @Description Belirli bir işlemi siler. Faturası kesilmiş işlem silinemez (TRANSACTION_INVOICED)
+// @ID deleteTransaction
 // @Tags Finance
 // @Accept json
 // @Produce json
 // @Security BearerAuth
 // @Param id path string true "İşlem ID"
+// @Param force query bool false "Başka kayıtlarla bağlantısı olsa da sil"
 // @Success 200 {object} models.APIResponse
 // @Failure 401 {object} models.APIResponse
 // @Failure 404 {object} models.APIResponse
+// @Failure 409 {object} models.APIResponse
 // @Router /finance/transactions/{id} [delete]
 func (h *FinanceHandler) DeleteTransaction(c *gin.Context) {
 	userID, err := utils.GetUserID(c)
@@ -418,6 +474,20 @@ func (h *FinanceHandler) DeleteTransaction(c *gin.Context) {
 		return
 	}
 
+	invoiceNumber, err := h.invoices.TransactionInvoice(userID, transactionID)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusInternalServerError, "DB_ERROR", "İşlem silinemedi", err.Error())
+		return
+	}
+	if invoiceNumber != "" {
+		utils.ErrorResponse(c, http.StatusConflict, "TRANSACTION_INVOICED", "Faturası kesilmiş işlem silinemez", invoiceNumber)
+		return
+	}
+
+	if !confirmLinkedDelete(c, h.db, userID, "transaction", transactionID) {
+		return
+	}
+
 	// İşlemi sil
 	result, err := h.db.Exec("DELETE FROM transactions WHERE id = ? AND user_id = ?", transactionID, userID)
 	if err != nil {
@@ -431,12 +501,21 @@ func (h *FinanceHandler) DeleteTransaction(c *gin.Context) {
 		return
 	}
 
+	h.db.Exec("DELETE FROM transaction_tags WHERE transaction_id = ? AND user_id = ?", transactionID, userID)
+	// Silinen işlemle eşleşen ekstre satırları yeniden eşleştirilebilir
+	h.db.Exec("UPDATE bank_statement_lines SET status = ?, transaction_id = NULL, match_score = NULL WHERE transaction_id = ? AND user_id = ?",
+		models.StatementLineUnmatched, transactionID, userID)
+
+	removeRecordLinks(h.db, userID, "transaction", transactionID)
+	h.eventRules.SyncQuietly(userID, models.EventRuleInstallmentDue)
+
 	utils.SuccessResponse(c, nil, "İşlem başarıyla silindi")
 }
 
 // GetCategories kategori listesi
 // @Summary Kategori listesi
 // @Description Finansal işlem kategorilerini getirir
+// @ID getFinanceCategories
 // @Tags Finance
 // @Accept json
 // @Produce json
@@ -471,9 +550,11 @@ func (h *FinanceHandler) GetCategories(c *gin.Context) {
 		"Su",
 		"İşçilik",
 		"Veteriner",
+		"Hayvan Alımı",
 		"Bakım-Onarım",
 		"Sigorta",
 		"Vergi",
+		"Amortisman",
 		"Diğer Giderler",
 	}
 
@@ -487,7 +568,8 @@ func (h *FinanceHandler) GetCategories(c *gin.Context) {
 
 // GetFinanceAnalysis gelir-gider analizi
 // @Summary Gelir-gider analizi
-// @Description Finansal analiz verileri getirir
+// @Description Aylık gelir-gider dağılımını ve gelir ile gider için ayrı kategori kırılımlarını (tutar, işlem sayısı, ortalama, türü içindeki yüzde) getirir
+// @ID getFinanceAnalysis
 // @Tags Finance
 // @Accept json
 // @Produce json
@@ -495,7 +577,8 @@ func (h *FinanceHandler) GetCategories(c *gin.Context) {
 // @Param period query string false "Periyot"
 // @Param startDate query string false "Başlangıç tarihi"
 // @Param endDate query string false "Bitiş tarihi"
-// @Success 200 {object} models.APIResponse{data=map[string]interface{}}
+// @Param top query int false "Tür başına gösterilecek kategori sayısı; kalanlar other grubunda toplanır"
+// @Success 200 {object} models.APIResponse{data=models.FinanceAnalysis}
 // @Failure 401 {object} models.APIResponse
 // @Router /finance/analysis [get]
 func (h *FinanceHandler) GetFinanceAnalysis(c *gin.Context) {
@@ -509,20 +592,31 @@ func (h *FinanceHandler) GetFinanceAnalysis(c *gin.Context) {
 	startDate := c.DefaultQuery("startDate", "")
 	endDate := c.DefaultQuery("endDate", "")
 
-	// Tarih aralığını belirle
+	top := 0
+	if value := c.Query("top"); value != "" {
+		parsed, err := strconv.Atoi(value)
+		if err != nil || parsed < 1 {
+			utils.ErrorResponse(c, http.StatusBadRequest, "INVALID_TOP", "top pozitif bir tam sayı olmalı", nil)
+			return
+		}
+		top = parsed
+	}
+
+	// Tarih aralığını mali takvime göre belirle: son 6 ay, 4 mali çeyrek, 3 mali yıl veya 3 dönem
 	if startDate == "" || endDate == "" {
-		now := time.Now()
+		count := 3
 		switch period {
-		case "month":
-			startDate = now.AddDate(0, -6, 0).Format("2006-01-02")
-			endDate = now.Format("2006-01-02")
-		case "quarter":
-			startDate = now.AddDate(0, -12, 0).Format("2006-01-02")
-			endDate = now.Format("2006-01-02")
-		case "year":
-			startDate = now.AddDate(-3, 0, 0).Format("2006-01-02")
-			endDate = now.Format("2006-01-02")
+		case services.PeriodMonth:
+			count = 6
+		case services.PeriodQuarter:
+			count = 4
+		}
+		window, ok := fiscalPeriodRange(c, h.farms, period, count)
+		if !ok {
+			return
 		}
+		startDate = window.StartDate.Format("2006-01-02")
+		endDate = window.EndDate.AddDate(0, 0, -1).Format("2006-01-02")
 	}
 
 	// Aylık analiz
@@ -531,7 +625,7 @@ func (h *FinanceHandler) GetFinanceAnalysis(c *gin.Context) {
 		       SUM(CASE WHEN type = 'income' THEN amount ELSE 0 END) as income,
 		       SUM(CASE WHEN type = 'expense' THEN amount ELSE 0 END) as expense
 		FROM transactions 
-		WHERE user_id = ? AND date >= ? AND date <= ?
+		WHERE user_id = ? AND date(date) >= ? AND date(date) <= ?
 		GROUP BY strftime('%Y-%m', date)
 		ORDER BY month
 	`, userID, startDate, endDate)
@@ -541,7 +635,7 @@ func (h *FinanceHandler) GetFinanceAnalysis(c *gin.Context) {
 	}
 	defer rows.Close()
 
-	var monthly []map[string]interface{}
+	var monthly []models.FinanceMonth
 	for rows.Next() {
 		var month string
 		var income, expense float64
@@ -551,20 +645,20 @@ func (h *FinanceHandler) GetFinanceAnalysis(c *gin.Context) {
 			continue
 		}
 
-		monthly = append(monthly, map[string]interface{}{
-			"month":   month,
-			"income":  income,
-			"expense": expense,
-			"profit":  income - expense,
+		monthly = append(monthly, models.FinanceMonth{
+			Month:   month,
+			Income:  income,
+			Expense: expense,
+			Profit:  income - expense,
 		})
 	}
 
-	// Kategori bazında analiz
+	// Kategori bazında analiz; gelir ve gider kategorileri ayrı listelenir, yüzdeler kendi türünün toplamına göre hesaplanır
 	rows, err = h.db.Query(`
-		SELECT category, SUM(amount) as amount
+		SELECT type, category, SUM(amount) as amount, COUNT(*) as count
 		FROM transactions 
-		WHERE user_id = ? AND date >= ? AND date <= ?
-		GROUP BY category
+		WHERE user_id = ? AND date(date) >= ? AND date(date) <= ?
+		GROUP BY type, category
 		ORDER BY amount DESC
 	`, userID, startDate, endDate)
 	if err != nil {
@@ -573,38 +667,204 @@ func (h *FinanceHandler) GetFinanceAnalysis(c *gin.Context) {
 	}
 	defer rows.Close()
 
-	var byCategory []map[string]interface{}
-	var totalAmount float64
+	byCategory := map[string][]models.FinanceCategoryTotal{
+		"income":  {},
+		"expense": {},
+	}
+	totals := map[string]float64{}
 	for rows.Next() {
-		var category string
+		var transactionType, category string
 		var amount float64
+		var count int
 
-		err := rows.Scan(&category, &amount)
+		err := rows.Scan(&transactionType, &category, &amount, &count)
 		if err != nil {
 			continue
 		}
+		if _, ok := byCategory[transactionType]; !ok {
+			continue
+		}
 
-		totalAmount += amount
-		byCategory = append(byCategory, map[string]interface{}{
-			"category": category,
-			"amount":   amount,
+		totals[transactionType] += amount
+		byCategory[transactionType] = append(byCategory[transactionType], models.FinanceCategoryTotal{
+			Category: category,
+			Amount:   amount,
+			Count:    count,
+			Average:  roundTo2(amount / float64(count)),
 		})
 	}
 
+	// İlk N kategoriden sonrakiler "other" grubunda toplanır
+	if top > 0 {
+		for transactionType, categories := range byCategory {
+			if len(categories) <= top {
+				continue
+			}
+
+			var amount float64
+			var count int
+			for _, item := range categories[top:] {
+				amount += item.Amount
+				count += item.Count
+			}
+			byCategory[transactionType] = append(categories[:top], models.FinanceCategoryTotal{
+				Category:   "other",
+				Amount:     amount,
+				Count:      count,
+				Average:    roundTo2(amount / float64(count)),
+				Categories: len(categories) - top,
+			})
+		}
+	}
+
 	// Yüzdeleri hesapla
-	for i := range byCategory {
-		if totalAmount > 0 {
-			amount := byCategory[i]["amount"].(float64)
-			byCategory[i]["percentage"] = amount / totalAmount * 100
-		} else {
-			byCategory[i]["percentage"] = 0
+	for transactionType, categories := range byCategory {
+		for i := range categories {
+			if totals[transactionType] > 0 {
+				categories[i].Percentage = roundTo2(categories[i].Amount / totals[transactionType] * 100)
+			}
 		}
 	}
 
-	analysis := map[string]interface{}{
-		"monthly":    monthly,
-		"byCategory": byCategory,
+	analysis := models.FinanceAnalysis{
+		Monthly: monthly,
+		ByCategory: models.FinanceCategoryBreakdown{
+			Income:  byCategory["income"],
+			Expense: byCategory["expense"],
+		},
+		Totals: models.FinanceTotals{
+			Income:  totals["income"],
+			Expense: totals["expense"],
+		},
 	}
 
 	utils.SuccessResponse(c, analysis, "Finansal analiz başarıyla getirildi")
 }
+
+// transactionSelect işlem sütunları
+const transactionSelect = `
+	SELECT id, user_id, type, category, description, amount, COALESCE(NULLIF(currency, ''), 'TRY'), date,
+	       status, COALESCE(payment_method, ''), COALESCE(receipt, ''), COALESCE(notes, ''),
+	       due_date, paid_at, enterprise_id, created_at, updated_at
+	FROM transactions`
+
+// scanTransaction işlem satırını okur; bekleyen ve vadesi geçmiş işlemler için gecikme gününü hesaplar
+func scanTransaction(row interface{ Scan(...interface{}) error }) (models.Transaction, error) {
+	var transaction models.Transaction
+	var dueDate, paidAt sql.NullTime
+
+	err := row.Scan(
+		&transaction.ID, &transaction.UserID, &transaction.Type, &transaction.Category,
+		&transaction.Description, &transaction.Amount, &transaction.Currency, &transaction.Date,
+		&transaction.Status, &transaction.PaymentMethod, &transaction.Receipt, &transaction.Notes,
+		&dueDate, &paidAt, &transaction.EnterpriseID, &transaction.CreatedAt, &transaction.UpdatedAt,
+	)
+	if err != nil {
+		return transaction, err
+	}
+
+	transaction.DueDate = utils.NullTimeToPtr(dueDate)
+	transaction.PaidAt = utils.NullTimeToPtr(paidAt)
+	if transaction.Status == "pending" {
+		if days := daysOverdue(transaction, today()); days > 0 {
+			transaction.DaysOverdue = &days
+		}
+	}
+	return transaction, nil
+}
+
+// insertTransaction işlemi etiketleriyle birlikte kaydeder ve yeni işlemin ID'sini döner;
+// vadeli işlemler ödenene kadar bekleyen durumunda tutulur
+func (h *FinanceHandler) insertTransaction(userID string, req models.Transaction, tags []transactionTag) (string, error) {
+	status := "completed"
+	if req.Status == "pending" || req.DueDate != nil && req.Status == "" {
+		status = "pending"
+	}
+
+	transactionID := utils.GenerateID()
+	_, err := h.db.Exec(`
+		INSERT INTO transactions (id, user_id, type, category, description, amount, currency,
+		                         date, status, payment_method, receipt, notes, due_date, paid_at, enterprise_id,
+		                         created_at, updated_at)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, CASE WHEN ? = 'completed' THEN CURRENT_TIMESTAMP END, ?,
+		        CURRENT_TIMESTAMP, CURRENT_TIMESTAMP)
+	`, transactionID, userID, req.Type, req.Category, req.Description, req.Amount, req.Currency,
+		req.Date, status, req.PaymentMethod, req.Receipt, req.Notes, req.DueDate, status, req.EnterpriseID)
+	if err != nil {
+		return "", err
+	}
+
+	if err := h.saveTransactionTags(userID, transactionID, tags); err != nil {
+		return "", err
+	}
+	return transactionID, nil
+}
+
+// getTransaction işlemi etiketleriyle birlikte getirir
+func (h *FinanceHandler) getTransaction(transactionID, userID string) (models.Transaction, error) {
+	transaction, err := scanTransaction(h.db.QueryRow(transactionSelect+" WHERE id = ? AND user_id = ?", transactionID, userID))
+	if err != nil {
+		return transaction, err
+	}
+
+	transactions := []models.Transaction{transaction}
+	err = h.attachTransactionTags(userID, transactions)
+	return transactions[0], err
+}
+
+// GetFiscalPeriods mali takvim periyotları
+// @Summary Mali takvim periyotları
+// @Description Seçili çiftliğin mali takvimine göre verilen tarihi içeren ay, mali çeyrek, mali yıl ve kullanıcı tanımlı dönemlerin tarih aralıklarını getirir; endDate aralığa dahil değildir
+// @ID getFiscalPeriods
+// @Tags Finance
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param date query string false "Referans tarih (YYYY-MM-DD, varsayılan bugün)"
+// @Success 200 {object} models.APIResponse{data=[]models.PeriodRange}
+// @Failure 400 {object} models.APIResponse
+// @Failure 401 {object} models.APIResponse
+// @Router /finance/periods [get]
+func (h *FinanceHandler) GetFiscalPeriods(c *gin.Context) {
+	if _, err := utils.GetUserID(c); err != nil {
+		utils.ErrorResponse(c, http.StatusUnauthorized, "UNAUTHORIZED", "Kullanıcı kimliği doğrulanamadı", nil)
+		return
+	}
+
+	ref := time.Now()
+	if value := c.Query("date"); value != "" {
+		parsed, err := time.Parse("2006-01-02", value)
+		if err != nil {
+			utils.ErrorResponse(c, http.StatusBadRequest, "INVALID_DATE", "Geçersiz tarih", nil)
+			return
+		}
+		ref = parsed
+	}
+
+	farmID, _ := utils.GetFarmID(c)
+	calendar, err := h.farms.FiscalCalendar(farmID)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusInternalServerError, "DB_ERROR", "Mali takvim getirilemedi", err.Error())
+		return
+	}
+
+	utils.SuccessResponse(c, calendar.Ranges(ref), "Mali takvim periyotları başarıyla getirildi")
+}
+
+// fiscalPeriodRange seçili çiftliğin mali takvimine göre bugünü içeren periyotla biten count periyodun
+// aralığını hesaplar; hata varsa yanıtı yazar
+func fiscalPeriodRange(c *gin.Context, farms *services.FarmService, period string, count int) (models.PeriodRange, bool) {
+	farmID, _ := utils.GetFarmID(c)
+	calendar, err := farms.FiscalCalendar(farmID)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusInternalServerError, "DB_ERROR", "Mali takvim getirilemedi", err.Error())
+		return models.PeriodRange{}, false
+	}
+
+	periodRange, err := calendar.Window(period, time.Now(), count)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusBadRequest, "INVALID_PERIOD", "Geçersiz periyot; month, quarter, year veya mali takvimde tanımlı bir dönem kodu olmalı", period)
+		return periodRange, false
+	}
+	return periodRange, true
+}
@@ -0,0 +1,879 @@
+package handlers
+
+import (
+	"database/sql"
+	"fmt"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"agri-management-api/internal/models"
+	"agri-management-api/internal/services"
+	"agri-management-api/internal/utils"
+
+	"github.com/gin-gonic/gin"
+)
+
+// GreenhouseHandler sera ayarlarını, iklim sensörlerini ve ölçümlerini yönetir
+type GreenhouseHandler struct {
+	db            *sql.DB
+	notifications *services.NotificationService
+}
+
+// NewGreenhouseHandler yeni greenhouse handler oluşturur
+func NewGreenhouseHandler(db *sql.DB) *GreenhouseHandler {
+	return &GreenhouseHandler{
+		db:            db,
+		notifications: services.NewNotificationService(db),
+	}
+}
+
+// climateMetricLabels uyarı mesajlarında kullanılan ölçüm adları ve birimleri
+var climateMetricLabels = map[string][2]string{
+	"temperature": {"sıcaklık", "°C"},
+	"humidity":    {"nem", "%"},
+}
+
+// GetGreenhouses sera listesi
+// @Summary Sera listesi
+// @Description Sera olarak işaretlenmiş arazileri iklim hedefleri, son ölçüm ve iklim durumuyla listeler
+// @ID getGreenhouses
+// @Tags Greenhouses
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {object} models.APIResponse{data=[]models.Greenhouse}
+// @Failure 401 {object} models.APIResponse
+// @Router /greenhouses [get]
+func (h *GreenhouseHandler) GetGreenhouses(c *gin.Context) {
+	userID, err := utils.GetUserID(c)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusUnauthorized, "UNAUTHORIZED", "Kullanıcı kimliği doğrulanamadı", nil)
+		return
+	}
+
+	rows, err := h.db.Query(greenhouseSelect+" WHERE l.user_id = ? ORDER BY l.name", userID)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusInternalServerError, "DB_ERROR", "Seralar alınamadı", err.Error())
+		return
+	}
+
+	greenhouses := []models.Greenhouse{}
+	for rows.Next() {
+		greenhouse, err := scanGreenhouse(rows)
+		if err != nil {
+			continue
+		}
+		greenhouses = append(greenhouses, greenhouse)
+	}
+	rows.Close()
+
+	for i := range greenhouses {
+		if err := h.attachLatestReading(userID, &greenhouses[i]); err != nil {
+			utils.ErrorResponse(c, http.StatusInternalServerError, "DB_ERROR", "Son iklim ölçümleri alınamadı", err.Error())
+			return
+		}
+	}
+
+	utils.SuccessResponse(c, greenhouses, "Seralar başarıyla getirildi")
+}
+
+// GetGreenhouse sera detayları
+// @Summary Sera detayları
+// @Description Seranın iklim hedeflerini, sensörlerini, son ölçümünü ve iklim durumunu getirir
+// @ID getGreenhouse
+// @Tags Greenhouses
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Arazi ID"
+// @Success 200 {object} models.APIResponse{data=models.Greenhouse}
+// @Failure 401 {object} models.APIResponse
+// @Failure 404 {object} models.APIResponse
+// @Router /greenhouses/{id} [get]
+func (h *GreenhouseHandler) GetGreenhouse(c *gin.Context) {
+	userID, err := utils.GetUserID(c)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusUnauthorized, "UNAUTHORIZED", "Kullanıcı kimliği doğrulanamadı", nil)
+		return
+	}
+
+	greenhouse, ok := h.loadGreenhouse(c, c.Param("id"), userID)
+	if !ok {
+		return
+	}
+
+	if err := h.attachLatestReading(userID, &greenhouse); err != nil {
+		utils.ErrorResponse(c, http.StatusInternalServerError, "DB_ERROR", "Son iklim ölçümü alınamadı", err.Error())
+		return
+	}
+	if greenhouse.Sensors, err = h.sensors(userID, greenhouse.LandID); err != nil {
+		utils.ErrorResponse(c, http.StatusInternalServerError, "DB_ERROR", "Sensörler alınamadı", err.Error())
+		return
+	}
+
+	utils.DetailResponse(c, greenhouse, services.EntityLinks("greenhouse", greenhouse.LandID), "Sera detayları başarıyla getirildi")
+}
+
+// UpdateGreenhouse araziyi seraya çevirme veya sera ayarlarını güncelleme
+// @Summary Sera ayarları
+// @Description Araziyi sera olarak işaretler veya seranın yapı tipi, ısıtma ve iklim hedeflerini günceller; alertCooldownMinutes aynı ölçüm için uyarılar arasındaki en kısa süredir (varsayılan 60)
+// @ID updateGreenhouse
+// @Tags Greenhouses
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Arazi ID"
+// @Param request body models.GreenhouseRequest true "Sera ayarları"
+// @Success 200 {object} models.APIResponse{data=models.Greenhouse}
+// @Failure 400 {object} models.APIResponse
+// @Failure 401 {object} models.APIResponse
+// @Failure 404 {object} models.APIResponse
+// @Router /greenhouses/{id} [put]
+func (h *GreenhouseHandler) UpdateGreenhouse(c *gin.Context) {
+	userID, err := utils.GetUserID(c)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusUnauthorized, "UNAUTHORIZED", "Kullanıcı kimliği doğrulanamadı", nil)
+		return
+	}
+
+	landID := c.Param("id")
+
+	var req models.GreenhouseRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		utils.ErrorResponse(c, http.StatusBadRequest, "INVALID_REQUEST", "Geçersiz istek formatı", err.Error())
+		return
+	}
+	if message := validateClimateSetpoints(req.Setpoints); message != "" {
+		utils.ErrorResponse(c, http.StatusBadRequest, "INVALID_SETPOINTS", message, nil)
+		return
+	}
+	if req.AlertCooldownMinutes == 0 {
+		req.AlertCooldownMinutes = 60
+	}
+
+	var exists bool
+	h.db.QueryRow("SELECT 1 FROM lands WHERE id = ? AND user_id = ?", landID, userID).Scan(&exists)
+	if !exists {
+		utils.ErrorResponse(c, http.StatusNotFound, "LAND_NOT_FOUND", "Arazi bulunamadı", nil)
+		return
+	}
+
+	tx, err := h.db.Begin()
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusInternalServerError, "DB_ERROR", "Sera ayarları kaydedilemedi", err.Error())
+		return
+	}
+	defer tx.Rollback()
+
+	_, err = tx.Exec(`
+		INSERT INTO greenhouses (land_id, user_id, structure_type, heated, min_temperature, max_temperature,
+		                         min_humidity, max_humidity, alert_cooldown_minutes, created_at, updated_at)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, CURRENT_TIMESTAMP, CURRENT_TIMESTAMP)
+		ON CONFLICT (land_id) DO UPDATE SET
+		    structure_type = excluded.structure_type, heated = excluded.heated,
+		    min_temperature = excluded.min_temperature, max_temperature = excluded.max_temperature,
+		    min_humidity = excluded.min_humidity, max_humidity = excluded.max_humidity,
+		    alert_cooldown_minutes = excluded.alert_cooldown_minutes, updated_at = CURRENT_TIMESTAMP
+	`, landID, userID, req.StructureType, req.Heated, req.Setpoints.MinTemperature, req.Setpoints.MaxTemperature,
+		req.Setpoints.MinHumidity, req.Setpoints.MaxHumidity, req.AlertCooldownMinutes)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusInternalServerError, "DB_ERROR", "Sera ayarları kaydedilemedi", err.Error())
+		return
+	}
+
+	_, err = tx.Exec("UPDATE lands SET land_type = ?, updated_at = CURRENT_TIMESTAMP WHERE id = ? AND user_id = ?",
+		models.LandTypeGreenhouse, landID, userID)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusInternalServerError, "UPDATE_ERROR", "Arazi türü güncellenemedi", err.Error())
+		return
+	}
+
+	if err := tx.Commit(); err != nil {
+		utils.ErrorResponse(c, http.StatusInternalServerError, "DB_ERROR", "Sera ayarları kaydedilemedi", err.Error())
+		return
+	}
+
+	h.GetGreenhouse(c)
+}
+
+// DeleteGreenhouse sera işaretini kaldırma
+// @Summary Sera işaretini kaldır
+// @Description Araziyi yeniden tarla türüne çevirir; sera ayarları, sensörleri, iklim ölçümleri ve uyarıları silinir
+// @ID deleteGreenhouse
+// @Tags Greenhouses
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Arazi ID"
+// @Success 200 {object} models.APIResponse
+// @Failure 401 {object} models.APIResponse
+// @Failure 404 {object} models.APIResponse
+// @Router /greenhouses/{id} [delete]
+func (h *GreenhouseHandler) DeleteGreenhouse(c *gin.Context) {
+	userID, err := utils.GetUserID(c)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusUnauthorized, "UNAUTHORIZED", "Kullanıcı kimliği doğrulanamadı", nil)
+		return
+	}
+
+	landID := c.Param("id")
+
+	tx, err := h.db.Begin()
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusInternalServerError, "DB_ERROR", "Sera kaldırılamadı", err.Error())
+		return
+	}
+	defer tx.Rollback()
+
+	result, err := tx.Exec("DELETE FROM greenhouses WHERE land_id = ? AND user_id = ?", landID, userID)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusInternalServerError, "DELETE_ERROR", "Sera kaldırılamadı", err.Error())
+		return
+	}
+	if affected, _ := result.RowsAffected(); affected == 0 {
+		utils.ErrorResponse(c, http.StatusNotFound, "GREENHOUSE_NOT_FOUND", "Sera bulunamadı", nil)
+		return
+	}
+
+	for _, table := range []string{"climate_alerts", "climate_readings", "climate_sensors"} {
+		if _, err := tx.Exec("DELETE FROM "+table+" WHERE land_id = ? AND user_id = ?", landID, userID); err != nil {
+			utils.ErrorResponse(c, http.StatusInternalServerError, "DELETE_ERROR", "Sera kaldırılamadı", err.Error())
+			return
+		}
+	}
+	_, err = tx.Exec("UPDATE lands SET land_type = ?, updated_at = CURRENT_TIMESTAMP WHERE id = ? AND user_id = ?",
+		models.LandTypeField, landID, userID)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusInternalServerError, "UPDATE_ERROR", "Arazi türü güncellenemedi", err.Error())
+		return
+	}
+
+	if err := tx.Commit(); err != nil {
+		utils.ErrorResponse(c, http.StatusInternalServerError, "DB_ERROR", "Sera kaldırılamadı", err.Error())
+		return
+	}
+
+	utils.SuccessResponse(c, nil, "Sera başarıyla kaldırıldı")
+}
+
+// CreateClimateSensor sensör ekleme
+// @Summary İklim sensörü ekle
+// @Description Seraya iklim sensörü bağlar; dönen token cihazın POST /sensors/readings isteğinde X-Sensor-Token başlığıyla gönderilir ve yalnızca bu yanıtta gösterilir
+// @ID createClimateSensor
+// @Tags Greenhouses
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Arazi ID"
+// @Param request body models.ClimateSensorRequest true "Sensör bilgileri"
+// @Success 201 {object} models.APIResponse{data=models.ClimateSensor}
+// @Failure 400 {object} models.APIResponse
+// @Failure 401 {object} models.APIResponse
+// @Failure 404 {object} models.APIResponse
+// @Router /greenhouses/{id}/sensors [post]
+func (h *GreenhouseHandler) CreateClimateSensor(c *gin.Context) {
+	userID, err := utils.GetUserID(c)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusUnauthorized, "UNAUTHORIZED", "Kullanıcı kimliği doğrulanamadı", nil)
+		return
+	}
+
+	var req models.ClimateSensorRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		utils.ErrorResponse(c, http.StatusBadRequest, "INVALID_REQUEST", "Geçersiz istek formatı", err.Error())
+		return
+	}
+
+	greenhouse, ok := h.loadGreenhouse(c, c.Param("id"), userID)
+	if !ok {
+		return
+	}
+
+	sensor := models.ClimateSensor{
+		ID:        utils.GenerateID(),
+		LandID:    greenhouse.LandID,
+		Name:      strings.TrimSpace(req.Name),
+		Token:     "sns-" + strings.ReplaceAll(utils.GenerateID(), "-", ""),
+		CreatedAt: time.Now(),
+	}
+	_, err = h.db.Exec(`
+		INSERT INTO climate_sensors (id, user_id, land_id, name, token, created_at)
+		VALUES (?, ?, ?, ?, ?, CURRENT_TIMESTAMP)
+	`, sensor.ID, userID, sensor.LandID, sensor.Name, sensor.Token)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusInternalServerError, "DB_ERROR", "Sensör eklenemedi", err.Error())
+		return
+	}
+
+	utils.CreatedResponse(c, sensor, "Sensör başarıyla eklendi")
+}
+
+// DeleteClimateSensor sensör silme
+// @Summary İklim sensörünü kaldır
+// @Description Sensörü seradan kaldırır; sensörün önceki ölçümleri elle girilmiş gibi saklanır
+// @ID deleteClimateSensor
+// @Tags Greenhouses
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Arazi ID"
+// @Param sensorId path string true "Sensör ID"
+// @Success 200 {object} models.APIResponse
+// @Failure 401 {object} models.APIResponse
+// @Failure 404 {object} models.APIResponse
+// @Router /greenhouses/{id}/sensors/{sensorId} [delete]
+func (h *GreenhouseHandler) DeleteClimateSensor(c *gin.Context) {
+	userID, err := utils.GetUserID(c)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusUnauthorized, "UNAUTHORIZED", "Kullanıcı kimliği doğrulanamadı", nil)
+		return
+	}
+
+	sensorID := c.Param("sensorId")
+	result, err := h.db.Exec("DELETE FROM climate_sensors WHERE id = ? AND land_id = ? AND user_id = ?", sensorID, c.Param("id"), userID)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusInternalServerError, "DELETE_ERROR", "Sensör kaldırılamadı", err.Error())
+		return
+	}
+	if affected, _ := result.RowsAffected(); affected == 0 {
+		utils.ErrorResponse(c, http.StatusNotFound, "SENSOR_NOT_FOUND", "Sensör bulunamadı", nil)
+		return
+	}
+	h.db.Exec("UPDATE climate_readings SET sensor_id = NULL WHERE sensor_id = ? AND user_id = ?", sensorID, userID)
+
+	utils.SuccessResponse(c, nil, "Sensör başarıyla kaldırıldı")
+}
+
+// CreateClimateReading elle iklim ölçümü girişi
+// @Summary İklim ölçümü gir
+// @Description Sera için sıcaklık ve/veya nem ölçümü kaydeder; hedef aralık dışındaki değerler için uyarı ve bildirim oluşturulur
+// @ID createClimateReading
+// @Tags Greenhouses
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Arazi ID"
+// @Param request body models.ClimateReadingRequest true "Ölçüm"
+// @Success 201 {object} models.APIResponse{data=models.ClimateReadingResult}
+// @Failure 400 {object} models.APIResponse
+// @Failure 401 {object} models.APIResponse
+// @Failure 404 {object} models.APIResponse
+// @Router /greenhouses/{id}/readings [post]
+func (h *GreenhouseHandler) CreateClimateReading(c *gin.Context) {
+	userID, err := utils.GetUserID(c)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusUnauthorized, "UNAUTHORIZED", "Kullanıcı kimliği doğrulanamadı", nil)
+		return
+	}
+
+	var req models.ClimateReadingRequest
+	if !bindClimateReading(c, &req) {
+		return
+	}
+
+	greenhouse, ok := h.loadGreenhouse(c, c.Param("id"), userID)
+	if !ok {
+		return
+	}
+
+	result, err := h.recordClimateReading(userID, greenhouse, nil, req)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusInternalServerError, "DB_ERROR", "Ölçüm kaydedilemedi", err.Error())
+		return
+	}
+
+	utils.CreatedResponse(c, result, "Ölçüm başarıyla kaydedildi")
+}
+
+// ReceiveSensorReading sensörden gelen ölçüm
+// @Summary Sensör ölçümü gönder
+// @Description İklim sensörünün ölçüm gönderdiği uç nokta; kimlik doğrulama sensör eklenirken verilen X-Sensor-Token başlığıyla yapılır
+// @ID receiveSensorReading
+// @Tags Greenhouses
+// @Accept json
+// @Produce json
+// @Param X-Sensor-Token header string true "Sensör anahtarı"
+// @Param request body models.ClimateReadingRequest true "Ölçüm"
+// @Success 201 {object} models.APIResponse{data=models.ClimateReadingResult}
+// @Failure 400 {object} models.APIResponse
+// @Failure 401 {object} models.APIResponse
+// @Router /sensors/readings [post]
+func (h *GreenhouseHandler) ReceiveSensorReading(c *gin.Context) {
+	token := c.GetHeader("X-Sensor-Token")
+	if token == "" {
+		utils.ErrorResponse(c, http.StatusUnauthorized, "INVALID_SENSOR_TOKEN", "Geçersiz sensör anahtarı", nil)
+		return
+	}
+
+	var sensorID, landID, userID string
+	err := h.db.QueryRow("SELECT id, land_id, user_id FROM climate_sensors WHERE token = ?", token).Scan(&sensorID, &landID, &userID)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusUnauthorized, "INVALID_SENSOR_TOKEN", "Geçersiz sensör anahtarı", nil)
+		return
+	}
+
+	var req models.ClimateReadingRequest
+	if !bindClimateReading(c, &req) {
+		return
+	}
+
+	greenhouse, ok := h.loadGreenhouse(c, landID, userID)
+	if !ok {
+		return
+	}
+
+	result, err := h.recordClimateReading(userID, greenhouse, &sensorID, req)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusInternalServerError, "DB_ERROR", "Ölçüm kaydedilemedi", err.Error())
+		return
+	}
+
+	utils.CreatedResponse(c, result, "Ölçüm başarıyla kaydedildi")
+}
+
+// GetClimateReadings iklim ölçümleri
+// @Summary İklim ölçümleri
+// @Description Seranın tarih aralığındaki iklim ölçümlerini en yeniden eskiye listeler
+// @ID getClimateReadings
+// @Tags Greenhouses
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Arazi ID"
+// @Param startDate query string false "Başlangıç tarihi (YYYY-MM-DD, varsayılan: 30 gün önce)"
+// @Param endDate query string false "Bitiş tarihi (YYYY-MM-DD, varsayılan: bugün)"
+// @Param sensorId query string false "Sensör ID"
+// @Param limit query int false "En fazla kayıt (varsayılan 500, en çok 5000)"
+// @Success 200 {object} models.APIResponse{data=[]models.ClimateReading}
+// @Failure 400 {object} models.APIResponse
+// @Failure 401 {object} models.APIResponse
+// @Failure 404 {object} models.APIResponse
+// @Router /greenhouses/{id}/readings [get]
+func (h *GreenhouseHandler) GetClimateReadings(c *gin.Context) {
+	userID, err := utils.GetUserID(c)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusUnauthorized, "UNAUTHORIZED", "Kullanıcı kimliği doğrulanamadı", nil)
+		return
+	}
+
+	startDate, endDate, ok := weatherDateRange(c)
+	if !ok {
+		return
+	}
+	greenhouse, ok := h.loadGreenhouse(c, c.Param("id"), userID)
+	if !ok {
+		return
+	}
+
+	limit, err := strconv.Atoi(c.DefaultQuery("limit", "500"))
+	if err != nil || limit < 1 {
+		limit = 500
+	}
+	limit = min(limit, 5000)
+
+	query := climateReadingSelect + " WHERE land_id = ? AND user_id = ? AND recorded_at >= ? AND recorded_at < ?"
+	args := []interface{}{greenhouse.LandID, userID, startDate, endDate.AddDate(0, 0, 1)}
+	if sensorID := c.Query("sensorId"); sensorID != "" {
+		query += " AND sensor_id = ?"
+		args = append(args, sensorID)
+	}
+	query += " ORDER BY recorded_at DESC LIMIT ?"
+	args = append(args, limit)
+
+	rows, err := h.db.Query(query, args...)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusInternalServerError, "DB_ERROR", "Ölçümler alınamadı", err.Error())
+		return
+	}
+	defer rows.Close()
+
+	readings := []models.ClimateReading{}
+	for rows.Next() {
+		reading, err := scanClimateReading(rows)
+		if err != nil {
+			continue
+		}
+		readings = append(readings, reading)
+	}
+
+	utils.SuccessResponse(c, readings, "Ölçümler başarıyla getirildi")
+}
+
+// GetClimateAlerts iklim uyarıları
+// @Summary İklim uyarıları
+// @Description Seranın tarih aralığında hedef dışına çıkan ölçümler için oluşturulan uyarıları en yeniden eskiye listeler
+// @ID getClimateAlerts
+// @Tags Greenhouses
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Arazi ID"
+// @Param startDate query string false "Başlangıç tarihi (YYYY-MM-DD, varsayılan: 30 gün önce)"
+// @Param endDate query string false "Bitiş tarihi (YYYY-MM-DD, varsayılan: bugün)"
+// @Success 200 {object} models.APIResponse{data=[]models.ClimateAlert}
+// @Failure 400 {object} models.APIResponse
+// @Failure 401 {object} models.APIResponse
+// @Failure 404 {object} models.APIResponse
+// @Router /greenhouses/{id}/alerts [get]
+func (h *GreenhouseHandler) GetClimateAlerts(c *gin.Context) {
+	userID, err := utils.GetUserID(c)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusUnauthorized, "UNAUTHORIZED", "Kullanıcı kimliği doğrulanamadı", nil)
+		return
+	}
+
+	startDate, endDate, ok := weatherDateRange(c)
+	if !ok {
+		return
+	}
+	greenhouse, ok := h.loadGreenhouse(c, c.Param("id"), userID)
+	if !ok {
+		return
+	}
+
+	rows, err := h.db.Query(`
+		SELECT id, land_id, COALESCE(reading_id, ''), metric, value, min_value, max_value, recorded_at, created_at
+		FROM climate_alerts
+		WHERE land_id = ? AND user_id = ? AND recorded_at >= ? AND recorded_at < ?
+		ORDER BY recorded_at DESC
+	`, greenhouse.LandID, userID, startDate, endDate.AddDate(0, 0, 1))
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusInternalServerError, "DB_ERROR", "Uyarılar alınamadı", err.Error())
+		return
+	}
+	defer rows.Close()
+
+	alerts := []models.ClimateAlert{}
+	for rows.Next() {
+		var alert models.ClimateAlert
+		var minValue, maxValue sql.NullFloat64
+		err := rows.Scan(&alert.ID, &alert.LandID, &alert.ReadingID, &alert.Metric, &alert.Value,
+			&minValue, &maxValue, &alert.RecordedAt, &alert.CreatedAt)
+		if err != nil {
+			continue
+		}
+		alert.Min = utils.NullFloat64ToPtr(minValue)
+		alert.Max = utils.NullFloat64ToPtr(maxValue)
+		alerts = append(alerts, alert)
+	}
+
+	utils.SuccessResponse(c, alerts, "Uyarılar başarıyla getirildi")
+}
+
+// GetGreenhouseProduction sera üretimi
+// @Summary Sera üretimi
+// @Description Seraya bağlı üretim kayıtlarını ve ürün bazında toplam, satılan, kaybedilen miktar ile alan birimi başına verimi getirir
+// @ID getGreenhouseProduction
+// @Tags Greenhouses
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Arazi ID"
+// @Success 200 {object} models.APIResponse{data=models.GreenhouseProduction}
+// @Failure 401 {object} models.APIResponse
+// @Failure 404 {object} models.APIResponse
+// @Router /greenhouses/{id}/production [get]
+func (h *GreenhouseHandler) GetGreenhouseProduction(c *gin.Context) {
+	userID, err := utils.GetUserID(c)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusUnauthorized, "UNAUTHORIZED", "Kullanıcı kimliği doğrulanamadı", nil)
+		return
+	}
+
+	greenhouse, ok := h.loadGreenhouse(c, c.Param("id"), userID)
+	if !ok {
+		return
+	}
+
+	rows, err := h.db.Query(productionSelect+" WHERE land_id = ? AND user_id = ? ORDER BY COALESCE(harvest_date, created_at) DESC", greenhouse.LandID, userID)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusInternalServerError, "DB_ERROR", "Sera üretimi alınamadı", err.Error())
+		return
+	}
+	defer rows.Close()
+
+	result := models.GreenhouseProduction{
+		LandID:      greenhouse.LandID,
+		Area:        greenhouse.Area,
+		Unit:        greenhouse.Unit,
+		Products:    []models.GreenhouseProductionSummary{},
+		Productions: []models.Production{},
+	}
+	summaries := map[string]*models.GreenhouseProductionSummary{}
+	for rows.Next() {
+		production, err := scanProduction(rows)
+		if err != nil {
+			continue
+		}
+		result.Productions = append(result.Productions, production)
+
+		key := production.Name + "|" + production.Unit
+		if summaries[key] == nil {
+			summaries[key] = &models.GreenhouseProductionSummary{Name: production.Name, Unit: production.Unit}
+		}
+		summary := summaries[key]
+		summary.Amount += production.Amount
+		summary.SoldAmount += production.SoldAmount
+		summary.LostAmount += production.LostAmount
+		summary.Stock += production.Stock
+	}
+
+	for _, summary := range summaries {
+		summary.Amount = roundTo2(summary.Amount)
+		summary.SoldAmount = roundTo2(summary.SoldAmount)
+		summary.LostAmount = roundTo2(summary.LostAmount)
+		summary.Stock = roundTo2(summary.Stock)
+		if greenhouse.Area > 0 {
+			summary.YieldPerArea = roundTo2(summary.Amount / greenhouse.Area)
+		}
+		result.Products = append(result.Products, *summary)
+	}
+	sort.Slice(result.Products, func(i, j int) bool { return result.Products[i].Amount > result.Products[j].Amount })
+
+	utils.SuccessResponse(c, result, "Sera üretimi başarıyla getirildi")
+}
+
+// recordClimateReading ölçümü kaydeder, hedef aralık dışındaki değerler için bekleme süresi dolmuşsa uyarı ve bildirim oluşturur
+func (h *GreenhouseHandler) recordClimateReading(userID string, greenhouse models.Greenhouse, sensorID *string, req models.ClimateReadingRequest) (models.ClimateReadingResult, error) {
+	reading := models.ClimateReading{
+		ID:          utils.GenerateID(),
+		LandID:      greenhouse.LandID,
+		SensorID:    sensorID,
+		RecordedAt:  time.Now(),
+		Temperature: req.Temperature,
+		Humidity:    req.Humidity,
+		CreatedAt:   time.Now(),
+	}
+	if req.RecordedAt != nil {
+		reading.RecordedAt = *req.RecordedAt
+	}
+
+	_, err := h.db.Exec(`
+		INSERT INTO climate_readings (id, user_id, land_id, sensor_id, recorded_at, temperature, humidity, created_at)
+		VALUES (?, ?, ?, ?, ?, ?, ?, CURRENT_TIMESTAMP)
+	`, reading.ID, userID, reading.LandID, reading.SensorID, reading.RecordedAt, reading.Temperature, reading.Humidity)
+	if err != nil {
+		return models.ClimateReadingResult{}, err
+	}
+	if sensorID != nil {
+		h.db.Exec("UPDATE climate_sensors SET last_reading_at = ? WHERE id = ? AND user_id = ?", reading.RecordedAt, *sensorID, userID)
+	}
+
+	result := models.ClimateReadingResult{Reading: reading, Alerts: []models.ClimateAlert{}}
+	checks := []struct {
+		metric   string
+		value    *float64
+		min, max *float64
+	}{
+		{"temperature", reading.Temperature, greenhouse.Setpoints.MinTemperature, greenhouse.Setpoints.MaxTemperature},
+		{"humidity", reading.Humidity, greenhouse.Setpoints.MinHumidity, greenhouse.Setpoints.MaxHumidity},
+	}
+	for _, check := range checks {
+		if check.value == nil || !outOfRange(*check.value, check.min, check.max) {
+			continue
+		}
+
+		// Aynı ölçüm için bekleme süresi içinde uyarı verildiyse tekrar bildirim gönderilmez
+		var recent bool
+		h.db.QueryRow(`
+			SELECT 1 FROM climate_alerts WHERE land_id = ? AND user_id = ? AND metric = ? AND created_at > ? LIMIT 1
+		`, greenhouse.LandID, userID, check.metric, time.Now().UTC().Add(-time.Duration(greenhouse.AlertCooldownMinutes)*time.Minute).Format("2006-01-02 15:04:05")).Scan(&recent)
+		if recent {
+			continue
+		}
+
+		alert := models.ClimateAlert{
+			ID:         utils.GenerateID(),
+			LandID:     greenhouse.LandID,
+			ReadingID:  reading.ID,
+			Metric:     check.metric,
+			Value:      *check.value,
+			Min:        check.min,
+			Max:        check.max,
+			RecordedAt: reading.RecordedAt,
+			CreatedAt:  time.Now(),
+		}
+		_, err := h.db.Exec(`
+			INSERT INTO climate_alerts (id, user_id, land_id, reading_id, metric, value, min_value, max_value, recorded_at, created_at)
+			VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, CURRENT_TIMESTAMP)
+		`, alert.ID, userID, alert.LandID, alert.ReadingID, alert.Metric, alert.Value, alert.Min, alert.Max, alert.RecordedAt)
+		if err != nil {
+			return result, err
+		}
+		result.Alerts = append(result.Alerts, alert)
+
+		label := climateMetricLabels[check.metric]
+		h.notifications.Create(services.Notification{
+			UserID: userID,
+			Title:  "Sera iklimi hedef dışında",
+			Message: fmt.Sprintf("%s serasında %s %s%s ölçüldü; hedef aralık %s.", greenhouse.Name, label[0],
+				formatQuantity(*check.value), label[1], formatSetpointRange(check.min, check.max, label[1])),
+			Type:     "warning",
+			Priority: "high",
+			Topic:    models.NotificationTopicGreenhouseClimate,
+			Entity:   &models.RelatedEntity{Type: "greenhouse", ID: greenhouse.LandID, Name: greenhouse.Name},
+		})
+	}
+
+	return result, nil
+}
+
+// loadGreenhouse seranın arazi ve iklim ayarlarını getirir; bulunamazsa 404 yanıtını yazar
+func (h *GreenhouseHandler) loadGreenhouse(c *gin.Context, landID, userID string) (models.Greenhouse, bool) {
+	greenhouse, err := scanGreenhouse(h.db.QueryRow(greenhouseSelect+" WHERE g.land_id = ? AND l.user_id = ?", landID, userID))
+	if err != nil {
+		if err == sql.ErrNoRows {
+			utils.ErrorResponse(c, http.StatusNotFound, "GREENHOUSE_NOT_FOUND", "Sera bulunamadı", nil)
+		} else {
+			utils.ErrorResponse(c, http.StatusInternalServerError, "DB_ERROR", "Sera getirilemedi", err.Error())
+		}
+		return greenhouse, false
+	}
+	return greenhouse, true
+}
+
+// attachLatestReading seranın son ölçümünü ekler ve iklim durumunu belirler
+func (h *GreenhouseHandler) attachLatestReading(userID string, greenhouse *models.Greenhouse) error {
+	reading, err := scanClimateReading(h.db.QueryRow(climateReadingSelect+" WHERE land_id = ? AND user_id = ? ORDER BY recorded_at DESC LIMIT 1",
+		greenhouse.LandID, userID))
+	if err == sql.ErrNoRows {
+		greenhouse.ClimateStatus = "no_data"
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	greenhouse.LatestReading = &reading
+	greenhouse.ClimateStatus = "ok"
+	setpoints := greenhouse.Setpoints
+	if (reading.Temperature != nil && outOfRange(*reading.Temperature, setpoints.MinTemperature, setpoints.MaxTemperature)) ||
+		(reading.Humidity != nil && outOfRange(*reading.Humidity, setpoints.MinHumidity, setpoints.MaxHumidity)) {
+		greenhouse.ClimateStatus = "out_of_range"
+	}
+	return nil
+}
+
+// sensors seraya bağlı sensörleri anahtarları olmadan döner
+func (h *GreenhouseHandler) sensors(userID, landID string) ([]models.ClimateSensor, error) {
+	rows, err := h.db.Query(`
+		SELECT id, land_id, name, last_reading_at, created_at FROM climate_sensors WHERE land_id = ? AND user_id = ? ORDER BY name
+	`, landID, userID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	sensors := []models.ClimateSensor{}
+	for rows.Next() {
+		var sensor models.ClimateSensor
+		var lastReadingAt sql.NullTime
+		if err := rows.Scan(&sensor.ID, &sensor.LandID, &sensor.Name, &lastReadingAt, &sensor.CreatedAt); err != nil {
+			return nil, err
+		}
+		sensor.LastReadingAt = utils.NullTimeToPtr(lastReadingAt)
+		sensors = append(sensors, sensor)
+	}
+	return sensors, rows.Err()
+}
+
+// greenhouseSelect sera sorgularının ortak sütunları
+const greenhouseSelect = `
+	SELECT g.land_id, l.name, l.area, COALESCE(l.unit, ''), COALESCE(l.crop, ''), COALESCE(g.structure_type, ''), g.heated,
+	       g.min_temperature, g.max_temperature, g.min_humidity, g.max_humidity, g.alert_cooldown_minutes,
+	       g.created_at, g.updated_at
+	FROM greenhouses g
+	JOIN lands l ON l.id = g.land_id`
+
+// scanGreenhouse greenhouseSelect ile seçilen satırı seraya çevirir
+func scanGreenhouse(row interface{ Scan(...interface{}) error }) (models.Greenhouse, error) {
+	var greenhouse models.Greenhouse
+	var minTemperature, maxTemperature, minHumidity, maxHumidity sql.NullFloat64
+
+	err := row.Scan(&greenhouse.LandID, &greenhouse.Name, &greenhouse.Area, &greenhouse.Unit, &greenhouse.Crop,
+		&greenhouse.StructureType, &greenhouse.Heated, &minTemperature, &maxTemperature, &minHumidity, &maxHumidity,
+		&greenhouse.AlertCooldownMinutes, &greenhouse.CreatedAt, &greenhouse.UpdatedAt)
+	if err != nil {
+		return greenhouse, err
+	}
+
+	greenhouse.Setpoints = models.ClimateSetpoints{
+		MinTemperature: utils.NullFloat64ToPtr(minTemperature),
+		MaxTemperature: utils.NullFloat64ToPtr(maxTemperature),
+		MinHumidity:    utils.NullFloat64ToPtr(minHumidity),
+		MaxHumidity:    utils.NullFloat64ToPtr(maxHumidity),
+	}
+	return greenhouse, nil
+}
+
+// climateReadingSelect iklim ölçümü sorgularının ortak sütunları
+const climateReadingSelect = `
+	SELECT id, land_id, sensor_id, recorded_at, temperature, humidity, created_at FROM climate_readings`
+
+// scanClimateReading climateReadingSelect ile seçilen satırı ölçüme çevirir
+func scanClimateReading(row interface{ Scan(...interface{}) error }) (models.ClimateReading, error) {
+	var reading models.ClimateReading
+	var sensorID sql.NullString
+	var temperature, humidity sql.NullFloat64
+
+	err := row.Scan(&reading.ID, &reading.LandID, &sensorID, &reading.RecordedAt, &temperature, &humidity, &reading.CreatedAt)
+	if err != nil {
+		return reading, err
+	}
+
+	if sensorID.Valid {
+		reading.SensorID = &sensorID.String
+	}
+	reading.Temperature = utils.NullFloat64ToPtr(temperature)
+	reading.Humidity = utils.NullFloat64ToPtr(humidity)
+	return reading, nil
+}
+
+// bindClimateReading ölçüm isteğini okur; sıcaklık ve nemden biri yoksa 400 yanıtını yazar
+func bindClimateReading(c *gin.Context, req *models.ClimateReadingRequest) bool {
+	if err := c.ShouldBindJSON(req); err != nil {
+		utils.ErrorResponse(c, http.StatusBadRequest, "INVALID_REQUEST", "Geçersiz istek formatı", err.Error())
+		return false
+	}
+	if req.Temperature == nil && req.Humidity == nil {
+		utils.ErrorResponse(c, http.StatusBadRequest, "MISSING_FIELDS", "Sıcaklık veya nem değeri gerekli", nil)
+		return false
+	}
+	return true
+}
+
+// validateClimateSetpoints hedef aralıkları denetler; hata yoksa boş döner
+func validateClimateSetpoints(setpoints models.ClimateSetpoints) string {
+	if setpoints.MinTemperature != nil && setpoints.MaxTemperature != nil && *setpoints.MinTemperature >= *setpoints.MaxTemperature {
+		return "En düşük sıcaklık en yüksek sıcaklıktan küçük olmalı"
+	}
+	for _, humidity := range []*float64{setpoints.MinHumidity, setpoints.MaxHumidity} {
+		if humidity != nil && (*humidity < 0 || *humidity > 100) {
+			return "Nem hedefi 0-100 arasında olmalı"
+		}
+	}
+	if setpoints.MinHumidity != nil && setpoints.MaxHumidity != nil && *setpoints.MinHumidity >= *setpoints.MaxHumidity {
+		return "En düşük nem en yüksek nemden küçük olmalı"
+	}
+	return ""
+}
+
+// outOfRange değer tanımlı sınırların dışında mı
+func outOfRange(value float64, minValue, maxValue *float64) bool {
+	return (minValue != nil && value < *minValue) || (maxValue != nil && value > *maxValue)
+}
+
+// formatSetpointRange hedef aralığı bildirim metni için yazar
+func formatSetpointRange(minValue, maxValue *float64, unit string) string {
+	switch {
+	case minValue != nil && maxValue != nil:
+		return formatQuantity(*minValue) + "-" + formatQuantity(*maxValue) + unit
+	case minValue != nil:
+		return "en az " + formatQuantity(*minValue) + unit
+	default:
+		return "en çok " + formatQuantity(*maxValue) + unit
+	}
+}
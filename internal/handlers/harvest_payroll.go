@@ -0,0 +1,240 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+	"strings"
+
+	"agri-management-api/internal/models"
+	"agri-management-api/internal/services"
+	"agri-management-api/internal/utils"
+
+	"github.com/gin-gonic/gin"
+)
+
+// GetHarvestCrew hasat ekibi
+// @Summary Hasat ekibi
+// @Description Hasat aktivitesinde (type: harvest, harvesting, hasat) ekip üyelerinin topladığı miktarları, parça başı ücretleri ve tutarları ödenen/ödenmemiş toplamlarla getirir
+// @ID getHarvestCrew
+// @Tags Lands
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Arazi ID"
+// @Param activityId path string true "Aktivite ID"
+// @Success 200 {object} models.APIResponse{data=models.HarvestCrew}
+// @Failure 400 {object} models.APIResponse
+// @Failure 401 {object} models.APIResponse
+// @Failure 404 {object} models.APIResponse
+// @Router /lands/{id}/activities/{activityId}/crew [get]
+func (h *LandHandler) GetHarvestCrew(c *gin.Context) {
+	userID, err := utils.GetUserID(c)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusUnauthorized, "UNAUTHORIZED", "Kullanıcı kimliği doğrulanamadı", nil)
+		return
+	}
+
+	activityID, ok := h.landActivityParam(c, userID)
+	if !ok {
+		return
+	}
+
+	crew, err := h.payroll.Crew(userID, activityID)
+	if err != nil {
+		writeHarvestPayrollError(c, err)
+		return
+	}
+
+	utils.SuccessResponse(c, crew, "Hasat ekibi başarıyla getirildi")
+}
+
+// UpdateHarvestCrew hasat ekibini güncelleme
+// @Summary Hasat ekibini güncelleme
+// @Description Hasat aktivitesinin ekip kayıtlarını verilen listeyle değiştirir; tutar miktar ile parça başı ücretin çarpımıdır (birim verilmezse kg). Her işçi için parça başı işçilik maliyet kalemi yazılır ve aktivitenin maliyeti kalemlerin toplamı olur. Ödemesi kaydedilmiş kayıt varsa liste değiştirilemez
+// @ID updateHarvestCrew
+// @Tags Lands
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Arazi ID"
+// @Param activityId path string true "Aktivite ID"
+// @Param request body models.HarvestCrewRequest true "Ekip kayıtları"
+// @Success 200 {object} models.APIResponse{data=models.HarvestCrew}
+// @Failure 400 {object} models.APIResponse
+// @Failure 401 {object} models.APIResponse
+// @Failure 404 {object} models.APIResponse
+// @Failure 409 {object} models.APIResponse
+// @Router /lands/{id}/activities/{activityId}/crew [put]
+func (h *LandHandler) UpdateHarvestCrew(c *gin.Context) {
+	userID, err := utils.GetUserID(c)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusUnauthorized, "UNAUTHORIZED", "Kullanıcı kimliği doğrulanamadı", nil)
+		return
+	}
+
+	activityID, ok := h.landActivityParam(c, userID)
+	if !ok {
+		return
+	}
+
+	var req models.HarvestCrewRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		utils.ErrorResponse(c, http.StatusBadRequest, "INVALID_REQUEST", "Geçersiz istek formatı", err.Error())
+		return
+	}
+	for _, entry := range req.Entries {
+		if strings.TrimSpace(entry.WorkerName) == "" {
+			utils.ErrorResponse(c, http.StatusBadRequest, "VALIDATION_ERROR", "İşçi adı boş olamaz", nil)
+			return
+		}
+	}
+
+	crew, err := h.payroll.Replace(userID, activityID, req.Entries)
+	if err != nil {
+		writeHarvestPayrollError(c, err)
+		return
+	}
+
+	utils.SuccessResponse(c, crew, "Hasat ekibi başarıyla güncellendi")
+}
+
+// PostHarvestPayroll hasat ödemelerini kaydetme
+// @Summary Hasat ödemelerini kaydetme
+// @Description Ödemesi kaydedilmemiş ekip kayıtları için işçi başına birer "İşçilik" gider işlemi oluşturur ve kayıtları işlemlere bağlar. Tarih verilmezse aktivitenin gerçekleşme tarihi, o da yoksa bugün kullanılır; tutarı sıfır olan kayıtlar atlanır
+// @ID postHarvestPayroll
+// @Tags Lands
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Arazi ID"
+// @Param activityId path string true "Aktivite ID"
+// @Param request body models.HarvestPayrollPostRequest false "Ödeme tarihi ve yöntemi"
+// @Success 201 {object} models.APIResponse{data=models.HarvestPayrollPosting}
+// @Failure 400 {object} models.APIResponse
+// @Failure 401 {object} models.APIResponse
+// @Failure 404 {object} models.APIResponse
+// @Router /lands/{id}/activities/{activityId}/crew/payroll [post]
+func (h *LandHandler) PostHarvestPayroll(c *gin.Context) {
+	userID, err := utils.GetUserID(c)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusUnauthorized, "UNAUTHORIZED", "Kullanıcı kimliği doğrulanamadı", nil)
+		return
+	}
+
+	activityID, ok := h.landActivityParam(c, userID)
+	if !ok {
+		return
+	}
+
+	var req models.HarvestPayrollPostRequest
+	if c.Request.ContentLength > 0 {
+		if err := c.ShouldBindJSON(&req); err != nil {
+			utils.ErrorResponse(c, http.StatusBadRequest, "INVALID_REQUEST", "Geçersiz istek formatı", err.Error())
+			return
+		}
+	}
+
+	result, err := h.payroll.PostPayroll(userID, activityID, req)
+	if err != nil {
+		writeHarvestPayrollError(c, err)
+		return
+	}
+
+	utils.CreatedResponse(c, result, "Hasat ödemeleri başarıyla kaydedildi")
+}
+
+// GetHarvestPayroll hasat ödeme özeti
+// @Summary Hasat ödeme özeti
+// @Description Tarihi (gerçekleşme, planlanan veya kayıt) aralıktaki hasat aktivitelerinin ekip kayıtlarını işçi ve birim bazında miktar, tutar, ödenen ve ödenmemiş tutarlarla özetler
+// @ID getHarvestPayroll
+// @Tags Lands
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param startDate query string false "Başlangıç tarihi (YYYY-MM-DD)"
+// @Param endDate query string false "Bitiş tarihi (YYYY-MM-DD, dahil)"
+// @Success 200 {object} models.APIResponse{data=models.HarvestPayrollSummary}
+// @Failure 400 {object} models.APIResponse
+// @Failure 401 {object} models.APIResponse
+// @Router /lands/harvest-payroll [get]
+func (h *LandHandler) GetHarvestPayroll(c *gin.Context) {
+	userID, err := utils.GetUserID(c)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusUnauthorized, "UNAUTHORIZED", "Kullanıcı kimliği doğrulanamadı", nil)
+		return
+	}
+
+	startDate, endDate, ok := dateRangeQuery(c)
+	if !ok {
+		return
+	}
+
+	summary, err := h.payroll.Summary(userID, startDate, endDate)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusInternalServerError, "DB_ERROR", "Hasat ödeme özeti alınamadı", err.Error())
+		return
+	}
+
+	utils.SuccessResponse(c, summary, "Hasat ödeme özeti başarıyla getirildi")
+}
+
+// ExportHarvestPayroll hasat ödeme özeti dışa aktarımı
+// @Summary Hasat ödeme özetini dışa aktar
+// @Description Hasat ödeme özetini işçi bazında satırlar ve toplam satırıyla CSV (noktalı virgülle ayrılmış, UTF-8 BOM) veya Excel (XLSX) dosyası olarak indirir
+// @ID exportHarvestPayroll
+// @Tags Lands
+// @Accept json
+// @Produce text/csv
+// @Produce application/vnd.openxmlformats-officedocument.spreadsheetml.sheet
+// @Security BearerAuth
+// @Param format query string false "Dosya formatı (csv, xlsx)" default(csv)
+// @Param startDate query string false "Başlangıç tarihi (YYYY-MM-DD)"
+// @Param endDate query string false "Bitiş tarihi (YYYY-MM-DD, dahil)"
+// @Success 200 {file} file
+// @Failure 400 {object} models.APIResponse
+// @Failure 401 {object} models.APIResponse
+// @Router /lands/harvest-payroll/export [get]
+func (h *LandHandler) ExportHarvestPayroll(c *gin.Context) {
+	userID, err := utils.GetUserID(c)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusUnauthorized, "UNAUTHORIZED", "Kullanıcı kimliği doğrulanamadı", nil)
+		return
+	}
+
+	format := strings.ToLower(c.DefaultQuery("format", services.CalendarExportCSV))
+	if format != services.CalendarExportCSV && format != services.CalendarExportXLSX {
+		utils.ErrorResponse(c, http.StatusBadRequest, "INVALID_FORMAT", "Geçersiz dosya formatı", []string{services.CalendarExportCSV, services.CalendarExportXLSX})
+		return
+	}
+
+	startDate, endDate, ok := dateRangeQuery(c)
+	if !ok {
+		return
+	}
+
+	summary, err := h.payroll.Summary(userID, startDate, endDate)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusInternalServerError, "DB_ERROR", "Hasat ödeme özeti alınamadı", err.Error())
+		return
+	}
+	formatter, ok := exportFormatter(c, h.formats, userID, format)
+	if !ok {
+		return
+	}
+
+	writeCalendarExport(c, format, "hasat-odemeleri", "Hasat Ödemeleri", services.PayrollRecords(summary, formatter))
+}
+
+// writeHarvestPayrollError hasat ekibi hatasını uygun HTTP yanıtına çevirir
+func writeHarvestPayrollError(c *gin.Context, err error) {
+	switch {
+	case errors.Is(err, services.ErrNotHarvestActivity):
+		utils.ErrorResponse(c, http.StatusBadRequest, "NOT_HARVEST_ACTIVITY", "Ekip kayıtları yalnızca hasat aktivitelerine eklenebilir", nil)
+	case errors.Is(err, services.ErrHarvestCrewPaid):
+		utils.ErrorResponse(c, http.StatusConflict, "CREW_ALREADY_PAID", "Ödemesi kaydedilmiş ekip kayıtları değiştirilemez", nil)
+	case errors.Is(err, services.ErrLandActivityNotFound):
+		utils.ErrorResponse(c, http.StatusNotFound, "ACTIVITY_NOT_FOUND", "Arazi aktivitesi bulunamadı", nil)
+	default:
+		utils.ErrorResponse(c, http.StatusInternalServerError, "DB_ERROR", "Hasat ekibi işlenemedi", err.Error())
+	}
+}
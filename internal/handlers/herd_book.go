@@ -0,0 +1,247 @@
+package handlers
+
+import (
+	"bytes"
+	"database/sql"
+	"errors"
+	"net/http"
+	"strings"
+	"time"
+
+	"agri-management-api/internal/models"
+	"agri-management-api/internal/services"
+	"agri-management-api/internal/utils"
+
+	"github.com/gin-gonic/gin"
+)
+
+// HerdBookHandler yetiştirici birliği şablonlarını ve soy kütüğü/tescil belgelerini yönetir
+type HerdBookHandler struct {
+	herdBooks  *services.HerdBookService
+	formats    *services.FormattingService
+	signatures *services.DocumentSigningService
+}
+
+// NewHerdBookHandler yeni herd book handler oluşturur
+func NewHerdBookHandler(db *sql.DB) *HerdBookHandler {
+	return &HerdBookHandler{
+		herdBooks:  services.NewHerdBookService(db),
+		formats:    services.NewFormattingService(db),
+		signatures: services.NewDocumentSigningService(db),
+	}
+}
+
+// GetHerdBookTemplates kullanılabilir soy kütüğü şablonları
+// @Summary Soy kütüğü şablonları
+// @Description Yöneticinin tanımladığı ve kullanıma açık birlik şablonlarını listeler. species verilirse o türe ve tüm türlere açık şablonlar döner
+// @ID getHerdBookTemplates
+// @Tags Livestock
+// @Produce json
+// @Security BearerAuth
+// @Param species query string false "Hayvan türü (ör. cattle)"
+// @Success 200 {object} models.APIResponse{data=[]models.HerdBookTemplate}
+// @Failure 401 {object} models.APIResponse
+// @Router /livestock/herd-book/templates [get]
+func (h *HerdBookHandler) GetHerdBookTemplates(c *gin.Context) {
+	templates, err := h.herdBooks.Templates(true, strings.TrimSpace(c.Query("species")))
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusInternalServerError, "DB_ERROR", "Şablonlar alınamadı", err.Error())
+		return
+	}
+
+	utils.SuccessResponse(c, templates, "Şablonlar başarıyla getirildi")
+}
+
+// GenerateHerdBook soy kütüğü belgesi oluşturma
+// @Summary Soy kütüğü belgesi oluştur
+// @Description Seçilen hayvanlar için birlik şablonundaki alanlarla ve şablonun kuşak sayısı kadar soy kaydıyla tescil belgesini indirir. PDF'de her hayvan ayrı sayfadadır; CSV'de her hayvan bir satırdır ve atalar S (baba) ile D (anne) kodlarıyla sütunlara yazılır (ör. SD babanın annesi). Atalar hayvanın anne/baba küpe numaralarından sürüdeki kayıtlar izlenerek bulunur. Tarih ve sayılar çiftlik ayarlarındaki biçimlerle yazılır. Şablon bir türe ayrılmışsa tüm hayvanlar o türde olmalıdır. Belge sunucu anahtarıyla imzalanır; ayrık imza X-Document-Signature başlığıyla döner
+// @ID generateHerdBook
+// @Tags Livestock
+// @Accept json
+// @Produce application/pdf,text/csv
+// @Security BearerAuth
+// @Param request body models.HerdBookRequest true "Şablon ve hayvanlar"
+// @Success 200 {file} file
+// @Failure 400 {object} models.APIResponse
+// @Failure 401 {object} models.APIResponse
+// @Failure 404 {object} models.APIResponse
+// @Failure 422 {object} models.APIResponse
+// @Router /livestock/herd-book [post]
+func (h *HerdBookHandler) GenerateHerdBook(c *gin.Context) {
+	userID, err := utils.GetUserID(c)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusUnauthorized, "UNAUTHORIZED", "Kullanıcı kimliği doğrulanamadı", nil)
+		return
+	}
+
+	var req models.HerdBookRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		utils.ErrorResponse(c, http.StatusBadRequest, "INVALID_REQUEST", "Geçersiz istek formatı", err.Error())
+		return
+	}
+
+	template, err := h.herdBooks.Template(req.TemplateID)
+	if err == nil && !template.Active {
+		err = services.ErrHerdBookTemplateNotFound
+	}
+	if err != nil {
+		writeHerdBookError(c, err, nil, "Şablon alınamadı")
+		return
+	}
+
+	formatter, ok := exportFormatter(c, h.formats, userID, template.Format)
+	if !ok {
+		return
+	}
+	entries, details, err := h.herdBooks.Entries(userID, template, req, formatter)
+	if err != nil {
+		writeHerdBookError(c, err, details, "Soy kayıtları alınamadı")
+		return
+	}
+
+	now := time.Now()
+	var buf bytes.Buffer
+	contentType := "application/pdf"
+	if template.Format == services.HerdBookFormatCSV {
+		contentType = "text/csv; charset=utf-8"
+		err = services.WriteHerdBookCSV(&buf, template, entries, formatter)
+	} else {
+		err = services.WriteHerdBookPDF(&buf, template, entries, formatter, now)
+	}
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusInternalServerError, "EXPORT_ERROR", "Belge oluşturulamadı", err.Error())
+		return
+	}
+
+	filename := "soy-kutugu-" + now.Format("20060102") + "." + template.Format
+	if len(entries) == 1 {
+		filename = "soy-kutugu-" + safeFilename(entries[0].TagNumber) + "." + template.Format
+	}
+	if !signExport(c, h.signatures, userID, models.DocumentTypeHerdBook, template.ID, filename, contentType, buf.Bytes()) {
+		return
+	}
+	c.Header("Content-Disposition", "attachment; filename="+filename)
+	c.Data(http.StatusOK, contentType, buf.Bytes())
+}
+
+// GetAdminHerdBookTemplates tüm soy kütüğü şablonları
+// @Summary Soy kütüğü şablonları (yönetici)
+// @Description Kullanıma kapalı olanlar dahil tüm birlik şablonlarını listeler. Yönetici rolü gerektirir
+// @ID getAdminHerdBookTemplates
+// @Tags Admin
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {object} models.APIResponse{data=[]models.HerdBookTemplate}
+// @Failure 401 {object} models.APIResponse
+// @Failure 403 {object} models.APIResponse
+// @Router /admin/herd-book-templates [get]
+func (h *HerdBookHandler) GetAdminHerdBookTemplates(c *gin.Context) {
+	templates, err := h.herdBooks.Templates(false, "")
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusInternalServerError, "DB_ERROR", "Şablonlar alınamadı", err.Error())
+		return
+	}
+
+	utils.SuccessResponse(c, templates, "Şablonlar başarıyla getirildi")
+}
+
+// CreateHerdBookTemplate soy kütüğü şablonu ekleme
+// @Summary Soy kütüğü şablonu ekle
+// @Description Yetiştirici birliğinin belge şablonunu ekler. fields belgede gösterilecek alanları sırasıyla ve birliğin alan adlarıyla (label) verir; verilmezse küpe no, ırk, cinsiyet, doğum tarihi, baba, anne, yetiştirici ve işletme no gösterilir. generations soy kütüğünün kaç kuşak geriye gideceğidir (1-4, varsayılan 3). Yönetici rolü gerektirir
+// @ID createHerdBookTemplate
+// @Tags Admin
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param request body models.HerdBookTemplateRequest true "Şablon"
+// @Success 201 {object} models.APIResponse{data=models.HerdBookTemplate}
+// @Failure 400 {object} models.APIResponse
+// @Failure 401 {object} models.APIResponse
+// @Failure 403 {object} models.APIResponse
+// @Router /admin/herd-book-templates [post]
+func (h *HerdBookHandler) CreateHerdBookTemplate(c *gin.Context) {
+	var req models.HerdBookTemplateRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		utils.ErrorResponse(c, http.StatusBadRequest, "INVALID_REQUEST", "Geçersiz istek formatı", err.Error())
+		return
+	}
+
+	template, err := h.herdBooks.CreateTemplate(req)
+	if err != nil {
+		writeHerdBookError(c, err, nil, "Şablon eklenemedi")
+		return
+	}
+
+	utils.CreatedResponse(c, template, "Şablon başarıyla eklendi")
+}
+
+// UpdateHerdBookTemplate soy kütüğü şablonu güncelleme
+// @Summary Soy kütüğü şablonunu güncelle
+// @Description Şablonu günceller; active=false şablonu çiftliklerin kullanımına kapatır. Yönetici rolü gerektirir
+// @ID updateHerdBookTemplate
+// @Tags Admin
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Şablon ID"
+// @Param request body models.HerdBookTemplateRequest true "Şablon"
+// @Success 200 {object} models.APIResponse{data=models.HerdBookTemplate}
+// @Failure 400 {object} models.APIResponse
+// @Failure 401 {object} models.APIResponse
+// @Failure 403 {object} models.APIResponse
+// @Failure 404 {object} models.APIResponse
+// @Router /admin/herd-book-templates/{id} [put]
+func (h *HerdBookHandler) UpdateHerdBookTemplate(c *gin.Context) {
+	var req models.HerdBookTemplateRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		utils.ErrorResponse(c, http.StatusBadRequest, "INVALID_REQUEST", "Geçersiz istek formatı", err.Error())
+		return
+	}
+
+	template, err := h.herdBooks.UpdateTemplate(c.Param("id"), req)
+	if err != nil {
+		writeHerdBookError(c, err, nil, "Şablon güncellenemedi")
+		return
+	}
+
+	utils.SuccessResponse(c, template, "Şablon başarıyla güncellendi")
+}
+
+// DeleteHerdBookTemplate soy kütüğü şablonu silme
+// @Summary Soy kütüğü şablonunu sil
+// @Description Şablonu siler. Yönetici rolü gerektirir
+// @ID deleteHerdBookTemplate
+// @Tags Admin
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Şablon ID"
+// @Success 200 {object} models.APIResponse
+// @Failure 401 {object} models.APIResponse
+// @Failure 403 {object} models.APIResponse
+// @Failure 404 {object} models.APIResponse
+// @Router /admin/herd-book-templates/{id} [delete]
+func (h *HerdBookHandler) DeleteHerdBookTemplate(c *gin.Context) {
+	if err := h.herdBooks.DeleteTemplate(c.Param("id")); err != nil {
+		writeHerdBookError(c, err, nil, "Şablon silinemedi")
+		return
+	}
+
+	utils.SuccessResponse(c, nil, "Şablon başarıyla silindi")
+}
+
+// writeHerdBookError servis hatasını HTTP yanıtına çevirir; details bulunamayan hayvan kimlikleri veya türü
+// uymayan küpe numaralarıdır
+func writeHerdBookError(c *gin.Context, err error, details []string, message string) {
+	switch {
+	case errors.Is(err, services.ErrHerdBookTemplateNotFound):
+		utils.ErrorResponse(c, http.StatusNotFound, "TEMPLATE_NOT_FOUND", "Şablon bulunamadı", nil)
+	case errors.Is(err, services.ErrHerdBookAnimalNotFound):
+		utils.ErrorResponse(c, http.StatusNotFound, "ANIMAL_NOT_FOUND", "Hayvan bulunamadı", details)
+	case errors.Is(err, services.ErrHerdBookSpecies):
+		utils.ErrorResponse(c, http.StatusUnprocessableEntity, "SPECIES_MISMATCH", err.Error(), details)
+	case errors.Is(err, services.ErrUnknownSpecies):
+		utils.ErrorResponse(c, http.StatusBadRequest, "INVALID_SPECIES", "Tanımsız hayvan türü", nil)
+	default:
+		utils.ErrorResponse(c, http.StatusInternalServerError, "DB_ERROR", message, err.Error())
+	}
+}
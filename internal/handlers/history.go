@@ -0,0 +1,35 @@
+package handlers
+
+import (
+	"log"
+	"net/http"
+
+	"agri-management-api/internal/models"
+	"agri-management-api/internal/services"
+	"agri-management-api/internal/utils"
+
+	"github.com/gin-gonic/gin"
+)
+
+// writeEntityHistory çiftliğe ait varlığın sayfalı değişiklik geçmişini yanıt olarak yazar
+func writeEntityHistory(c *gin.Context, history *services.ChangeHistoryService, farmID, entityType, entityID string) {
+	page, limit := utils.ParsePagination(c)
+
+	changes, total, err := history.History(farmID, entityType, entityID, c.Query("field"), page, limit)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusInternalServerError, "DB_ERROR", "Değişiklik geçmişi alınamadı", err.Error())
+		return
+	}
+
+	utils.SuccessResponse(c, models.EntityHistory{
+		Changes:    changes,
+		Pagination: utils.CalculatePagination(page, limit, total),
+	}, "Değişiklik geçmişi başarıyla getirildi")
+}
+
+// removeEntityHistory silinen varlığın değişiklik geçmişini siler; hata silme işlemini geri almaz
+func removeEntityHistory(history *services.ChangeHistoryService, farmID, entityType, entityID string) {
+	if err := history.RemoveEntity(farmID, entityType, entityID); err != nil {
+		log.Printf("Değişiklik geçmişi silinemedi (%s %s): %v", entityType, entityID, err)
+	}
+}
@@ -0,0 +1,1012 @@
+package handlers
+
+import (
+	"database/sql"
+	"fmt"
+	"log"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"agri-management-api/internal/models"
+	"agri-management-api/internal/services"
+	"agri-management-api/internal/utils"
+
+	"github.com/gin-gonic/gin"
+)
+
+// hiveTreatmentReminderDays tedavi tarihinden kaç gün önce hatırlatma gönderileceği
+const hiveTreatmentReminderDays = 7
+
+// hiveTreatmentSeasons varroa tedavisinin yapıldığı mevsimlerin başlangıcı (ay, gün): ilkbahar ve hasat sonrası
+var hiveTreatmentSeasons = [][2]int{{3, 1}, {8, 15}}
+
+// hiveTreatmentTargetLabels hatırlatma mesajlarında kullanılan tedavi hedefi adları
+var hiveTreatmentTargetLabels = map[string]string{
+	"varroa":    "varroa",
+	"nosema":    "nosema",
+	"foulbrood": "yavru çürüklüğü",
+	"wax_moth":  "mum güvesi",
+	"other":     "diğer",
+}
+
+// HiveHandler arı kovanlarını, muayeneleri, bal hasadını ve tedavileri yönetir
+type HiveHandler struct {
+	db            *sql.DB
+	notifications *services.NotificationService
+}
+
+// NewHiveHandler yeni hive handler oluşturur
+func NewHiveHandler(db *sql.DB) *HiveHandler {
+	return &HiveHandler{
+		db:            db,
+		notifications: services.NewNotificationService(db),
+	}
+}
+
+// GetHives kovan listesi
+// @Summary Kovan listesi
+// @Description Arı kovanlarını son muayene, bu yılki bal hasadı ve sıradaki tedavi tarihiyle listeler
+// @ID getHives
+// @Tags Beekeeping
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param status query string false "Kovan durumu (active, dead, sold, merged)"
+// @Param apiary query string false "Arılık adı"
+// @Success 200 {object} models.APIResponse{data=[]models.Hive}
+// @Failure 401 {object} models.APIResponse
+// @Router /hives [get]
+func (h *HiveHandler) GetHives(c *gin.Context) {
+	userID, err := utils.GetUserID(c)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusUnauthorized, "UNAUTHORIZED", "Kullanıcı kimliği doğrulanamadı", nil)
+		return
+	}
+
+	query := hiveSelect + " WHERE user_id = ?"
+	args := []interface{}{userID}
+	if status := c.Query("status"); status != "" {
+		query += " AND status = ?"
+		args = append(args, status)
+	}
+	if apiary := c.Query("apiary"); apiary != "" {
+		query += " AND apiary = ?"
+		args = append(args, apiary)
+	}
+	query += " ORDER BY apiary, name"
+
+	rows, err := h.db.Query(query, args...)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusInternalServerError, "DB_ERROR", "Kovanlar alınamadı", err.Error())
+		return
+	}
+
+	hives := []models.Hive{}
+	for rows.Next() {
+		hive, err := scanHive(rows)
+		if err != nil {
+			continue
+		}
+		hives = append(hives, hive)
+	}
+	rows.Close()
+
+	for i := range hives {
+		if err := h.attachHiveSummary(&hives[i]); err != nil {
+			utils.ErrorResponse(c, http.StatusInternalServerError, "DB_ERROR", "Kovan özetleri alınamadı", err.Error())
+			return
+		}
+	}
+
+	utils.SuccessResponse(c, hives, "Kovanlar başarıyla getirildi")
+}
+
+// CreateHive yeni kovan
+// @Summary Yeni kovan
+// @Description Arılığa yeni kovan ekler; landId verilirse kovan araziye bağlanır
+// @ID createHive
+// @Tags Beekeeping
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param request body models.HiveRequest true "Kovan bilgileri"
+// @Success 201 {object} models.APIResponse{data=models.Hive}
+// @Failure 400 {object} models.APIResponse
+// @Failure 401 {object} models.APIResponse
+// @Failure 404 {object} models.APIResponse
+// @Router /hives [post]
+func (h *HiveHandler) CreateHive(c *gin.Context) {
+	userID, err := utils.GetUserID(c)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusUnauthorized, "UNAUTHORIZED", "Kullanıcı kimliği doğrulanamadı", nil)
+		return
+	}
+
+	var req models.HiveRequest
+	if !h.bindHiveRequest(c, userID, &req) {
+		return
+	}
+
+	hiveID := utils.GenerateID()
+	_, err = h.db.Exec(`
+		INSERT INTO hives (id, user_id, name, apiary, land_id, hive_type, queen_year, status, notes, created_at, updated_at)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, CURRENT_TIMESTAMP, CURRENT_TIMESTAMP)
+	`, hiveID, userID, req.Name, req.Apiary, req.LandID, req.HiveType, req.QueenYear, req.Status, req.Notes)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusInternalServerError, "DB_ERROR", "Kovan oluşturulamadı", err.Error())
+		return
+	}
+
+	hive, err := h.getHive(hiveID, userID)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusInternalServerError, "DB_ERROR", "Oluşturulan kovan alınamadı", err.Error())
+		return
+	}
+
+	utils.CreatedResponse(c, hive, "Kovan başarıyla oluşturuldu")
+}
+
+// GetHive kovan detayları
+// @Summary Kovan detayları
+// @Description Kovanı son muayene, bu yılki bal hasadı ve sıradaki tedavi tarihiyle getirir
+// @ID getHive
+// @Tags Beekeeping
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Kovan ID"
+// @Success 200 {object} models.APIResponse{data=models.Hive}
+// @Failure 401 {object} models.APIResponse
+// @Failure 404 {object} models.APIResponse
+// @Router /hives/{id} [get]
+func (h *HiveHandler) GetHive(c *gin.Context) {
+	userID, err := utils.GetUserID(c)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusUnauthorized, "UNAUTHORIZED", "Kullanıcı kimliği doğrulanamadı", nil)
+		return
+	}
+
+	hive, err := h.getHive(c.Param("id"), userID)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusNotFound, "HIVE_NOT_FOUND", "Kovan bulunamadı", nil)
+		return
+	}
+
+	links := services.EntityLinks("hive", hive.ID, services.RelatedLink{Rel: "land", EntityType: "land", ID: hive.LandID})
+	utils.DetailResponse(c, hive, links, "Kovan detayları başarıyla getirildi")
+}
+
+// UpdateHive kovan güncelleme
+// @Summary Kovan güncelle
+// @Description Kovan bilgilerini ve durumunu günceller; aktif olmayan kovanlar için tedavi hatırlatması gönderilmez
+// @ID updateHive
+// @Tags Beekeeping
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Kovan ID"
+// @Param request body models.HiveRequest true "Kovan bilgileri"
+// @Success 200 {object} models.APIResponse{data=models.Hive}
+// @Failure 400 {object} models.APIResponse
+// @Failure 401 {object} models.APIResponse
+// @Failure 404 {object} models.APIResponse
+// @Router /hives/{id} [put]
+func (h *HiveHandler) UpdateHive(c *gin.Context) {
+	userID, err := utils.GetUserID(c)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusUnauthorized, "UNAUTHORIZED", "Kullanıcı kimliği doğrulanamadı", nil)
+		return
+	}
+
+	var req models.HiveRequest
+	if !h.bindHiveRequest(c, userID, &req) {
+		return
+	}
+
+	hiveID := c.Param("id")
+	result, err := h.db.Exec(`
+		UPDATE hives SET name = ?, apiary = ?, land_id = ?, hive_type = ?, queen_year = ?, status = ?, notes = ?,
+		                 updated_at = CURRENT_TIMESTAMP
+		WHERE id = ? AND user_id = ?
+	`, req.Name, req.Apiary, req.LandID, req.HiveType, req.QueenYear, req.Status, req.Notes, hiveID, userID)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusInternalServerError, "UPDATE_ERROR", "Kovan güncellenemedi", err.Error())
+		return
+	}
+	if affected, _ := result.RowsAffected(); affected == 0 {
+		utils.ErrorResponse(c, http.StatusNotFound, "HIVE_NOT_FOUND", "Kovan bulunamadı", nil)
+		return
+	}
+
+	hive, err := h.getHive(hiveID, userID)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusInternalServerError, "DB_ERROR", "Güncellenen kovan alınamadı", err.Error())
+		return
+	}
+
+	utils.SuccessResponse(c, hive, "Kovan başarıyla güncellendi")
+}
+
+// DeleteHive kovan silme
+// @Summary Kovan sil
+// @Description Kovanı muayene, hasat ve tedavi kayıtlarıyla birlikte siler; hasatlardan oluşan üretim kayıtları korunur
+// @ID deleteHive
+// @Tags Beekeeping
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Kovan ID"
+// @Param force query bool false "Başka kayıtlarla bağlantısı olsa da sil"
+// @Success 200 {object} models.APIResponse
+// @Failure 401 {object} models.APIResponse
+// @Failure 404 {object} models.APIResponse
+// @Failure 409 {object} models.APIResponse
+// @Router /hives/{id} [delete]
+func (h *HiveHandler) DeleteHive(c *gin.Context) {
+	userID, err := utils.GetUserID(c)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusUnauthorized, "UNAUTHORIZED", "Kullanıcı kimliği doğrulanamadı", nil)
+		return
+	}
+
+	hiveID := c.Param("id")
+
+	if !confirmLinkedDelete(c, h.db, userID, "hive", hiveID) {
+		return
+	}
+
+	tx, err := h.db.Begin()
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusInternalServerError, "DB_ERROR", "Kovan silinemedi", err.Error())
+		return
+	}
+	defer tx.Rollback()
+
+	result, err := tx.Exec("DELETE FROM hives WHERE id = ? AND user_id = ?", hiveID, userID)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusInternalServerError, "DELETE_ERROR", "Kovan silinemedi", err.Error())
+		return
+	}
+	if affected, _ := result.RowsAffected(); affected == 0 {
+		utils.ErrorResponse(c, http.StatusNotFound, "HIVE_NOT_FOUND", "Kovan bulunamadı", nil)
+		return
+	}
+
+	for _, table := range []string{"hive_inspections", "hive_harvests", "hive_treatments"} {
+		if _, err := tx.Exec("DELETE FROM "+table+" WHERE hive_id = ? AND user_id = ?", hiveID, userID); err != nil {
+			utils.ErrorResponse(c, http.StatusInternalServerError, "DELETE_ERROR", "Kovan silinemedi", err.Error())
+			return
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		utils.ErrorResponse(c, http.StatusInternalServerError, "DB_ERROR", "Kovan silinemedi", err.Error())
+		return
+	}
+
+	removeRecordLinks(h.db, userID, "hive", hiveID)
+
+	utils.SuccessResponse(c, nil, "Kovan başarıyla silindi")
+}
+
+// GetHiveInspections kovan muayeneleri
+// @Summary Kovan muayeneleri
+// @Description Kovanın muayene kayıtlarını en yeniden eskiye listeler
+// @ID getHiveInspections
+// @Tags Beekeeping
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Kovan ID"
+// @Success 200 {object} models.APIResponse{data=[]models.HiveInspection}
+// @Failure 401 {object} models.APIResponse
+// @Failure 404 {object} models.APIResponse
+// @Router /hives/{id}/inspections [get]
+func (h *HiveHandler) GetHiveInspections(c *gin.Context) {
+	userID, err := utils.GetUserID(c)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusUnauthorized, "UNAUTHORIZED", "Kullanıcı kimliği doğrulanamadı", nil)
+		return
+	}
+
+	hive, ok := h.ownedHive(c, userID)
+	if !ok {
+		return
+	}
+
+	rows, err := h.db.Query(hiveInspectionSelect+" WHERE hive_id = ? AND user_id = ? ORDER BY inspection_date DESC", hive.ID, userID)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusInternalServerError, "DB_ERROR", "Muayeneler alınamadı", err.Error())
+		return
+	}
+	defer rows.Close()
+
+	inspections := []models.HiveInspection{}
+	for rows.Next() {
+		inspection, err := scanHiveInspection(rows)
+		if err != nil {
+			continue
+		}
+		inspections = append(inspections, inspection)
+	}
+
+	utils.SuccessResponse(c, inspections, "Muayeneler başarıyla getirildi")
+}
+
+// CreateHiveInspection kovan muayenesi
+// @Summary Kovan muayenesi ekle
+// @Description Ana arı durumu, yavru ve bal çerçeveleri ile varroa sayımını kaydeder; alkol yıkama veya şeker tozu sayımında varroa oranı %3'ü aşarsa tedavi uyarısı gönderilir
+// @ID createHiveInspection
+// @Tags Beekeeping
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Kovan ID"
+// @Param request body models.HiveInspectionRequest true "Muayene bilgileri"
+// @Success 201 {object} models.APIResponse{data=models.HiveInspection}
+// @Failure 400 {object} models.APIResponse
+// @Failure 401 {object} models.APIResponse
+// @Failure 404 {object} models.APIResponse
+// @Router /hives/{id}/inspections [post]
+func (h *HiveHandler) CreateHiveInspection(c *gin.Context) {
+	userID, err := utils.GetUserID(c)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusUnauthorized, "UNAUTHORIZED", "Kullanıcı kimliği doğrulanamadı", nil)
+		return
+	}
+
+	var req models.HiveInspectionRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		utils.ErrorResponse(c, http.StatusBadRequest, "INVALID_REQUEST", "Geçersiz istek formatı", err.Error())
+		return
+	}
+	if req.VarroaCount != nil && req.VarroaMethod == "" {
+		utils.ErrorResponse(c, http.StatusBadRequest, "MISSING_FIELDS", "Varroa sayımı için yöntem gerekli", nil)
+		return
+	}
+
+	hive, ok := h.ownedHive(c, userID)
+	if !ok {
+		return
+	}
+
+	inspectionDate := time.Now()
+	if req.InspectionDate != nil {
+		inspectionDate = *req.InspectionDate
+	}
+
+	inspectionID := utils.GenerateID()
+	_, err = h.db.Exec(`
+		INSERT INTO hive_inspections (id, user_id, hive_id, inspection_date, queen_status, brood_frames, brood_pattern,
+		                              honey_frames, temperament, varroa_count, varroa_method, varroa_sample_size, notes, created_at)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, CURRENT_TIMESTAMP)
+	`, inspectionID, userID, hive.ID, inspectionDate, req.QueenStatus, req.BroodFrames, req.BroodPattern,
+		req.HoneyFrames, req.Temperament, req.VarroaCount, req.VarroaMethod, req.VarroaSampleSize, req.Notes)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusInternalServerError, "DB_ERROR", "Muayene kaydedilemedi", err.Error())
+		return
+	}
+
+	inspection, err := scanHiveInspection(h.db.QueryRow(hiveInspectionSelect+" WHERE id = ? AND user_id = ?", inspectionID, userID))
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusInternalServerError, "DB_ERROR", "Kaydedilen muayene alınamadı", err.Error())
+		return
+	}
+
+	if inspection.VarroaAboveThreshold {
+		h.notifications.Create(services.Notification{
+			UserID:   userID,
+			Title:    "Varroa eşiği aşıldı",
+			Message:  fmt.Sprintf("%s kovanında varroa oranı %%%s ölçüldü; tedavi önerilir.", hive.Name, formatQuantity(*inspection.VarroaRate)),
+			Type:     "warning",
+			Priority: "high",
+			Topic:    models.NotificationTopicHiveTreatmentDue,
+			Entity:   &models.RelatedEntity{Type: "hive", ID: hive.ID, Name: hive.Name},
+		})
+	}
+	if req.QueenStatus == "missing" {
+		h.notifications.Create(services.Notification{
+			UserID:   userID,
+			Title:    "Kovanda ana arı yok",
+			Message:  fmt.Sprintf("%s kovanında muayenede ana arı bulunamadı.", hive.Name),
+			Type:     "warning",
+			Priority: "high",
+			Topic:    models.NotificationTopicGeneral,
+			Entity:   &models.RelatedEntity{Type: "hive", ID: hive.ID, Name: hive.Name},
+		})
+	}
+
+	utils.CreatedResponse(c, inspection, "Muayene başarıyla kaydedildi")
+}
+
+// DeleteHiveInspection muayene silme
+// @Summary Kovan muayenesini sil
+// @Description Kovanın muayene kaydını siler
+// @ID deleteHiveInspection
+// @Tags Beekeeping
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Kovan ID"
+// @Param inspectionId path string true "Muayene ID"
+// @Success 200 {object} models.APIResponse
+// @Failure 401 {object} models.APIResponse
+// @Failure 404 {object} models.APIResponse
+// @Router /hives/{id}/inspections/{inspectionId} [delete]
+func (h *HiveHandler) DeleteHiveInspection(c *gin.Context) {
+	userID, err := utils.GetUserID(c)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusUnauthorized, "UNAUTHORIZED", "Kullanıcı kimliği doğrulanamadı", nil)
+		return
+	}
+
+	result, err := h.db.Exec("DELETE FROM hive_inspections WHERE id = ? AND hive_id = ? AND user_id = ?",
+		c.Param("inspectionId"), c.Param("id"), userID)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusInternalServerError, "DELETE_ERROR", "Muayene silinemedi", err.Error())
+		return
+	}
+	if affected, _ := result.RowsAffected(); affected == 0 {
+		utils.ErrorResponse(c, http.StatusNotFound, "INSPECTION_NOT_FOUND", "Muayene bulunamadı", nil)
+		return
+	}
+
+	utils.SuccessResponse(c, nil, "Muayene başarıyla silindi")
+}
+
+// GetHiveHarvests bal hasatları
+// @Summary Bal hasatları
+// @Description Kovanın bal hasatlarını en yeniden eskiye listeler
+// @ID getHiveHarvests
+// @Tags Beekeeping
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Kovan ID"
+// @Success 200 {object} models.APIResponse{data=[]models.HiveHarvest}
+// @Failure 401 {object} models.APIResponse
+// @Failure 404 {object} models.APIResponse
+// @Router /hives/{id}/harvests [get]
+func (h *HiveHandler) GetHiveHarvests(c *gin.Context) {
+	userID, err := utils.GetUserID(c)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusUnauthorized, "UNAUTHORIZED", "Kullanıcı kimliği doğrulanamadı", nil)
+		return
+	}
+
+	hive, ok := h.ownedHive(c, userID)
+	if !ok {
+		return
+	}
+
+	rows, err := h.db.Query(`
+		SELECT id, hive_id, harvest_date, amount, COALESCE(honey_type, ''), moisture, COALESCE(production_id, ''),
+		       COALESCE(notes, ''), created_at
+		FROM hive_harvests WHERE hive_id = ? AND user_id = ? ORDER BY harvest_date DESC
+	`, hive.ID, userID)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusInternalServerError, "DB_ERROR", "Hasatlar alınamadı", err.Error())
+		return
+	}
+	defer rows.Close()
+
+	harvests := []models.HiveHarvest{}
+	for rows.Next() {
+		var harvest models.HiveHarvest
+		var moisture sql.NullFloat64
+		err := rows.Scan(&harvest.ID, &harvest.HiveID, &harvest.HarvestDate, &harvest.Amount, &harvest.HoneyType,
+			&moisture, &harvest.ProductionID, &harvest.Notes, &harvest.CreatedAt)
+		if err != nil {
+			continue
+		}
+		harvest.Moisture = utils.NullFloat64ToPtr(moisture)
+		harvests = append(harvests, harvest)
+	}
+
+	utils.SuccessResponse(c, harvests, "Hasatlar başarıyla getirildi")
+}
+
+// CreateHiveHarvest bal hasadı
+// @Summary Bal hasadı ekle
+// @Description Kovandan bal hasadını kaydeder ve hasat miktarı kadar "Bal" üretim kaydı (kg) oluşturur; üretim stoğu satış ve kayıp uç noktalarıyla yönetilir
+// @ID createHiveHarvest
+// @Tags Beekeeping
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Kovan ID"
+// @Param request body models.HiveHarvestRequest true "Hasat bilgileri"
+// @Success 201 {object} models.APIResponse{data=models.HiveHarvestResult}
+// @Failure 400 {object} models.APIResponse
+// @Failure 401 {object} models.APIResponse
+// @Failure 404 {object} models.APIResponse
+// @Router /hives/{id}/harvests [post]
+func (h *HiveHandler) CreateHiveHarvest(c *gin.Context) {
+	userID, err := utils.GetUserID(c)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusUnauthorized, "UNAUTHORIZED", "Kullanıcı kimliği doğrulanamadı", nil)
+		return
+	}
+
+	var req models.HiveHarvestRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		utils.ErrorResponse(c, http.StatusBadRequest, "INVALID_REQUEST", "Geçersiz istek formatı", err.Error())
+		return
+	}
+
+	hive, ok := h.ownedHive(c, userID)
+	if !ok {
+		return
+	}
+
+	harvest := models.HiveHarvest{
+		ID:           utils.GenerateID(),
+		HiveID:       hive.ID,
+		HarvestDate:  time.Now(),
+		Amount:       req.Amount,
+		HoneyType:    strings.TrimSpace(req.HoneyType),
+		Moisture:     req.Moisture,
+		ProductionID: utils.GenerateID(),
+		Notes:        req.Notes,
+		CreatedAt:    time.Now(),
+	}
+	if req.HarvestDate != nil {
+		harvest.HarvestDate = *req.HarvestDate
+	}
+
+	productionName := "Bal"
+	if harvest.HoneyType != "" {
+		productionName += " (" + harvest.HoneyType + ")"
+	}
+	productionNotes := "Kovan: " + hive.Name
+	if hive.Apiary != "" {
+		productionNotes += ", Arılık: " + hive.Apiary
+	}
+
+	tx, err := h.db.Begin()
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusInternalServerError, "DB_ERROR", "Hasat kaydedilemedi", err.Error())
+		return
+	}
+	defer tx.Rollback()
+
+	_, err = tx.Exec(`
+		INSERT INTO production (id, user_id, land_id, name, category, amount, unit, harvest_date,
+		                       quality, storage_location, status, unit_cost, notes, created_at, updated_at)
+		VALUES (?, ?, ?, ?, 'Arıcılık', ?, 'kg', ?, ?, ?, 'active', ?, ?, CURRENT_TIMESTAMP, CURRENT_TIMESTAMP)
+	`, harvest.ProductionID, userID, hive.LandID, productionName, harvest.Amount, harvest.HarvestDate,
+		req.Quality, req.StorageLocation, req.UnitCost, productionNotes)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusInternalServerError, "DB_ERROR", "Üretim kaydı oluşturulamadı", err.Error())
+		return
+	}
+
+	_, err = tx.Exec(`
+		INSERT INTO hive_harvests (id, user_id, hive_id, harvest_date, amount, honey_type, moisture, production_id, notes, created_at)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, CURRENT_TIMESTAMP)
+	`, harvest.ID, userID, hive.ID, harvest.HarvestDate, harvest.Amount, harvest.HoneyType, harvest.Moisture,
+		harvest.ProductionID, harvest.Notes)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusInternalServerError, "DB_ERROR", "Hasat kaydedilemedi", err.Error())
+		return
+	}
+
+	if err := tx.Commit(); err != nil {
+		utils.ErrorResponse(c, http.StatusInternalServerError, "DB_ERROR", "Hasat kaydedilemedi", err.Error())
+		return
+	}
+
+	production, err := scanProduction(h.db.QueryRow(productionSelect+" WHERE id = ? AND user_id = ?", harvest.ProductionID, userID))
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusInternalServerError, "DB_ERROR", "Oluşturulan üretim kaydı alınamadı", err.Error())
+		return
+	}
+
+	utils.CreatedResponse(c, models.HiveHarvestResult{Harvest: harvest, Production: production}, "Hasat başarıyla kaydedildi")
+}
+
+// GetHiveTreatments kovan tedavileri
+// @Summary Kovan tedavileri
+// @Description Kovanın tedavi kayıtlarını en yeniden eskiye listeler
+// @ID getHiveTreatments
+// @Tags Beekeeping
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Kovan ID"
+// @Success 200 {object} models.APIResponse{data=[]models.HiveTreatment}
+// @Failure 401 {object} models.APIResponse
+// @Failure 404 {object} models.APIResponse
+// @Router /hives/{id}/treatments [get]
+func (h *HiveHandler) GetHiveTreatments(c *gin.Context) {
+	userID, err := utils.GetUserID(c)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusUnauthorized, "UNAUTHORIZED", "Kullanıcı kimliği doğrulanamadı", nil)
+		return
+	}
+
+	hive, ok := h.ownedHive(c, userID)
+	if !ok {
+		return
+	}
+
+	treatments, err := h.queryTreatments(" WHERE t.hive_id = ? AND t.user_id = ? ORDER BY t.treatment_date DESC", hive.ID, userID)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusInternalServerError, "DB_ERROR", "Tedaviler alınamadı", err.Error())
+		return
+	}
+
+	utils.SuccessResponse(c, treatments, "Tedaviler başarıyla getirildi")
+}
+
+// CreateHiveTreatment kovan tedavisi
+// @Summary Kovan tedavisi ekle
+// @Description Kovana uygulanan tedaviyi kaydeder; varroa tedavisinde nextDueDate verilmezse sıradaki tedavi mevsiminin başlangıcı (1 Mart veya 15 Ağustos) kullanılır ve tarihten 7 gün önce hatırlatma gönderilir
+// @ID createHiveTreatment
+// @Tags Beekeeping
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Kovan ID"
+// @Param request body models.HiveTreatmentRequest true "Tedavi bilgileri"
+// @Success 201 {object} models.APIResponse{data=models.HiveTreatment}
+// @Failure 400 {object} models.APIResponse
+// @Failure 401 {object} models.APIResponse
+// @Failure 404 {object} models.APIResponse
+// @Router /hives/{id}/treatments [post]
+func (h *HiveHandler) CreateHiveTreatment(c *gin.Context) {
+	userID, err := utils.GetUserID(c)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusUnauthorized, "UNAUTHORIZED", "Kullanıcı kimliği doğrulanamadı", nil)
+		return
+	}
+
+	var req models.HiveTreatmentRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		utils.ErrorResponse(c, http.StatusBadRequest, "INVALID_REQUEST", "Geçersiz istek formatı", err.Error())
+		return
+	}
+
+	hive, ok := h.ownedHive(c, userID)
+	if !ok {
+		return
+	}
+
+	treatmentDate := time.Now()
+	if req.TreatmentDate != nil {
+		treatmentDate = *req.TreatmentDate
+	}
+	nextDueDate := req.NextDueDate
+	if nextDueDate == nil && req.Target == models.HiveTreatmentVarroa {
+		next := nextHiveTreatmentSeason(treatmentDate)
+		nextDueDate = &next
+	}
+	if nextDueDate != nil && !nextDueDate.After(treatmentDate) {
+		utils.ErrorResponse(c, http.StatusBadRequest, "INVALID_DATE", "Sonraki tedavi tarihi tedavi tarihinden sonra olmalı", nil)
+		return
+	}
+
+	treatmentID := utils.GenerateID()
+	_, err = h.db.Exec(`
+		INSERT INTO hive_treatments (id, user_id, hive_id, treatment_date, target, product, dose, next_due_date, notes, created_at)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, CURRENT_TIMESTAMP)
+	`, treatmentID, userID, hive.ID, treatmentDate, req.Target, req.Product, req.Dose, nextDueDate, req.Notes)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusInternalServerError, "DB_ERROR", "Tedavi kaydedilemedi", err.Error())
+		return
+	}
+
+	treatments, err := h.queryTreatments(" WHERE t.id = ? AND t.user_id = ?", treatmentID, userID)
+	if err != nil || len(treatments) == 0 {
+		utils.ErrorResponse(c, http.StatusInternalServerError, "DB_ERROR", "Kaydedilen tedavi alınamadı", nil)
+		return
+	}
+
+	utils.CreatedResponse(c, treatments[0], "Tedavi başarıyla kaydedildi")
+}
+
+// GetDueHiveTreatments yaklaşan kovan tedavileri
+// @Summary Yaklaşan kovan tedavileri
+// @Description Aktif kovanlarda tarihi yaklaşan veya geçmiş, henüz yenisi yapılmamış tedavileri tarihe göre listeler
+// @ID getDueHiveTreatments
+// @Tags Beekeeping
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param days query int false "Kaç gün sonrasına kadar (varsayılan 30)"
+// @Success 200 {object} models.APIResponse{data=[]models.HiveTreatment}
+// @Failure 401 {object} models.APIResponse
+// @Router /hives/treatments/due [get]
+func (h *HiveHandler) GetDueHiveTreatments(c *gin.Context) {
+	userID, err := utils.GetUserID(c)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusUnauthorized, "UNAUTHORIZED", "Kullanıcı kimliği doğrulanamadı", nil)
+		return
+	}
+
+	days, err := strconv.Atoi(c.DefaultQuery("days", "30"))
+	if err != nil || days < 0 {
+		days = 30
+	}
+
+	treatments, err := h.queryTreatments(pendingHiveTreatmentFilter+" AND t.user_id = ? ORDER BY t.next_due_date",
+		time.Now().AddDate(0, 0, days), userID)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusInternalServerError, "DB_ERROR", "Yaklaşan tedaviler alınamadı", err.Error())
+		return
+	}
+
+	utils.SuccessResponse(c, treatments, "Yaklaşan tedaviler başarıyla getirildi")
+}
+
+// StartReminders yaklaşan kovan tedavileri için saatlik olarak hatırlatma gönderir
+func (h *HiveHandler) StartReminders() {
+	go func() {
+		ticker := time.NewTicker(time.Hour)
+		defer ticker.Stop()
+
+		for {
+			if err := h.SendDueReminders(); err != nil {
+				log.Printf("Kovan tedavi hatırlatmaları gönderilemedi: %v", err)
+			}
+			<-ticker.C
+		}
+	}()
+}
+
+// SendDueReminders tarihi yaklaşan tedaviler için henüz gönderilmemiş hatırlatmaları gönderir
+func (h *HiveHandler) SendDueReminders() error {
+	rows, err := h.db.Query(hiveTreatmentSelect+pendingHiveTreatmentFilter+" AND t.reminder_sent_at IS NULL",
+		time.Now().AddDate(0, 0, hiveTreatmentReminderDays))
+	if err != nil {
+		return err
+	}
+
+	type dueTreatment struct {
+		treatment models.HiveTreatment
+		userID    string
+	}
+	var due []dueTreatment
+	for rows.Next() {
+		var item dueTreatment
+		treatment, err := scanHiveTreatment(rows, &item.userID)
+		if err != nil {
+			continue
+		}
+		item.treatment = treatment
+		due = append(due, item)
+	}
+	rows.Close()
+
+	for _, item := range due {
+		treatment := item.treatment
+		_, err := h.notifications.Create(services.Notification{
+			UserID: item.userID,
+			Title:  "Kovan Tedavisi Yaklaşıyor",
+			Message: fmt.Sprintf("%s kovanının %s tedavisi %s tarihinde yapılmalı (son uygulama: %s).", treatment.HiveName,
+				hiveTreatmentTargetLabels[treatment.Target], treatment.NextDueDate.Format("02.01.2006"), treatment.Product),
+			Type:     "reminder",
+			Priority: "medium",
+			Topic:    models.NotificationTopicHiveTreatmentDue,
+			Entity:   &models.RelatedEntity{Type: "hive", ID: treatment.HiveID, Name: treatment.HiveName},
+		})
+		if err != nil {
+			return err
+		}
+
+		if _, err := h.db.Exec("UPDATE hive_treatments SET reminder_sent_at = CURRENT_TIMESTAMP WHERE id = ?", treatment.ID); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// bindHiveRequest kovan isteğini okur, varsayılanları uygular ve araziyi doğrular; hata varsa yanıtı yazar
+func (h *HiveHandler) bindHiveRequest(c *gin.Context, userID string, req *models.HiveRequest) bool {
+	if err := c.ShouldBindJSON(req); err != nil {
+		utils.ErrorResponse(c, http.StatusBadRequest, "INVALID_REQUEST", "Geçersiz istek formatı", err.Error())
+		return false
+	}
+	if req.Status == "" {
+		req.Status = models.HiveStatusActive
+	}
+	if req.LandID != nil && *req.LandID == "" {
+		req.LandID = nil
+	}
+
+	if req.LandID != nil {
+		var exists bool
+		h.db.QueryRow("SELECT 1 FROM lands WHERE id = ? AND user_id = ?", *req.LandID, userID).Scan(&exists)
+		if !exists {
+			utils.ErrorResponse(c, http.StatusNotFound, "LAND_NOT_FOUND", "Arazi bulunamadı", nil)
+			return false
+		}
+	}
+	return true
+}
+
+// ownedHive yol parametresindeki kovanı getirir; bulunamazsa 404 yanıtını yazar
+func (h *HiveHandler) ownedHive(c *gin.Context, userID string) (models.Hive, bool) {
+	hive, err := scanHive(h.db.QueryRow(hiveSelect+" WHERE id = ? AND user_id = ?", c.Param("id"), userID))
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusNotFound, "HIVE_NOT_FOUND", "Kovan bulunamadı", nil)
+		return hive, false
+	}
+	return hive, true
+}
+
+// getHive kovanı özet bilgileriyle getirir
+func (h *HiveHandler) getHive(hiveID, userID string) (models.Hive, error) {
+	hive, err := scanHive(h.db.QueryRow(hiveSelect+" WHERE id = ? AND user_id = ?", hiveID, userID))
+	if err != nil {
+		return hive, err
+	}
+	return hive, h.attachHiveSummary(&hive)
+}
+
+// attachHiveSummary kovanın son muayenesini, bu yılki bal hasadını ve sıradaki tedavi tarihini ekler
+func (h *HiveHandler) attachHiveSummary(hive *models.Hive) error {
+	inspection, err := scanHiveInspection(h.db.QueryRow(hiveInspectionSelect+" WHERE hive_id = ? AND user_id = ? ORDER BY inspection_date DESC LIMIT 1", hive.ID, hive.UserID))
+	if err == nil {
+		hive.LatestInspection = &inspection
+	} else if err != sql.ErrNoRows {
+		return err
+	}
+
+	yearStart := time.Date(time.Now().Year(), 1, 1, 0, 0, 0, 0, time.Local)
+	err = h.db.QueryRow("SELECT COALESCE(SUM(amount), 0) FROM hive_harvests WHERE hive_id = ? AND user_id = ? AND harvest_date >= ?",
+		hive.ID, hive.UserID, yearStart).Scan(&hive.HoneyThisYear)
+	if err != nil {
+		return err
+	}
+	hive.HoneyThisYear = roundTo2(hive.HoneyThisYear)
+
+	var nextDueDate sql.NullTime
+	err = h.db.QueryRow(`
+		SELECT t.next_due_date FROM hive_treatments t
+		WHERE t.hive_id = ? AND t.user_id = ? AND t.next_due_date IS NOT NULL AND NOT EXISTS (
+		    SELECT 1 FROM hive_treatments n WHERE n.hive_id = t.hive_id AND n.target = t.target AND n.treatment_date > t.treatment_date
+		)
+		ORDER BY t.next_due_date LIMIT 1
+	`, hive.ID, hive.UserID).Scan(&nextDueDate)
+	if err != nil && err != sql.ErrNoRows {
+		return err
+	}
+	hive.NextTreatmentDue = utils.NullTimeToPtr(nextDueDate)
+	return nil
+}
+
+// queryTreatments hiveTreatmentSelect ile verilen koşuldaki tedavileri getirir
+func (h *HiveHandler) queryTreatments(condition string, args ...interface{}) ([]models.HiveTreatment, error) {
+	rows, err := h.db.Query(hiveTreatmentSelect+condition, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	treatments := []models.HiveTreatment{}
+	for rows.Next() {
+		var userID string
+		treatment, err := scanHiveTreatment(rows, &userID)
+		if err != nil {
+			return nil, err
+		}
+		treatments = append(treatments, treatment)
+	}
+	return treatments, rows.Err()
+}
+
+// nextHiveTreatmentSeason tedaviden en az bir ay sonra başlayan ilk tedavi mevsimini döner
+func nextHiveTreatmentSeason(treatmentDate time.Time) time.Time {
+	earliest := treatmentDate.AddDate(0, 1, 0)
+	for year := treatmentDate.Year(); ; year++ {
+		for _, season := range hiveTreatmentSeasons {
+			start := time.Date(year, time.Month(season[0]), season[1], 9, 0, 0, 0, treatmentDate.Location())
+			if start.After(earliest) {
+				return start
+			}
+		}
+	}
+}
+
+// hiveSelect kovan sorgularının ortak sütunları
+const hiveSelect = `
+	SELECT id, user_id, name, COALESCE(apiary, ''), land_id, COALESCE(hive_type, ''), queen_year,
+	       COALESCE(status, 'active'), COALESCE(notes, ''), created_at, updated_at
+	FROM hives`
+
+// scanHive hiveSelect ile seçilen satırı kovana çevirir
+func scanHive(row interface{ Scan(...interface{}) error }) (models.Hive, error) {
+	var hive models.Hive
+	var landID sql.NullString
+	var queenYear sql.NullInt64
+
+	err := row.Scan(&hive.ID, &hive.UserID, &hive.Name, &hive.Apiary, &landID, &hive.HiveType, &queenYear,
+		&hive.Status, &hive.Notes, &hive.CreatedAt, &hive.UpdatedAt)
+	if err != nil {
+		return hive, err
+	}
+
+	if landID.Valid {
+		hive.LandID = &landID.String
+	}
+	if queenYear.Valid {
+		year := int(queenYear.Int64)
+		hive.QueenYear = &year
+	}
+	return hive, nil
+}
+
+// hiveInspectionSelect muayene sorgularının ortak sütunları
+const hiveInspectionSelect = `
+	SELECT id, hive_id, inspection_date, queen_status, brood_frames, COALESCE(brood_pattern, ''), honey_frames,
+	       COALESCE(temperament, ''), varroa_count, COALESCE(varroa_method, ''), varroa_sample_size,
+	       COALESCE(notes, ''), created_at
+	FROM hive_inspections`
+
+// scanHiveInspection hiveInspectionSelect ile seçilen satırı muayeneye çevirir ve varroa oranını hesaplar
+func scanHiveInspection(row interface{ Scan(...interface{}) error }) (models.HiveInspection, error) {
+	var inspection models.HiveInspection
+	var broodFrames, honeyFrames, varroaCount, sampleSize sql.NullInt64
+
+	err := row.Scan(&inspection.ID, &inspection.HiveID, &inspection.InspectionDate, &inspection.QueenStatus,
+		&broodFrames, &inspection.BroodPattern, &honeyFrames, &inspection.Temperament, &varroaCount,
+		&inspection.VarroaMethod, &sampleSize, &inspection.Notes, &inspection.CreatedAt)
+	if err != nil {
+		return inspection, err
+	}
+
+	inspection.BroodFrames = nullIntToPtr(broodFrames)
+	inspection.HoneyFrames = nullIntToPtr(honeyFrames)
+	inspection.VarroaCount = nullIntToPtr(varroaCount)
+	inspection.VarroaSampleSize = nullIntToPtr(sampleSize)
+
+	// Yapışkan tabla sayımı günlük akar dökümüdür, orana çevrilmez
+	if inspection.VarroaCount != nil && inspection.VarroaSampleSize != nil && inspection.VarroaMethod != "sticky_board" {
+		rate := roundTo2(float64(*inspection.VarroaCount) / float64(*inspection.VarroaSampleSize) * 100)
+		inspection.VarroaRate = &rate
+		inspection.VarroaAboveThreshold = rate > models.VarroaAlertThresholdPct
+	}
+	return inspection, nil
+}
+
+// hiveTreatmentSelect tedavi sorgularının ortak sütunları; kovan adı ve sahibi ile birlikte
+const hiveTreatmentSelect = `
+	SELECT t.id, t.user_id, t.hive_id, hv.name, t.treatment_date, t.target, t.product, COALESCE(t.dose, ''),
+	       t.next_due_date, COALESCE(t.notes, ''), t.created_at
+	FROM hive_treatments t
+	JOIN hives hv ON hv.id = t.hive_id`
+
+// pendingHiveTreatmentFilter aktif kovanlarda tarihi verilen zamandan önce olan ve aynı hedef için yenisi yapılmamış tedaviler
+const pendingHiveTreatmentFilter = `
+	WHERE t.next_due_date IS NOT NULL AND t.next_due_date <= ? AND COALESCE(hv.status, 'active') = 'active'
+	  AND NOT EXISTS (
+	      SELECT 1 FROM hive_treatments n WHERE n.hive_id = t.hive_id AND n.target = t.target AND n.treatment_date > t.treatment_date
+	  )`
+
+// scanHiveTreatment hiveTreatmentSelect ile seçilen satırı tedaviye çevirir
+func scanHiveTreatment(row interface{ Scan(...interface{}) error }, userID *string) (models.HiveTreatment, error) {
+	var treatment models.HiveTreatment
+	var nextDueDate sql.NullTime
+
+	err := row.Scan(&treatment.ID, userID, &treatment.HiveID, &treatment.HiveName, &treatment.TreatmentDate,
+		&treatment.Target, &treatment.Product, &treatment.Dose, &nextDueDate, &treatment.Notes, &treatment.CreatedAt)
+	if err != nil {
+		return treatment, err
+	}
+
+	treatment.NextDueDate = utils.NullTimeToPtr(nextDueDate)
+	return treatment, nil
+}
+
+// nullIntToPtr boş olmayan tam sayıyı pointer olarak döner
+func nullIntToPtr(value sql.NullInt64) *int {
+	if !value.Valid {
+		return nil
+	}
+	number := int(value.Int64)
+	return &number
+}
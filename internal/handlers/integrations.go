@@ -0,0 +1,316 @@
+package handlers
+
+import (
+	"database/sql"
+	"net/http"
+	"strconv"
+	"time"
+
+	"agri-management-api/internal/models"
+	"agri-management-api/internal/services"
+	"agri-management-api/internal/utils"
+
+	"github.com/gin-gonic/gin"
+)
+
+// IntegrationHandler Zapier, IFTTT gibi otomasyon araçları için anahtarları ve yoklama tetikleyicilerini yönetir
+type IntegrationHandler struct {
+	db           *sql.DB
+	integrations *services.IntegrationService
+}
+
+// NewIntegrationHandler yeni integration handler oluşturur
+func NewIntegrationHandler(db *sql.DB) *IntegrationHandler {
+	return &IntegrationHandler{
+		db:           db,
+		integrations: services.NewIntegrationService(db),
+	}
+}
+
+// GetIntegrationKeys entegrasyon anahtarları
+// @Summary Entegrasyon anahtarları
+// @Description Çiftliğin iptal edilmemiş entegrasyon anahtarlarını önek ve son kullanım zamanıyla listeler; anahtarların kendisi dönmez
+// @ID getIntegrationKeys
+// @Tags Integrations
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {object} models.APIResponse{data=[]models.IntegrationKey}
+// @Failure 401 {object} models.APIResponse
+// @Router /integrations/keys [get]
+func (h *IntegrationHandler) GetIntegrationKeys(c *gin.Context) {
+	userID, err := utils.GetUserID(c)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusUnauthorized, "UNAUTHORIZED", "Kullanıcı kimliği doğrulanamadı", nil)
+		return
+	}
+
+	keys, err := h.integrations.ListKeys(userID)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusInternalServerError, "DB_ERROR", "Entegrasyon anahtarları alınamadı", err.Error())
+		return
+	}
+
+	utils.SuccessResponse(c, keys, "Entegrasyon anahtarları başarıyla getirildi")
+}
+
+// CreateIntegrationKey entegrasyon anahtarı oluşturma
+// @Summary Entegrasyon anahtarı oluştur
+// @Description Seçili çiftlik için otomasyon araçlarında kullanılacak API anahtarı üretir. Anahtar (key) yalnızca bu yanıtta döner, sonradan görüntülenemez. Sandbox hesapları anahtar oluşturamaz (403 DEMO_ACCOUNT)
+// @ID createIntegrationKey
+// @Tags Integrations
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param request body models.CreateIntegrationKeyRequest true "Anahtar adı"
+// @Success 201 {object} models.APIResponse{data=models.IntegrationKey}
+// @Failure 400 {object} models.APIResponse
+// @Failure 401 {object} models.APIResponse
+// @Failure 403 {object} models.APIResponse
+// @Router /integrations/keys [post]
+func (h *IntegrationHandler) CreateIntegrationKey(c *gin.Context) {
+	userID, err := utils.GetUserID(c)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusUnauthorized, "UNAUTHORIZED", "Kullanıcı kimliği doğrulanamadı", nil)
+		return
+	}
+	accountID, _ := utils.GetAccountID(c)
+
+	var req models.CreateIntegrationKeyRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		utils.ErrorResponse(c, http.StatusBadRequest, "INVALID_REQUEST", "Geçersiz istek formatı", err.Error())
+		return
+	}
+
+	key, err := h.integrations.CreateKey(userID, accountID, req.Name)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusInternalServerError, "DB_ERROR", "Entegrasyon anahtarı oluşturulamadı", err.Error())
+		return
+	}
+
+	utils.CreatedResponse(c, key, "Entegrasyon anahtarı oluşturuldu; anahtarı güvenli bir yerde saklayın")
+}
+
+// RevokeIntegrationKey entegrasyon anahtarını iptal etme
+// @Summary Entegrasyon anahtarını iptal et
+// @Description Anahtarı iptal eder; bu anahtarla yapılan tetikleyici istekleri 401 döner
+// @ID revokeIntegrationKey
+// @Tags Integrations
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Anahtar ID"
+// @Success 200 {object} models.APIResponse
+// @Failure 401 {object} models.APIResponse
+// @Failure 404 {object} models.APIResponse
+// @Router /integrations/keys/{id} [delete]
+func (h *IntegrationHandler) RevokeIntegrationKey(c *gin.Context) {
+	userID, err := utils.GetUserID(c)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusUnauthorized, "UNAUTHORIZED", "Kullanıcı kimliği doğrulanamadı", nil)
+		return
+	}
+
+	err = h.integrations.RevokeKey(userID, c.Param("id"))
+	if err == services.ErrIntegrationKeyNotFound {
+		utils.ErrorResponse(c, http.StatusNotFound, "KEY_NOT_FOUND", "Entegrasyon anahtarı bulunamadı", nil)
+		return
+	}
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusInternalServerError, "DB_ERROR", "Entegrasyon anahtarı iptal edilemedi", err.Error())
+		return
+	}
+
+	utils.SuccessResponse(c, nil, "Entegrasyon anahtarı iptal edildi")
+}
+
+// GetIntegrationAccount entegrasyon bağlantı testi
+// @Summary Entegrasyon bağlantı testi
+// @Description Anahtarın geçerli olduğunu doğrular ve bağlı çiftliği döner; Zapier ve IFTTT bağlantı testi için kullanılır
+// @ID getIntegrationAccount
+// @Tags Integrations
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Success 200 {object} models.APIResponse{data=models.IntegrationAccount}
+// @Failure 401 {object} models.APIResponse
+// @Router /integrations/me [get]
+func (h *IntegrationHandler) GetIntegrationAccount(c *gin.Context) {
+	userID, err := utils.GetUserID(c)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusUnauthorized, "UNAUTHORIZED", "Kullanıcı kimliği doğrulanamadı", nil)
+		return
+	}
+
+	account, err := h.integrations.Account(userID, c.GetString("integration_key_name"))
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusInternalServerError, "DB_ERROR", "Çiftlik bilgisi alınamadı", err.Error())
+		return
+	}
+
+	utils.SuccessResponse(c, account, "Entegrasyon anahtarı geçerli")
+}
+
+// GetIntegrationTriggers tetikleyici listesi
+// @Summary Entegrasyon tetikleyicileri
+// @Description Tanımlı yoklama tetikleyicilerini yol ve örnek öğeleriyle listeler; örnekler tetikleyici yanıtlarıyla aynı alanları taşır
+// @ID getIntegrationTriggers
+// @Tags Integrations
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Success 200 {object} models.APIResponse{data=[]models.IntegrationTrigger}
+// @Failure 401 {object} models.APIResponse
+// @Router /integrations/triggers [get]
+func (h *IntegrationHandler) GetIntegrationTriggers(c *gin.Context) {
+	utils.SuccessResponse(c, services.IntegrationTriggers(), "Tetikleyiciler başarıyla getirildi")
+}
+
+// GetNewTransactions yeni işlem tetikleyicisi
+// @Summary Yeni finans işlemleri
+// @Description since'ten sonra eklenen işlemleri en yeniden başlayarak döner. Her öğe Zapier tekilleştirmesi için id ve IFTTT için meta (id, timestamp) taşır; Zapier'de yalın dizi için envelope=false kullanılır
+// @ID getIntegrationNewTransactions
+// @Tags Integrations
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param since query string false "Bu zamandan sonra eklenenler (RFC3339 veya YYYY-MM-DD)"
+// @Param limit query int false "En fazla öğe (1-100)" default(50)
+// @Success 200 {object} models.APIResponse{data=[]models.IntegrationTransaction}
+// @Failure 400 {object} models.APIResponse
+// @Failure 401 {object} models.APIResponse
+// @Router /integrations/new-transactions [get]
+func (h *IntegrationHandler) GetNewTransactions(c *gin.Context) {
+	userID, since, limit, ok := integrationPollParams(c)
+	if !ok {
+		return
+	}
+
+	items, err := h.integrations.Transactions(userID, since, limit)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusInternalServerError, "DB_ERROR", "İşlemler alınamadı", err.Error())
+		return
+	}
+
+	utils.SuccessResponse(c, items, "Yeni işlemler başarıyla getirildi")
+}
+
+// GetNewAnimals yeni hayvan tetikleyicisi
+// @Summary Yeni hayvanlar
+// @Description since'ten sonra eklenen hayvanları en yeniden başlayarak döner
+// @ID getIntegrationNewAnimals
+// @Tags Integrations
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param since query string false "Bu zamandan sonra eklenenler (RFC3339 veya YYYY-MM-DD)"
+// @Param limit query int false "En fazla öğe (1-100)" default(50)
+// @Success 200 {object} models.APIResponse{data=[]models.IntegrationAnimal}
+// @Failure 400 {object} models.APIResponse
+// @Failure 401 {object} models.APIResponse
+// @Router /integrations/new-animals [get]
+func (h *IntegrationHandler) GetNewAnimals(c *gin.Context) {
+	userID, since, limit, ok := integrationPollParams(c)
+	if !ok {
+		return
+	}
+
+	items, err := h.integrations.Animals(userID, since, limit)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusInternalServerError, "DB_ERROR", "Hayvanlar alınamadı", err.Error())
+		return
+	}
+
+	utils.SuccessResponse(c, items, "Yeni hayvanlar başarıyla getirildi")
+}
+
+// GetNewEvents yeni etkinlik tetikleyicisi
+// @Summary Yeni takvim etkinlikleri
+// @Description since'ten sonra eklenen takvim etkinliklerini en yeniden başlayarak döner
+// @ID getIntegrationNewEvents
+// @Tags Integrations
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param since query string false "Bu zamandan sonra eklenenler (RFC3339 veya YYYY-MM-DD)"
+// @Param limit query int false "En fazla öğe (1-100)" default(50)
+// @Success 200 {object} models.APIResponse{data=[]models.IntegrationEvent}
+// @Failure 400 {object} models.APIResponse
+// @Failure 401 {object} models.APIResponse
+// @Router /integrations/new-events [get]
+func (h *IntegrationHandler) GetNewEvents(c *gin.Context) {
+	userID, since, limit, ok := integrationPollParams(c)
+	if !ok {
+		return
+	}
+
+	items, err := h.integrations.Events(userID, since, limit)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusInternalServerError, "DB_ERROR", "Etkinlikler alınamadı", err.Error())
+		return
+	}
+
+	utils.SuccessResponse(c, items, "Yeni etkinlikler başarıyla getirildi")
+}
+
+// GetNewHarvests yeni hasat tetikleyicisi
+// @Summary Yeni hasatlar
+// @Description since'ten sonra eklenen üretim kayıtlarını arazi adıyla en yeniden başlayarak döner
+// @ID getIntegrationNewHarvests
+// @Tags Integrations
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param since query string false "Bu zamandan sonra eklenenler (RFC3339 veya YYYY-MM-DD)"
+// @Param limit query int false "En fazla öğe (1-100)" default(50)
+// @Success 200 {object} models.APIResponse{data=[]models.IntegrationHarvest}
+// @Failure 400 {object} models.APIResponse
+// @Failure 401 {object} models.APIResponse
+// @Router /integrations/new-harvests [get]
+func (h *IntegrationHandler) GetNewHarvests(c *gin.Context) {
+	userID, since, limit, ok := integrationPollParams(c)
+	if !ok {
+		return
+	}
+
+	items, err := h.integrations.Harvests(userID, since, limit)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusInternalServerError, "DB_ERROR", "Hasatlar alınamadı", err.Error())
+		return
+	}
+
+	utils.SuccessResponse(c, items, "Yeni hasatlar başarıyla getirildi")
+}
+
+// integrationPollParams tetikleyicilerin ortak since ve limit parametrelerini okur; hatalı değerde 400 yazar
+func integrationPollParams(c *gin.Context) (string, *time.Time, int, bool) {
+	userID, err := utils.GetUserID(c)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusUnauthorized, "UNAUTHORIZED", "Kullanıcı kimliği doğrulanamadı", nil)
+		return "", nil, 0, false
+	}
+
+	var since *time.Time
+	if value := c.Query("since"); value != "" {
+		parsed, err := time.Parse(time.RFC3339, value)
+		if err != nil {
+			parsed, err = time.Parse("2006-01-02", value)
+		}
+		if err != nil {
+			utils.ErrorResponse(c, http.StatusBadRequest, "INVALID_SINCE", "since RFC3339 veya YYYY-MM-DD biçiminde olmalı", value)
+			return "", nil, 0, false
+		}
+		since = &parsed
+	}
+
+	limit := services.DefaultIntegrationLimit
+	if value := c.Query("limit"); value != "" {
+		parsed, err := strconv.Atoi(value)
+		if err != nil || parsed < 1 || parsed > services.MaxIntegrationLimit {
+			utils.ErrorResponse(c, http.StatusBadRequest, "INVALID_LIMIT", "limit 1 ile 100 arasında olmalı", value)
+			return "", nil, 0, false
+		}
+		limit = parsed
+	}
+	return userID, since, limit, true
+}
@@ -0,0 +1,189 @@
+package handlers
+
+import (
+	"errors"
+	"io"
+	"net/http"
+
+	"agri-management-api/internal/models"
+	"agri-management-api/internal/services"
+	"agri-management-api/internal/utils"
+
+	"github.com/gin-gonic/gin"
+)
+
+// GetInvoices fatura listesi
+// @Summary Faturalar
+// @Description Kesilen faturaları fatura tarihine göre yeniden eskiye, bağlı işlemleriyle sayfalı listeler
+// @ID getInvoices
+// @Tags Finance
+// @Produce json
+// @Security BearerAuth
+// @Param customerId query string false "Müşteri ID"
+// @Param startDate query string false "Fatura tarihi başlangıcı (YYYY-MM-DD)"
+// @Param endDate query string false "Fatura tarihi bitişi (YYYY-MM-DD)"
+// @Param page query int false "Sayfa numarası" default(1)
+// @Param limit query int false "Sayfa başına kayıt" default(10)
+// @Success 200 {object} models.APIResponse{data=models.InvoiceListResponse}
+// @Failure 400 {object} models.APIResponse
+// @Failure 401 {object} models.APIResponse
+// @Router /finance/invoices [get]
+func (h *FinanceHandler) GetInvoices(c *gin.Context) {
+	userID, err := utils.GetUserID(c)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusUnauthorized, "UNAUTHORIZED", "Kullanıcı kimliği doğrulanamadı", nil)
+		return
+	}
+
+	startDate, endDate, ok := dateRangeQuery(c)
+	if !ok {
+		return
+	}
+	filter := models.InvoiceFilter{CustomerID: c.Query("customerId"), StartDate: startDate, EndDate: endDate}
+	filter.Page, filter.Limit = utils.ParsePagination(c)
+
+	invoices, err := h.invoices.Invoices(userID, filter)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusInternalServerError, "DB_ERROR", "Faturalar alınamadı", err.Error())
+		return
+	}
+
+	utils.SuccessResponse(c, invoices, "Faturalar başarıyla getirildi")
+}
+
+// CreateInvoice fatura kesme
+// @Summary Fatura kes
+// @Description Bir veya daha fazla gelir işleminden (transactionIds) ya da satış siparişinden (salesOrderId) numaralı fatura keser; ikisinden yalnızca biri verilmelidir. Fatura numarası yıl bazında sıralıdır (FT-2024-0001). İşlemlerden kesilen faturada her işlem bir kalemdir ve tutarlar vergi dahil kabul edilir; işlemler iptal edilmemiş, aynı para biriminde gelir işlemleri olmalıdır ve alıcı customerId ile seçilir. Siparişten kesilen faturada siparişin miktarı, birim fiyatı, KDV'si ve müşterisi kullanılır; iptal edilmiş siparişin faturası kesilemez. PDF saklanır (downloadUrl) ve fatura numarası bağlı işlemlerin fiş numarasına (receipt) yazılır; henüz ödenmemiş siparişin faturası sipariş ödendiğinde oluşturulan gelir işlemine bağlanır. Bir işlemin veya siparişin yalnızca bir faturası olabilir
+// @ID createInvoice
+// @Tags Finance
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param request body models.InvoiceRequest true "Fatura"
+// @Success 201 {object} models.APIResponse{data=models.Invoice}
+// @Failure 400 {object} models.APIResponse
+// @Failure 401 {object} models.APIResponse
+// @Failure 404 {object} models.APIResponse
+// @Failure 409 {object} models.APIResponse
+// @Failure 422 {object} models.APIResponse
+// @Router /finance/invoices [post]
+func (h *FinanceHandler) CreateInvoice(c *gin.Context) {
+	userID, err := utils.GetUserID(c)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusUnauthorized, "UNAUTHORIZED", "Kullanıcı kimliği doğrulanamadı", nil)
+		return
+	}
+
+	var req models.InvoiceRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		utils.ErrorResponse(c, http.StatusBadRequest, "INVALID_REQUEST", "Geçersiz istek formatı", err.Error())
+		return
+	}
+
+	invoice, details, err := h.invoices.Create(userID, req)
+	if err != nil {
+		writeInvoiceError(c, err, details, "Fatura kesilemedi")
+		return
+	}
+
+	utils.CreatedResponse(c, invoice, "Fatura başarıyla kesildi")
+}
+
+// GetInvoice fatura detayı
+// @Summary Fatura detayı
+// @ID getInvoice
+// @Tags Finance
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Fatura ID"
+// @Success 200 {object} models.APIResponse{data=models.Invoice}
+// @Failure 401 {object} models.APIResponse
+// @Failure 404 {object} models.APIResponse
+// @Router /finance/invoices/{id} [get]
+func (h *FinanceHandler) GetInvoice(c *gin.Context) {
+	userID, err := utils.GetUserID(c)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusUnauthorized, "UNAUTHORIZED", "Kullanıcı kimliği doğrulanamadı", nil)
+		return
+	}
+
+	invoice, err := h.invoices.Invoice(userID, c.Param("id"))
+	if err != nil {
+		writeInvoiceError(c, err, nil, "Fatura alınamadı")
+		return
+	}
+
+	utils.SuccessResponse(c, invoice, "Fatura başarıyla getirildi")
+}
+
+// GetInvoicePDF fatura PDF'i
+// @Summary Fatura PDF'i
+// @Description Fatura kesildiğinde saklanan PDF'i döner. download=true ise dosya ek olarak indirilir
+// @ID getInvoicePdf
+// @Tags Finance
+// @Produce application/pdf
+// @Security BearerAuth
+// @Param id path string true "Fatura ID"
+// @Param download query bool false "Dosyayı indir (varsayılan: tarayıcıda aç)"
+// @Success 200 {file} file
+// @Failure 401 {object} models.APIResponse
+// @Failure 404 {object} models.APIResponse
+// @Router /finance/invoices/{id}/pdf [get]
+func (h *FinanceHandler) GetInvoicePDF(c *gin.Context) {
+	userID, err := utils.GetUserID(c)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusUnauthorized, "UNAUTHORIZED", "Kullanıcı kimliği doğrulanamadı", nil)
+		return
+	}
+
+	invoice, file, err := h.invoices.File(userID, c.Param("id"))
+	if err != nil {
+		if invoice != nil {
+			utils.ErrorResponse(c, http.StatusNotFound, "FILE_NOT_FOUND", "Fatura dosyası bulunamadı", nil)
+			return
+		}
+		writeInvoiceError(c, err, nil, "Fatura alınamadı")
+		return
+	}
+	defer file.Close()
+
+	disposition := "inline"
+	if c.Query("download") == "true" {
+		disposition = "attachment"
+	}
+	c.Header("Content-Disposition", disposition+"; filename=fatura-"+safeFilename(invoice.InvoiceNumber)+".pdf")
+	c.Header("Content-Type", "application/pdf")
+	c.Status(http.StatusOK)
+	io.Copy(c.Writer, file)
+}
+
+// writeInvoiceError servis hatasını HTTP yanıtına çevirir; details bulunamayan, faturalanamayan veya faturası
+// kesilmiş işlemlerin kimlikleri ya da farklı para birimleridir
+func writeInvoiceError(c *gin.Context, err error, details []string, message string) {
+	switch {
+	case errors.Is(err, services.ErrInvoiceNotFound):
+		utils.ErrorResponse(c, http.StatusNotFound, "INVOICE_NOT_FOUND", "Fatura bulunamadı", nil)
+	case errors.Is(err, services.ErrInvoiceSource):
+		utils.ErrorResponse(c, http.StatusBadRequest, "INVALID_SOURCE", err.Error(), nil)
+	case errors.Is(err, services.ErrInvoiceInvalidDueDate):
+		utils.ErrorResponse(c, http.StatusBadRequest, "INVALID_DUE_DATE", err.Error(), nil)
+	case errors.Is(err, services.ErrInvoiceTransactionNotFound):
+		utils.ErrorResponse(c, http.StatusNotFound, "TRANSACTION_NOT_FOUND", "İşlem bulunamadı", details)
+	case errors.Is(err, services.ErrSalesOrderNotFound):
+		utils.ErrorResponse(c, http.StatusNotFound, "ORDER_NOT_FOUND", "Sipariş bulunamadı", nil)
+	case errors.Is(err, services.ErrCustomerNotFound):
+		utils.ErrorResponse(c, http.StatusNotFound, "CUSTOMER_NOT_FOUND", "Müşteri bulunamadı", nil)
+	case errors.Is(err, services.ErrInvoiceAlreadyInvoiced):
+		utils.ErrorResponse(c, http.StatusConflict, "ALREADY_INVOICED", "Faturası daha önce kesilmiş", details)
+	case errors.Is(err, services.ErrInvoiceNotBillable):
+		utils.ErrorResponse(c, http.StatusUnprocessableEntity, "TRANSACTION_NOT_BILLABLE", err.Error(), details)
+	case errors.Is(err, services.ErrInvoiceCurrencyMismatch):
+		utils.ErrorResponse(c, http.StatusUnprocessableEntity, "CURRENCY_MISMATCH", err.Error(), details)
+	case errors.Is(err, services.ErrInvoiceOrderCancelled):
+		utils.ErrorResponse(c, http.StatusConflict, "ORDER_CANCELLED", err.Error(), nil)
+	case errors.Is(err, services.ErrFieldEncryptionDisabled), errors.Is(err, services.ErrFieldKeyUnavailable):
+		utils.ErrorResponse(c, http.StatusInternalServerError, "ENCRYPTION_ERROR", "Alıcı bilgileri şifrelenemedi", err.Error())
+	default:
+		utils.ErrorResponse(c, http.StatusInternalServerError, "DB_ERROR", message, err.Error())
+	}
+}
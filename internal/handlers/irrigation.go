@@ -0,0 +1,89 @@
+package handlers
+
+import (
+	"database/sql"
+	"errors"
+	"net/http"
+	"strconv"
+	"time"
+
+	"agri-management-api/internal/services"
+	"agri-management-api/internal/utils"
+
+	"github.com/gin-gonic/gin"
+)
+
+// IrrigationHandler arazilerin hava tahminine dayalı sulama takvimini yönetir
+type IrrigationHandler struct {
+	irrigation *services.IrrigationService
+	flags      *services.FeatureFlagService
+}
+
+// NewIrrigationHandler yeni irrigation handler oluşturur
+func NewIrrigationHandler(db *sql.DB) *IrrigationHandler {
+	return &IrrigationHandler{
+		irrigation: services.NewIrrigationService(db),
+		flags:      services.NewFeatureFlagService(db),
+	}
+}
+
+// GetIrrigationSchedule arazinin sulama takvimi
+// @Summary Arazinin sulama takvimi
+// @Description Arazinin toprak türü (soilType), sulama türü (irrigationType), ürünün gelişim evresi, son sulama etkinliği ve hava tahmininden günlük su dengesini hesaplar. Bitki su tüketimi Hargreaves yöntemiyle bulunur; su açığı sulama yönteminin eşiğine ulaştığı gün sulama önerilir ve uygulanacak su miktarı (mm ve m³) yöntemin randımanına göre verilir. Ertesi gün yüksek olasılıkla yeterli yağış bekleniyorsa sulama ertelenir. Arazinin sulama türü ve konumu girilmiş olmalıdır
+// @ID getIrrigationSchedule
+// @Tags Lands
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Arazi ID"
+// @Param days query int false "Gün sayısı (varsayılan: 5, en fazla 7)"
+// @Success 200 {object} models.APIResponse{data=models.IrrigationSchedule}
+// @Failure 401 {object} models.APIResponse
+// @Failure 404 {object} models.APIResponse
+// @Failure 409 {object} models.APIResponse
+// @Failure 503 {object} models.APIResponse
+// @Router /lands/{id}/irrigation-schedule [get]
+func (h *IrrigationHandler) GetIrrigationSchedule(c *gin.Context) {
+	userID, err := utils.GetUserID(c)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusUnauthorized, "UNAUTHORIZED", "Kullanıcı kimliği doğrulanamadı", nil)
+		return
+	}
+
+	days, err := strconv.Atoi(c.DefaultQuery("days", strconv.Itoa(services.IrrigationForecastDays)))
+	if err != nil || days < 1 || days > 7 {
+		days = services.IrrigationForecastDays
+	}
+
+	flag, exists := h.flags.Get(services.FlagMockWeather, userID)
+	mock := exists && flag.Enabled
+
+	schedule, err := h.irrigation.Schedule(userID, c.Param("id"), days, mock, time.Now())
+	if err != nil {
+		writeIrrigationError(c, err, "Sulama takvimi hesaplanamadı")
+		return
+	}
+	if schedule.ForecastSource == services.IrrigationForecastMock {
+		c.Header("X-Mock-Data", "true")
+		if flag.Deprecated {
+			utils.MarkDeprecated(c, flag.DeprecationNote)
+		}
+	}
+
+	utils.SuccessResponse(c, schedule, "Sulama takvimi başarıyla getirildi")
+}
+
+// writeIrrigationError servis hatasını HTTP yanıtına çevirir
+func writeIrrigationError(c *gin.Context, err error, message string) {
+	switch {
+	case errors.Is(err, services.ErrIrrigationLand):
+		utils.ErrorResponse(c, http.StatusNotFound, "LAND_NOT_FOUND", "Arazi bulunamadı", nil)
+	case errors.Is(err, services.ErrIrrigationNotIrrigated):
+		utils.ErrorResponse(c, http.StatusConflict, "LAND_NOT_IRRIGATED", err.Error(), nil)
+	case errors.Is(err, services.ErrIrrigationLocation):
+		utils.ErrorResponse(c, http.StatusConflict, "LAND_LOCATION_REQUIRED", err.Error(), nil)
+	case errors.Is(err, services.ErrIrrigationForecast):
+		utils.ErrorResponse(c, http.StatusServiceUnavailable, "WEATHER_UNAVAILABLE", "Hava durumu tahmini alınamadı", err.Error())
+	default:
+		utils.ErrorResponse(c, http.StatusInternalServerError, "DB_ERROR", message, err.Error())
+	}
+}
@@ -0,0 +1,88 @@
+package handlers
+
+import (
+	"net/http"
+	"time"
+
+	"agri-management-api/internal/utils"
+
+	"github.com/gin-gonic/gin"
+)
+
+// defaultKPISnapshotDays from verilmediğinde listelenen gün sayısı
+const defaultKPISnapshotDays = 90
+
+// GetKPISnapshots KPI anlık görüntüleri
+// @Summary KPI anlık görüntüleri
+// @Description Gecelik alınan sürü büyüklüğü, stok değeri, nakit bakiyesi ve toplam alan görüntülerini tarihe göre sıralı döner. Görüntüler kayıtlar sonradan düzenlense veya silinse de değişmez; aynı değerler /analytics/timeseries üzerinden herd_size, stock_value, cash_balance ve total_area metrikleri olarak da sorgulanabilir
+// @ID getKpiSnapshots
+// @Tags Dashboard
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param from query string false "Başlangıç tarihi (YYYY-MM-DD, varsayılan: 90 gün önce)"
+// @Param to query string false "Bitiş tarihi (YYYY-MM-DD, varsayılan: bugün)"
+// @Success 200 {object} models.APIResponse{data=[]models.KPISnapshot}
+// @Failure 400 {object} models.APIResponse
+// @Failure 401 {object} models.APIResponse
+// @Router /dashboard/kpi-snapshots [get]
+func (h *DashboardHandler) GetKPISnapshots(c *gin.Context) {
+	userID, err := utils.GetUserID(c)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusUnauthorized, "UNAUTHORIZED", "Kullanıcı kimliği doğrulanamadı", nil)
+		return
+	}
+
+	from, ok := optionalDateQuery(c, "from")
+	if !ok {
+		return
+	}
+	to, ok := optionalDateQuery(c, "to")
+	if !ok {
+		return
+	}
+
+	end := time.Now().UTC()
+	if to != nil {
+		end = *to
+	}
+	start := end.AddDate(0, 0, -defaultKPISnapshotDays)
+	if from != nil {
+		start = *from
+	}
+
+	snapshots, err := h.kpis.List(userID, start, end)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusInternalServerError, "DB_ERROR", "KPI anlık görüntüleri alınamadı", err.Error())
+		return
+	}
+
+	utils.SuccessResponse(c, snapshots, "KPI anlık görüntüleri başarıyla getirildi")
+}
+
+// CaptureKPISnapshot KPI anlık görüntüsü alma
+// @Summary KPI anlık görüntüsü al
+// @Description Çiftliğin güncel KPI'larını hemen hesaplayıp bugünün anlık görüntüsü olarak kaydeder; bugün için görüntü varsa güncellenir. Görüntüler normalde gecelik iş tarafından otomatik alınır
+// @ID captureKpiSnapshot
+// @Tags Dashboard
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {object} models.APIResponse{data=models.KPISnapshot}
+// @Failure 401 {object} models.APIResponse
+// @Router /dashboard/kpi-snapshots [post]
+func (h *DashboardHandler) CaptureKPISnapshot(c *gin.Context) {
+	userID, err := utils.GetUserID(c)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusUnauthorized, "UNAUTHORIZED", "Kullanıcı kimliği doğrulanamadı", nil)
+		return
+	}
+
+	snapshot, err := h.kpis.Capture(userID, time.Now())
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusInternalServerError, "DB_ERROR", "KPI anlık görüntüsü alınamadı", err.Error())
+		return
+	}
+
+	utils.SuccessResponse(c, snapshot, "KPI anlık görüntüsü başarıyla alındı")
+}
@@ -2,9 +2,12 @@ package handlers
 
 import (
 	"database/sql"
+	"log"
 	"net/http"
+	"time"
 
 	"agri-management-api/internal/models"
+	"agri-management-api/internal/services"
 	"agri-management-api/internal/utils"
 
 	"github.com/gin-gonic/gin"
@@ -12,41 +15,44 @@ import (
 
 // LandHandler arazi işlemlerini yönetir
 type LandHandler struct {
-	db *sql.DB
+	db              *sql.DB
+	weather         *services.WeatherHistoryService
+	history         *services.ChangeHistoryService
+	parcels         services.ParcelProvider
+	recommendations *services.LandRecommendationService
+	costs           *services.LandCostService
+	payroll         *services.HarvestPayrollService
+	waterQuotas     *services.WaterQuotaService
+	eventRules      *services.EventRuleService
+	workers         *services.WorkerService
+	enterprises     *services.EnterpriseService
+	formats         *services.FormattingService
+	areas           *services.LandAreaService
 }
 
 // NewLandHandler yeni land handler oluşturur
 func NewLandHandler(db *sql.DB) *LandHandler {
-	return &LandHandler{db: db}
+	return &LandHandler{
+		db:              db,
+		weather:         services.NewWeatherHistoryService(db),
+		history:         services.NewChangeHistoryService(db),
+		parcels:         services.NewParcelProvider(),
+		recommendations: services.NewLandRecommendationService(db),
+		costs:           services.NewLandCostService(db),
+		payroll:         services.NewHarvestPayrollService(db),
+		waterQuotas:     services.NewWaterQuotaService(db),
+		eventRules:      services.NewEventRuleService(db),
+		workers:         services.NewWorkerService(db),
+		enterprises:     services.NewEnterpriseService(db),
+		formats:         services.NewFormattingService(db),
+		areas:           services.NewLandAreaService(db),
+	}
 }
 
 // GetLands arazi listesi
 // @Summary Arazi listesi
-// @Description Kullanıcının ar// GetLandActivities arazi aktiviteleri
-// @Summary Arazi aktiviteleri
-// @Descri// CreateLandActivity arazi aktivitesi oluşturma
-// @Summary Arazi aktivitesi oluşturma
-// @Description Arazi için yeni aktivite oluşturur
-// @Tags Lands
-// @Accept json
-// @Produce json
-// @Security BearerAuth
-// @Param id path string true "Arazi ID"
-// @Param request body models.LandActivityRecord true "Aktivite bilgileri"
-// @Success 201 {object} models.APIResponse{data=models.LandActivityRecord}
-// @Failure 400 {object} models.APIResponse
-// @Failure 401 {object} models.APIResponse
-// @Failure 404 {object} models.APIResponse
-// @Router /lands/{id}/activities [post]in aktiviteleri listeler
-// @Tags Lands
-// @Accept json
-// @Produce json
-// @Security BearerAuth
-// @Param id path string true "Arazi ID"
-// @Success 200 {object} models.APIResponse{data=[]models.LandActivityRecord}
-// @Failure 401 {object} models.APIResponse
-// @Failure 404 {object} models.APIResponse
-// @Router /lands/{id}/activities [get]steler
+// @Description Kullanıcının arazilerini listeler
+// @ID getLands
 // @Tags Lands
 // @Accept json
 // @Produce json
@@ -54,7 +60,9 @@ func NewLandHandler(db *sql.DB) *LandHandler {
 // @Param page query int false "Sayfa numarası"
 // @Param limit query int false "Sayfa başına kayıt"
 // @Param status query string false "Arazi durumu"
-// @Success 200 {object} models.APIResponse{data=map[string]interface{}}
+// @Param type query string false "Arazi türü (field, greenhouse)"
+// @Param enterpriseId query string false "Faaliyet kolu ID"
+// @Success 200 {object} models.APIResponse{data=models.LandListResponse}
 // @Failure 401 {object} models.APIResponse
 // @Router /lands [get]
 func (h *LandHandler) GetLands(c *gin.Context) {
@@ -76,6 +84,14 @@ func (h *LandHandler) GetLands(c *gin.Context) {
 		whereClause += " AND status = ?"
 		args = append(args, status)
 	}
+	if landType := c.Query("type"); landType != "" {
+		whereClause += " AND COALESCE(land_type, 'field') = ?"
+		args = append(args, landType)
+	}
+	if enterpriseID := c.Query("enterpriseId"); enterpriseID != "" {
+		whereClause += " AND enterprise_id = ?"
+		args = append(args, enterpriseID)
+	}
 
 	err = h.db.QueryRow("SELECT COUNT(*) FROM lands "+whereClause, args...).Scan(&total)
 	if err != nil {
@@ -91,7 +107,7 @@ func (h *LandHandler) GetLands(c *gin.Context) {
 	query := `
 		SELECT id, user_id, name, area, unit, crop, status, last_activity, 
 		       productivity, latitude, longitude, address, soil_type, irrigation_type,
-		       created_at, updated_at
+		       COALESCE(land_type, 'field'), enterprise_id, created_at, updated_at, ` + landParcelColumns + `
 		FROM lands ` + whereClause + `
 		ORDER BY created_at DESC LIMIT ? OFFSET ?
 	`
@@ -110,16 +126,18 @@ func (h *LandHandler) GetLands(c *gin.Context) {
 		var lastActivity sql.NullTime
 		var latitude, longitude sql.NullFloat64
 		var address string
+		var parcel landParcelScan
 
-		err := rows.Scan(
+		err := rows.Scan(append([]interface{}{
 			&land.ID, &land.UserID, &land.Name, &land.Area, &land.Unit, &land.Crop,
 			&land.Status, &lastActivity, &land.Productivity, &latitude, &longitude,
-			&address, &land.SoilType, &land.IrrigationType, &land.CreatedAt, &land.UpdatedAt,
-		)
+			&address, &land.SoilType, &land.IrrigationType, &land.Type, &land.EnterpriseID, &land.CreatedAt, &land.UpdatedAt,
+		}, parcel.dest()...)...)
 		if err != nil {
 			continue
 		}
 
+		parcel.apply(&land)
 		land.LastActivity = utils.NullTimeToPtr(lastActivity)
 		if latitude.Valid && longitude.Valid {
 			land.Location = models.Location{
@@ -132,9 +150,9 @@ func (h *LandHandler) GetLands(c *gin.Context) {
 		lands = append(lands, land)
 	}
 
-	response := map[string]interface{}{
-		"lands":      lands,
-		"pagination": pagination,
+	response := models.LandListResponse{
+		Lands:      lands,
+		Pagination: pagination,
 	}
 
 	utils.SuccessResponse(c, response, "Araziler başarıyla getirildi")
@@ -142,11 +160,13 @@ func (h *LandHandler) GetLands(c *gin.Context) {
 
 // CreateLand yeni arazi oluşturma
 // @Summary Yeni arazi oluşturma
-// @Description Yeni arazi kaydı oluşturur
+// @Description Yeni arazi kaydı oluşturur. Sınır (boundary) verilirse girilen alan sınırdan hesaplanan alanla karşılaştırılır ve sonuç areaCheck alanında döner; fark çiftlik ayarlarındaki toleransı (landArea.tolerancePercent) aşarsa landArea.autoCorrect açıkken veya correctArea=true ile alan hesaplanan alanla değiştirilir
+// @ID createLand
 // @Tags Lands
 // @Accept json
 // @Produce json
 // @Security BearerAuth
+// @Param correctArea query bool false "Fark toleransı aşarsa alanı sınırdan hesaplanan alanla değiştir"
 // @Param request body models.Land true "Arazi bilgileri"
 // @Success 201 {object} models.APIResponse{data=models.Land}
 // @Failure 400 {object} models.APIResponse
@@ -171,53 +191,76 @@ func (h *LandHandler) CreateLand(c *gin.Context) {
 		return
 	}
 
+	if req.Type == "" {
+		req.Type = models.LandTypeField
+	}
+
 	landID := utils.GenerateID()
 
+	if !h.validateLandParcel(c, &req, userID, landID) {
+		return
+	}
+	if !h.validateLandArea(c, &req, userID) {
+		return
+	}
+	if !validateEnterprise(c, h.enterprises, userID, &req.EnterpriseID) {
+		return
+	}
+
 	// Araziyi oluştur
 	_, err = h.db.Exec(`
 		INSERT INTO lands (id, user_id, name, area, unit, crop, status, productivity,
-		                  latitude, longitude, address, soil_type, irrigation_type,
-		                  created_at, updated_at)
-		VALUES (?, ?, ?, ?, ?, ?, 'active', 0, ?, ?, ?, ?, ?, CURRENT_TIMESTAMP, CURRENT_TIMESTAMP)
-	`, landID, userID, req.Name, req.Area, req.Unit, req.Crop,
+		                  latitude, longitude, address, soil_type, irrigation_type, land_type,
+		                  parcel_province, parcel_district, parcel_neighborhood, parcel_neighborhood_code,
+		                  parcel_block, parcel_number, boundary, enterprise_id, created_at, updated_at)
+		VALUES (?, ?, ?, ?, ?, ?, 'active', 0, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, CURRENT_TIMESTAMP, CURRENT_TIMESTAMP)
+	`, append(append([]interface{}{landID, userID, req.Name, req.Area, req.Unit, req.Crop,
 		req.Location.Latitude, req.Location.Longitude, req.Location.Address,
-		req.SoilType, req.IrrigationType)
+		req.SoilType, req.IrrigationType, req.Type}, landParcelValues(req)...), req.EnterpriseID)...)
 
 	if err != nil {
 		utils.ErrorResponse(c, http.StatusInternalServerError, "DB_ERROR", "Arazi oluşturulamadı", err.Error())
 		return
 	}
 
+	// Sera olarak oluşturulan arazi için varsayılan iklim ayarlarını aç
+	if req.Type == models.LandTypeGreenhouse {
+		h.db.Exec(`
+			INSERT INTO greenhouses (land_id, user_id, created_at, updated_at)
+			VALUES (?, ?, CURRENT_TIMESTAMP, CURRENT_TIMESTAMP)
+		`, landID, userID)
+	}
+
 	// Oluşturulan araziyi getir
 	var land models.Land
 	var latitude, longitude sql.NullFloat64
 	var address string
+	var parcel landParcelScan
 	err = h.db.QueryRow(`
 		SELECT id, user_id, name, area, unit, crop, status, last_activity, 
 		       productivity, latitude, longitude, address, soil_type, irrigation_type,
-		       created_at, updated_at
-		FROM lands WHERE id = ?
-	`, landID).Scan(
+		       COALESCE(land_type, 'field'), enterprise_id, created_at, updated_at, `+landParcelColumns+`
+		FROM lands WHERE id = ? AND user_id = ?
+	`, landID, userID).Scan(append([]interface{}{
 		&land.ID, &land.UserID, &land.Name, &land.Area, &land.Unit, &land.Crop,
 		&land.Status, &land.LastActivity, &land.Productivity, &latitude, &longitude,
-		&address, &land.SoilType, &land.IrrigationType, &land.CreatedAt, &land.UpdatedAt,
-	)
+		&address, &land.SoilType, &land.IrrigationType, &land.Type, &land.EnterpriseID, &land.CreatedAt, &land.UpdatedAt,
+	}, parcel.dest()...)...)
 
 	if err != nil {
 		utils.ErrorResponse(c, http.StatusInternalServerError, "FETCH_ERROR", "Oluşturulan arazi getirilemedi", err.Error())
 		return
 	}
+	parcel.apply(&land)
+	land.AreaCheck, _ = h.areas.Check(userID, land)
 
-	c.JSON(http.StatusCreated, models.APIResponse{
-		Success: true,
-		Data:    land,
-		Message: "Arazi başarıyla oluşturuldu",
-	})
+	utils.CreatedResponse(c, land, "Arazi başarıyla oluşturuldu")
 }
 
 // GetLand arazi detayları
 // @Summary Arazi detayları
 // @Description Belirli bir arazinin detaylarını getirir
+// @ID getLand
 // @Tags Lands
 // @Accept json
 // @Produce json
@@ -244,17 +287,18 @@ func (h *LandHandler) GetLand(c *gin.Context) {
 	var lastActivity sql.NullTime
 	var latitude, longitude sql.NullFloat64
 	var address string
+	var parcel landParcelScan
 
 	err = h.db.QueryRow(`
 		SELECT id, user_id, name, area, unit, crop, status, last_activity, 
 		       productivity, latitude, longitude, address, soil_type, irrigation_type,
-		       created_at, updated_at
+		       COALESCE(land_type, 'field'), enterprise_id, created_at, updated_at, `+landParcelColumns+`
 		FROM lands WHERE id = ? AND user_id = ?
-	`, landID, userID).Scan(
+	`, landID, userID).Scan(append([]interface{}{
 		&land.ID, &land.UserID, &land.Name, &land.Area, &land.Unit, &land.Crop,
 		&land.Status, &lastActivity, &land.Productivity, &latitude, &longitude,
-		&address, &land.SoilType, &land.IrrigationType, &land.CreatedAt, &land.UpdatedAt,
-	)
+		&address, &land.SoilType, &land.IrrigationType, &land.Type, &land.EnterpriseID, &land.CreatedAt, &land.UpdatedAt,
+	}, parcel.dest()...)...)
 
 	if err != nil {
 		if err == sql.ErrNoRows {
@@ -265,6 +309,8 @@ func (h *LandHandler) GetLand(c *gin.Context) {
 		return
 	}
 
+	parcel.apply(&land)
+	land.AreaCheck, _ = h.areas.Check(userID, land)
 	land.LastActivity = utils.NullTimeToPtr(lastActivity)
 	if latitude.Valid && longitude.Valid {
 		land.Location = models.Location{
@@ -278,17 +324,19 @@ func (h *LandHandler) GetLand(c *gin.Context) {
 		}
 	}
 
-	utils.SuccessResponse(c, land, "Arazi detayları başarıyla getirildi")
+	utils.DetailResponse(c, land, services.EntityLinks("land", land.ID), "Arazi detayları başarıyla getirildi")
 }
 
 // UpdateLand arazi güncelleme
 // @Summary Arazi güncelleme
-// @Description Mevcut arazi bilgilerini günceller
+// @Description Mevcut arazi bilgilerini günceller. Sınırı olan arazide alan, oluşturmadaki gibi sınırdan hesaplanan alanla doğrulanır ve gerekirse düzeltilir
+// @ID updateLand
 // @Tags Lands
 // @Accept json
 // @Produce json
 // @Security BearerAuth
 // @Param id path string true "Arazi ID"
+// @Param correctArea query bool false "Fark toleransı aşarsa alanı sınırdan hesaplanan alanla değiştir"
 // @Param request body models.Land true "Güncellenecek arazi bilgileri"
 // @Success 200 {object} models.APIResponse{data=models.Land}
 // @Failure 400 {object} models.APIResponse
@@ -314,16 +362,34 @@ func (h *LandHandler) UpdateLand(c *gin.Context) {
 		return
 	}
 
-	// Araziyi güncelle
-	_, err = h.db.Exec(`
-		UPDATE lands 
-		SET name = ?, area = ?, unit = ?, crop = ?, status = ?, productivity = ?,
-		    latitude = ?, longitude = ?, address = ?, soil_type = ?, irrigation_type = ?,
-		    updated_at = CURRENT_TIMESTAMP
-		WHERE id = ? AND user_id = ?
-	`, req.Name, req.Area, req.Unit, req.Crop, req.Status, req.Productivity,
-		req.Location.Latitude, req.Location.Longitude, req.Location.Address,
-		req.SoilType, req.IrrigationType, landID, userID)
+	if !h.validateLandParcel(c, &req, userID, landID) {
+		return
+	}
+	if !h.validateLandArea(c, &req, userID) {
+		return
+	}
+	if !validateEnterprise(c, h.enterprises, userID, &req.EnterpriseID) {
+		return
+	}
+
+	// Araziyi güncelle ve değişen alanları geçmişe kaydet
+	err = h.history.Track(h.db, services.HistoryEntityLand, userID, landID, userID, func() error {
+		args := []interface{}{req.Name, req.Area, req.Unit, req.Crop, req.Status, req.Productivity,
+			req.Location.Latitude, req.Location.Longitude, req.Location.Address,
+			req.SoilType, req.IrrigationType}
+		args = append(args, landParcelValues(req)...)
+		args = append(args, req.EnterpriseID)
+		_, err := h.db.Exec(`
+			UPDATE lands 
+			SET name = ?, area = ?, unit = ?, crop = ?, status = ?, productivity = ?,
+			    latitude = ?, longitude = ?, address = ?, soil_type = ?, irrigation_type = ?,
+			    parcel_province = ?, parcel_district = ?, parcel_neighborhood = ?, parcel_neighborhood_code = ?,
+			    parcel_block = ?, parcel_number = ?, boundary = ?, enterprise_id = ?,
+			    updated_at = CURRENT_TIMESTAMP
+			WHERE id = ? AND user_id = ?
+		`, append(args, landID, userID)...)
+		return err
+	})
 
 	if err != nil {
 		utils.ErrorResponse(c, http.StatusInternalServerError, "UPDATE_ERROR", "Arazi güncellenemedi", err.Error())
@@ -337,14 +403,17 @@ func (h *LandHandler) UpdateLand(c *gin.Context) {
 // DeleteLand arazi silme
 // @Summary Arazi silme
 // @Description Belirli bir araziyi siler
+// @ID deleteLand
 // @Tags Lands
 // @Accept json
 // @Produce json
 // @Security BearerAuth
 // @Param id path string true "Arazi ID"
+// @Param force query bool false "Başka kayıtlarla bağlantısı olsa da sil"
 // @Success 200 {object} models.APIResponse
 // @Failure 401 {object} models.APIResponse
 // @Failure 404 {object} models.APIResponse
+// @Failure 409 {object} models.APIResponse
 // @Router /lands/{id} [delete]
 func (h *LandHandler) DeleteLand(c *gin.Context) {
 	userID, err := utils.GetUserID(c)
@@ -359,6 +428,10 @@ func (h *LandHandler) DeleteLand(c *gin.Context) {
 		return
 	}
 
+	if !confirmLinkedDelete(c, h.db, userID, "land", landID) {
+		return
+	}
+
 	// Araziyi sil
 	result, err := h.db.Exec("DELETE FROM lands WHERE id = ? AND user_id = ?", landID, userID)
 	if err != nil {
@@ -372,17 +445,21 @@ func (h *LandHandler) DeleteLand(c *gin.Context) {
 		return
 	}
 
+	removeRecordLinks(h.db, userID, "land", landID)
+	removeEntityHistory(h.history, userID, services.HistoryEntityLand, landID)
+
 	utils.SuccessResponse(c, nil, "Arazi başarıyla silindi")
 }
 
 // GetLandStatistics arazi istatistikleri
 // @Summary Arazi istatistikleri
 // @Description Arazi istatistiklerini getirir
+// @ID getLandStatistics
 // @Tags Lands
 // @Accept json
 // @Produce json
 // @Security BearerAuth
-// @Success 200 {object} models.APIResponse{data=map[string]interface{}}
+// @Success 200 {object} models.APIResponse{data=models.LandStatistics}
 // @Failure 401 {object} models.APIResponse
 // @Router /lands/statistics [get]
 func (h *LandHandler) GetLandStatistics(c *gin.Context) {
@@ -416,15 +493,15 @@ func (h *LandHandler) GetLandStatistics(c *gin.Context) {
 	h.db.QueryRow("SELECT COUNT(*) FROM lands WHERE user_id = ? AND status = 'inactive'", userID).Scan(&inactiveLands)
 	h.db.QueryRow("SELECT COUNT(*) FROM lands WHERE user_id = ? AND status = 'maintenance'", userID).Scan(&maintenanceLands)
 
-	statistics := map[string]interface{}{
-		"totalArea":           totalArea,
-		"totalLands":          totalLands,
-		"averageProductivity": avgProductivity,
-		"activeCrops":         activeCrops,
-		"landsByStatus": map[string]int{
-			"active":      activeLands,
-			"inactive":    inactiveLands,
-			"maintenance": maintenanceLands,
+	statistics := models.LandStatistics{
+		TotalArea:           totalArea,
+		TotalLands:          totalLands,
+		AverageProductivity: avgProductivity,
+		ActiveCrops:         activeCrops,
+		LandsByStatus: models.LandStatusCounts{
+			Active:      activeLands,
+			Inactive:    inactiveLands,
+			Maintenance: maintenanceLands,
 		},
 	}
 
@@ -434,12 +511,13 @@ func (h *LandHandler) GetLandStatistics(c *gin.Context) {
 // GetProductivityAnalysis verimlilik analizi
 // @Summary Verimlilik analizi
 // @Description Arazi verimlilik analizini getirir
+// @ID getProductivityAnalysis
 // @Tags Lands
 // @Accept json
 // @Produce json
 // @Security BearerAuth
 // @Param period query string false "Analiz periyodu"
-// @Success 200 {object} models.APIResponse{data=map[string]interface{}}
+// @Success 200 {object} models.APIResponse{data=models.ProductivityAnalysis}
 // @Failure 401 {object} models.APIResponse
 // @Router /lands/productivity-analysis [get]
 func (h *LandHandler) GetProductivityAnalysis(c *gin.Context) {
@@ -466,12 +544,12 @@ func (h *LandHandler) GetProductivityAnalysis(c *gin.Context) {
 		return
 	}
 
-	analysis := map[string]interface{}{
-		"period":              period,
-		"averageProductivity": avgProductivity,
-		"maxProductivity":     maxProductivity,
-		"minProductivity":     minProductivity,
-		"totalLands":          0, // Bu değer daha sonra hesaplanabilir
+	analysis := models.ProductivityAnalysis{
+		Period:              period,
+		AverageProductivity: avgProductivity,
+		MaxProductivity:     maxProductivity,
+		MinProductivity:     minProductivity,
+		TotalLands:          0, // Bu değer daha sonra hesaplanabilir
 	}
 
 	utils.SuccessResponse(c, analysis, "Verimlilik analizi başarıyla getirildi")
@@ -480,6 +558,7 @@ func (h *LandHandler) GetProductivityAnalysis(c *gin.Context) {
 // GetLandActivities arazi aktiviteleri
 // @Summary Arazi aktiviteleri
 // @Description Belirli bir arazinin aktivitelerini listeler
+// @ID getLandActivities
 // @Tags Lands
 // @Accept json
 // @Produce json
@@ -513,33 +592,46 @@ func (h *LandHandler) GetLandActivities(c *gin.Context) {
 	// Aktivite listesini getir
 	rows, err := h.db.Query(`
 		SELECT id, land_id, type, description, scheduled_date, actual_date,
-		       notes, cost, result, created_at
-		FROM land_activities WHERE land_id = ?
+		       notes, cost, result, fertilizer_kg, nitrogen_percent, water_volume, assigned_worker_id, created_at
+		FROM land_activities WHERE land_id = ? AND land_id IN (SELECT id FROM lands WHERE user_id = ?)
 		ORDER BY created_at DESC
-	`, landID)
+	`, landID, userID)
 	if err != nil {
 		utils.ErrorResponse(c, http.StatusInternalServerError, "DB_ERROR", "Aktivite listesi alınamadı", err.Error())
 		return
 	}
 	defer rows.Close()
 
+	costItems, err := h.costs.LandItems(userID, landID)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusInternalServerError, "DB_ERROR", "Aktivite maliyet kalemleri alınamadı", err.Error())
+		return
+	}
+
 	var activities []models.LandActivityRecord
 	for rows.Next() {
 		var activity models.LandActivityRecord
 		var scheduledDate, actualDate sql.NullTime
-		var cost sql.NullFloat64
+		var cost, fertilizerKg, nitrogenPercent, waterVolume sql.NullFloat64
+		var assignedWorker sql.NullString
 
 		err := rows.Scan(
 			&activity.ID, &activity.LandID, &activity.Type, &activity.Description,
-			&scheduledDate, &actualDate, &activity.Notes, &cost, &activity.Result, &activity.CreatedAt,
+			&scheduledDate, &actualDate, &activity.Notes, &cost, &activity.Result,
+			&fertilizerKg, &nitrogenPercent, &waterVolume, &assignedWorker, &activity.CreatedAt,
 		)
 		if err != nil {
 			continue
 		}
+		activity.AssignedWorkerID = utils.NullStringToPtr(assignedWorker)
 
 		activity.ScheduledDate = utils.NullTimeToPtr(scheduledDate)
 		activity.ActualDate = utils.NullTimeToPtr(actualDate)
 		activity.Cost = utils.NullFloat64ToPtr(cost)
+		activity.FertilizerKg = utils.NullFloat64ToPtr(fertilizerKg)
+		activity.NitrogenPercent = utils.NullFloat64ToPtr(nitrogenPercent)
+		activity.WaterVolume = utils.NullFloat64ToPtr(waterVolume)
+		activity.CostItems = costItems[activity.ID]
 
 		activities = append(activities, activity)
 	}
@@ -549,7 +641,8 @@ func (h *LandHandler) GetLandActivities(c *gin.Context) {
 
 // CreateLandActivity arazi aktivitesi oluşturma
 // @Summary Arazi aktivitesi oluşturma
-// @Description Yeni arazi aktivitesi kaydı oluşturur
+// @Description Yeni arazi aktivitesi kaydı oluşturur. costItems verilirse kalemler değerlenir (girdi: stok birim maliyeti veya fiyatı; işçilik ve makine: makinenin veya ayarlardaki saatlik ücret) ve maliyet kalemlerin toplamı olur. Sulamalarda waterVolume (m³) verilirse gerçekleşen hacim araziyi kapsayan su kotalarından düşülür ve eşiğe ulaşan kotalar bildirilir. assignedWorkerId verilirse aktivite çalışana atanır; aktivite tarihinde çalışanın sözleşmesi veya aktivite türünün gerektirdiği belge geçerli değilse sorunlar assignmentIssues alanında döner ve bildirilir
+// @ID createLandActivity
 // @Tags Lands
 // @Accept json
 // @Produce json
@@ -588,32 +681,80 @@ func (h *LandHandler) CreateLandActivity(c *gin.Context) {
 		return
 	}
 
+	// Atanacak çalışan çiftliğe ait mi kontrol et
+	if req.AssignedWorkerID != nil {
+		if _, err := h.workers.Get(userID, *req.AssignedWorkerID); err != nil {
+			writeWorkerError(c, err, "Çalışan alınamadı")
+			return
+		}
+	}
+
+	// Maliyet kalemleri verildiyse değerle; aktivitenin maliyeti kalemlerin toplamıdır
+	costItems, costTotal, err := h.costs.Value(userID, req.CostItems)
+	if err != nil {
+		writeCostItemError(c, err)
+		return
+	}
+	if len(costItems) > 0 {
+		req.Cost = &costTotal
+	}
+
 	// Aktiviteyi oluştur
 	activityID := utils.GenerateID()
 	_, err = h.db.Exec(`
 		INSERT INTO land_activities (id, land_id, type, description, scheduled_date,
-		                           actual_date, notes, cost, result, created_at)
-		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, CURRENT_TIMESTAMP)
+		                           actual_date, notes, cost, result, fertilizer_kg, nitrogen_percent, water_volume, created_at)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, CURRENT_TIMESTAMP)
 	`, activityID, landID, req.Type, req.Description, req.ScheduledDate,
-		req.ActualDate, req.Notes, req.Cost, req.Result)
+		req.ActualDate, req.Notes, req.Cost, req.Result, req.FertilizerKg, req.NitrogenPercent, req.WaterVolume)
 
 	if err != nil {
 		utils.ErrorResponse(c, http.StatusInternalServerError, "DB_ERROR", "Aktivite oluşturulamadı", err.Error())
 		return
 	}
 
+	if len(costItems) > 0 {
+		if err := h.costs.Save(userID, activityID, costItems, costTotal); err != nil {
+			utils.ErrorResponse(c, http.StatusInternalServerError, "DB_ERROR", "Aktivite maliyet kalemleri kaydedilemedi", err.Error())
+			return
+		}
+	}
+
+	// Gerçekleşen sulama su kotalarından düşülür; eşiğe yaklaşan kotalar bildirilir, bildirim hatası kaydı engellemez
+	if req.WaterVolume != nil && (req.ActualDate != nil || req.ScheduledDate == nil) {
+		day := time.Now()
+		if req.ActualDate != nil {
+			day = *req.ActualDate
+		}
+		if err := h.waterQuotas.CheckLand(userID, landID, day); err != nil {
+			log.Printf("Su kotası kontrol edilemedi: %v", err)
+		}
+	}
+	h.eventRules.SyncQuietly(userID, models.EventRuleHarvestWindow)
+
+	// Çalışan atanırsa aktivite tarihindeki sözleşme ve belge geçerliliği denetlenir; sorunlar bildirilir
+	var assignment models.ActivityAssignment
+	if req.AssignedWorkerID != nil {
+		assignment, err = h.workers.Assign(userID, landID, activityID, req.AssignedWorkerID)
+		if err != nil {
+			writeWorkerError(c, err, "Çalışan atanamadı")
+			return
+		}
+	}
+
 	// Oluşturulan aktiviteyi getir
 	var activity models.LandActivityRecord
 	var scheduledDate, actualDate sql.NullTime
-	var cost sql.NullFloat64
+	var cost, fertilizerKg, nitrogenPercent, waterVolume sql.NullFloat64
 
 	err = h.db.QueryRow(`
 		SELECT id, land_id, type, description, scheduled_date, actual_date,
-		       notes, cost, result, created_at
-		FROM land_activities WHERE id = ?
-	`, activityID).Scan(
+		       notes, cost, result, fertilizer_kg, nitrogen_percent, water_volume, created_at
+		FROM land_activities WHERE id = ? AND land_id IN (SELECT id FROM lands WHERE user_id = ?)
+	`, activityID, userID).Scan(
 		&activity.ID, &activity.LandID, &activity.Type, &activity.Description,
-		&scheduledDate, &actualDate, &activity.Notes, &cost, &activity.Result, &activity.CreatedAt,
+		&scheduledDate, &actualDate, &activity.Notes, &cost, &activity.Result,
+		&fertilizerKg, &nitrogenPercent, &waterVolume, &activity.CreatedAt,
 	)
 
 	if err != nil {
@@ -624,10 +765,360 @@ func (h *LandHandler) CreateLandActivity(c *gin.Context) {
 	activity.ScheduledDate = utils.NullTimeToPtr(scheduledDate)
 	activity.ActualDate = utils.NullTimeToPtr(actualDate)
 	activity.Cost = utils.NullFloat64ToPtr(cost)
+	activity.FertilizerKg = utils.NullFloat64ToPtr(fertilizerKg)
+	activity.NitrogenPercent = utils.NullFloat64ToPtr(nitrogenPercent)
+	activity.WaterVolume = utils.NullFloat64ToPtr(waterVolume)
+	activity.AssignedWorkerID = assignment.WorkerID
+	activity.AssignmentIssues = assignment.Issues
+
+	if len(costItems) > 0 {
+		breakdown, err := h.costs.Breakdown(userID, activityID)
+		if err != nil {
+			utils.ErrorResponse(c, http.StatusInternalServerError, "FETCH_ERROR", "Aktivite maliyet kalemleri getirilemedi", err.Error())
+			return
+		}
+		activity.CostItems = breakdown.Items
+	}
 
-	c.JSON(http.StatusCreated, models.APIResponse{
-		Success: true,
-		Data:    activity,
-		Message: "Arazi aktivitesi başarıyla oluşturuldu",
-	})
+	utils.CreatedResponse(c, activity, "Arazi aktivitesi başarıyla oluşturuldu")
+}
+
+// GetWeatherHistory arazi hava geçmişi
+// @Summary Arazi hava geçmişi
+// @Description Arazi konumu için sağlayıcı, istasyon ve kullanıcı gözlemlerini gün bazında birleştirerek (kullanıcı ölçümü önceliklidir, eksik değerler arazinin kaynak sırasına göre tamamlanır); yağış birikimi, sıcaklık uçları, don günleri ve geçen yılın aynı dönemiyle karşılaştırma ile getirir
+// @ID getWeatherHistory
+// @Tags Lands
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Arazi ID"
+// @Param startDate query string false "Başlangıç tarihi (YYYY-MM-DD, varsayılan: 30 gün önce)"
+// @Param endDate query string false "Bitiş tarihi (YYYY-MM-DD, varsayılan: bugün)"
+// @Success 200 {object} models.APIResponse{data=models.WeatherHistory}
+// @Failure 400 {object} models.APIResponse
+// @Failure 401 {object} models.APIResponse
+// @Failure 404 {object} models.APIResponse
+// @Router /lands/{id}/weather-history [get]
+func (h *LandHandler) GetWeatherHistory(c *gin.Context) {
+	userID, err := utils.GetUserID(c)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusUnauthorized, "UNAUTHORIZED", "Kullanıcı kimliği doğrulanamadı", nil)
+		return
+	}
+
+	landID := c.Param("id")
+	if utils.IsEmptyString(landID) {
+		utils.ErrorResponse(c, http.StatusBadRequest, "MISSING_ID", "Arazi ID gerekli", nil)
+		return
+	}
+
+	// Arazi kullanıcıya ait mi kontrol et
+	var exists bool
+	err = h.db.QueryRow("SELECT 1 FROM lands WHERE id = ? AND user_id = ?", landID, userID).Scan(&exists)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusNotFound, "LAND_NOT_FOUND", "Arazi bulunamadı", nil)
+		return
+	}
+
+	start, end, ok := weatherDateRange(c)
+	if !ok {
+		return
+	}
+
+	history, err := h.weather.History(userID, landID, start, end)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusInternalServerError, "DB_ERROR", "Hava geçmişi alınamadı", err.Error())
+		return
+	}
+
+	utils.SuccessResponse(c, history, "Hava geçmişi başarıyla getirildi")
+}
+
+// GetWeatherObservations arazi hava gözlemleri
+// @Summary Arazi hava gözlemleri
+// @Description Arazi için kaydedilen ham hava gözlemlerini kaynak bilgisiyle listeler
+// @ID getWeatherObservations
+// @Tags Lands
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Arazi ID"
+// @Param source query string false "Kaynak (provider, station, manual)"
+// @Param startDate query string false "Başlangıç tarihi (YYYY-MM-DD, varsayılan: 30 gün önce)"
+// @Param endDate query string false "Bitiş tarihi (YYYY-MM-DD, varsayılan: bugün)"
+// @Success 200 {object} models.APIResponse{data=[]models.WeatherObservation}
+// @Failure 400 {object} models.APIResponse
+// @Failure 401 {object} models.APIResponse
+// @Failure 404 {object} models.APIResponse
+// @Router /lands/{id}/weather-observations [get]
+func (h *LandHandler) GetWeatherObservations(c *gin.Context) {
+	userID, err := utils.GetUserID(c)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusUnauthorized, "UNAUTHORIZED", "Kullanıcı kimliği doğrulanamadı", nil)
+		return
+	}
+
+	landID := c.Param("id")
+
+	// Arazi kullanıcıya ait mi kontrol et
+	var exists bool
+	err = h.db.QueryRow("SELECT 1 FROM lands WHERE id = ? AND user_id = ?", landID, userID).Scan(&exists)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusNotFound, "LAND_NOT_FOUND", "Arazi bulunamadı", nil)
+		return
+	}
+
+	source := c.Query("source")
+	if source != "" && source != models.WeatherSourceProvider && source != models.WeatherSourceStation && source != models.WeatherSourceManual {
+		utils.ErrorResponse(c, http.StatusBadRequest, "INVALID_SOURCE", "Geçersiz gözlem kaynağı",
+			[]string{models.WeatherSourceProvider, models.WeatherSourceStation, models.WeatherSourceManual})
+		return
+	}
+
+	start, end, ok := weatherDateRange(c)
+	if !ok {
+		return
+	}
+
+	observations, err := h.weather.Observations(userID, landID, source, start, end)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusInternalServerError, "DB_ERROR", "Hava gözlemleri alınamadı", err.Error())
+		return
+	}
+
+	utils.SuccessResponse(c, observations, "Hava gözlemleri başarıyla getirildi")
+}
+
+// CreateWeatherObservation hava gözlemi girişi
+// @Summary Hava gözlemi girişi
+// @Description Yağış ölçer veya termometre ile alınan günlük gözlemi kaydeder; aynı gün için önceki girişin yerine geçer
+// @ID createWeatherObservation
+// @Tags Lands
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Arazi ID"
+// @Param request body models.WeatherObservationRequest true "Gözlem bilgileri"
+// @Success 201 {object} models.APIResponse{data=models.WeatherObservation}
+// @Failure 400 {object} models.APIResponse
+// @Failure 401 {object} models.APIResponse
+// @Failure 404 {object} models.APIResponse
+// @Router /lands/{id}/weather-observations [post]
+func (h *LandHandler) CreateWeatherObservation(c *gin.Context) {
+	var req models.WeatherObservationRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		utils.ErrorResponse(c, http.StatusBadRequest, "INVALID_REQUEST", "Geçersiz istek formatı", err.Error())
+		return
+	}
+
+	observations, ok := h.saveWeatherObservations(c, []models.WeatherObservationRequest{req})
+	if !ok {
+		return
+	}
+
+	utils.CreatedResponse(c, observations[0], "Hava gözlemi başarıyla kaydedildi")
+}
+
+// BulkCreateWeatherObservations toplu hava gözlemi girişi
+// @Summary Toplu hava gözlemi girişi
+// @Description Defterden aktarılan günlük gözlemleri tek istekte kaydeder (en fazla 366 gün); hatalı satır varsa hiçbiri kaydedilmez
+// @ID bulkCreateWeatherObservations
+// @Tags Lands
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Arazi ID"
+// @Param request body models.WeatherObservationBulkRequest true "Gözlemler"
+// @Success 201 {object} models.APIResponse{data=[]models.WeatherObservation}
+// @Failure 400 {object} models.APIResponse
+// @Failure 401 {object} models.APIResponse
+// @Failure 404 {object} models.APIResponse
+// @Router /lands/{id}/weather-observations/bulk [post]
+func (h *LandHandler) BulkCreateWeatherObservations(c *gin.Context) {
+	var req models.WeatherObservationBulkRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		utils.ErrorResponse(c, http.StatusBadRequest, "INVALID_REQUEST", "Geçersiz istek formatı", err.Error())
+		return
+	}
+
+	observations, ok := h.saveWeatherObservations(c, req.Observations)
+	if !ok {
+		return
+	}
+
+	utils.CreatedResponse(c, observations, "Hava gözlemleri başarıyla kaydedildi")
+}
+
+// DeleteWeatherObservation hava gözlemi silme
+// @Summary Hava gözlemi silme
+// @Description Kullanıcının girdiği hava gözlemini siler; sağlayıcı gözlemleri silinemez
+// @ID deleteWeatherObservation
+// @Tags Lands
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Arazi ID"
+// @Param observationId path string true "Gözlem ID"
+// @Success 200 {object} models.APIResponse
+// @Failure 401 {object} models.APIResponse
+// @Failure 404 {object} models.APIResponse
+// @Router /lands/{id}/weather-observations/{observationId} [delete]
+func (h *LandHandler) DeleteWeatherObservation(c *gin.Context) {
+	userID, err := utils.GetUserID(c)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusUnauthorized, "UNAUTHORIZED", "Kullanıcı kimliği doğrulanamadı", nil)
+		return
+	}
+
+	landID := c.Param("id")
+
+	// Arazi kullanıcıya ait mi kontrol et
+	var exists bool
+	err = h.db.QueryRow("SELECT 1 FROM lands WHERE id = ? AND user_id = ?", landID, userID).Scan(&exists)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusNotFound, "LAND_NOT_FOUND", "Arazi bulunamadı", nil)
+		return
+	}
+
+	deleted, err := h.weather.DeleteManual(userID, landID, c.Param("observationId"))
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusInternalServerError, "DELETE_ERROR", "Hava gözlemi silinemedi", err.Error())
+		return
+	}
+	if !deleted {
+		utils.ErrorResponse(c, http.StatusNotFound, "OBSERVATION_NOT_FOUND", "Hava gözlemi bulunamadı", nil)
+		return
+	}
+
+	utils.SuccessResponse(c, nil, "Hava gözlemi başarıyla silindi")
+}
+
+// saveWeatherObservations arazi sahipliğini ve gözlemleri doğrulayıp kaydeder; hata varsa yanıtı yazar
+func (h *LandHandler) saveWeatherObservations(c *gin.Context, requests []models.WeatherObservationRequest) ([]models.WeatherObservation, bool) {
+	userID, err := utils.GetUserID(c)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusUnauthorized, "UNAUTHORIZED", "Kullanıcı kimliği doğrulanamadı", nil)
+		return nil, false
+	}
+
+	landID := c.Param("id")
+
+	// Arazi kullanıcıya ait mi kontrol et
+	var exists bool
+	err = h.db.QueryRow("SELECT 1 FROM lands WHERE id = ? AND user_id = ?", landID, userID).Scan(&exists)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusNotFound, "LAND_NOT_FOUND", "Arazi bulunamadı", nil)
+		return nil, false
+	}
+
+	invalid := map[int]string{}
+	seen := map[string]bool{}
+	today := time.Now().Format("2006-01-02")
+	for i, req := range requests {
+		if problem := validateWeatherObservation(req, today); problem != "" {
+			invalid[i] = problem
+		} else if seen[req.Date] {
+			invalid[i] = "Aynı tarih birden fazla kez girilmiş"
+		}
+		seen[req.Date] = true
+	}
+	if len(invalid) > 0 {
+		utils.ErrorResponse(c, http.StatusBadRequest, "INVALID_OBSERVATIONS", "Geçersiz hava gözlemleri", invalid)
+		return nil, false
+	}
+
+	observations, err := h.weather.SaveManual(landID, userID, requests)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusInternalServerError, "DB_ERROR", "Hava gözlemleri kaydedilemedi", err.Error())
+		return nil, false
+	}
+
+	return observations, true
+}
+
+// validateWeatherObservation kullanıcı gözlemini doğrular; geçerliyse boş döner
+func validateWeatherObservation(req models.WeatherObservationRequest, today string) string {
+	if _, err := time.Parse("2006-01-02", req.Date); err != nil {
+		return "Tarih YYYY-MM-DD biçiminde olmalı"
+	}
+	if req.Date > today {
+		return "Gelecek tarihli gözlem girilemez"
+	}
+	if req.Rainfall == nil && req.MinTemp == nil && req.MaxTemp == nil && req.Humidity == nil {
+		return "En az bir ölçüm girilmeli"
+	}
+	if req.Rainfall != nil && (*req.Rainfall < 0 || *req.Rainfall > 1000) {
+		return "Yağış 0-1000 mm arasında olmalı"
+	}
+	if req.MinTemp != nil && req.MaxTemp != nil && *req.MinTemp > *req.MaxTemp {
+		return "En düşük sıcaklık en yüksek sıcaklıktan büyük olamaz"
+	}
+	if req.Humidity != nil && (*req.Humidity < 0 || *req.Humidity > 100) {
+		return "Nem 0-100 arasında olmalı"
+	}
+	return ""
+}
+
+// weatherDateRange startDate/endDate sorgu parametrelerini okur (varsayılan: son 30 gün); hata varsa yanıtı yazar
+func weatherDateRange(c *gin.Context) (time.Time, time.Time, bool) {
+	var err error
+
+	end := time.Now()
+	if value := c.Query("endDate"); value != "" {
+		if end, err = time.Parse("2006-01-02", value); err != nil {
+			utils.ErrorResponse(c, http.StatusBadRequest, "INVALID_DATE", "Geçersiz bitiş tarihi", nil)
+			return end, end, false
+		}
+	}
+
+	start := end.AddDate(0, 0, -30)
+	if value := c.Query("startDate"); value != "" {
+		if start, err = time.Parse("2006-01-02", value); err != nil {
+			utils.ErrorResponse(c, http.StatusBadRequest, "INVALID_DATE", "Geçersiz başlangıç tarihi", nil)
+			return start, end, false
+		}
+	}
+
+	if start.After(end) {
+		utils.ErrorResponse(c, http.StatusBadRequest, "INVALID_DATE_RANGE", "Başlangıç tarihi bitiş tarihinden sonra olamaz", nil)
+		return start, end, false
+	}
+
+	return start, end, true
+}
+
+// GetLandHistory arazi değişiklik geçmişi
+// @Summary Arazi değişiklik geçmişi
+// @Description Arazinin durum, ürün, alan gibi alanlarında yapılan değişiklikleri, eski/yeni değer ve değiştiren kullanıcıyla en yeniden eskiye listeler
+// @ID getLandHistory
+// @Tags Lands
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Arazi ID"
+// @Param field query string false "Yalnızca bu alanın değişiklikleri (örn. status, crop)"
+// @Param page query int false "Sayfa numarası"
+// @Param limit query int false "Sayfa başına kayıt"
+// @Success 200 {object} models.APIResponse{data=models.EntityHistory}
+// @Failure 401 {object} models.APIResponse
+// @Failure 404 {object} models.APIResponse
+// @Router /lands/{id}/history [get]
+func (h *LandHandler) GetLandHistory(c *gin.Context) {
+	userID, err := utils.GetUserID(c)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusUnauthorized, "UNAUTHORIZED", "Kullanıcı kimliği doğrulanamadı", nil)
+		return
+	}
+
+	landID := c.Param("id")
+
+	// Arazi kullanıcıya ait mi kontrol et
+	var exists bool
+	err = h.db.QueryRow("SELECT 1 FROM lands WHERE id = ? AND user_id = ?", landID, userID).Scan(&exists)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusNotFound, "LAND_NOT_FOUND", "Arazi bulunamadı", nil)
+		return
+	}
+
+	writeEntityHistory(c, h.history, userID, services.HistoryEntityLand, landID)
 }
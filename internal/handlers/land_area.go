@@ -0,0 +1,102 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+
+	"agri-management-api/internal/models"
+	"agri-management-api/internal/services"
+	"agri-management-api/internal/utils"
+
+	"github.com/gin-gonic/gin"
+)
+
+// validateLandArea sınırı verilen arazinin alanını sınırdan hesaplanan alanla karşılaştırır; fark toleransı aşarsa
+// çiftlik ayarlarında otomatik düzeltme açıksa veya correctArea=true ise alanı hesaplanan alanla değiştirir.
+// Hata varsa yanıtı yazar
+func (h *LandHandler) validateLandArea(c *gin.Context, land *models.Land, farmID string) bool {
+	if land.Boundary == nil || land.Area <= 0 {
+		return true
+	}
+
+	settings, err := h.areas.Settings(farmID)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusInternalServerError, "DB_ERROR", "Çiftlik ayarları alınamadı", err.Error())
+		return false
+	}
+
+	check := services.CheckLandArea(land.Area, land.Unit, *land.Boundary, settings.TolerancePercent)
+	if check.Flagged && (settings.AutoCorrect || c.Query("correctArea") == "true") {
+		land.Area = check.GeometryArea
+	}
+	return true
+}
+
+// GetLandDataQuality arazi veri kalitesi raporu
+// @Summary Arazi veri kalitesi raporu
+// @Description Sınırı kayıtlı olmayan, yani alanı doğrulanamayan arazileri ve girilen alanı sınırdan hesaplanan alandan çiftlik ayarlarındaki toleranstan (landArea.tolerancePercent, varsayılan %5) fazla farklı olan arazileri farkı büyükten küçüğe listeler. Alan, sınırın küresel yaklaşımla hesaplanan yüzölçümünün arazi birimine çevrilmesiyle bulunur
+// @ID getLandDataQuality
+// @Tags Lands
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {object} models.APIResponse{data=models.LandDataQualityReport}
+// @Failure 401 {object} models.APIResponse
+// @Router /lands/data-quality [get]
+func (h *LandHandler) GetLandDataQuality(c *gin.Context) {
+	userID, err := utils.GetUserID(c)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusUnauthorized, "UNAUTHORIZED", "Kullanıcı kimliği doğrulanamadı", nil)
+		return
+	}
+
+	report, err := h.areas.Report(userID)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusInternalServerError, "DB_ERROR", "Veri kalitesi raporu alınamadı", err.Error())
+		return
+	}
+
+	utils.SuccessResponse(c, report, "Veri kalitesi raporu başarıyla getirildi")
+}
+
+// CorrectLandAreas arazi alanlarını sınırdan düzeltme
+// @Summary Arazi alanlarını sınırdan düzelt
+// @Description Arazilerin alanını sınırdan hesaplanan alanla değiştirir ve eski değeri arazi geçmişine kaydeder. landIds boşsa veri kalitesi raporundaki farkı toleransı aşan tüm araziler, verilirse toleranstan bağımsız olarak seçili araziler düzeltilir; seçili arazilerin sınırı kayıtlı olmalıdır. Yalnızca alanı değişen araziler döner
+// @ID correctLandAreas
+// @Tags Lands
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param request body models.LandAreaCorrectionRequest false "Düzeltilecek araziler"
+// @Success 200 {object} models.APIResponse{data=[]models.LandAreaCheck}
+// @Failure 400 {object} models.APIResponse
+// @Failure 401 {object} models.APIResponse
+// @Failure 404 {object} models.APIResponse
+// @Failure 422 {object} models.APIResponse
+// @Router /lands/data-quality/area-corrections [post]
+func (h *LandHandler) CorrectLandAreas(c *gin.Context) {
+	userID, err := utils.GetUserID(c)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusUnauthorized, "UNAUTHORIZED", "Kullanıcı kimliği doğrulanamadı", nil)
+		return
+	}
+
+	var req models.LandAreaCorrectionRequest
+	if c.Request.ContentLength > 0 {
+		if err := c.ShouldBindJSON(&req); err != nil {
+			utils.ErrorResponse(c, http.StatusBadRequest, "INVALID_REQUEST", "Geçersiz istek formatı", err.Error())
+			return
+		}
+	}
+
+	corrected, details, err := h.areas.Correct(userID, userID, req.LandIDs)
+	switch {
+	case err == nil:
+		utils.SuccessResponse(c, corrected, "Arazi alanları başarıyla düzeltildi")
+	case errors.Is(err, services.ErrLandAreaLandNotFound):
+		utils.ErrorResponse(c, http.StatusNotFound, "LAND_NOT_FOUND", "Arazi bulunamadı", details)
+	case errors.Is(err, services.ErrLandAreaNoBoundary):
+		utils.ErrorResponse(c, http.StatusUnprocessableEntity, "BOUNDARY_NOT_SET", "Arazinin sınırı kayıtlı değil", details)
+	default:
+		utils.ErrorResponse(c, http.StatusInternalServerError, "UPDATE_ERROR", "Arazi alanları düzeltilemedi", err.Error())
+	}
+}
@@ -0,0 +1,164 @@
+package handlers
+
+import (
+	"database/sql"
+	"errors"
+	"net/http"
+
+	"agri-management-api/internal/models"
+	"agri-management-api/internal/services"
+	"agri-management-api/internal/utils"
+
+	"github.com/gin-gonic/gin"
+)
+
+// GetActivityCosts aktivite maliyet dökümü
+// @Summary Aktivite maliyet dökümü
+// @Description Arazi aktivitesinin girdi, işçilik ve makine kalemlerini tür bazında toplamlarla getirir
+// @ID getLandActivityCosts
+// @Tags Lands
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Arazi ID"
+// @Param activityId path string true "Aktivite ID"
+// @Success 200 {object} models.APIResponse{data=models.LandActivityCostBreakdown}
+// @Failure 401 {object} models.APIResponse
+// @Failure 404 {object} models.APIResponse
+// @Router /lands/{id}/activities/{activityId}/costs [get]
+func (h *LandHandler) GetActivityCosts(c *gin.Context) {
+	userID, err := utils.GetUserID(c)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusUnauthorized, "UNAUTHORIZED", "Kullanıcı kimliği doğrulanamadı", nil)
+		return
+	}
+
+	activityID, ok := h.landActivityParam(c, userID)
+	if !ok {
+		return
+	}
+
+	breakdown, err := h.costs.Breakdown(userID, activityID)
+	if err != nil {
+		writeCostItemError(c, err)
+		return
+	}
+
+	utils.SuccessResponse(c, breakdown, "Aktivite maliyet dökümü başarıyla getirildi")
+}
+
+// UpdateActivityCosts aktivite maliyet kalemlerini güncelleme
+// @Summary Aktivite maliyet kalemlerini güncelleme
+// @Description Aktivitenin maliyet kalemlerini verilen listeyle değiştirir. Birim ücret verilmeyen girdiler stoktaki ürünün birim maliyetiyle (yoksa fiyatıyla), makine saatleri makinenin saatlik ücretiyle, işçilik ve diğer makine saatleri ayarlardaki varsayılan ücretlerle değerlenir; aktivitenin maliyeti kalemlerin toplamı olur. Boş liste dökümü kaldırır, maliyeti değiştirmez
+// @ID updateLandActivityCosts
+// @Tags Lands
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Arazi ID"
+// @Param activityId path string true "Aktivite ID"
+// @Param request body models.LandActivityCostItemsRequest true "Maliyet kalemleri"
+// @Success 200 {object} models.APIResponse{data=models.LandActivityCostBreakdown}
+// @Failure 400 {object} models.APIResponse
+// @Failure 401 {object} models.APIResponse
+// @Failure 404 {object} models.APIResponse
+// @Router /lands/{id}/activities/{activityId}/costs [put]
+func (h *LandHandler) UpdateActivityCosts(c *gin.Context) {
+	userID, err := utils.GetUserID(c)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusUnauthorized, "UNAUTHORIZED", "Kullanıcı kimliği doğrulanamadı", nil)
+		return
+	}
+
+	activityID, ok := h.landActivityParam(c, userID)
+	if !ok {
+		return
+	}
+
+	var req models.LandActivityCostItemsRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		utils.ErrorResponse(c, http.StatusBadRequest, "INVALID_REQUEST", "Geçersiz istek formatı", err.Error())
+		return
+	}
+
+	breakdown, err := h.costs.Replace(userID, activityID, req.Items)
+	if err != nil {
+		writeCostItemError(c, err)
+		return
+	}
+
+	utils.SuccessResponse(c, breakdown, "Aktivite maliyet kalemleri başarıyla güncellendi")
+}
+
+// GetLandProfitability arazi karlılığı
+// @Summary Arazi karlılığı
+// @Description Arazide üretilen ürünlerin vergisiz satış gelirini, dönemdeki aktivitelerin girdi, işçilik, makine ve kalemlere ayrılmamış maliyetleri ve araziye dağıtılan ortak giderlerle (allocatedCost) karşılaştırır; alan başına kar da hesaplanır
+// @ID getLandProfitability
+// @Tags Lands
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Arazi ID"
+// @Param startDate query string false "Başlangıç tarihi (YYYY-MM-DD)"
+// @Param endDate query string false "Bitiş tarihi (YYYY-MM-DD)"
+// @Success 200 {object} models.APIResponse{data=models.LandProfitability}
+// @Failure 400 {object} models.APIResponse
+// @Failure 401 {object} models.APIResponse
+// @Failure 404 {object} models.APIResponse
+// @Router /lands/{id}/profitability [get]
+func (h *LandHandler) GetLandProfitability(c *gin.Context) {
+	userID, err := utils.GetUserID(c)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusUnauthorized, "UNAUTHORIZED", "Kullanıcı kimliği doğrulanamadı", nil)
+		return
+	}
+
+	startDate, ok := optionalDateQuery(c, "startDate")
+	if !ok {
+		return
+	}
+	endDate, ok := optionalDateQuery(c, "endDate")
+	if !ok {
+		return
+	}
+
+	result, err := h.costs.Profitability(userID, c.Param("id"), startDate, endDate)
+	if err == sql.ErrNoRows {
+		utils.ErrorResponse(c, http.StatusNotFound, "LAND_NOT_FOUND", "Arazi bulunamadı", nil)
+		return
+	}
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusInternalServerError, "DB_ERROR", "Arazi karlılığı hesaplanamadı", err.Error())
+		return
+	}
+
+	utils.SuccessResponse(c, result, "Arazi karlılığı başarıyla getirildi")
+}
+
+// landActivityParam yoldaki aktivitenin yoldaki araziye ait olduğunu doğrular. Hata varsa yanıtı yazar
+func (h *LandHandler) landActivityParam(c *gin.Context, userID string) (string, bool) {
+	activityID := c.Param("activityId")
+
+	var exists int
+	err := h.db.QueryRow(`
+		SELECT 1 FROM land_activities a JOIN lands l ON l.id = a.land_id
+		WHERE a.id = ? AND a.land_id = ? AND l.user_id = ?
+	`, activityID, c.Param("id"), userID).Scan(&exists)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusNotFound, "ACTIVITY_NOT_FOUND", "Arazi aktivitesi bulunamadı", nil)
+		return "", false
+	}
+	return activityID, true
+}
+
+// writeCostItemError maliyet kalemi hatasını uygun HTTP yanıtına çevirir
+func writeCostItemError(c *gin.Context, err error) {
+	switch {
+	case errors.Is(err, services.ErrInvalidCostItem):
+		utils.ErrorResponse(c, http.StatusBadRequest, "INVALID_COST_ITEM", err.Error(), nil)
+	case errors.Is(err, services.ErrLandActivityNotFound):
+		utils.ErrorResponse(c, http.StatusNotFound, "ACTIVITY_NOT_FOUND", "Arazi aktivitesi bulunamadı", nil)
+	default:
+		utils.ErrorResponse(c, http.StatusInternalServerError, "DB_ERROR", "Maliyet kalemleri işlenemedi", err.Error())
+	}
+}
@@ -0,0 +1,124 @@
+package handlers
+
+import (
+	"net/http"
+	"time"
+
+	"agri-management-api/internal/models"
+	"agri-management-api/internal/services"
+	"agri-management-api/internal/utils"
+
+	"github.com/gin-gonic/gin"
+)
+
+// GetRecommendations arazi aktivite önerileri
+// @Summary Arazi aktivite önerileri
+// @Description Aktif araziler için ürün gelişim evresi (son ekim aktivitesinden), son gübreleme, sulama ve kontrol tarihleri, son 7 günün hava gözlemleri ve açık zararlı/hastalık gözlemlerine göre sonraki aktiviteleri (fertilizing, irrigation, scouting) öncelikleriyle önerir. Her önerinin action alanı etkinliği tek dokunuşla takvime ekleyen API çağrısıdır
+// @ID getLandRecommendations
+// @Tags Lands
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {object} models.APIResponse{data=[]models.LandRecommendations}
+// @Failure 401 {object} models.APIResponse
+// @Router /lands/recommendations [get]
+func (h *LandHandler) GetRecommendations(c *gin.Context) {
+	userID, err := utils.GetUserID(c)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusUnauthorized, "UNAUTHORIZED", "Kullanıcı kimliği doğrulanamadı", nil)
+		return
+	}
+
+	recommendations, err := h.recommendations.Recommendations(userID, "", time.Now())
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusInternalServerError, "DB_ERROR", "Öneriler alınamadı", err.Error())
+		return
+	}
+
+	utils.SuccessResponse(c, recommendations, "Öneriler başarıyla getirildi")
+}
+
+// GetLandRecommendations tek arazinin aktivite önerileri
+// @Summary Arazinin aktivite önerileri
+// @Description Arazinin gelişim evresi, son aktiviteleri ve son 7 günün hava gözlemlerine göre önerilen sonraki aktiviteleri döner
+// @ID getLandRecommendationsById
+// @Tags Lands
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Arazi ID"
+// @Success 200 {object} models.APIResponse{data=models.LandRecommendations}
+// @Failure 401 {object} models.APIResponse
+// @Failure 404 {object} models.APIResponse
+// @Router /lands/{id}/recommendations [get]
+func (h *LandHandler) GetLandRecommendations(c *gin.Context) {
+	userID, err := utils.GetUserID(c)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusUnauthorized, "UNAUTHORIZED", "Kullanıcı kimliği doğrulanamadı", nil)
+		return
+	}
+
+	recommendations, err := h.recommendations.Recommendations(userID, c.Param("id"), time.Now())
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusInternalServerError, "DB_ERROR", "Öneriler alınamadı", err.Error())
+		return
+	}
+	if len(recommendations) == 0 {
+		utils.ErrorResponse(c, http.StatusNotFound, "LAND_NOT_FOUND", "Arazi bulunamadı", nil)
+		return
+	}
+
+	utils.SuccessResponse(c, recommendations[0], "Öneriler başarıyla getirildi")
+}
+
+// ScheduleRecommendation öneriyi takvime ekleme
+// @Summary Öneriyi takvime ekle
+// @Description Önerilen aktiviteyi (fertilizing, irrigation, scouting) araziye bağlı, tüm gün süren bir takvim etkinliği olarak oluşturur; tarih verilmezse bugün kullanılır. Önerilerin action alanı bu uç noktayı hazır payload ile çağırır
+// @ID scheduleLandRecommendation
+// @Tags Lands
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Arazi ID"
+// @Param request body models.ScheduleRecommendationRequest true "Planlanacak aktivite"
+// @Success 201 {object} models.APIResponse{data=models.Event}
+// @Failure 400 {object} models.APIResponse
+// @Failure 401 {object} models.APIResponse
+// @Failure 404 {object} models.APIResponse
+// @Router /lands/{id}/recommendations/schedule [post]
+func (h *LandHandler) ScheduleRecommendation(c *gin.Context) {
+	userID, err := utils.GetUserID(c)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusUnauthorized, "UNAUTHORIZED", "Kullanıcı kimliği doğrulanamadı", nil)
+		return
+	}
+
+	var req models.ScheduleRecommendationRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		utils.ErrorResponse(c, http.StatusBadRequest, "INVALID_REQUEST", "Geçersiz istek formatı", err.Error())
+		return
+	}
+	if req.Date != "" {
+		if _, err := time.Parse("2006-01-02", req.Date); err != nil {
+			utils.ErrorResponse(c, http.StatusBadRequest, "INVALID_DATE", "Tarih YYYY-MM-DD biçiminde olmalı", req.Date)
+			return
+		}
+	}
+
+	event, err := h.recommendations.Schedule(userID, c.Param("id"), req, time.Now())
+	switch err {
+	case nil:
+	case services.ErrRecommendationActivity:
+		utils.ErrorResponse(c, http.StatusBadRequest, "INVALID_ACTIVITY", "Bu aktivite takvime eklenemez",
+			[]string{services.ActivityFertilizing, services.ActivityIrrigation, services.ActivityScouting})
+		return
+	case services.ErrRecommendationLand:
+		utils.ErrorResponse(c, http.StatusNotFound, "LAND_NOT_FOUND", "Arazi bulunamadı", nil)
+		return
+	default:
+		utils.ErrorResponse(c, http.StatusInternalServerError, "DB_ERROR", "Etkinlik oluşturulamadı", err.Error())
+		return
+	}
+
+	utils.CreatedResponse(c, event, "Aktivite takvime eklendi")
+}
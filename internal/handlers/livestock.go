@@ -2,27 +2,69 @@ package handlers
 
 import (
 	"database/sql"
+	"errors"
+	"fmt"
+	"log"
 	"net/http"
+	"strings"
+	"time"
 
 	"agri-management-api/internal/models"
+	"agri-management-api/internal/services"
 	"agri-management-api/internal/utils"
 
 	"github.com/gin-gonic/gin"
 )
 
+// livestockSortFields hayvan listesinde sıralanabilen alanlar
+var livestockSortFields = map[string]string{
+	"createdAt": "created_at",
+	"tagNumber": "tag_number",
+	"type":      "type",
+	"birthDate": "birth_date",
+	"weight":    "weight",
+}
+
 // LivestockHandler hayvan işlemlerini yönetir
 type LivestockHandler struct {
-	db *sql.DB
+	db            *sql.DB
+	categories    *services.CategoryService
+	history       *services.ChangeHistoryService
+	notifications *services.NotificationService
+	passports     *services.AnimalPassportService
+	eventRules    *services.EventRuleService
+	movements     *services.LivestockMovementService
+	breeds        *services.LivestockBreedService
+	vaccinations  *services.VaccinationService
+	enterprises   *services.EnterpriseService
+	weights       *services.WeightService
+	formats       *services.FormattingService
+	signatures    *services.DocumentSigningService
 }
 
 // NewLivestockHandler yeni livestock handler oluşturur
 func NewLivestockHandler(db *sql.DB) *LivestockHandler {
-	return &LivestockHandler{db: db}
+	return &LivestockHandler{
+		db:            db,
+		categories:    services.NewCategoryService(db),
+		history:       services.NewChangeHistoryService(db),
+		notifications: services.NewNotificationService(db),
+		passports:     services.NewAnimalPassportService(db),
+		eventRules:    services.NewEventRuleService(db),
+		movements:     services.NewLivestockMovementService(db),
+		breeds:        services.NewLivestockBreedService(db),
+		vaccinations:  services.NewVaccinationService(db),
+		enterprises:   services.NewEnterpriseService(db),
+		weights:       services.NewWeightService(db),
+		formats:       services.NewFormattingService(db),
+		signatures:    services.NewDocumentSigningService(db),
+	}
 }
 
 // GetLivestock hayvan listesi
 // @Summary Hayvan listesi
 // @Description Kullanıcının hayvanlarını listeler
+// @ID getLivestock
 // @Tags Livestock
 // @Accept json
 // @Produce json
@@ -31,7 +73,10 @@ func NewLivestockHandler(db *sql.DB) *LivestockHandler {
 // @Param limit query int false "Sayfa başına kayıt"
 // @Param type query string false "Hayvan türü"
 // @Param status query string false "Sağlık durumu"
-// @Success 200 {object} models.APIResponse{data=map[string]interface{}}
+// @Param enterpriseId query string false "Faaliyet kolu ID"
+// @Param sortBy query string false "Sıralama alanı (createdAt, tagNumber, type, birthDate, weight)"
+// @Param sortOrder query string false "Sıralama yönü (asc, desc)"
+// @Success 200 {object} models.APIResponse{data=models.LivestockListResponse}
 // @Failure 401 {object} models.APIResponse
 // @Router /livestock [get]
 func (h *LivestockHandler) GetLivestock(c *gin.Context) {
@@ -44,6 +89,7 @@ func (h *LivestockHandler) GetLivestock(c *gin.Context) {
 	page, limit := utils.ParsePagination(c)
 	animalType := c.DefaultQuery("type", "all")
 	status := c.DefaultQuery("status", "all")
+	orderBy := utils.ParseSort(c, livestockSortFields, "created_at DESC")
 
 	// Toplam kayıt sayısını al
 	var total int
@@ -60,6 +106,11 @@ func (h *LivestockHandler) GetLivestock(c *gin.Context) {
 		args = append(args, status)
 	}
 
+	if enterpriseID := c.Query("enterpriseId"); enterpriseID != "" {
+		whereClause += " AND enterprise_id = ?"
+		args = append(args, enterpriseID)
+	}
+
 	err = h.db.QueryRow("SELECT COUNT(*) FROM livestock "+whereClause, args...).Scan(&total)
 	if err != nil {
 		utils.ErrorResponse(c, http.StatusInternalServerError, "DB_ERROR", "Toplam kayıt sayısı alınamadı", err.Error())
@@ -73,9 +124,9 @@ func (h *LivestockHandler) GetLivestock(c *gin.Context) {
 	offset := (page - 1) * limit
 	query := `
 		SELECT id, user_id, tag_number, type, breed, gender, birth_date, weight,
-		       health_status, location, mother, father, notes, created_at, updated_at
+		       health_status, location, mother, father, notes, enterprise_id, created_at, updated_at
 		FROM livestock ` + whereClause + `
-		ORDER BY created_at DESC LIMIT ? OFFSET ?
+		ORDER BY ` + orderBy + ` LIMIT ? OFFSET ?
 	`
 	args = append(args, limit, offset)
 
@@ -91,11 +142,12 @@ func (h *LivestockHandler) GetLivestock(c *gin.Context) {
 		var animal models.Livestock
 		var birthDate sql.NullTime
 		var weight sql.NullFloat64
+		var enterpriseID sql.NullString
 
 		err := rows.Scan(
 			&animal.ID, &animal.UserID, &animal.TagNumber, &animal.Type, &animal.Breed,
 			&animal.Gender, &birthDate, &weight, &animal.HealthStatus, &animal.Location,
-			&animal.Mother, &animal.Father, &animal.Notes, &animal.CreatedAt, &animal.UpdatedAt,
+			&animal.Mother, &animal.Father, &animal.Notes, &enterpriseID, &animal.CreatedAt, &animal.UpdatedAt,
 		)
 		if err != nil {
 			continue
@@ -103,13 +155,14 @@ func (h *LivestockHandler) GetLivestock(c *gin.Context) {
 
 		animal.BirthDate = utils.NullTimeToPtr(birthDate)
 		animal.Weight = utils.NullFloat64ToPtr(weight)
+		animal.EnterpriseID = utils.NullStringToPtr(enterpriseID)
 
 		animals = append(animals, animal)
 	}
 
-	response := map[string]interface{}{
-		"animals":    animals,
-		"pagination": pagination,
+	response := models.LivestockListResponse{
+		Animals:    animals,
+		Pagination: pagination,
 	}
 
 	utils.SuccessResponse(c, response, "Hayvanlar başarıyla getirildi")
@@ -117,7 +170,8 @@ func (h *LivestockHandler) GetLivestock(c *gin.Context) {
 
 // CreateLivestock yeni hayvan oluşturma
 // @Summary Yeni hayvan oluşturma
-// @Description Yeni hayvan kaydı oluşturur
+// @Description Yeni hayvan kaydı oluşturur; tür /livestock/species listesinde tanımlı olmalı, ırk türün ırk listesinde bulunmalı (ırk listesi boş türlerde serbest)
+// @ID createLivestock
 // @Tags Livestock
 // @Accept json
 // @Produce json
@@ -146,6 +200,17 @@ func (h *LivestockHandler) CreateLivestock(c *gin.Context) {
 		return
 	}
 
+	// Tür ve ırk başvuru listesinde tanımlı olmalı
+	req.Type = strings.TrimSpace(req.Type)
+	breed, ok := validateBreed(c, h.breeds, userID, req.Type, req.Breed)
+	if !ok {
+		return
+	}
+	req.Breed = breed
+	if !validateEnterprise(c, h.enterprises, userID, &req.EnterpriseID) {
+		return
+	}
+
 	// Tag number benzersiz mi kontrol et
 	var exists bool
 	err = h.db.QueryRow("SELECT 1 FROM livestock WHERE tag_number = ? AND user_id = ?", req.TagNumber, userID).Scan(&exists)
@@ -159,30 +224,34 @@ func (h *LivestockHandler) CreateLivestock(c *gin.Context) {
 	// Hayvanı oluştur
 	_, err = h.db.Exec(`
 		INSERT INTO livestock (id, user_id, tag_number, type, breed, gender, birth_date,
-		                      weight, health_status, location, mother, father, notes,
+		                      weight, health_status, location, mother, father, notes, enterprise_id,
 		                      created_at, updated_at)
-		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, CURRENT_TIMESTAMP, CURRENT_TIMESTAMP)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, CURRENT_TIMESTAMP, CURRENT_TIMESTAMP)
 	`, animalID, userID, req.TagNumber, req.Type, req.Breed, req.Gender, req.BirthDate,
-		req.Weight, req.HealthStatus, req.Location, req.Mother, req.Father, req.Notes)
+		req.Weight, req.HealthStatus, req.Location, req.Mother, req.Father, req.Notes, req.EnterpriseID)
 
 	if err != nil {
 		utils.ErrorResponse(c, http.StatusInternalServerError, "DB_ERROR", "Hayvan oluşturulamadı", err.Error())
 		return
 	}
+	if err := h.weights.RecordCurrent(userID, animalID); err != nil {
+		log.Printf("İlk tartım kaydı eklenemedi: %v", err)
+	}
 
 	// Oluşturulan hayvanı getir
 	var animal models.Livestock
 	var birthDate sql.NullTime
 	var weight sql.NullFloat64
+	var enterpriseID sql.NullString
 
 	err = h.db.QueryRow(`
 		SELECT id, user_id, tag_number, type, breed, gender, birth_date, weight,
-		       health_status, location, mother, father, notes, created_at, updated_at
-		FROM livestock WHERE id = ?
-	`, animalID).Scan(
+		       health_status, location, mother, father, notes, enterprise_id, created_at, updated_at
+		FROM livestock WHERE id = ? AND user_id = ?
+	`, animalID, userID).Scan(
 		&animal.ID, &animal.UserID, &animal.TagNumber, &animal.Type, &animal.Breed,
 		&animal.Gender, &birthDate, &weight, &animal.HealthStatus, &animal.Location,
-		&animal.Mother, &animal.Father, &animal.Notes, &animal.CreatedAt, &animal.UpdatedAt,
+		&animal.Mother, &animal.Father, &animal.Notes, &enterpriseID, &animal.CreatedAt, &animal.UpdatedAt,
 	)
 
 	if err != nil {
@@ -192,17 +261,15 @@ func (h *LivestockHandler) CreateLivestock(c *gin.Context) {
 
 	animal.BirthDate = utils.NullTimeToPtr(birthDate)
 	animal.Weight = utils.NullFloat64ToPtr(weight)
+	animal.EnterpriseID = utils.NullStringToPtr(enterpriseID)
 
-	c.JSON(http.StatusCreated, models.APIResponse{
-		Success: true,
-		Data:    animal,
-		Message: "Hayvan başarıyla oluşturuldu",
-	})
+	utils.CreatedResponse(c, animal, "Hayvan başarıyla oluşturuldu")
 }
 
 // GetLivestock hayvan detayları
 // @Summary Hayvan detayları
 // @Description Belirli bir hayvanın detaylarını getirir
+// @ID getLivestockByID
 // @Tags Livestock
 // @Accept json
 // @Produce json
@@ -228,15 +295,16 @@ func (h *LivestockHandler) GetLivestockByID(c *gin.Context) {
 	var animal models.Livestock
 	var birthDate sql.NullTime
 	var weight sql.NullFloat64
+	var enterpriseID sql.NullString
 
 	err = h.db.QueryRow(`
 		SELECT id, user_id, tag_number, type, breed, gender, birth_date, weight,
-		       health_status, location, mother, father, notes, created_at, updated_at
+		       health_status, location, mother, father, notes, enterprise_id, created_at, updated_at
 		FROM livestock WHERE id = ? AND user_id = ?
 	`, animalID, userID).Scan(
 		&animal.ID, &animal.UserID, &animal.TagNumber, &animal.Type, &animal.Breed,
 		&animal.Gender, &birthDate, &weight, &animal.HealthStatus, &animal.Location,
-		&animal.Mother, &animal.Father, &animal.Notes, &animal.CreatedAt, &animal.UpdatedAt,
+		&animal.Mother, &animal.Father, &animal.Notes, &enterpriseID, &animal.CreatedAt, &animal.UpdatedAt,
 	)
 
 	if err != nil {
@@ -250,13 +318,15 @@ func (h *LivestockHandler) GetLivestockByID(c *gin.Context) {
 
 	animal.BirthDate = utils.NullTimeToPtr(birthDate)
 	animal.Weight = utils.NullFloat64ToPtr(weight)
+	animal.EnterpriseID = utils.NullStringToPtr(enterpriseID)
 
-	utils.SuccessResponse(c, animal, "Hayvan detayları başarıyla getirildi")
+	utils.DetailResponse(c, animal, services.EntityLinks("livestock", animal.ID), "Hayvan detayları başarıyla getirildi")
 }
 
 // UpdateLivestock hayvan güncelleme
 // @Summary Hayvan güncelleme
-// @Description Mevcut hayvan bilgilerini günceller
+// @Description Mevcut hayvan bilgilerini günceller; tür ve ırk oluşturmadaki gibi doğrulanır, konum değişikliği hareket geçmişine nakil olarak yazılır
+// @ID updateLivestock
 // @Tags Livestock
 // @Accept json
 // @Produce json
@@ -287,21 +357,55 @@ func (h *LivestockHandler) UpdateLivestock(c *gin.Context) {
 		return
 	}
 
-	// Hayvanı güncelle
-	_, err = h.db.Exec(`
-		UPDATE livestock 
-		SET tag_number = ?, type = ?, breed = ?, gender = ?, birth_date = ?, weight = ?,
-		    health_status = ?, location = ?, mother = ?, father = ?, notes = ?,
-		    updated_at = CURRENT_TIMESTAMP
-		WHERE id = ? AND user_id = ?
-	`, req.TagNumber, req.Type, req.Breed, req.Gender, req.BirthDate, req.Weight,
-		req.HealthStatus, req.Location, req.Mother, req.Father, req.Notes, animalID, userID)
+	// Tür ve ırk başvuru listesinde tanımlı olmalı
+	req.Type = strings.TrimSpace(req.Type)
+	breed, ok := validateBreed(c, h.breeds, userID, req.Type, req.Breed)
+	if !ok {
+		return
+	}
+	req.Breed = breed
+	if !validateEnterprise(c, h.enterprises, userID, &req.EnterpriseID) {
+		return
+	}
+
+	var previousLocation string
+	found := h.db.QueryRow("SELECT COALESCE(location, '') FROM livestock WHERE id = ? AND user_id = ?", animalID, userID).Scan(&previousLocation) == nil
+
+	// Hayvanı güncelle ve değişen alanları geçmişe kaydet
+	err = h.history.Track(h.db, services.HistoryEntityLivestock, userID, animalID, userID, func() error {
+		_, err := h.db.Exec(`
+			UPDATE livestock 
+			SET tag_number = ?, type = ?, breed = ?, gender = ?, birth_date = ?, weight = ?,
+			    health_status = ?, location = ?, mother = ?, father = ?, notes = ?, enterprise_id = ?,
+			    updated_at = CURRENT_TIMESTAMP
+			WHERE id = ? AND user_id = ?
+		`, req.TagNumber, req.Type, req.Breed, req.Gender, req.BirthDate, req.Weight,
+			req.HealthStatus, req.Location, req.Mother, req.Father, req.Notes, req.EnterpriseID, animalID, userID)
+		return err
+	})
 
 	if err != nil {
 		utils.ErrorResponse(c, http.StatusInternalServerError, "UPDATE_ERROR", "Hayvan güncellenemedi", err.Error())
 		return
 	}
 
+	// Elle değiştirilen ağırlık tartım geçmişine bugünün tarihiyle eklenir
+	if err := h.weights.RecordCurrent(userID, animalID); err != nil {
+		log.Printf("Ağırlık değişikliği tartım olarak kaydedilemedi: %v", err)
+	}
+
+	// Elle değiştirilen konum hareket geçmişine nakil olarak yazılır
+	if found && req.Location != "" && req.Location != previousLocation {
+		if err := h.movements.RecordRelocation(userID, animalID, previousLocation, req.Location); err != nil {
+			log.Printf("Konum değişikliği hareket olarak kaydedilemedi: %v", err)
+		}
+	}
+
+	// Sağlık durumu tarihi geçmiş aşılardan türetilir; elle girilen healthy durumu aşı yapılana kadar geçerli olmaz
+	if err := h.vaccinations.SyncHealthStatus(userID); err != nil {
+		log.Printf("Aşı takvimine göre sağlık durumu güncellenemedi: %v", err)
+	}
+
 	// Güncellenmiş hayvanı getir
 	h.GetLivestockByID(c)
 }
@@ -309,14 +413,17 @@ func (h *LivestockHandler) UpdateLivestock(c *gin.Context) {
 // DeleteLivestock hayvan silme
 // @Summary Hayvan silme
 // @Description Belirli bir hayvanı siler
+// @ID deleteLivestock
 // @Tags Livestock
 // @Accept json
 // @Produce json
 // @Security BearerAuth
 // @Param id path string true "Hayvan ID"
+// @Param force query bool false "Başka kayıtlarla bağlantısı olsa da sil"
 // @Success 200 {object} models.APIResponse
 // @Failure 401 {object} models.APIResponse
 // @Failure 404 {object} models.APIResponse
+// @Failure 409 {object} models.APIResponse
 // @Router /livestock/{id} [delete]
 func (h *LivestockHandler) DeleteLivestock(c *gin.Context) {
 	userID, err := utils.GetUserID(c)
@@ -331,6 +438,10 @@ func (h *LivestockHandler) DeleteLivestock(c *gin.Context) {
 		return
 	}
 
+	if !confirmLinkedDelete(c, h.db, userID, "livestock", animalID) {
+		return
+	}
+
 	// Hayvanı sil
 	result, err := h.db.Exec("DELETE FROM livestock WHERE id = ? AND user_id = ?", animalID, userID)
 	if err != nil {
@@ -344,17 +455,21 @@ func (h *LivestockHandler) DeleteLivestock(c *gin.Context) {
 		return
 	}
 
+	removeRecordLinks(h.db, userID, "livestock", animalID)
+	removeEntityHistory(h.history, userID, services.HistoryEntityLivestock, animalID)
+
 	utils.SuccessResponse(c, nil, "Hayvan başarıyla silindi")
 }
 
 // GetLivestockStatistics hayvancılık istatistikleri
 // @Summary Hayvancılık istatistikleri
-// @Description Hayvancılık istatistiklerini getirir
+// @Description Toplam hayvan sayısını, kayıtlarda bulunan her tür için hayvan sayısını (animalsByType), sağlık durumu dağılımını ve günlük süt üretimini getirir
+// @ID getLivestockStatistics
 // @Tags Livestock
 // @Accept json
 // @Produce json
 // @Security BearerAuth
-// @Success 200 {object} models.APIResponse{data=map[string]interface{}}
+// @Success 200 {object} models.APIResponse{data=models.LivestockStatistics}
 // @Failure 401 {object} models.APIResponse
 // @Router /livestock/statistics [get]
 func (h *LivestockHandler) GetLivestockStatistics(c *gin.Context) {
@@ -372,12 +487,22 @@ func (h *LivestockHandler) GetLivestockStatistics(c *gin.Context) {
 		return
 	}
 
-	// Tür bazında hayvan sayıları
-	var cattle, sheep, goat, chicken int
-	h.db.QueryRow("SELECT COUNT(*) FROM livestock WHERE user_id = ? AND type = 'cattle'", userID).Scan(&cattle)
-	h.db.QueryRow("SELECT COUNT(*) FROM livestock WHERE user_id = ? AND type = 'sheep'", userID).Scan(&sheep)
-	h.db.QueryRow("SELECT COUNT(*) FROM livestock WHERE user_id = ? AND type = 'goat'", userID).Scan(&goat)
-	h.db.QueryRow("SELECT COUNT(*) FROM livestock WHERE user_id = ? AND type = 'chicken'", userID).Scan(&chicken)
+	// Tür bazında hayvan sayıları; kayıtlardaki tüm türler sayılır
+	animalsByType := map[string]int{}
+	rows, err := h.db.Query("SELECT type, COUNT(*) FROM livestock WHERE user_id = ? GROUP BY type", userID)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusInternalServerError, "DB_ERROR", "Tür bazında hayvan sayıları alınamadı", err.Error())
+		return
+	}
+	for rows.Next() {
+		var animalType string
+		var count int
+		if err := rows.Scan(&animalType, &count); err != nil {
+			continue
+		}
+		animalsByType[animalType] = count
+	}
+	rows.Close()
 
 	// Sağlık durumu istatistikleri
 	var healthy, sick, pregnant, vaccinationNeeded int
@@ -389,9 +514,10 @@ func (h *LivestockHandler) GetLivestockStatistics(c *gin.Context) {
 	// Günlük süt üretimi (basit hesaplama)
 	var dailyMilkProduction float64
 	err = h.db.QueryRow(`
-		SELECT COALESCE(SUM(amount), 0)
-		FROM milk_production 
-		WHERE user_id = ? AND DATE(date) = CURDATE()
+		SELECT COALESCE(SUM(m.amount), 0)
+		FROM milk_production m
+		JOIN livestock l ON l.id = m.livestock_id
+		WHERE l.user_id = ? AND DATE(m.date) = DATE('now')
 	`, userID).Scan(&dailyMilkProduction)
 
 	// Aşılama oranı
@@ -400,22 +526,17 @@ func (h *LivestockHandler) GetLivestockStatistics(c *gin.Context) {
 		vaccinationRate = float64(healthy) / float64(totalAnimals) * 100
 	}
 
-	statistics := map[string]interface{}{
-		"totalAnimals": totalAnimals,
-		"animalsByType": map[string]int{
-			"cattle":  cattle,
-			"sheep":   sheep,
-			"goat":    goat,
-			"chicken": chicken,
-		},
-		"healthStatistics": map[string]int{
-			"healthy":            healthy,
-			"sick":               sick,
-			"pregnant":           pregnant,
-			"vaccination_needed": vaccinationNeeded,
+	statistics := models.LivestockStatistics{
+		TotalAnimals:  totalAnimals,
+		AnimalsByType: animalsByType,
+		HealthStatistics: models.LivestockHealthCounts{
+			Healthy:           healthy,
+			Sick:              sick,
+			Pregnant:          pregnant,
+			VaccinationNeeded: vaccinationNeeded,
 		},
-		"dailyMilkProduction": dailyMilkProduction,
-		"vaccinationRate":     vaccinationRate,
+		DailyMilkProduction: dailyMilkProduction,
+		VaccinationRate:     vaccinationRate,
 	}
 
 	utils.SuccessResponse(c, statistics, "Hayvancılık istatistikleri başarıyla getirildi")
@@ -424,6 +545,7 @@ func (h *LivestockHandler) GetLivestockStatistics(c *gin.Context) {
 // GetLivestockCategories hayvan kategorileri
 // @Summary Hayvan kategorileri
 // @Description Hayvan kategorilerini getirir
+// @ID getLivestockCategories
 // @Tags Livestock
 // @Accept json
 // @Produce json
@@ -458,34 +580,19 @@ func (h *LivestockHandler) GetLivestockCategories(c *gin.Context) {
 			continue
 		}
 
-		// Her kategori için icon ve renk ata
-		switch category.Name {
-		case "cattle":
-			category.Icon = "🐄"
-			category.Color = "#4CAF50"
-		case "sheep":
-			category.Icon = "🐑"
-			category.Color = "#2196F3"
-		case "goat":
-			category.Icon = "🐐"
-			category.Color = "#FF9800"
-		case "chicken":
-			category.Icon = "🐔"
-			category.Color = "#9C27B0"
-		default:
-			category.Icon = "🐾"
-			category.Color = "#607D8B"
-		}
-
 		categories = append(categories, category)
 	}
 
+	// İkon ve renkler kategori kayıt defterinden alınır
+	categories = h.categories.Decorate(userID, models.CategoryDomainLivestock, categories)
+
 	utils.SuccessResponse(c, categories, "Hayvan kategorileri başarıyla getirildi")
 }
 
 // GetHealthRecords sağlık kayıtları
 // @Summary Sağlık kayıtları
 // @Description Belirli bir hayvanın sağlık kayıtlarını listeler
+// @ID getHealthRecords
 // @Tags Livestock
 // @Accept json
 // @Produce json
@@ -518,10 +625,12 @@ func (h *LivestockHandler) GetHealthRecords(c *gin.Context) {
 
 	// Sağlık kayıtlarını getir
 	rows, err := h.db.Query(`
-		SELECT id, animal_id, type, description, date, veterinarian, cost, notes, next_checkup
-		FROM health_records WHERE animal_id = ?
-		ORDER BY date DESC
-	`, animalID)
+		SELECT r.id, r.livestock_id, r.type, COALESCE(r.description, ''), r.date, COALESCE(r.veterinarian, ''), r.cost, COALESCE(r.notes, ''), r.next_checkup
+		FROM health_records r
+		JOIN livestock l ON l.id = r.livestock_id
+		WHERE r.livestock_id = ? AND l.user_id = ?
+		ORDER BY r.date DESC
+	`, animalID, userID)
 	if err != nil {
 		utils.ErrorResponse(c, http.StatusInternalServerError, "DB_ERROR", "Sağlık kayıtları alınamadı", err.Error())
 		return
@@ -555,6 +664,7 @@ func (h *LivestockHandler) GetHealthRecords(c *gin.Context) {
 // CreateHealthRecord sağlık kaydı oluşturma
 // @Summary Sağlık kaydı oluşturma
 // @Description Yeni sağlık kaydı oluşturur
+// @ID createHealthRecord
 // @Tags Livestock
 // @Accept json
 // @Produce json
@@ -596,7 +706,7 @@ func (h *LivestockHandler) CreateHealthRecord(c *gin.Context) {
 	// Sağlık kaydını oluştur
 	recordID := utils.GenerateID()
 	_, err = h.db.Exec(`
-		INSERT INTO health_records (id, animal_id, type, description, date, veterinarian,
+		INSERT INTO health_records (id, livestock_id, type, description, date, veterinarian,
 		                           cost, notes, next_checkup, created_at)
 		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, CURRENT_TIMESTAMP)
 	`, recordID, animalID, req.Type, req.Description, req.Date, req.Veterinarian,
@@ -613,9 +723,12 @@ func (h *LivestockHandler) CreateHealthRecord(c *gin.Context) {
 	var cost sql.NullFloat64
 
 	err = h.db.QueryRow(`
-		SELECT id, animal_id, type, description, date, veterinarian, cost, notes, next_checkup, created_at
-		FROM health_records WHERE id = ?
-	`, recordID).Scan(
+		SELECT r.id, r.livestock_id, r.type, COALESCE(r.description, ''), r.date, COALESCE(r.veterinarian, ''), r.cost,
+		       COALESCE(r.notes, ''), r.next_checkup, r.created_at
+		FROM health_records r
+		JOIN livestock l ON l.id = r.livestock_id
+		WHERE r.id = ? AND l.user_id = ?
+	`, recordID, userID).Scan(
 		&record.ID, &record.AnimalID, &record.Type, &record.Description,
 		&date, &record.Veterinarian, &cost, &record.Notes, &nextCheckup, &record.CreatedAt,
 	)
@@ -628,17 +741,15 @@ func (h *LivestockHandler) CreateHealthRecord(c *gin.Context) {
 	record.Date = utils.NullTimeToPtr(date)
 	record.Cost = utils.NullFloat64ToPtr(cost)
 	record.NextCheckup = utils.NullTimeToPtr(nextCheckup)
+	h.eventRules.SyncQuietly(userID, models.EventRuleExpectedBirth, models.EventRuleHealthCheckup)
 
-	c.JSON(http.StatusCreated, models.APIResponse{
-		Success: true,
-		Data:    record,
-		Message: "Sağlık kaydı başarıyla oluşturuldu",
-	})
+	utils.CreatedResponse(c, record, "Sağlık kaydı başarıyla oluşturuldu")
 }
 
 // GetMilkProduction süt üretim kayıtları
 // @Summary Süt üretim kayıtları
 // @Description Süt üretim kayıtlarını getirir
+// @ID getMilkProduction
 // @Tags Livestock
 // @Accept json
 // @Produce json
@@ -661,29 +772,31 @@ func (h *LivestockHandler) GetMilkProduction(c *gin.Context) {
 	animalID := c.DefaultQuery("animalId", "")
 
 	// Sorgu oluştur
-	whereClause := "WHERE user_id = ?"
+	// Süt kayıtlarında kullanıcı sütunu yoktur; çiftlik kapsamı hayvan üzerinden uygulanır
+	whereClause := "WHERE l.user_id = ?"
 	args := []interface{}{userID}
 
 	if animalID != "" {
-		whereClause += " AND animal_id = ?"
+		whereClause += " AND m.livestock_id = ?"
 		args = append(args, animalID)
 	}
 
 	if startDate != "" {
-		whereClause += " AND date >= ?"
+		whereClause += " AND m.date >= ?"
 		args = append(args, startDate)
 	}
 
 	if endDate != "" {
-		whereClause += " AND date <= ?"
+		whereClause += " AND m.date <= ?"
 		args = append(args, endDate)
 	}
 
 	// Süt üretim kayıtlarını getir
 	rows, err := h.db.Query(`
-		SELECT id, animal_id, date, amount, quality, notes, created_at
-		FROM milk_production `+whereClause+`
-		ORDER BY date DESC
+		SELECT m.id, m.livestock_id, m.date, m.amount, COALESCE(m.quality, ''), COALESCE(m.notes, ''), m.created_at
+		FROM milk_production m
+		JOIN livestock l ON l.id = m.livestock_id `+whereClause+`
+		ORDER BY m.date DESC
 	`, args...)
 	if err != nil {
 		utils.ErrorResponse(c, http.StatusInternalServerError, "DB_ERROR", "Süt üretim kayıtları alınamadı", err.Error())
@@ -714,6 +827,7 @@ func (h *LivestockHandler) GetMilkProduction(c *gin.Context) {
 // CreateMilkProduction süt üretim kaydı oluşturma
 // @Summary Süt üretim kaydı oluşturma
 // @Description Yeni süt üretim kaydı oluşturur
+// @ID createMilkProduction
 // @Tags Livestock
 // @Accept json
 // @Produce json
@@ -753,9 +867,9 @@ func (h *LivestockHandler) CreateMilkProduction(c *gin.Context) {
 	// Süt üretim kaydını oluştur
 	productionID := utils.GenerateID()
 	_, err = h.db.Exec(`
-		INSERT INTO milk_production (id, user_id, animal_id, date, amount, quality, notes, created_at)
-		VALUES (?, ?, ?, ?, ?, ?, ?, CURRENT_TIMESTAMP)
-	`, productionID, userID, req.AnimalID, req.Date, req.Amount, req.Quality, req.Notes)
+		INSERT INTO milk_production (id, livestock_id, date, amount, quality, notes, created_at)
+		VALUES (?, ?, ?, ?, ?, ?, CURRENT_TIMESTAMP)
+	`, productionID, req.AnimalID, req.Date, req.Amount, req.Quality, req.Notes)
 
 	if err != nil {
 		utils.ErrorResponse(c, http.StatusInternalServerError, "DB_ERROR", "Süt üretim kaydı oluşturulamadı", err.Error())
@@ -767,9 +881,11 @@ func (h *LivestockHandler) CreateMilkProduction(c *gin.Context) {
 	var date sql.NullTime
 
 	err = h.db.QueryRow(`
-		SELECT id, animal_id, date, amount, quality, notes, created_at
-		FROM milk_production WHERE id = ?
-	`, productionID).Scan(
+		SELECT m.id, m.livestock_id, m.date, m.amount, COALESCE(m.quality, ''), COALESCE(m.notes, ''), m.created_at
+		FROM milk_production m
+		JOIN livestock l ON l.id = m.livestock_id
+		WHERE m.id = ? AND l.user_id = ?
+	`, productionID, userID).Scan(
 		&production.ID, &production.AnimalID, &date, &production.Amount,
 		&production.Quality, &production.Notes, &production.CreatedAt,
 	)
@@ -781,9 +897,233 @@ func (h *LivestockHandler) CreateMilkProduction(c *gin.Context) {
 
 	production.Date = utils.NullTimeToPtr(date)
 
-	c.JSON(http.StatusCreated, models.APIResponse{
-		Success: true,
-		Data:    production,
-		Message: "Süt üretim kaydı başarıyla oluşturuldu",
-	})
+	utils.CreatedResponse(c, production, "Süt üretim kaydı başarıyla oluşturuldu")
+}
+
+// GetMovements hayvan hareket kayıtları
+// @Summary Hayvan hareket kayıtları
+// @Description Belirli bir hayvanın doğum, giriş, satış, nakil ve çıkış hareketlerini listeler
+// @ID getMovements
+// @Tags Livestock
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Hayvan ID"
+// @Success 200 {object} models.APIResponse{data=[]models.LivestockMovement}
+// @Failure 401 {object} models.APIResponse
+// @Failure 404 {object} models.APIResponse
+// @Router /livestock/{id}/movements [get]
+func (h *LivestockHandler) GetMovements(c *gin.Context) {
+	userID, err := utils.GetUserID(c)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusUnauthorized, "UNAUTHORIZED", "Kullanıcı kimliği doğrulanamadı", nil)
+		return
+	}
+
+	animalID := c.Param("id")
+	if utils.IsEmptyString(animalID) {
+		utils.ErrorResponse(c, http.StatusBadRequest, "MISSING_ID", "Hayvan ID gerekli", nil)
+		return
+	}
+
+	// Hayvan kullanıcıya ait mi kontrol et
+	var exists bool
+	err = h.db.QueryRow("SELECT 1 FROM livestock WHERE id = ? AND user_id = ?", animalID, userID).Scan(&exists)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusNotFound, "ANIMAL_NOT_FOUND", "Hayvan bulunamadı", nil)
+		return
+	}
+
+	rows, err := h.db.Query(`
+		SELECT id, livestock_id, movement_type, movement_date, COALESCE(from_location, ''),
+		       COALESCE(to_location, ''), COALESCE(premises_number, ''), COALESCE(reason, ''),
+		       COALESCE(notes, ''), created_at
+		FROM livestock_movements WHERE livestock_id = ? AND user_id = ?
+		ORDER BY movement_date DESC, created_at DESC
+	`, animalID, userID)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusInternalServerError, "DB_ERROR", "Hareket kayıtları alınamadı", err.Error())
+		return
+	}
+	defer rows.Close()
+
+	var movements []models.LivestockMovement
+	for rows.Next() {
+		var movement models.LivestockMovement
+		var movementDate sql.NullTime
+
+		err := rows.Scan(
+			&movement.ID, &movement.LivestockID, &movement.MovementType, &movementDate,
+			&movement.FromLocation, &movement.ToLocation, &movement.PremisesNumber,
+			&movement.Reason, &movement.Notes, &movement.CreatedAt,
+		)
+		if err != nil {
+			continue
+		}
+
+		movement.MovementDate = utils.NullTimeToPtr(movementDate)
+		movements = append(movements, movement)
+	}
+
+	utils.SuccessResponse(c, movements, "Hareket kayıtları başarıyla getirildi")
+}
+
+// CreateMovement hayvan hareket kaydı oluşturma
+// @Summary Hayvan hareket kaydı oluşturma
+// @Description Hayvan için doğum, giriş, satış, nakil, ölüm veya kesim hareketi kaydeder. Nakilde varış yeri zorunludur; çıkış yeri verilmezse hayvanın hareket tarihindeki konumu kullanılır. Hayvanın konum alanı varış yeri olan en son hareketten türetilir
+// @ID createMovement
+// @Tags Livestock
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Hayvan ID"
+// @Param request body models.LivestockMovement true "Hareket bilgileri"
+// @Success 201 {object} models.APIResponse{data=models.LivestockMovement}
+// @Failure 400 {object} models.APIResponse
+// @Failure 401 {object} models.APIResponse
+// @Failure 404 {object} models.APIResponse
+// @Router /livestock/{id}/movements [post]
+func (h *LivestockHandler) CreateMovement(c *gin.Context) {
+	userID, err := utils.GetUserID(c)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusUnauthorized, "UNAUTHORIZED", "Kullanıcı kimliği doğrulanamadı", nil)
+		return
+	}
+
+	animalID := c.Param("id")
+	if utils.IsEmptyString(animalID) {
+		utils.ErrorResponse(c, http.StatusBadRequest, "MISSING_ID", "Hayvan ID gerekli", nil)
+		return
+	}
+
+	var req models.LivestockMovement
+	if err := c.ShouldBindJSON(&req); err != nil {
+		utils.ErrorResponse(c, http.StatusBadRequest, "INVALID_REQUEST", "Geçersiz istek formatı", err.Error())
+		return
+	}
+
+	if !services.IsValidMovementType(req.MovementType) {
+		utils.ErrorResponse(c, http.StatusBadRequest, "INVALID_MOVEMENT_TYPE", "Geçersiz hareket tipi", services.MovementTypes())
+		return
+	}
+
+	movement, err := h.movements.Record(userID, animalID, req)
+	if err != nil {
+		switch {
+		case errors.Is(err, services.ErrLivestockNotFound):
+			utils.ErrorResponse(c, http.StatusNotFound, "ANIMAL_NOT_FOUND", "Hayvan bulunamadı", nil)
+		case errors.Is(err, services.ErrMovementDestinationRequired):
+			utils.ErrorResponse(c, http.StatusBadRequest, "DESTINATION_REQUIRED", "Nakil hareketinde varış yeri zorunludur", nil)
+		case errors.Is(err, services.ErrMovementSameLocation):
+			utils.ErrorResponse(c, http.StatusBadRequest, "SAME_LOCATION", "Çıkış ve varış yeri aynı olamaz", nil)
+		default:
+			utils.ErrorResponse(c, http.StatusInternalServerError, "DB_ERROR", "Hareket kaydı oluşturulamadı", err.Error())
+		}
+		return
+	}
+
+	utils.CreatedResponse(c, movement, "Hareket kaydı başarıyla oluşturuldu")
+}
+
+// GetLivestockHistory hayvan değişiklik geçmişi
+// @Summary Hayvan değişiklik geçmişi
+// @Description Hayvanın kilo, sağlık durumu gibi alanlarında yapılan değişiklikleri, eski/yeni değer ve değiştiren kullanıcıyla en yeniden eskiye listeler
+// @ID getLivestockHistory
+// @Tags Livestock
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Hayvan ID"
+// @Param field query string false "Yalnızca bu alanın değişiklikleri (örn. weight, healthStatus)"
+// @Param page query int false "Sayfa numarası"
+// @Param limit query int false "Sayfa başına kayıt"
+// @Success 200 {object} models.APIResponse{data=models.EntityHistory}
+// @Failure 401 {object} models.APIResponse
+// @Failure 404 {object} models.APIResponse
+// @Router /livestock/{id}/history [get]
+func (h *LivestockHandler) GetLivestockHistory(c *gin.Context) {
+	userID, err := utils.GetUserID(c)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusUnauthorized, "UNAUTHORIZED", "Kullanıcı kimliği doğrulanamadı", nil)
+		return
+	}
+
+	animalID := c.Param("id")
+
+	// Hayvan kullanıcıya ait mi kontrol et
+	var exists bool
+	err = h.db.QueryRow("SELECT 1 FROM livestock WHERE id = ? AND user_id = ?", animalID, userID).Scan(&exists)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusNotFound, "ANIMAL_NOT_FOUND", "Hayvan bulunamadı", nil)
+		return
+	}
+
+	writeEntityHistory(c, h.history, userID, services.HistoryEntityLivestock, animalID)
+}
+
+// healthCheckupReminderDays sonraki kontrol/aşı tarihine kaç gün kala hatırlatma gönderileceği
+const healthCheckupReminderDays = 3
+
+// StartHealthReminders yaklaşan aşı ve sağlık kontrolü hatırlatmalarını saatlik olarak arka planda gönderir
+func (h *LivestockHandler) StartHealthReminders() {
+	go func() {
+		ticker := time.NewTicker(time.Hour)
+		defer ticker.Stop()
+
+		for {
+			if err := h.SendHealthReminders(); err != nil {
+				log.Printf("Sağlık hatırlatmaları gönderilemedi: %v", err)
+			}
+			<-ticker.C
+		}
+	}()
+}
+
+// SendHealthReminders sonraki kontrol tarihi 3 gün içinde olan sağlık kayıtları için hatırlatma gönderir;
+// aşı kayıtları aşı, diğerleri sağlık konusuyla bildirilir ve her kayıt-tarih çifti bir kez hatırlatılır
+func (h *LivestockHandler) SendHealthReminders() error {
+	today := time.Now().Format("2006-01-02")
+	rows, err := h.db.Query(`
+		SELECT r.id, r.type, r.next_checkup, l.id, l.user_id, l.tag_number
+		FROM health_records r
+		JOIN livestock l ON l.id = r.livestock_id
+		WHERE r.next_checkup IS NOT NULL AND date(r.next_checkup) BETWEEN date(?) AND date(?, ?)
+	`, today, today, fmt.Sprintf("+%d days", healthCheckupReminderDays))
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	var reminders []services.Notification
+	for rows.Next() {
+		var recordID, recordType, animalID, userID, tagNumber string
+		var nextCheckup time.Time
+		if err := rows.Scan(&recordID, &recordType, &nextCheckup, &animalID, &userID, &tagNumber); err != nil {
+			continue
+		}
+
+		reminder := services.Notification{
+			UserID:       userID,
+			Template:     "health_checkup",
+			Type:         "reminder",
+			Priority:     "medium",
+			Topic:        models.NotificationTopicHealthAlert,
+			Entity:       &models.RelatedEntity{Type: "livestock", ID: animalID, Name: tagNumber},
+			Params:       map[string]interface{}{"date": nextCheckup},
+			DedupeKey:    "health_checkup:" + recordID + ":" + nextCheckup.Format("2006-01-02"),
+			DedupeWindow: (healthCheckupReminderDays + 1) * 24 * time.Hour,
+		}
+		if recordType == models.ProtocolStepVaccination {
+			reminder.Template = "vaccination_due"
+			reminder.Priority = "high"
+			reminder.Topic = models.NotificationTopicVaccinationDue
+		}
+		reminders = append(reminders, reminder)
+	}
+	if err := rows.Err(); err != nil {
+		return err
+	}
+
+	_, err = h.notifications.CreateBatch(reminders)
+	return err
 }
@@ -0,0 +1,204 @@
+package handlers
+
+import (
+	"database/sql"
+	"errors"
+	"net/http"
+
+	"agri-management-api/internal/models"
+	"agri-management-api/internal/services"
+	"agri-management-api/internal/utils"
+
+	"github.com/gin-gonic/gin"
+)
+
+// LivestockBreedHandler hayvan türü ve ırk başvuru listesini yönetir
+type LivestockBreedHandler struct {
+	db     *sql.DB
+	breeds *services.LivestockBreedService
+}
+
+// NewLivestockBreedHandler yeni livestock breed handler oluşturur
+func NewLivestockBreedHandler(db *sql.DB) *LivestockBreedHandler {
+	return &LivestockBreedHandler{
+		db:     db,
+		breeds: services.NewLivestockBreedService(db),
+	}
+}
+
+// GetSpecies hayvan türleri ve ırkları
+// @Summary Hayvan türleri ve ırkları
+// @Description Hayvan kaydında kullanılabilecek türleri (sistem türleri ve çiftliğin livestock kategorileri) sistem ve çiftlik ırklarıyla listeler
+// @ID getLivestockSpecies
+// @Tags Livestock
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {object} models.APIResponse{data=[]models.LivestockSpecies}
+// @Failure 401 {object} models.APIResponse
+// @Router /livestock/species [get]
+func (h *LivestockBreedHandler) GetSpecies(c *gin.Context) {
+	userID, err := utils.GetUserID(c)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusUnauthorized, "UNAUTHORIZED", "Kullanıcı kimliği doğrulanamadı", nil)
+		return
+	}
+
+	species, err := h.breeds.Species(userID)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusInternalServerError, "DB_ERROR", "Hayvan türleri alınamadı", err.Error())
+		return
+	}
+
+	utils.SuccessResponse(c, species, "Hayvan türleri başarıyla getirildi")
+}
+
+// CreateBreed çiftliğe özel ırk ekleme
+// @Summary Irk ekleme
+// @Description Çiftliğin görebildiği bir türe yalnızca bu çiftlikte geçerli yeni ırk ekler
+// @ID createLivestockBreed
+// @Tags Livestock
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param request body models.LivestockBreedRequest true "Irk bilgileri"
+// @Success 201 {object} models.APIResponse{data=models.LivestockBreed}
+// @Failure 400 {object} models.APIResponse
+// @Failure 401 {object} models.APIResponse
+// @Failure 409 {object} models.APIResponse
+// @Router /livestock/breeds [post]
+func (h *LivestockBreedHandler) CreateBreed(c *gin.Context) {
+	userID, err := utils.GetUserID(c)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusUnauthorized, "UNAUTHORIZED", "Kullanıcı kimliği doğrulanamadı", nil)
+		return
+	}
+
+	h.createBreed(c, userID)
+}
+
+// DeleteBreed çiftliğe özel ırkı silme
+// @Summary Irk silme
+// @Description Çiftliğin eklediği ırkı siler; sistem ırkları silinemez. Bu ırktaki hayvan kayıtları değişmez
+// @ID deleteLivestockBreed
+// @Tags Livestock
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Irk ID"
+// @Success 200 {object} models.APIResponse
+// @Failure 401 {object} models.APIResponse
+// @Failure 404 {object} models.APIResponse
+// @Router /livestock/breeds/{id} [delete]
+func (h *LivestockBreedHandler) DeleteBreed(c *gin.Context) {
+	userID, err := utils.GetUserID(c)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusUnauthorized, "UNAUTHORIZED", "Kullanıcı kimliği doğrulanamadı", nil)
+		return
+	}
+
+	err = h.breeds.DeleteBreed(userID, c.Param("id"))
+	if errors.Is(err, services.ErrBreedNotFound) {
+		utils.ErrorResponse(c, http.StatusNotFound, "BREED_NOT_FOUND", "Irk bulunamadı veya sistem ırkı", nil)
+		return
+	}
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusInternalServerError, "DB_ERROR", "Irk silinemedi", err.Error())
+		return
+	}
+
+	utils.SuccessResponse(c, nil, "Irk başarıyla silindi")
+}
+
+// CreateSystemSpecies sistem hayvan türü ekleme
+// @Summary Sistem hayvan türü ekleme
+// @Description Tüm çiftliklerde hayvan kaydında kullanılabilecek yeni tür ekler (livestock sistem kategorisi). Yönetici rolü gerektirir
+// @ID createSystemLivestockSpecies
+// @Tags Admin
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param request body models.LivestockSpeciesRequest true "Tür bilgileri"
+// @Success 201 {object} models.APIResponse{data=models.LivestockSpecies}
+// @Failure 400 {object} models.APIResponse
+// @Failure 401 {object} models.APIResponse
+// @Failure 403 {object} models.APIResponse
+// @Failure 409 {object} models.APIResponse
+// @Router /admin/livestock/species [post]
+func (h *LivestockBreedHandler) CreateSystemSpecies(c *gin.Context) {
+	var req models.LivestockSpeciesRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		utils.ErrorResponse(c, http.StatusBadRequest, "INVALID_REQUEST", "Geçersiz istek formatı", err.Error())
+		return
+	}
+
+	species, err := h.breeds.AddSystemSpecies(req)
+	if errors.Is(err, services.ErrSpeciesExists) {
+		utils.ErrorResponse(c, http.StatusConflict, "SPECIES_EXISTS", "Bu tür zaten tanımlı", nil)
+		return
+	}
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusInternalServerError, "DB_ERROR", "Tür eklenemedi", err.Error())
+		return
+	}
+
+	utils.CreatedResponse(c, species, "Tür başarıyla eklendi")
+}
+
+// CreateSystemBreed sistem ırkı ekleme
+// @Summary Sistem ırkı ekleme
+// @Description Bir sistem türüne tüm çiftliklerde geçerli yeni ırk ekler. Yönetici rolü gerektirir
+// @ID createSystemLivestockBreed
+// @Tags Admin
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param request body models.LivestockBreedRequest true "Irk bilgileri"
+// @Success 201 {object} models.APIResponse{data=models.LivestockBreed}
+// @Failure 400 {object} models.APIResponse
+// @Failure 401 {object} models.APIResponse
+// @Failure 403 {object} models.APIResponse
+// @Failure 409 {object} models.APIResponse
+// @Router /admin/livestock/breeds [post]
+func (h *LivestockBreedHandler) CreateSystemBreed(c *gin.Context) {
+	h.createBreed(c, "")
+}
+
+// createBreed ırkı çiftliğe veya farmID boşsa sisteme ekler ve yanıtı yazar
+func (h *LivestockBreedHandler) createBreed(c *gin.Context, farmID string) {
+	var req models.LivestockBreedRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		utils.ErrorResponse(c, http.StatusBadRequest, "INVALID_REQUEST", "Geçersiz istek formatı", err.Error())
+		return
+	}
+
+	breed, err := h.breeds.AddBreed(farmID, req)
+	switch {
+	case errors.Is(err, services.ErrUnknownSpecies):
+		utils.ErrorResponse(c, http.StatusBadRequest, "INVALID_SPECIES", "Tanımsız hayvan türü", h.breeds.SpeciesKeys(farmID))
+	case errors.Is(err, services.ErrBreedExists):
+		utils.ErrorResponse(c, http.StatusConflict, "BREED_EXISTS", "Bu ırk zaten tanımlı", nil)
+	case err != nil:
+		utils.ErrorResponse(c, http.StatusInternalServerError, "DB_ERROR", "Irk eklenemedi", err.Error())
+	default:
+		utils.CreatedResponse(c, breed, "Irk başarıyla eklendi")
+	}
+}
+
+// validateBreed hayvanın türünü ve ırkını başvuru listesine göre doğrular ve ırkı listedeki yazımıyla döner;
+// hata yanıtı yazıldıysa false döner
+func validateBreed(c *gin.Context, breeds *services.LivestockBreedService, farmID, species, breed string) (string, bool) {
+	breed, err := breeds.Validate(farmID, species, breed)
+	switch {
+	case errors.Is(err, services.ErrUnknownSpecies):
+		utils.ErrorResponse(c, http.StatusBadRequest, "INVALID_SPECIES", "Tanımsız hayvan türü", breeds.SpeciesKeys(farmID))
+		return breed, false
+	case errors.Is(err, services.ErrUnknownBreed):
+		utils.ErrorResponse(c, http.StatusBadRequest, "INVALID_BREED", "Bu tür için tanımsız ırk", breeds.BreedNames(farmID, species))
+		return breed, false
+	case err != nil:
+		utils.ErrorResponse(c, http.StatusInternalServerError, "DB_ERROR", "Irk doğrulanamadı", err.Error())
+		return breed, false
+	}
+	return breed, true
+}
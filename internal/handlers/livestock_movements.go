@@ -0,0 +1,134 @@
+package handlers
+
+import (
+	"net/http"
+	"strings"
+
+	"agri-management-api/internal/models"
+	"agri-management-api/internal/services"
+	"agri-management-api/internal/utils"
+
+	"github.com/gin-gonic/gin"
+)
+
+// GetLocationOccupancy konum doluluğu
+// @Summary Konum (ahır) doluluğu
+// @Description Sürüdeki (satılmamış, ölmemiş, kesilmemiş) hayvanları güncel konumlarına ve türlerine göre sayar; konumu olmayan hayvanlar boş konum satırında listelenir. Son giriş, konuma yapılan en son hareketin tarihidir
+// @ID getLocationOccupancy
+// @Tags Livestock
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {object} models.APIResponse{data=[]models.LivestockLocationOccupancy}
+// @Failure 401 {object} models.APIResponse
+// @Router /livestock/locations [get]
+func (h *LivestockHandler) GetLocationOccupancy(c *gin.Context) {
+	userID, err := utils.GetUserID(c)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusUnauthorized, "UNAUTHORIZED", "Kullanıcı kimliği doğrulanamadı", nil)
+		return
+	}
+
+	occupancy, err := h.movements.Occupancy(userID)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusInternalServerError, "DB_ERROR", "Konum doluluğu alınamadı", err.Error())
+		return
+	}
+
+	utils.SuccessResponse(c, occupancy, "Konum doluluğu başarıyla getirildi")
+}
+
+// GetMovementReport hayvan hareket raporu
+// @Summary Hayvan hareket raporu
+// @Description İzlenebilirlik denetimleri için tarih aralığındaki tüm hayvan hareketlerini küpe numarası ve türle eskiden yeniye listeler; konum verilirse çıkış veya varış yeri bu konum olan hareketler döner
+// @ID getMovementReport
+// @Tags Livestock
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param startDate query string false "Başlangıç tarihi (YYYY-MM-DD)"
+// @Param endDate query string false "Bitiş tarihi (YYYY-MM-DD, dahil)"
+// @Param location query string false "Konum (ahır, bölme, mera)"
+// @Param type query string false "Hareket tipi (birth, purchase, sale, transfer, death, slaughter)"
+// @Success 200 {object} models.APIResponse{data=models.LivestockMovementReport}
+// @Failure 400 {object} models.APIResponse
+// @Failure 401 {object} models.APIResponse
+// @Router /livestock/movements [get]
+func (h *LivestockHandler) GetMovementReport(c *gin.Context) {
+	userID, err := utils.GetUserID(c)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusUnauthorized, "UNAUTHORIZED", "Kullanıcı kimliği doğrulanamadı", nil)
+		return
+	}
+
+	report, ok := h.movementReport(c, userID)
+	if !ok {
+		return
+	}
+
+	utils.SuccessResponse(c, report, "Hareket raporu başarıyla getirildi")
+}
+
+// ExportMovementReport hayvan hareket raporu dışa aktarımı
+// @Summary Hayvan hareket raporunu dışa aktar
+// @Description Hareket raporunu hareket başına bir satır ve toplam satırıyla CSV (noktalı virgülle ayrılmış, UTF-8 BOM) veya Excel (XLSX) dosyası olarak indirir
+// @ID exportMovementReport
+// @Tags Livestock
+// @Accept json
+// @Produce text/csv
+// @Produce application/vnd.openxmlformats-officedocument.spreadsheetml.sheet
+// @Security BearerAuth
+// @Param format query string false "Dosya formatı (csv, xlsx)" default(csv)
+// @Param startDate query string false "Başlangıç tarihi (YYYY-MM-DD)"
+// @Param endDate query string false "Bitiş tarihi (YYYY-MM-DD, dahil)"
+// @Param location query string false "Konum (ahır, bölme, mera)"
+// @Param type query string false "Hareket tipi (birth, purchase, sale, transfer, death, slaughter)"
+// @Success 200 {file} file
+// @Failure 400 {object} models.APIResponse
+// @Failure 401 {object} models.APIResponse
+// @Router /livestock/movements/export [get]
+func (h *LivestockHandler) ExportMovementReport(c *gin.Context) {
+	userID, err := utils.GetUserID(c)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusUnauthorized, "UNAUTHORIZED", "Kullanıcı kimliği doğrulanamadı", nil)
+		return
+	}
+
+	format := strings.ToLower(c.DefaultQuery("format", services.CalendarExportCSV))
+	if format != services.CalendarExportCSV && format != services.CalendarExportXLSX {
+		utils.ErrorResponse(c, http.StatusBadRequest, "INVALID_FORMAT", "Geçersiz dosya formatı", []string{services.CalendarExportCSV, services.CalendarExportXLSX})
+		return
+	}
+
+	report, ok := h.movementReport(c, userID)
+	if !ok {
+		return
+	}
+	formatter, ok := exportFormatter(c, h.formats, userID, format)
+	if !ok {
+		return
+	}
+
+	writeCalendarExport(c, format, "hayvan-hareketleri", "Hayvan Hareketleri", services.MovementRecords(report, formatter))
+}
+
+// movementReport rapor filtrelerini sorgu parametrelerinden okuyup raporu üretir; hata yanıtı yazıldıysa false döner
+func (h *LivestockHandler) movementReport(c *gin.Context, userID string) (models.LivestockMovementReport, bool) {
+	startDate, endDate, ok := dateRangeQuery(c)
+	if !ok {
+		return models.LivestockMovementReport{}, false
+	}
+
+	movementType := c.Query("type")
+	if movementType != "" && !services.IsValidMovementType(movementType) {
+		utils.ErrorResponse(c, http.StatusBadRequest, "INVALID_MOVEMENT_TYPE", "Geçersiz hareket tipi", services.MovementTypes())
+		return models.LivestockMovementReport{}, false
+	}
+
+	report, err := h.movements.Report(userID, startDate, endDate, strings.TrimSpace(c.Query("location")), movementType)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusInternalServerError, "DB_ERROR", "Hareket raporu alınamadı", err.Error())
+		return report, false
+	}
+	return report, true
+}
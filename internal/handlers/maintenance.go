@@ -0,0 +1,175 @@
+package handlers
+
+import (
+	"database/sql"
+	"errors"
+	"net/http"
+
+	"agri-management-api/internal/models"
+	"agri-management-api/internal/services"
+	"agri-management-api/internal/utils"
+
+	"github.com/gin-gonic/gin"
+)
+
+// MaintenanceHandler bakım modunu ve planlanmış bakım pencerelerini yönetir
+type MaintenanceHandler struct {
+	db          *sql.DB
+	maintenance *services.MaintenanceService
+}
+
+// NewMaintenanceHandler yeni maintenance handler oluşturur
+func NewMaintenanceHandler(db *sql.DB) *MaintenanceHandler {
+	return &MaintenanceHandler{
+		db:          db,
+		maintenance: services.NewMaintenanceService(db),
+	}
+}
+
+// GetMaintenanceStatus bakım durumu
+// @Summary Bakım durumu
+// @Description Süren bakımı (varsa tahmini bitiş zamanıyla) ve planlanmış bakım pencerelerini getirir; uygulamalar bakım bandı göstermek için kimlik doğrulamadan çağırabilir. Bakım sürerken yazma istekleri MAINTENANCE_MODE koduyla 503 döner, okumalar çalışmaya devam eder
+// @ID getMaintenanceStatus
+// @Tags Maintenance
+// @Produce json
+// @Success 200 {object} models.APIResponse{data=models.MaintenanceStatus}
+// @Router /maintenance [get]
+func (h *MaintenanceHandler) GetMaintenanceStatus(c *gin.Context) {
+	status, err := h.maintenance.Status()
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusInternalServerError, "DB_ERROR", "Bakım durumu alınamadı", err.Error())
+		return
+	}
+
+	utils.SuccessResponse(c, status, "Bakım durumu başarıyla getirildi")
+}
+
+// GetMaintenanceWindows bakım pencereleri
+// @Summary Bakım pencereleri
+// @Description Bitmiş olanlar dahil son 50 bakım penceresini başlangıç zamanına göre yeniden eskiye getirir. Yönetici rolü gerektirir
+// @ID getMaintenanceWindows
+// @Tags Admin
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {object} models.APIResponse{data=[]models.MaintenanceWindow}
+// @Failure 401 {object} models.APIResponse
+// @Failure 403 {object} models.APIResponse
+// @Router /admin/maintenance/windows [get]
+func (h *MaintenanceHandler) GetMaintenanceWindows(c *gin.Context) {
+	windows, err := h.maintenance.Windows(50)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusInternalServerError, "DB_ERROR", "Bakım pencereleri alınamadı", err.Error())
+		return
+	}
+
+	utils.SuccessResponse(c, windows, "Bakım pencereleri başarıyla getirildi")
+}
+
+// SetMaintenanceMode bakım modunu açma/kapama
+// @Summary Bakım modunu aç/kapat
+// @Description enabled=true bakım modunu hemen açar; message kullanıcılara gösterilir, expectedEndAt verilirse 503 yanıtlarında bitiş zamanı ve Retry-After başlığı olarak döner. Bakım zaten sürüyorsa mesajı ve tahmini bitişi güncellenir. enabled=false süren bakımı bitirir, planlanmış pencereleri etkilemez. Yönetici uç noktaları bakım sırasında da çalışır. Yönetici rolü gerektirir
+// @ID setMaintenanceMode
+// @Tags Admin
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param request body models.MaintenanceModeRequest true "Bakım modu"
+// @Success 200 {object} models.APIResponse{data=models.MaintenanceStatus}
+// @Failure 400 {object} models.APIResponse
+// @Failure 401 {object} models.APIResponse
+// @Failure 403 {object} models.APIResponse
+// @Router /admin/maintenance [put]
+func (h *MaintenanceHandler) SetMaintenanceMode(c *gin.Context) {
+	var req models.MaintenanceModeRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		utils.ErrorResponse(c, http.StatusBadRequest, "INVALID_REQUEST", "Geçersiz istek formatı", err.Error())
+		return
+	}
+
+	var err error
+	if req.Enabled {
+		_, err = h.maintenance.Enable(c.GetString("user_id"), req.Message, req.ExpectedEndAt)
+	} else {
+		err = h.maintenance.Disable()
+	}
+	if err != nil {
+		writeMaintenanceError(c, err)
+		return
+	}
+
+	status, err := h.maintenance.Status()
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusInternalServerError, "DB_ERROR", "Bakım durumu alınamadı", err.Error())
+		return
+	}
+
+	message := "Bakım modu kapatıldı"
+	if req.Enabled {
+		message = "Bakım modu açıldı"
+	}
+	utils.SuccessResponse(c, status, message)
+}
+
+// ScheduleMaintenanceWindow bakım penceresi planlama
+// @Summary Bakım penceresi planla
+// @Description Başlangıç zamanında kendiliğinden devreye girip bitiş zamanında sona eren bakım penceresi planlar; pencere başlamadan GET /maintenance yanıtında upcoming altında görünür. Yönetici rolü gerektirir
+// @ID scheduleMaintenanceWindow
+// @Tags Admin
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param request body models.MaintenanceWindowRequest true "Bakım penceresi"
+// @Success 201 {object} models.APIResponse{data=models.MaintenanceWindow}
+// @Failure 400 {object} models.APIResponse
+// @Failure 401 {object} models.APIResponse
+// @Failure 403 {object} models.APIResponse
+// @Router /admin/maintenance/windows [post]
+func (h *MaintenanceHandler) ScheduleMaintenanceWindow(c *gin.Context) {
+	var req models.MaintenanceWindowRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		utils.ErrorResponse(c, http.StatusBadRequest, "INVALID_REQUEST", "Geçersiz istek formatı", err.Error())
+		return
+	}
+
+	window, err := h.maintenance.Schedule(c.GetString("user_id"), req)
+	if err != nil {
+		writeMaintenanceError(c, err)
+		return
+	}
+
+	utils.CreatedResponse(c, window, "Bakım penceresi başarıyla planlandı")
+}
+
+// DeleteMaintenanceWindow bakım penceresi silme
+// @Summary Bakım penceresi sil
+// @Description Planlanmış bakım penceresini iptal eder; süren bir pencere silinirse bakım hemen sona erer. Yönetici rolü gerektirir
+// @ID deleteMaintenanceWindow
+// @Tags Admin
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Bakım penceresi ID"
+// @Success 200 {object} models.APIResponse
+// @Failure 401 {object} models.APIResponse
+// @Failure 403 {object} models.APIResponse
+// @Failure 404 {object} models.APIResponse
+// @Router /admin/maintenance/windows/{id} [delete]
+func (h *MaintenanceHandler) DeleteMaintenanceWindow(c *gin.Context) {
+	if err := h.maintenance.Delete(c.Param("id")); err != nil {
+		writeMaintenanceError(c, err)
+		return
+	}
+
+	utils.SuccessResponse(c, nil, "Bakım penceresi başarıyla silindi")
+}
+
+// writeMaintenanceError bakım hatasını uygun HTTP yanıtına çevirir
+func writeMaintenanceError(c *gin.Context, err error) {
+	switch {
+	case errors.Is(err, services.ErrInvalidMaintenanceWindow):
+		utils.ErrorResponse(c, http.StatusBadRequest, "INVALID_MAINTENANCE_WINDOW", err.Error(), nil)
+	case errors.Is(err, services.ErrMaintenanceWindowNotFound):
+		utils.ErrorResponse(c, http.StatusNotFound, "MAINTENANCE_WINDOW_NOT_FOUND", "Bakım penceresi bulunamadı", nil)
+	default:
+		utils.ErrorResponse(c, http.StatusInternalServerError, "DB_ERROR", "Bakım modu güncellenemedi", err.Error())
+	}
+}
@@ -0,0 +1,508 @@
+package handlers
+
+import (
+	"bytes"
+	"context"
+	"database/sql"
+	"io"
+	"log"
+	"net/http"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"agri-management-api/internal/models"
+	"agri-management-api/internal/services"
+	"agri-management-api/internal/utils"
+
+	"github.com/gin-gonic/gin"
+)
+
+// maxVoiceNoteSize yüklenebilecek en büyük ses notu boyutu
+const maxVoiceNoteSize = 10 << 20
+
+// maxPhotoSize yüklenebilecek en büyük fotoğraf boyutu
+const maxPhotoSize = 10 << 20
+
+// photoContentTypes yüklenebilen fotoğraf biçimleri; hayvan pasaportuna bu biçimler gömülebilir
+var photoContentTypes = map[string]bool{
+	"image/jpeg": true,
+	"image/png":  true,
+	"image/gif":  true,
+}
+
+// mediaEntityOwnership medya eklenebilecek kayıtlar için sahiplik sorguları
+var mediaEntityOwnership = map[string]string{
+	"livestock":                "SELECT 1 FROM livestock WHERE id = ? AND user_id = ?",
+	"land":                     "SELECT 1 FROM lands WHERE id = ? AND user_id = ?",
+	"land_activity":            "SELECT 1 FROM land_activities la JOIN lands l ON la.land_id = l.id WHERE la.id = ? AND l.user_id = ?",
+	"pest_disease_observation": "SELECT 1 FROM pest_disease_observations WHERE id = ? AND user_id = ?",
+	"scouting_point":           "SELECT 1 FROM scouting_points WHERE id = ? AND user_id = ?",
+}
+
+// MediaHandler medya eklerini (ses notları ve fotoğraflar) yönetir
+type MediaHandler struct {
+	db          *sql.DB
+	store       services.MediaStore
+	transcriber services.Transcriber
+}
+
+// NewMediaHandler yeni media handler oluşturur
+func NewMediaHandler(db *sql.DB) *MediaHandler {
+	return &MediaHandler{
+		db:          db,
+		store:       services.NewMediaStore(),
+		transcriber: services.NewTranscriber(),
+	}
+}
+
+// UploadVoiceNote ses notu yükleme
+// @Summary Ses notu yükleme
+// @Description Hayvan, arazi veya arazi aktivitesine kısa ses notu ekler; konuşma-metin sağlayıcısı yapılandırılmışsa transkript arka planda oluşturulur
+// @ID uploadVoiceNote
+// @Tags Media
+// @Accept multipart/form-data
+// @Produce json
+// @Security BearerAuth
+// @Param file formData file true "Ses dosyası"
+// @Param entityType formData string true "Kayıt türü (livestock, land, land_activity, pest_disease_observation, scouting_point)"
+// @Param entityId formData string true "Kayıt ID"
+// @Param durationSeconds formData number false "Kayıt süresi (saniye)"
+// @Success 201 {object} models.APIResponse{data=models.MediaAttachment}
+// @Failure 400 {object} models.APIResponse
+// @Failure 401 {object} models.APIResponse
+// @Failure 404 {object} models.APIResponse
+// @Router /media/voice-notes [post]
+func (h *MediaHandler) UploadVoiceNote(c *gin.Context) {
+	userID, err := utils.GetUserID(c)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusUnauthorized, "UNAUTHORIZED", "Kullanıcı kimliği doğrulanamadı", nil)
+		return
+	}
+
+	entityType := c.PostForm("entityType")
+	entityID := c.PostForm("entityId")
+	if utils.IsEmptyString(entityType) || utils.IsEmptyString(entityID) {
+		utils.ErrorResponse(c, http.StatusBadRequest, "MISSING_FIELDS", "Gerekli alanlar eksik", nil)
+		return
+	}
+
+	ownershipQuery, ok := mediaEntityOwnership[entityType]
+	if !ok {
+		utils.ErrorResponse(c, http.StatusBadRequest, "INVALID_ENTITY_TYPE", "Geçersiz kayıt türü", []string{"livestock", "land", "land_activity", "pest_disease_observation", "scouting_point"})
+		return
+	}
+
+	var exists bool
+	if err := h.db.QueryRow(ownershipQuery, entityID, userID).Scan(&exists); err != nil {
+		utils.ErrorResponse(c, http.StatusNotFound, "ENTITY_NOT_FOUND", "Kayıt bulunamadı", nil)
+		return
+	}
+
+	fileHeader, err := c.FormFile("file")
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusBadRequest, "MISSING_FILE", "Ses dosyası gerekli", nil)
+		return
+	}
+	if fileHeader.Size > maxVoiceNoteSize {
+		utils.ErrorResponse(c, http.StatusBadRequest, "FILE_TOO_LARGE", "Ses dosyası çok büyük", nil)
+		return
+	}
+
+	contentType := fileHeader.Header.Get("Content-Type")
+	if !strings.HasPrefix(contentType, "audio/") {
+		utils.ErrorResponse(c, http.StatusBadRequest, "INVALID_FILE_TYPE", "Yalnızca ses dosyaları yüklenebilir", nil)
+		return
+	}
+
+	file, err := fileHeader.Open()
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusBadRequest, "INVALID_FILE", "Ses dosyası okunamadı", err.Error())
+		return
+	}
+	defer file.Close()
+
+	audio, err := io.ReadAll(file)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusBadRequest, "INVALID_FILE", "Ses dosyası okunamadı", err.Error())
+		return
+	}
+
+	var duration *float64
+	if value := c.PostForm("durationSeconds"); value != "" {
+		if seconds, err := strconv.ParseFloat(value, 64); err == nil {
+			duration = &seconds
+		}
+	}
+
+	mediaID := utils.GenerateID()
+	storageKey := filepath.Join(userID, mediaID+strings.ToLower(filepath.Ext(fileHeader.Filename)))
+
+	size, err := h.store.Save(storageKey, bytes.NewReader(audio))
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusInternalServerError, "STORAGE_ERROR", "Ses dosyası kaydedilemedi", err.Error())
+		return
+	}
+
+	transcriptStatus := models.TranscriptStatusNone
+	if h.transcriber != nil {
+		transcriptStatus = models.TranscriptStatusPending
+	}
+
+	_, err = h.db.Exec(`
+		INSERT INTO media_attachments (id, user_id, entity_type, entity_id, kind, filename, content_type,
+		                               size, storage_key, duration_seconds, transcript_status, created_at)
+		VALUES (?, ?, ?, ?, 'audio', ?, ?, ?, ?, ?, ?, CURRENT_TIMESTAMP)
+	`, mediaID, userID, entityType, entityID, filepath.Base(fileHeader.Filename), contentType,
+		size, storageKey, duration, transcriptStatus)
+	if err != nil {
+		h.store.Delete(storageKey)
+		utils.ErrorResponse(c, http.StatusInternalServerError, "DB_ERROR", "Ses notu oluşturulamadı", err.Error())
+		return
+	}
+
+	if h.transcriber != nil {
+		go h.transcribe(userID, mediaID, fileHeader.Filename, audio)
+	}
+
+	media, err := h.getMedia(mediaID, userID)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusInternalServerError, "FETCH_ERROR", "Oluşturulan ses notu getirilemedi", err.Error())
+		return
+	}
+
+	utils.CreatedResponse(c, media, "Ses notu başarıyla yüklendi")
+}
+
+// GetVoiceNotes ses notu listesi
+// @Summary Ses notları
+// @Description Kullanıcının ses notlarını, isteğe bağlı olarak kayda göre filtreleyerek listeler
+// @ID getVoiceNotes
+// @Tags Media
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param entityType query string false "Kayıt türü (livestock, land, land_activity, pest_disease_observation, scouting_point)"
+// @Param entityId query string false "Kayıt ID"
+// @Success 200 {object} models.APIResponse{data=[]models.MediaAttachment}
+// @Failure 401 {object} models.APIResponse
+// @Router /media/voice-notes [get]
+func (h *MediaHandler) GetVoiceNotes(c *gin.Context) {
+	userID, err := utils.GetUserID(c)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusUnauthorized, "UNAUTHORIZED", "Kullanıcı kimliği doğrulanamadı", nil)
+		return
+	}
+
+	whereClause := "WHERE user_id = ? AND kind = 'audio'"
+	args := []interface{}{userID}
+
+	if entityType := c.Query("entityType"); entityType != "" {
+		whereClause += " AND entity_type = ?"
+		args = append(args, entityType)
+	}
+
+	if entityID := c.Query("entityId"); entityID != "" {
+		whereClause += " AND entity_id = ?"
+		args = append(args, entityID)
+	}
+
+	rows, err := h.db.Query(`
+		SELECT id, user_id, entity_type, entity_id, kind, filename, COALESCE(content_type, ''), size,
+		       duration_seconds, COALESCE(transcript, ''), transcript_status, created_at
+		FROM media_attachments `+whereClause+`
+		ORDER BY created_at DESC
+	`, args...)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusInternalServerError, "DB_ERROR", "Ses notları alınamadı", err.Error())
+		return
+	}
+	defer rows.Close()
+
+	var notes []models.MediaAttachment
+	for rows.Next() {
+		media, err := scanMedia(rows)
+		if err != nil {
+			continue
+		}
+		notes = append(notes, media)
+	}
+
+	utils.SuccessResponse(c, notes, "Ses notları başarıyla getirildi")
+}
+
+// UploadPhoto fotoğraf yükleme
+// @Summary Fotoğraf yükleme
+// @Description Hayvan, arazi veya arazi aktivitesine fotoğraf ekler. Hayvanın en son yüklenen fotoğrafı hayvan pasaportunda (GET /livestock/{id}/passport.pdf) kullanılır
+// @ID uploadPhoto
+// @Tags Media
+// @Accept multipart/form-data
+// @Produce json
+// @Security BearerAuth
+// @Param file formData file true "Fotoğraf (JPEG, PNG veya GIF, en fazla 10 MB)"
+// @Param entityType formData string true "Kayıt türü (livestock, land, land_activity, pest_disease_observation, scouting_point)"
+// @Param entityId formData string true "Kayıt ID"
+// @Success 201 {object} models.APIResponse{data=models.MediaAttachment}
+// @Failure 400 {object} models.APIResponse
+// @Failure 401 {object} models.APIResponse
+// @Failure 404 {object} models.APIResponse
+// @Router /media/photos [post]
+func (h *MediaHandler) UploadPhoto(c *gin.Context) {
+	userID, err := utils.GetUserID(c)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusUnauthorized, "UNAUTHORIZED", "Kullanıcı kimliği doğrulanamadı", nil)
+		return
+	}
+
+	entityType := c.PostForm("entityType")
+	entityID := c.PostForm("entityId")
+	if utils.IsEmptyString(entityType) || utils.IsEmptyString(entityID) {
+		utils.ErrorResponse(c, http.StatusBadRequest, "MISSING_FIELDS", "Gerekli alanlar eksik", nil)
+		return
+	}
+
+	ownershipQuery, ok := mediaEntityOwnership[entityType]
+	if !ok {
+		utils.ErrorResponse(c, http.StatusBadRequest, "INVALID_ENTITY_TYPE", "Geçersiz kayıt türü", []string{"livestock", "land", "land_activity", "pest_disease_observation", "scouting_point"})
+		return
+	}
+
+	var exists bool
+	if err := h.db.QueryRow(ownershipQuery, entityID, userID).Scan(&exists); err != nil {
+		utils.ErrorResponse(c, http.StatusNotFound, "ENTITY_NOT_FOUND", "Kayıt bulunamadı", nil)
+		return
+	}
+
+	fileHeader, err := c.FormFile("file")
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusBadRequest, "MISSING_FILE", "Fotoğraf gerekli", nil)
+		return
+	}
+	if fileHeader.Size > maxPhotoSize {
+		utils.ErrorResponse(c, http.StatusBadRequest, "FILE_TOO_LARGE", "Fotoğraf çok büyük", nil)
+		return
+	}
+
+	contentType := fileHeader.Header.Get("Content-Type")
+	if !photoContentTypes[contentType] {
+		utils.ErrorResponse(c, http.StatusBadRequest, "INVALID_FILE_TYPE", "Yalnızca JPEG, PNG veya GIF fotoğraf yüklenebilir", nil)
+		return
+	}
+
+	file, err := fileHeader.Open()
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusBadRequest, "INVALID_FILE", "Fotoğraf okunamadı", err.Error())
+		return
+	}
+	defer file.Close()
+
+	mediaID := utils.GenerateID()
+	storageKey := filepath.Join(userID, mediaID+strings.ToLower(filepath.Ext(fileHeader.Filename)))
+
+	size, err := h.store.Save(storageKey, file)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusInternalServerError, "STORAGE_ERROR", "Fotoğraf kaydedilemedi", err.Error())
+		return
+	}
+
+	_, err = h.db.Exec(`
+		INSERT INTO media_attachments (id, user_id, entity_type, entity_id, kind, filename, content_type,
+		                               size, storage_key, created_at)
+		VALUES (?, ?, ?, ?, 'image', ?, ?, ?, ?, CURRENT_TIMESTAMP)
+	`, mediaID, userID, entityType, entityID, filepath.Base(fileHeader.Filename), contentType, size, storageKey)
+	if err != nil {
+		h.store.Delete(storageKey)
+		utils.ErrorResponse(c, http.StatusInternalServerError, "DB_ERROR", "Fotoğraf oluşturulamadı", err.Error())
+		return
+	}
+
+	media, err := h.getMedia(mediaID, userID)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusInternalServerError, "FETCH_ERROR", "Oluşturulan fotoğraf getirilemedi", err.Error())
+		return
+	}
+
+	utils.CreatedResponse(c, media, "Fotoğraf başarıyla yüklendi")
+}
+
+// GetMediaContent medya dosyası indirme
+// @Summary Medya dosyası
+// @Description Yüklenen medya dosyasının içeriğini döner
+// @ID getMediaContent
+// @Tags Media
+// @Produce application/octet-stream
+// @Security BearerAuth
+// @Param id path string true "Medya ID"
+// @Success 200 {file} file
+// @Failure 401 {object} models.APIResponse
+// @Failure 404 {object} models.APIResponse
+// @Router /media/{id}/content [get]
+func (h *MediaHandler) GetMediaContent(c *gin.Context) {
+	userID, err := utils.GetUserID(c)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusUnauthorized, "UNAUTHORIZED", "Kullanıcı kimliği doğrulanamadı", nil)
+		return
+	}
+
+	var storageKey, filename, contentType string
+	var size int64
+	err = h.db.QueryRow(`
+		SELECT storage_key, filename, COALESCE(content_type, 'application/octet-stream'), size
+		FROM media_attachments WHERE id = ? AND user_id = ?
+	`, c.Param("id"), userID).Scan(&storageKey, &filename, &contentType, &size)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusNotFound, "MEDIA_NOT_FOUND", "Medya bulunamadı", nil)
+		return
+	}
+
+	file, err := h.store.Open(storageKey)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusNotFound, "MEDIA_NOT_FOUND", "Medya dosyası bulunamadı", nil)
+		return
+	}
+	defer file.Close()
+
+	c.DataFromReader(http.StatusOK, size, contentType, file, map[string]string{
+		"Content-Disposition": "inline; filename=" + filename,
+	})
+}
+
+// TranscribeMedia ses notunu yeniden metne çevirme
+// @Summary Ses notu transkripsiyonu
+// @Description Ses notu için transkripsiyonu yeniden başlatır
+// @ID transcribeMedia
+// @Tags Media
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Medya ID"
+// @Success 202 {object} models.APIResponse{data=models.MediaAttachment}
+// @Failure 401 {object} models.APIResponse
+// @Failure 404 {object} models.APIResponse
+// @Failure 503 {object} models.APIResponse
+// @Router /media/{id}/transcribe [post]
+func (h *MediaHandler) TranscribeMedia(c *gin.Context) {
+	userID, err := utils.GetUserID(c)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusUnauthorized, "UNAUTHORIZED", "Kullanıcı kimliği doğrulanamadı", nil)
+		return
+	}
+
+	if h.transcriber == nil {
+		utils.ErrorResponse(c, http.StatusServiceUnavailable, "TRANSCRIPTION_UNAVAILABLE", "Konuşma-metin sağlayıcısı yapılandırılmamış", services.ErrTranscriptionDisabled.Error())
+		return
+	}
+
+	mediaID := c.Param("id")
+	var storageKey, filename string
+	err = h.db.QueryRow(`
+		SELECT storage_key, filename FROM media_attachments WHERE id = ? AND user_id = ? AND kind = 'audio'
+	`, mediaID, userID).Scan(&storageKey, &filename)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusNotFound, "MEDIA_NOT_FOUND", "Medya bulunamadı", nil)
+		return
+	}
+
+	file, err := h.store.Open(storageKey)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusNotFound, "MEDIA_NOT_FOUND", "Medya dosyası bulunamadı", nil)
+		return
+	}
+	audio, err := io.ReadAll(file)
+	file.Close()
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusInternalServerError, "STORAGE_ERROR", "Medya dosyası okunamadı", err.Error())
+		return
+	}
+
+	h.db.Exec("UPDATE media_attachments SET transcript_status = ? WHERE id = ? AND user_id = ?", models.TranscriptStatusPending, mediaID, userID)
+	go h.transcribe(userID, mediaID, filename, audio)
+
+	media, _ := h.getMedia(mediaID, userID)
+	utils.SuccessResponseWithStatus(c, http.StatusAccepted, media, "Transkripsiyon başlatıldı")
+}
+
+// DeleteMedia medya silme
+// @Summary Medya silme
+// @Description Medya kaydını ve dosyasını siler
+// @ID deleteMedia
+// @Tags Media
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Medya ID"
+// @Success 200 {object} models.APIResponse
+// @Failure 401 {object} models.APIResponse
+// @Failure 404 {object} models.APIResponse
+// @Router /media/{id} [delete]
+func (h *MediaHandler) DeleteMedia(c *gin.Context) {
+	userID, err := utils.GetUserID(c)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusUnauthorized, "UNAUTHORIZED", "Kullanıcı kimliği doğrulanamadı", nil)
+		return
+	}
+
+	mediaID := c.Param("id")
+	var storageKey string
+	err = h.db.QueryRow("SELECT storage_key FROM media_attachments WHERE id = ? AND user_id = ?", mediaID, userID).Scan(&storageKey)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusNotFound, "MEDIA_NOT_FOUND", "Medya bulunamadı", nil)
+		return
+	}
+
+	if _, err := h.db.Exec("DELETE FROM media_attachments WHERE id = ? AND user_id = ?", mediaID, userID); err != nil {
+		utils.ErrorResponse(c, http.StatusInternalServerError, "DELETE_ERROR", "Medya silinemedi", err.Error())
+		return
+	}
+
+	if err := h.store.Delete(storageKey); err != nil {
+		log.Printf("Medya dosyası silinemedi (%s): %v", storageKey, err)
+	}
+
+	utils.SuccessResponse(c, nil, "Medya başarıyla silindi")
+}
+
+// transcribe ses notunu arka planda metne çevirir ve sonucu kaydeder
+func (h *MediaHandler) transcribe(userID, mediaID, filename string, audio []byte) {
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Minute)
+	defer cancel()
+
+	transcript, err := h.transcriber.Transcribe(ctx, filename, audio)
+	if err != nil {
+		log.Printf("Ses notu transkripsiyonu başarısız (%s): %v", mediaID, err)
+		h.db.Exec("UPDATE media_attachments SET transcript_status = ? WHERE id = ? AND user_id = ?", models.TranscriptStatusFailed, mediaID, userID)
+		return
+	}
+
+	h.db.Exec(`
+		UPDATE media_attachments SET transcript = ?, transcript_status = ? WHERE id = ? AND user_id = ?
+	`, strings.TrimSpace(transcript), models.TranscriptStatusCompleted, mediaID, userID)
+}
+
+// getMedia kullanıcıya ait medya kaydını getirir
+func (h *MediaHandler) getMedia(mediaID, userID string) (models.MediaAttachment, error) {
+	row := h.db.QueryRow(`
+		SELECT id, user_id, entity_type, entity_id, kind, filename, COALESCE(content_type, ''), size,
+		       duration_seconds, COALESCE(transcript, ''), transcript_status, created_at
+		FROM media_attachments WHERE id = ? AND user_id = ?
+	`, mediaID, userID)
+	return scanMedia(row)
+}
+
+// scanMedia medya satırını okur
+func scanMedia(row interface{ Scan(...interface{}) error }) (models.MediaAttachment, error) {
+	var media models.MediaAttachment
+	var duration sql.NullFloat64
+
+	err := row.Scan(
+		&media.ID, &media.UserID, &media.EntityType, &media.EntityID, &media.Kind, &media.Filename,
+		&media.ContentType, &media.Size, &duration, &media.Transcript, &media.TranscriptStatus, &media.CreatedAt,
+	)
+	if err != nil {
+		return media, err
+	}
+
+	media.DurationSeconds = utils.NullFloat64ToPtr(duration)
+	return media, nil
+}
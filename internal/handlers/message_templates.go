@@ -0,0 +1,197 @@
+package handlers
+
+import (
+	"database/sql"
+	"errors"
+	"net/http"
+	"regexp"
+
+	"agri-management-api/internal/models"
+	"agri-management-api/internal/services"
+	"agri-management-api/internal/utils"
+
+	"github.com/gin-gonic/gin"
+)
+
+// messageLanguagePattern şablon dil kodu (ISO 639-1, ör. tr, en)
+var messageLanguagePattern = regexp.MustCompile(`^[a-z]{2}$`)
+
+// MessageTemplateHandler bildirim ve e-posta şablonlarının yönetimini sağlar
+type MessageTemplateHandler struct {
+	db        *sql.DB
+	templates *services.MessageTemplateRegistry
+}
+
+// NewMessageTemplateHandler yeni message template handler oluşturur
+func NewMessageTemplateHandler(db *sql.DB) *MessageTemplateHandler {
+	return &MessageTemplateHandler{
+		db:        db,
+		templates: services.NewMessageTemplateRegistry(db),
+	}
+}
+
+// GetMessageTemplates mesaj şablonları
+// @Summary Mesaj şablonları
+// @Description Bildirim ve e-posta şablonlarını kanal, anahtar ve dile göre listeler. source alanı şablonun hazır dosyadan mı yoksa yöneticinin kaydettiği veritabanı kaydından mı geldiğini gösterir. Yönetici rolü gerektirir
+// @ID getMessageTemplates
+// @Tags Admin
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {object} models.APIResponse{data=[]models.MessageTemplate}
+// @Failure 401 {object} models.APIResponse
+// @Failure 403 {object} models.APIResponse
+// @Router /admin/message-templates [get]
+func (h *MessageTemplateHandler) GetMessageTemplates(c *gin.Context) {
+	templates, err := h.templates.Templates()
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusInternalServerError, "DB_ERROR", "Şablonlar getirilemedi", err.Error())
+		return
+	}
+
+	utils.SuccessResponse(c, templates, "Şablonlar başarıyla getirildi")
+}
+
+// SaveMessageTemplate mesaj şablonu kaydetme
+// @Summary Mesaj şablonu kaydetme
+// @Description Hazır şablonun metnini değiştirir veya yeni dil ekler; kayıt aynı kanal, anahtar ve dildeki dosyanın yerine geçer. Başlık ve metin Go text/template sözdizimindedir ({{.entity}}, {{date .date}}, {{number .stock}}); ayrıştırılamayan şablon kaydedilmez. Yönetici rolü gerektirir
+// @ID saveMessageTemplate
+// @Tags Admin
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param channel path string true "Kanal" Enums(notification, email)
+// @Param key path string true "Şablon anahtarı"
+// @Param language path string true "Dil kodu (ör. tr, en)"
+// @Param request body models.SaveMessageTemplateRequest true "Şablon"
+// @Success 200 {object} models.APIResponse{data=models.MessageTemplate}
+// @Failure 400 {object} models.APIResponse
+// @Failure 401 {object} models.APIResponse
+// @Failure 403 {object} models.APIResponse
+// @Failure 404 {object} models.APIResponse
+// @Router /admin/message-templates/{channel}/{key}/{language} [put]
+func (h *MessageTemplateHandler) SaveMessageTemplate(c *gin.Context) {
+	accountID, err := utils.GetAccountID(c)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusUnauthorized, "UNAUTHORIZED", "Kullanıcı kimliği doğrulanamadı", nil)
+		return
+	}
+
+	channel, language := c.Param("channel"), c.Param("language")
+	if !validMessageChannel(c, channel) || !validMessageLanguage(c, language) {
+		return
+	}
+
+	var req models.SaveMessageTemplateRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		utils.ErrorResponse(c, http.StatusBadRequest, "INVALID_REQUEST", "Geçersiz istek formatı", err.Error())
+		return
+	}
+
+	tmpl, err := h.templates.Save(channel, c.Param("key"), language, req.Title, req.Body, accountID)
+	switch {
+	case err == nil:
+	case errors.Is(err, services.ErrMessageTemplateNotFound):
+		utils.ErrorResponse(c, http.StatusNotFound, "TEMPLATE_NOT_FOUND", "Şablon anahtarı bulunamadı", nil)
+		return
+	case errors.Is(err, services.ErrMessageTemplateInvalid):
+		utils.ErrorResponse(c, http.StatusBadRequest, "INVALID_TEMPLATE", "Şablon ayrıştırılamadı", err.Error())
+		return
+	default:
+		utils.ErrorResponse(c, http.StatusInternalServerError, "DB_ERROR", "Şablon kaydedilemedi", err.Error())
+		return
+	}
+
+	utils.SuccessResponse(c, tmpl, "Şablon başarıyla kaydedildi")
+}
+
+// DeleteMessageTemplate mesaj şablonu silme
+// @Summary Mesaj şablonu silme
+// @Description Veritabanına kaydedilen şablonu siler; aynı dilde hazır dosya varsa yeniden o kullanılır. Yönetici rolü gerektirir
+// @ID deleteMessageTemplate
+// @Tags Admin
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param channel path string true "Kanal" Enums(notification, email)
+// @Param key path string true "Şablon anahtarı"
+// @Param language path string true "Dil kodu (ör. tr, en)"
+// @Success 200 {object} models.APIResponse
+// @Failure 401 {object} models.APIResponse
+// @Failure 403 {object} models.APIResponse
+// @Failure 404 {object} models.APIResponse
+// @Router /admin/message-templates/{channel}/{key}/{language} [delete]
+func (h *MessageTemplateHandler) DeleteMessageTemplate(c *gin.Context) {
+	err := h.templates.Delete(c.Param("channel"), c.Param("key"), c.Param("language"))
+	if errors.Is(err, services.ErrMessageTemplateNotFound) {
+		utils.ErrorResponse(c, http.StatusNotFound, "TEMPLATE_NOT_FOUND", "Kayıtlı şablon bulunamadı", nil)
+		return
+	}
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusInternalServerError, "DELETE_ERROR", "Şablon silinemedi", err.Error())
+		return
+	}
+
+	utils.SuccessResponse(c, nil, "Şablon başarıyla silindi")
+}
+
+// PreviewMessageTemplate mesaj şablonu önizleme
+// @Summary Mesaj şablonu önizleme
+// @Description Şablonu verilen verilerle doldurur. title veya body verilirse kaydetmeden önce taslak önizlenir; verilmezse kayıtlı şablon, o dilde yoksa varsayılan dil (tr) kullanılır. data verilmezse anahtarın örnek verileri kullanılır; şablonda kullanılan ama verilmeyen alanlar 400 döner. Yönetici rolü gerektirir
+// @ID previewMessageTemplate
+// @Tags Admin
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param request body models.MessageTemplatePreviewRequest true "Önizleme"
+// @Success 200 {object} models.APIResponse{data=models.RenderedMessage}
+// @Failure 400 {object} models.APIResponse
+// @Failure 401 {object} models.APIResponse
+// @Failure 403 {object} models.APIResponse
+// @Failure 404 {object} models.APIResponse
+// @Router /admin/message-templates/preview [post]
+func (h *MessageTemplateHandler) PreviewMessageTemplate(c *gin.Context) {
+	var req models.MessageTemplatePreviewRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		utils.ErrorResponse(c, http.StatusBadRequest, "INVALID_REQUEST", "Geçersiz istek formatı", err.Error())
+		return
+	}
+	if !validMessageChannel(c, req.Channel) || !validMessageLanguage(c, req.Language) {
+		return
+	}
+
+	rendered, err := h.templates.Preview(req)
+	switch {
+	case err == nil:
+	case errors.Is(err, services.ErrMessageTemplateNotFound):
+		utils.ErrorResponse(c, http.StatusNotFound, "TEMPLATE_NOT_FOUND", "Şablon bulunamadı", nil)
+		return
+	case errors.Is(err, services.ErrMessageTemplateInvalid):
+		utils.ErrorResponse(c, http.StatusBadRequest, "INVALID_TEMPLATE", "Şablon doldurulamadı", err.Error())
+		return
+	default:
+		utils.ErrorResponse(c, http.StatusInternalServerError, "DB_ERROR", "Şablon önizlenemedi", err.Error())
+		return
+	}
+
+	utils.SuccessResponse(c, rendered, "Şablon başarıyla önizlendi")
+}
+
+// validMessageChannel kanalı doğrular; geçersizse hata yanıtı yazar
+func validMessageChannel(c *gin.Context, channel string) bool {
+	if channel != models.MessageChannelNotification && channel != models.MessageChannelEmail {
+		utils.ErrorResponse(c, http.StatusBadRequest, "INVALID_CHANNEL", "Geçersiz kanal",
+			[]string{models.MessageChannelNotification, models.MessageChannelEmail})
+		return false
+	}
+	return true
+}
+
+// validMessageLanguage dil kodunu doğrular; geçersizse hata yanıtı yazar
+func validMessageLanguage(c *gin.Context, language string) bool {
+	if !messageLanguagePattern.MatchString(language) {
+		utils.ErrorResponse(c, http.StatusBadRequest, "INVALID_LANGUAGE", "Geçersiz dil kodu", "language")
+		return false
+	}
+	return true
+}
@@ -0,0 +1,193 @@
+package handlers
+
+import (
+	"database/sql"
+	"fmt"
+	"log"
+	"net/http"
+
+	"agri-management-api/internal/models"
+	"agri-management-api/internal/services"
+	"agri-management-api/internal/utils"
+
+	"github.com/gin-gonic/gin"
+)
+
+// NoteHandler kayıtların not zaman çizelgesini yönetir
+type NoteHandler struct {
+	db            *sql.DB
+	notes         *services.NoteService
+	notifications *services.NotificationService
+}
+
+// NewNoteHandler yeni note handler oluşturur
+func NewNoteHandler(db *sql.DB) *NoteHandler {
+	return &NoteHandler{
+		db:            db,
+		notes:         services.NewNoteService(db),
+		notifications: services.NewNotificationService(db),
+	}
+}
+
+// GetNoteMembers etiketlenebilen çiftlik üyeleri
+// @Summary Etiketlenebilen üyeler
+// @Description Notlarda @ ile etiketlenebilen çiftlik sahibi, çiftliğe ziyaret planlanmış veterinerler ve onaylı kooperatif bağlantılarını listeler
+// @ID getNoteMembers
+// @Tags Notes
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {object} models.APIResponse{data=[]models.FarmMember}
+// @Failure 401 {object} models.APIResponse
+// @Router /notes/members [get]
+func (h *NoteHandler) GetNoteMembers(c *gin.Context) {
+	userID, err := utils.GetUserID(c)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusUnauthorized, "UNAUTHORIZED", "Kullanıcı kimliği doğrulanamadı", nil)
+		return
+	}
+
+	members, err := h.notes.Members(userID)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusInternalServerError, "DB_ERROR", "Çiftlik üyeleri alınamadı", err.Error())
+		return
+	}
+
+	utils.SuccessResponse(c, members, "Çiftlik üyeleri başarıyla getirildi")
+}
+
+// GetEntityNotes kayıt notları
+// @Summary Kayıt not zaman çizelgesi
+// @Description Kaydın notlarını yazar, etiketler ve eklerle birlikte en yeniden eskiye listeler; zaman çizelgesi olmayan kayıtlarda eski notes alanı ilk not olarak aktarılır
+// @ID getEntityNotes
+// @Tags Notes
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param entityType path string true "Kayıt türü (livestock, land, production, transaction, asset, hive, pond, fish_batch)"
+// @Param entityId path string true "Kayıt ID"
+// @Success 200 {object} models.APIResponse{data=[]models.EntityNote}
+// @Failure 400 {object} models.APIResponse
+// @Failure 401 {object} models.APIResponse
+// @Failure 404 {object} models.APIResponse
+// @Router /notes/{entityType}/{entityId} [get]
+func (h *NoteHandler) GetEntityNotes(c *gin.Context) {
+	userID, err := utils.GetUserID(c)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusUnauthorized, "UNAUTHORIZED", "Kullanıcı kimliği doğrulanamadı", nil)
+		return
+	}
+
+	entityType, entityID := c.Param("entityType"), c.Param("entityId")
+	if _, ok := h.noteEntity(c, userID, entityType, entityID); !ok {
+		return
+	}
+
+	notes, err := h.notes.List(userID, entityType, entityID)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusInternalServerError, "DB_ERROR", "Notlar alınamadı", err.Error())
+		return
+	}
+
+	utils.SuccessResponse(c, notes, "Notlar başarıyla getirildi")
+}
+
+// CreateEntityNote kayda not ekleme
+// @Summary Kayda not ekle
+// @Description Kaydın zaman çizelgesine değiştirilemeyen bir not ekler ve kaydın notes alanını bu notla günceller; etiketlenen çiftlik üyelerine bildirim gönderilir, ekler aynı çiftliğin medya veya dokümanları olmalıdır
+// @ID createEntityNote
+// @Tags Notes
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param entityType path string true "Kayıt türü (livestock, land, production, transaction, asset, hive, pond, fish_batch)"
+// @Param entityId path string true "Kayıt ID"
+// @Param request body models.EntityNoteRequest true "Not"
+// @Success 201 {object} models.APIResponse{data=models.EntityNote}
+// @Failure 400 {object} models.APIResponse
+// @Failure 401 {object} models.APIResponse
+// @Failure 404 {object} models.APIResponse
+// @Router /notes/{entityType}/{entityId} [post]
+func (h *NoteHandler) CreateEntityNote(c *gin.Context) {
+	userID, err := utils.GetUserID(c)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusUnauthorized, "UNAUTHORIZED", "Kullanıcı kimliği doğrulanamadı", nil)
+		return
+	}
+	accountID, _ := utils.GetAccountID(c)
+
+	var req models.EntityNoteRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		utils.ErrorResponse(c, http.StatusBadRequest, "INVALID_REQUEST", "Geçersiz istek formatı", err.Error())
+		return
+	}
+
+	entityType, entityID := c.Param("entityType"), c.Param("entityId")
+	entityName, ok := h.noteEntity(c, userID, entityType, entityID)
+	if !ok {
+		return
+	}
+
+	note, err := h.notes.Create(userID, accountID, entityType, entityID, req)
+	switch err {
+	case nil:
+	case services.ErrNoteInvalidMention:
+		utils.ErrorResponse(c, http.StatusBadRequest, "INVALID_MENTION", "Etiketlenen kullanıcı çiftlik üyesi değil", nil)
+		return
+	case services.ErrNoteInvalidAttachment:
+		utils.ErrorResponse(c, http.StatusBadRequest, "INVALID_ATTACHMENT", "Eklenen medya veya doküman bulunamadı", nil)
+		return
+	default:
+		utils.ErrorResponse(c, http.StatusInternalServerError, "DB_ERROR", "Not eklenemedi", err.Error())
+		return
+	}
+
+	var mentions []services.Notification
+	for _, mention := range note.Mentions {
+		if mention.UserID == accountID {
+			continue
+		}
+		mentions = append(mentions, services.Notification{
+			UserID:   mention.UserID,
+			Title:    "Bir notta etiketlendiniz",
+			Message:  fmt.Sprintf("%s, {entity} kaydına eklediği notta sizi etiketledi: %s", note.AuthorName, noteExcerpt(note.Text)),
+			Type:     "info",
+			Priority: "medium",
+			Topic:    models.NotificationTopicNoteMention,
+			Entity:   &models.RelatedEntity{Type: entityType, ID: entityID, Name: entityName},
+		})
+	}
+	if _, err := h.notifications.CreateBatch(mentions); err != nil {
+		log.Printf("Not etiket bildirimleri oluşturulamadı: %v", err)
+	}
+
+	utils.CreatedResponse(c, note, "Not başarıyla eklendi")
+}
+
+// noteEntity kayıt türünü ve kaydın çiftliğe ait olduğunu doğrular, kaydın adını döner; hata varsa yanıtı yazar
+func (h *NoteHandler) noteEntity(c *gin.Context, userID, entityType, entityID string) (string, bool) {
+	if !services.SupportsEntity(entityType) {
+		utils.ErrorResponse(c, http.StatusBadRequest, "INVALID_ENTITY_TYPE", "Bu kayıt türüne not eklenemez", entityType)
+		return "", false
+	}
+
+	name, err := h.notes.EntityName(userID, entityType, entityID)
+	if err == services.ErrNoteEntityNotFound {
+		utils.ErrorResponse(c, http.StatusNotFound, "ENTITY_NOT_FOUND", "Kayıt bulunamadı", nil)
+		return "", false
+	}
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusInternalServerError, "DB_ERROR", "Kayıt alınamadı", err.Error())
+		return "", false
+	}
+	return name, true
+}
+
+// noteExcerpt bildirimde gösterilecek not özeti
+func noteExcerpt(text string) string {
+	runes := []rune(text)
+	if len(runes) <= 120 {
+		return text
+	}
+	return string(runes[:120]) + "…"
+}
@@ -5,6 +5,7 @@ import (
 	"net/http"
 
 	"agri-management-api/internal/models"
+	"agri-management-api/internal/services"
 	"agri-management-api/internal/utils"
 
 	"github.com/gin-gonic/gin"
@@ -23,6 +24,7 @@ func NewNotificationHandler(db *sql.DB) *NotificationHandler {
 // GetNotifications bildirim listesi
 // @Summary Bildirim listesi
 // @Description Kullanıcının bildirimlerini listeler
+// @ID getNotifications
 // @Tags Notifications
 // @Accept json
 // @Produce json
@@ -31,7 +33,7 @@ func NewNotificationHandler(db *sql.DB) *NotificationHandler {
 // @Param limit query int false "Sayfa başına kayıt"
 // @Param type query string false "Bildirim türü"
 // @Param read query bool false "Okunmuş durumu"
-// @Success 200 {object} models.APIResponse{data=map[string]interface{}}
+// @Success 200 {object} models.APIResponse{data=models.NotificationListResponse}
 // @Failure 401 {object} models.APIResponse
 // @Router /notifications [get]
 func (h *NotificationHandler) GetNotifications(c *gin.Context) {
@@ -82,7 +84,9 @@ func (h *NotificationHandler) GetNotifications(c *gin.Context) {
 	// Bildirimleri getir
 	offset := (page - 1) * limit
 	query := `
-		SELECT id, user_id, title, message, type, priority, is_read, created_at
+		SELECT id, user_id, title, message, type, priority, is_read, COALESCE(topic, ''),
+		       COALESCE(related_entity_type, ''), COALESCE(related_entity_id, ''), COALESCE(related_entity_name, ''),
+		       COALESCE(actions, ''), created_at
 		FROM notifications ` + whereClause + `
 		ORDER BY created_at DESC LIMIT ? OFFSET ?
 	`
@@ -98,33 +102,34 @@ func (h *NotificationHandler) GetNotifications(c *gin.Context) {
 	var notifications []models.NotificationExtended
 	for rows.Next() {
 		var notification models.NotificationExtended
+		var entity models.RelatedEntity
+		var actions string
 
 		err := rows.Scan(
 			&notification.ID, &notification.UserID, &notification.Title, &notification.Message,
-			&notification.Type, &notification.Priority, &notification.IsRead, &notification.CreatedAt,
+			&notification.Type, &notification.Priority, &notification.IsRead, &notification.Topic,
+			&entity.Type, &entity.ID, &entity.Name, &actions, &notification.CreatedAt,
 		)
 		if err != nil {
 			continue
 		}
 
-		// İlişkili varlık ve aksiyonlar için basit değerler (gerçek implementasyonda DB'den gelecek)
-		notification.RelatedEntity = &models.RelatedEntity{
-			Type: "general",
-			ID:   "",
-			Name: "",
+		if entity.Type != "" {
+			notification.RelatedEntity = &entity
 		}
 
-		notification.Actions = []models.Action{
-			{Label: "Görüntüle", Action: "view", URL: "/"},
+		// Aksiyonları kaydedilmemiş eski bildirimler için konuya göre oluşturulur
+		if actions == "" || utils.FromJSON(actions, &notification.Actions) != nil {
+			notification.Actions = services.NotificationActions(notification.Topic, notification.RelatedEntity)
 		}
 
 		notifications = append(notifications, notification)
 	}
 
-	response := map[string]interface{}{
-		"notifications": notifications,
-		"pagination":    pagination,
-		"unreadCount":   unreadCount,
+	response := models.NotificationListResponse{
+		Notifications: notifications,
+		Pagination:    pagination,
+		UnreadCount:   unreadCount,
 	}
 
 	utils.SuccessResponse(c, response, "Bildirimler başarıyla getirildi")
@@ -133,6 +138,7 @@ func (h *NotificationHandler) GetNotifications(c *gin.Context) {
 // MarkAsRead bildirim okundu işaretleme
 // @Summary Bildirim okundu işaretleme
 // @Description Belirli bir bildirimi okundu olarak işaretler
+// @ID markAsRead
 // @Tags Notifications
 // @Accept json
 // @Produce json
@@ -179,6 +185,7 @@ func (h *NotificationHandler) MarkAsRead(c *gin.Context) {
 // MarkAllAsRead tüm bildirimleri okundu işaretleme
 // @Summary Tüm bildirimleri okundu işaretleme
 // @Description Kullanıcının tüm bildirimlerini okundu olarak işaretler
+// @ID markAllAsRead
 // @Tags Notifications
 // @Accept json
 // @Produce json
@@ -211,6 +218,7 @@ func (h *NotificationHandler) MarkAllAsRead(c *gin.Context) {
 // DeleteNotification bildirim silme
 // @Summary Bildirim silme
 // @Description Belirli bir bildirimi siler
+// @ID deleteNotification
 // @Tags Notifications
 // @Accept json
 // @Produce json
@@ -252,11 +260,12 @@ func (h *NotificationHandler) DeleteNotification(c *gin.Context) {
 // GetNotificationSettings bildirim ayarları
 // @Summary Bildirim ayarları
 // @Description Kullanıcının bildirim ayarlarını getirir
+// @ID getNotificationSettings
 // @Tags Notifications
 // @Accept json
 // @Produce json
 // @Security BearerAuth
-// @Success 200 {object} models.APIResponse{data=map[string]interface{}}
+// @Success 200 {object} models.APIResponse{data=models.NotificationPreferences}
 // @Failure 401 {object} models.APIResponse
 // @Router /notifications/settings [get]
 func (h *NotificationHandler) GetNotificationSettings(c *gin.Context) {
@@ -267,20 +276,20 @@ func (h *NotificationHandler) GetNotificationSettings(c *gin.Context) {
 	}
 
 	// Bildirim ayarlarını getir (basit implementasyon - gerçek uygulamada DB'den gelecek)
-	settings := map[string]interface{}{
-		"pushNotifications":  true,
-		"emailNotifications": true,
-		"smsNotifications":   false,
-		"notificationTypes": map[string]bool{
-			"reminders": true,
-			"alerts":    true,
-			"updates":   true,
-			"marketing": false,
+	settings := models.NotificationPreferences{
+		PushNotifications:  true,
+		EmailNotifications: true,
+		SMSNotifications:   false,
+		NotificationTypes: models.NotificationTypeToggle{
+			Reminders: true,
+			Alerts:    true,
+			Updates:   true,
+			Marketing: false,
 		},
-		"quietHours": map[string]interface{}{
-			"enabled":   true,
-			"startTime": "22:00",
-			"endTime":   "08:00",
+		QuietHours: models.QuietHours{
+			Enabled:   true,
+			StartTime: "22:00",
+			EndTime:   "08:00",
 		},
 	}
 
@@ -290,11 +299,12 @@ func (h *NotificationHandler) GetNotificationSettings(c *gin.Context) {
 // UpdateNotificationSettings bildirim ayarları güncelleme
 // @Summary Bildirim ayarları güncelleme
 // @Description Kullanıcının bildirim ayarlarını günceller
+// @ID updateNotificationSettings
 // @Tags Notifications
 // @Accept json
 // @Produce json
 // @Security BearerAuth
-// @Param request body map[string]interface{} true "Bildirim ayarları"
+// @Param request body models.NotificationPreferences true "Bildirim ayarları"
 // @Success 200 {object} models.APIResponse
 // @Failure 400 {object} models.APIResponse
 // @Failure 401 {object} models.APIResponse
@@ -306,7 +316,7 @@ func (h *NotificationHandler) UpdateNotificationSettings(c *gin.Context) {
 		return
 	}
 
-	var req map[string]interface{}
+	var req models.NotificationPreferences
 	if err := c.ShouldBindJSON(&req); err != nil {
 		utils.ErrorResponse(c, http.StatusBadRequest, "INVALID_REQUEST", "Geçersiz istek formatı", err.Error())
 		return
@@ -317,47 +327,22 @@ func (h *NotificationHandler) UpdateNotificationSettings(c *gin.Context) {
 	utils.SuccessResponse(c, nil, "Bildirim ayarları başarıyla güncellendi")
 }
 
-// CreateNotification yeni bildirim oluşturma (dahili kullanım için)
-func (h *NotificationHandler) CreateNotification(userID, title, message, notificationType, priority string) error {
-	notificationID := utils.GenerateID()
-
-	_, err := h.db.Exec(`
-		INSERT INTO notifications (id, user_id, title, message, type, priority, is_read, created_at)
-		VALUES (?, ?, ?, ?, ?, ?, false, CURRENT_TIMESTAMP)
-	`, notificationID, userID, title, message, notificationType, priority)
-
-	return err
-}
-
-// SendWelcomeNotification hoş geldin bildirimi gönder
-func (h *NotificationHandler) SendWelcomeNotification(userID string) error {
-	return h.CreateNotification(
-		userID,
-		"Hoş Geldiniz!",
-		"Tarım Yönetim Sistemi'ne hoş geldiniz. Başlamak için dashboard'unuzu ziyaret edin.",
-		"info",
-		"medium",
-	)
-}
-
-// SendReminderNotification hatırlatıcı bildirimi gönder
-func (h *NotificationHandler) SendReminderNotification(userID, title, message string) error {
-	return h.CreateNotification(
-		userID,
-		title,
-		message,
-		"reminder",
-		"high",
-	)
-}
+// GetActionCatalog bildirim aksiyon kataloğu
+// @Summary Bildirim aksiyon kataloğu
+// @Description Bildirim konularına göre üretilen aksiyonları (etiket, tür, deep-link route, payload) listeler
+// @ID getActionCatalog
+// @Tags Notifications
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {object} models.APIResponse{data=[]models.NotificationActionTemplate}
+// @Failure 401 {object} models.APIResponse
+// @Router /notifications/action-catalog [get]
+func (h *NotificationHandler) GetActionCatalog(c *gin.Context) {
+	if _, err := utils.GetUserID(c); err != nil {
+		utils.ErrorResponse(c, http.StatusUnauthorized, "UNAUTHORIZED", "Kullanıcı kimliği doğrulanamadı", nil)
+		return
+	}
 
-// SendAlertNotification uyarı bildirimi gönder
-func (h *NotificationHandler) SendAlertNotification(userID, title, message string) error {
-	return h.CreateNotification(
-		userID,
-		title,
-		message,
-		"alert",
-		"high",
-	)
+	utils.SuccessResponse(c, services.NotificationActionCatalog(), "Bildirim aksiyon kataloğu başarıyla getirildi")
 }
@@ -0,0 +1,235 @@
+package handlers
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"net/http"
+	"time"
+
+	"agri-management-api/internal/models"
+	"agri-management-api/internal/services"
+	"agri-management-api/internal/utils"
+
+	"github.com/gin-gonic/gin"
+)
+
+// landParcelColumns arazilerin kadastro ve sınır sütunları
+const landParcelColumns = `COALESCE(parcel_province, ''), COALESCE(parcel_district, ''), COALESCE(parcel_neighborhood, ''),
+       COALESCE(parcel_neighborhood_code, ''), COALESCE(parcel_block, ''), COALESCE(parcel_number, ''), COALESCE(boundary, '')`
+
+// landParcelScan arazi sorgularında kadastro sütunlarını okur
+type landParcelScan struct {
+	parcel   models.LandParcel
+	boundary string
+}
+
+// dest Scan için hedefleri döner
+func (s *landParcelScan) dest() []interface{} {
+	return []interface{}{
+		&s.parcel.Province, &s.parcel.District, &s.parcel.Neighborhood,
+		&s.parcel.NeighborhoodCode, &s.parcel.Block, &s.parcel.Parcel, &s.boundary,
+	}
+}
+
+// apply okunan kadastro bilgisini araziye aktarır
+func (s *landParcelScan) apply(land *models.Land) {
+	if s.parcel.Parcel != "" {
+		parcel := s.parcel
+		land.Parcel = &parcel
+	}
+	if s.boundary != "" {
+		var boundary models.GeoPolygon
+		if err := utils.FromJSON(s.boundary, &boundary); err == nil {
+			land.Boundary = &boundary
+		}
+	}
+}
+
+// landParcelValues kadastro ve sınır bilgisini veritabanı değerlerine çevirir
+func landParcelValues(land models.Land) []interface{} {
+	var parcel models.LandParcel
+	if land.Parcel != nil {
+		parcel = *land.Parcel
+	}
+
+	var boundary interface{}
+	if land.Boundary != nil {
+		boundary, _ = utils.ToJSON(land.Boundary)
+	}
+
+	return []interface{}{
+		utils.StringToNullString(parcel.Province), utils.StringToNullString(parcel.District),
+		utils.StringToNullString(parcel.Neighborhood), utils.StringToNullString(parcel.NeighborhoodCode),
+		utils.StringToNullString(parcel.Block), utils.StringToNullString(parcel.Parcel), boundary,
+	}
+}
+
+// validateLandParcel arazinin kadastro bilgisini ve sınırını doğrular; aynı parselin başka bir araziye
+// kayıtlı olmasını engeller ve konumu boşsa sınırın merkezini kullanır. Hata varsa yanıtı yazar
+func (h *LandHandler) validateLandParcel(c *gin.Context, land *models.Land, userID, landID string) bool {
+	if land.Parcel != nil {
+		if err := services.NormalizeParcel(land.Parcel); err != nil {
+			utils.ErrorResponse(c, http.StatusBadRequest, "INVALID_PARCEL", err.Error(), nil)
+			return false
+		}
+
+		var existingID string
+		err := h.db.QueryRow(`
+			SELECT id FROM lands
+			WHERE user_id = ? AND id != ? AND parcel_block = ? AND parcel_number = ?
+			  AND (COALESCE(parcel_neighborhood_code, '') = ? AND ? != ''
+			       OR COALESCE(parcel_province, '') = ? AND COALESCE(parcel_district, '') = ? AND COALESCE(parcel_neighborhood, '') = ?)
+		`, userID, landID, land.Parcel.Block, land.Parcel.Parcel, land.Parcel.NeighborhoodCode, land.Parcel.NeighborhoodCode,
+			land.Parcel.Province, land.Parcel.District, land.Parcel.Neighborhood).Scan(&existingID)
+		if err == nil {
+			utils.ErrorResponse(c, http.StatusConflict, "PARCEL_ALREADY_REGISTERED", "Bu ada/parsel başka bir araziye kayıtlı", existingID)
+			return false
+		}
+	}
+
+	if land.Boundary != nil {
+		if err := services.ValidatePolygon(land.Boundary); err != nil {
+			utils.ErrorResponse(c, http.StatusBadRequest, "INVALID_BOUNDARY", err.Error(), nil)
+			return false
+		}
+		if land.Location.Latitude == 0 && land.Location.Longitude == 0 {
+			centroid := services.PolygonCentroid(*land.Boundary)
+			land.Location.Latitude, land.Location.Longitude = centroid.Latitude, centroid.Longitude
+		}
+	}
+
+	return true
+}
+
+// LookupParcel kadastro parsel sorgusu
+// @Summary Kadastro parsel sorgusu
+// @Description İl, ilçe, mahalle (veya mahalle kodu), ada ve parsel numarasıyla resmi kadastro kaydından parsel sınırını, alanını ve niteliğini getirir; arazi formunu doldurmak için kullanılır
+// @ID lookupParcel
+// @Tags Lands
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param request body models.LandParcel true "Ada/parsel bilgileri"
+// @Success 200 {object} models.APIResponse{data=models.ParcelLookupResult}
+// @Failure 400 {object} models.APIResponse
+// @Failure 401 {object} models.APIResponse
+// @Failure 404 {object} models.APIResponse
+// @Failure 503 {object} models.APIResponse
+// @Router /lands/parcel-lookup [post]
+func (h *LandHandler) LookupParcel(c *gin.Context) {
+	if _, err := utils.GetUserID(c); err != nil {
+		utils.ErrorResponse(c, http.StatusUnauthorized, "UNAUTHORIZED", "Kullanıcı kimliği doğrulanamadı", nil)
+		return
+	}
+
+	var parcel models.LandParcel
+	if err := c.ShouldBindJSON(&parcel); err != nil {
+		utils.ErrorResponse(c, http.StatusBadRequest, "INVALID_REQUEST", "Geçersiz istek formatı", err.Error())
+		return
+	}
+
+	if err := services.NormalizeParcel(&parcel); err != nil {
+		utils.ErrorResponse(c, http.StatusBadRequest, "INVALID_PARCEL", err.Error(), nil)
+		return
+	}
+
+	result, ok := h.lookupParcel(c, parcel)
+	if !ok {
+		return
+	}
+
+	utils.SuccessResponse(c, result, "Parsel bilgileri başarıyla getirildi")
+}
+
+// SyncLandParcel arazi sınırını kadastrodan doldurma
+// @Summary Arazi sınırını kadastrodan doldurma
+// @Description Araziye kayıtlı ada/parsel için kadastro sorgusu yapar ve parsel sınırını araziye kaydeder; konum boşsa sınırın merkezi, applyArea=true ise parsel alanı arazi birimine çevrilerek alan olarak kaydedilir
+// @ID syncLandParcel
+// @Tags Lands
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Arazi ID"
+// @Param applyArea query bool false "Parsel alanını arazi alanı olarak kaydet"
+// @Success 200 {object} models.APIResponse{data=models.Land}
+// @Failure 400 {object} models.APIResponse
+// @Failure 401 {object} models.APIResponse
+// @Failure 404 {object} models.APIResponse
+// @Failure 503 {object} models.APIResponse
+// @Router /lands/{id}/parcel/sync [post]
+func (h *LandHandler) SyncLandParcel(c *gin.Context) {
+	userID, err := utils.GetUserID(c)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusUnauthorized, "UNAUTHORIZED", "Kullanıcı kimliği doğrulanamadı", nil)
+		return
+	}
+
+	landID := c.Param("id")
+
+	var unit string
+	var latitude, longitude sql.NullFloat64
+	var parcel landParcelScan
+	err = h.db.QueryRow("SELECT unit, latitude, longitude, "+landParcelColumns+" FROM lands WHERE id = ? AND user_id = ?",
+		landID, userID).Scan(append([]interface{}{&unit, &latitude, &longitude}, parcel.dest()...)...)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusNotFound, "LAND_NOT_FOUND", "Arazi bulunamadı", nil)
+		return
+	}
+	if parcel.parcel.Parcel == "" {
+		utils.ErrorResponse(c, http.StatusBadRequest, "PARCEL_NOT_SET", "Araziye ada/parsel bilgisi girilmemiş", nil)
+		return
+	}
+
+	result, ok := h.lookupParcel(c, parcel.parcel)
+	if !ok {
+		return
+	}
+
+	boundary, _ := utils.ToJSON(result.Boundary)
+	if latitude.Float64 == 0 && longitude.Float64 == 0 {
+		latitude = sql.NullFloat64{Float64: result.Centroid.Latitude, Valid: true}
+		longitude = sql.NullFloat64{Float64: result.Centroid.Longitude, Valid: true}
+	}
+
+	err = h.history.Track(h.db, services.HistoryEntityLand, userID, landID, userID, func() error {
+		query := "UPDATE lands SET boundary = ?, latitude = ?, longitude = ?, updated_at = CURRENT_TIMESTAMP"
+		args := []interface{}{boundary, latitude, longitude}
+		if c.Query("applyArea") == "true" && result.AreaM2 > 0 {
+			query += ", area = ?"
+			args = append(args, services.AreaInUnit(result.AreaM2, unit))
+		}
+		_, err := h.db.Exec(query+" WHERE id = ? AND user_id = ?", append(args, landID, userID)...)
+		return err
+	})
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusInternalServerError, "UPDATE_ERROR", "Arazi sınırı kaydedilemedi", err.Error())
+		return
+	}
+
+	h.GetLand(c)
+}
+
+// lookupParcel kadastro sağlayıcısını sorgular; hata varsa yanıtı yazar
+func (h *LandHandler) lookupParcel(c *gin.Context, parcel models.LandParcel) (*models.ParcelLookupResult, bool) {
+	if h.parcels == nil {
+		utils.ErrorResponse(c, http.StatusServiceUnavailable, "PARCEL_PROVIDER_DISABLED", "Kadastro sorgu servisi yapılandırılmamış", nil)
+		return nil, false
+	}
+
+	ctx, cancel := context.WithTimeout(c.Request.Context(), 20*time.Second)
+	defer cancel()
+
+	result, err := h.parcels.Lookup(ctx, parcel)
+	switch {
+	case err == nil:
+		return result, true
+	case errors.Is(err, services.ErrParcelCodeRequired):
+		utils.ErrorResponse(c, http.StatusBadRequest, "NEIGHBORHOOD_CODE_REQUIRED", "Kadastro sorgusu için mahalle kodu gerekli", nil)
+	case errors.Is(err, services.ErrParcelNotFound):
+		utils.ErrorResponse(c, http.StatusNotFound, "PARCEL_NOT_FOUND", "Parsel kadastro kaydında bulunamadı", nil)
+	default:
+		utils.ErrorResponse(c, http.StatusBadGateway, "PARCEL_LOOKUP_FAILED", "Kadastro sorgusu yapılamadı", err.Error())
+	}
+	return nil, false
+}
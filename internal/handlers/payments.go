@@ -0,0 +1,246 @@
+package handlers
+
+import (
+	"fmt"
+	"log"
+	"net/http"
+	"time"
+
+	"agri-management-api/internal/models"
+	"agri-management-api/internal/services"
+	"agri-management-api/internal/utils"
+
+	"github.com/gin-gonic/gin"
+)
+
+// agingBuckets vade yaşlandırma dilimleri; current vadesi gelmemiş ödemeleri, max 0 ise üst sınır olmadığını belirtir
+var agingBuckets = []struct {
+	key, label string
+	min, max   int
+}{
+	{"current", "Vadesi gelmemiş", 0, 0},
+	{"0-30", "0-30 gün", 1, 30},
+	{"31-60", "31-60 gün", 31, 60},
+	{"61-90", "61-90 gün", 61, 90},
+	{"90+", "90 günden fazla", 91, 0},
+}
+
+// GetPaymentAging vade yaşlandırma raporu
+// @Summary Vade yaşlandırma raporu
+// @Description Bekleyen alacak (gelir) ve borçları (gider) vadesinden bu yana geçen güne göre 0-30, 31-60, 61-90 ve 90+ gün dilimlerinde toplar; vade tarihi girilmemiş işlemlerde işlem tarihi vade kabul edilir
+// @ID getPaymentAging
+// @Tags Finance
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param asOf query string false "Rapor tarihi (YYYY-MM-DD, varsayılan bugün)"
+// @Success 200 {object} models.APIResponse{data=models.PaymentAging}
+// @Failure 400 {object} models.APIResponse
+// @Failure 401 {object} models.APIResponse
+// @Router /finance/aging [get]
+func (h *FinanceHandler) GetPaymentAging(c *gin.Context) {
+	userID, err := utils.GetUserID(c)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusUnauthorized, "UNAUTHORIZED", "Kullanıcı kimliği doğrulanamadı", nil)
+		return
+	}
+
+	asOf := today()
+	if value := c.Query("asOf"); value != "" {
+		if asOf, err = time.Parse("2006-01-02", value); err != nil {
+			utils.ErrorResponse(c, http.StatusBadRequest, "INVALID_DATE", "Geçersiz rapor tarihi", nil)
+			return
+		}
+	}
+
+	rows, err := h.db.Query(transactionSelect+" WHERE user_id = ? AND status = 'pending' ORDER BY COALESCE(due_date, date)", userID)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusInternalServerError, "DB_ERROR", "Bekleyen ödemeler alınamadı", err.Error())
+		return
+	}
+	defer rows.Close()
+
+	aging := models.PaymentAging{
+		AsOf:        asOf,
+		Receivables: newAgingSummary(),
+		Payables:    newAgingSummary(),
+	}
+	for rows.Next() {
+		transaction, err := scanTransaction(rows)
+		if err != nil {
+			continue
+		}
+
+		summary := &aging.Payables
+		if transaction.Type == "income" {
+			summary = &aging.Receivables
+		}
+
+		days := daysOverdue(transaction, asOf)
+		transaction.DaysOverdue = nil
+		if days > 0 {
+			transaction.DaysOverdue = &days
+			summary.Overdue += transaction.Amount
+		}
+		summary.Total += transaction.Amount
+		summary.Count++
+		summary.Items = append(summary.Items, transaction)
+
+		bucket := agingBucketIndex(days)
+		summary.Buckets[bucket].Amount += transaction.Amount
+		summary.Buckets[bucket].Count++
+	}
+
+	for _, summary := range []*models.AgingSummary{&aging.Receivables, &aging.Payables} {
+		summary.Total = roundTo2(summary.Total)
+		summary.Overdue = roundTo2(summary.Overdue)
+		for i := range summary.Buckets {
+			summary.Buckets[i].Amount = roundTo2(summary.Buckets[i].Amount)
+		}
+	}
+
+	utils.SuccessResponse(c, aging, "Vade yaşlandırma raporu başarıyla getirildi")
+}
+
+// PayTransaction bekleyen ödemeyi kapatma
+// @Summary Ödemeyi kapatma
+// @Description Bekleyen işlemi ödendi olarak işaretler ve ödeme zamanını kaydeder
+// @ID payTransaction
+// @Tags Finance
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "İşlem ID"
+// @Success 200 {object} models.APIResponse{data=models.Transaction}
+// @Failure 401 {object} models.APIResponse
+// @Failure 404 {object} models.APIResponse
+// @Failure 409 {object} models.APIResponse
+// @Router /finance/transactions/{id}/pay [patch]
+func (h *FinanceHandler) PayTransaction(c *gin.Context) {
+	userID, err := utils.GetUserID(c)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusUnauthorized, "UNAUTHORIZED", "Kullanıcı kimliği doğrulanamadı", nil)
+		return
+	}
+
+	transactionID := c.Param("id")
+	var status string
+	err = h.db.QueryRow("SELECT status FROM transactions WHERE id = ? AND user_id = ?", transactionID, userID).Scan(&status)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusNotFound, "TRANSACTION_NOT_FOUND", "İşlem bulunamadı", nil)
+		return
+	}
+	if status != "pending" {
+		utils.ErrorResponse(c, http.StatusConflict, "TRANSACTION_NOT_PENDING", "Yalnızca bekleyen işlemler ödendi olarak işaretlenebilir", status)
+		return
+	}
+
+	_, err = h.db.Exec(`
+		UPDATE transactions SET status = 'completed', paid_at = CURRENT_TIMESTAMP, updated_at = CURRENT_TIMESTAMP
+		WHERE id = ? AND user_id = ?
+	`, transactionID, userID)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusInternalServerError, "UPDATE_ERROR", "Ödeme kaydedilemedi", err.Error())
+		return
+	}
+	h.eventRules.SyncQuietly(userID, models.EventRuleInstallmentDue)
+
+	h.GetTransaction(c)
+}
+
+// StartReminders vadesi geçen bekleyen ödemeler için saatlik olarak bildirim gönderir
+func (h *FinanceHandler) StartReminders() {
+	go func() {
+		ticker := time.NewTicker(time.Hour)
+		defer ticker.Stop()
+
+		for {
+			if err := h.SendOverdueReminders(); err != nil {
+				log.Printf("Vadesi geçen ödeme bildirimleri gönderilemedi: %v", err)
+			}
+			<-ticker.C
+		}
+	}()
+}
+
+// SendOverdueReminders vadesi geçmiş ve henüz bildirilmemiş bekleyen ödemeler için bildirim gönderir
+func (h *FinanceHandler) SendOverdueReminders() error {
+	rows, err := h.db.Query(transactionSelect + `
+		WHERE status = 'pending' AND due_date IS NOT NULL AND overdue_notified_at IS NULL
+		  AND date(due_date) < date('now')`)
+	if err != nil {
+		return err
+	}
+
+	var overdue []models.Transaction
+	for rows.Next() {
+		transaction, err := scanTransaction(rows)
+		if err != nil {
+			continue
+		}
+		overdue = append(overdue, transaction)
+	}
+	rows.Close()
+
+	for _, transaction := range overdue {
+		title := "Borç Ödemesinin Vadesi Geçti"
+		if transaction.Type == "income" {
+			title = "Alacak Tahsilatının Vadesi Geçti"
+		}
+		message := fmt.Sprintf("%s (%.2f %s) için vade tarihi %s idi.",
+			transaction.Description, transaction.Amount, transaction.Currency, transaction.DueDate.Format("02.01.2006"))
+
+		_, err := h.notifications.Create(services.Notification{
+			UserID:   transaction.UserID,
+			Title:    title,
+			Message:  message,
+			Type:     "reminder",
+			Priority: "high",
+			Topic:    models.NotificationTopicPaymentOverdue,
+			Entity:   &models.RelatedEntity{Type: "transaction", ID: transaction.ID, Name: transaction.Description},
+		})
+		if err != nil {
+			return err
+		}
+
+		if _, err := h.db.Exec("UPDATE transactions SET overdue_notified_at = CURRENT_TIMESTAMP WHERE id = ?", transaction.ID); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// newAgingSummary boş dilimlerle yaşlandırma özeti oluşturur
+func newAgingSummary() models.AgingSummary {
+	summary := models.AgingSummary{Items: []models.Transaction{}}
+	for _, bucket := range agingBuckets {
+		item := models.AgingBucket{Key: bucket.key, Label: bucket.label, MinDays: bucket.min}
+		if bucket.max > 0 || bucket.min == 0 {
+			max := bucket.max
+			item.MaxDays = &max
+		}
+		summary.Buckets = append(summary.Buckets, item)
+	}
+	return summary
+}
+
+// daysOverdue ödemenin verilen tarihte vadesinden kaç gün geçtiğini döner; vade girilmemişse işlem tarihi kullanılır
+func daysOverdue(transaction models.Transaction, asOf time.Time) int {
+	due := transaction.Date
+	if transaction.DueDate != nil {
+		due = *transaction.DueDate
+	}
+	due = time.Date(due.Year(), due.Month(), due.Day(), 0, 0, 0, 0, time.UTC)
+	return int(asOf.Sub(due).Hours() / 24)
+}
+
+// agingBucketIndex gecikme gününe karşılık gelen yaşlandırma dilimini döner
+func agingBucketIndex(days int) int {
+	for i := len(agingBuckets) - 1; i > 0; i-- {
+		if days >= agingBuckets[i].min {
+			return i
+		}
+	}
+	return 0
+}
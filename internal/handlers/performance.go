@@ -0,0 +1,47 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+	"time"
+
+	"agri-management-api/internal/services"
+	"agri-management-api/internal/utils"
+
+	"github.com/gin-gonic/gin"
+)
+
+// PerformanceHandler yöneticiler için rota bazında yanıt süresi ve SLO özetini sağlar
+type PerformanceHandler struct{}
+
+// NewPerformanceHandler yeni performance handler oluşturur
+func NewPerformanceHandler() *PerformanceHandler {
+	return &PerformanceHandler{}
+}
+
+// GetPerformance rota bazında performans özeti
+// @Summary Rota performansı ve SLO ihlalleri
+// @Description Seçilen penceredeki (5m, 15m, 1h, 6h, 24h; varsayılan 1h) istekleri rota bazında özetler: istek sayısı, 5xx hata oranı (yüzde) ve p50/p95/p99 gecikme (ms). p95 gecikmesi veya hata oranı hedefi aşan rotalar breaches alanında (latency, error_rate) işaretlenir ve listenin başında yer alır; minRequests altında isteği olan rotalar değerlendirilmez. Hedefler SLO_LATENCY_P95_MS, SLO_ERROR_RATE, SLO_MIN_REQUESTS ve rota bazında SLO_ROUTE_TARGETS ile ayarlanır. Ölçümler bellekte son 24 saat için tutulur ve sunucu yeniden başlatılınca sıfırlanır. Yönetici rolü gerektirir
+// @ID getPerformance
+// @Tags Admin
+// @Produce json
+// @Security BearerAuth
+// @Param window query string false "Pencere (5m, 15m, 1h, 6h, 24h)"
+// @Success 200 {object} models.APIResponse{data=models.PerformanceReport}
+// @Failure 400 {object} models.APIResponse
+// @Failure 401 {object} models.APIResponse
+// @Failure 403 {object} models.APIResponse
+// @Router /admin/performance [get]
+func (h *PerformanceHandler) GetPerformance(c *gin.Context) {
+	report, err := services.PerformanceReport(c.Query("window"), time.Now())
+	if err != nil {
+		if errors.Is(err, services.ErrInvalidPerformanceWindow) {
+			utils.ErrorResponse(c, http.StatusBadRequest, "INVALID_WINDOW", "Geçersiz pencere", "5m, 15m, 1h, 6h veya 24h olmalıdır")
+			return
+		}
+		utils.ErrorResponse(c, http.StatusInternalServerError, "PERFORMANCE_ERROR", "Performans özeti alınamadı", err.Error())
+		return
+	}
+
+	utils.SuccessResponse(c, report, "Performans özeti başarıyla getirildi")
+}
@@ -5,24 +5,44 @@ import (
 	"net/http"
 
 	"agri-management-api/internal/models"
+	"agri-management-api/internal/services"
 	"agri-management-api/internal/utils"
 
 	"github.com/gin-gonic/gin"
 )
 
+// productionSortFields üretim listesinde sıralanabilen alanlar
+var productionSortFields = map[string]string{
+	"createdAt":   "created_at",
+	"name":        "name",
+	"amount":      "amount",
+	"harvestDate": "harvest_date",
+}
+
 // ProductionHandler üretim işlemlerini yönetir
 type ProductionHandler struct {
-	db *sql.DB
+	db            *sql.DB
+	categories    *services.CategoryService
+	prices        *services.PriceHistoryService
+	notifications *services.NotificationService
+	enterprises   *services.EnterpriseService
 }
 
 // NewProductionHandler yeni production handler oluşturur
 func NewProductionHandler(db *sql.DB) *ProductionHandler {
-	return &ProductionHandler{db: db}
+	return &ProductionHandler{
+		db:            db,
+		categories:    services.NewCategoryService(db),
+		prices:        services.NewPriceHistoryService(db),
+		notifications: services.NewNotificationService(db),
+		enterprises:   services.NewEnterpriseService(db),
+	}
 }
 
 // GetProductions üretim listesi
 // @Summary Üretim listesi
 // @Description Kullanıcının üretimlerini listeler
+// @ID getProductions
 // @Tags Production
 // @Accept json
 // @Produce json
@@ -31,7 +51,10 @@ func NewProductionHandler(db *sql.DB) *ProductionHandler {
 // @Param limit query int false "Sayfa başına kayıt"
 // @Param category query string false "Ürün kategorisi"
 // @Param status query string false "Üretim durumu"
-// @Success 200 {object} models.APIResponse{data=map[string]interface{}}
+// @Param enterpriseId query string false "Faaliyet kolu ID"
+// @Param sortBy query string false "Sıralama alanı (createdAt, name, amount, harvestDate)"
+// @Param sortOrder query string false "Sıralama yönü (asc, desc)"
+// @Success 200 {object} models.APIResponse{data=models.ProductionListResponse}
 // @Failure 401 {object} models.APIResponse
 // @Router /production [get]
 func (h *ProductionHandler) GetProductions(c *gin.Context) {
@@ -44,6 +67,7 @@ func (h *ProductionHandler) GetProductions(c *gin.Context) {
 	page, limit := utils.ParsePagination(c)
 	category := c.DefaultQuery("category", "all")
 	status := c.DefaultQuery("status", "all")
+	orderBy := utils.ParseSort(c, productionSortFields, "created_at DESC")
 
 	// Toplam kayıt sayısını al
 	var total int
@@ -60,6 +84,11 @@ func (h *ProductionHandler) GetProductions(c *gin.Context) {
 		args = append(args, status)
 	}
 
+	if enterpriseID := c.Query("enterpriseId"); enterpriseID != "" {
+		whereClause += " AND enterprise_id = ?"
+		args = append(args, enterpriseID)
+	}
+
 	err = h.db.QueryRow("SELECT COUNT(*) FROM production "+whereClause, args...).Scan(&total)
 	if err != nil {
 		utils.ErrorResponse(c, http.StatusInternalServerError, "DB_ERROR", "Toplam kayıt sayısı alınamadı", err.Error())
@@ -71,12 +100,7 @@ func (h *ProductionHandler) GetProductions(c *gin.Context) {
 
 	// Üretimleri getir
 	offset := (page - 1) * limit
-	query := `
-		SELECT id, user_id, land_id, name, category, amount, unit, harvest_date,
-		       quality, storage_location, status, price, notes, created_at, updated_at
-		FROM production ` + whereClause + `
-		ORDER BY created_at DESC LIMIT ? OFFSET ?
-	`
+	query := productionSelect + " " + whereClause + " ORDER BY " + orderBy + " LIMIT ? OFFSET ?"
 	args = append(args, limit, offset)
 
 	rows, err := h.db.Query(query, args...)
@@ -88,29 +112,17 @@ func (h *ProductionHandler) GetProductions(c *gin.Context) {
 
 	var productions []models.Production
 	for rows.Next() {
-		var production models.Production
-		var harvestDate sql.NullTime
-		var price sql.NullFloat64
-
-		err := rows.Scan(
-			&production.ID, &production.UserID, &production.LandID, &production.Name,
-			&production.Category, &production.Amount, &production.Unit, &harvestDate,
-			&production.Quality, &production.StorageLocation, &production.Status,
-			&price, &production.Notes, &production.CreatedAt, &production.UpdatedAt,
-		)
+		production, err := scanProduction(rows)
 		if err != nil {
 			continue
 		}
 
-		production.HarvestDate = utils.NullTimeToPtr(harvestDate)
-		production.Price = utils.NullFloat64ToPtr(price)
-
 		productions = append(productions, production)
 	}
 
-	response := map[string]interface{}{
-		"productions": productions,
-		"pagination":  pagination,
+	response := models.ProductionListResponse{
+		Productions: productions,
+		Pagination:  pagination,
 	}
 
 	utils.SuccessResponse(c, response, "Üretimler başarıyla getirildi")
@@ -119,6 +131,7 @@ func (h *ProductionHandler) GetProductions(c *gin.Context) {
 // CreateProduction yeni üretim oluşturma
 // @Summary Yeni üretim oluşturma
 // @Description Yeni üretim kaydı oluşturur
+// @ID createProduction
 // @Tags Production
 // @Accept json
 // @Produce json
@@ -147,15 +160,20 @@ func (h *ProductionHandler) CreateProduction(c *gin.Context) {
 		return
 	}
 
+	if !validateEnterprise(c, h.enterprises, userID, &req.EnterpriseID) {
+		return
+	}
+
 	productionID := utils.GenerateID()
 
 	// Üretimi oluştur
 	_, err = h.db.Exec(`
 		INSERT INTO production (id, user_id, land_id, name, category, amount, unit, harvest_date,
-		                       quality, storage_location, status, price, notes, created_at, updated_at)
-		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, 'active', ?, ?, CURRENT_TIMESTAMP, CURRENT_TIMESTAMP)
+		                       quality, storage_location, status, price, unit_cost, notes, enterprise_id,
+		                       created_at, updated_at)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, 'active', ?, ?, ?, ?, CURRENT_TIMESTAMP, CURRENT_TIMESTAMP)
 	`, productionID, userID, req.LandID, req.Name, req.Category, req.Amount, req.Unit,
-		req.HarvestDate, req.Quality, req.StorageLocation, req.Price, req.Notes)
+		req.HarvestDate, req.Quality, req.StorageLocation, req.Price, req.UnitCost, req.Notes, req.EnterpriseID)
 
 	if err != nil {
 		utils.ErrorResponse(c, http.StatusInternalServerError, "DB_ERROR", "Üretim oluşturulamadı", err.Error())
@@ -163,39 +181,19 @@ func (h *ProductionHandler) CreateProduction(c *gin.Context) {
 	}
 
 	// Oluşturulan üretimi getir
-	var production models.Production
-	var harvestDate sql.NullTime
-	var price sql.NullFloat64
-
-	err = h.db.QueryRow(`
-		SELECT id, user_id, land_id, name, category, amount, unit, harvest_date,
-		       quality, storage_location, status, price, notes, created_at, updated_at
-		FROM production WHERE id = ?
-	`, productionID).Scan(
-		&production.ID, &production.UserID, &production.LandID, &production.Name,
-		&production.Category, &production.Amount, &production.Unit, &harvestDate,
-		&production.Quality, &production.StorageLocation, &production.Status,
-		&price, &production.Notes, &production.CreatedAt, &production.UpdatedAt,
-	)
-
+	production, err := scanProduction(h.db.QueryRow(productionSelect+" WHERE id = ? AND user_id = ?", productionID, userID))
 	if err != nil {
 		utils.ErrorResponse(c, http.StatusInternalServerError, "FETCH_ERROR", "Oluşturulan üretim getirilemedi", err.Error())
 		return
 	}
 
-	production.HarvestDate = utils.NullTimeToPtr(harvestDate)
-	production.Price = utils.NullFloat64ToPtr(price)
-
-	c.JSON(http.StatusCreated, models.APIResponse{
-		Success: true,
-		Data:    production,
-		Message: "Üretim başarıyla oluşturuldu",
-	})
+	utils.CreatedResponse(c, production, "Üretim başarıyla oluşturuldu")
 }
 
 // GetProduction üretim detayları
 // @Summary Üretim detayları
 // @Description Belirli bir üretimin detaylarını getirir
+// @ID getProduction
 // @Tags Production
 // @Accept json
 // @Produce json
@@ -218,21 +216,7 @@ func (h *ProductionHandler) GetProduction(c *gin.Context) {
 		return
 	}
 
-	var production models.Production
-	var harvestDate sql.NullTime
-	var price sql.NullFloat64
-
-	err = h.db.QueryRow(`
-		SELECT id, user_id, land_id, name, category, amount, unit, harvest_date,
-		       quality, storage_location, status, price, notes, created_at, updated_at
-		FROM production WHERE id = ? AND user_id = ?
-	`, productionID, userID).Scan(
-		&production.ID, &production.UserID, &production.LandID, &production.Name,
-		&production.Category, &production.Amount, &production.Unit, &harvestDate,
-		&production.Quality, &production.StorageLocation, &production.Status,
-		&price, &production.Notes, &production.CreatedAt, &production.UpdatedAt,
-	)
-
+	production, err := scanProduction(h.db.QueryRow(productionSelect+" WHERE id = ? AND user_id = ?", productionID, userID))
 	if err != nil {
 		if err == sql.ErrNoRows {
 			utils.ErrorResponse(c, http.StatusNotFound, "PRODUCTION_NOT_FOUND", "Üretim bulunamadı", nil)
@@ -242,15 +226,14 @@ func (h *ProductionHandler) GetProduction(c *gin.Context) {
 		return
 	}
 
-	production.HarvestDate = utils.NullTimeToPtr(harvestDate)
-	production.Price = utils.NullFloat64ToPtr(price)
-
-	utils.SuccessResponse(c, production, "Üretim detayları başarıyla getirildi")
+	links := services.EntityLinks("production", production.ID, services.RelatedLink{Rel: "land", EntityType: "land", ID: production.LandID})
+	utils.DetailResponse(c, production, links, "Üretim detayları başarıyla getirildi")
 }
 
 // UpdateProduction üretim güncelleme
 // @Summary Üretim güncelleme
 // @Description Mevcut üretim bilgilerini günceller
+// @ID updateProduction
 // @Tags Production
 // @Accept json
 // @Produce json
@@ -280,15 +263,19 @@ func (h *ProductionHandler) UpdateProduction(c *gin.Context) {
 		utils.ErrorResponse(c, http.StatusBadRequest, "INVALID_REQUEST", "Geçersiz istek formatı", err.Error())
 		return
 	}
+	if !validateEnterprise(c, h.enterprises, userID, &req.EnterpriseID) {
+		return
+	}
 
 	// Üretimi güncelle
 	_, err = h.db.Exec(`
 		UPDATE production 
 		SET name = ?, category = ?, amount = ?, unit = ?, harvest_date = ?, quality = ?,
-		    storage_location = ?, status = ?, price = ?, notes = ?, updated_at = CURRENT_TIMESTAMP
+		    storage_location = ?, status = ?, price = ?, unit_cost = ?, notes = ?, enterprise_id = ?,
+		    updated_at = CURRENT_TIMESTAMP
 		WHERE id = ? AND user_id = ?
 	`, req.Name, req.Category, req.Amount, req.Unit, req.HarvestDate, req.Quality,
-		req.StorageLocation, req.Status, req.Price, req.Notes, productionID, userID)
+		req.StorageLocation, req.Status, req.Price, req.UnitCost, req.Notes, req.EnterpriseID, productionID, userID)
 
 	if err != nil {
 		utils.ErrorResponse(c, http.StatusInternalServerError, "UPDATE_ERROR", "Üretim güncellenemedi", err.Error())
@@ -302,14 +289,17 @@ func (h *ProductionHandler) UpdateProduction(c *gin.Context) {
 // DeleteProduction üretim silme
 // @Summary Üretim silme
 // @Description Belirli bir üretimi siler
+// @ID deleteProduction
 // @Tags Production
 // @Accept json
 // @Produce json
 // @Security BearerAuth
 // @Param id path string true "Üretim ID"
+// @Param force query bool false "Başka kayıtlarla bağlantısı olsa da sil"
 // @Success 200 {object} models.APIResponse
 // @Failure 401 {object} models.APIResponse
 // @Failure 404 {object} models.APIResponse
+// @Failure 409 {object} models.APIResponse
 // @Router /production/{id} [delete]
 func (h *ProductionHandler) DeleteProduction(c *gin.Context) {
 	userID, err := utils.GetUserID(c)
@@ -324,6 +314,10 @@ func (h *ProductionHandler) DeleteProduction(c *gin.Context) {
 		return
 	}
 
+	if !confirmLinkedDelete(c, h.db, userID, "production", productionID) {
+		return
+	}
+
 	// Üretimi sil
 	result, err := h.db.Exec("DELETE FROM production WHERE id = ? AND user_id = ?", productionID, userID)
 	if err != nil {
@@ -337,17 +331,20 @@ func (h *ProductionHandler) DeleteProduction(c *gin.Context) {
 		return
 	}
 
+	removeRecordLinks(h.db, userID, "production", productionID)
+
 	utils.SuccessResponse(c, nil, "Üretim başarıyla silindi")
 }
 
 // GetProductionStatistics üretim istatistikleri
 // @Summary Üretim istatistikleri
-// @Description Üretim istatistiklerini getirir
+// @Description Üretim istatistiklerini getirir; son 12 ayın satış geliri, birim maliyeti girilmiş satışlar üzerinden marjı ve aylık marj eğilimi de döner
+// @ID getProductionStatistics
 // @Tags Production
 // @Accept json
 // @Produce json
 // @Security BearerAuth
-// @Success 200 {object} models.APIResponse{data=map[string]interface{}}
+// @Success 200 {object} models.APIResponse{data=models.ProductionStatistics}
 // @Failure 401 {object} models.APIResponse
 // @Router /production/statistics [get]
 func (h *ProductionHandler) GetProductionStatistics(c *gin.Context) {
@@ -400,7 +397,7 @@ func (h *ProductionHandler) GetProductionStatistics(c *gin.Context) {
 	}
 	defer rows.Close()
 
-	var categoryBreakdown []map[string]interface{}
+	categoryBreakdown := []models.ProductionCategoryBreakdown{}
 	totalCount := 0
 	for rows.Next() {
 		var category string
@@ -413,34 +410,38 @@ func (h *ProductionHandler) GetProductionStatistics(c *gin.Context) {
 		}
 
 		totalCount += count
-		categoryBreakdown = append(categoryBreakdown, map[string]interface{}{
-			"name":   category,
-			"count":  count,
-			"amount": amount,
+		categoryBreakdown = append(categoryBreakdown, models.ProductionCategoryBreakdown{
+			Name:   category,
+			Count:  count,
+			Amount: amount,
 		})
 	}
 
 	// Yüzdeleri hesapla
-	for i := range categoryBreakdown {
-		if totalCount > 0 {
-			count := categoryBreakdown[i]["count"].(int)
-			categoryBreakdown[i]["percentage"] = float64(count) / float64(totalCount) * 100
-		} else {
-			categoryBreakdown[i]["percentage"] = 0
+	if totalCount > 0 {
+		for i := range categoryBreakdown {
+			categoryBreakdown[i].Percentage = float64(categoryBreakdown[i].Count) / float64(totalCount) * 100
 		}
 	}
 
-	statistics := map[string]interface{}{
-		"activeProducts":      activeProducts,
-		"totalProduction":     totalProduction,
-		"averageProductivity": averageProductivity,
-		"qualityDistribution": map[string]int{
-			"A+": aPlus,
-			"A":  a,
-			"B":  b,
-			"C":  cQuality,
+	statistics := models.ProductionStatistics{
+		ActiveProducts:      activeProducts,
+		TotalProduction:     totalProduction,
+		AverageProductivity: averageProductivity,
+		QualityDistribution: models.QualityDistribution{
+			APlus: aPlus,
+			A:     a,
+			B:     b,
+			C:     cQuality,
 		},
-		"categoryBreakdown": categoryBreakdown,
+		CategoryBreakdown: categoryBreakdown,
+	}
+
+	// Son 12 ayın satış geliri ve marj eğilimi
+	statistics.MarginTrend, statistics.SalesRevenue, statistics.MarginPercent, err = h.prices.MarginTrend(userID, 12)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusInternalServerError, "DB_ERROR", "Marj eğilimi alınamadı", err.Error())
+		return
 	}
 
 	utils.SuccessResponse(c, statistics, "Üretim istatistikleri başarıyla getirildi")
@@ -449,6 +450,7 @@ func (h *ProductionHandler) GetProductionStatistics(c *gin.Context) {
 // GetProductionCategories üretim kategorileri
 // @Summary Üretim kategorileri
 // @Description Üretim kategorilerini getirir
+// @ID getProductionCategories
 // @Tags Production
 // @Accept json
 // @Produce json
@@ -483,30 +485,42 @@ func (h *ProductionHandler) GetProductionCategories(c *gin.Context) {
 			continue
 		}
 
-		// Her kategori için icon ve renk ata
-		switch category.Name {
-		case "vegetables":
-			category.Icon = "🥬"
-			category.Color = "#4CAF50"
-		case "fruits":
-			category.Icon = "🍎"
-			category.Color = "#FF5722"
-		case "grains":
-			category.Icon = "🌾"
-			category.Color = "#FF9800"
-		case "dairy":
-			category.Icon = "🥛"
-			category.Color = "#2196F3"
-		case "meat":
-			category.Icon = "🥩"
-			category.Color = "#795548"
-		default:
-			category.Icon = "🌱"
-			category.Color = "#607D8B"
-		}
-
 		categories = append(categories, category)
 	}
 
+	// İkon ve renkler kategori kayıt defterinden alınır
+	categories = h.categories.Decorate(userID, models.CategoryDomainProduction, categories)
+
 	utils.SuccessResponse(c, categories, "Üretim kategorileri başarıyla getirildi")
 }
+
+// productionSelect üretim sorgularının ortak sütunları
+const productionSelect = `
+	SELECT id, user_id, land_id, name, category, amount, unit, harvest_date,
+	       quality, storage_location, status, price, unit_cost, notes, COALESCE(sold_amount, 0), COALESCE(lost_amount, 0), enterprise_id,
+	       created_at, updated_at
+	FROM production`
+
+// scanProduction productionSelect ile seçilen satırı üretime çevirir; stok, üretim miktarından satılan ve kaybedilen miktar düşülerek hesaplanır
+func scanProduction(row interface{ Scan(...interface{}) error }) (models.Production, error) {
+	var production models.Production
+	var harvestDate sql.NullTime
+	var price, unitCost sql.NullFloat64
+
+	err := row.Scan(
+		&production.ID, &production.UserID, &production.LandID, &production.Name,
+		&production.Category, &production.Amount, &production.Unit, &harvestDate,
+		&production.Quality, &production.StorageLocation, &production.Status,
+		&price, &unitCost, &production.Notes, &production.SoldAmount, &production.LostAmount, &production.EnterpriseID,
+		&production.CreatedAt, &production.UpdatedAt,
+	)
+	if err != nil {
+		return production, err
+	}
+
+	production.HarvestDate = utils.NullTimeToPtr(harvestDate)
+	production.Price = utils.NullFloat64ToPtr(price)
+	production.UnitCost = utils.NullFloat64ToPtr(unitCost)
+	production.Stock = roundTo2(production.Amount - production.SoldAmount - production.LostAmount)
+	return production, nil
+}
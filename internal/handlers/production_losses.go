@@ -0,0 +1,429 @@
+package handlers
+
+import (
+	"database/sql"
+	"log"
+	"net/http"
+	"sort"
+	"time"
+
+	"agri-management-api/internal/models"
+	"agri-management-api/internal/services"
+	"agri-management-api/internal/utils"
+
+	"github.com/gin-gonic/gin"
+)
+
+// lossReasons kayıp analizinde nedenlerin sırası
+var lossReasons = []string{models.LossReasonSpoilage, models.LossReasonPest, models.LossReasonHandling, models.LossReasonOther}
+
+// CreateProductionLoss üretim kaybı kaydı
+// @Summary Üretim kaybı kaydet
+// @Description Üretim partisinden bozulma (spoilage), zararlı (pest_damage), taşıma (handling) veya diğer nedenlerle kaybedilen miktarı kaydeder ve stoktan düşer
+// @ID createProductionLoss
+// @Tags Production
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Üretim ID"
+// @Param request body models.ProductionLossRequest true "Kayıp bilgileri"
+// @Success 201 {object} models.APIResponse{data=models.ProductionLoss}
+// @Failure 400 {object} models.APIResponse
+// @Failure 401 {object} models.APIResponse
+// @Failure 404 {object} models.APIResponse
+// @Failure 409 {object} models.APIResponse
+// @Router /production/{id}/losses [post]
+func (h *ProductionHandler) CreateProductionLoss(c *gin.Context) {
+	userID, err := utils.GetUserID(c)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusUnauthorized, "UNAUTHORIZED", "Kullanıcı kimliği doğrulanamadı", nil)
+		return
+	}
+
+	productionID := c.Param("id")
+
+	var req models.ProductionLossRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		utils.ErrorResponse(c, http.StatusBadRequest, "INVALID_REQUEST", "Geçersiz istek formatı", err.Error())
+		return
+	}
+
+	production, err := scanProduction(h.db.QueryRow(productionSelect+" WHERE id = ? AND user_id = ?", productionID, userID))
+	if err != nil {
+		if err == sql.ErrNoRows {
+			utils.ErrorResponse(c, http.StatusNotFound, "PRODUCTION_NOT_FOUND", "Üretim bulunamadı", nil)
+		} else {
+			utils.ErrorResponse(c, http.StatusInternalServerError, "DB_ERROR", "Üretim getirilemedi", err.Error())
+		}
+		return
+	}
+
+	lossDate := time.Now()
+	if req.Date != nil {
+		lossDate = *req.Date
+	}
+
+	tx, err := h.db.Begin()
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusInternalServerError, "DB_ERROR", "Kayıp kaydedilemedi", err.Error())
+		return
+	}
+	defer tx.Rollback()
+
+	// Kayıp, satışlar gibi stok koşuluyla birlikte düşülür
+	result, err := tx.Exec(`
+		UPDATE production SET lost_amount = COALESCE(lost_amount, 0) + ?, updated_at = CURRENT_TIMESTAMP
+		WHERE id = ? AND user_id = ? AND amount - COALESCE(sold_amount, 0) - COALESCE(lost_amount, 0) >= ? - 0.000001
+	`, req.Quantity, productionID, userID, req.Quantity)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusInternalServerError, "UPDATE_ERROR", "Stok güncellenemedi", err.Error())
+		return
+	}
+	if affected, _ := result.RowsAffected(); affected == 0 {
+		utils.ErrorResponse(c, http.StatusConflict, "INSUFFICIENT_STOCK", "Kayıp miktarı mevcut stoktan fazla", gin.H{
+			"stock": production.Stock,
+			"unit":  production.Unit,
+		})
+		return
+	}
+
+	lossID := utils.GenerateID()
+	_, err = tx.Exec(`
+		INSERT INTO production_losses (id, user_id, production_id, loss_date, quantity, reason, notes, created_at)
+		VALUES (?, ?, ?, ?, ?, ?, ?, CURRENT_TIMESTAMP)
+	`, lossID, userID, productionID, lossDate, req.Quantity, req.Reason, req.Notes)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusInternalServerError, "DB_ERROR", "Kayıp kaydedilemedi", err.Error())
+		return
+	}
+
+	if err := tx.Commit(); err != nil {
+		utils.ErrorResponse(c, http.StatusInternalServerError, "DB_ERROR", "Kayıp kaydedilemedi", err.Error())
+		return
+	}
+	h.notifyLowStock(userID, productionID)
+
+	loss, err := scanProductionLoss(h.db.QueryRow(productionLossSelect+" WHERE l.id = ? AND l.user_id = ?", lossID, userID))
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusInternalServerError, "FETCH_ERROR", "Oluşturulan kayıp getirilemedi", err.Error())
+		return
+	}
+
+	utils.CreatedResponse(c, loss, "Kayıp başarıyla kaydedildi")
+}
+
+// GetProductionLosses üretim kayıpları
+// @Summary Üretim kayıpları
+// @Description Üretim partisine kaydedilen kayıpları tarihe göre listeler
+// @ID getProductionLosses
+// @Tags Production
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Üretim ID"
+// @Success 200 {object} models.APIResponse{data=[]models.ProductionLoss}
+// @Failure 401 {object} models.APIResponse
+// @Failure 404 {object} models.APIResponse
+// @Router /production/{id}/losses [get]
+func (h *ProductionHandler) GetProductionLosses(c *gin.Context) {
+	userID, err := utils.GetUserID(c)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusUnauthorized, "UNAUTHORIZED", "Kullanıcı kimliği doğrulanamadı", nil)
+		return
+	}
+
+	productionID := c.Param("id")
+
+	var exists bool
+	h.db.QueryRow("SELECT 1 FROM production WHERE id = ? AND user_id = ?", productionID, userID).Scan(&exists)
+	if !exists {
+		utils.ErrorResponse(c, http.StatusNotFound, "PRODUCTION_NOT_FOUND", "Üretim bulunamadı", nil)
+		return
+	}
+
+	rows, err := h.db.Query(productionLossSelect+" WHERE l.production_id = ? AND l.user_id = ? ORDER BY l.loss_date DESC, l.created_at DESC", productionID, userID)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusInternalServerError, "DB_ERROR", "Kayıplar alınamadı", err.Error())
+		return
+	}
+	defer rows.Close()
+
+	losses := []models.ProductionLoss{}
+	for rows.Next() {
+		loss, err := scanProductionLoss(rows)
+		if err != nil {
+			continue
+		}
+		losses = append(losses, loss)
+	}
+
+	utils.SuccessResponse(c, losses, "Kayıplar başarıyla getirildi")
+}
+
+// DeleteProductionLoss üretim kaybı silme
+// @Summary Üretim kaybı sil
+// @Description Kayıp kaydını siler ve kaybedilen miktarı stoğa geri ekler; stoğu tükendiği için satıldı durumundaki üretim yeniden aktif olur
+// @ID deleteProductionLoss
+// @Tags Production
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Üretim ID"
+// @Param lossId path string true "Kayıp ID"
+// @Success 200 {object} models.APIResponse
+// @Failure 401 {object} models.APIResponse
+// @Failure 404 {object} models.APIResponse
+// @Router /production/{id}/losses/{lossId} [delete]
+func (h *ProductionHandler) DeleteProductionLoss(c *gin.Context) {
+	userID, err := utils.GetUserID(c)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusUnauthorized, "UNAUTHORIZED", "Kullanıcı kimliği doğrulanamadı", nil)
+		return
+	}
+
+	productionID := c.Param("id")
+	lossID := c.Param("lossId")
+
+	tx, err := h.db.Begin()
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusInternalServerError, "DB_ERROR", "Kayıp silinemedi", err.Error())
+		return
+	}
+	defer tx.Rollback()
+
+	var quantity float64
+	err = tx.QueryRow(`
+		SELECT quantity FROM production_losses WHERE id = ? AND production_id = ? AND user_id = ?
+	`, lossID, productionID, userID).Scan(&quantity)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusNotFound, "LOSS_NOT_FOUND", "Kayıp bulunamadı", nil)
+		return
+	}
+
+	if _, err := tx.Exec("DELETE FROM production_losses WHERE id = ? AND user_id = ?", lossID, userID); err != nil {
+		utils.ErrorResponse(c, http.StatusInternalServerError, "DELETE_ERROR", "Kayıp silinemedi", err.Error())
+		return
+	}
+	_, err = tx.Exec(`
+		UPDATE production
+		SET lost_amount = MAX(COALESCE(lost_amount, 0) - ?, 0),
+		    status = CASE WHEN status = 'sold' THEN 'active' ELSE status END,
+		    updated_at = CURRENT_TIMESTAMP
+		WHERE id = ? AND user_id = ?
+	`, quantity, productionID, userID)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusInternalServerError, "UPDATE_ERROR", "Stok güncellenemedi", err.Error())
+		return
+	}
+
+	if err := tx.Commit(); err != nil {
+		utils.ErrorResponse(c, http.StatusInternalServerError, "DB_ERROR", "Kayıp silinemedi", err.Error())
+		return
+	}
+
+	utils.SuccessResponse(c, nil, "Kayıp başarıyla silindi")
+}
+
+// GetLossAnalysis kayıp analizi
+// @Summary Hasat kaybı analizi
+// @Description Hasat tarihi (yoksa kayıt tarihi) dönem içinde olan üretim partilerinin kayıp oranlarını ürün ve depolama yeri bazında, neden kırılımıyla getirir; tahmini değer birim maliyet girilmiş partiler için hesaplanır
+// @ID getLossAnalysis
+// @Tags Production
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param startDate query string false "Başlangıç tarihi (YYYY-MM-DD, varsayılan: 12 ay önce)"
+// @Param endDate query string false "Bitiş tarihi (YYYY-MM-DD, varsayılan: bugün)"
+// @Param category query string false "Kategori"
+// @Success 200 {object} models.APIResponse{data=models.ProductionLossAnalysis}
+// @Failure 400 {object} models.APIResponse
+// @Failure 401 {object} models.APIResponse
+// @Router /production/losses/analysis [get]
+func (h *ProductionHandler) GetLossAnalysis(c *gin.Context) {
+	userID, err := utils.GetUserID(c)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusUnauthorized, "UNAUTHORIZED", "Kullanıcı kimliği doğrulanamadı", nil)
+		return
+	}
+
+	startDate, endDate, ok := priceHistoryDateRange(c)
+	if !ok {
+		return
+	}
+
+	query := `
+		SELECT p.id, p.name, p.category, COALESCE(p.unit, ''), COALESCE(NULLIF(p.storage_location, ''), 'Belirtilmemiş'),
+		       p.amount, p.unit_cost, l.reason, COUNT(l.id), COALESCE(SUM(l.quantity), 0)
+		FROM production p
+		LEFT JOIN production_losses l ON l.production_id = p.id
+		WHERE p.user_id = ? AND COALESCE(p.harvest_date, p.created_at) >= ? AND COALESCE(p.harvest_date, p.created_at) < ?`
+	args := []interface{}{userID, startDate, endDate.AddDate(0, 0, 1)}
+	if category := c.Query("category"); category != "" {
+		query += " AND p.category = ?"
+		args = append(args, category)
+	}
+	query += " GROUP BY p.id, l.reason"
+
+	rows, err := h.db.Query(query, args...)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusInternalServerError, "DB_ERROR", "Kayıp analizi alınamadı", err.Error())
+		return
+	}
+	defer rows.Close()
+
+	analysis := models.ProductionLossAnalysis{StartDate: startDate, EndDate: endDate}
+	crops := map[string]*models.LossGroup{}
+	locations := map[string]*models.LossGroup{}
+	reasons := map[string]*models.LossReasonBreakdown{}
+	counted := map[string]bool{}
+
+	group := func(groups map[string]*models.LossGroup, key, name, category, unit string) *models.LossGroup {
+		if groups[key] == nil {
+			groups[key] = &models.LossGroup{Name: name, Category: category, Unit: unit, ByReason: map[string]float64{}}
+		}
+		return groups[key]
+	}
+
+	for rows.Next() {
+		var productionID, name, category, unit, location string
+		var amount, lost float64
+		var unitCost sql.NullFloat64
+		var reason sql.NullString
+		var count int
+		if err := rows.Scan(&productionID, &name, &category, &unit, &location, &amount, &unitCost, &reason, &count, &lost); err != nil {
+			continue
+		}
+
+		crop := group(crops, name+"|"+unit, name, category, unit)
+		storage := group(locations, location, location, "", unit)
+		if storage.Unit != unit {
+			storage.Unit = ""
+		}
+
+		// Parti, neden sayısı kadar satırda döner; üretilen miktar bir kez eklenir
+		if !counted[productionID] {
+			counted[productionID] = true
+			crop.Produced += amount
+			storage.Produced += amount
+			analysis.Produced += amount
+		}
+		if !reason.Valid {
+			continue
+		}
+
+		value := 0.0
+		if unitCost.Valid {
+			value = lost * unitCost.Float64
+		}
+		for _, target := range []*models.LossGroup{crop, storage} {
+			target.Lost += lost
+			target.EstimatedValue += value
+			target.ByReason[reason.String] += lost
+		}
+		analysis.Lost += lost
+		analysis.EstimatedValue += value
+
+		if reasons[reason.String] == nil {
+			reasons[reason.String] = &models.LossReasonBreakdown{Reason: reason.String}
+		}
+		reasons[reason.String].Count += count
+		reasons[reason.String].Quantity += lost
+	}
+
+	analysis.LossPercentage = lossPercentage(analysis.Lost, analysis.Produced)
+	analysis.Lost = roundTo2(analysis.Lost)
+	analysis.Produced = roundTo2(analysis.Produced)
+	analysis.EstimatedValue = roundTo2(analysis.EstimatedValue)
+
+	analysis.ByReason = []models.LossReasonBreakdown{}
+	for _, reason := range lossReasons {
+		if breakdown := reasons[reason]; breakdown != nil {
+			breakdown.Quantity = roundTo2(breakdown.Quantity)
+			breakdown.Percentage = lossPercentage(breakdown.Quantity, analysis.Lost)
+			analysis.ByReason = append(analysis.ByReason, *breakdown)
+		}
+	}
+	analysis.ByCrop = sortedLossGroups(crops)
+	analysis.ByStorageLocation = sortedLossGroups(locations)
+
+	utils.SuccessResponse(c, analysis, "Kayıp analizi başarıyla getirildi")
+}
+
+// productionLossSelect kayıp sorgularının ortak sütunları
+const productionLossSelect = `
+	SELECT l.id, l.production_id, p.name, l.loss_date, l.quantity, COALESCE(p.unit, ''), l.reason,
+	       COALESCE(p.storage_location, ''), p.unit_cost, COALESCE(l.notes, ''), l.created_at
+	FROM production_losses l
+	JOIN production p ON p.id = l.production_id`
+
+// scanProductionLoss productionLossSelect ile seçilen satırı kayıp kaydına çevirir
+func scanProductionLoss(row interface{ Scan(...interface{}) error }) (models.ProductionLoss, error) {
+	var loss models.ProductionLoss
+	var unitCost sql.NullFloat64
+
+	err := row.Scan(&loss.ID, &loss.ProductionID, &loss.ProductName, &loss.Date, &loss.Quantity, &loss.Unit,
+		&loss.Reason, &loss.StorageLocation, &unitCost, &loss.Notes, &loss.CreatedAt)
+	if err != nil {
+		return loss, err
+	}
+
+	if unitCost.Valid {
+		value := roundTo2(loss.Quantity * unitCost.Float64)
+		loss.EstimatedValue = &value
+	}
+	return loss, nil
+}
+
+// sortedLossGroups grupların oranlarını hesaplar ve kayıp oranına göre azalan sırada döner
+func sortedLossGroups(groups map[string]*models.LossGroup) []models.LossGroup {
+	result := make([]models.LossGroup, 0, len(groups))
+	for _, group := range groups {
+		group.LossPercentage = lossPercentage(group.Lost, group.Produced)
+		group.Produced = roundTo2(group.Produced)
+		group.Lost = roundTo2(group.Lost)
+		group.EstimatedValue = roundTo2(group.EstimatedValue)
+		for reason, quantity := range group.ByReason {
+			group.ByReason[reason] = roundTo2(quantity)
+		}
+		result = append(result, *group)
+	}
+
+	sort.Slice(result, func(i, j int) bool {
+		if result[i].LossPercentage != result[j].LossPercentage {
+			return result[i].LossPercentage > result[j].LossPercentage
+		}
+		return result[i].Name < result[j].Name
+	})
+	return result
+}
+
+// lossPercentage kaybın toplam içindeki yüzdesi
+func lossPercentage(lost, total float64) float64 {
+	if total <= 0 {
+		return 0
+	}
+	return roundTo2(lost / total * 100)
+}
+
+// notifyLowStock satış veya kayıp sonrası kalan stok partinin %10'una düştüyse günde en fazla bir uyarı gönderir;
+// tamamen satılan partiler için uyarı gönderilmez, bildirim hatası işlemi engellemez
+func (h *ProductionHandler) notifyLowStock(userID, productionID string) {
+	production, err := scanProduction(h.db.QueryRow(productionSelect+" WHERE id = ? AND user_id = ?", productionID, userID))
+	if err != nil || production.Amount <= 0 || production.Stock <= 0 || production.Stock > production.Amount*services.LowStockRatio {
+		return
+	}
+
+	_, err = h.notifications.Create(services.Notification{
+		UserID:    userID,
+		Template:  "inventory_low",
+		Type:      "warning",
+		Priority:  "medium",
+		Topic:     models.NotificationTopicInventoryLow,
+		Entity:    &models.RelatedEntity{Type: "production", ID: production.ID, Name: production.Name},
+		Params:    map[string]interface{}{"stock": production.Stock, "amount": production.Amount, "unit": production.Unit},
+		DedupeKey: "inventory_low:" + production.ID,
+	})
+	if err != nil {
+		log.Printf("Stok uyarısı oluşturulamadı: %v", err)
+	}
+}
@@ -0,0 +1,308 @@
+package handlers
+
+import (
+	"net/http"
+	"strings"
+	"time"
+
+	"agri-management-api/internal/models"
+	"agri-management-api/internal/services"
+	"agri-management-api/internal/utils"
+
+	"github.com/gin-gonic/gin"
+)
+
+// GetMarketPrices piyasa fiyatları
+// @Summary Piyasa fiyatları
+// @Description Elle girilen ve fiyat servisinden alınan piyasa fiyatlarını tarihe göre listeler
+// @ID getMarketPrices
+// @Tags Production
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param product query string false "Ürün adı (büyük/küçük harf duyarsız)"
+// @Param source query string false "Kaynak (manual, feed)"
+// @Param startDate query string false "Başlangıç tarihi (YYYY-MM-DD, varsayılan: 12 ay önce)"
+// @Param endDate query string false "Bitiş tarihi (YYYY-MM-DD, varsayılan: bugün)"
+// @Success 200 {object} models.APIResponse{data=[]models.MarketPrice}
+// @Failure 400 {object} models.APIResponse
+// @Failure 401 {object} models.APIResponse
+// @Router /production/market-prices [get]
+func (h *ProductionHandler) GetMarketPrices(c *gin.Context) {
+	userID, err := utils.GetUserID(c)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusUnauthorized, "UNAUTHORIZED", "Kullanıcı kimliği doğrulanamadı", nil)
+		return
+	}
+
+	startDate, endDate, ok := priceHistoryDateRange(c)
+	if !ok {
+		return
+	}
+
+	query := `
+		SELECT id, product, COALESCE(category, ''), price, COALESCE(unit, ''), currency, price_date, source, created_at
+		FROM market_prices
+		WHERE user_id = ? AND price_date >= ? AND price_date < ?`
+	args := []interface{}{userID, startDate, endDate.AddDate(0, 0, 1)}
+
+	if product := c.Query("product"); product != "" {
+		query += " AND product_key = ?"
+		args = append(args, services.MarketPriceKey(product))
+	}
+	if source := c.Query("source"); source != "" {
+		query += " AND source = ?"
+		args = append(args, source)
+	}
+	query += " ORDER BY price_date DESC, product"
+
+	rows, err := h.db.Query(query, args...)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusInternalServerError, "DB_ERROR", "Piyasa fiyatları alınamadı", err.Error())
+		return
+	}
+	defer rows.Close()
+
+	prices := []models.MarketPrice{}
+	for rows.Next() {
+		var price models.MarketPrice
+		err := rows.Scan(&price.ID, &price.Product, &price.Category, &price.Price, &price.Unit,
+			&price.Currency, &price.Date, &price.Source, &price.CreatedAt)
+		if err != nil {
+			continue
+		}
+		prices = append(prices, price)
+	}
+
+	utils.SuccessResponse(c, prices, "Piyasa fiyatları başarıyla getirildi")
+}
+
+// CreateMarketPrice piyasa fiyatı girişi
+// @Summary Piyasa fiyatı gir
+// @Description Ürün için günlük piyasa fiyatı kaydeder; aynı ürün ve gün için elle girilmiş fiyat varsa güncellenir
+// @ID createMarketPrice
+// @Tags Production
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param request body models.MarketPriceRequest true "Piyasa fiyatı"
+// @Success 201 {object} models.APIResponse{data=models.MarketPrice}
+// @Failure 400 {object} models.APIResponse
+// @Failure 401 {object} models.APIResponse
+// @Router /production/market-prices [post]
+func (h *ProductionHandler) CreateMarketPrice(c *gin.Context) {
+	userID, err := utils.GetUserID(c)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusUnauthorized, "UNAUTHORIZED", "Kullanıcı kimliği doğrulanamadı", nil)
+		return
+	}
+
+	var req models.MarketPriceRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		utils.ErrorResponse(c, http.StatusBadRequest, "INVALID_REQUEST", "Geçersiz istek formatı", err.Error())
+		return
+	}
+	if utils.IsEmptyString(req.Product) {
+		utils.ErrorResponse(c, http.StatusBadRequest, "MISSING_FIELDS", "Ürün adı gerekli", nil)
+		return
+	}
+	if req.Currency == "" {
+		req.Currency = "TRY"
+	}
+
+	price := models.MarketPrice{
+		Product:  strings.TrimSpace(req.Product),
+		Category: req.Category,
+		Price:    req.Price,
+		Unit:     req.Unit,
+		Currency: strings.ToUpper(req.Currency),
+		Date:     time.Date(req.Date.Year(), req.Date.Month(), req.Date.Day(), 0, 0, 0, 0, time.UTC),
+		Source:   "manual",
+	}
+	if err := h.saveMarketPrice(userID, &price); err != nil {
+		utils.ErrorResponse(c, http.StatusInternalServerError, "DB_ERROR", "Piyasa fiyatı kaydedilemedi", err.Error())
+		return
+	}
+
+	utils.CreatedResponse(c, price, "Piyasa fiyatı başarıyla kaydedildi")
+}
+
+// DeleteMarketPrice piyasa fiyatı silme
+// @Summary Piyasa fiyatı sil
+// @Description Piyasa fiyatı kaydını siler
+// @ID deleteMarketPrice
+// @Tags Production
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param priceId path string true "Piyasa fiyatı ID"
+// @Success 200 {object} models.APIResponse
+// @Failure 401 {object} models.APIResponse
+// @Failure 404 {object} models.APIResponse
+// @Router /production/market-prices/{priceId} [delete]
+func (h *ProductionHandler) DeleteMarketPrice(c *gin.Context) {
+	userID, err := utils.GetUserID(c)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusUnauthorized, "UNAUTHORIZED", "Kullanıcı kimliği doğrulanamadı", nil)
+		return
+	}
+
+	result, err := h.db.Exec("DELETE FROM market_prices WHERE id = ? AND user_id = ?", c.Param("priceId"), userID)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusInternalServerError, "DELETE_ERROR", "Piyasa fiyatı silinemedi", err.Error())
+		return
+	}
+	if affected, _ := result.RowsAffected(); affected == 0 {
+		utils.ErrorResponse(c, http.StatusNotFound, "MARKET_PRICE_NOT_FOUND", "Piyasa fiyatı bulunamadı", nil)
+		return
+	}
+
+	utils.SuccessResponse(c, nil, "Piyasa fiyatı başarıyla silindi")
+}
+
+// SyncMarketPrices piyasa fiyatlarını servisten al
+// @Summary Piyasa fiyatlarını güncelle
+// @Description Kullanıcının üretim kayıtlarındaki ürünler için MARKET_PRICE_FEED_URL ile tanımlı fiyat servisinden son 90 günün fiyatlarını alır; aynı ürün ve gün için servis fiyatı güncellenir
+// @ID syncMarketPrices
+// @Tags Production
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {object} models.APIResponse
+// @Failure 401 {object} models.APIResponse
+// @Failure 502 {object} models.APIResponse
+// @Failure 503 {object} models.APIResponse
+// @Router /production/market-prices/sync [post]
+func (h *ProductionHandler) SyncMarketPrices(c *gin.Context) {
+	userID, err := utils.GetUserID(c)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusUnauthorized, "UNAUTHORIZED", "Kullanıcı kimliği doğrulanamadı", nil)
+		return
+	}
+
+	if !services.MarketPriceFeedConfigured() {
+		utils.ErrorResponse(c, http.StatusServiceUnavailable, "MARKET_PRICE_FEED_DISABLED", "Piyasa fiyatı servisi yapılandırılmamış", nil)
+		return
+	}
+
+	rows, err := h.db.Query("SELECT DISTINCT name FROM production WHERE user_id = ? ORDER BY name", userID)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusInternalServerError, "DB_ERROR", "Ürünler alınamadı", err.Error())
+		return
+	}
+	var products []string
+	for rows.Next() {
+		var name string
+		if rows.Scan(&name) == nil {
+			products = append(products, name)
+		}
+	}
+	rows.Close()
+
+	if len(products) == 0 {
+		utils.SuccessResponse(c, gin.H{"imported": 0}, "Fiyatı alınacak ürün bulunamadı")
+		return
+	}
+
+	prices, err := services.FetchMarketPrices(products, time.Now().AddDate(0, 0, -90))
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusBadGateway, "MARKET_PRICE_FEED_ERROR", "Piyasa fiyatları alınamadı", err.Error())
+		return
+	}
+
+	imported := 0
+	for i := range prices {
+		if err := h.saveMarketPrice(userID, &prices[i]); err != nil {
+			utils.ErrorResponse(c, http.StatusInternalServerError, "DB_ERROR", "Piyasa fiyatları kaydedilemedi", err.Error())
+			return
+		}
+		imported++
+	}
+
+	utils.SuccessResponse(c, gin.H{"imported": imported}, "Piyasa fiyatları başarıyla güncellendi")
+}
+
+// GetPriceHistory ürün fiyat geçmişi ve marj analizi
+// @Summary Ürün fiyat geçmişi
+// @Description Ürün bazında aylık gerçekleşen ortalama satış fiyatını (vergi hariç), aynı ayın ortalama piyasa fiyatını, aradaki yüzde farkı ve birim maliyeti girilmiş satışlar üzerinden marjı getirir
+// @ID getPriceHistory
+// @Tags Production
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param product query string false "Ürün adı (büyük/küçük harf duyarsız)"
+// @Param category query string false "Kategori"
+// @Param startDate query string false "Başlangıç tarihi (YYYY-MM-DD, varsayılan: 12 ay önce)"
+// @Param endDate query string false "Bitiş tarihi (YYYY-MM-DD, varsayılan: bugün)"
+// @Success 200 {object} models.APIResponse{data=[]models.ProductPriceHistory}
+// @Failure 400 {object} models.APIResponse
+// @Failure 401 {object} models.APIResponse
+// @Router /production/price-history [get]
+func (h *ProductionHandler) GetPriceHistory(c *gin.Context) {
+	userID, err := utils.GetUserID(c)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusUnauthorized, "UNAUTHORIZED", "Kullanıcı kimliği doğrulanamadı", nil)
+		return
+	}
+
+	startDate, endDate, ok := priceHistoryDateRange(c)
+	if !ok {
+		return
+	}
+
+	history, err := h.prices.History(userID, c.Query("product"), c.Query("category"), startDate, endDate)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusInternalServerError, "DB_ERROR", "Fiyat geçmişi alınamadı", err.Error())
+		return
+	}
+
+	utils.SuccessResponse(c, history, "Fiyat geçmişi başarıyla getirildi")
+}
+
+// saveMarketPrice piyasa fiyatını kaydeder; aynı ürün, gün ve kaynak için kayıt varsa fiyatı günceller
+func (h *ProductionHandler) saveMarketPrice(userID string, price *models.MarketPrice) error {
+	_, err := h.db.Exec(`
+		INSERT INTO market_prices (id, user_id, product, product_key, category, price, unit, currency, price_date, source, created_at)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, CURRENT_TIMESTAMP)
+		ON CONFLICT (user_id, product_key, price_date, source) DO UPDATE SET
+		    product = excluded.product, category = excluded.category, price = excluded.price,
+		    unit = excluded.unit, currency = excluded.currency
+	`, utils.GenerateID(), userID, price.Product, services.MarketPriceKey(price.Product), price.Category,
+		price.Price, price.Unit, price.Currency, price.Date, price.Source)
+	if err != nil {
+		return err
+	}
+
+	return h.db.QueryRow(`
+		SELECT id, created_at FROM market_prices WHERE user_id = ? AND product_key = ? AND price_date = ? AND source = ?
+	`, userID, services.MarketPriceKey(price.Product), price.Date, price.Source).Scan(&price.ID, &price.CreatedAt)
+}
+
+// priceHistoryDateRange startDate/endDate sorgu parametrelerini okur (varsayılan: son 12 ay); hata varsa yanıtı yazar
+func priceHistoryDateRange(c *gin.Context) (time.Time, time.Time, bool) {
+	var err error
+
+	now := time.Now().UTC()
+	end := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC)
+	if value := c.Query("endDate"); value != "" {
+		if end, err = time.Parse("2006-01-02", value); err != nil {
+			utils.ErrorResponse(c, http.StatusBadRequest, "INVALID_DATE", "Geçersiz bitiş tarihi", nil)
+			return end, end, false
+		}
+	}
+
+	start := end.AddDate(-1, 0, 0)
+	if value := c.Query("startDate"); value != "" {
+		if start, err = time.Parse("2006-01-02", value); err != nil {
+			utils.ErrorResponse(c, http.StatusBadRequest, "INVALID_DATE", "Geçersiz başlangıç tarihi", nil)
+			return start, end, false
+		}
+	}
+
+	if start.After(end) {
+		utils.ErrorResponse(c, http.StatusBadRequest, "INVALID_DATE_RANGE", "Başlangıç tarihi bitiş tarihinden sonra olamaz", nil)
+		return start, end, false
+	}
+
+	return start, end, true
+}
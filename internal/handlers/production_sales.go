@@ -0,0 +1,305 @@
+package handlers
+
+import (
+	"database/sql"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"agri-management-api/internal/models"
+	"agri-management-api/internal/services"
+	"agri-management-api/internal/utils"
+
+	"github.com/gin-gonic/gin"
+)
+
+// productionSaleCategory üretim satışlarından oluşan gelir işlemlerinin kategorisi
+const productionSaleCategory = "Ürün Satışı"
+
+// SellProduction üretimden satış
+// @Summary Üretimden satış
+// @Description Üretimden satılan miktarı stoktan düşer, gelir işlemi ve istenirse numaralı satış faturası oluşturur; tümü tek işlemde kaydedilir, stok tükenirse üretim durumu "sold" olur. Vade tarihi girilirse gelir işlemi bekleyen durumda oluşturulur
+// @ID sellProduction
+// @Tags Production
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Üretim ID"
+// @Param request body models.ProductionSaleRequest true "Satış bilgileri"
+// @Success 201 {object} models.APIResponse{data=models.ProductionSaleResult}
+// @Failure 400 {object} models.APIResponse
+// @Failure 401 {object} models.APIResponse
+// @Failure 404 {object} models.APIResponse
+// @Failure 409 {object} models.APIResponse
+// @Router /production/{id}/sell [post]
+func (h *ProductionHandler) SellProduction(c *gin.Context) {
+	userID, err := utils.GetUserID(c)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusUnauthorized, "UNAUTHORIZED", "Kullanıcı kimliği doğrulanamadı", nil)
+		return
+	}
+
+	productionID := c.Param("id")
+
+	var req models.ProductionSaleRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		utils.ErrorResponse(c, http.StatusBadRequest, "INVALID_REQUEST", "Geçersiz istek formatı", err.Error())
+		return
+	}
+
+	production, err := scanProduction(h.db.QueryRow(productionSelect+" WHERE id = ? AND user_id = ?", productionID, userID))
+	if err != nil {
+		if err == sql.ErrNoRows {
+			utils.ErrorResponse(c, http.StatusNotFound, "PRODUCTION_NOT_FOUND", "Üretim bulunamadı", nil)
+		} else {
+			utils.ErrorResponse(c, http.StatusInternalServerError, "DB_ERROR", "Üretim getirilemedi", err.Error())
+		}
+		return
+	}
+
+	if req.Quantity > production.Stock {
+		utils.ErrorResponse(c, http.StatusConflict, "INSUFFICIENT_STOCK", "Satış miktarı mevcut stoktan fazla", gin.H{
+			"stock": production.Stock,
+			"unit":  production.Unit,
+		})
+		return
+	}
+
+	saleDate := time.Now()
+	if req.Date != nil {
+		saleDate = *req.Date
+	}
+	if req.DueDate != nil && req.DueDate.Before(saleDate.Truncate(24*time.Hour)) {
+		utils.ErrorResponse(c, http.StatusBadRequest, "INVALID_DUE_DATE", "Vade tarihi satış tarihinden önce olamaz", nil)
+		return
+	}
+	if req.Currency == "" {
+		req.Currency = "TRY"
+	}
+	req.Currency = strings.ToUpper(req.Currency)
+
+	subtotal := roundTo2(req.Quantity * req.UnitPrice)
+	taxAmount := roundTo2(subtotal * req.TaxRate / 100)
+	sale := models.ProductionSale{
+		ID:            utils.GenerateID(),
+		ProductionID:  productionID,
+		ProductName:   production.Name,
+		Date:          saleDate,
+		Quantity:      req.Quantity,
+		Unit:          production.Unit,
+		UnitPrice:     req.UnitPrice,
+		Subtotal:      subtotal,
+		TaxRate:       req.TaxRate,
+		TaxAmount:     taxAmount,
+		Total:         roundTo2(subtotal + taxAmount),
+		Currency:      req.Currency,
+		Buyer:         req.Buyer,
+		BuyerTaxID:    req.BuyerTaxID,
+		BuyerAddress:  req.BuyerAddress,
+		DueDate:       req.DueDate,
+		TransactionID: utils.GenerateID(),
+		Notes:         req.Notes,
+	}
+
+	// Alıcının vergi/kimlik numarası ve adresi şifrelenerek saklanır; yanıtta açık hali döner
+	buyerTaxID, err := services.EncryptField(services.ColumnBuyerTaxID, sale.BuyerTaxID)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusInternalServerError, "ENCRYPTION_ERROR", "Alıcı bilgileri şifrelenemedi", err.Error())
+		return
+	}
+	buyerAddress, err := services.EncryptField(services.ColumnBuyerAddress, sale.BuyerAddress)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusInternalServerError, "ENCRYPTION_ERROR", "Alıcı bilgileri şifrelenemedi", err.Error())
+		return
+	}
+
+	tx, err := h.db.Begin()
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusInternalServerError, "DB_ERROR", "Satış kaydedilemedi", err.Error())
+		return
+	}
+	defer tx.Rollback()
+
+	// Stok koşulu güncellemeyle birlikte kontrol edilir; eşzamanlı satışlar stoğu eksiye düşüremez
+	result, err := tx.Exec(`
+		UPDATE production
+		SET sold_amount = COALESCE(sold_amount, 0) + ?,
+		    status = CASE WHEN amount - COALESCE(sold_amount, 0) - COALESCE(lost_amount, 0) - ? <= 0.000001 THEN 'sold' ELSE status END,
+		    updated_at = CURRENT_TIMESTAMP
+		WHERE id = ? AND user_id = ? AND amount - COALESCE(sold_amount, 0) - COALESCE(lost_amount, 0) >= ? - 0.000001
+	`, req.Quantity, req.Quantity, productionID, userID, req.Quantity)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusInternalServerError, "UPDATE_ERROR", "Stok güncellenemedi", err.Error())
+		return
+	}
+	if affected, _ := result.RowsAffected(); affected == 0 {
+		utils.ErrorResponse(c, http.StatusConflict, "INSUFFICIENT_STOCK", "Satış miktarı mevcut stoktan fazla", nil)
+		return
+	}
+
+	if req.Invoice {
+		invoiceNumber, err := nextProductionInvoiceNumber(tx, userID, saleDate.Year())
+		if err != nil {
+			utils.ErrorResponse(c, http.StatusInternalServerError, "DB_ERROR", "Fatura numarası oluşturulamadı", err.Error())
+			return
+		}
+		sale.InvoiceNumber = &invoiceNumber
+	}
+
+	status := "completed"
+	if req.DueDate != nil {
+		status = "pending"
+	}
+	description := fmt.Sprintf("%s satışı - %s %s (%s)", production.Name, formatQuantity(req.Quantity), production.Unit, req.Buyer)
+	var receipt string
+	if sale.InvoiceNumber != nil {
+		receipt = *sale.InvoiceNumber
+	}
+	_, err = tx.Exec(`
+		INSERT INTO transactions (id, user_id, type, category, description, amount, currency,
+		                         date, status, payment_method, receipt, notes, due_date, paid_at, created_at, updated_at)
+		VALUES (?, ?, 'income', ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, CASE WHEN ? = 'completed' THEN CURRENT_TIMESTAMP END, CURRENT_TIMESTAMP, CURRENT_TIMESTAMP)
+	`, sale.TransactionID, userID, productionSaleCategory, description, sale.Total, sale.Currency,
+		saleDate, status, req.PaymentMethod, receipt, req.Notes, req.DueDate, status)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusInternalServerError, "DB_ERROR", "Gelir işlemi oluşturulamadı", err.Error())
+		return
+	}
+
+	_, err = tx.Exec(`
+		INSERT INTO production_sales (id, user_id, production_id, sale_date, quantity, unit, unit_price, subtotal,
+		                              tax_rate, tax_amount, total, currency, buyer, buyer_tax_id, buyer_address,
+		                              invoice_number, due_date, transaction_id, notes, created_at)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, CURRENT_TIMESTAMP)
+	`, sale.ID, userID, productionID, saleDate, sale.Quantity, sale.Unit, sale.UnitPrice, sale.Subtotal,
+		sale.TaxRate, sale.TaxAmount, sale.Total, sale.Currency, sale.Buyer, buyerTaxID, buyerAddress,
+		sale.InvoiceNumber, sale.DueDate, sale.TransactionID, sale.Notes)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusInternalServerError, "DB_ERROR", "Satış kaydı oluşturulamadı", err.Error())
+		return
+	}
+
+	if err := tx.Commit(); err != nil {
+		utils.ErrorResponse(c, http.StatusInternalServerError, "DB_ERROR", "Satış kaydedilemedi", err.Error())
+		return
+	}
+	h.notifyLowStock(userID, productionID)
+
+	var response models.ProductionSaleResult
+	response.Sale, err = scanProductionSale(h.db.QueryRow(productionSaleSelect+" WHERE s.id = ? AND s.user_id = ?", sale.ID, userID))
+	if err == nil {
+		response.Production, err = scanProduction(h.db.QueryRow(productionSelect+" WHERE id = ? AND user_id = ?", productionID, userID))
+	}
+	if err == nil {
+		response.Transaction, err = scanTransaction(h.db.QueryRow(transactionSelect+" WHERE id = ? AND user_id = ?", sale.TransactionID, userID))
+	}
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusInternalServerError, "FETCH_ERROR", "Satış kaydı getirilemedi", err.Error())
+		return
+	}
+
+	utils.CreatedResponse(c, response, "Satış başarıyla kaydedildi")
+}
+
+// GetProductionSales üretimin satışları
+// @Summary Üretim satışları
+// @Description Üretimden yapılan satışları fatura numarası ve bağlı gelir işlemiyle birlikte tarihe göre listeler
+// @ID getProductionSales
+// @Tags Production
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Üretim ID"
+// @Success 200 {object} models.APIResponse{data=[]models.ProductionSale}
+// @Failure 401 {object} models.APIResponse
+// @Failure 404 {object} models.APIResponse
+// @Router /production/{id}/sales [get]
+func (h *ProductionHandler) GetProductionSales(c *gin.Context) {
+	userID, err := utils.GetUserID(c)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusUnauthorized, "UNAUTHORIZED", "Kullanıcı kimliği doğrulanamadı", nil)
+		return
+	}
+
+	productionID := c.Param("id")
+
+	var exists bool
+	h.db.QueryRow("SELECT 1 FROM production WHERE id = ? AND user_id = ?", productionID, userID).Scan(&exists)
+	if !exists {
+		utils.ErrorResponse(c, http.StatusNotFound, "PRODUCTION_NOT_FOUND", "Üretim bulunamadı", nil)
+		return
+	}
+
+	rows, err := h.db.Query(productionSaleSelect+" WHERE s.production_id = ? AND s.user_id = ? ORDER BY s.sale_date DESC, s.created_at DESC", productionID, userID)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusInternalServerError, "DB_ERROR", "Satışlar alınamadı", err.Error())
+		return
+	}
+	defer rows.Close()
+
+	sales := []models.ProductionSale{}
+	for rows.Next() {
+		sale, err := scanProductionSale(rows)
+		if err != nil {
+			continue
+		}
+		sales = append(sales, sale)
+	}
+
+	utils.SuccessResponse(c, sales, "Satışlar başarıyla getirildi")
+}
+
+// productionSaleSelect satış sorgularının ortak sütunları
+const productionSaleSelect = `
+	SELECT s.id, s.production_id, p.name, s.sale_date, s.quantity, COALESCE(s.unit, ''), s.unit_price, s.subtotal,
+	       s.tax_rate, s.tax_amount, s.total, s.currency, s.buyer, COALESCE(s.buyer_tax_id, ''), COALESCE(s.buyer_address, ''),
+	       s.invoice_number, s.due_date, COALESCE(s.transaction_id, ''), COALESCE(s.notes, ''), s.created_at
+	FROM production_sales s
+	JOIN production p ON p.id = s.production_id`
+
+// scanProductionSale productionSaleSelect ile seçilen satırı satış kaydına çevirir
+func scanProductionSale(row interface{ Scan(...interface{}) error }) (models.ProductionSale, error) {
+	var sale models.ProductionSale
+	var invoiceNumber sql.NullString
+	var dueDate sql.NullTime
+
+	err := row.Scan(
+		&sale.ID, &sale.ProductionID, &sale.ProductName, &sale.Date, &sale.Quantity, &sale.Unit, &sale.UnitPrice, &sale.Subtotal,
+		&sale.TaxRate, &sale.TaxAmount, &sale.Total, &sale.Currency, &sale.Buyer, &sale.BuyerTaxID, &sale.BuyerAddress,
+		&invoiceNumber, &dueDate, &sale.TransactionID, &sale.Notes, &sale.CreatedAt,
+	)
+	if err != nil {
+		return sale, err
+	}
+
+	if invoiceNumber.Valid {
+		sale.InvoiceNumber = &invoiceNumber.String
+	}
+	sale.DueDate = utils.NullTimeToPtr(dueDate)
+	sale.BuyerTaxID = services.RevealField(services.ColumnBuyerTaxID, sale.BuyerTaxID)
+	sale.BuyerAddress = services.RevealField(services.ColumnBuyerAddress, sale.BuyerAddress)
+	return sale, nil
+}
+
+// nextProductionInvoiceNumber kullanıcının o yıldaki sıradaki satış faturası numarasını üretir (SF-2024-0001);
+// sıra numarası sayı olarak karşılaştırılır, 9999'dan sonra SF-2024-10000 gelir
+func nextProductionInvoiceNumber(tx *sql.Tx, userID string, year int) (string, error) {
+	prefix := fmt.Sprintf("SF-%d-", year)
+
+	var last sql.NullInt64
+	err := tx.QueryRow(`
+		SELECT MAX(CAST(SUBSTR(invoice_number, ?) AS INTEGER)) FROM production_sales
+		WHERE user_id = ? AND invoice_number LIKE ?
+	`, len(prefix)+1, userID, prefix+"%").Scan(&last)
+	if err != nil {
+		return "", err
+	}
+
+	return fmt.Sprintf("%s%04d", prefix, last.Int64+1), nil
+}
+
+// formatQuantity miktarı gereksiz ondalık basamak olmadan yazar
+func formatQuantity(value float64) string {
+	return strings.TrimRight(strings.TrimRight(fmt.Sprintf("%.2f", value), "0"), ".")
+}
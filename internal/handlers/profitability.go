@@ -0,0 +1,472 @@
+package handlers
+
+import (
+	"database/sql"
+	"net/http"
+	"time"
+
+	"agri-management-api/internal/models"
+	"agri-management-api/internal/services"
+	"agri-management-api/internal/utils"
+
+	"github.com/gin-gonic/gin"
+)
+
+// livestockCostCategories hayvan maliyet türlerinin gider kategorileri
+var livestockCostCategories = map[string]string{
+	"feed":   "Yem",
+	"health": "Veteriner",
+	"other":  "Diğer Giderler",
+}
+
+// ProfitabilityHandler hayvan edinme, maliyet ve karlılık işlemlerini yönetir
+type ProfitabilityHandler struct {
+	db            *sql.DB
+	profitability *services.ProfitabilityService
+}
+
+// NewProfitabilityHandler yeni profitability handler oluşturur
+func NewProfitabilityHandler(db *sql.DB) *ProfitabilityHandler {
+	return &ProfitabilityHandler{
+		db:            db,
+		profitability: services.NewProfitabilityService(db),
+	}
+}
+
+// UpdateAcquisition hayvan edinme bilgisi
+// @Summary Hayvan edinme bilgisi
+// @Description Hayvanın çiftlikte doğduğunu veya fiyat ve satıcıyla satın alındığını kaydeder; recordExpense ile alım gider işlemi ve giriş hareketi oluşturulur
+// @ID updateAcquisition
+// @Tags Livestock
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Hayvan ID"
+// @Param request body models.AnimalAcquisition true "Edinme bilgileri"
+// @Success 200 {object} models.APIResponse{data=models.AnimalProfitability}
+// @Failure 400 {object} models.APIResponse
+// @Failure 401 {object} models.APIResponse
+// @Failure 404 {object} models.APIResponse
+// @Router /livestock/{id}/acquisition [put]
+func (h *ProfitabilityHandler) UpdateAcquisition(c *gin.Context) {
+	userID, err := utils.GetUserID(c)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusUnauthorized, "UNAUTHORIZED", "Kullanıcı kimliği doğrulanamadı", nil)
+		return
+	}
+
+	animalID := c.Param("id")
+
+	var req models.AnimalAcquisition
+	if err := c.ShouldBindJSON(&req); err != nil {
+		utils.ErrorResponse(c, http.StatusBadRequest, "INVALID_REQUEST", "Geçersiz istek formatı", err.Error())
+		return
+	}
+
+	var tagNumber, transactionID string
+	err = h.db.QueryRow(`
+		SELECT tag_number, COALESCE(purchase_transaction_id, '') FROM livestock WHERE id = ? AND user_id = ?
+	`, animalID, userID).Scan(&tagNumber, &transactionID)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusNotFound, "ANIMAL_NOT_FOUND", "Hayvan bulunamadı", nil)
+		return
+	}
+
+	if req.Type != models.AcquisitionPurchased {
+		req.Price, req.Seller = 0, ""
+	}
+	if req.Currency == "" {
+		req.Currency = "TRY"
+	}
+	date := req.Date
+	if date == nil {
+		now := time.Now()
+		date = &now
+	}
+
+	tx, err := h.db.Begin()
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusInternalServerError, "DB_ERROR", "Edinme bilgisi kaydedilemedi", err.Error())
+		return
+	}
+	defer tx.Rollback()
+
+	// Alım gideri ve giriş hareketi yalnızca bir kez oluşturulur; sonraki güncellemelerde gider tutarı düzeltilir
+	if transactionID != "" && req.Type == models.AcquisitionPurchased {
+		_, err = tx.Exec(`
+			UPDATE transactions SET amount = ?, currency = ?, date = ?, updated_at = CURRENT_TIMESTAMP
+			WHERE id = ? AND user_id = ?
+		`, req.Price, req.Currency, date, transactionID, userID)
+		if err != nil {
+			utils.ErrorResponse(c, http.StatusInternalServerError, "UPDATE_ERROR", "Alım gideri güncellenemedi", err.Error())
+			return
+		}
+	} else if req.Type == models.AcquisitionPurchased && req.RecordExpense && req.Price > 0 {
+		transactionID = utils.GenerateID()
+		description := "Hayvan alımı - " + tagNumber
+		if req.Seller != "" {
+			description += " (" + req.Seller + ")"
+		}
+
+		_, err = tx.Exec(`
+			INSERT INTO transactions (id, user_id, type, category, description, amount, currency,
+			                         date, status, payment_method, receipt, notes, created_at, updated_at)
+			VALUES (?, ?, 'expense', 'Hayvan Alımı', ?, ?, ?, ?, 'completed', '', '', '', CURRENT_TIMESTAMP, CURRENT_TIMESTAMP)
+		`, transactionID, userID, description, req.Price, req.Currency, date)
+		if err != nil {
+			utils.ErrorResponse(c, http.StatusInternalServerError, "DB_ERROR", "Alım gideri oluşturulamadı", err.Error())
+			return
+		}
+
+		_, err = tx.Exec(`
+			INSERT INTO livestock_movements (id, livestock_id, user_id, movement_type, movement_date, from_location, reason, created_at)
+			VALUES (?, ?, ?, 'purchase', ?, ?, 'Satın alma', CURRENT_TIMESTAMP)
+		`, utils.GenerateID(), animalID, userID, date, req.Seller)
+		if err != nil {
+			utils.ErrorResponse(c, http.StatusInternalServerError, "DB_ERROR", "Giriş hareketi oluşturulamadı", err.Error())
+			return
+		}
+	}
+
+	_, err = tx.Exec(`
+		UPDATE livestock SET acquisition_type = ?, acquisition_date = ?, purchase_price = ?, purchase_currency = ?,
+		                     seller = ?, purchase_transaction_id = ?, updated_at = CURRENT_TIMESTAMP
+		WHERE id = ? AND user_id = ?
+	`, req.Type, req.Date, req.Price, req.Currency, req.Seller, utils.StringToNullString(transactionID), animalID, userID)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusInternalServerError, "UPDATE_ERROR", "Edinme bilgisi kaydedilemedi", err.Error())
+		return
+	}
+
+	if err := tx.Commit(); err != nil {
+		utils.ErrorResponse(c, http.StatusInternalServerError, "DB_ERROR", "Edinme bilgisi kaydedilemedi", err.Error())
+		return
+	}
+
+	result, err := h.profitability.Animal(userID, animalID)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusInternalServerError, "FETCH_ERROR", "Maliyet bilgisi hesaplanamadı", err.Error())
+		return
+	}
+
+	utils.SuccessResponse(c, result, "Edinme bilgisi başarıyla kaydedildi")
+}
+
+// GetCosts hayvan maliyet kayıtları
+// @Summary Hayvan maliyet kayıtları
+// @Description Hayvana doğrudan işlenen yem, sağlık ve diğer maliyetleri listeler
+// @ID getCosts
+// @Tags Livestock
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Hayvan ID"
+// @Success 200 {object} models.APIResponse{data=[]models.LivestockCost}
+// @Failure 401 {object} models.APIResponse
+// @Failure 404 {object} models.APIResponse
+// @Router /livestock/{id}/costs [get]
+func (h *ProfitabilityHandler) GetCosts(c *gin.Context) {
+	userID, err := utils.GetUserID(c)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusUnauthorized, "UNAUTHORIZED", "Kullanıcı kimliği doğrulanamadı", nil)
+		return
+	}
+
+	animalID := c.Param("id")
+
+	// Hayvan kullanıcıya ait mi kontrol et
+	var exists bool
+	err = h.db.QueryRow("SELECT 1 FROM livestock WHERE id = ? AND user_id = ?", animalID, userID).Scan(&exists)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusNotFound, "ANIMAL_NOT_FOUND", "Hayvan bulunamadı", nil)
+		return
+	}
+
+	rows, err := h.db.Query(`
+		SELECT id, livestock_id, type, amount, date, COALESCE(description, ''), COALESCE(transaction_id, ''), created_at
+		FROM livestock_costs WHERE livestock_id = ? AND user_id = ?
+		ORDER BY date DESC, created_at DESC
+	`, animalID, userID)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusInternalServerError, "DB_ERROR", "Maliyet kayıtları alınamadı", err.Error())
+		return
+	}
+	defer rows.Close()
+
+	costs := []models.LivestockCost{}
+	for rows.Next() {
+		var cost models.LivestockCost
+		var date sql.NullTime
+
+		err := rows.Scan(&cost.ID, &cost.LivestockID, &cost.Type, &cost.Amount, &date,
+			&cost.Description, &cost.TransactionID, &cost.CreatedAt)
+		if err != nil {
+			continue
+		}
+
+		cost.Date = utils.NullTimeToPtr(date)
+		costs = append(costs, cost)
+	}
+
+	utils.SuccessResponse(c, costs, "Maliyet kayıtları başarıyla getirildi")
+}
+
+// CreateCost hayvana maliyet ekleme
+// @Summary Hayvana maliyet ekleme
+// @Description Hayvanın maliyet esasına yem, sağlık veya diğer maliyet ekler; recordExpense ile ilgili kategoride gider işlemi de oluşturulur
+// @ID createCost
+// @Tags Livestock
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Hayvan ID"
+// @Param request body models.LivestockCost true "Maliyet bilgileri"
+// @Success 201 {object} models.APIResponse{data=models.LivestockCost}
+// @Failure 400 {object} models.APIResponse
+// @Failure 401 {object} models.APIResponse
+// @Failure 404 {object} models.APIResponse
+// @Router /livestock/{id}/costs [post]
+func (h *ProfitabilityHandler) CreateCost(c *gin.Context) {
+	userID, err := utils.GetUserID(c)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusUnauthorized, "UNAUTHORIZED", "Kullanıcı kimliği doğrulanamadı", nil)
+		return
+	}
+
+	animalID := c.Param("id")
+
+	var req models.LivestockCost
+	if err := c.ShouldBindJSON(&req); err != nil {
+		utils.ErrorResponse(c, http.StatusBadRequest, "INVALID_REQUEST", "Geçersiz istek formatı", err.Error())
+		return
+	}
+
+	var tagNumber string
+	err = h.db.QueryRow("SELECT tag_number FROM livestock WHERE id = ? AND user_id = ?", animalID, userID).Scan(&tagNumber)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusNotFound, "ANIMAL_NOT_FOUND", "Hayvan bulunamadı", nil)
+		return
+	}
+
+	tx, err := h.db.Begin()
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusInternalServerError, "DB_ERROR", "Maliyet kaydı oluşturulamadı", err.Error())
+		return
+	}
+	defer tx.Rollback()
+
+	if req.RecordExpense {
+		req.TransactionID = utils.GenerateID()
+		description := req.Description
+		if description == "" {
+			description = livestockCostCategories[req.Type]
+		}
+
+		_, err = tx.Exec(`
+			INSERT INTO transactions (id, user_id, type, category, description, amount, currency,
+			                         date, status, payment_method, receipt, notes, created_at, updated_at)
+			VALUES (?, ?, 'expense', ?, ?, ?, 'TRY', ?, 'completed', '', '', '', CURRENT_TIMESTAMP, CURRENT_TIMESTAMP)
+		`, req.TransactionID, userID, livestockCostCategories[req.Type], description+" - "+tagNumber, req.Amount, req.Date)
+		if err != nil {
+			utils.ErrorResponse(c, http.StatusInternalServerError, "DB_ERROR", "Gider işlemi oluşturulamadı", err.Error())
+			return
+		}
+	}
+
+	req.ID = utils.GenerateID()
+	req.LivestockID = animalID
+	_, err = tx.Exec(`
+		INSERT INTO livestock_costs (id, user_id, livestock_id, type, amount, date, description, transaction_id, created_at)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, CURRENT_TIMESTAMP)
+	`, req.ID, userID, animalID, req.Type, req.Amount, req.Date, req.Description, utils.StringToNullString(req.TransactionID))
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusInternalServerError, "DB_ERROR", "Maliyet kaydı oluşturulamadı", err.Error())
+		return
+	}
+
+	if err := tx.Commit(); err != nil {
+		utils.ErrorResponse(c, http.StatusInternalServerError, "DB_ERROR", "Maliyet kaydı oluşturulamadı", err.Error())
+		return
+	}
+
+	h.db.QueryRow("SELECT created_at FROM livestock_costs WHERE id = ? AND user_id = ?", req.ID, userID).Scan(&req.CreatedAt)
+
+	utils.CreatedResponse(c, req, "Maliyet kaydı başarıyla oluşturuldu")
+}
+
+// SellAnimal hayvan satışı
+// @Summary Hayvan satışı
+// @Description Hayvanın canlı satışını kaydeder; satış hareketi ve gelir işlemi oluşturulur, maliyet esasına göre karlılık döner
+// @ID sellAnimal
+// @Tags Livestock
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Hayvan ID"
+// @Param request body models.AnimalSale true "Satış bilgileri"
+// @Success 200 {object} models.APIResponse{data=models.AnimalProfitability}
+// @Failure 400 {object} models.APIResponse
+// @Failure 401 {object} models.APIResponse
+// @Failure 404 {object} models.APIResponse
+// @Failure 409 {object} models.APIResponse
+// @Router /livestock/{id}/sale [post]
+func (h *ProfitabilityHandler) SellAnimal(c *gin.Context) {
+	userID, err := utils.GetUserID(c)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusUnauthorized, "UNAUTHORIZED", "Kullanıcı kimliği doğrulanamadı", nil)
+		return
+	}
+
+	animalID := c.Param("id")
+
+	var req models.AnimalSale
+	if err := c.ShouldBindJSON(&req); err != nil {
+		utils.ErrorResponse(c, http.StatusBadRequest, "INVALID_REQUEST", "Geçersiz istek formatı", err.Error())
+		return
+	}
+
+	var tagNumber, location string
+	var saleDate sql.NullTime
+	err = h.db.QueryRow(`
+		SELECT tag_number, COALESCE(location, ''), sale_date FROM livestock WHERE id = ? AND user_id = ?
+	`, animalID, userID).Scan(&tagNumber, &location, &saleDate)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusNotFound, "ANIMAL_NOT_FOUND", "Hayvan bulunamadı", nil)
+		return
+	}
+
+	var slaughtered bool
+	h.db.QueryRow("SELECT 1 FROM slaughter_records WHERE livestock_id = ? AND user_id = ?", animalID, userID).Scan(&slaughtered)
+	if saleDate.Valid || slaughtered {
+		utils.ErrorResponse(c, http.StatusConflict, "ANIMAL_EXITED", "Hayvanın çıkışı zaten kaydedilmiş", nil)
+		return
+	}
+
+	if req.Currency == "" {
+		req.Currency = "TRY"
+	}
+
+	tx, err := h.db.Begin()
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusInternalServerError, "DB_ERROR", "Satış kaydedilemedi", err.Error())
+		return
+	}
+	defer tx.Rollback()
+
+	_, err = tx.Exec(`
+		INSERT INTO livestock_movements (id, livestock_id, user_id, movement_type, movement_date,
+		                                 from_location, to_location, reason, notes, created_at)
+		VALUES (?, ?, ?, 'sale', ?, ?, ?, 'Satış', ?, CURRENT_TIMESTAMP)
+	`, utils.GenerateID(), animalID, userID, req.Date, location, req.Buyer, req.Notes)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusInternalServerError, "DB_ERROR", "Satış hareketi oluşturulamadı", err.Error())
+		return
+	}
+
+	transactionID := utils.GenerateID()
+	description := "Hayvan satışı - " + tagNumber
+	if req.Buyer != "" {
+		description += " (" + req.Buyer + ")"
+	}
+	_, err = tx.Exec(`
+		INSERT INTO transactions (id, user_id, type, category, description, amount, currency,
+		                         date, status, payment_method, receipt, notes, created_at, updated_at)
+		VALUES (?, ?, 'income', 'Hayvan Satışı', ?, ?, ?, ?, 'completed', '', '', ?, CURRENT_TIMESTAMP, CURRENT_TIMESTAMP)
+	`, transactionID, userID, description, req.Price, req.Currency, req.Date, req.Notes)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusInternalServerError, "DB_ERROR", "Gelir işlemi oluşturulamadı", err.Error())
+		return
+	}
+
+	_, err = tx.Exec(`
+		UPDATE livestock SET sale_date = ?, sale_price = ?, buyer = ?, sale_transaction_id = ?, updated_at = CURRENT_TIMESTAMP
+		WHERE id = ? AND user_id = ?
+	`, req.Date, req.Price, req.Buyer, transactionID, animalID, userID)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusInternalServerError, "UPDATE_ERROR", "Satış kaydedilemedi", err.Error())
+		return
+	}
+
+	if err := tx.Commit(); err != nil {
+		utils.ErrorResponse(c, http.StatusInternalServerError, "DB_ERROR", "Satış kaydedilemedi", err.Error())
+		return
+	}
+
+	result, err := h.profitability.Animal(userID, animalID)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusInternalServerError, "FETCH_ERROR", "Karlılık hesaplanamadı", err.Error())
+		return
+	}
+
+	utils.SuccessResponse(c, result, "Satış başarıyla kaydedildi")
+}
+
+// GetAnimalProfitability hayvan karlılığı
+// @Summary Hayvan maliyet ve karlılığı
+// @Description Hayvanın edinme bilgisi, alım/yem/sağlık/diğer maliyet esası, dağıtılan ortak giderler (overhead) ve satıldıysa kar ile marjını getirir
+// @ID getAnimalProfitability
+// @Tags Livestock
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Hayvan ID"
+// @Success 200 {object} models.APIResponse{data=models.AnimalProfitability}
+// @Failure 401 {object} models.APIResponse
+// @Failure 404 {object} models.APIResponse
+// @Router /livestock/{id}/profitability [get]
+func (h *ProfitabilityHandler) GetAnimalProfitability(c *gin.Context) {
+	userID, err := utils.GetUserID(c)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusUnauthorized, "UNAUTHORIZED", "Kullanıcı kimliği doğrulanamadı", nil)
+		return
+	}
+
+	result, err := h.profitability.Animal(userID, c.Param("id"))
+	if err == sql.ErrNoRows {
+		utils.ErrorResponse(c, http.StatusNotFound, "ANIMAL_NOT_FOUND", "Hayvan bulunamadı", nil)
+		return
+	}
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusInternalServerError, "DB_ERROR", "Karlılık hesaplanamadı", err.Error())
+		return
+	}
+
+	utils.SuccessResponse(c, result, "Hayvan karlılığı başarıyla getirildi")
+}
+
+// GetProfitability satılan hayvanların karlılığı
+// @Summary Satılan hayvanların karlılığı
+// @Description Satış veya kesimle çıkan hayvanların maliyet esası, gelir ve karını çıkış tarihine göre listeler
+// @ID getProfitability
+// @Tags Livestock
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param startDate query string false "Başlangıç tarihi (YYYY-MM-DD)"
+// @Param endDate query string false "Bitiş tarihi (YYYY-MM-DD)"
+// @Success 200 {object} models.APIResponse{data=models.ProfitabilitySummary}
+// @Failure 401 {object} models.APIResponse
+// @Router /livestock/profitability [get]
+func (h *ProfitabilityHandler) GetProfitability(c *gin.Context) {
+	userID, err := utils.GetUserID(c)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusUnauthorized, "UNAUTHORIZED", "Kullanıcı kimliği doğrulanamadı", nil)
+		return
+	}
+
+	var startDate, endDate *time.Time
+	if date, err := time.Parse("2006-01-02", c.Query("startDate")); err == nil {
+		startDate = &date
+	}
+	if date, err := time.Parse("2006-01-02", c.Query("endDate")); err == nil {
+		endDate = &date
+	}
+
+	summary, err := h.profitability.Sold(userID, startDate, endDate)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusInternalServerError, "DB_ERROR", "Karlılık hesaplanamadı", err.Error())
+		return
+	}
+
+	utils.SuccessResponse(c, summary, "Hayvan karlılığı başarıyla getirildi")
+}
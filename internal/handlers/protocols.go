@@ -0,0 +1,467 @@
+package handlers
+
+import (
+	"database/sql"
+	"fmt"
+	"net/http"
+	"sort"
+	"strings"
+	"time"
+
+	"agri-management-api/internal/models"
+	"agri-management-api/internal/utils"
+
+	"github.com/gin-gonic/gin"
+)
+
+// ProtocolHandler tedavi protokolü işlemlerini yönetir
+type ProtocolHandler struct {
+	db *sql.DB
+}
+
+// NewProtocolHandler yeni protocol handler oluşturur
+func NewProtocolHandler(db *sql.DB) *ProtocolHandler {
+	return &ProtocolHandler{db: db}
+}
+
+// GetProtocols protokol listesi
+// @Summary Tedavi protokolleri
+// @Description Standart sistem protokolleri ile kullanıcının tanımladığı protokolleri listeler
+// @ID getProtocols
+// @Tags Protocols
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param species query string false "Hayvan türü (örn. cattle); türden bağımsız protokoller her zaman listelenir"
+// @Success 200 {object} models.APIResponse{data=[]models.TreatmentProtocol}
+// @Failure 401 {object} models.APIResponse
+// @Router /protocols [get]
+func (h *ProtocolHandler) GetProtocols(c *gin.Context) {
+	userID, err := utils.GetUserID(c)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusUnauthorized, "UNAUTHORIZED", "Kullanıcı kimliği doğrulanamadı", nil)
+		return
+	}
+
+	whereClause := "WHERE (user_id IS NULL OR user_id = ?)"
+	args := []interface{}{userID}
+
+	if species := c.Query("species"); species != "" {
+		whereClause += " AND (COALESCE(species, '') = '' OR species = ?)"
+		args = append(args, species)
+	}
+
+	rows, err := h.db.Query(`
+		SELECT id, user_id, name, COALESCE(species, ''), COALESCE(description, ''), steps, created_at, updated_at
+		FROM treatment_protocols `+whereClause+`
+		ORDER BY user_id IS NOT NULL, name
+	`, args...)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusInternalServerError, "DB_ERROR", "Protokoller alınamadı", err.Error())
+		return
+	}
+	defer rows.Close()
+
+	protocols := []models.TreatmentProtocol{}
+	for rows.Next() {
+		protocol, err := scanProtocol(rows)
+		if err != nil {
+			continue
+		}
+		protocols = append(protocols, protocol)
+	}
+
+	utils.SuccessResponse(c, protocols, "Protokoller başarıyla getirildi")
+}
+
+// CreateProtocol yeni protokol oluşturma
+// @Summary Tedavi protokolü oluşturma
+// @Description Adımları gün aralıklarıyla tanımlanan kullanıcıya özel protokol oluşturur
+// @ID createProtocol
+// @Tags Protocols
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param request body models.TreatmentProtocol true "Protokol bilgileri"
+// @Success 201 {object} models.APIResponse{data=models.TreatmentProtocol}
+// @Failure 400 {object} models.APIResponse
+// @Failure 401 {object} models.APIResponse
+// @Router /protocols [post]
+func (h *ProtocolHandler) CreateProtocol(c *gin.Context) {
+	userID, err := utils.GetUserID(c)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusUnauthorized, "UNAUTHORIZED", "Kullanıcı kimliği doğrulanamadı", nil)
+		return
+	}
+
+	var req models.TreatmentProtocol
+	if err := c.ShouldBindJSON(&req); err != nil {
+		utils.ErrorResponse(c, http.StatusBadRequest, "INVALID_REQUEST", "Geçersiz istek formatı", err.Error())
+		return
+	}
+
+	sortProtocolSteps(req.Steps)
+	steps, _ := utils.ToJSON(req.Steps)
+
+	protocolID := utils.GenerateID()
+	_, err = h.db.Exec(`
+		INSERT INTO treatment_protocols (id, user_id, name, species, description, steps, created_at, updated_at)
+		VALUES (?, ?, ?, ?, ?, ?, CURRENT_TIMESTAMP, CURRENT_TIMESTAMP)
+	`, protocolID, userID, req.Name, req.Species, req.Description, steps)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusInternalServerError, "DB_ERROR", "Protokol oluşturulamadı", err.Error())
+		return
+	}
+
+	protocol, err := h.getProtocol(protocolID, userID)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusInternalServerError, "FETCH_ERROR", "Oluşturulan protokol getirilemedi", err.Error())
+		return
+	}
+
+	utils.CreatedResponse(c, protocol, "Protokol başarıyla oluşturuldu")
+}
+
+// UpdateProtocol protokol güncelleme
+// @Summary Tedavi protokolü güncelleme
+// @Description Kullanıcıya ait protokolü günceller; sistem protokolleri değiştirilemez
+// @ID updateProtocol
+// @Tags Protocols
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Protokol ID"
+// @Param request body models.TreatmentProtocol true "Protokol bilgileri"
+// @Success 200 {object} models.APIResponse{data=models.TreatmentProtocol}
+// @Failure 400 {object} models.APIResponse
+// @Failure 401 {object} models.APIResponse
+// @Failure 404 {object} models.APIResponse
+// @Router /protocols/{id} [put]
+func (h *ProtocolHandler) UpdateProtocol(c *gin.Context) {
+	userID, err := utils.GetUserID(c)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusUnauthorized, "UNAUTHORIZED", "Kullanıcı kimliği doğrulanamadı", nil)
+		return
+	}
+
+	protocolID := c.Param("id")
+
+	var req models.TreatmentProtocol
+	if err := c.ShouldBindJSON(&req); err != nil {
+		utils.ErrorResponse(c, http.StatusBadRequest, "INVALID_REQUEST", "Geçersiz istek formatı", err.Error())
+		return
+	}
+
+	sortProtocolSteps(req.Steps)
+	steps, _ := utils.ToJSON(req.Steps)
+
+	result, err := h.db.Exec(`
+		UPDATE treatment_protocols SET name = ?, species = ?, description = ?, steps = ?, updated_at = CURRENT_TIMESTAMP
+		WHERE id = ? AND user_id = ?
+	`, req.Name, req.Species, req.Description, steps, protocolID, userID)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusInternalServerError, "UPDATE_ERROR", "Protokol güncellenemedi", err.Error())
+		return
+	}
+
+	rowsAffected, _ := result.RowsAffected()
+	if rowsAffected == 0 {
+		utils.ErrorResponse(c, http.StatusNotFound, "PROTOCOL_NOT_FOUND", "Protokol bulunamadı", nil)
+		return
+	}
+
+	protocol, err := h.getProtocol(protocolID, userID)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusInternalServerError, "FETCH_ERROR", "Güncellenen protokol getirilemedi", err.Error())
+		return
+	}
+
+	utils.SuccessResponse(c, protocol, "Protokol başarıyla güncellendi")
+}
+
+// DeleteProtocol protokol silme
+// @Summary Tedavi protokolü silme
+// @Description Kullanıcıya ait protokolü siler; daha önce oluşturulan sağlık kayıtları ve etkinlikler korunur
+// @ID deleteProtocol
+// @Tags Protocols
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Protokol ID"
+// @Success 200 {object} models.APIResponse
+// @Failure 401 {object} models.APIResponse
+// @Failure 404 {object} models.APIResponse
+// @Router /protocols/{id} [delete]
+func (h *ProtocolHandler) DeleteProtocol(c *gin.Context) {
+	userID, err := utils.GetUserID(c)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusUnauthorized, "UNAUTHORIZED", "Kullanıcı kimliği doğrulanamadı", nil)
+		return
+	}
+
+	result, err := h.db.Exec("DELETE FROM treatment_protocols WHERE id = ? AND user_id = ?", c.Param("id"), userID)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusInternalServerError, "DELETE_ERROR", "Protokol silinemedi", err.Error())
+		return
+	}
+
+	rowsAffected, _ := result.RowsAffected()
+	if rowsAffected == 0 {
+		utils.ErrorResponse(c, http.StatusNotFound, "PROTOCOL_NOT_FOUND", "Protokol bulunamadı", nil)
+		return
+	}
+
+	utils.SuccessResponse(c, nil, "Protokol başarıyla silindi")
+}
+
+// ApplyProtocol protokolü hayvanlara uygulama
+// @Summary Tedavi protokolü uygulama
+// @Description Protokolü seçilen hayvanlara veya bir türün tüm hayvanlarına uygular; her adım için sağlık kaydı ve takip adımları için takvim etkinliği oluşturur
+// @ID applyProtocol
+// @Tags Protocols
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Protokol ID"
+// @Param request body models.ProtocolApplyRequest true "Uygulama bilgileri"
+// @Success 201 {object} models.APIResponse{data=models.ProtocolApplication}
+// @Failure 400 {object} models.APIResponse
+// @Failure 401 {object} models.APIResponse
+// @Failure 404 {object} models.APIResponse
+// @Router /protocols/{id}/apply [post]
+func (h *ProtocolHandler) ApplyProtocol(c *gin.Context) {
+	userID, err := utils.GetUserID(c)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusUnauthorized, "UNAUTHORIZED", "Kullanıcı kimliği doğrulanamadı", nil)
+		return
+	}
+
+	protocol, err := h.getProtocol(c.Param("id"), userID)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusNotFound, "PROTOCOL_NOT_FOUND", "Protokol bulunamadı", nil)
+		return
+	}
+
+	var req models.ProtocolApplyRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		utils.ErrorResponse(c, http.StatusBadRequest, "INVALID_REQUEST", "Geçersiz istek formatı", err.Error())
+		return
+	}
+
+	startDate, err := time.Parse("2006-01-02", req.StartDate)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusBadRequest, "INVALID_DATE", "Başlangıç tarihi YYYY-MM-DD biçiminde olmalı", nil)
+		return
+	}
+
+	if len(req.AnimalIDs) == 0 && req.Species == "" {
+		utils.ErrorResponse(c, http.StatusBadRequest, "MISSING_ANIMALS", "Hayvan listesi veya hayvan türü gerekli", nil)
+		return
+	}
+
+	animals, ok := h.protocolAnimals(c, userID, protocol, req)
+	if !ok {
+		return
+	}
+
+	application := models.ProtocolApplication{
+		ProtocolID: protocol.ID,
+		AnimalIDs:  make([]string, 0, len(animals)),
+		Schedule:   make([]models.ProtocolScheduleItem, 0, len(protocol.Steps)),
+	}
+	for _, animal := range animals {
+		application.AnimalIDs = append(application.AnimalIDs, animal.id)
+	}
+
+	tx, err := h.db.Begin()
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusInternalServerError, "DB_ERROR", "Protokol uygulanamadı", err.Error())
+		return
+	}
+	defer tx.Rollback()
+
+	notes := "Protokol: " + protocol.Name
+	for i, step := range protocol.Steps {
+		date := startDate.AddDate(0, 0, step.DayOffset)
+		item := models.ProtocolScheduleItem{
+			DayOffset:   step.DayOffset,
+			Date:        date.Format("2006-01-02"),
+			Type:        step.Type,
+			Description: step.Description,
+		}
+
+		// Bir sonraki adımın tarihi sağlık kaydında kontrol tarihi olarak saklanır
+		var nextCheckup interface{}
+		if i+1 < len(protocol.Steps) {
+			nextCheckup = startDate.AddDate(0, 0, protocol.Steps[i+1].DayOffset)
+		}
+
+		stepNotes := notes
+		if step.Notes != "" {
+			stepNotes += " - " + step.Notes
+		}
+
+		for _, animal := range animals {
+			_, err := tx.Exec(`
+				INSERT INTO health_records (id, livestock_id, type, description, date, veterinarian, notes, next_checkup, created_at)
+				VALUES (?, ?, ?, ?, ?, ?, ?, ?, CURRENT_TIMESTAMP)
+			`, utils.GenerateID(), animal.id, step.Type, step.Description, date, req.Veterinarian, stepNotes, nextCheckup)
+			if err != nil {
+				utils.ErrorResponse(c, http.StatusInternalServerError, "DB_ERROR", "Sağlık kayıtları oluşturulamadı", err.Error())
+				return
+			}
+			application.HealthRecords++
+		}
+
+		// İlk adım uygulama günüdür; takip adımları için takvim etkinliği oluşturulur
+		if step.DayOffset > 0 {
+			item.EventID = utils.GenerateID()
+
+			title := step.Description
+			var entityType, entityID interface{}
+			if len(animals) == 1 {
+				title += " - " + animals[0].tagNumber
+				entityType, entityID = "livestock", animals[0].id
+			} else {
+				title += fmt.Sprintf(" (%d hayvan)", len(animals))
+			}
+
+			_, err := tx.Exec(`
+				INSERT INTO events (id, user_id, title, description, type, start_date, is_all_day, status, priority,
+				                   related_entity_type, related_entity_id, created_at, updated_at)
+				VALUES (?, ?, ?, ?, 'health', ?, TRUE, 'pending', 'high', ?, ?, CURRENT_TIMESTAMP, CURRENT_TIMESTAMP)
+			`, item.EventID, userID, title, notes+"\nHayvanlar: "+animalTags(animals), date, entityType, entityID)
+			if err != nil {
+				utils.ErrorResponse(c, http.StatusInternalServerError, "DB_ERROR", "Takip etkinlikleri oluşturulamadı", err.Error())
+				return
+			}
+			application.Events++
+		}
+
+		application.Schedule = append(application.Schedule, item)
+	}
+
+	if err := tx.Commit(); err != nil {
+		utils.ErrorResponse(c, http.StatusInternalServerError, "DB_ERROR", "Protokol uygulanamadı", err.Error())
+		return
+	}
+
+	utils.CreatedResponse(c, application, "Protokol başarıyla uygulandı")
+}
+
+// protocolAnimal protokol uygulanacak hayvan
+type protocolAnimal struct {
+	id, tagNumber, species string
+}
+
+// protocolAnimals istekteki hayvanları veya türün tüm hayvanlarını getirir ve protokol türüyle uyumunu doğrular; hata varsa yanıtı yazar
+func (h *ProtocolHandler) protocolAnimals(c *gin.Context, userID string, protocol models.TreatmentProtocol, req models.ProtocolApplyRequest) ([]protocolAnimal, bool) {
+	query := "SELECT id, tag_number, type FROM livestock WHERE user_id = ?"
+	args := []interface{}{userID}
+
+	if len(req.AnimalIDs) > 0 {
+		placeholders := make([]string, len(req.AnimalIDs))
+		for i, id := range req.AnimalIDs {
+			placeholders[i] = "?"
+			args = append(args, id)
+		}
+		query += " AND id IN (" + strings.Join(placeholders, ", ") + ")"
+	} else {
+		query += " AND type = ?"
+		args = append(args, req.Species)
+	}
+
+	rows, err := h.db.Query(query+" ORDER BY tag_number", args...)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusInternalServerError, "DB_ERROR", "Hayvanlar alınamadı", err.Error())
+		return nil, false
+	}
+	defer rows.Close()
+
+	var animals []protocolAnimal
+	found := map[string]bool{}
+	for rows.Next() {
+		var animal protocolAnimal
+		if err := rows.Scan(&animal.id, &animal.tagNumber, &animal.species); err != nil {
+			continue
+		}
+		animals = append(animals, animal)
+		found[animal.id] = true
+	}
+
+	var missing []string
+	for _, id := range req.AnimalIDs {
+		if !found[id] {
+			missing = append(missing, id)
+		}
+	}
+	if len(missing) > 0 {
+		utils.ErrorResponse(c, http.StatusNotFound, "ANIMAL_NOT_FOUND", "Bazı hayvanlar bulunamadı", missing)
+		return nil, false
+	}
+	if len(animals) == 0 {
+		utils.ErrorResponse(c, http.StatusBadRequest, "NO_ANIMALS", "Protokolün uygulanacağı hayvan bulunamadı", nil)
+		return nil, false
+	}
+
+	if protocol.Species != "" {
+		var mismatched []string
+		for _, animal := range animals {
+			if animal.species != protocol.Species {
+				mismatched = append(mismatched, animal.tagNumber)
+			}
+		}
+		if len(mismatched) > 0 {
+			utils.ErrorResponse(c, http.StatusBadRequest, "SPECIES_MISMATCH", "Protokol bu hayvan türü için tanımlanmamış", mismatched)
+			return nil, false
+		}
+	}
+
+	return animals, true
+}
+
+// getProtocol kullanıcının erişebildiği (sistem veya kendi) protokolü getirir
+func (h *ProtocolHandler) getProtocol(protocolID, userID string) (models.TreatmentProtocol, error) {
+	row := h.db.QueryRow(`
+		SELECT id, user_id, name, COALESCE(species, ''), COALESCE(description, ''), steps, created_at, updated_at
+		FROM treatment_protocols WHERE id = ? AND (user_id IS NULL OR user_id = ?)
+	`, protocolID, userID)
+	return scanProtocol(row)
+}
+
+// scanProtocol protokol satırını okur
+func scanProtocol(row interface{ Scan(...interface{}) error }) (models.TreatmentProtocol, error) {
+	var protocol models.TreatmentProtocol
+	var owner sql.NullString
+	var steps string
+
+	err := row.Scan(
+		&protocol.ID, &owner, &protocol.Name, &protocol.Species, &protocol.Description,
+		&steps, &protocol.CreatedAt, &protocol.UpdatedAt,
+	)
+	if err != nil {
+		return protocol, err
+	}
+
+	protocol.IsSystem = !owner.Valid
+	if err := utils.FromJSON(steps, &protocol.Steps); err != nil {
+		return protocol, err
+	}
+	return protocol, nil
+}
+
+// sortProtocolSteps adımları gün sırasına dizer
+func sortProtocolSteps(steps []models.ProtocolStep) {
+	sort.SliceStable(steps, func(i, j int) bool {
+		return steps[i].DayOffset < steps[j].DayOffset
+	})
+}
+
+// animalTags hayvan küpe numaralarını virgülle birleştirir
+func animalTags(animals []protocolAnimal) string {
+	tags := make([]string, len(animals))
+	for i, animal := range animals {
+		tags[i] = animal.tagNumber
+	}
+	return strings.Join(tags, ", ")
+}
@@ -0,0 +1,248 @@
+package handlers
+
+import (
+	"database/sql"
+	"errors"
+	"net/http"
+
+	"agri-management-api/internal/models"
+	"agri-management-api/internal/services"
+	"agri-management-api/internal/utils"
+
+	"github.com/gin-gonic/gin"
+)
+
+// PublicProfileHandler çiftliklerin herkese açık profillerini ve oturum açmadan okunan çiftlik dizinini yönetir
+type PublicProfileHandler struct {
+	farms    *services.FarmService
+	profiles *services.PublicProfileService
+	store    services.MediaStore
+}
+
+// NewPublicProfileHandler yeni public profile handler oluşturur
+func NewPublicProfileHandler(db *sql.DB) *PublicProfileHandler {
+	return &PublicProfileHandler{
+		farms:    services.NewFarmService(db),
+		profiles: services.NewPublicProfileService(db),
+		store:    services.NewMediaStore(),
+	}
+}
+
+// GetFarmPublicProfile çiftliğin herkese açık profili
+// @Summary Çiftliğin herkese açık profili
+// @Description Çiftliğin herkese açık profilini döner. Profil oluşturulmamışsa çiftlik adından önerilen adres ve çiftliğin konumuyla yayınlanmamış (enabled=false) bir taslak döner
+// @ID getFarmPublicProfile
+// @Tags Farms
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Çiftlik ID"
+// @Success 200 {object} models.APIResponse{data=models.FarmPublicProfile}
+// @Failure 401 {object} models.APIResponse
+// @Failure 404 {object} models.APIResponse
+// @Router /farms/{id}/public-profile [get]
+func (h *PublicProfileHandler) GetFarmPublicProfile(c *gin.Context) {
+	farmID, ok := h.ownedFarm(c)
+	if !ok {
+		return
+	}
+
+	profile, err := h.profiles.Get(farmID)
+	if errors.Is(err, services.ErrPublicProfileNotFound) {
+		profile, err = h.profiles.Draft(farmID)
+	}
+	if err != nil {
+		writePublicProfileError(c, err, "Herkese açık profil alınamadı")
+		return
+	}
+
+	utils.SuccessResponse(c, profile, "Herkese açık profil başarıyla getirildi")
+}
+
+// SaveFarmPublicProfile çiftliğin herkese açık profilini kaydetme
+// @Summary Herkese açık profili kaydet
+// @Description Çiftliğin herkese açık profilini oluşturur veya günceller. enabled=true olan profil oturum açmadan /public/farms/{slug} adresinde yayınlanır; profilde yalnızca çiftlik adı, açıklama, ürünler, konum bölgesi ve seçilen fotoğraflar gösterilir. photoIds çiftliğe POST /media/photos ile yüklenmiş fotoğraflar olmalıdır
+// @ID saveFarmPublicProfile
+// @Tags Farms
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Çiftlik ID"
+// @Param request body models.FarmPublicProfileRequest true "Profil bilgileri"
+// @Success 200 {object} models.APIResponse{data=models.FarmPublicProfile}
+// @Failure 400 {object} models.APIResponse
+// @Failure 401 {object} models.APIResponse
+// @Failure 403 {object} models.APIResponse
+// @Failure 404 {object} models.APIResponse
+// @Failure 409 {object} models.APIResponse
+// @Router /farms/{id}/public-profile [put]
+func (h *PublicProfileHandler) SaveFarmPublicProfile(c *gin.Context) {
+	farmID, ok := h.ownedFarm(c)
+	if !ok {
+		return
+	}
+
+	var req models.FarmPublicProfileRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		utils.ErrorResponse(c, http.StatusBadRequest, "INVALID_REQUEST", "Geçersiz istek formatı", err.Error())
+		return
+	}
+
+	profile, err := h.profiles.Save(farmID, req)
+	if err != nil {
+		writePublicProfileError(c, err, "Herkese açık profil kaydedilemedi")
+		return
+	}
+
+	utils.SuccessResponse(c, profile, "Herkese açık profil başarıyla kaydedildi")
+}
+
+// DeleteFarmPublicProfile çiftliğin herkese açık profilini silme
+// @Summary Herkese açık profili sil
+// @Description Profili yayından kaldırıp siler; profil adresi boşa çıkar
+// @ID deleteFarmPublicProfile
+// @Tags Farms
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Çiftlik ID"
+// @Success 200 {object} models.APIResponse
+// @Failure 401 {object} models.APIResponse
+// @Failure 404 {object} models.APIResponse
+// @Router /farms/{id}/public-profile [delete]
+func (h *PublicProfileHandler) DeleteFarmPublicProfile(c *gin.Context) {
+	farmID, ok := h.ownedFarm(c)
+	if !ok {
+		return
+	}
+
+	if err := h.profiles.Delete(farmID); err != nil {
+		writePublicProfileError(c, err, "Herkese açık profil silinemedi")
+		return
+	}
+
+	utils.SuccessResponse(c, nil, "Herkese açık profil başarıyla silindi")
+}
+
+// GetPublicFarms herkese açık çiftlik dizini
+// @Summary Çiftlik dizini
+// @Description Profilini yayınlayan çiftlikleri en son yayınlanandan başlayarak listeler. Oturum gerektirmez; istemci IP'si başına dakikada 60 istekle sınırlıdır
+// @ID getPublicFarms
+// @Tags Public
+// @Produce json
+// @Param product query string false "Ürün adında arama"
+// @Param location query string false "Konum bölgesinde arama"
+// @Param page query int false "Sayfa numarası"
+// @Param limit query int false "Sayfa başına kayıt"
+// @Success 200 {object} models.APIResponse{data=models.FarmDirectoryPage}
+// @Failure 429 {object} models.APIResponse
+// @Router /public/farms [get]
+func (h *PublicProfileHandler) GetPublicFarms(c *gin.Context) {
+	page, limit := utils.ParsePagination(c)
+
+	farms, total, err := h.profiles.Directory(c.Query("product"), c.Query("location"), page, limit)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusInternalServerError, "DB_ERROR", "Çiftlik dizini alınamadı", nil)
+		return
+	}
+
+	utils.SuccessResponse(c, models.FarmDirectoryPage{
+		Farms:      farms,
+		Pagination: utils.CalculatePagination(page, limit, total),
+	}, "Çiftlik dizini başarıyla getirildi")
+}
+
+// GetPublicFarm herkese açık çiftlik profili
+// @Summary Çiftlik profili
+// @Description Yayındaki çiftlik profilini adresinden döner. Oturum gerektirmez; istemci IP'si başına dakikada 60 istekle sınırlıdır
+// @ID getPublicFarm
+// @Tags Public
+// @Produce json
+// @Param slug path string true "Profil adresi"
+// @Success 200 {object} models.APIResponse{data=models.FarmPublicProfile}
+// @Failure 404 {object} models.APIResponse
+// @Failure 429 {object} models.APIResponse
+// @Router /public/farms/{slug} [get]
+func (h *PublicProfileHandler) GetPublicFarm(c *gin.Context) {
+	profile, err := h.profiles.Published(c.Param("slug"))
+	if err != nil {
+		writePublicProfileError(c, err, "Çiftlik profili alınamadı")
+		return
+	}
+
+	utils.SuccessResponse(c, profile, "Çiftlik profili başarıyla getirildi")
+}
+
+// GetPublicFarmPhoto herkese açık profil fotoğrafı
+// @Summary Çiftlik profili fotoğrafı
+// @Description Yayındaki profilde gösterilen fotoğrafın içeriğini döner; profile eklenmemiş fotoğraflar indirilemez. Oturum gerektirmez; istemci IP'si başına dakikada 60 istekle sınırlıdır
+// @ID getPublicFarmPhoto
+// @Tags Public
+// @Produce image/jpeg,image/png,image/gif
+// @Param slug path string true "Profil adresi"
+// @Param photoId path string true "Fotoğraf ID"
+// @Success 200 {file} file
+// @Failure 404 {object} models.APIResponse
+// @Failure 429 {object} models.APIResponse
+// @Router /public/farms/{slug}/photos/{photoId} [get]
+func (h *PublicProfileHandler) GetPublicFarmPhoto(c *gin.Context) {
+	photo, storageKey, err := h.profiles.PublishedPhoto(c.Param("slug"), c.Param("photoId"))
+	if err != nil {
+		writePublicProfileError(c, err, "Fotoğraf alınamadı")
+		return
+	}
+
+	file, err := h.store.Open(storageKey)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusNotFound, "PHOTO_NOT_FOUND", "Fotoğraf bulunamadı", nil)
+		return
+	}
+	defer file.Close()
+
+	c.Header("Cache-Control", "public, max-age=3600")
+	c.DataFromReader(http.StatusOK, photo.Size, photo.ContentType, file, map[string]string{
+		"Content-Disposition": "inline; filename=" + photo.Filename,
+	})
+}
+
+// ownedFarm yol parametresindeki çiftliğin hesaba ait olduğunu doğrular; değilse yanıtı yazar
+func (h *PublicProfileHandler) ownedFarm(c *gin.Context) (string, bool) {
+	userID, err := utils.GetUserID(c)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusUnauthorized, "UNAUTHORIZED", "Kullanıcı kimliği doğrulanamadı", nil)
+		return "", false
+	}
+
+	farmID := c.Param("id")
+	if farmID == userID {
+		if err := h.farms.EnsureDefaultFarm(userID); err != nil {
+			utils.ErrorResponse(c, http.StatusInternalServerError, "DB_ERROR", "Çiftlik getirilemedi", err.Error())
+			return "", false
+		}
+	}
+
+	owns, err := h.farms.Owns(userID, farmID)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusInternalServerError, "DB_ERROR", "Çiftlik getirilemedi", err.Error())
+		return "", false
+	}
+	if !owns {
+		utils.ErrorResponse(c, http.StatusNotFound, "FARM_NOT_FOUND", "Çiftlik bulunamadı", nil)
+		return "", false
+	}
+	return farmID, true
+}
+
+// writePublicProfileError servis hatasını HTTP yanıtına çevirir
+func writePublicProfileError(c *gin.Context, err error, message string) {
+	switch {
+	case errors.Is(err, services.ErrPublicProfileNotFound):
+		utils.ErrorResponse(c, http.StatusNotFound, "PROFILE_NOT_FOUND", "Çiftlik profili bulunamadı", nil)
+	case errors.Is(err, services.ErrPublicProfilePhoto):
+		utils.ErrorResponse(c, http.StatusNotFound, "PHOTO_NOT_FOUND", "Fotoğraf bulunamadı", nil)
+	case errors.Is(err, services.ErrPublicProfileSlug):
+		utils.ErrorResponse(c, http.StatusBadRequest, "INVALID_SLUG", err.Error(), nil)
+	case errors.Is(err, services.ErrPublicProfileSlugTaken):
+		utils.ErrorResponse(c, http.StatusConflict, "SLUG_TAKEN", err.Error(), nil)
+	default:
+		utils.ErrorResponse(c, http.StatusInternalServerError, "DB_ERROR", message, nil)
+	}
+}
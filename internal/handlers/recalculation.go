@@ -0,0 +1,116 @@
+package handlers
+
+import (
+	"database/sql"
+	"errors"
+	"net/http"
+
+	"agri-management-api/internal/models"
+	"agri-management-api/internal/services"
+	"agri-management-api/internal/utils"
+
+	"github.com/gin-gonic/gin"
+)
+
+// RecalculationHandler türetilmiş değerlerin toplu yeniden hesaplanmasını yönetir
+type RecalculationHandler struct {
+	db            *sql.DB
+	recalculation *services.RecalculationService
+}
+
+// NewRecalculationHandler yeni recalculation handler oluşturur
+func NewRecalculationHandler(db *sql.DB) *RecalculationHandler {
+	return &RecalculationHandler{
+		db:            db,
+		recalculation: services.NewRecalculationService(db),
+	}
+}
+
+// StartRecalculation yeniden hesaplama başlatma
+// @Summary Yeniden hesaplama başlat
+// @Description Veri aktarımı veya hata düzeltmesinden sonra türetilmiş değerleri arka planda yeniden hesaplar ve işi hemen döner; ilerleme iş kimliğiyle izlenir. Hedefler: production_stock (satış ve kayıp kayıtlarından satılan/kaybedilen miktarlar), land_productivity (son 12 aydaki hasat verimi ve son aktivite tarihi), kpi_snapshots (günün KPI anlık görüntüsü). targets boşsa tüm hedefler, farmId boşsa tüm çiftlikler işlenir. İşler sırayla çalışır. Yönetici rolü gerektirir
+// @ID startRecalculation
+// @Tags Admin
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param request body models.RecalculationRequest true "Yeniden hesaplama"
+// @Success 202 {object} models.APIResponse{data=models.RecalculationJob}
+// @Failure 400 {object} models.APIResponse
+// @Failure 401 {object} models.APIResponse
+// @Failure 403 {object} models.APIResponse
+// @Router /admin/recalculations [post]
+func (h *RecalculationHandler) StartRecalculation(c *gin.Context) {
+	var req models.RecalculationRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		utils.ErrorResponse(c, http.StatusBadRequest, "INVALID_REQUEST", "Geçersiz istek formatı", err.Error())
+		return
+	}
+
+	job, err := h.recalculation.Start(c.GetString("user_id"), req)
+	if errors.Is(err, services.ErrInvalidRecalculation) {
+		utils.ErrorResponse(c, http.StatusBadRequest, "INVALID_RECALCULATION", err.Error(), gin.H{"targets": services.RecalculationTargets})
+		return
+	}
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusInternalServerError, "DB_ERROR", "Yeniden hesaplama başlatılamadı", err.Error())
+		return
+	}
+
+	utils.SuccessResponseWithStatus(c, http.StatusAccepted, job, "Yeniden hesaplama başlatıldı")
+}
+
+// GetRecalculations yeniden hesaplama işleri
+// @Summary Yeniden hesaplama işleri
+// @Description Yeniden hesaplama işlerini ilerlemeleriyle yeniden eskiye listeler; yanıtta geçerli hedefler de döner. Yönetici rolü gerektirir
+// @ID getRecalculations
+// @Tags Admin
+// @Produce json
+// @Security BearerAuth
+// @Param page query int false "Sayfa numarası"
+// @Param limit query int false "Sayfa başına kayıt"
+// @Success 200 {object} models.APIResponse{data=models.RecalculationJobListResponse}
+// @Failure 401 {object} models.APIResponse
+// @Failure 403 {object} models.APIResponse
+// @Router /admin/recalculations [get]
+func (h *RecalculationHandler) GetRecalculations(c *gin.Context) {
+	page, limit := utils.ParsePagination(c)
+	jobs, total, err := h.recalculation.Jobs(page, limit)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusInternalServerError, "DB_ERROR", "Yeniden hesaplama işleri alınamadı", err.Error())
+		return
+	}
+
+	utils.SuccessResponse(c, models.RecalculationJobListResponse{
+		Jobs:       jobs,
+		Targets:    services.RecalculationTargets,
+		Pagination: utils.CalculatePagination(page, limit, total),
+	}, "Yeniden hesaplama işleri başarıyla getirildi")
+}
+
+// GetRecalculation yeniden hesaplama işi
+// @Summary Yeniden hesaplama işi
+// @Description İşin durumunu (queued, running, completed, failed), işlenen adım sayısını, yüzde ilerlemeyi, değişen kayıt sayısını ve hataları getirir. Yönetici rolü gerektirir
+// @ID getRecalculation
+// @Tags Admin
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "İş ID"
+// @Success 200 {object} models.APIResponse{data=models.RecalculationJob}
+// @Failure 401 {object} models.APIResponse
+// @Failure 403 {object} models.APIResponse
+// @Failure 404 {object} models.APIResponse
+// @Router /admin/recalculations/{id} [get]
+func (h *RecalculationHandler) GetRecalculation(c *gin.Context) {
+	job, err := h.recalculation.Job(c.Param("id"))
+	if errors.Is(err, services.ErrRecalculationJobNotFound) {
+		utils.ErrorResponse(c, http.StatusNotFound, "JOB_NOT_FOUND", "Yeniden hesaplama işi bulunamadı", nil)
+		return
+	}
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusInternalServerError, "DB_ERROR", "Yeniden hesaplama işi alınamadı", err.Error())
+		return
+	}
+
+	utils.SuccessResponse(c, job, "Yeniden hesaplama işi başarıyla getirildi")
+}
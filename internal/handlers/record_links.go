@@ -0,0 +1,198 @@
+package handlers
+
+import (
+	"database/sql"
+	"log"
+	"net/http"
+
+	"agri-management-api/internal/models"
+	"agri-management-api/internal/services"
+	"agri-management-api/internal/utils"
+
+	"github.com/gin-gonic/gin"
+)
+
+// RecordLinkHandler kayıtlar arasındaki serbest ilişkileri yönetir
+type RecordLinkHandler struct {
+	db    *sql.DB
+	links *services.RecordLinkService
+}
+
+// NewRecordLinkHandler yeni record link handler oluşturur
+func NewRecordLinkHandler(db *sql.DB) *RecordLinkHandler {
+	return &RecordLinkHandler{
+		db:    db,
+		links: services.NewRecordLinkService(db),
+	}
+}
+
+// CreateRecordLink kayıt bağlantısı oluşturma
+// @Summary Kayıtları birbirine bağla
+// @Description İki kaydı serbest bir ilişkiyle bağlar (ör. gider işlemi ↔ arazi aktivitesi, etkinlik ↔ ekipman). Bağlantı yönsüzdür ve her iki kaydın bağlantı listesinde görünür; iki kayıt da çiftliğe ait olmalıdır
+// @ID createRecordLink
+// @Tags Links
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param request body models.CreateRecordLinkRequest true "Bağlantı (türler: livestock, land, land_activity, production, transaction, event, asset, document, utility_meter, hive, pond, fish_batch)"
+// @Success 201 {object} models.APIResponse{data=models.RecordLink}
+// @Failure 400 {object} models.APIResponse
+// @Failure 401 {object} models.APIResponse
+// @Failure 404 {object} models.APIResponse
+// @Failure 409 {object} models.APIResponse
+// @Router /links [post]
+func (h *RecordLinkHandler) CreateRecordLink(c *gin.Context) {
+	userID, err := utils.GetUserID(c)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusUnauthorized, "UNAUTHORIZED", "Kullanıcı kimliği doğrulanamadı", nil)
+		return
+	}
+	accountID, _ := utils.GetAccountID(c)
+
+	var req models.CreateRecordLinkRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		utils.ErrorResponse(c, http.StatusBadRequest, "INVALID_REQUEST", "Geçersiz istek formatı", err.Error())
+		return
+	}
+	for _, entityType := range []string{req.SourceType, req.TargetType} {
+		if !services.IsLinkableEntity(entityType) {
+			utils.ErrorResponse(c, http.StatusBadRequest, "INVALID_ENTITY_TYPE", "Bu kayıt türü bağlanamaz", services.LinkableEntityTypes())
+			return
+		}
+	}
+
+	link, err := h.links.Create(userID, accountID, req)
+	switch err {
+	case nil:
+	case services.ErrRecordLinkSelf:
+		utils.ErrorResponse(c, http.StatusBadRequest, "SELF_LINK", "Kayıt kendisine bağlanamaz", nil)
+		return
+	case services.ErrRecordLinkEntityNotFound:
+		utils.ErrorResponse(c, http.StatusNotFound, "ENTITY_NOT_FOUND", "Bağlanacak kayıt bulunamadı", nil)
+		return
+	case services.ErrRecordLinkExists:
+		utils.ErrorResponse(c, http.StatusConflict, "LINK_EXISTS", "Kayıtlar bu ilişkiyle zaten bağlı", nil)
+		return
+	default:
+		utils.ErrorResponse(c, http.StatusInternalServerError, "DB_ERROR", "Bağlantı oluşturulamadı", err.Error())
+		return
+	}
+
+	utils.CreatedResponse(c, link, "Bağlantı başarıyla oluşturuldu")
+}
+
+// GetRecordLinks kayıt bağlantıları
+// @Summary Kayıt bağlantıları
+// @Description Kaydın diğer kayıtlarla olan tüm bağlantılarını, kaydın kaynak ya da hedef olmasından bağımsız olarak en yeniden eskiye listeler
+// @ID getRecordLinks
+// @Tags Links
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param entityType path string true "Kayıt türü (livestock, land, land_activity, production, transaction, event, asset, document, utility_meter, hive, pond, fish_batch)"
+// @Param entityId path string true "Kayıt ID"
+// @Success 200 {object} models.APIResponse{data=[]models.RecordLink}
+// @Failure 400 {object} models.APIResponse
+// @Failure 401 {object} models.APIResponse
+// @Failure 404 {object} models.APIResponse
+// @Router /links/{entityType}/{entityId} [get]
+func (h *RecordLinkHandler) GetRecordLinks(c *gin.Context) {
+	userID, err := utils.GetUserID(c)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusUnauthorized, "UNAUTHORIZED", "Kullanıcı kimliği doğrulanamadı", nil)
+		return
+	}
+
+	entityType, entityID := c.Param("entityType"), c.Param("entityId")
+	if !services.IsLinkableEntity(entityType) {
+		utils.ErrorResponse(c, http.StatusBadRequest, "INVALID_ENTITY_TYPE", "Bu kayıt türü bağlanamaz", services.LinkableEntityTypes())
+		return
+	}
+
+	_, err = h.links.EntityName(userID, entityType, entityID)
+	if err == services.ErrRecordLinkEntityNotFound {
+		utils.ErrorResponse(c, http.StatusNotFound, "ENTITY_NOT_FOUND", "Kayıt bulunamadı", nil)
+		return
+	}
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusInternalServerError, "DB_ERROR", "Kayıt alınamadı", err.Error())
+		return
+	}
+
+	links, err := h.links.List(userID, entityType, entityID)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusInternalServerError, "DB_ERROR", "Bağlantılar alınamadı", err.Error())
+		return
+	}
+
+	utils.SuccessResponse(c, links, "Bağlantılar başarıyla getirildi")
+}
+
+// DeleteRecordLink kayıt bağlantısı silme
+// @Summary Kayıt bağlantısı silme
+// @Description İki kayıt arasındaki bağlantıyı kaldırır; kayıtların kendisi silinmez
+// @ID deleteRecordLink
+// @Tags Links
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Bağlantı ID"
+// @Success 200 {object} models.APIResponse
+// @Failure 401 {object} models.APIResponse
+// @Failure 404 {object} models.APIResponse
+// @Router /links/{id} [delete]
+func (h *RecordLinkHandler) DeleteRecordLink(c *gin.Context) {
+	userID, err := utils.GetUserID(c)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusUnauthorized, "UNAUTHORIZED", "Kullanıcı kimliği doğrulanamadı", nil)
+		return
+	}
+
+	err = h.links.Delete(userID, c.Param("id"))
+	if err == services.ErrRecordLinkNotFound {
+		utils.ErrorResponse(c, http.StatusNotFound, "LINK_NOT_FOUND", "Bağlantı bulunamadı", nil)
+		return
+	}
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusInternalServerError, "DELETE_ERROR", "Bağlantı silinemedi", err.Error())
+		return
+	}
+
+	utils.SuccessResponse(c, nil, "Bağlantı başarıyla silindi")
+}
+
+// confirmLinkedDelete bağlantısı olan kaydın silinmesini force=true verilene kadar 409 ile durdurur ve
+// bağlantıları yanıtta listeler; silme devam edebilirse true döner
+func confirmLinkedDelete(c *gin.Context, db *sql.DB, userID, entityType, entityID string) bool {
+	if c.Query("force") == "true" {
+		return true
+	}
+
+	links := services.NewRecordLinkService(db)
+	count, err := links.Count(userID, entityType, entityID)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusInternalServerError, "DB_ERROR", "Kayıt bağlantıları kontrol edilemedi", err.Error())
+		return false
+	}
+	if count == 0 {
+		return true
+	}
+
+	linked, err := links.List(userID, entityType, entityID)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusInternalServerError, "DB_ERROR", "Kayıt bağlantıları alınamadı", err.Error())
+		return false
+	}
+	utils.ErrorResponse(c, http.StatusConflict, "RECORD_HAS_LINKS", "Kaydın başka kayıtlarla bağlantısı var; silmek için force=true gönderin", map[string]interface{}{
+		"count": count,
+		"links": linked,
+	})
+	return false
+}
+
+// removeRecordLinks silinen kaydın bağlantılarını kaldırır
+func removeRecordLinks(db *sql.DB, userID, entityType, entityID string) {
+	if err := services.NewRecordLinkService(db).RemoveEntity(userID, entityType, entityID); err != nil {
+		log.Printf("Kayıt bağlantıları silinemedi (%s %s): %v", entityType, entityID, err)
+	}
+}
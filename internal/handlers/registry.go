@@ -0,0 +1,221 @@
+package handlers
+
+import (
+	"bytes"
+	"database/sql"
+	"errors"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"agri-management-api/internal/models"
+	"agri-management-api/internal/services"
+	"agri-management-api/internal/utils"
+
+	"github.com/gin-gonic/gin"
+)
+
+// RegistryHandler resmi hayvan kayıt sistemi işlemlerini yönetir
+type RegistryHandler struct {
+	db         *sql.DB
+	registry   *services.RegistryService
+	signatures *services.DocumentSigningService
+}
+
+// NewRegistryHandler yeni registry handler oluşturur
+func NewRegistryHandler(db *sql.DB) *RegistryHandler {
+	return &RegistryHandler{
+		db:         db,
+		registry:   services.NewRegistryService(db),
+		signatures: services.NewDocumentSigningService(db),
+	}
+}
+
+// ExportRegistry resmi kayıt formatında hayvan listesi
+// @Summary Resmi hayvan kayıt dışa aktarımı
+// @Description Hayvanları kulak numarası, doğum tarihi ve hareketleriyle TÜRKVET uyumlu CSV veya XML formatında dışa aktarır. Dosya sunucu anahtarıyla imzalanır; ayrık imza X-Document-Signature başlığıyla döner
+// @ID exportRegistry
+// @Tags Livestock
+// @Accept json
+// @Produce text/csv
+// @Produce application/xml
+// @Security BearerAuth
+// @Param format query string false "Dosya formatı (csv, xml)" default(csv)
+// @Param premisesNo query string false "İşletme numarası"
+// @Success 200 {file} file
+// @Failure 400 {object} models.APIResponse
+// @Failure 401 {object} models.APIResponse
+// @Router /livestock/registry/export [get]
+func (h *RegistryHandler) ExportRegistry(c *gin.Context) {
+	userID, err := utils.GetUserID(c)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusUnauthorized, "UNAUTHORIZED", "Kullanıcı kimliği doğrulanamadı", nil)
+		return
+	}
+
+	format := strings.ToLower(c.DefaultQuery("format", services.RegistryFormatCSV))
+	if format != services.RegistryFormatCSV && format != services.RegistryFormatXML {
+		utils.ErrorResponse(c, http.StatusBadRequest, "INVALID_FORMAT", "Geçersiz dosya formatı", []string{services.RegistryFormatCSV, services.RegistryFormatXML})
+		return
+	}
+
+	premisesNumber := strings.TrimSpace(c.Query("premisesNo"))
+
+	animals, err := h.registry.Animals(userID, premisesNumber)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusInternalServerError, "DB_ERROR", "Hayvan kayıtları alınamadı", err.Error())
+		return
+	}
+
+	now := time.Now()
+	var buf bytes.Buffer
+	contentType := "text/csv; charset=utf-8"
+
+	if format == services.RegistryFormatXML {
+		contentType = "application/xml; charset=utf-8"
+		err = services.WriteRegistryXML(&buf, premisesNumber, now, animals)
+	} else {
+		err = services.WriteRegistryCSV(&buf, animals)
+	}
+
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusInternalServerError, "EXPORT_ERROR", "Kayıt dosyası oluşturulamadı", err.Error())
+		return
+	}
+
+	filename := "hayvan-kayit-" + now.Format("20060102") + "." + format
+	if !signExport(c, h.signatures, userID, models.DocumentTypeRegistryExport, "", filename, contentType, buf.Bytes()) {
+		return
+	}
+	c.Header("Content-Disposition", "attachment; filename="+filename)
+	c.Data(http.StatusOK, contentType, buf.Bytes())
+}
+
+// maxRegistryFileSize içe aktarılabilecek en büyük kayıt dosyası boyutu
+const maxRegistryFileSize = 10 << 20
+
+// PreviewRegistryImport resmi kayıt dosyası içe aktarım önizlemesi
+// @Summary Resmi kayıt içe aktarım önizlemesi
+// @Description TÜRKVET kayıt dosyasını mevcut hayvanlarla karşılaştırır ve değişiklik yapmadan ayrıntılı fark listesi döner
+// @ID previewRegistryImport
+// @Tags Livestock
+// @Accept multipart/form-data
+// @Produce json
+// @Security BearerAuth
+// @Param file formData file true "Kayıt dosyası (CSV veya XML)"
+// @Param mode formData string false "Mutabakat modu (create, update, flag)" default(create)
+// @Param format formData string false "Dosya formatı (csv, xml); boşsa içerikten tespit edilir"
+// @Success 200 {object} models.APIResponse{data=models.RegistryImportResult}
+// @Failure 400 {object} models.APIResponse
+// @Failure 401 {object} models.APIResponse
+// @Router /livestock/registry/import/preview [post]
+func (h *RegistryHandler) PreviewRegistryImport(c *gin.Context) {
+	h.importRegistry(c, false)
+}
+
+// ImportRegistry resmi kayıt dosyasını içe aktarma
+// @Summary Resmi kayıt içe aktarımı
+// @Description TÜRKVET kayıt dosyasını seçilen moda göre uygular: create yeni hayvanları ekler, update farklılıkları günceller, flag yalnızca uyuşmazlıkları bildirir
+// @ID importRegistry
+// @Tags Livestock
+// @Accept multipart/form-data
+// @Produce json
+// @Security BearerAuth
+// @Param file formData file true "Kayıt dosyası (CSV veya XML)"
+// @Param mode formData string false "Mutabakat modu (create, update, flag)" default(create)
+// @Param format formData string false "Dosya formatı (csv, xml); boşsa içerikten tespit edilir"
+// @Success 200 {object} models.APIResponse{data=models.RegistryImportResult}
+// @Failure 400 {object} models.APIResponse
+// @Failure 401 {object} models.APIResponse
+// @Router /livestock/registry/import [post]
+func (h *RegistryHandler) ImportRegistry(c *gin.Context) {
+	h.importRegistry(c, true)
+}
+
+// importRegistry önizleme ve içe aktarım için ortak akış
+func (h *RegistryHandler) importRegistry(c *gin.Context, commit bool) {
+	userID, err := utils.GetUserID(c)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusUnauthorized, "UNAUTHORIZED", "Kullanıcı kimliği doğrulanamadı", nil)
+		return
+	}
+
+	mode := strings.ToLower(c.DefaultPostForm("mode", models.RegistryImportModeCreate))
+	if mode != models.RegistryImportModeCreate && mode != models.RegistryImportModeUpdate && mode != models.RegistryImportModeFlag {
+		utils.ErrorResponse(c, http.StatusBadRequest, "INVALID_MODE", "Geçersiz içe aktarım modu",
+			[]string{models.RegistryImportModeCreate, models.RegistryImportModeUpdate, models.RegistryImportModeFlag})
+		return
+	}
+
+	format := strings.ToLower(c.PostForm("format"))
+	if format != "" && format != services.RegistryFormatCSV && format != services.RegistryFormatXML {
+		utils.ErrorResponse(c, http.StatusBadRequest, "INVALID_FORMAT", "Geçersiz dosya formatı", []string{services.RegistryFormatCSV, services.RegistryFormatXML})
+		return
+	}
+
+	fileHeader, err := c.FormFile("file")
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusBadRequest, "MISSING_FILE", "Kayıt dosyası gerekli", nil)
+		return
+	}
+	if fileHeader.Size > maxRegistryFileSize {
+		utils.ErrorResponse(c, http.StatusBadRequest, "FILE_TOO_LARGE", "Kayıt dosyası çok büyük", nil)
+		return
+	}
+
+	file, err := fileHeader.Open()
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusBadRequest, "INVALID_FILE", "Kayıt dosyası okunamadı", err.Error())
+		return
+	}
+	defer file.Close()
+
+	data, err := io.ReadAll(file)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusBadRequest, "INVALID_FILE", "Kayıt dosyası okunamadı", err.Error())
+		return
+	}
+
+	if format == "" && strings.HasSuffix(strings.ToLower(fileHeader.Filename), ".xml") {
+		format = services.RegistryFormatXML
+	}
+
+	animals, err := services.ParseRegistryFile(data, format)
+	if err != nil {
+		if errors.Is(err, services.ErrEmptyRegistryFile) {
+			utils.ErrorResponse(c, http.StatusBadRequest, "EMPTY_FILE", "Kayıt dosyasında hayvan bulunamadı", nil)
+			return
+		}
+		utils.ErrorResponse(c, http.StatusBadRequest, "PARSE_ERROR", "Kayıt dosyası çözümlenemedi", err.Error())
+		return
+	}
+
+	result, err := h.registry.ImportRegistry(userID, animals, mode, commit)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusInternalServerError, "IMPORT_ERROR", "Kayıt dosyası içe aktarılamadı", err.Error())
+		return
+	}
+
+	if !commit {
+		utils.SuccessResponse(c, result, "İçe aktarım önizlemesi başarıyla oluşturuldu")
+		return
+	}
+
+	// Uyuşmazlıklar kullanıcıya uyarı olarak bildirilir
+	flagged := result.Summary[services.RegistryActionMismatch] + result.Summary[services.RegistryActionMissing] +
+		result.Summary[services.RegistryActionNotInRegistry]
+	if flagged > 0 {
+		services.NewNotificationService(h.db).Create(services.Notification{
+			UserID:   userID,
+			Title:    "Resmi Kayıt Uyuşmazlığı",
+			Message:  strconv.Itoa(flagged) + " hayvanda resmi kayıt ile çiftlik kayıtları arasında uyuşmazlık bulundu.",
+			Type:     "alert",
+			Priority: "high",
+			Topic:    models.NotificationTopicRegistryMismatch,
+		})
+	}
+
+	utils.SuccessResponse(c, result, "Kayıt dosyası başarıyla içe aktarıldı")
+}
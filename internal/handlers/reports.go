@@ -2,10 +2,12 @@ package handlers
 
 import (
 	"database/sql"
+	"errors"
 	"net/http"
 	"time"
 
 	"agri-management-api/internal/models"
+	"agri-management-api/internal/services"
 	"agri-management-api/internal/utils"
 
 	"github.com/gin-gonic/gin"
@@ -13,124 +15,96 @@ import (
 
 // ReportsHandler rapor işlemlerini yönetir
 type ReportsHandler struct {
-	db *sql.DB
+	db      *sql.DB
+	flags   *services.FeatureFlagService
+	carbon  *services.CarbonService
+	farms   *services.FarmService
+	reports *services.ReportService
+	jobs    *services.JobService
 }
 
 // NewReportsHandler yeni reports handler oluşturur
 func NewReportsHandler(db *sql.DB) *ReportsHandler {
-	return &ReportsHandler{db: db}
+	return &ReportsHandler{
+		db:      db,
+		flags:   services.NewFeatureFlagService(db),
+		carbon:  services.NewCarbonService(db),
+		farms:   services.NewFarmService(db),
+		reports: services.NewReportService(db),
+		jobs:    services.NewJobService(db),
+	}
 }
 
 // GetReports rapor listesi
 // @Summary Rapor listesi
-// @Description Kullanıcının raporlarını listeler
+// @Description Çiftlik için oluşturulmuş raporları en yeniden eskiye sayfalı listeler; tür ve dönem (rapor oluşturulurken verilen period veya "YYYY-MM-DD / YYYY-MM-DD" aralığı) ile filtrelenebilir
+// @ID getReports
 // @Tags Reports
 // @Accept json
 // @Produce json
 // @Security BearerAuth
-// @Param type query string false "Rapor türü"
-// @Param period query string false "Periyot"
-// @Success 200 {object} models.APIResponse{data=[]map[string]interface{}}
+// @Param type query string false "Rapor türü (financial, production, livestock, land; all tümü)"
+// @Param period query string false "Periyot (all tümü)"
+// @Param page query int false "Sayfa numarası"
+// @Param limit query int false "Sayfa başına kayıt"
+// @Success 200 {object} models.APIResponse{data=models.ReportListResponse}
+// @Failure 400 {object} models.APIResponse
 // @Failure 401 {object} models.APIResponse
 // @Router /reports [get]
 func (h *ReportsHandler) GetReports(c *gin.Context) {
-	_, err := utils.GetUserID(c)
+	userID, err := utils.GetUserID(c)
 	if err != nil {
 		utils.ErrorResponse(c, http.StatusUnauthorized, "UNAUTHORIZED", "Kullanıcı kimliği doğrulanamadı", nil)
 		return
 	}
 
-	reportType := c.DefaultQuery("type", "all")
-	period := c.DefaultQuery("period", "all")
-
-	// Mock rapor listesi (gerçek uygulamada DB'den gelecek)
-	reports := []map[string]interface{}{
-		{
-			"id":            utils.GenerateID(),
-			"title":         "Aylık Finansal Rapor",
-			"type":          "financial",
-			"description":   "Geçen ay için gelir, gider ve kar analizi",
-			"generatedDate": time.Now().AddDate(0, 0, -1).Format("2006-01-02T15:04:05Z"),
-			"period":        "2024-01",
-			"format":        "pdf",
-			"downloadUrl":   "/api/v1/reports/download/report-001.pdf",
-		},
-		{
-			"id":            utils.GenerateID(),
-			"title":         "Üretim Performans Raporu",
-			"type":          "production",
-			"description":   "Çeyreklik üretim performansı ve verimlilik analizi",
-			"generatedDate": time.Now().AddDate(0, 0, -7).Format("2006-01-02T15:04:05Z"),
-			"period":        "Q1-2024",
-			"format":        "excel",
-			"downloadUrl":   "/api/v1/reports/download/report-002.xlsx",
-		},
-		{
-			"id":            utils.GenerateID(),
-			"title":         "Hayvancılık Sağlık Raporu",
-			"type":          "livestock",
-			"description":   "Hayvan sağlığı ve aşılama durumu raporu",
-			"generatedDate": time.Now().AddDate(0, 0, -14).Format("2006-01-02T15:04:05Z"),
-			"period":        "2024-01",
-			"format":        "pdf",
-			"downloadUrl":   "/api/v1/reports/download/report-003.pdf",
-		},
-		{
-			"id":            utils.GenerateID(),
-			"title":         "Arazi Kullanım Raporu",
-			"type":          "land",
-			"description":   "Arazi kullanımı ve verimlilik analizi",
-			"generatedDate": time.Now().AddDate(0, 0, -21).Format("2006-01-02T15:04:05Z"),
-			"period":        "2023",
-			"format":        "csv",
-			"downloadUrl":   "/api/v1/reports/download/report-004.csv",
-		},
+	reportType := c.Query("type")
+	if reportType == "all" {
+		reportType = ""
+	}
+	if reportType != "" && !services.IsValidReportType(reportType) {
+		utils.ErrorResponse(c, http.StatusBadRequest, "INVALID_REPORT_TYPE", "Geçersiz rapor türü", services.ReportTypes())
+		return
+	}
+	period := c.Query("period")
+	if period == "all" {
+		period = ""
 	}
 
-	// Filtreleme
-	var filteredReports []map[string]interface{}
-	for _, report := range reports {
-		if reportType != "all" && report["type"] != reportType {
-			continue
-		}
-		if period != "all" && report["period"] != period {
-			continue
-		}
-		filteredReports = append(filteredReports, report)
+	page, limit := utils.ParsePagination(c)
+	reports, total, err := h.reports.Reports(userID, reportType, period, page, limit)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusInternalServerError, "DB_ERROR", "Raporlar alınamadı", err.Error())
+		return
 	}
 
-	utils.SuccessResponse(c, filteredReports, "Raporlar başarıyla getirildi")
+	utils.SuccessResponse(c, models.ReportListResponse{
+		Reports:    reports,
+		Pagination: utils.CalculatePagination(page, limit, total),
+	}, "Raporlar başarıyla getirildi")
 }
 
 // GenerateReport rapor oluşturma
 // @Summary Rapor oluşturma
-// @Description Yeni rapor oluşturur
+// @Description Seçilen dönem için finansal (işlemler), üretim, hayvancılık veya arazi raporunun PDF, XLSX veya CSV olarak oluşturulmasını arka plan işi olarak sıraya alır ve iş kimliğini hemen döner; iş durumu /reports/jobs/{id} ile izlenir ve rapor hazır olunca bildirim gönderilir. Dönem period (month, quarter, year veya mali takvimde tanımlı dönem kodu) ile seçilir; startDate ve endDate verilirse onlar kullanılır. Kategoriler finansal raporda işlem kategorisine, üretim raporunda ürün kategorisine, hayvancılık raporunda hayvan türüne göre filtreler. Tarih, saat ve sayılar çiftlik ayarlarındaki dateFormat, timeFormat, decimalSeparator, thousandsSeparator ve currencySymbol ile yazılır
+// @ID generateReport
 // @Tags Reports
 // @Accept json
 // @Produce json
 // @Security BearerAuth
-// @Param request body map[string]interface{} true "Rapor parametreleri"
-// @Success 201 {object} models.APIResponse{data=map[string]interface{}}
+// @Param request body models.ReportRequest true "Rapor parametreleri"
+// @Success 202 {object} models.APIResponse{data=models.ReportJob}
 // @Failure 400 {object} models.APIResponse
 // @Failure 401 {object} models.APIResponse
 // @Router /reports/generate [post]
 func (h *ReportsHandler) GenerateReport(c *gin.Context) {
-	_, err := utils.GetUserID(c)
+	userID, err := utils.GetUserID(c)
 	if err != nil {
 		utils.ErrorResponse(c, http.StatusUnauthorized, "UNAUTHORIZED", "Kullanıcı kimliği doğrulanamadı", nil)
 		return
 	}
 
-	var req struct {
-		Type          string   `json:"type"`
-		Period        string   `json:"period"`
-		StartDate     string   `json:"startDate"`
-		EndDate       string   `json:"endDate"`
-		Format        string   `json:"format"`
-		IncludeCharts bool     `json:"includeCharts"`
-		Categories    []string `json:"categories"`
-	}
-
+	var req models.ReportRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
 		utils.ErrorResponse(c, http.StatusBadRequest, "INVALID_REQUEST", "Geçersiz istek formatı", err.Error())
 		return
@@ -142,46 +116,84 @@ func (h *ReportsHandler) GenerateReport(c *gin.Context) {
 		return
 	}
 
-	// Rapor oluşturma işlemi simülasyonu
-	reportID := utils.GenerateID()
-
-	// Gerçek uygulamada burada:
-	// 1. Seçili verileri DB'den çek
-	// 2. Raporu oluştur (PDF, Excel, CSV)
-	// 3. Dosyayı storage'a kaydet
-	// 4. Download URL'i oluştur
-
-	report := map[string]interface{}{
-		"id":            reportID,
-		"title":         h.getReportTitle(req.Type, req.Period),
-		"type":          req.Type,
-		"description":   h.getReportDescription(req.Type),
-		"generatedDate": time.Now().Format("2006-01-02T15:04:05Z"),
-		"period":        req.Period,
-		"format":        req.Format,
-		"status":        "completed",
-		"downloadUrl":   "/api/v1/reports/" + reportID + "/download",
-		"parameters": map[string]interface{}{
-			"startDate":     req.StartDate,
-			"endDate":       req.EndDate,
-			"includeCharts": req.IncludeCharts,
-			"categories":    req.Categories,
-		},
+	startDate, endDate, ok := h.reportDateRange(c, req)
+	if !ok {
+		return
+	}
+
+	job, err := h.reports.Enqueue(userID, req, startDate, endDate)
+	if err != nil {
+		switch {
+		case errors.Is(err, services.ErrInvalidReportType):
+			utils.ErrorResponse(c, http.StatusBadRequest, "INVALID_REPORT_TYPE", "Geçersiz rapor türü", services.ReportTypes())
+		case errors.Is(err, services.ErrInvalidReportFormat):
+			utils.ErrorResponse(c, http.StatusBadRequest, "INVALID_FORMAT", "Geçersiz dosya formatı", services.ReportFormats())
+		default:
+			utils.ErrorResponse(c, http.StatusInternalServerError, "DB_ERROR", "Rapor işi oluşturulamadı", err.Error())
+		}
+		return
+	}
+
+	utils.SuccessResponseWithStatus(c, http.StatusAccepted, models.ReportJob{Job: job}, "Rapor oluşturma sıraya alındı")
+}
+
+// GetReportJob rapor işi durumu
+// @Summary Rapor işi durumu
+// @Description Rapor oluşturma işinin durumunu (queued, running, completed, failed) getirir; iş tamamlandıysa oluşturulan rapor indirme adresiyle report alanında, başarısız olduysa hata error alanında döner
+// @ID getReportJob
+// @Tags Reports
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "İş ID"
+// @Success 200 {object} models.APIResponse{data=models.ReportJob}
+// @Failure 401 {object} models.APIResponse
+// @Failure 404 {object} models.APIResponse
+// @Router /reports/jobs/{id} [get]
+func (h *ReportsHandler) GetReportJob(c *gin.Context) {
+	userID, err := utils.GetUserID(c)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusUnauthorized, "UNAUTHORIZED", "Kullanıcı kimliği doğrulanamadı", nil)
+		return
+	}
+
+	job, err := h.jobs.Job(userID, c.Param("id"))
+	if err == nil && job.Type != models.JobTypeReport {
+		err = services.ErrJobNotFound
+	}
+	if errors.Is(err, services.ErrJobNotFound) {
+		utils.ErrorResponse(c, http.StatusNotFound, "JOB_NOT_FOUND", "Rapor işi bulunamadı", nil)
+		return
+	}
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusInternalServerError, "DB_ERROR", "Rapor işi alınamadı", err.Error())
+		return
+	}
+
+	reportJob := models.ReportJob{Job: job}
+	if job.Status == models.JobStatusCompleted && job.ResultID != nil {
+		report, err := h.reports.Report(userID, *job.ResultID)
+		if err != nil && !errors.Is(err, services.ErrReportNotFound) {
+			utils.ErrorResponse(c, http.StatusInternalServerError, "DB_ERROR", "Rapor alınamadı", err.Error())
+			return
+		}
+		if err == nil {
+			reportJob.Report = &report
+		}
 	}
 
-	c.JSON(http.StatusCreated, models.APIResponse{
-		Success: true,
-		Data:    report,
-		Message: "Rapor başarıyla oluşturuldu",
-	})
+	utils.SuccessResponse(c, reportJob, "Rapor işi başarıyla getirildi")
 }
 
 // DownloadReport rapor indirme
 // @Summary Rapor indirme
-// @Description Belirli bir raporu indirir
+// @Description Oluşturulmuş rapor dosyasını formatına uygun içerik türüyle (application/pdf, XLSX veya text/csv) indirir
+// @ID downloadReport
 // @Tags Reports
 // @Accept json
-// @Produce application/octet-stream
+// @Produce application/pdf
+// @Produce application/vnd.openxmlformats-officedocument.spreadsheetml.sheet
+// @Produce text/csv
 // @Security BearerAuth
 // @Param id path string true "Rapor ID"
 // @Success 200 {file} binary
@@ -189,7 +201,7 @@ func (h *ReportsHandler) GenerateReport(c *gin.Context) {
 // @Failure 404 {object} models.APIResponse
 // @Router /reports/{id}/download [get]
 func (h *ReportsHandler) DownloadReport(c *gin.Context) {
-	_, err := utils.GetUserID(c)
+	userID, err := utils.GetUserID(c)
 	if err != nil {
 		utils.ErrorResponse(c, http.StatusUnauthorized, "UNAUTHORIZED", "Kullanıcı kimliği doğrulanamadı", nil)
 		return
@@ -201,22 +213,31 @@ func (h *ReportsHandler) DownloadReport(c *gin.Context) {
 		return
 	}
 
-	// Gerçek uygulamada dosya storage'dan alınacak
-	// Şimdilik mock response
-	c.Header("Content-Type", "application/pdf")
-	c.Header("Content-Disposition", "attachment; filename=rapor-"+reportID+".pdf")
-	c.Data(http.StatusOK, "application/pdf", []byte("Mock PDF content"))
+	file, format, err := h.reports.Open(userID, reportID)
+	if err != nil {
+		if errors.Is(err, services.ErrReportNotFound) {
+			utils.ErrorResponse(c, http.StatusNotFound, "REPORT_NOT_FOUND", "Rapor bulunamadı", nil)
+			return
+		}
+		utils.ErrorResponse(c, http.StatusInternalServerError, "STORAGE_ERROR", "Rapor dosyası okunamadı", err.Error())
+		return
+	}
+	defer file.Close()
+
+	c.Header("Content-Disposition", "attachment; filename=rapor-"+reportID+"."+format)
+	c.DataFromReader(http.StatusOK, -1, services.ReportContentType(format), file, nil)
 }
 
 // GetPerformanceMetrics performans metrikleri
 // @Summary Performans metrikleri
 // @Description Performans metriklerini getirir
+// @ID getPerformanceMetrics
 // @Tags Reports
 // @Accept json
 // @Produce json
 // @Security BearerAuth
 // @Param period query string false "Periyot"
-// @Success 200 {object} models.APIResponse{data=map[string]interface{}}
+// @Success 200 {object} models.APIResponse{data=models.PerformanceMetrics}
 // @Failure 401 {object} models.APIResponse
 // @Router /reports/performance-metrics [get]
 func (h *ReportsHandler) GetPerformanceMetrics(c *gin.Context) {
@@ -226,6 +247,10 @@ func (h *ReportsHandler) GetPerformanceMetrics(c *gin.Context) {
 		return
 	}
 
+	if !h.useMockData(c, userID) {
+		return
+	}
+
 	_ = c.DefaultQuery("period", "month")
 
 	// Performans metriklerini hesapla
@@ -234,36 +259,16 @@ func (h *ReportsHandler) GetPerformanceMetrics(c *gin.Context) {
 	profitability := h.calculateProfitability(userID)
 	sustainability := h.calculateSustainability(userID)
 
-	metrics := map[string]interface{}{
-		"efficiency":     efficiency,
-		"productivity":   productivity,
-		"profitability":  profitability,
-		"sustainability": sustainability,
-		"trends": []map[string]interface{}{
-			{
-				"metric": "efficiency",
-				"value":  efficiency,
-				"change": 5.2,
-				"trend":  "up",
-			},
-			{
-				"metric": "productivity",
-				"value":  productivity,
-				"change": -2.1,
-				"trend":  "down",
-			},
-			{
-				"metric": "profitability",
-				"value":  profitability,
-				"change": 8.7,
-				"trend":  "up",
-			},
-			{
-				"metric": "sustainability",
-				"value":  sustainability,
-				"change": 3.4,
-				"trend":  "up",
-			},
+	metrics := models.PerformanceMetrics{
+		Efficiency:     efficiency,
+		Productivity:   productivity,
+		Profitability:  profitability,
+		Sustainability: sustainability,
+		Trends: []models.MetricTrend{
+			{Metric: "efficiency", Value: efficiency, Change: 5.2, Trend: "up"},
+			{Metric: "productivity", Value: productivity, Change: -2.1, Trend: "down"},
+			{Metric: "profitability", Value: profitability, Change: 8.7, Trend: "up"},
+			{Metric: "sustainability", Value: sustainability, Change: 3.4, Trend: "up"},
 		},
 	}
 
@@ -273,6 +278,7 @@ func (h *ReportsHandler) GetPerformanceMetrics(c *gin.Context) {
 // GetComparisonAnalysis karşılaştırma analizi
 // @Summary Karşılaştırma analizi
 // @Description İki periyot arasında karşılaştırma analizi yapar
+// @ID getComparisonAnalysis
 // @Tags Reports
 // @Accept json
 // @Produce json
@@ -280,17 +286,21 @@ func (h *ReportsHandler) GetPerformanceMetrics(c *gin.Context) {
 // @Param period1 query string true "İlk periyot"
 // @Param period2 query string true "İkinci periyot"
 // @Param metrics query string false "Karşılaştırılacak metrikler (virgülle ayrılmış)"
-// @Success 200 {object} models.APIResponse{data=map[string]interface{}}
+// @Success 200 {object} models.APIResponse{data=models.ComparisonAnalysis}
 // @Failure 400 {object} models.APIResponse
 // @Failure 401 {object} models.APIResponse
 // @Router /reports/comparison [get]
 func (h *ReportsHandler) GetComparisonAnalysis(c *gin.Context) {
-	_, err := utils.GetUserID(c)
+	userID, err := utils.GetUserID(c)
 	if err != nil {
 		utils.ErrorResponse(c, http.StatusUnauthorized, "UNAUTHORIZED", "Kullanıcı kimliği doğrulanamadı", nil)
 		return
 	}
 
+	if !h.useMockData(c, userID) {
+		return
+	}
+
 	period1 := c.Query("period1")
 	period2 := c.Query("period2")
 	_ = c.DefaultQuery("metrics", "income,expense,profit,production")
@@ -301,73 +311,114 @@ func (h *ReportsHandler) GetComparisonAnalysis(c *gin.Context) {
 	}
 
 	// Karşılaştırma analizi (mock data)
-	comparison := map[string]interface{}{
-		"period1": period1,
-		"period2": period2,
-		"metrics": map[string]interface{}{
-			"income": map[string]interface{}{
-				"period1": 125000,
-				"period2": 142000,
-				"change":  13.6,
-				"trend":   "up",
-			},
-			"expense": map[string]interface{}{
-				"period1": 89000,
-				"period2": 95000,
-				"change":  6.7,
-				"trend":   "up",
-			},
-			"profit": map[string]interface{}{
-				"period1": 36000,
-				"period2": 47000,
-				"change":  30.6,
-				"trend":   "up",
-			},
-			"production": map[string]interface{}{
-				"period1": 2500,
-				"period2": 2750,
-				"change":  10.0,
-				"trend":   "up",
-			},
+	comparison := models.ComparisonAnalysis{
+		Period1: period1,
+		Period2: period2,
+		Metrics: models.ComparisonMetrics{
+			Income:     models.MetricComparison{Period1: 125000, Period2: 142000, Change: 13.6, Trend: "up"},
+			Expense:    models.MetricComparison{Period1: 89000, Period2: 95000, Change: 6.7, Trend: "up"},
+			Profit:     models.MetricComparison{Period1: 36000, Period2: 47000, Change: 30.6, Trend: "up"},
+			Production: models.MetricComparison{Period1: 2500, Period2: 2750, Change: 10.0, Trend: "up"},
 		},
-		"summary": map[string]interface{}{
-			"overallTrend":   "positive",
-			"keyImprovement": "Kar artışı %30.6",
-			"areaForFocus":   "Gider kontrolü",
+		Summary: models.ComparisonSummary{
+			OverallTrend:   "positive",
+			KeyImprovement: "Kar artışı %30.6",
+			AreaForFocus:   "Gider kontrolü",
 		},
 	}
 
 	utils.SuccessResponse(c, comparison, "Karşılaştırma analizi başarıyla getirildi")
 }
 
-// Helper functions
+// GetFarmComparison çiftlik karşılaştırması
+// @Summary Çiftlik karşılaştırması
+// @Description Hesaba bağlı çiftliklerin mali takvime göre seçilen dönemdeki üretim, gelir-gider ve kârlılık metriklerini karşılaştırır; startDate ve endDate verilirse period yerine kullanılır
+// @ID getFarmComparison
+// @Tags Reports
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param period query string false "Periyot (month, quarter, year veya mali takvimde tanımlı dönem kodu)" default(year)
+// @Param startDate query string false "Başlangıç tarihi (YYYY-MM-DD)"
+// @Param endDate query string false "Bitiş tarihi (YYYY-MM-DD)"
+// @Success 200 {object} models.APIResponse{data=models.FarmComparison}
+// @Failure 400 {object} models.APIResponse
+// @Failure 401 {object} models.APIResponse
+// @Router /reports/farm-comparison [get]
+func (h *ReportsHandler) GetFarmComparison(c *gin.Context) {
+	accountID, err := utils.GetAccountID(c)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusUnauthorized, "UNAUTHORIZED", "Kullanıcı kimliği doğrulanamadı", nil)
+		return
+	}
+
+	startDate, endDate, ok := analysisDateRange(c, h.farms, services.PeriodYear)
+	if !ok {
+		return
+	}
 
-func (h *ReportsHandler) getReportTitle(reportType, period string) string {
-	titles := map[string]string{
-		"financial":  "Finansal Rapor",
-		"production": "Üretim Raporu",
-		"livestock":  "Hayvancılık Raporu",
-		"land":       "Arazi Raporu",
+	if err := h.farms.EnsureDefaultFarm(accountID); err != nil {
+		utils.ErrorResponse(c, http.StatusInternalServerError, "DB_ERROR", "Çiftlikler getirilemedi", err.Error())
+		return
 	}
 
-	if title, exists := titles[reportType]; exists {
-		return title + " - " + period
+	comparison, err := h.farms.Compare(accountID, startDate, endDate)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusInternalServerError, "DB_ERROR", "Çiftlik karşılaştırması hesaplanamadı", err.Error())
+		return
 	}
-	return "Genel Rapor - " + period
+
+	utils.SuccessResponse(c, comparison, "Çiftlik karşılaştırması başarıyla getirildi")
 }
 
-func (h *ReportsHandler) getReportDescription(reportType string) string {
-	descriptions := map[string]string{
-		"financial":  "Gelir, gider ve karlılık analizi",
-		"production": "Üretim performansı ve verimlilik analizi",
-		"livestock":  "Hayvan sağlığı ve üretkenlik raporu",
-		"land":       "Arazi kullanımı ve verimlilik analizi",
+// Helper functions
+
+// useMockData mock_reports bayrağını kontrol eder; kapalıysa 501 yanıtı yazar
+func (h *ReportsHandler) useMockData(c *gin.Context, userID string) bool {
+	flag, exists := h.flags.Get(services.FlagMockReports, userID)
+	if !exists || !flag.Enabled {
+		utils.ErrorResponse(c, http.StatusNotImplemented, "FEATURE_DISABLED", "Rapor özelliği bu ortamda kullanılamıyor", services.FlagMockReports)
+		return false
 	}
 
-	if desc, exists := descriptions[reportType]; exists {
-		return desc
+	c.Header("X-Mock-Data", "true")
+	if flag.Deprecated {
+		utils.MarkDeprecated(c, flag.DeprecationNote)
 	}
-	return "Genel analiz raporu"
+	return true
+}
+
+// reportDateRange rapor dönemini period alanından mali takvime göre çözer; startDate ve endDate verilirse onları kullanır
+func (h *ReportsHandler) reportDateRange(c *gin.Context, req models.ReportRequest) (time.Time, time.Time, bool) {
+	period := req.Period
+	if period == "" || req.StartDate != "" || req.EndDate != "" {
+		period = services.PeriodMonth
+	}
+	periodRange, ok := fiscalPeriodRange(c, h.farms, period, 1)
+	if !ok {
+		return time.Time{}, time.Time{}, false
+	}
+	startDate := periodRange.StartDate
+	endDate := periodRange.EndDate.AddDate(0, 0, -1)
+
+	var err error
+	if req.StartDate != "" {
+		if startDate, err = time.Parse("2006-01-02", req.StartDate); err != nil {
+			utils.ErrorResponse(c, http.StatusBadRequest, "INVALID_DATE", "Geçersiz başlangıç tarihi", nil)
+			return startDate, endDate, false
+		}
+	}
+	if req.EndDate != "" {
+		if endDate, err = time.Parse("2006-01-02", req.EndDate); err != nil {
+			utils.ErrorResponse(c, http.StatusBadRequest, "INVALID_DATE", "Geçersiz bitiş tarihi", nil)
+			return startDate, endDate, false
+		}
+	}
+	if endDate.Before(startDate) {
+		utils.ErrorResponse(c, http.StatusBadRequest, "INVALID_DATE_RANGE", "Bitiş tarihi başlangıç tarihinden önce olamaz", nil)
+		return startDate, endDate, false
+	}
+	return startDate, endDate, true
 }
 
 func (h *ReportsHandler) calculateEfficiency(userID string) float64 {
@@ -387,6 +438,10 @@ func (h *ReportsHandler) calculateProfitability(userID string) float64 {
 }
 
 func (h *ReportsHandler) calculateSustainability(userID string) float64 {
-	// Sürdürülebilirlik hesaplama algoritması
-	return 81.2
+	// Sürdürülebilirlik puanı son 12 ayın hektar başına karbon ayak izinden hesaplanır
+	score, err := h.carbon.SustainabilityScore(userID)
+	if err != nil {
+		return 0
+	}
+	return score
 }
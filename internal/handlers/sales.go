@@ -0,0 +1,425 @@
+package handlers
+
+import (
+	"database/sql"
+	"errors"
+	"net/http"
+
+	"agri-management-api/internal/models"
+	"agri-management-api/internal/services"
+	"agri-management-api/internal/utils"
+
+	"github.com/gin-gonic/gin"
+)
+
+// SalesHandler müşterileri ve satış siparişlerini yönetir
+type SalesHandler struct {
+	sales *services.SalesService
+}
+
+// NewSalesHandler yeni sales handler oluşturur
+func NewSalesHandler(db *sql.DB) *SalesHandler {
+	return &SalesHandler{sales: services.NewSalesService(db)}
+}
+
+// GetCustomers müşteri listesi
+// @Summary Müşteriler
+// @Description Müşterileri ada göre, sipariş sayısı, toplam satış ve ödenmemiş tutarla listeler; iptal edilen siparişler özetlere dahil edilmez
+// @ID getCustomers
+// @Tags Sales
+// @Produce json
+// @Security BearerAuth
+// @Param search query string false "Ad, telefon veya e-postada arama"
+// @Success 200 {object} models.APIResponse{data=[]models.Customer}
+// @Failure 401 {object} models.APIResponse
+// @Router /sales/customers [get]
+func (h *SalesHandler) GetCustomers(c *gin.Context) {
+	userID, err := utils.GetUserID(c)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusUnauthorized, "UNAUTHORIZED", "Kullanıcı kimliği doğrulanamadı", nil)
+		return
+	}
+
+	customers, err := h.sales.Customers(userID, c.Query("search"))
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusInternalServerError, "DB_ERROR", "Müşteriler alınamadı", err.Error())
+		return
+	}
+
+	utils.SuccessResponse(c, customers, "Müşteriler başarıyla getirildi")
+}
+
+// GetCustomer müşteri detayı
+// @Summary Müşteri detayı
+// @ID getCustomer
+// @Tags Sales
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Müşteri ID"
+// @Success 200 {object} models.APIResponse{data=models.Customer}
+// @Failure 401 {object} models.APIResponse
+// @Failure 404 {object} models.APIResponse
+// @Router /sales/customers/{id} [get]
+func (h *SalesHandler) GetCustomer(c *gin.Context) {
+	userID, err := utils.GetUserID(c)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusUnauthorized, "UNAUTHORIZED", "Kullanıcı kimliği doğrulanamadı", nil)
+		return
+	}
+
+	customer, err := h.sales.Customer(userID, c.Param("id"))
+	if err != nil {
+		writeSalesError(c, err, "Müşteri alınamadı")
+		return
+	}
+
+	utils.SuccessResponse(c, customer, "Müşteri başarıyla getirildi")
+}
+
+// CreateCustomer müşteri ekleme
+// @Summary Müşteri ekle
+// @Description Müşteri ekler; type individual (şahıs, varsayılan) veya company (şirket) olabilir. Vergi/kimlik numarası ve adres alan şifreleme açıksa şifrelenerek saklanır
+// @ID createCustomer
+// @Tags Sales
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param request body models.CustomerRequest true "Müşteri bilgileri"
+// @Success 201 {object} models.APIResponse{data=models.Customer}
+// @Failure 400 {object} models.APIResponse
+// @Failure 401 {object} models.APIResponse
+// @Router /sales/customers [post]
+func (h *SalesHandler) CreateCustomer(c *gin.Context) {
+	userID, err := utils.GetUserID(c)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusUnauthorized, "UNAUTHORIZED", "Kullanıcı kimliği doğrulanamadı", nil)
+		return
+	}
+
+	var req models.CustomerRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		utils.ErrorResponse(c, http.StatusBadRequest, "INVALID_REQUEST", "Geçersiz istek formatı", err.Error())
+		return
+	}
+
+	customer, err := h.sales.CreateCustomer(userID, req)
+	if err != nil {
+		writeSalesError(c, err, "Müşteri eklenemedi")
+		return
+	}
+
+	utils.CreatedResponse(c, customer, "Müşteri başarıyla eklendi")
+}
+
+// UpdateCustomer müşteri güncelleme
+// @Summary Müşteriyi güncelle
+// @ID updateCustomer
+// @Tags Sales
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Müşteri ID"
+// @Param request body models.CustomerRequest true "Müşteri bilgileri"
+// @Success 200 {object} models.APIResponse{data=models.Customer}
+// @Failure 400 {object} models.APIResponse
+// @Failure 401 {object} models.APIResponse
+// @Failure 404 {object} models.APIResponse
+// @Router /sales/customers/{id} [put]
+func (h *SalesHandler) UpdateCustomer(c *gin.Context) {
+	userID, err := utils.GetUserID(c)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusUnauthorized, "UNAUTHORIZED", "Kullanıcı kimliği doğrulanamadı", nil)
+		return
+	}
+
+	var req models.CustomerRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		utils.ErrorResponse(c, http.StatusBadRequest, "INVALID_REQUEST", "Geçersiz istek formatı", err.Error())
+		return
+	}
+
+	customer, err := h.sales.UpdateCustomer(userID, c.Param("id"), req)
+	if err != nil {
+		writeSalesError(c, err, "Müşteri güncellenemedi")
+		return
+	}
+
+	utils.SuccessResponse(c, customer, "Müşteri başarıyla güncellendi")
+}
+
+// DeleteCustomer müşteri silme
+// @Summary Müşteriyi sil
+// @Description Müşteriyi siler; siparişi olan müşteri silinemez
+// @ID deleteCustomer
+// @Tags Sales
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Müşteri ID"
+// @Success 200 {object} models.APIResponse
+// @Failure 401 {object} models.APIResponse
+// @Failure 404 {object} models.APIResponse
+// @Failure 409 {object} models.APIResponse
+// @Router /sales/customers/{id} [delete]
+func (h *SalesHandler) DeleteCustomer(c *gin.Context) {
+	userID, err := utils.GetUserID(c)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusUnauthorized, "UNAUTHORIZED", "Kullanıcı kimliği doğrulanamadı", nil)
+		return
+	}
+
+	if err := h.sales.DeleteCustomer(userID, c.Param("id")); err != nil {
+		writeSalesError(c, err, "Müşteri silinemedi")
+		return
+	}
+
+	utils.SuccessResponse(c, nil, "Müşteri başarıyla silindi")
+}
+
+// GetSalesOrders sipariş listesi
+// @Summary Satış siparişleri
+// @Description Siparişleri müşteri ve ürün adıyla, sipariş tarihine göre yeniden eskiye sayfalı listeler
+// @ID getSalesOrders
+// @Tags Sales
+// @Produce json
+// @Security BearerAuth
+// @Param customerId query string false "Müşteri ID"
+// @Param productionId query string false "Üretim partisi ID"
+// @Param paymentStatus query string false "Ödeme durumu (pending, paid, cancelled)"
+// @Param startDate query string false "Başlangıç tarihi (YYYY-MM-DD)"
+// @Param endDate query string false "Bitiş tarihi (YYYY-MM-DD)"
+// @Param page query int false "Sayfa numarası"
+// @Param limit query int false "Sayfa başına kayıt"
+// @Success 200 {object} models.APIResponse{data=models.SalesOrderListResponse}
+// @Failure 400 {object} models.APIResponse
+// @Failure 401 {object} models.APIResponse
+// @Router /sales/orders [get]
+func (h *SalesHandler) GetSalesOrders(c *gin.Context) {
+	userID, err := utils.GetUserID(c)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusUnauthorized, "UNAUTHORIZED", "Kullanıcı kimliği doğrulanamadı", nil)
+		return
+	}
+
+	startDate, endDate, ok := dateRangeQuery(c)
+	if !ok {
+		return
+	}
+	filter := models.SalesOrderFilter{
+		CustomerID:    c.Query("customerId"),
+		ProductionID:  c.Query("productionId"),
+		PaymentStatus: c.Query("paymentStatus"),
+		StartDate:     startDate,
+		EndDate:       endDate,
+	}
+	switch filter.PaymentStatus {
+	case "", models.SalesOrderPending, models.SalesOrderPaid, models.SalesOrderCancelled:
+	default:
+		utils.ErrorResponse(c, http.StatusBadRequest, "INVALID_STATUS", "Ödeme durumu pending, paid veya cancelled olmalı", nil)
+		return
+	}
+	filter.Page, filter.Limit = utils.ParsePagination(c)
+
+	orders, err := h.sales.Orders(userID, filter)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusInternalServerError, "DB_ERROR", "Siparişler alınamadı", err.Error())
+		return
+	}
+
+	utils.SuccessResponse(c, orders, "Siparişler başarıyla getirildi")
+}
+
+// GetSalesOrder sipariş detayı
+// @Summary Satış siparişi detayı
+// @ID getSalesOrder
+// @Tags Sales
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Sipariş ID"
+// @Success 200 {object} models.APIResponse{data=models.SalesOrder}
+// @Failure 401 {object} models.APIResponse
+// @Failure 404 {object} models.APIResponse
+// @Router /sales/orders/{id} [get]
+func (h *SalesHandler) GetSalesOrder(c *gin.Context) {
+	userID, err := utils.GetUserID(c)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusUnauthorized, "UNAUTHORIZED", "Kullanıcı kimliği doğrulanamadı", nil)
+		return
+	}
+
+	order, err := h.sales.Order(userID, c.Param("id"))
+	if err != nil {
+		writeSalesError(c, err, "Sipariş alınamadı")
+		return
+	}
+
+	utils.SuccessResponse(c, order, "Sipariş başarıyla getirildi")
+}
+
+// CreateSalesOrder sipariş oluşturma
+// @Summary Satış siparişi oluştur
+// @Description Müşteriye üretim partisinden yapılan satışı ödenmemiş (pending) sipariş olarak kaydeder ve miktarı partinin stoğundan düşer; stok tükenirse parti satıldı olur. Sipariş numarası yıl bazında sıralıdır (SS-2024-0001). Gelir işlemi sipariş ödendi olarak işaretlenince oluşturulur
+// @ID createSalesOrder
+// @Tags Sales
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param request body models.SalesOrderRequest true "Sipariş bilgileri"
+// @Success 201 {object} models.APIResponse{data=models.SalesOrder}
+// @Failure 400 {object} models.APIResponse
+// @Failure 401 {object} models.APIResponse
+// @Failure 404 {object} models.APIResponse
+// @Failure 409 {object} models.APIResponse
+// @Router /sales/orders [post]
+func (h *SalesHandler) CreateSalesOrder(c *gin.Context) {
+	userID, err := utils.GetUserID(c)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusUnauthorized, "UNAUTHORIZED", "Kullanıcı kimliği doğrulanamadı", nil)
+		return
+	}
+
+	var req models.SalesOrderRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		utils.ErrorResponse(c, http.StatusBadRequest, "INVALID_REQUEST", "Geçersiz istek formatı", err.Error())
+		return
+	}
+
+	order, err := h.sales.CreateOrder(userID, req)
+	if err != nil {
+		writeSalesError(c, err, "Sipariş oluşturulamadı")
+		return
+	}
+
+	utils.CreatedResponse(c, order, "Sipariş başarıyla oluşturuldu")
+}
+
+// UpdateSalesOrder sipariş güncelleme
+// @Summary Satış siparişini güncelle
+// @Description Ödenmemiş siparişi günceller; eski miktar partinin stoğuna geri eklenip yeni miktar düşülür. Ödenmiş, iptal edilmiş veya faturası kesilmiş sipariş değiştirilemez
+// @ID updateSalesOrder
+// @Tags Sales
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Sipariş ID"
+// @Param request body models.SalesOrderRequest true "Sipariş bilgileri"
+// @Success 200 {object} models.APIResponse{data=models.SalesOrder}
+// @Failure 400 {object} models.APIResponse
+// @Failure 401 {object} models.APIResponse
+// @Failure 404 {object} models.APIResponse
+// @Failure 409 {object} models.APIResponse
+// @Router /sales/orders/{id} [put]
+func (h *SalesHandler) UpdateSalesOrder(c *gin.Context) {
+	userID, err := utils.GetUserID(c)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusUnauthorized, "UNAUTHORIZED", "Kullanıcı kimliği doğrulanamadı", nil)
+		return
+	}
+
+	var req models.SalesOrderRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		utils.ErrorResponse(c, http.StatusBadRequest, "INVALID_REQUEST", "Geçersiz istek formatı", err.Error())
+		return
+	}
+
+	order, err := h.sales.UpdateOrder(userID, c.Param("id"), req)
+	if err != nil {
+		writeSalesError(c, err, "Sipariş güncellenemedi")
+		return
+	}
+
+	utils.SuccessResponse(c, order, "Sipariş başarıyla güncellendi")
+}
+
+// UpdateSalesOrderStatus sipariş ödeme durumu
+// @Summary Siparişi ödendi veya iptal olarak işaretle
+// @Description paid siparişi ödendi olarak işaretler ve finans modülünde sipariş tutarında, ödeme tarihli (paidAt, varsayılan şimdi), "Ürün Satışı" kategorili ve fiş numarası sipariş numarası (faturası kesilmişse fatura numarası) olan tamamlanmış gelir işlemi oluşturur; işlemin kimliği siparişin transactionId alanında döner. cancelled siparişi iptal eder ve miktarı partinin stoğuna geri ekler; faturası kesilmiş sipariş iptal edilemez. Yalnızca ödenmemiş siparişlerin durumu değiştirilebilir
+// @ID updateSalesOrderStatus
+// @Tags Sales
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Sipariş ID"
+// @Param request body models.SalesOrderStatusRequest true "Ödeme durumu"
+// @Success 200 {object} models.APIResponse{data=models.SalesOrder}
+// @Failure 400 {object} models.APIResponse
+// @Failure 401 {object} models.APIResponse
+// @Failure 404 {object} models.APIResponse
+// @Failure 409 {object} models.APIResponse
+// @Router /sales/orders/{id}/status [patch]
+func (h *SalesHandler) UpdateSalesOrderStatus(c *gin.Context) {
+	userID, err := utils.GetUserID(c)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusUnauthorized, "UNAUTHORIZED", "Kullanıcı kimliği doğrulanamadı", nil)
+		return
+	}
+
+	var req models.SalesOrderStatusRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		utils.ErrorResponse(c, http.StatusBadRequest, "INVALID_REQUEST", "Geçersiz istek formatı", err.Error())
+		return
+	}
+
+	order, err := h.sales.UpdateOrderStatus(userID, c.Param("id"), req)
+	if err != nil {
+		writeSalesError(c, err, "Sipariş durumu güncellenemedi")
+		return
+	}
+
+	utils.SuccessResponse(c, order, "Sipariş durumu başarıyla güncellendi")
+}
+
+// DeleteSalesOrder sipariş silme
+// @Summary Satış siparişini sil
+// @Description Ödenmemiş veya iptal edilmiş siparişi siler; ödenmemiş siparişin miktarı partinin stoğuna geri eklenir. Gelir işlemi oluşturulmuş ödenmiş siparişler ve faturası kesilmiş siparişler silinemez
+// @ID deleteSalesOrder
+// @Tags Sales
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Sipariş ID"
+// @Success 200 {object} models.APIResponse
+// @Failure 401 {object} models.APIResponse
+// @Failure 404 {object} models.APIResponse
+// @Failure 409 {object} models.APIResponse
+// @Router /sales/orders/{id} [delete]
+func (h *SalesHandler) DeleteSalesOrder(c *gin.Context) {
+	userID, err := utils.GetUserID(c)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusUnauthorized, "UNAUTHORIZED", "Kullanıcı kimliği doğrulanamadı", nil)
+		return
+	}
+
+	if err := h.sales.DeleteOrder(userID, c.Param("id")); err != nil {
+		writeSalesError(c, err, "Sipariş silinemedi")
+		return
+	}
+
+	utils.SuccessResponse(c, nil, "Sipariş başarıyla silindi")
+}
+
+// writeSalesError servis hatasını HTTP yanıtına çevirir
+func writeSalesError(c *gin.Context, err error, message string) {
+	switch {
+	case errors.Is(err, services.ErrCustomerNotFound):
+		utils.ErrorResponse(c, http.StatusNotFound, "CUSTOMER_NOT_FOUND", "Müşteri bulunamadı", nil)
+	case errors.Is(err, services.ErrSalesOrderNotFound):
+		utils.ErrorResponse(c, http.StatusNotFound, "ORDER_NOT_FOUND", "Sipariş bulunamadı", nil)
+	case errors.Is(err, services.ErrSalesProductionNotFound):
+		utils.ErrorResponse(c, http.StatusNotFound, "PRODUCTION_NOT_FOUND", "Üretim bulunamadı", nil)
+	case errors.Is(err, services.ErrSalesInsufficientStock):
+		utils.ErrorResponse(c, http.StatusConflict, "INSUFFICIENT_STOCK", "Sipariş miktarı mevcut stoktan fazla", nil)
+	case errors.Is(err, services.ErrSalesOrderClosed):
+		utils.ErrorResponse(c, http.StatusConflict, "ORDER_CLOSED", "Ödenmiş veya iptal edilmiş sipariş değiştirilemez", nil)
+	case errors.Is(err, services.ErrSalesOrderPaid):
+		utils.ErrorResponse(c, http.StatusConflict, "ORDER_PAID", "Ödenmiş sipariş silinemez", nil)
+	case errors.Is(err, services.ErrSalesOrderInvoiced):
+		utils.ErrorResponse(c, http.StatusConflict, "ORDER_INVOICED", "Faturası kesilmiş sipariş değiştirilemez, iptal edilemez ve silinemez", nil)
+	case errors.Is(err, services.ErrCustomerHasOrders):
+		utils.ErrorResponse(c, http.StatusConflict, "CUSTOMER_HAS_ORDERS", "Siparişi olan müşteri silinemez", nil)
+	case errors.Is(err, services.ErrSalesInvalidDueDate):
+		utils.ErrorResponse(c, http.StatusBadRequest, "INVALID_DUE_DATE", err.Error(), nil)
+	case errors.Is(err, services.ErrFieldEncryptionDisabled), errors.Is(err, services.ErrFieldKeyUnavailable):
+		utils.ErrorResponse(c, http.StatusInternalServerError, "ENCRYPTION_ERROR", "Müşteri bilgileri şifrelenemedi", err.Error())
+	default:
+		utils.ErrorResponse(c, http.StatusInternalServerError, "DB_ERROR", message, err.Error())
+	}
+}
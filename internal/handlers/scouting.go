@@ -0,0 +1,381 @@
+package handlers
+
+import (
+	"database/sql"
+	"errors"
+	"net/http"
+
+	"agri-management-api/internal/models"
+	"agri-management-api/internal/services"
+	"agri-management-api/internal/utils"
+
+	"github.com/gin-gonic/gin"
+)
+
+// ScoutingHandler arazi keşif turlarını ve konumlu gözlem noktalarını yönetir
+type ScoutingHandler struct {
+	db       *sql.DB
+	scouting *services.ScoutingService
+}
+
+// NewScoutingHandler yeni scouting handler oluşturur
+func NewScoutingHandler(db *sql.DB) *ScoutingHandler {
+	return &ScoutingHandler{
+		db:       db,
+		scouting: services.NewScoutingService(db),
+	}
+}
+
+// GetLandScoutingSessions arazinin keşif turları
+// @Summary Arazinin keşif turları
+// @Description Arazide yapılan keşif turlarını en yeniden eskiye nokta sayısı ve en yüksek bulgu şiddetiyle listeler
+// @ID getLandScoutingSessions
+// @Tags Scouting
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Arazi ID"
+// @Success 200 {object} models.APIResponse{data=[]models.ScoutingSession}
+// @Failure 401 {object} models.APIResponse
+// @Failure 404 {object} models.APIResponse
+// @Router /lands/{id}/scouting [get]
+func (h *ScoutingHandler) GetLandScoutingSessions(c *gin.Context) {
+	userID, err := utils.GetUserID(c)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusUnauthorized, "UNAUTHORIZED", "Kullanıcı kimliği doğrulanamadı", nil)
+		return
+	}
+
+	sessions, err := h.scouting.List(userID, c.Param("id"))
+	if err != nil {
+		writeScoutingError(c, err, "Keşif turları alınamadı")
+		return
+	}
+
+	utils.SuccessResponse(c, sessions, "Keşif turları başarıyla getirildi")
+}
+
+// CreateScoutingSession keşif turu ekleme
+// @Summary Keşif turu ekle
+// @Description Araziye keşif turu ekler; points ile konumlu (enlem, boylam) gözlem noktaları şiddet (low, medium, high) ve notla birlikte aynı istekte kaydedilebilir. Noktalara fotoğraf /media/photos ucuyla entityType=scouting_point olarak eklenir
+// @ID createScoutingSession
+// @Tags Scouting
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Arazi ID"
+// @Param request body models.ScoutingSessionRequest true "Keşif turu bilgileri"
+// @Success 201 {object} models.APIResponse{data=models.ScoutingSession}
+// @Failure 400 {object} models.APIResponse
+// @Failure 401 {object} models.APIResponse
+// @Failure 404 {object} models.APIResponse
+// @Router /lands/{id}/scouting [post]
+func (h *ScoutingHandler) CreateScoutingSession(c *gin.Context) {
+	userID, err := utils.GetUserID(c)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusUnauthorized, "UNAUTHORIZED", "Kullanıcı kimliği doğrulanamadı", nil)
+		return
+	}
+
+	var req models.ScoutingSessionRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		utils.ErrorResponse(c, http.StatusBadRequest, "INVALID_REQUEST", "Geçersiz istek formatı", err.Error())
+		return
+	}
+
+	session, err := h.scouting.Create(userID, c.Param("id"), req)
+	if err != nil {
+		writeScoutingError(c, err, "Keşif turu eklenemedi")
+		return
+	}
+
+	utils.CreatedResponse(c, session, "Keşif turu başarıyla eklendi")
+}
+
+// GetLandScoutingMap keşif harita katmanı
+// @Summary Keşif harita katmanı
+// @Description Arazinin gözlem noktalarını GeoJSON FeatureCollection olarak döner. Noktalar [boylam, enlem] sırasında Point geometrisiyle; şiddet, not, keşif tarihi, fotoğraf kimlikleri ve dönüştürülen gözlem/görev kimlikleri özellik olarak yer alır. Arazinin sınırı kayıtlıysa kind=land_boundary olan Polygon eklenir ve noktaların sınır içinde olup olmadığı insideBoundary ile belirtilir
+// @ID getLandScoutingMap
+// @Tags Scouting
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Arazi ID"
+// @Param sessionId query string false "Keşif turu ID"
+// @Param startDate query string false "Başlangıç tarihi (YYYY-MM-DD)"
+// @Param endDate query string false "Bitiş tarihi (YYYY-MM-DD, dahil)"
+// @Success 200 {object} models.APIResponse{data=models.GeoFeatureCollection}
+// @Failure 400 {object} models.APIResponse
+// @Failure 401 {object} models.APIResponse
+// @Failure 404 {object} models.APIResponse
+// @Router /lands/{id}/scouting/map [get]
+func (h *ScoutingHandler) GetLandScoutingMap(c *gin.Context) {
+	userID, err := utils.GetUserID(c)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusUnauthorized, "UNAUTHORIZED", "Kullanıcı kimliği doğrulanamadı", nil)
+		return
+	}
+
+	startDate, endDate, ok := dateRangeQuery(c)
+	if !ok {
+		return
+	}
+
+	collection, err := h.scouting.Map(userID, c.Param("id"), c.Query("sessionId"), startDate, endDate)
+	if err != nil {
+		writeScoutingError(c, err, "Keşif haritası alınamadı")
+		return
+	}
+
+	utils.SuccessResponse(c, collection, "Keşif haritası başarıyla getirildi")
+}
+
+// GetScoutingSession keşif turu detayı
+// @Summary Keşif turu detayı
+// @Description Keşif turunu gözlem noktaları ve nokta fotoğraflarının kimlikleriyle getirir
+// @ID getScoutingSession
+// @Tags Scouting
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Keşif turu ID"
+// @Success 200 {object} models.APIResponse{data=models.ScoutingSession}
+// @Failure 401 {object} models.APIResponse
+// @Failure 404 {object} models.APIResponse
+// @Router /scouting/{id} [get]
+func (h *ScoutingHandler) GetScoutingSession(c *gin.Context) {
+	userID, err := utils.GetUserID(c)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusUnauthorized, "UNAUTHORIZED", "Kullanıcı kimliği doğrulanamadı", nil)
+		return
+	}
+
+	session, err := h.scouting.Get(userID, c.Param("id"))
+	if err != nil {
+		writeScoutingError(c, err, "Keşif turu alınamadı")
+		return
+	}
+
+	utils.SuccessResponse(c, session, "Keşif turu başarıyla getirildi")
+}
+
+// UpdateScoutingSession keşif turu güncelleme
+// @Summary Keşif turunu güncelle
+// @Description Keşif turunun tarihini, keşifçisini ve notlarını günceller; istekteki points yok sayılır, noktalar ayrı uçlarla yönetilir
+// @ID updateScoutingSession
+// @Tags Scouting
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Keşif turu ID"
+// @Param request body models.ScoutingSessionRequest true "Keşif turu bilgileri"
+// @Success 200 {object} models.APIResponse{data=models.ScoutingSession}
+// @Failure 400 {object} models.APIResponse
+// @Failure 401 {object} models.APIResponse
+// @Failure 404 {object} models.APIResponse
+// @Router /scouting/{id} [put]
+func (h *ScoutingHandler) UpdateScoutingSession(c *gin.Context) {
+	userID, err := utils.GetUserID(c)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusUnauthorized, "UNAUTHORIZED", "Kullanıcı kimliği doğrulanamadı", nil)
+		return
+	}
+
+	var req models.ScoutingSessionRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		utils.ErrorResponse(c, http.StatusBadRequest, "INVALID_REQUEST", "Geçersiz istek formatı", err.Error())
+		return
+	}
+
+	session, err := h.scouting.Update(userID, c.Param("id"), req)
+	if err != nil {
+		writeScoutingError(c, err, "Keşif turu güncellenemedi")
+		return
+	}
+
+	utils.SuccessResponse(c, session, "Keşif turu başarıyla güncellendi")
+}
+
+// DeleteScoutingSession keşif turu silme
+// @Summary Keşif turunu sil
+// @Description Keşif turunu ve gözlem noktalarını siler; noktalardan oluşturulan zararlı/hastalık gözlemleri ve görevler korunur
+// @ID deleteScoutingSession
+// @Tags Scouting
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Keşif turu ID"
+// @Success 200 {object} models.APIResponse
+// @Failure 401 {object} models.APIResponse
+// @Failure 404 {object} models.APIResponse
+// @Router /scouting/{id} [delete]
+func (h *ScoutingHandler) DeleteScoutingSession(c *gin.Context) {
+	userID, err := utils.GetUserID(c)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusUnauthorized, "UNAUTHORIZED", "Kullanıcı kimliği doğrulanamadı", nil)
+		return
+	}
+
+	if err := h.scouting.Delete(userID, c.Param("id")); err != nil {
+		writeScoutingError(c, err, "Keşif turu silinemedi")
+		return
+	}
+
+	utils.SuccessResponse(c, nil, "Keşif turu başarıyla silindi")
+}
+
+// CreateScoutingPoint gözlem noktası ekleme
+// @Summary Gözlem noktası ekle
+// @Description Keşif turuna konumlu gözlem noktası ekler
+// @ID createScoutingPoint
+// @Tags Scouting
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Keşif turu ID"
+// @Param request body models.ScoutingPointRequest true "Nokta bilgileri"
+// @Success 201 {object} models.APIResponse{data=models.ScoutingPoint}
+// @Failure 400 {object} models.APIResponse
+// @Failure 401 {object} models.APIResponse
+// @Failure 404 {object} models.APIResponse
+// @Router /scouting/{id}/points [post]
+func (h *ScoutingHandler) CreateScoutingPoint(c *gin.Context) {
+	userID, err := utils.GetUserID(c)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusUnauthorized, "UNAUTHORIZED", "Kullanıcı kimliği doğrulanamadı", nil)
+		return
+	}
+
+	var req models.ScoutingPointRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		utils.ErrorResponse(c, http.StatusBadRequest, "INVALID_REQUEST", "Geçersiz istek formatı", err.Error())
+		return
+	}
+
+	point, err := h.scouting.AddPoint(userID, c.Param("id"), req)
+	if err != nil {
+		writeScoutingError(c, err, "Gözlem noktası eklenemedi")
+		return
+	}
+
+	utils.CreatedResponse(c, point, "Gözlem noktası başarıyla eklendi")
+}
+
+// UpdateScoutingPoint gözlem noktası güncelleme
+// @Summary Gözlem noktasını güncelle
+// @Description Gözlem noktasının konumunu, şiddetini ve notunu günceller
+// @ID updateScoutingPoint
+// @Tags Scouting
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Keşif turu ID"
+// @Param pointId path string true "Nokta ID"
+// @Param request body models.ScoutingPointRequest true "Nokta bilgileri"
+// @Success 200 {object} models.APIResponse{data=models.ScoutingPoint}
+// @Failure 400 {object} models.APIResponse
+// @Failure 401 {object} models.APIResponse
+// @Failure 404 {object} models.APIResponse
+// @Router /scouting/{id}/points/{pointId} [put]
+func (h *ScoutingHandler) UpdateScoutingPoint(c *gin.Context) {
+	userID, err := utils.GetUserID(c)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusUnauthorized, "UNAUTHORIZED", "Kullanıcı kimliği doğrulanamadı", nil)
+		return
+	}
+
+	var req models.ScoutingPointRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		utils.ErrorResponse(c, http.StatusBadRequest, "INVALID_REQUEST", "Geçersiz istek formatı", err.Error())
+		return
+	}
+
+	point, err := h.scouting.UpdatePoint(userID, c.Param("id"), c.Param("pointId"), req)
+	if err != nil {
+		writeScoutingError(c, err, "Gözlem noktası güncellenemedi")
+		return
+	}
+
+	utils.SuccessResponse(c, point, "Gözlem noktası başarıyla güncellendi")
+}
+
+// DeleteScoutingPoint gözlem noktası silme
+// @Summary Gözlem noktasını sil
+// @Description Gözlem noktasını siler; noktadan oluşturulan gözlem ve görevler korunur
+// @ID deleteScoutingPoint
+// @Tags Scouting
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Keşif turu ID"
+// @Param pointId path string true "Nokta ID"
+// @Success 200 {object} models.APIResponse
+// @Failure 401 {object} models.APIResponse
+// @Failure 404 {object} models.APIResponse
+// @Router /scouting/{id}/points/{pointId} [delete]
+func (h *ScoutingHandler) DeleteScoutingPoint(c *gin.Context) {
+	userID, err := utils.GetUserID(c)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusUnauthorized, "UNAUTHORIZED", "Kullanıcı kimliği doğrulanamadı", nil)
+		return
+	}
+
+	if err := h.scouting.DeletePoint(userID, c.Param("id"), c.Param("pointId")); err != nil {
+		writeScoutingError(c, err, "Gözlem noktası silinemedi")
+		return
+	}
+
+	utils.SuccessResponse(c, nil, "Gözlem noktası başarıyla silindi")
+}
+
+// ConvertScoutingPoint gözlem noktasını dönüştürme
+// @Summary Gözlem noktasını dönüştür
+// @Description Gözlem noktasından target=issue ile zararlı/hastalık gözlemi (kaynak scouting, durum suspected, keşif tarihi ve noktanın ilk fotoğrafıyla) veya target=task ile planlanmış arazi görevi (tür verilmezse scouting, tarih verilmezse keşif tarihi) oluşturur ve kaydı noktaya bağlar. Nokta her türe bir kez dönüştürülebilir
+// @ID convertScoutingPoint
+// @Tags Scouting
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Keşif turu ID"
+// @Param pointId path string true "Nokta ID"
+// @Param request body models.ScoutingConvertRequest true "Dönüştürme bilgileri"
+// @Success 201 {object} models.APIResponse{data=models.ScoutingConversion}
+// @Failure 400 {object} models.APIResponse
+// @Failure 401 {object} models.APIResponse
+// @Failure 404 {object} models.APIResponse
+// @Failure 409 {object} models.APIResponse
+// @Router /scouting/{id}/points/{pointId}/convert [post]
+func (h *ScoutingHandler) ConvertScoutingPoint(c *gin.Context) {
+	userID, err := utils.GetUserID(c)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusUnauthorized, "UNAUTHORIZED", "Kullanıcı kimliği doğrulanamadı", nil)
+		return
+	}
+
+	var req models.ScoutingConvertRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		utils.ErrorResponse(c, http.StatusBadRequest, "INVALID_REQUEST", "Geçersiz istek formatı", err.Error())
+		return
+	}
+
+	conversion, err := h.scouting.Convert(userID, c.Param("id"), c.Param("pointId"), req)
+	if err != nil {
+		writeScoutingError(c, err, "Gözlem noktası dönüştürülemedi")
+		return
+	}
+
+	utils.CreatedResponse(c, conversion, "Gözlem noktası başarıyla dönüştürüldü")
+}
+
+// writeScoutingError servis hatasını HTTP yanıtına çevirir
+func writeScoutingError(c *gin.Context, err error, message string) {
+	switch {
+	case errors.Is(err, services.ErrScoutingLand):
+		utils.ErrorResponse(c, http.StatusNotFound, "LAND_NOT_FOUND", "Arazi bulunamadı", nil)
+	case errors.Is(err, services.ErrScoutingSessionNotFound):
+		utils.ErrorResponse(c, http.StatusNotFound, "SESSION_NOT_FOUND", "Keşif turu bulunamadı", nil)
+	case errors.Is(err, services.ErrScoutingPointNotFound):
+		utils.ErrorResponse(c, http.StatusNotFound, "POINT_NOT_FOUND", "Gözlem noktası bulunamadı", nil)
+	case errors.Is(err, services.ErrScoutingPointConverted):
+		utils.ErrorResponse(c, http.StatusConflict, "ALREADY_CONVERTED", "Gözlem noktası bu türde bir kayda zaten dönüştürülmüş", nil)
+	case errors.Is(err, services.ErrScoutingIssueName):
+		utils.ErrorResponse(c, http.StatusBadRequest, "VALIDATION_ERROR", "Zararlı/hastalık gözlemi için ad gerekli", nil)
+	default:
+		utils.ErrorResponse(c, http.StatusInternalServerError, "DB_ERROR", message, err.Error())
+	}
+}
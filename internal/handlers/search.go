@@ -0,0 +1,194 @@
+package handlers
+
+import (
+	"database/sql"
+	"net/http"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+
+	"agri-management-api/internal/models"
+	"agri-management-api/internal/utils"
+
+	"github.com/gin-gonic/gin"
+)
+
+// searchSource aranabilen kayıt türü; sorgu id, başlık ve aranan metni döner
+type searchSource struct {
+	entityType string
+	query      string
+	fields     int
+}
+
+// searchSources genel aramaya dahil edilen kayıtlar
+var searchSources = []searchSource{
+	{
+		entityType: "livestock",
+		query: `SELECT id, tag_number || ' (' || type || ')', COALESCE(breed, '') || ' ' || COALESCE(notes, '')
+			FROM livestock WHERE user_id = ? AND (tag_number LIKE ? ESCAPE '\' OR breed LIKE ? ESCAPE '\' OR notes LIKE ? ESCAPE '\')`,
+		fields: 3,
+	},
+	{
+		entityType: "land",
+		query: `SELECT id, name, COALESCE(crop, '') || ' ' || COALESCE(address, '')
+			FROM lands WHERE user_id = ? AND (name LIKE ? ESCAPE '\' OR crop LIKE ? ESCAPE '\' OR address LIKE ? ESCAPE '\')`,
+		fields: 3,
+	},
+	{
+		entityType: "land_activity",
+		query: `SELECT la.id, l.name || ' - ' || la.type, la.description || ' ' || COALESCE(la.notes, '')
+			FROM land_activities la JOIN lands l ON la.land_id = l.id
+			WHERE l.user_id = ? AND (la.description LIKE ? ESCAPE '\' OR la.notes LIKE ? ESCAPE '\')`,
+		fields: 2,
+	},
+	{
+		entityType: "production",
+		query: `SELECT id, name, category || ' ' || COALESCE(notes, '')
+			FROM production WHERE user_id = ? AND (name LIKE ? ESCAPE '\' OR notes LIKE ? ESCAPE '\')`,
+		fields: 2,
+	},
+	{
+		entityType: "transaction",
+		query: `SELECT id, description, category || ' ' || COALESCE(notes, '')
+			FROM transactions WHERE user_id = ? AND (description LIKE ? ESCAPE '\' OR notes LIKE ? ESCAPE '\')`,
+		fields: 2,
+	},
+	{
+		entityType: "event",
+		query: `SELECT id, title, COALESCE(description, '')
+			FROM events WHERE user_id = ? AND (title LIKE ? ESCAPE '\' OR description LIKE ? ESCAPE '\')`,
+		fields: 2,
+	},
+	{
+		entityType: "voice_note",
+		query: `SELECT id, filename, COALESCE(transcript, '')
+			FROM media_attachments WHERE user_id = ? AND kind = 'audio' AND transcript LIKE ? ESCAPE '\'`,
+		fields: 1,
+	},
+}
+
+// SearchHandler genel arama işlemlerini yönetir
+type SearchHandler struct {
+	db *sql.DB
+}
+
+// NewSearchHandler yeni search handler oluşturur
+func NewSearchHandler(db *sql.DB) *SearchHandler {
+	return &SearchHandler{db: db}
+}
+
+// Search genel arama
+// @Summary Genel arama
+// @Description Hayvanlar, araziler, aktiviteler, üretim, finans, etkinlikler ve ses notu transkriptlerinde arama yapar
+// @ID search
+// @Tags Search
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param q query string true "Arama metni (en az 2 karakter)"
+// @Param types query string false "Virgülle ayrılmış kayıt türleri (livestock, land, land_activity, production, transaction, event, voice_note)"
+// @Param limit query int false "Tür başına en fazla sonuç" default(10)
+// @Success 200 {object} models.APIResponse{data=[]models.SearchResult}
+// @Failure 400 {object} models.APIResponse
+// @Failure 401 {object} models.APIResponse
+// @Router /search [get]
+func (h *SearchHandler) Search(c *gin.Context) {
+	userID, err := utils.GetUserID(c)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusUnauthorized, "UNAUTHORIZED", "Kullanıcı kimliği doğrulanamadı", nil)
+		return
+	}
+
+	query := strings.TrimSpace(c.Query("q"))
+	if utf8.RuneCountInString(query) < 2 {
+		utils.ErrorResponse(c, http.StatusBadRequest, "INVALID_QUERY", "Arama metni en az 2 karakter olmalı", nil)
+		return
+	}
+
+	limit, _ := strconv.Atoi(c.DefaultQuery("limit", "10"))
+	if limit < 1 || limit > 50 {
+		limit = 10
+	}
+
+	types := map[string]bool{}
+	for _, t := range strings.Split(c.Query("types"), ",") {
+		if t = strings.TrimSpace(t); t != "" {
+			types[t] = true
+		}
+	}
+
+	pattern := "%" + escapeLike(query) + "%"
+	results := []models.SearchResult{}
+
+	for _, source := range searchSources {
+		if len(types) > 0 && !types[source.entityType] {
+			continue
+		}
+
+		args := []interface{}{userID}
+		for i := 0; i < source.fields; i++ {
+			args = append(args, pattern)
+		}
+		args = append(args, limit)
+
+		rows, err := h.db.Query(source.query+" LIMIT ?", args...)
+		if err != nil {
+			continue
+		}
+
+		for rows.Next() {
+			var result models.SearchResult
+			var body string
+			if err := rows.Scan(&result.EntityID, &result.Title, &body); err != nil {
+				continue
+			}
+			result.EntityType = source.entityType
+			result.Snippet = searchSnippet(body, query)
+			results = append(results, result)
+		}
+		rows.Close()
+	}
+
+	utils.SuccessResponse(c, results, "Arama sonuçları başarıyla getirildi")
+}
+
+// escapeLike LIKE sorgusundaki özel karakterleri kaçışlar
+func escapeLike(s string) string {
+	s = strings.ReplaceAll(s, `\`, `\\`)
+	s = strings.ReplaceAll(s, "%", `\%`)
+	return strings.ReplaceAll(s, "_", `\_`)
+}
+
+// searchSnippet eşleşmenin çevresinden kısa bir alıntı döner
+func searchSnippet(body, query string) string {
+	const radius = 60
+
+	body = strings.TrimSpace(body)
+	runes := []rune(body)
+	lower := strings.ToLower(body)
+	index := strings.Index(lower, strings.ToLower(query))
+	if index < 0 {
+		if len(runes) > radius*2 {
+			return string(runes[:radius*2]) + "…"
+		}
+		return body
+	}
+
+	// Küçük harfe çevirme rune sayısını değiştirebileceği için konum sınırlandırılır
+	position := utf8.RuneCountInString(lower[:index])
+	start := position - radius
+	end := position + utf8.RuneCountInString(query) + radius
+
+	prefix, suffix := "…", "…"
+	if start <= 0 {
+		start, prefix = 0, ""
+	}
+	if start >= len(runes) {
+		start = 0
+	}
+	if end >= len(runes) {
+		end, suffix = len(runes), ""
+	}
+
+	return prefix + string(runes[start:end]) + suffix
+}
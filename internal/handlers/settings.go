@@ -2,10 +2,13 @@ package handlers
 
 import (
 	"database/sql"
+	"errors"
+	"math"
 	"net/http"
 	"time"
 
 	"agri-management-api/internal/models"
+	"agri-management-api/internal/services"
 	"agri-management-api/internal/utils"
 
 	"github.com/gin-gonic/gin"
@@ -13,17 +16,28 @@ import (
 
 // SettingsHandler ayar işlemlerini yönetir
 type SettingsHandler struct {
-	db *sql.DB
+	db      *sql.DB
+	farms   *services.FarmService
+	support *services.SupportService
+	backups *services.BackupService
+	dbStats *services.DatabaseMaintenanceService
 }
 
 // NewSettingsHandler yeni settings handler oluşturur
 func NewSettingsHandler(db *sql.DB) *SettingsHandler {
-	return &SettingsHandler{db: db}
+	return &SettingsHandler{
+		db:      db,
+		farms:   services.NewFarmService(db),
+		support: services.NewSupportService(db),
+		backups: services.NewBackupService(db),
+		dbStats: services.NewDatabaseMaintenanceService(db),
+	}
 }
 
 // GetSettings uygulama ayarları
 // @Summary Uygulama ayarları
-// @Description Kullanıcının uygulama ayarlarını getirir
+// @Description Seçili çiftliğin (X-Farm-ID) uygulama ayarlarını getirir
+// @ID getSettings
 // @Tags Settings
 // @Accept json
 // @Produce json
@@ -32,40 +46,14 @@ func NewSettingsHandler(db *sql.DB) *SettingsHandler {
 // @Failure 401 {object} models.APIResponse
 // @Router /settings [get]
 func (h *SettingsHandler) GetSettings(c *gin.Context) {
-	_, err := utils.GetUserID(c)
-	if err != nil {
+	if _, err := utils.GetUserID(c); err != nil {
 		utils.ErrorResponse(c, http.StatusUnauthorized, "UNAUTHORIZED", "Kullanıcı kimliği doğrulanamadı", nil)
 		return
 	}
 
-	// Ayarları getir (basit implementasyon - gerçek uygulamada DB'den gelecek)
-	settings := models.Settings{
-		General: models.GeneralSettings{
-			Language:   "tr",
-			Currency:   "TRY",
-			DateFormat: "DD/MM/YYYY",
-			TimeFormat: "24H",
-			Units: models.UnitSettings{
-				Area:   "dönüm",
-				Weight: "kg",
-				Volume: "litre",
-			},
-		},
-		Notifications: models.NotificationSettings{
-			Push:  true,
-			Email: true,
-			SMS:   false,
-		},
-		Privacy: models.PrivacySettings{
-			LocationSharing: true,
-			DataAnalytics:   true,
-			PersonalizedAds: false,
-		},
-		Backup: models.BackupSettings{
-			AutoBackup:      true,
-			BackupFrequency: "weekly",
-			CloudStorage:    true,
-		},
+	settings, ok := h.farmSettings(c)
+	if !ok {
+		return
 	}
 
 	utils.SuccessResponse(c, settings, "Ayarlar başarıyla getirildi")
@@ -73,7 +61,8 @@ func (h *SettingsHandler) GetSettings(c *gin.Context) {
 
 // UpdateSettings ayarları güncelleme
 // @Summary Ayarları güncelleme
-// @Description Kullanıcının uygulama ayarlarını günceller
+// @Description Seçili çiftliğin (X-Farm-ID) uygulama ayarlarını günceller
+// @ID updateSettings
 // @Tags Settings
 // @Accept json
 // @Produce json
@@ -96,19 +85,62 @@ func (h *SettingsHandler) UpdateSettings(c *gin.Context) {
 		return
 	}
 
-	// Ayarları güncelle (basit implementasyon)
-	// Gerçek uygulamada bu ayarlar veritabanına kaydedilecek
-	utils.SuccessResponse(c, nil, "Ayarlar başarıyla güncellendi")
+	if err := services.NormalizeGeneralSettings(&req.General); err != nil {
+		utils.ErrorResponse(c, http.StatusBadRequest, "INVALID_GENERAL_SETTINGS", err.Error(), nil)
+		return
+	}
+	if err := services.NormalizeFiscalSettings(&req.Fiscal); err != nil {
+		utils.ErrorResponse(c, http.StatusBadRequest, "INVALID_FISCAL_SETTINGS", err.Error(), nil)
+		return
+	}
+	if err := services.NormalizeBackupSettings(&req.Backup); err != nil {
+		utils.ErrorResponse(c, http.StatusBadRequest, "INVALID_BACKUP_SETTINGS", err.Error(), nil)
+		return
+	}
+	if err := services.NormalizeLandAreaSettings(&req.LandArea); err != nil {
+		utils.ErrorResponse(c, http.StatusBadRequest, "INVALID_LAND_AREA_SETTINGS", err.Error(), nil)
+		return
+	}
+
+	if _, ok := h.farmSettings(c); !ok {
+		return
+	}
+
+	farmID, _ := utils.GetFarmID(c)
+	if err := h.farms.SaveSettings(farmID, req); err != nil {
+		utils.ErrorResponse(c, http.StatusInternalServerError, "UPDATE_ERROR", "Ayarlar kaydedilemedi", err.Error())
+		return
+	}
+
+	utils.SuccessResponse(c, req, "Ayarlar başarıyla güncellendi")
+}
+
+// farmSettings seçili çiftliğin ayarlarını getirir; varsayılan çiftlik ilk kullanımda oluşturulur. Hata varsa yanıtı yazar
+func (h *SettingsHandler) farmSettings(c *gin.Context) (models.Settings, bool) {
+	accountID, _ := utils.GetAccountID(c)
+	if err := h.farms.EnsureDefaultFarm(accountID); err != nil {
+		utils.ErrorResponse(c, http.StatusInternalServerError, "DB_ERROR", "Ayarlar getirilemedi", err.Error())
+		return models.Settings{}, false
+	}
+
+	farmID, _ := utils.GetFarmID(c)
+	settings, err := h.farms.Settings(farmID)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusInternalServerError, "DB_ERROR", "Ayarlar getirilemedi", err.Error())
+		return settings, false
+	}
+	return settings, true
 }
 
 // GetSystemInfo sistem bilgileri
 // @Summary Sistem bilgileri
-// @Description Sistem bilgilerini getirir
+// @Description Uygulama sürümünü, son başarılı yedeği, veritabanı dosyasının (WAL dahil) gerçek boyutunu (storageUsed, MB) ve sayfa bilgilerini, seçili çiftliğin tablo bazında kayıt sayılarını ve destek bilgilerini getirir
+// @ID getSystemInfo
 // @Tags Settings
 // @Accept json
 // @Produce json
 // @Security BearerAuth
-// @Success 200 {object} models.APIResponse{data=map[string]interface{}}
+// @Success 200 {object} models.APIResponse{data=models.SystemInfo}
 // @Failure 401 {object} models.APIResponse
 // @Router /settings/system-info [get]
 func (h *SettingsHandler) GetSystemInfo(c *gin.Context) {
@@ -118,26 +150,31 @@ func (h *SettingsHandler) GetSystemInfo(c *gin.Context) {
 		return
 	}
 
-	// Kullanıcının verilerini say
-	var landCount, animalCount, productionCount, transactionCount int
+	tableCounts, err := h.dbStats.FarmTableCounts(userID)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusInternalServerError, "DB_ERROR", "Sistem bilgileri alınamadı", err.Error())
+		return
+	}
+	dbStats, err := h.dbStats.Stats()
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusInternalServerError, "DB_ERROR", "Sistem bilgileri alınamadı", err.Error())
+		return
+	}
 
-	h.db.QueryRow("SELECT COUNT(*) FROM lands WHERE user_id = ?", userID).Scan(&landCount)
-	h.db.QueryRow("SELECT COUNT(*) FROM livestock WHERE user_id = ?", userID).Scan(&animalCount)
-	h.db.QueryRow("SELECT COUNT(*) FROM production WHERE user_id = ?", userID).Scan(&productionCount)
-	h.db.QueryRow("SELECT COUNT(*) FROM transactions WHERE user_id = ?", userID).Scan(&transactionCount)
+	openTickets, _ := h.support.OpenCount(userID)
 
-	// Depolama kullanımını hesapla (basit implementasyon)
-	totalRecords := landCount + animalCount + productionCount + transactionCount
-	storageUsed := float64(totalRecords) * 0.1 // Her kayıt için 0.1MB varsayımı
-	storageLimit := 1000.0                     // 1GB limit
+	lastBackup := ""
+	if last, _ := h.backups.LastBackup(userID); last != nil {
+		lastBackup = last.UTC().Format("2006-01-02T15:04:05Z")
+	}
 
-	systemInfo := map[string]interface{}{
-		"appVersion":   "1.0.0",
-		"apiVersion":   "v1",
-		"lastBackup":   time.Now().AddDate(0, 0, -1).Format("2006-01-02T15:04:05Z"),
-		"storageUsed":  storageUsed,
-		"storageLimit": storageLimit,
-		"features": []string{
+	systemInfo := models.SystemInfo{
+		AppVersion:   "1.0.0",
+		APIVersion:   "v1",
+		LastBackup:   lastBackup,
+		StorageUsed:  math.Round(float64(dbStats.TotalSizeBytes)/(1<<20)*100) / 100,
+		StorageLimit: services.DatabaseStorageLimitMB(),
+		Features: []string{
 			"Arazi Yönetimi",
 			"Hayvancılık",
 			"Finans Takibi",
@@ -146,13 +183,18 @@ func (h *SettingsHandler) GetSystemInfo(c *gin.Context) {
 			"Raporlar",
 			"Hava Durumu",
 		},
-		"supportContact": "support@agrimanagement.com",
-		"dataStats": map[string]int{
-			"lands":        landCount,
-			"animals":      animalCount,
-			"productions":  productionCount,
-			"transactions": transactionCount,
+		Support: models.SupportInfo{
+			TicketsURL:  "/api/v1/support/tickets",
+			OpenTickets: openTickets,
 		},
+		DataStats: models.SystemDataStats{
+			Lands:        tableCounts["lands"],
+			Animals:      tableCounts["livestock"],
+			Productions:  tableCounts["production"],
+			Transactions: tableCounts["transactions"],
+		},
+		Database:    dbStats,
+		TableCounts: tableCounts,
 	}
 
 	utils.SuccessResponse(c, systemInfo, "Sistem bilgileri başarıyla getirildi")
@@ -160,112 +202,171 @@ func (h *SettingsHandler) GetSystemInfo(c *gin.Context) {
 
 // CreateBackup veri yedekleme
 // @Summary Veri yedekleme
-// @Description Kullanıcı verilerinin yedeğini oluşturur
+// @Description Seçili çiftliğin arazi, hayvan, üretim, finans ve takvim kayıtlarının yedeğini alıp yedek depolamasına (S3 uyumlu nesne depolaması veya sunucu dizini) yükler; ayarlardaki saklama sayısı ve süresini aşan eski yedekler silinir. Doküman ve fotoğraf dosyaları yedeğe dahil değildir
+// @ID createBackup
 // @Tags Settings
 // @Accept json
 // @Produce json
 // @Security BearerAuth
-// @Success 200 {object} models.APIResponse{data=map[string]interface{}}
+// @Success 201 {object} models.APIResponse{data=models.BackupResult}
 // @Failure 401 {object} models.APIResponse
+// @Failure 502 {object} models.APIResponse
 // @Router /settings/backup [post]
 func (h *SettingsHandler) CreateBackup(c *gin.Context) {
-	_, err := utils.GetUserID(c)
+	userID, err := utils.GetUserID(c)
 	if err != nil {
 		utils.ErrorResponse(c, http.StatusUnauthorized, "UNAUTHORIZED", "Kullanıcı kimliği doğrulanamadı", nil)
 		return
 	}
 
-	// Yedekleme işlemi simülasyonu
-	backupID := utils.GenerateID()
-	backupDate := time.Now()
-
-	// Gerçek uygulamada burada:
-	// 1. Kullanıcının tüm verileri JSON formatında export edilir
-	// 2. Dosya cloud storage'a yüklenir
-	// 3. Yedekleme kaydı veritabanına kaydedilir
-
-	backup := map[string]interface{}{
-		"backupId":    backupID,
-		"status":      "completed",
-		"createdAt":   backupDate.Format("2006-01-02T15:04:05Z"),
-		"size":        "2.5MB",
-		"downloadUrl": "/api/v1/settings/backup/" + backupID + "/download",
-		"expiresAt":   backupDate.AddDate(0, 1, 0).Format("2006-01-02T15:04:05Z"), // 1 ay sonra
-		"includes": []string{
-			"Arazi Verileri",
-			"Hayvan Kayıtları",
-			"Üretim Bilgileri",
-			"Finansal İşlemler",
-			"Takvim Etkinlikleri",
-		},
+	backup, err := h.backups.Create(userID, models.BackupTriggerManual)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusBadGateway, "BACKUP_FAILED", "Yedekleme alınamadı", err.Error())
+		return
+	}
+
+	utils.CreatedResponse(c, backup, "Yedekleme başarıyla oluşturuldu")
+}
+
+// GetBackups yedek listesi
+// @Summary Yedek listesi
+// @Description Seçili çiftliğin yedeklerini yedek depolamasındaki dosyalarla birleştirerek yeniden eskiye listeler. Kaydı olmayan ancak depolamada bulunan yedekler "external" tetikleyicisiyle listelenir ve geri yüklenebilir; available=false olan kayıtların dosyası depolamada bulunamamıştır
+// @ID getBackups
+// @Tags Settings
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {object} models.APIResponse{data=models.BackupListResponse}
+// @Failure 401 {object} models.APIResponse
+// @Failure 502 {object} models.APIResponse
+// @Router /settings/backups [get]
+func (h *SettingsHandler) GetBackups(c *gin.Context) {
+	userID, err := utils.GetUserID(c)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusUnauthorized, "UNAUTHORIZED", "Kullanıcı kimliği doğrulanamadı", nil)
+		return
+	}
+
+	backups, err := h.backups.List(userID)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusBadGateway, "BACKUP_STORAGE_ERROR", "Yedekler listelenemedi", err.Error())
+		return
+	}
+
+	utils.SuccessResponse(c, backups, "Yedekler başarıyla getirildi")
+}
+
+// DownloadBackup yedek indirme
+// @Summary Yedek indirme
+// @Description Yedek dosyasını (gzip ile sıkıştırılmış JSON) indirir
+// @ID downloadBackup
+// @Tags Settings
+// @Produce application/gzip
+// @Security BearerAuth
+// @Param id path string true "Yedek ID"
+// @Success 200 {file} file
+// @Failure 401 {object} models.APIResponse
+// @Failure 404 {object} models.APIResponse
+// @Router /settings/backups/{id}/download [get]
+func (h *SettingsHandler) DownloadBackup(c *gin.Context) {
+	userID, err := utils.GetUserID(c)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusUnauthorized, "UNAUTHORIZED", "Kullanıcı kimliği doğrulanamadı", nil)
+		return
 	}
 
-	utils.SuccessResponse(c, backup, "Yedekleme başarıyla oluşturuldu")
+	id := c.Param("id")
+	file, err := h.backups.Open(userID, id)
+	if err != nil {
+		writeBackupError(c, err, "Yedek indirilemedi")
+		return
+	}
+	defer file.Close()
+
+	c.DataFromReader(http.StatusOK, -1, "application/gzip", file, map[string]string{
+		"Content-Disposition": "attachment; filename=backup-" + id + ".json.gz",
+	})
+}
+
+// DeleteBackup yedek silme
+// @Summary Yedek silme
+// @Description Yedeği depolamadan ve kaydıyla birlikte siler
+// @ID deleteBackup
+// @Tags Settings
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Yedek ID"
+// @Success 200 {object} models.APIResponse
+// @Failure 401 {object} models.APIResponse
+// @Failure 404 {object} models.APIResponse
+// @Router /settings/backups/{id} [delete]
+func (h *SettingsHandler) DeleteBackup(c *gin.Context) {
+	userID, err := utils.GetUserID(c)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusUnauthorized, "UNAUTHORIZED", "Kullanıcı kimliği doğrulanamadı", nil)
+		return
+	}
+
+	if err := h.backups.Delete(userID, c.Param("id")); err != nil {
+		writeBackupError(c, err, "Yedek silinemedi")
+		return
+	}
+
+	utils.SuccessResponse(c, nil, "Yedek başarıyla silindi")
 }
 
 // RestoreBackup veri geri yükleme
 // @Summary Veri geri yükleme
-// @Description Yedekten veri geri yükler
+// @Description Yedekteki seçili veri gruplarını geri yükler: grubun mevcut kayıtları silinir ve yerine yedektekiler yazılır. İşlem tek bir veritabanı işleminde yapılır; hata olursa hiçbir değişiklik kalmaz. backupFile GET /settings/backups listesindeki yedek ID'sidir
+// @ID restoreBackup
 // @Tags Settings
 // @Accept json
 // @Produce json
 // @Security BearerAuth
-// @Param request body map[string]interface{} true "Geri yükleme seçenekleri"
-// @Success 200 {object} models.APIResponse{data=map[string]interface{}}
+// @Param request body models.RestoreRequest true "Geri yükleme seçenekleri"
+// @Success 200 {object} models.APIResponse{data=models.RestoreResult}
 // @Failure 400 {object} models.APIResponse
 // @Failure 401 {object} models.APIResponse
+// @Failure 404 {object} models.APIResponse
+// @Failure 422 {object} models.APIResponse
 // @Router /settings/restore [post]
 func (h *SettingsHandler) RestoreBackup(c *gin.Context) {
-	_, err := utils.GetUserID(c)
+	userID, err := utils.GetUserID(c)
 	if err != nil {
 		utils.ErrorResponse(c, http.StatusUnauthorized, "UNAUTHORIZED", "Kullanıcı kimliği doğrulanamadı", nil)
 		return
 	}
 
-	var req struct {
-		BackupFile     string `json:"backupFile"`
-		RestoreOptions struct {
-			IncludeFinance    bool `json:"includeFinance"`
-			IncludeLivestock  bool `json:"includeLivestock"`
-			IncludeLands      bool `json:"includeLands"`
-			IncludeProduction bool `json:"includeProduction"`
-		} `json:"restoreOptions"`
-	}
-
+	var req models.RestoreRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
 		utils.ErrorResponse(c, http.StatusBadRequest, "INVALID_REQUEST", "Geçersiz istek formatı", err.Error())
 		return
 	}
 
-	// Geri yükleme işlemi simülasyonu
-	restoreID := utils.GenerateID()
-
-	// Gerçek uygulamada burada:
-	// 1. Yedek dosyası doğrulanır
-	// 2. Seçili veriler geri yüklenir
-	// 3. Mevcut verilerle çakışma kontrolü yapılır
-	// 4. İşlem logları tutulur
-
-	restore := map[string]interface{}{
-		"restoreId":  restoreID,
-		"status":     "completed",
-		"restoredAt": time.Now().Format("2006-01-02T15:04:05Z"),
-		"backupFile": req.BackupFile,
-		"restored": map[string]interface{}{
-			"lands":      req.RestoreOptions.IncludeLands,
-			"livestock":  req.RestoreOptions.IncludeLivestock,
-			"finance":    req.RestoreOptions.IncludeFinance,
-			"production": req.RestoreOptions.IncludeProduction,
-		},
-		"summary": map[string]int{
-			"restoredLands":        25,
-			"restoredAnimals":      48,
-			"restoredTransactions": 156,
-			"restoredProductions":  12,
-		},
+	result, err := h.backups.Restore(userID, req.BackupFile, req.RestoreOptions)
+	if err != nil {
+		writeBackupError(c, err, "Veriler geri yüklenemedi")
+		return
 	}
 
-	utils.SuccessResponse(c, restore, "Veriler başarıyla geri yüklendi")
+	utils.SuccessResponse(c, result, "Veriler başarıyla geri yüklendi")
+}
+
+// writeBackupError yedekleme hatasını uygun HTTP yanıtına çevirir
+func writeBackupError(c *gin.Context, err error, message string) {
+	switch {
+	case errors.Is(err, services.ErrBackupNotFound):
+		utils.ErrorResponse(c, http.StatusNotFound, "BACKUP_NOT_FOUND", "Yedek bulunamadı", nil)
+	case errors.Is(err, services.ErrNoRestoreGroups):
+		utils.ErrorResponse(c, http.StatusBadRequest, "NO_RESTORE_GROUPS", "Geri yüklenecek en az bir veri grubu seçilmeli", nil)
+	case errors.Is(err, services.ErrInvalidBackupKey):
+		utils.ErrorResponse(c, http.StatusBadRequest, "INVALID_BACKUP_KEY", "Geçersiz yedek anahtarı", nil)
+	case errors.Is(err, services.ErrInvalidBackup):
+		utils.ErrorResponse(c, http.StatusUnprocessableEntity, "INVALID_BACKUP", "Yedek dosyası okunamadı veya bu çiftliğe ait değil", nil)
+	default:
+		utils.ErrorResponse(c, http.StatusInternalServerError, "BACKUP_ERROR", message, err.Error())
+	}
 }
 
 // ExportData veri export
@@ -0,0 +1,394 @@
+package handlers
+
+import (
+	"database/sql"
+	"math"
+	"net/http"
+	"time"
+
+	"agri-management-api/internal/models"
+	"agri-management-api/internal/utils"
+
+	"github.com/gin-gonic/gin"
+)
+
+// slaughterIncomeCategory kesim satışlarının gelir kategorisi
+const slaughterIncomeCategory = "Hayvan Satışı"
+
+// SlaughterHandler kesim ve karkas verimi işlemlerini yönetir
+type SlaughterHandler struct {
+	db *sql.DB
+}
+
+// NewSlaughterHandler yeni slaughter handler oluşturur
+func NewSlaughterHandler(db *sql.DB) *SlaughterHandler {
+	return &SlaughterHandler{db: db}
+}
+
+// GetSlaughterRecords kesim kayıtları listesi
+// @Summary Kesim kayıtları
+// @Description Kesim ile çıkışı yapılan hayvanların karkas ve verim kayıtlarını listeler
+// @ID getSlaughterRecords
+// @Tags Livestock
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param type query string false "Hayvan türü"
+// @Param breed query string false "Irk"
+// @Param startDate query string false "Başlangıç tarihi (YYYY-MM-DD)"
+// @Param endDate query string false "Bitiş tarihi (YYYY-MM-DD)"
+// @Success 200 {object} models.APIResponse{data=[]models.SlaughterRecord}
+// @Failure 401 {object} models.APIResponse
+// @Router /livestock/slaughter [get]
+func (h *SlaughterHandler) GetSlaughterRecords(c *gin.Context) {
+	userID, err := utils.GetUserID(c)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusUnauthorized, "UNAUTHORIZED", "Kullanıcı kimliği doğrulanamadı", nil)
+		return
+	}
+
+	whereClause, args := slaughterFilters(c, userID)
+	rows, err := h.db.Query(slaughterSelect+whereClause+" ORDER BY s.slaughter_date DESC, s.created_at DESC", args...)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusInternalServerError, "DB_ERROR", "Kesim kayıtları alınamadı", err.Error())
+		return
+	}
+	defer rows.Close()
+
+	records := []models.SlaughterRecord{}
+	for rows.Next() {
+		record, err := scanSlaughterRecord(rows)
+		if err != nil {
+			continue
+		}
+		records = append(records, record)
+	}
+
+	utils.SuccessResponse(c, records, "Kesim kayıtları başarıyla getirildi")
+}
+
+// GetSlaughterRecord hayvanın kesim kaydı
+// @Summary Hayvan kesim kaydı
+// @Description Hayvanın karkas ağırlığı, sınıfı, alıcı ve verim bilgilerini getirir
+// @ID getSlaughterRecord
+// @Tags Livestock
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Hayvan ID"
+// @Success 200 {object} models.APIResponse{data=models.SlaughterRecord}
+// @Failure 401 {object} models.APIResponse
+// @Failure 404 {object} models.APIResponse
+// @Router /livestock/{id}/slaughter [get]
+func (h *SlaughterHandler) GetSlaughterRecord(c *gin.Context) {
+	userID, err := utils.GetUserID(c)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusUnauthorized, "UNAUTHORIZED", "Kullanıcı kimliği doğrulanamadı", nil)
+		return
+	}
+
+	record, err := scanSlaughterRecord(h.db.QueryRow(slaughterSelect+" WHERE s.livestock_id = ? AND s.user_id = ?", c.Param("id"), userID))
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusNotFound, "SLAUGHTER_NOT_FOUND", "Kesim kaydı bulunamadı", nil)
+		return
+	}
+
+	utils.SuccessResponse(c, record, "Kesim kaydı başarıyla getirildi")
+}
+
+// CreateSlaughterRecord kesim kaydı oluşturma
+// @Summary Kesim kaydı oluşturma
+// @Description Hayvanın kesim ile çıkışını karkas bilgileriyle kaydeder; kesim hareketi ve fiyat girilmişse gelir işlemi otomatik oluşturulur. Canlı ağırlık verilmezse hayvanın son kilosu kullanılır
+// @ID createSlaughterRecord
+// @Tags Livestock
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Hayvan ID"
+// @Param request body models.SlaughterRecord true "Kesim bilgileri"
+// @Success 201 {object} models.APIResponse{data=models.SlaughterRecord}
+// @Failure 400 {object} models.APIResponse
+// @Failure 401 {object} models.APIResponse
+// @Failure 404 {object} models.APIResponse
+// @Failure 409 {object} models.APIResponse
+// @Router /livestock/{id}/slaughter [post]
+func (h *SlaughterHandler) CreateSlaughterRecord(c *gin.Context) {
+	userID, err := utils.GetUserID(c)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusUnauthorized, "UNAUTHORIZED", "Kullanıcı kimliği doğrulanamadı", nil)
+		return
+	}
+
+	animalID := c.Param("id")
+
+	var req models.SlaughterRecord
+	if err := c.ShouldBindJSON(&req); err != nil {
+		utils.ErrorResponse(c, http.StatusBadRequest, "INVALID_REQUEST", "Geçersiz istek formatı", err.Error())
+		return
+	}
+
+	var tagNumber, location string
+	var weight sql.NullFloat64
+	var saleDate sql.NullTime
+	err = h.db.QueryRow(`
+		SELECT tag_number, COALESCE(location, ''), weight, sale_date FROM livestock WHERE id = ? AND user_id = ?
+	`, animalID, userID).Scan(&tagNumber, &location, &weight, &saleDate)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusNotFound, "ANIMAL_NOT_FOUND", "Hayvan bulunamadı", nil)
+		return
+	}
+
+	if saleDate.Valid {
+		utils.ErrorResponse(c, http.StatusConflict, "ANIMAL_EXITED", "Hayvan satılmış olarak kayıtlı", nil)
+		return
+	}
+
+	var exists bool
+	if err := h.db.QueryRow("SELECT 1 FROM slaughter_records WHERE livestock_id = ? AND user_id = ?", animalID, userID).Scan(&exists); err == nil {
+		utils.ErrorResponse(c, http.StatusConflict, "SLAUGHTER_EXISTS", "Bu hayvan için kesim kaydı zaten var", nil)
+		return
+	}
+
+	if req.LiveWeight == 0 && weight.Valid {
+		req.LiveWeight = weight.Float64
+	}
+	if req.LiveWeight > 0 && req.CarcassWeight > req.LiveWeight {
+		utils.ErrorResponse(c, http.StatusBadRequest, "INVALID_WEIGHT", "Karkas ağırlığı canlı ağırlıktan büyük olamaz", nil)
+		return
+	}
+	if req.Currency == "" {
+		req.Currency = "TRY"
+	}
+
+	tx, err := h.db.Begin()
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusInternalServerError, "DB_ERROR", "Kesim kaydı oluşturulamadı", err.Error())
+		return
+	}
+	defer tx.Rollback()
+
+	// Kesim, hayvanın çıkış hareketi olarak kaydedilir
+	movementID := utils.GenerateID()
+	_, err = tx.Exec(`
+		INSERT INTO livestock_movements (id, livestock_id, user_id, movement_type, movement_date,
+		                                 from_location, to_location, reason, notes, created_at)
+		VALUES (?, ?, ?, 'slaughter', ?, ?, ?, 'Kesim', ?, CURRENT_TIMESTAMP)
+	`, movementID, animalID, userID, req.SlaughterDate, location, req.Buyer, req.Notes)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusInternalServerError, "DB_ERROR", "Kesim hareketi oluşturulamadı", err.Error())
+		return
+	}
+
+	var transactionID interface{}
+	if req.Price > 0 {
+		id := utils.GenerateID()
+		description := "Kesim satışı - " + tagNumber
+		if req.Buyer != "" {
+			description += " (" + req.Buyer + ")"
+		}
+
+		_, err = tx.Exec(`
+			INSERT INTO transactions (id, user_id, type, category, description, amount, currency,
+			                         date, status, payment_method, receipt, notes, created_at, updated_at)
+			VALUES (?, ?, 'income', ?, ?, ?, ?, ?, 'completed', '', '', ?, CURRENT_TIMESTAMP, CURRENT_TIMESTAMP)
+		`, id, userID, slaughterIncomeCategory, description, req.Price, req.Currency, req.SlaughterDate, req.Notes)
+		if err != nil {
+			utils.ErrorResponse(c, http.StatusInternalServerError, "DB_ERROR", "Gelir işlemi oluşturulamadı", err.Error())
+			return
+		}
+		transactionID = id
+	}
+
+	recordID := utils.GenerateID()
+	_, err = tx.Exec(`
+		INSERT INTO slaughter_records (id, user_id, livestock_id, slaughter_date, live_weight, carcass_weight, grade,
+		                               buyer, price, currency, movement_id, transaction_id, notes, created_at)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, CURRENT_TIMESTAMP)
+	`, recordID, userID, animalID, req.SlaughterDate, req.LiveWeight, req.CarcassWeight, req.Grade,
+		req.Buyer, req.Price, req.Currency, movementID, transactionID, req.Notes)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusInternalServerError, "DB_ERROR", "Kesim kaydı oluşturulamadı", err.Error())
+		return
+	}
+
+	if err := tx.Commit(); err != nil {
+		utils.ErrorResponse(c, http.StatusInternalServerError, "DB_ERROR", "Kesim kaydı oluşturulamadı", err.Error())
+		return
+	}
+
+	record, err := scanSlaughterRecord(h.db.QueryRow(slaughterSelect+" WHERE s.id = ? AND s.user_id = ?", recordID, userID))
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusInternalServerError, "FETCH_ERROR", "Oluşturulan kesim kaydı getirilemedi", err.Error())
+		return
+	}
+
+	utils.CreatedResponse(c, record, "Kesim kaydı başarıyla oluşturuldu")
+}
+
+// GetYieldAnalytics ırk bazında karkas verimi analizi
+// @Summary Karkas verimi analizi
+// @Description Kesim kayıtlarından tür ve ırk bazında ortalama canlı/karkas ağırlığı, verim yüzdesi, sınıf dağılımı ve kg başına fiyatı hesaplar
+// @ID getYieldAnalytics
+// @Tags Livestock
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param type query string false "Hayvan türü"
+// @Param breed query string false "Irk"
+// @Param startDate query string false "Başlangıç tarihi (YYYY-MM-DD)"
+// @Param endDate query string false "Bitiş tarihi (YYYY-MM-DD)"
+// @Success 200 {object} models.APIResponse{data=models.SlaughterYieldAnalytics}
+// @Failure 401 {object} models.APIResponse
+// @Router /livestock/slaughter/analytics [get]
+func (h *SlaughterHandler) GetYieldAnalytics(c *gin.Context) {
+	userID, err := utils.GetUserID(c)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusUnauthorized, "UNAUTHORIZED", "Kullanıcı kimliği doğrulanamadı", nil)
+		return
+	}
+
+	whereClause, args := slaughterFilters(c, userID)
+	rows, err := h.db.Query(slaughterSelect+whereClause+" ORDER BY l.type, l.breed", args...)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusInternalServerError, "DB_ERROR", "Kesim kayıtları alınamadı", err.Error())
+		return
+	}
+	defer rows.Close()
+
+	analytics := models.SlaughterYieldAnalytics{Breeds: []models.BreedYield{}}
+	index := map[[2]string]int{}
+	yieldCounts := map[[2]string]int{}
+	var yieldSum float64
+	var yieldCount int
+
+	for rows.Next() {
+		record, err := scanSlaughterRecord(rows)
+		if err != nil {
+			continue
+		}
+
+		key := [2]string{record.Type, record.Breed}
+		i, ok := index[key]
+		if !ok {
+			analytics.Breeds = append(analytics.Breeds, models.BreedYield{
+				Type: record.Type, Breed: record.Breed, Grades: map[string]int{},
+			})
+			i = len(analytics.Breeds) - 1
+			index[key] = i
+		}
+		breed := &analytics.Breeds[i]
+
+		breed.Count++
+		breed.AvgLiveWeight += record.LiveWeight
+		breed.AvgCarcassWeight += record.CarcassWeight
+		breed.TotalRevenue += record.Price
+		if record.Grade != "" {
+			breed.Grades[record.Grade]++
+		}
+
+		// Canlı ağırlığı bilinmeyen kayıtlar verim ortalamasına katılmaz
+		if record.LiveWeight > 0 {
+			if yieldCounts[key] == 0 || record.YieldPercent < breed.MinYieldPercent {
+				breed.MinYieldPercent = record.YieldPercent
+			}
+			if record.YieldPercent > breed.MaxYieldPercent {
+				breed.MaxYieldPercent = record.YieldPercent
+			}
+			breed.AvgYieldPercent += record.YieldPercent
+			yieldCounts[key]++
+			yieldSum += record.YieldPercent
+			yieldCount++
+		}
+
+		analytics.TotalAnimals++
+		analytics.TotalCarcassKg += record.CarcassWeight
+		analytics.TotalRevenue += record.Price
+	}
+
+	for i := range analytics.Breeds {
+		breed := &analytics.Breeds[i]
+		key := [2]string{breed.Type, breed.Breed}
+
+		if breed.AvgCarcassWeight > 0 {
+			breed.AvgPricePerKg = roundTo2(breed.TotalRevenue / breed.AvgCarcassWeight)
+		}
+		if yieldCounts[key] > 0 {
+			breed.AvgYieldPercent = roundTo2(breed.AvgYieldPercent / float64(yieldCounts[key]))
+		}
+		breed.AvgLiveWeight = roundTo2(breed.AvgLiveWeight / float64(breed.Count))
+		breed.AvgCarcassWeight = roundTo2(breed.AvgCarcassWeight / float64(breed.Count))
+		breed.TotalRevenue = roundTo2(breed.TotalRevenue)
+	}
+	if yieldCount > 0 {
+		analytics.AvgYieldPercent = roundTo2(yieldSum / float64(yieldCount))
+	}
+	analytics.TotalCarcassKg = roundTo2(analytics.TotalCarcassKg)
+	analytics.TotalRevenue = roundTo2(analytics.TotalRevenue)
+
+	utils.SuccessResponse(c, analytics, "Karkas verimi analizi başarıyla getirildi")
+}
+
+// slaughterSelect kesim kayıtlarını hayvan bilgileriyle seçen sorgu
+const slaughterSelect = `
+	SELECT s.id, s.livestock_id, l.tag_number, l.type, COALESCE(l.breed, ''), s.slaughter_date,
+	       COALESCE(s.live_weight, 0), s.carcass_weight, COALESCE(s.grade, ''), COALESCE(s.buyer, ''),
+	       COALESCE(s.price, 0), COALESCE(s.currency, 'TRY'), COALESCE(s.movement_id, ''),
+	       COALESCE(s.transaction_id, ''), COALESCE(s.notes, ''), s.created_at
+	FROM slaughter_records s
+	JOIN livestock l ON s.livestock_id = l.id
+`
+
+// slaughterFilters liste ve analiz sorguları için filtreleri oluşturur
+func slaughterFilters(c *gin.Context, userID string) (string, []interface{}) {
+	whereClause := " WHERE s.user_id = ?"
+	args := []interface{}{userID}
+
+	if animalType := c.Query("type"); animalType != "" {
+		whereClause += " AND l.type = ?"
+		args = append(args, animalType)
+	}
+	if breed := c.Query("breed"); breed != "" {
+		whereClause += " AND l.breed = ?"
+		args = append(args, breed)
+	}
+	if startDate, err := time.Parse("2006-01-02", c.Query("startDate")); err == nil {
+		whereClause += " AND s.slaughter_date >= ?"
+		args = append(args, startDate)
+	}
+	if endDate, err := time.Parse("2006-01-02", c.Query("endDate")); err == nil {
+		whereClause += " AND s.slaughter_date < ?"
+		args = append(args, endDate.AddDate(0, 0, 1))
+	}
+
+	return whereClause, args
+}
+
+// scanSlaughterRecord kesim satırını okur ve verim yüzdesi ile kg fiyatını hesaplar
+func scanSlaughterRecord(row interface{ Scan(...interface{}) error }) (models.SlaughterRecord, error) {
+	var record models.SlaughterRecord
+	var slaughterDate sql.NullTime
+
+	err := row.Scan(
+		&record.ID, &record.LivestockID, &record.TagNumber, &record.Type, &record.Breed, &slaughterDate,
+		&record.LiveWeight, &record.CarcassWeight, &record.Grade, &record.Buyer,
+		&record.Price, &record.Currency, &record.MovementID, &record.TransactionID, &record.Notes, &record.CreatedAt,
+	)
+	if err != nil {
+		return record, err
+	}
+
+	record.SlaughterDate = utils.NullTimeToPtr(slaughterDate)
+	if record.LiveWeight > 0 {
+		record.YieldPercent = roundTo2(record.CarcassWeight / record.LiveWeight * 100)
+	}
+	if record.CarcassWeight > 0 {
+		record.PricePerKg = roundTo2(record.Price / record.CarcassWeight)
+	}
+
+	return record, nil
+}
+
+// roundTo2 değeri iki ondalık basamağa yuvarlar
+func roundTo2(value float64) float64 {
+	return math.Round(value*100) / 100
+}
@@ -0,0 +1,413 @@
+package handlers
+
+import (
+	"database/sql"
+	"errors"
+	"mime/multipart"
+	"net/http"
+	"path/filepath"
+	"strings"
+
+	"agri-management-api/internal/models"
+	"agri-management-api/internal/services"
+	"agri-management-api/internal/utils"
+
+	"github.com/gin-gonic/gin"
+)
+
+// SupportHandler kullanıcı destek taleplerini ve destek ekibinin yanıtlarını yönetir
+type SupportHandler struct {
+	db      *sql.DB
+	support *services.SupportService
+	store   services.MediaStore
+}
+
+// NewSupportHandler yeni support handler oluşturur
+func NewSupportHandler(db *sql.DB) *SupportHandler {
+	return &SupportHandler{
+		db:      db,
+		support: services.NewSupportService(db),
+		store:   services.NewMediaStore(),
+	}
+}
+
+// CreateTicket destek talebi oluşturma
+// @Summary Destek talebi oluştur
+// @Description Kullanıcının mesajını uygulama sürümü, cihaz bilgileri, istemcinin bildirdiği istek kimlikleri ve sunucunun kaydettiği son isteklerle birlikte destek talebi olarak kaydeder. Ekran görüntüsü eklemek için istek multipart form olarak gönderilip dosya screenshot alanına konur (JPEG, PNG veya GIF, en fazla 10 MB); formda cihaz bilgileri platform, osVersion, deviceModel, locale alanlarıdır
+// @ID createSupportTicket
+// @Tags Support
+// @Accept json,mpfd
+// @Produce json
+// @Security BearerAuth
+// @Param request body models.SupportTicketRequest true "Talep bilgileri"
+// @Success 201 {object} models.APIResponse{data=models.SupportTicket}
+// @Failure 400 {object} models.APIResponse
+// @Failure 401 {object} models.APIResponse
+// @Failure 403 {object} models.APIResponse
+// @Router /support/tickets [post]
+func (h *SupportHandler) CreateTicket(c *gin.Context) {
+	userID, err := utils.GetUserID(c)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusUnauthorized, "UNAUTHORIZED", "Kullanıcı kimliği doğrulanamadı", nil)
+		return
+	}
+	accountID, _ := utils.GetAccountID(c)
+
+	var req models.SupportTicketRequest
+	if err := c.ShouldBind(&req); err != nil {
+		utils.ErrorResponse(c, http.StatusBadRequest, "INVALID_REQUEST", "Geçersiz istek formatı", err.Error())
+		return
+	}
+	if utils.IsEmptyString(req.Message) {
+		utils.ErrorResponse(c, http.StatusBadRequest, "MISSING_FIELDS", "Mesaj gerekli", nil)
+		return
+	}
+	req.Device.UserAgent = c.Request.UserAgent()
+
+	// Ekran görüntüsü varsa talep oluşturulmadan önce doğrulanır
+	fileHeader, _ := c.FormFile("screenshot")
+	if fileHeader != nil {
+		if fileHeader.Size > maxPhotoSize {
+			utils.ErrorResponse(c, http.StatusBadRequest, "FILE_TOO_LARGE", "Ekran görüntüsü çok büyük", nil)
+			return
+		}
+		if !photoContentTypes[fileHeader.Header.Get("Content-Type")] {
+			utils.ErrorResponse(c, http.StatusBadRequest, "INVALID_FILE_TYPE", "Yalnızca JPEG, PNG veya GIF ekran görüntüsü yüklenebilir", nil)
+			return
+		}
+	}
+
+	ticket, err := h.support.Create(userID, accountID, req)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusInternalServerError, "DB_ERROR", "Destek talebi oluşturulamadı", err.Error())
+		return
+	}
+
+	if fileHeader != nil {
+		mediaID, err := h.saveScreenshot(userID, ticket.ID, fileHeader)
+		if err != nil {
+			utils.ErrorResponse(c, http.StatusInternalServerError, "STORAGE_ERROR", "Ekran görüntüsü kaydedilemedi", err.Error())
+			return
+		}
+		ticket.ScreenshotID = mediaID
+	}
+
+	utils.CreatedResponse(c, ticket, "Destek talebi başarıyla oluşturuldu")
+}
+
+// GetTickets destek talepleri
+// @Summary Destek talepleri
+// @Description Çiftlik adına gönderilen destek taleplerini en yeniden eskiye listeler
+// @ID getSupportTickets
+// @Tags Support
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param page query int false "Sayfa numarası"
+// @Param limit query int false "Sayfa başına kayıt"
+// @Success 200 {object} models.APIResponse{data=models.SupportTicketListResponse}
+// @Failure 401 {object} models.APIResponse
+// @Router /support/tickets [get]
+func (h *SupportHandler) GetTickets(c *gin.Context) {
+	userID, err := utils.GetUserID(c)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusUnauthorized, "UNAUTHORIZED", "Kullanıcı kimliği doğrulanamadı", nil)
+		return
+	}
+
+	page, limit := utils.ParsePagination(c)
+	tickets, total, err := h.support.Tickets(userID, page, limit)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusInternalServerError, "DB_ERROR", "Destek talepleri alınamadı", err.Error())
+		return
+	}
+
+	utils.SuccessResponse(c, models.SupportTicketListResponse{
+		Tickets:    tickets,
+		Pagination: utils.CalculatePagination(page, limit, total),
+	}, "Destek talepleri başarıyla getirildi")
+}
+
+// GetTicket destek talebi detayı
+// @Summary Destek talebi detayı
+// @Description Destek talebini kullanıcı ve destek ekibi mesajlarıyla getirir
+// @ID getSupportTicket
+// @Tags Support
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Talep ID"
+// @Success 200 {object} models.APIResponse{data=models.SupportTicket}
+// @Failure 401 {object} models.APIResponse
+// @Failure 404 {object} models.APIResponse
+// @Router /support/tickets/{id} [get]
+func (h *SupportHandler) GetTicket(c *gin.Context) {
+	userID, err := utils.GetUserID(c)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusUnauthorized, "UNAUTHORIZED", "Kullanıcı kimliği doğrulanamadı", nil)
+		return
+	}
+
+	ticket, err := h.support.Ticket(userID, c.Param("id"))
+	if err != nil {
+		writeSupportError(c, err)
+		return
+	}
+
+	utils.SuccessResponse(c, ticket, "Destek talebi başarıyla getirildi")
+}
+
+// AddTicketMessage destek talebine mesaj ekleme
+// @Summary Destek talebine mesaj ekle
+// @Description Kullanıcının talebe ek bilgi veya yanıt yazmasını sağlar; çözülmüş ya da kapatılmış talep yeniden açılır
+// @ID addSupportTicketMessage
+// @Tags Support
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Talep ID"
+// @Param request body models.SupportTicketMessageRequest true "Mesaj (status alanı yok sayılır)"
+// @Success 200 {object} models.APIResponse{data=models.SupportTicket}
+// @Failure 400 {object} models.APIResponse
+// @Failure 401 {object} models.APIResponse
+// @Failure 403 {object} models.APIResponse
+// @Failure 404 {object} models.APIResponse
+// @Router /support/tickets/{id}/messages [post]
+func (h *SupportHandler) AddTicketMessage(c *gin.Context) {
+	userID, err := utils.GetUserID(c)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusUnauthorized, "UNAUTHORIZED", "Kullanıcı kimliği doğrulanamadı", nil)
+		return
+	}
+	accountID, _ := utils.GetAccountID(c)
+
+	var req models.SupportTicketMessageRequest
+	if err := c.ShouldBindJSON(&req); err != nil || utils.IsEmptyString(req.Message) {
+		utils.ErrorResponse(c, http.StatusBadRequest, "INVALID_REQUEST", "Mesaj gerekli", nil)
+		return
+	}
+
+	ticket, err := h.support.AddUserMessage(userID, accountID, c.Param("id"), req.Message)
+	if err != nil {
+		writeSupportError(c, err)
+		return
+	}
+
+	utils.SuccessResponse(c, ticket, "Mesaj başarıyla eklendi")
+}
+
+// GetAdminTickets tüm destek talepleri
+// @Summary Tüm destek talepleri
+// @Description Sistem yöneticisi için tüm çiftliklerin destek taleplerini son güncellemeye göre listeler
+// @ID getAdminSupportTickets
+// @Tags Admin
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param status query string false "Durum filtresi" Enums(open, in_progress, resolved, closed)
+// @Param page query int false "Sayfa numarası"
+// @Param limit query int false "Sayfa başına kayıt"
+// @Success 200 {object} models.APIResponse{data=models.SupportTicketListResponse}
+// @Failure 400 {object} models.APIResponse
+// @Failure 401 {object} models.APIResponse
+// @Failure 403 {object} models.APIResponse
+// @Router /admin/support/tickets [get]
+func (h *SupportHandler) GetAdminTickets(c *gin.Context) {
+	status := c.Query("status")
+	if status != "" && !validSupportStatus(status) {
+		utils.ErrorResponse(c, http.StatusBadRequest, "INVALID_STATUS", "Geçersiz durum", nil)
+		return
+	}
+
+	page, limit := utils.ParsePagination(c)
+	tickets, total, err := h.support.AllTickets(status, page, limit)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusInternalServerError, "DB_ERROR", "Destek talepleri alınamadı", err.Error())
+		return
+	}
+
+	utils.SuccessResponse(c, models.SupportTicketListResponse{
+		Tickets:    tickets,
+		Pagination: utils.CalculatePagination(page, limit, total),
+	}, "Destek talepleri başarıyla getirildi")
+}
+
+// GetAdminTicket destek talebi detayı (yönetici)
+// @Summary Destek talebi detayı (yönetici)
+// @Description Talebi bağlam bilgileri (cihaz, sürüm, son istekler) ve mesajlarıyla getirir
+// @ID getAdminSupportTicket
+// @Tags Admin
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Talep ID"
+// @Success 200 {object} models.APIResponse{data=models.SupportTicket}
+// @Failure 401 {object} models.APIResponse
+// @Failure 403 {object} models.APIResponse
+// @Failure 404 {object} models.APIResponse
+// @Router /admin/support/tickets/{id} [get]
+func (h *SupportHandler) GetAdminTicket(c *gin.Context) {
+	ticket, err := h.support.AdminTicket(c.Param("id"))
+	if err != nil {
+		writeSupportError(c, err)
+		return
+	}
+
+	utils.SuccessResponse(c, ticket, "Destek talebi başarıyla getirildi")
+}
+
+// RespondTicket destek talebini yanıtlama
+// @Summary Destek talebini yanıtla
+// @Description Destek ekibinin yanıtını talebe ekler ve isteğe bağlı olarak durumu değiştirir; durum verilmezse açık talep işleme alınır. Kullanıcıya bildirim gönderilir
+// @ID respondSupportTicket
+// @Tags Admin
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Talep ID"
+// @Param request body models.SupportTicketMessageRequest true "Yanıt"
+// @Success 200 {object} models.APIResponse{data=models.SupportTicket}
+// @Failure 400 {object} models.APIResponse
+// @Failure 401 {object} models.APIResponse
+// @Failure 403 {object} models.APIResponse
+// @Failure 404 {object} models.APIResponse
+// @Router /admin/support/tickets/{id}/responses [post]
+func (h *SupportHandler) RespondTicket(c *gin.Context) {
+	adminID := c.GetString("user_id")
+
+	var req models.SupportTicketMessageRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		utils.ErrorResponse(c, http.StatusBadRequest, "INVALID_REQUEST", "Geçersiz istek formatı", err.Error())
+		return
+	}
+	if utils.IsEmptyString(req.Message) {
+		utils.ErrorResponse(c, http.StatusBadRequest, "MISSING_FIELDS", "Yanıt gerekli", nil)
+		return
+	}
+
+	ticket, err := h.support.Respond(c.Param("id"), adminID, req.Message, req.Status)
+	if err != nil {
+		writeSupportError(c, err)
+		return
+	}
+
+	utils.SuccessResponse(c, ticket, "Destek talebi yanıtlandı")
+}
+
+// UpdateTicketStatus destek talebi durumunu değiştirme
+// @Summary Destek talebi durumunu değiştir
+// @Description Talebin durumunu değiştirir; durum değiştiyse kullanıcıya bildirim gönderilir
+// @ID updateSupportTicketStatus
+// @Tags Admin
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Talep ID"
+// @Param request body models.SupportTicketStatusRequest true "Yeni durum"
+// @Success 200 {object} models.APIResponse{data=models.SupportTicket}
+// @Failure 400 {object} models.APIResponse
+// @Failure 401 {object} models.APIResponse
+// @Failure 403 {object} models.APIResponse
+// @Failure 404 {object} models.APIResponse
+// @Router /admin/support/tickets/{id}/status [patch]
+func (h *SupportHandler) UpdateTicketStatus(c *gin.Context) {
+	var req models.SupportTicketStatusRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		utils.ErrorResponse(c, http.StatusBadRequest, "INVALID_REQUEST", "Geçersiz istek formatı", err.Error())
+		return
+	}
+
+	ticket, err := h.support.UpdateStatus(c.Param("id"), req.Status)
+	if err != nil {
+		writeSupportError(c, err)
+		return
+	}
+
+	utils.SuccessResponse(c, ticket, "Destek talebi durumu güncellendi")
+}
+
+// GetAdminTicketScreenshot destek talebi ekran görüntüsü
+// @Summary Destek talebi ekran görüntüsü
+// @Description Talebe eklenen ekran görüntüsünü döner
+// @ID getAdminSupportTicketScreenshot
+// @Tags Admin
+// @Produce octet-stream
+// @Security BearerAuth
+// @Param id path string true "Talep ID"
+// @Success 200 {file} file
+// @Failure 401 {object} models.APIResponse
+// @Failure 403 {object} models.APIResponse
+// @Failure 404 {object} models.APIResponse
+// @Router /admin/support/tickets/{id}/screenshot [get]
+func (h *SupportHandler) GetAdminTicketScreenshot(c *gin.Context) {
+	var storageKey, filename, contentType string
+	var size int64
+	err := h.db.QueryRow(`
+		SELECT m.storage_key, m.filename, COALESCE(m.content_type, 'application/octet-stream'), m.size
+		FROM support_tickets t
+		JOIN media_attachments m ON m.id = t.screenshot_id
+		WHERE t.id = ?
+	`, c.Param("id")).Scan(&storageKey, &filename, &contentType, &size)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusNotFound, "SCREENSHOT_NOT_FOUND", "Ekran görüntüsü bulunamadı", nil)
+		return
+	}
+
+	file, err := h.store.Open(storageKey)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusNotFound, "SCREENSHOT_NOT_FOUND", "Ekran görüntüsü dosyası bulunamadı", nil)
+		return
+	}
+	defer file.Close()
+
+	c.DataFromReader(http.StatusOK, size, contentType, file, map[string]string{
+		"Content-Disposition": "inline; filename=" + filename,
+	})
+}
+
+// saveScreenshot ekran görüntüsünü talebe bağlı medya eki olarak kaydeder ve talebe bağlar
+func (h *SupportHandler) saveScreenshot(userID, ticketID string, fileHeader *multipart.FileHeader) (string, error) {
+	file, err := fileHeader.Open()
+	if err != nil {
+		return "", err
+	}
+	defer file.Close()
+
+	mediaID := utils.GenerateID()
+	storageKey := filepath.Join(userID, mediaID+strings.ToLower(filepath.Ext(fileHeader.Filename)))
+
+	size, err := h.store.Save(storageKey, file)
+	if err != nil {
+		return "", err
+	}
+
+	_, err = h.db.Exec(`
+		INSERT INTO media_attachments (id, user_id, entity_type, entity_id, kind, filename, content_type,
+		                               size, storage_key, created_at)
+		VALUES (?, ?, 'support_ticket', ?, 'image', ?, ?, ?, ?, CURRENT_TIMESTAMP)
+	`, mediaID, userID, ticketID, filepath.Base(fileHeader.Filename), fileHeader.Header.Get("Content-Type"), size, storageKey)
+	if err != nil {
+		h.store.Delete(storageKey)
+		return "", err
+	}
+
+	return mediaID, h.support.AttachScreenshot(userID, ticketID, mediaID)
+}
+
+// writeSupportError destek talebi hatasını uygun HTTP yanıtına çevirir
+func writeSupportError(c *gin.Context, err error) {
+	if errors.Is(err, services.ErrSupportTicketNotFound) {
+		utils.ErrorResponse(c, http.StatusNotFound, "TICKET_NOT_FOUND", "Destek talebi bulunamadı", nil)
+		return
+	}
+	utils.ErrorResponse(c, http.StatusInternalServerError, "DB_ERROR", "Destek talebi işlenemedi", err.Error())
+}
+
+// validSupportStatus durumun geçerli bir destek talebi durumu olup olmadığını döner
+func validSupportStatus(status string) bool {
+	switch status {
+	case models.SupportTicketOpen, models.SupportTicketInProgress, models.SupportTicketResolved, models.SupportTicketClosed:
+		return true
+	}
+	return false
+}
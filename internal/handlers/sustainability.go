@@ -0,0 +1,151 @@
+package handlers
+
+import (
+	"bytes"
+	"database/sql"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"agri-management-api/internal/models"
+	"agri-management-api/internal/services"
+	"agri-management-api/internal/utils"
+
+	"github.com/gin-gonic/gin"
+)
+
+// SustainabilityHandler karbon ayak izi ve sürdürülebilirlik metriklerini yönetir
+type SustainabilityHandler struct {
+	db     *sql.DB
+	carbon *services.CarbonService
+}
+
+// NewSustainabilityHandler yeni sustainability handler oluşturur
+func NewSustainabilityHandler(db *sql.DB) *SustainabilityHandler {
+	return &SustainabilityHandler{
+		db:     db,
+		carbon: services.NewCarbonService(db),
+	}
+}
+
+// GetCarbonFootprint yıllık karbon ayak izi
+// @Summary Karbon ayak izi
+// @Description Hayvan sayısı, azotlu gübre, yakıt ve elektrik kullanımından standart katsayılarla tahmini sera gazı emisyonlarını aylık olarak hesaplar; tamamlanan aylar saklanarak zaman içinde izlenir
+// @ID getCarbonFootprint
+// @Tags Sustainability
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param year query int false "Yıl (varsayılan: bu yıl)"
+// @Param recalculate query bool false "Saklanmış ayları yeniden hesapla"
+// @Success 200 {object} models.APIResponse{data=models.CarbonReport}
+// @Failure 401 {object} models.APIResponse
+// @Router /sustainability/carbon [get]
+func (h *SustainabilityHandler) GetCarbonFootprint(c *gin.Context) {
+	userID, err := utils.GetUserID(c)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusUnauthorized, "UNAUTHORIZED", "Kullanıcı kimliği doğrulanamadı", nil)
+		return
+	}
+
+	report, err := h.report(c, userID)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusInternalServerError, "CALCULATION_ERROR", "Karbon ayak izi hesaplanamadı", err.Error())
+		return
+	}
+
+	utils.SuccessResponse(c, report, "Karbon ayak izi başarıyla getirildi")
+}
+
+// ExportCarbonFootprint karbon ayak izi dışa aktarımı
+// @Summary Karbon ayak izi dışa aktarımı
+// @Description Yıllık karbon raporunu sürdürülebilirlik sertifikasyonları için aylık emisyonlar ve kullanılan katsayılarla CSV veya JSON dosyası olarak indirir
+// @ID exportCarbonFootprint
+// @Tags Sustainability
+// @Accept json
+// @Produce text/csv
+// @Produce application/json
+// @Security BearerAuth
+// @Param year query int false "Yıl (varsayılan: bu yıl)"
+// @Param format query string false "Dosya formatı (csv, json)" default(csv)
+// @Success 200 {file} file
+// @Failure 400 {object} models.APIResponse
+// @Failure 401 {object} models.APIResponse
+// @Router /sustainability/carbon/export [get]
+func (h *SustainabilityHandler) ExportCarbonFootprint(c *gin.Context) {
+	userID, err := utils.GetUserID(c)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusUnauthorized, "UNAUTHORIZED", "Kullanıcı kimliği doğrulanamadı", nil)
+		return
+	}
+
+	format := strings.ToLower(c.DefaultQuery("format", "csv"))
+	if format != "csv" && format != "json" {
+		utils.ErrorResponse(c, http.StatusBadRequest, "INVALID_FORMAT", "Geçersiz dosya formatı", []string{"csv", "json"})
+		return
+	}
+
+	report, err := h.report(c, userID)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusInternalServerError, "CALCULATION_ERROR", "Karbon ayak izi hesaplanamadı", err.Error())
+		return
+	}
+
+	var buf bytes.Buffer
+	contentType := "text/csv; charset=utf-8"
+
+	if format == "json" {
+		contentType = "application/json; charset=utf-8"
+		var data string
+		data, err = utils.ToJSON(report)
+		buf.WriteString(data)
+	} else {
+		err = services.WriteCarbonCSV(&buf, report)
+	}
+
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusInternalServerError, "EXPORT_ERROR", "Karbon raporu oluşturulamadı", err.Error())
+		return
+	}
+
+	filename := "karbon-ayak-izi-" + strconv.Itoa(report.Year) + "." + format
+	c.Header("Content-Disposition", "attachment; filename="+filename)
+	c.Data(http.StatusOK, contentType, buf.Bytes())
+}
+
+// GetCarbonCoefficients emisyon katsayıları
+// @Summary Emisyon katsayıları
+// @Description Karbon ayak izi hesaplamasında kullanılan standart emisyon katsayılarını ve kaynaklarını listeler
+// @ID getCarbonCoefficients
+// @Tags Sustainability
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {object} models.APIResponse{data=[]models.CarbonCoefficient}
+// @Failure 401 {object} models.APIResponse
+// @Router /sustainability/coefficients [get]
+func (h *SustainabilityHandler) GetCarbonCoefficients(c *gin.Context) {
+	if _, err := utils.GetUserID(c); err != nil {
+		utils.ErrorResponse(c, http.StatusUnauthorized, "UNAUTHORIZED", "Kullanıcı kimliği doğrulanamadı", nil)
+		return
+	}
+
+	utils.SuccessResponse(c, services.CarbonCoefficients(), "Emisyon katsayıları başarıyla getirildi")
+}
+
+// report sorgu parametrelerine göre yıllık karbon raporunu sürdürülebilirlik puanıyla oluşturur
+func (h *SustainabilityHandler) report(c *gin.Context, userID string) (models.CarbonReport, error) {
+	year := time.Now().Year()
+	if value, err := strconv.Atoi(c.Query("year")); err == nil {
+		year = value
+	}
+
+	report, err := h.carbon.Year(userID, year, c.Query("recalculate") == "true")
+	if err != nil {
+		return report, err
+	}
+
+	report.Score, err = h.carbon.SustainabilityScore(userID)
+	return report, err
+}
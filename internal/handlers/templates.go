@@ -0,0 +1,518 @@
+package handlers
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"agri-management-api/internal/models"
+	"agri-management-api/internal/utils"
+
+	"github.com/gin-gonic/gin"
+)
+
+// errTemplateTargetNotFound şablonun işaret ettiği hayvan/arazi bulunamadığında döner
+var errTemplateTargetNotFound = errors.New("template target not found")
+
+// errTemplateInvalidValue şablon alanı beklenen türde olmadığında döner
+var errTemplateInvalidValue = errors.New("invalid template field value")
+
+// templateRequiredFields hedef türüne göre kayıt oluşturmak için zorunlu alanlar
+var templateRequiredFields = map[string][]string{
+	models.TemplateTargetMilk:         {"animalId", "amount"},
+	models.TemplateTargetLandActivity: {"landId", "type", "description"},
+	models.TemplateTargetHealth:       {"animalId", "type", "description"},
+	models.TemplateTargetTransaction:  {"type", "category", "description", "amount"},
+}
+
+// TemplateHandler aktivite şablonları ve hızlı kayıt işlemlerini yönetir
+type TemplateHandler struct {
+	db *sql.DB
+}
+
+// NewTemplateHandler yeni template handler oluşturur
+func NewTemplateHandler(db *sql.DB) *TemplateHandler {
+	return &TemplateHandler{db: db}
+}
+
+// GetTemplates şablon listesi
+// @Summary Aktivite şablonları
+// @Description Kullanıcının kayıtlı aktivite şablonlarını en çok kullanılandan başlayarak listeler
+// @ID getTemplates
+// @Tags Templates
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param targetType query string false "Hedef kayıt türü (milk, land_activity, health, transaction)"
+// @Success 200 {object} models.APIResponse{data=[]models.ActivityTemplate}
+// @Failure 401 {object} models.APIResponse
+// @Router /templates [get]
+func (h *TemplateHandler) GetTemplates(c *gin.Context) {
+	userID, err := utils.GetUserID(c)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusUnauthorized, "UNAUTHORIZED", "Kullanıcı kimliği doğrulanamadı", nil)
+		return
+	}
+
+	targetType := c.DefaultQuery("targetType", "all")
+
+	whereClause := "WHERE user_id = ?"
+	args := []interface{}{userID}
+
+	if targetType != "all" {
+		whereClause += " AND target_type = ?"
+		args = append(args, targetType)
+	}
+
+	rows, err := h.db.Query(`
+		SELECT id, user_id, name, target_type, fields, use_count, last_used_at, created_at, updated_at
+		FROM activity_templates `+whereClause+`
+		ORDER BY use_count DESC, name
+	`, args...)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusInternalServerError, "DB_ERROR", "Şablonlar alınamadı", err.Error())
+		return
+	}
+	defer rows.Close()
+
+	var templates []models.ActivityTemplate
+	for rows.Next() {
+		template, err := scanTemplate(rows)
+		if err != nil {
+			continue
+		}
+		templates = append(templates, template)
+	}
+
+	utils.SuccessResponse(c, templates, "Şablonlar başarıyla getirildi")
+}
+
+// CreateTemplate yeni şablon oluşturma
+// @Summary Aktivite şablonu oluşturma
+// @Description Sık kullanılan bir kayıt için varsayılan alanlarla şablon oluşturur (örn. sabah sağımı 20L A kalite)
+// @ID createTemplate
+// @Tags Templates
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param request body models.ActivityTemplate true "Şablon bilgileri"
+// @Success 201 {object} models.APIResponse{data=models.ActivityTemplate}
+// @Failure 400 {object} models.APIResponse
+// @Failure 401 {object} models.APIResponse
+// @Router /templates [post]
+func (h *TemplateHandler) CreateTemplate(c *gin.Context) {
+	userID, err := utils.GetUserID(c)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusUnauthorized, "UNAUTHORIZED", "Kullanıcı kimliği doğrulanamadı", nil)
+		return
+	}
+
+	var req models.ActivityTemplate
+	if err := c.ShouldBindJSON(&req); err != nil {
+		utils.ErrorResponse(c, http.StatusBadRequest, "INVALID_REQUEST", "Geçersiz istek formatı", err.Error())
+		return
+	}
+
+	if _, ok := templateRequiredFields[req.TargetType]; !ok {
+		utils.ErrorResponse(c, http.StatusBadRequest, "INVALID_TARGET_TYPE", "Geçersiz hedef kayıt türü", templateTargetTypes())
+		return
+	}
+
+	if req.Fields == nil {
+		req.Fields = map[string]interface{}{}
+	}
+
+	fields, err := utils.ToJSON(req.Fields)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusBadRequest, "INVALID_REQUEST", "Geçersiz şablon alanları", err.Error())
+		return
+	}
+
+	templateID := utils.GenerateID()
+	_, err = h.db.Exec(`
+		INSERT INTO activity_templates (id, user_id, name, target_type, fields, created_at, updated_at)
+		VALUES (?, ?, ?, ?, ?, CURRENT_TIMESTAMP, CURRENT_TIMESTAMP)
+	`, templateID, userID, req.Name, req.TargetType, fields)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusInternalServerError, "DB_ERROR", "Şablon oluşturulamadı", err.Error())
+		return
+	}
+
+	template, err := h.getTemplate(templateID, userID)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusInternalServerError, "FETCH_ERROR", "Oluşturulan şablon getirilemedi", err.Error())
+		return
+	}
+
+	utils.CreatedResponse(c, template, "Şablon başarıyla oluşturuldu")
+}
+
+// UpdateTemplate şablon güncelleme
+// @Summary Aktivite şablonu güncelleme
+// @Description Şablonun adını ve varsayılan alanlarını günceller
+// @ID updateTemplate
+// @Tags Templates
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Şablon ID"
+// @Param request body models.ActivityTemplate true "Şablon bilgileri"
+// @Success 200 {object} models.APIResponse{data=models.ActivityTemplate}
+// @Failure 400 {object} models.APIResponse
+// @Failure 401 {object} models.APIResponse
+// @Failure 404 {object} models.APIResponse
+// @Router /templates/{id} [put]
+func (h *TemplateHandler) UpdateTemplate(c *gin.Context) {
+	userID, err := utils.GetUserID(c)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusUnauthorized, "UNAUTHORIZED", "Kullanıcı kimliği doğrulanamadı", nil)
+		return
+	}
+
+	templateID := c.Param("id")
+	if utils.IsEmptyString(templateID) {
+		utils.ErrorResponse(c, http.StatusBadRequest, "MISSING_ID", "Şablon ID gerekli", nil)
+		return
+	}
+
+	var req models.ActivityTemplate
+	if err := c.ShouldBindJSON(&req); err != nil {
+		utils.ErrorResponse(c, http.StatusBadRequest, "INVALID_REQUEST", "Geçersiz istek formatı", err.Error())
+		return
+	}
+
+	if _, ok := templateRequiredFields[req.TargetType]; !ok {
+		utils.ErrorResponse(c, http.StatusBadRequest, "INVALID_TARGET_TYPE", "Geçersiz hedef kayıt türü", templateTargetTypes())
+		return
+	}
+
+	if req.Fields == nil {
+		req.Fields = map[string]interface{}{}
+	}
+
+	fields, err := utils.ToJSON(req.Fields)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusBadRequest, "INVALID_REQUEST", "Geçersiz şablon alanları", err.Error())
+		return
+	}
+
+	result, err := h.db.Exec(`
+		UPDATE activity_templates SET name = ?, target_type = ?, fields = ?, updated_at = CURRENT_TIMESTAMP
+		WHERE id = ? AND user_id = ?
+	`, req.Name, req.TargetType, fields, templateID, userID)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusInternalServerError, "UPDATE_ERROR", "Şablon güncellenemedi", err.Error())
+		return
+	}
+
+	rowsAffected, _ := result.RowsAffected()
+	if rowsAffected == 0 {
+		utils.ErrorResponse(c, http.StatusNotFound, "TEMPLATE_NOT_FOUND", "Şablon bulunamadı", nil)
+		return
+	}
+
+	template, err := h.getTemplate(templateID, userID)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusInternalServerError, "FETCH_ERROR", "Güncellenen şablon getirilemedi", err.Error())
+		return
+	}
+
+	utils.SuccessResponse(c, template, "Şablon başarıyla güncellendi")
+}
+
+// DeleteTemplate şablon silme
+// @Summary Aktivite şablonu silme
+// @Description Şablonu siler; şablondan oluşturulmuş kayıtlar etkilenmez
+// @ID deleteTemplate
+// @Tags Templates
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Şablon ID"
+// @Success 200 {object} models.APIResponse
+// @Failure 401 {object} models.APIResponse
+// @Failure 404 {object} models.APIResponse
+// @Router /templates/{id} [delete]
+func (h *TemplateHandler) DeleteTemplate(c *gin.Context) {
+	userID, err := utils.GetUserID(c)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusUnauthorized, "UNAUTHORIZED", "Kullanıcı kimliği doğrulanamadı", nil)
+		return
+	}
+
+	templateID := c.Param("id")
+	if utils.IsEmptyString(templateID) {
+		utils.ErrorResponse(c, http.StatusBadRequest, "MISSING_ID", "Şablon ID gerekli", nil)
+		return
+	}
+
+	result, err := h.db.Exec("DELETE FROM activity_templates WHERE id = ? AND user_id = ?", templateID, userID)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusInternalServerError, "DELETE_ERROR", "Şablon silinemedi", err.Error())
+		return
+	}
+
+	rowsAffected, _ := result.RowsAffected()
+	if rowsAffected == 0 {
+		utils.ErrorResponse(c, http.StatusNotFound, "TEMPLATE_NOT_FOUND", "Şablon bulunamadı", nil)
+		return
+	}
+
+	utils.SuccessResponse(c, nil, "Şablon başarıyla silindi")
+}
+
+// QuickLog şablondan hızlı kayıt
+// @Summary Hızlı kayıt
+// @Description Şablonu verilen alanlarla birleştirerek ilgili kaydı (süt, arazi aktivitesi, sağlık, finans) tek istekte oluşturur
+// @ID quickLog
+// @Tags Templates
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param request body models.QuickLogRequest true "Şablon ID ve değiştirilecek alanlar"
+// @Success 201 {object} models.APIResponse{data=models.QuickLogResult}
+// @Failure 400 {object} models.APIResponse
+// @Failure 401 {object} models.APIResponse
+// @Failure 404 {object} models.APIResponse
+// @Router /quick-log [post]
+func (h *TemplateHandler) QuickLog(c *gin.Context) {
+	userID, err := utils.GetUserID(c)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusUnauthorized, "UNAUTHORIZED", "Kullanıcı kimliği doğrulanamadı", nil)
+		return
+	}
+
+	var req models.QuickLogRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		utils.ErrorResponse(c, http.StatusBadRequest, "INVALID_REQUEST", "Geçersiz istek formatı", err.Error())
+		return
+	}
+
+	template, err := h.getTemplate(req.TemplateID, userID)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusNotFound, "TEMPLATE_NOT_FOUND", "Şablon bulunamadı", nil)
+		return
+	}
+
+	// Şablon alanları, istekte gönderilen alanlarla ezilir
+	fields := make(map[string]interface{}, len(template.Fields)+len(req.Overrides))
+	for key, value := range template.Fields {
+		fields[key] = value
+	}
+	for key, value := range req.Overrides {
+		fields[key] = value
+	}
+
+	var missing []string
+	for _, key := range templateRequiredFields[template.TargetType] {
+		if templateString(fields, key) == "" {
+			missing = append(missing, key)
+		}
+	}
+	if len(missing) > 0 {
+		utils.ErrorResponse(c, http.StatusBadRequest, "MISSING_FIELDS", "Gerekli alanlar eksik", missing)
+		return
+	}
+
+	date := time.Now()
+	if value := templateString(fields, "date"); value != "" {
+		parsed, err := utils.ParseTime(value)
+		if err != nil {
+			utils.ErrorResponse(c, http.StatusBadRequest, "INVALID_DATE", "Geçersiz tarih formatı", nil)
+			return
+		}
+		date = *parsed
+	}
+	fields["date"] = date.Format("2006-01-02")
+
+	recordID, err := h.createRecord(userID, template.TargetType, fields, date)
+	if err != nil {
+		if errors.Is(err, errTemplateTargetNotFound) {
+			utils.ErrorResponse(c, http.StatusNotFound, "TARGET_NOT_FOUND", "Şablondaki hayvan veya arazi bulunamadı", nil)
+			return
+		}
+		if errors.Is(err, errTemplateInvalidValue) {
+			utils.ErrorResponse(c, http.StatusBadRequest, "INVALID_FIELD", "Geçersiz alan değeri", err.Error())
+			return
+		}
+		utils.ErrorResponse(c, http.StatusInternalServerError, "DB_ERROR", "Kayıt oluşturulamadı", err.Error())
+		return
+	}
+
+	h.db.Exec(`
+		UPDATE activity_templates SET use_count = use_count + 1, last_used_at = CURRENT_TIMESTAMP
+		WHERE id = ? AND user_id = ?
+	`, template.ID, userID)
+
+	utils.CreatedResponse(c, models.QuickLogResult{
+		TemplateID: template.ID,
+		TargetType: template.TargetType,
+		RecordID:   recordID,
+		Fields:     fields,
+	}, "Kayıt şablondan başarıyla oluşturuldu")
+}
+
+// createRecord şablon türüne göre ilgili tabloya kayıt ekler
+func (h *TemplateHandler) createRecord(userID, targetType string, fields map[string]interface{}, date time.Time) (string, error) {
+	recordID := utils.GenerateID()
+	notes := templateString(fields, "notes")
+
+	switch targetType {
+	case models.TemplateTargetMilk:
+		animalID := templateString(fields, "animalId")
+		if !h.owns("livestock", animalID, userID) {
+			return "", errTemplateTargetNotFound
+		}
+		amount, err := templateFloat(fields, "amount")
+		if err != nil {
+			return "", err
+		}
+
+		_, err = h.db.Exec(`
+			INSERT INTO milk_production (id, livestock_id, date, amount, quality, notes, created_at)
+			VALUES (?, ?, ?, ?, ?, ?, CURRENT_TIMESTAMP)
+		`, recordID, animalID, date, amount, templateString(fields, "quality"), notes)
+		return recordID, err
+
+	case models.TemplateTargetLandActivity:
+		landID := templateString(fields, "landId")
+		if !h.owns("lands", landID, userID) {
+			return "", errTemplateTargetNotFound
+		}
+
+		_, err := h.db.Exec(`
+			INSERT INTO land_activities (id, land_id, type, description, actual_date, notes, cost, result,
+			                             fertilizer_kg, nitrogen_percent, created_at)
+			VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, CURRENT_TIMESTAMP)
+		`, recordID, landID, templateString(fields, "type"), templateString(fields, "description"), date,
+			notes, templateOptionalFloat(fields, "cost"), templateString(fields, "result"),
+			templateOptionalFloat(fields, "fertilizerKg"), templateOptionalFloat(fields, "nitrogenPercent"))
+		if err != nil {
+			return "", err
+		}
+
+		h.db.Exec("UPDATE lands SET last_activity = ?, updated_at = CURRENT_TIMESTAMP WHERE id = ? AND user_id = ?", date, landID, userID)
+		return recordID, nil
+
+	case models.TemplateTargetHealth:
+		animalID := templateString(fields, "animalId")
+		if !h.owns("livestock", animalID, userID) {
+			return "", errTemplateTargetNotFound
+		}
+
+		_, err := h.db.Exec(`
+			INSERT INTO health_records (id, livestock_id, type, description, date, veterinarian, cost, notes, created_at)
+			VALUES (?, ?, ?, ?, ?, ?, ?, ?, CURRENT_TIMESTAMP)
+		`, recordID, animalID, templateString(fields, "type"), templateString(fields, "description"), date,
+			templateString(fields, "veterinarian"), templateOptionalFloat(fields, "cost"), notes)
+		return recordID, err
+
+	case models.TemplateTargetTransaction:
+		amount, err := templateFloat(fields, "amount")
+		if err != nil {
+			return "", err
+		}
+		currency := templateString(fields, "currency")
+		if currency == "" {
+			currency = "TRY"
+		}
+
+		_, err = h.db.Exec(`
+			INSERT INTO transactions (id, user_id, type, category, description, amount, currency,
+			                         date, status, payment_method, notes, created_at, updated_at)
+			VALUES (?, ?, ?, ?, ?, ?, ?, ?, 'completed', ?, ?, CURRENT_TIMESTAMP, CURRENT_TIMESTAMP)
+		`, recordID, userID, templateString(fields, "type"), templateString(fields, "category"),
+			templateString(fields, "description"), amount, currency, date, templateString(fields, "paymentMethod"), notes)
+		return recordID, err
+	}
+
+	return "", fmt.Errorf("unsupported template target type: %s", targetType)
+}
+
+// owns kaydın kullanıcıya ait olup olmadığını kontrol eder
+func (h *TemplateHandler) owns(table, id, userID string) bool {
+	var exists bool
+	err := h.db.QueryRow("SELECT 1 FROM "+table+" WHERE id = ? AND user_id = ?", id, userID).Scan(&exists)
+	return err == nil
+}
+
+// getTemplate kullanıcıya ait şablonu getirir
+func (h *TemplateHandler) getTemplate(templateID, userID string) (models.ActivityTemplate, error) {
+	row := h.db.QueryRow(`
+		SELECT id, user_id, name, target_type, fields, use_count, last_used_at, created_at, updated_at
+		FROM activity_templates WHERE id = ? AND user_id = ?
+	`, templateID, userID)
+	return scanTemplate(row)
+}
+
+// scanTemplate şablon satırını okur
+func scanTemplate(row interface{ Scan(...interface{}) error }) (models.ActivityTemplate, error) {
+	var template models.ActivityTemplate
+	var fields string
+	var lastUsedAt sql.NullTime
+
+	err := row.Scan(
+		&template.ID, &template.UserID, &template.Name, &template.TargetType, &fields,
+		&template.UseCount, &lastUsedAt, &template.CreatedAt, &template.UpdatedAt,
+	)
+	if err != nil {
+		return template, err
+	}
+
+	template.LastUsedAt = utils.NullTimeToPtr(lastUsedAt)
+	template.Fields = map[string]interface{}{}
+	utils.FromJSON(fields, &template.Fields)
+
+	return template, nil
+}
+
+// templateTargetTypes desteklenen hedef kayıt türleri
+func templateTargetTypes() []string {
+	return []string{
+		models.TemplateTargetMilk,
+		models.TemplateTargetLandActivity,
+		models.TemplateTargetHealth,
+		models.TemplateTargetTransaction,
+	}
+}
+
+// templateString alan değerini string olarak döner
+func templateString(fields map[string]interface{}, key string) string {
+	value, ok := fields[key]
+	if !ok || value == nil {
+		return ""
+	}
+	if s, ok := value.(string); ok {
+		return strings.TrimSpace(s)
+	}
+	return fmt.Sprint(value)
+}
+
+// templateFloat alan değerini sayıya çevirir
+func templateFloat(fields map[string]interface{}, key string) (float64, error) {
+	switch value := fields[key].(type) {
+	case float64:
+		return value, nil
+	case string:
+		if number, err := strconv.ParseFloat(strings.TrimSpace(value), 64); err == nil {
+			return number, nil
+		}
+	}
+	return 0, fmt.Errorf("%w: %s", errTemplateInvalidValue, key)
+}
+
+// templateOptionalFloat boş olabilen sayısal alanı döner
+func templateOptionalFloat(fields map[string]interface{}, key string) *float64 {
+	if templateString(fields, key) == "" {
+		return nil
+	}
+	value, err := templateFloat(fields, key)
+	if err != nil {
+		return nil
+	}
+	return &value
+}
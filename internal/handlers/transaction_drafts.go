@@ -0,0 +1,627 @@
+package handlers
+
+import (
+	"bytes"
+	"crypto/subtle"
+	"database/sql"
+	"io"
+	"log"
+	"mime/multipart"
+	"net/http"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"agri-management-api/internal/models"
+	"agri-management-api/internal/services"
+	"agri-management-api/internal/utils"
+
+	"github.com/gin-gonic/gin"
+)
+
+// maxReceiptSize e-postayla iletilen fiş dosyasının en büyük boyutu
+const maxReceiptSize = 10 << 20
+
+// maxDraftBodyLength taslakta saklanan e-posta metninin en fazla karakter sayısı
+const maxDraftBodyLength = 5000
+
+// transactionDraftSelect taslak sorgularının ortak sütunları
+const transactionDraftSelect = `
+	SELECT id, user_id, source, COALESCE(sender, ''), COALESCE(subject, ''), COALESCE(body, ''), type,
+	       COALESCE(category, ''), COALESCE(description, ''), amount, COALESCE(NULLIF(currency, ''), 'TRY'), date,
+	       status, transaction_id, COALESCE(filename, ''), COALESCE(content_type, ''), size, storage_key,
+	       attachment_count, created_at, updated_at
+	FROM transaction_drafts`
+
+// GetInboundEmail fiş iletim adresi
+// @Summary Fiş iletim adresi
+// @Description Seçili çiftliğe özel e-posta adresini döner; bu adrese iletilen fiş ve faturalar onay bekleyen işlem taslağı olarak eklenir
+// @ID getInboundEmail
+// @Tags Finance
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {object} models.APIResponse{data=models.InboundEmailAddress}
+// @Failure 401 {object} models.APIResponse
+// @Failure 403 {object} models.APIResponse
+// @Router /finance/inbox [get]
+func (h *FinanceHandler) GetInboundEmail(c *gin.Context) {
+	userID, err := utils.GetUserID(c)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusUnauthorized, "UNAUTHORIZED", "Kullanıcı kimliği doğrulanamadı", nil)
+		return
+	}
+
+	_, err = h.db.Exec("INSERT OR IGNORE INTO inbound_email_addresses (token, user_id) VALUES (?, ?)", services.NewInboundEmailToken(), userID)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusInternalServerError, "DB_ERROR", "İletim adresi oluşturulamadı", err.Error())
+		return
+	}
+
+	address, err := h.getInboundEmail(userID)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusInternalServerError, "DB_ERROR", "İletim adresi getirilemedi", err.Error())
+		return
+	}
+
+	utils.SuccessResponse(c, address, "İletim adresi başarıyla getirildi")
+}
+
+// RotateInboundEmail fiş iletim adresini yenileme
+// @Summary Fiş iletim adresini yenileme
+// @Description Çiftliğin iletim adresini yenisiyle değiştirir; eski adrese gelen e-postalar artık kabul edilmez
+// @ID rotateInboundEmail
+// @Tags Finance
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {object} models.APIResponse{data=models.InboundEmailAddress}
+// @Failure 401 {object} models.APIResponse
+// @Failure 403 {object} models.APIResponse
+// @Router /finance/inbox/rotate [post]
+func (h *FinanceHandler) RotateInboundEmail(c *gin.Context) {
+	userID, err := utils.GetUserID(c)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusUnauthorized, "UNAUTHORIZED", "Kullanıcı kimliği doğrulanamadı", nil)
+		return
+	}
+
+	_, err = h.db.Exec(`
+		INSERT INTO inbound_email_addresses (token, user_id, created_at) VALUES (?, ?, CURRENT_TIMESTAMP)
+		ON CONFLICT (user_id) DO UPDATE SET token = excluded.token, created_at = excluded.created_at
+	`, services.NewInboundEmailToken(), userID)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusInternalServerError, "DB_ERROR", "İletim adresi yenilenemedi", err.Error())
+		return
+	}
+
+	address, err := h.getInboundEmail(userID)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusInternalServerError, "DB_ERROR", "İletim adresi getirilemedi", err.Error())
+		return
+	}
+
+	utils.SuccessResponse(c, address, "İletim adresi başarıyla yenilendi")
+}
+
+// ReceiveInboundEmail gelen e-posta webhook'u
+// @Summary Gelen e-posta webhook'u
+// @Description E-posta sağlayıcısının iletilen e-postaları gönderdiği uç nokta. Alıcı adresindeki anahtara göre çiftlik bulunur, ilk PDF veya görsel eki fiş olarak saklanır ve metinden tutar, tarih ve para birimi tahmin edilerek onay bekleyen taslak oluşturulur. INBOUND_EMAIL_SECRET ile paylaşılan anahtar X-Inbound-Secret başlığında gönderilmelidir; sorgu parametresindeki anahtar erişim günlüklerine düşeceği için kabul edilmez
+// @ID receiveInboundEmail
+// @Tags Finance
+// @Accept multipart/form-data
+// @Produce json
+// @Param X-Inbound-Secret header string true "Webhook anahtarı"
+// @Param recipient formData string true "Alıcı adres(ler)i"
+// @Param from formData string false "Gönderen"
+// @Param subject formData string false "Konu"
+// @Param body-plain formData string false "E-posta metni"
+// @Param attachment formData file false "Fiş veya fatura eki"
+// @Success 201 {object} models.APIResponse{data=models.TransactionDraft}
+// @Failure 400 {object} models.APIResponse
+// @Failure 401 {object} models.APIResponse
+// @Failure 404 {object} models.APIResponse
+// @Failure 503 {object} models.APIResponse
+// @Router /inbound/email [post]
+func (h *FinanceHandler) ReceiveInboundEmail(c *gin.Context) {
+	secret := os.Getenv("INBOUND_EMAIL_SECRET")
+	if secret == "" {
+		utils.ErrorResponse(c, http.StatusServiceUnavailable, "INBOUND_EMAIL_DISABLED", "E-posta ile fiş iletimi yapılandırılmamış", nil)
+		return
+	}
+	provided := c.GetHeader("X-Inbound-Secret")
+	if subtle.ConstantTimeCompare([]byte(provided), []byte(secret)) != 1 {
+		utils.ErrorResponse(c, http.StatusUnauthorized, "INVALID_SECRET", "Geçersiz webhook anahtarı", nil)
+		return
+	}
+
+	recipients := firstFormValue(c, "recipient", "to", "To")
+	if recipients == "" {
+		utils.ErrorResponse(c, http.StatusBadRequest, "MISSING_RECIPIENT", "Alıcı adresi gerekli", nil)
+		return
+	}
+
+	farmID := ""
+	for _, token := range services.InboundEmailTokens(recipients) {
+		err := h.db.QueryRow("SELECT user_id FROM inbound_email_addresses WHERE token = ?", token).Scan(&farmID)
+		if err == nil {
+			break
+		}
+		if err != sql.ErrNoRows {
+			utils.ErrorResponse(c, http.StatusInternalServerError, "DB_ERROR", "İletim adresi bulunamadı", err.Error())
+			return
+		}
+	}
+	if farmID == "" {
+		utils.ErrorResponse(c, http.StatusNotFound, "INBOX_NOT_FOUND", "İletim adresi bulunamadı", nil)
+		return
+	}
+
+	subject := firstFormValue(c, "subject", "Subject")
+	body := firstFormValue(c, "body-plain", "stripped-text", "text")
+	if len([]rune(body)) > maxDraftBodyLength {
+		body = string([]rune(body)[:maxDraftBodyLength])
+	}
+	guess := services.ParseReceiptEmail(subject, body)
+
+	draft := models.TransactionDraft{
+		ID:          utils.GenerateID(),
+		UserID:      farmID,
+		Source:      "email",
+		Sender:      firstFormValue(c, "from", "sender", "From"),
+		Subject:     subject,
+		Body:        body,
+		Type:        "expense",
+		Description: guess.Description,
+		Amount:      guess.Amount,
+		Currency:    guess.Currency,
+		Date:        today(),
+	}
+	if guess.Date != nil {
+		draft.Date = *guess.Date
+	}
+	if draft.Currency == "" {
+		settings, err := h.farms.Settings(farmID)
+		if err == nil {
+			draft.Currency = settings.General.Currency
+		}
+	}
+
+	// Yalnızca ilk PDF veya görsel eki fiş olarak saklanır; diğer ekler sayılır
+	var receipt *multipart.FileHeader
+	if form, err := c.MultipartForm(); err == nil {
+		keys := make([]string, 0, len(form.File))
+		for key := range form.File {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+		for _, key := range keys {
+			for _, fileHeader := range form.File[key] {
+				draft.AttachmentCount++
+				if receipt == nil && isReceiptFile(fileHeader) {
+					receipt = fileHeader
+				}
+			}
+		}
+	}
+
+	var storageKey, filename, contentType interface{}
+	var size int64
+	if receipt != nil {
+		data, err := readReceiptFile(receipt)
+		if err != nil {
+			utils.ErrorResponse(c, http.StatusBadRequest, "INVALID_FILE", "Fiş dosyası okunamadı", err.Error())
+			return
+		}
+		key := filepath.Join(farmID, "receipts", draft.ID+strings.ToLower(filepath.Ext(receipt.Filename)))
+		if size, err = h.store.Save(key, bytes.NewReader(data)); err != nil {
+			utils.ErrorResponse(c, http.StatusInternalServerError, "STORAGE_ERROR", "Fiş dosyası kaydedilemedi", err.Error())
+			return
+		}
+		storageKey, filename, contentType = key, filepath.Base(receipt.Filename), receiptContentType(receipt, data)
+	}
+
+	_, err := h.db.Exec(`
+		INSERT INTO transaction_drafts (id, user_id, source, sender, subject, body, type, description, amount, currency, date,
+		                                status, filename, content_type, size, storage_key, attachment_count, created_at, updated_at)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, 'pending', ?, ?, ?, ?, ?, CURRENT_TIMESTAMP, CURRENT_TIMESTAMP)
+	`, draft.ID, draft.UserID, draft.Source, draft.Sender, draft.Subject, draft.Body, draft.Type, draft.Description,
+		draft.Amount, draft.Currency, draft.Date.Format("2006-01-02"), filename, contentType, size, storageKey, draft.AttachmentCount)
+	if err != nil {
+		if key, ok := storageKey.(string); ok {
+			h.store.Delete(key)
+		}
+		utils.ErrorResponse(c, http.StatusInternalServerError, "DB_ERROR", "İşlem taslağı oluşturulamadı", err.Error())
+		return
+	}
+
+	title := "Yeni Fiş Onay Bekliyor"
+	message := "E-postayla iletilen fişten işlem taslağı oluşturuldu: " + draft.Description
+	_, err = h.notifications.Create(services.Notification{
+		UserID:   farmID,
+		Title:    title,
+		Message:  message,
+		Type:     "info",
+		Priority: "low",
+		Topic:    models.NotificationTopicTransactionDraft,
+		Entity:   &models.RelatedEntity{Type: "transaction_draft", ID: draft.ID, Name: draft.Description},
+	})
+	if err != nil {
+		log.Printf("İşlem taslağı bildirimi gönderilemedi: %v", err)
+	}
+
+	created, err := h.getTransactionDraft(draft.ID, farmID)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusInternalServerError, "FETCH_ERROR", "Oluşturulan taslak getirilemedi", err.Error())
+		return
+	}
+
+	utils.CreatedResponse(c, created, "İşlem taslağı başarıyla oluşturuldu")
+}
+
+// GetTransactionDrafts işlem taslakları
+// @Summary İşlem taslakları
+// @Description E-postayla iletilen fişlerden oluşturulan taslakları listeler; varsayılan olarak yalnızca onay bekleyenler döner
+// @ID getTransactionDrafts
+// @Tags Finance
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param status query string false "Durum (pending, approved, all)"
+// @Success 200 {object} models.APIResponse{data=[]models.TransactionDraft}
+// @Failure 400 {object} models.APIResponse
+// @Failure 401 {object} models.APIResponse
+// @Router /finance/drafts [get]
+func (h *FinanceHandler) GetTransactionDrafts(c *gin.Context) {
+	userID, err := utils.GetUserID(c)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusUnauthorized, "UNAUTHORIZED", "Kullanıcı kimliği doğrulanamadı", nil)
+		return
+	}
+
+	query := transactionDraftSelect + " WHERE user_id = ?"
+	args := []interface{}{userID}
+	switch status := c.DefaultQuery("status", "pending"); status {
+	case "all":
+	case "pending", "approved":
+		query += " AND status = ?"
+		args = append(args, status)
+	default:
+		utils.ErrorResponse(c, http.StatusBadRequest, "INVALID_STATUS", "Geçersiz taslak durumu", []string{"pending", "approved", "all"})
+		return
+	}
+
+	rows, err := h.db.Query(query+" ORDER BY created_at DESC", args...)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusInternalServerError, "DB_ERROR", "İşlem taslakları getirilemedi", err.Error())
+		return
+	}
+	defer rows.Close()
+
+	drafts := []models.TransactionDraft{}
+	for rows.Next() {
+		draft, err := scanTransactionDraft(rows)
+		if err != nil {
+			continue
+		}
+		drafts = append(drafts, draft)
+	}
+
+	utils.SuccessResponse(c, drafts, "İşlem taslakları başarıyla getirildi")
+}
+
+// GetTransactionDraft işlem taslağı detayı
+// @Summary İşlem taslağı detayı
+// @Description Belirli bir işlem taslağını getirir
+// @ID getTransactionDraft
+// @Tags Finance
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Taslak ID"
+// @Success 200 {object} models.APIResponse{data=models.TransactionDraft}
+// @Failure 401 {object} models.APIResponse
+// @Failure 404 {object} models.APIResponse
+// @Router /finance/drafts/{id} [get]
+func (h *FinanceHandler) GetTransactionDraft(c *gin.Context) {
+	userID, err := utils.GetUserID(c)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusUnauthorized, "UNAUTHORIZED", "Kullanıcı kimliği doğrulanamadı", nil)
+		return
+	}
+
+	draft, err := h.getTransactionDraft(c.Param("id"), userID)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			utils.ErrorResponse(c, http.StatusNotFound, "DRAFT_NOT_FOUND", "İşlem taslağı bulunamadı", nil)
+		} else {
+			utils.ErrorResponse(c, http.StatusInternalServerError, "DB_ERROR", "İşlem taslağı getirilemedi", err.Error())
+		}
+		return
+	}
+
+	utils.SuccessResponse(c, draft, "İşlem taslağı başarıyla getirildi")
+}
+
+// GetTransactionDraftReceipt işlem taslağı fiş dosyası
+// @Summary Fiş dosyası
+// @Description Taslağa eklenen fiş dosyasını indirir; onaylanan işlemlerin fiş bağlantısı da bu adrese yönlenir
+// @ID getTransactionDraftReceipt
+// @Tags Finance
+// @Produce application/octet-stream
+// @Security BearerAuth
+// @Param id path string true "Taslak ID"
+// @Success 200 {file} file
+// @Failure 401 {object} models.APIResponse
+// @Failure 404 {object} models.APIResponse
+// @Router /finance/drafts/{id}/receipt [get]
+func (h *FinanceHandler) GetTransactionDraftReceipt(c *gin.Context) {
+	userID, err := utils.GetUserID(c)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusUnauthorized, "UNAUTHORIZED", "Kullanıcı kimliği doğrulanamadı", nil)
+		return
+	}
+
+	var storageKey, filename, contentType string
+	var size int64
+	err = h.db.QueryRow(`
+		SELECT storage_key, filename, COALESCE(content_type, 'application/octet-stream'), size
+		FROM transaction_drafts WHERE id = ? AND user_id = ? AND storage_key IS NOT NULL
+	`, c.Param("id"), userID).Scan(&storageKey, &filename, &contentType, &size)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusNotFound, "RECEIPT_NOT_FOUND", "Fiş dosyası bulunamadı", nil)
+		return
+	}
+
+	file, err := h.store.Open(storageKey)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusNotFound, "RECEIPT_NOT_FOUND", "Fiş dosyası bulunamadı", nil)
+		return
+	}
+	defer file.Close()
+
+	c.DataFromReader(http.StatusOK, size, contentType, file, map[string]string{
+		"Content-Disposition": "attachment; filename=" + filename,
+	})
+}
+
+// ApproveTransactionDraft işlem taslağını onaylama
+// @Summary İşlem taslağını onaylama
+// @Description Taslaktan işlem oluşturur. Gövdede gönderilen alanlar taslaktaki tahminlerin yerine kullanılır; kategori ve tutar taslakta yoksa gönderilmelidir. Fiş dosyası işlemin receipt alanına bağlanır
+// @ID approveTransactionDraft
+// @Tags Finance
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Taslak ID"
+// @Param transaction body models.Transaction false "Düzeltilen işlem alanları"
+// @Success 201 {object} models.APIResponse{data=models.Transaction}
+// @Failure 400 {object} models.APIResponse
+// @Failure 401 {object} models.APIResponse
+// @Failure 404 {object} models.APIResponse
+// @Failure 409 {object} models.APIResponse
+// @Router /finance/drafts/{id}/approve [post]
+func (h *FinanceHandler) ApproveTransactionDraft(c *gin.Context) {
+	userID, err := utils.GetUserID(c)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusUnauthorized, "UNAUTHORIZED", "Kullanıcı kimliği doğrulanamadı", nil)
+		return
+	}
+
+	var req models.Transaction
+	if err := c.ShouldBindJSON(&req); err != nil && err != io.EOF {
+		utils.ErrorResponse(c, http.StatusBadRequest, "INVALID_REQUEST", "Geçersiz istek formatı", err.Error())
+		return
+	}
+
+	draft, err := h.getTransactionDraft(c.Param("id"), userID)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			utils.ErrorResponse(c, http.StatusNotFound, "DRAFT_NOT_FOUND", "İşlem taslağı bulunamadı", nil)
+		} else {
+			utils.ErrorResponse(c, http.StatusInternalServerError, "DB_ERROR", "İşlem taslağı getirilemedi", err.Error())
+		}
+		return
+	}
+	if draft.Status != "pending" {
+		utils.ErrorResponse(c, http.StatusConflict, "DRAFT_ALREADY_APPROVED", "İşlem taslağı zaten onaylanmış", draft.TransactionID)
+		return
+	}
+
+	// Gönderilmeyen alanlar taslaktaki tahminlerle doldurulur
+	if req.Type == "" {
+		req.Type = draft.Type
+	}
+	if req.Category == "" {
+		req.Category = draft.Category
+	}
+	if req.Description == "" {
+		req.Description = draft.Description
+	}
+	if req.Amount == 0 {
+		req.Amount = draft.Amount
+	}
+	if req.Currency == "" {
+		req.Currency = draft.Currency
+	}
+	if req.Date.IsZero() {
+		req.Date = draft.Date
+	}
+	if req.Receipt == "" && draft.Receipt != nil {
+		req.Receipt = draft.Receipt.URL
+	}
+
+	if utils.IsEmptyString(req.Category) || req.Amount <= 0 {
+		utils.ErrorResponse(c, http.StatusBadRequest, "MISSING_FIELDS", "Gerekli alanlar eksik", []string{"category", "amount"})
+		return
+	}
+
+	tags, err := parseTransactionTags(req.Tags)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusBadRequest, "INVALID_TAGS", err.Error(), nil)
+		return
+	}
+
+	// Aynı taslağın iki kez onaylanmasını önlemek için önce taslak onaylandı olarak işaretlenir
+	result, err := h.db.Exec(`
+		UPDATE transaction_drafts SET status = 'approved', updated_at = CURRENT_TIMESTAMP
+		WHERE id = ? AND user_id = ? AND status = 'pending'
+	`, draft.ID, userID)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusInternalServerError, "UPDATE_ERROR", "İşlem taslağı onaylanamadı", err.Error())
+		return
+	}
+	if rows, _ := result.RowsAffected(); rows == 0 {
+		utils.ErrorResponse(c, http.StatusConflict, "DRAFT_ALREADY_APPROVED", "İşlem taslağı zaten onaylanmış", nil)
+		return
+	}
+
+	transactionID, err := h.insertTransaction(userID, req, tags)
+	if err != nil {
+		h.db.Exec("UPDATE transaction_drafts SET status = 'pending' WHERE id = ? AND user_id = ?", draft.ID, userID)
+		utils.ErrorResponse(c, http.StatusInternalServerError, "DB_ERROR", "İşlem oluşturulamadı", err.Error())
+		return
+	}
+	h.db.Exec("UPDATE transaction_drafts SET transaction_id = ? WHERE id = ? AND user_id = ?", transactionID, draft.ID, userID)
+
+	transaction, err := h.getTransaction(transactionID, userID)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusInternalServerError, "FETCH_ERROR", "Oluşturulan işlem getirilemedi", err.Error())
+		return
+	}
+
+	utils.CreatedResponse(c, transaction, "İşlem taslağı onaylandı")
+}
+
+// DeleteTransactionDraft işlem taslağını reddetme
+// @Summary İşlem taslağını reddetme
+// @Description Onay bekleyen taslağı ve fiş dosyasını siler
+// @ID deleteTransactionDraft
+// @Tags Finance
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Taslak ID"
+// @Success 200 {object} models.APIResponse
+// @Failure 401 {object} models.APIResponse
+// @Failure 404 {object} models.APIResponse
+// @Failure 409 {object} models.APIResponse
+// @Router /finance/drafts/{id} [delete]
+func (h *FinanceHandler) DeleteTransactionDraft(c *gin.Context) {
+	userID, err := utils.GetUserID(c)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusUnauthorized, "UNAUTHORIZED", "Kullanıcı kimliği doğrulanamadı", nil)
+		return
+	}
+
+	var status string
+	var storageKey sql.NullString
+	err = h.db.QueryRow("SELECT status, storage_key FROM transaction_drafts WHERE id = ? AND user_id = ?", c.Param("id"), userID).Scan(&status, &storageKey)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusNotFound, "DRAFT_NOT_FOUND", "İşlem taslağı bulunamadı", nil)
+		return
+	}
+	// Onaylanan taslağın fişi işleme bağlı olduğu için silinemez
+	if status != "pending" {
+		utils.ErrorResponse(c, http.StatusConflict, "DRAFT_ALREADY_APPROVED", "Onaylanan taslak silinemez", nil)
+		return
+	}
+
+	if _, err := h.db.Exec("DELETE FROM transaction_drafts WHERE id = ? AND user_id = ?", c.Param("id"), userID); err != nil {
+		utils.ErrorResponse(c, http.StatusInternalServerError, "DELETE_ERROR", "İşlem taslağı silinemedi", err.Error())
+		return
+	}
+	if storageKey.Valid {
+		h.store.Delete(storageKey.String)
+	}
+
+	utils.SuccessResponse(c, nil, "İşlem taslağı başarıyla silindi")
+}
+
+// getInboundEmail çiftliğin iletim adresini getirir
+func (h *FinanceHandler) getInboundEmail(userID string) (models.InboundEmailAddress, error) {
+	var address models.InboundEmailAddress
+	var token string
+	err := h.db.QueryRow("SELECT token, created_at FROM inbound_email_addresses WHERE user_id = ?", userID).Scan(&token, &address.CreatedAt)
+	address.Address = services.InboundEmailAddress(token)
+	return address, err
+}
+
+// getTransactionDraft işlem taslağını getirir
+func (h *FinanceHandler) getTransactionDraft(draftID, userID string) (models.TransactionDraft, error) {
+	return scanTransactionDraft(h.db.QueryRow(transactionDraftSelect+" WHERE id = ? AND user_id = ?", draftID, userID))
+}
+
+// scanTransactionDraft transactionDraftSelect ile seçilen satırı taslağa çevirir
+func scanTransactionDraft(row interface{ Scan(...interface{}) error }) (models.TransactionDraft, error) {
+	var draft models.TransactionDraft
+	var transactionID, storageKey sql.NullString
+	var filename, contentType string
+	var size int64
+
+	err := row.Scan(&draft.ID, &draft.UserID, &draft.Source, &draft.Sender, &draft.Subject, &draft.Body, &draft.Type,
+		&draft.Category, &draft.Description, &draft.Amount, &draft.Currency, &draft.Date,
+		&draft.Status, &transactionID, &filename, &contentType, &size, &storageKey,
+		&draft.AttachmentCount, &draft.CreatedAt, &draft.UpdatedAt)
+	if err != nil {
+		return draft, err
+	}
+
+	if transactionID.Valid {
+		draft.TransactionID = &transactionID.String
+	}
+	if storageKey.Valid {
+		draft.Receipt = &models.TransactionReceipt{
+			Filename:    filename,
+			ContentType: contentType,
+			Size:        size,
+			URL:         "/api/v1/finance/drafts/" + draft.ID + "/receipt",
+		}
+	}
+	return draft, nil
+}
+
+// firstFormValue e-posta sağlayıcılarının farklı adlandırdığı form alanlarından ilk dolu olanı döner
+func firstFormValue(c *gin.Context, keys ...string) string {
+	for _, key := range keys {
+		if value := strings.TrimSpace(c.PostForm(key)); value != "" {
+			return value
+		}
+	}
+	return ""
+}
+
+// isReceiptFile ekin fiş olarak saklanabilecek PDF veya görsel dosyası olup olmadığını döner
+func isReceiptFile(fileHeader *multipart.FileHeader) bool {
+	if fileHeader.Size > maxReceiptSize {
+		return false
+	}
+	contentType := fileHeader.Header.Get("Content-Type")
+	if strings.HasPrefix(contentType, "image/") || contentType == "application/pdf" {
+		return true
+	}
+	switch strings.ToLower(filepath.Ext(fileHeader.Filename)) {
+	case ".pdf", ".jpg", ".jpeg", ".png", ".heic", ".webp":
+		return true
+	}
+	return false
+}
+
+// readReceiptFile fiş ekinin içeriğini okur
+func readReceiptFile(fileHeader *multipart.FileHeader) ([]byte, error) {
+	file, err := fileHeader.Open()
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+	return io.ReadAll(file)
+}
+
+// receiptContentType ekin içerik türünü döner; sağlayıcı göndermediyse içerikten tespit edilir
+func receiptContentType(fileHeader *multipart.FileHeader, data []byte) string {
+	if contentType := fileHeader.Header.Get("Content-Type"); contentType != "" && contentType != "application/octet-stream" {
+		return contentType
+	}
+	return http.DetectContentType(data)
+}
@@ -0,0 +1,379 @@
+package handlers
+
+import (
+	"fmt"
+	"net/http"
+	"sort"
+	"strings"
+	"time"
+
+	"agri-management-api/internal/models"
+	"agri-management-api/internal/services"
+	"agri-management-api/internal/utils"
+
+	"github.com/gin-gonic/gin"
+)
+
+// plainTagDimension boyut belirtilmeden girilen etiketlerin boyutu
+const plainTagDimension = "tag"
+
+// maxTransactionTags bir işleme eklenebilecek en fazla etiket sayısı
+const maxTransactionTags = 20
+
+// transactionTag boyut ve değere ayrılmış etiket
+type transactionTag struct {
+	dimension, value string
+}
+
+// String etiketi API'deki "boyut:değer" biçimine çevirir; boyutsuz etiketler yalnızca değerle gösterilir
+func (t transactionTag) String() string {
+	if t.dimension == plainTagDimension {
+		return t.value
+	}
+	return t.dimension + ":" + t.value
+}
+
+// parseTransactionTags "boyut:değer" biçimindeki etiketleri ayrıştırır; boyut küçük harfe çevrilir, tekrarlar atlanır
+func parseTransactionTags(raw []string) ([]transactionTag, error) {
+	if len(raw) > maxTransactionTags {
+		return nil, fmt.Errorf("bir işleme en fazla %d etiket eklenebilir", maxTransactionTags)
+	}
+
+	seen := map[transactionTag]bool{}
+	tags := []transactionTag{}
+	for _, item := range raw {
+		tag := parseTransactionTag(item)
+		if tag.value == "" || tag.dimension == "" {
+			return nil, fmt.Errorf("geçersiz etiket: %q", item)
+		}
+		if len(tag.dimension) > 50 || len(tag.value) > 100 {
+			return nil, fmt.Errorf("etiket çok uzun: %q", item)
+		}
+		if !seen[tag] {
+			seen[tag] = true
+			tags = append(tags, tag)
+		}
+	}
+	return tags, nil
+}
+
+// parseTransactionTag tek bir etiketi boyut ve değere ayırır
+func parseTransactionTag(item string) transactionTag {
+	item = strings.TrimSpace(item)
+	if dimension, value, found := strings.Cut(item, ":"); found {
+		return transactionTag{dimension: strings.ToLower(strings.TrimSpace(dimension)), value: strings.TrimSpace(value)}
+	}
+	return transactionTag{dimension: plainTagDimension, value: item}
+}
+
+// saveTransactionTags işlemin etiketlerini verilen listeyle değiştirir
+func (h *FinanceHandler) saveTransactionTags(userID, transactionID string, tags []transactionTag) error {
+	tx, err := h.db.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	if _, err := tx.Exec("DELETE FROM transaction_tags WHERE transaction_id = ? AND user_id = ?", transactionID, userID); err != nil {
+		return err
+	}
+	for _, tag := range tags {
+		_, err := tx.Exec("INSERT INTO transaction_tags (transaction_id, user_id, dimension, value) VALUES (?, ?, ?, ?)",
+			transactionID, userID, tag.dimension, tag.value)
+		if err != nil {
+			return err
+		}
+	}
+	return tx.Commit()
+}
+
+// attachTransactionTags işlemlerin etiketlerini tek sorguda yükler
+func (h *FinanceHandler) attachTransactionTags(userID string, transactions []models.Transaction) error {
+	if len(transactions) == 0 {
+		return nil
+	}
+
+	index := map[string]int{}
+	placeholders := make([]string, len(transactions))
+	args := []interface{}{userID}
+	for i := range transactions {
+		transactions[i].Tags = []string{}
+		index[transactions[i].ID] = i
+		placeholders[i] = "?"
+		args = append(args, transactions[i].ID)
+	}
+
+	rows, err := h.db.Query(`
+		SELECT transaction_id, dimension, value FROM transaction_tags
+		WHERE user_id = ? AND transaction_id IN (`+strings.Join(placeholders, ", ")+`)
+		ORDER BY dimension, value
+	`, args...)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var transactionID string
+		var tag transactionTag
+		if err := rows.Scan(&transactionID, &tag.dimension, &tag.value); err != nil {
+			return err
+		}
+		if i, ok := index[transactionID]; ok {
+			transactions[i].Tags = append(transactions[i].Tags, tag.String())
+		}
+	}
+	return rows.Err()
+}
+
+// GetTransactionTags işlem etiketleri
+// @Summary İşlem etiketleri
+// @Description Kullanılan etiket boyutlarını ve her boyuttaki değerleri kullanım sayılarıyla listeler; boyutsuz etiketler "tag" boyutunda gösterilir
+// @ID getTransactionTags
+// @Tags Finance
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param dimension query string false "Boyut filtresi (örn. land, season, project)"
+// @Success 200 {object} models.APIResponse{data=[]models.TransactionTagDimension}
+// @Failure 401 {object} models.APIResponse
+// @Router /finance/tags [get]
+func (h *FinanceHandler) GetTransactionTags(c *gin.Context) {
+	userID, err := utils.GetUserID(c)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusUnauthorized, "UNAUTHORIZED", "Kullanıcı kimliği doğrulanamadı", nil)
+		return
+	}
+
+	query := "SELECT dimension, value, COUNT(*) FROM transaction_tags WHERE user_id = ?"
+	args := []interface{}{userID}
+	if dimension := strings.ToLower(strings.TrimSpace(c.Query("dimension"))); dimension != "" {
+		query += " AND dimension = ?"
+		args = append(args, dimension)
+	}
+
+	rows, err := h.db.Query(query+" GROUP BY dimension, value ORDER BY dimension, COUNT(*) DESC, value", args...)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusInternalServerError, "DB_ERROR", "Etiketler alınamadı", err.Error())
+		return
+	}
+	defer rows.Close()
+
+	dimensions := []models.TransactionTagDimension{}
+	for rows.Next() {
+		var dimension string
+		var value models.TransactionTagValue
+		if err := rows.Scan(&dimension, &value.Value, &value.Count); err != nil {
+			continue
+		}
+		if len(dimensions) == 0 || dimensions[len(dimensions)-1].Dimension != dimension {
+			dimensions = append(dimensions, models.TransactionTagDimension{Dimension: dimension, Values: []models.TransactionTagValue{}})
+		}
+		last := &dimensions[len(dimensions)-1]
+		last.Values = append(last.Values, value)
+	}
+
+	utils.SuccessResponse(c, dimensions, "Etiketler başarıyla getirildi")
+}
+
+// GetTagAnalysis etiket boyutuna göre gelir-gider analizi
+// @Summary Etiket boyutuna göre gelir-gider analizi
+// @Description Gelir ve giderleri bir etiket boyutunun değerlerine göre toplar; by verilirse ikinci boyutla çapraz tablo oluşturur. Aynı boyutta birden fazla değeri olan işlemler her değerin satırında sayılır, boş değer etiketsiz işlemleri gösterir
+// @ID getTagAnalysis
+// @Tags Finance
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param dimension query string true "Satır boyutu (örn. land)"
+// @Param by query string false "Sütun boyutu (örn. season)"
+// @Param period query string false "Periyot (month, quarter, year veya mali takvimde tanımlı dönem kodu)" default(year)
+// @Param startDate query string false "Başlangıç tarihi (YYYY-MM-DD)"
+// @Param endDate query string false "Bitiş tarihi (YYYY-MM-DD)"
+// @Param type query string false "İşlem türü (income, expense)"
+// @Success 200 {object} models.APIResponse{data=models.TagAnalysis}
+// @Failure 400 {object} models.APIResponse
+// @Failure 401 {object} models.APIResponse
+// @Router /finance/tags/analysis [get]
+func (h *FinanceHandler) GetTagAnalysis(c *gin.Context) {
+	userID, err := utils.GetUserID(c)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusUnauthorized, "UNAUTHORIZED", "Kullanıcı kimliği doğrulanamadı", nil)
+		return
+	}
+
+	dimension := strings.ToLower(strings.TrimSpace(c.Query("dimension")))
+	by := strings.ToLower(strings.TrimSpace(c.Query("by")))
+	if dimension == "" {
+		utils.ErrorResponse(c, http.StatusBadRequest, "MISSING_DIMENSION", "Etiket boyutu gerekli", nil)
+		return
+	}
+	if by == dimension {
+		utils.ErrorResponse(c, http.StatusBadRequest, "INVALID_DIMENSION", "Satır ve sütun boyutları farklı olmalı", nil)
+		return
+	}
+
+	startDate, endDate, ok := analysisDateRange(c, h.farms, services.PeriodYear)
+	if !ok {
+		return
+	}
+
+	// İkinci boyut verilmezse sütun değeri boş döner ve satır toplamları tek sütunda birikir
+	query := `
+		SELECT COALESCE(rt.value, ''), COALESCE(ct.value, ''), t.type, SUM(t.amount), COUNT(*)
+		FROM transactions t
+		LEFT JOIN transaction_tags rt ON rt.transaction_id = t.id AND rt.dimension = ?
+		LEFT JOIN transaction_tags ct ON ct.transaction_id = t.id AND ct.dimension = ?
+		WHERE t.user_id = ? AND date(t.date) >= ? AND date(t.date) <= ?`
+	filter := " AND t.type IN ('income', 'expense')"
+	filterArgs := []interface{}{userID, startDate.Format("2006-01-02"), endDate.Format("2006-01-02")}
+	if transactionType := c.Query("type"); transactionType == "income" || transactionType == "expense" {
+		filter = " AND t.type = ?"
+		filterArgs = append(filterArgs, transactionType)
+	}
+
+	rows, err := h.db.Query(query+filter+" GROUP BY 1, 2, 3", append([]interface{}{dimension, by}, filterArgs...)...)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusInternalServerError, "DB_ERROR", "Etiket analizi alınamadı", err.Error())
+		return
+	}
+	defer rows.Close()
+
+	analysis := models.TagAnalysis{Dimension: dimension, By: by, StartDate: startDate, EndDate: endDate, Rows: []models.TagAnalysisRow{}}
+	rowIndex := map[string]int{}
+	for rows.Next() {
+		var rowValue, columnValue, transactionType string
+		var amount float64
+		var count int
+		if err := rows.Scan(&rowValue, &columnValue, &transactionType, &amount, &count); err != nil {
+			continue
+		}
+
+		i, exists := rowIndex[rowValue]
+		if !exists {
+			i = len(analysis.Rows)
+			rowIndex[rowValue] = i
+			analysis.Rows = append(analysis.Rows, models.TagAnalysisRow{TagAnalysisCell: models.TagAnalysisCell{Value: rowValue}})
+		}
+		row := &analysis.Rows[i]
+		addTagAmount(&row.TagAnalysisCell, transactionType, amount, count)
+
+		if by != "" {
+			j := -1
+			for k := range row.Columns {
+				if row.Columns[k].Value == columnValue {
+					j = k
+					break
+				}
+			}
+			if j < 0 {
+				row.Columns = append(row.Columns, models.TagAnalysisCell{Value: columnValue})
+				j = len(row.Columns) - 1
+			}
+			addTagAmount(&row.Columns[j], transactionType, amount, count)
+		}
+	}
+
+	// Toplamlar aynı işlemin birden fazla değerde sayılmaması için doğrudan işlemlerden hesaplanır
+	totalsQuery := "SELECT t.type, SUM(t.amount), COUNT(*) FROM transactions t WHERE t.user_id = ? AND date(t.date) >= ? AND date(t.date) <= ?"
+	if err := h.sumTagTotals(&analysis.Totals, totalsQuery+filter+" GROUP BY t.type", filterArgs...); err != nil {
+		utils.ErrorResponse(c, http.StatusInternalServerError, "DB_ERROR", "Etiket analizi alınamadı", err.Error())
+		return
+	}
+
+	for i := range analysis.Rows {
+		row := &analysis.Rows[i]
+		roundTagCell(&row.TagAnalysisCell)
+		for j := range row.Columns {
+			roundTagCell(&row.Columns[j])
+		}
+		sort.SliceStable(row.Columns, func(a, b int) bool { return row.Columns[a].Value < row.Columns[b].Value })
+	}
+	roundTagCell(&analysis.Totals)
+	sort.SliceStable(analysis.Rows, func(a, b int) bool { return analysis.Rows[a].Net > analysis.Rows[b].Net })
+
+	utils.SuccessResponse(c, analysis, "Etiket analizi başarıyla getirildi")
+}
+
+// sumTagTotals türlere göre gruplanmış toplamları hücreye ekler
+func (h *FinanceHandler) sumTagTotals(cell *models.TagAnalysisCell, query string, args ...interface{}) error {
+	rows, err := h.db.Query(query, args...)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var transactionType string
+		var amount float64
+		var count int
+		if err := rows.Scan(&transactionType, &amount, &count); err != nil {
+			return err
+		}
+		addTagAmount(cell, transactionType, amount, count)
+	}
+	return rows.Err()
+}
+
+// addTagAmount tutarı işlem türüne göre hücreye ekler
+func addTagAmount(cell *models.TagAnalysisCell, transactionType string, amount float64, count int) {
+	switch transactionType {
+	case "income":
+		cell.Income += amount
+	case "expense":
+		cell.Expense += amount
+	}
+	cell.Count += count
+	cell.Net = cell.Income - cell.Expense
+}
+
+// roundTagCell hücre tutarlarını yuvarlar
+func roundTagCell(cell *models.TagAnalysisCell) {
+	cell.Income = roundTo2(cell.Income)
+	cell.Expense = roundTo2(cell.Expense)
+	cell.Net = roundTo2(cell.Net)
+}
+
+// analysisDateRange analiz uç noktalarındaki period, startDate ve endDate parametrelerinden dahil aralığı hesaplar;
+// tarih verilmezse mali takvimdeki periyot kullanılır. Hata varsa yanıtı yazar
+func analysisDateRange(c *gin.Context, farms *services.FarmService, defaultPeriod string) (time.Time, time.Time, bool) {
+	periodRange, ok := fiscalPeriodRange(c, farms, c.DefaultQuery("period", defaultPeriod), 1)
+	if !ok {
+		return time.Time{}, time.Time{}, false
+	}
+	startDate := periodRange.StartDate
+	endDate := periodRange.EndDate.AddDate(0, 0, -1)
+
+	var err error
+	if value := c.Query("startDate"); value != "" {
+		if startDate, err = time.Parse("2006-01-02", value); err != nil {
+			utils.ErrorResponse(c, http.StatusBadRequest, "INVALID_DATE", "Geçersiz başlangıç tarihi", nil)
+			return startDate, endDate, false
+		}
+	}
+	if value := c.Query("endDate"); value != "" {
+		if endDate, err = time.Parse("2006-01-02", value); err != nil {
+			utils.ErrorResponse(c, http.StatusBadRequest, "INVALID_DATE", "Geçersiz bitiş tarihi", nil)
+			return startDate, endDate, false
+		}
+	}
+	if endDate.Before(startDate) {
+		utils.ErrorResponse(c, http.StatusBadRequest, "INVALID_DATE_RANGE", "Bitiş tarihi başlangıç tarihinden önce olamaz", nil)
+		return startDate, endDate, false
+	}
+	return startDate, endDate, true
+}
+
+// tagFilterClause tag parametrelerini (her biri "boyut:değer") işlem sorgusu koşuluna çevirir; tüm etiketler eşleşmelidir
+func tagFilterClause(values []string) (string, []interface{}) {
+	var clause strings.Builder
+	var args []interface{}
+	for _, value := range values {
+		tag := parseTransactionTag(value)
+		if tag.value == "" {
+			continue
+		}
+		clause.WriteString(" AND EXISTS (SELECT 1 FROM transaction_tags tf WHERE tf.transaction_id = transactions.id AND tf.dimension = ? AND tf.value = ?)")
+		args = append(args, tag.dimension, tag.value)
+	}
+	return clause.String(), args
+}
@@ -0,0 +1,771 @@
+package handlers
+
+import (
+	"database/sql"
+	"fmt"
+	"log"
+	"math"
+	"net/http"
+	"strconv"
+	"time"
+
+	"agri-management-api/internal/models"
+	"agri-management-api/internal/services"
+	"agri-management-api/internal/utils"
+
+	"github.com/gin-gonic/gin"
+)
+
+// utilityExpenseCategories sayaç türlerinin gider kategorileri
+var utilityExpenseCategories = map[string]string{
+	"electricity": "Elektrik",
+	"water":       "Su",
+	"fuel":        "Akaryakıt",
+}
+
+// utilityDefaultUnits sayaç türlerinin varsayılan birimleri
+var utilityDefaultUnits = map[string]string{
+	"electricity": "kWh",
+	"water":       "m³",
+	"fuel":        "L",
+}
+
+// spikeBaselineReadings artış tespitinde ortalaması alınan önceki okuma sayısı
+const spikeBaselineReadings = 6
+
+// UtilityHandler enerji, su ve yakıt sayaçlarını yönetir
+type UtilityHandler struct {
+	db            *sql.DB
+	notifications *services.NotificationService
+}
+
+// NewUtilityHandler yeni utility handler oluşturur
+func NewUtilityHandler(db *sql.DB) *UtilityHandler {
+	return &UtilityHandler{
+		db:            db,
+		notifications: services.NewNotificationService(db),
+	}
+}
+
+// GetMeters sayaç listesi
+// @Summary Sayaç listesi
+// @Description Elektrik, su ve yakıt sayaçlarını son okumalarıyla listeler
+// @ID getMeters
+// @Tags Utilities
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param type query string false "Sayaç türü (electricity, water, fuel)"
+// @Param facility query string false "Tesis"
+// @Success 200 {object} models.APIResponse{data=[]models.UtilityMeter}
+// @Failure 401 {object} models.APIResponse
+// @Router /utilities/meters [get]
+func (h *UtilityHandler) GetMeters(c *gin.Context) {
+	userID, err := utils.GetUserID(c)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusUnauthorized, "UNAUTHORIZED", "Kullanıcı kimliği doğrulanamadı", nil)
+		return
+	}
+
+	query := utilityMeterSelect + " WHERE m.user_id = ?"
+	args := []interface{}{userID}
+	if meterType := c.Query("type"); meterType != "" {
+		query += " AND m.type = ?"
+		args = append(args, meterType)
+	}
+	if facility := c.Query("facility"); facility != "" {
+		query += " AND m.facility = ?"
+		args = append(args, facility)
+	}
+
+	rows, err := h.db.Query(query+" ORDER BY m.type, m.name", args...)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusInternalServerError, "DB_ERROR", "Sayaçlar alınamadı", err.Error())
+		return
+	}
+	defer rows.Close()
+
+	meters := []models.UtilityMeter{}
+	for rows.Next() {
+		meter, err := scanUtilityMeter(rows)
+		if err != nil {
+			continue
+		}
+		meters = append(meters, meter)
+	}
+
+	utils.SuccessResponse(c, meters, "Sayaçlar başarıyla getirildi")
+}
+
+// GetMeter sayaç detayı
+// @Summary Sayaç detayı
+// @Description Sayacı son okumasıyla getirir
+// @ID getMeter
+// @Tags Utilities
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Sayaç ID"
+// @Success 200 {object} models.APIResponse{data=models.UtilityMeter}
+// @Failure 401 {object} models.APIResponse
+// @Failure 404 {object} models.APIResponse
+// @Router /utilities/meters/{id} [get]
+func (h *UtilityHandler) GetMeter(c *gin.Context) {
+	userID, err := utils.GetUserID(c)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusUnauthorized, "UNAUTHORIZED", "Kullanıcı kimliği doğrulanamadı", nil)
+		return
+	}
+
+	meter, err := h.getMeter(c.Param("id"), userID)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusNotFound, "METER_NOT_FOUND", "Sayaç bulunamadı", nil)
+		return
+	}
+
+	links := services.EntityLinks("utility_meter", meter.ID, services.RelatedLink{Rel: "land", EntityType: "land", ID: meter.LandID})
+	utils.DetailResponse(c, meter, links, "Sayaç başarıyla getirildi")
+}
+
+// CreateMeter sayaç ekleme
+// @Summary Sayaç ekleme
+// @Description Elektrik, su veya yakıt sayacı ekler; birim fiyat girilirse okumaların maliyeti finansa gider olarak işlenir
+// @ID createMeter
+// @Tags Utilities
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param request body models.UtilityMeter true "Sayaç bilgileri"
+// @Success 201 {object} models.APIResponse{data=models.UtilityMeter}
+// @Failure 400 {object} models.APIResponse
+// @Failure 401 {object} models.APIResponse
+// @Router /utilities/meters [post]
+func (h *UtilityHandler) CreateMeter(c *gin.Context) {
+	userID, err := utils.GetUserID(c)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusUnauthorized, "UNAUTHORIZED", "Kullanıcı kimliği doğrulanamadı", nil)
+		return
+	}
+
+	var req models.UtilityMeter
+	if err := c.ShouldBindJSON(&req); err != nil {
+		utils.ErrorResponse(c, http.StatusBadRequest, "INVALID_REQUEST", "Geçersiz istek formatı", err.Error())
+		return
+	}
+
+	if !h.prepareMeter(c, userID, &req) {
+		return
+	}
+
+	meterID := utils.GenerateID()
+	_, err = h.db.Exec(`
+		INSERT INTO utility_meters (id, user_id, name, type, unit, reading_mode, facility, land_id, unit_price,
+		                            spike_threshold, notes, created_at, updated_at)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, CURRENT_TIMESTAMP, CURRENT_TIMESTAMP)
+	`, meterID, userID, req.Name, req.Type, req.Unit, req.ReadingMode, req.Facility, req.LandID, req.UnitPrice,
+		req.SpikeThreshold, req.Notes)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusInternalServerError, "DB_ERROR", "Sayaç oluşturulamadı", err.Error())
+		return
+	}
+
+	meter, err := h.getMeter(meterID, userID)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusInternalServerError, "FETCH_ERROR", "Oluşturulan sayaç getirilemedi", err.Error())
+		return
+	}
+
+	utils.CreatedResponse(c, meter, "Sayaç başarıyla oluşturuldu")
+}
+
+// UpdateMeter sayaç güncelleme
+// @Summary Sayaç güncelleme
+// @Description Sayaç bilgilerini günceller; yeni birim fiyat yalnızca sonraki okumalara uygulanır
+// @ID updateMeter
+// @Tags Utilities
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Sayaç ID"
+// @Param request body models.UtilityMeter true "Sayaç bilgileri"
+// @Success 200 {object} models.APIResponse{data=models.UtilityMeter}
+// @Failure 400 {object} models.APIResponse
+// @Failure 401 {object} models.APIResponse
+// @Failure 404 {object} models.APIResponse
+// @Router /utilities/meters/{id} [put]
+func (h *UtilityHandler) UpdateMeter(c *gin.Context) {
+	userID, err := utils.GetUserID(c)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusUnauthorized, "UNAUTHORIZED", "Kullanıcı kimliği doğrulanamadı", nil)
+		return
+	}
+
+	meterID := c.Param("id")
+
+	var req models.UtilityMeter
+	if err := c.ShouldBindJSON(&req); err != nil {
+		utils.ErrorResponse(c, http.StatusBadRequest, "INVALID_REQUEST", "Geçersiz istek formatı", err.Error())
+		return
+	}
+
+	if !h.prepareMeter(c, userID, &req) {
+		return
+	}
+
+	result, err := h.db.Exec(`
+		UPDATE utility_meters SET name = ?, type = ?, unit = ?, reading_mode = ?, facility = ?, land_id = ?,
+		                          unit_price = ?, spike_threshold = ?, notes = ?, updated_at = CURRENT_TIMESTAMP
+		WHERE id = ? AND user_id = ?
+	`, req.Name, req.Type, req.Unit, req.ReadingMode, req.Facility, req.LandID, req.UnitPrice, req.SpikeThreshold,
+		req.Notes, meterID, userID)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusInternalServerError, "UPDATE_ERROR", "Sayaç güncellenemedi", err.Error())
+		return
+	}
+
+	rowsAffected, _ := result.RowsAffected()
+	if rowsAffected == 0 {
+		utils.ErrorResponse(c, http.StatusNotFound, "METER_NOT_FOUND", "Sayaç bulunamadı", nil)
+		return
+	}
+
+	meter, err := h.getMeter(meterID, userID)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusInternalServerError, "FETCH_ERROR", "Güncellenen sayaç getirilemedi", err.Error())
+		return
+	}
+
+	utils.SuccessResponse(c, meter, "Sayaç başarıyla güncellendi")
+}
+
+// DeleteMeter sayaç silme
+// @Summary Sayaç silme
+// @Description Sayacı ve okumalarını siler; finansa işlenmiş gider işlemleri korunur
+// @ID deleteMeter
+// @Tags Utilities
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Sayaç ID"
+// @Param force query bool false "Başka kayıtlarla bağlantısı olsa da sil"
+// @Success 200 {object} models.APIResponse
+// @Failure 401 {object} models.APIResponse
+// @Failure 404 {object} models.APIResponse
+// @Failure 409 {object} models.APIResponse
+// @Router /utilities/meters/{id} [delete]
+func (h *UtilityHandler) DeleteMeter(c *gin.Context) {
+	userID, err := utils.GetUserID(c)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusUnauthorized, "UNAUTHORIZED", "Kullanıcı kimliği doğrulanamadı", nil)
+		return
+	}
+
+	meterID := c.Param("id")
+
+	if !confirmLinkedDelete(c, h.db, userID, "utility_meter", meterID) {
+		return
+	}
+
+	result, err := h.db.Exec("DELETE FROM utility_meters WHERE id = ? AND user_id = ?", meterID, userID)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusInternalServerError, "DELETE_ERROR", "Sayaç silinemedi", err.Error())
+		return
+	}
+
+	rowsAffected, _ := result.RowsAffected()
+	if rowsAffected == 0 {
+		utils.ErrorResponse(c, http.StatusNotFound, "METER_NOT_FOUND", "Sayaç bulunamadı", nil)
+		return
+	}
+
+	h.db.Exec("DELETE FROM meter_readings WHERE meter_id = ? AND user_id = ?", meterID, userID)
+
+	removeRecordLinks(h.db, userID, "utility_meter", meterID)
+
+	utils.SuccessResponse(c, nil, "Sayaç başarıyla silindi")
+}
+
+// GetReadings sayaç okumaları
+// @Summary Sayaç okumaları
+// @Description Sayacın okumalarını tüketim, maliyet ve artış uyarısı bilgileriyle listeler
+// @ID getReadings
+// @Tags Utilities
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Sayaç ID"
+// @Param startDate query string false "Başlangıç tarihi (YYYY-MM-DD)"
+// @Param endDate query string false "Bitiş tarihi (YYYY-MM-DD)"
+// @Success 200 {object} models.APIResponse{data=[]models.MeterReading}
+// @Failure 401 {object} models.APIResponse
+// @Failure 404 {object} models.APIResponse
+// @Router /utilities/meters/{id}/readings [get]
+func (h *UtilityHandler) GetReadings(c *gin.Context) {
+	userID, err := utils.GetUserID(c)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusUnauthorized, "UNAUTHORIZED", "Kullanıcı kimliği doğrulanamadı", nil)
+		return
+	}
+
+	meter, err := h.getMeter(c.Param("id"), userID)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusNotFound, "METER_NOT_FOUND", "Sayaç bulunamadı", nil)
+		return
+	}
+
+	query := meterReadingSelect + " WHERE meter_id = ? AND user_id = ?"
+	args := []interface{}{meter.ID, userID}
+	if startDate, err := time.Parse("2006-01-02", c.Query("startDate")); err == nil {
+		query += " AND reading_date >= ?"
+		args = append(args, startDate)
+	}
+	if endDate, err := time.Parse("2006-01-02", c.Query("endDate")); err == nil {
+		query += " AND reading_date < ?"
+		args = append(args, endDate.AddDate(0, 0, 1))
+	}
+
+	rows, err := h.db.Query(query+" ORDER BY reading_date DESC", args...)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusInternalServerError, "DB_ERROR", "Okumalar alınamadı", err.Error())
+		return
+	}
+	defer rows.Close()
+
+	readings := []models.MeterReading{}
+	for rows.Next() {
+		reading, err := scanMeterReading(rows)
+		if err != nil {
+			continue
+		}
+		readings = append(readings, reading)
+	}
+
+	utils.SuccessResponse(c, readings, "Okumalar başarıyla getirildi")
+}
+
+// CreateReading sayaç okuması ekleme
+// @Summary Sayaç okuması ekleme
+// @Description Okumayı kaydeder, önceki okumaya göre tüketimi ve maliyeti hesaplar, maliyeti finansa gider olarak işler; günlük tüketim önceki okumaların ortalamasını eşik yüzdesinden fazla aşarsa uyarı bildirimi gönderir
+// @ID createReading
+// @Tags Utilities
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Sayaç ID"
+// @Param request body models.MeterReading true "Okuma bilgileri (sayaç değeri veya dönem tüketimi)"
+// @Success 201 {object} models.APIResponse{data=models.MeterReading}
+// @Failure 400 {object} models.APIResponse
+// @Failure 401 {object} models.APIResponse
+// @Failure 404 {object} models.APIResponse
+// @Router /utilities/meters/{id}/readings [post]
+func (h *UtilityHandler) CreateReading(c *gin.Context) {
+	userID, err := utils.GetUserID(c)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusUnauthorized, "UNAUTHORIZED", "Kullanıcı kimliği doğrulanamadı", nil)
+		return
+	}
+
+	meter, err := h.getMeter(c.Param("id"), userID)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusNotFound, "METER_NOT_FOUND", "Sayaç bulunamadı", nil)
+		return
+	}
+
+	var req models.MeterReading
+	if err := c.ShouldBindJSON(&req); err != nil {
+		utils.ErrorResponse(c, http.StatusBadRequest, "INVALID_REQUEST", "Geçersiz istek formatı", err.Error())
+		return
+	}
+
+	// Okumalar sırayla girilir; tüketim her zaman bir önceki okumaya göre hesaplanır
+	if meter.LastReadingDate != nil && !req.ReadingDate.After(*meter.LastReadingDate) {
+		utils.ErrorResponse(c, http.StatusBadRequest, "INVALID_READING_DATE", "Okuma tarihi son okumadan sonra olmalı", nil)
+		return
+	}
+
+	reading := models.MeterReading{
+		ID:          utils.GenerateID(),
+		MeterID:     meter.ID,
+		ReadingDate: req.ReadingDate,
+		Value:       req.Value,
+		Notes:       req.Notes,
+	}
+
+	days := 1.0
+	if meter.LastReadingDate != nil {
+		days = math.Max(req.ReadingDate.Sub(*meter.LastReadingDate).Hours()/24, 1)
+	}
+
+	switch {
+	case meter.ReadingMode == models.MeterReadingConsumption:
+		reading.Consumption = req.Value
+	case meter.LastValue != nil:
+		if req.Value < *meter.LastValue {
+			utils.ErrorResponse(c, http.StatusBadRequest, "INVALID_METER_VALUE", "Sayaç değeri son okumadan küçük olamaz", gin.H{
+				"lastValue": *meter.LastValue,
+			})
+			return
+		}
+		reading.Consumption = roundTo2(req.Value - *meter.LastValue)
+	}
+
+	// Sayaç değerli sayaçlarda ilk okuma başlangıç değeridir; tüketim ve maliyet oluşmaz
+	if meter.ReadingMode == models.MeterReadingConsumption || meter.LastValue != nil {
+		reading.DailyAverage = roundTo2(reading.Consumption / days)
+		reading.Cost = roundTo2(reading.Consumption * meter.UnitPrice)
+
+		baseline, err := h.baselineDailyAverage(meter)
+		if err != nil {
+			utils.ErrorResponse(c, http.StatusInternalServerError, "DB_ERROR", "Tüketim ortalaması hesaplanamadı", err.Error())
+			return
+		}
+		reading.Anomaly = baseline > 0 && reading.DailyAverage > baseline*(1+meter.SpikeThreshold/100)
+	}
+
+	tx, err := h.db.Begin()
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusInternalServerError, "DB_ERROR", "Okuma kaydedilemedi", err.Error())
+		return
+	}
+	defer tx.Rollback()
+
+	if reading.Cost > 0 {
+		reading.TransactionID = utils.GenerateID()
+		description := fmt.Sprintf("%s tüketimi - %s (%s %s)", utilityExpenseCategories[meter.Type], meter.Name,
+			strconv.FormatFloat(reading.Consumption, 'f', -1, 64), meter.Unit)
+
+		_, err = tx.Exec(`
+			INSERT INTO transactions (id, user_id, type, category, description, amount, currency,
+			                         date, status, payment_method, receipt, notes, created_at, updated_at)
+			VALUES (?, ?, 'expense', ?, ?, ?, 'TRY', ?, 'completed', '', '', '', CURRENT_TIMESTAMP, CURRENT_TIMESTAMP)
+		`, reading.TransactionID, userID, utilityExpenseCategories[meter.Type], description, reading.Cost, reading.ReadingDate)
+		if err != nil {
+			utils.ErrorResponse(c, http.StatusInternalServerError, "DB_ERROR", "Tüketim gideri oluşturulamadı", err.Error())
+			return
+		}
+	}
+
+	_, err = tx.Exec(`
+		INSERT INTO meter_readings (id, meter_id, user_id, reading_date, value, consumption, cost, daily_average,
+		                            anomaly, transaction_id, notes, created_at)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, CURRENT_TIMESTAMP)
+	`, reading.ID, meter.ID, userID, reading.ReadingDate, reading.Value, reading.Consumption, reading.Cost,
+		reading.DailyAverage, reading.Anomaly, utils.StringToNullString(reading.TransactionID), reading.Notes)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusInternalServerError, "DB_ERROR", "Okuma kaydedilemedi", err.Error())
+		return
+	}
+
+	if err := tx.Commit(); err != nil {
+		utils.ErrorResponse(c, http.StatusInternalServerError, "DB_ERROR", "Okuma kaydedilemedi", err.Error())
+		return
+	}
+
+	if reading.Anomaly {
+		h.notifySpike(userID, meter, reading)
+	}
+
+	created, err := scanMeterReading(h.db.QueryRow(meterReadingSelect+" WHERE id = ? AND user_id = ?", reading.ID, userID))
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusInternalServerError, "FETCH_ERROR", "Oluşturulan okuma getirilemedi", err.Error())
+		return
+	}
+
+	utils.CreatedResponse(c, created, "Okuma başarıyla kaydedildi")
+}
+
+// DeleteReading sayaç okuması silme
+// @Summary Sayaç okuması silme
+// @Description Sayacın son okumasını ve oluşturduğu gider işlemini siler; önceki okumalar sonraki tüketimleri etkilediği için yalnızca son okuma silinebilir
+// @ID deleteReading
+// @Tags Utilities
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Sayaç ID"
+// @Param readingId path string true "Okuma ID"
+// @Success 200 {object} models.APIResponse
+// @Failure 400 {object} models.APIResponse
+// @Failure 401 {object} models.APIResponse
+// @Failure 404 {object} models.APIResponse
+// @Router /utilities/meters/{id}/readings/{readingId} [delete]
+func (h *UtilityHandler) DeleteReading(c *gin.Context) {
+	userID, err := utils.GetUserID(c)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusUnauthorized, "UNAUTHORIZED", "Kullanıcı kimliği doğrulanamadı", nil)
+		return
+	}
+
+	meter, err := h.getMeter(c.Param("id"), userID)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusNotFound, "METER_NOT_FOUND", "Sayaç bulunamadı", nil)
+		return
+	}
+
+	var latestID, transactionID string
+	err = h.db.QueryRow(`
+		SELECT id, COALESCE(transaction_id, '') FROM meter_readings WHERE meter_id = ? AND user_id = ?
+		ORDER BY reading_date DESC LIMIT 1
+	`, meter.ID, userID).Scan(&latestID, &transactionID)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusNotFound, "READING_NOT_FOUND", "Okuma bulunamadı", nil)
+		return
+	}
+	if latestID != c.Param("readingId") {
+		utils.ErrorResponse(c, http.StatusBadRequest, "NOT_LATEST_READING", "Yalnızca son okuma silinebilir", nil)
+		return
+	}
+
+	tx, err := h.db.Begin()
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusInternalServerError, "DB_ERROR", "Okuma silinemedi", err.Error())
+		return
+	}
+	defer tx.Rollback()
+
+	if _, err := tx.Exec("DELETE FROM meter_readings WHERE id = ? AND user_id = ?", latestID, userID); err != nil {
+		utils.ErrorResponse(c, http.StatusInternalServerError, "DELETE_ERROR", "Okuma silinemedi", err.Error())
+		return
+	}
+	if transactionID != "" {
+		if _, err := tx.Exec("DELETE FROM transactions WHERE id = ? AND user_id = ?", transactionID, userID); err != nil {
+			utils.ErrorResponse(c, http.StatusInternalServerError, "DELETE_ERROR", "Tüketim gideri silinemedi", err.Error())
+			return
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		utils.ErrorResponse(c, http.StatusInternalServerError, "DB_ERROR", "Okuma silinemedi", err.Error())
+		return
+	}
+
+	utils.SuccessResponse(c, nil, "Okuma başarıyla silindi")
+}
+
+// GetUtilityAnalytics tüketim analizi
+// @Summary Enerji ve su tüketim analizi
+// @Description Yıl içindeki tüketim ve maliyeti ay ve tesis bazında, sayaç türlerine göre özetler; artış uyarısı veren okumaları listeler
+// @ID getUtilityAnalytics
+// @Tags Utilities
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param year query int false "Yıl (varsayılan: bu yıl)"
+// @Param type query string false "Sayaç türü (electricity, water, fuel)"
+// @Success 200 {object} models.APIResponse{data=models.UtilityAnalytics}
+// @Failure 401 {object} models.APIResponse
+// @Router /utilities/analytics [get]
+func (h *UtilityHandler) GetUtilityAnalytics(c *gin.Context) {
+	userID, err := utils.GetUserID(c)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusUnauthorized, "UNAUTHORIZED", "Kullanıcı kimliği doğrulanamadı", nil)
+		return
+	}
+
+	year := time.Now().Year()
+	if value, err := strconv.Atoi(c.Query("year")); err == nil {
+		year = value
+	}
+	start := time.Date(year, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	query := `
+		SELECT r.id, r.meter_id, r.reading_date, r.value, r.consumption, COALESCE(r.cost, 0),
+		       COALESCE(r.daily_average, 0), COALESCE(r.anomaly, false), COALESCE(r.transaction_id, ''),
+		       COALESCE(r.notes, ''), r.created_at, m.type, m.unit, COALESCE(m.facility, '')
+		FROM meter_readings r
+		JOIN utility_meters m ON r.meter_id = m.id
+		WHERE r.user_id = ? AND r.reading_date >= ? AND r.reading_date < ?
+	`
+	args := []interface{}{userID, start, start.AddDate(1, 0, 0)}
+	if meterType := c.Query("type"); meterType != "" {
+		query += " AND m.type = ?"
+		args = append(args, meterType)
+	}
+
+	rows, err := h.db.Query(query+" ORDER BY r.reading_date", args...)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusInternalServerError, "DB_ERROR", "Tüketim verileri alınamadı", err.Error())
+		return
+	}
+	defer rows.Close()
+
+	analytics := models.UtilityAnalytics{
+		Year:       year,
+		Months:     []models.UtilityMonthlyUsage{},
+		Facilities: []models.UtilityFacilityUsage{},
+		Anomalies:  []models.MeterReading{},
+	}
+	monthIndex := map[string]int{}
+	facilityIndex := map[string]int{}
+
+	for rows.Next() {
+		var reading models.MeterReading
+		var readingDate sql.NullTime
+		var meterType, unit, facility string
+
+		err := rows.Scan(
+			&reading.ID, &reading.MeterID, &readingDate, &reading.Value, &reading.Consumption, &reading.Cost,
+			&reading.DailyAverage, &reading.Anomaly, &reading.TransactionID, &reading.Notes, &reading.CreatedAt,
+			&meterType, &unit, &facility,
+		)
+		if err != nil || !readingDate.Valid {
+			continue
+		}
+		reading.ReadingDate = &readingDate.Time
+		if facility == "" {
+			facility = "Genel"
+		}
+
+		// Tüketim okumanın yapıldığı aya yazılır
+		month := readingDate.Time.Format("2006-01")
+		i, ok := monthIndex[month+meterType]
+		if !ok {
+			analytics.Months = append(analytics.Months, models.UtilityMonthlyUsage{Month: month, Type: meterType, Unit: unit})
+			i = len(analytics.Months) - 1
+			monthIndex[month+meterType] = i
+		}
+		analytics.Months[i].Consumption = roundTo2(analytics.Months[i].Consumption + reading.Consumption)
+		analytics.Months[i].Cost = roundTo2(analytics.Months[i].Cost + reading.Cost)
+
+		j, ok := facilityIndex[facility+meterType]
+		if !ok {
+			analytics.Facilities = append(analytics.Facilities, models.UtilityFacilityUsage{Facility: facility, Type: meterType, Unit: unit})
+			j = len(analytics.Facilities) - 1
+			facilityIndex[facility+meterType] = j
+		}
+		analytics.Facilities[j].Consumption = roundTo2(analytics.Facilities[j].Consumption + reading.Consumption)
+		analytics.Facilities[j].Cost = roundTo2(analytics.Facilities[j].Cost + reading.Cost)
+
+		if reading.Anomaly {
+			analytics.Months[i].Anomalies++
+			analytics.Anomalies = append(analytics.Anomalies, reading)
+		}
+		analytics.TotalCost = roundTo2(analytics.TotalCost + reading.Cost)
+	}
+
+	utils.SuccessResponse(c, analytics, "Tüketim analizi başarıyla getirildi")
+}
+
+// utilityMeterSelect sayaçları son okumalarıyla seçen sorgu
+const utilityMeterSelect = `
+	SELECT m.id, m.user_id, m.name, m.type, m.unit, m.reading_mode, COALESCE(m.facility, ''), m.land_id,
+	       COALESCE(m.unit_price, 0), COALESCE(m.spike_threshold, 50), COALESCE(m.notes, ''), m.created_at, m.updated_at,
+	       r.value, r.reading_date
+	FROM utility_meters m
+	LEFT JOIN meter_readings r ON r.id = (
+		SELECT id FROM meter_readings WHERE meter_id = m.id ORDER BY reading_date DESC LIMIT 1
+	)
+`
+
+// meterReadingSelect sayaç okuması sütunları
+const meterReadingSelect = `
+	SELECT id, meter_id, reading_date, value, consumption, COALESCE(cost, 0), COALESCE(daily_average, 0),
+	       COALESCE(anomaly, false), COALESCE(transaction_id, ''), COALESCE(notes, ''), created_at
+	FROM meter_readings
+`
+
+// scanUtilityMeter sayaç satırını okur
+func scanUtilityMeter(row interface{ Scan(...interface{}) error }) (models.UtilityMeter, error) {
+	var meter models.UtilityMeter
+	var landID sql.NullString
+	var lastValue sql.NullFloat64
+	var lastReadingDate sql.NullTime
+
+	err := row.Scan(
+		&meter.ID, &meter.UserID, &meter.Name, &meter.Type, &meter.Unit, &meter.ReadingMode, &meter.Facility, &landID,
+		&meter.UnitPrice, &meter.SpikeThreshold, &meter.Notes, &meter.CreatedAt, &meter.UpdatedAt,
+		&lastValue, &lastReadingDate,
+	)
+	if err != nil {
+		return meter, err
+	}
+
+	meter.LandID = utils.NullStringToPtr(landID)
+	meter.LastValue = utils.NullFloat64ToPtr(lastValue)
+	meter.LastReadingDate = utils.NullTimeToPtr(lastReadingDate)
+	return meter, nil
+}
+
+// scanMeterReading okuma satırını okur
+func scanMeterReading(row interface{ Scan(...interface{}) error }) (models.MeterReading, error) {
+	var reading models.MeterReading
+	var readingDate sql.NullTime
+
+	err := row.Scan(
+		&reading.ID, &reading.MeterID, &readingDate, &reading.Value, &reading.Consumption, &reading.Cost,
+		&reading.DailyAverage, &reading.Anomaly, &reading.TransactionID, &reading.Notes, &reading.CreatedAt,
+	)
+	if err != nil {
+		return reading, err
+	}
+
+	reading.ReadingDate = utils.NullTimeToPtr(readingDate)
+	return reading, nil
+}
+
+// getMeter kullanıcının sayacını getirir
+func (h *UtilityHandler) getMeter(meterID, userID string) (models.UtilityMeter, error) {
+	return scanUtilityMeter(h.db.QueryRow(utilityMeterSelect+" WHERE m.id = ? AND m.user_id = ?", meterID, userID))
+}
+
+// prepareMeter varsayılanları doldurur ve araziyi doğrular; hata varsa yanıtı yazar
+func (h *UtilityHandler) prepareMeter(c *gin.Context, userID string, req *models.UtilityMeter) bool {
+	if req.Unit == "" {
+		req.Unit = utilityDefaultUnits[req.Type]
+	}
+	if req.ReadingMode == "" {
+		req.ReadingMode = models.MeterReadingCumulative
+	}
+	if req.SpikeThreshold == 0 {
+		req.SpikeThreshold = 50
+	}
+
+	if req.LandID != nil && *req.LandID != "" {
+		var exists bool
+		err := h.db.QueryRow("SELECT 1 FROM lands WHERE id = ? AND user_id = ?", *req.LandID, userID).Scan(&exists)
+		if err != nil {
+			utils.ErrorResponse(c, http.StatusBadRequest, "LAND_NOT_FOUND", "Arazi bulunamadı", nil)
+			return false
+		}
+	} else {
+		req.LandID = nil
+	}
+
+	return true
+}
+
+// baselineDailyAverage sayacın son okumalarındaki günlük ortalama tüketimi döner; en az iki okuma yoksa sıfırdır
+func (h *UtilityHandler) baselineDailyAverage(meter models.UtilityMeter) (float64, error) {
+	var average float64
+	var count int
+	err := h.db.QueryRow(`
+		SELECT COALESCE(AVG(daily_average), 0), COUNT(*) FROM (
+			SELECT daily_average FROM meter_readings
+			WHERE meter_id = ? AND user_id = ? AND consumption > 0
+			ORDER BY reading_date DESC LIMIT ?
+		)
+	`, meter.ID, meter.UserID, spikeBaselineReadings).Scan(&average, &count)
+	if err != nil || count < 2 {
+		return 0, err
+	}
+	return average, nil
+}
+
+// notifySpike tüketim artışını bildirir; bildirim hatası okumayı engellemez
+func (h *UtilityHandler) notifySpike(userID string, meter models.UtilityMeter, reading models.MeterReading) {
+	message := fmt.Sprintf("%s sayacında günlük tüketim %s %s/gün oldu ve olağan seviyenin üzerine çıktı. Kaçak veya arızalı ekipman (örn. takılı kalan su pompası) olup olmadığını kontrol edin.",
+		meter.Name, strconv.FormatFloat(reading.DailyAverage, 'f', -1, 64), meter.Unit)
+
+	_, err := h.notifications.Create(services.Notification{
+		UserID:   userID,
+		Title:    "Olağandışı Tüketim",
+		Message:  message,
+		Type:     "alert",
+		Priority: "high",
+		Topic:    models.NotificationTopicConsumptionSpike,
+		Entity:   &models.RelatedEntity{Type: "utility_meter", ID: meter.ID, Name: meter.Name},
+	})
+	if err != nil {
+		log.Printf("Tüketim uyarısı oluşturulamadı: %v", err)
+	}
+}
@@ -0,0 +1,233 @@
+package handlers
+
+import (
+	"database/sql"
+	"errors"
+	"net/http"
+	"strconv"
+	"time"
+
+	"agri-management-api/internal/models"
+	"agri-management-api/internal/services"
+	"agri-management-api/internal/utils"
+
+	"github.com/gin-gonic/gin"
+)
+
+// VaccinationHandler hayvanların aşı takvimini yönetir
+type VaccinationHandler struct {
+	db           *sql.DB
+	vaccinations *services.VaccinationService
+}
+
+// NewVaccinationHandler yeni vaccination handler oluşturur
+func NewVaccinationHandler(db *sql.DB) *VaccinationHandler {
+	return &VaccinationHandler{
+		db:           db,
+		vaccinations: services.NewVaccinationService(db),
+	}
+}
+
+// GetVaccinations hayvanın aşı takvimi
+// @Summary Hayvanın aşı takvimi
+// @Description Hayvanın planlanan ve yapılan aşılarını tarih sırasıyla durumlarıyla (scheduled, overdue, administered) listeler
+// @ID getVaccinations
+// @Tags Livestock
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Hayvan ID"
+// @Success 200 {object} models.APIResponse{data=[]models.Vaccination}
+// @Failure 401 {object} models.APIResponse
+// @Failure 404 {object} models.APIResponse
+// @Router /livestock/{id}/vaccinations [get]
+func (h *VaccinationHandler) GetVaccinations(c *gin.Context) {
+	userID, err := utils.GetUserID(c)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusUnauthorized, "UNAUTHORIZED", "Kullanıcı kimliği doğrulanamadı", nil)
+		return
+	}
+
+	vaccinations, err := h.vaccinations.List(userID, c.Param("id"))
+	if err != nil {
+		writeVaccinationError(c, err, "Aşı takvimi alınamadı")
+		return
+	}
+
+	utils.SuccessResponse(c, vaccinations, "Aşı takvimi başarıyla getirildi")
+}
+
+// GetVaccination aşı takvimi kaydı
+// @Summary Aşı takvimi kaydı
+// @Description Hayvanın aşı takvimindeki kaydı getirir
+// @ID getVaccination
+// @Tags Livestock
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Hayvan ID"
+// @Param vaccinationId path string true "Aşı ID"
+// @Success 200 {object} models.APIResponse{data=models.Vaccination}
+// @Failure 401 {object} models.APIResponse
+// @Failure 404 {object} models.APIResponse
+// @Router /livestock/{id}/vaccinations/{vaccinationId} [get]
+func (h *VaccinationHandler) GetVaccination(c *gin.Context) {
+	userID, err := utils.GetUserID(c)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusUnauthorized, "UNAUTHORIZED", "Kullanıcı kimliği doğrulanamadı", nil)
+		return
+	}
+
+	vaccination, err := h.vaccinations.Get(userID, c.Param("id"), c.Param("vaccinationId"))
+	if err != nil {
+		writeVaccinationError(c, err, "Aşı kaydı alınamadı")
+		return
+	}
+
+	utils.SuccessResponse(c, vaccination, "Aşı kaydı başarıyla getirildi")
+}
+
+// CreateVaccination aşı planlama
+// @Summary Aşı planla
+// @Description Hayvana aşı planlar. administeredDate verilirse aşı yapılmış sayılır ve hayvanın sağlık kayıtlarına aşı kaydı eklenir. Tarihi geçmiş yapılmamış aşısı olan sağlıklı hayvanların sağlık durumu vaccination_needed olur, aşıları tamamlanınca yeniden healthy olur
+// @ID createVaccination
+// @Tags Livestock
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Hayvan ID"
+// @Param request body models.VaccinationRequest true "Aşı bilgileri"
+// @Success 201 {object} models.APIResponse{data=models.Vaccination}
+// @Failure 400 {object} models.APIResponse
+// @Failure 401 {object} models.APIResponse
+// @Failure 404 {object} models.APIResponse
+// @Router /livestock/{id}/vaccinations [post]
+func (h *VaccinationHandler) CreateVaccination(c *gin.Context) {
+	userID, err := utils.GetUserID(c)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusUnauthorized, "UNAUTHORIZED", "Kullanıcı kimliği doğrulanamadı", nil)
+		return
+	}
+
+	var req models.VaccinationRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		utils.ErrorResponse(c, http.StatusBadRequest, "INVALID_REQUEST", "Geçersiz istek formatı", err.Error())
+		return
+	}
+
+	vaccination, err := h.vaccinations.Create(userID, c.Param("id"), req)
+	if err != nil {
+		writeVaccinationError(c, err, "Aşı planlanamadı")
+		return
+	}
+
+	utils.CreatedResponse(c, vaccination, "Aşı başarıyla planlandı")
+}
+
+// UpdateVaccination aşı takvimi kaydı güncelleme
+// @Summary Aşı takvimi kaydını güncelle
+// @Description Aşıyı günceller; administeredDate girilince aşı yapılmış sayılır ve sağlık kaydı eklenir, kaldırılınca bağlı sağlık kaydı silinir. Hayvanın sağlık durumu aşı takvimine göre yeniden türetilir
+// @ID updateVaccination
+// @Tags Livestock
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Hayvan ID"
+// @Param vaccinationId path string true "Aşı ID"
+// @Param request body models.VaccinationRequest true "Aşı bilgileri"
+// @Success 200 {object} models.APIResponse{data=models.Vaccination}
+// @Failure 400 {object} models.APIResponse
+// @Failure 401 {object} models.APIResponse
+// @Failure 404 {object} models.APIResponse
+// @Router /livestock/{id}/vaccinations/{vaccinationId} [put]
+func (h *VaccinationHandler) UpdateVaccination(c *gin.Context) {
+	userID, err := utils.GetUserID(c)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusUnauthorized, "UNAUTHORIZED", "Kullanıcı kimliği doğrulanamadı", nil)
+		return
+	}
+
+	var req models.VaccinationRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		utils.ErrorResponse(c, http.StatusBadRequest, "INVALID_REQUEST", "Geçersiz istek formatı", err.Error())
+		return
+	}
+
+	vaccination, err := h.vaccinations.Update(userID, c.Param("id"), c.Param("vaccinationId"), req)
+	if err != nil {
+		writeVaccinationError(c, err, "Aşı kaydı güncellenemedi")
+		return
+	}
+
+	utils.SuccessResponse(c, vaccination, "Aşı kaydı başarıyla güncellendi")
+}
+
+// DeleteVaccination aşı takvimi kaydı silme
+// @Summary Aşı takvimi kaydını sil
+// @Description Aşıyı ve yapıldıysa bağlı sağlık kaydını siler; hayvanın sağlık durumu yeniden türetilir
+// @ID deleteVaccination
+// @Tags Livestock
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Hayvan ID"
+// @Param vaccinationId path string true "Aşı ID"
+// @Success 200 {object} models.APIResponse
+// @Failure 401 {object} models.APIResponse
+// @Failure 404 {object} models.APIResponse
+// @Router /livestock/{id}/vaccinations/{vaccinationId} [delete]
+func (h *VaccinationHandler) DeleteVaccination(c *gin.Context) {
+	userID, err := utils.GetUserID(c)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusUnauthorized, "UNAUTHORIZED", "Kullanıcı kimliği doğrulanamadı", nil)
+		return
+	}
+
+	if err := h.vaccinations.Delete(userID, c.Param("id"), c.Param("vaccinationId")); err != nil {
+		writeVaccinationError(c, err, "Aşı kaydı silinemedi")
+		return
+	}
+
+	utils.SuccessResponse(c, nil, "Aşı kaydı başarıyla silindi")
+}
+
+// GetUpcomingVaccinations yaklaşan aşılar
+// @Summary Yaklaşan aşılar
+// @Description Sürüdeki hayvanların önümüzdeki days gün içinde yapılması gereken ve tarihi geçmiş yapılmamış aşılarını hayvanın küpe numarası ve türüyle tarih sırasıyla listeler
+// @ID getUpcomingVaccinations
+// @Tags Livestock
+// @Produce json
+// @Security BearerAuth
+// @Param days query int false "Kaç gün sonrasına kadar (varsayılan 30)"
+// @Success 200 {object} models.APIResponse{data=[]models.Vaccination}
+// @Failure 401 {object} models.APIResponse
+// @Router /livestock/vaccinations/upcoming [get]
+func (h *VaccinationHandler) GetUpcomingVaccinations(c *gin.Context) {
+	userID, err := utils.GetUserID(c)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusUnauthorized, "UNAUTHORIZED", "Kullanıcı kimliği doğrulanamadı", nil)
+		return
+	}
+
+	days, err := strconv.Atoi(c.DefaultQuery("days", "30"))
+	if err != nil || days < 0 {
+		days = 30
+	}
+
+	vaccinations, err := h.vaccinations.Upcoming(userID, days, time.Now().UTC())
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusInternalServerError, "DB_ERROR", "Yaklaşan aşılar alınamadı", err.Error())
+		return
+	}
+
+	utils.SuccessResponse(c, vaccinations, "Yaklaşan aşılar başarıyla getirildi")
+}
+
+// writeVaccinationError servis hatasını HTTP yanıtına çevirir
+func writeVaccinationError(c *gin.Context, err error, message string) {
+	switch {
+	case errors.Is(err, services.ErrLivestockNotFound):
+		utils.ErrorResponse(c, http.StatusNotFound, "ANIMAL_NOT_FOUND", "Hayvan bulunamadı", nil)
+	case errors.Is(err, services.ErrVaccinationNotFound):
+		utils.ErrorResponse(c, http.StatusNotFound, "VACCINATION_NOT_FOUND", "Aşı kaydı bulunamadı", nil)
+	default:
+		utils.ErrorResponse(c, http.StatusInternalServerError, "DB_ERROR", message, err.Error())
+	}
+}
@@ -0,0 +1,886 @@
+package handlers
+
+import (
+	"database/sql"
+	"fmt"
+	"log"
+	"net/http"
+	"strings"
+	"time"
+
+	"agri-management-api/internal/models"
+	"agri-management-api/internal/services"
+	"agri-management-api/internal/utils"
+
+	"github.com/gin-gonic/gin"
+)
+
+// vetVisitReminderWindow onaylı ziyaretler için hatırlatmanın gönderileceği süre
+const vetVisitReminderWindow = 24 * time.Hour
+
+// VetVisitHandler veteriner ziyaret işlemlerini yönetir
+type VetVisitHandler struct {
+	db            *sql.DB
+	notifications *services.NotificationService
+}
+
+// NewVetVisitHandler yeni vet visit handler oluşturur
+func NewVetVisitHandler(db *sql.DB) *VetVisitHandler {
+	return &VetVisitHandler{
+		db:            db,
+		notifications: services.NewNotificationService(db),
+	}
+}
+
+// GetVeterinarians bağlı veteriner listesi
+// @Summary Bağlı veterinerler
+// @Description Çiftçinin ziyaret talep edebileceği bağlı veteriner hesaplarını listeler
+// @ID getVeterinarians
+// @Tags VetVisits
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {object} models.APIResponse{data=[]models.VeterinarianLink}
+// @Failure 401 {object} models.APIResponse
+// @Router /vet-visits/veterinarians [get]
+func (h *VetVisitHandler) GetVeterinarians(c *gin.Context) {
+	userID, err := utils.GetUserID(c)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusUnauthorized, "UNAUTHORIZED", "Kullanıcı kimliği doğrulanamadı", nil)
+		return
+	}
+
+	rows, err := h.db.Query(`
+		SELECT vl.id, vl.veterinarian_id, u.name, u.email, vl.created_at
+		FROM veterinarian_links vl
+		JOIN users u ON vl.veterinarian_id = u.id
+		WHERE vl.farmer_id = ?
+		ORDER BY u.name
+	`, userID)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusInternalServerError, "DB_ERROR", "Veterinerler alınamadı", err.Error())
+		return
+	}
+	defer rows.Close()
+
+	links := []models.VeterinarianLink{}
+	for rows.Next() {
+		var link models.VeterinarianLink
+		if err := rows.Scan(&link.ID, &link.VeterinarianID, &link.Name, &link.Email, &link.CreatedAt); err != nil {
+			continue
+		}
+		links = append(links, link)
+	}
+
+	utils.SuccessResponse(c, links, "Veterinerler başarıyla getirildi")
+}
+
+// LinkVeterinarian veteriner hesabı bağlama
+// @Summary Veteriner bağlama
+// @Description E-posta adresiyle veteriner rolündeki bir hesabı çiftliğe bağlar
+// @ID linkVeterinarian
+// @Tags VetVisits
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param request body map[string]string true "Veteriner e-posta adresi (email)"
+// @Success 201 {object} models.APIResponse{data=models.VeterinarianLink}
+// @Failure 400 {object} models.APIResponse
+// @Failure 401 {object} models.APIResponse
+// @Failure 404 {object} models.APIResponse
+// @Failure 409 {object} models.APIResponse
+// @Router /vet-visits/veterinarians [post]
+func (h *VetVisitHandler) LinkVeterinarian(c *gin.Context) {
+	userID, err := utils.GetUserID(c)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusUnauthorized, "UNAUTHORIZED", "Kullanıcı kimliği doğrulanamadı", nil)
+		return
+	}
+
+	var req struct {
+		Email string `json:"email" binding:"required,email"`
+	}
+	if err := c.ShouldBindJSON(&req); err != nil {
+		utils.ErrorResponse(c, http.StatusBadRequest, "INVALID_REQUEST", "Geçersiz istek formatı", err.Error())
+		return
+	}
+
+	var link models.VeterinarianLink
+	err = h.db.QueryRow(`
+		SELECT id, name, email FROM users WHERE email = ? AND role = ?
+	`, strings.TrimSpace(req.Email), models.RoleVeterinarian).Scan(&link.VeterinarianID, &link.Name, &link.Email)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusNotFound, "VETERINARIAN_NOT_FOUND", "Veteriner hesabı bulunamadı", nil)
+		return
+	}
+
+	if link.VeterinarianID == userID {
+		utils.ErrorResponse(c, http.StatusBadRequest, "INVALID_VETERINARIAN", "Kendinizi veteriner olarak bağlayamazsınız", nil)
+		return
+	}
+
+	var exists bool
+	err = h.db.QueryRow(`
+		SELECT 1 FROM veterinarian_links WHERE farmer_id = ? AND veterinarian_id = ?
+	`, userID, link.VeterinarianID).Scan(&exists)
+	if err == nil {
+		utils.ErrorResponse(c, http.StatusConflict, "VETERINARIAN_EXISTS", "Bu veteriner zaten bağlı", nil)
+		return
+	}
+
+	link.ID = utils.GenerateID()
+	link.CreatedAt = time.Now()
+	_, err = h.db.Exec(`
+		INSERT INTO veterinarian_links (id, farmer_id, veterinarian_id, created_at)
+		VALUES (?, ?, ?, CURRENT_TIMESTAMP)
+	`, link.ID, userID, link.VeterinarianID)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusInternalServerError, "DB_ERROR", "Veteriner bağlanamadı", err.Error())
+		return
+	}
+
+	utils.CreatedResponse(c, link, "Veteriner başarıyla bağlandı")
+}
+
+// UnlinkVeterinarian veteriner bağlantısını kaldırma
+// @Summary Veteriner bağlantısını kaldırma
+// @Description Veteriner bağlantısını kaldırır; geçmiş ziyaretler korunur
+// @ID unlinkVeterinarian
+// @Tags VetVisits
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Bağlantı ID"
+// @Success 200 {object} models.APIResponse
+// @Failure 401 {object} models.APIResponse
+// @Failure 404 {object} models.APIResponse
+// @Router /vet-visits/veterinarians/{id} [delete]
+func (h *VetVisitHandler) UnlinkVeterinarian(c *gin.Context) {
+	userID, err := utils.GetUserID(c)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusUnauthorized, "UNAUTHORIZED", "Kullanıcı kimliği doğrulanamadı", nil)
+		return
+	}
+
+	result, err := h.db.Exec("DELETE FROM veterinarian_links WHERE id = ? AND farmer_id = ?", c.Param("id"), userID)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusInternalServerError, "DELETE_ERROR", "Veteriner bağlantısı kaldırılamadı", err.Error())
+		return
+	}
+
+	rowsAffected, _ := result.RowsAffected()
+	if rowsAffected == 0 {
+		utils.ErrorResponse(c, http.StatusNotFound, "LINK_NOT_FOUND", "Veteriner bağlantısı bulunamadı", nil)
+		return
+	}
+
+	utils.SuccessResponse(c, nil, "Veteriner bağlantısı başarıyla kaldırıldı")
+}
+
+// GetAvailability veterinerin dolu zaman aralıkları
+// @Summary Veteriner müsaitliği
+// @Description Bağlı veterinerin onaylanmış ziyaretlerle dolu zaman aralıklarını döner; diğer çiftliklerin bilgileri paylaşılmaz
+// @ID getAvailability
+// @Tags VetVisits
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Veteriner kullanıcı ID"
+// @Param from query string false "Başlangıç (RFC3339, varsayılan: şimdi)"
+// @Param to query string false "Bitiş (RFC3339, varsayılan: 14 gün sonrası)"
+// @Success 200 {object} models.APIResponse{data=[]models.VetVisitSlot}
+// @Failure 400 {object} models.APIResponse
+// @Failure 401 {object} models.APIResponse
+// @Failure 404 {object} models.APIResponse
+// @Router /vet-visits/veterinarians/{id}/availability [get]
+func (h *VetVisitHandler) GetAvailability(c *gin.Context) {
+	userID, err := utils.GetUserID(c)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusUnauthorized, "UNAUTHORIZED", "Kullanıcı kimliği doğrulanamadı", nil)
+		return
+	}
+
+	veterinarianID := c.Param("id")
+	if veterinarianID != userID && !h.isLinked(userID, veterinarianID) {
+		utils.ErrorResponse(c, http.StatusNotFound, "VETERINARIAN_NOT_FOUND", "Veteriner bulunamadı", nil)
+		return
+	}
+
+	from := time.Now()
+	to := from.AddDate(0, 0, 14)
+	if value := c.Query("from"); value != "" {
+		if from, err = time.Parse(time.RFC3339, value); err != nil {
+			utils.ErrorResponse(c, http.StatusBadRequest, "INVALID_DATE", "from RFC3339 biçiminde olmalı", nil)
+			return
+		}
+	}
+	if value := c.Query("to"); value != "" {
+		if to, err = time.Parse(time.RFC3339, value); err != nil {
+			utils.ErrorResponse(c, http.StatusBadRequest, "INVALID_DATE", "to RFC3339 biçiminde olmalı", nil)
+			return
+		}
+	}
+
+	rows, err := h.db.Query(`
+		SELECT scheduled_start, scheduled_end FROM vet_visits
+		WHERE veterinarian_id = ? AND status = ? AND scheduled_end > ? AND scheduled_start < ?
+		ORDER BY scheduled_start
+	`, veterinarianID, models.VetVisitConfirmed, from, to)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusInternalServerError, "DB_ERROR", "Müsaitlik bilgisi alınamadı", err.Error())
+		return
+	}
+	defer rows.Close()
+
+	busy := []models.VetVisitSlot{}
+	for rows.Next() {
+		var slot models.VetVisitSlot
+		if err := rows.Scan(&slot.Start, &slot.End); err != nil {
+			continue
+		}
+		busy = append(busy, slot)
+	}
+
+	utils.SuccessResponse(c, busy, "Veteriner müsaitliği başarıyla getirildi")
+}
+
+// GetVisits ziyaret listesi
+// @Summary Veteriner ziyaretleri
+// @Description Kullanıcının çiftçi veya veteriner olarak taraf olduğu ziyaretleri listeler
+// @ID getVisits
+// @Tags VetVisits
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param status query string false "Durum (requested, confirmed, declined, cancelled, completed)"
+// @Success 200 {object} models.APIResponse{data=[]models.VetVisit}
+// @Failure 401 {object} models.APIResponse
+// @Router /vet-visits [get]
+func (h *VetVisitHandler) GetVisits(c *gin.Context) {
+	userID, err := utils.GetUserID(c)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusUnauthorized, "UNAUTHORIZED", "Kullanıcı kimliği doğrulanamadı", nil)
+		return
+	}
+
+	whereClause := "WHERE (v.farmer_id = ? OR v.veterinarian_id = ?)"
+	args := []interface{}{userID, userID}
+
+	if status := c.Query("status"); status != "" {
+		whereClause += " AND v.status = ?"
+		args = append(args, status)
+	}
+
+	rows, err := h.db.Query(vetVisitSelect+whereClause+" ORDER BY COALESCE(v.scheduled_start, v.created_at) DESC", args...)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusInternalServerError, "DB_ERROR", "Ziyaretler alınamadı", err.Error())
+		return
+	}
+	defer rows.Close()
+
+	visits := []models.VetVisit{}
+	for rows.Next() {
+		visit, err := scanVetVisit(rows)
+		if err != nil {
+			continue
+		}
+		visits = append(visits, visit)
+	}
+
+	utils.SuccessResponse(c, visits, "Ziyaretler başarıyla getirildi")
+}
+
+// GetVisit ziyaret detayı
+// @Summary Veteriner ziyareti detayı
+// @Description Çiftçi veya veteriner olarak taraf olunan ziyaretin detayını getirir
+// @ID getVisit
+// @Tags VetVisits
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Ziyaret ID"
+// @Success 200 {object} models.APIResponse{data=models.VetVisit}
+// @Failure 401 {object} models.APIResponse
+// @Failure 404 {object} models.APIResponse
+// @Router /vet-visits/{id} [get]
+func (h *VetVisitHandler) GetVisit(c *gin.Context) {
+	userID, err := utils.GetUserID(c)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusUnauthorized, "UNAUTHORIZED", "Kullanıcı kimliği doğrulanamadı", nil)
+		return
+	}
+
+	visit, err := h.getVisit(c.Param("id"), userID)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusNotFound, "VISIT_NOT_FOUND", "Ziyaret bulunamadı", nil)
+		return
+	}
+
+	utils.DetailResponse(c, visit, services.EntityLinks("vet_visit", visit.ID), "Ziyaret başarıyla getirildi")
+}
+
+// RequestVisit ziyaret talebi oluşturma
+// @Summary Veteriner ziyareti talebi
+// @Description Bağlı veterinerden ziyaret nedeni ve tercih edilen zaman aralıklarıyla ziyaret talep eder
+// @ID requestVisit
+// @Tags VetVisits
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param request body models.VetVisitRequest true "Ziyaret talebi"
+// @Success 201 {object} models.APIResponse{data=models.VetVisit}
+// @Failure 400 {object} models.APIResponse
+// @Failure 401 {object} models.APIResponse
+// @Failure 403 {object} models.APIResponse
+// @Failure 404 {object} models.APIResponse
+// @Router /vet-visits [post]
+func (h *VetVisitHandler) RequestVisit(c *gin.Context) {
+	userID, err := utils.GetUserID(c)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusUnauthorized, "UNAUTHORIZED", "Kullanıcı kimliği doğrulanamadı", nil)
+		return
+	}
+
+	var req models.VetVisitRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		utils.ErrorResponse(c, http.StatusBadRequest, "INVALID_REQUEST", "Geçersiz istek formatı", err.Error())
+		return
+	}
+
+	if !h.isLinked(userID, req.VeterinarianID) {
+		utils.ErrorResponse(c, http.StatusNotFound, "VETERINARIAN_NOT_FOUND", "Bağlı veteriner bulunamadı", nil)
+		return
+	}
+
+	now := time.Now()
+	for _, slot := range req.PreferredSlots {
+		if !slot.End.After(slot.Start) || slot.Start.Before(now) {
+			utils.ErrorResponse(c, http.StatusBadRequest, "INVALID_SLOT", "Tercih edilen aralıklar gelecekte olmalı ve bitiş başlangıçtan sonra olmalı", slot)
+			return
+		}
+	}
+
+	for _, livestockID := range req.LivestockIDs {
+		var exists bool
+		err := h.db.QueryRow("SELECT 1 FROM livestock WHERE id = ? AND user_id = ?", livestockID, userID).Scan(&exists)
+		if err != nil {
+			utils.ErrorResponse(c, http.StatusNotFound, "ANIMAL_NOT_FOUND", "Hayvan bulunamadı", livestockID)
+			return
+		}
+	}
+	if req.LivestockIDs == nil {
+		req.LivestockIDs = []string{}
+	}
+
+	livestockIDs, _ := utils.ToJSON(req.LivestockIDs)
+	slots, _ := utils.ToJSON(req.PreferredSlots)
+
+	visitID := utils.GenerateID()
+	_, err = h.db.Exec(`
+		INSERT INTO vet_visits (id, farmer_id, veterinarian_id, reason, livestock_ids, preferred_slots, status, created_at, updated_at)
+		VALUES (?, ?, ?, ?, ?, ?, ?, CURRENT_TIMESTAMP, CURRENT_TIMESTAMP)
+	`, visitID, userID, req.VeterinarianID, req.Reason, livestockIDs, slots, models.VetVisitRequested)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusInternalServerError, "DB_ERROR", "Ziyaret talebi oluşturulamadı", err.Error())
+		return
+	}
+
+	visit, err := h.getVisit(visitID, userID)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusInternalServerError, "FETCH_ERROR", "Oluşturulan ziyaret getirilemedi", err.Error())
+		return
+	}
+
+	h.notify(visit, visit.VeterinarianID, "Yeni Ziyaret Talebi",
+		fmt.Sprintf("%s ziyaret talep etti: %s", visitPartyName(visit.FarmName, visit.FarmerName), visit.Reason), "medium")
+
+	utils.CreatedResponse(c, visit, "Ziyaret talebi başarıyla oluşturuldu")
+}
+
+// ConfirmVisit ziyareti onaylama
+// @Summary Veteriner ziyaretini onaylama
+// @Description Veteriner tercih edilen aralıklardan birini (slot) veya kendi önerdiği zamanı seçerek ziyareti onaylar; iki taraf için takvim etkinliği oluşturulur
+// @ID confirmVisit
+// @Tags VetVisits
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Ziyaret ID"
+// @Param request body models.VetVisitConfirmRequest true "Onay bilgileri"
+// @Success 200 {object} models.APIResponse{data=models.VetVisit}
+// @Failure 400 {object} models.APIResponse
+// @Failure 401 {object} models.APIResponse
+// @Failure 403 {object} models.APIResponse
+// @Failure 404 {object} models.APIResponse
+// @Failure 409 {object} models.APIResponse
+// @Router /vet-visits/{id}/confirm [patch]
+func (h *VetVisitHandler) ConfirmVisit(c *gin.Context) {
+	userID, err := utils.GetUserID(c)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusUnauthorized, "UNAUTHORIZED", "Kullanıcı kimliği doğrulanamadı", nil)
+		return
+	}
+
+	var req models.VetVisitConfirmRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		utils.ErrorResponse(c, http.StatusBadRequest, "INVALID_REQUEST", "Geçersiz istek formatı", err.Error())
+		return
+	}
+
+	visit, ok := h.veterinarianVisit(c, userID, models.VetVisitRequested)
+	if !ok {
+		return
+	}
+
+	var slot models.VetVisitSlot
+	switch {
+	case req.Slot != nil:
+		if *req.Slot < 0 || *req.Slot >= len(visit.PreferredSlots) {
+			utils.ErrorResponse(c, http.StatusBadRequest, "INVALID_SLOT", "Tercih edilen aralık bulunamadı", nil)
+			return
+		}
+		slot = visit.PreferredSlots[*req.Slot]
+	case req.Start != nil && req.End != nil:
+		slot = models.VetVisitSlot{Start: *req.Start, End: *req.End}
+	default:
+		utils.ErrorResponse(c, http.StatusBadRequest, "MISSING_SLOT", "slot veya start/end gerekli", nil)
+		return
+	}
+	if !slot.End.After(slot.Start) {
+		utils.ErrorResponse(c, http.StatusBadRequest, "INVALID_SLOT", "Bitiş başlangıçtan sonra olmalı", nil)
+		return
+	}
+
+	// Veterinerin aynı aralıkta başka onaylı ziyareti olmamalı
+	var conflictID string
+	err = h.db.QueryRow(`
+		SELECT id FROM vet_visits
+		WHERE veterinarian_id = ? AND status = ? AND id != ? AND scheduled_start < ? AND scheduled_end > ?
+		LIMIT 1
+	`, userID, models.VetVisitConfirmed, visit.ID, slot.End, slot.Start).Scan(&conflictID)
+	if err == nil {
+		utils.ErrorResponse(c, http.StatusConflict, "SLOT_UNAVAILABLE", "Bu aralıkta başka bir ziyaret var", conflictID)
+		return
+	}
+
+	tx, err := h.db.Begin()
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusInternalServerError, "DB_ERROR", "Ziyaret onaylanamadı", err.Error())
+		return
+	}
+	defer tx.Rollback()
+
+	var farmerLocation string
+	h.db.QueryRow("SELECT COALESCE(location, '') FROM users WHERE id = ?", visit.FarmerID).Scan(&farmerLocation)
+
+	farmerEventID := utils.GenerateID()
+	veterinarianEventID := utils.GenerateID()
+	events := []struct {
+		id, userID, title string
+	}{
+		{farmerEventID, visit.FarmerID, "Veteriner ziyareti - " + visit.VeterinarianName},
+		{veterinarianEventID, visit.VeterinarianID, "Çiftlik ziyareti - " + visitPartyName(visit.FarmName, visit.FarmerName)},
+	}
+	for _, event := range events {
+		_, err := tx.Exec(`
+			INSERT INTO events (id, user_id, title, description, type, start_date, end_date, is_all_day, status, priority,
+			                   location, related_entity_type, related_entity_id, created_at, updated_at)
+			VALUES (?, ?, ?, ?, 'health', ?, ?, FALSE, 'pending', 'high', ?, 'vet_visit', ?, CURRENT_TIMESTAMP, CURRENT_TIMESTAMP)
+		`, event.id, event.userID, event.title, visit.Reason, slot.Start, slot.End, farmerLocation, visit.ID)
+		if err != nil {
+			utils.ErrorResponse(c, http.StatusInternalServerError, "DB_ERROR", "Takvim etkinlikleri oluşturulamadı", err.Error())
+			return
+		}
+	}
+
+	_, err = tx.Exec(`
+		UPDATE vet_visits SET status = ?, scheduled_start = ?, scheduled_end = ?, farmer_event_id = ?, veterinarian_event_id = ?,
+		                      response_note = ?, reminder_sent = FALSE, updated_at = CURRENT_TIMESTAMP
+		WHERE id = ?
+	`, models.VetVisitConfirmed, slot.Start, slot.End, farmerEventID, veterinarianEventID, req.Note, visit.ID)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusInternalServerError, "UPDATE_ERROR", "Ziyaret onaylanamadı", err.Error())
+		return
+	}
+
+	if err := tx.Commit(); err != nil {
+		utils.ErrorResponse(c, http.StatusInternalServerError, "DB_ERROR", "Ziyaret onaylanamadı", err.Error())
+		return
+	}
+
+	visit, _ = h.getVisit(visit.ID, userID)
+	h.notify(visit, visit.FarmerID, "Veteriner Ziyareti Onaylandı",
+		fmt.Sprintf("%s ziyareti %s tarihinde gerçekleştirecek", visit.VeterinarianName, slot.Start.Format("02.01.2006 15:04")), "high")
+
+	utils.SuccessResponse(c, visit, "Ziyaret başarıyla onaylandı")
+}
+
+// DeclineVisit ziyaret talebini reddetme
+// @Summary Veteriner ziyaretini reddetme
+// @Description Veteriner bekleyen ziyaret talebini açıklamayla reddeder
+// @ID declineVisit
+// @Tags VetVisits
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Ziyaret ID"
+// @Param request body map[string]string false "Açıklama (note)"
+// @Success 200 {object} models.APIResponse{data=models.VetVisit}
+// @Failure 401 {object} models.APIResponse
+// @Failure 403 {object} models.APIResponse
+// @Failure 404 {object} models.APIResponse
+// @Failure 409 {object} models.APIResponse
+// @Router /vet-visits/{id}/decline [patch]
+func (h *VetVisitHandler) DeclineVisit(c *gin.Context) {
+	userID, err := utils.GetUserID(c)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusUnauthorized, "UNAUTHORIZED", "Kullanıcı kimliği doğrulanamadı", nil)
+		return
+	}
+
+	var req struct {
+		Note string `json:"note"`
+	}
+	c.ShouldBindJSON(&req)
+
+	visit, ok := h.veterinarianVisit(c, userID, models.VetVisitRequested)
+	if !ok {
+		return
+	}
+
+	_, err = h.db.Exec(`
+		UPDATE vet_visits SET status = ?, response_note = ?, updated_at = CURRENT_TIMESTAMP WHERE id = ?
+	`, models.VetVisitDeclined, req.Note, visit.ID)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusInternalServerError, "UPDATE_ERROR", "Ziyaret reddedilemedi", err.Error())
+		return
+	}
+
+	visit, _ = h.getVisit(visit.ID, userID)
+	message := visit.VeterinarianName + " ziyaret talebini reddetti"
+	if req.Note != "" {
+		message += ": " + req.Note
+	}
+	h.notify(visit, visit.FarmerID, "Veteriner Ziyareti Reddedildi", message, "medium")
+
+	utils.SuccessResponse(c, visit, "Ziyaret talebi reddedildi")
+}
+
+// CancelVisit ziyareti iptal etme
+// @Summary Veteriner ziyaretini iptal etme
+// @Description Çiftçi veya veteriner bekleyen ya da onaylanmış ziyareti iptal eder; takvim etkinlikleri iptal edilir
+// @ID cancelVisit
+// @Tags VetVisits
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Ziyaret ID"
+// @Success 200 {object} models.APIResponse{data=models.VetVisit}
+// @Failure 401 {object} models.APIResponse
+// @Failure 404 {object} models.APIResponse
+// @Failure 409 {object} models.APIResponse
+// @Router /vet-visits/{id}/cancel [patch]
+func (h *VetVisitHandler) CancelVisit(c *gin.Context) {
+	userID, err := utils.GetUserID(c)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusUnauthorized, "UNAUTHORIZED", "Kullanıcı kimliği doğrulanamadı", nil)
+		return
+	}
+
+	visit, err := h.getVisit(c.Param("id"), userID)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusNotFound, "VISIT_NOT_FOUND", "Ziyaret bulunamadı", nil)
+		return
+	}
+
+	if visit.Status != models.VetVisitRequested && visit.Status != models.VetVisitConfirmed {
+		utils.ErrorResponse(c, http.StatusConflict, "INVALID_STATUS", "Bu ziyaret iptal edilemez", visit.Status)
+		return
+	}
+
+	tx, err := h.db.Begin()
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusInternalServerError, "DB_ERROR", "Ziyaret iptal edilemedi", err.Error())
+		return
+	}
+	defer tx.Rollback()
+
+	if _, err := tx.Exec(`
+		UPDATE vet_visits SET status = ?, updated_at = CURRENT_TIMESTAMP WHERE id = ?
+	`, models.VetVisitCancelled, visit.ID); err != nil {
+		utils.ErrorResponse(c, http.StatusInternalServerError, "UPDATE_ERROR", "Ziyaret iptal edilemedi", err.Error())
+		return
+	}
+	if err := setVisitEventStatus(tx, visit, "cancelled"); err != nil {
+		utils.ErrorResponse(c, http.StatusInternalServerError, "UPDATE_ERROR", "Takvim etkinlikleri güncellenemedi", err.Error())
+		return
+	}
+
+	if err := tx.Commit(); err != nil {
+		utils.ErrorResponse(c, http.StatusInternalServerError, "DB_ERROR", "Ziyaret iptal edilemedi", err.Error())
+		return
+	}
+
+	visit, _ = h.getVisit(visit.ID, userID)
+	if userID == visit.FarmerID {
+		h.notify(visit, visit.VeterinarianID, "Ziyaret İptal Edildi",
+			visitPartyName(visit.FarmName, visit.FarmerName)+" ziyareti iptal etti: "+visit.Reason, "medium")
+	} else {
+		h.notify(visit, visit.FarmerID, "Veteriner Ziyareti İptal Edildi",
+			visit.VeterinarianName+" ziyareti iptal etti: "+visit.Reason, "high")
+	}
+
+	utils.SuccessResponse(c, visit, "Ziyaret başarıyla iptal edildi")
+}
+
+// CompleteVisit ziyareti tamamlama
+// @Summary Veteriner ziyaretini tamamlama
+// @Description Veteriner ziyareti tamamlar; ziyarete eklenen her hayvan için sağlık kaydı oluşturulur ve takvim etkinlikleri tamamlanır
+// @ID completeVisit
+// @Tags VetVisits
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Ziyaret ID"
+// @Param request body models.VetVisitCompleteRequest true "Ziyaret sonucu"
+// @Success 200 {object} models.APIResponse{data=models.VetVisit}
+// @Failure 400 {object} models.APIResponse
+// @Failure 401 {object} models.APIResponse
+// @Failure 403 {object} models.APIResponse
+// @Failure 404 {object} models.APIResponse
+// @Failure 409 {object} models.APIResponse
+// @Router /vet-visits/{id}/complete [post]
+func (h *VetVisitHandler) CompleteVisit(c *gin.Context) {
+	userID, err := utils.GetUserID(c)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusUnauthorized, "UNAUTHORIZED", "Kullanıcı kimliği doğrulanamadı", nil)
+		return
+	}
+
+	var req models.VetVisitCompleteRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		utils.ErrorResponse(c, http.StatusBadRequest, "INVALID_REQUEST", "Geçersiz istek formatı", err.Error())
+		return
+	}
+
+	var nextCheckup interface{}
+	if req.NextCheckup != "" {
+		date, err := time.Parse("2006-01-02", req.NextCheckup)
+		if err != nil {
+			utils.ErrorResponse(c, http.StatusBadRequest, "INVALID_DATE", "Kontrol tarihi YYYY-MM-DD biçiminde olmalı", nil)
+			return
+		}
+		nextCheckup = date
+	}
+
+	visit, ok := h.veterinarianVisit(c, userID, models.VetVisitConfirmed)
+	if !ok {
+		return
+	}
+
+	tx, err := h.db.Begin()
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusInternalServerError, "DB_ERROR", "Ziyaret tamamlanamadı", err.Error())
+		return
+	}
+	defer tx.Rollback()
+
+	notes := "Veteriner ziyareti: " + visit.Reason
+	if req.Findings != "" {
+		notes += "\n" + req.Findings
+	}
+
+	// Ziyaret tarihi sağlık kaydının tarihi olarak kullanılır; sahibi değişen hayvanlar atlanır
+	recordIDs := []string{}
+	for _, livestockID := range visit.LivestockIDs {
+		recordID := utils.GenerateID()
+		result, err := tx.Exec(`
+			INSERT INTO health_records (id, livestock_id, type, description, date, veterinarian, cost, notes, next_checkup, created_at)
+			SELECT ?, id, ?, ?, ?, ?, ?, ?, ?, CURRENT_TIMESTAMP FROM livestock WHERE id = ? AND user_id = ?
+		`, recordID, req.Type, req.Description, visit.ScheduledStart, visit.VeterinarianName, req.Cost, notes, nextCheckup,
+			livestockID, visit.FarmerID)
+		if err != nil {
+			utils.ErrorResponse(c, http.StatusInternalServerError, "DB_ERROR", "Sağlık kayıtları oluşturulamadı", err.Error())
+			return
+		}
+		if rowsAffected, _ := result.RowsAffected(); rowsAffected > 0 {
+			recordIDs = append(recordIDs, recordID)
+		}
+	}
+
+	recordJSON, _ := utils.ToJSON(recordIDs)
+	if _, err := tx.Exec(`
+		UPDATE vet_visits SET status = ?, findings = ?, health_record_ids = ?, updated_at = CURRENT_TIMESTAMP WHERE id = ?
+	`, models.VetVisitCompleted, req.Findings, recordJSON, visit.ID); err != nil {
+		utils.ErrorResponse(c, http.StatusInternalServerError, "UPDATE_ERROR", "Ziyaret tamamlanamadı", err.Error())
+		return
+	}
+	if err := setVisitEventStatus(tx, visit, "completed"); err != nil {
+		utils.ErrorResponse(c, http.StatusInternalServerError, "UPDATE_ERROR", "Takvim etkinlikleri güncellenemedi", err.Error())
+		return
+	}
+
+	if err := tx.Commit(); err != nil {
+		utils.ErrorResponse(c, http.StatusInternalServerError, "DB_ERROR", "Ziyaret tamamlanamadı", err.Error())
+		return
+	}
+
+	visit, _ = h.getVisit(visit.ID, userID)
+	h.notify(visit, visit.FarmerID, "Veteriner Ziyareti Tamamlandı",
+		fmt.Sprintf("%s ziyareti tamamladı, %d sağlık kaydı eklendi", visit.VeterinarianName, len(recordIDs)), "medium")
+
+	utils.SuccessResponse(c, visit, "Ziyaret başarıyla tamamlandı")
+}
+
+// StartReminders yaklaşan onaylı ziyaretler için iki tarafa saatlik olarak hatırlatma gönderir
+func (h *VetVisitHandler) StartReminders() {
+	go func() {
+		ticker := time.NewTicker(time.Hour)
+		defer ticker.Stop()
+
+		for {
+			if err := h.SendDueReminders(); err != nil {
+				log.Printf("Veteriner ziyaret hatırlatmaları gönderilemedi: %v", err)
+			}
+			<-ticker.C
+		}
+	}()
+}
+
+// SendDueReminders önümüzdeki gün içindeki onaylı ziyaretler için henüz gönderilmemiş hatırlatmaları gönderir
+func (h *VetVisitHandler) SendDueReminders() error {
+	now := time.Now()
+	rows, err := h.db.Query(vetVisitSelect+`
+		WHERE v.status = ? AND v.reminder_sent = FALSE AND v.scheduled_start > ? AND v.scheduled_start <= ?
+	`, models.VetVisitConfirmed, now, now.Add(vetVisitReminderWindow))
+	if err != nil {
+		return err
+	}
+
+	var visits []models.VetVisit
+	for rows.Next() {
+		visit, err := scanVetVisit(rows)
+		if err != nil {
+			continue
+		}
+		visits = append(visits, visit)
+	}
+	rows.Close()
+
+	for _, visit := range visits {
+		when := visit.ScheduledStart.Format("02.01.2006 15:04")
+		h.notify(visit, visit.FarmerID, "Yaklaşan Veteriner Ziyareti",
+			fmt.Sprintf("%s ziyareti %s: %s", visit.VeterinarianName, when, visit.Reason), "high")
+		h.notify(visit, visit.VeterinarianID, "Yaklaşan Çiftlik Ziyareti",
+			fmt.Sprintf("%s ziyareti %s: %s", visitPartyName(visit.FarmName, visit.FarmerName), when, visit.Reason), "high")
+
+		if _, err := h.db.Exec("UPDATE vet_visits SET reminder_sent = TRUE WHERE id = ?", visit.ID); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// veterinarianVisit ziyaretin veterinere ait ve beklenen durumda olduğunu doğrular; hata varsa yanıtı yazar
+func (h *VetVisitHandler) veterinarianVisit(c *gin.Context, userID, status string) (models.VetVisit, bool) {
+	visit, err := h.getVisit(c.Param("id"), userID)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusNotFound, "VISIT_NOT_FOUND", "Ziyaret bulunamadı", nil)
+		return visit, false
+	}
+
+	if visit.VeterinarianID != userID {
+		utils.ErrorResponse(c, http.StatusForbidden, "FORBIDDEN", "Bu işlem yalnızca ziyaretin veterineri tarafından yapılabilir", nil)
+		return visit, false
+	}
+
+	if visit.Status != status {
+		utils.ErrorResponse(c, http.StatusConflict, "INVALID_STATUS", "Ziyaret bu işlem için uygun durumda değil", visit.Status)
+		return visit, false
+	}
+
+	return visit, true
+}
+
+// isLinked veterinerin çiftçiye bağlı olup olmadığını kontrol eder
+func (h *VetVisitHandler) isLinked(farmerID, veterinarianID string) bool {
+	var exists bool
+	err := h.db.QueryRow(`
+		SELECT 1 FROM veterinarian_links WHERE farmer_id = ? AND veterinarian_id = ?
+	`, farmerID, veterinarianID).Scan(&exists)
+	return err == nil
+}
+
+// notify ziyaretle ilişkili bildirim gönderir; bildirim hatası işlemi engellemez
+func (h *VetVisitHandler) notify(visit models.VetVisit, userID, title, message, priority string) {
+	_, err := h.notifications.Create(services.Notification{
+		UserID:   userID,
+		Title:    title,
+		Message:  message,
+		Type:     "reminder",
+		Priority: priority,
+		Topic:    models.NotificationTopicVetVisit,
+		Entity:   &models.RelatedEntity{Type: "vet_visit", ID: visit.ID, Name: visit.Reason},
+	})
+	if err != nil {
+		log.Printf("Ziyaret bildirimi oluşturulamadı: %v", err)
+	}
+}
+
+// getVisit kullanıcının taraf olduğu ziyareti getirir
+func (h *VetVisitHandler) getVisit(visitID, userID string) (models.VetVisit, error) {
+	row := h.db.QueryRow(vetVisitSelect+" WHERE v.id = ? AND (v.farmer_id = ? OR v.veterinarian_id = ?)", visitID, userID, userID)
+	return scanVetVisit(row)
+}
+
+// vetVisitSelect ziyaretleri taraf isimleriyle birlikte seçen sorgu
+const vetVisitSelect = `
+	SELECT v.id, v.farmer_id, f.name, COALESCE(f.farm_name, ''), v.veterinarian_id, vu.name, v.reason,
+	       v.livestock_ids, v.preferred_slots, v.status, v.scheduled_start, v.scheduled_end,
+	       COALESCE(v.response_note, ''), COALESCE(v.findings, ''), v.health_record_ids, v.created_at, v.updated_at
+	FROM vet_visits v
+	JOIN users f ON v.farmer_id = f.id
+	JOIN users vu ON v.veterinarian_id = vu.id
+`
+
+// scanVetVisit ziyaret satırını okur
+func scanVetVisit(row interface{ Scan(...interface{}) error }) (models.VetVisit, error) {
+	var visit models.VetVisit
+	var livestockIDs, slots, recordIDs string
+	var scheduledStart, scheduledEnd sql.NullTime
+
+	err := row.Scan(
+		&visit.ID, &visit.FarmerID, &visit.FarmerName, &visit.FarmName, &visit.VeterinarianID, &visit.VeterinarianName,
+		&visit.Reason, &livestockIDs, &slots, &visit.Status, &scheduledStart, &scheduledEnd,
+		&visit.ResponseNote, &visit.Findings, &recordIDs, &visit.CreatedAt, &visit.UpdatedAt,
+	)
+	if err != nil {
+		return visit, err
+	}
+
+	visit.ScheduledStart = utils.NullTimeToPtr(scheduledStart)
+	visit.ScheduledEnd = utils.NullTimeToPtr(scheduledEnd)
+	utils.FromJSON(livestockIDs, &visit.LivestockIDs)
+	utils.FromJSON(slots, &visit.PreferredSlots)
+	utils.FromJSON(recordIDs, &visit.HealthRecordIDs)
+
+	return visit, nil
+}
+
+// setVisitEventStatus ziyaretin iki taraftaki takvim etkinliklerinin durumunu günceller
+func setVisitEventStatus(tx *sql.Tx, visit models.VetVisit, status string) error {
+	_, err := tx.Exec(`
+		UPDATE events SET status = ?, updated_at = CURRENT_TIMESTAMP
+		WHERE related_entity_type = 'vet_visit' AND related_entity_id = ? AND user_id IN (?, ?)
+	`, status, visit.ID, visit.FarmerID, visit.VeterinarianID)
+	return err
+}
+
+// visitPartyName çiftlik adı varsa onu, yoksa kullanıcı adını döner
+func visitPartyName(farmName, name string) string {
+	if farmName != "" {
+		return farmName
+	}
+	return name
+}
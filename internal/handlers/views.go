@@ -0,0 +1,423 @@
+package handlers
+
+import (
+	"database/sql"
+	"net/http"
+	"sort"
+	"strings"
+
+	"agri-management-api/internal/models"
+	"agri-management-api/internal/utils"
+
+	"github.com/gin-gonic/gin"
+)
+
+// viewResource kaydedilebilir liste ekranı; filtreler liste uç noktasının sorgu parametreleridir
+type viewResource struct {
+	filters    []string
+	sortFields map[string]string
+}
+
+// viewResources görünüm kaydedilebilen listeler
+var viewResources = map[string]viewResource{
+	"livestock":    {filters: []string{"type", "status"}, sortFields: livestockSortFields},
+	"transactions": {filters: []string{"type", "category", "startDate", "endDate"}, sortFields: transactionSortFields},
+	"production":   {filters: []string{"category", "status"}, sortFields: productionSortFields},
+}
+
+// ViewHandler kayıtlı liste görünümlerini yönetir
+type ViewHandler struct {
+	db *sql.DB
+}
+
+// NewViewHandler yeni view handler oluşturur
+func NewViewHandler(db *sql.DB) *ViewHandler {
+	return &ViewHandler{db: db}
+}
+
+// GetViews kayıtlı görünümler
+// @Summary Kayıtlı görünümler
+// @Description Kullanıcının liste ekranları için kaydettiği filtre/sıralama görünümlerini listeler
+// @ID getViews
+// @Tags Views
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param resource query string false "Liste (livestock, transactions, production)"
+// @Success 200 {object} models.APIResponse{data=[]models.SavedView}
+// @Failure 401 {object} models.APIResponse
+// @Router /views [get]
+func (h *ViewHandler) GetViews(c *gin.Context) {
+	userID, err := utils.GetUserID(c)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusUnauthorized, "UNAUTHORIZED", "Kullanıcı kimliği doğrulanamadı", nil)
+		return
+	}
+
+	whereClause := "WHERE user_id = ?"
+	args := []interface{}{userID}
+
+	if resource := c.Query("resource"); resource != "" {
+		whereClause += " AND resource = ?"
+		args = append(args, resource)
+	}
+
+	rows, err := h.db.Query(`
+		SELECT id, user_id, resource, name, filters, COALESCE(sort_by, ''), COALESCE(sort_order, ''),
+		       is_default, created_at, updated_at
+		FROM saved_views `+whereClause+`
+		ORDER BY resource, is_default DESC, name
+	`, args...)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusInternalServerError, "DB_ERROR", "Görünümler alınamadı", err.Error())
+		return
+	}
+	defer rows.Close()
+
+	var views []models.SavedView
+	for rows.Next() {
+		view, err := scanView(rows)
+		if err != nil {
+			continue
+		}
+		views = append(views, view)
+	}
+
+	utils.SuccessResponse(c, views, "Görünümler başarıyla getirildi")
+}
+
+// GetDefaultView varsayılan görünüm
+// @Summary Varsayılan görünüm
+// @Description Liste açılırken uygulanacak varsayılan görünümü getirir
+// @ID getDefaultView
+// @Tags Views
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param resource path string true "Liste (livestock, transactions, production)"
+// @Success 200 {object} models.APIResponse{data=models.SavedView}
+// @Failure 401 {object} models.APIResponse
+// @Failure 404 {object} models.APIResponse
+// @Router /views/default/{resource} [get]
+func (h *ViewHandler) GetDefaultView(c *gin.Context) {
+	userID, err := utils.GetUserID(c)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusUnauthorized, "UNAUTHORIZED", "Kullanıcı kimliği doğrulanamadı", nil)
+		return
+	}
+
+	row := h.db.QueryRow(`
+		SELECT id, user_id, resource, name, filters, COALESCE(sort_by, ''), COALESCE(sort_order, ''),
+		       is_default, created_at, updated_at
+		FROM saved_views WHERE user_id = ? AND resource = ? AND is_default = TRUE
+	`, userID, c.Param("resource"))
+
+	view, err := scanView(row)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusNotFound, "VIEW_NOT_FOUND", "Varsayılan görünüm bulunamadı", nil)
+		return
+	}
+
+	utils.SuccessResponse(c, view, "Varsayılan görünüm başarıyla getirildi")
+}
+
+// CreateView yeni görünüm kaydetme
+// @Summary Görünüm kaydetme
+// @Description Liste ekranı için adlandırılmış filtre ve sıralama ayarını kaydeder
+// @ID createView
+// @Tags Views
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param request body models.SavedView true "Görünüm bilgileri"
+// @Success 201 {object} models.APIResponse{data=models.SavedView}
+// @Failure 400 {object} models.APIResponse
+// @Failure 401 {object} models.APIResponse
+// @Router /views [post]
+func (h *ViewHandler) CreateView(c *gin.Context) {
+	userID, err := utils.GetUserID(c)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusUnauthorized, "UNAUTHORIZED", "Kullanıcı kimliği doğrulanamadı", nil)
+		return
+	}
+
+	var req models.SavedView
+	if err := c.ShouldBindJSON(&req); err != nil {
+		utils.ErrorResponse(c, http.StatusBadRequest, "INVALID_REQUEST", "Geçersiz istek formatı", err.Error())
+		return
+	}
+
+	if !validateView(c, &req) {
+		return
+	}
+
+	filters, _ := utils.ToJSON(req.Filters)
+	viewID := utils.GenerateID()
+
+	tx, err := h.db.Begin()
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusInternalServerError, "DB_ERROR", "Görünüm kaydedilemedi", err.Error())
+		return
+	}
+	defer tx.Rollback()
+
+	if req.IsDefault {
+		tx.Exec("UPDATE saved_views SET is_default = FALSE WHERE user_id = ? AND resource = ?", userID, req.Resource)
+	}
+
+	_, err = tx.Exec(`
+		INSERT INTO saved_views (id, user_id, resource, name, filters, sort_by, sort_order, is_default, created_at, updated_at)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, CURRENT_TIMESTAMP, CURRENT_TIMESTAMP)
+	`, viewID, userID, req.Resource, req.Name, filters, req.SortBy, req.SortOrder, req.IsDefault)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusInternalServerError, "DB_ERROR", "Görünüm kaydedilemedi", err.Error())
+		return
+	}
+
+	if err := tx.Commit(); err != nil {
+		utils.ErrorResponse(c, http.StatusInternalServerError, "DB_ERROR", "Görünüm kaydedilemedi", err.Error())
+		return
+	}
+
+	view, err := h.getView(viewID, userID)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusInternalServerError, "FETCH_ERROR", "Kaydedilen görünüm getirilemedi", err.Error())
+		return
+	}
+
+	utils.CreatedResponse(c, view, "Görünüm başarıyla kaydedildi")
+}
+
+// UpdateView görünüm güncelleme
+// @Summary Görünüm güncelleme
+// @Description Kayıtlı görünümün adını, filtrelerini ve sıralamasını günceller
+// @ID updateView
+// @Tags Views
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Görünüm ID"
+// @Param request body models.SavedView true "Görünüm bilgileri"
+// @Success 200 {object} models.APIResponse{data=models.SavedView}
+// @Failure 400 {object} models.APIResponse
+// @Failure 401 {object} models.APIResponse
+// @Failure 404 {object} models.APIResponse
+// @Router /views/{id} [put]
+func (h *ViewHandler) UpdateView(c *gin.Context) {
+	userID, err := utils.GetUserID(c)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusUnauthorized, "UNAUTHORIZED", "Kullanıcı kimliği doğrulanamadı", nil)
+		return
+	}
+
+	viewID := c.Param("id")
+	current, err := h.getView(viewID, userID)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusNotFound, "VIEW_NOT_FOUND", "Görünüm bulunamadı", nil)
+		return
+	}
+
+	var req models.SavedView
+	if err := c.ShouldBindJSON(&req); err != nil {
+		utils.ErrorResponse(c, http.StatusBadRequest, "INVALID_REQUEST", "Geçersiz istek formatı", err.Error())
+		return
+	}
+
+	// Görünümün ait olduğu liste değiştirilemez
+	req.Resource = current.Resource
+	if !validateView(c, &req) {
+		return
+	}
+
+	filters, _ := utils.ToJSON(req.Filters)
+
+	tx, err := h.db.Begin()
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusInternalServerError, "UPDATE_ERROR", "Görünüm güncellenemedi", err.Error())
+		return
+	}
+	defer tx.Rollback()
+
+	if req.IsDefault {
+		tx.Exec("UPDATE saved_views SET is_default = FALSE WHERE user_id = ? AND resource = ?", userID, req.Resource)
+	}
+
+	_, err = tx.Exec(`
+		UPDATE saved_views SET name = ?, filters = ?, sort_by = ?, sort_order = ?, is_default = ?, updated_at = CURRENT_TIMESTAMP
+		WHERE id = ? AND user_id = ?
+	`, req.Name, filters, req.SortBy, req.SortOrder, req.IsDefault, viewID, userID)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusInternalServerError, "UPDATE_ERROR", "Görünüm güncellenemedi", err.Error())
+		return
+	}
+
+	if err := tx.Commit(); err != nil {
+		utils.ErrorResponse(c, http.StatusInternalServerError, "UPDATE_ERROR", "Görünüm güncellenemedi", err.Error())
+		return
+	}
+
+	view, err := h.getView(viewID, userID)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusInternalServerError, "FETCH_ERROR", "Güncellenen görünüm getirilemedi", err.Error())
+		return
+	}
+
+	utils.SuccessResponse(c, view, "Görünüm başarıyla güncellendi")
+}
+
+// SetDefaultView varsayılan görünüm belirleme
+// @Summary Varsayılan görünüm belirleme
+// @Description Görünümü ait olduğu liste için varsayılan yapar; aynı listedeki diğer varsayılan işaretleri kaldırılır
+// @ID setDefaultView
+// @Tags Views
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Görünüm ID"
+// @Success 200 {object} models.APIResponse{data=models.SavedView}
+// @Failure 401 {object} models.APIResponse
+// @Failure 404 {object} models.APIResponse
+// @Router /views/{id}/default [patch]
+func (h *ViewHandler) SetDefaultView(c *gin.Context) {
+	userID, err := utils.GetUserID(c)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusUnauthorized, "UNAUTHORIZED", "Kullanıcı kimliği doğrulanamadı", nil)
+		return
+	}
+
+	viewID := c.Param("id")
+	view, err := h.getView(viewID, userID)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusNotFound, "VIEW_NOT_FOUND", "Görünüm bulunamadı", nil)
+		return
+	}
+
+	tx, err := h.db.Begin()
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusInternalServerError, "UPDATE_ERROR", "Varsayılan görünüm belirlenemedi", err.Error())
+		return
+	}
+	defer tx.Rollback()
+
+	tx.Exec("UPDATE saved_views SET is_default = FALSE WHERE user_id = ? AND resource = ?", userID, view.Resource)
+	_, err = tx.Exec("UPDATE saved_views SET is_default = TRUE, updated_at = CURRENT_TIMESTAMP WHERE id = ? AND user_id = ?", viewID, userID)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusInternalServerError, "UPDATE_ERROR", "Varsayılan görünüm belirlenemedi", err.Error())
+		return
+	}
+
+	if err := tx.Commit(); err != nil {
+		utils.ErrorResponse(c, http.StatusInternalServerError, "UPDATE_ERROR", "Varsayılan görünüm belirlenemedi", err.Error())
+		return
+	}
+
+	view, _ = h.getView(viewID, userID)
+	utils.SuccessResponse(c, view, "Varsayılan görünüm başarıyla belirlendi")
+}
+
+// DeleteView görünüm silme
+// @Summary Görünüm silme
+// @Description Kayıtlı görünümü siler
+// @ID deleteView
+// @Tags Views
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Görünüm ID"
+// @Success 200 {object} models.APIResponse
+// @Failure 401 {object} models.APIResponse
+// @Failure 404 {object} models.APIResponse
+// @Router /views/{id} [delete]
+func (h *ViewHandler) DeleteView(c *gin.Context) {
+	userID, err := utils.GetUserID(c)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusUnauthorized, "UNAUTHORIZED", "Kullanıcı kimliği doğrulanamadı", nil)
+		return
+	}
+
+	result, err := h.db.Exec("DELETE FROM saved_views WHERE id = ? AND user_id = ?", c.Param("id"), userID)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusInternalServerError, "DELETE_ERROR", "Görünüm silinemedi", err.Error())
+		return
+	}
+
+	rowsAffected, _ := result.RowsAffected()
+	if rowsAffected == 0 {
+		utils.ErrorResponse(c, http.StatusNotFound, "VIEW_NOT_FOUND", "Görünüm bulunamadı", nil)
+		return
+	}
+
+	utils.SuccessResponse(c, nil, "Görünüm başarıyla silindi")
+}
+
+// validateView görünümün liste, filtre ve sıralama alanlarını doğrular; hata varsa yanıtı yazar
+func validateView(c *gin.Context, view *models.SavedView) bool {
+	resource, ok := viewResources[view.Resource]
+	if !ok {
+		utils.ErrorResponse(c, http.StatusBadRequest, "INVALID_RESOURCE", "Geçersiz liste türü", []string{"livestock", "transactions", "production"})
+		return false
+	}
+
+	allowed := map[string]bool{}
+	for _, key := range resource.filters {
+		allowed[key] = true
+	}
+
+	var invalid []string
+	for key := range view.Filters {
+		if !allowed[key] {
+			invalid = append(invalid, key)
+		}
+	}
+	if len(invalid) > 0 {
+		sort.Strings(invalid)
+		utils.ErrorResponse(c, http.StatusBadRequest, "INVALID_FILTERS", "Geçersiz filtre alanları", invalid)
+		return false
+	}
+
+	if view.SortBy != "" {
+		if _, ok := resource.sortFields[view.SortBy]; !ok {
+			utils.ErrorResponse(c, http.StatusBadRequest, "INVALID_SORT", "Geçersiz sıralama alanı", nil)
+			return false
+		}
+	}
+
+	view.SortOrder = strings.ToLower(view.SortOrder)
+	if view.SortOrder != "" && view.SortOrder != "asc" && view.SortOrder != "desc" {
+		utils.ErrorResponse(c, http.StatusBadRequest, "INVALID_SORT", "Geçersiz sıralama yönü", []string{"asc", "desc"})
+		return false
+	}
+
+	if view.Filters == nil {
+		view.Filters = map[string]string{}
+	}
+	return true
+}
+
+// getView kullanıcıya ait görünümü getirir
+func (h *ViewHandler) getView(viewID, userID string) (models.SavedView, error) {
+	row := h.db.QueryRow(`
+		SELECT id, user_id, resource, name, filters, COALESCE(sort_by, ''), COALESCE(sort_order, ''),
+		       is_default, created_at, updated_at
+		FROM saved_views WHERE id = ? AND user_id = ?
+	`, viewID, userID)
+	return scanView(row)
+}
+
+// scanView görünüm satırını okur
+func scanView(row interface{ Scan(...interface{}) error }) (models.SavedView, error) {
+	var view models.SavedView
+	var filters string
+
+	err := row.Scan(
+		&view.ID, &view.UserID, &view.Resource, &view.Name, &filters, &view.SortBy, &view.SortOrder,
+		&view.IsDefault, &view.CreatedAt, &view.UpdatedAt,
+	)
+	if err != nil {
+		return view, err
+	}
+
+	view.Filters = map[string]string{}
+	utils.FromJSON(filters, &view.Filters)
+	return view, nil
+}
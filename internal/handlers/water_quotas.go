@@ -0,0 +1,239 @@
+package handlers
+
+import (
+	"database/sql"
+	"errors"
+	"net/http"
+
+	"agri-management-api/internal/models"
+	"agri-management-api/internal/services"
+	"agri-management-api/internal/utils"
+
+	"github.com/gin-gonic/gin"
+)
+
+// WaterQuotaHandler sulama suyu kotalarını ve arazilerin su kullanımını yönetir
+type WaterQuotaHandler struct {
+	db     *sql.DB
+	quotas *services.WaterQuotaService
+}
+
+// NewWaterQuotaHandler yeni water quota handler oluşturur
+func NewWaterQuotaHandler(db *sql.DB) *WaterQuotaHandler {
+	return &WaterQuotaHandler{
+		db:     db,
+		quotas: services.NewWaterQuotaService(db),
+	}
+}
+
+// GetWaterQuotas su kotaları
+// @Summary Su kotaları
+// @Description Arazi ve çiftlik bazındaki sezonluk su tahsislerini kullanılan ve kalan hacim (m³), kullanım oranı (yüzde), su maliyeti ve durumla (ok, warning, exceeded) listeler. landId verilirse araziyi kapsayan arazi ve çiftlik kotaları döner
+// @ID getWaterQuotas
+// @Tags Water Quotas
+// @Produce json
+// @Security BearerAuth
+// @Param landId query string false "Arazi ID"
+// @Success 200 {object} models.APIResponse{data=[]models.WaterQuota}
+// @Failure 401 {object} models.APIResponse
+// @Router /water-quotas [get]
+func (h *WaterQuotaHandler) GetWaterQuotas(c *gin.Context) {
+	userID, err := utils.GetUserID(c)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusUnauthorized, "UNAUTHORIZED", "Kullanıcı kimliği doğrulanamadı", nil)
+		return
+	}
+
+	quotas, err := h.quotas.List(userID, c.Query("landId"))
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusInternalServerError, "DB_ERROR", "Su kotaları alınamadı", err.Error())
+		return
+	}
+
+	utils.SuccessResponse(c, quotas, "Su kotaları başarıyla getirildi")
+}
+
+// GetWaterQuota su kotası detayı
+// @Summary Su kotası detayı
+// @Description Kotayı sezon içindeki kullanım durumuyla getirir
+// @ID getWaterQuota
+// @Tags Water Quotas
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Kota ID"
+// @Success 200 {object} models.APIResponse{data=models.WaterQuota}
+// @Failure 401 {object} models.APIResponse
+// @Failure 404 {object} models.APIResponse
+// @Router /water-quotas/{id} [get]
+func (h *WaterQuotaHandler) GetWaterQuota(c *gin.Context) {
+	userID, err := utils.GetUserID(c)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusUnauthorized, "UNAUTHORIZED", "Kullanıcı kimliği doğrulanamadı", nil)
+		return
+	}
+
+	quota, err := h.quotas.Get(userID, c.Param("id"))
+	if err != nil {
+		writeWaterQuotaError(c, err, "Su kotası alınamadı")
+		return
+	}
+
+	utils.SuccessResponse(c, quota, "Su kotası başarıyla getirildi")
+}
+
+// CreateWaterQuota su kotası ekleme
+// @Summary Su kotası ekle
+// @Description Arazi (landId) veya tüm çiftlik için sezonluk su tahsisi (m³) ekler. unitPrice m³ başına su ücretidir; warningPercent (varsayılan 80) kullanım bu orana ulaşınca uyarı bildirimi gönderilmesini sağlar, kota aşılınca ayrıca bildirilir
+// @ID createWaterQuota
+// @Tags Water Quotas
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param request body models.WaterQuotaRequest true "Kota bilgileri"
+// @Success 201 {object} models.APIResponse{data=models.WaterQuota}
+// @Failure 400 {object} models.APIResponse
+// @Failure 401 {object} models.APIResponse
+// @Failure 404 {object} models.APIResponse
+// @Router /water-quotas [post]
+func (h *WaterQuotaHandler) CreateWaterQuota(c *gin.Context) {
+	userID, err := utils.GetUserID(c)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusUnauthorized, "UNAUTHORIZED", "Kullanıcı kimliği doğrulanamadı", nil)
+		return
+	}
+
+	var req models.WaterQuotaRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		utils.ErrorResponse(c, http.StatusBadRequest, "INVALID_REQUEST", "Geçersiz istek formatı", err.Error())
+		return
+	}
+
+	quota, err := h.quotas.Create(userID, req)
+	if err != nil {
+		writeWaterQuotaError(c, err, "Su kotası eklenemedi")
+		return
+	}
+
+	utils.CreatedResponse(c, quota, "Su kotası başarıyla eklendi")
+}
+
+// UpdateWaterQuota su kotası güncelleme
+// @Summary Su kotasını güncelle
+// @Description Kotanın arazisini, sezonunu, hacmini, birim fiyatını ve uyarı eşiğini günceller; uyarı durumu yeni değerlere göre yeniden değerlendirilir
+// @ID updateWaterQuota
+// @Tags Water Quotas
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Kota ID"
+// @Param request body models.WaterQuotaRequest true "Kota bilgileri"
+// @Success 200 {object} models.APIResponse{data=models.WaterQuota}
+// @Failure 400 {object} models.APIResponse
+// @Failure 401 {object} models.APIResponse
+// @Failure 404 {object} models.APIResponse
+// @Router /water-quotas/{id} [put]
+func (h *WaterQuotaHandler) UpdateWaterQuota(c *gin.Context) {
+	userID, err := utils.GetUserID(c)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusUnauthorized, "UNAUTHORIZED", "Kullanıcı kimliği doğrulanamadı", nil)
+		return
+	}
+
+	var req models.WaterQuotaRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		utils.ErrorResponse(c, http.StatusBadRequest, "INVALID_REQUEST", "Geçersiz istek formatı", err.Error())
+		return
+	}
+
+	quota, err := h.quotas.Update(userID, c.Param("id"), req)
+	if err != nil {
+		writeWaterQuotaError(c, err, "Su kotası güncellenemedi")
+		return
+	}
+
+	utils.SuccessResponse(c, quota, "Su kotası başarıyla güncellendi")
+}
+
+// DeleteWaterQuota su kotası silme
+// @Summary Su kotasını sil
+// @Description Kotayı siler; sulama kayıtları değişmez
+// @ID deleteWaterQuota
+// @Tags Water Quotas
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Kota ID"
+// @Success 200 {object} models.APIResponse
+// @Failure 401 {object} models.APIResponse
+// @Failure 404 {object} models.APIResponse
+// @Router /water-quotas/{id} [delete]
+func (h *WaterQuotaHandler) DeleteWaterQuota(c *gin.Context) {
+	userID, err := utils.GetUserID(c)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusUnauthorized, "UNAUTHORIZED", "Kullanıcı kimliği doğrulanamadı", nil)
+		return
+	}
+
+	if err := h.quotas.Delete(userID, c.Param("id")); err != nil {
+		writeWaterQuotaError(c, err, "Su kotası silinemedi")
+		return
+	}
+
+	utils.SuccessResponse(c, nil, "Su kotası başarıyla silindi")
+}
+
+// GetLandWaterUsage arazi su kullanımı
+// @Summary Arazi su kullanımı
+// @Description Arazinin dönemdeki gerçekleşen sulamalarının toplam hacmini (m³), tarihini kapsayan arazi (yoksa çiftlik) kotasının birim fiyatıyla su maliyetini, kotasız hacmi ve araziyi kapsayan kotaların kullanımını getirir
+// @ID getLandWaterUsage
+// @Tags Lands
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Arazi ID"
+// @Param startDate query string false "Başlangıç tarihi (YYYY-MM-DD)"
+// @Param endDate query string false "Bitiş tarihi (YYYY-MM-DD, dahil)"
+// @Success 200 {object} models.APIResponse{data=models.LandWaterUsage}
+// @Failure 400 {object} models.APIResponse
+// @Failure 401 {object} models.APIResponse
+// @Failure 404 {object} models.APIResponse
+// @Router /lands/{id}/water-usage [get]
+func (h *WaterQuotaHandler) GetLandWaterUsage(c *gin.Context) {
+	userID, err := utils.GetUserID(c)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusUnauthorized, "UNAUTHORIZED", "Kullanıcı kimliği doğrulanamadı", nil)
+		return
+	}
+
+	startDate, endDate, ok := dateRangeQuery(c)
+	if !ok {
+		return
+	}
+	from, to := "0001-01-01", "9999-12-31"
+	if startDate != nil {
+		from = startDate.Format("2006-01-02")
+	}
+	if endDate != nil {
+		to = endDate.Format("2006-01-02")
+	}
+
+	usage, err := h.quotas.LandWater(userID, c.Param("id"), from, to)
+	if err != nil {
+		writeWaterQuotaError(c, err, "Su kullanımı alınamadı")
+		return
+	}
+
+	utils.SuccessResponse(c, usage, "Su kullanımı başarıyla getirildi")
+}
+
+// writeWaterQuotaError servis hatasını HTTP yanıtına çevirir
+func writeWaterQuotaError(c *gin.Context, err error, message string) {
+	switch {
+	case errors.Is(err, services.ErrWaterQuotaNotFound):
+		utils.ErrorResponse(c, http.StatusNotFound, "QUOTA_NOT_FOUND", "Su kotası bulunamadı", nil)
+	case errors.Is(err, services.ErrWaterQuotaLand):
+		utils.ErrorResponse(c, http.StatusNotFound, "LAND_NOT_FOUND", "Arazi bulunamadı", nil)
+	case errors.Is(err, services.ErrInvalidWaterQuotaSeason):
+		utils.ErrorResponse(c, http.StatusBadRequest, "INVALID_SEASON", "Sezon bitişi başlangıçtan önce olamaz", nil)
+	default:
+		utils.ErrorResponse(c, http.StatusInternalServerError, "DB_ERROR", message, err.Error())
+	}
+}
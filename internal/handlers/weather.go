@@ -3,13 +3,12 @@ package handlers
 import (
 	"database/sql"
 	"encoding/json"
-	"fmt"
-	"io"
 	"net/http"
 	"strconv"
 	"time"
 
 	"agri-management-api/internal/models"
+	"agri-management-api/internal/services"
 	"agri-management-api/internal/utils"
 
 	"github.com/gin-gonic/gin"
@@ -17,17 +16,22 @@ import (
 
 // WeatherHandler hava durumu işlemlerini yönetir
 type WeatherHandler struct {
-	db *sql.DB
+	db    *sql.DB
+	flags *services.FeatureFlagService
 }
 
 // NewWeatherHandler yeni weather handler oluşturur
 func NewWeatherHandler(db *sql.DB) *WeatherHandler {
-	return &WeatherHandler{db: db}
+	return &WeatherHandler{
+		db:    db,
+		flags: services.NewFeatureFlagService(db),
+	}
 }
 
 // GetCurrentWeather güncel hava durumu
 // @Summary Güncel hava durumu
 // @Description Belirtilen koordinatlar için güncel hava durumu getirir
+// @ID getCurrentWeather
 // @Tags Weather
 // @Accept json
 // @Produce json
@@ -39,7 +43,7 @@ func NewWeatherHandler(db *sql.DB) *WeatherHandler {
 // @Failure 401 {object} models.APIResponse
 // @Router /weather/current [get]
 func (h *WeatherHandler) GetCurrentWeather(c *gin.Context) {
-	_, err := utils.GetUserID(c)
+	userID, err := utils.GetUserID(c)
 	if err != nil {
 		utils.ErrorResponse(c, http.StatusUnauthorized, "UNAUTHORIZED", "Kullanıcı kimliği doğrulanamadı", nil)
 		return
@@ -68,7 +72,11 @@ func (h *WeatherHandler) GetCurrentWeather(c *gin.Context) {
 	// Hava durumu verilerini al (OpenWeatherMap API simülasyonu)
 	weather, err := h.fetchCurrentWeather(lat, lon)
 	if err != nil {
-		// API hatası durumunda mock data döndür
+		// API hatası durumunda bayrak açıksa mock data döndür
+		if !h.useMockData(c, userID) {
+			utils.ErrorResponse(c, http.StatusServiceUnavailable, "WEATHER_UNAVAILABLE", "Hava durumu sağlayıcısına ulaşılamadı", err.Error())
+			return
+		}
 		weather = h.getMockCurrentWeather(lat, lon)
 	}
 
@@ -77,7 +85,8 @@ func (h *WeatherHandler) GetCurrentWeather(c *gin.Context) {
 
 // GetWeatherForecast hava durumu tahmini
 // @Summary Hava durumu tahmini
-// @Description Belirtilen koordinatlar için 7 günlük hava durumu tahmini getirir
+// @Description Belirtilen koordinatlar için bugünden başlayarak günlük hava durumu tahmini getirir; sağlayıcı en fazla 5 günlük tahmin verir. rainfall günün toplam beklenen yağışıdır (mm)
+// @ID getWeatherForecast
 // @Tags Weather
 // @Accept json
 // @Produce json
@@ -90,7 +99,7 @@ func (h *WeatherHandler) GetCurrentWeather(c *gin.Context) {
 // @Failure 401 {object} models.APIResponse
 // @Router /weather/forecast [get]
 func (h *WeatherHandler) GetWeatherForecast(c *gin.Context) {
-	_, err := utils.GetUserID(c)
+	userID, err := utils.GetUserID(c)
 	if err != nil {
 		utils.ErrorResponse(c, http.StatusUnauthorized, "UNAUTHORIZED", "Kullanıcı kimliği doğrulanamadı", nil)
 		return
@@ -125,7 +134,11 @@ func (h *WeatherHandler) GetWeatherForecast(c *gin.Context) {
 	// Hava durumu tahminini al
 	forecast, err := h.fetchWeatherForecast(lat, lon, days)
 	if err != nil {
-		// API hatası durumunda mock data döndür
+		// API hatası durumunda bayrak açıksa mock data döndür
+		if !h.useMockData(c, userID) {
+			utils.ErrorResponse(c, http.StatusServiceUnavailable, "WEATHER_UNAVAILABLE", "Hava durumu tahmini alınamadı", err.Error())
+			return
+		}
 		forecast = h.getMockWeatherForecast(days)
 	}
 
@@ -135,6 +148,7 @@ func (h *WeatherHandler) GetWeatherForecast(c *gin.Context) {
 // GetAgriculturalAlerts tarımsal uyarılar
 // @Summary Tarımsal uyarılar
 // @Description Belirtilen koordinatlar için tarımsal uyarıları getirir
+// @ID getAgriculturalAlerts
 // @Tags Weather
 // @Accept json
 // @Produce json
@@ -146,7 +160,7 @@ func (h *WeatherHandler) GetWeatherForecast(c *gin.Context) {
 // @Failure 401 {object} models.APIResponse
 // @Router /weather/agricultural-alerts [get]
 func (h *WeatherHandler) GetAgriculturalAlerts(c *gin.Context) {
-	_, err := utils.GetUserID(c)
+	userID, err := utils.GetUserID(c)
 	if err != nil {
 		utils.ErrorResponse(c, http.StatusUnauthorized, "UNAUTHORIZED", "Kullanıcı kimliği doğrulanamadı", nil)
 		return
@@ -172,6 +186,12 @@ func (h *WeatherHandler) GetAgriculturalAlerts(c *gin.Context) {
 		return
 	}
 
+	// Tarımsal uyarılar şimdilik örnek veridir
+	if !h.useMockData(c, userID) {
+		utils.ErrorResponse(c, http.StatusServiceUnavailable, "WEATHER_UNAVAILABLE", "Tarımsal uyarı sağlayıcısı yapılandırılmamış", nil)
+		return
+	}
+
 	// Tarımsal uyarıları al
 	alerts := h.getAgriculturalAlerts(lat, lon)
 
@@ -180,66 +200,26 @@ func (h *WeatherHandler) GetAgriculturalAlerts(c *gin.Context) {
 
 // fetchCurrentWeather gerçek API'den güncel hava durumu alır
 func (h *WeatherHandler) fetchCurrentWeather(lat, lon float64) (*models.Weather, error) {
-	// OpenWeatherMap API key (gerçek uygulamada environment variable'dan alınacak)
-	apiKey := "YOUR_API_KEY"
-	url := fmt.Sprintf("https://api.openweathermap.org/data/2.5/weather?lat=%f&lon=%f&appid=%s&units=metric&lang=tr", lat, lon, apiKey)
-
-	resp, err := http.Get(url)
-	if err != nil {
-		return nil, err
-	}
-	defer resp.Body.Close()
-
-	body, err := io.ReadAll(resp.Body)
-	if err != nil {
-		return nil, err
-	}
+	return services.FetchCurrentWeather(lat, lon)
+}
 
-	var apiResponse struct {
-		Name string `json:"name"`
-		Main struct {
-			Temp     float64 `json:"temp"`
-			Humidity float64 `json:"humidity"`
-			Pressure float64 `json:"pressure"`
-		} `json:"main"`
-		Weather []struct {
-			Main        string `json:"main"`
-			Description string `json:"description"`
-			Icon        string `json:"icon"`
-		} `json:"weather"`
-		Wind struct {
-			Speed float64 `json:"speed"`
-			Deg   float64 `json:"deg"`
-		} `json:"wind"`
-		Visibility int `json:"visibility"`
-	}
+// fetchWeatherForecast gerçek API'den hava durumu tahmini alır
+func (h *WeatherHandler) fetchWeatherForecast(lat, lon float64, days int) ([]models.WeatherForecast, error) {
+	return services.FetchWeatherForecast(lat, lon, days)
+}
 
-	if err := json.Unmarshal(body, &apiResponse); err != nil {
-		return nil, err
+// useMockData mock_weather bayrağını kontrol eder ve açıksa yanıtı kullanımdan kaldırılacak olarak işaretler
+func (h *WeatherHandler) useMockData(c *gin.Context, userID string) bool {
+	flag, exists := h.flags.Get(services.FlagMockWeather, userID)
+	if !exists || !flag.Enabled {
+		return false
 	}
 
-	weather := &models.Weather{
-		Location:      apiResponse.Name,
-		Temperature:   apiResponse.Main.Temp,
-		Humidity:      apiResponse.Main.Humidity,
-		WindSpeed:     apiResponse.Wind.Speed,
-		WindDirection: getWindDirection(apiResponse.Wind.Deg),
-		Pressure:      apiResponse.Main.Pressure,
-		Visibility:    float64(apiResponse.Visibility) / 1000, // m to km
-		UVIndex:       5.0,                                    // Mock value
-		Condition:     apiResponse.Weather[0].Description,
-		Icon:          apiResponse.Weather[0].Icon,
-		LastUpdated:   time.Now().Format("2006-01-02T15:04:05Z"),
+	c.Header("X-Mock-Data", "true")
+	if flag.Deprecated {
+		utils.MarkDeprecated(c, flag.DeprecationNote)
 	}
-
-	return weather, nil
-}
-
-// fetchWeatherForecast gerçek API'den hava durumu tahmini alır
-func (h *WeatherHandler) fetchWeatherForecast(lat, lon float64, days int) ([]models.WeatherForecast, error) {
-	// Bu fonksiyon gerçek API çağrısı yapacak
-	// Şimdilik mock data döndürüyoruz
-	return h.getMockWeatherForecast(days), nil
+	return true
 }
 
 // getMockCurrentWeather mock güncel hava durumu
@@ -261,27 +241,7 @@ func (h *WeatherHandler) getMockCurrentWeather(lat, lon float64) *models.Weather
 
 // getMockWeatherForecast mock hava durumu tahmini
 func (h *WeatherHandler) getMockWeatherForecast(days int) []models.WeatherForecast {
-	var forecast []models.WeatherForecast
-
-	conditions := []string{"Güneşli", "Parçalı bulutlu", "Bulutlu", "Hafif yağmur", "Güneşli"}
-	icons := []string{"01d", "02d", "03d", "10d", "01d"}
-
-	for i := 0; i < days; i++ {
-		date := time.Now().AddDate(0, 0, i+1)
-
-		forecast = append(forecast, models.WeatherForecast{
-			Date:       date.Format("2006-01-02"),
-			MinTemp:    15.0 + float64(i%3),
-			MaxTemp:    25.0 + float64(i%5),
-			Condition:  conditions[i%len(conditions)],
-			Icon:       icons[i%len(icons)],
-			Humidity:   60.0 + float64(i%20),
-			RainChance: float64((i * 15) % 80),
-			WindSpeed:  8.0 + float64(i%10),
-		})
-	}
-
-	return forecast
+	return services.MockWeatherForecast(days)
 }
 
 // getAgriculturalAlerts tarımsal uyarıları döndürür
@@ -321,13 +281,6 @@ func (h *WeatherHandler) getAgriculturalAlerts(lat, lon float64) []models.Agricu
 	return alerts
 }
 
-// getWindDirection rüzgar derecesini yön olarak çevirir
-func getWindDirection(deg float64) string {
-	directions := []string{"K", "KKD", "KD", "DKD", "D", "DGD", "GD", "GGD", "G", "GGB", "GB", "BGB", "B", "BBK", "BK", "KBK"}
-	index := int((deg + 11.25) / 22.5)
-	return directions[index%16]
-}
-
 // SaveWeatherData hava durumu verilerini cache'e kaydet
 func (h *WeatherHandler) SaveWeatherData(lat, lon float64, weather *models.Weather) error {
 	// Hava durumu verilerini veritabanına cache olarak kaydet
@@ -0,0 +1,238 @@
+package handlers
+
+import (
+	"database/sql"
+	"errors"
+	"net/http"
+	"strconv"
+
+	"agri-management-api/internal/models"
+	"agri-management-api/internal/services"
+	"agri-management-api/internal/utils"
+
+	"github.com/gin-gonic/gin"
+)
+
+// WeatherLocationHandler arazi dışındaki kayıtlı hava durumu konumlarını ve aboneliklerini yönetir
+type WeatherLocationHandler struct {
+	locations *services.WeatherLocationService
+	flags     *services.FeatureFlagService
+}
+
+// NewWeatherLocationHandler yeni weather location handler oluşturur
+func NewWeatherLocationHandler(db *sql.DB) *WeatherLocationHandler {
+	return &WeatherLocationHandler{
+		locations: services.NewWeatherLocationService(db),
+		flags:     services.NewFeatureFlagService(db),
+	}
+}
+
+// GetWeatherLocations kayıtlı konumlar
+// @Summary Kayıtlı hava durumu konumları
+// @Description Arazi dışında hava durumu takip edilen kayıtlı konumları (ör. başka ildeki mera) abonelik ayarlarıyla listeler
+// @ID getWeatherLocations
+// @Tags Weather
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {object} models.APIResponse{data=[]models.WeatherLocation}
+// @Failure 401 {object} models.APIResponse
+// @Router /weather/locations [get]
+func (h *WeatherLocationHandler) GetWeatherLocations(c *gin.Context) {
+	userID, err := utils.GetUserID(c)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusUnauthorized, "UNAUTHORIZED", "Kullanıcı kimliği doğrulanamadı", nil)
+		return
+	}
+
+	locations, err := h.locations.List(userID)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusInternalServerError, "DB_ERROR", "Konumlar alınamadı", err.Error())
+		return
+	}
+
+	utils.SuccessResponse(c, locations, "Konumlar başarıyla getirildi")
+}
+
+// GetWeatherLocation kayıtlı konum detayı
+// @Summary Kayıtlı konum detayı
+// @ID getWeatherLocation
+// @Tags Weather
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Konum ID"
+// @Success 200 {object} models.APIResponse{data=models.WeatherLocation}
+// @Failure 401 {object} models.APIResponse
+// @Failure 404 {object} models.APIResponse
+// @Router /weather/locations/{id} [get]
+func (h *WeatherLocationHandler) GetWeatherLocation(c *gin.Context) {
+	userID, err := utils.GetUserID(c)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusUnauthorized, "UNAUTHORIZED", "Kullanıcı kimliği doğrulanamadı", nil)
+		return
+	}
+
+	location, err := h.locations.Get(userID, c.Param("id"))
+	if err != nil {
+		writeWeatherLocationError(c, err, "Konum alınamadı")
+		return
+	}
+
+	utils.SuccessResponse(c, location, "Konum başarıyla getirildi")
+}
+
+// CreateWeatherLocation kayıtlı konum ekleme
+// @Summary Kayıtlı konum ekle
+// @Description Hava durumu takip edilecek konumu ekler ve günlük tahmin özeti ile don/yoğun yağış uyarılarına abone olur. Bildirimler konumun saat diliminde (timezone, varsayılan Europe/Istanbul) deliveryHour saatinde (varsayılan 7) channels kanallarından gönderilir: push uygulama bildirimi, email çiftlik sahibinin e-posta adresidir ve ayarlarda e-posta bildirimleri açık olmalıdır. dailySummary ve alerts verilmezse ikisi de açıktır
+// @ID createWeatherLocation
+// @Tags Weather
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param request body models.WeatherLocationRequest true "Konum bilgileri"
+// @Success 201 {object} models.APIResponse{data=models.WeatherLocation}
+// @Failure 400 {object} models.APIResponse
+// @Failure 401 {object} models.APIResponse
+// @Router /weather/locations [post]
+func (h *WeatherLocationHandler) CreateWeatherLocation(c *gin.Context) {
+	userID, err := utils.GetUserID(c)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusUnauthorized, "UNAUTHORIZED", "Kullanıcı kimliği doğrulanamadı", nil)
+		return
+	}
+
+	var req models.WeatherLocationRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		utils.ErrorResponse(c, http.StatusBadRequest, "INVALID_REQUEST", "Geçersiz istek formatı", err.Error())
+		return
+	}
+
+	location, err := h.locations.Create(userID, req)
+	if err != nil {
+		writeWeatherLocationError(c, err, "Konum eklenemedi")
+		return
+	}
+
+	utils.CreatedResponse(c, location, "Konum başarıyla eklendi")
+}
+
+// UpdateWeatherLocation kayıtlı konum güncelleme
+// @Summary Kayıtlı konumu güncelle
+// @Description Konumu ve aboneliğini günceller; verilmeyen abonelik alanları korunur. Teslim saati veya saat dilimi değişirse günün bildirimi yeni saatte yeniden gönderilebilir
+// @ID updateWeatherLocation
+// @Tags Weather
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Konum ID"
+// @Param request body models.WeatherLocationRequest true "Konum bilgileri"
+// @Success 200 {object} models.APIResponse{data=models.WeatherLocation}
+// @Failure 400 {object} models.APIResponse
+// @Failure 401 {object} models.APIResponse
+// @Failure 404 {object} models.APIResponse
+// @Router /weather/locations/{id} [put]
+func (h *WeatherLocationHandler) UpdateWeatherLocation(c *gin.Context) {
+	userID, err := utils.GetUserID(c)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusUnauthorized, "UNAUTHORIZED", "Kullanıcı kimliği doğrulanamadı", nil)
+		return
+	}
+
+	var req models.WeatherLocationRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		utils.ErrorResponse(c, http.StatusBadRequest, "INVALID_REQUEST", "Geçersiz istek formatı", err.Error())
+		return
+	}
+
+	location, err := h.locations.Update(userID, c.Param("id"), req)
+	if err != nil {
+		writeWeatherLocationError(c, err, "Konum güncellenemedi")
+		return
+	}
+
+	utils.SuccessResponse(c, location, "Konum başarıyla güncellendi")
+}
+
+// DeleteWeatherLocation kayıtlı konum silme
+// @Summary Kayıtlı konumu sil
+// @Description Konumu ve aboneliğini siler; konum için bildirim gönderilmez
+// @ID deleteWeatherLocation
+// @Tags Weather
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Konum ID"
+// @Success 200 {object} models.APIResponse
+// @Failure 401 {object} models.APIResponse
+// @Failure 404 {object} models.APIResponse
+// @Router /weather/locations/{id} [delete]
+func (h *WeatherLocationHandler) DeleteWeatherLocation(c *gin.Context) {
+	userID, err := utils.GetUserID(c)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusUnauthorized, "UNAUTHORIZED", "Kullanıcı kimliği doğrulanamadı", nil)
+		return
+	}
+
+	if err := h.locations.Delete(userID, c.Param("id")); err != nil {
+		writeWeatherLocationError(c, err, "Konum silinemedi")
+		return
+	}
+
+	utils.SuccessResponse(c, nil, "Konum başarıyla silindi")
+}
+
+// GetWeatherLocationForecast kayıtlı konumun hava tahmini
+// @Summary Kayıtlı konumun hava tahmini
+// @Description Konumun günlük hava tahminini ve tahminden üretilen uyarıları döner: en düşük sıcaklığın 0°C ve altında olduğu günler frost, günlük yağışın 20 mm ve üzerinde olduğu günler heavy_rain uyarısıdır. Sağlayıcı yapılandırılmamışsa mock_weather bayrağı açıkken örnek tahmin döner (X-Mock-Data başlığı)
+// @ID getWeatherLocationForecast
+// @Tags Weather
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Konum ID"
+// @Param days query int false "Gün sayısı (varsayılan: 5, en fazla 7)"
+// @Success 200 {object} models.APIResponse{data=models.WeatherLocationForecast}
+// @Failure 401 {object} models.APIResponse
+// @Failure 404 {object} models.APIResponse
+// @Failure 503 {object} models.APIResponse
+// @Router /weather/locations/{id}/forecast [get]
+func (h *WeatherLocationHandler) GetWeatherLocationForecast(c *gin.Context) {
+	userID, err := utils.GetUserID(c)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusUnauthorized, "UNAUTHORIZED", "Kullanıcı kimliği doğrulanamadı", nil)
+		return
+	}
+
+	days, err := strconv.Atoi(c.DefaultQuery("days", strconv.Itoa(services.WeatherLocationForecastDays)))
+	if err != nil || days < 1 || days > 7 {
+		days = services.WeatherLocationForecastDays
+	}
+
+	flag, exists := h.flags.Get(services.FlagMockWeather, userID)
+	mock := exists && flag.Enabled
+
+	forecast, err := h.locations.Forecast(userID, c.Param("id"), days, mock)
+	if err != nil {
+		writeWeatherLocationError(c, err, "Hava tahmini alınamadı")
+		return
+	}
+	if forecast.ForecastSource == services.WeatherLocationForecastMock {
+		c.Header("X-Mock-Data", "true")
+		if flag.Deprecated {
+			utils.MarkDeprecated(c, flag.DeprecationNote)
+		}
+	}
+
+	utils.SuccessResponse(c, forecast, "Hava tahmini başarıyla getirildi")
+}
+
+// writeWeatherLocationError servis hatasını HTTP yanıtına çevirir
+func writeWeatherLocationError(c *gin.Context, err error, message string) {
+	switch {
+	case errors.Is(err, services.ErrWeatherLocationNotFound):
+		utils.ErrorResponse(c, http.StatusNotFound, "LOCATION_NOT_FOUND", "Konum bulunamadı", nil)
+	case errors.Is(err, services.ErrWeatherLocationTimezone):
+		utils.ErrorResponse(c, http.StatusBadRequest, "INVALID_TIMEZONE", err.Error(), nil)
+	case errors.Is(err, services.ErrWeatherLocationForecast):
+		utils.ErrorResponse(c, http.StatusServiceUnavailable, "WEATHER_UNAVAILABLE", "Hava durumu tahmini alınamadı", err.Error())
+	default:
+		utils.ErrorResponse(c, http.StatusInternalServerError, "DB_ERROR", message, err.Error())
+	}
+}
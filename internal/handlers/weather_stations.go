@@ -0,0 +1,322 @@
+package handlers
+
+import (
+	"database/sql"
+	"errors"
+	"net/http"
+	"strconv"
+
+	"agri-management-api/internal/models"
+	"agri-management-api/internal/services"
+	"agri-management-api/internal/utils"
+
+	"github.com/gin-gonic/gin"
+)
+
+// WeatherStationHandler hava istasyonlarını, istasyon ölçümlerini ve arazilerin hava verisi kaynaklarını yönetir
+type WeatherStationHandler struct {
+	db       *sql.DB
+	stations *services.WeatherStationService
+}
+
+// NewWeatherStationHandler yeni weather station handler oluşturur
+func NewWeatherStationHandler(db *sql.DB) *WeatherStationHandler {
+	return &WeatherStationHandler{
+		db:       db,
+		stations: services.NewWeatherStationService(db),
+	}
+}
+
+// GetWeatherStations hava istasyonları
+// @Summary Hava istasyonları
+// @Description Çiftliğin kendi ve yakındaki hava istasyonlarını eşleştirilmiş arazi sayısı ve son ölçüm zamanıyla listeler
+// @ID getWeatherStations
+// @Tags Weather Stations
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {object} models.APIResponse{data=[]models.WeatherStation}
+// @Failure 401 {object} models.APIResponse
+// @Router /weather-stations [get]
+func (h *WeatherStationHandler) GetWeatherStations(c *gin.Context) {
+	userID, err := utils.GetUserID(c)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusUnauthorized, "UNAUTHORIZED", "Kullanıcı kimliği doğrulanamadı", nil)
+		return
+	}
+
+	stations, err := h.stations.List(userID)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusInternalServerError, "DB_ERROR", "İstasyonlar alınamadı", err.Error())
+		return
+	}
+
+	utils.SuccessResponse(c, stations, "İstasyonlar başarıyla getirildi")
+}
+
+// CreateWeatherStation hava istasyonu ekleme
+// @Summary Hava istasyonu ekle
+// @Description Kullanıcının kendi istasyonunu (own) veya araziye yakın fiziksel bir istasyonu (nearby) ekler. Dönen token istasyonun POST /sensors/weather-readings isteğinde X-Sensor-Token başlığıyla gönderilir ve yalnızca bu yanıtta gösterilir
+// @ID createWeatherStation
+// @Tags Weather Stations
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param request body models.WeatherStationRequest true "İstasyon bilgileri"
+// @Success 201 {object} models.APIResponse{data=models.WeatherStation}
+// @Failure 400 {object} models.APIResponse
+// @Failure 401 {object} models.APIResponse
+// @Router /weather-stations [post]
+func (h *WeatherStationHandler) CreateWeatherStation(c *gin.Context) {
+	userID, err := utils.GetUserID(c)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusUnauthorized, "UNAUTHORIZED", "Kullanıcı kimliği doğrulanamadı", nil)
+		return
+	}
+
+	var req models.WeatherStationRequest
+	if !bindWeatherStation(c, &req) {
+		return
+	}
+
+	station, err := h.stations.Create(userID, req)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusInternalServerError, "DB_ERROR", "İstasyon eklenemedi", err.Error())
+		return
+	}
+
+	utils.CreatedResponse(c, station, "İstasyon başarıyla eklendi")
+}
+
+// UpdateWeatherStation hava istasyonu güncelleme
+// @Summary Hava istasyonunu güncelle
+// @Description İstasyonun adını, türünü ve konumunu günceller; istasyon anahtarı değişmez
+// @ID updateWeatherStation
+// @Tags Weather Stations
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "İstasyon ID"
+// @Param request body models.WeatherStationRequest true "İstasyon bilgileri"
+// @Success 200 {object} models.APIResponse{data=models.WeatherStation}
+// @Failure 400 {object} models.APIResponse
+// @Failure 401 {object} models.APIResponse
+// @Failure 404 {object} models.APIResponse
+// @Router /weather-stations/{id} [put]
+func (h *WeatherStationHandler) UpdateWeatherStation(c *gin.Context) {
+	userID, err := utils.GetUserID(c)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusUnauthorized, "UNAUTHORIZED", "Kullanıcı kimliği doğrulanamadı", nil)
+		return
+	}
+
+	var req models.WeatherStationRequest
+	if !bindWeatherStation(c, &req) {
+		return
+	}
+
+	station, err := h.stations.Update(userID, c.Param("id"), req)
+	if err != nil {
+		writeWeatherStationError(c, err, "İstasyon güncellenemedi")
+		return
+	}
+
+	utils.SuccessResponse(c, station, "İstasyon başarıyla güncellendi")
+}
+
+// DeleteWeatherStation hava istasyonu silme
+// @Summary Hava istasyonunu sil
+// @Description İstasyonu ve ölçümlerini siler; eşleştirilmiş arazilerin istasyon gözlemleri kaldırılır ve araziler sıradaki kaynağı kullanır
+// @ID deleteWeatherStation
+// @Tags Weather Stations
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "İstasyon ID"
+// @Success 200 {object} models.APIResponse
+// @Failure 401 {object} models.APIResponse
+// @Failure 404 {object} models.APIResponse
+// @Router /weather-stations/{id} [delete]
+func (h *WeatherStationHandler) DeleteWeatherStation(c *gin.Context) {
+	userID, err := utils.GetUserID(c)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusUnauthorized, "UNAUTHORIZED", "Kullanıcı kimliği doğrulanamadı", nil)
+		return
+	}
+
+	if err := h.stations.Delete(userID, c.Param("id")); err != nil {
+		writeWeatherStationError(c, err, "İstasyon silinemedi")
+		return
+	}
+
+	utils.SuccessResponse(c, nil, "İstasyon başarıyla silindi")
+}
+
+// GetWeatherStationReadings istasyon ölçümleri
+// @Summary İstasyon ölçümleri
+// @Description İstasyonun tarih aralığındaki ölçümlerini en yeniden eskiye listeler
+// @ID getWeatherStationReadings
+// @Tags Weather Stations
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "İstasyon ID"
+// @Param startDate query string false "Başlangıç tarihi (YYYY-MM-DD, varsayılan: 30 gün önce)"
+// @Param endDate query string false "Bitiş tarihi (YYYY-MM-DD, varsayılan: bugün)"
+// @Param limit query int false "En fazla kayıt (varsayılan 500, en çok 5000)"
+// @Success 200 {object} models.APIResponse{data=[]models.WeatherStationReading}
+// @Failure 400 {object} models.APIResponse
+// @Failure 401 {object} models.APIResponse
+// @Failure 404 {object} models.APIResponse
+// @Router /weather-stations/{id}/readings [get]
+func (h *WeatherStationHandler) GetWeatherStationReadings(c *gin.Context) {
+	userID, err := utils.GetUserID(c)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusUnauthorized, "UNAUTHORIZED", "Kullanıcı kimliği doğrulanamadı", nil)
+		return
+	}
+
+	startDate, endDate, ok := weatherDateRange(c)
+	if !ok {
+		return
+	}
+
+	limit, err := strconv.Atoi(c.DefaultQuery("limit", "500"))
+	if err != nil || limit < 1 {
+		limit = 500
+	}
+
+	readings, err := h.stations.Readings(userID, c.Param("id"), startDate, endDate, limit)
+	if err != nil {
+		writeWeatherStationError(c, err, "Ölçümler alınamadı")
+		return
+	}
+
+	utils.SuccessResponse(c, readings, "Ölçümler başarıyla getirildi")
+}
+
+// ReceiveWeatherStationReading istasyondan gelen ölçüm
+// @Summary İstasyon ölçümü gönder
+// @Description Hava istasyonunun ölçüm gönderdiği uç nokta; kimlik doğrulama istasyon eklenirken verilen X-Sensor-Token başlığıyla yapılır. rainfall önceki ölçümden bu yana düşen yağıştır (mm). Ölçümler istasyonla eşleştirilmiş arazilerin günlük hava gözlemlerine station kaynağıyla işlenir; istasyonu sağlayıcıdan önce tercih eden araziler için don ve yoğun yağış uyarıları bu ölçümlerden üretilir
+// @ID receiveWeatherStationReading
+// @Tags Weather Stations
+// @Accept json
+// @Produce json
+// @Param X-Sensor-Token header string true "İstasyon anahtarı"
+// @Param request body models.WeatherStationReadingRequest true "Ölçüm"
+// @Success 201 {object} models.APIResponse{data=models.WeatherStationReading}
+// @Failure 400 {object} models.APIResponse
+// @Failure 401 {object} models.APIResponse
+// @Router /sensors/weather-readings [post]
+func (h *WeatherStationHandler) ReceiveWeatherStationReading(c *gin.Context) {
+	stationID, farmID, err := h.stations.Authenticate(c.GetHeader("X-Sensor-Token"))
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusUnauthorized, "INVALID_SENSOR_TOKEN", "Geçersiz sensör anahtarı", nil)
+		return
+	}
+
+	var req models.WeatherStationReadingRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		utils.ErrorResponse(c, http.StatusBadRequest, "INVALID_REQUEST", "Geçersiz istek formatı", err.Error())
+		return
+	}
+	if req.Temperature == nil && req.Humidity == nil && req.Rainfall == nil {
+		utils.ErrorResponse(c, http.StatusBadRequest, "MISSING_FIELDS", "Sıcaklık, nem veya yağış değeri gerekli", nil)
+		return
+	}
+
+	reading, err := h.stations.RecordReading(farmID, stationID, req)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusInternalServerError, "DB_ERROR", "Ölçüm kaydedilemedi", err.Error())
+		return
+	}
+
+	utils.CreatedResponse(c, reading, "Ölçüm başarıyla kaydedildi")
+}
+
+// GetLandWeatherSources arazi hava verisi kaynakları
+// @Summary Arazi hava verisi kaynakları
+// @Description Arazinin eşleştirildiği hava istasyonunu, kaynakların tercih sırasını (station, provider; kullanıcının elle girdiği gözlemler her zaman önce gelir), şu anda veri sağlayan kaynağı ve çiftliğin istasyonlarını araziye uzaklığa göre döner. Hava geçmişi, arazi önerileri, yağış grafikleri, danışman ve hava uyarıları bu sırayı kullanır
+// @ID getLandWeatherSources
+// @Tags Lands
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Arazi ID"
+// @Success 200 {object} models.APIResponse{data=models.LandWeatherSources}
+// @Failure 401 {object} models.APIResponse
+// @Failure 404 {object} models.APIResponse
+// @Router /lands/{id}/weather-sources [get]
+func (h *WeatherStationHandler) GetLandWeatherSources(c *gin.Context) {
+	userID, err := utils.GetUserID(c)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusUnauthorized, "UNAUTHORIZED", "Kullanıcı kimliği doğrulanamadı", nil)
+		return
+	}
+
+	sources, err := h.stations.LandSources(userID, c.Param("id"))
+	if err != nil {
+		writeWeatherStationError(c, err, "Hava verisi kaynakları alınamadı")
+		return
+	}
+
+	utils.SuccessResponse(c, sources, "Hava verisi kaynakları başarıyla getirildi")
+}
+
+// UpdateLandWeatherSources arazi hava verisi kaynaklarını güncelleme
+// @Summary Arazi istasyon eşleştirmesi ve kaynak sırası
+// @Description Araziyi çiftliğin bir hava istasyonuyla eşleştirir (stationId boş dize ise eşleştirme kaldırılır) ve kaynakların tercih sırasını kaydeder (ör. ["station", "provider"]; listede olmayan kaynak kullanılmaz). İstasyon değişirse arazinin istasyon gözlemleri yeni istasyonun geçmiş ölçümlerinden yeniden oluşturulur
+// @ID updateLandWeatherSources
+// @Tags Lands
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Arazi ID"
+// @Param request body models.LandWeatherSourcesRequest true "Eşleştirme ve kaynak sırası"
+// @Success 200 {object} models.APIResponse{data=models.LandWeatherSources}
+// @Failure 400 {object} models.APIResponse
+// @Failure 401 {object} models.APIResponse
+// @Failure 404 {object} models.APIResponse
+// @Router /lands/{id}/weather-sources [put]
+func (h *WeatherStationHandler) UpdateLandWeatherSources(c *gin.Context) {
+	userID, err := utils.GetUserID(c)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusUnauthorized, "UNAUTHORIZED", "Kullanıcı kimliği doğrulanamadı", nil)
+		return
+	}
+
+	var req models.LandWeatherSourcesRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		utils.ErrorResponse(c, http.StatusBadRequest, "INVALID_REQUEST", "Geçersiz istek formatı", err.Error())
+		return
+	}
+
+	sources, err := h.stations.SetLandSources(userID, c.Param("id"), req)
+	if err != nil {
+		writeWeatherStationError(c, err, "Hava verisi kaynakları güncellenemedi")
+		return
+	}
+
+	utils.SuccessResponse(c, sources, "Hava verisi kaynakları başarıyla güncellendi")
+}
+
+// bindWeatherStation istasyon isteğini okur
+func bindWeatherStation(c *gin.Context, req *models.WeatherStationRequest) bool {
+	if err := c.ShouldBindJSON(req); err != nil {
+		utils.ErrorResponse(c, http.StatusBadRequest, "INVALID_REQUEST", "Geçersiz istek formatı", err.Error())
+		return false
+	}
+	if (req.Latitude == nil) != (req.Longitude == nil) {
+		utils.ErrorResponse(c, http.StatusBadRequest, "INVALID_LOCATION", "Enlem ve boylam birlikte verilmeli", nil)
+		return false
+	}
+	return true
+}
+
+// writeWeatherStationError servis hatasını HTTP yanıtına çevirir
+func writeWeatherStationError(c *gin.Context, err error, message string) {
+	switch {
+	case errors.Is(err, services.ErrWeatherStationNotFound):
+		utils.ErrorResponse(c, http.StatusNotFound, "STATION_NOT_FOUND", "İstasyon bulunamadı", nil)
+	case errors.Is(err, services.ErrWeatherLandNotFound):
+		utils.ErrorResponse(c, http.StatusNotFound, "LAND_NOT_FOUND", "Arazi bulunamadı", nil)
+	default:
+		utils.ErrorResponse(c, http.StatusInternalServerError, "DB_ERROR", message, err.Error())
+	}
+}
@@ -0,0 +1,168 @@
+package handlers
+
+import (
+	"database/sql"
+	"errors"
+	"net/http"
+
+	"agri-management-api/internal/models"
+	"agri-management-api/internal/services"
+	"agri-management-api/internal/utils"
+
+	"github.com/gin-gonic/gin"
+)
+
+// WeightHandler hayvanların tartım geçmişini ve büyüme eğrisini yönetir
+type WeightHandler struct {
+	db      *sql.DB
+	weights *services.WeightService
+}
+
+// NewWeightHandler yeni weight handler oluşturur
+func NewWeightHandler(db *sql.DB) *WeightHandler {
+	return &WeightHandler{
+		db:      db,
+		weights: services.NewWeightService(db),
+	}
+}
+
+// GetWeightRecords hayvanın tartım geçmişi
+// @Summary Hayvanın tartım geçmişi
+// @Description Hayvanın tartımlarını tarih sırasıyla, önceki tartımdan bu yana günlük canlı ağırlık artışıyla (kg/gün) listeler
+// @ID getWeightRecords
+// @Tags Livestock
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Hayvan ID"
+// @Success 200 {object} models.APIResponse{data=[]models.WeightRecord}
+// @Failure 401 {object} models.APIResponse
+// @Failure 404 {object} models.APIResponse
+// @Router /livestock/{id}/weights [get]
+func (h *WeightHandler) GetWeightRecords(c *gin.Context) {
+	userID, err := utils.GetUserID(c)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusUnauthorized, "UNAUTHORIZED", "Kullanıcı kimliği doğrulanamadı", nil)
+		return
+	}
+
+	records, err := h.weights.List(userID, c.Param("id"))
+	if err != nil {
+		writeWeightError(c, err, "Tartım geçmişi alınamadı")
+		return
+	}
+
+	utils.SuccessResponse(c, records, "Tartım geçmişi başarıyla getirildi")
+}
+
+// CreateWeightRecord tartım kaydı ekleme
+// @Summary Tartım kaydı ekle
+// @Description Hayvana tartım kaydı ekler; recordDate verilmezse bugün kullanılır. Kayıt en son tartımsa hayvanın ağırlığı güncellenir ve değişiklik geçmişine yazılır
+// @ID createWeightRecord
+// @Tags Livestock
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Hayvan ID"
+// @Param request body models.WeightRecordRequest true "Tartım bilgileri"
+// @Success 201 {object} models.APIResponse{data=models.WeightRecord}
+// @Failure 400 {object} models.APIResponse
+// @Failure 401 {object} models.APIResponse
+// @Failure 404 {object} models.APIResponse
+// @Router /livestock/{id}/weights [post]
+func (h *WeightHandler) CreateWeightRecord(c *gin.Context) {
+	userID, err := utils.GetUserID(c)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusUnauthorized, "UNAUTHORIZED", "Kullanıcı kimliği doğrulanamadı", nil)
+		return
+	}
+
+	var req models.WeightRecordRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		utils.ErrorResponse(c, http.StatusBadRequest, "INVALID_REQUEST", "Geçersiz istek formatı", err.Error())
+		return
+	}
+
+	record, err := h.weights.Create(userID, c.Param("id"), req)
+	if err != nil {
+		writeWeightError(c, err, "Tartım kaydı eklenemedi")
+		return
+	}
+
+	utils.CreatedResponse(c, record, "Tartım kaydı başarıyla eklendi")
+}
+
+// DeleteWeightRecord tartım kaydı silme
+// @Summary Tartım kaydını sil
+// @Description Tartım kaydını siler; hayvanın ağırlığı kalan en son tartıma göre güncellenir
+// @ID deleteWeightRecord
+// @Tags Livestock
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Hayvan ID"
+// @Param weightId path string true "Tartım kaydı ID"
+// @Success 200 {object} models.APIResponse
+// @Failure 401 {object} models.APIResponse
+// @Failure 404 {object} models.APIResponse
+// @Router /livestock/{id}/weights/{weightId} [delete]
+func (h *WeightHandler) DeleteWeightRecord(c *gin.Context) {
+	userID, err := utils.GetUserID(c)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusUnauthorized, "UNAUTHORIZED", "Kullanıcı kimliği doğrulanamadı", nil)
+		return
+	}
+
+	if err := h.weights.Delete(userID, c.Param("id"), c.Param("weightId")); err != nil {
+		writeWeightError(c, err, "Tartım kaydı silinemedi")
+		return
+	}
+
+	utils.SuccessResponse(c, nil, "Tartım kaydı başarıyla silindi")
+}
+
+// GetGrowthCurve hayvanın büyüme eğrisi
+// @Summary Hayvanın büyüme eğrisi
+// @Description Grafik için hayvanın tartımlarını tarih, yaş (gün) ve günlük artışla döner; başlangıç ve son ağırlık, toplam artış ve ortalama günlük canlı ağırlık artışı özetlenir
+// @ID getGrowthCurve
+// @Tags Livestock
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Hayvan ID"
+// @Param startDate query string false "Başlangıç tarihi (YYYY-MM-DD)"
+// @Param endDate query string false "Bitiş tarihi (YYYY-MM-DD)"
+// @Success 200 {object} models.APIResponse{data=models.GrowthCurve}
+// @Failure 400 {object} models.APIResponse
+// @Failure 401 {object} models.APIResponse
+// @Failure 404 {object} models.APIResponse
+// @Router /livestock/{id}/growth-curve [get]
+func (h *WeightHandler) GetGrowthCurve(c *gin.Context) {
+	userID, err := utils.GetUserID(c)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusUnauthorized, "UNAUTHORIZED", "Kullanıcı kimliği doğrulanamadı", nil)
+		return
+	}
+
+	startDate, endDate, ok := dateRangeQuery(c)
+	if !ok {
+		return
+	}
+
+	curve, err := h.weights.GrowthCurve(userID, c.Param("id"), startDate, endDate)
+	if err != nil {
+		writeWeightError(c, err, "Büyüme eğrisi alınamadı")
+		return
+	}
+
+	utils.SuccessResponse(c, curve, "Büyüme eğrisi başarıyla getirildi")
+}
+
+// writeWeightError servis hatasını HTTP yanıtına çevirir
+func writeWeightError(c *gin.Context, err error, message string) {
+	switch {
+	case errors.Is(err, services.ErrLivestockNotFound):
+		utils.ErrorResponse(c, http.StatusNotFound, "ANIMAL_NOT_FOUND", "Hayvan bulunamadı", nil)
+	case errors.Is(err, services.ErrWeightRecordNotFound):
+		utils.ErrorResponse(c, http.StatusNotFound, "WEIGHT_RECORD_NOT_FOUND", "Tartım kaydı bulunamadı", nil)
+	default:
+		utils.ErrorResponse(c, http.StatusInternalServerError, "DB_ERROR", message, err.Error())
+	}
+}
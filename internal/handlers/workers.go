@@ -0,0 +1,311 @@
+package handlers
+
+import (
+	"database/sql"
+	"errors"
+	"net/http"
+
+	"agri-management-api/internal/models"
+	"agri-management-api/internal/services"
+	"agri-management-api/internal/utils"
+
+	"github.com/gin-gonic/gin"
+)
+
+// WorkerHandler çalışanları, sözleşme dönemlerini, sertifikalarını ve aktivite atamalarını yönetir
+type WorkerHandler struct {
+	db      *sql.DB
+	workers *services.WorkerService
+}
+
+// NewWorkerHandler yeni worker handler oluşturur
+func NewWorkerHandler(db *sql.DB) *WorkerHandler {
+	return &WorkerHandler{
+		db:      db,
+		workers: services.NewWorkerService(db),
+	}
+}
+
+// GetWorkers çalışan listesi
+// @Summary Çalışanlar
+// @Description Çiftliğin çalışanlarını sözleşme dönemleri ve sertifikalarıyla (geçerlilik durumu: valid, bitişe 30 gün ve daha az kalmışsa expiring, bitmişse expired) listeler. activeOn verilirse yalnızca o gün sözleşmesi süren çalışanlar döner
+// @ID getWorkers
+// @Tags Workers
+// @Produce json
+// @Security BearerAuth
+// @Param contractType query string false "Sözleşme türü (seasonal, permanent)"
+// @Param activeOn query string false "Sözleşmenin sürdüğü gün (YYYY-MM-DD)"
+// @Success 200 {object} models.APIResponse{data=[]models.Worker}
+// @Failure 400 {object} models.APIResponse
+// @Failure 401 {object} models.APIResponse
+// @Router /workers [get]
+func (h *WorkerHandler) GetWorkers(c *gin.Context) {
+	userID, err := utils.GetUserID(c)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusUnauthorized, "UNAUTHORIZED", "Kullanıcı kimliği doğrulanamadı", nil)
+		return
+	}
+
+	activeOn, ok := optionalDateQuery(c, "activeOn")
+	if !ok {
+		return
+	}
+
+	workers, err := h.workers.List(userID, c.Query("contractType"), activeOn)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusInternalServerError, "DB_ERROR", "Çalışanlar alınamadı", err.Error())
+		return
+	}
+
+	utils.SuccessResponse(c, workers, "Çalışanlar başarıyla getirildi")
+}
+
+// GetWorker çalışan detayı
+// @Summary Çalışan detayı
+// @Description Çalışanı sözleşme dönemi ve sertifikalarıyla getirir
+// @ID getWorker
+// @Tags Workers
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Çalışan ID"
+// @Success 200 {object} models.APIResponse{data=models.Worker}
+// @Failure 401 {object} models.APIResponse
+// @Failure 404 {object} models.APIResponse
+// @Router /workers/{id} [get]
+func (h *WorkerHandler) GetWorker(c *gin.Context) {
+	userID, err := utils.GetUserID(c)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusUnauthorized, "UNAUTHORIZED", "Kullanıcı kimliği doğrulanamadı", nil)
+		return
+	}
+
+	worker, err := h.workers.Get(userID, c.Param("id"))
+	if err != nil {
+		writeWorkerError(c, err, "Çalışan alınamadı")
+		return
+	}
+
+	utils.SuccessResponse(c, worker, "Çalışan başarıyla getirildi")
+}
+
+// CreateWorker çalışan ekleme
+// @Summary Çalışan ekle
+// @Description Sezonluk (seasonal) veya süresiz (permanent) çalışan ekler. Sezonluk çalışanların sözleşmesi contractEnd tarihinde biter; sözleşme dönemi dışındaki aktivitelere atama uyarı üretir
+// @ID createWorker
+// @Tags Workers
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param request body models.WorkerRequest true "Çalışan bilgileri"
+// @Success 201 {object} models.APIResponse{data=models.Worker}
+// @Failure 400 {object} models.APIResponse
+// @Failure 401 {object} models.APIResponse
+// @Router /workers [post]
+func (h *WorkerHandler) CreateWorker(c *gin.Context) {
+	userID, err := utils.GetUserID(c)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusUnauthorized, "UNAUTHORIZED", "Kullanıcı kimliği doğrulanamadı", nil)
+		return
+	}
+
+	var req models.WorkerRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		utils.ErrorResponse(c, http.StatusBadRequest, "INVALID_REQUEST", "Geçersiz istek formatı", err.Error())
+		return
+	}
+
+	worker, err := h.workers.Create(userID, req)
+	if err != nil {
+		writeWorkerError(c, err, "Çalışan eklenemedi")
+		return
+	}
+
+	utils.CreatedResponse(c, worker, "Çalışan başarıyla eklendi")
+}
+
+// UpdateWorker çalışan güncelleme
+// @Summary Çalışanı güncelle
+// @Description Çalışanın bilgilerini ve sözleşme dönemini günceller
+// @ID updateWorker
+// @Tags Workers
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Çalışan ID"
+// @Param request body models.WorkerRequest true "Çalışan bilgileri"
+// @Success 200 {object} models.APIResponse{data=models.Worker}
+// @Failure 400 {object} models.APIResponse
+// @Failure 401 {object} models.APIResponse
+// @Failure 404 {object} models.APIResponse
+// @Router /workers/{id} [put]
+func (h *WorkerHandler) UpdateWorker(c *gin.Context) {
+	userID, err := utils.GetUserID(c)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusUnauthorized, "UNAUTHORIZED", "Kullanıcı kimliği doğrulanamadı", nil)
+		return
+	}
+
+	var req models.WorkerRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		utils.ErrorResponse(c, http.StatusBadRequest, "INVALID_REQUEST", "Geçersiz istek formatı", err.Error())
+		return
+	}
+
+	worker, err := h.workers.Update(userID, c.Param("id"), req)
+	if err != nil {
+		writeWorkerError(c, err, "Çalışan güncellenemedi")
+		return
+	}
+
+	utils.SuccessResponse(c, worker, "Çalışan başarıyla güncellendi")
+}
+
+// DeleteWorker çalışan silme
+// @Summary Çalışanı sil
+// @Description Çalışanı sertifikalarıyla siler; atandığı aktivitelerin ataması kaldırılır
+// @ID deleteWorker
+// @Tags Workers
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Çalışan ID"
+// @Success 200 {object} models.APIResponse
+// @Failure 401 {object} models.APIResponse
+// @Failure 404 {object} models.APIResponse
+// @Router /workers/{id} [delete]
+func (h *WorkerHandler) DeleteWorker(c *gin.Context) {
+	userID, err := utils.GetUserID(c)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusUnauthorized, "UNAUTHORIZED", "Kullanıcı kimliği doğrulanamadı", nil)
+		return
+	}
+
+	if err := h.workers.Delete(userID, c.Param("id")); err != nil {
+		writeWorkerError(c, err, "Çalışan silinemedi")
+		return
+	}
+
+	utils.SuccessResponse(c, nil, "Çalışan başarıyla silindi")
+}
+
+// CreateWorkerCertification çalışana sertifika ekleme
+// @Summary Sertifika ekle
+// @Description Çalışana zirai ilaç uygulama belgesi (pesticide_applicator), sürücü belgesi (driving_license) veya diğer belge ekler; expiresAt boşsa belge süresizdir. Yenilenen belge yeni kayıt olarak eklenir, geçerlilik denetiminde aynı türdeki en geç biten belge kullanılır
+// @ID createWorkerCertification
+// @Tags Workers
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Çalışan ID"
+// @Param request body models.WorkerCertificationRequest true "Sertifika bilgileri"
+// @Success 201 {object} models.APIResponse{data=models.Worker}
+// @Failure 400 {object} models.APIResponse
+// @Failure 401 {object} models.APIResponse
+// @Failure 404 {object} models.APIResponse
+// @Router /workers/{id}/certifications [post]
+func (h *WorkerHandler) CreateWorkerCertification(c *gin.Context) {
+	userID, err := utils.GetUserID(c)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusUnauthorized, "UNAUTHORIZED", "Kullanıcı kimliği doğrulanamadı", nil)
+		return
+	}
+
+	var req models.WorkerCertificationRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		utils.ErrorResponse(c, http.StatusBadRequest, "INVALID_REQUEST", "Geçersiz istek formatı", err.Error())
+		return
+	}
+
+	worker, err := h.workers.AddCertification(userID, c.Param("id"), req)
+	if err != nil {
+		writeWorkerError(c, err, "Sertifika eklenemedi")
+		return
+	}
+
+	utils.CreatedResponse(c, worker, "Sertifika başarıyla eklendi")
+}
+
+// DeleteWorkerCertification çalışanın sertifikasını silme
+// @Summary Sertifika sil
+// @Description Çalışanın sertifikasını siler
+// @ID deleteWorkerCertification
+// @Tags Workers
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Çalışan ID"
+// @Param certificationId path string true "Sertifika ID"
+// @Success 200 {object} models.APIResponse
+// @Failure 401 {object} models.APIResponse
+// @Failure 404 {object} models.APIResponse
+// @Router /workers/{id}/certifications/{certificationId} [delete]
+func (h *WorkerHandler) DeleteWorkerCertification(c *gin.Context) {
+	userID, err := utils.GetUserID(c)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusUnauthorized, "UNAUTHORIZED", "Kullanıcı kimliği doğrulanamadı", nil)
+		return
+	}
+
+	if err := h.workers.DeleteCertification(userID, c.Param("id"), c.Param("certificationId")); err != nil {
+		writeWorkerError(c, err, "Sertifika silinemedi")
+		return
+	}
+
+	utils.SuccessResponse(c, nil, "Sertifika başarıyla silindi")
+}
+
+// AssignActivityWorker arazi aktivitesine çalışan atama
+// @Summary Aktiviteye çalışan ata
+// @Description Arazi aktivitesini çalışana atar; workerId null ise atama kaldırılır. Aktivite tarihinde (planlanan, yoksa gerçekleşen, o da yoksa bugün) çalışanın sözleşmesi sürmüyorsa veya aktivite türünün gerektirdiği belge (ilaçlama türleri için pesticide_applicator, nakliye ve toprak işleme türleri için driving_license) yoksa ya da süresi dolmuşsa atama yine yapılır; sorunlar issues alanında döner ve çiftliğe bildirim gönderilir
+// @ID assignActivityWorker
+// @Tags Lands
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Arazi ID"
+// @Param activityId path string true "Aktivite ID"
+// @Param request body models.ActivityAssignmentRequest true "Atanan çalışan"
+// @Success 200 {object} models.APIResponse{data=models.ActivityAssignment}
+// @Failure 400 {object} models.APIResponse
+// @Failure 401 {object} models.APIResponse
+// @Failure 404 {object} models.APIResponse
+// @Router /lands/{id}/activities/{activityId}/assignee [put]
+func (h *WorkerHandler) AssignActivityWorker(c *gin.Context) {
+	userID, err := utils.GetUserID(c)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusUnauthorized, "UNAUTHORIZED", "Kullanıcı kimliği doğrulanamadı", nil)
+		return
+	}
+
+	var req models.ActivityAssignmentRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		utils.ErrorResponse(c, http.StatusBadRequest, "INVALID_REQUEST", "Geçersiz istek formatı", err.Error())
+		return
+	}
+
+	assignment, err := h.workers.Assign(userID, c.Param("id"), c.Param("activityId"), req.WorkerID)
+	if err != nil {
+		writeWorkerError(c, err, "Çalışan atanamadı")
+		return
+	}
+
+	message := "Çalışan başarıyla atandı"
+	if len(assignment.Issues) > 0 {
+		message = "Çalışan atandı; yeterlilik sorunları var"
+	}
+	utils.SuccessResponse(c, assignment, message)
+}
+
+// writeWorkerError servis hatasını HTTP yanıtına çevirir
+func writeWorkerError(c *gin.Context, err error, message string) {
+	switch {
+	case errors.Is(err, services.ErrWorkerNotFound):
+		utils.ErrorResponse(c, http.StatusNotFound, "WORKER_NOT_FOUND", "Çalışan bulunamadı", nil)
+	case errors.Is(err, services.ErrCertificationNotFound):
+		utils.ErrorResponse(c, http.StatusNotFound, "CERTIFICATION_NOT_FOUND", "Sertifika bulunamadı", nil)
+	case errors.Is(err, services.ErrActivityNotFound):
+		utils.ErrorResponse(c, http.StatusNotFound, "ACTIVITY_NOT_FOUND", "Aktivite bulunamadı", nil)
+	case errors.Is(err, services.ErrInvalidContractPeriod):
+		utils.ErrorResponse(c, http.StatusBadRequest, "INVALID_CONTRACT_PERIOD", "Sözleşme bitişi başlangıçtan önce olamaz", nil)
+	default:
+		utils.ErrorResponse(c, http.StatusInternalServerError, "DB_ERROR", message, err.Error())
+	}
+}
@@ -1,11 +1,20 @@
 package middleware
 
 import (
+	"database/sql"
 	"fmt"
+	"log"
 	"net/http"
+	"os"
+	"strconv"
 	"strings"
+	"sync"
 	"time"
 
+	"agri-management-api/internal/database"
+	"agri-management-api/internal/models"
+	"agri-management-api/internal/services"
+	"agri-management-api/internal/utils"
 	"agri-management-api/pkg/auth"
 
 	"github.com/gin-gonic/gin"
@@ -17,8 +26,10 @@ func CORS() gin.HandlerFunc {
 	return func(c *gin.Context) {
 		c.Header("Access-Control-Allow-Origin", "*")
 		c.Header("Access-Control-Allow-Credentials", "true")
-		c.Header("Access-Control-Allow-Headers", "Content-Type, Content-Length, Accept-Encoding, X-CSRF-Token, Authorization, accept, origin, Cache-Control, X-Requested-With")
+		c.Header("Access-Control-Allow-Headers", "Content-Type, Content-Length, Accept-Encoding, X-CSRF-Token, Authorization, accept, origin, Cache-Control, X-Requested-With, X-Farm-ID")
 		c.Header("Access-Control-Allow-Methods", "POST, OPTIONS, GET, PUT, DELETE, PATCH")
+		// İmzalı dışa aktarımların ayrık imzası yanıt başlıklarıyla döner
+		c.Header("Access-Control-Expose-Headers", "Content-Disposition, X-Document-Signature, X-Document-Signature-Id, X-Document-Signature-Key-Id, X-Document-SHA256")
 
 		if c.Request.Method == "OPTIONS" {
 			c.AbortWithStatus(204)
@@ -68,13 +79,7 @@ func Auth() gin.HandlerFunc {
 	return func(c *gin.Context) {
 		authHeader := c.GetHeader("Authorization")
 		if authHeader == "" {
-			c.JSON(http.StatusUnauthorized, gin.H{
-				"success": false,
-				"error": gin.H{
-					"code":    "MISSING_TOKEN",
-					"message": "Authorization token gerekli",
-				},
-			})
+			utils.ErrorResponse(c, http.StatusUnauthorized, "MISSING_TOKEN", "Authorization token gerekli", nil)
 			c.Abort()
 			return
 		}
@@ -82,13 +87,7 @@ func Auth() gin.HandlerFunc {
 		// Bearer token formatını kontrol et
 		tokenParts := strings.Split(authHeader, " ")
 		if len(tokenParts) != 2 || tokenParts[0] != "Bearer" {
-			c.JSON(http.StatusUnauthorized, gin.H{
-				"success": false,
-				"error": gin.H{
-					"code":    "INVALID_TOKEN_FORMAT",
-					"message": "Geçersiz token formatı",
-				},
-			})
+			utils.ErrorResponse(c, http.StatusUnauthorized, "INVALID_TOKEN_FORMAT", "Geçersiz token formatı", nil)
 			c.Abort()
 			return
 		}
@@ -98,13 +97,7 @@ func Auth() gin.HandlerFunc {
 
 		claims, err := jwtManager.ValidateToken(tokenString)
 		if err != nil {
-			c.JSON(http.StatusUnauthorized, gin.H{
-				"success": false,
-				"error": gin.H{
-					"code":    "INVALID_TOKEN",
-					"message": "Geçersiz veya süresi dolmuş token",
-				},
-			})
+			utils.ErrorResponse(c, http.StatusUnauthorized, "INVALID_TOKEN", "Geçersiz veya süresi dolmuş token", nil)
 			c.Abort()
 			return
 		}
@@ -118,6 +111,176 @@ func Auth() gin.HandlerFunc {
 	}
 }
 
+// FarmHeader isteğin hangi çiftlik için yapıldığını belirten başlık
+const FarmHeader = "X-Farm-ID"
+
+// FarmScope X-Farm-ID başlığındaki çiftliğin hesaba ait olduğunu doğrular ve isteği o çiftliğin
+// kayıtlarıyla sınırlar; başlık yoksa varsayılan çiftlik kullanılır. Çiftliğin muhasebeci erişimi verdiği
+// hesaplar yalnızca finans uç noktalarını okuyabilir ve raporları indirebilir; bu istekler erişim günlüğüne
+// yazılır. Auth middleware'inden sonra kullanılmalıdır
+func FarmScope(db *sql.DB) gin.HandlerFunc {
+	farms := services.NewFarmService(db)
+	accountants := services.NewAccountantService(db)
+
+	return func(c *gin.Context) {
+		accountID := c.GetString("user_id")
+		c.Set("account_id", accountID)
+
+		farmID := c.GetHeader(FarmHeader)
+		if farmID == "" || farmID == accountID {
+			c.Set("farm_id", accountID)
+			c.Next()
+			return
+		}
+
+		owned, err := farms.Owns(accountID, farmID)
+		if err == nil && !owned {
+			accessID, accessErr := accountants.Authorize(accountID, farmID)
+			if accessErr == nil {
+				accountantScope(c, accountants, farmID, accessID)
+				return
+			}
+		}
+		if err != nil || !owned {
+			utils.ErrorResponse(c, http.StatusNotFound, "FARM_NOT_FOUND", "Çiftlik bulunamadı", nil)
+			c.Abort()
+			return
+		}
+
+		// Handler'lar kayıtları user_id ile sorguladığından seçili çiftliğin kimliği veri sahibi olarak atanır
+		c.Set("user_id", farmID)
+		c.Set("farm_id", farmID)
+		c.Next()
+	}
+}
+
+// accountantScope muhasebecinin isteğini salt okunur finans kapsamıyla sınırlar ve erişim günlüğüne yazar
+func accountantScope(c *gin.Context, accountants *services.AccountantService, farmID, accessID string) {
+	accountantID := c.GetString("account_id")
+	if !services.AccountantRouteAllowed(c.Request.Method, c.FullPath()) {
+		utils.ErrorResponse(c, http.StatusForbidden, "ACCOUNTANT_SCOPE", "Muhasebeci erişimi yalnızca finans kayıtlarını okumaya ve rapor indirmeye izin verir", nil)
+		c.Abort()
+	} else {
+		c.Set("user_id", farmID)
+		c.Set("farm_id", farmID)
+		c.Set("accountant_access_id", accessID)
+		c.Next()
+	}
+
+	if err := accountants.LogAccess(farmID, accessID, accountantID, c.Request.Method, c.Request.URL.RequestURI(),
+		c.Writer.Status(), c.ClientIP()); err != nil {
+		log.Printf("Muhasebeci erişimi günlüğe yazılamadı (%s): %v", accessID, err)
+	}
+}
+
+// IntegrationKeyHeader otomasyon araçlarının entegrasyon anahtarını gönderdiği başlık
+const IntegrationKeyHeader = "X-API-Key"
+
+// IntegrationKey X-API-Key başlığındaki entegrasyon anahtarını doğrular ve isteği anahtarın bağlı olduğu
+// çiftlikle sınırlar. Anahtar sorgu parametresiyle kabul edilmez; adresler erişim ve vekil sunucu günlüklerine
+// olduğu gibi yazılır
+func IntegrationKey(db *sql.DB) gin.HandlerFunc {
+	integrations := services.NewIntegrationService(db)
+
+	return func(c *gin.Context) {
+		key := c.GetHeader(IntegrationKeyHeader)
+		if key == "" {
+			utils.ErrorResponse(c, http.StatusUnauthorized, "MISSING_API_KEY", "Entegrasyon anahtarı gerekli", nil)
+			c.Abort()
+			return
+		}
+
+		farmID, accountID, keyName, err := integrations.Authenticate(key)
+		if err != nil {
+			utils.ErrorResponse(c, http.StatusUnauthorized, "INVALID_API_KEY", "Geçersiz veya iptal edilmiş entegrasyon anahtarı", nil)
+			c.Abort()
+			return
+		}
+
+		c.Set("user_id", farmID)
+		c.Set("account_id", accountID)
+		c.Set("farm_id", farmID)
+		c.Set("integration_key_name", keyName)
+		c.Next()
+	}
+}
+
+// RequireRole kullanıcının belirtilen rollerden birine sahip olmasını zorunlu kılar
+// Auth middleware'inden sonra kullanılmalıdır
+func RequireRole(roles ...string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		userRole, _ := c.Get("user_role")
+		role, _ := userRole.(string)
+
+		for _, allowed := range roles {
+			if role == allowed {
+				c.Next()
+				return
+			}
+		}
+
+		utils.ErrorResponse(c, http.StatusForbidden, "FORBIDDEN", "Bu işlem için yetkiniz yok", nil)
+		c.Abort()
+	}
+}
+
+// DenyDemo sandbox (demo) hesaplarının paylaşılan girişi bozabilecek veya çiftlik dışına gerçek bildirim
+// gönderebilecek işlemlerini 403 ile reddeder. Auth middleware'inden sonra kullanılmalıdır
+func DenyDemo() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if c.GetString("user_role") == models.RoleDemo {
+			utils.ErrorResponse(c, http.StatusForbidden, "DEMO_ACCOUNT", "Bu işlem sandbox hesaplarında kullanılamaz", nil)
+			c.Abort()
+			return
+		}
+		c.Next()
+	}
+}
+
+// QueryMetrics isteğin veritabanı sorgularını endpoint adına ölçer; yavaş sorgular bu endpoint ile kaydedilir.
+// DEBUG_DB_TIMING açıksa isteğin sorgu sayısı ve süresi yanıtın meta bilgisine eklenir
+func QueryMetrics() gin.HandlerFunc {
+	debug, _ := strconv.ParseBool(os.Getenv("DEBUG_DB_TIMING"))
+
+	return func(c *gin.Context) {
+		timing, done := database.TrackRequest(c.Request.Method + " " + c.FullPath())
+		defer done()
+
+		if debug {
+			c.Set(utils.DBTimingKey, timing.Timing)
+		}
+		c.Next()
+	}
+}
+
+// CacheHints GET isteklerine rota desenine göre önbellek ipucu atar; başarılı yanıtlarda ipucu meta bilgisine
+// ve Cache-Control/Last-Modified başlıklarına yazılır. Süreler CACHE_HINTS ile değiştirilebilir
+func CacheHints() gin.HandlerFunc {
+	rules := services.CacheHintRules()
+
+	return func(c *gin.Context) {
+		if c.Request.Method == http.MethodGet && c.FullPath() != "" {
+			c.Set(utils.CacheHintKey, services.CacheHintFor(c.FullPath(), rules))
+		}
+		c.Next()
+	}
+}
+
+// RequestMetrics tamamlanan isteklerin süresini ve durum kodunu rota bazında kaydeder; yönetici performans
+// özeti bu ölçümlerden hesaplanır. Eşleşmeyen (404) yollar kaydedilmez
+func RequestMetrics() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		start := time.Now()
+		c.Next()
+
+		route := c.FullPath()
+		if route == "" {
+			return
+		}
+		services.RecordRequestMetric(c.Request.Method, route, c.Writer.Status(), time.Since(start), start)
+	}
+}
+
 // RequestID her istek için benzersiz ID oluşturur
 func RequestID() gin.HandlerFunc {
 	return func(c *gin.Context) {
@@ -132,12 +295,142 @@ func RequestID() gin.HandlerFunc {
 	}
 }
 
-// RateLimit basit rate limiting middleware
+// RequestTrail kimliği doğrulanmış hesapların tamamlanan isteklerini kaydeder; destek talepleri son istekleri
+// bağlam olarak ekler. RequestID middleware'inden sonra kullanılmalıdır
+func RequestTrail() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		c.Next()
+
+		accountID := c.GetString("account_id")
+		if accountID == "" {
+			accountID = c.GetString("user_id")
+		}
+		if accountID == "" {
+			return
+		}
+
+		path := c.FullPath()
+		if path == "" {
+			path = c.Request.URL.Path
+		}
+		services.RecordRequest(accountID, models.RecentRequest{
+			RequestID: c.GetString("request_id"),
+			Method:    c.Request.Method,
+			Path:      path,
+			Status:    c.Writer.Status(),
+			At:        time.Now().UTC(),
+		})
+	}
+}
+
+// maintenanceExemptPaths bakım sırasında da yazılabilen yollar; yöneticiler giriş yapıp bakımı kapatabilmelidir
+var maintenanceExemptPaths = []string{"/api/v1/admin/", "/api/v1/auth/login", "/api/v1/auth/refresh"}
+
+// Maintenance bakım penceresi sürerken yazma isteklerini tahmini bitiş zamanıyla birlikte 503 ile reddeder;
+// okuma istekleri (GET, HEAD, OPTIONS) ve yönetici uç noktaları çalışmaya devam eder
+func Maintenance(db *sql.DB) gin.HandlerFunc {
+	maintenance := services.NewMaintenanceService(db)
+
+	return func(c *gin.Context) {
+		switch c.Request.Method {
+		case http.MethodGet, http.MethodHead, http.MethodOptions:
+			c.Next()
+			return
+		}
+		for _, prefix := range maintenanceExemptPaths {
+			if strings.HasPrefix(c.Request.URL.Path, prefix) {
+				c.Next()
+				return
+			}
+		}
+
+		window, err := maintenance.Active()
+		if err != nil || window == nil {
+			c.Next()
+			return
+		}
+
+		details := models.MaintenanceErrorDetails{Message: window.Message, EndsAt: window.EndsAt}
+		if details.Message == "" {
+			details.Message = services.DefaultMaintenanceMessage
+		}
+		if window.EndsAt != nil {
+			details.RetryAfterSeconds = int(time.Until(*window.EndsAt).Seconds()) + 1
+			c.Header("Retry-After", strconv.Itoa(details.RetryAfterSeconds))
+		}
+
+		utils.ErrorResponse(c, http.StatusServiceUnavailable, "MAINTENANCE_MODE", details.Message, details)
+		c.Abort()
+	}
+}
+
+// TrustedProxies TRUSTED_PROXIES ortam değişkenindeki virgülle ayrılmış vekil IP/CIDR listesini döner; boşsa nil
+// döner ve X-Forwarded-For başlığına güvenilmez, istemci IP'si bağlantının uzak adresidir
+func TrustedProxies() []string {
+	var proxies []string
+	for _, proxy := range strings.Split(os.Getenv("TRUSTED_PROXIES"), ",") {
+		if proxy = strings.TrimSpace(proxy); proxy != "" {
+			proxies = append(proxies, proxy)
+		}
+	}
+	return proxies
+}
+
+// rateLimitCounter istemcinin geçerli penceredeki istek sayısı
+type rateLimitCounter struct {
+	count   int
+	resetAt time.Time
+}
+
+// RateLimit istemci IP'si başına sabit pencereli rate limiting middleware; pencere içinde limit aşılırsa
+// istek Retry-After başlığıyla 429 döner. İstemci IP'si yalnızca güvenilen vekillerden (TrustedProxies)
+// gelen X-Forwarded-For başlığından okunur. Sayaçlar süreç belleğinde tutulur; birden fazla örnek
+// çalıştırılıyorsa Redis gibi paylaşılan bir sayaç kullanılmalı
 func RateLimit(limit int, window time.Duration) gin.HandlerFunc {
-	// Basit in-memory rate limiter
-	// Production'da Redis gibi bir çözüm kullanılmalı
+	var mu sync.Mutex
+	counters := map[string]*rateLimitCounter{}
+	var sweepAt time.Time
+
 	return func(c *gin.Context) {
-		// Bu basit bir implementasyon, gerçek projede daha gelişmiş olmalı
+		now := time.Now()
+		client := c.ClientIP()
+
+		mu.Lock()
+		// Süresi dolan sayaçlar pencerede bir kez temizlenir
+		if now.After(sweepAt) {
+			for key, counter := range counters {
+				if now.After(counter.resetAt) {
+					delete(counters, key)
+				}
+			}
+			sweepAt = now.Add(window)
+		}
+		counter, ok := counters[client]
+		if !ok || now.After(counter.resetAt) {
+			counter = &rateLimitCounter{resetAt: now.Add(window)}
+			counters[client] = counter
+		}
+		counter.count++
+		count, resetAt := counter.count, counter.resetAt
+		mu.Unlock()
+
+		remaining := limit - count
+		if remaining < 0 {
+			remaining = 0
+		}
+		c.Header("X-RateLimit-Limit", strconv.Itoa(limit))
+		c.Header("X-RateLimit-Remaining", strconv.Itoa(remaining))
+		c.Header("X-RateLimit-Reset", strconv.FormatInt(resetAt.Unix(), 10))
+
+		if count > limit {
+			retryAfter := int(time.Until(resetAt).Seconds()) + 1
+			c.Header("Retry-After", strconv.Itoa(retryAfter))
+			utils.ErrorResponse(c, http.StatusTooManyRequests, "RATE_LIMITED", "Çok fazla istek gönderildi, lütfen daha sonra tekrar deneyin", map[string]int{
+				"retryAfterSeconds": retryAfter,
+			})
+			c.Abort()
+			return
+		}
 		c.Next()
 	}
 }
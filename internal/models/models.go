@@ -21,20 +21,26 @@ type User struct {
 
 // Land arazi modeli
 type Land struct {
-	ID             string     `json:"id" db:"id"`
-	UserID         string     `json:"userId" db:"user_id"`
-	Name           string     `json:"name" db:"name"`
-	Area           float64    `json:"area" db:"area"`
-	Unit           string     `json:"unit" db:"unit"`
-	Crop           string     `json:"crop" db:"crop"`
-	Status         string     `json:"status" db:"status"`
-	LastActivity   *time.Time `json:"lastActivity" db:"last_activity"`
-	Productivity   float64    `json:"productivity" db:"productivity"`
-	Location       Location   `json:"location" db:"-"`
-	SoilType       string     `json:"soilType" db:"soil_type"`
-	IrrigationType string     `json:"irrigationType" db:"irrigation_type"`
-	CreatedAt      time.Time  `json:"createdAt" db:"created_at"`
-	UpdatedAt      time.Time  `json:"updatedAt" db:"updated_at"`
+	ID             string      `json:"id" db:"id"`
+	UserID         string      `json:"userId" db:"user_id"`
+	Name           string      `json:"name" db:"name"`
+	Area           float64     `json:"area" db:"area"`
+	Unit           string      `json:"unit" db:"unit"`
+	Crop           string      `json:"crop" db:"crop"`
+	Status         string      `json:"status" db:"status"`
+	LastActivity   *time.Time  `json:"lastActivity" db:"last_activity"`
+	Productivity   float64     `json:"productivity" db:"productivity"`
+	Location       Location    `json:"location" db:"-"`
+	SoilType       string      `json:"soilType" db:"soil_type"`
+	IrrigationType string      `json:"irrigationType" db:"irrigation_type"`
+	Type           string      `json:"type" db:"land_type" binding:"omitempty,oneof=field greenhouse"`
+	Parcel         *LandParcel `json:"parcel" db:"-"`
+	Boundary       *GeoPolygon `json:"boundary" db:"boundary"`
+	EnterpriseID   *string     `json:"enterpriseId" db:"enterprise_id"`
+	// AreaCheck sınırı kayıtlı arazide girilen alanın sınırdan hesaplanan alanla karşılaştırması (salt okunur)
+	AreaCheck *LandAreaCheck `json:"areaCheck,omitempty" db:"-"`
+	CreatedAt time.Time      `json:"createdAt" db:"created_at"`
+	UpdatedAt time.Time      `json:"updatedAt" db:"updated_at"`
 }
 
 // Location konum modeli
@@ -44,6 +50,61 @@ type Location struct {
 	Address   string  `json:"address"`
 }
 
+// Ekim planı durumları
+const (
+	CropPlanStatusPlanned   = "planned"
+	CropPlanStatusPlanted   = "planted"
+	CropPlanStatusHarvested = "harvested"
+	CropPlanStatusCancelled = "cancelled"
+)
+
+// Ekim nöbeti uyarı kodları
+const (
+	RotationWarningSameCrop       = "same_crop"
+	RotationWarningSameFamily     = "same_family"
+	RotationWarningReturnInterval = "return_interval"
+)
+
+// CropPlan arazide bir sezon için planlanan ürün; tarihler YYYY-MM-DD biçimindedir. harvestDate girilmezse
+// ekimden hasat dönemine kadar geçen süreyle hesaplanır. Uyarılar arazinin önceki planlarıyla ekim nöbeti
+// kurallarına göre her okumada yeniden hesaplanır
+type CropPlan struct {
+	ID           string            `json:"id" db:"id"`
+	LandID       string            `json:"landId" db:"land_id"`
+	LandName     string            `json:"landName" db:"-"`
+	Season       string            `json:"season" db:"season"`
+	Crop         string            `json:"crop" db:"crop"`
+	Variety      string            `json:"variety" db:"variety"`
+	CropFamily   string            `json:"cropFamily" db:"-"`
+	PlantingDate string            `json:"plantingDate" db:"planting_date"`
+	HarvestDate  string            `json:"harvestDate" db:"harvest_date"`
+	Status       string            `json:"status" db:"status" enums:"planned,planted,harvested,cancelled"`
+	Notes        string            `json:"notes" db:"notes"`
+	Warnings     []RotationWarning `json:"warnings" db:"-"`
+	CreatedAt    time.Time         `json:"createdAt" db:"created_at"`
+	UpdatedAt    time.Time         `json:"updatedAt" db:"updated_at"`
+}
+
+// RotationWarning ekim nöbeti kuralına uymayan plan için uyarı; plan yine de kaydedilir
+type RotationWarning struct {
+	Code           string  `json:"code" enums:"same_crop,same_family,return_interval"`
+	Message        string  `json:"message"`
+	PreviousPlanID *string `json:"previousPlanId"`
+	PreviousCrop   string  `json:"previousCrop"`
+	PreviousSeason string  `json:"previousSeason"`
+}
+
+// CropPlanRequest ekim planı ekleme ve güncelleme isteği; tarihler YYYY-MM-DD biçimindedir
+type CropPlanRequest struct {
+	Season       string `json:"season" binding:"required"`
+	Crop         string `json:"crop" binding:"required"`
+	Variety      string `json:"variety"`
+	PlantingDate string `json:"plantingDate" binding:"required,datetime=2006-01-02"`
+	HarvestDate  string `json:"harvestDate" binding:"omitempty,datetime=2006-01-02"`
+	Status       string `json:"status" binding:"omitempty,oneof=planned planted harvested cancelled"`
+	Notes        string `json:"notes"`
+}
+
 // Livestock hayvan modeli
 type Livestock struct {
 	ID           string     `json:"id" db:"id"`
@@ -59,6 +120,7 @@ type Livestock struct {
 	Mother       string     `json:"mother" db:"mother"`
 	Father       string     `json:"father" db:"father"`
 	Notes        string     `json:"notes" db:"notes"`
+	EnterpriseID *string    `json:"enterpriseId" db:"enterprise_id"`
 	CreatedAt    time.Time  `json:"createdAt" db:"created_at"`
 	UpdatedAt    time.Time  `json:"updatedAt" db:"updated_at"`
 }
@@ -77,27 +139,37 @@ type Production struct {
 	StorageLocation string     `json:"storageLocation" db:"storage_location"`
 	Status          string     `json:"status" db:"status"`
 	Price           *float64   `json:"price" db:"price"`
+	UnitCost        *float64   `json:"unitCost" db:"unit_cost"`
 	Notes           string     `json:"notes" db:"notes"`
+	SoldAmount      float64    `json:"soldAmount" db:"sold_amount"`
+	LostAmount      float64    `json:"lostAmount" db:"lost_amount"`
+	Stock           float64    `json:"stock" db:"-"`
+	EnterpriseID    *string    `json:"enterpriseId" db:"enterprise_id"`
 	CreatedAt       time.Time  `json:"createdAt" db:"created_at"`
 	UpdatedAt       time.Time  `json:"updatedAt" db:"updated_at"`
 }
 
 // Transaction finansal işlem modeli
 type Transaction struct {
-	ID            string    `json:"id" db:"id"`
-	UserID        string    `json:"userId" db:"user_id"`
-	Type          string    `json:"type" db:"type"`
-	Category      string    `json:"category" db:"category"`
-	Description   string    `json:"description" db:"description"`
-	Amount        float64   `json:"amount" db:"amount"`
-	Currency      string    `json:"currency" db:"currency"`
-	Date          time.Time `json:"date" db:"date"`
-	Status        string    `json:"status" db:"status"`
-	PaymentMethod string    `json:"paymentMethod" db:"payment_method"`
-	Receipt       string    `json:"receipt" db:"receipt"`
-	Notes         string    `json:"notes" db:"notes"`
-	CreatedAt     time.Time `json:"createdAt" db:"created_at"`
-	UpdatedAt     time.Time `json:"updatedAt" db:"updated_at"`
+	ID            string     `json:"id" db:"id"`
+	UserID        string     `json:"userId" db:"user_id"`
+	Type          string     `json:"type" db:"type"`
+	Category      string     `json:"category" db:"category"`
+	Description   string     `json:"description" db:"description"`
+	Amount        float64    `json:"amount" db:"amount"`
+	Currency      string     `json:"currency" db:"currency"`
+	Date          time.Time  `json:"date" db:"date"`
+	Status        string     `json:"status" db:"status"`
+	PaymentMethod string     `json:"paymentMethod" db:"payment_method"`
+	Receipt       string     `json:"receipt" db:"receipt"`
+	Notes         string     `json:"notes" db:"notes"`
+	DueDate       *time.Time `json:"dueDate,omitempty" db:"due_date"`
+	PaidAt        *time.Time `json:"paidAt,omitempty" db:"paid_at"`
+	DaysOverdue   *int       `json:"daysOverdue,omitempty" db:"-"`
+	Tags          []string   `json:"tags" db:"-"`
+	EnterpriseID  *string    `json:"enterpriseId" db:"enterprise_id"`
+	CreatedAt     time.Time  `json:"createdAt" db:"created_at"`
+	UpdatedAt     time.Time  `json:"updatedAt" db:"updated_at"`
 }
 
 // EventBasic temel etkinlik modeli
@@ -197,6 +269,11 @@ type AuthResponse struct {
 	RefreshToken string `json:"refreshToken"`
 }
 
+// TokenResponse yenilenen access token
+type TokenResponse struct {
+	Token string `json:"token"`
+}
+
 // DashboardSummary dashboard özet verileri
 type DashboardSummary struct {
 	TotalAnimals   AnimalSummary  `json:"totalAnimals"`
@@ -233,6 +310,41 @@ type ProductSummary struct {
 	Categories int `json:"categories"`
 }
 
+// Dashboard uyarı önem dereceleri (en yüksekten düşüğe)
+const (
+	AlertSeverityCritical = "critical"
+	AlertSeverityHigh     = "high"
+	AlertSeverityMedium   = "medium"
+	AlertSeverityLow      = "low"
+)
+
+// Dashboard uyarı türleri
+const (
+	DashboardAlertOverdueVaccination = "overdue_vaccination"
+	DashboardAlertDocumentExpiry     = "document_expiry"
+	DashboardAlertLowStock           = "low_stock"
+	DashboardAlertNegativeCashFlow   = "negative_cash_flow"
+	DashboardAlertWeather            = "weather"
+	DashboardAlertCertification      = "certification_expiry"
+)
+
+// DashboardAlert "ilgilenilmesi gerekenler" listesindeki tek uyarı
+type DashboardAlert struct {
+	Kind     string         `json:"kind" enums:"overdue_vaccination,document_expiry,low_stock,negative_cash_flow,weather,certification_expiry"`
+	Severity string         `json:"severity" enums:"critical,high,medium,low"`
+	Title    string         `json:"title"`
+	Message  string         `json:"message"`
+	Date     *time.Time     `json:"date"`
+	Entity   *RelatedEntity `json:"entity,omitempty"`
+}
+
+// DashboardAlerts modüllerden toplanan ve önem derecesine göre sıralanan güncel uyarılar
+type DashboardAlerts struct {
+	Total      int              `json:"total"`
+	BySeverity map[string]int   `json:"bySeverity"`
+	Alerts     []DashboardAlert `json:"alerts"`
+}
+
 // Pagination sayfalama
 type Pagination struct {
 	Page       int `json:"page"`
@@ -248,8 +360,18 @@ type APIResponse struct {
 	Message string      `json:"message,omitempty"`
 	Error   *APIError   `json:"error,omitempty"`
 	Meta    *APIMeta    `json:"meta,omitempty"`
+	Links   Links       `json:"links,omitempty"`
 }
 
+// Link detay yanıtındaki ilişkili kaynak bağlantısı; method yalnızca GET dışındaki işlemlerde döner
+type Link struct {
+	Href   string `json:"href"`
+	Method string `json:"method,omitempty"`
+}
+
+// Links ilişki adına (self, healthRecords, activities...) göre bağlantılar
+type Links map[string]Link
+
 // APIError API hata formatı
 type APIError struct {
 	Code    string      `json:"code"`
@@ -259,9 +381,39 @@ type APIError struct {
 
 // APIMeta API meta bilgileri
 type APIMeta struct {
-	Timestamp string `json:"timestamp"`
-	Version   string `json:"version"`
-	RequestID string `json:"requestId"`
+	Timestamp string     `json:"timestamp"`
+	Version   string     `json:"version"`
+	RequestID string     `json:"requestId"`
+	DB        *DBTiming  `json:"db,omitempty"`
+	Cache     *CacheHint `json:"cache,omitempty"`
+}
+
+// DBTiming isteğin veritabanında geçirdiği süre; yalnızca DEBUG_DB_TIMING açıkken meta bilgisinde döner
+type DBTiming struct {
+	Queries    int     `json:"queries"`
+	DurationMs float64 `json:"durationMs"`
+}
+
+// CacheHint mobil istemcinin çevrimdışı önbelleği için kaynağın ne kadar süre yeniden kullanılabileceği; yalnızca
+// başarılı GET yanıtlarında döner. Yanıt maxAge saniye boyunca taze kabul edilir, ardından staleWhileRevalidate
+// saniye daha arka planda yenilenirken gösterilebilir. noStore ise yanıt saklanmamalıdır. lastModified yanıttaki
+// kayıtların en yeni güncellenme (yoksa oluşturulma) zamanıdır
+type CacheHint struct {
+	Resource             string     `json:"resource"`
+	MaxAge               int        `json:"maxAge"`
+	StaleWhileRevalidate int        `json:"staleWhileRevalidate"`
+	NoStore              bool       `json:"noStore,omitempty"`
+	LastModified         *time.Time `json:"lastModified,omitempty"`
+}
+
+// ProblemDetails zarfsız modda dönen RFC 7807 hata gövdesi; code ve details APIError ile aynıdır
+type ProblemDetails struct {
+	Type     string      `json:"type"`
+	Title    string      `json:"title"`
+	Status   int         `json:"status"`
+	Code     string      `json:"code"`
+	Details  interface{} `json:"details,omitempty"`
+	Instance string      `json:"instance,omitempty"`
 }
 
 // CategoryData kategori verileri
@@ -286,6 +438,152 @@ type HealthRecord struct {
 	CreatedAt    time.Time  `json:"createdAt" db:"created_at"`
 }
 
+// Hayvan sağlık durumları; vaccination_needed aşı takviminden, pregnant üreme kayıtlarından türetilir
+const (
+	HealthStatusHealthy           = "healthy"
+	HealthStatusVaccinationNeeded = "vaccination_needed"
+	HealthStatusPregnant          = "pregnant"
+)
+
+// Aşı takvimi durumları
+const (
+	VaccinationStatusScheduled    = "scheduled"
+	VaccinationStatusOverdue      = "overdue"
+	VaccinationStatusAdministered = "administered"
+)
+
+// Vaccination hayvanın planlanan aşısı; administeredDate girildiğinde aşı yapılmış sayılır ve hayvanın sağlık
+// kayıtlarına aşı kaydı eklenir (healthRecordId). Durum tarihlere göre hesaplanır
+type Vaccination struct {
+	ID               string    `json:"id" db:"id"`
+	AnimalID         string    `json:"animalId" db:"livestock_id"`
+	TagNumber        string    `json:"tagNumber,omitempty" db:"-"`
+	AnimalType       string    `json:"animalType,omitempty" db:"-"`
+	Vaccine          string    `json:"vaccine" db:"vaccine"`
+	Dose             string    `json:"dose" db:"dose"`
+	DueDate          string    `json:"dueDate" db:"due_date"`
+	AdministeredDate *string   `json:"administeredDate" db:"administered_date"`
+	BatchNumber      string    `json:"batchNumber" db:"batch_number"`
+	Veterinarian     string    `json:"veterinarian" db:"veterinarian"`
+	Notes            string    `json:"notes" db:"notes"`
+	HealthRecordID   *string   `json:"healthRecordId" db:"health_record_id"`
+	Status           string    `json:"status" db:"-" enums:"scheduled,overdue,administered"`
+	DaysUntilDue     *int      `json:"daysUntilDue,omitempty" db:"-"`
+	CreatedAt        time.Time `json:"createdAt" db:"created_at"`
+	UpdatedAt        time.Time `json:"updatedAt" db:"updated_at"`
+}
+
+// VaccinationRequest aşı takvimi ekleme ve güncelleme isteği; tarihler YYYY-MM-DD biçimindedir
+type VaccinationRequest struct {
+	Vaccine          string  `json:"vaccine" binding:"required"`
+	Dose             string  `json:"dose"`
+	DueDate          string  `json:"dueDate" binding:"required,datetime=2006-01-02"`
+	AdministeredDate *string `json:"administeredDate" binding:"omitempty,datetime=2006-01-02"`
+	BatchNumber      string  `json:"batchNumber"`
+	Veterinarian     string  `json:"veterinarian"`
+	Notes            string  `json:"notes"`
+}
+
+// Üreme kaydı yöntemleri
+const (
+	BreedingMethodArtificial = "artificial_insemination"
+	BreedingMethodNatural    = "natural"
+)
+
+// Üreme kaydı gebelik durumları; pending ve confirmed açık gebeliktir
+const (
+	PregnancyStatusPending     = "pending"
+	PregnancyStatusConfirmed   = "confirmed"
+	PregnancyStatusNotPregnant = "not_pregnant"
+	PregnancyStatusAborted     = "aborted"
+	PregnancyStatusDelivered   = "delivered"
+)
+
+// BreedingRecord dişi hayvanın tohumlama/aşım kaydı. Beklenen doğum tarihi girilmezse türün gebelik süresiyle
+// hesaplanır ve takvime beklenen doğum etkinliği olarak eklenir; doğan yavrular kayda bağlanır
+type BreedingRecord struct {
+	ID                 string              `json:"id" db:"id"`
+	AnimalID           string              `json:"animalId" db:"livestock_id"`
+	TagNumber          string              `json:"tagNumber" db:"-"`
+	Method             string              `json:"method" db:"method" enums:"artificial_insemination,natural"`
+	ServiceDate        string              `json:"serviceDate" db:"service_date"`
+	SireID             *string             `json:"sireId" db:"sire_id"`
+	Sire               string              `json:"sire" db:"sire"`
+	Technician         string              `json:"technician" db:"technician"`
+	PregnancyStatus    string              `json:"pregnancyStatus" db:"pregnancy_status" enums:"pending,confirmed,not_pregnant,aborted,delivered"`
+	PregnancyCheckDate *string             `json:"pregnancyCheckDate" db:"pregnancy_check_date"`
+	ExpectedBirthDate  *string             `json:"expectedBirthDate" db:"expected_birth_date"`
+	BirthDate          *string             `json:"birthDate" db:"birth_date"`
+	DaysUntilBirth     *int                `json:"daysUntilBirth,omitempty" db:"-"`
+	Offspring          []BreedingOffspring `json:"offspring" db:"-"`
+	Notes              string              `json:"notes" db:"notes"`
+	CreatedAt          time.Time           `json:"createdAt" db:"created_at"`
+	UpdatedAt          time.Time           `json:"updatedAt" db:"updated_at"`
+}
+
+// BreedingOffspring üreme kaydına bağlı yavru
+type BreedingOffspring struct {
+	ID        string `json:"id"`
+	TagNumber string `json:"tagNumber"`
+	Gender    string `json:"gender"`
+}
+
+// BreedingRecordRequest üreme kaydı ekleme ve güncelleme isteği; tarihler YYYY-MM-DD biçimindedir.
+// sireId çiftlikteki erkek hayvanı, sire çiftlik dışı boğa/koç veya sperma kodunu belirtir
+type BreedingRecordRequest struct {
+	Method             string   `json:"method" binding:"omitempty,oneof=artificial_insemination natural"`
+	ServiceDate        string   `json:"serviceDate" binding:"required,datetime=2006-01-02"`
+	SireID             *string  `json:"sireId"`
+	Sire               string   `json:"sire"`
+	Technician         string   `json:"technician"`
+	PregnancyStatus    string   `json:"pregnancyStatus" binding:"omitempty,oneof=pending confirmed not_pregnant aborted delivered"`
+	PregnancyCheckDate *string  `json:"pregnancyCheckDate" binding:"omitempty,datetime=2006-01-02"`
+	ExpectedBirthDate  *string  `json:"expectedBirthDate" binding:"omitempty,datetime=2006-01-02"`
+	BirthDate          *string  `json:"birthDate" binding:"omitempty,datetime=2006-01-02"`
+	OffspringIDs       []string `json:"offspringIds"`
+	Notes              string   `json:"notes"`
+}
+
+// WeightRecord hayvanın tartım kaydı; dailyGain önceki tartımdan bu yana günlük ortalama canlı ağırlık artışıdır (kg/gün)
+type WeightRecord struct {
+	ID         string    `json:"id" db:"id"`
+	AnimalID   string    `json:"animalId" db:"livestock_id"`
+	Weight     float64   `json:"weight" db:"weight"`
+	RecordDate string    `json:"recordDate" db:"record_date"`
+	Notes      string    `json:"notes" db:"notes"`
+	DailyGain  *float64  `json:"dailyGain" db:"-"`
+	CreatedAt  time.Time `json:"createdAt" db:"created_at"`
+}
+
+// WeightRecordRequest tartım kaydı isteği; ağırlık kg, tarih YYYY-MM-DD biçimindedir ve verilmezse bugündür
+type WeightRecordRequest struct {
+	Weight     float64 `json:"weight" binding:"required,gt=0"`
+	RecordDate string  `json:"recordDate" binding:"omitempty,datetime=2006-01-02"`
+	Notes      string  `json:"notes"`
+}
+
+// GrowthPoint büyüme eğrisinin noktası; ageDays doğum tarihi biliniyorsa hayvanın tartım günündeki yaşıdır
+type GrowthPoint struct {
+	Date      string   `json:"date"`
+	Weight    float64  `json:"weight"`
+	AgeDays   *int     `json:"ageDays"`
+	DailyGain *float64 `json:"dailyGain"`
+}
+
+// GrowthCurve hayvanın tartımlarından oluşan büyüme eğrisi; averageDailyGain ilk ve son tartım arasındaki günlük
+// ortalama artıştır
+type GrowthCurve struct {
+	AnimalID         string        `json:"animalId"`
+	TagNumber        string        `json:"tagNumber"`
+	Type             string        `json:"type"`
+	BirthDate        *time.Time    `json:"birthDate"`
+	Points           []GrowthPoint `json:"points"`
+	StartWeight      *float64      `json:"startWeight"`
+	CurrentWeight    *float64      `json:"currentWeight"`
+	TotalGain        float64       `json:"totalGain"`
+	AverageDailyGain *float64      `json:"averageDailyGain"`
+}
+
 // MilkProductionRecord süt üretim kaydı
 type MilkProductionRecord struct {
 	ID        string     `json:"id" db:"id"`
@@ -316,6 +614,79 @@ type Event struct {
 	UpdatedAt     time.Time      `json:"updatedAt" db:"updated_at"`
 }
 
+// Otomatik etkinlik kuralları
+const (
+	EventRuleExpectedBirth   = "expected_birth"
+	EventRuleHealthCheckup   = "health_checkup"
+	EventRuleHarvestWindow   = "harvest_window"
+	EventRuleInstallmentDue  = "installment_due"
+	EventRulePlannedPlanting = "planned_planting"
+)
+
+// EventRule kayıtlardaki tarihlerden takvim etkinliği oluşturan kural ve çiftlikteki durumu
+type EventRule struct {
+	Key         string `json:"key"`
+	Name        string `json:"name"`
+	Description string `json:"description"`
+	Source      string `json:"source"`
+	EventType   string `json:"eventType"`
+	Enabled     bool   `json:"enabled"`
+	// UpcomingEvents kuralın oluşturduğu bekleyen yaklaşan etkinlik sayısı
+	UpcomingEvents int `json:"upcomingEvents"`
+}
+
+// EventRuleUpdateRequest kuralı açma veya kapatma isteği
+type EventRuleUpdateRequest struct {
+	Enabled *bool `json:"enabled" binding:"required"`
+}
+
+// EventRuleSyncResult kuralların çalıştırılması sonucunda oluşturulan, güncellenen ve kaldırılan etkinlik sayıları
+type EventRuleSyncResult struct {
+	Created int `json:"created"`
+	Updated int `json:"updated"`
+	Removed int `json:"removed"`
+}
+
+// CalendarExportFilter takvim dışa aktarım filtreleri; tarihler gün olarak karşılaştırılır
+type CalendarExportFilter struct {
+	StartDate *time.Time
+	EndDate   *time.Time
+	Type      string
+	Status    string
+}
+
+// CalendarExportEvent dışa aktarılan takvim etkinliği
+type CalendarExportEvent struct {
+	ID          string     `json:"id"`
+	Title       string     `json:"title"`
+	Description string     `json:"description"`
+	Type        string     `json:"type"`
+	StartDate   time.Time  `json:"startDate"`
+	EndDate     *time.Time `json:"endDate,omitempty"`
+	IsAllDay    bool       `json:"isAllDay"`
+	Status      string     `json:"status"`
+	Priority    string     `json:"priority"`
+	Location    string     `json:"location"`
+	RelatedType string     `json:"relatedType,omitempty"`
+	RelatedID   string     `json:"relatedId,omitempty"`
+	RelatedName string     `json:"relatedName,omitempty"`
+}
+
+// CalendarExportTask dışa aktarılan görev (arazi aktivitesi)
+type CalendarExportTask struct {
+	ID            string     `json:"id"`
+	LandID        string     `json:"landId"`
+	LandName      string     `json:"landName"`
+	Type          string     `json:"type"`
+	Description   string     `json:"description"`
+	ScheduledDate *time.Time `json:"scheduledDate,omitempty"`
+	ActualDate    *time.Time `json:"actualDate,omitempty"`
+	Status        string     `json:"status" example:"planned"`
+	Cost          float64    `json:"cost"`
+	Result        string     `json:"result"`
+	Notes         string     `json:"notes"`
+}
+
 // RelatedEntity ilişkili varlık
 type RelatedEntity struct {
 	Type string `json:"type"`
@@ -338,16 +709,27 @@ type NotificationExtended struct {
 	Type          string         `json:"type" db:"type"`
 	Priority      string         `json:"priority" db:"priority"`
 	IsRead        bool           `json:"isRead" db:"is_read"`
+	Topic         string         `json:"topic" db:"topic"`
 	RelatedEntity *RelatedEntity `json:"relatedEntity" db:"-"`
-	Actions       []Action       `json:"actions" db:"-"`
+	Actions       []Action       `json:"actions" db:"actions"`
 	CreatedAt     time.Time      `json:"createdAt" db:"created_at"`
 }
 
+// Bildirim aksiyon türleri
+const (
+	ActionTypeNavigate = "navigate" // Mobil uygulamada route alanındaki ekranı açar
+	ActionTypeAPI      = "api"      // route alanındaki API uç noktasını method ve payload ile çağırır
+	ActionTypeDismiss  = "dismiss"  // Bildirimi okundu işaretleyip kapatır
+)
+
 // Action bildirim aksiyonu
 type Action struct {
-	Label  string `json:"label"`
-	Action string `json:"action"`
-	URL    string `json:"url"`
+	Key     string                 `json:"key"`
+	Label   string                 `json:"label"`
+	Type    string                 `json:"type"`
+	Route   string                 `json:"route,omitempty"`
+	Method  string                 `json:"method,omitempty"`
+	Payload map[string]interface{} `json:"payload,omitempty"`
 }
 
 // Settings ayarlar
@@ -356,15 +738,29 @@ type Settings struct {
 	Notifications NotificationSettings `json:"notifications"`
 	Privacy       PrivacySettings      `json:"privacy"`
 	Backup        BackupSettings       `json:"backup"`
+	Fiscal        FiscalSettings       `json:"fiscal"`
+	Costing       CostingSettings      `json:"costing"`
+	LandArea      LandAreaSettings     `json:"landArea"`
+	// EventRules otomatik etkinlik kurallarının açık/kapalı durumu; listede olmayan kurallar açıktır
+	EventRules map[string]bool `json:"eventRules"`
 }
 
 // GeneralSettings genel ayarlar
 type GeneralSettings struct {
-	Language   string       `json:"language"`
-	Currency   string       `json:"currency"`
-	DateFormat string       `json:"dateFormat"`
-	TimeFormat string       `json:"timeFormat"`
-	Units      UnitSettings `json:"units"`
+	Language string `json:"language"`
+	Currency string `json:"currency"`
+	// DateFormat dışa aktarım ve raporlardaki tarih biçimi (ör. DD/MM/YYYY, DD.MM.YYYY, YYYY-MM-DD)
+	DateFormat string `json:"dateFormat"`
+	// TimeFormat saat biçimi
+	TimeFormat string `json:"timeFormat" enums:"24H,12H"`
+	// DecimalSeparator sayılardaki ondalık ayırıcı ("," veya ".")
+	DecimalSeparator string `json:"decimalSeparator"`
+	// ThousandsSeparator binlik ayırıcı ("." "," " " veya gruplama yapılmaması için "none"); boşsa ondalık
+	// ayırıcıya göre belirlenir
+	ThousandsSeparator string `json:"thousandsSeparator"`
+	// CurrencySymbol çiftliğin para birimindeki tutarların simgesi; boşsa para biriminden bulunur (TRY için TL)
+	CurrencySymbol string       `json:"currencySymbol"`
+	Units          UnitSettings `json:"units"`
 }
 
 // UnitSettings birim ayarları
@@ -391,8 +787,26 @@ type PrivacySettings struct {
 // BackupSettings yedekleme ayarları
 type BackupSettings struct {
 	AutoBackup      bool   `json:"autoBackup"`
-	BackupFrequency string `json:"backupFrequency"`
+	BackupFrequency string `json:"backupFrequency" enums:"daily,weekly,monthly"`
 	CloudStorage    bool   `json:"cloudStorage"`
+	// RetentionCount saklanacak en fazla başarılı yedek sayısı (0: sınırsız)
+	RetentionCount int `json:"retentionCount"`
+	// RetentionDays bu kadar günden eski yedekler silinir (0: süresiz); en yeni yedek her zaman saklanır
+	RetentionDays int `json:"retentionDays"`
+}
+
+// CostingSettings arazi aktivitesi maliyetlerinin değerlemesinde kullanılan varsayılan ücretler
+type CostingSettings struct {
+	LaborHourlyRate   float64 `json:"laborHourlyRate" binding:"min=0"`
+	MachineHourlyRate float64 `json:"machineHourlyRate" binding:"min=0"`
+}
+
+// LandAreaSettings arazi alanının sınır geometrisiyle karşılaştırılmasında kullanılan ayarlar
+type LandAreaSettings struct {
+	// TolerancePercent girilen alan ile sınırdan hesaplanan alan arasında kabul edilen en fazla fark (yüzde, 0 ise 5)
+	TolerancePercent float64 `json:"tolerancePercent" binding:"min=0,max=100"`
+	// AutoCorrect fark toleransı aşan arazilerin alanını kaydederken sınırdan hesaplanan alanla değiştirir
+	AutoCorrect bool `json:"autoCorrect"`
 }
 
 // Weather hava durumu
@@ -407,6 +821,7 @@ type Weather struct {
 	UVIndex       float64 `json:"uvIndex"`
 	Condition     string  `json:"condition"`
 	Icon          string  `json:"icon"`
+	Rainfall      float64 `json:"rainfall"`
 	LastUpdated   string  `json:"lastUpdated"`
 }
 
@@ -419,6 +834,7 @@ type WeatherForecast struct {
 	Icon       string  `json:"icon"`
 	Humidity   float64 `json:"humidity"`
 	RainChance float64 `json:"rainChance"`
+	Rainfall   float64 `json:"rainfall"`
 	WindSpeed  float64 `json:"windSpeed"`
 }
 
@@ -435,14 +851,4585 @@ type AgriculturalAlert struct {
 
 // LandActivityRecord arazi aktivitesi kaydı
 type LandActivityRecord struct {
+	ID               string                  `json:"id" db:"id"`
+	LandID           string                  `json:"landId" db:"land_id"`
+	Type             string                  `json:"type" db:"type"`
+	Description      string                  `json:"description" db:"description"`
+	ScheduledDate    *time.Time              `json:"scheduledDate" db:"scheduled_date"`
+	ActualDate       *time.Time              `json:"actualDate" db:"actual_date"`
+	Notes            string                  `json:"notes" db:"notes"`
+	Cost             *float64                `json:"cost" db:"cost"`
+	Result           string                  `json:"result" db:"result"`
+	FertilizerKg     *float64                `json:"fertilizerKg" db:"fertilizer_kg"`
+	NitrogenPercent  *float64                `json:"nitrogenPercent" db:"nitrogen_percent" binding:"omitempty,min=0,max=100"`
+	WaterVolume      *float64                `json:"waterVolume" db:"water_volume" binding:"omitempty,gt=0"`
+	CostItems        []LandActivityCostItem  `json:"costItems,omitempty" db:"-" binding:"omitempty,dive"`
+	AssignedWorkerID *string                 `json:"assignedWorkerId" db:"assigned_worker_id"`
+	AssignmentIssues []WorkerAssignmentIssue `json:"assignmentIssues,omitempty" db:"-"`
+	CreatedAt        time.Time               `json:"createdAt" db:"created_at"`
+}
+
+// Arazi aktivitesi maliyet kalemi türleri
+const (
+	LandCostInput     = "input"
+	LandCostLabor     = "labor"
+	LandCostMachinery = "machinery"
+)
+
+// LandActivityCostItem aktivitenin tek bir maliyet kalemi: tüketilen girdi (stoktaki ürün), işçilik saati veya makine saati.
+// Birim ücret verilmezse girdi için stok birim maliyeti (yoksa fiyatı), işçilik ve makine için makinenin saatlik ücreti
+// ya da çiftlik ayarlarındaki varsayılan ücret kullanılır
+type LandActivityCostItem struct {
+	ID           string    `json:"id" db:"id"`
+	ActivityID   string    `json:"activityId" db:"activity_id"`
+	Category     string    `json:"category" db:"category" binding:"required,oneof=input labor machinery"`
+	ProductionID string    `json:"productionId" db:"production_id"`
+	AssetID      string    `json:"assetId" db:"asset_id"`
+	Description  string    `json:"description" db:"description"`
+	Quantity     float64   `json:"quantity" db:"quantity" binding:"required,gt=0"`
+	Unit         string    `json:"unit" db:"unit"`
+	UnitRate     *float64  `json:"unitRate" db:"unit_rate" binding:"omitempty,min=0"`
+	RateSource   string    `json:"rateSource" db:"rate_source"`
+	Amount       float64   `json:"amount" db:"amount"`
+	CreatedAt    time.Time `json:"createdAt" db:"created_at"`
+}
+
+// LandActivityCostItemsRequest aktivitenin maliyet kalemlerini topluca değiştirme isteği
+type LandActivityCostItemsRequest struct {
+	Items []LandActivityCostItem `json:"items" binding:"dive"`
+}
+
+// LandActivityCostBreakdown aktivitenin kalem bazında maliyet dökümü
+type LandActivityCostBreakdown struct {
+	ActivityID string                 `json:"activityId"`
+	Input      float64                `json:"input"`
+	Labor      float64                `json:"labor"`
+	Machinery  float64                `json:"machinery"`
+	Total      float64                `json:"total"`
+	Items      []LandActivityCostItem `json:"items"`
+}
+
+// LandProfitability arazinin dönem içindeki satış gelirleri ve aktivite maliyetleriyle karlılığı
+type LandProfitability struct {
+	LandID         string         `json:"landId"`
+	LandName       string         `json:"landName"`
+	Area           float64        `json:"area"`
+	StartDate      string         `json:"startDate,omitempty"`
+	EndDate        string         `json:"endDate,omitempty"`
+	Revenue        float64        `json:"revenue"`
+	InputCost      float64        `json:"inputCost"`
+	LaborCost      float64        `json:"laborCost"`
+	MachineryCost  float64        `json:"machineryCost"`
+	UnitemizedCost float64        `json:"unitemizedCost"`
+	AllocatedCost  float64        `json:"allocatedCost"`
+	TotalCost      float64        `json:"totalCost"`
+	Profit         float64        `json:"profit"`
+	ProfitPerArea  *float64       `json:"profitPerArea"`
+	ActivityCount  int            `json:"activityCount"`
+	ItemizedCount  int            `json:"itemizedCount"`
+	Water          LandWaterUsage `json:"water"`
+}
+
+// Su kotası kullanım durumları
+const (
+	WaterQuotaOK       = "ok"
+	WaterQuotaWarning  = "warning"
+	WaterQuotaExceeded = "exceeded"
+)
+
+// WaterQuota arazi veya çiftlik (landId boş) için sezonluk su tahsisi (m³). Kullanım sezon içinde gerçekleşen
+// sulama aktivitelerinin waterVolume toplamıdır; çiftlik kotası tüm arazileri kapsar
+type WaterQuota struct {
+	ID             string    `json:"id" db:"id"`
+	LandID         *string   `json:"landId" db:"land_id"`
+	LandName       string    `json:"landName,omitempty" db:"-"`
+	Name           string    `json:"name" db:"name"`
+	SeasonStart    string    `json:"seasonStart" db:"season_start"`
+	SeasonEnd      string    `json:"seasonEnd" db:"season_end"`
+	Volume         float64   `json:"volume" db:"volume"`
+	UnitPrice      float64   `json:"unitPrice" db:"unit_price"`
+	WarningPercent float64   `json:"warningPercent" db:"warning_percent"`
+	Notes          string    `json:"notes" db:"notes"`
+	Used           float64   `json:"used" db:"-"`
+	Remaining      float64   `json:"remaining" db:"-"`
+	Utilization    float64   `json:"utilization" db:"-"`
+	WaterCost      float64   `json:"waterCost" db:"-"`
+	Status         string    `json:"status" db:"-"`
+	CreatedAt      time.Time `json:"createdAt" db:"created_at"`
+	UpdatedAt      time.Time `json:"updatedAt" db:"updated_at"`
+}
+
+// WaterQuotaRequest su kotası ekleme ve güncelleme isteği; tarihler YYYY-MM-DD biçimindedir
+type WaterQuotaRequest struct {
+	LandID         *string  `json:"landId"`
+	Name           string   `json:"name" binding:"required"`
+	SeasonStart    string   `json:"seasonStart" binding:"required,datetime=2006-01-02"`
+	SeasonEnd      string   `json:"seasonEnd" binding:"required,datetime=2006-01-02"`
+	Volume         float64  `json:"volume" binding:"required,gt=0"`
+	UnitPrice      float64  `json:"unitPrice" binding:"min=0"`
+	WarningPercent *float64 `json:"warningPercent" binding:"omitempty,gt=0,max=100"`
+	Notes          string   `json:"notes"`
+}
+
+// LandWaterUsage arazinin dönemdeki sulama suyu hacmi, kota birim fiyatlarıyla su maliyeti ve araziyi kapsayan
+// kotaların kullanımı
+type LandWaterUsage struct {
+	Volume     float64      `json:"volume"`
+	Cost       float64      `json:"cost"`
+	Unquoted   float64      `json:"unquotedVolume"`
+	Irrigation int          `json:"irrigations"`
+	Quotas     []WaterQuota `json:"quotas"`
+}
+
+// Çalışan sözleşme türleri
+const (
+	WorkerContractSeasonal  = "seasonal"
+	WorkerContractPermanent = "permanent"
+)
+
+// Çalışan sertifika türleri
+const (
+	CertificationPesticideApplicator = "pesticide_applicator"
+	CertificationDrivingLicense      = "driving_license"
+	CertificationOther               = "other"
+)
+
+// Sertifika geçerlilik durumları
+const (
+	CertificationStatusValid    = "valid"
+	CertificationStatusExpiring = "expiring"
+	CertificationStatusExpired  = "expired"
+)
+
+// Aktiviteye atanan çalışanın yeterlilik sorunları
+const (
+	AssignmentIssueContractInactive     = "contract_inactive"
+	AssignmentIssueCertificationMissing = "certification_missing"
+	AssignmentIssueCertificationExpired = "certification_expired"
+)
+
+// Worker çiftlik çalışanı; sezonluk çalışanların sözleşmesi contractEnd tarihinde biter, süresiz çalışanlarda
+// contractEnd boştur. contractActive bugünün sözleşme dönemi içinde olup olmadığını gösterir
+type Worker struct {
+	ID             string                `json:"id" db:"id"`
+	Name           string                `json:"name" db:"name"`
+	Role           string                `json:"role" db:"role"`
+	Phone          string                `json:"phone" db:"phone"`
+	ContractType   string                `json:"contractType" db:"contract_type" enums:"seasonal,permanent"`
+	ContractStart  string                `json:"contractStart" db:"contract_start"`
+	ContractEnd    *string               `json:"contractEnd" db:"contract_end"`
+	ContractActive bool                  `json:"contractActive" db:"-"`
+	Notes          string                `json:"notes" db:"notes"`
+	Certifications []WorkerCertification `json:"certifications" db:"-"`
+	CreatedAt      time.Time             `json:"createdAt" db:"created_at"`
+	UpdatedAt      time.Time             `json:"updatedAt" db:"updated_at"`
+}
+
+// WorkerRequest çalışan ekleme ve güncelleme isteği; tarihler YYYY-MM-DD biçimindedir
+type WorkerRequest struct {
+	Name          string  `json:"name" binding:"required"`
+	Role          string  `json:"role"`
+	Phone         string  `json:"phone"`
+	ContractType  string  `json:"contractType" binding:"required,oneof=seasonal permanent"`
+	ContractStart string  `json:"contractStart" binding:"required,datetime=2006-01-02"`
+	ContractEnd   *string `json:"contractEnd" binding:"omitempty,datetime=2006-01-02"`
+	Notes         string  `json:"notes"`
+}
+
+// WorkerCertification çalışanın belgesi (zirai ilaç uygulama belgesi, sürücü belgesi); expiresAt boşsa süresizdir.
+// Durum bitişe 30 gün ve daha az kaldığında expiring, bitiş geçtiğinde expired olur
+type WorkerCertification struct {
+	ID           string    `json:"id" db:"id"`
+	WorkerID     string    `json:"workerId" db:"worker_id"`
+	Type         string    `json:"type" db:"type" enums:"pesticide_applicator,driving_license,other"`
+	Number       string    `json:"number" db:"number"`
+	IssuedAt     *string   `json:"issuedAt" db:"issued_at"`
+	ExpiresAt    *string   `json:"expiresAt" db:"expires_at"`
+	Status       string    `json:"status" db:"-" enums:"valid,expiring,expired"`
+	DaysToExpiry *int      `json:"daysToExpiry,omitempty" db:"-"`
+	CreatedAt    time.Time `json:"createdAt" db:"created_at"`
+}
+
+// WorkerCertificationRequest sertifika ekleme isteği; tarihler YYYY-MM-DD biçimindedir
+type WorkerCertificationRequest struct {
+	Type      string  `json:"type" binding:"required,oneof=pesticide_applicator driving_license other"`
+	Number    string  `json:"number"`
+	IssuedAt  *string `json:"issuedAt" binding:"omitempty,datetime=2006-01-02"`
+	ExpiresAt *string `json:"expiresAt" binding:"omitempty,datetime=2006-01-02"`
+}
+
+// WorkerAssignmentIssue aktiviteye atanan çalışanın aktivite tarihinde eksik olan yeterliliği; certification
+// eksik veya süresi dolmuş belgenin türüdür
+type WorkerAssignmentIssue struct {
+	Code          string `json:"code" enums:"contract_inactive,certification_missing,certification_expired"`
+	Certification string `json:"certification,omitempty"`
+	Message       string `json:"message"`
+}
+
+// ActivityAssignmentRequest aktiviteye çalışan atama isteği; workerId null ise atama kaldırılır
+type ActivityAssignmentRequest struct {
+	WorkerID *string `json:"workerId"`
+}
+
+// ActivityAssignment aktivitenin atandığı çalışan ve aktivite tarihindeki yeterlilik sorunları
+type ActivityAssignment struct {
+	ActivityID string                  `json:"activityId"`
+	WorkerID   *string                 `json:"workerId"`
+	Issues     []WorkerAssignmentIssue `json:"issues"`
+}
+
+// Keşif bulgusu şiddet seviyeleri
+const (
+	ScoutingSeverityLow    = "low"
+	ScoutingSeverityMedium = "medium"
+	ScoutingSeverityHigh   = "high"
+)
+
+// Keşif bulgusunun dönüştürülebileceği kayıt türleri
+const (
+	ScoutingTargetIssue = "issue"
+	ScoutingTargetTask  = "task"
+)
+
+// ScoutingPoint keşif turunda konumu işaretlenen gözlem noktası. Fotoğraflar medya eklerinden
+// (entityType: scouting_point) gelir; bulgu zararlı/hastalık gözlemine veya arazi görevine dönüştürülebilir
+type ScoutingPoint struct {
+	ID        string    `json:"id" db:"id"`
+	SessionID string    `json:"sessionId" db:"session_id"`
+	Latitude  float64   `json:"latitude" db:"latitude"`
+	Longitude float64   `json:"longitude" db:"longitude"`
+	Severity  string    `json:"severity" db:"severity" enums:"low,medium,high"`
+	Note      string    `json:"note" db:"note"`
+	PhotoIDs  []string  `json:"photoIds" db:"-"`
+	IssueID   *string   `json:"issueId" db:"issue_id"`
+	TaskID    *string   `json:"taskId" db:"task_id"`
+	CreatedAt time.Time `json:"createdAt" db:"created_at"`
+}
+
+// ScoutingPointRequest gözlem noktası ekleme ve güncelleme isteği
+type ScoutingPointRequest struct {
+	Latitude  *float64 `json:"latitude" binding:"required,min=-90,max=90"`
+	Longitude *float64 `json:"longitude" binding:"required,min=-180,max=180"`
+	Severity  string   `json:"severity" binding:"required,oneof=low medium high"`
+	Note      string   `json:"note"`
+}
+
+// ScoutingSession arazide belirli bir günde yapılan keşif turu ve gözlem noktaları
+type ScoutingSession struct {
+	ID          string          `json:"id" db:"id"`
+	LandID      string          `json:"landId" db:"land_id"`
+	LandName    string          `json:"landName" db:"-"`
+	ScoutedOn   string          `json:"scoutedOn" db:"scouted_on"`
+	ScoutName   string          `json:"scoutName" db:"scout_name"`
+	Notes       string          `json:"notes" db:"notes"`
+	PointCount  int             `json:"pointCount" db:"-"`
+	MaxSeverity string          `json:"maxSeverity,omitempty" db:"-"`
+	Points      []ScoutingPoint `json:"points,omitempty" db:"-"`
+	CreatedAt   time.Time       `json:"createdAt" db:"created_at"`
+	UpdatedAt   time.Time       `json:"updatedAt" db:"updated_at"`
+}
+
+// ScoutingSessionRequest keşif turu ekleme ve güncelleme isteği; points yalnızca eklemede kullanılır
+type ScoutingSessionRequest struct {
+	ScoutedOn string                 `json:"scoutedOn" binding:"required,datetime=2006-01-02"`
+	ScoutName string                 `json:"scoutName" binding:"max=100"`
+	Notes     string                 `json:"notes"`
+	Points    []ScoutingPointRequest `json:"points" binding:"dive"`
+}
+
+// ScoutingConvertRequest gözlem noktasını zararlı/hastalık gözlemine (issue) veya arazi görevine (task) dönüştürme isteği.
+// issue için name zorunludur; task için type verilmezse "scouting", dueDate verilmezse keşif tarihi kullanılır
+type ScoutingConvertRequest struct {
+	Target      string `json:"target" binding:"required,oneof=issue task"`
+	Name        string `json:"name"`
+	Category    string `json:"category" binding:"omitempty,oneof=pest disease deficiency other"`
+	Type        string `json:"type"`
+	Description string `json:"description"`
+	DueDate     string `json:"dueDate" binding:"omitempty,datetime=2006-01-02"`
+}
+
+// ScoutingConversion dönüştürme sonucu; güncel nokta ve oluşturulan kaydın kimliği
+type ScoutingConversion struct {
+	Target   string        `json:"target"`
+	RecordID string        `json:"recordId"`
+	Point    ScoutingPoint `json:"point"`
+}
+
+// GeoFeature GeoJSON Feature; geometri Point veya Polygon olabilir
+type GeoFeature struct {
+	Type       string                 `json:"type"`
+	Geometry   interface{}            `json:"geometry"`
+	Properties map[string]interface{} `json:"properties"`
+}
+
+// GeoFeatureCollection GeoJSON FeatureCollection
+type GeoFeatureCollection struct {
+	Type     string       `json:"type"`
+	Features []GeoFeature `json:"features"`
+}
+
+// HarvestCrewEntry hasat aktivitesinde bir ekip üyesinin topladığı miktar ve parça başı ücreti.
+// Tutar miktar ile parça başı ücretin çarpımıdır; ödeme kaydedilince gider işlemine bağlanır
+type HarvestCrewEntry struct {
 	ID            string     `json:"id" db:"id"`
-	LandID        string     `json:"landId" db:"land_id"`
-	Type          string     `json:"type" db:"type"`
-	Description   string     `json:"description" db:"description"`
-	ScheduledDate *time.Time `json:"scheduledDate" db:"scheduled_date"`
-	ActualDate    *time.Time `json:"actualDate" db:"actual_date"`
+	ActivityID    string     `json:"activityId" db:"activity_id"`
+	WorkerName    string     `json:"workerName" db:"worker_name" binding:"required,max=100"`
+	Quantity      float64    `json:"quantity" db:"quantity" binding:"required,gt=0"`
+	Unit          string     `json:"unit" db:"unit"`
+	PieceRate     float64    `json:"pieceRate" db:"piece_rate" binding:"min=0"`
+	Amount        float64    `json:"amount" db:"amount"`
 	Notes         string     `json:"notes" db:"notes"`
-	Cost          *float64   `json:"cost" db:"cost"`
-	Result        string     `json:"result" db:"result"`
+	TransactionID *string    `json:"transactionId" db:"transaction_id"`
+	PaidAt        *time.Time `json:"paidAt" db:"paid_at"`
 	CreatedAt     time.Time  `json:"createdAt" db:"created_at"`
 }
+
+// HarvestCrewRequest hasat ekibini topluca değiştirme isteği
+type HarvestCrewRequest struct {
+	Entries []HarvestCrewEntry `json:"entries" binding:"dive"`
+}
+
+// HarvestCrew hasat aktivitesinin ekip kayıtları ve ödeme toplamları
+type HarvestCrew struct {
+	ActivityID    string             `json:"activityId"`
+	Workers       int                `json:"workers"`
+	TotalQuantity float64            `json:"totalQuantity"`
+	TotalAmount   float64            `json:"totalAmount"`
+	PaidAmount    float64            `json:"paidAmount"`
+	UnpaidAmount  float64            `json:"unpaidAmount"`
+	Entries       []HarvestCrewEntry `json:"entries"`
+}
+
+// HarvestPayrollPostRequest ekip ödemelerini gider olarak kaydetme isteği; tarih verilmezse aktivitenin
+// gerçekleşme tarihi, o da yoksa bugün kullanılır
+type HarvestPayrollPostRequest struct {
+	Date          *time.Time `json:"date"`
+	PaymentMethod string     `json:"paymentMethod"`
+}
+
+// HarvestPayrollPosting ödeme kaydı sonucu
+type HarvestPayrollPosting struct {
+	ActivityID   string   `json:"activityId"`
+	Posted       int      `json:"posted"`
+	TotalAmount  float64  `json:"totalAmount"`
+	Transactions []string `json:"transactions"`
+}
+
+// HarvestPayrollWorker işçinin dönemdeki hasat ödemeleri; farklı birimlerdeki miktarlar ayrı satırlardır
+type HarvestPayrollWorker struct {
+	WorkerName   string  `json:"workerName"`
+	Unit         string  `json:"unit"`
+	Activities   int     `json:"activities"`
+	Quantity     float64 `json:"quantity"`
+	Amount       float64 `json:"amount"`
+	PaidAmount   float64 `json:"paidAmount"`
+	UnpaidAmount float64 `json:"unpaidAmount"`
+}
+
+// HarvestPayrollSummary dönemdeki hasat aktivitelerinin işçi bazında ödeme özeti
+type HarvestPayrollSummary struct {
+	StartDate    string                 `json:"startDate,omitempty"`
+	EndDate      string                 `json:"endDate,omitempty"`
+	Activities   int                    `json:"activities"`
+	TotalAmount  float64                `json:"totalAmount"`
+	PaidAmount   float64                `json:"paidAmount"`
+	UnpaidAmount float64                `json:"unpaidAmount"`
+	Workers      []HarvestPayrollWorker `json:"workers"`
+}
+
+// Ürün gelişim evreleri; son ekim aktivitesinden bu yana geçen güne göre belirlenir
+const (
+	CropStageUnknown     = "unknown"
+	CropStageGermination = "germination"
+	CropStageVegetative  = "vegetative"
+	CropStageFlowering   = "flowering"
+	CropStageMaturity    = "maturity"
+	CropStageHarvest     = "harvest"
+)
+
+// Öneri öncelikleri
+const (
+	RecommendationPriorityLow    = "low"
+	RecommendationPriorityMedium = "medium"
+	RecommendationPriorityHigh   = "high"
+)
+
+// LandRecommendations arazi için önerilen sonraki aktiviteler ve önerilerin dayandığı durum
+type LandRecommendations struct {
+	LandID            string                 `json:"landId"`
+	LandName          string                 `json:"landName"`
+	Crop              string                 `json:"crop"`
+	Stage             string                 `json:"stage" example:"vegetative"`
+	StageLabel        string                 `json:"stageLabel" example:"Vejetatif gelişme"`
+	PlantedOn         string                 `json:"plantedOn,omitempty"`
+	DaysSincePlanting *int                   `json:"daysSincePlanting,omitempty"`
+	Weather           *RecommendationWeather `json:"weather,omitempty"`
+	Recommendations   []LandRecommendation   `json:"recommendations"`
+}
+
+// RecommendationWeather önerilerde kullanılan son günlerin hava özeti
+type RecommendationWeather struct {
+	Days        int      `json:"days"`
+	Rainfall    float64  `json:"rainfall"`
+	AvgTemp     *float64 `json:"avgTemp"`
+	MaxTemp     *float64 `json:"maxTemp"`
+	AvgHumidity *float64 `json:"avgHumidity"`
+}
+
+// LandRecommendation önerilen aktivite; action tek dokunuşla takvime etkinlik ekler
+type LandRecommendation struct {
+	Key           string `json:"key" example:"irrigate"`
+	ActivityType  string `json:"activityType" example:"irrigation"`
+	Title         string `json:"title"`
+	Reason        string `json:"reason"`
+	Priority      string `json:"priority" example:"high"`
+	SuggestedDate string `json:"suggestedDate" example:"2026-10-16"`
+	LastDoneOn    string `json:"lastDoneOn,omitempty"`
+	Action        Action `json:"action"`
+}
+
+// ScheduleRecommendationRequest önerilen aktiviteyi takvime ekleme isteği
+type ScheduleRecommendationRequest struct {
+	ActivityType string `json:"activityType" binding:"required" example:"irrigation"`
+	Date         string `json:"date,omitempty" example:"2026-10-16"`
+	Title        string `json:"title,omitempty"`
+	Priority     string `json:"priority,omitempty" binding:"omitempty,oneof=low medium high"`
+	Notes        string `json:"notes,omitempty"`
+}
+
+// Sulama yöntemleri; arazinin serbest metin irrigation_type değerinden belirlenir
+const (
+	IrrigationMethodDrip      = "drip"
+	IrrigationMethodSprinkler = "sprinkler"
+	IrrigationMethodSurface   = "surface"
+	IrrigationMethodOther     = "other"
+)
+
+// Toprak sınıfları; arazinin serbest metin soil_type değerinden belirlenir
+const (
+	SoilClassSandy = "sandy"
+	SoilClassLoam  = "loam"
+	SoilClassClay  = "clay"
+)
+
+// IrrigationSchedule arazinin hava tahminine göre hesaplanan sulama takvimi. Kök bölgesindeki su açığı
+// (depletion) her gün bitki su tüketimi kadar artar, etkili yağış kadar azalır; açık toprağın kolay
+// alınabilir su kapasitesine ulaştığı gün sulama planlanır. Değerler mm su yüksekliğidir
+type IrrigationSchedule struct {
+	LandID                string          `json:"landId"`
+	LandName              string          `json:"landName"`
+	Crop                  string          `json:"crop"`
+	Stage                 string          `json:"stage" example:"vegetative"`
+	CropCoefficient       float64         `json:"cropCoefficient" example:"0.8"`
+	SoilType              string          `json:"soilType"`
+	SoilClass             string          `json:"soilClass" example:"loam"`
+	IrrigationType        string          `json:"irrigationType"`
+	IrrigationMethod      string          `json:"irrigationMethod" example:"drip"`
+	Efficiency            float64         `json:"efficiency" example:"0.9"`
+	ReadilyAvailableWater float64         `json:"readilyAvailableWater" example:"40"`
+	Threshold             float64         `json:"threshold" example:"20"`
+	AreaM2                float64         `json:"areaM2"`
+	LastIrrigatedOn       string          `json:"lastIrrigatedOn,omitempty"`
+	InitialDepletion      float64         `json:"initialDepletion"`
+	ForecastSource        string          `json:"forecastSource" example:"provider"`
+	Assumptions           []string        `json:"assumptions"`
+	NextIrrigation        *IrrigationDay  `json:"nextIrrigation,omitempty"`
+	Days                  []IrrigationDay `json:"days"`
+}
+
+// IrrigationDay sulama takviminin bir günü; irrigate true ise amount brüt sulama yüksekliği (mm), volume
+// arazi alanı için gereken su hacmidir (m³). action sulamayı takvime ekler
+type IrrigationDay struct {
+	Date               string  `json:"date" example:"2026-10-18"`
+	MinTemp            float64 `json:"minTemp"`
+	MaxTemp            float64 `json:"maxTemp"`
+	Rainfall           float64 `json:"rainfall"`
+	RainChance         float64 `json:"rainChance"`
+	Evapotranspiration float64 `json:"evapotranspiration"`
+	EffectiveRainfall  float64 `json:"effectiveRainfall"`
+	Depletion          float64 `json:"depletion"`
+	Irrigate           bool    `json:"irrigate"`
+	Amount             float64 `json:"amount,omitempty"`
+	Volume             float64 `json:"volume,omitempty"`
+	Reason             string  `json:"reason,omitempty"`
+	Action             *Action `json:"action,omitempty"`
+}
+
+// FeatureFlag özellik bayrağı durumu
+type FeatureFlag struct {
+	Key             string `json:"key"`
+	Description     string `json:"description"`
+	Enabled         bool   `json:"enabled"`
+	Deprecated      bool   `json:"deprecated"`
+	DeprecationNote string `json:"deprecationNote,omitempty"`
+	Source          string `json:"source"`
+}
+
+// Kullanıcı rolleri
+const (
+	RoleFarmer           = "farmer"
+	RoleCooperativeAdmin = "cooperative_admin"
+	RoleVeterinarian     = "veterinarian"
+	RoleAdmin            = "admin"
+	// RoleDemo verileri her gece örnek verilerle sıfırlanan, gerçek bildirim gönderemeyen sandbox hesabı
+	RoleDemo = "demo"
+)
+
+// CooperativeMembership kooperatif üyelik ve veri paylaşım onayı
+type CooperativeMembership struct {
+	ID              string     `json:"id" db:"id"`
+	CooperativeID   string     `json:"cooperativeId" db:"cooperative_admin_id"`
+	CooperativeName string     `json:"cooperativeName,omitempty" db:"-"`
+	MemberID        string     `json:"memberId" db:"member_user_id"`
+	MemberName      string     `json:"memberName" db:"-"`
+	MemberEmail     string     `json:"memberEmail" db:"-"`
+	FarmName        string     `json:"farmName" db:"-"`
+	ConsentStatus   string     `json:"consentStatus" db:"consent_status"`
+	ConsentedAt     *time.Time `json:"consentedAt" db:"consented_at"`
+	CreatedAt       time.Time  `json:"createdAt" db:"created_at"`
+}
+
+// Muhasebeci erişim durumları
+const (
+	AccountantAccessActive  = "active"
+	AccountantAccessExpired = "expired"
+	AccountantAccessRevoked = "revoked"
+)
+
+// AccountantAccess çiftliğin dış muhasebeciye verdiği salt okunur erişim; muhasebeci kendi hesabıyla X-Farm-ID
+// başlığında çiftliği seçerek yalnızca finans uç noktalarını okuyabilir ve raporları indirebilir
+type AccountantAccess struct {
+	ID              string     `json:"id" db:"id"`
+	FarmID          string     `json:"farmId" db:"user_id"`
+	FarmName        string     `json:"farmName,omitempty" db:"-"`
+	AccountantID    string     `json:"accountantId" db:"accountant_id"`
+	AccountantName  string     `json:"accountantName" db:"-"`
+	AccountantEmail string     `json:"accountantEmail" db:"-"`
+	Note            string     `json:"note" db:"note"`
+	Status          string     `json:"status" db:"-" enums:"active,expired,revoked"`
+	ExpiresAt       *string    `json:"expiresAt" db:"expires_at"`
+	LastAccessAt    *time.Time `json:"lastAccessAt" db:"last_access_at"`
+	RevokedAt       *time.Time `json:"revokedAt" db:"revoked_at"`
+	CreatedAt       time.Time  `json:"createdAt" db:"created_at"`
+}
+
+// AccountantInviteRequest muhasebeci davet isteği; expiresAt (YYYY-MM-DD) verilirse erişim o gün sonunda biter
+type AccountantInviteRequest struct {
+	Email     string `json:"email" binding:"required,email"`
+	ExpiresAt string `json:"expiresAt" binding:"omitempty,datetime=2006-01-02"`
+	Note      string `json:"note"`
+}
+
+// AccountantAccessLog muhasebecinin çiftlik verisine yaptığı tek bir istek
+type AccountantAccessLog struct {
+	ID             string    `json:"id" db:"id"`
+	AccessID       string    `json:"accessId" db:"access_id"`
+	AccountantID   string    `json:"accountantId" db:"accountant_id"`
+	AccountantName string    `json:"accountantName" db:"-"`
+	Method         string    `json:"method" db:"method"`
+	Path           string    `json:"path" db:"path"`
+	StatusCode     int       `json:"statusCode" db:"status_code"`
+	IPAddress      string    `json:"ipAddress" db:"ip_address"`
+	CreatedAt      time.Time `json:"createdAt" db:"created_at"`
+}
+
+// AccountantAccessLogPage muhasebeci erişim günlüğünün bir sayfası
+type AccountantAccessLogPage struct {
+	Entries    []AccountantAccessLog `json:"entries"`
+	Pagination Pagination            `json:"pagination"`
+}
+
+// CooperativeFarmSummary kooperatif üyesi çiftlik özeti
+type CooperativeFarmSummary struct {
+	MemberID         string             `json:"memberId"`
+	MemberName       string             `json:"memberName"`
+	FarmName         string             `json:"farmName"`
+	Location         string             `json:"location"`
+	HerdCount        int                `json:"herdCount"`
+	AnimalsByType    map[string]int     `json:"animalsByType"`
+	ProductionVolume map[string]float64 `json:"productionVolume"`
+	SickAnimals      int                `json:"sickAnimals"`
+	OpenAlerts       int                `json:"openAlerts"`
+}
+
+// CooperativeSummary kooperatif toplu dashboard verisi
+type CooperativeSummary struct {
+	MemberCount      int                      `json:"memberCount"`
+	PendingConsents  int                      `json:"pendingConsents"`
+	TotalHerd        int                      `json:"totalHerd"`
+	AnimalsByType    map[string]int           `json:"animalsByType"`
+	ProductionVolume map[string]float64       `json:"productionVolume"`
+	TotalOpenAlerts  int                      `json:"totalOpenAlerts"`
+	TotalSickAnimals int                      `json:"totalSickAnimals"`
+	Farms            []CooperativeFarmSummary `json:"farms"`
+}
+
+// LivestockMovement hayvan giriş/çıkış ve nakil kaydı
+type LivestockMovement struct {
+	ID             string     `json:"id" db:"id"`
+	LivestockID    string     `json:"livestockId" db:"livestock_id"`
+	MovementType   string     `json:"movementType" db:"movement_type" binding:"required"`
+	MovementDate   *time.Time `json:"movementDate" db:"movement_date" binding:"required"`
+	FromLocation   string     `json:"fromLocation" db:"from_location"`
+	ToLocation     string     `json:"toLocation" db:"to_location"`
+	PremisesNumber string     `json:"premisesNumber" db:"premises_number"`
+	Reason         string     `json:"reason" db:"reason"`
+	Notes          string     `json:"notes" db:"notes"`
+	CreatedAt      time.Time  `json:"createdAt" db:"created_at"`
+}
+
+// LivestockMovementReportRow hareket raporundaki tek satır; hareket kaydı hayvanın küpe numarası ve türüyle
+type LivestockMovementReportRow struct {
+	LivestockMovement
+	TagNumber  string `json:"tagNumber"`
+	AnimalType string `json:"animalType"`
+}
+
+// LivestockMovementReport izlenebilirlik denetimleri için dönem ve konuma göre hayvan hareketleri
+type LivestockMovementReport struct {
+	StartDate string                       `json:"startDate,omitempty" example:"2026-01-01"`
+	EndDate   string                       `json:"endDate,omitempty" example:"2026-12-31"`
+	Location  string                       `json:"location,omitempty"`
+	Total     int                          `json:"total"`
+	ByType    map[string]int               `json:"byType"`
+	Movements []LivestockMovementReportRow `json:"movements"`
+}
+
+// LivestockLocationOccupancy bir konumdaki (ahır, bölme, mera) sürüde bulunan hayvan sayıları
+type LivestockLocationOccupancy struct {
+	Location    string         `json:"location"`
+	Animals     int            `json:"animals"`
+	ByType      map[string]int `json:"byType"`
+	LastArrival *time.Time     `json:"lastArrival"`
+}
+
+// RegistryAnimal resmi hayvan kayıt sistemi formatındaki hayvan kaydı
+type RegistryAnimal struct {
+	TagNumber      string              `json:"tagNumber"`
+	Species        string              `json:"species"`
+	Breed          string              `json:"breed"`
+	Gender         string              `json:"gender"`
+	BirthDate      *time.Time          `json:"birthDate"`
+	MotherTag      string              `json:"motherTag"`
+	FatherTag      string              `json:"fatherTag"`
+	PremisesNumber string              `json:"premisesNumber"`
+	Movements      []LivestockMovement `json:"movements"`
+}
+
+// Resmi kayıt içe aktarım modları
+const (
+	RegistryImportModeCreate = "create"
+	RegistryImportModeUpdate = "update"
+	RegistryImportModeFlag   = "flag"
+)
+
+// RegistryFieldChange kayıt dosyası ile mevcut hayvan arasındaki alan farkı
+type RegistryFieldChange struct {
+	Field    string `json:"field"`
+	Current  string `json:"current"`
+	Incoming string `json:"incoming"`
+}
+
+// RegistryImportItem içe aktarım önizlemesindeki tek hayvan
+type RegistryImportItem struct {
+	TagNumber    string                `json:"tagNumber"`
+	LivestockID  string                `json:"livestockId,omitempty"`
+	Action       string                `json:"action"`
+	Changes      []RegistryFieldChange `json:"changes,omitempty"`
+	NewMovements int                   `json:"newMovements"`
+	Message      string                `json:"message,omitempty"`
+}
+
+// RegistryImportResult içe aktarım önizleme/uygulama sonucu
+type RegistryImportResult struct {
+	Mode      string               `json:"mode"`
+	Committed bool                 `json:"committed"`
+	Summary   map[string]int       `json:"summary"`
+	Items     []RegistryImportItem `json:"items"`
+}
+
+// Şablon hedef kayıt türleri
+const (
+	TemplateTargetMilk         = "milk"
+	TemplateTargetLandActivity = "land_activity"
+	TemplateTargetHealth       = "health"
+	TemplateTargetTransaction  = "transaction"
+)
+
+// ActivityTemplate sık kullanılan kayıtlar için kullanıcı şablonu
+type ActivityTemplate struct {
+	ID         string                 `json:"id" db:"id"`
+	UserID     string                 `json:"userId" db:"user_id"`
+	Name       string                 `json:"name" db:"name" binding:"required"`
+	TargetType string                 `json:"targetType" db:"target_type" binding:"required"`
+	Fields     map[string]interface{} `json:"fields" db:"fields"`
+	UseCount   int                    `json:"useCount" db:"use_count"`
+	LastUsedAt *time.Time             `json:"lastUsedAt" db:"last_used_at"`
+	CreatedAt  time.Time              `json:"createdAt" db:"created_at"`
+	UpdatedAt  time.Time              `json:"updatedAt" db:"updated_at"`
+}
+
+// QuickLogRequest şablondan hızlı kayıt isteği
+type QuickLogRequest struct {
+	TemplateID string                 `json:"templateId" binding:"required"`
+	Overrides  map[string]interface{} `json:"overrides"`
+}
+
+// QuickLogResult şablondan oluşturulan kayıt
+type QuickLogResult struct {
+	TemplateID string                 `json:"templateId"`
+	TargetType string                 `json:"targetType"`
+	RecordID   string                 `json:"recordId"`
+	Fields     map[string]interface{} `json:"fields"`
+}
+
+// Transkripsiyon durumları
+const (
+	TranscriptStatusNone      = "none"
+	TranscriptStatusPending   = "pending"
+	TranscriptStatusCompleted = "completed"
+	TranscriptStatusFailed    = "failed"
+)
+
+// MediaAttachment hayvan, arazi veya aktiviteye eklenmiş medya dosyası
+type MediaAttachment struct {
+	ID               string    `json:"id" db:"id"`
+	UserID           string    `json:"userId" db:"user_id"`
+	EntityType       string    `json:"entityType" db:"entity_type"`
+	EntityID         string    `json:"entityId" db:"entity_id"`
+	Kind             string    `json:"kind" db:"kind"`
+	Filename         string    `json:"filename" db:"filename"`
+	ContentType      string    `json:"contentType" db:"content_type"`
+	Size             int64     `json:"size" db:"size"`
+	DurationSeconds  *float64  `json:"durationSeconds" db:"duration_seconds"`
+	Transcript       string    `json:"transcript" db:"transcript"`
+	TranscriptStatus string    `json:"transcriptStatus" db:"transcript_status"`
+	CreatedAt        time.Time `json:"createdAt" db:"created_at"`
+}
+
+// SearchResult genel arama sonucu
+type SearchResult struct {
+	EntityType string `json:"entityType"`
+	EntityID   string `json:"entityId"`
+	Title      string `json:"title"`
+	Snippet    string `json:"snippet"`
+}
+
+// Kategori alanları
+const (
+	CategoryDomainLivestock  = "livestock"
+	CategoryDomainProduction = "production"
+)
+
+// Category ikon ve renk bilgisiyle kategori tanımı
+type Category struct {
+	ID        string    `json:"id" db:"id"`
+	Domain    string    `json:"domain" db:"domain" binding:"required"`
+	Key       string    `json:"key" db:"key" binding:"required"`
+	Label     string    `json:"label" db:"label" binding:"required"`
+	Icon      string    `json:"icon" db:"icon"`
+	Color     string    `json:"color" db:"color"`
+	SortOrder int       `json:"sortOrder" db:"sort_order"`
+	IsSystem  bool      `json:"isSystem" db:"-"`
+	CreatedAt time.Time `json:"createdAt" db:"created_at"`
+	UpdatedAt time.Time `json:"updatedAt" db:"updated_at"`
+}
+
+// SavedView kullanıcının liste ekranları için kaydettiği filtre ve sıralama ayarı
+type SavedView struct {
+	ID        string            `json:"id" db:"id"`
+	UserID    string            `json:"userId" db:"user_id"`
+	Resource  string            `json:"resource" db:"resource" binding:"required"`
+	Name      string            `json:"name" db:"name" binding:"required"`
+	Filters   map[string]string `json:"filters" db:"filters"`
+	SortBy    string            `json:"sortBy" db:"sort_by"`
+	SortOrder string            `json:"sortOrder" db:"sort_order"`
+	IsDefault bool              `json:"isDefault" db:"is_default"`
+	CreatedAt time.Time         `json:"createdAt" db:"created_at"`
+	UpdatedAt time.Time         `json:"updatedAt" db:"updated_at"`
+}
+
+// LivestockStatistics hayvancılık istatistikleri
+type LivestockStatistics struct {
+	TotalAnimals        int                   `json:"totalAnimals"`
+	AnimalsByType       map[string]int        `json:"animalsByType"`
+	HealthStatistics    LivestockHealthCounts `json:"healthStatistics"`
+	DailyMilkProduction float64               `json:"dailyMilkProduction"`
+	VaccinationRate     float64               `json:"vaccinationRate"`
+}
+
+// LivestockSpecies hayvan türü (livestock alanındaki kategori) ve tanımlı ırkları
+type LivestockSpecies struct {
+	Key      string           `json:"key"`
+	Label    string           `json:"label"`
+	Icon     string           `json:"icon"`
+	Color    string           `json:"color"`
+	IsSystem bool             `json:"isSystem"`
+	Breeds   []LivestockBreed `json:"breeds"`
+}
+
+// LivestockBreed hayvan ırkı; sistem ırkları tüm çiftliklerde, çiftlik ırkları yalnızca tanımlayan çiftlikte görünür
+type LivestockBreed struct {
+	ID        string    `json:"id" db:"id"`
+	Species   string    `json:"species" db:"species"`
+	Name      string    `json:"name" db:"name"`
+	IsSystem  bool      `json:"isSystem" db:"-"`
+	CreatedAt time.Time `json:"createdAt" db:"created_at"`
+}
+
+// LivestockBreedRequest ırk ekleme isteği
+type LivestockBreedRequest struct {
+	Species string `json:"species" binding:"required" example:"cattle"`
+	Name    string `json:"name" binding:"required" example:"Brown Swiss"`
+}
+
+// LivestockSpeciesRequest sistem hayvan türü ekleme isteği
+type LivestockSpeciesRequest struct {
+	Key       string `json:"key" binding:"required" example:"buffalo"`
+	Label     string `json:"label" binding:"required" example:"Manda"`
+	Icon      string `json:"icon"`
+	Color     string `json:"color"`
+	SortOrder int    `json:"sortOrder"`
+}
+
+// LivestockHealthCounts sağlık durumu bazında hayvan sayıları
+type LivestockHealthCounts struct {
+	Healthy           int `json:"healthy"`
+	Sick              int `json:"sick"`
+	Pregnant          int `json:"pregnant"`
+	VaccinationNeeded int `json:"vaccination_needed"`
+}
+
+// LandStatistics arazi istatistikleri
+type LandStatistics struct {
+	TotalArea           float64          `json:"totalArea"`
+	TotalLands          int              `json:"totalLands"`
+	AverageProductivity float64          `json:"averageProductivity"`
+	ActiveCrops         int              `json:"activeCrops"`
+	LandsByStatus       LandStatusCounts `json:"landsByStatus"`
+}
+
+// LandStatusCounts durum bazında arazi sayıları
+type LandStatusCounts struct {
+	Active      int `json:"active"`
+	Inactive    int `json:"inactive"`
+	Maintenance int `json:"maintenance"`
+}
+
+// ProductionStatistics üretim istatistikleri
+type ProductionStatistics struct {
+	ActiveProducts      int                           `json:"activeProducts"`
+	TotalProduction     float64                       `json:"totalProduction"`
+	AverageProductivity float64                       `json:"averageProductivity"`
+	QualityDistribution QualityDistribution           `json:"qualityDistribution"`
+	CategoryBreakdown   []ProductionCategoryBreakdown `json:"categoryBreakdown"`
+	SalesRevenue        float64                       `json:"salesRevenue"`
+	MarginPercent       *float64                      `json:"marginPercent"`
+	MarginTrend         []ProductionMarginPoint       `json:"marginTrend"`
+}
+
+// ProductionMarginPoint aylık satış geliri, birim maliyeti bilinen satışların ve kayıpların maliyeti ile marjı
+type ProductionMarginPoint struct {
+	Period        string   `json:"period"`
+	Revenue       float64  `json:"revenue"`
+	Cost          float64  `json:"cost"`
+	LossCost      float64  `json:"lossCost"`
+	MarginPercent *float64 `json:"marginPercent"`
+}
+
+// QualityDistribution kalite sınıfı bazında ürün sayıları
+type QualityDistribution struct {
+	APlus int `json:"A+"`
+	A     int `json:"A"`
+	B     int `json:"B"`
+	C     int `json:"C"`
+}
+
+// ProductionCategoryBreakdown kategori bazında üretim dağılımı
+type ProductionCategoryBreakdown struct {
+	Name       string  `json:"name"`
+	Count      int     `json:"count"`
+	Amount     float64 `json:"amount"`
+	Percentage float64 `json:"percentage"`
+}
+
+// CalendarStatistics takvim istatistikleri
+type CalendarStatistics struct {
+	TotalEvents     int              `json:"totalEvents"`
+	CompletedEvents int              `json:"completedEvents"`
+	PendingEvents   int              `json:"pendingEvents"`
+	TodayEvents     int              `json:"todayEvents"`
+	UpcomingEvents  int              `json:"upcomingEvents"`
+	EventsByType    []EventTypeCount `json:"eventsByType"`
+}
+
+// CalendarHeatmapDay ısı haritasında bir günün iş yoğunluğu
+type CalendarHeatmapDay struct {
+	Date       string `json:"date" example:"2026-04-15"`
+	Events     int    `json:"events"`
+	Activities int    `json:"activities"`
+	Records    int    `json:"records"`
+	Total      int    `json:"total"`
+	Level      int    `json:"level" example:"2"`
+}
+
+// CalendarHeatmap yılın her günü için etkinlik, aktivite ve kayıt sayıları
+type CalendarHeatmap struct {
+	Year            int                  `json:"year" example:"2026"`
+	TotalEvents     int                  `json:"totalEvents"`
+	TotalActivities int                  `json:"totalActivities"`
+	TotalRecords    int                  `json:"totalRecords"`
+	ActiveDays      int                  `json:"activeDays"`
+	MaxTotal        int                  `json:"maxTotal"`
+	LongestStreak   int                  `json:"longestStreak"`
+	Days            []CalendarHeatmapDay `json:"days"`
+}
+
+// EventTypeCount tür bazında etkinlik sayısı
+type EventTypeCount struct {
+	Type  string `json:"type"`
+	Count int    `json:"count"`
+}
+
+// Hava gözlemi kaynakları; station arazinin eşleştirildiği hava istasyonunun ölçümleridir, merged aynı gün için
+// birden fazla kaynağın ölçümünün birleştirildiğini belirtir
+const (
+	WeatherSourceProvider = "provider"
+	WeatherSourceManual   = "manual"
+	WeatherSourceStation  = "station"
+	WeatherSourceMerged   = "merged"
+)
+
+// DefaultWeatherSources arazi için tercih sırası belirlenmemişse kullanılan sıra; kullanıcının elle girdiği
+// gözlemler her zaman önce gelir
+var DefaultWeatherSources = []string{WeatherSourceStation, WeatherSourceProvider}
+
+// Hava istasyonu türleri; own kullanıcının kendi istasyonu, nearby araziye yakın fiziksel bir istasyondur
+// (ör. kooperatif veya belediye istasyonu)
+const (
+	WeatherStationOwn    = "own"
+	WeatherStationNearby = "nearby"
+)
+
+// WeatherObservation arazi konumu için günlük hava gözlemi
+type WeatherObservation struct {
+	ID                 string    `json:"id" db:"id"`
+	LandID             string    `json:"landId" db:"land_id"`
+	Date               string    `json:"date" db:"observed_on"`
+	Source             string    `json:"source" db:"source"`
+	MinTemp            *float64  `json:"minTemp" db:"min_temp"`
+	MaxTemp            *float64  `json:"maxTemp" db:"max_temp"`
+	AvgTemp            *float64  `json:"avgTemp" db:"avg_temp"`
+	Rainfall           *float64  `json:"rainfall" db:"rainfall"`
+	Humidity           *float64  `json:"humidity" db:"humidity"`
+	Notes              string    `json:"notes,omitempty" db:"notes"`
+	CumulativeRainfall float64   `json:"cumulativeRainfall" db:"-"`
+	CreatedAt          time.Time `json:"createdAt" db:"created_at"`
+	UpdatedAt          time.Time `json:"updatedAt" db:"updated_at"`
+}
+
+// WeatherObservationRequest kullanıcının yağış ölçer veya termometre ile girdiği günlük gözlem
+type WeatherObservationRequest struct {
+	Date     string   `json:"date" binding:"required"`
+	Rainfall *float64 `json:"rainfall"`
+	MinTemp  *float64 `json:"minTemp"`
+	MaxTemp  *float64 `json:"maxTemp"`
+	Humidity *float64 `json:"humidity"`
+	Notes    string   `json:"notes"`
+}
+
+// WeatherObservationBulkRequest toplu gözlem girişi
+type WeatherObservationBulkRequest struct {
+	Observations []WeatherObservationRequest `json:"observations" binding:"required,min=1,max=366,dive"`
+}
+
+// WeatherPeriodSummary bir dönemdeki gözlemlerin özeti
+type WeatherPeriodSummary struct {
+	Days            int      `json:"days"`
+	TotalRainfall   float64  `json:"totalRainfall"`
+	RainyDays       int      `json:"rainyDays"`
+	MinTemp         *float64 `json:"minTemp"`
+	MinTempDate     string   `json:"minTempDate,omitempty"`
+	MaxTemp         *float64 `json:"maxTemp"`
+	MaxTempDate     string   `json:"maxTempDate,omitempty"`
+	AvgTemp         *float64 `json:"avgTemp"`
+	FrostDays       int      `json:"frostDays"`
+	LongestDrySpell int      `json:"longestDrySpell"`
+}
+
+// WeatherComparison dönemin geçen yılın aynı dönemiyle karşılaştırması
+type WeatherComparison struct {
+	RainfallDiff          float64  `json:"rainfallDiff"`
+	RainfallChangePercent *float64 `json:"rainfallChangePercent"`
+	AvgTempDiff           *float64 `json:"avgTempDiff"`
+	FrostDaysDiff         int      `json:"frostDaysDiff"`
+}
+
+// WeatherHistory arazi hava geçmişi ve iklim analizi
+type WeatherHistory struct {
+	LandID       string                `json:"landId"`
+	StartDate    string                `json:"startDate"`
+	EndDate      string                `json:"endDate"`
+	Observations []WeatherObservation  `json:"observations"`
+	Summary      WeatherPeriodSummary  `json:"summary"`
+	LastYear     *WeatherPeriodSummary `json:"lastYear"`
+	Comparison   *WeatherComparison    `json:"comparison"`
+}
+
+// WeatherStation çiftliğin hava istasyonu; ölçümler istasyon anahtarıyla POST /sensors/weather-readings ile gönderilir
+type WeatherStation struct {
+	ID            string     `json:"id" db:"id"`
+	Name          string     `json:"name" db:"name"`
+	Type          string     `json:"type" db:"station_type"`
+	Latitude      *float64   `json:"latitude" db:"latitude"`
+	Longitude     *float64   `json:"longitude" db:"longitude"`
+	Token         string     `json:"token,omitempty" db:"token"`
+	LastReadingAt *time.Time `json:"lastReadingAt" db:"last_reading_at"`
+	PairedLands   int        `json:"pairedLands" db:"-"`
+	DistanceKm    *float64   `json:"distanceKm,omitempty" db:"-"`
+	CreatedAt     time.Time  `json:"createdAt" db:"created_at"`
+	UpdatedAt     time.Time  `json:"updatedAt" db:"updated_at"`
+}
+
+// WeatherStationRequest istasyon ekleme ve güncelleme isteği; type verilmezse own kabul edilir
+type WeatherStationRequest struct {
+	Name      string   `json:"name" binding:"required"`
+	Type      string   `json:"type" binding:"omitempty,oneof=own nearby"`
+	Latitude  *float64 `json:"latitude" binding:"omitempty,min=-90,max=90"`
+	Longitude *float64 `json:"longitude" binding:"omitempty,min=-180,max=180"`
+}
+
+// WeatherStationReading istasyon ölçümü; rainfall önceki ölçümden bu yana düşen yağıştır (mm)
+type WeatherStationReading struct {
+	ID          string    `json:"id" db:"id"`
+	StationID   string    `json:"stationId" db:"station_id"`
+	RecordedAt  time.Time `json:"recordedAt" db:"recorded_at"`
+	Temperature *float64  `json:"temperature" db:"temperature"`
+	Humidity    *float64  `json:"humidity" db:"humidity"`
+	Rainfall    *float64  `json:"rainfall" db:"rainfall"`
+	CreatedAt   time.Time `json:"createdAt" db:"created_at"`
+}
+
+// Kayıtlı konum bildirimlerinin teslim kanalları
+const (
+	WeatherChannelPush  = "push"
+	WeatherChannelEmail = "email"
+)
+
+// WeatherLocation arazi dışında hava durumu takip edilen kayıtlı konum (ör. başka ildeki mera) ve abonelik ayarları
+type WeatherLocation struct {
+	ID              string              `json:"id" db:"id"`
+	Name            string              `json:"name" db:"name"`
+	Latitude        float64             `json:"latitude" db:"latitude"`
+	Longitude       float64             `json:"longitude" db:"longitude"`
+	Notes           string              `json:"notes,omitempty" db:"notes"`
+	Subscription    WeatherSubscription `json:"subscription" db:"-"`
+	LastDeliveredOn *string             `json:"lastDeliveredOn" db:"last_delivered_on"`
+	CreatedAt       time.Time           `json:"createdAt" db:"created_at"`
+	UpdatedAt       time.Time           `json:"updatedAt" db:"updated_at"`
+}
+
+// WeatherSubscription konumun günlük tahmin özeti ve uyarı aboneliği; bildirimler konumun saat diliminde
+// deliveryHour saatinde channels kanallarından gönderilir
+type WeatherSubscription struct {
+	DailySummary bool     `json:"dailySummary"`
+	Alerts       bool     `json:"alerts"`
+	DeliveryHour int      `json:"deliveryHour"`
+	Timezone     string   `json:"timezone"`
+	Channels     []string `json:"channels"`
+}
+
+// WeatherLocationRequest kayıtlı konum ekleme ve güncelleme isteği; abonelik alanları verilmezse günlük özet ve
+// uyarılar saat 07:00'de (Europe/Istanbul) push kanalından gönderilir
+type WeatherLocationRequest struct {
+	Name         string   `json:"name" binding:"required"`
+	Latitude     *float64 `json:"latitude" binding:"required,min=-90,max=90"`
+	Longitude    *float64 `json:"longitude" binding:"required,min=-180,max=180"`
+	Notes        string   `json:"notes"`
+	DailySummary *bool    `json:"dailySummary"`
+	Alerts       *bool    `json:"alerts"`
+	DeliveryHour *int     `json:"deliveryHour" binding:"omitempty,min=0,max=23"`
+	Timezone     string   `json:"timezone"`
+	Channels     []string `json:"channels" binding:"omitempty,dive,oneof=push email"`
+}
+
+// WeatherLocationForecast kayıtlı konumun günlük tahmini ve tahminden üretilen don ve yoğun yağış uyarıları
+type WeatherLocationForecast struct {
+	Location       WeatherLocation     `json:"location"`
+	ForecastSource string              `json:"forecastSource" example:"provider"`
+	Forecast       []WeatherForecast   `json:"forecast"`
+	Alerts         []WeatherDailyAlert `json:"alerts"`
+}
+
+// WeatherDailyAlert tahmindeki günün uyarısı; frost en düşük sıcaklığın, heavy_rain beklenen yağışın eşiği aştığı gündür
+type WeatherDailyAlert struct {
+	Date  string  `json:"date"`
+	Type  string  `json:"type" enums:"frost,heavy_rain"`
+	Value float64 `json:"value"`
+}
+
+// WeatherStationReadingRequest istasyonun gönderdiği ölçüm; en az bir değer gönderilmelidir
+type WeatherStationReadingRequest struct {
+	RecordedAt  *time.Time `json:"recordedAt"`
+	Temperature *float64   `json:"temperature" binding:"omitempty,min=-60,max=60"`
+	Humidity    *float64   `json:"humidity" binding:"omitempty,min=0,max=100"`
+	Rainfall    *float64   `json:"rainfall" binding:"omitempty,min=0,max=500"`
+}
+
+// LandWeatherSources arazinin eşleştirildiği istasyon ve hava verisi kaynaklarının tercih sırası; activeSource
+// şu anda veri sağlayan ilk kaynaktır, nearbyStations çiftliğin istasyonlarını araziye uzaklığa göre sıralar
+type LandWeatherSources struct {
+	LandID         string           `json:"landId"`
+	Station        *WeatherStation  `json:"station"`
+	Sources        []string         `json:"sources"`
+	ActiveSource   string           `json:"activeSource"`
+	NearbyStations []WeatherStation `json:"nearbyStations"`
+}
+
+// LandWeatherSourcesRequest arazi istasyon eşleştirmesi ve kaynak sırası; stationId boş dize ise eşleştirme
+// kaldırılır, gönderilmezse değişmez. sources verilmezse sıra değişmez
+type LandWeatherSourcesRequest struct {
+	StationID *string  `json:"stationId"`
+	Sources   []string `json:"sources" binding:"omitempty,min=1,max=2,unique,dive,oneof=station provider"`
+}
+
+// Bildirim konuları; her konu mobil uygulamadaki aksiyonları belirler
+const (
+	NotificationTopicGeneral               = "general"
+	NotificationTopicWelcome               = "welcome"
+	NotificationTopicVaccinationDue        = "vaccination_due"
+	NotificationTopicHealthAlert           = "health_alert"
+	NotificationTopicEventReminder         = "event_reminder"
+	NotificationTopicWeatherAlert          = "weather_alert"
+	NotificationTopicRegistryMismatch      = "registry_mismatch"
+	NotificationTopicCooperativeInvitation = "cooperative_invitation"
+	NotificationTopicVetVisit              = "vet_visit"
+	NotificationTopicConsumptionSpike      = "consumption_spike"
+	NotificationTopicDocumentExpiry        = "document_expiry"
+	NotificationTopicPaymentOverdue        = "payment_overdue"
+	NotificationTopicTransactionDraft      = "transaction_draft"
+	NotificationTopicGreenhouseClimate     = "greenhouse_climate"
+	NotificationTopicHiveTreatmentDue      = "hive_treatment_due"
+	NotificationTopicNoteMention           = "note_mention"
+	NotificationTopicMetricAnomaly         = "metric_anomaly"
+	NotificationTopicInventoryLow          = "inventory_low"
+	NotificationTopicSupportTicket         = "support_ticket"
+	NotificationTopicBackupFailed          = "backup_failed"
+	NotificationTopicBackupFailedAdmin     = "backup_failed_admin"
+	NotificationTopicWaterQuota            = "water_quota"
+	NotificationTopicReportReady           = "report_ready"
+	NotificationTopicReportFailed          = "report_failed"
+	NotificationTopicWorkerCertification   = "worker_certification"
+	NotificationTopicAccountantAccess      = "accountant_access"
+	NotificationTopicIrrigationDue         = "irrigation_due"
+	NotificationTopicWeatherLocation       = "weather_location"
+)
+
+// NotificationActionTemplate bir bildirim konusunun aksiyon tanımı; route içindeki {id} ilişkili varlık ID'si ile doldurulur
+type NotificationActionTemplate struct {
+	Topic       string   `json:"topic"`
+	EntityType  string   `json:"entityType,omitempty"`
+	Description string   `json:"description"`
+	Actions     []Action `json:"actions"`
+}
+
+// EntityChange varlığın tek bir alanındaki değişiklik; aynı güncellemedeki alanlar aynı changeSetId'yi taşır
+type EntityChange struct {
+	ID          string     `json:"id" db:"id"`
+	ChangeSetID string     `json:"changeSetId" db:"change_set_id"`
+	EntityType  string     `json:"entityType" db:"entity_type"`
+	EntityID    string     `json:"entityId" db:"entity_id"`
+	Field       string     `json:"field" db:"field"`
+	OldValue    string     `json:"oldValue" db:"old_value"`
+	NewValue    string     `json:"newValue" db:"new_value"`
+	ChangedBy   ChangeUser `json:"changedBy" db:"-"`
+	ChangedAt   time.Time  `json:"changedAt" db:"changed_at"`
+}
+
+// ChangeUser değişikliği yapan kullanıcı
+type ChangeUser struct {
+	ID   string `json:"id"`
+	Name string `json:"name"`
+}
+
+// EntityHistory varlığın sayfalı değişiklik geçmişi
+type EntityHistory struct {
+	Changes    []EntityChange `json:"changes"`
+	Pagination Pagination     `json:"pagination"`
+}
+
+// Protokol adım türleri
+const (
+	ProtocolStepVaccination = "vaccination"
+	ProtocolStepTreatment   = "treatment"
+	ProtocolStepDeworming   = "deworming"
+	ProtocolStepCheckup     = "checkup"
+)
+
+// ProtocolStep tedavi protokolünün bir adımı; dayOffset başlangıç tarihinden itibaren gün sayısıdır
+type ProtocolStep struct {
+	DayOffset   int    `json:"dayOffset" binding:"min=0,max=1825"`
+	Type        string `json:"type" binding:"required,oneof=vaccination treatment deworming checkup"`
+	Description string `json:"description" binding:"required"`
+	Notes       string `json:"notes"`
+}
+
+// TreatmentProtocol standart tedavi veya aşılama protokolü
+type TreatmentProtocol struct {
+	ID          string         `json:"id" db:"id"`
+	Name        string         `json:"name" db:"name" binding:"required"`
+	Species     string         `json:"species" db:"species"`
+	Description string         `json:"description" db:"description"`
+	Steps       []ProtocolStep `json:"steps" db:"steps" binding:"required,min=1,max=50,dive"`
+	IsSystem    bool           `json:"isSystem" db:"-"`
+	CreatedAt   time.Time      `json:"createdAt" db:"created_at"`
+	UpdatedAt   time.Time      `json:"updatedAt" db:"updated_at"`
+}
+
+// ProtocolApplyRequest protokolü hayvanlara veya bir türün tüm hayvanlarına uygulama isteği
+type ProtocolApplyRequest struct {
+	AnimalIDs    []string `json:"animalIds"`
+	Species      string   `json:"species"`
+	StartDate    string   `json:"startDate" binding:"required"`
+	Veterinarian string   `json:"veterinarian"`
+}
+
+// ProtocolScheduleItem protokol uygulamasında oluşturulan bir adımın tarihi
+type ProtocolScheduleItem struct {
+	DayOffset   int    `json:"dayOffset"`
+	Date        string `json:"date"`
+	Type        string `json:"type"`
+	Description string `json:"description"`
+	EventID     string `json:"eventId,omitempty"`
+}
+
+// ProtocolApplication protokol uygulamasının sonucu
+type ProtocolApplication struct {
+	ProtocolID    string                 `json:"protocolId"`
+	AnimalIDs     []string               `json:"animalIds"`
+	HealthRecords int                    `json:"healthRecords"`
+	Events        int                    `json:"events"`
+	Schedule      []ProtocolScheduleItem `json:"schedule"`
+}
+
+// Veteriner ziyareti durumları
+const (
+	VetVisitRequested = "requested"
+	VetVisitConfirmed = "confirmed"
+	VetVisitDeclined  = "declined"
+	VetVisitCancelled = "cancelled"
+	VetVisitCompleted = "completed"
+)
+
+// VeterinarianLink çiftçinin bağlı olduğu veteriner hesabı
+type VeterinarianLink struct {
+	ID             string    `json:"id" db:"id"`
+	VeterinarianID string    `json:"veterinarianId" db:"veterinarian_id"`
+	Name           string    `json:"name" db:"-"`
+	Email          string    `json:"email" db:"-"`
+	CreatedAt      time.Time `json:"createdAt" db:"created_at"`
+}
+
+// VetVisitSlot ziyaret için zaman aralığı
+type VetVisitSlot struct {
+	Start time.Time `json:"start" binding:"required"`
+	End   time.Time `json:"end" binding:"required"`
+}
+
+// VetVisit veteriner ziyaret talebi ve sonucu
+type VetVisit struct {
+	ID               string         `json:"id" db:"id"`
+	FarmerID         string         `json:"farmerId" db:"farmer_id"`
+	FarmerName       string         `json:"farmerName" db:"-"`
+	FarmName         string         `json:"farmName" db:"-"`
+	VeterinarianID   string         `json:"veterinarianId" db:"veterinarian_id"`
+	VeterinarianName string         `json:"veterinarianName" db:"-"`
+	Reason           string         `json:"reason" db:"reason"`
+	LivestockIDs     []string       `json:"livestockIds" db:"livestock_ids"`
+	PreferredSlots   []VetVisitSlot `json:"preferredSlots" db:"preferred_slots"`
+	Status           string         `json:"status" db:"status"`
+	ScheduledStart   *time.Time     `json:"scheduledStart" db:"scheduled_start"`
+	ScheduledEnd     *time.Time     `json:"scheduledEnd" db:"scheduled_end"`
+	ResponseNote     string         `json:"responseNote" db:"response_note"`
+	Findings         string         `json:"findings" db:"findings"`
+	HealthRecordIDs  []string       `json:"healthRecordIds" db:"health_record_ids"`
+	CreatedAt        time.Time      `json:"createdAt" db:"created_at"`
+	UpdatedAt        time.Time      `json:"updatedAt" db:"updated_at"`
+}
+
+// VetVisitRequest ziyaret talebi; tercih edilen zaman aralıkları veterinerin seçimi için sunulur
+type VetVisitRequest struct {
+	VeterinarianID string         `json:"veterinarianId" binding:"required"`
+	Reason         string         `json:"reason" binding:"required"`
+	LivestockIDs   []string       `json:"livestockIds"`
+	PreferredSlots []VetVisitSlot `json:"preferredSlots" binding:"required,min=1,max=5,dive"`
+}
+
+// VetVisitConfirmRequest veterinerin ziyareti onaylaması; slot tercih edilen aralığın sırası, verilmezse start/end kullanılır
+type VetVisitConfirmRequest struct {
+	Slot  *int       `json:"slot"`
+	Start *time.Time `json:"start"`
+	End   *time.Time `json:"end"`
+	Note  string     `json:"note"`
+}
+
+// VetVisitCompleteRequest tamamlanan ziyaretin hayvanlara işlenecek sağlık kaydı bilgileri
+type VetVisitCompleteRequest struct {
+	Type        string   `json:"type" binding:"required,oneof=vaccination treatment deworming checkup"`
+	Description string   `json:"description" binding:"required"`
+	Findings    string   `json:"findings"`
+	Cost        *float64 `json:"cost"`
+	NextCheckup string   `json:"nextCheckup"`
+}
+
+// SlaughterRecord hayvanın kesim ile çıkışında karkas ve verim kaydı
+type SlaughterRecord struct {
+	ID            string     `json:"id" db:"id"`
+	LivestockID   string     `json:"livestockId" db:"livestock_id"`
+	TagNumber     string     `json:"tagNumber" db:"-"`
+	Type          string     `json:"type" db:"-"`
+	Breed         string     `json:"breed" db:"-"`
+	SlaughterDate *time.Time `json:"slaughterDate" db:"slaughter_date" binding:"required"`
+	LiveWeight    float64    `json:"liveWeight" db:"live_weight" binding:"min=0"`
+	CarcassWeight float64    `json:"carcassWeight" db:"carcass_weight" binding:"required,gt=0"`
+	Grade         string     `json:"grade" db:"grade"`
+	Buyer         string     `json:"buyer" db:"buyer"`
+	Price         float64    `json:"price" db:"price" binding:"min=0"`
+	Currency      string     `json:"currency" db:"currency"`
+	YieldPercent  float64    `json:"yieldPercent" db:"-"`
+	PricePerKg    float64    `json:"pricePerKg" db:"-"`
+	MovementID    string     `json:"movementId" db:"movement_id"`
+	TransactionID string     `json:"transactionId" db:"transaction_id"`
+	Notes         string     `json:"notes" db:"notes"`
+	CreatedAt     time.Time  `json:"createdAt" db:"created_at"`
+}
+
+// BreedYield tür ve ırk bazında karkas verimi
+type BreedYield struct {
+	Type             string         `json:"type"`
+	Breed            string         `json:"breed"`
+	Count            int            `json:"count"`
+	AvgLiveWeight    float64        `json:"avgLiveWeight"`
+	AvgCarcassWeight float64        `json:"avgCarcassWeight"`
+	AvgYieldPercent  float64        `json:"avgYieldPercent"`
+	MinYieldPercent  float64        `json:"minYieldPercent"`
+	MaxYieldPercent  float64        `json:"maxYieldPercent"`
+	TotalRevenue     float64        `json:"totalRevenue"`
+	AvgPricePerKg    float64        `json:"avgPricePerKg"`
+	Grades           map[string]int `json:"grades"`
+}
+
+// SlaughterYieldAnalytics kesim verimi analizi
+type SlaughterYieldAnalytics struct {
+	TotalAnimals    int          `json:"totalAnimals"`
+	TotalCarcassKg  float64      `json:"totalCarcassKg"`
+	TotalRevenue    float64      `json:"totalRevenue"`
+	AvgYieldPercent float64      `json:"avgYieldPercent"`
+	Breeds          []BreedYield `json:"breeds"`
+}
+
+// Hayvan edinme türleri
+const (
+	AcquisitionBorn      = "born"
+	AcquisitionPurchased = "purchased"
+	AcquisitionGift      = "gift"
+	AcquisitionOther     = "other"
+)
+
+// AnimalAcquisition hayvanın çiftliğe nasıl geldiği; satın alındıysa fiyat ve satıcı bilgisiyle
+type AnimalAcquisition struct {
+	Type          string     `json:"type" binding:"required,oneof=born purchased gift other"`
+	Date          *time.Time `json:"date"`
+	Price         float64    `json:"price" binding:"min=0"`
+	Currency      string     `json:"currency"`
+	Seller        string     `json:"seller"`
+	RecordExpense bool       `json:"recordExpense"`
+	TransactionID string     `json:"transactionId"`
+}
+
+// LivestockCost hayvana doğrudan işlenen yem, sağlık veya diğer maliyet
+type LivestockCost struct {
+	ID            string     `json:"id" db:"id"`
+	LivestockID   string     `json:"livestockId" db:"livestock_id"`
+	Type          string     `json:"type" db:"type" binding:"required,oneof=feed health other"`
+	Amount        float64    `json:"amount" db:"amount" binding:"required,gt=0"`
+	Date          *time.Time `json:"date" db:"date" binding:"required"`
+	Description   string     `json:"description" db:"description"`
+	RecordExpense bool       `json:"recordExpense" db:"-"`
+	TransactionID string     `json:"transactionId" db:"transaction_id"`
+	CreatedAt     time.Time  `json:"createdAt" db:"created_at"`
+}
+
+// AnimalSale hayvan satışı
+type AnimalSale struct {
+	Date     *time.Time `json:"date" binding:"required"`
+	Price    float64    `json:"price" binding:"required,gt=0"`
+	Currency string     `json:"currency"`
+	Buyer    string     `json:"buyer"`
+	Notes    string     `json:"notes"`
+}
+
+// CostBasis hayvanın maliyet esası; sağlık maliyeti sağlık kayıtlarındaki ücretleri, overhead dağıtım
+// kurallarıyla hayvana paylaştırılan ortak giderleri içerir
+type CostBasis struct {
+	Purchase float64 `json:"purchase"`
+	Feed     float64 `json:"feed"`
+	Health   float64 `json:"health"`
+	Other    float64 `json:"other"`
+	Overhead float64 `json:"overhead"`
+	Total    float64 `json:"total"`
+}
+
+// Ortak gider dağıtım hedefleri
+const (
+	AllocationTargetLand      = "land"
+	AllocationTargetLivestock = "livestock"
+)
+
+// Ortak gider dağıtım anahtarları: area arazi alanı, head_count hayvanın dönemde sürüde kaldığı gün sayısı,
+// revenue dönemdeki satış geliri, equal eşit paydır
+const (
+	AllocationBasisArea      = "area"
+	AllocationBasisHeadCount = "head_count"
+	AllocationBasisRevenue   = "revenue"
+	AllocationBasisEqual     = "equal"
+)
+
+// CostAllocationRule ortak giderlerin (sigorta, elektrik, veteriner çağrı ücreti) arazilere veya hayvanlara
+// dağıtım kuralı; categories listesindeki gider işlemleri seçilen anahtara göre paylaştırılır
+type CostAllocationRule struct {
+	ID         string    `json:"id" db:"id"`
+	Name       string    `json:"name" db:"name"`
+	Categories []string  `json:"categories" db:"categories"`
+	TargetType string    `json:"targetType" db:"target_type" enums:"land,livestock"`
+	Basis      string    `json:"basis" db:"basis" enums:"area,head_count,revenue,equal"`
+	AnimalType string    `json:"animalType" db:"animal_type"`
+	CreatedAt  time.Time `json:"createdAt" db:"created_at"`
+	UpdatedAt  time.Time `json:"updatedAt" db:"updated_at"`
+}
+
+// CostAllocationRuleRequest dağıtım kuralı ekleme ve güncelleme isteği; animalType yalnızca hayvan hedefinde
+// dağıtımı bir türle sınırlar
+type CostAllocationRuleRequest struct {
+	Name       string   `json:"name" binding:"required"`
+	Categories []string `json:"categories" binding:"required,min=1,dive,required"`
+	TargetType string   `json:"targetType" binding:"required,oneof=land livestock"`
+	Basis      string   `json:"basis" binding:"required,oneof=area head_count revenue equal"`
+	AnimalType string   `json:"animalType"`
+}
+
+// CostAllocationRunRequest dağıtımın çalıştırılacağı dönem; tarihler YYYY-MM-DD biçimindedir
+type CostAllocationRunRequest struct {
+	StartDate string `json:"startDate" binding:"required,datetime=2006-01-02"`
+	EndDate   string `json:"endDate" binding:"required,datetime=2006-01-02"`
+}
+
+// CostAllocation bir dağıtım çalıştırmasında hedefe düşen pay; share yüzde olarak verilir
+type CostAllocation struct {
+	ID          string    `json:"id" db:"id"`
+	RuleID      string    `json:"ruleId" db:"rule_id"`
+	RuleName    string    `json:"ruleName" db:"-"`
+	PeriodStart string    `json:"periodStart" db:"period_start"`
+	PeriodEnd   string    `json:"periodEnd" db:"period_end"`
+	TargetType  string    `json:"targetType" db:"target_type"`
+	TargetID    string    `json:"targetId" db:"target_id"`
+	TargetName  string    `json:"targetName" db:"-"`
+	BasisValue  float64   `json:"basisValue" db:"basis_value"`
+	Share       float64   `json:"share" db:"share"`
+	Amount      float64   `json:"amount" db:"amount"`
+	CreatedAt   time.Time `json:"createdAt" db:"created_at"`
+}
+
+// CostAllocationRun dağıtım çalıştırmasının sonucu
+type CostAllocationRun struct {
+	RuleID           string           `json:"ruleId"`
+	RuleName         string           `json:"ruleName"`
+	Basis            string           `json:"basis"`
+	PeriodStart      string           `json:"periodStart"`
+	PeriodEnd        string           `json:"periodEnd"`
+	TransactionCount int              `json:"transactionCount"`
+	TotalCost        float64          `json:"totalCost"`
+	Allocations      []CostAllocation `json:"allocations"`
+}
+
+// Faaliyet kolu türleri
+const (
+	EnterpriseTypeDairy          = "dairy"
+	EnterpriseTypeBeef           = "beef"
+	EnterpriseTypeSmallRuminants = "small_ruminants"
+	EnterpriseTypePoultry        = "poultry"
+	EnterpriseTypeCrops          = "crops"
+	EnterpriseTypeHorticulture   = "horticulture"
+	EnterpriseTypeApiculture     = "apiculture"
+	EnterpriseTypeAquaculture    = "aquaculture"
+	EnterpriseTypeOther          = "other"
+)
+
+// Enterprise çiftliğin faaliyet kolu (süt, besi, bitkisel üretim, kanatlı); hayvanlar, araziler, üretim ve
+// finansal işlemler bir faaliyet koluna atanarak kol bazında kâr/zarar izlenir
+type Enterprise struct {
+	ID              string    `json:"id" db:"id"`
+	Name            string    `json:"name" db:"name"`
+	Type            string    `json:"type" db:"type" enums:"dairy,beef,small_ruminants,poultry,crops,horticulture,apiculture,aquaculture,other"`
+	Description     string    `json:"description" db:"description"`
+	LandCount       int       `json:"landCount" db:"-"`
+	LivestockCount  int       `json:"livestockCount" db:"-"`
+	ProductionCount int       `json:"productionCount" db:"-"`
+	CreatedAt       time.Time `json:"createdAt" db:"created_at"`
+	UpdatedAt       time.Time `json:"updatedAt" db:"updated_at"`
+}
+
+// EnterpriseRequest faaliyet kolu ekleme ve güncelleme isteği
+type EnterpriseRequest struct {
+	Name        string `json:"name" binding:"required"`
+	Type        string `json:"type" binding:"required,oneof=dairy beef small_ruminants poultry crops horticulture apiculture aquaculture other"`
+	Description string `json:"description"`
+}
+
+// EnterprisePnL faaliyet kolunun dönem kâr/zararı; allocatedCost ortak gider dağıtımlarından kolun arazi ve
+// hayvanlarına düşen paydır. Faaliyet kolu atanmamış kayıtlar enterpriseId boş satırda toplanır; bu satırda
+// allocatedCost kollara dağıtılan ortak giderlerin atanmamış giderlerden düşülen tutarını eksi olarak gösterir
+type EnterprisePnL struct {
+	EnterpriseID      *string          `json:"enterpriseId"`
+	Name              string           `json:"name"`
+	Type              string           `json:"type"`
+	Income            float64          `json:"income"`
+	Expense           float64          `json:"expense"`
+	AllocatedCost     float64          `json:"allocatedCost"`
+	Profit            float64          `json:"profit"`
+	Margin            float64          `json:"margin"`
+	ShareOfIncome     float64          `json:"shareOfIncome"`
+	IncomeByCategory  []CategoryAmount `json:"incomeByCategory,omitempty"`
+	ExpenseByCategory []CategoryAmount `json:"expenseByCategory,omitempty"`
+}
+
+// EnterprisePnLReport faaliyet kollarının dönem kâr/zarar karşılaştırması; unassigned hiçbir kola
+// bağlanamayan ve dağıtılmamış gelir/giderleri gösterir
+type EnterprisePnLReport struct {
+	StartDate   string          `json:"startDate"`
+	EndDate     string          `json:"endDate"`
+	Enterprises []EnterprisePnL `json:"enterprises"`
+	Unassigned  EnterprisePnL   `json:"unassigned"`
+	TotalIncome float64         `json:"totalIncome"`
+	TotalCost   float64         `json:"totalCost"`
+	TotalProfit float64         `json:"totalProfit"`
+}
+
+// AnimalExit hayvanın satış veya kesimle çıkışı
+type AnimalExit struct {
+	Type    string     `json:"type"`
+	Date    *time.Time `json:"date"`
+	Revenue float64    `json:"revenue"`
+	Buyer   string     `json:"buyer"`
+}
+
+// AnimalProfitability hayvan bazında maliyet ve karlılık
+type AnimalProfitability struct {
+	LivestockID   string            `json:"livestockId"`
+	TagNumber     string            `json:"tagNumber"`
+	Type          string            `json:"type"`
+	Breed         string            `json:"breed"`
+	Acquisition   AnimalAcquisition `json:"acquisition"`
+	CostBasis     CostBasis         `json:"costBasis"`
+	Exit          *AnimalExit       `json:"exit"`
+	Sold          bool              `json:"sold"`
+	Profit        *float64          `json:"profit"`
+	MarginPercent *float64          `json:"marginPercent"`
+	DaysOnFarm    *int              `json:"daysOnFarm"`
+}
+
+// ProfitabilitySummary satılan hayvanların toplam karlılığı
+type ProfitabilitySummary struct {
+	AnimalCount  int                   `json:"animalCount"`
+	TotalCost    float64               `json:"totalCost"`
+	TotalRevenue float64               `json:"totalRevenue"`
+	TotalProfit  float64               `json:"totalProfit"`
+	AvgProfit    float64               `json:"avgProfit"`
+	Animals      []AnimalProfitability `json:"animals"`
+}
+
+// Amortisman yöntemleri
+const (
+	DepreciationStraightLine     = "straight_line"
+	DepreciationDecliningBalance = "declining_balance"
+)
+
+// FixedAsset ekipman, bina veya araç gibi amortismana tabi duran varlık
+type FixedAsset struct {
+	ID                      string     `json:"id" db:"id"`
+	UserID                  string     `json:"userId" db:"user_id"`
+	Name                    string     `json:"name" db:"name" binding:"required"`
+	Category                string     `json:"category" db:"category" binding:"required,oneof=equipment building vehicle other"`
+	PurchaseDate            *time.Time `json:"purchaseDate" db:"purchase_date" binding:"required"`
+	Cost                    float64    `json:"cost" db:"cost" binding:"required,gt=0"`
+	SalvageValue            float64    `json:"salvageValue" db:"salvage_value" binding:"min=0"`
+	UsefulLifeMonths        int        `json:"usefulLifeMonths" db:"useful_life_months" binding:"required,min=1,max=1200"`
+	Method                  string     `json:"method" db:"method" binding:"required,oneof=straight_line declining_balance"`
+	DecliningFactor         float64    `json:"decliningFactor" db:"declining_factor" binding:"min=0,max=5"`
+	Status                  string     `json:"status" db:"status"`
+	DisposedAt              *time.Time `json:"disposedAt" db:"disposed_at"`
+	HourlyRate              *float64   `json:"hourlyRate" db:"hourly_rate" binding:"omitempty,min=0"`
+	Notes                   string     `json:"notes" db:"notes"`
+	AccumulatedDepreciation float64    `json:"accumulatedDepreciation" db:"-"`
+	NetBookValue            float64    `json:"netBookValue" db:"-"`
+	CreatedAt               time.Time  `json:"createdAt" db:"created_at"`
+	UpdatedAt               time.Time  `json:"updatedAt" db:"updated_at"`
+}
+
+// DepreciationEntry amortisman tablosunun bir aylık satırı
+type DepreciationEntry struct {
+	Period      string  `json:"period"`
+	Amount      float64 `json:"amount"`
+	Accumulated float64 `json:"accumulated"`
+	BookValue   float64 `json:"bookValue"`
+	Posted      bool    `json:"posted"`
+}
+
+// DepreciationSchedule varlığın ay ay amortisman tablosu
+type DepreciationSchedule struct {
+	Asset   FixedAsset          `json:"asset"`
+	Entries []DepreciationEntry `json:"entries"`
+}
+
+// DepreciationPostResult bir dönemin amortisman giderlerinin finansa işlenmesi sonucu
+type DepreciationPostResult struct {
+	Period        string   `json:"period"`
+	Posted        int      `json:"posted"`
+	AlreadyPosted int      `json:"alreadyPosted"`
+	TotalAmount   float64  `json:"totalAmount"`
+	Transactions  []string `json:"transactions"`
+}
+
+// FixedAssetCategoryTotal kategori bazında duran varlık toplamları
+type FixedAssetCategoryTotal struct {
+	Category     string  `json:"category"`
+	Count        int     `json:"count"`
+	Cost         float64 `json:"cost"`
+	Accumulated  float64 `json:"accumulated"`
+	NetBookValue float64 `json:"netBookValue"`
+}
+
+// FixedAssetReport belirli bir tarihteki net defter değerleriyle duran varlık raporu
+type FixedAssetReport struct {
+	Date              string                    `json:"date"`
+	TotalCost         float64                   `json:"totalCost"`
+	TotalAccumulated  float64                   `json:"totalAccumulated"`
+	TotalNetBookValue float64                   `json:"totalNetBookValue"`
+	Categories        []FixedAssetCategoryTotal `json:"categories"`
+	Assets            []FixedAsset              `json:"assets"`
+}
+
+// Sayaç okuma biçimleri
+const (
+	MeterReadingCumulative  = "cumulative"
+	MeterReadingConsumption = "consumption"
+)
+
+// UtilityMeter elektrik, su veya yakıt sayacı
+type UtilityMeter struct {
+	ID              string     `json:"id" db:"id"`
+	UserID          string     `json:"userId" db:"user_id"`
+	Name            string     `json:"name" db:"name" binding:"required"`
+	Type            string     `json:"type" db:"type" binding:"required,oneof=electricity water fuel"`
+	Unit            string     `json:"unit" db:"unit"`
+	ReadingMode     string     `json:"readingMode" db:"reading_mode" binding:"omitempty,oneof=cumulative consumption"`
+	Facility        string     `json:"facility" db:"facility"`
+	LandID          *string    `json:"landId" db:"land_id"`
+	UnitPrice       float64    `json:"unitPrice" db:"unit_price" binding:"min=0"`
+	SpikeThreshold  float64    `json:"spikeThreshold" db:"spike_threshold" binding:"min=0"`
+	Notes           string     `json:"notes" db:"notes"`
+	LastValue       *float64   `json:"lastValue" db:"-"`
+	LastReadingDate *time.Time `json:"lastReadingDate" db:"-"`
+	CreatedAt       time.Time  `json:"createdAt" db:"created_at"`
+	UpdatedAt       time.Time  `json:"updatedAt" db:"updated_at"`
+}
+
+// MeterReading sayaç okuması; tüketim önceki okumaya göre hesaplanır
+type MeterReading struct {
+	ID            string     `json:"id" db:"id"`
+	MeterID       string     `json:"meterId" db:"meter_id"`
+	ReadingDate   *time.Time `json:"readingDate" db:"reading_date" binding:"required"`
+	Value         float64    `json:"value" db:"value" binding:"min=0"`
+	Consumption   float64    `json:"consumption" db:"consumption"`
+	Cost          float64    `json:"cost" db:"cost"`
+	DailyAverage  float64    `json:"dailyAverage" db:"daily_average"`
+	Anomaly       bool       `json:"anomaly" db:"anomaly"`
+	TransactionID string     `json:"transactionId" db:"transaction_id"`
+	Notes         string     `json:"notes" db:"notes"`
+	CreatedAt     time.Time  `json:"createdAt" db:"created_at"`
+}
+
+// UtilityMonthlyUsage ay ve sayaç türü bazında tüketim
+type UtilityMonthlyUsage struct {
+	Month       string  `json:"month"`
+	Type        string  `json:"type"`
+	Unit        string  `json:"unit"`
+	Consumption float64 `json:"consumption"`
+	Cost        float64 `json:"cost"`
+	Anomalies   int     `json:"anomalies"`
+}
+
+// UtilityFacilityUsage tesis ve sayaç türü bazında tüketim
+type UtilityFacilityUsage struct {
+	Facility    string  `json:"facility"`
+	Type        string  `json:"type"`
+	Unit        string  `json:"unit"`
+	Consumption float64 `json:"consumption"`
+	Cost        float64 `json:"cost"`
+}
+
+// UtilityAnalytics enerji ve su tüketim analizi
+type UtilityAnalytics struct {
+	Year       int                    `json:"year"`
+	TotalCost  float64                `json:"totalCost"`
+	Months     []UtilityMonthlyUsage  `json:"months"`
+	Facilities []UtilityFacilityUsage `json:"facilities"`
+	Anomalies  []MeterReading         `json:"anomalies"`
+}
+
+// CarbonCoefficient emisyon hesaplamasında kullanılan katsayı (kg CO2e / birim)
+type CarbonCoefficient struct {
+	Source    string  `json:"source"`
+	Key       string  `json:"key"`
+	Unit      string  `json:"unit"`
+	Factor    float64 `json:"factor"`
+	Reference string  `json:"reference"`
+}
+
+// CarbonSource bir emisyon kaynağının dönemlik faaliyet verisi ve emisyonu
+type CarbonSource struct {
+	Source    string  `json:"source"`
+	Key       string  `json:"key"`
+	Activity  float64 `json:"activity"`
+	Unit      string  `json:"unit"`
+	Factor    float64 `json:"factor"`
+	Emissions float64 `json:"emissions"`
+}
+
+// CarbonFootprint aylık tahmini sera gazı emisyonları (kg CO2e)
+type CarbonFootprint struct {
+	Period       string         `json:"period"`
+	Livestock    float64        `json:"livestock"`
+	Fertilizer   float64        `json:"fertilizer"`
+	Fuel         float64        `json:"fuel"`
+	Electricity  float64        `json:"electricity"`
+	Total        float64        `json:"total"`
+	Sources      []CarbonSource `json:"sources"`
+	Stored       bool           `json:"stored"`
+	CalculatedAt time.Time      `json:"calculatedAt"`
+}
+
+// CarbonReport yıllık karbon ayak izi raporu
+type CarbonReport struct {
+	Year         int                 `json:"year"`
+	Livestock    float64             `json:"livestock"`
+	Fertilizer   float64             `json:"fertilizer"`
+	Fuel         float64             `json:"fuel"`
+	Electricity  float64             `json:"electricity"`
+	Total        float64             `json:"total"`
+	Hectares     float64             `json:"hectares"`
+	PerHectare   float64             `json:"perHectare"`
+	Score        float64             `json:"sustainabilityScore"`
+	Months       []CarbonFootprint   `json:"months"`
+	Coefficients []CarbonCoefficient `json:"coefficients"`
+}
+
+// Uyum gereksinimi durumları
+const (
+	ComplianceStatusPending       = "pending"
+	ComplianceStatusCompliant     = "compliant"
+	ComplianceStatusNonCompliant  = "non_compliant"
+	ComplianceStatusNotApplicable = "not_applicable"
+)
+
+// ComplianceRequirement kontrol listesindeki tek bir gereksinim
+type ComplianceRequirement struct {
+	Code             string `json:"code" binding:"required,max=20"`
+	Title            string `json:"title" binding:"required"`
+	Description      string `json:"description"`
+	Category         string `json:"category"`
+	EvidenceRequired bool   `json:"evidenceRequired"`
+}
+
+// ComplianceChecklist organik, GlobalGAP veya kullanıcı tanımlı sertifikasyon kontrol listesi;
+// logSources denetim paketine eklenecek faaliyet kayıtlarını belirler
+type ComplianceChecklist struct {
+	ID           string                  `json:"id" db:"id"`
+	Standard     string                  `json:"standard" db:"standard" binding:"required,oneof=organic globalgap custom"`
+	Name         string                  `json:"name" db:"name" binding:"required"`
+	Description  string                  `json:"description" db:"description"`
+	Requirements []ComplianceRequirement `json:"requirements" db:"requirements" binding:"required,min=1,max=200,dive"`
+	LogSources   []string                `json:"logSources" db:"log_sources" binding:"dive,oneof=land_activities health_records livestock_movements transactions"`
+	IsSystem     bool                    `json:"isSystem" db:"-"`
+	Progress     *ComplianceProgress     `json:"progress,omitempty" db:"-"`
+	Items        []ComplianceItem        `json:"items,omitempty" db:"-"`
+	CreatedAt    time.Time               `json:"createdAt" db:"created_at"`
+	UpdatedAt    time.Time               `json:"updatedAt" db:"updated_at"`
+}
+
+// ComplianceItem gereksinimin kullanıcıya ait durumu ve kanıtları
+type ComplianceItem struct {
+	ComplianceRequirement
+	StatusID   string            `json:"statusId"`
+	Status     string            `json:"status"`
+	Notes      string            `json:"notes"`
+	ReviewedAt *time.Time        `json:"reviewedAt"`
+	Evidence   []MediaAttachment `json:"evidence"`
+}
+
+// ComplianceProgress kontrol listesinin durum özeti
+type ComplianceProgress struct {
+	Total           int     `json:"total"`
+	Compliant       int     `json:"compliant"`
+	NonCompliant    int     `json:"nonCompliant"`
+	NotApplicable   int     `json:"notApplicable"`
+	Pending         int     `json:"pending"`
+	MissingEvidence int     `json:"missingEvidence"`
+	Percent         float64 `json:"percent"`
+}
+
+// ComplianceStatusRequest gereksinim durumu güncelleme isteği
+type ComplianceStatusRequest struct {
+	Status string `json:"status" binding:"required,oneof=pending compliant non_compliant not_applicable"`
+	Notes  string `json:"notes"`
+}
+
+// Doküman kategorileri
+const (
+	DocumentCategoryContract    = "contract"
+	DocumentCategoryDeed        = "deed"
+	DocumentCategoryPermit      = "permit"
+	DocumentCategoryCertificate = "certificate"
+	DocumentCategoryInsurance   = "insurance"
+	DocumentCategoryOther       = "other"
+)
+
+// Document sözleşme, tapu, izin ve analiz belgeleri gibi başlıklı dokümanlar;
+// dosyadan çıkarılan metin tam metin aramada kullanılır
+type Document struct {
+	ID            string     `json:"id" db:"id"`
+	UserID        string     `json:"userId" db:"user_id"`
+	Title         string     `json:"title" db:"title"`
+	Category      string     `json:"category" db:"category"`
+	Description   string     `json:"description" db:"description"`
+	EntityType    *string    `json:"entityType" db:"entity_type"`
+	EntityID      *string    `json:"entityId" db:"entity_id"`
+	EntityName    *string    `json:"entityName" db:"-"`
+	IssueDate     *time.Time `json:"issueDate" db:"issue_date"`
+	ExpiryDate    *time.Time `json:"expiryDate" db:"expiry_date"`
+	ReminderDays  int        `json:"reminderDays" db:"reminder_days"`
+	DaysToExpiry  *int       `json:"daysToExpiry" db:"-"`
+	Expired       bool       `json:"expired" db:"-"`
+	Filename      *string    `json:"filename" db:"filename"`
+	ContentType   *string    `json:"contentType" db:"content_type"`
+	Size          int64      `json:"size" db:"size"`
+	HasText       bool       `json:"hasText" db:"-"`
+	Snippet       string     `json:"snippet,omitempty" db:"-"`
+	CreatedAt     time.Time  `json:"createdAt" db:"created_at"`
+	UpdatedAt     time.Time  `json:"updatedAt" db:"updated_at"`
+	ExtractedText string     `json:"-" db:"extracted_text"`
+}
+
+// DocumentRequest doküman oluşturma ve güncelleme isteği; dosya ayrı olarak yüklenir
+type DocumentRequest struct {
+	Title        string     `json:"title" binding:"required,max=200"`
+	Category     string     `json:"category" binding:"required,oneof=contract deed permit certificate insurance other"`
+	Description  string     `json:"description"`
+	EntityType   *string    `json:"entityType" binding:"omitempty,oneof=land livestock asset"`
+	EntityID     *string    `json:"entityId"`
+	IssueDate    *time.Time `json:"issueDate"`
+	ExpiryDate   *time.Time `json:"expiryDate"`
+	ReminderDays *int       `json:"reminderDays" binding:"omitempty,min=0,max=365"`
+}
+
+// LandParcel resmi kadastro kaydındaki ada/parsel bilgisi; mahalle kodu TKGM sorgularında kullanılır
+type LandParcel struct {
+	Province         string `json:"province"`
+	District         string `json:"district"`
+	Neighborhood     string `json:"neighborhood"`
+	NeighborhoodCode string `json:"neighborhoodCode"`
+	Block            string `json:"block"`
+	Parcel           string `json:"parcel"`
+}
+
+// GeoPolygon GeoJSON Polygon geometrisi; koordinatlar [boylam, enlem] sırasındadır
+type GeoPolygon struct {
+	Type        string        `json:"type"`
+	Coordinates [][][]float64 `json:"coordinates"`
+}
+
+// ParcelLookupResult kadastro sağlayıcısından alınan parsel sınırı ve bilgileri
+type ParcelLookupResult struct {
+	Parcel   LandParcel `json:"parcel"`
+	Boundary GeoPolygon `json:"boundary"`
+	AreaM2   float64    `json:"areaM2"`
+	LandUse  string     `json:"landUse"`
+	Centroid Location   `json:"centroid"`
+	Source   string     `json:"source"`
+}
+
+// LandAreaCheck arazinin girilen alanı ile sınır geometrisinden hesaplanan alanın karşılaştırması
+type LandAreaCheck struct {
+	LandID   string `json:"landId"`
+	LandName string `json:"landName,omitempty"`
+	// Area arazinin kayıtlı alanı; düzeltildiyse yeni alan
+	Area float64 `json:"area"`
+	Unit string  `json:"unit"`
+	// GeometryArea sınırdan hesaplanan alanın arazi birimindeki karşılığı
+	GeometryArea   float64 `json:"geometryArea"`
+	GeometryAreaM2 float64 `json:"geometryAreaM2"`
+	// DifferencePercent girilen alanın hesaplanan alandan farkı (hesaplanan alana göre yüzde)
+	DifferencePercent float64 `json:"differencePercent"`
+	TolerancePercent  float64 `json:"tolerancePercent"`
+	// Flagged fark toleransı aşıyor
+	Flagged bool `json:"flagged"`
+	// Corrected alan hesaplanan alanla değiştirildi; PreviousArea girilen alandır
+	Corrected    bool     `json:"corrected"`
+	PreviousArea *float64 `json:"previousArea,omitempty"`
+}
+
+// LandDataQualityReport arazi kayıtlarının veri kalitesi raporu
+type LandDataQualityReport struct {
+	TolerancePercent float64 `json:"tolerancePercent"`
+	AutoCorrect      bool    `json:"autoCorrect"`
+	// TotalLands çiftliğin arazi sayısı; CheckedLands sınırı kayıtlı olup alanı karşılaştırılan araziler
+	TotalLands   int `json:"totalLands"`
+	CheckedLands int `json:"checkedLands"`
+	// MissingBoundary sınırı kayıtlı olmadığı için alanı doğrulanamayan araziler
+	MissingBoundary []LandDataQualityItem `json:"missingBoundary"`
+	// AreaDiscrepancies alan farkı toleransı aşan araziler (farkı büyükten küçüğe)
+	AreaDiscrepancies []LandAreaCheck `json:"areaDiscrepancies"`
+}
+
+// LandDataQualityItem veri kalitesi raporunda listelenen arazi
+type LandDataQualityItem struct {
+	LandID   string  `json:"landId"`
+	LandName string  `json:"landName"`
+	Area     float64 `json:"area"`
+	Unit     string  `json:"unit"`
+}
+
+// LandAreaCorrectionRequest alan düzeltme isteği
+type LandAreaCorrectionRequest struct {
+	// LandIDs düzeltilecek araziler; boşsa farkı toleransı aşan tüm araziler düzeltilir
+	LandIDs []string `json:"landIds" binding:"max=500"`
+}
+
+// Farm hesaba bağlı çiftlik; varsayılan çiftliğin kimliği hesap kimliğiyle aynıdır
+type Farm struct {
+	ID          string       `json:"id" db:"id"`
+	Name        string       `json:"name" db:"name" binding:"required,max=100"`
+	Location    string       `json:"location" db:"location"`
+	Description string       `json:"description" db:"description"`
+	IsDefault   bool         `json:"isDefault" db:"is_default"`
+	IsCurrent   bool         `json:"isCurrent" db:"-"`
+	Settings    *Settings    `json:"settings,omitempty" db:"settings"`
+	Summary     *FarmSummary `json:"summary,omitempty" db:"-"`
+	CreatedAt   time.Time    `json:"createdAt" db:"created_at"`
+	UpdatedAt   time.Time    `json:"updatedAt" db:"updated_at"`
+}
+
+// FarmSummary çiftlik seçicide gösterilen özet istatistikler
+type FarmSummary struct {
+	Lands               int     `json:"lands"`
+	Hectares            float64 `json:"hectares"`
+	Livestock           int     `json:"livestock"`
+	ActiveProductions   int     `json:"activeProductions"`
+	MonthIncome         float64 `json:"monthIncome"`
+	MonthExpense        float64 `json:"monthExpense"`
+	MonthNet            float64 `json:"monthNet"`
+	UnreadNotifications int     `json:"unreadNotifications"`
+}
+
+// FarmComparison hesaba bağlı çiftliklerin seçilen dönemdeki üretim, maliyet ve kârlılık karşılaştırması
+type FarmComparison struct {
+	StartDate time.Time            `json:"startDate"`
+	EndDate   time.Time            `json:"endDate"`
+	Farms     []FarmComparisonItem `json:"farms"`
+	Totals    FarmComparisonTotals `json:"totals"`
+}
+
+// FarmComparisonItem bir çiftliğin dönem metrikleri; sıralamalar 1'den başlar
+type FarmComparisonItem struct {
+	FarmID               string             `json:"farmId"`
+	FarmName             string             `json:"farmName"`
+	IsDefault            bool               `json:"isDefault"`
+	Hectares             float64            `json:"hectares"`
+	ProductionRecords    int                `json:"productionRecords"`
+	ProductionByUnit     map[string]float64 `json:"productionByUnit"`
+	ProductionValue      float64            `json:"productionValue"`
+	Income               float64            `json:"income"`
+	Expense              float64            `json:"expense"`
+	NetProfit            float64            `json:"netProfit"`
+	ProfitMargin         float64            `json:"profitMargin"`
+	CostPerHectare       float64            `json:"costPerHectare"`
+	ProfitPerHectare     float64            `json:"profitPerHectare"`
+	ExpenseByCategory    []CategoryAmount   `json:"expenseByCategory"`
+	ProfitRank           int                `json:"profitRank"`
+	ProfitPerHectareRank int                `json:"profitPerHectareRank"`
+	ShareOfTotalIncome   float64            `json:"shareOfTotalIncome"`
+}
+
+// CategoryAmount kategori bazında tutar
+type CategoryAmount struct {
+	Category string  `json:"category"`
+	Amount   float64 `json:"amount"`
+}
+
+// FarmComparisonTotals tüm çiftliklerin dönem toplamları
+type FarmComparisonTotals struct {
+	Hectares        float64 `json:"hectares"`
+	ProductionValue float64 `json:"productionValue"`
+	Income          float64 `json:"income"`
+	Expense         float64 `json:"expense"`
+	NetProfit       float64 `json:"netProfit"`
+	ProfitMargin    float64 `json:"profitMargin"`
+}
+
+// FarmPublicProfile çiftliğin herkese açık profili; enabled olan profiller oturum açmadan /public/farms
+// altında yayınlanır. Profilde çiftlik kimliği ve tam konum bulunmaz; konum yalnızca locationArea ile verilir
+type FarmPublicProfile struct {
+	Slug         string            `json:"slug" db:"slug"`
+	Enabled      bool              `json:"enabled" db:"enabled"`
+	FarmName     string            `json:"farmName" db:"-"`
+	Description  string            `json:"description" db:"description"`
+	Products     []string          `json:"products" db:"products"`
+	LocationArea string            `json:"locationArea" db:"location_area"`
+	Photos       []FarmPublicPhoto `json:"photos" db:"photo_ids"`
+	PublishedAt  *time.Time        `json:"publishedAt,omitempty" db:"published_at"`
+	UpdatedAt    *time.Time        `json:"updatedAt,omitempty" db:"updated_at"`
+}
+
+// FarmPublicPhoto profilde yayınlanan fotoğraf; url oturum açmadan indirilebilir
+type FarmPublicPhoto struct {
+	ID  string `json:"id"`
+	URL string `json:"url"`
+}
+
+// FarmPublicProfileRequest herkese açık profil ayarları; slug boşsa çiftlik adından üretilir, locationArea
+// boşsa çiftliğin konumu kullanılır. photoIds çiftliğe yüklenmiş fotoğrafların (POST /media/photos) kimlikleridir
+type FarmPublicProfileRequest struct {
+	Enabled      bool     `json:"enabled"`
+	Slug         string   `json:"slug" binding:"omitempty,max=60"`
+	Description  string   `json:"description" binding:"max=1000"`
+	Products     []string `json:"products" binding:"max=30,dive,required,max=60"`
+	LocationArea string   `json:"locationArea" binding:"max=100"`
+	PhotoIDs     []string `json:"photoIds" binding:"max=10,dive,required"`
+}
+
+// FarmDirectoryPage herkese açık çiftlik dizininin bir sayfası
+type FarmDirectoryPage struct {
+	Farms      []FarmPublicProfile `json:"farms"`
+	Pagination Pagination          `json:"pagination"`
+}
+
+// FiscalSettings mali takvim ayarları; finans ve rapor uç noktalarındaki period parametreleri bu takvime göre hesaplanır
+type FiscalSettings struct {
+	YearStartMonth int            `json:"yearStartMonth"`
+	Periods        []FiscalPeriod `json:"periods"`
+}
+
+// FiscalPeriod her yıl tekrarlanan kullanıcı tanımlı dönem (örn. hububat pazarlama yılı)
+type FiscalPeriod struct {
+	Code       string `json:"code"`
+	Name       string `json:"name"`
+	StartMonth int    `json:"startMonth"`
+	StartDay   int    `json:"startDay"`
+	Months     int    `json:"months"`
+}
+
+// PeriodRange bir periyodun tarih aralığı; EndDate dahil değildir
+type PeriodRange struct {
+	Period    string    `json:"period"`
+	Name      string    `json:"name"`
+	StartDate time.Time `json:"startDate"`
+	EndDate   time.Time `json:"endDate"`
+}
+
+// PaymentAging bekleyen alacak ve borçların vade yaşlandırma raporu
+type PaymentAging struct {
+	AsOf        time.Time    `json:"asOf"`
+	Receivables AgingSummary `json:"receivables"`
+	Payables    AgingSummary `json:"payables"`
+}
+
+// AgingSummary bir işlem türündeki bekleyen ödemelerin vade dilimlerine dağılımı
+type AgingSummary struct {
+	Total   float64       `json:"total"`
+	Count   int           `json:"count"`
+	Overdue float64       `json:"overdue"`
+	Buckets []AgingBucket `json:"buckets"`
+	Items   []Transaction `json:"items"`
+}
+
+// AgingBucket vade dilimi; current henüz vadesi gelmemiş ödemeleri içerir
+type AgingBucket struct {
+	Key     string  `json:"key"`
+	Label   string  `json:"label"`
+	MinDays int     `json:"minDays"`
+	MaxDays *int    `json:"maxDays"`
+	Amount  float64 `json:"amount"`
+	Count   int     `json:"count"`
+}
+
+// TransactionTagValue bir etiket boyutundaki değer ve kullanım sayısı
+type TransactionTagValue struct {
+	Value string `json:"value"`
+	Count int    `json:"count"`
+}
+
+// TransactionTagDimension etiket boyutu (örn. land, season, project) ve değerleri
+type TransactionTagDimension struct {
+	Dimension string                `json:"dimension"`
+	Values    []TransactionTagValue `json:"values"`
+}
+
+// TagAnalysis gelir-giderin etiket boyutuna (ve isteğe bağlı ikinci boyuta) göre kırılımı
+type TagAnalysis struct {
+	Dimension string           `json:"dimension"`
+	By        string           `json:"by,omitempty"`
+	StartDate time.Time        `json:"startDate"`
+	EndDate   time.Time        `json:"endDate"`
+	Rows      []TagAnalysisRow `json:"rows"`
+	Totals    TagAnalysisCell  `json:"totals"`
+}
+
+// TagAnalysisRow boyut değerinin toplamları; by verildiyse ikinci boyut değerlerine göre hücreler. Boş değer etiketsiz işlemleri gösterir
+type TagAnalysisRow struct {
+	TagAnalysisCell
+	Columns []TagAnalysisCell `json:"columns,omitempty"`
+}
+
+// TagAnalysisCell gelir, gider, net ve işlem sayısı
+type TagAnalysisCell struct {
+	Value   string  `json:"value"`
+	Income  float64 `json:"income"`
+	Expense float64 `json:"expense"`
+	Net     float64 `json:"net"`
+	Count   int     `json:"count"`
+}
+
+// InboundEmailAddress çiftliğe iletilen fiş ve fatura e-postalarının gönderileceği adres
+type InboundEmailAddress struct {
+	Address   string    `json:"address"`
+	CreatedAt time.Time `json:"createdAt"`
+}
+
+// TransactionDraft e-postayla iletilen ve onay bekleyen işlem taslağı
+type TransactionDraft struct {
+	ID              string              `json:"id" db:"id"`
+	UserID          string              `json:"userId" db:"user_id"`
+	Source          string              `json:"source" db:"source"`
+	Sender          string              `json:"sender" db:"sender"`
+	Subject         string              `json:"subject" db:"subject"`
+	Body            string              `json:"body" db:"body"`
+	Type            string              `json:"type" db:"type"`
+	Category        string              `json:"category" db:"category"`
+	Description     string              `json:"description" db:"description"`
+	Amount          float64             `json:"amount" db:"amount"`
+	Currency        string              `json:"currency" db:"currency"`
+	Date            time.Time           `json:"date" db:"date"`
+	Status          string              `json:"status" db:"status"`
+	TransactionID   *string             `json:"transactionId" db:"transaction_id"`
+	Receipt         *TransactionReceipt `json:"receipt" db:"-"`
+	AttachmentCount int                 `json:"attachmentCount" db:"attachment_count"`
+	CreatedAt       time.Time           `json:"createdAt" db:"created_at"`
+	UpdatedAt       time.Time           `json:"updatedAt" db:"updated_at"`
+}
+
+// TransactionReceipt işlem taslağına eklenen fiş dosyası
+type TransactionReceipt struct {
+	Filename    string `json:"filename"`
+	ContentType string `json:"contentType"`
+	Size        int64  `json:"size"`
+	URL         string `json:"url"`
+}
+
+// Banka ekstresi satırı durumları
+const (
+	StatementLineUnmatched = "unmatched"
+	StatementLineMatched   = "matched"
+	StatementLineCreated   = "created"
+	StatementLineIgnored   = "ignored"
+)
+
+// BankAccount ekstreleri içe aktarılan banka hesabı
+type BankAccount struct {
+	ID             string              `json:"id" db:"id"`
+	UserID         string              `json:"userId" db:"user_id"`
+	Name           string              `json:"name" db:"name"`
+	BankName       string              `json:"bankName" db:"bank_name"`
+	AccountNumber  string              `json:"accountNumber" db:"account_number"`
+	Currency       string              `json:"currency" db:"currency"`
+	OpeningBalance float64             `json:"openingBalance" db:"opening_balance"`
+	Reconciliation *BankReconciliation `json:"reconciliation,omitempty" db:"-"`
+	CreatedAt      time.Time           `json:"createdAt" db:"created_at"`
+	UpdatedAt      time.Time           `json:"updatedAt" db:"updated_at"`
+}
+
+// BankReconciliation banka hesabının mutabakat durumu; tüm satırlar eşleşmiş, oluşturulmuş veya yok sayılmışsa hesap mutabıktır
+type BankReconciliation struct {
+	Status            string     `json:"status"`
+	TotalLines        int        `json:"totalLines"`
+	Matched           int        `json:"matched"`
+	Created           int        `json:"created"`
+	Ignored           int        `json:"ignored"`
+	Unmatched         int        `json:"unmatched"`
+	UnmatchedAmount   float64    `json:"unmatchedAmount"`
+	StatementBalance  float64    `json:"statementBalance"`
+	LastStatementDate *time.Time `json:"lastStatementDate"`
+	ReconciledThrough *time.Time `json:"reconciledThrough"`
+}
+
+// BankStatementLine içe aktarılan ekstre satırı; tutar hesaba giren para için pozitif, çıkan para için negatiftir
+type BankStatementLine struct {
+	ID            string    `json:"id" db:"id"`
+	AccountID     string    `json:"accountId" db:"account_id"`
+	StatementID   string    `json:"statementId" db:"statement_id"`
+	Date          time.Time `json:"date" db:"date"`
+	Amount        float64   `json:"amount" db:"amount"`
+	Description   string    `json:"description" db:"description"`
+	Reference     string    `json:"reference" db:"reference"`
+	Status        string    `json:"status" db:"status"`
+	TransactionID *string   `json:"transactionId" db:"transaction_id"`
+	MatchScore    *float64  `json:"matchScore,omitempty" db:"match_score"`
+	CreatedAt     time.Time `json:"createdAt" db:"created_at"`
+}
+
+// BankStatementImport ekstre içe aktarım sonucu
+type BankStatementImport struct {
+	StatementID    string              `json:"statementId"`
+	AccountID      string              `json:"accountId"`
+	Format         string              `json:"format"`
+	StartDate      *time.Time          `json:"startDate"`
+	EndDate        *time.Time          `json:"endDate"`
+	ClosingBalance *float64            `json:"closingBalance"`
+	Imported       int                 `json:"imported"`
+	Duplicates     int                 `json:"duplicates"`
+	Matched        int                 `json:"matched"`
+	Lines          []BankStatementLine `json:"lines"`
+	Reconciliation BankReconciliation  `json:"reconciliation"`
+}
+
+// BankStatementLineRequest ekstre satırını elle eşleştirme, eşleşmeyi kaldırma veya yok sayma isteği
+type BankStatementLineRequest struct {
+	Status        string `json:"status" binding:"required"`
+	TransactionID string `json:"transactionId"`
+}
+
+// BankStatementTransactionsRequest eşleşmeyen ekstre satırlarından işlem oluşturma isteği; satır seçilmezse tüm eşleşmeyen satırlar kullanılır
+type BankStatementTransactionsRequest struct {
+	LineIDs  []string `json:"lineIds"`
+	Category string   `json:"category"`
+	Tags     []string `json:"tags"`
+}
+
+// ProductionSaleRequest üretimden satış isteği; invoice true ise numaralı satış faturası düzenlenir,
+// vade tarihi girilirse gelir işlemi tahsil edilene kadar bekleyen durumunda tutulur
+type ProductionSaleRequest struct {
+	Date          *time.Time `json:"date"`
+	Quantity      float64    `json:"quantity" binding:"required,gt=0"`
+	UnitPrice     float64    `json:"unitPrice" binding:"required,gt=0"`
+	Currency      string     `json:"currency"`
+	Buyer         string     `json:"buyer" binding:"required"`
+	BuyerTaxID    string     `json:"buyerTaxId"`
+	BuyerAddress  string     `json:"buyerAddress"`
+	Invoice       bool       `json:"invoice"`
+	TaxRate       float64    `json:"taxRate" binding:"min=0,max=100"`
+	DueDate       *time.Time `json:"dueDate"`
+	PaymentMethod string     `json:"paymentMethod"`
+	Notes         string     `json:"notes"`
+}
+
+// ProductionSale üretimden yapılan satış kaydı
+type ProductionSale struct {
+	ID            string     `json:"id" db:"id"`
+	ProductionID  string     `json:"productionId" db:"production_id"`
+	ProductName   string     `json:"productName" db:"-"`
+	Date          time.Time  `json:"date" db:"sale_date"`
+	Quantity      float64    `json:"quantity" db:"quantity"`
+	Unit          string     `json:"unit" db:"unit"`
+	UnitPrice     float64    `json:"unitPrice" db:"unit_price"`
+	Subtotal      float64    `json:"subtotal" db:"subtotal"`
+	TaxRate       float64    `json:"taxRate" db:"tax_rate"`
+	TaxAmount     float64    `json:"taxAmount" db:"tax_amount"`
+	Total         float64    `json:"total" db:"total"`
+	Currency      string     `json:"currency" db:"currency"`
+	Buyer         string     `json:"buyer" db:"buyer"`
+	BuyerTaxID    string     `json:"buyerTaxId" db:"buyer_tax_id"`
+	BuyerAddress  string     `json:"buyerAddress" db:"buyer_address"`
+	InvoiceNumber *string    `json:"invoiceNumber" db:"invoice_number"`
+	DueDate       *time.Time `json:"dueDate" db:"due_date"`
+	TransactionID string     `json:"transactionId" db:"transaction_id"`
+	Notes         string     `json:"notes" db:"notes"`
+	CreatedAt     time.Time  `json:"createdAt" db:"created_at"`
+}
+
+// ProductionSaleResult satış sonrası satış kaydı, güncellenen üretim ve oluşturulan gelir işlemi
+type ProductionSaleResult struct {
+	Sale        ProductionSale `json:"sale"`
+	Production  Production     `json:"production"`
+	Transaction Transaction    `json:"transaction"`
+}
+
+// Müşteri türleri
+const (
+	CustomerTypeIndividual = "individual"
+	CustomerTypeCompany    = "company"
+)
+
+// Satış siparişi ödeme durumları
+const (
+	SalesOrderPending   = "pending"
+	SalesOrderPaid      = "paid"
+	SalesOrderCancelled = "cancelled"
+)
+
+// Customer ürün satılan müşteri; sipariş özetleri iptal edilmemiş siparişlerden hesaplanır
+type Customer struct {
+	ID      string `json:"id" db:"id"`
+	Name    string `json:"name" db:"name"`
+	Type    string `json:"type" db:"customer_type" enums:"individual,company"`
+	TaxID   string `json:"taxId" db:"tax_id"`
+	Phone   string `json:"phone" db:"phone"`
+	Email   string `json:"email" db:"email"`
+	Address string `json:"address" db:"address"`
+	Notes   string `json:"notes" db:"notes"`
+	// OrderCount sipariş sayısı; TotalSales sipariş tutarlarının toplamı, Outstanding ödenmemiş siparişlerin toplamı
+	OrderCount  int       `json:"orderCount" db:"-"`
+	TotalSales  float64   `json:"totalSales" db:"-"`
+	Outstanding float64   `json:"outstanding" db:"-"`
+	CreatedAt   time.Time `json:"createdAt" db:"created_at"`
+	UpdatedAt   time.Time `json:"updatedAt" db:"updated_at"`
+}
+
+// CustomerRequest müşteri ekleme/güncelleme isteği
+type CustomerRequest struct {
+	Name    string `json:"name" binding:"required,max=200"`
+	Type    string `json:"type" binding:"omitempty,oneof=individual company"`
+	TaxID   string `json:"taxId" binding:"max=20"`
+	Phone   string `json:"phone" binding:"max=30"`
+	Email   string `json:"email" binding:"omitempty,email,max=200"`
+	Address string `json:"address" binding:"max=500"`
+	Notes   string `json:"notes" binding:"max=1000"`
+}
+
+// SalesOrder müşteriye üretim partisinden yapılan satış siparişi
+type SalesOrder struct {
+	ID           string    `json:"id" db:"id"`
+	OrderNumber  string    `json:"orderNumber" db:"order_number"`
+	CustomerID   string    `json:"customerId" db:"customer_id"`
+	CustomerName string    `json:"customerName" db:"-"`
+	ProductionID string    `json:"productionId" db:"production_id"`
+	ProductName  string    `json:"productName" db:"-"`
+	Date         time.Time `json:"date" db:"order_date"`
+	Quantity     float64   `json:"quantity" db:"quantity"`
+	Unit         string    `json:"unit" db:"unit"`
+	UnitPrice    float64   `json:"unitPrice" db:"unit_price"`
+	Subtotal     float64   `json:"subtotal" db:"subtotal"`
+	TaxRate      float64   `json:"taxRate" db:"tax_rate"`
+	TaxAmount    float64   `json:"taxAmount" db:"tax_amount"`
+	Total        float64   `json:"total" db:"total"`
+	Currency     string    `json:"currency" db:"currency"`
+	// PaymentStatus pending (ödenmedi), paid (ödendi, gelir işlemi oluşturuldu) veya cancelled (iptal, stok geri eklendi)
+	PaymentStatus string     `json:"paymentStatus" db:"payment_status" enums:"pending,paid,cancelled"`
+	DueDate       *time.Time `json:"dueDate" db:"due_date"`
+	PaidAt        *time.Time `json:"paidAt" db:"paid_at"`
+	PaymentMethod string     `json:"paymentMethod" db:"payment_method"`
+	// TransactionID ödendi olarak işaretlenince oluşturulan gelir işlemi
+	TransactionID *string   `json:"transactionId" db:"transaction_id"`
+	Notes         string    `json:"notes" db:"notes"`
+	CreatedAt     time.Time `json:"createdAt" db:"created_at"`
+	UpdatedAt     time.Time `json:"updatedAt" db:"updated_at"`
+}
+
+// SalesOrderRequest satış siparişi isteği
+type SalesOrderRequest struct {
+	CustomerID   string     `json:"customerId" binding:"required"`
+	ProductionID string     `json:"productionId" binding:"required"`
+	Date         *time.Time `json:"date"`
+	Quantity     float64    `json:"quantity" binding:"required,gt=0"`
+	UnitPrice    float64    `json:"unitPrice" binding:"required,gt=0"`
+	Currency     string     `json:"currency" binding:"omitempty,len=3"`
+	TaxRate      float64    `json:"taxRate" binding:"min=0,max=100"`
+	DueDate      *time.Time `json:"dueDate"`
+	Notes        string     `json:"notes" binding:"max=1000"`
+}
+
+// SalesOrderFilter sipariş listesi filtreleri
+type SalesOrderFilter struct {
+	CustomerID    string
+	ProductionID  string
+	PaymentStatus string
+	StartDate     *time.Time
+	EndDate       *time.Time
+	Page          int
+	Limit         int
+}
+
+// SalesOrderListResponse sayfalı sipariş listesi
+type SalesOrderListResponse struct {
+	Orders     []SalesOrder `json:"orders"`
+	Pagination Pagination   `json:"pagination"`
+}
+
+// SalesOrderStatusRequest sipariş ödeme durumu değişikliği
+type SalesOrderStatusRequest struct {
+	PaymentStatus string `json:"paymentStatus" binding:"required,oneof=paid cancelled"`
+	// PaidAt ödeme tarihi (varsayılan: şimdi); gelir işleminin tarihi olur
+	PaidAt        *time.Time `json:"paidAt"`
+	PaymentMethod string     `json:"paymentMethod" binding:"max=50"`
+}
+
+// InvoiceLine fatura kalemi; işlemden kesilen faturalarda her işlem bir kalemdir
+type InvoiceLine struct {
+	Description   string  `json:"description"`
+	Quantity      float64 `json:"quantity"`
+	Unit          string  `json:"unit,omitempty"`
+	UnitPrice     float64 `json:"unitPrice"`
+	Subtotal      float64 `json:"subtotal"`
+	TaxRate       float64 `json:"taxRate"`
+	TaxAmount     float64 `json:"taxAmount"`
+	Total         float64 `json:"total"`
+	TransactionID string  `json:"transactionId,omitempty"`
+}
+
+// Invoice gelir işlemlerinden veya satış siparişinden kesilen numaralı fatura; PDF'i saklanır ve fatura numarası
+// bağlı işlemlerin fiş numarası (receipt) olur
+type Invoice struct {
+	ID            string  `json:"id" db:"id"`
+	InvoiceNumber string  `json:"invoiceNumber" db:"invoice_number"`
+	SalesOrderID  *string `json:"salesOrderId" db:"sales_order_id"`
+	CustomerID    *string `json:"customerId" db:"customer_id"`
+	// Alıcı bilgileri fatura kesildiği andaki haliyle saklanır
+	BuyerName      string        `json:"buyerName" db:"buyer_name"`
+	BuyerTaxID     string        `json:"buyerTaxId" db:"buyer_tax_id"`
+	BuyerAddress   string        `json:"buyerAddress" db:"buyer_address"`
+	IssueDate      time.Time     `json:"issueDate" db:"issue_date"`
+	DueDate        *time.Time    `json:"dueDate" db:"due_date"`
+	Currency       string        `json:"currency" db:"currency"`
+	Subtotal       float64       `json:"subtotal" db:"subtotal"`
+	TaxAmount      float64       `json:"taxAmount" db:"tax_amount"`
+	Total          float64       `json:"total" db:"total"`
+	Lines          []InvoiceLine `json:"lines" db:"lines"`
+	TransactionIDs []string      `json:"transactionIds" db:"-"`
+	Notes          string        `json:"notes" db:"notes"`
+	DownloadURL    string        `json:"downloadUrl" db:"-"`
+	CreatedAt      time.Time     `json:"createdAt" db:"created_at"`
+}
+
+// InvoiceRequest fatura isteği; transactionIds veya salesOrderId'den yalnızca biri verilir
+type InvoiceRequest struct {
+	TransactionIDs []string `json:"transactionIds" binding:"max=100"`
+	SalesOrderID   string   `json:"salesOrderId"`
+	// CustomerID işlemlerden kesilen faturanın alıcısı; siparişten kesilen faturada siparişin müşterisi kullanılır
+	CustomerID string     `json:"customerId"`
+	IssueDate  *time.Time `json:"issueDate"`
+	DueDate    *time.Time `json:"dueDate"`
+	Notes      string     `json:"notes" binding:"max=1000"`
+}
+
+// InvoiceFilter fatura listesi filtreleri
+type InvoiceFilter struct {
+	CustomerID string
+	StartDate  *time.Time
+	EndDate    *time.Time
+	Page       int
+	Limit      int
+}
+
+// InvoiceListResponse sayfalı fatura listesi
+type InvoiceListResponse struct {
+	Invoices   []Invoice  `json:"invoices"`
+	Pagination Pagination `json:"pagination"`
+}
+
+// İmzalanan belge türleri
+const (
+	DocumentTypeComplianceBundle = "compliance_bundle"
+	DocumentTypeAnimalPassport   = "animal_passport"
+	DocumentTypeHerdBook         = "herd_book"
+	DocumentTypeRegistryExport   = "registry_export"
+)
+
+// DocumentSignature sunucu anahtarıyla imzalanan dışa aktarım kaydı; imza belgenin baytları üzerinde ayrık
+// (detached) Ed25519 imzasıdır ve base64 kodludur
+type DocumentSignature struct {
+	ID           string    `json:"id"`
+	DocumentType string    `json:"documentType"`
+	DocumentID   string    `json:"documentId,omitempty"`
+	Filename     string    `json:"filename"`
+	ContentType  string    `json:"contentType"`
+	Size         int64     `json:"size"`
+	SHA256       string    `json:"sha256"`
+	Algorithm    string    `json:"algorithm"`
+	KeyID        string    `json:"keyId"`
+	Signature    string    `json:"signature"`
+	CreatedAt    time.Time `json:"createdAt"`
+}
+
+// DocumentSignatureListResponse sayfalı imzalı belge listesi
+type DocumentSignatureListResponse struct {
+	Signatures []DocumentSignature `json:"signatures"`
+	Pagination Pagination          `json:"pagination"`
+}
+
+// DocumentSigningKey belge imzalarını doğrulamak için yayımlanan sunucu açık anahtarı
+type DocumentSigningKey struct {
+	ID           string     `json:"id"`
+	Algorithm    string     `json:"algorithm"`
+	PublicKey    string     `json:"publicKey"`
+	PublicKeyPEM string     `json:"publicKeyPem"`
+	Active       bool       `json:"active"`
+	CreatedAt    time.Time  `json:"createdAt"`
+	RetiredAt    *time.Time `json:"retiredAt,omitempty"`
+}
+
+// DocumentVerification belge doğrulama sonucu; valid yalnızca belge bu sunucuda üretilmiş ve üretildikten sonra
+// değiştirilmemişse true olur
+type DocumentVerification struct {
+	Valid  bool   `json:"valid"`
+	SHA256 string `json:"sha256"`
+	// Reason belge doğrulanamadığında nedeni
+	Reason    string             `json:"reason,omitempty"`
+	FarmName  string             `json:"farmName,omitempty"`
+	Signature *DocumentSignature `json:"signature,omitempty"`
+}
+
+// MarketPrice ürün için kaydedilen piyasa fiyatı; source manual (kullanıcı girişi) veya feed (fiyat servisi) olabilir
+type MarketPrice struct {
+	ID        string    `json:"id" db:"id"`
+	Product   string    `json:"product" db:"product"`
+	Category  string    `json:"category" db:"category"`
+	Price     float64   `json:"price" db:"price"`
+	Unit      string    `json:"unit" db:"unit"`
+	Currency  string    `json:"currency" db:"currency"`
+	Date      time.Time `json:"date" db:"price_date"`
+	Source    string    `json:"source" db:"source"`
+	CreatedAt time.Time `json:"createdAt" db:"created_at"`
+}
+
+// MarketPriceRequest elle piyasa fiyatı girişi; aynı ürün ve gün için tekrar girilirse fiyat güncellenir
+type MarketPriceRequest struct {
+	Product  string    `json:"product" binding:"required"`
+	Category string    `json:"category"`
+	Price    float64   `json:"price" binding:"required,gt=0"`
+	Unit     string    `json:"unit"`
+	Currency string    `json:"currency"`
+	Date     time.Time `json:"date" binding:"required"`
+}
+
+// ProductPriceHistory ürünün dönem içindeki gerçekleşen satış fiyatları, piyasa fiyatları ve marjı
+type ProductPriceHistory struct {
+	Product              string              `json:"product"`
+	Category             string              `json:"category"`
+	Unit                 string              `json:"unit"`
+	Currency             string              `json:"currency"`
+	QuantitySold         float64             `json:"quantitySold"`
+	Revenue              float64             `json:"revenue"`
+	AverageRealizedPrice *float64            `json:"averageRealizedPrice"`
+	AverageMarketPrice   *float64            `json:"averageMarketPrice"`
+	PriceDifference      *float64            `json:"priceDifference"`
+	AverageUnitCost      *float64            `json:"averageUnitCost"`
+	LossQuantity         float64             `json:"lossQuantity"`
+	LossCost             float64             `json:"lossCost"`
+	MarginPercent        *float64            `json:"marginPercent"`
+	Points               []ProductPricePoint `json:"points"`
+}
+
+// ProductPricePoint ürünün aylık fiyat ve marj noktası; priceDifference gerçekleşen fiyatın piyasa fiyatından yüzde farkıdır
+type ProductPricePoint struct {
+	Period          string   `json:"period"`
+	QuantitySold    float64  `json:"quantitySold"`
+	Revenue         float64  `json:"revenue"`
+	RealizedPrice   *float64 `json:"realizedPrice"`
+	MarketPrice     *float64 `json:"marketPrice"`
+	PriceDifference *float64 `json:"priceDifference"`
+	LossQuantity    float64  `json:"lossQuantity"`
+	LossCost        float64  `json:"lossCost"`
+	MarginPercent   *float64 `json:"marginPercent"`
+}
+
+// Üretim kaybı nedenleri
+const (
+	LossReasonSpoilage = "spoilage"
+	LossReasonPest     = "pest_damage"
+	LossReasonHandling = "handling"
+	LossReasonOther    = "other"
+)
+
+// ProductionLossRequest üretim partisine kayıp kaydı isteği
+type ProductionLossRequest struct {
+	Date     *time.Time `json:"date"`
+	Quantity float64    `json:"quantity" binding:"required,gt=0"`
+	Reason   string     `json:"reason" binding:"required,oneof=spoilage pest_damage handling other"`
+	Notes    string     `json:"notes"`
+}
+
+// ProductionLoss üretim partisinden bozulma, zararlı veya taşıma nedeniyle kaybedilen miktar;
+// estimatedValue üretimin birim maliyeti girilmişse kaybın maliyet karşılığıdır
+type ProductionLoss struct {
+	ID              string    `json:"id" db:"id"`
+	ProductionID    string    `json:"productionId" db:"production_id"`
+	ProductName     string    `json:"productName" db:"-"`
+	Date            time.Time `json:"date" db:"loss_date"`
+	Quantity        float64   `json:"quantity" db:"quantity"`
+	Unit            string    `json:"unit" db:"-"`
+	Reason          string    `json:"reason" db:"reason"`
+	StorageLocation string    `json:"storageLocation" db:"-"`
+	EstimatedValue  *float64  `json:"estimatedValue" db:"-"`
+	Notes           string    `json:"notes" db:"notes"`
+	CreatedAt       time.Time `json:"createdAt" db:"created_at"`
+}
+
+// ProductionLossAnalysis dönemde hasat edilen partilerin kayıp oranları; partinin tüm kayıpları tarihine bakılmadan dahil edilir
+type ProductionLossAnalysis struct {
+	StartDate         time.Time             `json:"startDate"`
+	EndDate           time.Time             `json:"endDate"`
+	Produced          float64               `json:"produced"`
+	Lost              float64               `json:"lost"`
+	LossPercentage    float64               `json:"lossPercentage"`
+	EstimatedValue    float64               `json:"estimatedValue"`
+	ByReason          []LossReasonBreakdown `json:"byReason"`
+	ByCrop            []LossGroup           `json:"byCrop"`
+	ByStorageLocation []LossGroup           `json:"byStorageLocation"`
+}
+
+// LossReasonBreakdown kayıp nedenine göre miktar ve toplam kayıp içindeki payı
+type LossReasonBreakdown struct {
+	Reason     string  `json:"reason"`
+	Count      int     `json:"count"`
+	Quantity   float64 `json:"quantity"`
+	Percentage float64 `json:"percentage"`
+}
+
+// LossGroup ürün veya depolama yeri bazında üretilen ve kaybedilen miktar
+type LossGroup struct {
+	Name           string             `json:"name"`
+	Category       string             `json:"category,omitempty"`
+	Unit           string             `json:"unit"`
+	Produced       float64            `json:"produced"`
+	Lost           float64            `json:"lost"`
+	LossPercentage float64            `json:"lossPercentage"`
+	EstimatedValue float64            `json:"estimatedValue"`
+	ByReason       map[string]float64 `json:"byReason"`
+}
+
+// Arazi türleri; sera, iklim hedefleri ve sensörleri olan arazi türüdür
+const (
+	LandTypeField      = "field"
+	LandTypeGreenhouse = "greenhouse"
+)
+
+// ClimateSetpoints sera için izin verilen sıcaklık (°C) ve bağıl nem (%) aralığı; boş sınırlar denetlenmez
+type ClimateSetpoints struct {
+	MinTemperature *float64 `json:"minTemperature"`
+	MaxTemperature *float64 `json:"maxTemperature"`
+	MinHumidity    *float64 `json:"minHumidity"`
+	MaxHumidity    *float64 `json:"maxHumidity"`
+}
+
+// Greenhouse sera olarak işaretlenmiş arazi, iklim hedefleri, sensörleri ve son iklim ölçümü;
+// climateStatus ok, out_of_range veya no_data olabilir
+type Greenhouse struct {
+	LandID               string           `json:"landId" db:"land_id"`
+	Name                 string           `json:"name" db:"-"`
+	Area                 float64          `json:"area" db:"-"`
+	Unit                 string           `json:"unit" db:"-"`
+	Crop                 string           `json:"crop" db:"-"`
+	StructureType        string           `json:"structureType" db:"structure_type"`
+	Heated               bool             `json:"heated" db:"heated"`
+	Setpoints            ClimateSetpoints `json:"setpoints" db:"-"`
+	AlertCooldownMinutes int              `json:"alertCooldownMinutes" db:"alert_cooldown_minutes"`
+	Sensors              []ClimateSensor  `json:"sensors,omitempty" db:"-"`
+	LatestReading        *ClimateReading  `json:"latestReading" db:"-"`
+	ClimateStatus        string           `json:"climateStatus" db:"-"`
+	CreatedAt            time.Time        `json:"createdAt" db:"created_at"`
+	UpdatedAt            time.Time        `json:"updatedAt" db:"updated_at"`
+}
+
+// GreenhouseRequest araziyi seraya çevirme veya sera ayarlarını güncelleme isteği
+type GreenhouseRequest struct {
+	StructureType        string           `json:"structureType" binding:"omitempty,oneof=glass plastic tunnel other"`
+	Heated               bool             `json:"heated"`
+	Setpoints            ClimateSetpoints `json:"setpoints"`
+	AlertCooldownMinutes int              `json:"alertCooldownMinutes" binding:"min=0,max=1440"`
+}
+
+// ClimateSensor seraya bağlı iklim sensörü; token yalnızca oluşturulurken döner ve cihazın ölçüm gönderirken kullandığı anahtardır
+type ClimateSensor struct {
+	ID            string     `json:"id" db:"id"`
+	LandID        string     `json:"landId" db:"land_id"`
+	Name          string     `json:"name" db:"name"`
+	Token         string     `json:"token,omitempty" db:"token"`
+	LastReadingAt *time.Time `json:"lastReadingAt" db:"last_reading_at"`
+	CreatedAt     time.Time  `json:"createdAt" db:"created_at"`
+}
+
+// ClimateSensorRequest sensör ekleme isteği
+type ClimateSensorRequest struct {
+	Name string `json:"name" binding:"required"`
+}
+
+// ClimateReading seradan alınan sıcaklık ve nem ölçümü; sensorId boşsa elle girilmiştir
+type ClimateReading struct {
+	ID          string    `json:"id" db:"id"`
+	LandID      string    `json:"landId" db:"land_id"`
+	SensorID    *string   `json:"sensorId" db:"sensor_id"`
+	RecordedAt  time.Time `json:"recordedAt" db:"recorded_at"`
+	Temperature *float64  `json:"temperature" db:"temperature"`
+	Humidity    *float64  `json:"humidity" db:"humidity"`
+	CreatedAt   time.Time `json:"createdAt" db:"created_at"`
+}
+
+// ClimateReadingRequest iklim ölçümü girişi; en az bir değer gönderilmelidir
+type ClimateReadingRequest struct {
+	RecordedAt  *time.Time `json:"recordedAt"`
+	Temperature *float64   `json:"temperature" binding:"omitempty,min=-50,max=80"`
+	Humidity    *float64   `json:"humidity" binding:"omitempty,min=0,max=100"`
+}
+
+// ClimateAlert hedef aralık dışına çıkan iklim ölçümü için oluşturulan uyarı; metric temperature veya humidity olabilir
+type ClimateAlert struct {
+	ID         string    `json:"id" db:"id"`
+	LandID     string    `json:"landId" db:"land_id"`
+	ReadingID  string    `json:"readingId" db:"reading_id"`
+	Metric     string    `json:"metric" db:"metric"`
+	Value      float64   `json:"value" db:"value"`
+	Min        *float64  `json:"min" db:"min_value"`
+	Max        *float64  `json:"max" db:"max_value"`
+	RecordedAt time.Time `json:"recordedAt" db:"recorded_at"`
+	CreatedAt  time.Time `json:"createdAt" db:"created_at"`
+}
+
+// ClimateReadingResult kaydedilen ölçüm ve tetiklediği uyarılar
+type ClimateReadingResult struct {
+	Reading ClimateReading `json:"reading"`
+	Alerts  []ClimateAlert `json:"alerts"`
+}
+
+// GreenhouseProduction seradaki üretim kayıtları ve ürün bazında alan başına verim
+type GreenhouseProduction struct {
+	LandID      string                        `json:"landId"`
+	Area        float64                       `json:"area"`
+	Unit        string                        `json:"unit"`
+	Products    []GreenhouseProductionSummary `json:"products"`
+	Productions []Production                  `json:"productions"`
+}
+
+// GreenhouseProductionSummary seradaki bir ürünün toplam, satılan, kaybedilen miktarı ve alan birimi başına verimi
+type GreenhouseProductionSummary struct {
+	Name         string  `json:"name"`
+	Unit         string  `json:"unit"`
+	Amount       float64 `json:"amount"`
+	SoldAmount   float64 `json:"soldAmount"`
+	LostAmount   float64 `json:"lostAmount"`
+	Stock        float64 `json:"stock"`
+	YieldPerArea float64 `json:"yieldPerArea"`
+}
+
+// Arıcılık sabitleri; varroa oranı yüzde olarak eşiği aşan muayenelerde tedavi uyarısı gönderilir
+const (
+	HiveStatusActive        = "active"
+	HiveTreatmentVarroa     = "varroa"
+	VarroaAlertThresholdPct = 3.0
+)
+
+// Hive arı kovanı; son muayene, yılın bal hasadı ve sıradaki tedavi tarihiyle birlikte döner
+type Hive struct {
+	ID               string          `json:"id" db:"id"`
+	UserID           string          `json:"userId" db:"user_id"`
+	Name             string          `json:"name" db:"name"`
+	Apiary           string          `json:"apiary" db:"apiary"`
+	LandID           *string         `json:"landId" db:"land_id"`
+	HiveType         string          `json:"hiveType" db:"hive_type"`
+	QueenYear        *int            `json:"queenYear" db:"queen_year"`
+	Status           string          `json:"status" db:"status"`
+	Notes            string          `json:"notes" db:"notes"`
+	LatestInspection *HiveInspection `json:"latestInspection" db:"-"`
+	HoneyThisYear    float64         `json:"honeyThisYear" db:"-"`
+	NextTreatmentDue *time.Time      `json:"nextTreatmentDue" db:"-"`
+	CreatedAt        time.Time       `json:"createdAt" db:"created_at"`
+	UpdatedAt        time.Time       `json:"updatedAt" db:"updated_at"`
+}
+
+// HiveRequest kovan oluşturma/güncelleme isteği
+type HiveRequest struct {
+	Name      string  `json:"name" binding:"required"`
+	Apiary    string  `json:"apiary"`
+	LandID    *string `json:"landId"`
+	HiveType  string  `json:"hiveType" binding:"omitempty,oneof=langstroth dadant top_bar warre other"`
+	QueenYear *int    `json:"queenYear" binding:"omitempty,min=2000,max=2100"`
+	Status    string  `json:"status" binding:"omitempty,oneof=active dead sold merged"`
+	Notes     string  `json:"notes"`
+}
+
+// HiveInspection kovan muayenesi; varroaRate alkol yıkama veya şeker tozu yönteminde örnekteki 100 arı başına akar sayısıdır
+type HiveInspection struct {
+	ID                   string    `json:"id" db:"id"`
+	HiveID               string    `json:"hiveId" db:"hive_id"`
+	InspectionDate       time.Time `json:"inspectionDate" db:"inspection_date"`
+	QueenStatus          string    `json:"queenStatus" db:"queen_status"`
+	BroodFrames          *int      `json:"broodFrames" db:"brood_frames"`
+	BroodPattern         string    `json:"broodPattern" db:"brood_pattern"`
+	HoneyFrames          *int      `json:"honeyFrames" db:"honey_frames"`
+	Temperament          string    `json:"temperament" db:"temperament"`
+	VarroaCount          *int      `json:"varroaCount" db:"varroa_count"`
+	VarroaMethod         string    `json:"varroaMethod" db:"varroa_method"`
+	VarroaSampleSize     *int      `json:"varroaSampleSize" db:"varroa_sample_size"`
+	VarroaRate           *float64  `json:"varroaRate" db:"-"`
+	VarroaAboveThreshold bool      `json:"varroaAboveThreshold" db:"-"`
+	Notes                string    `json:"notes" db:"notes"`
+	CreatedAt            time.Time `json:"createdAt" db:"created_at"`
+}
+
+// HiveInspectionRequest kovan muayenesi isteği
+type HiveInspectionRequest struct {
+	InspectionDate   *time.Time `json:"inspectionDate"`
+	QueenStatus      string     `json:"queenStatus" binding:"required,oneof=present missing queen_cells replaced unknown"`
+	BroodFrames      *int       `json:"broodFrames" binding:"omitempty,min=0,max=40"`
+	BroodPattern     string     `json:"broodPattern" binding:"omitempty,oneof=solid spotty none"`
+	HoneyFrames      *int       `json:"honeyFrames" binding:"omitempty,min=0,max=40"`
+	Temperament      string     `json:"temperament" binding:"omitempty,oneof=calm nervous aggressive"`
+	VarroaCount      *int       `json:"varroaCount" binding:"omitempty,min=0"`
+	VarroaMethod     string     `json:"varroaMethod" binding:"omitempty,oneof=alcohol_wash sugar_roll sticky_board"`
+	VarroaSampleSize *int       `json:"varroaSampleSize" binding:"omitempty,min=1"`
+	Notes            string     `json:"notes"`
+}
+
+// HiveHarvest kovandan bal hasadı; her hasat bir üretim kaydı oluşturur
+type HiveHarvest struct {
+	ID           string    `json:"id" db:"id"`
+	HiveID       string    `json:"hiveId" db:"hive_id"`
+	HarvestDate  time.Time `json:"harvestDate" db:"harvest_date"`
+	Amount       float64   `json:"amount" db:"amount"`
+	HoneyType    string    `json:"honeyType" db:"honey_type"`
+	Moisture     *float64  `json:"moisture" db:"moisture"`
+	ProductionID string    `json:"productionId" db:"production_id"`
+	Notes        string    `json:"notes" db:"notes"`
+	CreatedAt    time.Time `json:"createdAt" db:"created_at"`
+}
+
+// HiveHarvestRequest bal hasadı isteği; miktar kg cinsindendir
+type HiveHarvestRequest struct {
+	HarvestDate     *time.Time `json:"harvestDate"`
+	Amount          float64    `json:"amount" binding:"required,gt=0"`
+	HoneyType       string     `json:"honeyType"`
+	Moisture        *float64   `json:"moisture" binding:"omitempty,min=0,max=30"`
+	Quality         string     `json:"quality"`
+	StorageLocation string     `json:"storageLocation"`
+	UnitCost        *float64   `json:"unitCost" binding:"omitempty,min=0"`
+	Notes           string     `json:"notes"`
+}
+
+// HiveHarvestResult bal hasadı ve oluşturulan üretim kaydı
+type HiveHarvestResult struct {
+	Harvest    HiveHarvest `json:"harvest"`
+	Production Production  `json:"production"`
+}
+
+// HiveTreatment kovan tedavisi; nextDueDate yaklaşınca hatırlatma gönderilir
+type HiveTreatment struct {
+	ID            string     `json:"id" db:"id"`
+	HiveID        string     `json:"hiveId" db:"hive_id"`
+	HiveName      string     `json:"hiveName" db:"-"`
+	TreatmentDate time.Time  `json:"treatmentDate" db:"treatment_date"`
+	Target        string     `json:"target" db:"target"`
+	Product       string     `json:"product" db:"product"`
+	Dose          string     `json:"dose" db:"dose"`
+	NextDueDate   *time.Time `json:"nextDueDate" db:"next_due_date"`
+	Notes         string     `json:"notes" db:"notes"`
+	CreatedAt     time.Time  `json:"createdAt" db:"created_at"`
+}
+
+// HiveTreatmentRequest kovan tedavisi isteği; varroa tedavisinde nextDueDate verilmezse sıradaki tedavi mevsiminin başlangıcı kullanılır
+type HiveTreatmentRequest struct {
+	TreatmentDate *time.Time `json:"treatmentDate"`
+	Target        string     `json:"target" binding:"required,oneof=varroa nosema foulbrood wax_moth other"`
+	Product       string     `json:"product" binding:"required"`
+	Dose          string     `json:"dose"`
+	NextDueDate   *time.Time `json:"nextDueDate"`
+	Notes         string     `json:"notes"`
+}
+
+// Su ürünleri sabitleri; balık partisi kayıtları ölüm, yemleme ve tartım örneklemesi olabilir
+const (
+	FishBatchActive     = "active"
+	FishBatchHarvested  = "harvested"
+	FishRecordMortality = "mortality"
+	FishRecordFeeding   = "feeding"
+	FishRecordSampling  = "sampling"
+)
+
+// Pond havuz veya kafes; aktif partilerin tahmini biyokütlesi ve m³ başına stok yoğunluğu ile döner
+type Pond struct {
+	ID              string      `json:"id" db:"id"`
+	UserID          string      `json:"userId" db:"user_id"`
+	Name            string      `json:"name" db:"name"`
+	LandID          *string     `json:"landId" db:"land_id"`
+	PondType        string      `json:"pondType" db:"pond_type"`
+	Area            *float64    `json:"area" db:"area"`
+	Volume          *float64    `json:"volume" db:"volume"`
+	WaterSource     string      `json:"waterSource" db:"water_source"`
+	Status          string      `json:"status" db:"status"`
+	Notes           string      `json:"notes" db:"notes"`
+	ActiveBatches   int         `json:"activeBatches" db:"-"`
+	Biomass         float64     `json:"biomass" db:"-"`
+	StockingDensity *float64    `json:"stockingDensity" db:"-"`
+	Batches         []FishBatch `json:"batches,omitempty" db:"-"`
+	CreatedAt       time.Time   `json:"createdAt" db:"created_at"`
+	UpdatedAt       time.Time   `json:"updatedAt" db:"updated_at"`
+}
+
+// PondRequest havuz oluşturma/güncelleme isteği; alan m², hacim m³ cinsindendir
+type PondRequest struct {
+	Name        string   `json:"name" binding:"required"`
+	LandID      *string  `json:"landId"`
+	PondType    string   `json:"pondType" binding:"omitempty,oneof=earthen concrete cage tank ras"`
+	Area        *float64 `json:"area" binding:"omitempty,gt=0"`
+	Volume      *float64 `json:"volume" binding:"omitempty,gt=0"`
+	WaterSource string   `json:"waterSource"`
+	Status      string   `json:"status" binding:"omitempty,oneof=active fallow inactive"`
+	Notes       string   `json:"notes"`
+}
+
+// FishBatch havuza stoklanan balık partisi; sayım, ortalama ağırlık (g), biyokütle (kg) ve yem dönüşüm oranı
+// (verilen yem / biyokütle artışı) kayıtlardan hesaplanır
+type FishBatch struct {
+	ID               string     `json:"id" db:"id"`
+	PondID           string     `json:"pondId" db:"pond_id"`
+	PondName         string     `json:"pondName" db:"-"`
+	Species          string     `json:"species" db:"species"`
+	StockingDate     time.Time  `json:"stockingDate" db:"stocking_date"`
+	InitialCount     int        `json:"initialCount" db:"initial_count"`
+	InitialAvgWeight float64    `json:"initialAvgWeight" db:"initial_avg_weight"`
+	Status           string     `json:"status" db:"status"`
+	HarvestDate      *time.Time `json:"harvestDate" db:"harvest_date"`
+	HarvestedCount   int        `json:"harvestedCount" db:"harvested_count"`
+	HarvestedWeight  float64    `json:"harvestedWeight" db:"harvested_weight"`
+	ProductionID     *string    `json:"productionId" db:"production_id"`
+	Notes            string     `json:"notes" db:"notes"`
+	MortalityCount   int        `json:"mortalityCount" db:"-"`
+	CurrentCount     int        `json:"currentCount" db:"-"`
+	AvgWeight        float64    `json:"avgWeight" db:"-"`
+	Biomass          float64    `json:"biomass" db:"-"`
+	BiomassGain      float64    `json:"biomassGain" db:"-"`
+	FeedGiven        float64    `json:"feedGiven" db:"-"`
+	FeedCost         float64    `json:"feedCost" db:"-"`
+	FCR              *float64   `json:"fcr" db:"-"`
+	SurvivalRate     float64    `json:"survivalRate" db:"-"`
+	DaysInPond       int        `json:"daysInPond" db:"-"`
+	CreatedAt        time.Time  `json:"createdAt" db:"created_at"`
+	UpdatedAt        time.Time  `json:"updatedAt" db:"updated_at"`
+}
+
+// FishBatchRequest balık partisi stoklama isteği; ortalama ağırlık gram cinsindendir
+type FishBatchRequest struct {
+	Species          string     `json:"species" binding:"required"`
+	StockingDate     *time.Time `json:"stockingDate"`
+	InitialCount     int        `json:"initialCount" binding:"required,gt=0"`
+	InitialAvgWeight float64    `json:"initialAvgWeight" binding:"required,gt=0"`
+	Notes            string     `json:"notes"`
+}
+
+// FishBatchRecord partinin ölüm, yemleme veya tartım örneklemesi kaydı
+type FishBatchRecord struct {
+	ID         string    `json:"id" db:"id"`
+	BatchID    string    `json:"batchId" db:"batch_id"`
+	RecordType string    `json:"recordType" db:"record_type"`
+	RecordDate time.Time `json:"recordDate" db:"record_date"`
+	Count      *int      `json:"count" db:"count"`
+	FeedKg     *float64  `json:"feedKg" db:"feed_kg"`
+	FeedType   string    `json:"feedType" db:"feed_type"`
+	Cost       *float64  `json:"cost" db:"cost"`
+	AvgWeight  *float64  `json:"avgWeight" db:"avg_weight"`
+	Notes      string    `json:"notes" db:"notes"`
+	CreatedAt  time.Time `json:"createdAt" db:"created_at"`
+}
+
+// FishBatchRecordRequest parti kaydı isteği; ölümde count, yemlemede feedKg, örneklemede avgWeight (g) gerekir
+type FishBatchRecordRequest struct {
+	RecordType string     `json:"recordType" binding:"required,oneof=mortality feeding sampling"`
+	RecordDate *time.Time `json:"recordDate"`
+	Count      *int       `json:"count" binding:"omitempty,gt=0"`
+	FeedKg     *float64   `json:"feedKg" binding:"omitempty,gt=0"`
+	FeedType   string     `json:"feedType"`
+	Cost       *float64   `json:"cost" binding:"omitempty,min=0"`
+	AvgWeight  *float64   `json:"avgWeight" binding:"omitempty,gt=0"`
+	Notes      string     `json:"notes"`
+}
+
+// FishBatchHarvestRequest parti hasadı isteği; ağırlık kg cinsindendir, adet verilmezse kalan balık sayısı kullanılır
+type FishBatchHarvestRequest struct {
+	HarvestDate     *time.Time `json:"harvestDate"`
+	Count           *int       `json:"count" binding:"omitempty,gt=0"`
+	Weight          float64    `json:"weight" binding:"required,gt=0"`
+	Quality         string     `json:"quality"`
+	StorageLocation string     `json:"storageLocation"`
+	Notes           string     `json:"notes"`
+}
+
+// FishBatchHarvestResult hasat edilen parti ve oluşturulan üretim kaydı
+type FishBatchHarvestResult struct {
+	Batch      FishBatch  `json:"batch"`
+	Production Production `json:"production"`
+}
+
+// FeedConversionReport partilerin ve türlerin yem dönüşüm oranı analizi
+type FeedConversionReport struct {
+	Batches   []FishBatch           `json:"batches"`
+	BySpecies []FeedConversionGroup `json:"bySpecies"`
+	Overall   FeedConversionGroup   `json:"overall"`
+}
+
+// FeedConversionGroup bir tür veya tüm partiler için toplam yem, biyokütle artışı ve yem dönüşüm oranı
+type FeedConversionGroup struct {
+	Species         string   `json:"species,omitempty"`
+	Batches         int      `json:"batches"`
+	FeedGiven       float64  `json:"feedGiven"`
+	FeedCost        float64  `json:"feedCost"`
+	BiomassGain     float64  `json:"biomassGain"`
+	FCR             *float64 `json:"fcr"`
+	FeedCostPerKg   *float64 `json:"feedCostPerKg"`
+	AvgSurvivalRate float64  `json:"avgSurvivalRate"`
+}
+
+// EntityNote kayda eklenen zaman çizelgesi notu; notlar değiştirilmez, kaydın eski notes alanı son notu tutar
+type EntityNote struct {
+	ID          string           `json:"id" db:"id"`
+	EntityType  string           `json:"entityType" db:"entity_type"`
+	EntityID    string           `json:"entityId" db:"entity_id"`
+	AuthorID    string           `json:"authorId" db:"author_id"`
+	AuthorName  string           `json:"authorName" db:"-"`
+	Text        string           `json:"text" db:"text"`
+	Mentions    []FarmMember     `json:"mentions" db:"mentions"`
+	Attachments []NoteAttachment `json:"attachments" db:"attachments"`
+	Legacy      bool             `json:"legacy" db:"legacy"`
+	CreatedAt   time.Time        `json:"createdAt" db:"created_at"`
+}
+
+// EntityNoteRequest not ekleme isteği; mentions çiftlik üyelerinin kullanıcı ID'leridir, metindeki
+// @eposta-adı (e-postanın @ öncesi) etiketleri de üyelere çözülür
+type EntityNoteRequest struct {
+	Text        string           `json:"text" binding:"required,max=5000"`
+	Mentions    []string         `json:"mentions"`
+	Attachments []NoteAttachment `json:"attachments" binding:"omitempty,max=10,dive"`
+}
+
+// NoteAttachment nota bağlanan medya eki veya doküman
+type NoteAttachment struct {
+	Type string `json:"type" binding:"required,oneof=media document"`
+	ID   string `json:"id" binding:"required"`
+	Name string `json:"name,omitempty"`
+}
+
+// FarmMember çiftlik notlarında etiketlenebilen kullanıcı; rol owner, veterinarian veya cooperative olabilir
+type FarmMember struct {
+	UserID string `json:"userId"`
+	Name   string `json:"name"`
+	Email  string `json:"email,omitempty"`
+	Handle string `json:"handle,omitempty"`
+	Role   string `json:"role,omitempty"`
+}
+
+// LandListResponse sayfalı arazi listesi
+type LandListResponse struct {
+	Lands      []Land     `json:"lands"`
+	Pagination Pagination `json:"pagination"`
+}
+
+// LivestockListResponse sayfalı hayvan listesi
+type LivestockListResponse struct {
+	Animals    []Livestock `json:"animals"`
+	Pagination Pagination  `json:"pagination"`
+}
+
+// ProductionListResponse sayfalı üretim listesi
+type ProductionListResponse struct {
+	Productions []Production `json:"productions"`
+	Pagination  Pagination   `json:"pagination"`
+}
+
+// TransactionListResponse sayfalı finansal işlem listesi
+type TransactionListResponse struct {
+	Transactions []Transaction `json:"transactions"`
+	Pagination   Pagination    `json:"pagination"`
+}
+
+// NotificationListResponse sayfalı bildirim listesi ve okunmamış bildirim sayısı
+type NotificationListResponse struct {
+	Notifications []NotificationExtended `json:"notifications"`
+	Pagination    Pagination             `json:"pagination"`
+	UnreadCount   int                    `json:"unreadCount"`
+}
+
+// NotificationPreferences bildirim kanalları, türleri ve sessiz saatler
+type NotificationPreferences struct {
+	PushNotifications  bool                   `json:"pushNotifications"`
+	EmailNotifications bool                   `json:"emailNotifications"`
+	SMSNotifications   bool                   `json:"smsNotifications"`
+	NotificationTypes  NotificationTypeToggle `json:"notificationTypes"`
+	QuietHours         QuietHours             `json:"quietHours"`
+}
+
+// NotificationTypeToggle bildirim türlerinin açık/kapalı durumu
+type NotificationTypeToggle struct {
+	Reminders bool `json:"reminders"`
+	Alerts    bool `json:"alerts"`
+	Updates   bool `json:"updates"`
+	Marketing bool `json:"marketing"`
+}
+
+// QuietHours bildirim gönderilmeyen saat aralığı (HH:MM)
+type QuietHours struct {
+	Enabled   bool   `json:"enabled"`
+	StartTime string `json:"startTime"`
+	EndTime   string `json:"endTime"`
+}
+
+// ProductivityAnalysis arazi verimlilik analizi
+type ProductivityAnalysis struct {
+	Period              string  `json:"period"`
+	AverageProductivity float64 `json:"averageProductivity"`
+	MaxProductivity     float64 `json:"maxProductivity"`
+	MinProductivity     float64 `json:"minProductivity"`
+	TotalLands          int     `json:"totalLands"`
+}
+
+// RecentActivity dashboard son aktivite kaydı
+type RecentActivity struct {
+	Type        string    `json:"type"`
+	Title       string    `json:"title"`
+	Description string    `json:"description"`
+	Date        time.Time `json:"date"`
+	Category    string    `json:"category"`
+	Icon        string    `json:"icon"`
+}
+
+// IncomeExpenseChart aylık gelir-gider grafik serileri; seriler labels ile aynı sıradadır
+type IncomeExpenseChart struct {
+	Labels          []string        `json:"labels"`
+	Income          []float64       `json:"income"`
+	Expense         []float64       `json:"expense"`
+	Profit          []float64       `json:"profit"`
+	IncomeForecast  *ForecastSeries `json:"incomeForecast,omitempty"`
+	ExpenseForecast *ForecastSeries `json:"expenseForecast,omitempty"`
+}
+
+// ProductionChart üretim kategorisi grafik serileri
+type ProductionChart struct {
+	Categories []string `json:"categories"`
+	Values     []int    `json:"values"`
+	Colors     []string `json:"colors"`
+}
+
+// Dashboard grafik türleri
+const (
+	ChartTypeLine       = "line"
+	ChartTypeBar        = "bar"
+	ChartTypeStackedBar = "stacked_bar"
+	ChartTypePie        = "pie"
+)
+
+// Grafik parametre türleri
+const (
+	ChartParamInteger = "integer"
+	ChartParamString  = "string"
+	ChartParamEnum    = "enum"
+)
+
+// ChartConfig dashboard'da gösterilebilecek grafiğin tanımı; istemci grafiği endpoint ve parametrelerle çizer
+type ChartConfig struct {
+	ID          string           `json:"id"`
+	Title       string           `json:"title"`
+	Description string           `json:"description"`
+	Type        string           `json:"type" enums:"line,bar,stacked_bar,pie"`
+	Endpoint    string           `json:"endpoint"`
+	Schema      string           `json:"schema" enums:"ChartData,IncomeExpenseChart,ProductionChart"`
+	Unit        string           `json:"unit,omitempty"`
+	Parameters  []ChartParameter `json:"parameters"`
+}
+
+// ChartParameter grafik endpoint'inin sorgu parametresi
+type ChartParameter struct {
+	Name        string   `json:"name"`
+	Type        string   `json:"type" enums:"integer,string,enum"`
+	Required    bool     `json:"required"`
+	Default     string   `json:"default,omitempty"`
+	Options     []string `json:"options,omitempty"`
+	Min         *int     `json:"min,omitempty"`
+	Max         *int     `json:"max,omitempty"`
+	Description string   `json:"description"`
+}
+
+// ChartData genel grafik verisi; her serinin değerleri labels ile aynı sıradadır
+type ChartData struct {
+	ChartID  string          `json:"chartId"`
+	Unit     string          `json:"unit,omitempty"`
+	Labels   []string        `json:"labels"`
+	Series   []ChartSeries   `json:"series"`
+	Forecast *ForecastSeries `json:"forecast,omitempty"`
+}
+
+// ChartSeries grafik serisi
+type ChartSeries struct {
+	Key    string    `json:"key"`
+	Label  string    `json:"label"`
+	Values []float64 `json:"values"`
+}
+
+// Zaman serisi kova (bucket) türleri
+const (
+	BucketDay     = "day"
+	BucketWeek    = "week"
+	BucketMonth   = "month"
+	BucketQuarter = "quarter"
+	BucketYear    = "year"
+)
+
+// Zaman serisi toplama yöntemleri
+const (
+	AggregationSum = "sum"
+	AggregationAvg = "avg"
+)
+
+// TimeSeriesMetric zaman serisi olarak sorgulanabilen metriğin tanımı
+type TimeSeriesMetric struct {
+	Key         string   `json:"key"`
+	Label       string   `json:"label"`
+	Unit        string   `json:"unit"`
+	Aggregation string   `json:"aggregation" enums:"sum,avg"`
+	Filters     []string `json:"filters"`
+}
+
+// TimeSeries metriğin kovalara bölünmüş değerleri; aralıktaki her kova boş olsa da listelenir
+type TimeSeries struct {
+	Metric      string            `json:"metric"`
+	Unit        string            `json:"unit"`
+	Aggregation string            `json:"aggregation" enums:"sum,avg"`
+	Bucket      string            `json:"bucket" enums:"day,week,month,quarter,year"`
+	From        string            `json:"from"`
+	To          string            `json:"to"`
+	Total       *float64          `json:"total"`
+	Points      []TimeSeriesPoint `json:"points"`
+}
+
+// TimeSeriesPoint tek kovanın değeri; sum metriklerinde boş kova 0, avg metriklerinde null döner
+type TimeSeriesPoint struct {
+	Bucket string   `json:"bucket"`
+	Start  string   `json:"start"`
+	End    string   `json:"end"`
+	Value  *float64 `json:"value"`
+	Count  int      `json:"count"`
+}
+
+// Anomali kontrol türleri
+const (
+	AnomalyExpenseSpike = "expense_spike"
+	AnomalyMilkDrop     = "milk_drop"
+	AnomalyInactivity   = "inactivity"
+)
+
+// MetricAnomaly zamanlanmış kontrollerin bulduğu olağandışı değer; aynı kontrol aynı dönem için bir kez kaydedilir
+type MetricAnomaly struct {
+	ID            string    `json:"id"`
+	Check         string    `json:"check" enums:"expense_spike,milk_drop,inactivity"`
+	Metric        string    `json:"metric"`
+	Period        string    `json:"period"`
+	Value         float64   `json:"value"`
+	Baseline      float64   `json:"baseline"`
+	ChangePercent *float64  `json:"changePercent,omitempty"`
+	Message       string    `json:"message"`
+	Link          string    `json:"link,omitempty"`
+	DetectedAt    time.Time `json:"detectedAt"`
+}
+
+// Tahmin yöntemleri
+const (
+	ForecastHoltWinters   = "holt_winters"
+	ForecastMovingAverage = "moving_average"
+)
+
+// ForecastSeries metriğin gelecek aylar için tahmini; bantlar %95 güven aralığıdır
+type ForecastSeries struct {
+	Metric   string            `json:"metric"`
+	Unit     string            `json:"unit"`
+	Method   string            `json:"method" enums:"holt_winters,moving_average"`
+	History  int               `json:"history"`
+	Points   []ForecastPoint   `json:"points"`
+	Accuracy *ForecastAccuracy `json:"accuracy,omitempty"`
+}
+
+// ForecastPoint tek ayın tahmini
+type ForecastPoint struct {
+	Period string  `json:"period"`
+	Value  float64 `json:"value"`
+	Lower  float64 `json:"lower"`
+	Upper  float64 `json:"upper"`
+}
+
+// ForecastAccuracy geçmiş tahminlerin gerçekleşen değerlerle karşılaştırması
+type ForecastAccuracy struct {
+	Evaluated   int                  `json:"evaluated"`
+	MAE         float64              `json:"mae"`
+	MAPE        *float64             `json:"mape"`
+	WithinBand  float64              `json:"withinBand"`
+	Evaluations []ForecastEvaluation `json:"evaluations"`
+}
+
+// ForecastEvaluation gerçekleşmiş bir ayın kayıtlı tahmini ve gerçek değeri
+type ForecastEvaluation struct {
+	Period   string  `json:"period"`
+	Forecast float64 `json:"forecast"`
+	Lower    float64 `json:"lower"`
+	Upper    float64 `json:"upper"`
+	Actual   float64 `json:"actual"`
+	Error    float64 `json:"error"`
+}
+
+// Danışman mesaj rolleri
+const (
+	AdvisorRoleSystem    = "system"
+	AdvisorRoleUser      = "user"
+	AdvisorRoleAssistant = "assistant"
+)
+
+// AdvisorAskRequest danışmana soru isteği; conversationId verilirse soru mevcut konuşmaya eklenir
+type AdvisorAskRequest struct {
+	Question       string `json:"question" binding:"required"`
+	ConversationID string `json:"conversationId"`
+}
+
+// AdvisorAnswer danışmanın yanıtı ve güncel kullanım durumu
+type AdvisorAnswer struct {
+	ConversationID string         `json:"conversationId"`
+	Question       AdvisorMessage `json:"question"`
+	Answer         AdvisorMessage `json:"answer"`
+	Usage          AdvisorUsage   `json:"usage"`
+}
+
+// AdvisorConversation danışman konuşması
+type AdvisorConversation struct {
+	ID           string           `json:"id"`
+	Title        string           `json:"title"`
+	MessageCount int              `json:"messageCount"`
+	Messages     []AdvisorMessage `json:"messages,omitempty"`
+	CreatedAt    time.Time        `json:"createdAt"`
+	UpdatedAt    time.Time        `json:"updatedAt"`
+}
+
+// AdvisorMessage konuşmadaki soru veya yanıt; token ve maliyet yalnızca yanıtlarda dolu olur
+type AdvisorMessage struct {
+	ID           string    `json:"id"`
+	Role         string    `json:"role" enums:"user,assistant"`
+	Content      string    `json:"content"`
+	InputTokens  int       `json:"inputTokens,omitempty"`
+	OutputTokens int       `json:"outputTokens,omitempty"`
+	Cost         float64   `json:"cost,omitempty"`
+	CreatedAt    time.Time `json:"createdAt"`
+}
+
+// AdvisorUsage hesabın saatlik soru sayısı ve aylık maliyeti (USD)
+type AdvisorUsage struct {
+	Provider         string  `json:"provider"`
+	RequestsLastHour int     `json:"requestsLastHour"`
+	HourlyLimit      int     `json:"hourlyLimit"`
+	MonthlyCost      float64 `json:"monthlyCost"`
+	MonthlyBudget    float64 `json:"monthlyBudget"`
+}
+
+// Zararlı/hastalık gözlemi değerleri
+const (
+	DiagnosisSubjectPlant  = "plant"
+	DiagnosisSubjectAnimal = "animal"
+
+	ObservationCategoryPest       = "pest"
+	ObservationCategoryDisease    = "disease"
+	ObservationCategoryDeficiency = "deficiency"
+	ObservationCategoryOther      = "other"
+
+	ObservationStatusSuspected = "suspected"
+	ObservationStatusConfirmed = "confirmed"
+	ObservationStatusDismissed = "dismissed"
+
+	ObservationSourcePhoto    = "photo"
+	ObservationSourceManual   = "manual"
+	ObservationSourceScouting = "scouting"
+)
+
+// DiagnosisCandidate görsel sağlayıcısının önerdiği olası teşhis; confidence 0-1 arasıdır
+type DiagnosisCandidate struct {
+	Name        string  `json:"name"`
+	Category    string  `json:"category" enums:"pest,disease,deficiency,other"`
+	Confidence  float64 `json:"confidence"`
+	Description string  `json:"description,omitempty"`
+}
+
+// DiagnosisResult fotoğraf teşhisi sonucu; observation yalnızca gözlem oluşturulması istendiğinde doludur
+type DiagnosisResult struct {
+	Provider    string                  `json:"provider"`
+	SubjectType string                  `json:"subjectType"`
+	Candidates  []DiagnosisCandidate    `json:"candidates"`
+	Observation *PestDiseaseObservation `json:"observation,omitempty"`
+}
+
+// PestDiseaseObservation arazide veya hayvanda görülen zararlı/hastalık gözlemi
+type PestDiseaseObservation struct {
+	ID          string               `json:"id"`
+	SubjectType string               `json:"subjectType" enums:"plant,animal"`
+	LandID      *string              `json:"landId"`
+	LivestockID *string              `json:"livestockId"`
+	Name        string               `json:"name"`
+	Category    string               `json:"category" enums:"pest,disease,deficiency,other"`
+	Confidence  *float64             `json:"confidence"`
+	Status      string               `json:"status" enums:"suspected,confirmed,dismissed"`
+	Source      string               `json:"source" enums:"photo,manual,scouting"`
+	PhotoID     *string              `json:"photoId"`
+	Candidates  []DiagnosisCandidate `json:"candidates"`
+	ObservedOn  string               `json:"observedOn"`
+	Notes       string               `json:"notes"`
+	CreatedAt   time.Time            `json:"createdAt"`
+	UpdatedAt   time.Time            `json:"updatedAt"`
+}
+
+// UpdatePestDiseaseObservationRequest gözlemi doğrulama veya düzeltme isteği
+type UpdatePestDiseaseObservationRequest struct {
+	Name     *string `json:"name"`
+	Category *string `json:"category" binding:"omitempty,oneof=pest disease deficiency other"`
+	Status   *string `json:"status" binding:"omitempty,oneof=suspected confirmed dismissed"`
+	Notes    *string `json:"notes"`
+}
+
+// SystemInfo uygulama sürümü, depolama kullanımı ve kayıt sayıları
+type SystemInfo struct {
+	AppVersion string `json:"appVersion"`
+	APIVersion string `json:"apiVersion"`
+	LastBackup string `json:"lastBackup"`
+	// StorageUsed veritabanı dosyasının (WAL dahil) MB cinsinden boyutu
+	StorageUsed  float64         `json:"storageUsed"`
+	StorageLimit float64         `json:"storageLimit"`
+	Features     []string        `json:"features"`
+	Support      SupportInfo     `json:"support"`
+	DataStats    SystemDataStats `json:"dataStats"`
+	Database     DatabaseStats   `json:"database"`
+	// TableCounts çiftliğin tablo bazında kayıt sayıları (boş tablolar hariç)
+	TableCounts map[string]int `json:"tableCounts"`
+}
+
+// SystemDataStats kullanıcının kayıt sayıları
+type SystemDataStats struct {
+	Lands        int `json:"lands"`
+	Animals      int `json:"animals"`
+	Productions  int `json:"productions"`
+	Transactions int `json:"transactions"`
+}
+
+// DatabaseStats SQLite veritabanı dosyasının boyutu ve sayfa bilgileri
+type DatabaseStats struct {
+	JournalMode    string  `json:"journalMode"`
+	FileSizeBytes  int64   `json:"fileSizeBytes"`
+	WALSizeBytes   int64   `json:"walSizeBytes"`
+	TotalSizeBytes int64   `json:"totalSizeBytes"`
+	PageSize       int64   `json:"pageSize"`
+	PageCount      int64   `json:"pageCount"`
+	FreePages      int64   `json:"freePages"`
+	FreeRatio      float64 `json:"freeRatio"`
+	// LastVacuumAt ve LastAnalyzeAt son başarılı bakım işlerinin zamanı
+	LastVacuumAt  *time.Time `json:"lastVacuumAt"`
+	LastAnalyzeAt *time.Time `json:"lastAnalyzeAt"`
+}
+
+// Veritabanı bakım işi durumları
+const (
+	DatabaseMaintenanceRunning   = "running"
+	DatabaseMaintenanceCompleted = "completed"
+	DatabaseMaintenanceFailed    = "failed"
+)
+
+// DatabaseMaintenanceRun VACUUM/ANALYZE ve WAL checkpoint bakım işi
+type DatabaseMaintenanceRun struct {
+	ID string `json:"id"`
+	// Trigger scheduled veya manual
+	Trigger      string `json:"trigger"`
+	Status       string `json:"status"`
+	Analyzed     bool   `json:"analyzed"`
+	Vacuumed     bool   `json:"vacuumed"`
+	Checkpointed bool   `json:"checkpointed"`
+	// FreePagesBefore bakım öncesi boş sayfa sayısı; oran eşiği aşınca VACUUM çalışır
+	FreePagesBefore int64      `json:"freePagesBefore"`
+	SizeBeforeBytes int64      `json:"sizeBeforeBytes"`
+	SizeAfterBytes  int64      `json:"sizeAfterBytes"`
+	ReclaimedBytes  int64      `json:"reclaimedBytes"`
+	DurationMs      int64      `json:"durationMs"`
+	Error           string     `json:"error,omitempty"`
+	RequestedBy     string     `json:"requestedBy,omitempty"`
+	StartedAt       time.Time  `json:"startedAt"`
+	FinishedAt      *time.Time `json:"finishedAt"`
+}
+
+// DatabaseMaintenanceRequest elle başlatılan bakım işi
+type DatabaseMaintenanceRequest struct {
+	// Vacuum boş sayfa oranından bağımsız olarak VACUUM çalıştırır
+	Vacuum bool `json:"vacuum"`
+}
+
+// DatabaseMaintenanceReport veritabanı boyutu, tablo kayıt sayıları ve son bakım işleri
+type DatabaseMaintenanceReport struct {
+	Database    DatabaseStats            `json:"database"`
+	TableCounts map[string]int           `json:"tableCounts"`
+	Runs        []DatabaseMaintenanceRun `json:"runs"`
+}
+
+// Yedek durumları ve tetikleyicileri
+const (
+	BackupStatusRunning   = "running"
+	BackupStatusCompleted = "completed"
+	BackupStatusFailed    = "failed"
+
+	BackupTriggerManual    = "manual"
+	BackupTriggerScheduled = "scheduled"
+	BackupTriggerExternal  = "external"
+)
+
+// BackupResult oluşturulan veri yedeği
+type BackupResult struct {
+	BackupID string `json:"backupId"`
+	// Status running, completed veya failed
+	Status string `json:"status"`
+	// Trigger manual, scheduled veya yalnızca depolamada bulunan yedekler için external
+	Trigger     string         `json:"trigger"`
+	Storage     string         `json:"storage"`
+	CreatedAt   string         `json:"createdAt"`
+	CompletedAt string         `json:"completedAt,omitempty"`
+	Size        string         `json:"size"`
+	SizeBytes   int64          `json:"sizeBytes"`
+	DownloadURL string         `json:"downloadUrl"`
+	ExpiresAt   string         `json:"expiresAt"`
+	Includes    []string       `json:"includes"`
+	Records     map[string]int `json:"records,omitempty"`
+	// Available yedek dosyasının depolamada bulunup bulunmadığı
+	Available bool   `json:"available"`
+	Error     string `json:"error,omitempty"`
+}
+
+// BackupListResponse çiftliğin yedekleri
+type BackupListResponse struct {
+	Storage string         `json:"storage"`
+	Backups []BackupResult `json:"backups"`
+}
+
+// AdminBackup yönetici listesindeki çiftlik yedeği
+type AdminBackup struct {
+	FarmID string `json:"farmId"`
+	BackupResult
+}
+
+// AdminBackupListResponse yönetici yedek listesi
+type AdminBackupListResponse struct {
+	Storage    string        `json:"storage"`
+	Backups    []AdminBackup `json:"backups"`
+	Pagination Pagination    `json:"pagination"`
+}
+
+// RestoreRequest yedekten geri yükleme isteği
+type RestoreRequest struct {
+	// BackupFile geri yüklenecek yedeğin ID'si (GET /settings/backups)
+	BackupFile     string         `json:"backupFile" binding:"required"`
+	RestoreOptions RestoreOptions `json:"restoreOptions"`
+}
+
+// RestoreOptions geri yüklenecek veri grupları
+type RestoreOptions struct {
+	IncludeFinance    bool `json:"includeFinance"`
+	IncludeLivestock  bool `json:"includeLivestock"`
+	IncludeLands      bool `json:"includeLands"`
+	IncludeProduction bool `json:"includeProduction"`
+	// IncludeOther takvim, notlar, şablonlar, uyum listeleri gibi diğer kayıtlar
+	IncludeOther bool `json:"includeOther"`
+}
+
+// RestoreResult geri yükleme sonucu
+type RestoreResult struct {
+	RestoreID  string           `json:"restoreId"`
+	Status     string           `json:"status"`
+	RestoredAt string           `json:"restoredAt"`
+	BackupFile string           `json:"backupFile"`
+	Restored   RestoredSections `json:"restored"`
+	Summary    RestoreSummary   `json:"summary"`
+}
+
+// RestoredSections geri yüklenen veri grupları
+type RestoredSections struct {
+	Lands      bool `json:"lands"`
+	Livestock  bool `json:"livestock"`
+	Finance    bool `json:"finance"`
+	Production bool `json:"production"`
+	Other      bool `json:"other"`
+}
+
+// RestoreSummary geri yüklenen kayıt sayıları
+type RestoreSummary struct {
+	RestoredLands        int `json:"restoredLands"`
+	RestoredAnimals      int `json:"restoredAnimals"`
+	RestoredTransactions int `json:"restoredTransactions"`
+	RestoredProductions  int `json:"restoredProductions"`
+	// Records tablo bazında geri yüklenen kayıt sayıları
+	Records map[string]int `json:"records"`
+}
+
+// FinancePeriodSummary mali takvim periyodunun finansal özeti
+type FinancePeriodSummary struct {
+	Period          PeriodRange   `json:"period"`
+	TotalIncome     float64       `json:"totalIncome"`
+	TotalExpense    float64       `json:"totalExpense"`
+	NetProfit       float64       `json:"netProfit"`
+	PendingPayments float64       `json:"pendingPayments"`
+	OverduePayments float64       `json:"overduePayments"`
+	Trends          FinanceTrends `json:"trends"`
+}
+
+// FinanceTrends önceki periyoda göre yüzde değişimler
+type FinanceTrends struct {
+	Income  float64 `json:"income"`
+	Expense float64 `json:"expense"`
+	Profit  float64 `json:"profit"`
+}
+
+// FinanceAnalysis aylık gelir-gider dağılımı ve tür bazında kategori kırılımı
+type FinanceAnalysis struct {
+	Monthly    []FinanceMonth           `json:"monthly"`
+	ByCategory FinanceCategoryBreakdown `json:"byCategory"`
+	Totals     FinanceTotals            `json:"totals"`
+}
+
+// FinanceMonth aylık gelir, gider ve kâr
+type FinanceMonth struct {
+	Month   string  `json:"month"`
+	Income  float64 `json:"income"`
+	Expense float64 `json:"expense"`
+	Profit  float64 `json:"profit"`
+}
+
+// FinanceCategoryBreakdown gelir ve gider kategorileri
+type FinanceCategoryBreakdown struct {
+	Income  []FinanceCategoryTotal `json:"income"`
+	Expense []FinanceCategoryTotal `json:"expense"`
+}
+
+// FinanceCategoryTotal kategori toplamı; yüzde kendi türünün toplamına göredir, categories yalnızca other
+// grubunda toplanan kategori sayısıdır
+type FinanceCategoryTotal struct {
+	Category   string  `json:"category"`
+	Amount     float64 `json:"amount"`
+	Count      int     `json:"count"`
+	Average    float64 `json:"average"`
+	Percentage float64 `json:"percentage"`
+	Categories int     `json:"categories,omitempty"`
+}
+
+// FinanceTotals tür bazında toplamlar
+type FinanceTotals struct {
+	Income  float64 `json:"income"`
+	Expense float64 `json:"expense"`
+}
+
+// ReportRequest rapor oluşturma isteği
+type ReportRequest struct {
+	Type          string   `json:"type"`
+	Period        string   `json:"period"`
+	StartDate     string   `json:"startDate"`
+	EndDate       string   `json:"endDate"`
+	Format        string   `json:"format"`
+	IncludeCharts bool     `json:"includeCharts"`
+	Categories    []string `json:"categories"`
+}
+
+// Report oluşturulmuş rapor
+type Report struct {
+	ID            string            `json:"id"`
+	Title         string            `json:"title"`
+	Type          string            `json:"type"`
+	Description   string            `json:"description"`
+	GeneratedDate string            `json:"generatedDate"`
+	Period        string            `json:"period"`
+	Format        string            `json:"format"`
+	Status        string            `json:"status,omitempty"`
+	DownloadURL   string            `json:"downloadUrl"`
+	Parameters    *ReportParameters `json:"parameters,omitempty"`
+}
+
+// ReportListResponse sayfalı rapor listesi
+type ReportListResponse struct {
+	Reports    []Report   `json:"reports"`
+	Pagination Pagination `json:"pagination"`
+}
+
+// Arka plan işi durumları
+const (
+	JobStatusQueued    = "queued"
+	JobStatusRunning   = "running"
+	JobStatusCompleted = "completed"
+	JobStatusFailed    = "failed"
+)
+
+// Arka plan işi türleri
+const (
+	JobTypeReport  = "report"
+	JobTypeArchive = "archive"
+)
+
+// Job kuyruğa alınan arka plan işi; resultId işin ürettiği kaydın (ör. rapor) kimliğidir
+type Job struct {
+	ID         string     `json:"id" db:"id"`
+	Type       string     `json:"type" db:"type"`
+	Status     string     `json:"status" db:"status"`
+	Attempts   int        `json:"attempts" db:"attempts"`
+	ResultID   *string    `json:"resultId" db:"result_id"`
+	Error      *string    `json:"error" db:"error"`
+	CreatedAt  time.Time  `json:"createdAt" db:"created_at"`
+	StartedAt  *time.Time `json:"startedAt" db:"started_at"`
+	FinishedAt *time.Time `json:"finishedAt" db:"finished_at"`
+}
+
+// ReportJob rapor oluşturma işi; iş tamamlandığında oluşturulan rapor report alanında döner
+type ReportJob struct {
+	Job
+	Report *Report `json:"report,omitempty"`
+}
+
+// ReportParameters rapor oluşturma parametreleri
+type ReportParameters struct {
+	StartDate     string   `json:"startDate"`
+	EndDate       string   `json:"endDate"`
+	IncludeCharts bool     `json:"includeCharts"`
+	Categories    []string `json:"categories"`
+}
+
+// PerformanceMetrics performans puanları ve değişimleri
+type PerformanceMetrics struct {
+	Efficiency     float64       `json:"efficiency"`
+	Productivity   float64       `json:"productivity"`
+	Profitability  float64       `json:"profitability"`
+	Sustainability float64       `json:"sustainability"`
+	Trends         []MetricTrend `json:"trends"`
+}
+
+// MetricTrend metriğin değeri ve önceki periyoda göre değişimi
+type MetricTrend struct {
+	Metric string  `json:"metric"`
+	Value  float64 `json:"value"`
+	Change float64 `json:"change"`
+	Trend  string  `json:"trend"`
+}
+
+// ComparisonAnalysis iki periyodun karşılaştırması
+type ComparisonAnalysis struct {
+	Period1 string            `json:"period1"`
+	Period2 string            `json:"period2"`
+	Metrics ComparisonMetrics `json:"metrics"`
+	Summary ComparisonSummary `json:"summary"`
+}
+
+// ComparisonMetrics karşılaştırılan metrikler
+type ComparisonMetrics struct {
+	Income     MetricComparison `json:"income"`
+	Expense    MetricComparison `json:"expense"`
+	Profit     MetricComparison `json:"profit"`
+	Production MetricComparison `json:"production"`
+}
+
+// MetricComparison metriğin iki periyottaki değeri ve yüzde değişimi
+type MetricComparison struct {
+	Period1 float64 `json:"period1"`
+	Period2 float64 `json:"period2"`
+	Change  float64 `json:"change"`
+	Trend   string  `json:"trend"`
+}
+
+// ComparisonSummary karşılaştırma özeti
+type ComparisonSummary struct {
+	OverallTrend   string `json:"overallTrend"`
+	KeyImprovement string `json:"keyImprovement"`
+	AreaForFocus   string `json:"areaForFocus"`
+}
+
+// Mesaj kanalları
+const (
+	MessageChannelNotification = "notification"
+	MessageChannelEmail        = "email"
+)
+
+// Mesaj şablonu kaynakları
+const (
+	MessageTemplateSourceFile     = "file"
+	MessageTemplateSourceDatabase = "database"
+)
+
+// MessageTemplate bildirim veya e-posta şablonu; başlık ve metin Go text/template sözdizimindedir
+type MessageTemplate struct {
+	Channel   string     `json:"channel" enums:"notification,email"`
+	Key       string     `json:"key"`
+	Language  string     `json:"language"`
+	Title     string     `json:"title"`
+	Body      string     `json:"body"`
+	Source    string     `json:"source" enums:"file,database"`
+	UpdatedAt *time.Time `json:"updatedAt,omitempty"`
+}
+
+// SaveMessageTemplateRequest şablon kaydetme isteği
+type SaveMessageTemplateRequest struct {
+	Title string `json:"title" binding:"required"`
+	Body  string `json:"body" binding:"required"`
+}
+
+// MessageTemplatePreviewRequest şablon önizleme isteği; title veya body verilirse kaydedilmemiş taslak önizlenir
+type MessageTemplatePreviewRequest struct {
+	Channel  string                 `json:"channel" binding:"required" enums:"notification,email"`
+	Key      string                 `json:"key" binding:"required"`
+	Language string                 `json:"language" binding:"required"`
+	Title    *string                `json:"title,omitempty"`
+	Body     *string                `json:"body,omitempty"`
+	Data     map[string]interface{} `json:"data,omitempty"`
+}
+
+// RenderedMessage doldurulmuş şablon
+type RenderedMessage struct {
+	Channel  string `json:"channel"`
+	Key      string `json:"key"`
+	Language string `json:"language"`
+	Source   string `json:"source" enums:"file,database,draft"`
+	Title    string `json:"title"`
+	Body     string `json:"body"`
+}
+
+// RecordLinkRelated ilişki türü verilmeyen bağlantıların varsayılan ilişkisi
+const RecordLinkRelated = "related"
+
+// RecordLink iki kayıt arasındaki serbest ilişki (ör. gider ↔ arazi aktivitesi, etkinlik ↔ ekipman); bağlantılar
+// yönsüzdür ve her iki kaydın bağlantı listesinde görünür
+type RecordLink struct {
+	ID        string        `json:"id" db:"id"`
+	Relation  string        `json:"relation" db:"relation"`
+	Source    RelatedEntity `json:"source" db:"-"`
+	Target    RelatedEntity `json:"target" db:"-"`
+	Note      string        `json:"note,omitempty" db:"note"`
+	CreatedBy string        `json:"createdBy" db:"created_by"`
+	CreatedAt time.Time     `json:"createdAt" db:"created_at"`
+}
+
+// CreateRecordLinkRequest kayıt bağlantısı oluşturma isteği; relation verilmezse "related" kullanılır
+type CreateRecordLinkRequest struct {
+	SourceType string `json:"sourceType" binding:"required"`
+	SourceID   string `json:"sourceId" binding:"required"`
+	TargetType string `json:"targetType" binding:"required"`
+	TargetID   string `json:"targetId" binding:"required"`
+	Relation   string `json:"relation,omitempty" binding:"omitempty,max=50"`
+	Note       string `json:"note,omitempty" binding:"omitempty,max=500"`
+}
+
+// SlowQuery eşiği aşan sorgu; parametre değerleri saklanmaz, yalnızca sayısı tutulur
+type SlowQuery struct {
+	ID         string    `json:"id"`
+	Query      string    `json:"query"`
+	ParamCount int       `json:"paramCount"`
+	DurationMs float64   `json:"durationMs"`
+	Endpoint   string    `json:"endpoint,omitempty"`
+	OccurredAt time.Time `json:"occurredAt"`
+}
+
+// SlowQueryReport sunucu başladığından beri çalışan sorgu sayıları ve son yavaş sorgular
+type SlowQueryReport struct {
+	ThresholdMs  int64       `json:"thresholdMs"`
+	TotalQueries int64       `json:"totalQueries"`
+	SlowQueries  int64       `json:"slowQueries"`
+	Recent       []SlowQuery `json:"recent"`
+}
+
+// SLO ihlal türleri
+const (
+	SLOBreachLatency   = "latency"
+	SLOBreachErrorRate = "error_rate"
+)
+
+// PerformanceSLO gecikme (p95) ve hata oranı (yüzde, 5xx) hedefi; minRequests altındaki rotalar değerlendirilmez
+type PerformanceSLO struct {
+	LatencyP95Ms float64 `json:"latencyP95Ms"`
+	ErrorRate    float64 `json:"errorRate"`
+	MinRequests  int64   `json:"minRequests"`
+}
+
+// PerformanceStats istek sayısı, 5xx hata oranı (yüzde) ve gecikme yüzdelikleri (ms)
+type PerformanceStats struct {
+	Requests  int64   `json:"requests"`
+	Errors    int64   `json:"errors"`
+	ErrorRate float64 `json:"errorRate"`
+	AvgMs     float64 `json:"avgMs"`
+	P50Ms     float64 `json:"p50Ms"`
+	P95Ms     float64 `json:"p95Ms"`
+	P99Ms     float64 `json:"p99Ms"`
+	MaxMs     float64 `json:"maxMs"`
+}
+
+// RoutePerformance rotanın pencere içindeki performansı ve aşılan SLO hedefleri
+type RoutePerformance struct {
+	Method string `json:"method"`
+	Route  string `json:"route"`
+	PerformanceStats
+	SLO       PerformanceSLO `json:"slo"`
+	Breaches  []string       `json:"breaches"`
+	Breaching bool           `json:"breaching"`
+}
+
+// PerformanceReport seçilen penceredeki rota bazında gecikme ve hata oranı özeti
+type PerformanceReport struct {
+	Window          string             `json:"window"`
+	From            time.Time          `json:"from"`
+	To              time.Time          `json:"to"`
+	SLO             PerformanceSLO     `json:"slo"`
+	Summary         PerformanceStats   `json:"summary"`
+	BreachingRoutes int                `json:"breachingRoutes"`
+	Routes          []RoutePerformance `json:"routes"`
+}
+
+// TenantScopeViolation HTTP isteği içinde kullanıcıya ait tabloya user_id koşulu olmadan gönderilen sorgu
+type TenantScopeViolation struct {
+	Endpoint    string    `json:"endpoint"`
+	Query       string    `json:"query"`
+	Tables      []string  `json:"tables"`
+	Count       int64     `json:"count"`
+	FirstSeenAt time.Time `json:"firstSeenAt"`
+	LastSeenAt  time.Time `json:"lastSeenAt"`
+}
+
+// TenantScopeReport kiracı kapsamı denetim raporu
+type TenantScopeReport struct {
+	Mode         string                 `json:"mode" example:"log"`
+	TenantTables []string               `json:"tenantTables"`
+	Violations   []TenantScopeViolation `json:"violations"`
+}
+
+// ExplainQueryRequest sorgu planı isteği; query veya kayıtlı yavaş sorgunun slowQueryId değeri verilmelidir
+type ExplainQueryRequest struct {
+	Query       string `json:"query,omitempty"`
+	SlowQueryID string `json:"slowQueryId,omitempty"`
+}
+
+// QueryPlan SQLite EXPLAIN QUERY PLAN çıktısı
+type QueryPlan struct {
+	Query string          `json:"query"`
+	Steps []QueryPlanStep `json:"steps"`
+}
+
+// QueryPlanStep sorgu planı adımı; parent üst adımın kimliğidir (kök adımlarda 0)
+type QueryPlanStep struct {
+	ID     int    `json:"id"`
+	Parent int    `json:"parent"`
+	Detail string `json:"detail"`
+}
+
+// Geçmiş veri içe aktarımında desteklenen kayıt türleri
+const (
+	DataImportEntityAnimals      = "animals"
+	DataImportEntityLands        = "lands"
+	DataImportEntityTransactions = "transactions"
+)
+
+// Geçmiş veri içe aktarımı durumları
+const (
+	DataImportStatusPreviewed = "previewed"
+	DataImportStatusCommitted = "committed"
+	DataImportStatusDiscarded = "discarded"
+)
+
+// İçe aktarım satır aksiyonları
+const (
+	DataImportRowCreate    = "create"
+	DataImportRowCreated   = "created"
+	DataImportRowDuplicate = "duplicate"
+	DataImportRowInvalid   = "invalid"
+)
+
+// DataImportProfile başka çiftlik yönetimi uygulamalarının dışa aktarım dosyaları için sütun eşleme profili.
+// Columns her kayıt türü için alan → kabul edilen sütun başlıkları (öncelik sırasıyla) eşlemesidir
+type DataImportProfile struct {
+	Key         string                         `json:"key" example:"farmbrite"`
+	Name        string                         `json:"name" example:"Farmbrite"`
+	Description string                         `json:"description"`
+	DateFormats []string                       `json:"dateFormats"`
+	Columns     map[string]map[string][]string `json:"columns"`
+	Defaults    map[string]map[string]string   `json:"defaults,omitempty"`
+}
+
+// DataImportRow içe aktarım dosyasındaki tek satır; Values alanları profil eşlemesiyle normalleştirilmiş değerlerdir
+type DataImportRow struct {
+	Row      int               `json:"row"`
+	Action   string            `json:"action" example:"create"`
+	Values   map[string]string `json:"values"`
+	Errors   []string          `json:"errors,omitempty"`
+	RecordID string            `json:"recordId,omitempty"`
+}
+
+// DataImport geçmiş veri içe aktarımı; önizleme ile oluşturulur, onaylanınca kayıtlar eklenir. Kimin önizleyip
+// kimin onayladığı ve satır bazında oluşturulan kayıtlar denetim izi olarak saklanır
+type DataImport struct {
+	ID              string            `json:"id"`
+	Entity          string            `json:"entity" example:"animals"`
+	Profile         string            `json:"profile" example:"generic"`
+	Filename        string            `json:"filename"`
+	Status          string            `json:"status" example:"previewed"`
+	Mapping         map[string]string `json:"mapping"`
+	UnmappedColumns []string          `json:"unmappedColumns"`
+	Summary         map[string]int    `json:"summary"`
+	Rows            []DataImportRow   `json:"rows,omitempty"`
+	CreatedBy       string            `json:"createdBy"`
+	CommittedBy     string            `json:"committedBy,omitempty"`
+	CreatedAt       time.Time         `json:"createdAt"`
+	CommittedAt     *time.Time        `json:"committedAt,omitempty"`
+}
+
+// IntegrationKey Zapier, IFTTT gibi otomasyon araçları için çiftliğe bağlı API anahtarı. Anahtarın kendisi
+// yalnızca oluşturulurken döner; sonrasında önekiyle tanınır
+type IntegrationKey struct {
+	ID         string     `json:"id"`
+	Name       string     `json:"name" example:"Zapier"`
+	Prefix     string     `json:"prefix" example:"agk_3f9a1c"`
+	Key        string     `json:"key,omitempty"`
+	CreatedBy  string     `json:"createdBy"`
+	LastUsedAt *time.Time `json:"lastUsedAt,omitempty"`
+	CreatedAt  time.Time  `json:"createdAt"`
+}
+
+// CreateIntegrationKeyRequest entegrasyon anahtarı oluşturma isteği
+type CreateIntegrationKeyRequest struct {
+	Name string `json:"name" binding:"required,max=100"`
+}
+
+// IntegrationAccount entegrasyon anahtarının bağlı olduğu çiftlik; otomasyon araçlarının bağlantı testi için
+type IntegrationAccount struct {
+	FarmID   string `json:"farmId"`
+	FarmName string `json:"farmName"`
+	KeyName  string `json:"keyName"`
+}
+
+// IntegrationTriggerMeta IFTTT tetikleyici öğe meta bilgisi; timestamp kaydın oluşturulma zamanıdır (Unix saniye)
+type IntegrationTriggerMeta struct {
+	ID        string `json:"id"`
+	Timestamp int64  `json:"timestamp"`
+}
+
+// IntegrationTransaction yeni işlem tetikleyici öğesi
+type IntegrationTransaction struct {
+	ID            string                 `json:"id"`
+	Type          string                 `json:"type" example:"expense"`
+	Category      string                 `json:"category"`
+	Description   string                 `json:"description"`
+	Amount        float64                `json:"amount"`
+	Currency      string                 `json:"currency" example:"TRY"`
+	Date          string                 `json:"date" example:"2025-03-01"`
+	Status        string                 `json:"status"`
+	PaymentMethod string                 `json:"paymentMethod"`
+	CreatedAt     time.Time              `json:"createdAt"`
+	Meta          IntegrationTriggerMeta `json:"meta"`
+}
+
+// IntegrationAnimal yeni hayvan tetikleyici öğesi
+type IntegrationAnimal struct {
+	ID           string                 `json:"id"`
+	TagNumber    string                 `json:"tagNumber"`
+	Type         string                 `json:"type" example:"cattle"`
+	Breed        string                 `json:"breed"`
+	Gender       string                 `json:"gender"`
+	BirthDate    string                 `json:"birthDate"`
+	HealthStatus string                 `json:"healthStatus"`
+	Location     string                 `json:"location"`
+	CreatedAt    time.Time              `json:"createdAt"`
+	Meta         IntegrationTriggerMeta `json:"meta"`
+}
+
+// IntegrationEvent yeni takvim etkinliği tetikleyici öğesi
+type IntegrationEvent struct {
+	ID        string                 `json:"id"`
+	Title     string                 `json:"title"`
+	Type      string                 `json:"type"`
+	StartDate time.Time              `json:"startDate"`
+	IsAllDay  bool                   `json:"isAllDay"`
+	Status    string                 `json:"status"`
+	Priority  string                 `json:"priority"`
+	Location  string                 `json:"location"`
+	CreatedAt time.Time              `json:"createdAt"`
+	Meta      IntegrationTriggerMeta `json:"meta"`
+}
+
+// IntegrationHarvest yeni hasat (üretim kaydı) tetikleyici öğesi
+type IntegrationHarvest struct {
+	ID          string                 `json:"id"`
+	Name        string                 `json:"name"`
+	Category    string                 `json:"category"`
+	Amount      float64                `json:"amount"`
+	Unit        string                 `json:"unit"`
+	HarvestDate string                 `json:"harvestDate"`
+	LandName    string                 `json:"landName"`
+	CreatedAt   time.Time              `json:"createdAt"`
+	Meta        IntegrationTriggerMeta `json:"meta"`
+}
+
+// IntegrationTrigger otomasyon araçlarında tanımlanabilecek yoklama tetikleyicisi ve örnek öğesi
+type IntegrationTrigger struct {
+	Key         string      `json:"key" example:"new-transactions"`
+	Name        string      `json:"name"`
+	Description string      `json:"description"`
+	Route       string      `json:"route" example:"/api/v1/integrations/new-transactions"`
+	Sample      interface{} `json:"sample"`
+}
+
+// PedigreeAnimal soy kütüğündeki ata; çiftlikte kaydı yoksa yalnızca küpe numarası bilinir
+type PedigreeAnimal struct {
+	ID        string     `json:"id,omitempty"`
+	TagNumber string     `json:"tagNumber"`
+	Breed     string     `json:"breed"`
+	BirthDate *time.Time `json:"birthDate"`
+}
+
+// AnimalPedigree hayvanın iki kuşak soy kütüğü
+type AnimalPedigree struct {
+	Mother              *PedigreeAnimal `json:"mother"`
+	Father              *PedigreeAnimal `json:"father"`
+	MaternalGrandmother *PedigreeAnimal `json:"maternalGrandmother"`
+	MaternalGrandfather *PedigreeAnimal `json:"maternalGrandfather"`
+	PaternalGrandmother *PedigreeAnimal `json:"paternalGrandmother"`
+	PaternalGrandfather *PedigreeAnimal `json:"paternalGrandfather"`
+}
+
+// AnimalPassport fuar, satış ve denetimlerde kullanılan hayvan pasaportunun içeriği
+type AnimalPassport struct {
+	Animal       Livestock           `json:"animal"`
+	TypeLabel    string              `json:"typeLabel"`
+	FarmName     string              `json:"farmName"`
+	PhotoID      string              `json:"photoId"`
+	Pedigree     AnimalPedigree      `json:"pedigree"`
+	Vaccinations []HealthRecord      `json:"vaccinations"`
+	Movements    []LivestockMovement `json:"movements"`
+	GeneratedAt  time.Time           `json:"generatedAt"`
+}
+
+// Soy kütüğü belgesi alanları; şablonların fields listesinde kullanılır
+const (
+	HerdBookFieldTagNumber      = "tagNumber"
+	HerdBookFieldSpecies        = "species"
+	HerdBookFieldBreed          = "breed"
+	HerdBookFieldGender         = "gender"
+	HerdBookFieldBirthDate      = "birthDate"
+	HerdBookFieldWeight         = "weight"
+	HerdBookFieldLocation       = "location"
+	HerdBookFieldMother         = "mother"
+	HerdBookFieldFather         = "father"
+	HerdBookFieldFarm           = "farm"
+	HerdBookFieldOwner          = "owner"
+	HerdBookFieldPremisesNumber = "premisesNumber"
+	HerdBookFieldNotes          = "notes"
+)
+
+// HerdBookTemplate yetiştirici birliğinin soy kütüğü/tescil belgesi şablonu; belgede gösterilen hayvan alanlarını,
+// birliğin alan adlarını ve soy kütüğünün kaç kuşak geriye gideceğini belirler
+type HerdBookTemplate struct {
+	ID          string          `json:"id" db:"id"`
+	Association string          `json:"association" db:"association" example:"Türkiye Damızlık Sığır Yetiştiricileri Merkez Birliği"`
+	Title       string          `json:"title" db:"title" example:"Soy Kütüğü Tescil Belgesi"`
+	Species     string          `json:"species,omitempty" db:"species" example:"cattle"`
+	Format      string          `json:"format" db:"format" enums:"pdf,csv"`
+	Generations int             `json:"generations" db:"generations" example:"3"`
+	Fields      []HerdBookField `json:"fields" db:"-"`
+	Declaration string          `json:"declaration,omitempty" db:"declaration"`
+	Active      bool            `json:"active" db:"active"`
+	CreatedAt   time.Time       `json:"createdAt" db:"created_at"`
+	UpdatedAt   time.Time       `json:"updatedAt" db:"updated_at"`
+}
+
+// HerdBookField belgede gösterilen hayvan alanı; label verilmezse alanın Türkçe adı kullanılır
+type HerdBookField struct {
+	Key   string `json:"key" binding:"required,oneof=tagNumber species breed gender birthDate weight location mother father farm owner premisesNumber notes"`
+	Label string `json:"label"`
+}
+
+// HerdBookTemplateRequest soy kütüğü şablonu ekleme ve güncelleme isteği; species verilirse şablon yalnızca o
+// türdeki hayvanlar için kullanılabilir
+type HerdBookTemplateRequest struct {
+	Association string          `json:"association" binding:"required"`
+	Title       string          `json:"title" binding:"required"`
+	Species     string          `json:"species"`
+	Format      string          `json:"format" binding:"omitempty,oneof=pdf csv"`
+	Generations *int            `json:"generations" binding:"omitempty,min=1,max=4"`
+	Fields      []HerdBookField `json:"fields" binding:"omitempty,dive"`
+	Declaration string          `json:"declaration"`
+	Active      *bool           `json:"active"`
+}
+
+// HerdBookRequest seçilen hayvanlar için soy kütüğü belgesi oluşturma isteği
+type HerdBookRequest struct {
+	TemplateID     string   `json:"templateId" binding:"required"`
+	AnimalIDs      []string `json:"animalIds" binding:"required,min=1,max=100,dive,required"`
+	PremisesNumber string   `json:"premisesNumber"`
+}
+
+// HerdBookAncestor belgedeki ata; code babadan (S) ve anneden (D) izlenen soy yoludur, ör. SD babanın annesi
+type HerdBookAncestor struct {
+	Code   string          `json:"code" example:"SD"`
+	Label  string          `json:"label" example:"Babanın annesi"`
+	Animal *PedigreeAnimal `json:"animal"`
+}
+
+// HerdBookEntry belgedeki hayvanın şablon alanları ve ataları
+type HerdBookEntry struct {
+	AnimalID  string             `json:"animalId"`
+	TagNumber string             `json:"tagNumber"`
+	Values    []string           `json:"values"`
+	Ancestors []HerdBookAncestor `json:"ancestors"`
+}
+
+// KPISnapshot çiftliğin günlük KPI anlık görüntüsü; kayıtlar sonradan düzenlense veya silinse de
+// geçmiş eğilimler bu değerlerden okunur
+type KPISnapshot struct {
+	ID           string    `json:"id" db:"id"`
+	SnapshotDate string    `json:"snapshotDate" db:"snapshot_date" example:"2026-10-16"`
+	HerdSize     int       `json:"herdSize" db:"herd_size"`
+	StockValue   float64   `json:"stockValue" db:"stock_value"`
+	CashBalance  float64   `json:"cashBalance" db:"cash_balance"`
+	TotalArea    float64   `json:"totalArea" db:"total_area"`
+	CreatedAt    time.Time `json:"createdAt" db:"created_at"`
+}
+
+// Destek talebi durumları
+const (
+	SupportTicketOpen       = "open"
+	SupportTicketInProgress = "in_progress"
+	SupportTicketResolved   = "resolved"
+	SupportTicketClosed     = "closed"
+)
+
+// Destek talebi mesajı yazar rolleri
+const (
+	SupportAuthorUser    = "user"
+	SupportAuthorSupport = "support"
+)
+
+// SupportDeviceInfo talebi gönderen cihazın bilgileri
+type SupportDeviceInfo struct {
+	Platform    string `json:"platform" form:"platform"`
+	OSVersion   string `json:"osVersion" form:"osVersion"`
+	DeviceModel string `json:"deviceModel" form:"deviceModel"`
+	Locale      string `json:"locale" form:"locale"`
+	UserAgent   string `json:"userAgent" form:"-"`
+}
+
+// RecentRequest hesabın son API isteklerinden biri; destek taleplerine bağlam olarak eklenir
+type RecentRequest struct {
+	RequestID string    `json:"requestId"`
+	Method    string    `json:"method"`
+	Path      string    `json:"path"`
+	Status    int       `json:"status"`
+	At        time.Time `json:"at"`
+}
+
+// SupportTicketRequest destek talebi oluşturma isteği; JSON veya ekran görüntüsüyle (screenshot dosyası)
+// multipart form olarak gönderilebilir
+type SupportTicketRequest struct {
+	Subject    string            `json:"subject" form:"subject"`
+	Message    string            `json:"message" form:"message" binding:"required"`
+	Category   string            `json:"category" form:"category" binding:"omitempty,oneof=bug question feedback feature_request other"`
+	AppVersion string            `json:"appVersion" form:"appVersion"`
+	Device     SupportDeviceInfo `json:"device"`
+	RequestIDs []string          `json:"requestIds" form:"requestIds"`
+}
+
+// SupportTicketMessage destek talebindeki kullanıcı veya destek ekibi mesajı
+type SupportTicketMessage struct {
+	ID         string    `json:"id" db:"id"`
+	TicketID   string    `json:"ticketId" db:"ticket_id"`
+	AuthorID   string    `json:"authorId" db:"author_id"`
+	AuthorRole string    `json:"authorRole" db:"author_role"`
+	Message    string    `json:"message" db:"message"`
+	CreatedAt  time.Time `json:"createdAt" db:"created_at"`
+}
+
+// SupportTicket kullanıcının mesajı ve gönderildiği andaki uygulama bağlamıyla destek talebi
+type SupportTicket struct {
+	ID             string                 `json:"id" db:"id"`
+	AccountID      string                 `json:"accountId" db:"account_id"`
+	FarmID         string                 `json:"farmId" db:"user_id"`
+	AccountEmail   string                 `json:"accountEmail,omitempty" db:"-"`
+	Subject        string                 `json:"subject" db:"subject"`
+	Message        string                 `json:"message" db:"message"`
+	Category       string                 `json:"category" db:"category"`
+	Status         string                 `json:"status" db:"status"`
+	AppVersion     string                 `json:"appVersion" db:"app_version"`
+	Device         SupportDeviceInfo      `json:"device" db:"device_info"`
+	RequestIDs     []string               `json:"requestIds" db:"request_ids"`
+	RecentRequests []RecentRequest        `json:"recentRequests" db:"recent_requests"`
+	ScreenshotID   string                 `json:"screenshotId" db:"screenshot_id"`
+	Messages       []SupportTicketMessage `json:"messages,omitempty" db:"-"`
+	ResolvedAt     *time.Time             `json:"resolvedAt" db:"resolved_at"`
+	CreatedAt      time.Time              `json:"createdAt" db:"created_at"`
+	UpdatedAt      time.Time              `json:"updatedAt" db:"updated_at"`
+}
+
+// SupportTicketListResponse sayfalı destek talebi listesi
+type SupportTicketListResponse struct {
+	Tickets    []SupportTicket `json:"tickets"`
+	Pagination Pagination      `json:"pagination"`
+}
+
+// SupportTicketMessageRequest talebe mesaj ekleme isteği; destek ekibi yanıtla birlikte durumu da değiştirebilir
+type SupportTicketMessageRequest struct {
+	Message string `json:"message" binding:"required"`
+	Status  string `json:"status" binding:"omitempty,oneof=open in_progress resolved closed"`
+}
+
+// SupportTicketStatusRequest talep durumunu değiştirme isteği
+type SupportTicketStatusRequest struct {
+	Status string `json:"status" binding:"required,oneof=open in_progress resolved closed"`
+}
+
+// SupportInfo sistem bilgisinde dönen destek kanalı bilgileri
+type SupportInfo struct {
+	TicketsURL  string `json:"ticketsUrl"`
+	OpenTickets int    `json:"openTickets"`
+}
+
+// ChangelogEntry yönetici tarafından yazılan sürüm notu veya özellik duyurusu; yayın tarihi boşsa taslaktır
+type ChangelogEntry struct {
+	ID          string     `json:"id" db:"id"`
+	Version     string     `json:"version" db:"version" binding:"required" example:"2.1.0"`
+	Title       string     `json:"title" db:"title" binding:"required"`
+	Body        string     `json:"body" db:"body"`
+	Category    string     `json:"category" db:"category" binding:"omitempty,oneof=feature improvement fix announcement"`
+	Highlights  []string   `json:"highlights" db:"highlights"`
+	PublishedAt *time.Time `json:"publishedAt" db:"published_at"`
+	Seen        bool       `json:"seen" db:"-"`
+	CreatedAt   time.Time  `json:"createdAt" db:"created_at"`
+	UpdatedAt   time.Time  `json:"updatedAt" db:"updated_at"`
+}
+
+// ChangelogResponse kullanıcıya gösterilen sürüm notları ve görülmemiş olanların sayısı
+type ChangelogResponse struct {
+	Entries       []ChangelogEntry `json:"entries"`
+	UnseenCount   int              `json:"unseenCount"`
+	LatestVersion string           `json:"latestVersion"`
+}
+
+// ChangelogSeenRequest sürüm notlarını görüldü olarak işaretleme isteği; ids boşsa yayınlanmış tüm notlar işaretlenir
+type ChangelogSeenRequest struct {
+	IDs []string `json:"ids"`
+}
+
+// MaintenanceWindow bakım penceresi; bu aralıkta yazma istekleri 503 ile reddedilir, okumalar sürer.
+// Bitiş zamanı boş olan pencere yönetici bakım modunu kapatana kadar sürer
+type MaintenanceWindow struct {
+	ID        string     `json:"id" db:"id"`
+	Message   string     `json:"message" db:"message"`
+	StartsAt  time.Time  `json:"startsAt" db:"starts_at"`
+	EndsAt    *time.Time `json:"endsAt" db:"ends_at"`
+	Active    bool       `json:"active" db:"-"`
+	CreatedBy string     `json:"createdBy" db:"created_by"`
+	CreatedAt time.Time  `json:"createdAt" db:"created_at"`
+}
+
+// MaintenanceStatus bakım modunun güncel durumu ve planlanmış pencereler
+type MaintenanceStatus struct {
+	Active   bool                `json:"active"`
+	Current  *MaintenanceWindow  `json:"current,omitempty"`
+	Upcoming []MaintenanceWindow `json:"upcoming"`
+}
+
+// MaintenanceModeRequest bakım modunu açma/kapama isteği; expectedEndAt kullanıcılara gösterilen tahmini bitiştir
+type MaintenanceModeRequest struct {
+	Enabled       bool       `json:"enabled"`
+	Message       string     `json:"message"`
+	ExpectedEndAt *time.Time `json:"expectedEndAt"`
+}
+
+// MaintenanceWindowRequest ileri tarihli bakım penceresi planlama isteği
+type MaintenanceWindowRequest struct {
+	StartsAt time.Time `json:"startsAt" binding:"required"`
+	EndsAt   time.Time `json:"endsAt" binding:"required"`
+	Message  string    `json:"message"`
+}
+
+// MaintenanceErrorDetails bakım nedeniyle reddedilen yazma isteğinin 503 yanıtındaki ayrıntılar
+type MaintenanceErrorDetails struct {
+	Message           string     `json:"message"`
+	EndsAt            *time.Time `json:"endsAt"`
+	RetryAfterSeconds int        `json:"retryAfterSeconds,omitempty"`
+}
+
+// Yeniden hesaplama işi durumları
+const (
+	RecalculationQueued    = "queued"
+	RecalculationRunning   = "running"
+	RecalculationCompleted = "completed"
+	RecalculationFailed    = "failed"
+)
+
+// RecalculationRequest türetilmiş değerlerin yeniden hesaplanması isteği; targets boşsa tüm hedefler,
+// farmId boşsa tüm çiftlikler yeniden hesaplanır
+type RecalculationRequest struct {
+	Targets []string `json:"targets" example:"land_productivity,kpi_snapshots"`
+	FarmID  string   `json:"farmId"`
+}
+
+// RecalculationJob arka planda çalışan yeniden hesaplama işi ve ilerlemesi
+type RecalculationJob struct {
+	ID             string     `json:"id" db:"id"`
+	Targets        []string   `json:"targets" db:"targets"`
+	FarmID         *string    `json:"farmId" db:"farm_id"`
+	Status         string     `json:"status" db:"status"`
+	Total          int        `json:"total" db:"total"`
+	Processed      int        `json:"processed" db:"processed"`
+	Failed         int        `json:"failed" db:"failed"`
+	UpdatedRecords int        `json:"updatedRecords" db:"updated_records"`
+	Progress       float64    `json:"progress" db:"-"`
+	Errors         []string   `json:"errors" db:"errors"`
+	RequestedBy    string     `json:"requestedBy" db:"requested_by"`
+	CreatedAt      time.Time  `json:"createdAt" db:"created_at"`
+	StartedAt      *time.Time `json:"startedAt" db:"started_at"`
+	FinishedAt     *time.Time `json:"finishedAt" db:"finished_at"`
+}
+
+// RecalculationJobListResponse yeniden hesaplama işleri listesi ve geçerli hedefler
+type RecalculationJobListResponse struct {
+	Jobs       []RecalculationJob `json:"jobs"`
+	Targets    []string           `json:"targets"`
+	Pagination Pagination         `json:"pagination"`
+}
+
+// FieldEncryptionKey ana anahtarla sarılarak saklanan veri anahtarı; anahtarın kendisi yanıtta yer almaz
+type FieldEncryptionKey struct {
+	ID          string     `json:"id"`
+	MasterKeyID string     `json:"masterKeyId"`
+	Active      bool       `json:"active"`
+	CreatedAt   time.Time  `json:"createdAt"`
+	RetiredAt   *time.Time `json:"retiredAt"`
+}
+
+// EncryptedColumnStatus şifrelenen sütundaki değerlerin durumu
+type EncryptedColumnStatus struct {
+	Column    string `json:"column"`
+	Values    int    `json:"values"`
+	Plaintext int    `json:"plaintext"`
+	ActiveKey int    `json:"activeKey"`
+}
+
+// FieldEncryptionStatus alan şifreleme anahtarlarının ve sütunların durumu
+type FieldEncryptionStatus struct {
+	Enabled         bool                    `json:"enabled"`
+	ActiveMasterKey string                  `json:"activeMasterKey"`
+	MasterKeys      []string                `json:"masterKeys"`
+	ActiveDataKey   string                  `json:"activeDataKey"`
+	DataKeys        []FieldEncryptionKey    `json:"dataKeys"`
+	Columns         []EncryptedColumnStatus `json:"columns"`
+}
+
+// FieldEncryptionRotation anahtar döndürme sonucu
+type FieldEncryptionRotation struct {
+	DataKeyID         string `json:"dataKeyId"`
+	RewrappedKeys     int    `json:"rewrappedKeys"`
+	ReencryptedValues int    `json:"reencryptedValues"`
+}
+
+// DemoAccount sandbox hesabı; çiftlik verileri her gece örnek veri üreteciyle sıfırlanır
+type DemoAccount struct {
+	ID          string         `json:"id"`
+	Name        string         `json:"name"`
+	Email       string         `json:"email"`
+	FarmName    string         `json:"farmName"`
+	Location    string         `json:"location"`
+	Records     map[string]int `json:"records"`
+	LastResetAt *time.Time     `json:"lastResetAt"`
+	LastError   string         `json:"lastError,omitempty"`
+	CreatedAt   time.Time      `json:"createdAt"`
+}
+
+// DemoAccountRequest sandbox hesabı oluşturma isteği; giriş bilgileri uygulamayı deneyecek kişilerle paylaşılır
+type DemoAccountRequest struct {
+	Name     string `json:"name" binding:"required,max=100"`
+	Email    string `json:"email" binding:"required,email"`
+	Password string `json:"password" binding:"required,min=6"`
+	FarmName string `json:"farmName" binding:"required,max=100"`
+	Location string `json:"location" binding:"max=100"`
+}
+
+// Arşivlenebilen tablolar
+const (
+	ArchiveTableTransactions   = "transactions"
+	ArchiveTableEvents         = "events"
+	ArchiveTableMilkProduction = "milk_production"
+)
+
+// Arşiv paketi durumları
+const (
+	ArchiveStatusArchived = "archived"
+	ArchiveStatusRestored = "restored"
+)
+
+// ArchiveRequest eski kayıtları arşivleme isteği; tables boşsa arşivlenebilen tüm tablolar arşivlenir
+type ArchiveRequest struct {
+	OlderThanYears int      `json:"olderThanYears" binding:"required,min=1,max=50"`
+	Tables         []string `json:"tables"`
+}
+
+// ArchiveBatch bir tablonun kesim tarihinden eski kayıtlarının soğuk depolamaya taşınan paketi; records
+// tablo başına (bağlı alt kayıtlar dahil) taşınan kayıt sayısıdır
+type ArchiveBatch struct {
+	ID          string         `json:"id"`
+	JobID       *string        `json:"jobId"`
+	Table       string         `json:"table"`
+	Cutoff      time.Time      `json:"cutoff"`
+	PeriodStart *time.Time     `json:"periodStart"`
+	PeriodEnd   *time.Time     `json:"periodEnd"`
+	Records     map[string]int `json:"records"`
+	SizeBytes   int64          `json:"sizeBytes"`
+	Status      string         `json:"status"`
+	CreatedAt   time.Time      `json:"createdAt"`
+	RestoredAt  *time.Time     `json:"restoredAt"`
+}
+
+// ArchiveJob arşivleme işi; iş tamamlandığında oluşturulan paketler batches alanında döner
+type ArchiveJob struct {
+	Job
+	Batches []ArchiveBatch `json:"batches,omitempty"`
+}
+
+// ArchiveAggregateFilter arşiv özetleri filtresi; aylar YYYY-MM biçimindedir
+type ArchiveAggregateFilter struct {
+	Table      string
+	StartMonth string
+	EndMonth   string
+}
+
+// ArchiveAggregate arşivlenen kayıtların aylık özeti. İşlemlerde group tür (income/expense), subgroup
+// kategori, total tutardır; etkinliklerde group tür, subgroup durumdur; süt kayıtlarında group hayvan,
+// subgroup kalite, total litredir
+type ArchiveAggregate struct {
+	Table    string  `json:"table"`
+	Month    string  `json:"month"`
+	Group    string  `json:"group"`
+	Subgroup string  `json:"subgroup"`
+	Currency string  `json:"currency,omitempty"`
+	Count    int     `json:"count"`
+	Total    float64 `json:"total"`
+}
+
+// ArchiveRecordsResponse soğuk depolamadaki paketten okunan sayfalı kayıtlar; kayıtlar tablodaki sütun
+// adlarıyla döner
+type ArchiveRecordsResponse struct {
+	Batch      ArchiveBatch             `json:"batch"`
+	Records    []map[string]interface{} `json:"records"`
+	Pagination Pagination               `json:"pagination"`
+}
+
+// ArchiveRestoreResult paketin tablolara geri yüklenmesinin sonucu; skipped zaten var olan veya bağlı
+// olduğu hayvan silinmiş kayıtların sayısıdır
+type ArchiveRestoreResult struct {
+	Batch    ArchiveBatch   `json:"batch"`
+	Restored map[string]int `json:"restored"`
+	Skipped  int            `json:"skipped"`
+}
@@ -0,0 +1,37 @@
+package routes
+
+import (
+	"net/http"
+	"testing"
+)
+
+func TestDemoAccountOutwardActionsDenied(t *testing.T) {
+	engine, db := newTenantTestServer(t)
+	registerTenant(t, engine, "demo@example.com")
+	if _, err := db.Exec("UPDATE users SET role = 'demo', avatar = COALESCE(avatar, '') WHERE email = ?", "demo@example.com"); err != nil {
+		t.Fatalf("rol güncellenemedi: %v", err)
+	}
+	demo := &tenantClient{t: t, engine: engine}
+	status, resp := demo.do(http.MethodPost, "/auth/login", `{"email":"demo@example.com","password":"secret123"}`)
+	data, _ := resp["data"].(map[string]interface{})
+	demo.token, _ = data["token"].(string)
+	if status != http.StatusOK || demo.token == "" {
+		t.Fatalf("giriş başarısız (%d): %v", status, resp)
+	}
+
+	for _, probe := range []tenantProbe{
+		{http.MethodGet, "/finance/inbox", ""},
+		{http.MethodPost, "/finance/inbox/rotate", ""},
+		{http.MethodPut, "/farms/demo/public-profile", `{}`},
+		{http.MethodPost, "/vet-visits", `{}`},
+		{http.MethodPost, "/accountants", `{}`},
+		{http.MethodPost, "/support/tickets", `{}`},
+		{http.MethodPost, "/support/tickets/demo/messages", `{}`},
+	} {
+		status, resp := demo.do(probe.method, probe.path, probe.body)
+		errBody, _ := resp["error"].(map[string]interface{})
+		if status != http.StatusForbidden || errBody["code"] != "DEMO_ACCOUNT" {
+			t.Errorf("%s %s: sandbox hesabı için 403 beklenirken %d: %v", probe.method, probe.path, status, resp)
+		}
+	}
+}
@@ -0,0 +1,31 @@
+package routes
+
+import (
+	"net/http"
+	"testing"
+)
+
+func TestFeatureFlagDatabaseRolloutOverridesEnv(t *testing.T) {
+	t.Setenv("FEATURE_MOCK_WEATHER", "true")
+	t.Setenv("FEATURE_MOCK_REPORTS", "false")
+	engine, db := newTenantTestServer(t)
+	owner := registerTenant(t, engine, "flags@example.com")
+
+	if _, err := db.Exec("INSERT INTO feature_flags (key, enabled, rollout_percentage) VALUES ('mock_weather', TRUE, 0)"); err != nil {
+		t.Fatalf("bayrak eklenemedi: %v", err)
+	}
+
+	status, resp := owner.do(http.MethodGet, "/features", "")
+	flags, _ := resp["data"].([]interface{})
+	if status != http.StatusOK || len(flags) == 0 {
+		t.Fatalf("bayraklar alınamadı (%d): %v", status, resp)
+	}
+	want := map[string][2]interface{}{"mock_weather": {false, "db"}, "mock_reports": {false, "env"}}
+	for _, item := range flags {
+		flag, _ := item.(map[string]interface{})
+		key, _ := flag["key"].(string)
+		if expected, ok := want[key]; ok && (flag["enabled"] != expected[0] || flag["source"] != expected[1]) {
+			t.Errorf("%s: enabled=%v source=%v beklenirken %v", key, flag["enabled"], flag["source"], flag)
+		}
+	}
+}
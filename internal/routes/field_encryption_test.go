@@ -0,0 +1,51 @@
+package routes
+
+import (
+	"net/http"
+	"strings"
+	"testing"
+)
+
+func TestCustomerPhoneEncrypted(t *testing.T) {
+	engine, db := newTenantTestServer(t)
+	owner := registerTenant(t, engine, "customers@example.com")
+
+	id := owner.createID(tenantProbe{http.MethodPost, "/sales/customers", `{"name":"Manav","phone":"05551112233"}`})
+
+	var stored string
+	if err := db.QueryRow("SELECT phone FROM customers WHERE id = ?", id).Scan(&stored); err != nil {
+		t.Fatalf("müşteri okunamadı: %v", err)
+	}
+	if !strings.HasPrefix(stored, "enc:v1:") {
+		t.Fatalf("telefon şifrelenmeden saklandı: %q", stored)
+	}
+
+	status, resp := owner.do(http.MethodGet, "/sales/customers?search=1112", "")
+	customers, _ := resp["data"].([]interface{})
+	if status != http.StatusOK || len(customers) != 1 {
+		t.Fatalf("telefonla arama bir müşteri döndürmeli (%d): %v", status, resp)
+	}
+	if customer, _ := customers[0].(map[string]interface{}); customer["phone"] != "05551112233" {
+		t.Fatalf("telefon çözülmeden döndü: %v", customer["phone"])
+	}
+}
+
+func TestWorkerPhoneEncrypted(t *testing.T) {
+	engine, db := newTenantTestServer(t)
+	owner := registerTenant(t, engine, "workers@example.com")
+
+	id := owner.createID(tenantProbe{http.MethodPost, "/workers", `{"name":"İşçi","contractType":"seasonal","contractStart":"2026-01-01","phone":"05551234567"}`})
+
+	var stored string
+	if err := db.QueryRow("SELECT phone FROM workers WHERE id = ?", id).Scan(&stored); err != nil {
+		t.Fatalf("çalışan okunamadı: %v", err)
+	}
+	if !strings.HasPrefix(stored, "enc:v1:") {
+		t.Fatalf("telefon şifrelenmeden saklandı: %q", stored)
+	}
+
+	status, resp := owner.do(http.MethodGet, "/workers/"+id, "")
+	if worker, _ := resp["data"].(map[string]interface{}); status != http.StatusOK || worker["phone"] != "05551234567" {
+		t.Fatalf("telefon çözülmeden döndü (%d): %v", status, resp)
+	}
+}
@@ -0,0 +1,49 @@
+package routes
+
+import (
+	"net/http"
+	"testing"
+)
+
+func TestEntityHistoryBelongsToFarmAndIsDeletedWithEntity(t *testing.T) {
+	engine, db := newTenantTestServer(t)
+	owner := registerTenant(t, engine, "history@example.com")
+
+	animal := `{"tagNumber":"TR-7","type":"cattle","breed":"Holstein","gender":"female","birthDate":"2024-01-01T00:00:00Z","healthStatus":"healthy"}`
+	animalID := owner.createID(tenantProbe{http.MethodPost, "/livestock", animal})
+	status, resp := owner.do(http.MethodPut, "/livestock/"+animalID, `{"tagNumber":"TR-8","type":"cattle","breed":"Holstein","gender":"female","birthDate":"2024-01-01T00:00:00Z","healthStatus":"sick"}`)
+	if status != http.StatusOK {
+		t.Fatalf("hayvan güncellenemedi (%d): %v", status, resp)
+	}
+
+	var farmID string
+	if err := db.QueryRow("SELECT user_id FROM livestock WHERE id = ?", animalID).Scan(&farmID); err != nil {
+		t.Fatalf("çiftlik bulunamadı: %v", err)
+	}
+	var owned, total int
+	err := db.QueryRow(`
+		SELECT COUNT(CASE WHEN user_id = ? THEN 1 END), COUNT(*) FROM entity_changes WHERE entity_id = ?
+	`, farmID, animalID).Scan(&owned, &total)
+	if err != nil {
+		t.Fatalf("geçmiş okunamadı: %v", err)
+	}
+	if total == 0 || owned != total {
+		t.Fatalf("geçmiş satırları çiftliğe bağlanmadı: %d/%d", owned, total)
+	}
+
+	status, resp = owner.do(http.MethodGet, "/livestock/"+animalID+"/history", "")
+	data, _ := resp["data"].(map[string]interface{})
+	if changes, _ := data["changes"].([]interface{}); status != http.StatusOK || len(changes) != total {
+		t.Fatalf("geçmiş %d değişiklik döndürmeli (%d): %v", total, status, resp)
+	}
+
+	if status, resp := owner.do(http.MethodDelete, "/livestock/"+animalID, ""); status != http.StatusOK {
+		t.Fatalf("hayvan silinemedi (%d): %v", status, resp)
+	}
+	if err := db.QueryRow("SELECT COUNT(*) FROM entity_changes WHERE entity_id = ?", animalID).Scan(&total); err != nil {
+		t.Fatalf("geçmiş okunamadı: %v", err)
+	}
+	if total != 0 {
+		t.Fatalf("silinen hayvanın %d geçmiş satırı kaldı", total)
+	}
+}
@@ -0,0 +1,30 @@
+package routes
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestInboundEmailSecretRequiresHeader(t *testing.T) {
+	t.Setenv("INBOUND_EMAIL_SECRET", "webhook-secret")
+	engine, _ := newTenantTestServer(t)
+
+	req := httptest.NewRequest(http.MethodPost, "/api/v1/inbound/email?secret=webhook-secret", strings.NewReader("recipient=unknown@example.com"))
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	w := httptest.NewRecorder()
+	engine.ServeHTTP(w, req)
+	if w.Code != http.StatusUnauthorized {
+		t.Fatalf("sorgu parametresindeki anahtar için 401 beklenirken %d", w.Code)
+	}
+
+	req = httptest.NewRequest(http.MethodPost, "/api/v1/inbound/email", strings.NewReader("recipient=unknown@example.com"))
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	req.Header.Set("X-Inbound-Secret", "webhook-secret")
+	w = httptest.NewRecorder()
+	engine.ServeHTTP(w, req)
+	if w.Code != http.StatusNotFound {
+		t.Fatalf("bilinmeyen alıcı için 404 beklenirken %d: %s", w.Code, w.Body.String())
+	}
+}
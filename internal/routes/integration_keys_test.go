@@ -0,0 +1,34 @@
+package routes
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestIntegrationKeyRequiresHeader(t *testing.T) {
+	engine, _ := newTenantTestServer(t)
+	owner := registerTenant(t, engine, "integrations@example.com")
+
+	status, resp := owner.do(http.MethodPost, "/integrations/keys", `{"name":"Zapier"}`)
+	data, _ := resp["data"].(map[string]interface{})
+	key, _ := data["key"].(string)
+	if status != http.StatusCreated || key == "" {
+		t.Fatalf("anahtar oluşturulamadı (%d): %v", status, resp)
+	}
+
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/integrations/me?api_key="+key, nil)
+	w := httptest.NewRecorder()
+	engine.ServeHTTP(w, req)
+	if w.Code != http.StatusUnauthorized {
+		t.Fatalf("sorgu parametresindeki anahtar için 401 beklenirken %d", w.Code)
+	}
+
+	req = httptest.NewRequest(http.MethodGet, "/api/v1/integrations/me", nil)
+	req.Header.Set("X-API-Key", key)
+	w = httptest.NewRecorder()
+	engine.ServeHTTP(w, req)
+	if w.Code != http.StatusOK {
+		t.Fatalf("başlıktaki anahtar için 200 beklenirken %d: %s", w.Code, w.Body.String())
+	}
+}
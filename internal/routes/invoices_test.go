@@ -0,0 +1,63 @@
+package routes
+
+import (
+	"net/http"
+	"testing"
+)
+
+func TestInvoiceRejectsInvoicedProductionSale(t *testing.T) {
+	engine, _ := newTenantTestServer(t)
+	owner := registerTenant(t, engine, "seller@example.com")
+
+	productionID := owner.createID(tenantProbe{http.MethodPost, "/production", `{"name":"Elma","category":"fruit","amount":100,"unit":"kg","harvestDate":"2026-01-01T00:00:00Z","status":"active"}`})
+	status, resp := owner.do(http.MethodPost, "/production/"+productionID+"/sell", `{"quantity":10,"unitPrice":20,"buyer":"Manav","invoice":true,"taxRate":1}`)
+	if status != http.StatusCreated {
+		t.Fatalf("satış kaydedilemedi (%d): %v", status, resp)
+	}
+	data, _ := resp["data"].(map[string]interface{})
+	sale, _ := data["sale"].(map[string]interface{})
+	transactionID, _ := sale["transactionId"].(string)
+	if transactionID == "" || sale["invoiceNumber"] == nil {
+		t.Fatalf("satışta işlem veya fatura numarası yok: %v", sale)
+	}
+
+	status, resp = owner.do(http.MethodPost, "/finance/invoices", `{"transactionIds":["`+transactionID+`"]}`)
+	if status != http.StatusConflict {
+		t.Fatalf("satış faturası kesilmiş işlem için 409 beklenirken %d: %v", status, resp)
+	}
+
+	status, resp = owner.do(http.MethodGet, "/finance/transactions/"+transactionID, "")
+	transaction, _ := resp["data"].(map[string]interface{})
+	if status != http.StatusOK || transaction["receipt"] != sale["invoiceNumber"] {
+		t.Fatalf("işlemin fiş numarası değişmemeliydi (%d): %v", status, resp)
+	}
+}
+
+func TestInvoiceNumberPastFourDigits(t *testing.T) {
+	engine, db := newTenantTestServer(t)
+	owner := registerTenant(t, engine, "numbers@example.com")
+
+	transactionID := owner.createID(tenantProbe{http.MethodPost, "/finance/transactions", `{"type":"income","category":"Satış","description":"Satış","amount":100,"date":"2026-03-01T00:00:00Z","status":"completed"}`})
+	var farmID string
+	if err := db.QueryRow("SELECT user_id FROM transactions WHERE id = ?", transactionID).Scan(&farmID); err != nil {
+		t.Fatalf("çiftlik bulunamadı: %v", err)
+	}
+	for i, number := range []string{"FT-2026-9999", "FT-2026-10000"} {
+		_, err := db.Exec(`
+			INSERT INTO invoices (id, user_id, invoice_number, issue_date, subtotal, total, lines, file_path)
+			VALUES (?, ?, ?, '2026-01-01', 0, 0, '[]', '')
+		`, "seed-"+number, farmID, number)
+		if err != nil {
+			t.Fatalf("fatura %d eklenemedi: %v", i, err)
+		}
+	}
+
+	status, resp := owner.do(http.MethodPost, "/finance/invoices", `{"transactionIds":["`+transactionID+`"],"issueDate":"2026-03-01T00:00:00Z"}`)
+	if status != http.StatusCreated {
+		t.Fatalf("fatura kesilemedi (%d): %v", status, resp)
+	}
+	invoice, _ := resp["data"].(map[string]interface{})
+	if invoice["invoiceNumber"] != "FT-2026-10001" {
+		t.Fatalf("FT-2026-10001 beklenirken %v", invoice["invoiceNumber"])
+	}
+}
@@ -0,0 +1,32 @@
+package routes
+
+import (
+	"net/http"
+	"testing"
+
+	"agri-management-api/internal/database"
+)
+
+func TestWeightBackfillDoesNotRestoreDeletedWeighings(t *testing.T) {
+	engine, db := newTenantTestServer(t)
+	owner := registerTenant(t, engine, "weights@example.com")
+
+	animalID := owner.createID(tenantProbe{http.MethodPost, "/livestock", `{"tagNumber":"TR-9","type":"cattle","breed":"Holstein","gender":"female","birthDate":"2024-01-01T00:00:00Z","healthStatus":"healthy","weight":420}`})
+	if _, err := db.Exec("DELETE FROM weight_records WHERE livestock_id = ?", animalID); err != nil {
+		t.Fatalf("tartımlar silinemedi: %v", err)
+	}
+
+	reopened, err := database.InitDB()
+	if err != nil {
+		t.Fatalf("veritabanı yeniden açılamadı: %v", err)
+	}
+	defer reopened.Close()
+
+	var count int
+	if err := reopened.QueryRow("SELECT COUNT(*) FROM weight_records WHERE livestock_id = ?", animalID).Scan(&count); err != nil {
+		t.Fatalf("tartımlar okunamadı: %v", err)
+	}
+	if count != 0 {
+		t.Fatalf("silinen tartımlar yeniden açılışta geri geldi: %d", count)
+	}
+}
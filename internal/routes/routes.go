@@ -2,23 +2,35 @@ package routes
 
 import (
 	"database/sql"
+	"net/http"
+	"time"
 
+	"agri-management-api/docs"
 	"agri-management-api/internal/handlers"
 	"agri-management-api/internal/middleware"
+	"agri-management-api/internal/models"
 
 	"github.com/gin-gonic/gin"
 	swaggerFiles "github.com/swaggo/files"
 	ginSwagger "github.com/swaggo/gin-swagger"
 )
 
-// SetupRoutes tüm route'ları ayarlar
-func SetupRoutes(r *gin.Engine, db *sql.DB) {
+// SetupRoutes tüm route'ları ayarlar; readDB dashboard ve analiz okumalarının yapıldığı veritabanıdır
+func SetupRoutes(r *gin.Engine, db, readDB *sql.DB) {
 	// Middleware'leri ekle
 	r.Use(middleware.RequestID())
+	r.Use(middleware.RequestMetrics())
+	r.Use(middleware.RequestTrail())
+	r.Use(middleware.QueryMetrics())
+	r.Use(middleware.CacheHints())
+	r.Use(middleware.Maintenance(db))
 
 	// API v1 router
 	v1 := r.Group("/api/v1")
 	{
+		// Veri route'ları X-Farm-ID başlığıyla seçilen çiftliğin kayıtlarıyla sınırlanır
+		farmScope := middleware.FarmScope(db)
+
 		// Auth routes (public)
 		authHandler := handlers.NewAuthHandler(db)
 		auth := v1.Group("/auth")
@@ -33,84 +45,394 @@ func SetupRoutes(r *gin.Engine, db *sql.DB) {
 			{
 				authProtected.GET("/profile", authHandler.GetProfile)
 				authProtected.PUT("/profile", authHandler.UpdateProfile)
-				authProtected.PUT("/change-password", authHandler.ChangePassword)
+				authProtected.PUT("/change-password", middleware.DenyDemo(), authHandler.ChangePassword)
 				authProtected.POST("/logout", authHandler.Logout)
 			}
 		}
 
+		// Feature flag routes (protected)
+		featureHandler := handlers.NewFeatureHandler(db)
+		features := v1.Group("/features")
+		features.Use(middleware.Auth())
+		{
+			features.GET("", featureHandler.GetFeatures)
+		}
+
+		// Cooperative routes (protected)
+		cooperativeHandler := handlers.NewCooperativeHandler(db)
+		cooperative := v1.Group("/cooperative")
+		cooperative.Use(middleware.Auth())
+		{
+			cooperative.GET("/invitations", cooperativeHandler.GetInvitations)
+			cooperative.PATCH("/invitations/:id/consent", cooperativeHandler.UpdateConsent)
+
+			// Kooperatif yöneticisi rolü gerektiren işlemler
+			admin := cooperative.Group("")
+			admin.Use(middleware.RequireRole(models.RoleCooperativeAdmin))
+			{
+				admin.GET("/summary", cooperativeHandler.GetSummary)
+				admin.GET("/members", cooperativeHandler.GetMembers)
+				admin.POST("/members", cooperativeHandler.InviteMember)
+				admin.DELETE("/members/:id", cooperativeHandler.RemoveMember)
+			}
+		}
+
+		// Bakım durumu (public); bakım bandı oturum açılmadan da gösterilebilmelidir
+		maintenanceHandler := handlers.NewMaintenanceHandler(db)
+		v1.GET("/maintenance", maintenanceHandler.GetMaintenanceStatus)
+
+		// Admin routes (protected, yönetici rolü gerektirir)
+		messageTemplateHandler := handlers.NewMessageTemplateHandler(db)
+		databaseAdminHandler := handlers.NewDatabaseAdminHandler(db)
+		supportHandler := handlers.NewSupportHandler(db)
+		changelogHandler := handlers.NewChangelogHandler(db)
+		recalculationHandler := handlers.NewRecalculationHandler(db)
+		performanceHandler := handlers.NewPerformanceHandler()
+		livestockBreedHandler := handlers.NewLivestockBreedHandler(db)
+		adminHerdBookHandler := handlers.NewHerdBookHandler(db)
+		demoAccountHandler := handlers.NewDemoAccountHandler(db)
+		systemAdmin := v1.Group("/admin")
+		systemAdmin.Use(middleware.Auth(), middleware.RequireRole(models.RoleAdmin))
+		{
+			systemAdmin.GET("/message-templates", messageTemplateHandler.GetMessageTemplates)
+			systemAdmin.POST("/message-templates/preview", messageTemplateHandler.PreviewMessageTemplate)
+			systemAdmin.PUT("/message-templates/:channel/:key/:language", messageTemplateHandler.SaveMessageTemplate)
+			systemAdmin.DELETE("/message-templates/:channel/:key/:language", messageTemplateHandler.DeleteMessageTemplate)
+			systemAdmin.GET("/db/slow-queries", databaseAdminHandler.GetSlowQueries)
+			systemAdmin.POST("/db/explain", databaseAdminHandler.ExplainQuery)
+			systemAdmin.GET("/db/tenant-scope", databaseAdminHandler.GetTenantScopeAudit)
+			systemAdmin.GET("/db/encryption", databaseAdminHandler.GetFieldEncryption)
+			systemAdmin.POST("/db/encryption/rotate", databaseAdminHandler.RotateFieldEncryption)
+			systemAdmin.GET("/db/backups", databaseAdminHandler.GetBackups)
+			systemAdmin.GET("/db/maintenance", databaseAdminHandler.GetDatabaseMaintenance)
+			systemAdmin.POST("/db/maintenance", databaseAdminHandler.RunDatabaseMaintenance)
+			systemAdmin.GET("/support/tickets", supportHandler.GetAdminTickets)
+			systemAdmin.GET("/support/tickets/:id", supportHandler.GetAdminTicket)
+			systemAdmin.GET("/support/tickets/:id/screenshot", supportHandler.GetAdminTicketScreenshot)
+			systemAdmin.POST("/support/tickets/:id/responses", supportHandler.RespondTicket)
+			systemAdmin.PATCH("/support/tickets/:id/status", supportHandler.UpdateTicketStatus)
+			systemAdmin.GET("/changelog", changelogHandler.GetAdminChangelog)
+			systemAdmin.POST("/changelog", changelogHandler.CreateChangelogEntry)
+			systemAdmin.PUT("/changelog/:id", changelogHandler.UpdateChangelogEntry)
+			systemAdmin.DELETE("/changelog/:id", changelogHandler.DeleteChangelogEntry)
+			systemAdmin.PUT("/maintenance", maintenanceHandler.SetMaintenanceMode)
+			systemAdmin.GET("/maintenance/windows", maintenanceHandler.GetMaintenanceWindows)
+			systemAdmin.POST("/maintenance/windows", maintenanceHandler.ScheduleMaintenanceWindow)
+			systemAdmin.DELETE("/maintenance/windows/:id", maintenanceHandler.DeleteMaintenanceWindow)
+			systemAdmin.GET("/recalculations", recalculationHandler.GetRecalculations)
+			systemAdmin.POST("/recalculations", recalculationHandler.StartRecalculation)
+			systemAdmin.GET("/recalculations/:id", recalculationHandler.GetRecalculation)
+			systemAdmin.GET("/performance", performanceHandler.GetPerformance)
+			systemAdmin.POST("/livestock/species", livestockBreedHandler.CreateSystemSpecies)
+			systemAdmin.POST("/livestock/breeds", livestockBreedHandler.CreateSystemBreed)
+			systemAdmin.GET("/herd-book-templates", adminHerdBookHandler.GetAdminHerdBookTemplates)
+			systemAdmin.POST("/herd-book-templates", adminHerdBookHandler.CreateHerdBookTemplate)
+			systemAdmin.PUT("/herd-book-templates/:id", adminHerdBookHandler.UpdateHerdBookTemplate)
+			systemAdmin.DELETE("/herd-book-templates/:id", adminHerdBookHandler.DeleteHerdBookTemplate)
+			systemAdmin.GET("/demo-accounts", demoAccountHandler.GetDemoAccounts)
+			systemAdmin.POST("/demo-accounts", demoAccountHandler.CreateDemoAccount)
+			systemAdmin.POST("/demo-accounts/:id/reset", demoAccountHandler.ResetDemoAccount)
+			systemAdmin.DELETE("/demo-accounts/:id", demoAccountHandler.DeleteDemoAccount)
+		}
+
 		// Dashboard routes (protected)
-		dashboardHandler := handlers.NewDashboardHandler(db)
+		dashboardHandler := handlers.NewDashboardHandler(db, readDB)
 		dashboard := v1.Group("/dashboard")
-		dashboard.Use(middleware.Auth())
+		dashboard.Use(middleware.Auth(), farmScope)
 		{
 			dashboard.GET("/summary", dashboardHandler.GetSummary)
 			dashboard.GET("/recent-activities", dashboardHandler.GetRecentActivities)
+			dashboard.GET("/alerts", dashboardHandler.GetAlerts)
+			dashboard.GET("/kpi-snapshots", dashboardHandler.GetKPISnapshots)
+			dashboard.POST("/kpi-snapshots", dashboardHandler.CaptureKPISnapshot)
 
 			charts := dashboard.Group("/charts")
 			{
 				charts.GET("/income-expense", dashboardHandler.GetIncomeExpenseChart)
 				charts.GET("/production", dashboardHandler.GetProductionChart)
+				charts.GET("/config", dashboardHandler.GetChartConfig)
+				charts.GET("/:chartId", dashboardHandler.GetChartData)
 			}
 		}
 
+		// Analytics routes (protected)
+		analyticsHandler := handlers.NewAnalyticsHandler(db, readDB)
+		analytics := v1.Group("/analytics")
+		analytics.Use(middleware.Auth(), farmScope)
+		{
+			analytics.GET("/metrics", analyticsHandler.GetMetrics)
+			analytics.GET("/timeseries", analyticsHandler.GetTimeSeries)
+			analytics.GET("/forecast", analyticsHandler.GetForecast)
+			analytics.GET("/anomalies", analyticsHandler.GetAnomalies)
+		}
+
+		// Advisor routes (protected)
+		advisorHandler := handlers.NewAdvisorHandler(db)
+		advisor := v1.Group("/advisor")
+		advisor.Use(middleware.Auth(), farmScope)
+		{
+			advisor.POST("/ask", advisorHandler.Ask)
+			advisor.GET("/usage", advisorHandler.GetUsage)
+			advisor.GET("/conversations", advisorHandler.GetConversations)
+			advisor.GET("/conversations/:id", advisorHandler.GetConversation)
+		}
+
 		// Land routes (protected)
 		landHandler := handlers.NewLandHandler(db)
+		weatherStationHandler := handlers.NewWeatherStationHandler(db)
+		waterQuotaHandler := handlers.NewWaterQuotaHandler(db)
+		scoutingHandler := handlers.NewScoutingHandler(db)
+		workerHandler := handlers.NewWorkerHandler(db)
+		cropPlanHandler := handlers.NewCropPlanHandler(db)
+		irrigationHandler := handlers.NewIrrigationHandler(db)
 		lands := v1.Group("/lands")
-		lands.Use(middleware.Auth())
+		lands.Use(middleware.Auth(), farmScope)
 		{
 			lands.GET("", landHandler.GetLands)
 			lands.POST("", landHandler.CreateLand)
 			lands.GET("/:id", landHandler.GetLand)
 			lands.PUT("/:id", landHandler.UpdateLand)
 			lands.DELETE("/:id", landHandler.DeleteLand)
+			lands.GET("/:id/history", landHandler.GetLandHistory)
 			lands.GET("/statistics", landHandler.GetLandStatistics)
 			lands.GET("/productivity-analysis", landHandler.GetProductivityAnalysis)
+			lands.GET("/data-quality", landHandler.GetLandDataQuality)
+			lands.POST("/data-quality/area-corrections", landHandler.CorrectLandAreas)
+			lands.GET("/recommendations", landHandler.GetRecommendations)
+			lands.GET("/harvest-payroll", landHandler.GetHarvestPayroll)
+			lands.GET("/harvest-payroll/export", landHandler.ExportHarvestPayroll)
 
 			// Land activities
 			lands.GET("/:id/activities", landHandler.GetLandActivities)
 			lands.POST("/:id/activities", landHandler.CreateLandActivity)
+			lands.GET("/:id/activities/:activityId/costs", landHandler.GetActivityCosts)
+			lands.PUT("/:id/activities/:activityId/costs", landHandler.UpdateActivityCosts)
+			lands.GET("/:id/activities/:activityId/crew", landHandler.GetHarvestCrew)
+			lands.PUT("/:id/activities/:activityId/crew", landHandler.UpdateHarvestCrew)
+			lands.POST("/:id/activities/:activityId/crew/payroll", landHandler.PostHarvestPayroll)
+			lands.PUT("/:id/activities/:activityId/assignee", workerHandler.AssignActivityWorker)
+			lands.GET("/:id/profitability", landHandler.GetLandProfitability)
+
+			// Activity recommendations
+			lands.GET("/:id/recommendations", landHandler.GetLandRecommendations)
+			lands.POST("/:id/recommendations/schedule", landHandler.ScheduleRecommendation)
+
+			// Weather history
+			lands.GET("/:id/weather-history", landHandler.GetWeatherHistory)
+			lands.GET("/:id/weather-observations", landHandler.GetWeatherObservations)
+			lands.POST("/:id/weather-observations", landHandler.CreateWeatherObservation)
+			lands.POST("/:id/weather-observations/bulk", landHandler.BulkCreateWeatherObservations)
+			lands.DELETE("/:id/weather-observations/:observationId", landHandler.DeleteWeatherObservation)
+			lands.GET("/:id/weather-sources", weatherStationHandler.GetLandWeatherSources)
+			lands.PUT("/:id/weather-sources", weatherStationHandler.UpdateLandWeatherSources)
+			lands.GET("/:id/water-usage", waterQuotaHandler.GetLandWaterUsage)
+
+			// Field scouting
+			lands.GET("/:id/scouting", scoutingHandler.GetLandScoutingSessions)
+			lands.POST("/:id/scouting", scoutingHandler.CreateScoutingSession)
+			lands.GET("/:id/scouting/map", scoutingHandler.GetLandScoutingMap)
+
+			// Crop planning
+			lands.GET("/crop-plans", cropPlanHandler.GetCropPlans)
+			lands.GET("/:id/crop-plans", cropPlanHandler.GetLandCropPlans)
+			lands.POST("/:id/crop-plans", cropPlanHandler.CreateCropPlan)
+			lands.GET("/:id/crop-plans/:planId", cropPlanHandler.GetCropPlan)
+			lands.PUT("/:id/crop-plans/:planId", cropPlanHandler.UpdateCropPlan)
+			lands.DELETE("/:id/crop-plans/:planId", cropPlanHandler.DeleteCropPlan)
+
+			// Irrigation scheduling
+			lands.GET("/:id/irrigation-schedule", irrigationHandler.GetIrrigationSchedule)
+
+			// Cadastral parcels
+			lands.POST("/parcel-lookup", landHandler.LookupParcel)
+			lands.POST("/:id/parcel/sync", landHandler.SyncLandParcel)
+		}
+
+		// Greenhouse routes (protected)
+		greenhouseHandler := handlers.NewGreenhouseHandler(db)
+		greenhouses := v1.Group("/greenhouses")
+		greenhouses.Use(middleware.Auth(), farmScope)
+		{
+			greenhouses.GET("", greenhouseHandler.GetGreenhouses)
+			greenhouses.GET("/:id", greenhouseHandler.GetGreenhouse)
+			greenhouses.PUT("/:id", greenhouseHandler.UpdateGreenhouse)
+			greenhouses.DELETE("/:id", greenhouseHandler.DeleteGreenhouse)
+			greenhouses.GET("/:id/production", greenhouseHandler.GetGreenhouseProduction)
+
+			// Climate sensors and readings
+			greenhouses.POST("/:id/sensors", greenhouseHandler.CreateClimateSensor)
+			greenhouses.DELETE("/:id/sensors/:sensorId", greenhouseHandler.DeleteClimateSensor)
+			greenhouses.GET("/:id/readings", greenhouseHandler.GetClimateReadings)
+			greenhouses.POST("/:id/readings", greenhouseHandler.CreateClimateReading)
+			greenhouses.GET("/:id/alerts", greenhouseHandler.GetClimateAlerts)
+		}
+
+		// Climate sensor readings (public, sensör anahtarıyla doğrulanır)
+		sensors := v1.Group("/sensors")
+		{
+			sensors.POST("/readings", greenhouseHandler.ReceiveSensorReading)
+			sensors.POST("/weather-readings", weatherStationHandler.ReceiveWeatherStationReading)
 		}
 
 		// Livestock routes (protected)
 		livestockHandler := handlers.NewLivestockHandler(db)
 		livestock := v1.Group("/livestock")
-		livestock.Use(middleware.Auth())
+		livestock.Use(middleware.Auth(), farmScope)
 		{
 			livestock.GET("", livestockHandler.GetLivestock)
 			livestock.POST("", livestockHandler.CreateLivestock)
 			livestock.GET("/:id", livestockHandler.GetLivestockByID)
 			livestock.PUT("/:id", livestockHandler.UpdateLivestock)
 			livestock.DELETE("/:id", livestockHandler.DeleteLivestock)
+			livestock.GET("/:id/history", livestockHandler.GetLivestockHistory)
+			livestock.GET("/:id/passport.pdf", livestockHandler.GetPassport)
 			livestock.GET("/statistics", livestockHandler.GetLivestockStatistics)
 			livestock.GET("/categories", livestockHandler.GetLivestockCategories)
 
+			// Species and breeds
+			livestock.GET("/species", livestockBreedHandler.GetSpecies)
+			livestock.POST("/breeds", livestockBreedHandler.CreateBreed)
+			livestock.DELETE("/breeds/:id", livestockBreedHandler.DeleteBreed)
+
 			// Health records
 			livestock.GET("/:id/health-records", livestockHandler.GetHealthRecords)
 			livestock.POST("/:id/health-records", livestockHandler.CreateHealthRecord)
 
+			// Vaccination schedule
+			vaccinationHandler := handlers.NewVaccinationHandler(db)
+			livestock.GET("/vaccinations/upcoming", vaccinationHandler.GetUpcomingVaccinations)
+			livestock.GET("/:id/vaccinations", vaccinationHandler.GetVaccinations)
+			livestock.POST("/:id/vaccinations", vaccinationHandler.CreateVaccination)
+			livestock.GET("/:id/vaccinations/:vaccinationId", vaccinationHandler.GetVaccination)
+			livestock.PUT("/:id/vaccinations/:vaccinationId", vaccinationHandler.UpdateVaccination)
+			livestock.DELETE("/:id/vaccinations/:vaccinationId", vaccinationHandler.DeleteVaccination)
+
+			// Breeding and reproduction
+			breedingHandler := handlers.NewBreedingHandler(db)
+			livestock.GET("/:id/breeding", breedingHandler.GetBreedingRecords)
+			livestock.POST("/:id/breeding", breedingHandler.CreateBreedingRecord)
+			livestock.GET("/:id/breeding/:breedingId", breedingHandler.GetBreedingRecord)
+			livestock.PUT("/:id/breeding/:breedingId", breedingHandler.UpdateBreedingRecord)
+			livestock.DELETE("/:id/breeding/:breedingId", breedingHandler.DeleteBreedingRecord)
+
+			// Weight history
+			weightHandler := handlers.NewWeightHandler(db)
+			livestock.GET("/:id/weights", weightHandler.GetWeightRecords)
+			livestock.POST("/:id/weights", weightHandler.CreateWeightRecord)
+			livestock.DELETE("/:id/weights/:weightId", weightHandler.DeleteWeightRecord)
+			livestock.GET("/:id/growth-curve", weightHandler.GetGrowthCurve)
+
+			// Movements
+			livestock.GET("/locations", livestockHandler.GetLocationOccupancy)
+			livestock.GET("/movements", livestockHandler.GetMovementReport)
+			livestock.GET("/movements/export", livestockHandler.ExportMovementReport)
+			livestock.GET("/:id/movements", livestockHandler.GetMovements)
+			livestock.POST("/:id/movements", livestockHandler.CreateMovement)
+
+			// Slaughter and carcass yield
+			slaughterHandler := handlers.NewSlaughterHandler(db)
+			livestock.GET("/slaughter", slaughterHandler.GetSlaughterRecords)
+			livestock.GET("/slaughter/analytics", slaughterHandler.GetYieldAnalytics)
+			livestock.GET("/:id/slaughter", slaughterHandler.GetSlaughterRecord)
+			livestock.POST("/:id/slaughter", slaughterHandler.CreateSlaughterRecord)
+
+			// Acquisition, cost basis and profitability
+			profitabilityHandler := handlers.NewProfitabilityHandler(db)
+			livestock.GET("/profitability", profitabilityHandler.GetProfitability)
+			livestock.PUT("/:id/acquisition", profitabilityHandler.UpdateAcquisition)
+			livestock.GET("/:id/costs", profitabilityHandler.GetCosts)
+			livestock.POST("/:id/costs", profitabilityHandler.CreateCost)
+			livestock.POST("/:id/sale", profitabilityHandler.SellAnimal)
+			livestock.GET("/:id/profitability", profitabilityHandler.GetAnimalProfitability)
+
 			// Milk production
 			livestock.GET("/milk-production", livestockHandler.GetMilkProduction)
 			livestock.POST("/milk-production", livestockHandler.CreateMilkProduction)
+
+			// Official registry
+			registryHandler := handlers.NewRegistryHandler(db)
+			herdBookHandler := handlers.NewHerdBookHandler(db)
+			livestock.GET("/registry/export", registryHandler.ExportRegistry)
+			livestock.POST("/registry/import/preview", registryHandler.PreviewRegistryImport)
+			livestock.POST("/registry/import", registryHandler.ImportRegistry)
+			livestock.GET("/herd-book/templates", herdBookHandler.GetHerdBookTemplates)
+			livestock.POST("/herd-book", herdBookHandler.GenerateHerdBook)
+		}
+
+		// Beekeeping routes (protected)
+		hiveHandler := handlers.NewHiveHandler(db)
+		hives := v1.Group("/hives")
+		hives.Use(middleware.Auth(), farmScope)
+		{
+			hives.GET("", hiveHandler.GetHives)
+			hives.POST("", hiveHandler.CreateHive)
+			hives.GET("/treatments/due", hiveHandler.GetDueHiveTreatments)
+			hives.GET("/:id", hiveHandler.GetHive)
+			hives.PUT("/:id", hiveHandler.UpdateHive)
+			hives.DELETE("/:id", hiveHandler.DeleteHive)
+			hives.GET("/:id/inspections", hiveHandler.GetHiveInspections)
+			hives.POST("/:id/inspections", hiveHandler.CreateHiveInspection)
+			hives.DELETE("/:id/inspections/:inspectionId", hiveHandler.DeleteHiveInspection)
+			hives.GET("/:id/harvests", hiveHandler.GetHiveHarvests)
+			hives.POST("/:id/harvests", hiveHandler.CreateHiveHarvest)
+			hives.GET("/:id/treatments", hiveHandler.GetHiveTreatments)
+			hives.POST("/:id/treatments", hiveHandler.CreateHiveTreatment)
+		}
+
+		// Aquaculture routes (protected)
+		aquacultureHandler := handlers.NewAquacultureHandler(db)
+		ponds := v1.Group("/ponds")
+		ponds.Use(middleware.Auth(), farmScope)
+		{
+			ponds.GET("", aquacultureHandler.GetPonds)
+			ponds.POST("", aquacultureHandler.CreatePond)
+			ponds.GET("/feed-conversion", aquacultureHandler.GetFeedConversion)
+			ponds.GET("/:id", aquacultureHandler.GetPond)
+			ponds.PUT("/:id", aquacultureHandler.UpdatePond)
+			ponds.DELETE("/:id", aquacultureHandler.DeletePond)
+			ponds.GET("/:id/batches", aquacultureHandler.GetPondBatches)
+			ponds.POST("/:id/batches", aquacultureHandler.CreateFishBatch)
+		}
+		fishBatches := v1.Group("/fish-batches")
+		fishBatches.Use(middleware.Auth(), farmScope)
+		{
+			fishBatches.GET("/:id", aquacultureHandler.GetFishBatch)
+			fishBatches.DELETE("/:id", aquacultureHandler.DeleteFishBatch)
+			fishBatches.GET("/:id/records", aquacultureHandler.GetFishBatchRecords)
+			fishBatches.POST("/:id/records", aquacultureHandler.CreateFishBatchRecord)
+			fishBatches.DELETE("/:id/records/:recordId", aquacultureHandler.DeleteFishBatchRecord)
+			fishBatches.POST("/:id/harvest", aquacultureHandler.HarvestFishBatch)
 		}
 
 		// Production routes (protected)
 		productionHandler := handlers.NewProductionHandler(db)
 		production := v1.Group("/production")
-		production.Use(middleware.Auth())
+		production.Use(middleware.Auth(), farmScope)
 		{
 			production.GET("", productionHandler.GetProductions)
 			production.POST("", productionHandler.CreateProduction)
 			production.GET("/:id", productionHandler.GetProduction)
 			production.PUT("/:id", productionHandler.UpdateProduction)
 			production.DELETE("/:id", productionHandler.DeleteProduction)
+			production.POST("/:id/sell", productionHandler.SellProduction)
+			production.GET("/:id/sales", productionHandler.GetProductionSales)
+			production.GET("/:id/losses", productionHandler.GetProductionLosses)
+			production.POST("/:id/losses", productionHandler.CreateProductionLoss)
+			production.DELETE("/:id/losses/:lossId", productionHandler.DeleteProductionLoss)
 			production.GET("/statistics", productionHandler.GetProductionStatistics)
 			production.GET("/categories", productionHandler.GetProductionCategories)
+			production.GET("/price-history", productionHandler.GetPriceHistory)
+			production.GET("/losses/analysis", productionHandler.GetLossAnalysis)
+			production.GET("/market-prices", productionHandler.GetMarketPrices)
+			production.POST("/market-prices", productionHandler.CreateMarketPrice)
+			production.POST("/market-prices/sync", productionHandler.SyncMarketPrices)
+			production.DELETE("/market-prices/:priceId", productionHandler.DeleteMarketPrice)
 		}
 
 		// Finance routes (protected)
 		financeHandler := handlers.NewFinanceHandler(db)
 		finance := v1.Group("/finance")
-		finance.Use(middleware.Auth())
+		finance.Use(middleware.Auth(), farmScope)
 		{
 			finance.GET("/summary", financeHandler.GetFinanceSummary)
 			finance.GET("/transactions", financeHandler.GetTransactions)
@@ -118,28 +440,364 @@ func SetupRoutes(r *gin.Engine, db *sql.DB) {
 			finance.GET("/transactions/:id", financeHandler.GetTransaction)
 			finance.PUT("/transactions/:id", financeHandler.UpdateTransaction)
 			finance.DELETE("/transactions/:id", financeHandler.DeleteTransaction)
+			finance.PATCH("/transactions/:id/pay", financeHandler.PayTransaction)
 			finance.GET("/categories", financeHandler.GetCategories)
 			finance.GET("/analysis", financeHandler.GetFinanceAnalysis)
+			finance.GET("/periods", financeHandler.GetFiscalPeriods)
+			finance.GET("/aging", financeHandler.GetPaymentAging)
+			finance.GET("/tags", financeHandler.GetTransactionTags)
+			finance.GET("/tags/analysis", financeHandler.GetTagAnalysis)
+			finance.GET("/inbox", middleware.DenyDemo(), financeHandler.GetInboundEmail)
+			finance.POST("/inbox/rotate", middleware.DenyDemo(), financeHandler.RotateInboundEmail)
+			finance.GET("/drafts", financeHandler.GetTransactionDrafts)
+			finance.GET("/drafts/:id", financeHandler.GetTransactionDraft)
+			finance.GET("/drafts/:id/receipt", financeHandler.GetTransactionDraftReceipt)
+			finance.POST("/drafts/:id/approve", financeHandler.ApproveTransactionDraft)
+			finance.DELETE("/drafts/:id", financeHandler.DeleteTransactionDraft)
+			finance.GET("/bank-accounts", financeHandler.GetBankAccounts)
+			finance.POST("/bank-accounts", financeHandler.CreateBankAccount)
+			finance.GET("/bank-accounts/:id", financeHandler.GetBankAccount)
+			finance.DELETE("/bank-accounts/:id", financeHandler.DeleteBankAccount)
+			finance.POST("/bank-accounts/:id/statements", financeHandler.ImportBankStatement)
+			finance.POST("/bank-accounts/:id/match", financeHandler.MatchBankStatementLines)
+			finance.GET("/bank-accounts/:id/lines", financeHandler.GetBankStatementLines)
+			finance.POST("/bank-accounts/:id/lines/create-transactions", financeHandler.CreateTransactionsFromStatement)
+			finance.PATCH("/bank-accounts/:id/lines/:lineId", financeHandler.UpdateBankStatementLine)
+			finance.GET("/allocation-rules", financeHandler.GetAllocationRules)
+			finance.POST("/allocation-rules", financeHandler.CreateAllocationRule)
+			finance.PUT("/allocation-rules/:id", financeHandler.UpdateAllocationRule)
+			finance.DELETE("/allocation-rules/:id", financeHandler.DeleteAllocationRule)
+			finance.POST("/allocation-rules/:id/run", financeHandler.RunAllocationRule)
+			finance.DELETE("/allocation-rules/:id/run", financeHandler.DeleteAllocationRun)
+			finance.GET("/allocations", financeHandler.GetAllocations)
+			finance.GET("/invoices", financeHandler.GetInvoices)
+			finance.POST("/invoices", financeHandler.CreateInvoice)
+			finance.GET("/invoices/:id", financeHandler.GetInvoice)
+			finance.GET("/invoices/:id/pdf", financeHandler.GetInvoicePDF)
+		}
+
+		// Sales routes (protected)
+		salesHandler := handlers.NewSalesHandler(db)
+		sales := v1.Group("/sales")
+		sales.Use(middleware.Auth(), farmScope)
+		{
+			sales.GET("/customers", salesHandler.GetCustomers)
+			sales.POST("/customers", salesHandler.CreateCustomer)
+			sales.GET("/customers/:id", salesHandler.GetCustomer)
+			sales.PUT("/customers/:id", salesHandler.UpdateCustomer)
+			sales.DELETE("/customers/:id", salesHandler.DeleteCustomer)
+			sales.GET("/orders", salesHandler.GetSalesOrders)
+			sales.POST("/orders", salesHandler.CreateSalesOrder)
+			sales.GET("/orders/:id", salesHandler.GetSalesOrder)
+			sales.PUT("/orders/:id", salesHandler.UpdateSalesOrder)
+			sales.PATCH("/orders/:id/status", salesHandler.UpdateSalesOrderStatus)
+			sales.DELETE("/orders/:id", salesHandler.DeleteSalesOrder)
+		}
+
+		// Inbound email webhook (public, paylaşılan anahtarla doğrulanır)
+		inbound := v1.Group("/inbound")
+		{
+			inbound.POST("/email", financeHandler.ReceiveInboundEmail)
+		}
+
+		// Fixed asset routes (protected)
+		assetHandler := handlers.NewAssetHandler(db)
+		assets := v1.Group("/assets")
+		assets.Use(middleware.Auth(), farmScope)
+		{
+			assets.GET("", assetHandler.GetAssets)
+			assets.POST("", assetHandler.CreateAsset)
+			assets.GET("/report", assetHandler.GetAssetReport)
+			assets.POST("/depreciation/post", assetHandler.PostDepreciation)
+			assets.GET("/:id", assetHandler.GetAsset)
+			assets.PUT("/:id", assetHandler.UpdateAsset)
+			assets.DELETE("/:id", assetHandler.DeleteAsset)
+			assets.PATCH("/:id/dispose", assetHandler.DisposeAsset)
+			assets.GET("/:id/schedule", assetHandler.GetSchedule)
+		}
+
+		// Utility meter routes (protected)
+		utilityHandler := handlers.NewUtilityHandler(db)
+		utilities := v1.Group("/utilities")
+		utilities.Use(middleware.Auth(), farmScope)
+		{
+			utilities.GET("/meters", utilityHandler.GetMeters)
+			utilities.POST("/meters", utilityHandler.CreateMeter)
+			utilities.GET("/meters/:id", utilityHandler.GetMeter)
+			utilities.PUT("/meters/:id", utilityHandler.UpdateMeter)
+			utilities.DELETE("/meters/:id", utilityHandler.DeleteMeter)
+			utilities.GET("/meters/:id/readings", utilityHandler.GetReadings)
+			utilities.POST("/meters/:id/readings", utilityHandler.CreateReading)
+			utilities.DELETE("/meters/:id/readings/:readingId", utilityHandler.DeleteReading)
+			utilities.GET("/analytics", utilityHandler.GetUtilityAnalytics)
+		}
+
+		// Sustainability routes (protected)
+		sustainabilityHandler := handlers.NewSustainabilityHandler(db)
+		sustainability := v1.Group("/sustainability")
+		sustainability.Use(middleware.Auth(), farmScope)
+		{
+			sustainability.GET("/carbon", sustainabilityHandler.GetCarbonFootprint)
+			sustainability.GET("/carbon/export", sustainabilityHandler.ExportCarbonFootprint)
+			sustainability.GET("/coefficients", sustainabilityHandler.GetCarbonCoefficients)
+		}
+
+		// Compliance routes (protected)
+		complianceHandler := handlers.NewComplianceHandler(db)
+		compliance := v1.Group("/compliance")
+		compliance.Use(middleware.Auth(), farmScope)
+		{
+			compliance.GET("/checklists", complianceHandler.GetChecklists)
+			compliance.POST("/checklists", complianceHandler.CreateChecklist)
+			compliance.GET("/checklists/:id", complianceHandler.GetChecklist)
+			compliance.PUT("/checklists/:id", complianceHandler.UpdateChecklist)
+			compliance.DELETE("/checklists/:id", complianceHandler.DeleteChecklist)
+			compliance.PUT("/checklists/:id/requirements/:code", complianceHandler.UpdateRequirementStatus)
+			compliance.POST("/checklists/:id/requirements/:code/evidence", complianceHandler.UploadEvidence)
+			compliance.GET("/checklists/:id/export", complianceHandler.ExportBundle)
+		}
+
+		// Document routes (protected)
+		documentHandler := handlers.NewDocumentHandler(db)
+		documentSignatureHandler := handlers.NewDocumentSignatureHandler(db)
+		documents := v1.Group("/documents")
+		documents.Use(middleware.Auth(), farmScope)
+		{
+			documents.GET("", documentHandler.GetDocuments)
+			documents.GET("/signatures", documentSignatureHandler.GetDocumentSignatures)
+			documents.POST("", documentHandler.CreateDocument)
+			documents.GET("/:id", documentHandler.GetDocument)
+			documents.PUT("/:id", documentHandler.UpdateDocument)
+			documents.DELETE("/:id", documentHandler.DeleteDocument)
+			documents.PUT("/:id/file", documentHandler.UploadDocumentFile)
+			documents.GET("/:id/file", documentHandler.GetDocumentFile)
+		}
+
+		// Farm routes (protected)
+		farmHandler := handlers.NewFarmHandler(db)
+		publicProfileHandler := handlers.NewPublicProfileHandler(db)
+		farms := v1.Group("/farms")
+		farms.Use(middleware.Auth())
+		{
+			farms.GET("", farmHandler.GetFarms)
+			farms.POST("", farmHandler.CreateFarm)
+			farms.GET("/:id", farmHandler.GetFarm)
+			farms.PUT("/:id", farmHandler.UpdateFarm)
+			farms.DELETE("/:id", farmHandler.DeleteFarm)
+			farms.GET("/:id/public-profile", publicProfileHandler.GetFarmPublicProfile)
+			farms.PUT("/:id/public-profile", middleware.DenyDemo(), publicProfileHandler.SaveFarmPublicProfile)
+			farms.DELETE("/:id/public-profile", publicProfileHandler.DeleteFarmPublicProfile)
+		}
+
+		// Public farm profiles (public, istemci IP'si başına dakikada 60 istekle sınırlı)
+		publicFarms := v1.Group("/public/farms")
+		publicFarms.Use(middleware.RateLimit(60, time.Minute))
+		{
+			publicFarms.GET("", publicProfileHandler.GetPublicFarms)
+			publicFarms.GET("/:slug", publicProfileHandler.GetPublicFarm)
+			publicFarms.GET("/:slug/photos/:photoId", publicProfileHandler.GetPublicFarmPhoto)
+		}
+
+		// Document verification (public, belge alıcıları oturum açmadan doğrular; IP başına dakikada 30 istek)
+		publicDocuments := v1.Group("/public/documents")
+		publicDocuments.Use(middleware.RateLimit(30, time.Minute))
+		{
+			publicDocuments.GET("/signing-keys", documentSignatureHandler.GetDocumentSigningKeys)
+			publicDocuments.POST("/verify", documentSignatureHandler.VerifyDocument)
+		}
+
+		// Category routes (protected)
+		categoryHandler := handlers.NewCategoryHandler(db)
+		categories := v1.Group("/categories")
+		categories.Use(middleware.Auth(), farmScope)
+		{
+			categories.GET("", categoryHandler.GetCategories)
+			categories.POST("", categoryHandler.CreateCategory)
+			categories.PUT("/:id", categoryHandler.UpdateCategory)
+			categories.DELETE("/:id", categoryHandler.DeleteCategory)
+		}
+
+		// Treatment protocol routes (protected)
+		protocolHandler := handlers.NewProtocolHandler(db)
+		protocols := v1.Group("/protocols")
+		protocols.Use(middleware.Auth(), farmScope)
+		{
+			protocols.GET("", protocolHandler.GetProtocols)
+			protocols.POST("", protocolHandler.CreateProtocol)
+			protocols.PUT("/:id", protocolHandler.UpdateProtocol)
+			protocols.DELETE("/:id", protocolHandler.DeleteProtocol)
+			protocols.POST("/:id/apply", protocolHandler.ApplyProtocol)
+		}
+
+		// Veterinary visit routes (protected)
+		vetVisitHandler := handlers.NewVetVisitHandler(db)
+		vetVisits := v1.Group("/vet-visits")
+		vetVisits.Use(middleware.Auth())
+		{
+			// Çiftçi işlemleri seçili çiftlik (X-Farm-ID) adına yapılır; veterinerin istekleri başlıksız geçer
+			farmer := vetVisits.Group("")
+			farmer.Use(farmScope)
+			{
+				farmer.GET("", vetVisitHandler.GetVisits)
+				farmer.POST("", middleware.DenyDemo(), vetVisitHandler.RequestVisit)
+				farmer.GET("/veterinarians", vetVisitHandler.GetVeterinarians)
+				farmer.POST("/veterinarians", vetVisitHandler.LinkVeterinarian)
+				farmer.DELETE("/veterinarians/:id", vetVisitHandler.UnlinkVeterinarian)
+				farmer.GET("/veterinarians/:id/availability", vetVisitHandler.GetAvailability)
+				farmer.GET("/:id", vetVisitHandler.GetVisit)
+				farmer.PATCH("/:id/cancel", vetVisitHandler.CancelVisit)
+			}
+
+			// Veteriner rolü gerektiren işlemler
+			veterinarian := vetVisits.Group("")
+			veterinarian.Use(middleware.RequireRole(models.RoleVeterinarian))
+			{
+				veterinarian.PATCH("/:id/confirm", vetVisitHandler.ConfirmVisit)
+				veterinarian.PATCH("/:id/decline", vetVisitHandler.DeclineVisit)
+				veterinarian.POST("/:id/complete", vetVisitHandler.CompleteVisit)
+			}
+		}
+
+		// Saved view routes (protected)
+		viewHandler := handlers.NewViewHandler(db)
+		views := v1.Group("/views")
+		views.Use(middleware.Auth(), farmScope)
+		{
+			views.GET("", viewHandler.GetViews)
+			views.POST("", viewHandler.CreateView)
+			views.GET("/default/:resource", viewHandler.GetDefaultView)
+			views.PUT("/:id", viewHandler.UpdateView)
+			views.PATCH("/:id/default", viewHandler.SetDefaultView)
+			views.DELETE("/:id", viewHandler.DeleteView)
+		}
+
+		// Activity template routes (protected)
+		templateHandler := handlers.NewTemplateHandler(db)
+		templates := v1.Group("/templates")
+		templates.Use(middleware.Auth(), farmScope)
+		{
+			templates.GET("", templateHandler.GetTemplates)
+			templates.POST("", templateHandler.CreateTemplate)
+			templates.PUT("/:id", templateHandler.UpdateTemplate)
+			templates.DELETE("/:id", templateHandler.DeleteTemplate)
+		}
+
+		quickLog := v1.Group("/quick-log")
+		quickLog.Use(middleware.Auth(), farmScope)
+		{
+			quickLog.POST("", templateHandler.QuickLog)
+		}
+
+		// Media routes (protected)
+		mediaHandler := handlers.NewMediaHandler(db)
+		media := v1.Group("/media")
+		media.Use(middleware.Auth(), farmScope)
+		{
+			media.GET("/voice-notes", mediaHandler.GetVoiceNotes)
+			media.POST("/voice-notes", mediaHandler.UploadVoiceNote)
+			media.POST("/photos", mediaHandler.UploadPhoto)
+			media.GET("/:id/content", mediaHandler.GetMediaContent)
+			media.POST("/:id/transcribe", mediaHandler.TranscribeMedia)
+			media.DELETE("/:id", mediaHandler.DeleteMedia)
+		}
+
+		// Diagnosis routes (protected)
+		diagnosisHandler := handlers.NewDiagnosisHandler(db)
+		diagnosis := v1.Group("/diagnosis")
+		diagnosis.Use(middleware.Auth(), farmScope)
+		{
+			diagnosis.POST("/identify", diagnosisHandler.IdentifyPhoto)
+			diagnosis.GET("/observations", diagnosisHandler.GetObservations)
+			diagnosis.GET("/observations/:id", diagnosisHandler.GetObservation)
+			diagnosis.PUT("/observations/:id", diagnosisHandler.UpdateObservation)
+		}
+
+		// Notes timeline routes (protected)
+		noteHandler := handlers.NewNoteHandler(db)
+		notes := v1.Group("/notes")
+		notes.Use(middleware.Auth(), farmScope)
+		{
+			notes.GET("/members", noteHandler.GetNoteMembers)
+			notes.GET("/:entityType/:entityId", noteHandler.GetEntityNotes)
+			notes.POST("/:entityType/:entityId", noteHandler.CreateEntityNote)
+		}
+
+		// Record link routes (protected)
+		recordLinkHandler := handlers.NewRecordLinkHandler(db)
+		recordLinks := v1.Group("/links")
+		recordLinks.Use(middleware.Auth(), farmScope)
+		{
+			recordLinks.POST("", recordLinkHandler.CreateRecordLink)
+			recordLinks.GET("/:entityType/:entityId", recordLinkHandler.GetRecordLinks)
+			recordLinks.DELETE("/:id", recordLinkHandler.DeleteRecordLink)
+		}
+
+		// Historical data import routes (protected)
+		dataImportHandler := handlers.NewDataImportHandler(db)
+		dataImports := v1.Group("/imports")
+		dataImports.Use(middleware.Auth(), farmScope)
+		{
+			dataImports.GET("", dataImportHandler.GetImports)
+			dataImports.GET("/profiles", dataImportHandler.GetImportProfiles)
+			dataImports.POST("/preview", dataImportHandler.PreviewImport)
+			dataImports.GET("/:id", dataImportHandler.GetImport)
+			dataImports.POST("/:id/commit", dataImportHandler.CommitImport)
+			dataImports.POST("/:id/discard", dataImportHandler.DiscardImport)
+		}
+
+		// Integration routes: anahtar yönetimi JWT ile, tetikleyiciler entegrasyon anahtarıyla
+		integrationHandler := handlers.NewIntegrationHandler(db)
+		integrationKeys := v1.Group("/integrations/keys")
+		integrationKeys.Use(middleware.Auth(), farmScope)
+		{
+			integrationKeys.GET("", integrationHandler.GetIntegrationKeys)
+			integrationKeys.POST("", middleware.DenyDemo(), integrationHandler.CreateIntegrationKey)
+			integrationKeys.DELETE("/:id", integrationHandler.RevokeIntegrationKey)
+		}
+
+		integrations := v1.Group("/integrations")
+		integrations.Use(middleware.IntegrationKey(db))
+		{
+			integrations.GET("/me", integrationHandler.GetIntegrationAccount)
+			integrations.GET("/triggers", integrationHandler.GetIntegrationTriggers)
+			integrations.GET("/new-transactions", integrationHandler.GetNewTransactions)
+			integrations.GET("/new-animals", integrationHandler.GetNewAnimals)
+			integrations.GET("/new-events", integrationHandler.GetNewEvents)
+			integrations.GET("/new-harvests", integrationHandler.GetNewHarvests)
+		}
+
+		// Search routes (protected)
+		searchHandler := handlers.NewSearchHandler(db)
+		search := v1.Group("/search")
+		search.Use(middleware.Auth(), farmScope)
+		{
+			search.GET("", searchHandler.Search)
 		}
 
 		// Calendar routes (protected)
 		calendarHandler := handlers.NewCalendarHandler(db)
 		calendar := v1.Group("/calendar")
-		calendar.Use(middleware.Auth())
+		calendar.Use(middleware.Auth(), farmScope)
 		{
 			calendar.GET("/events", calendarHandler.GetEvents)
+			calendar.GET("/events/export", calendarHandler.ExportEvents)
+			calendar.GET("/tasks/export", calendarHandler.ExportTasks)
 			calendar.POST("/events", calendarHandler.CreateEvent)
 			calendar.GET("/events/:id", calendarHandler.GetEvent)
 			calendar.PUT("/events/:id", calendarHandler.UpdateEvent)
 			calendar.DELETE("/events/:id", calendarHandler.DeleteEvent)
 			calendar.PATCH("/events/:id/status", calendarHandler.UpdateEventStatus)
 			calendar.GET("/statistics", calendarHandler.GetCalendarStatistics)
+			calendar.GET("/heatmap", calendarHandler.GetCalendarHeatmap)
+			calendar.GET("/rules", calendarHandler.GetEventRules)
+			calendar.POST("/rules/sync", calendarHandler.SyncEventRules)
+			calendar.PUT("/rules/:rule", calendarHandler.UpdateEventRule)
 		}
 
 		// Notification routes (protected)
 		notificationHandler := handlers.NewNotificationHandler(db)
 		notifications := v1.Group("/notifications")
-		notifications.Use(middleware.Auth())
+		notifications.Use(middleware.Auth(), farmScope)
 		{
 			notifications.GET("", notificationHandler.GetNotifications)
 			notifications.PATCH("/:id/read", notificationHandler.MarkAsRead)
@@ -147,46 +805,170 @@ func SetupRoutes(r *gin.Engine, db *sql.DB) {
 			notifications.DELETE("/:id", notificationHandler.DeleteNotification)
 			notifications.GET("/settings", notificationHandler.GetNotificationSettings)
 			notifications.PUT("/settings", notificationHandler.UpdateNotificationSettings)
+			notifications.GET("/action-catalog", notificationHandler.GetActionCatalog)
 		}
 
 		// Settings routes (protected)
 		settingsHandler := handlers.NewSettingsHandler(db)
 		settings := v1.Group("/settings")
-		settings.Use(middleware.Auth())
+		settings.Use(middleware.Auth(), farmScope)
 		{
 			settings.GET("", settingsHandler.GetSettings)
 			settings.PUT("", settingsHandler.UpdateSettings)
 			settings.GET("/system-info", settingsHandler.GetSystemInfo)
 			settings.POST("/backup", settingsHandler.CreateBackup)
+			settings.GET("/backups", settingsHandler.GetBackups)
+			settings.GET("/backups/:id/download", settingsHandler.DownloadBackup)
+			settings.DELETE("/backups/:id", settingsHandler.DeleteBackup)
 			settings.POST("/restore", settingsHandler.RestoreBackup)
 		}
 
+		// Support routes (protected)
+		support := v1.Group("/support")
+		support.Use(middleware.Auth(), farmScope)
+		{
+			support.POST("/tickets", middleware.DenyDemo(), supportHandler.CreateTicket)
+			support.GET("/tickets", supportHandler.GetTickets)
+			support.GET("/tickets/:id", supportHandler.GetTicket)
+			support.POST("/tickets/:id/messages", middleware.DenyDemo(), supportHandler.AddTicketMessage)
+		}
+
+		// Changelog routes (protected, hesap düzeyinde)
+		changelog := v1.Group("/changelog")
+		changelog.Use(middleware.Auth())
+		{
+			changelog.GET("", changelogHandler.GetChangelog)
+			changelog.POST("/seen", changelogHandler.MarkChangelogSeen)
+		}
+
 		// Weather routes (protected)
 		weatherHandler := handlers.NewWeatherHandler(db)
+		weatherLocationHandler := handlers.NewWeatherLocationHandler(db)
 		weather := v1.Group("/weather")
-		weather.Use(middleware.Auth())
+		weather.Use(middleware.Auth(), farmScope)
 		{
 			weather.GET("/current", weatherHandler.GetCurrentWeather)
 			weather.GET("/forecast", weatherHandler.GetWeatherForecast)
 			weather.GET("/agricultural-alerts", weatherHandler.GetAgriculturalAlerts)
+			weather.GET("/locations", weatherLocationHandler.GetWeatherLocations)
+			weather.POST("/locations", weatherLocationHandler.CreateWeatherLocation)
+			weather.GET("/locations/:id", weatherLocationHandler.GetWeatherLocation)
+			weather.PUT("/locations/:id", weatherLocationHandler.UpdateWeatherLocation)
+			weather.DELETE("/locations/:id", weatherLocationHandler.DeleteWeatherLocation)
+			weather.GET("/locations/:id/forecast", weatherLocationHandler.GetWeatherLocationForecast)
+		}
+
+		// Weather station routes (protected)
+		weatherStations := v1.Group("/weather-stations")
+		weatherStations.Use(middleware.Auth(), farmScope)
+		{
+			weatherStations.GET("", weatherStationHandler.GetWeatherStations)
+			weatherStations.POST("", weatherStationHandler.CreateWeatherStation)
+			weatherStations.PUT("/:id", weatherStationHandler.UpdateWeatherStation)
+			weatherStations.DELETE("/:id", weatherStationHandler.DeleteWeatherStation)
+			weatherStations.GET("/:id/readings", weatherStationHandler.GetWeatherStationReadings)
+		}
+
+		// Water quota routes (protected)
+		waterQuotas := v1.Group("/water-quotas")
+		waterQuotas.Use(middleware.Auth(), farmScope)
+		{
+			waterQuotas.GET("", waterQuotaHandler.GetWaterQuotas)
+			waterQuotas.POST("", waterQuotaHandler.CreateWaterQuota)
+			waterQuotas.GET("/:id", waterQuotaHandler.GetWaterQuota)
+			waterQuotas.PUT("/:id", waterQuotaHandler.UpdateWaterQuota)
+			waterQuotas.DELETE("/:id", waterQuotaHandler.DeleteWaterQuota)
+		}
+
+		// Worker routes (protected)
+		workers := v1.Group("/workers")
+		workers.Use(middleware.Auth(), farmScope)
+		{
+			workers.GET("", workerHandler.GetWorkers)
+			workers.POST("", workerHandler.CreateWorker)
+			workers.GET("/:id", workerHandler.GetWorker)
+			workers.PUT("/:id", workerHandler.UpdateWorker)
+			workers.DELETE("/:id", workerHandler.DeleteWorker)
+			workers.POST("/:id/certifications", workerHandler.CreateWorkerCertification)
+			workers.DELETE("/:id/certifications/:certificationId", workerHandler.DeleteWorkerCertification)
+		}
+
+		// Accountant routes (protected); muhasebeci erişimi yalnızca çiftlik sahibi tarafından yönetilir
+		accountantHandler := handlers.NewAccountantHandler(db)
+		accountants := v1.Group("/accountants")
+		accountants.Use(middleware.Auth(), farmScope)
+		{
+			accountants.GET("", accountantHandler.GetAccountants)
+			accountants.POST("", middleware.DenyDemo(), accountantHandler.InviteAccountant)
+			accountants.GET("/access-log", accountantHandler.GetAccountantAccessLog)
+			accountants.GET("/farms", accountantHandler.GetAccountantFarms)
+			accountants.DELETE("/:id", accountantHandler.RevokeAccountant)
+		}
+
+		// Scouting routes (protected)
+		scouting := v1.Group("/scouting")
+		scouting.Use(middleware.Auth(), farmScope)
+		{
+			scouting.GET("/:id", scoutingHandler.GetScoutingSession)
+			scouting.PUT("/:id", scoutingHandler.UpdateScoutingSession)
+			scouting.DELETE("/:id", scoutingHandler.DeleteScoutingSession)
+			scouting.POST("/:id/points", scoutingHandler.CreateScoutingPoint)
+			scouting.PUT("/:id/points/:pointId", scoutingHandler.UpdateScoutingPoint)
+			scouting.DELETE("/:id/points/:pointId", scoutingHandler.DeleteScoutingPoint)
+			scouting.POST("/:id/points/:pointId/convert", scoutingHandler.ConvertScoutingPoint)
+		}
+
+		// Enterprise routes (protected)
+		enterpriseHandler := handlers.NewEnterpriseHandler(db)
+		enterprises := v1.Group("/enterprises")
+		enterprises.Use(middleware.Auth(), farmScope)
+		{
+			enterprises.GET("", enterpriseHandler.GetEnterprises)
+			enterprises.POST("", enterpriseHandler.CreateEnterprise)
+			enterprises.GET("/pnl", enterpriseHandler.GetEnterprisePnL)
+			enterprises.GET("/:id", enterpriseHandler.GetEnterprise)
+			enterprises.PUT("/:id", enterpriseHandler.UpdateEnterprise)
+			enterprises.DELETE("/:id", enterpriseHandler.DeleteEnterprise)
+			enterprises.GET("/:id/pnl", enterpriseHandler.GetEnterpriseDetailPnL)
 		}
 
 		// Reports routes (protected)
 		reportsHandler := handlers.NewReportsHandler(db)
 		reports := v1.Group("/reports")
-		reports.Use(middleware.Auth())
+		reports.Use(middleware.Auth(), farmScope)
 		{
 			reports.GET("", reportsHandler.GetReports)
 			reports.POST("/generate", reportsHandler.GenerateReport)
+			reports.GET("/jobs/:id", reportsHandler.GetReportJob)
 			reports.GET("/:id/download", reportsHandler.DownloadReport)
 			reports.GET("/performance-metrics", reportsHandler.GetPerformanceMetrics)
 			reports.GET("/comparison", reportsHandler.GetComparisonAnalysis)
+			reports.GET("/farm-comparison", reportsHandler.GetFarmComparison)
+		}
+
+		// Archive routes (protected)
+		archiveHandler := handlers.NewArchiveHandler(db)
+		archives := v1.Group("/archives")
+		archives.Use(middleware.Auth(), farmScope)
+		{
+			archives.GET("", archiveHandler.GetArchives)
+			archives.POST("", archiveHandler.CreateArchive)
+			archives.GET("/jobs/:id", archiveHandler.GetArchiveJob)
+			archives.GET("/aggregates", archiveHandler.GetArchiveAggregates)
+			archives.GET("/:id", archiveHandler.GetArchive)
+			archives.GET("/:id/records", archiveHandler.GetArchiveRecords)
+			archives.POST("/:id/restore", archiveHandler.RestoreArchive)
 		}
 	}
 
 	// Swagger dokümantasyonu
 	r.GET("/swagger/*any", ginSwagger.WrapHandler(swaggerFiles.Handler))
 
+	// Ham OpenAPI dokümanı; istemci kodu üretimi (ör. Dart) için operationId ve tipli şemalar içerir
+	r.GET("/openapi.json", func(c *gin.Context) {
+		c.Data(http.StatusOK, "application/json; charset=utf-8", []byte(docs.SwaggerInfo.ReadDoc()))
+	})
+
 	// Health check
 	r.GET("/health", func(c *gin.Context) {
 		c.JSON(200, gin.H{
@@ -202,6 +984,7 @@ func SetupRoutes(r *gin.Engine, db *sql.DB) {
 			"message": "Tarım Yönetim Sistemi API'ye Hoş Geldiniz!",
 			"version": "1.0.0",
 			"docs":    "/swagger/index.html",
+			"openapi": "/openapi.json",
 			"health":  "/health",
 		})
 	})
@@ -0,0 +1,92 @@
+package routes
+
+import (
+	"archive/zip"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+)
+
+func TestCalendarExportEscapesFormulas(t *testing.T) {
+	engine, _ := newTenantTestServer(t)
+	owner := registerTenant(t, engine, "calendar-export@example.com")
+
+	owner.createID(tenantProbe{http.MethodPost, "/calendar/events", `{"title":"=HYPERLINK(\"http://evil\")","type":"task","startDate":"2026-03-01T08:00:00Z","endDate":"2026-03-01T09:00:00Z","priority":"medium","status":"pending","description":"@SUM(A1)"}`})
+
+	status, resp := owner.do(http.MethodGet, "/calendar/events/export?format=csv&startDate=2026-01-01&endDate=2026-12-31", "")
+	body, _ := resp["raw"].(string)
+	if status != http.StatusOK {
+		t.Fatalf("dışa aktarım başarısız (%d): %v", status, resp)
+	}
+	if !strings.Contains(body, `'=HYPERLINK`) || !strings.Contains(body, `'@SUM(A1)`) {
+		t.Fatalf("formül hücreleri kaçışlanmadı: %s", body)
+	}
+}
+
+func TestHerdBookCSVEscapesFormulas(t *testing.T) {
+	engine, db := newTenantTestServer(t)
+	owner := registerTenant(t, engine, "herd-book-export@example.com")
+
+	_, err := db.Exec(`
+		INSERT INTO herd_book_templates (id, association, title, format, generations, fields)
+		VALUES ('csv-template', 'Birlik', 'Soy Kütüğü', 'csv', 1, '[{"key":"tagNumber","label":"Küpe No"},{"key":"breed","label":"Irk"},{"key":"notes","label":"Notlar"}]')
+	`)
+	if err != nil {
+		t.Fatalf("şablon eklenemedi: %v", err)
+	}
+	animalID := owner.createID(tenantProbe{http.MethodPost, "/livestock", `{"tagNumber":"+TR-1","type":"cattle","breed":"Holstein","gender":"female","birthDate":"2024-01-01T00:00:00Z","healthStatus":"healthy","notes":"@SUM(A1)"}`})
+
+	status, resp := owner.do(http.MethodPost, "/livestock/herd-book", `{"templateId":"csv-template","animalIds":["`+animalID+`"]}`)
+	body, _ := resp["raw"].(string)
+	if status != http.StatusOK {
+		t.Fatalf("soy kütüğü oluşturulamadı (%d): %v", status, resp)
+	}
+	for _, cell := range []string{`'+TR-1`, `'@SUM(A1)`} {
+		if !strings.Contains(body, cell) {
+			t.Fatalf("%s kaçışlanmadı: %s", cell, body)
+		}
+	}
+}
+
+func TestRegistryCSVEscapesFormulas(t *testing.T) {
+	engine, _ := newTenantTestServer(t)
+	owner := registerTenant(t, engine, "registry-export@example.com")
+
+	owner.createID(tenantProbe{http.MethodPost, "/livestock", `{"tagNumber":"=TR-1","type":"cattle","breed":"Holstein","gender":"female","birthDate":"2024-01-01T00:00:00Z","healthStatus":"healthy"}`})
+
+	status, resp := owner.do(http.MethodGet, "/livestock/registry/export?format=csv", "")
+	body, _ := resp["raw"].(string)
+	if status != http.StatusOK {
+		t.Fatalf("kayıt dışa aktarılamadı (%d): %v", status, resp)
+	}
+	if !strings.Contains(body, `'=TR-1`) {
+		t.Fatalf("küpe numarası kaçışlanmadı: %s", body)
+	}
+}
+
+func TestComplianceBundleEscapesFormulas(t *testing.T) {
+	engine, _ := newTenantTestServer(t)
+	owner := registerTenant(t, engine, "compliance-export@example.com")
+
+	id := owner.createID(tenantProbe{http.MethodPost, "/compliance/checklists", `{"standard":"custom","name":"İç denetim","requirements":[{"code":"R1","title":"=HYPERLINK(\"http://evil\")"}]}`})
+
+	status, resp := owner.do(http.MethodGet, "/compliance/checklists/"+id+"/export", "")
+	body, _ := resp["raw"].(string)
+	if status != http.StatusOK {
+		t.Fatalf("paket oluşturulamadı (%d): %v", status, resp)
+	}
+	archive, err := zip.NewReader(strings.NewReader(body), int64(len(body)))
+	if err != nil {
+		t.Fatalf("paket okunamadı: %v", err)
+	}
+	file, err := archive.Open("kontrol-listesi.csv")
+	if err != nil {
+		t.Fatalf("kontrol listesi bulunamadı: %v", err)
+	}
+	defer file.Close()
+	summary, _ := io.ReadAll(file)
+	if !strings.Contains(string(summary), `'=HYPERLINK`) {
+		t.Fatalf("gereksinim başlığı kaçışlanmadı: %s", summary)
+	}
+}
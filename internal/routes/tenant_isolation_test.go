@@ -0,0 +1,502 @@
+package routes
+
+import (
+	"bytes"
+	"database/sql"
+	"encoding/base64"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"agri-management-api/internal/database"
+	"agri-management-api/internal/services"
+
+	"github.com/gin-gonic/gin"
+)
+
+// tenantProbe bir uç noktaya gönderilen istek; yoldaki {id} oluşturulan kaydın, {land}, {animal},
+// {transaction}, {production}, {pond} ve {customer} sahibin ön kayıtlarının kimliğiyle değiştirilir
+type tenantProbe struct {
+	method string
+	path   string
+	body   string
+}
+
+// tenantCase sahibin oluşturduğu bir kayıt ve bu kayıt üzerinde denenen istekler. Her istek önce diğer
+// kullanıcıyla (404/403 beklenir), sonra sahibiyle (2xx beklenir) gönderilir; silme istekleri sona konur
+type tenantCase struct {
+	name   string
+	create *tenantProbe
+	probes []tenantProbe
+}
+
+// tenantClient test sunucusuna bir kullanıcının jetonuyla istek gönderir
+type tenantClient struct {
+	t      *testing.T
+	engine *gin.Engine
+	token  string
+}
+
+func (c *tenantClient) do(method, path, body string) (int, map[string]interface{}) {
+	c.t.Helper()
+	var reader *bytes.Reader
+	if body == "" {
+		reader = bytes.NewReader(nil)
+	} else {
+		reader = bytes.NewReader([]byte(body))
+	}
+	req := httptest.NewRequest(method, "/api/v1"+path, reader)
+	req.Header.Set("Content-Type", "application/json")
+	if c.token != "" {
+		req.Header.Set("Authorization", "Bearer "+c.token)
+	}
+	w := httptest.NewRecorder()
+	c.engine.ServeHTTP(w, req)
+
+	var resp map[string]interface{}
+	_ = json.Unmarshal(w.Body.Bytes(), &resp)
+	if resp == nil {
+		resp = map[string]interface{}{"raw": w.Body.String()}
+	}
+	return w.Code, resp
+}
+
+// createID isteği sahibin jetonuyla gönderir ve dönen kaydın kimliğini döner
+func (c *tenantClient) createID(probe tenantProbe) string {
+	c.t.Helper()
+	status, resp := c.do(probe.method, probe.path, probe.body)
+	if status >= 300 {
+		c.t.Fatalf("%s %s: beklenmeyen durum %d: %v", probe.method, probe.path, status, resp)
+	}
+	data, _ := resp["data"].(map[string]interface{})
+	id, _ := data["id"].(string)
+	if id == "" {
+		c.t.Fatalf("%s %s: yanıtta kimlik yok: %v", probe.method, probe.path, resp)
+	}
+	return id
+}
+
+// newTenantTestServer geçici veritabanıyla, kiracı kapsamı enforce modunda rotaları kurar
+func newTenantTestServer(t *testing.T) (*gin.Engine, *sql.DB) {
+	t.Helper()
+	gin.SetMode(gin.TestMode)
+
+	dir := t.TempDir()
+	t.Setenv("DB_PATH", filepath.Join(dir, "test.db"))
+	t.Setenv("BACKUP_DIR", filepath.Join(dir, "backups"))
+	t.Setenv("MEDIA_DIR", filepath.Join(dir, "media"))
+	t.Setenv("TENANT_SCOPE_GUARD", database.TenantScopeEnforce)
+	t.Setenv("FIELD_ENCRYPTION_KEYS", "test:"+base64.StdEncoding.EncodeToString(bytes.Repeat([]byte{7}, 32)))
+
+	db, err := database.InitDB()
+	if err != nil {
+		t.Fatalf("veritabanı başlatılamadı: %v", err)
+	}
+	t.Cleanup(func() { db.Close() })
+	if err := services.NewFieldEncryptionService(db).Init(); err != nil {
+		t.Fatalf("alan şifreleme başlatılamadı: %v", err)
+	}
+	if err := services.NewDocumentSigningService(db).Init(); err != nil {
+		t.Fatalf("belge imzalama başlatılamadı: %v", err)
+	}
+
+	engine := gin.New()
+	SetupRoutes(engine, db, db)
+	return engine, db
+}
+
+// registerTenant yeni bir kullanıcı ve çiftlik kaydeder
+func registerTenant(t *testing.T, engine *gin.Engine, email string) *tenantClient {
+	t.Helper()
+	client := &tenantClient{t: t, engine: engine}
+	status, resp := client.do(http.MethodPost, "/auth/register", `{"name":"Test","email":"`+email+`","password":"secret123","confirmPassword":"secret123","farmName":"Çiftlik","location":"Konya"}`)
+	if status != http.StatusCreated && status != http.StatusOK {
+		t.Fatalf("kayıt başarısız (%d): %v", status, resp)
+	}
+	data, _ := resp["data"].(map[string]interface{})
+	client.token, _ = data["token"].(string)
+	if client.token == "" {
+		t.Fatalf("kayıt yanıtında jeton yok: %v", resp)
+	}
+	return client
+}
+
+func TestTenantIsolation(t *testing.T) {
+	engine, _ := newTenantTestServer(t)
+	owner := registerTenant(t, engine, "owner@example.com")
+	intruder := registerTenant(t, engine, "intruder@example.com")
+
+	fixtures := map[string]string{
+		"{land}":        owner.createID(tenantProbe{http.MethodPost, "/lands", `{"name":"Tarla","area":10,"unit":"dekar","crop":"Buğday","status":"active"}`}),
+		"{animal}":      owner.createID(tenantProbe{http.MethodPost, "/livestock", `{"tagNumber":"TR-1","type":"cattle","breed":"Holstein","gender":"female","birthDate":"2024-01-01T00:00:00Z","healthStatus":"healthy"}`}),
+		"{transaction}": owner.createID(tenantProbe{http.MethodPost, "/finance/transactions", `{"type":"income","category":"Satış","description":"Süt satışı","amount":100,"date":"2026-01-05T00:00:00Z","status":"completed","tags":["parsel:A"]}`}),
+		"{production}":  owner.createID(tenantProbe{http.MethodPost, "/production", `{"name":"Buğday","category":"grain","amount":100,"unit":"kg","harvestDate":"2026-01-01T00:00:00Z","price":5,"status":"active"}`}),
+		"{pond}":        owner.createID(tenantProbe{http.MethodPost, "/ponds", `{"name":"Havuz","volume":100}`}),
+		"{customer}":    owner.createID(tenantProbe{http.MethodPost, "/sales/customers", `{"name":"Müşteri","phone":"05551112233","taxId":"1234567890"}`}),
+	}
+	expand := func(path, id string) string {
+		for key, value := range fixtures {
+			path = strings.ReplaceAll(path, key, value)
+		}
+		return strings.ReplaceAll(path, "{id}", id)
+	}
+
+	cases := []tenantCase{
+		{
+			name: "lands",
+			probes: []tenantProbe{
+				{http.MethodGet, "/lands/{land}", ""},
+				{http.MethodPut, "/lands/{land}", `{"name":"Tarla","area":12,"unit":"dekar","crop":"Buğday","status":"active"}`},
+				{http.MethodGet, "/lands/{land}/history", ""},
+				{http.MethodGet, "/lands/{land}/activities", ""},
+				{http.MethodPost, "/lands/{land}/activities", `{"type":"fertilizing","description":"Gübreleme","scheduledDate":"2026-03-01T00:00:00Z","cost":100}`},
+				{http.MethodGet, "/lands/{land}/profitability", ""},
+				{http.MethodGet, "/lands/{land}/weather-observations", ""},
+				{http.MethodGet, "/lands/{land}/crop-plans", ""},
+				{http.MethodGet, "/lands/{land}/scouting", ""},
+				{http.MethodGet, "/lands/{land}/water-usage", ""},
+			},
+		},
+		{
+			name:   "land activity",
+			create: &tenantProbe{http.MethodPost, "/lands/{land}/activities", `{"type":"harvest","description":"Hasat","scheduledDate":"2026-06-01T00:00:00Z"}`},
+			probes: []tenantProbe{
+				{http.MethodGet, "/lands/{land}/activities/{id}/costs", ""},
+				{http.MethodGet, "/lands/{land}/activities/{id}/crew", ""},
+			},
+		},
+		{
+			name:   "crop plan",
+			create: &tenantProbe{http.MethodPost, "/lands/{land}/crop-plans", `{"season":"2026","crop":"Mısır","plantingDate":"2026-04-01"}`},
+			probes: []tenantProbe{
+				{http.MethodGet, "/lands/{land}/crop-plans/{id}", ""},
+				{http.MethodPut, "/lands/{land}/crop-plans/{id}", `{"season":"2026","crop":"Mısır","plantingDate":"2026-04-15"}`},
+				{http.MethodDelete, "/lands/{land}/crop-plans/{id}", ""},
+			},
+		},
+		{
+			name: "livestock",
+			probes: []tenantProbe{
+				{http.MethodGet, "/livestock/{animal}", ""},
+				{http.MethodGet, "/livestock/{animal}/history", ""},
+				{http.MethodGet, "/livestock/{animal}/health-records", ""},
+				{http.MethodPost, "/livestock/{animal}/health-records", `{"type":"checkup","description":"Kontrol","date":"2026-01-10T00:00:00Z","cost":50}`},
+				{http.MethodGet, "/livestock/{animal}/vaccinations", ""},
+				{http.MethodPost, "/livestock/{animal}/vaccinations", `{"vaccine":"Şap","dueDate":"2026-05-01"}`},
+				{http.MethodGet, "/livestock/{animal}/breeding", ""},
+				{http.MethodGet, "/livestock/{animal}/weights", ""},
+				{http.MethodPost, "/livestock/{animal}/weights", `{"weight":450}`},
+				{http.MethodGet, "/livestock/{animal}/growth-curve", ""},
+				{http.MethodGet, "/livestock/{animal}/movements", ""},
+				{http.MethodPost, "/livestock/{animal}/movements", `{"movementType":"transfer","movementDate":"2026-02-01T00:00:00Z","toLocation":"Ahır 2"}`},
+				{http.MethodGet, "/livestock/{animal}/costs", ""},
+				{http.MethodPost, "/livestock/{animal}/costs", `{"type":"feed","amount":100,"date":"2026-02-01T00:00:00Z"}`},
+				{http.MethodGet, "/livestock/{animal}/profitability", ""},
+				{http.MethodPost, "/livestock/milk-production", `{"animalId":"{animal}","amount":20,"date":"2026-02-02T00:00:00Z"}`},
+			},
+		},
+		{
+			name:   "vaccination",
+			create: &tenantProbe{http.MethodPost, "/livestock/{animal}/vaccinations", `{"vaccine":"Brusella","dueDate":"2026-06-01"}`},
+			probes: []tenantProbe{
+				{http.MethodGet, "/livestock/{animal}/vaccinations/{id}", ""},
+				{http.MethodPut, "/livestock/{animal}/vaccinations/{id}", `{"vaccine":"Brusella","dueDate":"2026-06-02","administeredDate":"2026-06-02"}`},
+				{http.MethodDelete, "/livestock/{animal}/vaccinations/{id}", ""},
+			},
+		},
+		{
+			name:   "breeding record",
+			create: &tenantProbe{http.MethodPost, "/livestock/{animal}/breeding", `{"method":"artificial_insemination","serviceDate":"2026-01-20"}`},
+			probes: []tenantProbe{
+				{http.MethodGet, "/livestock/{animal}/breeding/{id}", ""},
+				{http.MethodPut, "/livestock/{animal}/breeding/{id}", `{"method":"artificial_insemination","serviceDate":"2026-01-21"}`},
+				{http.MethodDelete, "/livestock/{animal}/breeding/{id}", ""},
+			},
+		},
+		{
+			name:   "weight record",
+			create: &tenantProbe{http.MethodPost, "/livestock/{animal}/weights", `{"weight":460}`},
+			probes: []tenantProbe{
+				{http.MethodDelete, "/livestock/{animal}/weights/{id}", ""},
+			},
+		},
+		{
+			name: "transactions",
+			probes: []tenantProbe{
+				{http.MethodGet, "/finance/transactions/{transaction}", ""},
+				{http.MethodPut, "/finance/transactions/{transaction}", `{"type":"income","category":"Satış","description":"Süt satışı","amount":120,"date":"2026-01-05T00:00:00Z","status":"completed","tags":["parsel:B"]}`},
+			},
+		},
+		{
+			name:   "transaction delete",
+			create: &tenantProbe{http.MethodPost, "/finance/transactions", `{"type":"expense","category":"Yem","description":"Yem","amount":50,"date":"2026-01-06T00:00:00Z","status":"pending","tags":["parsel:A"]}`},
+			probes: []tenantProbe{
+				{http.MethodPatch, "/finance/transactions/{id}/pay", `{}`},
+				{http.MethodDelete, "/finance/transactions/{id}", ""},
+			},
+		},
+		{
+			name: "production",
+			probes: []tenantProbe{
+				{http.MethodGet, "/production/{production}", ""},
+				{http.MethodGet, "/production/{production}/sales", ""},
+				{http.MethodGet, "/production/{production}/losses", ""},
+				{http.MethodPost, "/production/{production}/losses", `{"quantity":1,"reason":"spoilage"}`},
+				{http.MethodPost, "/production/{production}/sell", `{"quantity":1,"unitPrice":5,"buyer":"Alıcı"}`},
+			},
+		},
+		{
+			name:   "production loss",
+			create: &tenantProbe{http.MethodPost, "/production/{production}/losses", `{"quantity":2,"reason":"pest_damage"}`},
+			probes: []tenantProbe{
+				{http.MethodDelete, "/production/{production}/losses/{id}", ""},
+			},
+		},
+		{
+			name:   "animal exit",
+			create: &tenantProbe{http.MethodPost, "/livestock", `{"tagNumber":"TR-2","type":"cattle","breed":"Simental","gender":"male","birthDate":"2024-02-01T00:00:00Z","healthStatus":"healthy"}`},
+			probes: []tenantProbe{
+				{http.MethodPut, "/livestock/{id}/acquisition", `{"type":"purchased","date":"2024-03-01T00:00:00Z","price":20000,"seller":"Satıcı"}`},
+				{http.MethodPost, "/livestock/{id}/sale", `{"date":"2026-03-01T00:00:00Z","price":40000,"buyer":"Alıcı"}`},
+			},
+		},
+		{
+			name:   "greenhouse",
+			create: &tenantProbe{http.MethodPost, "/lands", `{"name":"Sera","area":1,"unit":"dekar","crop":"Domates","status":"active"}`},
+			probes: []tenantProbe{
+				{http.MethodPut, "/greenhouses/{id}", `{"structureType":"glass","setpoints":{"minTemperature":15,"maxTemperature":25}}`},
+				{http.MethodGet, "/greenhouses/{id}", ""},
+				{http.MethodPost, "/greenhouses/{id}/sensors", `{"name":"Sensör"}`},
+				{http.MethodPost, "/greenhouses/{id}/readings", `{"temperature":30,"humidity":60}`},
+				{http.MethodGet, "/greenhouses/{id}/readings", ""},
+				{http.MethodGet, "/greenhouses/{id}/alerts", ""},
+				{http.MethodDelete, "/greenhouses/{id}", ""},
+			},
+		},
+		{
+			name:   "weather observation",
+			create: &tenantProbe{http.MethodPost, "/lands/{land}/weather-observations", `{"date":"2026-02-01","rainfall":12}`},
+			probes: []tenantProbe{
+				{http.MethodDelete, "/lands/{land}/weather-observations/{id}", ""},
+			},
+		},
+		{
+			name:   "quick log",
+			create: &tenantProbe{http.MethodPost, "/templates", `{"name":"Çapa","targetType":"land_activity","fields":{"landId":"{land}","type":"weeding","description":"Çapa"}}`},
+			probes: []tenantProbe{
+				{http.MethodPost, "/quick-log", `{"templateId":"{id}"}`},
+			},
+		},
+		{
+			name:   "calendar event",
+			create: &tenantProbe{http.MethodPost, "/calendar/events", `{"title":"Sulama","type":"task","startDate":"2026-03-01T08:00:00Z","endDate":"2026-03-01T09:00:00Z","priority":"medium","status":"pending"}`},
+			probes: []tenantProbe{
+				{http.MethodGet, "/calendar/events/{id}", ""},
+				{http.MethodPut, "/calendar/events/{id}", `{"title":"Sulama","type":"task","startDate":"2026-03-02T08:00:00Z","endDate":"2026-03-02T09:00:00Z","priority":"high","status":"pending"}`},
+				{http.MethodPatch, "/calendar/events/{id}/status", `{"status":"completed"}`},
+				{http.MethodDelete, "/calendar/events/{id}", ""},
+			},
+		},
+		{
+			name:   "hive",
+			create: &tenantProbe{http.MethodPost, "/hives", `{"name":"Kovan 1"}`},
+			probes: []tenantProbe{
+				{http.MethodGet, "/hives/{id}", ""},
+				{http.MethodPut, "/hives/{id}", `{"name":"Kovan 1A"}`},
+				{http.MethodGet, "/hives/{id}/inspections", ""},
+				{http.MethodPost, "/hives/{id}/inspections", `{"queenStatus":"present"}`},
+				{http.MethodGet, "/hives/{id}/harvests", ""},
+				{http.MethodPost, "/hives/{id}/harvests", `{"amount":5}`},
+				{http.MethodGet, "/hives/{id}/treatments", ""},
+				{http.MethodPost, "/hives/{id}/treatments", `{"target":"varroa","product":"Oksalik asit"}`},
+				{http.MethodDelete, "/hives/{id}", ""},
+			},
+		},
+		{
+			name:   "pond",
+			create: &tenantProbe{http.MethodPost, "/ponds", `{"name":"Havuz 1"}`},
+			probes: []tenantProbe{
+				{http.MethodGet, "/ponds/{id}", ""},
+				{http.MethodPut, "/ponds/{id}", `{"name":"Havuz 1A"}`},
+				{http.MethodGet, "/ponds/{id}/batches", ""},
+				{http.MethodDelete, "/ponds/{id}", ""},
+			},
+		},
+		{
+			name:   "fish batch",
+			create: &tenantProbe{http.MethodPost, "/ponds/{pond}/batches", `{"species":"Alabalık","initialCount":100,"initialAvgWeight":10}`},
+			probes: []tenantProbe{
+				{http.MethodGet, "/ponds/{pond}/batches", ""},
+				{http.MethodGet, "/fish-batches/{id}", ""},
+				{http.MethodGet, "/fish-batches/{id}/records", ""},
+				{http.MethodPost, "/fish-batches/{id}/records", `{"recordType":"feeding","feedKg":10,"cost":50}`},
+				{http.MethodDelete, "/fish-batches/{id}", ""},
+			},
+		},
+		{
+			name: "customer",
+			probes: []tenantProbe{
+				{http.MethodGet, "/sales/customers/{customer}", ""},
+				{http.MethodPut, "/sales/customers/{customer}", `{"name":"Müşteri","phone":"05559998877"}`},
+			},
+		},
+		{
+			name:   "sales order",
+			create: &tenantProbe{http.MethodPost, "/sales/orders", `{"customerId":"{customer}","productionId":"{production}","quantity":2,"unitPrice":6}`},
+			probes: []tenantProbe{
+				{http.MethodGet, "/sales/orders/{id}", ""},
+				{http.MethodDelete, "/sales/orders/{id}", ""},
+			},
+		},
+		{
+			name:   "invoice",
+			create: &tenantProbe{http.MethodPost, "/finance/invoices", `{"transactionIds":["{transaction}"],"customerId":"{customer}"}`},
+			probes: []tenantProbe{
+				{http.MethodGet, "/finance/invoices/{id}", ""},
+			},
+		},
+		{
+			name:   "worker",
+			create: &tenantProbe{http.MethodPost, "/workers", `{"name":"İşçi","contractType":"seasonal","contractStart":"2026-01-01","phone":"05551234567"}`},
+			probes: []tenantProbe{
+				{http.MethodGet, "/workers/{id}", ""},
+				{http.MethodPut, "/workers/{id}", `{"name":"İşçi","contractType":"permanent","contractStart":"2026-01-01","phone":"05557654321"}`},
+				{http.MethodDelete, "/workers/{id}", ""},
+			},
+		},
+		{
+			name:   "asset",
+			create: &tenantProbe{http.MethodPost, "/assets", `{"name":"Traktör","category":"equipment","purchaseDate":"2025-01-01T00:00:00Z","cost":100000,"usefulLifeMonths":60,"method":"straight_line"}`},
+			probes: []tenantProbe{
+				{http.MethodGet, "/assets/{id}", ""},
+				{http.MethodGet, "/assets/{id}/schedule", ""},
+				{http.MethodDelete, "/assets/{id}", ""},
+			},
+		},
+		{
+			name:   "utility meter",
+			create: &tenantProbe{http.MethodPost, "/utilities/meters", `{"name":"Ana sayaç","type":"electricity"}`},
+			probes: []tenantProbe{
+				{http.MethodGet, "/utilities/meters/{id}", ""},
+				{http.MethodGet, "/utilities/meters/{id}/readings", ""},
+				{http.MethodPost, "/utilities/meters/{id}/readings", `{"readingDate":"2026-01-01T00:00:00Z","value":100}`},
+				{http.MethodDelete, "/utilities/meters/{id}", ""},
+			},
+		},
+		{
+			name:   "document",
+			create: &tenantProbe{http.MethodPost, "/documents", `{"title":"Kira sözleşmesi","category":"contract"}`},
+			probes: []tenantProbe{
+				{http.MethodGet, "/documents/{id}", ""},
+				{http.MethodPut, "/documents/{id}", `{"title":"Kira sözleşmesi 2","category":"contract"}`},
+				{http.MethodDelete, "/documents/{id}", ""},
+			},
+		},
+		{
+			name:   "compliance checklist",
+			create: &tenantProbe{http.MethodPost, "/compliance/checklists", `{"standard":"custom","name":"İç denetim","requirements":[{"code":"R1","title":"Kayıt"}]}`},
+			probes: []tenantProbe{
+				{http.MethodGet, "/compliance/checklists/{id}", ""},
+				{http.MethodPut, "/compliance/checklists/{id}/requirements/R1", `{"status":"compliant"}`},
+				{http.MethodDelete, "/compliance/checklists/{id}", ""},
+			},
+		},
+		{
+			name:   "saved view",
+			create: &tenantProbe{http.MethodPost, "/views", `{"resource":"livestock","name":"Sağmal"}`},
+			probes: []tenantProbe{
+				{http.MethodPut, "/views/{id}", `{"resource":"livestock","name":"Sağmal inekler"}`},
+				{http.MethodPatch, "/views/{id}/default", ""},
+				{http.MethodDelete, "/views/{id}", ""},
+			},
+		},
+		{
+			name:   "activity template",
+			create: &tenantProbe{http.MethodPost, "/templates", `{"name":"Sulama","targetType":"land_activity"}`},
+			probes: []tenantProbe{
+				{http.MethodPut, "/templates/{id}", `{"name":"Sulama 2","targetType":"land_activity"}`},
+				{http.MethodDelete, "/templates/{id}", ""},
+			},
+		},
+		{
+			name:   "treatment protocol",
+			create: &tenantProbe{http.MethodPost, "/protocols", `{"name":"Buzağı aşıları","steps":[{"dayOffset":0,"type":"vaccination","description":"Şap"}]}`},
+			probes: []tenantProbe{
+				{http.MethodPut, "/protocols/{id}", `{"name":"Buzağı aşıları","steps":[{"dayOffset":7,"type":"vaccination","description":"Şap"}]}`},
+				{http.MethodDelete, "/protocols/{id}", ""},
+			},
+		},
+		{
+			name:   "water quota",
+			create: &tenantProbe{http.MethodPost, "/water-quotas", `{"name":"Kota","seasonStart":"2026-01-01","seasonEnd":"2026-12-31","volume":1000}`},
+			probes: []tenantProbe{
+				{http.MethodGet, "/water-quotas/{id}", ""},
+				{http.MethodDelete, "/water-quotas/{id}", ""},
+			},
+		},
+		{
+			name:   "bank account",
+			create: &tenantProbe{http.MethodPost, "/finance/bank-accounts", `{"name":"Ziraat"}`},
+			probes: []tenantProbe{
+				{http.MethodGet, "/finance/bank-accounts/{id}", ""},
+				{http.MethodGet, "/finance/bank-accounts/{id}/lines", ""},
+				{http.MethodDelete, "/finance/bank-accounts/{id}", ""},
+			},
+		},
+		{
+			name:   "allocation rule",
+			create: &tenantProbe{http.MethodPost, "/finance/allocation-rules", `{"name":"Yem dağıtımı","categories":["Yem"],"targetType":"land","basis":"area"}`},
+			probes: []tenantProbe{
+				{http.MethodPut, "/finance/allocation-rules/{id}", `{"name":"Yem dağıtımı","categories":["Yem"],"targetType":"land","basis":"equal"}`},
+				{http.MethodDelete, "/finance/allocation-rules/{id}", ""},
+			},
+		},
+		{
+			name:   "support ticket",
+			create: &tenantProbe{http.MethodPost, "/support/tickets", `{"message":"Yardım"}`},
+			probes: []tenantProbe{
+				{http.MethodGet, "/support/tickets/{id}", ""},
+				{http.MethodPost, "/support/tickets/{id}/messages", `{"message":"Ek bilgi"}`},
+			},
+		},
+		{
+			name: "entity notes",
+			probes: []tenantProbe{
+				{http.MethodPost, "/notes/land/{land}", `{"text":"Not"}`},
+				{http.MethodGet, "/notes/land/{land}", ""},
+			},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			owner.t, intruder.t = t, t
+			id := ""
+			if tc.create != nil {
+				id = owner.createID(tenantProbe{tc.create.method, expand(tc.create.path, ""), expand(tc.create.body, "")})
+			}
+			for _, probe := range tc.probes {
+				path, body := expand(probe.path, id), expand(probe.body, id)
+
+				status, resp := intruder.do(probe.method, path, body)
+				if status != http.StatusNotFound && status != http.StatusForbidden {
+					t.Errorf("diğer kullanıcı %s %s: 404/403 beklenirken %d: %v", probe.method, probe.path, status, resp)
+				}
+
+				status, resp = owner.do(probe.method, path, body)
+				if status < 200 || status >= 300 {
+					t.Errorf("sahip %s %s: 2xx beklenirken %d: %v", probe.method, probe.path, status, resp)
+				}
+			}
+		})
+	}
+
+	if report := database.TenantScopeAudit(); len(report.Violations) > 0 {
+		for _, violation := range report.Violations {
+			t.Errorf("kullanıcı kapsamı olmayan sorgu (%s): %s", violation.Endpoint, violation.Query)
+		}
+	}
+}
@@ -0,0 +1,278 @@
+package services
+
+import (
+	"database/sql"
+	"errors"
+	"strings"
+	"time"
+
+	"agri-management-api/internal/models"
+	"agri-management-api/internal/utils"
+)
+
+var (
+	// ErrAccountantAccessNotFound muhasebeci erişimi bulunamadı
+	ErrAccountantAccessNotFound = errors.New("muhasebeci erişimi bulunamadı")
+	// ErrAccountantNotFound davet edilen e-posta adresine ait hesap yok
+	ErrAccountantNotFound = errors.New("muhasebeci hesabı bulunamadı")
+	// ErrAccountantSelf çiftlik sahibi kendisini muhasebeci olarak davet edemez
+	ErrAccountantSelf = errors.New("kendinizi muhasebeci olarak davet edemezsiniz")
+	// ErrAccountantExists muhasebecinin çiftlikte zaten etkin erişimi var
+	ErrAccountantExists = errors.New("muhasebecinin zaten etkin erişimi var")
+	// ErrAccountantExpiry erişim bitiş tarihi geçmişte
+	ErrAccountantExpiry = errors.New("erişim bitiş tarihi bugünden önce olamaz")
+)
+
+// accountantRoutes muhasebecinin okuyabildiği finans dışı uç noktalar; /finance altındaki tüm GET
+// uç noktaları ayrıca açıktır
+var accountantRoutes = map[string]bool{
+	"/api/v1/reports":              true,
+	"/api/v1/reports/:id/download": true,
+	"/api/v1/sales/orders":         true,
+	"/api/v1/sales/orders/:id":     true,
+}
+
+// accountantActiveCondition erişimin iptal edilmemiş ve süresinin dolmamış olması
+const accountantActiveCondition = "a.revoked_at IS NULL AND (a.expires_at IS NULL OR date(a.expires_at) >= date('now'))"
+
+// accountantAccessSelect erişim sütunları; muhasebecinin ve çiftliğin adı birlikte okunur. Henüz çiftlik kaydı
+// oluşmamış varsayılan çiftliklerde profildeki çiftlik adı kullanılır
+const accountantAccessSelect = `
+	SELECT a.id, a.user_id, COALESCE(f.name, NULLIF(o.farm_name, ''), o.name, ''), a.accountant_id, u.name, u.email,
+	       COALESCE(a.note, ''), date(a.expires_at), a.last_access_at, a.revoked_at, a.created_at
+	FROM accountant_access a
+	JOIN users u ON u.id = a.accountant_id
+	LEFT JOIN farms f ON f.id = a.user_id
+	LEFT JOIN users o ON o.id = a.user_id`
+
+// AccountantService çiftliğin dış muhasebecilere verdiği salt okunur finans erişimini, erişimin kapsamını ve
+// muhasebecinin isteklerinin günlüğünü yönetir
+type AccountantService struct {
+	db            *sql.DB
+	notifications *NotificationService
+}
+
+// NewAccountantService yeni muhasebeci servisi oluşturur
+func NewAccountantService(db *sql.DB) *AccountantService {
+	return &AccountantService{db: db, notifications: NewNotificationService(db)}
+}
+
+// AccountantRouteAllowed isteğin muhasebeci erişim kapsamında olup olmadığını döner: yalnızca finans uç
+// noktalarının okunması ve raporların listelenip indirilmesi
+func AccountantRouteAllowed(method, route string) bool {
+	if method != "GET" {
+		return false
+	}
+	return strings.HasPrefix(route, "/api/v1/finance/") || accountantRoutes[route]
+}
+
+// List çiftliğin verdiği muhasebeci erişimlerini, iptal edilenler ve süresi dolanlar dahil döner
+func (s *AccountantService) List(farmID string) ([]models.AccountantAccess, error) {
+	return s.query(accountantAccessSelect+`
+		WHERE a.user_id = ?
+		ORDER BY a.revoked_at IS NOT NULL, a.created_at DESC
+	`, farmID)
+}
+
+// Farms muhasebecinin etkin erişimi olan çiftlikleri döner; muhasebeci bu çiftlikleri X-Farm-ID ile seçer
+func (s *AccountantService) Farms(accountantID string) ([]models.AccountantAccess, error) {
+	return s.query(accountantAccessSelect+`
+		WHERE a.accountant_id = ? AND `+accountantActiveCondition+`
+		ORDER BY 3
+	`, accountantID)
+}
+
+// Invite e-posta adresindeki hesaba çiftliğin finans verilerine salt okunur erişim verir ve muhasebeciye
+// bildirim gönderir
+func (s *AccountantService) Invite(farmID, accountID string, req models.AccountantInviteRequest) (*models.AccountantAccess, error) {
+	if req.ExpiresAt != "" && req.ExpiresAt < time.Now().Format("2006-01-02") {
+		return nil, ErrAccountantExpiry
+	}
+
+	var accountantID string
+	err := s.db.QueryRow("SELECT id FROM users WHERE email = ?", strings.TrimSpace(req.Email)).Scan(&accountantID)
+	if err == sql.ErrNoRows {
+		return nil, ErrAccountantNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	if accountantID == accountID || accountantID == farmID {
+		return nil, ErrAccountantSelf
+	}
+
+	var exists bool
+	err = s.db.QueryRow(`
+		SELECT 1 FROM accountant_access a WHERE a.user_id = ? AND a.accountant_id = ? AND `+accountantActiveCondition,
+		farmID, accountantID).Scan(&exists)
+	if err == nil {
+		return nil, ErrAccountantExists
+	}
+	if err != sql.ErrNoRows {
+		return nil, err
+	}
+
+	id := utils.GenerateID()
+	if _, err := s.db.Exec(`
+		INSERT INTO accountant_access (id, user_id, accountant_id, note, expires_at, created_at)
+		VALUES (?, ?, ?, ?, ?, CURRENT_TIMESTAMP)
+	`, id, farmID, accountantID, strings.TrimSpace(req.Note), utils.StringToNullString(req.ExpiresAt)); err != nil {
+		return nil, err
+	}
+
+	access, err := s.Get(farmID, id)
+	if err != nil {
+		return nil, err
+	}
+
+	message := access.FarmName + " çiftliği size finans kayıtları ve raporlar için salt okunur erişim verdi."
+	if access.ExpiresAt != nil {
+		message += " Erişim " + *access.ExpiresAt + " tarihine kadar geçerlidir."
+	}
+	s.notifications.Create(Notification{
+		UserID:   accountantID,
+		Title:    "Muhasebeci Erişimi",
+		Message:  message,
+		Type:     "info",
+		Priority: "medium",
+		Topic:    models.NotificationTopicAccountantAccess,
+		Entity:   &models.RelatedEntity{Type: "farm", ID: farmID, Name: access.FarmName},
+	})
+	return access, nil
+}
+
+// Get çiftliğin muhasebeci erişimini döner
+func (s *AccountantService) Get(farmID, id string) (*models.AccountantAccess, error) {
+	accesses, err := s.query(accountantAccessSelect+" WHERE a.id = ? AND a.user_id = ?", id, farmID)
+	if err != nil {
+		return nil, err
+	}
+	if len(accesses) == 0 {
+		return nil, ErrAccountantAccessNotFound
+	}
+	return &accesses[0], nil
+}
+
+// Revoke muhasebecinin erişimini hemen sonlandırır; erişim ve günlüğü çiftlik sahibi için saklanır
+func (s *AccountantService) Revoke(farmID, id string) (*models.AccountantAccess, error) {
+	result, err := s.db.Exec(`
+		UPDATE accountant_access SET revoked_at = CURRENT_TIMESTAMP
+		WHERE id = ? AND user_id = ? AND revoked_at IS NULL
+	`, id, farmID)
+	if err != nil {
+		return nil, err
+	}
+	if rows, _ := result.RowsAffected(); rows == 0 {
+		return nil, ErrAccountantAccessNotFound
+	}
+	return s.Get(farmID, id)
+}
+
+// Authorize muhasebecinin çiftlikte etkin erişimi varsa erişim ID'sini döner
+func (s *AccountantService) Authorize(accountantID, farmID string) (string, error) {
+	var id string
+	err := s.db.QueryRow(`
+		SELECT a.id FROM accountant_access a
+		WHERE a.user_id = ? AND a.accountant_id = ? AND `+accountantActiveCondition+`
+		ORDER BY a.created_at DESC LIMIT 1
+	`, farmID, accountantID).Scan(&id)
+	if err == sql.ErrNoRows {
+		return "", ErrAccountantAccessNotFound
+	}
+	return id, err
+}
+
+// LogAccess muhasebecinin isteğini erişim günlüğüne yazar ve erişimin son kullanım zamanını günceller
+func (s *AccountantService) LogAccess(farmID, accessID, accountantID, method, path string, status int, ip string) error {
+	if _, err := s.db.Exec(`
+		INSERT INTO accountant_access_logs (id, user_id, access_id, accountant_id, method, path, status_code, ip_address, created_at)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, CURRENT_TIMESTAMP)
+	`, utils.GenerateID(), farmID, accessID, accountantID, method, path, status, ip); err != nil {
+		return err
+	}
+	_, err := s.db.Exec(`
+		UPDATE accountant_access SET last_access_at = CURRENT_TIMESTAMP WHERE id = ? AND user_id = ?
+	`, accessID, farmID)
+	return err
+}
+
+// AccessLog çiftlikteki muhasebeci isteklerini yeniden eskiye sayfalı döner; accessID verilirse yalnızca o
+// erişimin, tarih aralığı verilirse [startDate, endDate] günlerindeki istekler listelenir
+func (s *AccountantService) AccessLog(farmID, accessID string, startDate, endDate *time.Time, page, limit int) ([]models.AccountantAccessLog, int, error) {
+	where := " WHERE l.user_id = ?"
+	args := []interface{}{farmID}
+	if accessID != "" {
+		where += " AND l.access_id = ?"
+		args = append(args, accessID)
+	}
+	if startDate != nil {
+		where += " AND date(l.created_at) >= ?"
+		args = append(args, startDate.Format("2006-01-02"))
+	}
+	if endDate != nil {
+		where += " AND date(l.created_at) <= ?"
+		args = append(args, endDate.Format("2006-01-02"))
+	}
+
+	var total int
+	if err := s.db.QueryRow("SELECT COUNT(*) FROM accountant_access_logs l"+where, args...).Scan(&total); err != nil {
+		return nil, 0, err
+	}
+
+	rows, err := s.db.Query(`
+		SELECT l.id, l.access_id, l.accountant_id, COALESCE(u.name, ''), l.method, l.path, l.status_code,
+		       COALESCE(l.ip_address, ''), l.created_at
+		FROM accountant_access_logs l
+		LEFT JOIN users u ON u.id = l.accountant_id`+where+`
+		ORDER BY l.created_at DESC
+		LIMIT ? OFFSET ?
+	`, append(args, limit, (page-1)*limit)...)
+	if err != nil {
+		return nil, 0, err
+	}
+	defer rows.Close()
+
+	entries := []models.AccountantAccessLog{}
+	for rows.Next() {
+		var entry models.AccountantAccessLog
+		if err := rows.Scan(&entry.ID, &entry.AccessID, &entry.AccountantID, &entry.AccountantName, &entry.Method,
+			&entry.Path, &entry.StatusCode, &entry.IPAddress, &entry.CreatedAt); err != nil {
+			return nil, 0, err
+		}
+		entries = append(entries, entry)
+	}
+	return entries, total, rows.Err()
+}
+
+// query erişimleri okur ve durumlarını belirler
+func (s *AccountantService) query(query string, args ...interface{}) ([]models.AccountantAccess, error) {
+	rows, err := s.db.Query(query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	today := time.Now().Format("2006-01-02")
+	accesses := []models.AccountantAccess{}
+	for rows.Next() {
+		var access models.AccountantAccess
+		var lastAccess, revokedAt sql.NullTime
+		if err := rows.Scan(&access.ID, &access.FarmID, &access.FarmName, &access.AccountantID, &access.AccountantName,
+			&access.AccountantEmail, &access.Note, &access.ExpiresAt, &lastAccess, &revokedAt, &access.CreatedAt); err != nil {
+			return nil, err
+		}
+		access.LastAccessAt = utils.NullTimeToPtr(lastAccess)
+		access.RevokedAt = utils.NullTimeToPtr(revokedAt)
+
+		switch {
+		case access.RevokedAt != nil:
+			access.Status = models.AccountantAccessRevoked
+		case access.ExpiresAt != nil && *access.ExpiresAt < today:
+			access.Status = models.AccountantAccessExpired
+		default:
+			access.Status = models.AccountantAccessActive
+		}
+		accesses = append(accesses, access)
+	}
+	return accesses, rows.Err()
+}
@@ -0,0 +1,545 @@
+package services
+
+import (
+	"bytes"
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"agri-management-api/internal/models"
+	"agri-management-api/internal/utils"
+)
+
+// Danışman hataları
+var (
+	// ErrAdvisorDisabled dil modeli sağlayıcısı yapılandırılmadığında döner
+	ErrAdvisorDisabled = errors.New("advisor provider not configured")
+	// ErrAdvisorRateLimited hesabın saatlik soru sınırı dolduğunda döner
+	ErrAdvisorRateLimited = errors.New("advisor hourly limit reached")
+	// ErrAdvisorBudgetExceeded hesabın aylık maliyet sınırı dolduğunda döner
+	ErrAdvisorBudgetExceeded = errors.New("advisor monthly budget exceeded")
+	// ErrAdvisorConversationNotFound konuşma bulunamadığında döner
+	ErrAdvisorConversationNotFound = errors.New("advisor conversation not found")
+)
+
+// Danışman parametreleri
+const (
+	// advisorHistoryMessages modele gönderilen en fazla önceki mesaj sayısı
+	advisorHistoryMessages = 10
+	// advisorWeatherDays bağlama eklenen hava gözlemi gün sayısı
+	advisorWeatherDays = 14
+	// advisorMaxTokens yanıt için izin verilen en fazla token
+	advisorMaxTokens = 800
+	// MaxAdvisorQuestionLength sorunun en fazla karakter sayısı
+	MaxAdvisorQuestionLength = 2000
+)
+
+// advisorSystemPrompt modele verilen rol tanımı; çiftlik bağlamı bu metnin arkasına eklenir
+const advisorSystemPrompt = `Sen bir tarım ve çiftlik yönetimi danışmanısın. Soruları aşağıdaki çiftlik verilerini dikkate alarak, ` +
+	`kısa ve uygulanabilir önerilerle Türkçe yanıtla. Veride olmayan bilgileri uydurma; emin olmadığın durumlarda ` +
+	`veteriner veya ziraat mühendisine danışılmasını öner.`
+
+// AdvisorMessage sağlayıcıya gönderilen sohbet mesajı
+type AdvisorMessage struct {
+	Role    string `json:"role"`
+	Content string `json:"content"`
+}
+
+// AdvisorCompletion sağlayıcı yanıtı ve token kullanımı
+type AdvisorCompletion struct {
+	Text         string
+	InputTokens  int
+	OutputTokens int
+}
+
+// AdvisorProvider sohbet mesajlarını yanıtlayan dil modeli sağlayıcısı arayüzü
+type AdvisorProvider interface {
+	Name() string
+	Complete(ctx context.Context, messages []AdvisorMessage) (*AdvisorCompletion, error)
+}
+
+// NewAdvisorProvider ADVISOR_PROVIDER ortam değişkenine göre sağlayıcı oluşturur; yapılandırılmamışsa nil döner
+func NewAdvisorProvider() AdvisorProvider {
+	switch os.Getenv("ADVISOR_PROVIDER") {
+	case "openai":
+		endpoint := os.Getenv("ADVISOR_ENDPOINT")
+		if endpoint == "" {
+			endpoint = "https://api.openai.com/v1/chat/completions"
+		}
+		model := os.Getenv("ADVISOR_MODEL")
+		if model == "" {
+			model = "gpt-4o-mini"
+		}
+		return &OpenAIAdvisor{
+			endpoint: endpoint,
+			apiKey:   os.Getenv("ADVISOR_API_KEY"),
+			model:    model,
+			client:   &http.Client{Timeout: 60 * time.Second},
+		}
+	}
+	return nil
+}
+
+// OpenAIAdvisor OpenAI uyumlu /chat/completions uç noktasını kullanır
+type OpenAIAdvisor struct {
+	endpoint string
+	apiKey   string
+	model    string
+	client   *http.Client
+}
+
+// Name sağlayıcı ve model adı
+func (a *OpenAIAdvisor) Name() string {
+	return "openai/" + a.model
+}
+
+// Complete mesajları sağlayıcıya gönderir ve yanıtı döner
+func (a *OpenAIAdvisor) Complete(ctx context.Context, messages []AdvisorMessage) (*AdvisorCompletion, error) {
+	payload, err := json.Marshal(map[string]interface{}{
+		"model":      a.model,
+		"messages":   messages,
+		"max_tokens": advisorMaxTokens,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, a.endpoint, bytes.NewReader(payload))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	if a.apiKey != "" {
+		req.Header.Set("Authorization", "Bearer "+a.apiKey)
+	}
+
+	resp, err := a.client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		message, _ := io.ReadAll(io.LimitReader(resp.Body, 512))
+		return nil, fmt.Errorf("advisor request failed: %s: %s", resp.Status, bytes.TrimSpace(message))
+	}
+
+	var result struct {
+		Choices []struct {
+			Message AdvisorMessage `json:"message"`
+		} `json:"choices"`
+		Usage struct {
+			PromptTokens     int `json:"prompt_tokens"`
+			CompletionTokens int `json:"completion_tokens"`
+		} `json:"usage"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		return nil, err
+	}
+	if len(result.Choices) == 0 {
+		return nil, errors.New("advisor response has no choices")
+	}
+
+	return &AdvisorCompletion{
+		Text:         strings.TrimSpace(result.Choices[0].Message.Content),
+		InputTokens:  result.Usage.PromptTokens,
+		OutputTokens: result.Usage.CompletionTokens,
+	}, nil
+}
+
+// AdvisorService çiftlik bağlamını toplayıp sağlayıcıya soru sorar; hesap başına saatlik soru sınırı ve
+// aylık maliyet sınırı uygular, konuşmaları saklar
+type AdvisorService struct {
+	db              *sql.DB
+	provider        AdvisorProvider
+	hourlyLimit     int
+	monthlyBudget   float64
+	inputCostPer1K  float64
+	outputCostPer1K float64
+}
+
+// NewAdvisorService yeni danışman servisi oluşturur; sınırlar ADVISOR_RATE_LIMIT (saatlik soru, varsayılan 20),
+// ADVISOR_MONTHLY_BUDGET (USD, 0 sınırsız) ve ADVISOR_INPUT_COST_PER_1K / ADVISOR_OUTPUT_COST_PER_1K ile ayarlanır
+func NewAdvisorService(db *sql.DB) *AdvisorService {
+	return &AdvisorService{
+		db:              db,
+		provider:        NewAdvisorProvider(),
+		hourlyLimit:     int(envFloat("ADVISOR_RATE_LIMIT", 20)),
+		monthlyBudget:   envFloat("ADVISOR_MONTHLY_BUDGET", 5),
+		inputCostPer1K:  envFloat("ADVISOR_INPUT_COST_PER_1K", 0.00015),
+		outputCostPer1K: envFloat("ADVISOR_OUTPUT_COST_PER_1K", 0.0006),
+	}
+}
+
+// Usage hesabın son bir saatteki soru sayısını ve bu ayki maliyetini döner
+func (s *AdvisorService) Usage(accountID string, now time.Time) (models.AdvisorUsage, error) {
+	usage := models.AdvisorUsage{HourlyLimit: s.hourlyLimit, MonthlyBudget: s.monthlyBudget}
+	if s.provider != nil {
+		usage.Provider = s.provider.Name()
+	}
+
+	err := s.db.QueryRow(`
+		SELECT COUNT(*) FROM advisor_messages
+		WHERE account_id = ? AND role = ? AND created_at >= ?
+	`, accountID, models.AdvisorRoleUser, now.Add(-time.Hour)).Scan(&usage.RequestsLastHour)
+	if err != nil {
+		return usage, err
+	}
+
+	monthStart := time.Date(now.Year(), now.Month(), 1, 0, 0, 0, 0, now.Location())
+	err = s.db.QueryRow(`
+		SELECT COALESCE(SUM(cost), 0) FROM advisor_messages
+		WHERE account_id = ? AND created_at >= ?
+	`, accountID, monthStart).Scan(&usage.MonthlyCost)
+	return usage, err
+}
+
+// Ask soruyu çiftlik bağlamı ve konuşma geçmişiyle birlikte sağlayıcıya gönderir; soru ve yanıt
+// yalnızca sağlayıcı başarıyla yanıt verirse kaydedilir
+func (s *AdvisorService) Ask(ctx context.Context, farmID, accountID, conversationID, question string) (*models.AdvisorAnswer, error) {
+	if s.provider == nil {
+		return nil, ErrAdvisorDisabled
+	}
+
+	now := time.Now()
+	usage, err := s.Usage(accountID, now)
+	if err != nil {
+		return nil, err
+	}
+	if s.hourlyLimit > 0 && usage.RequestsLastHour >= s.hourlyLimit {
+		return nil, ErrAdvisorRateLimited
+	}
+	if s.monthlyBudget > 0 && usage.MonthlyCost >= s.monthlyBudget {
+		return nil, ErrAdvisorBudgetExceeded
+	}
+
+	var history []AdvisorMessage
+	if conversationID != "" {
+		history, err = s.history(farmID, accountID, conversationID)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	farmContext, err := s.FarmContext(farmID, now)
+	if err != nil {
+		return nil, err
+	}
+
+	messages := append([]AdvisorMessage{{Role: models.AdvisorRoleSystem, Content: advisorSystemPrompt + "\n\n" + farmContext}},
+		history...)
+	messages = append(messages, AdvisorMessage{Role: models.AdvisorRoleUser, Content: question})
+
+	completion, err := s.provider.Complete(ctx, messages)
+	if err != nil {
+		return nil, err
+	}
+
+	answer := &models.AdvisorAnswer{
+		ConversationID: conversationID,
+		Question: models.AdvisorMessage{
+			ID:        utils.GenerateID(),
+			Role:      models.AdvisorRoleUser,
+			Content:   question,
+			CreatedAt: now,
+		},
+		Answer: models.AdvisorMessage{
+			ID:           utils.GenerateID(),
+			Role:         models.AdvisorRoleAssistant,
+			Content:      completion.Text,
+			InputTokens:  completion.InputTokens,
+			OutputTokens: completion.OutputTokens,
+			Cost: float64(completion.InputTokens)/1000*s.inputCostPer1K +
+				float64(completion.OutputTokens)/1000*s.outputCostPer1K,
+			CreatedAt: time.Now(),
+		},
+	}
+	if err := s.store(farmID, accountID, answer); err != nil {
+		return nil, err
+	}
+
+	answer.Usage, err = s.Usage(accountID, time.Now())
+	return answer, err
+}
+
+// store soru ve yanıtı kaydeder; konuşma yoksa sorunun başından başlık üretilerek oluşturulur
+func (s *AdvisorService) store(farmID, accountID string, answer *models.AdvisorAnswer) error {
+	tx, err := s.db.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	if answer.ConversationID == "" {
+		answer.ConversationID = utils.GenerateID()
+		_, err = tx.Exec(`
+			INSERT INTO advisor_conversations (id, user_id, account_id, title, created_at, updated_at)
+			VALUES (?, ?, ?, ?, ?, ?)
+		`, answer.ConversationID, farmID, accountID, advisorTitle(answer.Question.Content),
+			answer.Question.CreatedAt, answer.Answer.CreatedAt)
+	} else {
+		_, err = tx.Exec("UPDATE advisor_conversations SET updated_at = ? WHERE id = ? AND user_id = ?",
+			answer.Answer.CreatedAt, answer.ConversationID, farmID)
+	}
+	if err != nil {
+		return err
+	}
+
+	for _, message := range []models.AdvisorMessage{answer.Question, answer.Answer} {
+		_, err := tx.Exec(`
+			INSERT INTO advisor_messages (id, conversation_id, account_id, role, content, input_tokens, output_tokens,
+			                              cost, created_at)
+			VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?)
+		`, message.ID, answer.ConversationID, accountID, message.Role, message.Content, message.InputTokens,
+			message.OutputTokens, message.Cost, message.CreatedAt)
+		if err != nil {
+			return err
+		}
+	}
+
+	return tx.Commit()
+}
+
+// history konuşmanın son mesajlarını eskiden yeniye döner
+func (s *AdvisorService) history(farmID, accountID, conversationID string) ([]AdvisorMessage, error) {
+	if _, err := s.conversation(farmID, accountID, conversationID); err != nil {
+		return nil, err
+	}
+
+	rows, err := s.db.Query(`
+		SELECT role, content FROM (
+			SELECT m.role, m.content, m.created_at FROM advisor_messages m
+			JOIN advisor_conversations c ON c.id = m.conversation_id
+			WHERE m.conversation_id = ? AND c.user_id = ?
+			ORDER BY m.created_at DESC
+			LIMIT ?
+		) ORDER BY created_at
+	`, conversationID, farmID, advisorHistoryMessages)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var messages []AdvisorMessage
+	for rows.Next() {
+		var message AdvisorMessage
+		if err := rows.Scan(&message.Role, &message.Content); err != nil {
+			return nil, err
+		}
+		messages = append(messages, message)
+	}
+	return messages, rows.Err()
+}
+
+// Conversations hesabın seçili çiftlikteki konuşmalarını son güncellenene göre listeler
+func (s *AdvisorService) Conversations(farmID, accountID string) ([]models.AdvisorConversation, error) {
+	rows, err := s.db.Query(`
+		SELECT c.id, c.title, COUNT(m.id), c.created_at, c.updated_at
+		FROM advisor_conversations c
+		LEFT JOIN advisor_messages m ON m.conversation_id = c.id
+		WHERE c.user_id = ? AND c.account_id = ?
+		GROUP BY c.id
+		ORDER BY c.updated_at DESC
+	`, farmID, accountID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	conversations := []models.AdvisorConversation{}
+	for rows.Next() {
+		var conversation models.AdvisorConversation
+		err := rows.Scan(&conversation.ID, &conversation.Title, &conversation.MessageCount,
+			&conversation.CreatedAt, &conversation.UpdatedAt)
+		if err != nil {
+			return nil, err
+		}
+		conversations = append(conversations, conversation)
+	}
+	return conversations, rows.Err()
+}
+
+// Conversation konuşmayı tüm mesajlarıyla döner
+func (s *AdvisorService) Conversation(farmID, accountID, conversationID string) (*models.AdvisorConversation, error) {
+	conversation, err := s.conversation(farmID, accountID, conversationID)
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := s.db.Query(`
+		SELECT m.id, m.role, m.content, m.input_tokens, m.output_tokens, m.cost, m.created_at
+		FROM advisor_messages m
+		JOIN advisor_conversations c ON c.id = m.conversation_id
+		WHERE m.conversation_id = ? AND c.user_id = ?
+		ORDER BY m.created_at
+	`, conversationID, farmID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	conversation.Messages = []models.AdvisorMessage{}
+	for rows.Next() {
+		var message models.AdvisorMessage
+		err := rows.Scan(&message.ID, &message.Role, &message.Content, &message.InputTokens, &message.OutputTokens,
+			&message.Cost, &message.CreatedAt)
+		if err != nil {
+			return nil, err
+		}
+		conversation.Messages = append(conversation.Messages, message)
+	}
+	conversation.MessageCount = len(conversation.Messages)
+	return conversation, rows.Err()
+}
+
+// conversation konuşma başlığını döner; konuşma başka hesaba veya çiftliğe aitse bulunamadı sayılır
+func (s *AdvisorService) conversation(farmID, accountID, conversationID string) (*models.AdvisorConversation, error) {
+	var conversation models.AdvisorConversation
+	err := s.db.QueryRow(`
+		SELECT id, title, created_at, updated_at FROM advisor_conversations
+		WHERE id = ? AND user_id = ? AND account_id = ?
+	`, conversationID, farmID, accountID).Scan(&conversation.ID, &conversation.Title, &conversation.CreatedAt,
+		&conversation.UpdatedAt)
+	if err == sql.ErrNoRows {
+		return nil, ErrAdvisorConversationNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &conversation, nil
+}
+
+// FarmContext modele verilen çiftlik özetini üretir: hayvan sayıları, araziler ve ürünler, son 14 günün
+// hava gözlemleri ile son 30 gün ve 12 ayın gelir-gider toplamları
+func (s *AdvisorService) FarmContext(farmID string, now time.Time) (string, error) {
+	var b strings.Builder
+	b.WriteString("Çiftlik verileri (" + now.Format("02.01.2006") + "):\n")
+
+	rows, err := s.db.Query(`
+		SELECT type, health_status, COUNT(*), COALESCE(AVG(weight), 0)
+		FROM livestock
+		WHERE user_id = ? AND sale_date IS NULL
+		GROUP BY type, health_status
+		ORDER BY type, health_status
+	`, farmID)
+	if err != nil {
+		return "", err
+	}
+	b.WriteString("Hayvanlar:\n")
+	var livestockLines int
+	for rows.Next() {
+		var animalType, health string
+		var count int
+		var weight float64
+		if err := rows.Scan(&animalType, &health, &count, &weight); err != nil {
+			rows.Close()
+			return "", err
+		}
+		fmt.Fprintf(&b, "- %s (%s): %d baş, ort. %.0f kg\n", animalType, health, count, weight)
+		livestockLines++
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return "", err
+	}
+	if livestockLines == 0 {
+		b.WriteString("- kayıt yok\n")
+	}
+
+	rows, err = s.db.Query(`
+		SELECT name, area, unit, COALESCE(crop, ''), status
+		FROM lands
+		WHERE user_id = ?
+		ORDER BY name
+	`, farmID)
+	if err != nil {
+		return "", err
+	}
+	b.WriteString("Araziler:\n")
+	var landLines int
+	for rows.Next() {
+		var name, unit, crop, status string
+		var area float64
+		if err := rows.Scan(&name, &area, &unit, &crop, &status); err != nil {
+			rows.Close()
+			return "", err
+		}
+		if crop == "" {
+			crop = "ekili değil"
+		}
+		fmt.Fprintf(&b, "- %s: %.1f %s, %s, durum %s\n", name, area, unit, crop, status)
+		landLines++
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return "", err
+	}
+	if landLines == 0 {
+		b.WriteString("- kayıt yok\n")
+	}
+
+	var observations int
+	var minTemp, maxTemp, rainfall sql.NullFloat64
+	err = s.db.QueryRow(`
+		SELECT COUNT(*), MIN(min_temp), MAX(max_temp), SUM(rainfall)
+		FROM `+preferredWeatherObservations("")+`
+		WHERE observed_on >= ?
+	`, farmID, now.AddDate(0, 0, -advisorWeatherDays).Format("2006-01-02")).Scan(&observations, &minTemp, &maxTemp, &rainfall)
+	if err != nil {
+		return "", err
+	}
+	if observations > 0 {
+		fmt.Fprintf(&b, "Son %d gün hava: en düşük %.1f°C, en yüksek %.1f°C, toplam yağış %.1f mm\n",
+			advisorWeatherDays, minTemp.Float64, maxTemp.Float64, rainfall.Float64)
+	} else {
+		fmt.Fprintf(&b, "Son %d gün hava: gözlem yok\n", advisorWeatherDays)
+	}
+
+	for _, period := range []struct {
+		label string
+		from  time.Time
+	}{
+		{"Son 30 gün", now.AddDate(0, 0, -30)},
+		{"Son 12 ay", now.AddDate(-1, 0, 0)},
+	} {
+		var income, expense float64
+		err := s.db.QueryRow(`
+			SELECT COALESCE(SUM(CASE WHEN type = 'income' THEN amount ELSE 0 END), 0),
+			       COALESCE(SUM(CASE WHEN type = 'expense' THEN amount ELSE 0 END), 0)
+			FROM transactions
+			WHERE user_id = ? AND date >= ?
+		`, farmID, period.from.Format("2006-01-02")).Scan(&income, &expense)
+		if err != nil {
+			return "", err
+		}
+		fmt.Fprintf(&b, "%s: gelir %.2f TRY, gider %.2f TRY, net %.2f TRY\n", period.label, income, expense, income-expense)
+	}
+
+	return b.String(), nil
+}
+
+// advisorTitle konuşma başlığı olarak sorunun ilk 60 karakterini kullanır
+func advisorTitle(question string) string {
+	title := []rune(strings.Join(strings.Fields(question), " "))
+	if len(title) > 60 {
+		return string(title[:60]) + "…"
+	}
+	return string(title)
+}
+
+// envFloat sayısal ortam değişkenini okur; boş veya geçersizse varsayılanı döner
+func envFloat(name string, fallback float64) float64 {
+	value, err := strconv.ParseFloat(os.Getenv(name), 64)
+	if err != nil {
+		return fallback
+	}
+	return value
+}
@@ -0,0 +1,525 @@
+package services
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"agri-management-api/internal/models"
+	"agri-management-api/internal/utils"
+)
+
+// ErrPassportAnimalNotFound pasaportu istenen hayvan çiftlikte yok
+var ErrPassportAnimalNotFound = errors.New("animal not found")
+
+// passportDateLayout pasaportta kullanılan tarih formatı
+const passportDateLayout = "02.01.2006"
+
+// passportMaxVaccinations pasaportta listelenen en fazla aşı sayısı; kalanlar özette sayılır
+const passportMaxVaccinations = 8
+
+// Pasaport sayfa düzeni (pt)
+const (
+	passportMargin    = 40.0
+	passportRowHeight = 14.0
+	passportFooterTop = 800.0
+)
+
+// passportGenderLabels cinsiyet etiketleri
+var passportGenderLabels = map[string]string{
+	"female": "Dişi",
+	"male":   "Erkek",
+}
+
+// passportHealthLabels sağlık durumu etiketleri
+var passportHealthLabels = map[string]string{
+	"healthy":            "Sağlıklı",
+	"sick":               "Hasta",
+	"pregnant":           "Gebe",
+	"vaccination_needed": "Aşı gerekli",
+}
+
+// passportMovementLabels hareket tipi etiketleri
+var passportMovementLabels = map[string]string{
+	"birth":     "Doğum",
+	"purchase":  "Giriş",
+	"sale":      "Satış",
+	"transfer":  "Nakil",
+	"death":     "Ölüm",
+	"slaughter": "Kesim",
+}
+
+// passportColumn pasaport tablosunun sütunu
+type passportColumn struct {
+	title string
+	width float64
+}
+
+// AnimalPassportService tek sayfalık hayvan pasaportunu hazırlar
+type AnimalPassportService struct {
+	db         *sql.DB
+	store      MediaStore
+	categories *CategoryService
+}
+
+// NewAnimalPassportService yeni animal passport service oluşturur
+func NewAnimalPassportService(db *sql.DB) *AnimalPassportService {
+	return &AnimalPassportService{
+		db:         db,
+		store:      NewMediaStore(),
+		categories: NewCategoryService(db),
+	}
+}
+
+// Passport hayvanın kimlik bilgilerini, son fotoğrafını, iki kuşak soy kütüğünü, aşılarını ve hareketlerini toplar
+func (s *AnimalPassportService) Passport(farmID, animalID string, now time.Time) (models.AnimalPassport, error) {
+	passport := models.AnimalPassport{GeneratedAt: now}
+
+	var animal models.Livestock
+	var birthDate sql.NullTime
+	var weight sql.NullFloat64
+
+	err := s.db.QueryRow(`
+		SELECT id, user_id, tag_number, type, COALESCE(breed, ''), COALESCE(gender, ''), birth_date, weight,
+		       COALESCE(health_status, ''), COALESCE(location, ''), COALESCE(mother, ''), COALESCE(father, ''),
+		       COALESCE(notes, ''), created_at, updated_at
+		FROM livestock WHERE id = ? AND user_id = ?
+	`, animalID, farmID).Scan(
+		&animal.ID, &animal.UserID, &animal.TagNumber, &animal.Type, &animal.Breed, &animal.Gender, &birthDate, &weight,
+		&animal.HealthStatus, &animal.Location, &animal.Mother, &animal.Father, &animal.Notes,
+		&animal.CreatedAt, &animal.UpdatedAt,
+	)
+	if err == sql.ErrNoRows {
+		return passport, ErrPassportAnimalNotFound
+	}
+	if err != nil {
+		return passport, err
+	}
+
+	animal.BirthDate = utils.NullTimeToPtr(birthDate)
+	animal.Weight = utils.NullFloat64ToPtr(weight)
+	passport.Animal = animal
+
+	passport.TypeLabel = animal.Type
+	if categories, err := s.categories.List(farmID, "livestock"); err == nil {
+		for _, category := range categories {
+			if category.Key == animal.Type {
+				passport.TypeLabel = category.Label
+			}
+		}
+	}
+
+	s.db.QueryRow("SELECT name FROM farms WHERE id = ?", farmID).Scan(&passport.FarmName)
+
+	s.db.QueryRow(`
+		SELECT id FROM media_attachments
+		WHERE user_id = ? AND entity_type = 'livestock' AND entity_id = ? AND kind = 'image'
+		ORDER BY created_at DESC LIMIT 1
+	`, farmID, animalID).Scan(&passport.PhotoID)
+
+	var motherParents, fatherParents [2]string
+	passport.Pedigree.Mother, motherParents = s.ancestor(farmID, animal.Mother)
+	passport.Pedigree.Father, fatherParents = s.ancestor(farmID, animal.Father)
+	passport.Pedigree.MaternalGrandmother, _ = s.ancestor(farmID, motherParents[0])
+	passport.Pedigree.MaternalGrandfather, _ = s.ancestor(farmID, motherParents[1])
+	passport.Pedigree.PaternalGrandmother, _ = s.ancestor(farmID, fatherParents[0])
+	passport.Pedigree.PaternalGrandfather, _ = s.ancestor(farmID, fatherParents[1])
+
+	if passport.Vaccinations, err = s.vaccinations(farmID, animalID); err != nil {
+		return passport, err
+	}
+	if passport.Movements, err = s.movements(farmID, animalID); err != nil {
+		return passport, err
+	}
+
+	return passport, nil
+}
+
+// WritePDF pasaportu tek sayfalık PDF olarak yazar
+func (s *AnimalPassportService) WritePDF(w io.Writer, farmID string, passport models.AnimalPassport) error {
+	var photo []byte
+	if passport.PhotoID != "" {
+		var storageKey string
+		err := s.db.QueryRow("SELECT storage_key FROM media_attachments WHERE id = ? AND user_id = ?", passport.PhotoID, farmID).Scan(&storageKey)
+		if err == nil {
+			if file, err := s.store.Open(storageKey); err == nil {
+				photo, _ = io.ReadAll(file)
+				file.Close()
+			}
+		}
+	}
+
+	return writeAnimalPassportPDF(w, passport, photo)
+}
+
+// ancestor küpe numarasıyla çiftlikteki atayı ve onun anne/baba küpe numaralarını bulur
+func (s *AnimalPassportService) ancestor(farmID, tagNumber string) (*models.PedigreeAnimal, [2]string) {
+	var parents [2]string
+	tagNumber = strings.TrimSpace(tagNumber)
+	if tagNumber == "" {
+		return nil, parents
+	}
+
+	ancestor := &models.PedigreeAnimal{TagNumber: tagNumber}
+	var birthDate sql.NullTime
+	err := s.db.QueryRow(`
+		SELECT id, COALESCE(breed, ''), birth_date, COALESCE(mother, ''), COALESCE(father, '')
+		FROM livestock WHERE user_id = ? AND tag_number = ?
+		LIMIT 1
+	`, farmID, tagNumber).Scan(&ancestor.ID, &ancestor.Breed, &birthDate, &parents[0], &parents[1])
+	if err != nil {
+		return ancestor, parents
+	}
+	ancestor.BirthDate = utils.NullTimeToPtr(birthDate)
+
+	return ancestor, parents
+}
+
+// vaccinations hayvanın aşı kayıtları, en yeniden eskiye
+func (s *AnimalPassportService) vaccinations(farmID, animalID string) ([]models.HealthRecord, error) {
+	rows, err := s.db.Query(`
+		SELECT r.id, r.livestock_id, r.type, COALESCE(r.description, ''), r.date, COALESCE(r.veterinarian, ''),
+		       r.cost, COALESCE(r.notes, ''), r.next_checkup, r.created_at
+		FROM health_records r
+		JOIN livestock l ON l.id = r.livestock_id
+		WHERE r.livestock_id = ? AND l.user_id = ? AND r.type = 'vaccination'
+		ORDER BY r.date DESC, r.created_at DESC
+	`, animalID, farmID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	records := []models.HealthRecord{}
+	for rows.Next() {
+		var record models.HealthRecord
+		var date, nextCheckup sql.NullTime
+		var cost sql.NullFloat64
+
+		err := rows.Scan(
+			&record.ID, &record.AnimalID, &record.Type, &record.Description, &date,
+			&record.Veterinarian, &cost, &record.Notes, &nextCheckup, &record.CreatedAt,
+		)
+		if err != nil {
+			continue
+		}
+
+		record.Date = utils.NullTimeToPtr(date)
+		record.Cost = utils.NullFloat64ToPtr(cost)
+		record.NextCheckup = utils.NullTimeToPtr(nextCheckup)
+		records = append(records, record)
+	}
+
+	return records, rows.Err()
+}
+
+// movements hayvanın hareket kayıtları, en yeniden eskiye
+func (s *AnimalPassportService) movements(farmID, animalID string) ([]models.LivestockMovement, error) {
+	rows, err := s.db.Query(`
+		SELECT id, livestock_id, movement_type, movement_date, COALESCE(from_location, ''),
+		       COALESCE(to_location, ''), COALESCE(premises_number, ''), COALESCE(reason, ''),
+		       COALESCE(notes, ''), created_at
+		FROM livestock_movements WHERE livestock_id = ? AND user_id = ?
+		ORDER BY movement_date DESC, created_at DESC
+	`, animalID, farmID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	movements := []models.LivestockMovement{}
+	for rows.Next() {
+		var movement models.LivestockMovement
+		var movementDate sql.NullTime
+
+		err := rows.Scan(
+			&movement.ID, &movement.LivestockID, &movement.MovementType, &movementDate,
+			&movement.FromLocation, &movement.ToLocation, &movement.PremisesNumber,
+			&movement.Reason, &movement.Notes, &movement.CreatedAt,
+		)
+		if err != nil {
+			continue
+		}
+
+		movement.MovementDate = utils.NullTimeToPtr(movementDate)
+		movements = append(movements, movement)
+	}
+
+	return movements, rows.Err()
+}
+
+// writeAnimalPassportPDF pasaport sayfasını çizer: başlık, fotoğraf ve kimlik bilgileri, soy kütüğü ağacı,
+// aşı özeti ve sayfaya sığdığı kadar hareket geçmişi
+func writeAnimalPassportPDF(w io.Writer, passport models.AnimalPassport, photo []byte) error {
+	doc := NewPDFDocument()
+	animal := passport.Animal
+	right := PDFPageWidth - passportMargin
+	contentWidth := right - passportMargin
+
+	// Başlık
+	doc.Text(passportMargin, 62, 20, true, "HAYVAN PASAPORTU")
+	doc.TextFit(passportMargin, 78, contentWidth/2, 10, false, passport.FarmName)
+	doc.TextRight(right, 62, 16, true, animal.TagNumber)
+	doc.TextRight(right, 78, 9, false, "Düzenleme tarihi: "+passport.GeneratedAt.Format(passportDateLayout))
+	doc.Line(passportMargin, 88, right, 88, 1.5, 0)
+
+	// Fotoğraf
+	photoSize := 170.0
+	doc.Rect(passportMargin, 100, photoSize, photoSize, 0.75, 0.6)
+	if len(photo) == 0 || doc.Image(photo, passportMargin+4, 104, photoSize-8, photoSize-8) != nil {
+		doc.FillRect(passportMargin+4, 104, photoSize-8, photoSize-8, 0.93)
+		label := "Fotoğraf yok"
+		doc.Text(passportMargin+(photoSize-PDFTextWidth(label, 9, false))/2, 100+photoSize/2+3, 9, false, label)
+	}
+
+	// Kimlik bilgileri
+	age := ""
+	if animal.BirthDate != nil {
+		age = passportAge(*animal.BirthDate, passport.GeneratedAt)
+	}
+	weight := ""
+	if animal.Weight != nil {
+		weight = fmt.Sprintf("%.1f kg", *animal.Weight)
+	}
+	fields := [][2]string{
+		{"Küpe No", animal.TagNumber}, {"Tür", passport.TypeLabel},
+		{"Irk", animal.Breed}, {"Cinsiyet", passportLabel(passportGenderLabels, animal.Gender)},
+		{"Doğum Tarihi", passportDate(animal.BirthDate)}, {"Yaş", age},
+		{"Ağırlık", weight}, {"Sağlık Durumu", passportLabel(passportHealthLabels, animal.HealthStatus)},
+		{"Konum", animal.Location}, {"İşletmeye Kayıt", animal.CreatedAt.Format(passportDateLayout)},
+	}
+	fieldsLeft := passportMargin + photoSize + 20
+	columnWidth := (right - fieldsLeft) / 2
+	for i, field := range fields {
+		x := fieldsLeft + float64(i%2)*columnWidth
+		y := 112 + float64(i/2)*32
+		value := field[1]
+		if value == "" {
+			value = "-"
+		}
+		doc.Text(x, y, 8, false, field[0])
+		doc.TextFit(x, y+14, columnWidth-10, 11, true, value)
+	}
+
+	// Soy kütüğü
+	y := passportSection(doc, 290, "Soy Kütüğü")
+	pedigree := passport.Pedigree
+	animalBox, parentLeft, grandLeft := 150.0, passportMargin+170, passportMargin+350
+	grandHeight, grandGap := 22.0, 4.0
+	parentHeight := grandHeight*2 + grandGap
+
+	passportPedigreeBox(doc, passportMargin, y+parentHeight/2+grandGap/2, animalBox, parentHeight, "Hayvan",
+		&models.PedigreeAnimal{TagNumber: animal.TagNumber, Breed: animal.Breed, BirthDate: animal.BirthDate})
+	passportPedigreeBox(doc, parentLeft, y, 160, parentHeight, "Anne", pedigree.Mother)
+	passportPedigreeBox(doc, parentLeft, y+parentHeight+grandGap, 160, parentHeight, "Baba", pedigree.Father)
+
+	grandparents := []struct {
+		label    string
+		ancestor *models.PedigreeAnimal
+	}{
+		{"Annenin annesi", pedigree.MaternalGrandmother}, {"Annenin babası", pedigree.MaternalGrandfather},
+		{"Babanın annesi", pedigree.PaternalGrandmother}, {"Babanın babası", pedigree.PaternalGrandfather},
+	}
+	for i, grandparent := range grandparents {
+		passportPedigreeBox(doc, grandLeft, y+float64(i)*(grandHeight+grandGap), right-grandLeft, grandHeight,
+			grandparent.label, grandparent.ancestor)
+	}
+
+	// Bağlantı çizgileri
+	parentMids := [2]float64{y + parentHeight/2, y + parentHeight + grandGap + parentHeight/2}
+	passportConnector(doc, passportMargin+animalBox, parentLeft, (parentMids[0]+parentMids[1])/2, parentMids[0], parentMids[1])
+	for i, mid := range parentMids {
+		top := y + float64(i*2)*(grandHeight+grandGap) + grandHeight/2
+		passportConnector(doc, parentLeft+160, grandLeft, mid, top, top+grandHeight+grandGap)
+	}
+	y += 4*grandHeight + 3*grandGap + 16
+
+	// Aşı özeti
+	y = passportSection(doc, y, "Aşı Özeti")
+	summary := fmt.Sprintf("Toplam aşı: %d", len(passport.Vaccinations))
+	if len(passport.Vaccinations) > 0 {
+		summary += "   •   Son aşı: " + passportDate(passport.Vaccinations[0].Date)
+		var next *time.Time
+		for _, record := range passport.Vaccinations {
+			if record.NextCheckup != nil && !record.NextCheckup.Before(passport.GeneratedAt.Truncate(24*time.Hour)) &&
+				(next == nil || record.NextCheckup.Before(*next)) {
+				next = record.NextCheckup
+			}
+		}
+		if next != nil {
+			summary += "   •   Sıradaki aşı: " + passportDate(next)
+		}
+	}
+	doc.Text(passportMargin+6, y+10, 9, false, summary)
+	y += 18
+
+	vaccinationRows := make([][]string, 0, len(passport.Vaccinations))
+	for _, record := range passport.Vaccinations {
+		vaccinationRows = append(vaccinationRows, []string{
+			passportDate(record.Date), record.Description, record.Veterinarian, passportDate(record.NextCheckup),
+		})
+	}
+	y = passportTable(doc, y, []passportColumn{
+		{"Tarih", 75}, {"Aşı", 215}, {"Veteriner", 140}, {"Sonraki", contentWidth - 430},
+	}, vaccinationRows, passportMaxVaccinations, "Aşı kaydı bulunmuyor", "aşı")
+	y += 12
+
+	// Hareket geçmişi
+	y = passportSection(doc, y, "Hareket Geçmişi")
+	movementRows := make([][]string, 0, len(passport.Movements))
+	for _, movement := range passport.Movements {
+		movementRows = append(movementRows, []string{
+			passportDate(movement.MovementDate), passportLabel(passportMovementLabels, movement.MovementType),
+			movement.FromLocation, movement.ToLocation, movement.PremisesNumber,
+		})
+	}
+	maxMovements := int((passportFooterTop - y - passportRowHeight - 8) / passportRowHeight)
+	passportTable(doc, y, []passportColumn{
+		{"Tarih", 75}, {"Hareket", 70}, {"Çıkış", 140}, {"Varış", 140}, {"İşletme No", contentWidth - 425},
+	}, movementRows, maxMovements, "Hareket kaydı bulunmuyor", "hareket")
+
+	// Alt bilgi
+	doc.Line(passportMargin, passportFooterTop+6, right, passportFooterTop+6, 0.5, 0.6)
+	doc.TextFit(passportMargin, passportFooterTop+18, contentWidth-150, 7, false,
+		"Bu belge çiftlik kayıtlarından "+passport.GeneratedAt.Format(passportDateLayout+" 15:04")+" tarihinde oluşturulmuştur.")
+	doc.TextRight(right, passportFooterTop+18, 7, false, "Hayvan ID: "+animal.ID)
+
+	return doc.Write(w)
+}
+
+// passportSection gri zeminli bölüm başlığını çizer ve içeriğin başlayacağı konumu döner
+func passportSection(doc *PDFDocument, y float64, title string) float64 {
+	doc.FillRect(passportMargin, y, PDFPageWidth-2*passportMargin, 18, 0.9)
+	doc.Text(passportMargin+6, y+13, 10, true, title)
+	return y + 26
+}
+
+// passportPedigreeBox soy kütüğü kutusunu çizer; bilinmeyen atalar gri zeminle gösterilir
+func passportPedigreeBox(doc *PDFDocument, x, y, w, h float64, label string, ancestor *models.PedigreeAnimal) {
+	if ancestor == nil {
+		doc.FillRect(x, y, w, h, 0.95)
+	}
+	doc.Rect(x, y, w, h, 0.5, 0.6)
+
+	if h < 30 {
+		doc.Text(x+5, y+8, 6.5, false, label)
+		text := "Bilinmiyor"
+		if ancestor != nil {
+			text = ancestor.TagNumber
+			if ancestor.Breed != "" {
+				text += " · " + ancestor.Breed
+			}
+		}
+		doc.TextFit(x+5, y+18, w-10, 8.5, ancestor != nil, text)
+		return
+	}
+
+	doc.Text(x+6, y+12, 7, false, label)
+	if ancestor == nil {
+		doc.Text(x+6, y+28, 10, false, "Bilinmiyor")
+		return
+	}
+	doc.TextFit(x+6, y+28, w-12, 11, true, ancestor.TagNumber)
+
+	details := []string{}
+	if ancestor.Breed != "" {
+		details = append(details, ancestor.Breed)
+	}
+	if ancestor.BirthDate != nil {
+		details = append(details, "d. "+ancestor.BirthDate.Format(passportDateLayout))
+	}
+	if ancestor.ID == "" && label != "Hayvan" {
+		details = append(details, "sürüde kayıtlı değil")
+	}
+	doc.TextFit(x+6, y+41, w-12, 7.5, false, strings.Join(details, " · "))
+}
+
+// passportConnector soldaki kutunun ortasından sağdaki iki kutuya dirsekli bağlantı çizer
+func passportConnector(doc *PDFDocument, fromX, toX, fromY, topY, bottomY float64) {
+	midX := (fromX + toX) / 2
+	doc.Line(fromX, fromY, midX, fromY, 0.5, 0.4)
+	doc.Line(midX, topY, midX, bottomY, 0.5, 0.4)
+	doc.Line(midX, topY, toX, topY, 0.5, 0.4)
+	doc.Line(midX, bottomY, toX, bottomY, 0.5, 0.4)
+}
+
+// passportTable başlıklı tabloyu en fazla maxRows satırla çizer; sığmayan satırlar "… ve N kayıt daha"
+// satırında belirtilir. Tablonun bittiği konumu döner
+func passportTable(doc *PDFDocument, y float64, columns []passportColumn, rows [][]string, maxRows int, empty, noun string) float64 {
+	x := passportMargin + 6
+	for _, column := range columns {
+		doc.Text(x, y+10, 8, true, column.title)
+		x += column.width
+	}
+	doc.Line(passportMargin, y+14, PDFPageWidth-passportMargin, y+14, 0.5, 0.6)
+	y += 14
+
+	if len(rows) == 0 {
+		doc.Text(passportMargin+6, y+11, 8.5, false, empty)
+		return y + passportRowHeight
+	}
+
+	shown := len(rows)
+	if maxRows < 1 {
+		maxRows = 1
+	}
+	if shown > maxRows {
+		shown = maxRows - 1
+	}
+
+	for _, row := range rows[:shown] {
+		x := passportMargin + 6
+		for i, column := range columns {
+			value := row[i]
+			if value == "" {
+				value = "-"
+			}
+			doc.TextFit(x, y+11, column.width-8, 8.5, false, value)
+			x += column.width
+		}
+		y += passportRowHeight
+	}
+	if shown < len(rows) {
+		doc.Text(passportMargin+6, y+11, 8, false, fmt.Sprintf("… ve %d %s kaydı daha", len(rows)-shown, noun))
+		y += passportRowHeight
+	}
+
+	return y
+}
+
+// passportDate tarihi pasaport biçiminde yazar
+func passportDate(date *time.Time) string {
+	if date == nil {
+		return ""
+	}
+	return date.Format(passportDateLayout)
+}
+
+// passportLabel değerin Türkçe etiketini döner; tanımsız değerler olduğu gibi yazılır
+func passportLabel(labels map[string]string, value string) string {
+	if label, ok := labels[value]; ok {
+		return label
+	}
+	return value
+}
+
+// passportAge doğum tarihinden bugüne yaşı yıl ve ay olarak yazar
+func passportAge(birthDate, now time.Time) string {
+	months := (now.Year()-birthDate.Year())*12 + int(now.Month()-birthDate.Month())
+	if now.Day() < birthDate.Day() {
+		months--
+	}
+	switch {
+	case months < 0:
+		return ""
+	case months < 12:
+		return fmt.Sprintf("%d ay", months)
+	case months%12 == 0:
+		return fmt.Sprintf("%d yıl", months/12)
+	default:
+		return fmt.Sprintf("%d yıl %d ay", months/12, months%12)
+	}
+}
@@ -0,0 +1,267 @@
+package services
+
+import (
+	"database/sql"
+	"fmt"
+	"math"
+	"net/url"
+	"time"
+
+	"agri-management-api/internal/models"
+	"agri-management-api/internal/utils"
+)
+
+// Anomali kontrol eşikleri
+const (
+	// expenseSpikeBaselineWeeks gider artışında karşılaştırılan önceki hafta sayısı
+	expenseSpikeBaselineWeeks = 8
+	// expenseSpikeDeviations haftalık giderin ortalamayı aşması gereken standart sapma sayısı
+	expenseSpikeDeviations = 2.0
+	// expenseSpikeMinRatio haftalık giderin ortalamaya oranı için alt sınır; düşük varyanslı çiftliklerde küçük artışları eler
+	expenseSpikeMinRatio = 1.5
+	// milkDropThreshold son 7 günlük sütün önceki 4 haftanın haftalık ortalamasına göre düşüş oranı
+	milkDropThreshold = 0.20
+	// milkDropBaselineWeeks süt düşüşünde karşılaştırılan önceki hafta sayısı
+	milkDropBaselineWeeks = 4
+	// inactivityDays kayıt girilmeyen gün sayısı
+	inactivityDays = 14
+)
+
+// anomalyActivitySources son kayıt tarihinin arandığı tablolar; her sorgu çiftliğin en son kayıt zamanını
+// SQLite datetime biçiminde (YYYY-MM-DD HH:MM:SS, UTC) döner
+var anomalyActivitySources = []string{
+	"SELECT MAX(datetime(created_at)) FROM transactions WHERE user_id = ?",
+	"SELECT MAX(datetime(created_at)) FROM production WHERE user_id = ?",
+	"SELECT MAX(datetime(created_at)) FROM events WHERE user_id = ?",
+	"SELECT MAX(datetime(created_at)) FROM livestock WHERE user_id = ?",
+	"SELECT MAX(datetime(a.created_at)) FROM land_activities a JOIN lands l ON l.id = a.land_id WHERE l.user_id = ?",
+	"SELECT MAX(datetime(m.created_at)) FROM milk_production m JOIN livestock l ON l.id = m.livestock_id WHERE l.user_id = ?",
+	"SELECT MAX(datetime(h.created_at)) FROM health_records h JOIN livestock l ON l.id = h.livestock_id WHERE l.user_id = ?",
+}
+
+// AnomalyService anahtar metriklerde olağandışı değerleri bulur ve her kontrol-dönem çiftini bir kez kaydeder
+type AnomalyService struct {
+	db         *sql.DB
+	timeSeries *TimeSeriesService
+}
+
+// NewAnomalyService yeni anomali servisi oluşturur
+func NewAnomalyService(db *sql.DB) *AnomalyService {
+	return &AnomalyService{db: db, timeSeries: NewTimeSeriesService(db)}
+}
+
+// Owners kontrol edilecek veri sahiplerini (hesaplar ve çiftlikler) döner
+func (s *AnomalyService) Owners() ([]string, error) {
+	rows, err := s.db.Query("SELECT id FROM users UNION SELECT id FROM farms")
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var owners []string
+	for rows.Next() {
+		var id string
+		if err := rows.Scan(&id); err != nil {
+			return nil, err
+		}
+		owners = append(owners, id)
+	}
+	return owners, rows.Err()
+}
+
+// Detect çiftliğin tüm kontrollerini çalıştırır; yeni bulunan anomaliler kaydedilip döner, daha önce
+// aynı dönem için kaydedilmiş olanlar tekrar dönmez
+func (s *AnomalyService) Detect(farmID string, now time.Time) ([]models.MetricAnomaly, error) {
+	checks := []func(string, time.Time) (*models.MetricAnomaly, error){
+		s.expenseSpike,
+		s.milkDrop,
+		s.inactivity,
+	}
+
+	var detected []models.MetricAnomaly
+	for _, check := range checks {
+		anomaly, err := check(farmID, now)
+		if err != nil {
+			return detected, err
+		}
+		if anomaly == nil {
+			continue
+		}
+
+		created, err := s.record(farmID, anomaly)
+		if err != nil {
+			return detected, err
+		}
+		if created {
+			detected = append(detected, *anomaly)
+		}
+	}
+	return detected, nil
+}
+
+// List çiftliğin son anomalilerini yeniden eskiye döner
+func (s *AnomalyService) List(farmID string, limit int) ([]models.MetricAnomaly, error) {
+	rows, err := s.db.Query(`
+		SELECT id, check_type, metric, period, value, baseline, change_percent, message, COALESCE(link, ''), detected_at
+		FROM metric_anomalies
+		WHERE user_id = ?
+		ORDER BY detected_at DESC
+		LIMIT ?
+	`, farmID, limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	anomalies := []models.MetricAnomaly{}
+	for rows.Next() {
+		var anomaly models.MetricAnomaly
+		var changePercent sql.NullFloat64
+		err := rows.Scan(&anomaly.ID, &anomaly.Check, &anomaly.Metric, &anomaly.Period, &anomaly.Value, &anomaly.Baseline,
+			&changePercent, &anomaly.Message, &anomaly.Link, &anomaly.DetectedAt)
+		if err != nil {
+			return nil, err
+		}
+		anomaly.ChangePercent = utils.NullFloat64ToPtr(changePercent)
+		anomalies = append(anomalies, anomaly)
+	}
+	return anomalies, rows.Err()
+}
+
+// record anomaliyi kaydeder; aynı kontrol ve dönem için kayıt varsa false döner
+func (s *AnomalyService) record(farmID string, anomaly *models.MetricAnomaly) (bool, error) {
+	anomaly.ID = utils.GenerateID()
+	anomaly.DetectedAt = time.Now()
+
+	result, err := s.db.Exec(`
+		INSERT OR IGNORE INTO metric_anomalies (id, user_id, check_type, metric, period, value, baseline, change_percent,
+		                                        message, link, detected_at)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
+	`, anomaly.ID, farmID, anomaly.Check, anomaly.Metric, anomaly.Period, anomaly.Value, anomaly.Baseline,
+		anomaly.ChangePercent, anomaly.Message, anomaly.Link, anomaly.DetectedAt)
+	if err != nil {
+		return false, err
+	}
+	affected, err := result.RowsAffected()
+	return affected > 0, err
+}
+
+// expenseSpike geçen haftanın giderini önceki 8 haftanın ortalaması ve standart sapmasıyla karşılaştırır
+func (s *AnomalyService) expenseSpike(farmID string, now time.Time) (*models.MetricAnomaly, error) {
+	to := bucketStart(now, models.BucketWeek).AddDate(0, 0, -1)
+	from := bucketStart(to, models.BucketWeek).AddDate(0, 0, -7*expenseSpikeBaselineWeeks)
+	series, err := s.timeSeries.Series(farmID, "expense_total", models.BucketWeek, from, to, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	last := series.Points[len(series.Points)-1]
+	value := *last.Value
+	var mean float64
+	for _, point := range series.Points[:len(series.Points)-1] {
+		mean += *point.Value
+	}
+	mean /= expenseSpikeBaselineWeeks
+	var variance float64
+	for _, point := range series.Points[:len(series.Points)-1] {
+		variance += (*point.Value - mean) * (*point.Value - mean)
+	}
+	deviation := math.Sqrt(variance / expenseSpikeBaselineWeeks)
+
+	if mean <= 0 || value <= mean+expenseSpikeDeviations*deviation || value < mean*expenseSpikeMinRatio {
+		return nil, nil
+	}
+
+	change := round2((value - mean) / mean * 100)
+	return &models.MetricAnomaly{
+		Check:         models.AnomalyExpenseSpike,
+		Metric:        "expense_total",
+		Period:        last.Bucket,
+		Value:         value,
+		Baseline:      round2(mean),
+		ChangePercent: &change,
+		Message: fmt.Sprintf("%s haftasındaki giderler (%.2f TRY) son %d haftanın ortalamasının (%.2f TRY) %%%.0f üzerinde.",
+			last.Bucket, value, expenseSpikeBaselineWeeks, mean, change),
+		Link: timeSeriesLink("expense_total", models.BucketWeek, from, to),
+	}, nil
+}
+
+// milkDrop son 7 günün süt üretimini önceki 4 haftanın haftalık ortalamasıyla karşılaştırır
+func (s *AnomalyService) milkDrop(farmID string, now time.Time) (*models.MetricAnomaly, error) {
+	to := bucketStart(now, models.BucketDay).AddDate(0, 0, -1)
+	from := to.AddDate(0, 0, -7*(milkDropBaselineWeeks+1)+1)
+	series, err := s.timeSeries.Series(farmID, "milk_total", models.BucketDay, from, to, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var recent, baseline float64
+	for i, point := range series.Points {
+		if i >= len(series.Points)-7 {
+			recent += *point.Value
+		} else {
+			baseline += *point.Value
+		}
+	}
+	baseline /= milkDropBaselineWeeks
+
+	if baseline <= 0 || recent >= baseline*(1-milkDropThreshold) {
+		return nil, nil
+	}
+
+	change := round2((recent - baseline) / baseline * 100)
+	year, week := to.ISOWeek()
+	return &models.MetricAnomaly{
+		Check:         models.AnomalyMilkDrop,
+		Metric:        "milk_total",
+		Period:        fmt.Sprintf("%d-W%02d", year, week),
+		Value:         round2(recent),
+		Baseline:      round2(baseline),
+		ChangePercent: &change,
+		Message: fmt.Sprintf("Son 7 günün süt üretimi (%.1f L) önceki %d haftanın haftalık ortalamasından (%.1f L) %%%.0f düşük.",
+			recent, milkDropBaselineWeeks, baseline, -change),
+		Link: timeSeriesLink("milk_total", models.BucketDay, from, to),
+	}, nil
+}
+
+// inactivity çiftliğe 14 gündür hiç kayıt girilmediyse uyarır; hiç kaydı olmayan yeni çiftlikler atlanır
+func (s *AnomalyService) inactivity(farmID string, now time.Time) (*models.MetricAnomaly, error) {
+	var last time.Time
+	for _, query := range anomalyActivitySources {
+		var value sql.NullString
+		if err := s.db.QueryRow(query, farmID).Scan(&value); err != nil {
+			return nil, err
+		}
+		if !value.Valid {
+			continue
+		}
+		if latest, err := time.Parse("2006-01-02 15:04:05", value.String); err == nil && latest.After(last) {
+			last = latest
+		}
+	}
+
+	days := int(now.Sub(last).Hours() / 24)
+	if last.IsZero() || days < inactivityDays {
+		return nil, nil
+	}
+
+	return &models.MetricAnomaly{
+		Check:    models.AnomalyInactivity,
+		Metric:   "activity",
+		Period:   last.Format("2006-01-02"),
+		Value:    float64(days),
+		Baseline: inactivityDays,
+		Message:  fmt.Sprintf("%d gündür hiç kayıt girilmedi (son kayıt %s).", days, last.Format("02.01.2006")),
+		Link:     "/api/v1/dashboard/recent-activities",
+	}, nil
+}
+
+// timeSeriesLink anomalinin incelenebileceği zaman serisi sorgusu
+func timeSeriesLink(metric, bucket string, from, to time.Time) string {
+	query := url.Values{}
+	query.Set("metric", metric)
+	query.Set("bucket", bucket)
+	query.Set("from", from.Format("2006-01-02"))
+	query.Set("to", to.Format("2006-01-02"))
+	return "/api/v1/analytics/timeseries?" + query.Encode()
+}
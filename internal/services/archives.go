@@ -0,0 +1,834 @@
+package services
+
+import (
+	"bytes"
+	"compress/gzip"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"log"
+	"os"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"agri-management-api/internal/models"
+	"agri-management-api/internal/utils"
+)
+
+// archiveFormat soğuk depolamadaki arşiv dosyalarının biçim adı ve sürümü
+const (
+	archiveFormat        = "agri-archive"
+	archiveFormatVersion = 1
+)
+
+var (
+	// ErrArchiveNotFound arşiv paketi bulunamadığında döner
+	ErrArchiveNotFound = errors.New("archive batch not found")
+	// ErrInvalidArchiveTable arşivlenemeyen bir tablo istendiğinde döner
+	ErrInvalidArchiveTable = errors.New("invalid archive table")
+	// ErrArchiveRestored geri yüklenmiş paketin kayıtları istendiğinde veya paket yeniden geri yüklenmek istendiğinde döner
+	ErrArchiveRestored = errors.New("archive batch already restored")
+	// ErrInvalidArchiveFile arşiv dosyası okunamadığında veya başka bir pakete ait olduğunda döner
+	ErrInvalidArchiveFile = errors.New("invalid archive file")
+)
+
+// archiveMu arşivleme ve geri yüklemeleri sıraya koyar
+var archiveMu sync.Mutex
+
+// archiveReference arşivlenecek kaydı başka bir kayıttan gösteren sütun; typeColumn doluysa yalnızca o
+// sütunu typeValue olan satırlar sayılır. Bağlantısı olan kayıtlar ilişki bozulmasın diye sıcak tabloda kalır
+type archiveReference struct {
+	table      string
+	column     string
+	typeColumn string
+	typeValue  string
+}
+
+// archiveChild ana kayıtla birlikte taşınan alt tablo; key ana kaydın kimliğini tutan sütundur
+type archiveChild struct {
+	table string
+	key   string
+}
+
+// archiveSource arşivlenebilen tablo. parent doluysa tablonun user_id sütunu yoktur; satırlar parentKey
+// üzerinden üst tablonun user_id sütunuyla çiftliğe bağlanır. aggregate aylık özetin grup, alt grup, para
+// birimi ve toplam ifadeleridir; aggregateFilter özete girmeyen satırları (ör. iptal edilen işlemler) dışlar
+type archiveSource struct {
+	table           string
+	dateColumn      string
+	parent          string
+	parentKey       string
+	conditions      []string
+	references      []archiveReference
+	children        []archiveChild
+	aggregate       [4]string
+	aggregateFilter string
+}
+
+// archiveSources arşivlenebilen tablolar
+var archiveSources = []archiveSource{
+	{
+		table:      models.ArchiveTableTransactions,
+		dateColumn: "date",
+		// Bekleyen alacak ve borçlar vadesi geçmiş olsa da arşivlenmez
+		conditions: []string{"COALESCE(t.status, '') <> 'pending'"},
+		references: []archiveReference{
+			{table: "invoice_transactions", column: "transaction_id"},
+			{table: "sales_orders", column: "transaction_id"},
+			{table: "production_sales", column: "transaction_id"},
+			{table: "livestock", column: "purchase_transaction_id"},
+			{table: "livestock", column: "sale_transaction_id"},
+			{table: "slaughter_records", column: "transaction_id"},
+			{table: "livestock_costs", column: "transaction_id"},
+			{table: "depreciation_postings", column: "transaction_id"},
+			{table: "meter_readings", column: "transaction_id"},
+			{table: "transaction_drafts", column: "transaction_id"},
+			{table: "bank_statement_lines", column: "transaction_id"},
+			{table: "harvest_crew_entries", column: "transaction_id"},
+			{table: "entity_notes", column: "entity_id", typeColumn: "entity_type", typeValue: "transaction"},
+			{table: "media_attachments", column: "entity_id", typeColumn: "entity_type", typeValue: "transaction"},
+			{table: "documents", column: "entity_id", typeColumn: "entity_type", typeValue: "transaction"},
+			{table: "record_links", column: "source_id", typeColumn: "source_type", typeValue: "transaction"},
+			{table: "record_links", column: "target_id", typeColumn: "target_type", typeValue: "transaction"},
+		},
+		children:        []archiveChild{{table: "transaction_tags", key: "transaction_id"}},
+		aggregate:       [4]string{"t.type", "COALESCE(t.category, '')", "COALESCE(NULLIF(t.currency, ''), 'TRY')", "t.amount"},
+		aggregateFilter: "COALESCE(t.status, '') <> 'cancelled'",
+	},
+	{
+		table:      models.ArchiveTableEvents,
+		dateColumn: "start_date",
+		references: []archiveReference{
+			{table: "generated_events", column: "event_id"},
+			{table: "vet_visits", column: "farmer_event_id"},
+			{table: "vet_visits", column: "veterinarian_event_id"},
+			{table: "media_attachments", column: "entity_id", typeColumn: "entity_type", typeValue: "event"},
+			{table: "documents", column: "entity_id", typeColumn: "entity_type", typeValue: "event"},
+			{table: "record_links", column: "source_id", typeColumn: "source_type", typeValue: "event"},
+			{table: "record_links", column: "target_id", typeColumn: "target_type", typeValue: "event"},
+		},
+		aggregate: [4]string{"COALESCE(t.type, '')", "COALESCE(t.status, '')", "''", "0"},
+	},
+	{
+		table:      models.ArchiveTableMilkProduction,
+		dateColumn: "date",
+		parent:     "livestock",
+		parentKey:  "livestock_id",
+		aggregate:  [4]string{"t.livestock_id", "COALESCE(t.quality, '')", "''", "t.amount"},
+	},
+}
+
+// archiveFile soğuk depolamadaki arşiv dosyasının içeriği; dosya gzip ile sıkıştırılmış JSON olarak saklanır
+type archiveFile struct {
+	Format    string                              `json:"format"`
+	Version   int                                 `json:"version"`
+	FarmID    string                              `json:"farmId"`
+	BatchID   string                              `json:"batchId"`
+	Table     string                              `json:"table"`
+	Cutoff    time.Time                           `json:"cutoff"`
+	CreatedAt time.Time                           `json:"createdAt"`
+	Tables    map[string][]map[string]interface{} `json:"tables"`
+}
+
+// archiveJobPayload arşivleme işinin kuyrukta saklanan parametreleri
+type archiveJobPayload struct {
+	OlderThanYears int      `json:"olderThanYears"`
+	Tables         []string `json:"tables"`
+}
+
+// archiveBatchSelect arşiv paketlerini okuyan sorgu
+const archiveBatchSelect = `
+	SELECT id, job_id, table_name, cutoff, period_start, period_end, records, size_bytes, status, created_at, restored_at
+	FROM archive_batches`
+
+// ArchiveService eski işlem, etkinlik ve süt kayıtlarını sıcak tablolardan yedek deposundaki sıkıştırılmış
+// dosyalara taşır, aylık özetlerini veritabanında tutar ve istendiğinde kayıtları dosyadan okur veya geri yükler
+type ArchiveService struct {
+	db    *sql.DB
+	store BackupStore
+	jobs  *JobService
+}
+
+// NewArchiveService yeni archive service oluşturur
+func NewArchiveService(db *sql.DB) *ArchiveService {
+	return &ArchiveService{db: db, store: NewBackupStore(), jobs: NewJobService(db)}
+}
+
+// ArchiveTables arşivlenebilen tabloların adları
+func ArchiveTables() []string {
+	tables := make([]string, len(archiveSources))
+	for i, source := range archiveSources {
+		tables[i] = source.table
+	}
+	return tables
+}
+
+// archiveAfterYears zamanlanmış arşivlemenin kayıtları taşıdığı yaş (ARCHIVE_AFTER_YEARS; 0 veya boşsa kapalı)
+func archiveAfterYears() int {
+	if years, err := strconv.Atoi(os.Getenv("ARCHIVE_AFTER_YEARS")); err == nil && years > 0 {
+		return years
+	}
+	return 0
+}
+
+// archiveCutoff now'dan years yıl önceki ayın ilk günü; aylık özetlerde bir ay sıcak tablo ile arşiv
+// arasında bölünmez
+func archiveCutoff(now time.Time, years int) time.Time {
+	now = now.UTC()
+	return time.Date(now.Year()-years, now.Month(), 1, 0, 0, 0, 0, time.UTC)
+}
+
+// archiveSourceFor tablo adına göre arşiv tanımını döner
+func archiveSourceFor(table string) (archiveSource, bool) {
+	for _, source := range archiveSources {
+		if source.table == table {
+			return source, true
+		}
+	}
+	return archiveSource{}, false
+}
+
+// normalizeArchiveTables istenen tabloları doğrular; liste boşsa tüm tablolar döner
+func normalizeArchiveTables(tables []string) ([]string, error) {
+	if len(tables) == 0 {
+		return ArchiveTables(), nil
+	}
+	seen := map[string]bool{}
+	var normalized []string
+	for _, table := range tables {
+		if _, ok := archiveSourceFor(table); !ok {
+			return nil, fmt.Errorf("%w: %s", ErrInvalidArchiveTable, table)
+		}
+		if !seen[table] {
+			seen[table] = true
+			normalized = append(normalized, table)
+		}
+	}
+	return normalized, nil
+}
+
+// Enqueue arşivleme işini çiftlik adına sıraya alır
+func (s *ArchiveService) Enqueue(farmID string, req models.ArchiveRequest) (models.Job, error) {
+	tables, err := normalizeArchiveTables(req.Tables)
+	if err != nil {
+		return models.Job{}, err
+	}
+	return s.jobs.Enqueue(farmID, models.JobTypeArchive, archiveJobPayload{OlderThanYears: req.OlderThanYears, Tables: tables})
+}
+
+// runJob kuyruktaki arşivleme işini çalıştırır; oluşturulan paketler iş kimliğiyle kaydedilir
+func (s *ArchiveService) runJob(farmID string, job models.Job, payload string) (string, error) {
+	var p archiveJobPayload
+	if err := utils.FromJSON(payload, &p); err != nil {
+		return "", err
+	}
+	_, err := s.ArchiveFarm(farmID, p.Tables, archiveCutoff(time.Now(), p.OlderThanYears), job.ID)
+	return "", err
+}
+
+// RunScheduled ARCHIVE_AFTER_YEARS tanımlıysa tüm çiftliklerin o yaştan eski kayıtlarını arşivler; bir
+// çiftlikteki hata diğerlerini durdurmaz. Gecelik veritabanı bakımından önce çalışır
+func (s *ArchiveService) RunScheduled(now time.Time) error {
+	years := archiveAfterYears()
+	if years == 0 {
+		return nil
+	}
+
+	farmIDs, err := NewRecalculationService(s.db).farmIDs("")
+	if err != nil {
+		return err
+	}
+	cutoff := archiveCutoff(now, years)
+	for _, farmID := range farmIDs {
+		if _, err := s.ArchiveFarm(farmID, ArchiveTables(), cutoff, ""); err != nil {
+			log.Printf("Zamanlanmış arşivleme başarısız (%s): %v", farmID, err)
+		}
+	}
+	return nil
+}
+
+// ArchiveFarm çiftliğin verilen tablolardaki kesim tarihinden eski kayıtlarını tablo başına bir paket olarak
+// arşivler; arşivlenecek kaydı olmayan tablolar için paket oluşturulmaz
+func (s *ArchiveService) ArchiveFarm(farmID string, tables []string, cutoff time.Time, jobID string) ([]models.ArchiveBatch, error) {
+	batches := []models.ArchiveBatch{}
+	for _, table := range tables {
+		source, ok := archiveSourceFor(table)
+		if !ok {
+			return batches, fmt.Errorf("%w: %s", ErrInvalidArchiveTable, table)
+		}
+		batch, err := s.archive(farmID, source, cutoff, jobID)
+		if err != nil {
+			return batches, fmt.Errorf("%s: %w", table, err)
+		}
+		if batch != nil {
+			batches = append(batches, *batch)
+		}
+	}
+	return batches, nil
+}
+
+// archiveScope arşivlenecek satırları seçen FROM ve WHERE ifadesi ile parametreleri
+func (source archiveSource) archiveScope(farmID string, cutoff time.Time) (string, []interface{}) {
+	scope := " FROM " + source.table + " t"
+	if source.parent != "" {
+		scope += " JOIN " + source.parent + " p ON p.id = t." + source.parentKey + " WHERE p.user_id = ?"
+	} else {
+		scope += " WHERE t.user_id = ?"
+	}
+	scope += " AND t." + source.dateColumn + " < ?"
+	for _, condition := range source.conditions {
+		scope += " AND " + condition
+	}
+	for _, ref := range source.references {
+		scope += " AND t.id NOT IN (SELECT " + ref.column + " FROM " + ref.table + " WHERE " + ref.column + " IS NOT NULL"
+		if ref.typeColumn != "" {
+			scope += " AND " + ref.typeColumn + " = '" + ref.typeValue + "'"
+		}
+		scope += ")"
+	}
+	return scope, []interface{}{farmID, cutoff}
+}
+
+// archive tek bir tablonun kesim tarihinden eski kayıtlarını alt kayıtlarıyla birlikte dosyaya yazar, aylık
+// özetlerini kaydeder ve kayıtları tablodan siler. Dosya silme işleminden önce yazılır; veritabanı işlemi
+// başarısız olursa dosya kaldırılır
+func (s *ArchiveService) archive(farmID string, source archiveSource, cutoff time.Time, jobID string) (*models.ArchiveBatch, error) {
+	archiveMu.Lock()
+	defer archiveMu.Unlock()
+
+	tx, err := s.db.Begin()
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	scope, args := source.archiveScope(farmID, cutoff)
+	rows, err := archiveRows(tx, source.table, "SELECT t.*"+scope+" ORDER BY t."+source.dateColumn+", t.rowid", args)
+	if err != nil {
+		return nil, err
+	}
+	if len(rows) == 0 {
+		return nil, nil
+	}
+
+	batch := models.ArchiveBatch{
+		ID:        utils.GenerateID(),
+		Table:     source.table,
+		Cutoff:    cutoff,
+		Records:   map[string]int{source.table: len(rows)},
+		Status:    models.ArchiveStatusArchived,
+		CreatedAt: time.Now().UTC(),
+	}
+	if jobID != "" {
+		batch.JobID = &jobID
+	}
+	file := archiveFile{
+		Format:    archiveFormat,
+		Version:   archiveFormatVersion,
+		FarmID:    farmID,
+		BatchID:   batch.ID,
+		Table:     source.table,
+		Cutoff:    cutoff,
+		CreatedAt: batch.CreatedAt,
+		Tables:    map[string][]map[string]interface{}{source.table: rows},
+	}
+
+	for _, child := range source.children {
+		childRows, err := archiveRows(tx, child.table, "SELECT * FROM "+child.table+" WHERE "+child.key+" IN (SELECT t.id"+scope+")", args)
+		if err != nil {
+			return nil, err
+		}
+		file.Tables[child.table] = childRows
+		if len(childRows) > 0 {
+			batch.Records[child.table] = len(childRows)
+		}
+	}
+
+	var periodStart, periodEnd sql.NullString
+	if err := tx.QueryRow("SELECT MIN(t."+source.dateColumn+"), MAX(t."+source.dateColumn+")"+scope, args...).Scan(&periodStart, &periodEnd); err != nil {
+		return nil, err
+	}
+	batch.PeriodStart = archiveTime(periodStart)
+	batch.PeriodEnd = archiveTime(periodEnd)
+
+	aggregates, err := archiveAggregates(tx, source, scope, args)
+	if err != nil {
+		return nil, err
+	}
+
+	var buf bytes.Buffer
+	zw := gzip.NewWriter(&buf)
+	if err := json.NewEncoder(zw).Encode(file); err != nil {
+		return nil, err
+	}
+	if err := zw.Close(); err != nil {
+		return nil, err
+	}
+	batch.SizeBytes = int64(buf.Len())
+
+	objectKey := archiveObjectKey(farmID, batch.ID)
+	if err := s.store.Put(objectKey, buf.Bytes()); err != nil {
+		return nil, err
+	}
+
+	err = func() error {
+		records, err := utils.ToJSON(batch.Records)
+		if err != nil {
+			return err
+		}
+		if _, err := tx.Exec(`
+			INSERT INTO archive_batches (id, user_id, job_id, table_name, cutoff, period_start, period_end, records,
+				size_bytes, object_key, status, created_at)
+			VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
+		`, batch.ID, farmID, batch.JobID, batch.Table, batch.Cutoff, batch.PeriodStart, batch.PeriodEnd, records,
+			batch.SizeBytes, objectKey, batch.Status, batch.CreatedAt); err != nil {
+			return err
+		}
+		for _, aggregate := range aggregates {
+			if _, err := tx.Exec(`
+				INSERT INTO archive_aggregates (batch_id, user_id, table_name, month, group_key, subgroup_key, currency,
+					record_count, total)
+				VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?)
+			`, batch.ID, farmID, source.table, aggregate.Month, aggregate.Group, aggregate.Subgroup, aggregate.Currency,
+				aggregate.Count, aggregate.Total); err != nil {
+				return err
+			}
+		}
+
+		for _, child := range source.children {
+			if _, err := tx.Exec("DELETE FROM "+child.table+" WHERE "+child.key+" IN (SELECT t.id"+scope+")", args...); err != nil {
+				return err
+			}
+		}
+		if _, err := tx.Exec("DELETE FROM "+source.table+" WHERE id IN (SELECT t.id"+scope+")", args...); err != nil {
+			return err
+		}
+		return tx.Commit()
+	}()
+	if err != nil {
+		if deleteErr := s.store.Delete(objectKey); deleteErr != nil {
+			log.Printf("Arşiv dosyası silinemedi (%s): %v", objectKey, deleteErr)
+		}
+		return nil, err
+	}
+	return &batch, nil
+}
+
+// archiveRows sorgunun döndürdüğü satırları sütun adlarıyla okur
+func archiveRows(tx *sql.Tx, table, query string, args []interface{}) ([]map[string]interface{}, error) {
+	rows, err := tx.Query(query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", table, err)
+	}
+	defer rows.Close()
+
+	columns, err := rows.Columns()
+	if err != nil {
+		return nil, err
+	}
+	result := []map[string]interface{}{}
+	for rows.Next() {
+		values := make([]interface{}, len(columns))
+		pointers := make([]interface{}, len(columns))
+		for i := range values {
+			pointers[i] = &values[i]
+		}
+		if err := rows.Scan(pointers...); err != nil {
+			return nil, fmt.Errorf("%s: %w", table, err)
+		}
+		row := make(map[string]interface{}, len(columns))
+		for i, column := range columns {
+			if raw, ok := values[i].([]byte); ok {
+				values[i] = string(raw)
+			}
+			row[column] = values[i]
+		}
+		result = append(result, row)
+	}
+	return result, rows.Err()
+}
+
+// archiveAggregates arşivlenecek satırların aylık özetini hesaplar
+func archiveAggregates(tx *sql.Tx, source archiveSource, scope string, args []interface{}) ([]models.ArchiveAggregate, error) {
+	query := "SELECT strftime('%Y-%m', t." + source.dateColumn + "), " + source.aggregate[0] + ", " + source.aggregate[1] +
+		", " + source.aggregate[2] + ", COUNT(*), COALESCE(SUM(" + source.aggregate[3] + "), 0)" + scope
+	if source.aggregateFilter != "" {
+		query += " AND " + source.aggregateFilter
+	}
+	rows, err := tx.Query(query+" GROUP BY 1, 2, 3, 4", args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var aggregates []models.ArchiveAggregate
+	for rows.Next() {
+		aggregate := models.ArchiveAggregate{Table: source.table}
+		var month sql.NullString
+		if err := rows.Scan(&month, &aggregate.Group, &aggregate.Subgroup, &aggregate.Currency, &aggregate.Count,
+			&aggregate.Total); err != nil {
+			return nil, err
+		}
+		aggregate.Month = month.String
+		aggregates = append(aggregates, aggregate)
+	}
+	return aggregates, rows.Err()
+}
+
+// archiveTime SQLite'ın döndürdüğü tarih metnini zamana çevirir
+func archiveTime(value sql.NullString) *time.Time {
+	if !value.Valid {
+		return nil
+	}
+	for _, layout := range []string{"2006-01-02 15:04:05.999999999-07:00", "2006-01-02T15:04:05.999999999Z07:00", "2006-01-02 15:04:05", "2006-01-02"} {
+		if t, err := time.Parse(layout, value.String); err == nil {
+			t = t.UTC()
+			return &t
+		}
+	}
+	return nil
+}
+
+// archiveObjectKey arşiv dosyasının depolamadaki anahtarı; yedeklerden ayrı önektedir
+func archiveObjectKey(farmID, id string) string {
+	return "archives/" + farmID + "/" + id + ".json.gz"
+}
+
+// Batches çiftliğin arşiv paketlerini yeniden eskiye listeler; table boş değilse o tabloyla sınırlanır
+func (s *ArchiveService) Batches(farmID, table string) ([]models.ArchiveBatch, error) {
+	query := archiveBatchSelect + " WHERE user_id = ?"
+	args := []interface{}{farmID}
+	if table != "" {
+		if _, ok := archiveSourceFor(table); !ok {
+			return nil, fmt.Errorf("%w: %s", ErrInvalidArchiveTable, table)
+		}
+		query += " AND table_name = ?"
+		args = append(args, table)
+	}
+	return s.queryBatches(query+" ORDER BY created_at DESC, rowid DESC", args...)
+}
+
+// JobBatches arşivleme işinin oluşturduğu paketler
+func (s *ArchiveService) JobBatches(farmID, jobID string) ([]models.ArchiveBatch, error) {
+	return s.queryBatches(archiveBatchSelect+" WHERE user_id = ? AND job_id = ? ORDER BY rowid", farmID, jobID)
+}
+
+// Job çiftliğin arşivleme işini oluşturduğu paketlerle döner
+func (s *ArchiveService) Job(farmID, id string) (models.ArchiveJob, error) {
+	job, err := s.jobs.Job(farmID, id)
+	if err == nil && job.Type != models.JobTypeArchive {
+		err = ErrJobNotFound
+	}
+	if err != nil {
+		return models.ArchiveJob{}, err
+	}
+
+	archiveJob := models.ArchiveJob{Job: job}
+	if job.Status == models.JobStatusCompleted || job.Status == models.JobStatusFailed {
+		if archiveJob.Batches, err = s.JobBatches(farmID, id); err != nil {
+			return archiveJob, err
+		}
+	}
+	return archiveJob, nil
+}
+
+// queryBatches paket satırlarını okur
+func (s *ArchiveService) queryBatches(query string, args ...interface{}) ([]models.ArchiveBatch, error) {
+	rows, err := s.db.Query(query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	batches := []models.ArchiveBatch{}
+	for rows.Next() {
+		batch, err := scanArchiveBatch(rows)
+		if err != nil {
+			return nil, err
+		}
+		batches = append(batches, batch)
+	}
+	return batches, rows.Err()
+}
+
+// Batch çiftliğin arşiv paketini döner
+func (s *ArchiveService) Batch(farmID, id string) (models.ArchiveBatch, error) {
+	batch, err := scanArchiveBatch(s.db.QueryRow(archiveBatchSelect+" WHERE id = ? AND user_id = ?", id, farmID))
+	if err == sql.ErrNoRows {
+		return batch, ErrArchiveNotFound
+	}
+	return batch, err
+}
+
+// scanArchiveBatch paket satırını okur
+func scanArchiveBatch(row interface{ Scan(...interface{}) error }) (models.ArchiveBatch, error) {
+	var batch models.ArchiveBatch
+	var jobID sql.NullString
+	var periodStart, periodEnd, restoredAt sql.NullTime
+	var records string
+	err := row.Scan(&batch.ID, &jobID, &batch.Table, &batch.Cutoff, &periodStart, &periodEnd, &records,
+		&batch.SizeBytes, &batch.Status, &batch.CreatedAt, &restoredAt)
+	if err != nil {
+		return batch, err
+	}
+	batch.JobID = utils.NullStringToPtr(jobID)
+	batch.PeriodStart = utils.NullTimeToPtr(periodStart)
+	batch.PeriodEnd = utils.NullTimeToPtr(periodEnd)
+	batch.RestoredAt = utils.NullTimeToPtr(restoredAt)
+	batch.Records = map[string]int{}
+	utils.FromJSON(records, &batch.Records)
+	return batch, nil
+}
+
+// Aggregates arşivde duran kayıtların aylık özetlerini paketler arasında birleştirerek döner; geri yüklenen
+// paketlerin özetleri dahil edilmez
+func (s *ArchiveService) Aggregates(farmID string, filter models.ArchiveAggregateFilter) ([]models.ArchiveAggregate, error) {
+	query := `
+		SELECT table_name, month, group_key, subgroup_key, currency, SUM(record_count), SUM(total)
+		FROM archive_aggregates WHERE user_id = ?`
+	args := []interface{}{farmID}
+	if filter.Table != "" {
+		if _, ok := archiveSourceFor(filter.Table); !ok {
+			return nil, fmt.Errorf("%w: %s", ErrInvalidArchiveTable, filter.Table)
+		}
+		query += " AND table_name = ?"
+		args = append(args, filter.Table)
+	}
+	if filter.StartMonth != "" {
+		query += " AND month >= ?"
+		args = append(args, filter.StartMonth)
+	}
+	if filter.EndMonth != "" {
+		query += " AND month <= ?"
+		args = append(args, filter.EndMonth)
+	}
+
+	rows, err := s.db.Query(query+" GROUP BY table_name, month, group_key, subgroup_key, currency ORDER BY table_name, month, group_key, subgroup_key", args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	aggregates := []models.ArchiveAggregate{}
+	for rows.Next() {
+		var aggregate models.ArchiveAggregate
+		if err := rows.Scan(&aggregate.Table, &aggregate.Month, &aggregate.Group, &aggregate.Subgroup,
+			&aggregate.Currency, &aggregate.Count, &aggregate.Total); err != nil {
+			return nil, err
+		}
+		aggregate.Total = round2(aggregate.Total)
+		aggregates = append(aggregates, aggregate)
+	}
+	return aggregates, rows.Err()
+}
+
+// Records paketin ana tablo kayıtlarını soğuk depolamadaki dosyadan okuyup sayfalı döner
+func (s *ArchiveService) Records(farmID, id string, page, limit int) (models.ArchiveRecordsResponse, error) {
+	response := models.ArchiveRecordsResponse{Records: []map[string]interface{}{}}
+	batch, file, err := s.open(farmID, id)
+	response.Batch = batch
+	if err != nil {
+		return response, err
+	}
+
+	rows := file.Tables[batch.Table]
+	start := (page - 1) * limit
+	if start < len(rows) {
+		end := start + limit
+		if end > len(rows) {
+			end = len(rows)
+		}
+		response.Records = rows[start:end]
+	}
+	response.Pagination = utils.CalculatePagination(page, limit, len(rows))
+	return response, nil
+}
+
+// open paketi ve arşiv dosyasını okur; geri yüklenmiş paketin dosyası silindiğinden ErrArchiveRestored döner
+func (s *ArchiveService) open(farmID, id string) (models.ArchiveBatch, archiveFile, error) {
+	var file archiveFile
+	batch, err := s.Batch(farmID, id)
+	if err != nil {
+		return batch, file, err
+	}
+	if batch.Status == models.ArchiveStatusRestored {
+		return batch, file, ErrArchiveRestored
+	}
+
+	var objectKey string
+	if err := s.db.QueryRow("SELECT object_key FROM archive_batches WHERE id = ? AND user_id = ?", id, farmID).Scan(&objectKey); err != nil {
+		return batch, file, err
+	}
+	reader, err := s.store.Open(objectKey)
+	if err != nil {
+		return batch, file, err
+	}
+	defer reader.Close()
+
+	file, err = readArchiveFile(reader)
+	if err != nil {
+		return batch, file, err
+	}
+	if file.FarmID != farmID || file.BatchID != batch.ID {
+		return batch, file, ErrInvalidArchiveFile
+	}
+	return batch, file, nil
+}
+
+// readArchiveFile sıkıştırılmış arşiv dosyasını okur ve biçimini doğrular
+func readArchiveFile(r io.Reader) (archiveFile, error) {
+	var file archiveFile
+	zr, err := gzip.NewReader(r)
+	if err != nil {
+		return file, ErrInvalidArchiveFile
+	}
+	defer zr.Close()
+
+	decoder := json.NewDecoder(zr)
+	decoder.UseNumber()
+	if err := decoder.Decode(&file); err != nil {
+		return file, ErrInvalidArchiveFile
+	}
+	if file.Format != archiveFormat || file.Version < 1 || file.Version > archiveFormatVersion {
+		return file, ErrInvalidArchiveFile
+	}
+	return file, nil
+}
+
+// Restore paketin kayıtlarını alt kayıtlarıyla birlikte tablolara geri yazar, paketi geri yüklendi olarak
+// işaretler, özetlerini siler ve dosyayı depolamadan kaldırır. Tabloda zaten bulunan kayıtlar ve bağlı olduğu
+// üst kaydı (ör. hayvan) artık çiftlikte olmayan kayıtlar atlanır
+func (s *ArchiveService) Restore(farmID, id string) (models.ArchiveRestoreResult, error) {
+	archiveMu.Lock()
+	defer archiveMu.Unlock()
+
+	result := models.ArchiveRestoreResult{Restored: map[string]int{}}
+	batch, file, err := s.open(farmID, id)
+	result.Batch = batch
+	if err != nil {
+		return result, err
+	}
+	source, ok := archiveSourceFor(batch.Table)
+	if !ok {
+		return result, fmt.Errorf("%w: %s", ErrInvalidArchiveTable, batch.Table)
+	}
+
+	tx, err := s.db.Begin()
+	if err != nil {
+		return result, err
+	}
+	defer tx.Rollback()
+
+	restored, skipped, err := restoreArchiveRows(tx, source.table, file.Tables[source.table], func(row map[string]interface{}) (bool, error) {
+		if source.parent == "" {
+			row["user_id"] = farmID
+			return true, nil
+		}
+		var exists int
+		err := tx.QueryRow("SELECT 1 FROM "+source.parent+" WHERE id = ? AND user_id = ?", row[source.parentKey], farmID).Scan(&exists)
+		if err == sql.ErrNoRows {
+			return false, nil
+		}
+		return err == nil, err
+	})
+	if err != nil {
+		return result, fmt.Errorf("%s: %w", source.table, err)
+	}
+	result.Restored[source.table] = restored
+	result.Skipped = skipped
+
+	for _, child := range source.children {
+		restored, _, err := restoreArchiveRows(tx, child.table, file.Tables[child.table], func(row map[string]interface{}) (bool, error) {
+			var exists int
+			err := tx.QueryRow(source.restoredParentQuery(), row[child.key], farmID).Scan(&exists)
+			if err == sql.ErrNoRows {
+				return false, nil
+			}
+			return err == nil, err
+		})
+		if err != nil {
+			return result, fmt.Errorf("%s: %w", child.table, err)
+		}
+		if restored > 0 {
+			result.Restored[child.table] = restored
+		}
+	}
+
+	now := time.Now().UTC()
+	if _, err := tx.Exec("DELETE FROM archive_aggregates WHERE batch_id = ? AND user_id = ?", batch.ID, farmID); err != nil {
+		return result, err
+	}
+	if _, err := tx.Exec(`
+		UPDATE archive_batches SET status = ?, restored_at = ? WHERE id = ? AND user_id = ?
+	`, models.ArchiveStatusRestored, now, batch.ID, farmID); err != nil {
+		return result, err
+	}
+	if err := tx.Commit(); err != nil {
+		return result, err
+	}
+
+	if err := s.store.Delete(archiveObjectKey(farmID, batch.ID)); err != nil && !errors.Is(err, fs.ErrNotExist) {
+		log.Printf("Geri yüklenen arşiv dosyası silinemedi (%s): %v", batch.ID, err)
+	}
+	result.Batch.Status = models.ArchiveStatusRestored
+	result.Batch.RestoredAt = &now
+	return result, nil
+}
+
+// restoredParentQuery alt kaydın bağlı olduğu ana kaydın çiftlikte bulunup bulunmadığını sorgular
+func (source archiveSource) restoredParentQuery() string {
+	if source.parent != "" {
+		return "SELECT 1 FROM " + source.table + " t JOIN " + source.parent + " p ON p.id = t." + source.parentKey +
+			" WHERE t.id = ? AND p.user_id = ?"
+	}
+	return "SELECT 1 FROM " + source.table + " WHERE id = ? AND user_id = ?"
+}
+
+// restoreArchiveRows satırları tabloya yazar; yalnızca tabloda bulunan sütunlar kullanılır, aynı kimlikli
+// kayıtlar ve accept'in reddettiği satırlar atlanır
+func restoreArchiveRows(tx *sql.Tx, table string, rows []map[string]interface{}, accept func(map[string]interface{}) (bool, error)) (int, int, error) {
+	if len(rows) == 0 {
+		return 0, 0, nil
+	}
+	columns, err := tableColumns(tx, table)
+	if err != nil {
+		return 0, 0, err
+	}
+
+	restored, skipped := 0, 0
+	for _, row := range rows {
+		ok, err := accept(row)
+		if err != nil {
+			return restored, skipped, err
+		}
+		if !ok {
+			skipped++
+			continue
+		}
+
+		var names []string
+		var values []interface{}
+		for _, column := range columns {
+			if value, ok := row[column]; ok {
+				names = append(names, column)
+				values = append(values, backupValue(value))
+			}
+		}
+		placeholders := strings.TrimSuffix(strings.Repeat("?, ", len(names)), ", ")
+		res, err := tx.Exec(`INSERT OR IGNORE INTO `+table+` ("`+strings.Join(names, `", "`)+`") VALUES (`+placeholders+`)`, values...)
+		if err != nil {
+			return restored, skipped, err
+		}
+		if n, _ := res.RowsAffected(); n == 0 {
+			skipped++
+			continue
+		}
+		restored++
+	}
+	return restored, skipped, nil
+}
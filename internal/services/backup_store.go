@@ -0,0 +1,384 @@
+package services
+
+import (
+	"bytes"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+)
+
+// BackupObject depolamadaki bir yedek dosyası
+type BackupObject struct {
+	Key          string
+	Size         int64
+	LastModified time.Time
+}
+
+// BackupStore yedek dosyalarının saklandığı depolama arayüzü. Bulunamayan dosyalar için
+// dönen hata fs.ErrNotExist'i sarar
+type BackupStore interface {
+	Name() string
+	Put(key string, data []byte) error
+	Open(key string) (io.ReadCloser, error)
+	Delete(key string) error
+	List(prefix string) ([]BackupObject, error)
+}
+
+// NewBackupStore BACKUP_S3_BUCKET tanımlıysa S3 uyumlu nesne depolamasını (AWS S3, MinIO), değilse
+// BACKUP_DIR yerel dizinini kullanan depolama oluşturur
+func NewBackupStore() BackupStore {
+	if bucket := os.Getenv("BACKUP_S3_BUCKET"); bucket != "" {
+		region := os.Getenv("BACKUP_S3_REGION")
+		if region == "" {
+			region = "us-east-1"
+		}
+		endpoint := os.Getenv("BACKUP_S3_ENDPOINT")
+		if endpoint == "" {
+			endpoint = "https://s3." + region + ".amazonaws.com"
+		}
+		return &S3BackupStore{
+			endpoint:  strings.TrimRight(endpoint, "/"),
+			bucket:    bucket,
+			region:    region,
+			accessKey: os.Getenv("BACKUP_S3_ACCESS_KEY"),
+			secretKey: os.Getenv("BACKUP_S3_SECRET_KEY"),
+			pathStyle: os.Getenv("BACKUP_S3_PATH_STYLE") != "false",
+			client:    &http.Client{Timeout: 5 * time.Minute},
+		}
+	}
+
+	root := os.Getenv("BACKUP_DIR")
+	if root == "" {
+		root = "./backups"
+	}
+	return &LocalBackupStore{root: root}
+}
+
+// LocalBackupStore yedekleri yerel dizinde saklar
+type LocalBackupStore struct {
+	root string
+}
+
+// Name depolama türü
+func (s *LocalBackupStore) Name() string {
+	return "local"
+}
+
+// Put yedeği verilen anahtarla kaydeder; yarım kalan yazımlar geçici dosyada kalır
+func (s *LocalBackupStore) Put(key string, data []byte) error {
+	path, err := s.path(key)
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0o700); err != nil {
+		return err
+	}
+
+	tmp := path + ".tmp"
+	if err := os.WriteFile(tmp, data, 0o600); err != nil {
+		return err
+	}
+	return os.Rename(tmp, path)
+}
+
+// Open yedeği okumak için açar
+func (s *LocalBackupStore) Open(key string) (io.ReadCloser, error) {
+	path, err := s.path(key)
+	if err != nil {
+		return nil, err
+	}
+	return os.Open(path)
+}
+
+// Delete yedeği siler
+func (s *LocalBackupStore) Delete(key string) error {
+	path, err := s.path(key)
+	if err != nil {
+		return err
+	}
+	if err := os.Remove(path); err != nil && !os.IsNotExist(err) {
+		return err
+	}
+	return nil
+}
+
+// List önekle başlayan yedekleri listeler
+func (s *LocalBackupStore) List(prefix string) ([]BackupObject, error) {
+	dir, err := s.path(prefix)
+	if err != nil {
+		return nil, err
+	}
+
+	entries, err := os.ReadDir(dir)
+	if os.IsNotExist(err) {
+		return []BackupObject{}, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	objects := []BackupObject{}
+	for _, entry := range entries {
+		if entry.IsDir() || strings.HasSuffix(entry.Name(), ".tmp") {
+			continue
+		}
+		info, err := entry.Info()
+		if err != nil {
+			continue
+		}
+		objects = append(objects, BackupObject{
+			Key:          strings.TrimSuffix(prefix, "/") + "/" + entry.Name(),
+			Size:         info.Size(),
+			LastModified: info.ModTime().UTC(),
+		})
+	}
+	return objects, nil
+}
+
+// path anahtarı kök dizin altında güvenli bir dosya yoluna çevirir
+func (s *LocalBackupStore) path(key string) (string, error) {
+	path, ok := storePath(s.root, key)
+	if !ok {
+		return "", ErrInvalidBackupKey
+	}
+	return path, nil
+}
+
+// S3BackupStore yedekleri S3 uyumlu nesne depolamasında saklar; istekler AWS Signature V4 ile imzalanır.
+// MinIO gibi sunucular için yol tarzı adresleme (endpoint/bucket/key) varsayılandır
+type S3BackupStore struct {
+	endpoint  string
+	bucket    string
+	region    string
+	accessKey string
+	secretKey string
+	pathStyle bool
+	client    *http.Client
+}
+
+// s3EmptyPayloadHash gövdesiz isteklerin SHA-256 özeti
+const s3EmptyPayloadHash = "e3b0c44298fc1c149afbf4c8996fb92427ae41e4649b934ca495991b7852b855"
+
+// Name depolama türü
+func (s *S3BackupStore) Name() string {
+	return "s3"
+}
+
+// Put yedeği nesne olarak yükler
+func (s *S3BackupStore) Put(key string, data []byte) error {
+	resp, err := s.do(http.MethodPut, key, nil, data)
+	if err != nil {
+		return err
+	}
+	resp.Body.Close()
+	return nil
+}
+
+// Open nesneyi okumak için açar
+func (s *S3BackupStore) Open(key string) (io.ReadCloser, error) {
+	resp, err := s.do(http.MethodGet, key, nil, nil)
+	if err != nil {
+		return nil, err
+	}
+	return resp.Body, nil
+}
+
+// Delete nesneyi siler; olmayan nesne hata sayılmaz
+func (s *S3BackupStore) Delete(key string) error {
+	resp, err := s.do(http.MethodDelete, key, nil, nil)
+	if err != nil && !isNotExist(err) {
+		return err
+	}
+	if resp != nil {
+		resp.Body.Close()
+	}
+	return nil
+}
+
+// List önekle başlayan nesneleri ListObjectsV2 ile sayfa sayfa listeler
+func (s *S3BackupStore) List(prefix string) ([]BackupObject, error) {
+	objects := []BackupObject{}
+	token := ""
+	for {
+		query := url.Values{"list-type": {"2"}, "prefix": {prefix}}
+		if token != "" {
+			query.Set("continuation-token", token)
+		}
+
+		resp, err := s.do(http.MethodGet, "", query, nil)
+		if err != nil {
+			return nil, err
+		}
+		var result struct {
+			Contents []struct {
+				Key          string    `xml:"Key"`
+				Size         int64     `xml:"Size"`
+				LastModified time.Time `xml:"LastModified"`
+			} `xml:"Contents"`
+			IsTruncated           bool   `xml:"IsTruncated"`
+			NextContinuationToken string `xml:"NextContinuationToken"`
+		}
+		err = xml.NewDecoder(resp.Body).Decode(&result)
+		resp.Body.Close()
+		if err != nil {
+			return nil, fmt.Errorf("s3 listesi okunamadı: %w", err)
+		}
+
+		for _, item := range result.Contents {
+			objects = append(objects, BackupObject{Key: item.Key, Size: item.Size, LastModified: item.LastModified.UTC()})
+		}
+		if !result.IsTruncated || result.NextContinuationToken == "" {
+			break
+		}
+		token = result.NextContinuationToken
+	}
+	return objects, nil
+}
+
+// do imzalı isteği gönderir; 2xx dışındaki yanıtları hataya çevirir, 404 fs.ErrNotExist'i sarar
+func (s *S3BackupStore) do(method, key string, query url.Values, body []byte) (*http.Response, error) {
+	target, err := url.Parse(s.endpoint)
+	if err != nil {
+		return nil, fmt.Errorf("geçersiz BACKUP_S3_ENDPOINT: %w", err)
+	}
+
+	path := strings.TrimRight(target.Path, "/")
+	if s.pathStyle {
+		path += "/" + s.bucket
+	} else {
+		target.Host = s.bucket + "." + target.Host
+	}
+	if key != "" || !s.pathStyle {
+		path += "/" + key
+	}
+	target.Path = path
+	target.RawPath = s3EscapePath(path)
+	target.RawQuery = s3CanonicalQuery(query)
+
+	req, err := http.NewRequest(method, target.String(), bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+	payloadHash := s3EmptyPayloadHash
+	if body != nil {
+		sum := sha256.Sum256(body)
+		payloadHash = hex.EncodeToString(sum[:])
+		req.ContentLength = int64(len(body))
+	}
+	s.sign(req, payloadHash, time.Now())
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode >= 200 && resp.StatusCode < 300 {
+		return resp, nil
+	}
+
+	defer resp.Body.Close()
+	var s3Err struct {
+		Code    string `xml:"Code"`
+		Message string `xml:"Message"`
+	}
+	raw, _ := io.ReadAll(io.LimitReader(resp.Body, 4096))
+	xml.Unmarshal(raw, &s3Err)
+	if resp.StatusCode == http.StatusNotFound {
+		return nil, fmt.Errorf("s3 %s %s: %w", method, key, fs.ErrNotExist)
+	}
+	return nil, fmt.Errorf("s3 %s %s: %s %s %s", method, key, resp.Status, s3Err.Code, s3Err.Message)
+}
+
+// sign isteğe AWS Signature V4 yetkilendirme başlıklarını ekler
+func (s *S3BackupStore) sign(req *http.Request, payloadHash string, now time.Time) {
+	amzDate := now.UTC().Format("20060102T150405Z")
+	day := amzDate[:8]
+	req.Header.Set("x-amz-date", amzDate)
+	req.Header.Set("x-amz-content-sha256", payloadHash)
+
+	signedHeaders := "host;x-amz-content-sha256;x-amz-date"
+	canonicalRequest := strings.Join([]string{
+		req.Method,
+		req.URL.EscapedPath(),
+		req.URL.RawQuery,
+		"host:" + req.URL.Host + "\nx-amz-content-sha256:" + payloadHash + "\nx-amz-date:" + amzDate + "\n",
+		signedHeaders,
+		payloadHash,
+	}, "\n")
+
+	scope := day + "/" + s.region + "/s3/aws4_request"
+	requestHash := sha256.Sum256([]byte(canonicalRequest))
+	stringToSign := "AWS4-HMAC-SHA256\n" + amzDate + "\n" + scope + "\n" + hex.EncodeToString(requestHash[:])
+
+	key := hmacSHA256([]byte("AWS4"+s.secretKey), day)
+	key = hmacSHA256(key, s.region)
+	key = hmacSHA256(key, "s3")
+	key = hmacSHA256(key, "aws4_request")
+	signature := hex.EncodeToString(hmacSHA256(key, stringToSign))
+
+	req.Header.Set("Authorization", fmt.Sprintf("AWS4-HMAC-SHA256 Credential=%s/%s, SignedHeaders=%s, Signature=%s",
+		s.accessKey, scope, signedHeaders, signature))
+}
+
+// hmacSHA256 anahtarlı özet üretir
+func hmacSHA256(key []byte, data string) []byte {
+	mac := hmac.New(sha256.New, key)
+	mac.Write([]byte(data))
+	return mac.Sum(nil)
+}
+
+// s3EscapePath yolu SigV4 kurallarına göre kodlar: ayrılmamış karakterler ve / dışındaki her bayt %XX olur
+func s3EscapePath(path string) string {
+	var b strings.Builder
+	for i := 0; i < len(path); i++ {
+		c := path[i]
+		if c == '/' || c == '-' || c == '_' || c == '.' || c == '~' ||
+			('a' <= c && c <= 'z') || ('A' <= c && c <= 'Z') || ('0' <= c && c <= '9') {
+			b.WriteByte(c)
+			continue
+		}
+		fmt.Fprintf(&b, "%%%02X", c)
+	}
+	return b.String()
+}
+
+// s3CanonicalQuery sorgu parametrelerini anahtara göre sıralı ve SigV4 kurallarına göre kodlanmış yazar
+func s3CanonicalQuery(query url.Values) string {
+	if len(query) == 0 {
+		return ""
+	}
+	keys := make([]string, 0, len(query))
+	for key := range query {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	parts := make([]string, 0, len(keys))
+	for _, key := range keys {
+		for _, value := range query[key] {
+			parts = append(parts, s3EscapeQuery(key)+"="+s3EscapeQuery(value))
+		}
+	}
+	return strings.Join(parts, "&")
+}
+
+// s3EscapeQuery sorgu anahtarı veya değerini kodlar; yoldan farklı olarak / de kodlanır
+func s3EscapeQuery(value string) string {
+	return strings.ReplaceAll(s3EscapePath(value), "/", "%2F")
+}
+
+// isNotExist hatanın bulunamayan dosya veya nesne olup olmadığını döner
+func isNotExist(err error) bool {
+	return errors.Is(err, fs.ErrNotExist)
+}
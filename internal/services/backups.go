@@ -0,0 +1,949 @@
+package services
+
+import (
+	"bytes"
+	"compress/gzip"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"agri-management-api/internal/models"
+	"agri-management-api/internal/utils"
+)
+
+// backupFormat yedek dosyalarının biçim adı ve sürümü
+const (
+	backupFormat        = "agri-backup"
+	backupFormatVersion = 1
+)
+
+// backupTable yedeğe alınan tablo. parent doluysa tablonun user_id sütunu yoktur; satırlar parentKey
+// üzerinden üst tablonun user_id sütunuyla çiftliğe bağlanır
+type backupTable struct {
+	name      string
+	parent    string
+	parentKey string
+}
+
+// backupGroup geri yüklemede birlikte seçilen tablolar; üst tablolar alt tablolarından önce gelir
+type backupGroup struct {
+	key    string
+	label  string
+	tables []backupTable
+}
+
+// backupGroups yedeğe alınan veri grupları. Dosyası diskte duran dokümanlar ve fotoğraflar, bildirimler,
+// türetilmiş metrikler ve entegrasyon anahtarları yedeğe dahil edilmez
+var backupGroups = []backupGroup{
+	{key: "lands", label: "Arazi Verileri", tables: []backupTable{
+		{name: "lands"},
+		{name: "land_activities", parent: "lands", parentKey: "land_id"},
+		{name: "crop_plans"},
+		{name: "land_activity_cost_items"},
+		{name: "harvest_crew_entries"},
+		{name: "workers"},
+		{name: "worker_certifications"},
+		{name: "water_quotas"},
+		{name: "weather_observations"},
+		{name: "weather_stations"},
+		{name: "weather_station_readings"},
+		{name: "utility_meters"},
+		{name: "meter_readings"},
+		{name: "greenhouses"},
+		{name: "climate_sensors"},
+		{name: "climate_readings"},
+		{name: "climate_alerts"},
+		{name: "pest_disease_observations"},
+		{name: "scouting_sessions"},
+		{name: "scouting_points"},
+	}},
+	{key: "livestock", label: "Hayvan Kayıtları", tables: []backupTable{
+		{name: "livestock"},
+		{name: "health_records", parent: "livestock", parentKey: "livestock_id"},
+		{name: "vaccinations", parent: "livestock", parentKey: "livestock_id"},
+		{name: "breeding_records", parent: "livestock", parentKey: "livestock_id"},
+		{name: "weight_records", parent: "livestock", parentKey: "livestock_id"},
+		{name: "milk_production", parent: "livestock", parentKey: "livestock_id"},
+		{name: "livestock_movements"},
+		{name: "livestock_costs"},
+		{name: "slaughter_records"},
+		{name: "treatment_protocols"},
+	}},
+	{key: "production", label: "Üretim Bilgileri", tables: []backupTable{
+		{name: "production"},
+		{name: "production_sales"},
+		{name: "customers"},
+		{name: "sales_orders"},
+		{name: "production_losses"},
+		{name: "hives"},
+		{name: "hive_inspections"},
+		{name: "hive_harvests"},
+		{name: "hive_treatments"},
+		{name: "ponds"},
+		{name: "fish_batches"},
+		{name: "fish_batch_records"},
+	}},
+	{key: "finance", label: "Finansal İşlemler", tables: []backupTable{
+		{name: "transactions"},
+		{name: "transaction_tags"},
+		{name: "invoices"},
+		{name: "invoice_transactions"},
+		{name: "categories"},
+		{name: "bank_accounts"},
+		{name: "bank_statements"},
+		{name: "bank_statement_lines"},
+		{name: "fixed_assets"},
+		{name: "depreciation_postings"},
+		{name: "cost_allocation_rules"},
+		{name: "cost_allocations"},
+		{name: "enterprises"},
+		{name: "market_prices"},
+	}},
+	{key: "other", label: "Takvim Etkinlikleri ve Diğer Kayıtlar", tables: []backupTable{
+		{name: "events"},
+		{name: "generated_events"},
+		{name: "activity_templates"},
+		{name: "entity_notes"},
+		{name: "record_links"},
+		{name: "entity_changes"},
+		{name: "saved_views"},
+		{name: "compliance_checklists"},
+		{name: "compliance_statuses"},
+		{name: "carbon_footprints"},
+	}},
+}
+
+// backupArchive yedek dosyasının içeriği; dosya gzip ile sıkıştırılmış JSON olarak saklanır
+type backupArchive struct {
+	Format    string                              `json:"format"`
+	Version   int                                 `json:"version"`
+	FarmID    string                              `json:"farmId"`
+	CreatedAt time.Time                           `json:"createdAt"`
+	Tables    map[string][]map[string]interface{} `json:"tables"`
+}
+
+var (
+	// ErrBackupNotFound yedek bulunamadığında döner
+	ErrBackupNotFound = errors.New("backup not found")
+	// ErrInvalidBackup yedek dosyası okunamadığında veya başka bir çiftliğe ait olduğunda döner
+	ErrInvalidBackup = errors.New("invalid backup file")
+	// ErrInvalidBackupKey depolama anahtarı boş olduğunda veya depolama dizininin dışına çıktığında döner
+	ErrInvalidBackupKey = errors.New("invalid backup key")
+	// ErrNoRestoreGroups geri yükleme için veri grubu seçilmediğinde döner
+	ErrNoRestoreGroups = errors.New("no restore groups selected")
+)
+
+// backupMu yedekleme ve geri yüklemeleri sıraya koyar; geri yükleme sırasında alınan yedek yarım kalmaz
+var backupMu sync.Mutex
+
+// backupScheduleSlack saatlik kontrolün yedekleme zamanını her döngüde bir saat kaydırmaması için tanınan pay
+const backupScheduleSlack = 30 * time.Minute
+
+// BackupService çiftlik verilerini yedekler, zamanlanmış yedekleri alır, saklama kurallarını uygular
+// ve yedekten geri yükler
+type BackupService struct {
+	db            *sql.DB
+	store         BackupStore
+	farms         *FarmService
+	notifications *NotificationService
+}
+
+// NewBackupService yeni backup service oluşturur
+func NewBackupService(db *sql.DB) *BackupService {
+	return &BackupService{
+		db:            db,
+		store:         NewBackupStore(),
+		farms:         NewFarmService(db),
+		notifications: NewNotificationService(db),
+	}
+}
+
+// NormalizeBackupSettings yedekleme ayarlarını doğrular; boş sıklık haftalık kabul edilir
+func NormalizeBackupSettings(settings *models.BackupSettings) error {
+	settings.BackupFrequency = strings.ToLower(strings.TrimSpace(settings.BackupFrequency))
+	if settings.BackupFrequency == "" {
+		settings.BackupFrequency = "weekly"
+	}
+	if _, ok := backupInterval(settings.BackupFrequency, time.Time{}); !ok {
+		return errors.New("yedekleme sıklığı daily, weekly veya monthly olmalı")
+	}
+	if settings.RetentionCount < 0 || settings.RetentionDays < 0 {
+		return errors.New("yedek saklama değerleri negatif olamaz")
+	}
+	return nil
+}
+
+// backupInterval sıklığa göre son yedekten sonraki yedekleme zamanını döner
+func backupInterval(frequency string, last time.Time) (time.Time, bool) {
+	switch frequency {
+	case "daily":
+		return last.AddDate(0, 0, 1), true
+	case "weekly":
+		return last.AddDate(0, 0, 7), true
+	case "monthly":
+		return last.AddDate(0, 1, 0), true
+	}
+	return time.Time{}, false
+}
+
+// StartScheduler saatlik kontrolle otomatik yedeklemesi açık ve yedekleme zamanı gelmiş çiftliklerin
+// yedeğini alır
+func (s *BackupService) StartScheduler() {
+	go func() {
+		ticker := time.NewTicker(time.Hour)
+		defer ticker.Stop()
+
+		for {
+			if err := s.RunScheduled(time.Now()); err != nil {
+				log.Printf("Zamanlanmış yedekler alınamadı: %v", err)
+			}
+			<-ticker.C
+		}
+	}()
+}
+
+// RunScheduled yedekleme zamanı gelmiş çiftliklerin yedeğini alır. Başarısız yedekler bir sonraki
+// kontrolde yeniden denenir
+func (s *BackupService) RunScheduled(now time.Time) error {
+	rows, err := s.db.Query("SELECT id FROM users UNION SELECT id FROM farms")
+	if err != nil {
+		return err
+	}
+	var farmIDs []string
+	for rows.Next() {
+		var farmID string
+		if err := rows.Scan(&farmID); err != nil {
+			continue
+		}
+		farmIDs = append(farmIDs, farmID)
+	}
+	rows.Close()
+
+	for _, farmID := range farmIDs {
+		settings, err := s.farms.Settings(farmID)
+		if err != nil || !settings.Backup.AutoBackup {
+			continue
+		}
+		due, err := s.due(farmID, settings.Backup.BackupFrequency, now)
+		if err != nil {
+			log.Printf("Yedekleme zamanı okunamadı (%s): %v", farmID, err)
+			continue
+		}
+		if !due {
+			continue
+		}
+		if _, err := s.Create(farmID, models.BackupTriggerScheduled); err != nil {
+			log.Printf("Zamanlanmış yedek alınamadı (%s): %v", farmID, err)
+		}
+	}
+	return nil
+}
+
+// due çiftliğin son başarılı yedeğinden bu yana sıklığın gerektirdiği sürenin geçip geçmediğini döner
+func (s *BackupService) due(farmID, frequency string, now time.Time) (bool, error) {
+	last, err := s.LastBackup(farmID)
+	if err != nil || last == nil {
+		return last == nil && err == nil, err
+	}
+
+	next, ok := backupInterval(frequency, *last)
+	if !ok {
+		next, _ = backupInterval("weekly", *last)
+	}
+	return !now.Before(next.Add(-backupScheduleSlack)), nil
+}
+
+// Create çiftliğin tüm gruplarının yedeğini alıp depolamaya yükler ve saklama kurallarını uygular.
+// Başarısız yedek kaydı hatasıyla saklanır; çiftliğe (zamanlanmış yedeklerde) ve yöneticilere bildirim gider
+func (s *BackupService) Create(farmID, trigger string) (models.BackupResult, error) {
+	backupMu.Lock()
+	defer backupMu.Unlock()
+
+	id := utils.GenerateID()
+	key := backupObjectKey(farmID, id)
+	createdAt := time.Now().UTC()
+	_, err := s.db.Exec(`
+		INSERT INTO backups (id, user_id, trigger_type, status, storage, object_key, created_at)
+		VALUES (?, ?, ?, ?, ?, ?, ?)
+	`, id, farmID, trigger, models.BackupStatusRunning, s.store.Name(), key, createdAt)
+	if err != nil {
+		return models.BackupResult{}, err
+	}
+
+	data, records, err := s.dump(farmID, createdAt)
+	if err == nil {
+		err = s.store.Put(key, data)
+	}
+	if err != nil {
+		s.db.Exec(`
+			UPDATE backups SET status = ?, error = ?, completed_at = ? WHERE id = ? AND user_id = ?
+		`, models.BackupStatusFailed, err.Error(), time.Now().UTC(), id, farmID)
+		s.notifyFailure(farmID, id, trigger, err)
+		return models.BackupResult{}, err
+	}
+
+	recordsJSON, _ := utils.ToJSON(records)
+	_, err = s.db.Exec(`
+		UPDATE backups SET status = ?, size = ?, records = ?, completed_at = ? WHERE id = ? AND user_id = ?
+	`, models.BackupStatusCompleted, len(data), recordsJSON, time.Now().UTC(), id, farmID)
+	if err != nil {
+		return models.BackupResult{}, err
+	}
+
+	if settings, err := s.farms.Settings(farmID); err == nil {
+		if err := s.applyRetention(farmID, settings.Backup, time.Now()); err != nil {
+			log.Printf("Yedek saklama kuralları uygulanamadı (%s): %v", farmID, err)
+		}
+	}
+	return s.Get(farmID, id)
+}
+
+// FailInterrupted sunucu kapanırken yarım kalan yedekleri başarısız olarak işaretler
+func (s *BackupService) FailInterrupted() error {
+	_, err := s.db.Exec(`
+		UPDATE backups SET status = ?, error = 'sunucu yeniden başlatıldı', completed_at = ? WHERE status = ?
+	`, models.BackupStatusFailed, time.Now().UTC(), models.BackupStatusRunning)
+	return err
+}
+
+// dump çiftliğin kayıtlarını tek bir okuma işleminde (tutarlı anlık görüntü) okuyup sıkıştırılmış yedek
+// dosyası üretir. Sütunlar "+sütun" ifadesiyle okunur; böylece tarihler sürücü tarafından dönüştürülmeden
+// veritabanındaki biçimleriyle saklanır
+func (s *BackupService) dump(farmID string, createdAt time.Time) ([]byte, map[string]int, error) {
+	tx, err := s.db.Begin()
+	if err != nil {
+		return nil, nil, err
+	}
+	defer tx.Rollback()
+
+	archive := backupArchive{
+		Format:    backupFormat,
+		Version:   backupFormatVersion,
+		FarmID:    farmID,
+		CreatedAt: createdAt,
+		Tables:    map[string][]map[string]interface{}{},
+	}
+	records := map[string]int{}
+
+	for _, group := range backupGroups {
+		for _, table := range group.tables {
+			columns, err := tableColumns(tx, table.name)
+			if err != nil {
+				return nil, nil, err
+			}
+			selects := make([]string, len(columns))
+			for i, column := range columns {
+				selects[i] = fmt.Sprintf(`+t."%s" AS "%s"`, column, column)
+			}
+
+			query := "SELECT " + strings.Join(selects, ", ") + " FROM " + table.name + " t"
+			if table.parent != "" {
+				query += " JOIN " + table.parent + " p ON p.id = t." + table.parentKey + " WHERE p.user_id = ?"
+			} else {
+				query += " WHERE t.user_id = ?"
+			}
+			rows, err := tx.Query(query+" ORDER BY t.rowid", farmID)
+			if err != nil {
+				return nil, nil, fmt.Errorf("%s: %w", table.name, err)
+			}
+
+			tableRows := []map[string]interface{}{}
+			for rows.Next() {
+				values := make([]interface{}, len(columns))
+				pointers := make([]interface{}, len(columns))
+				for i := range values {
+					pointers[i] = &values[i]
+				}
+				if err := rows.Scan(pointers...); err != nil {
+					rows.Close()
+					return nil, nil, fmt.Errorf("%s: %w", table.name, err)
+				}
+				row := make(map[string]interface{}, len(columns))
+				for i, column := range columns {
+					if raw, ok := values[i].([]byte); ok {
+						values[i] = string(raw)
+					}
+					row[column] = values[i]
+				}
+				tableRows = append(tableRows, row)
+			}
+			rows.Close()
+			if err := rows.Err(); err != nil {
+				return nil, nil, fmt.Errorf("%s: %w", table.name, err)
+			}
+
+			archive.Tables[table.name] = tableRows
+			if len(tableRows) > 0 {
+				records[table.name] = len(tableRows)
+			}
+		}
+	}
+
+	var buf bytes.Buffer
+	zw := gzip.NewWriter(&buf)
+	if err := json.NewEncoder(zw).Encode(archive); err != nil {
+		return nil, nil, err
+	}
+	if err := zw.Close(); err != nil {
+		return nil, nil, err
+	}
+	return buf.Bytes(), records, nil
+}
+
+// List çiftliğin yedek kayıtlarını depolamadaki dosyalarla birleştirerek yeniden eskiye listeler.
+// Kaydı olmayan (örneğin başka bir sunucudan kopyalanmış) dosyalar da geri yüklenebilir olarak listelenir
+func (s *BackupService) List(farmID string) (models.BackupListResponse, error) {
+	response := models.BackupListResponse{Storage: s.store.Name(), Backups: []models.BackupResult{}}
+
+	objects, err := s.store.List(backupPrefix(farmID))
+	if err != nil {
+		return response, err
+	}
+	stored := map[string]BackupObject{}
+	for _, object := range objects {
+		if id := backupIDFromKey(object.Key); id != "" {
+			stored[id] = object
+		}
+	}
+
+	settings, _ := s.farms.Settings(farmID)
+	rows, err := s.db.Query(backupSelect+" WHERE user_id = ? ORDER BY created_at DESC", farmID)
+	if err != nil {
+		return response, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		backup, _, err := scanBackup(rows, settings.Backup)
+		if err != nil {
+			continue
+		}
+		object, ok := stored[backup.BackupID]
+		backup.Available = ok
+		delete(stored, backup.BackupID)
+		if ok && backup.SizeBytes == 0 {
+			backup.SizeBytes = object.Size
+			backup.Size = formatBackupSize(object.Size)
+		}
+		response.Backups = append(response.Backups, backup)
+	}
+	if err := rows.Err(); err != nil {
+		return response, err
+	}
+
+	for id, object := range stored {
+		response.Backups = append(response.Backups, models.BackupResult{
+			BackupID:    id,
+			Status:      models.BackupStatusCompleted,
+			Trigger:     models.BackupTriggerExternal,
+			Storage:     s.store.Name(),
+			CreatedAt:   object.LastModified.Format(time.RFC3339),
+			Size:        formatBackupSize(object.Size),
+			SizeBytes:   object.Size,
+			DownloadURL: backupDownloadURL(id),
+			Includes:    backupGroupLabels(),
+			Available:   true,
+		})
+	}
+	sort.SliceStable(response.Backups, func(i, j int) bool {
+		return response.Backups[i].CreatedAt > response.Backups[j].CreatedAt
+	})
+	return response, nil
+}
+
+// StorageName yedeklerin saklandığı depolama türü (s3 veya local)
+func (s *BackupService) StorageName() string {
+	return s.store.Name()
+}
+
+// Get çiftliğin yedek kaydını döner
+func (s *BackupService) Get(farmID, id string) (models.BackupResult, error) {
+	settings, _ := s.farms.Settings(farmID)
+	backup, _, err := scanBackup(s.db.QueryRow(backupSelect+" WHERE id = ? AND user_id = ?", id, farmID), settings.Backup)
+	if err == sql.ErrNoRows {
+		return backup, ErrBackupNotFound
+	}
+	if err != nil {
+		return backup, err
+	}
+	backup.Available = backup.Status == models.BackupStatusCompleted
+	return backup, nil
+}
+
+// Open yedek dosyasını okumak için açar; kaydı olmayan dosyalar çiftliğin önekinde aranır
+func (s *BackupService) Open(farmID, id string) (io.ReadCloser, error) {
+	key, err := s.objectKey(farmID, id)
+	if err != nil {
+		return nil, err
+	}
+	reader, err := s.store.Open(key)
+	if isNotExist(err) {
+		return nil, ErrBackupNotFound
+	}
+	return reader, err
+}
+
+// Delete yedeği depolamadan ve kaydıyla birlikte siler
+func (s *BackupService) Delete(farmID, id string) error {
+	key, err := s.objectKey(farmID, id)
+	if err != nil {
+		return err
+	}
+	if err := s.store.Delete(key); err != nil {
+		return err
+	}
+	_, err = s.db.Exec("DELETE FROM backups WHERE id = ? AND user_id = ?", id, farmID)
+	return err
+}
+
+// LastBackup çiftliğin son başarılı yedeğinin zamanını döner; yoksa nil
+func (s *BackupService) LastBackup(farmID string) (*time.Time, error) {
+	var last time.Time
+	err := s.db.QueryRow(`
+		SELECT created_at FROM backups WHERE user_id = ? AND status = ?
+		ORDER BY created_at DESC LIMIT 1
+	`, farmID, models.BackupStatusCompleted).Scan(&last)
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &last, nil
+}
+
+// AdminList tüm çiftliklerin yedeklerini yeniden eskiye listeler; status boş değilse duruma göre süzer
+func (s *BackupService) AdminList(status string, page, limit int) ([]models.AdminBackup, int, error) {
+	where := ""
+	args := []interface{}{}
+	if status != "" {
+		where = " WHERE status = ?"
+		args = append(args, status)
+	}
+
+	var total int
+	if err := s.db.QueryRow("SELECT COUNT(*) FROM backups"+where, args...).Scan(&total); err != nil {
+		return nil, 0, err
+	}
+
+	rows, err := s.db.Query(backupSelect+where+" ORDER BY created_at DESC LIMIT ? OFFSET ?", append(args, limit, (page-1)*limit)...)
+	if err != nil {
+		return nil, 0, err
+	}
+	defer rows.Close()
+
+	backups := []models.AdminBackup{}
+	for rows.Next() {
+		backup, farmID, err := scanBackup(rows, models.BackupSettings{})
+		if err != nil {
+			continue
+		}
+		backup.Available = backup.Status == models.BackupStatusCompleted
+		backups = append(backups, models.AdminBackup{FarmID: farmID, BackupResult: backup})
+	}
+	return backups, total, rows.Err()
+}
+
+// Restore yedekteki seçili grupların kayıtlarını geri yükler: grubun tablolarındaki çiftlik kayıtları
+// silinip yerine yedektekiler yazılır. İşlem tek bir veritabanı işleminde yapılır; hata olursa hiçbir
+// değişiklik kalmaz. Yedekten sonra eklenen sütunlar varsayılan değerlerini alır, kaldırılan sütunlar atlanır
+func (s *BackupService) Restore(farmID, id string, options models.RestoreOptions) (models.RestoreResult, error) {
+	selected := map[string]bool{
+		"lands":      options.IncludeLands,
+		"livestock":  options.IncludeLivestock,
+		"production": options.IncludeProduction,
+		"finance":    options.IncludeFinance,
+		"other":      options.IncludeOther,
+	}
+	result := models.RestoreResult{
+		RestoreID:  utils.GenerateID(),
+		BackupFile: id,
+		Restored: models.RestoredSections{
+			Lands:      options.IncludeLands,
+			Livestock:  options.IncludeLivestock,
+			Finance:    options.IncludeFinance,
+			Production: options.IncludeProduction,
+			Other:      options.IncludeOther,
+		},
+		Summary: models.RestoreSummary{Records: map[string]int{}},
+	}
+	if !options.IncludeLands && !options.IncludeLivestock && !options.IncludeFinance &&
+		!options.IncludeProduction && !options.IncludeOther {
+		return result, ErrNoRestoreGroups
+	}
+
+	reader, err := s.Open(farmID, id)
+	if err != nil {
+		return result, err
+	}
+	archive, err := readBackupArchive(reader)
+	reader.Close()
+	if err != nil {
+		return result, err
+	}
+	if archive.FarmID != farmID {
+		return result, ErrInvalidBackup
+	}
+
+	backupMu.Lock()
+	defer backupMu.Unlock()
+
+	tx, err := s.db.Begin()
+	if err != nil {
+		return result, err
+	}
+	defer tx.Rollback()
+
+	for _, group := range backupGroups {
+		if !selected[group.key] {
+			continue
+		}
+		for i := len(group.tables) - 1; i >= 0; i-- {
+			table := group.tables[i]
+			var err error
+			if table.parent != "" {
+				_, err = tx.Exec("DELETE FROM "+table.name+" WHERE "+table.parentKey+
+					" IN (SELECT id FROM "+table.parent+" WHERE user_id = ?)", farmID)
+			} else {
+				_, err = tx.Exec("DELETE FROM "+table.name+" WHERE user_id = ?", farmID)
+			}
+			if err != nil {
+				return result, fmt.Errorf("%s: %w", table.name, err)
+			}
+		}
+		for _, table := range group.tables {
+			count, err := restoreTable(tx, table, farmID, archive.Tables[table.name])
+			if err != nil {
+				return result, fmt.Errorf("%s: %w", table.name, err)
+			}
+			if count > 0 {
+				result.Summary.Records[table.name] = count
+			}
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return result, err
+	}
+
+	if FieldEncryptionEnabled() {
+		if _, err := NewFieldEncryptionService(s.db).EncryptPlaintext(); err != nil {
+			log.Printf("Geri yüklenen alanlar şifrelenemedi: %v", err)
+		}
+	}
+
+	result.Status = models.BackupStatusCompleted
+	result.RestoredAt = time.Now().UTC().Format(time.RFC3339)
+	result.Summary.RestoredLands = result.Summary.Records["lands"]
+	result.Summary.RestoredAnimals = result.Summary.Records["livestock"]
+	result.Summary.RestoredTransactions = result.Summary.Records["transactions"]
+	result.Summary.RestoredProductions = result.Summary.Records["production"]
+	return result, nil
+}
+
+// restoreTable yedekteki satırları tabloya yazar; yalnızca tabloda bulunan sütunlar kullanılır
+func restoreTable(tx *sql.Tx, table backupTable, farmID string, rows []map[string]interface{}) (int, error) {
+	if len(rows) == 0 {
+		return 0, nil
+	}
+	columns, err := tableColumns(tx, table.name)
+	if err != nil {
+		return 0, err
+	}
+
+	var names []string
+	for _, column := range columns {
+		if _, ok := rows[0][column]; ok || (column == "user_id" && table.parent == "") {
+			names = append(names, column)
+		}
+	}
+	// Üst kaydı silinmiş (yetim) alt kayıtlar çiftlik üzerinden silinemediğinden yedektekilerle değiştirilir
+	insert := "INSERT"
+	if table.parent != "" {
+		insert = "INSERT OR REPLACE"
+	}
+	placeholders := strings.TrimSuffix(strings.Repeat("?, ", len(names)), ", ")
+	stmt, err := tx.Prepare(insert + ` INTO ` + table.name + ` ("` + strings.Join(names, `", "`) + `") VALUES (` + placeholders + `)`)
+	if err != nil {
+		return 0, err
+	}
+	defer stmt.Close()
+
+	for _, row := range rows {
+		values := make([]interface{}, len(names))
+		for i, name := range names {
+			values[i] = backupValue(row[name])
+			if name == "user_id" && table.parent == "" {
+				values[i] = farmID
+			}
+		}
+		if _, err := stmt.Exec(values...); err != nil {
+			return 0, err
+		}
+	}
+	return len(rows), nil
+}
+
+// backupValue JSON'dan okunan değeri veritabanı değerine çevirir; tam sayılar tam sayı olarak yazılır
+func backupValue(value interface{}) interface{} {
+	number, ok := value.(json.Number)
+	if !ok {
+		return value
+	}
+	if i, err := number.Int64(); err == nil {
+		return i
+	}
+	f, _ := number.Float64()
+	return f
+}
+
+// readBackupArchive sıkıştırılmış yedek dosyasını okur ve biçimini doğrular
+func readBackupArchive(r io.Reader) (backupArchive, error) {
+	var archive backupArchive
+	zr, err := gzip.NewReader(r)
+	if err != nil {
+		return archive, ErrInvalidBackup
+	}
+	defer zr.Close()
+
+	decoder := json.NewDecoder(zr)
+	decoder.UseNumber()
+	if err := decoder.Decode(&archive); err != nil {
+		return archive, ErrInvalidBackup
+	}
+	if archive.Format != backupFormat || archive.Version < 1 || archive.Version > backupFormatVersion {
+		return archive, ErrInvalidBackup
+	}
+	return archive, nil
+}
+
+// tableColumns tablonun güncel sütunlarını döner
+func tableColumns(tx *sql.Tx, table string) ([]string, error) {
+	rows, err := tx.Query("SELECT name FROM pragma_table_info(?)", table)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var columns []string
+	for rows.Next() {
+		var name string
+		if err := rows.Scan(&name); err != nil {
+			return nil, err
+		}
+		columns = append(columns, name)
+	}
+	if len(columns) == 0 {
+		return nil, fmt.Errorf("tablo bulunamadı: %s", table)
+	}
+	return columns, rows.Err()
+}
+
+// applyRetention saklama sayısını aşan ve saklama süresinden eski başarılı yedekleri siler; en yeni
+// yedek her zaman saklanır. Bir haftadan eski başarısız yedek kayıtları da temizlenir
+func (s *BackupService) applyRetention(farmID string, settings models.BackupSettings, now time.Time) error {
+	rows, err := s.db.Query(`
+		SELECT id, object_key, created_at FROM backups WHERE user_id = ? AND status = ?
+		ORDER BY created_at DESC
+	`, farmID, models.BackupStatusCompleted)
+	if err != nil {
+		return err
+	}
+
+	type expired struct{ id, key string }
+	var remove []expired
+	cutoff := now.AddDate(0, 0, -settings.RetentionDays)
+	for i := 0; rows.Next(); i++ {
+		var id, key string
+		var createdAt time.Time
+		if err := rows.Scan(&id, &key, &createdAt); err != nil {
+			continue
+		}
+		if i == 0 {
+			continue
+		}
+		if (settings.RetentionCount > 0 && i >= settings.RetentionCount) ||
+			(settings.RetentionDays > 0 && createdAt.Before(cutoff)) {
+			remove = append(remove, expired{id: id, key: key})
+		}
+	}
+	rows.Close()
+
+	for _, backup := range remove {
+		if err := s.store.Delete(backup.key); err != nil {
+			return err
+		}
+		if _, err := s.db.Exec("DELETE FROM backups WHERE id = ? AND user_id = ?", backup.id, farmID); err != nil {
+			return err
+		}
+	}
+
+	_, err = s.db.Exec("DELETE FROM backups WHERE user_id = ? AND status = ? AND created_at < ?",
+		farmID, models.BackupStatusFailed, now.UTC().AddDate(0, 0, -7))
+	return err
+}
+
+// notifyFailure başarısız yedeği zamanlanmış yedeklerde çiftliğe, her durumda yöneticilere bildirir;
+// aynı çiftlik için 24 saatte bir bildirim gider
+func (s *BackupService) notifyFailure(farmID, backupID, trigger string, cause error) {
+	var farmName string
+	s.db.QueryRow(`
+		SELECT name FROM farms WHERE id = ?
+		UNION ALL SELECT COALESCE(NULLIF(farm_name, ''), name) FROM users WHERE id = ?
+		LIMIT 1
+	`, farmID, farmID).Scan(&farmName)
+	entity := &models.RelatedEntity{Type: "backup", ID: backupID, Name: farmName}
+
+	if trigger == models.BackupTriggerScheduled {
+		_, err := s.notifications.Create(Notification{
+			UserID:    farmID,
+			Template:  "backup_failed",
+			Type:      "error",
+			Priority:  "high",
+			Topic:     models.NotificationTopicBackupFailed,
+			Entity:    entity,
+			Params:    map[string]interface{}{"error": cause.Error()},
+			DedupeKey: "backup_failed:" + farmID,
+		})
+		if err != nil {
+			log.Printf("Yedekleme hatası bildirimi oluşturulamadı: %v", err)
+		}
+	}
+
+	rows, err := s.db.Query("SELECT id FROM users WHERE role = ?", models.RoleAdmin)
+	if err != nil {
+		log.Printf("Yöneticiler okunamadı: %v", err)
+		return
+	}
+	var notifications []Notification
+	for rows.Next() {
+		var adminID string
+		if err := rows.Scan(&adminID); err != nil {
+			continue
+		}
+		notifications = append(notifications, Notification{
+			UserID:   adminID,
+			Template: "backup_failed_admin",
+			Type:     "error",
+			Priority: "high",
+			Topic:    models.NotificationTopicBackupFailedAdmin,
+			Entity:   entity,
+			Params: map[string]interface{}{
+				"farmId": farmID, "trigger": trigger, "storage": s.store.Name(), "error": cause.Error(),
+			},
+			DedupeKey: "backup_failed_admin:" + farmID,
+		})
+	}
+	rows.Close()
+
+	if len(notifications) > 0 {
+		if _, err := s.notifications.CreateBatch(notifications); err != nil {
+			log.Printf("Yönetici yedekleme bildirimi oluşturulamadı: %v", err)
+		}
+	}
+}
+
+// objectKey yedeğin depolama anahtarını döner; kaydı olmayan yedekler için çiftliğin öneki kullanılır
+func (s *BackupService) objectKey(farmID, id string) (string, error) {
+	var key string
+	err := s.db.QueryRow("SELECT object_key FROM backups WHERE id = ? AND user_id = ? AND status = ?",
+		id, farmID, models.BackupStatusCompleted).Scan(&key)
+	if err == nil {
+		return key, nil
+	}
+	if err != sql.ErrNoRows {
+		return "", err
+	}
+	if id == "" || strings.ContainsAny(id, "/\\.") {
+		return "", ErrBackupNotFound
+	}
+	return backupObjectKey(farmID, id), nil
+}
+
+// backupSelect yedek kayıtlarını okuyan sorgu
+const backupSelect = `
+	SELECT id, user_id, trigger_type, status, storage, size, records, error, created_at, completed_at
+	FROM backups`
+
+// scanBackup yedek satırını okur; saklama süresi verilmişse son geçerlilik zamanı hesaplanır
+func scanBackup(row interface{ Scan(...interface{}) error }, settings models.BackupSettings) (models.BackupResult, string, error) {
+	var backup models.BackupResult
+	var farmID string
+	var records, errMessage sql.NullString
+	var createdAt time.Time
+	var completedAt sql.NullTime
+	err := row.Scan(&backup.BackupID, &farmID, &backup.Trigger, &backup.Status, &backup.Storage, &backup.SizeBytes,
+		&records, &errMessage, &createdAt, &completedAt)
+	if err != nil {
+		return backup, farmID, err
+	}
+
+	backup.CreatedAt = createdAt.UTC().Format(time.RFC3339)
+	if completedAt.Valid {
+		backup.CompletedAt = completedAt.Time.UTC().Format(time.RFC3339)
+	}
+	if settings.RetentionDays > 0 && backup.Status == models.BackupStatusCompleted {
+		backup.ExpiresAt = createdAt.UTC().AddDate(0, 0, settings.RetentionDays).Format(time.RFC3339)
+	}
+	backup.Size = formatBackupSize(backup.SizeBytes)
+	backup.Error = errMessage.String
+	backup.Includes = backupGroupLabels()
+	if backup.Status == models.BackupStatusCompleted {
+		backup.DownloadURL = backupDownloadURL(backup.BackupID)
+	}
+	if records.Valid && records.String != "" {
+		utils.FromJSON(records.String, &backup.Records)
+	}
+	return backup, farmID, nil
+}
+
+// backupPrefix çiftliğin yedeklerinin depolama öneki
+func backupPrefix(farmID string) string {
+	return "backups/" + farmID + "/"
+}
+
+// backupObjectKey yedeğin depolama anahtarı
+func backupObjectKey(farmID, id string) string {
+	return backupPrefix(farmID) + id + ".json.gz"
+}
+
+// backupIDFromKey depolama anahtarından yedek ID'sini çıkarır; yedek dosyası değilse boş döner
+func backupIDFromKey(key string) string {
+	name := key[strings.LastIndex(key, "/")+1:]
+	if !strings.HasSuffix(name, ".json.gz") {
+		return ""
+	}
+	return strings.TrimSuffix(name, ".json.gz")
+}
+
+// backupDownloadURL yedeğin indirme adresi
+func backupDownloadURL(id string) string {
+	return "/api/v1/settings/backups/" + id + "/download"
+}
+
+// backupGroupLabels yedeğe dahil edilen veri gruplarının adları
+func backupGroupLabels() []string {
+	labels := make([]string, len(backupGroups))
+	for i, group := range backupGroups {
+		labels[i] = group.label
+	}
+	return labels
+}
+
+// formatBackupSize bayt cinsinden boyutu okunabilir biçime çevirir
+func formatBackupSize(size int64) string {
+	switch {
+	case size >= 1<<20:
+		return fmt.Sprintf("%.1fMB", float64(size)/(1<<20))
+	case size >= 1<<10:
+		return fmt.Sprintf("%.1fKB", float64(size)/(1<<10))
+	}
+	return fmt.Sprintf("%dB", size)
+}
@@ -0,0 +1,576 @@
+package services
+
+import (
+	"bytes"
+	"crypto/sha1"
+	"database/sql"
+	"encoding/csv"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+
+	"agri-management-api/internal/models"
+)
+
+// Banka ekstresi dosya formatları
+const (
+	StatementFormatCSV = "csv"
+	StatementFormatOFX = "ofx"
+)
+
+// DefaultMatchToleranceDays ekstre satırı ile işlem tarihi arasında varsayılan en fazla gün farkı
+const DefaultMatchToleranceDays = 3
+
+// ErrEmptyStatement ekstre dosyasında hareket bulunamadığında döner
+var ErrEmptyStatement = errors.New("statement file contains no lines")
+
+// statementColumnAliases CSV başlıklarının bankalara göre farklı adları
+var statementColumnAliases = map[string][]string{
+	"date":        {"tarih", "işlem tarihi", "islem tarihi", "valör", "valor", "date", "transaction date", "posting date", "value date"},
+	"description": {"açıklama", "aciklama", "işlem açıklaması", "islem aciklamasi", "description", "details", "memo", "narrative"},
+	"amount":      {"tutar", "işlem tutarı", "islem tutari", "miktar", "amount"},
+	"debit":       {"borç", "borc", "çıkış", "cikis", "debit", "withdrawal"},
+	"credit":      {"alacak", "giriş", "giris", "credit", "deposit"},
+	"reference":   {"referans", "referans no", "dekont no", "fiş no", "fis no", "reference", "ref"},
+	"balance":     {"bakiye", "balance"},
+}
+
+// statementDateLayouts ekstrelerde kullanılan tarih biçimleri
+var statementDateLayouts = []string{"02.01.2006", "02/01/2006", "2006-01-02", "02-01-2006", "2.1.2006", "20060102"}
+
+var ofxTagPattern = regexp.MustCompile(`(?i)<([A-Z0-9.]+)>([^<\r\n]*)`)
+
+// StatementLine ekstre dosyasından okunan hareket
+type StatementLine struct {
+	Date        time.Time
+	Amount      float64
+	Description string
+	Reference   string
+	ExternalID  string
+}
+
+// BankStatementFile çözümlenen ekstre dosyası
+type BankStatementFile struct {
+	Format         string
+	Currency       string
+	StartDate      *time.Time
+	EndDate        *time.Time
+	ClosingBalance *float64
+	Lines          []StatementLine
+}
+
+// BankService banka hesabı ekstrelerinin işlemlerle mutabakatını yönetir
+type BankService struct {
+	db *sql.DB
+}
+
+// NewBankService yeni bank service oluşturur
+func NewBankService(db *sql.DB) *BankService {
+	return &BankService{db: db}
+}
+
+// ParseBankStatement CSV veya OFX formatındaki ekstreyi çözümler; format boşsa içerikten tespit edilir.
+// Bankanın hareket kimliği olmayan satırlara tarih, tutar ve açıklamadan kimlik üretilir
+func ParseBankStatement(data []byte, format string) (BankStatementFile, error) {
+	data = bytes.TrimPrefix(data, []byte("\xef\xbb\xbf"))
+
+	if format == "" {
+		format = StatementFormatCSV
+		if bytes.Contains(bytes.ToUpper(data[:min(len(data), 2048)]), []byte("<OFX>")) {
+			format = StatementFormatOFX
+		}
+	}
+
+	var statement BankStatementFile
+	var err error
+	if format == StatementFormatOFX {
+		statement, err = parseStatementOFX(data)
+	} else {
+		statement, err = parseStatementCSV(data)
+	}
+	if err != nil {
+		return statement, err
+	}
+	if len(statement.Lines) == 0 {
+		return statement, ErrEmptyStatement
+	}
+
+	// Aynı gün aynı tutar ve açıklamayla birden fazla hareket olabileceği için sıra numarası eklenir
+	seen := map[string]int{}
+	for i := range statement.Lines {
+		line := &statement.Lines[i]
+		if line.ExternalID != "" {
+			continue
+		}
+		key := fmt.Sprintf("%s|%.2f|%s|%s", line.Date.Format("2006-01-02"), line.Amount, line.Description, line.Reference)
+		seen[key]++
+		sum := sha1.Sum([]byte(fmt.Sprintf("%s|%d", key, seen[key])))
+		line.ExternalID = hex.EncodeToString(sum[:])
+	}
+
+	if statement.StartDate == nil || statement.EndDate == nil {
+		start, end := statement.Lines[0].Date, statement.Lines[0].Date
+		for _, line := range statement.Lines {
+			if line.Date.Before(start) {
+				start = line.Date
+			}
+			if line.Date.After(end) {
+				end = line.Date
+			}
+		}
+		statement.StartDate, statement.EndDate = &start, &end
+	}
+
+	statement.Format = format
+	return statement, nil
+}
+
+// parseStatementCSV başlık satırındaki sütun adlarına göre ekstreyi okur; ayırıcı başlıktan tespit edilir
+func parseStatementCSV(data []byte) (BankStatementFile, error) {
+	var statement BankStatementFile
+
+	headerLine, _, _ := bytes.Cut(data, []byte("\n"))
+	reader := csv.NewReader(bytes.NewReader(data))
+	reader.Comma = ';'
+	for _, comma := range []rune{'\t', ',', ';'} {
+		if bytes.Count(headerLine, []byte(string(comma))) > bytes.Count(headerLine, []byte(string(reader.Comma))) {
+			reader.Comma = comma
+		}
+	}
+	reader.FieldsPerRecord = -1
+	reader.LazyQuotes = true
+	reader.TrimLeadingSpace = true
+
+	header, err := reader.Read()
+	if err != nil {
+		return statement, err
+	}
+
+	columns := map[string]int{}
+	for i, name := range header {
+		name = strings.ToLower(strings.TrimSpace(name))
+		for column, aliases := range statementColumnAliases {
+			if _, ok := columns[column]; ok {
+				continue
+			}
+			for _, alias := range aliases {
+				if name == alias {
+					columns[column] = i
+				}
+			}
+		}
+	}
+	if _, ok := columns["date"]; !ok {
+		return statement, errors.New("date column not found")
+	}
+	_, hasAmount := columns["amount"]
+	_, hasDebit := columns["debit"]
+	_, hasCredit := columns["credit"]
+	if !hasAmount && !hasDebit && !hasCredit {
+		return statement, errors.New("amount column not found")
+	}
+
+	field := func(record []string, name string) string {
+		i, ok := columns[name]
+		if !ok || i >= len(record) {
+			return ""
+		}
+		return strings.TrimSpace(record[i])
+	}
+
+	for {
+		record, err := reader.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return statement, err
+		}
+
+		date, ok := parseStatementDate(field(record, "date"))
+		if !ok {
+			continue
+		}
+
+		amount := parseStatementAmount(field(record, "amount"))
+		if !hasAmount {
+			amount = math.Abs(parseStatementAmount(field(record, "credit"))) - math.Abs(parseStatementAmount(field(record, "debit")))
+		}
+		if amount == 0 {
+			continue
+		}
+
+		statement.Lines = append(statement.Lines, StatementLine{
+			Date:        date,
+			Amount:      round2(amount),
+			Description: field(record, "description"),
+			Reference:   field(record, "reference"),
+		})
+
+		if balance := field(record, "balance"); balance != "" {
+			value := parseStatementAmount(balance)
+			statement.ClosingBalance = &value
+		}
+	}
+
+	return statement, nil
+}
+
+// parseStatementOFX OFX 1.x (SGML) ve 2.x (XML) ekstrelerindeki STMTTRN kayıtlarını okur
+func parseStatementOFX(data []byte) (BankStatementFile, error) {
+	var statement BankStatementFile
+	text := string(data)
+
+	values := func(block string) map[string]string {
+		fields := map[string]string{}
+		for _, match := range ofxTagPattern.FindAllStringSubmatch(block, -1) {
+			if value := strings.TrimSpace(match[2]); value != "" {
+				fields[strings.ToUpper(match[1])] = value
+			}
+		}
+		return fields
+	}
+
+	header := values(text)
+	statement.Currency = strings.ToUpper(header["CURDEF"])
+	if date, ok := parseOFXDate(header["DTSTART"]); ok {
+		statement.StartDate = &date
+	}
+	if date, ok := parseOFXDate(header["DTEND"]); ok {
+		statement.EndDate = &date
+	}
+
+	upper := strings.ToUpper(text)
+	if i := strings.Index(upper, "<LEDGERBAL>"); i >= 0 {
+		if value, ok := values(text[i:])["BALAMT"]; ok {
+			balance := parseStatementAmount(value)
+			statement.ClosingBalance = &balance
+		}
+	}
+
+	for offset := 0; ; {
+		start := strings.Index(upper[offset:], "<STMTTRN>")
+		if start < 0 {
+			break
+		}
+		start += offset
+		end := strings.Index(upper[start:], "</STMTTRN>")
+		if end < 0 {
+			if next := strings.Index(upper[start+9:], "<STMTTRN>"); next >= 0 {
+				end = next + 9
+			} else if next := strings.Index(upper[start:], "</BANKTRANLIST>"); next >= 0 {
+				end = next
+			} else {
+				end = len(upper) - start
+			}
+		}
+		block := values(text[start : start+end])
+		offset = start + end
+
+		date, ok := parseOFXDate(block["DTPOSTED"])
+		if !ok {
+			continue
+		}
+		amount := parseStatementAmount(block["TRNAMT"])
+		if amount == 0 {
+			continue
+		}
+
+		description := block["NAME"]
+		if memo := block["MEMO"]; memo != "" && memo != description {
+			description = strings.TrimSpace(description + " " + memo)
+		}
+		reference := block["REFNUM"]
+		if reference == "" {
+			reference = block["CHECKNUM"]
+		}
+
+		statement.Lines = append(statement.Lines, StatementLine{
+			Date:        date,
+			Amount:      round2(amount),
+			Description: description,
+			Reference:   reference,
+			ExternalID:  block["FITID"],
+		})
+	}
+
+	return statement, nil
+}
+
+// parseStatementDate ekstre tarihini bilinen biçimlerden biriyle çözümler
+func parseStatementDate(value string) (time.Time, bool) {
+	value = strings.TrimSpace(value)
+	if len(value) > 10 {
+		value = strings.Fields(value)[0]
+	}
+	for _, layout := range statementDateLayouts {
+		if date, err := time.Parse(layout, value); err == nil {
+			return date, true
+		}
+	}
+	return time.Time{}, false
+}
+
+// parseOFXDate OFX tarihini (YYYYMMDD[HHMMSS][.XXX][TZ]) gün olarak çözümler
+func parseOFXDate(value string) (time.Time, bool) {
+	if len(value) < 8 {
+		return time.Time{}, false
+	}
+	date, err := time.Parse("20060102", value[:8])
+	return date, err == nil
+}
+
+// parseStatementAmount işaretli ekstre tutarını sayıya çevirir; "-", parantez ve B/A (borç/alacak) son ekleri desteklenir
+func parseStatementAmount(value string) float64 {
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return 0
+	}
+
+	negative := false
+	upper := strings.ToUpper(value)
+	switch {
+	case strings.HasPrefix(value, "-"), strings.HasSuffix(value, "-"):
+		negative = true
+	case strings.HasPrefix(value, "(") && strings.HasSuffix(value, ")"):
+		negative = true
+	case strings.HasSuffix(upper, " B"), strings.HasSuffix(upper, "(B)"):
+		negative = true
+	}
+
+	amount := parseLocalizedAmount(value)
+	if negative {
+		return -amount
+	}
+	return amount
+}
+
+// statementCandidate eşleştirmede değerlendirilen işlem
+type statementCandidate struct {
+	id          string
+	income      bool
+	amount      float64
+	date        time.Time
+	description string
+	pending     bool
+}
+
+// statementMatch ekstre satırı ile işlem arasındaki olası eşleşme
+type statementMatch struct {
+	lineID        string
+	transactionID string
+	pending       bool
+	date          time.Time
+	score         float64
+}
+
+// MatchStatementLines hesabın eşleşmemiş ekstre satırlarını tutar, yön ve tarih yakınlığına göre henüz eşleşmemiş işlemlerle eşleştirir.
+// Skor tarih farkı arttıkça düşer, açıklamalarda ortak kelime varsa artar; en yüksek skorlu eşleşmeler önce atanır.
+// Eşleşen bekleyen işlemler ekstre tarihinde ödenmiş kabul edilir
+func (s *BankService) MatchStatementLines(userID, accountID, currency string, toleranceDays int) (int, error) {
+	rows, err := s.db.Query(`
+		SELECT id, date, amount, COALESCE(description, '')
+		FROM bank_statement_lines
+		WHERE account_id = ? AND user_id = ? AND status = ?
+	`, accountID, userID, models.StatementLineUnmatched)
+	if err != nil {
+		return 0, err
+	}
+
+	var lines []StatementLine
+	var lineIDs []string
+	var minDate, maxDate time.Time
+	for rows.Next() {
+		var id string
+		var line StatementLine
+		if err := rows.Scan(&id, &line.Date, &line.Amount, &line.Description); err != nil {
+			rows.Close()
+			return 0, err
+		}
+		if len(lines) == 0 || line.Date.Before(minDate) {
+			minDate = line.Date
+		}
+		if len(lines) == 0 || line.Date.After(maxDate) {
+			maxDate = line.Date
+		}
+		lines = append(lines, line)
+		lineIDs = append(lineIDs, id)
+	}
+	rows.Close()
+	if len(lines) == 0 {
+		return 0, nil
+	}
+
+	rows, err = s.db.Query(`
+		SELECT id, type, amount, date, COALESCE(description, ''), status
+		FROM transactions
+		WHERE user_id = ? AND COALESCE(NULLIF(currency, ''), 'TRY') = ?
+		  AND date(date) BETWEEN ? AND ?
+		  AND id NOT IN (SELECT transaction_id FROM bank_statement_lines WHERE transaction_id IS NOT NULL)
+	`, userID, currency, minDate.AddDate(0, 0, -toleranceDays).Format("2006-01-02"), maxDate.AddDate(0, 0, toleranceDays).Format("2006-01-02"))
+	if err != nil {
+		return 0, err
+	}
+
+	var candidates []statementCandidate
+	for rows.Next() {
+		var candidate statementCandidate
+		var txType, status string
+		if err := rows.Scan(&candidate.id, &txType, &candidate.amount, &candidate.date, &candidate.description, &status); err != nil {
+			rows.Close()
+			return 0, err
+		}
+		candidate.income = txType == "income"
+		candidate.pending = status == "pending"
+		candidates = append(candidates, candidate)
+	}
+	rows.Close()
+
+	var matches []statementMatch
+	for i, line := range lines {
+		for _, candidate := range candidates {
+			if candidate.income != (line.Amount > 0) || math.Abs(candidate.amount-math.Abs(line.Amount)) >= 0.005 {
+				continue
+			}
+			days := math.Abs(line.Date.Sub(truncateDay(candidate.date)).Hours() / 24)
+			if days > float64(toleranceDays) {
+				continue
+			}
+
+			score := 1 - days/float64(toleranceDays+1)*0.5
+			if sharesWord(line.Description, candidate.description) {
+				score += 0.25
+			}
+			matches = append(matches, statementMatch{
+				lineID:        lineIDs[i],
+				transactionID: candidate.id,
+				pending:       candidate.pending,
+				date:          line.Date,
+				score:         round2(math.Min(score, 1)),
+			})
+		}
+	}
+
+	sort.SliceStable(matches, func(i, j int) bool {
+		return matches[i].score > matches[j].score
+	})
+
+	tx, err := s.db.Begin()
+	if err != nil {
+		return 0, err
+	}
+	defer tx.Rollback()
+
+	usedLines, usedTransactions := map[string]bool{}, map[string]bool{}
+	matched := 0
+	for _, match := range matches {
+		if usedLines[match.lineID] || usedTransactions[match.transactionID] {
+			continue
+		}
+		usedLines[match.lineID], usedTransactions[match.transactionID] = true, true
+
+		_, err := tx.Exec("UPDATE bank_statement_lines SET status = ?, transaction_id = ?, match_score = ? WHERE id = ? AND user_id = ?",
+			models.StatementLineMatched, match.transactionID, match.score, match.lineID, userID)
+		if err != nil {
+			return 0, err
+		}
+		if match.pending {
+			_, err := tx.Exec(`
+				UPDATE transactions SET status = 'completed', paid_at = ?, updated_at = CURRENT_TIMESTAMP
+				WHERE id = ? AND user_id = ? AND status = 'pending'
+			`, match.date, match.transactionID, userID)
+			if err != nil {
+				return 0, err
+			}
+		}
+		matched++
+	}
+
+	return matched, tx.Commit()
+}
+
+// Reconciliation banka hesabının mutabakat durumunu hesaplar; mutabakat tarihi ilk eşleşmemiş satırdan önceki gündür
+func (s *BankService) Reconciliation(userID, accountID string, openingBalance float64) (models.BankReconciliation, error) {
+	reconciliation := models.BankReconciliation{}
+
+	var lastDate, firstUnmatched sql.NullString
+	var total float64
+	err := s.db.QueryRow(`
+		SELECT COUNT(*),
+		       COALESCE(SUM(CASE WHEN status = ? THEN 1 ELSE 0 END), 0),
+		       COALESCE(SUM(CASE WHEN status = ? THEN 1 ELSE 0 END), 0),
+		       COALESCE(SUM(CASE WHEN status = ? THEN 1 ELSE 0 END), 0),
+		       COALESCE(SUM(CASE WHEN status = ? THEN 1 ELSE 0 END), 0),
+		       COALESCE(SUM(CASE WHEN status = ? THEN ABS(amount) ELSE 0 END), 0),
+		       COALESCE(SUM(amount), 0),
+		       MAX(date(date)),
+		       MIN(CASE WHEN status = ? THEN date(date) END)
+		FROM bank_statement_lines WHERE account_id = ? AND user_id = ?
+	`, models.StatementLineMatched, models.StatementLineCreated, models.StatementLineIgnored, models.StatementLineUnmatched,
+		models.StatementLineUnmatched, models.StatementLineUnmatched, accountID, userID).Scan(
+		&reconciliation.TotalLines, &reconciliation.Matched, &reconciliation.Created, &reconciliation.Ignored,
+		&reconciliation.Unmatched, &reconciliation.UnmatchedAmount, &total, &lastDate, &firstUnmatched,
+	)
+	if err != nil {
+		return reconciliation, err
+	}
+
+	reconciliation.UnmatchedAmount = round2(reconciliation.UnmatchedAmount)
+	reconciliation.StatementBalance = round2(openingBalance + total)
+
+	if lastDate.Valid {
+		if date, err := time.Parse("2006-01-02", lastDate.String); err == nil {
+			reconciliation.LastStatementDate = &date
+			reconciliation.ReconciledThrough = &date
+		}
+	}
+	if firstUnmatched.Valid {
+		reconciliation.ReconciledThrough = nil
+		if date, err := time.Parse("2006-01-02", firstUnmatched.String); err == nil {
+			through := date.AddDate(0, 0, -1)
+			reconciliation.ReconciledThrough = &through
+		}
+	}
+
+	switch {
+	case reconciliation.TotalLines == 0:
+		reconciliation.Status = "no_statements"
+	case reconciliation.Unmatched == 0:
+		reconciliation.Status = "reconciled"
+	default:
+		reconciliation.Status = "unreconciled"
+	}
+	return reconciliation, nil
+}
+
+// truncateDay zamanı gün başlangıcına yuvarlar
+func truncateDay(t time.Time) time.Time {
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+}
+
+// sharesWord iki açıklamanın dört harf ve üzeri ortak bir kelime içerip içermediğini döner
+func sharesWord(a, b string) bool {
+	words := map[string]bool{}
+	for _, word := range strings.FieldsFunc(strings.ToLower(a), isWordSeparator) {
+		if len([]rune(word)) >= 4 {
+			words[word] = true
+		}
+	}
+	for _, word := range strings.FieldsFunc(strings.ToLower(b), isWordSeparator) {
+		if words[word] {
+			return true
+		}
+	}
+	return false
+}
+
+// isWordSeparator açıklamaları kelimelere ayırırken kullanılan ayırıcılar
+func isWordSeparator(r rune) bool {
+	return !(r >= 'a' && r <= 'z' || r >= '0' && r <= '9' || r > 127)
+}
@@ -0,0 +1,386 @@
+package services
+
+import (
+	"database/sql"
+	"errors"
+	"strings"
+	"time"
+
+	"agri-management-api/internal/models"
+	"agri-management-api/internal/utils"
+)
+
+var (
+	// ErrBreedingRecordNotFound üreme kaydı bulunamadı
+	ErrBreedingRecordNotFound = errors.New("üreme kaydı bulunamadı")
+	// ErrBreedingMaleAnimal erkek hayvana üreme kaydı girilemez
+	ErrBreedingMaleAnimal = errors.New("üreme kaydı yalnızca dişi hayvanlara girilebilir")
+	// ErrInvalidSire baba hayvan çiftlikte yok, dişi veya annenin kendisi
+	ErrInvalidSire = errors.New("geçersiz baba hayvan")
+	// ErrOffspringNotFound yavrulardan biri çiftlikte yok veya annenin kendisi
+	ErrOffspringNotFound = errors.New("yavru hayvan bulunamadı")
+)
+
+// breedingRecordSelect üreme kaydı sütunları; b takma adı breeding_records, l takma adı livestock tablosudur
+const breedingRecordSelect = `
+	SELECT b.id, b.livestock_id, l.tag_number, b.method, date(b.service_date), b.sire_id, COALESCE(b.sire, ''),
+	       COALESCE(b.technician, ''), b.pregnancy_status, date(b.pregnancy_check_date), date(b.expected_birth_date),
+	       date(b.birth_date), COALESCE(b.notes, ''), b.created_at, b.updated_at
+	FROM breeding_records b
+	JOIN livestock l ON l.id = b.livestock_id`
+
+// openPregnancyStatuses doğum beklenen gebelik durumları
+var openPregnancyStatuses = []string{models.PregnancyStatusPending, models.PregnancyStatusConfirmed}
+
+// breedingAnimal üreme kaydındaki anne veya baba hayvan
+type breedingAnimal struct {
+	id, tag, animalType, gender string
+}
+
+// BreedingService dişi hayvanların tohumlama/aşım, gebelik ve doğum kayıtlarını yönetir. Doğrulanan gebelikler
+// hayvanın sağlık durumuna pregnant olarak yansır; doğan yavrular kayda ve anne/baba bilgisine bağlanır
+type BreedingService struct {
+	db           *sql.DB
+	vaccinations *VaccinationService
+}
+
+// NewBreedingService yeni üreme kaydı servisi oluşturur
+func NewBreedingService(db *sql.DB) *BreedingService {
+	return &BreedingService{db: db, vaccinations: NewVaccinationService(db)}
+}
+
+// List hayvanın üreme kayıtlarını en yeniden eskiye döner
+func (s *BreedingService) List(farmID, animalID string) ([]models.BreedingRecord, error) {
+	if _, err := s.animal(farmID, animalID); err != nil {
+		return nil, err
+	}
+	records, err := s.query(breedingRecordSelect+`
+		WHERE b.livestock_id = ? AND l.user_id = ?
+		ORDER BY b.service_date DESC, b.created_at DESC
+	`, animalID, farmID)
+	if err != nil {
+		return nil, err
+	}
+	return records, s.attachOffspring(farmID, animalID, records)
+}
+
+// Get hayvanın üreme kaydını yavrularıyla döner
+func (s *BreedingService) Get(farmID, animalID, id string) (*models.BreedingRecord, error) {
+	records, err := s.query(breedingRecordSelect+`
+		WHERE b.id = ? AND b.livestock_id = ? AND l.user_id = ?
+	`, id, animalID, farmID)
+	if err != nil {
+		return nil, err
+	}
+	if len(records) == 0 {
+		return nil, ErrBreedingRecordNotFound
+	}
+	if err := s.attachOffspring(farmID, animalID, records); err != nil {
+		return nil, err
+	}
+	return &records[0], nil
+}
+
+// Create dişi hayvana üreme kaydı ekler; beklenen doğum tarihi verilmezse türün gebelik süresiyle hesaplanır
+func (s *BreedingService) Create(farmID, animalID string, req models.BreedingRecordRequest) (*models.BreedingRecord, error) {
+	dam, err := s.animal(farmID, animalID)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.prepare(farmID, dam, &req); err != nil {
+		return nil, err
+	}
+
+	tx, err := s.db.Begin()
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	id := utils.GenerateID()
+	if _, err := tx.Exec(`
+		INSERT INTO breeding_records (id, livestock_id, method, service_date, sire_id, sire, technician, pregnancy_status,
+		                              pregnancy_check_date, expected_birth_date, birth_date, notes, created_at, updated_at)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, CURRENT_TIMESTAMP, CURRENT_TIMESTAMP)
+	`, id, animalID, req.Method, req.ServiceDate, req.SireID, req.Sire, req.Technician, req.PregnancyStatus,
+		req.PregnancyCheckDate, req.ExpectedBirthDate, req.BirthDate, req.Notes); err != nil {
+		return nil, err
+	}
+	if req.OffspringIDs != nil {
+		if err := linkOffspring(tx, farmID, id, dam, req); err != nil {
+			return nil, err
+		}
+	}
+	if err := tx.Commit(); err != nil {
+		return nil, err
+	}
+
+	if err := s.syncPregnancy(farmID, animalID); err != nil {
+		return nil, err
+	}
+	return s.Get(farmID, animalID, id)
+}
+
+// Update üreme kaydını günceller; offspringIds gönderilmezse bağlı yavrular değişmez, boş liste bağlantıları kaldırır
+func (s *BreedingService) Update(farmID, animalID, id string, req models.BreedingRecordRequest) (*models.BreedingRecord, error) {
+	dam, err := s.animal(farmID, animalID)
+	if err != nil {
+		return nil, err
+	}
+	current, err := s.Get(farmID, animalID, id)
+	if err != nil {
+		return nil, err
+	}
+	if req.OffspringIDs == nil && len(current.Offspring) > 0 && req.PregnancyStatus == "" {
+		req.PregnancyStatus = models.PregnancyStatusDelivered
+	}
+	if err := s.prepare(farmID, dam, &req); err != nil {
+		return nil, err
+	}
+
+	tx, err := s.db.Begin()
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	if _, err := tx.Exec(`
+		UPDATE breeding_records
+		SET method = ?, service_date = ?, sire_id = ?, sire = ?, technician = ?, pregnancy_status = ?,
+		    pregnancy_check_date = ?, expected_birth_date = ?, birth_date = ?, notes = ?, updated_at = CURRENT_TIMESTAMP
+		WHERE id = ? AND `+farmLivestockCondition+`
+	`, req.Method, req.ServiceDate, req.SireID, req.Sire, req.Technician, req.PregnancyStatus, req.PregnancyCheckDate,
+		req.ExpectedBirthDate, req.BirthDate, req.Notes, id, farmID); err != nil {
+		return nil, err
+	}
+	if req.OffspringIDs != nil {
+		if err := linkOffspring(tx, farmID, id, dam, req); err != nil {
+			return nil, err
+		}
+	}
+	if err := tx.Commit(); err != nil {
+		return nil, err
+	}
+
+	if err := s.syncPregnancy(farmID, animalID); err != nil {
+		return nil, err
+	}
+	return s.Get(farmID, animalID, id)
+}
+
+// Delete üreme kaydını siler; yavruların kayıtla bağlantısı kaldırılır, anne/baba bilgileri değişmez
+func (s *BreedingService) Delete(farmID, animalID, id string) error {
+	if _, err := s.Get(farmID, animalID, id); err != nil {
+		return err
+	}
+
+	tx, err := s.db.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	if _, err := tx.Exec(`
+		UPDATE livestock SET breeding_record_id = NULL, updated_at = CURRENT_TIMESTAMP
+		WHERE breeding_record_id = ? AND user_id = ?
+	`, id, farmID); err != nil {
+		return err
+	}
+	if _, err := tx.Exec("DELETE FROM breeding_records WHERE id = ? AND "+farmLivestockCondition, id, farmID); err != nil {
+		return err
+	}
+	if err := tx.Commit(); err != nil {
+		return err
+	}
+	return s.syncPregnancy(farmID, animalID)
+}
+
+// prepare isteği doğrular ve varsayılanları doldurur: yöntem, gebelik durumu, baba adı ve beklenen doğum tarihi
+func (s *BreedingService) prepare(farmID string, dam breedingAnimal, req *models.BreedingRecordRequest) error {
+	if dam.gender == "male" {
+		return ErrBreedingMaleAnimal
+	}
+
+	if req.Method == "" {
+		req.Method = models.BreedingMethodArtificial
+	}
+	if req.PregnancyStatus == "" {
+		req.PregnancyStatus = models.PregnancyStatusPending
+		if req.BirthDate != nil || len(req.OffspringIDs) > 0 {
+			req.PregnancyStatus = models.PregnancyStatusDelivered
+		}
+	}
+	req.Sire = strings.TrimSpace(req.Sire)
+
+	if req.SireID != nil && *req.SireID == "" {
+		req.SireID = nil
+	}
+	if req.SireID != nil {
+		sire, err := s.animal(farmID, *req.SireID)
+		if err != nil || sire.id == dam.id || sire.gender == "female" {
+			return ErrInvalidSire
+		}
+		if req.Sire == "" {
+			req.Sire = sire.tag
+		}
+	}
+
+	if req.ExpectedBirthDate == nil {
+		if days, ok := gestationDays[strings.ToLower(dam.animalType)]; ok {
+			if service, err := time.Parse("2006-01-02", req.ServiceDate); err == nil {
+				expected := service.AddDate(0, 0, days).Format("2006-01-02")
+				req.ExpectedBirthDate = &expected
+			}
+		}
+	}
+	return nil
+}
+
+// syncPregnancy hayvanın son üreme kaydına göre sağlık durumunu günceller: doğrulanmış gebelikte sağlıklı hayvan
+// pregnant olur, gebelik sona erince pregnant durumu healthy olur ve aşı takvimine göre yeniden türetilir
+func (s *BreedingService) syncPregnancy(farmID, animalID string) error {
+	var status string
+	err := s.db.QueryRow(`
+		SELECT pregnancy_status FROM breeding_records
+		WHERE livestock_id = ? AND `+farmLivestockCondition+`
+		ORDER BY service_date DESC, created_at DESC LIMIT 1
+	`, animalID, farmID).Scan(&status)
+	if err == sql.ErrNoRows {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	switch status {
+	case models.PregnancyStatusConfirmed:
+		_, err = s.db.Exec(`
+			UPDATE livestock SET health_status = ?, updated_at = CURRENT_TIMESTAMP
+			WHERE id = ? AND user_id = ? AND COALESCE(health_status, '') IN ('', ?, ?)
+		`, models.HealthStatusPregnant, animalID, farmID, models.HealthStatusHealthy, models.HealthStatusVaccinationNeeded)
+		return err
+	case models.PregnancyStatusPending:
+		return nil
+	}
+
+	if _, err := s.db.Exec(`
+		UPDATE livestock SET health_status = ?, updated_at = CURRENT_TIMESTAMP
+		WHERE id = ? AND user_id = ? AND health_status = ?
+	`, models.HealthStatusHealthy, animalID, farmID, models.HealthStatusPregnant); err != nil {
+		return err
+	}
+	return s.vaccinations.SyncHealthStatus(farmID)
+}
+
+// animal çiftliğin hayvanını döner
+func (s *BreedingService) animal(farmID, animalID string) (breedingAnimal, error) {
+	animal := breedingAnimal{id: animalID}
+	err := s.db.QueryRow(`
+		SELECT tag_number, type, COALESCE(gender, '') FROM livestock WHERE id = ? AND user_id = ?
+	`, animalID, farmID).Scan(&animal.tag, &animal.animalType, &animal.gender)
+	if err == sql.ErrNoRows {
+		return animal, ErrLivestockNotFound
+	}
+	return animal, err
+}
+
+// attachOffspring kayıtlara bağlı yavruları ekler
+func (s *BreedingService) attachOffspring(farmID, animalID string, records []models.BreedingRecord) error {
+	rows, err := s.db.Query(`
+		SELECT breeding_record_id, id, tag_number, COALESCE(gender, '')
+		FROM livestock
+		WHERE user_id = ? AND breeding_record_id IN (SELECT id FROM breeding_records WHERE livestock_id = ?)
+		ORDER BY tag_number
+	`, farmID, animalID)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	offspring := map[string][]models.BreedingOffspring{}
+	for rows.Next() {
+		var recordID string
+		var child models.BreedingOffspring
+		if err := rows.Scan(&recordID, &child.ID, &child.TagNumber, &child.Gender); err != nil {
+			return err
+		}
+		offspring[recordID] = append(offspring[recordID], child)
+	}
+	if err := rows.Err(); err != nil {
+		return err
+	}
+
+	for i := range records {
+		records[i].Offspring = []models.BreedingOffspring{}
+		if children, ok := offspring[records[i].ID]; ok {
+			records[i].Offspring = children
+		}
+	}
+	return nil
+}
+
+// query üreme kaydı satırlarını okur; açık gebeliklerde doğuma kalan gün hesaplanır
+func (s *BreedingService) query(query string, args ...interface{}) ([]models.BreedingRecord, error) {
+	rows, err := s.db.Query(query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	now := time.Now().UTC()
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC)
+
+	records := []models.BreedingRecord{}
+	for rows.Next() {
+		var record models.BreedingRecord
+		var sireID, checkDate, expectedBirth, birthDate sql.NullString
+		if err := rows.Scan(&record.ID, &record.AnimalID, &record.TagNumber, &record.Method, &record.ServiceDate,
+			&sireID, &record.Sire, &record.Technician, &record.PregnancyStatus, &checkDate, &expectedBirth,
+			&birthDate, &record.Notes, &record.CreatedAt, &record.UpdatedAt); err != nil {
+			return nil, err
+		}
+		record.SireID = utils.NullStringToPtr(sireID)
+		record.PregnancyCheckDate = utils.NullStringToPtr(checkDate)
+		record.ExpectedBirthDate = utils.NullStringToPtr(expectedBirth)
+		record.BirthDate = utils.NullStringToPtr(birthDate)
+
+		if expectedBirth.Valid && ruleSelected(openPregnancyStatuses, record.PregnancyStatus) {
+			if expected, err := time.Parse("2006-01-02", expectedBirth.String); err == nil {
+				days := int(expected.Sub(today).Hours() / 24)
+				record.DaysUntilBirth = &days
+			}
+		}
+		records = append(records, record)
+	}
+	return records, rows.Err()
+}
+
+// linkOffspring kayda bağlı yavruları istekteki hayvanlarla değiştirir; yavruların boş anne/baba bilgisi annenin
+// küpe numarası ve baba adıyla doldurulur
+func linkOffspring(tx *sql.Tx, farmID, recordID string, dam breedingAnimal, req models.BreedingRecordRequest) error {
+	if _, err := tx.Exec(`
+		UPDATE livestock SET breeding_record_id = NULL, updated_at = CURRENT_TIMESTAMP
+		WHERE breeding_record_id = ? AND user_id = ?
+	`, recordID, farmID); err != nil {
+		return err
+	}
+
+	for _, offspringID := range req.OffspringIDs {
+		if offspringID == dam.id {
+			return ErrOffspringNotFound
+		}
+		result, err := tx.Exec(`
+			UPDATE livestock
+			SET breeding_record_id = ?, mother = COALESCE(NULLIF(mother, ''), ?),
+			    father = COALESCE(NULLIF(father, ''), NULLIF(?, '')), updated_at = CURRENT_TIMESTAMP
+			WHERE id = ? AND user_id = ?
+		`, recordID, dam.tag, req.Sire, offspringID, farmID)
+		if err != nil {
+			return err
+		}
+		if affected, _ := result.RowsAffected(); affected == 0 {
+			return ErrOffspringNotFound
+		}
+	}
+	return nil
+}
@@ -0,0 +1,125 @@
+package services
+
+import (
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+
+	"agri-management-api/internal/models"
+)
+
+// cacheHintRule bir rota öneki için kaynak türü ve istemci önbelleği süreleri (saniye); maxAge ve
+// staleWhileRevalidate sıfırsa yanıt saklanmamalıdır
+type cacheHintRule struct {
+	pattern              string
+	resource             string
+	maxAge               int
+	staleWhileRevalidate int
+}
+
+// defaultCacheHints kaynak türlerine göre varsayılan önbellek süreleri; * ile biten desenler önektir
+var defaultCacheHints = []cacheHintRule{
+	{"/api/v1/*", "default", 60, 600},
+	{"/api/v1/auth/*", "auth", 0, 0},
+	{"/api/v1/admin/*", "admin", 0, 0},
+	{"/api/v1/advisor/*", "advisor", 0, 0},
+	{"/api/v1/support/*", "support", 0, 0},
+	{"/api/v1/notifications*", "notifications", 0, 300},
+	{"/api/v1/search*", "search", 0, 60},
+	{"/api/v1/sensors*", "sensors", 30, 300},
+	{"/api/v1/dashboard*", "dashboard", 60, 900},
+	{"/api/v1/charts*", "dashboard", 60, 900},
+	{"/api/v1/finance*", "finance", 60, 3600},
+	{"/api/v1/sales*", "finance", 60, 3600},
+	{"/api/v1/calendar*", "calendar", 120, 86400},
+	{"/api/v1/weather*", "weather", 900, 3600},
+	{"/api/v1/analytics*", "analytics", 300, 3600},
+	{"/api/v1/reports*", "analytics", 300, 3600},
+	{"/api/v1/lands*", "records", 300, 86400},
+	{"/api/v1/greenhouses*", "records", 300, 86400},
+	{"/api/v1/livestock*", "records", 300, 86400},
+	{"/api/v1/hives*", "records", 300, 86400},
+	{"/api/v1/ponds*", "records", 300, 86400},
+	{"/api/v1/fish-batches*", "records", 300, 86400},
+	{"/api/v1/production*", "records", 300, 86400},
+	{"/api/v1/categories*", "reference", 86400, 604800},
+	{"/api/v1/protocols*", "reference", 3600, 86400},
+	{"/api/v1/templates*", "reference", 3600, 86400},
+	{"/api/v1/features*", "reference", 300, 3600},
+	{"/api/v1/changelog*", "reference", 3600, 86400},
+	{"/api/v1/public/*", "public", 3600, 86400},
+}
+
+// CacheHintRules varsayılan önbellek sürelerini CACHE_HINTS değişkenindeki tanımlarla birleştirir ve en
+// özel desen önce gelecek şekilde sıralar. Biçim virgülle ayrılmış "/api/v1/weather*=1800:7200" girdileridir
+// (max-age ve isteğe bağlı stale-while-revalidate saniyesi); varsayılan listede olmayan desenler eşleşen
+// varsayılan kuralın kaynak türünü alır
+func CacheHintRules() []cacheHintRule {
+	rules := append([]cacheHintRule(nil), defaultCacheHints...)
+	sortCacheHintRules(rules)
+
+	for _, entry := range strings.Split(os.Getenv("CACHE_HINTS"), ",") {
+		pattern, values, ok := strings.Cut(strings.TrimSpace(entry), "=")
+		pattern = strings.TrimSpace(pattern)
+		if !ok || pattern == "" {
+			continue
+		}
+		maxAge, stale, hasStale := strings.Cut(values, ":")
+		rule := matchCacheHint(strings.TrimSuffix(pattern, "*"), rules)
+		rule.pattern = pattern
+		if value, err := strconv.Atoi(strings.TrimSpace(maxAge)); err == nil && value >= 0 {
+			rule.maxAge = value
+		}
+		if hasStale {
+			if value, err := strconv.Atoi(strings.TrimSpace(stale)); err == nil && value >= 0 {
+				rule.staleWhileRevalidate = value
+			}
+		}
+
+		replaced := false
+		for i := range rules {
+			if rules[i].pattern == pattern {
+				rules[i], replaced = rule, true
+			}
+		}
+		if !replaced {
+			rules = append(rules, rule)
+		}
+	}
+
+	sortCacheHintRules(rules)
+	return rules
+}
+
+// sortCacheHintRules en uzun (en özel) deseni öne alır
+func sortCacheHintRules(rules []cacheHintRule) {
+	sort.SliceStable(rules, func(i, j int) bool {
+		return len(rules[i].pattern) > len(rules[j].pattern)
+	})
+}
+
+// matchCacheHint rotaya uyan ilk kuralı döner; hiçbiri uymazsa önbelleğe alınmayan bir kural döner
+func matchCacheHint(route string, rules []cacheHintRule) cacheHintRule {
+	for _, rule := range rules {
+		if prefix, ok := strings.CutSuffix(rule.pattern, "*"); ok {
+			if strings.HasPrefix(route, prefix) {
+				return rule
+			}
+		} else if route == rule.pattern {
+			return rule
+		}
+	}
+	return cacheHintRule{resource: "default"}
+}
+
+// CacheHintFor rota deseninin (ör. /api/v1/lands/:id) önbellek ipucunu döner
+func CacheHintFor(route string, rules []cacheHintRule) *models.CacheHint {
+	rule := matchCacheHint(route, rules)
+	return &models.CacheHint{
+		Resource:             rule.resource,
+		MaxAge:               rule.maxAge,
+		StaleWhileRevalidate: rule.staleWhileRevalidate,
+		NoStore:              rule.maxAge == 0 && rule.staleWhileRevalidate == 0,
+	}
+}
@@ -0,0 +1,233 @@
+package services
+
+import (
+	"database/sql"
+	"encoding/csv"
+	"io"
+	"time"
+
+	"agri-management-api/internal/models"
+)
+
+// Takvim dışa aktarım formatları
+const (
+	CalendarExportCSV  = "csv"
+	CalendarExportXLSX = "xlsx"
+)
+
+// Görev durumları; görevler arazi aktiviteleridir ve durum planlanan/gerçekleşen tarihlerden hesaplanır
+const (
+	TaskStatusPlanned   = "planned"
+	TaskStatusOverdue   = "overdue"
+	TaskStatusCompleted = "completed"
+)
+
+// taskStatusLabels görev durumlarının dışa aktarımda kullanılan Türkçe adları
+var taskStatusLabels = map[string]string{
+	TaskStatusPlanned:   "Planlandı",
+	TaskStatusOverdue:   "Gecikti",
+	TaskStatusCompleted: "Tamamlandı",
+}
+
+// IsTaskStatus değerin geçerli bir görev durumu olup olmadığını döner
+func IsTaskStatus(status string) bool {
+	_, ok := taskStatusLabels[status]
+	return ok
+}
+
+// CalendarExportService takvim etkinliklerini ve görevleri tablo dosyalarına aktarır
+type CalendarExportService struct {
+	db    *sql.DB
+	links *RecordLinkService
+}
+
+// NewCalendarExportService yeni takvim dışa aktarım servisi oluşturur
+func NewCalendarExportService(db *sql.DB) *CalendarExportService {
+	return &CalendarExportService{db: db, links: NewRecordLinkService(db)}
+}
+
+// Events başlangıç tarihi aralıktaki etkinlikleri ilişkili kayıt adlarıyla birlikte başlangıca göre sıralı döner
+func (s *CalendarExportService) Events(farmID string, filter models.CalendarExportFilter) ([]models.CalendarExportEvent, error) {
+	query := `
+		SELECT id, title, COALESCE(description, ''), type, start_date, end_date, COALESCE(is_all_day, FALSE),
+		       COALESCE(status, 'pending'), COALESCE(priority, 'medium'), COALESCE(location, ''),
+		       COALESCE(related_entity_type, ''), COALESCE(related_entity_id, '')
+		FROM events
+		WHERE user_id = ?`
+	args := []interface{}{farmID}
+	if filter.StartDate != nil {
+		query += " AND date(start_date) >= ?"
+		args = append(args, filter.StartDate.Format("2006-01-02"))
+	}
+	if filter.EndDate != nil {
+		query += " AND date(start_date) <= ?"
+		args = append(args, filter.EndDate.Format("2006-01-02"))
+	}
+	if filter.Type != "" {
+		query += " AND type = ?"
+		args = append(args, filter.Type)
+	}
+	if filter.Status != "" {
+		query += " AND status = ?"
+		args = append(args, filter.Status)
+	}
+
+	rows, err := s.db.Query(query+" ORDER BY start_date ASC", args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	events := []models.CalendarExportEvent{}
+	for rows.Next() {
+		var event models.CalendarExportEvent
+		var endDate sql.NullTime
+		err := rows.Scan(&event.ID, &event.Title, &event.Description, &event.Type, &event.StartDate, &endDate,
+			&event.IsAllDay, &event.Status, &event.Priority, &event.Location, &event.RelatedType, &event.RelatedID)
+		if err != nil {
+			return nil, err
+		}
+		if endDate.Valid {
+			event.EndDate = &endDate.Time
+		}
+		events = append(events, event)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	names := map[string]string{}
+	for i, event := range events {
+		if event.RelatedID == "" || !IsLinkableEntity(event.RelatedType) {
+			continue
+		}
+		key := event.RelatedType + "/" + event.RelatedID
+		name, ok := names[key]
+		if !ok {
+			name, _ = s.links.EntityName(farmID, event.RelatedType, event.RelatedID)
+			names[key] = name
+		}
+		events[i].RelatedName = name
+	}
+	return events, nil
+}
+
+// Tasks planlanan veya gerçekleşen tarihi aralıktaki arazi aktivitelerini görev olarak tarihe göre sıralı döner
+func (s *CalendarExportService) Tasks(farmID string, filter models.CalendarExportFilter) ([]models.CalendarExportTask, error) {
+	query := `
+		SELECT a.id, a.land_id, l.name, a.type, a.description, a.scheduled_date, a.actual_date,
+		       COALESCE(a.cost, 0), COALESCE(a.result, ''), COALESCE(a.notes, '')
+		FROM land_activities a
+		JOIN lands l ON l.id = a.land_id
+		WHERE l.user_id = ?`
+	args := []interface{}{farmID}
+	if filter.StartDate != nil {
+		query += " AND date(COALESCE(a.actual_date, a.scheduled_date, a.created_at)) >= ?"
+		args = append(args, filter.StartDate.Format("2006-01-02"))
+	}
+	if filter.EndDate != nil {
+		query += " AND date(COALESCE(a.actual_date, a.scheduled_date, a.created_at)) <= ?"
+		args = append(args, filter.EndDate.Format("2006-01-02"))
+	}
+	if filter.Type != "" {
+		query += " AND a.type = ?"
+		args = append(args, filter.Type)
+	}
+
+	rows, err := s.db.Query(query+" ORDER BY COALESCE(a.actual_date, a.scheduled_date, a.created_at) ASC", args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	today := time.Now().Format("2006-01-02")
+	tasks := []models.CalendarExportTask{}
+	for rows.Next() {
+		var task models.CalendarExportTask
+		var scheduled, actual sql.NullTime
+		err := rows.Scan(&task.ID, &task.LandID, &task.LandName, &task.Type, &task.Description, &scheduled, &actual,
+			&task.Cost, &task.Result, &task.Notes)
+		if err != nil {
+			return nil, err
+		}
+		if scheduled.Valid {
+			task.ScheduledDate = &scheduled.Time
+		}
+		if actual.Valid {
+			task.ActualDate = &actual.Time
+		}
+
+		switch {
+		case task.ActualDate != nil:
+			task.Status = TaskStatusCompleted
+		case task.ScheduledDate != nil && task.ScheduledDate.Format("2006-01-02") < today:
+			task.Status = TaskStatusOverdue
+		default:
+			task.Status = TaskStatusPlanned
+		}
+		if filter.Status != "" && task.Status != filter.Status {
+			continue
+		}
+		tasks = append(tasks, task)
+	}
+	return tasks, rows.Err()
+}
+
+// EventRecords etkinlikleri başlık satırıyla birlikte tablo satırlarına çevirir; tarihler çiftlik ayarlarındaki
+// biçimde yazılır, tüm gün etkinliklerinde saat yazılmaz. Serbest metin alanları formül olarak yazılmaz
+func EventRecords(events []models.CalendarExportEvent, f Formatter) [][]string {
+	records := [][]string{
+		{"Başlık", "Tür", "Durum", "Öncelik", "Başlangıç", "Bitiş", "Tüm Gün", "Konum", "İlgili Kayıt Türü", "İlgili Kayıt", "Açıklama"},
+	}
+	for _, event := range events {
+		format := f.DateTime
+		if event.IsAllDay {
+			format = f.Date
+		}
+		allDay := "Hayır"
+		if event.IsAllDay {
+			allDay = "Evet"
+		}
+		endDate := ""
+		if event.EndDate != nil {
+			endDate = format(*event.EndDate)
+		}
+		records = append(records, []string{
+			SpreadsheetText(event.Title), SpreadsheetText(event.Type), SpreadsheetText(event.Status),
+			SpreadsheetText(event.Priority), format(event.StartDate), endDate, allDay, SpreadsheetText(event.Location),
+			SpreadsheetText(event.RelatedType), SpreadsheetText(event.RelatedName), SpreadsheetText(event.Description),
+		})
+	}
+	return records
+}
+
+// TaskRecords görevleri başlık satırıyla birlikte tablo satırlarına çevirir
+func TaskRecords(tasks []models.CalendarExportTask, f Formatter) [][]string {
+	records := [][]string{
+		{"Arazi", "Tür", "Açıklama", "Planlanan Tarih", "Gerçekleşen Tarih", "Durum", "Maliyet", "Sonuç", "Notlar"},
+	}
+	for _, task := range tasks {
+		records = append(records, []string{
+			SpreadsheetText(task.LandName), SpreadsheetText(task.Type), SpreadsheetText(task.Description), f.OptionalDate(task.ScheduledDate),
+			f.OptionalDate(task.ActualDate), taskStatusLabels[task.Status],
+			f.Amount(task.Cost), SpreadsheetText(task.Result), SpreadsheetText(task.Notes),
+		})
+	}
+	return records
+}
+
+// WriteCalendarExport tablo satırlarını istenen formatta yazar; CSV dosyaları Excel'in Türkçe ayarlarıyla
+// açılabilmesi için noktalı virgülle ayrılır ve UTF-8 BOM ile başlar
+func WriteCalendarExport(w io.Writer, format, sheetName string, records [][]string) error {
+	if format == CalendarExportXLSX {
+		return WriteXLSX(w, sheetName, records)
+	}
+
+	if _, err := io.WriteString(w, "\ufeff"); err != nil {
+		return err
+	}
+	writer := csv.NewWriter(w)
+	writer.Comma = ';'
+	writer.WriteAll(records)
+	return writer.Error()
+}
@@ -0,0 +1,153 @@
+package services
+
+import (
+	"database/sql"
+	"time"
+
+	"agri-management-api/internal/models"
+)
+
+// heatmapLevels ısı haritasındaki yoğunluk kademesi sayısı (0 boş gün dahil değil)
+const heatmapLevels = 4
+
+// heatmapEventsQuery başlangıç gününe göre takvim etkinlikleri
+const heatmapEventsQuery = `
+	SELECT date(start_date) AS day, COUNT(*) FROM events
+	WHERE user_id = ? AND date(start_date) BETWEEN ? AND ?
+	GROUP BY day`
+
+// heatmapActivitiesQuery yapıldığı, yoksa planlandığı güne göre arazi aktiviteleri
+const heatmapActivitiesQuery = `
+	SELECT date(COALESCE(la.actual_date, la.scheduled_date, la.created_at)) AS day, COUNT(*)
+	FROM land_activities la
+	JOIN lands l ON l.id = la.land_id
+	WHERE l.user_id = ? AND date(COALESCE(la.actual_date, la.scheduled_date, la.created_at)) BETWEEN ? AND ?
+	GROUP BY day`
+
+// heatmapRecordQueries oluşturulduğu güne göre sayılan çiftlik kayıtları
+var heatmapRecordQueries = []string{
+	`SELECT date(created_at) AS day, COUNT(*) FROM livestock
+	 WHERE user_id = ? AND date(created_at) BETWEEN ? AND ? GROUP BY day`,
+	`SELECT date(created_at) AS day, COUNT(*) FROM lands
+	 WHERE user_id = ? AND date(created_at) BETWEEN ? AND ? GROUP BY day`,
+	`SELECT date(created_at) AS day, COUNT(*) FROM production
+	 WHERE user_id = ? AND date(created_at) BETWEEN ? AND ? GROUP BY day`,
+	`SELECT date(created_at) AS day, COUNT(*) FROM transactions
+	 WHERE user_id = ? AND date(created_at) BETWEEN ? AND ? GROUP BY day`,
+	`SELECT date(created_at) AS day, COUNT(*) FROM livestock_movements
+	 WHERE user_id = ? AND date(created_at) BETWEEN ? AND ? GROUP BY day`,
+	`SELECT date(r.created_at) AS day, COUNT(*) FROM health_records r
+	 JOIN livestock l ON l.id = r.livestock_id
+	 WHERE l.user_id = ? AND date(r.created_at) BETWEEN ? AND ? GROUP BY day`,
+	`SELECT date(m.created_at) AS day, COUNT(*) FROM milk_production m
+	 JOIN livestock l ON l.id = m.livestock_id
+	 WHERE l.user_id = ? AND date(m.created_at) BETWEEN ? AND ? GROUP BY day`,
+}
+
+// CalendarHeatmapService takvim ısı haritası için günlük iş yoğunluğunu hesaplar
+type CalendarHeatmapService struct {
+	db *sql.DB
+}
+
+// NewCalendarHeatmapService yeni calendar heatmap service oluşturur
+func NewCalendarHeatmapService(db *sql.DB) *CalendarHeatmapService {
+	return &CalendarHeatmapService{db: db}
+}
+
+// Year yılın her günü için etkinlik, arazi aktivitesi ve oluşturulan kayıt sayılarını döner. Her gün
+// en yoğun güne göre 0-4 arası bir kademe alır; boş günler 0'dır
+func (s *CalendarHeatmapService) Year(farmID string, year int) (models.CalendarHeatmap, error) {
+	heatmap := models.CalendarHeatmap{Year: year, Days: []models.CalendarHeatmapDay{}}
+
+	start := time.Date(year, time.January, 1, 0, 0, 0, 0, time.UTC)
+	end := start.AddDate(1, 0, -1)
+	from, to := start.Format("2006-01-02"), end.Format("2006-01-02")
+
+	events, err := s.counts(heatmapEventsQuery, farmID, from, to, nil)
+	if err != nil {
+		return heatmap, err
+	}
+	activities, err := s.counts(heatmapActivitiesQuery, farmID, from, to, nil)
+	if err != nil {
+		return heatmap, err
+	}
+	records := map[string]int{}
+	for _, query := range heatmapRecordQueries {
+		if _, err := s.counts(query, farmID, from, to, records); err != nil {
+			return heatmap, err
+		}
+	}
+
+	streak := 0
+	for day := start; !day.After(end); day = day.AddDate(0, 0, 1) {
+		key := day.Format("2006-01-02")
+		entry := models.CalendarHeatmapDay{
+			Date:       key,
+			Events:     events[key],
+			Activities: activities[key],
+			Records:    records[key],
+		}
+		entry.Total = entry.Events + entry.Activities + entry.Records
+
+		heatmap.TotalEvents += entry.Events
+		heatmap.TotalActivities += entry.Activities
+		heatmap.TotalRecords += entry.Records
+		if entry.Total > heatmap.MaxTotal {
+			heatmap.MaxTotal = entry.Total
+		}
+
+		if entry.Total > 0 {
+			heatmap.ActiveDays++
+			streak++
+			if streak > heatmap.LongestStreak {
+				heatmap.LongestStreak = streak
+			}
+		} else {
+			streak = 0
+		}
+
+		heatmap.Days = append(heatmap.Days, entry)
+	}
+
+	for i := range heatmap.Days {
+		heatmap.Days[i].Level = heatmapLevel(heatmap.Days[i].Total, heatmap.MaxTotal)
+	}
+
+	return heatmap, nil
+}
+
+// counts gün bazında sayıları verilen haritaya ekler; harita nil ise yenisi oluşturulur
+func (s *CalendarHeatmapService) counts(query, farmID, from, to string, into map[string]int) (map[string]int, error) {
+	if into == nil {
+		into = map[string]int{}
+	}
+
+	rows, err := s.db.Query(query, farmID, from, to)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var day sql.NullString
+		var count int
+		if err := rows.Scan(&day, &count); err != nil || !day.Valid {
+			continue
+		}
+		into[day.String] += count
+	}
+
+	return into, rows.Err()
+}
+
+// heatmapLevel günün toplamını en yoğun güne oranla 1-4 arası kademeye çevirir
+func heatmapLevel(total, max int) int {
+	if total <= 0 || max <= 0 {
+		return 0
+	}
+	level := (total*heatmapLevels + max - 1) / max
+	if level > heatmapLevels {
+		level = heatmapLevels
+	}
+	return level
+}
@@ -0,0 +1,359 @@
+package services
+
+import (
+	"database/sql"
+	"encoding/csv"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+
+	"agri-management-api/internal/models"
+	"agri-management-api/internal/utils"
+)
+
+// carbonPeriodLayout karbon ayak izi dönemi biçimi (YYYY-MM)
+const carbonPeriodLayout = "2006-01"
+
+// carbonBenchmarkPerHectare sürdürülebilirlik puanında 50 puana karşılık gelen yıllık emisyon yoğunluğu (kg CO2e/ha)
+const carbonBenchmarkPerHectare = 5000.0
+
+// livestockEmissionFactors hayvan başına yıllık enterik fermantasyon ve gübre yönetimi emisyonları (kg CO2e/baş/yıl);
+// IPCC 2006 Tier 1 varsayılan metan değerlerinin GWP100 = 28 ile karşılığıdır
+var livestockEmissionFactors = map[string]float64{
+	"cattle":  (57 + 6) * 28,
+	"sheep":   (8 + 0.28) * 28,
+	"goat":    (5 + 0.2) * 28,
+	"chicken": 0.02 * 28,
+	"other":   (5 + 0.2) * 28,
+}
+
+// Girdi emisyon katsayıları
+const (
+	// fertilizerNitrogenFactor uygulanan kg azot başına doğrudan N2O emisyonu: 0.01 × 44/28 × GWP100 265
+	fertilizerNitrogenFactor = 0.01 * 44 / 28 * 265
+	// dieselFactor litre dizel başına yanma emisyonu
+	dieselFactor = 2.68
+	// electricityFactor kWh başına Türkiye şebeke elektriği emisyonu
+	electricityFactor = 0.442
+)
+
+// landHectareFactors arazi birimlerinin hektar karşılıkları
+var landHectareFactors = map[string]float64{
+	"dönüm":   0.1,
+	"donum":   0.1,
+	"dekar":   0.1,
+	"da":      0.1,
+	"hektar":  1,
+	"hectare": 1,
+	"ha":      1,
+	"acre":    0.4047,
+	"m2":      0.0001,
+	"m²":      0.0001,
+}
+
+// CarbonService hayvan sayısı, gübre ve enerji kullanımından tahmini sera gazı emisyonlarını hesaplar
+type CarbonService struct {
+	db *sql.DB
+}
+
+// NewCarbonService yeni carbon service oluşturur
+func NewCarbonService(db *sql.DB) *CarbonService {
+	return &CarbonService{db: db}
+}
+
+// CarbonCoefficients hesaplamada kullanılan emisyon katsayılarını döner
+func CarbonCoefficients() []models.CarbonCoefficient {
+	coefficients := []models.CarbonCoefficient{}
+	for _, animalType := range []string{"cattle", "sheep", "goat", "chicken", "other"} {
+		coefficients = append(coefficients, models.CarbonCoefficient{
+			Source: "livestock", Key: animalType, Unit: "baş/yıl", Factor: round2(livestockEmissionFactors[animalType]),
+			Reference: "IPCC 2006 Tier 1 enterik fermantasyon ve gübre yönetimi (CH4, GWP100 28)",
+		})
+	}
+
+	return append(coefficients,
+		models.CarbonCoefficient{Source: "fertilizer", Key: "nitrogen", Unit: "kg N", Factor: round2(fertilizerNitrogenFactor),
+			Reference: "IPCC 2006 doğrudan N2O emisyon faktörü EF1 = 0.01 (GWP100 265)"},
+		models.CarbonCoefficient{Source: "fuel", Key: "diesel", Unit: "L", Factor: dieselFactor,
+			Reference: "IPCC 2006 dizel yanma emisyonu"},
+		models.CarbonCoefficient{Source: "electricity", Key: "grid", Unit: "kWh", Factor: electricityFactor,
+			Reference: "Türkiye şebeke elektriği emisyon faktörü"},
+	)
+}
+
+// Footprint dönemin karbon ayak izini döner; tamamlanmış aylar ilk hesaplamada saklanır ve sonraki
+// sorgularda aynı değer kullanılır, recalculate ile yeniden hesaplanır
+func (s *CarbonService) Footprint(userID, period string, recalculate bool) (models.CarbonFootprint, error) {
+	start, err := time.Parse(carbonPeriodLayout, period)
+	if err != nil {
+		return models.CarbonFootprint{}, err
+	}
+	closed := !start.AddDate(0, 1, 0).After(time.Now())
+
+	if closed && !recalculate {
+		if footprint, err := s.stored(userID, period); err == nil {
+			return footprint, nil
+		}
+	}
+
+	footprint, err := s.calculate(userID, start)
+	if err != nil {
+		return footprint, err
+	}
+
+	if closed {
+		sources, _ := utils.ToJSON(footprint.Sources)
+		_, err = s.db.Exec(`
+			INSERT INTO carbon_footprints (id, user_id, period, livestock, fertilizer, fuel, electricity, total, sources, calculated_at)
+			VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, CURRENT_TIMESTAMP)
+			ON CONFLICT (user_id, period) DO UPDATE SET livestock = excluded.livestock, fertilizer = excluded.fertilizer,
+				fuel = excluded.fuel, electricity = excluded.electricity, total = excluded.total, sources = excluded.sources,
+				calculated_at = CURRENT_TIMESTAMP
+		`, utils.GenerateID(), userID, period, footprint.Livestock, footprint.Fertilizer, footprint.Fuel,
+			footprint.Electricity, footprint.Total, sources)
+		if err != nil {
+			return footprint, err
+		}
+		footprint.Stored = true
+	}
+
+	return footprint, nil
+}
+
+// Year yılın aylık karbon ayak izlerini ve toplamlarını döner; gelecek aylar dahil edilmez
+func (s *CarbonService) Year(userID string, year int, recalculate bool) (models.CarbonReport, error) {
+	report := models.CarbonReport{Year: year, Months: []models.CarbonFootprint{}, Coefficients: CarbonCoefficients()}
+
+	now := time.Now()
+	for month := time.January; month <= time.December; month++ {
+		start := time.Date(year, month, 1, 0, 0, 0, 0, time.UTC)
+		if start.After(now) {
+			break
+		}
+
+		footprint, err := s.Footprint(userID, start.Format(carbonPeriodLayout), recalculate)
+		if err != nil {
+			return report, err
+		}
+
+		report.Livestock += footprint.Livestock
+		report.Fertilizer += footprint.Fertilizer
+		report.Fuel += footprint.Fuel
+		report.Electricity += footprint.Electricity
+		report.Total += footprint.Total
+		report.Months = append(report.Months, footprint)
+	}
+
+	report.Livestock = round2(report.Livestock)
+	report.Fertilizer = round2(report.Fertilizer)
+	report.Fuel = round2(report.Fuel)
+	report.Electricity = round2(report.Electricity)
+	report.Total = round2(report.Total)
+
+	hectares, err := s.hectares(userID)
+	if err != nil {
+		return report, err
+	}
+	report.Hectares = round2(hectares)
+	if hectares > 0 && len(report.Months) > 0 {
+		// Yıl tamamlanmadıysa yoğunluk geçen ay sayısına göre yıllığa çevrilir
+		report.PerHectare = round2(report.Total / hectares * 12 / float64(len(report.Months)))
+	}
+
+	return report, nil
+}
+
+// SustainabilityScore son 12 ayın hektar başına emisyon yoğunluğundan 0-100 arası sürdürülebilirlik puanı hesaplar;
+// yoğunluk karşılaştırma değerine eşitse puan 50'dir
+func (s *CarbonService) SustainabilityScore(userID string) (float64, error) {
+	now := time.Now()
+	total := 0.0
+	for i := 1; i <= 12; i++ {
+		footprint, err := s.Footprint(userID, now.AddDate(0, -i, 0).Format(carbonPeriodLayout), false)
+		if err != nil {
+			return 0, err
+		}
+		total += footprint.Total
+	}
+
+	hectares, err := s.hectares(userID)
+	if err != nil {
+		return 0, err
+	}
+	if hectares < 1 {
+		hectares = 1
+	}
+
+	intensity := total / hectares
+	return round2(100 * carbonBenchmarkPerHectare / (carbonBenchmarkPerHectare + intensity)), nil
+}
+
+// calculate ayın emisyonlarını kaynak bazında hesaplar
+func (s *CarbonService) calculate(userID string, start time.Time) (models.CarbonFootprint, error) {
+	end := start.AddDate(0, 1, 0)
+	footprint := models.CarbonFootprint{Period: start.Format(carbonPeriodLayout), Sources: []models.CarbonSource{}}
+
+	// Ay içinde çiftlikte bulunan hayvanlar; edinme tarihi yoksa doğum veya kayıt tarihi, çıkış olarak satış veya kesim tarihi kullanılır
+	rows, err := s.db.Query(`
+		SELECT l.type, COUNT(*) FROM livestock l
+		LEFT JOIN slaughter_records sr ON sr.livestock_id = l.id
+		WHERE l.user_id = ? AND COALESCE(l.acquisition_date, l.birth_date, l.created_at) < ?
+		  AND (COALESCE(l.sale_date, sr.slaughter_date) IS NULL OR COALESCE(l.sale_date, sr.slaughter_date) >= ?)
+		GROUP BY l.type
+	`, userID, end, start)
+	if err != nil {
+		return footprint, err
+	}
+	for rows.Next() {
+		var animalType string
+		var count int
+		if err := rows.Scan(&animalType, &count); err != nil {
+			continue
+		}
+		factor, ok := livestockEmissionFactors[animalType]
+		if !ok {
+			factor = livestockEmissionFactors["other"]
+		}
+		emissions := round2(float64(count) * factor / 12)
+		footprint.Livestock += emissions
+		footprint.Sources = append(footprint.Sources, models.CarbonSource{
+			Source: "livestock", Key: animalType, Activity: float64(count), Unit: "baş", Factor: round2(factor / 12), Emissions: emissions,
+		})
+	}
+	rows.Close()
+
+	var nitrogen float64
+	err = s.db.QueryRow(`
+		SELECT COALESCE(SUM(la.fertilizer_kg * la.nitrogen_percent / 100), 0)
+		FROM land_activities la JOIN lands l ON la.land_id = l.id
+		WHERE l.user_id = ? AND COALESCE(la.actual_date, la.scheduled_date) >= ? AND COALESCE(la.actual_date, la.scheduled_date) < ?
+	`, userID, start, end).Scan(&nitrogen)
+	if err != nil {
+		return footprint, err
+	}
+	if nitrogen > 0 {
+		footprint.Fertilizer = round2(nitrogen * fertilizerNitrogenFactor)
+		footprint.Sources = append(footprint.Sources, models.CarbonSource{
+			Source: "fertilizer", Key: "nitrogen", Activity: round2(nitrogen), Unit: "kg N", Factor: round2(fertilizerNitrogenFactor), Emissions: footprint.Fertilizer,
+		})
+	}
+
+	usage := map[string]float64{}
+	rows, err = s.db.Query(`
+		SELECT m.type, COALESCE(SUM(r.consumption), 0)
+		FROM meter_readings r JOIN utility_meters m ON r.meter_id = m.id
+		WHERE r.user_id = ? AND m.type IN ('fuel', 'electricity') AND r.reading_date >= ? AND r.reading_date < ?
+		GROUP BY m.type
+	`, userID, start, end)
+	if err != nil {
+		return footprint, err
+	}
+	for rows.Next() {
+		var meterType string
+		var consumption float64
+		if err := rows.Scan(&meterType, &consumption); err != nil {
+			continue
+		}
+		usage[meterType] = consumption
+	}
+	rows.Close()
+
+	if usage["fuel"] > 0 {
+		footprint.Fuel = round2(usage["fuel"] * dieselFactor)
+		footprint.Sources = append(footprint.Sources, models.CarbonSource{
+			Source: "fuel", Key: "diesel", Activity: round2(usage["fuel"]), Unit: "L", Factor: dieselFactor, Emissions: footprint.Fuel,
+		})
+	}
+	if usage["electricity"] > 0 {
+		footprint.Electricity = round2(usage["electricity"] * electricityFactor)
+		footprint.Sources = append(footprint.Sources, models.CarbonSource{
+			Source: "electricity", Key: "grid", Activity: round2(usage["electricity"]), Unit: "kWh", Factor: electricityFactor, Emissions: footprint.Electricity,
+		})
+	}
+
+	footprint.Livestock = round2(footprint.Livestock)
+	footprint.Total = round2(footprint.Livestock + footprint.Fertilizer + footprint.Fuel + footprint.Electricity)
+	footprint.CalculatedAt = time.Now()
+	return footprint, nil
+}
+
+// stored saklanmış dönem karbon ayak izini getirir
+func (s *CarbonService) stored(userID, period string) (models.CarbonFootprint, error) {
+	footprint := models.CarbonFootprint{Period: period, Stored: true}
+	var sources string
+
+	err := s.db.QueryRow(`
+		SELECT livestock, fertilizer, fuel, electricity, total, COALESCE(sources, '[]'), calculated_at
+		FROM carbon_footprints WHERE user_id = ? AND period = ?
+	`, userID, period).Scan(&footprint.Livestock, &footprint.Fertilizer, &footprint.Fuel, &footprint.Electricity,
+		&footprint.Total, &sources, &footprint.CalculatedAt)
+	if err != nil {
+		return footprint, err
+	}
+
+	footprint.Sources = []models.CarbonSource{}
+	utils.FromJSON(sources, &footprint.Sources)
+	return footprint, nil
+}
+
+// hectares kullanıcının toplam arazi alanını hektar olarak döner; bilinmeyen birimler dönüm kabul edilir
+func (s *CarbonService) hectares(userID string) (float64, error) {
+	rows, err := s.db.Query("SELECT area, COALESCE(unit, '') FROM lands WHERE user_id = ?", userID)
+	if err != nil {
+		return 0, err
+	}
+	defer rows.Close()
+
+	total := 0.0
+	for rows.Next() {
+		var area float64
+		var unit string
+		if err := rows.Scan(&area, &unit); err != nil {
+			continue
+		}
+		factor, ok := landHectareFactors[strings.ToLower(strings.TrimSpace(unit))]
+		if !ok {
+			factor = landHectareFactors["dönüm"]
+		}
+		total += area * factor
+	}
+	return total, rows.Err()
+}
+
+// WriteCarbonCSV yıllık karbon raporunu sertifikasyon başvurularında kullanılmak üzere aylık emisyonlar
+// ve kullanılan katsayılarla birlikte noktalı virgülle ayrılmış CSV olarak yazar
+func WriteCarbonCSV(w io.Writer, report models.CarbonReport) error {
+	writer := csv.NewWriter(w)
+	writer.Comma = ';'
+
+	format := func(value float64) string {
+		return strconv.FormatFloat(value, 'f', 2, 64)
+	}
+
+	records := [][]string{
+		{"Dönem", "Hayvancılık (kg CO2e)", "Gübre (kg CO2e)", "Yakıt (kg CO2e)", "Elektrik (kg CO2e)", "Toplam (kg CO2e)"},
+	}
+	for _, month := range report.Months {
+		records = append(records, []string{
+			month.Period, format(month.Livestock), format(month.Fertilizer), format(month.Fuel), format(month.Electricity), format(month.Total),
+		})
+	}
+	records = append(records,
+		[]string{strconv.Itoa(report.Year), format(report.Livestock), format(report.Fertilizer), format(report.Fuel),
+			format(report.Electricity), format(report.Total)},
+		[]string{},
+		[]string{"Arazi (ha)", format(report.Hectares), "Hektar başına (kg CO2e/yıl)", format(report.PerHectare),
+			"Sürdürülebilirlik puanı", format(report.Score)},
+		[]string{},
+		[]string{"Kaynak", "Anahtar", "Birim", "Katsayı (kg CO2e)", "Referans"},
+	)
+	for _, coefficient := range report.Coefficients {
+		records = append(records, []string{
+			coefficient.Source, coefficient.Key, coefficient.Unit, format(coefficient.Factor), coefficient.Reference,
+		})
+	}
+
+	writer.WriteAll(records)
+	return writer.Error()
+}
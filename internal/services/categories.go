@@ -0,0 +1,92 @@
+package services
+
+import (
+	"database/sql"
+
+	"agri-management-api/internal/models"
+)
+
+// fallbackCategoryKey kayıtta bulunmayan kategoriler için kullanılan sistem kategorisi
+const fallbackCategoryKey = "other"
+
+// CategoryService kategori kayıt defterinden ikon ve renk bilgisini çözer
+type CategoryService struct {
+	db *sql.DB
+}
+
+// NewCategoryService yeni category service oluşturur
+func NewCategoryService(db *sql.DB) *CategoryService {
+	return &CategoryService{db: db}
+}
+
+// List kullanıcının görebildiği kategorileri döner; kullanıcı kategorileri aynı anahtarlı sistem kategorisini ezer
+func (s *CategoryService) List(userID, domain string) ([]models.Category, error) {
+	query := `
+		SELECT id, user_id, domain, key, label, COALESCE(icon, ''), COALESCE(color, ''), sort_order, created_at, updated_at
+		FROM categories
+		WHERE (user_id IS NULL OR user_id = ?)`
+	args := []interface{}{userID}
+
+	if domain != "" {
+		query += " AND domain = ?"
+		args = append(args, domain)
+	}
+	query += " ORDER BY domain, user_id IS NOT NULL, sort_order, label"
+
+	rows, err := s.db.Query(query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	index := map[string]int{}
+	var categories []models.Category
+	for rows.Next() {
+		var category models.Category
+		var owner sql.NullString
+
+		err := rows.Scan(
+			&category.ID, &owner, &category.Domain, &category.Key, &category.Label, &category.Icon,
+			&category.Color, &category.SortOrder, &category.CreatedAt, &category.UpdatedAt,
+		)
+		if err != nil {
+			continue
+		}
+		category.IsSystem = !owner.Valid
+
+		key := category.Domain + ":" + category.Key
+		if i, ok := index[key]; ok {
+			categories[i] = category
+			continue
+		}
+		index[key] = len(categories)
+		categories = append(categories, category)
+	}
+
+	return categories, nil
+}
+
+// Decorate kategori verilerine kayıt defterindeki ikon ve rengi uygular
+func (s *CategoryService) Decorate(userID, domain string, data []models.CategoryData) []models.CategoryData {
+	categories, err := s.List(userID, domain)
+	if err != nil {
+		return data
+	}
+
+	byKey := make(map[string]models.Category, len(categories))
+	for _, category := range categories {
+		byKey[category.Key] = category
+	}
+	fallback := byKey[fallbackCategoryKey]
+
+	for i := range data {
+		category, ok := byKey[data[i].Name]
+		if !ok {
+			category = fallback
+		}
+		data[i].Icon = category.Icon
+		data[i].Color = category.Color
+	}
+
+	return data
+}
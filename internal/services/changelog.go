@@ -0,0 +1,282 @@
+package services
+
+import (
+	"database/sql"
+	"errors"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"agri-management-api/internal/models"
+	"agri-management-api/internal/utils"
+)
+
+// ErrChangelogEntryNotFound sürüm notu bulunamadığında döner
+var ErrChangelogEntryNotFound = errors.New("sürüm notu bulunamadı")
+
+// changelogSelect sürüm notlarını okuyan sorgu
+const changelogSelect = `
+	SELECT id, version, title, COALESCE(body, ''), category, COALESCE(highlights, ''), published_at, created_at, updated_at
+	FROM changelog_entries`
+
+// ChangelogService uygulama içi sürüm notlarını ve kullanıcıların hangilerini gördüğünü yönetir
+type ChangelogService struct {
+	db *sql.DB
+}
+
+// NewChangelogService yeni changelog service oluşturur
+func NewChangelogService(db *sql.DB) *ChangelogService {
+	return &ChangelogService{db: db}
+}
+
+// Published yayınlanmış sürüm notlarını en yeni sürüm önce olacak şekilde hesabın görme durumuyla döner.
+// appVersion verilirse yalnızca o sürüme kadar olan notlar, unseenOnly ise yalnızca görülmemiş olanlar listelenir
+func (s *ChangelogService) Published(accountID, appVersion string, unseenOnly bool) (models.ChangelogResponse, error) {
+	response := models.ChangelogResponse{Entries: []models.ChangelogEntry{}}
+
+	rows, err := s.db.Query(`
+		SELECT e.id, e.version, e.title, COALESCE(e.body, ''), e.category, COALESCE(e.highlights, ''),
+		       e.published_at, e.created_at, e.updated_at, s.entry_id IS NOT NULL
+		FROM changelog_entries e
+		LEFT JOIN changelog_seen s ON s.entry_id = e.id AND s.account_id = ?
+		WHERE e.published_at IS NOT NULL AND e.published_at <= ?
+	`, accountID, time.Now().UTC())
+	if err != nil {
+		return response, err
+	}
+	defer rows.Close()
+
+	var entries []models.ChangelogEntry
+	for rows.Next() {
+		var entry models.ChangelogEntry
+		var highlights string
+		var publishedAt sql.NullTime
+		if err := rows.Scan(&entry.ID, &entry.Version, &entry.Title, &entry.Body, &entry.Category, &highlights,
+			&publishedAt, &entry.CreatedAt, &entry.UpdatedAt, &entry.Seen); err != nil {
+			continue
+		}
+		entry.Highlights = decodeHighlights(highlights)
+		entry.PublishedAt = utils.NullTimeToPtr(publishedAt)
+		if appVersion != "" && compareVersions(entry.Version, appVersion) > 0 {
+			continue
+		}
+		entries = append(entries, entry)
+	}
+	if err := rows.Err(); err != nil {
+		return response, err
+	}
+
+	sortChangelog(entries)
+	for _, entry := range entries {
+		if response.LatestVersion == "" {
+			response.LatestVersion = entry.Version
+		}
+		if !entry.Seen {
+			response.UnseenCount++
+		}
+		if unseenOnly && entry.Seen {
+			continue
+		}
+		response.Entries = append(response.Entries, entry)
+	}
+	return response, nil
+}
+
+// MarkSeen verilen sürüm notlarını hesap için görüldü olarak işaretler; ids boşsa yayınlanmış tüm notlar
+// işaretlenir. Taslak veya bilinmeyen kimlikler yok sayılır, yeni işaretlenen not sayısı döner
+func (s *ChangelogService) MarkSeen(accountID string, ids []string) (int, error) {
+	query := `
+		INSERT OR IGNORE INTO changelog_seen (account_id, entry_id, seen_at)
+		SELECT ?, id, CURRENT_TIMESTAMP FROM changelog_entries
+		WHERE published_at IS NOT NULL AND published_at <= ?`
+	args := []interface{}{accountID, time.Now().UTC()}
+	if len(ids) > 0 {
+		query += " AND id IN (" + strings.TrimSuffix(strings.Repeat("?,", len(ids)), ",") + ")"
+		for _, id := range ids {
+			args = append(args, id)
+		}
+	}
+
+	result, err := s.db.Exec(query, args...)
+	if err != nil {
+		return 0, err
+	}
+	affected, err := result.RowsAffected()
+	return int(affected), err
+}
+
+// All taslaklar dahil tüm sürüm notlarını yönetim için döner
+func (s *ChangelogService) All() ([]models.ChangelogEntry, error) {
+	rows, err := s.db.Query(changelogSelect)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	entries := []models.ChangelogEntry{}
+	for rows.Next() {
+		entry, err := scanChangelogEntry(rows)
+		if err != nil {
+			continue
+		}
+		entries = append(entries, entry)
+	}
+	sortChangelog(entries)
+	return entries, rows.Err()
+}
+
+// Entry tek bir sürüm notunu döner
+func (s *ChangelogService) Entry(id string) (models.ChangelogEntry, error) {
+	entry, err := scanChangelogEntry(s.db.QueryRow(changelogSelect+" WHERE id = ?", id))
+	if err == sql.ErrNoRows {
+		return entry, ErrChangelogEntryNotFound
+	}
+	return entry, err
+}
+
+// Create yeni sürüm notu ekler; yayın tarihi boşsa not taslak olarak kalır
+func (s *ChangelogService) Create(entry models.ChangelogEntry) (models.ChangelogEntry, error) {
+	entry.ID = utils.GenerateID()
+	if entry.Category == "" {
+		entry.Category = "feature"
+	}
+	entry.PublishedAt = utcTimePtr(entry.PublishedAt)
+
+	_, err := s.db.Exec(`
+		INSERT INTO changelog_entries (id, version, title, body, category, highlights, published_at, created_at, updated_at)
+		VALUES (?, ?, ?, ?, ?, ?, ?, CURRENT_TIMESTAMP, CURRENT_TIMESTAMP)
+	`, entry.ID, strings.TrimSpace(entry.Version), entry.Title, entry.Body, entry.Category,
+		encodeHighlights(entry.Highlights), entry.PublishedAt)
+	if err != nil {
+		return entry, err
+	}
+	return s.Entry(entry.ID)
+}
+
+// Update sürüm notunu günceller. Yayınlanmış bir notun içeriği değişse de kullanıcıların görme durumu korunur
+func (s *ChangelogService) Update(id string, entry models.ChangelogEntry) (models.ChangelogEntry, error) {
+	if entry.Category == "" {
+		entry.Category = "feature"
+	}
+	entry.PublishedAt = utcTimePtr(entry.PublishedAt)
+
+	result, err := s.db.Exec(`
+		UPDATE changelog_entries
+		SET version = ?, title = ?, body = ?, category = ?, highlights = ?, published_at = ?, updated_at = CURRENT_TIMESTAMP
+		WHERE id = ?
+	`, strings.TrimSpace(entry.Version), entry.Title, entry.Body, entry.Category,
+		encodeHighlights(entry.Highlights), entry.PublishedAt, id)
+	if err != nil {
+		return entry, err
+	}
+	if affected, _ := result.RowsAffected(); affected == 0 {
+		return entry, ErrChangelogEntryNotFound
+	}
+	return s.Entry(id)
+}
+
+// Delete sürüm notunu ve görme kayıtlarını siler
+func (s *ChangelogService) Delete(id string) error {
+	result, err := s.db.Exec("DELETE FROM changelog_entries WHERE id = ?", id)
+	if err != nil {
+		return err
+	}
+	if affected, _ := result.RowsAffected(); affected == 0 {
+		return ErrChangelogEntryNotFound
+	}
+	return nil
+}
+
+// utcTimePtr zamanı UTC'ye çevirir; saklanan zamanlar metin olarak karşılaştırıldığından tek saat diliminde tutulur
+func utcTimePtr(t *time.Time) *time.Time {
+	if t == nil {
+		return nil
+	}
+	utc := t.UTC()
+	return &utc
+}
+
+// scanChangelogEntry sürüm notu satırını okur
+func scanChangelogEntry(row interface{ Scan(...interface{}) error }) (models.ChangelogEntry, error) {
+	var entry models.ChangelogEntry
+	var highlights string
+	var publishedAt sql.NullTime
+	err := row.Scan(&entry.ID, &entry.Version, &entry.Title, &entry.Body, &entry.Category, &highlights,
+		&publishedAt, &entry.CreatedAt, &entry.UpdatedAt)
+	entry.Highlights = decodeHighlights(highlights)
+	entry.PublishedAt = utils.NullTimeToPtr(publishedAt)
+	return entry, err
+}
+
+// encodeHighlights öne çıkan maddeleri JSON olarak saklar
+func encodeHighlights(highlights []string) string {
+	if len(highlights) == 0 {
+		return ""
+	}
+	raw, _ := utils.ToJSON(highlights)
+	return raw
+}
+
+// decodeHighlights saklanan öne çıkan maddeleri okur
+func decodeHighlights(raw string) []string {
+	highlights := []string{}
+	if raw != "" {
+		utils.FromJSON(raw, &highlights)
+	}
+	return highlights
+}
+
+// sortChangelog notları sürüme göre azalan, aynı sürümde yayın tarihine göre yeni önce sıralar
+func sortChangelog(entries []models.ChangelogEntry) {
+	sort.SliceStable(entries, func(i, j int) bool {
+		return changelogBefore(entries[i], entries[j])
+	})
+}
+
+// changelogBefore a notunun b'den önce listelenip listelenmeyeceğini döner
+func changelogBefore(a, b models.ChangelogEntry) bool {
+	if cmp := compareVersions(a.Version, b.Version); cmp != 0 {
+		return cmp > 0
+	}
+	if a.PublishedAt != nil && b.PublishedAt != nil {
+		return a.PublishedAt.After(*b.PublishedAt)
+	}
+	return a.CreatedAt.After(b.CreatedAt)
+}
+
+// compareVersions noktalı sürüm numaralarını parça parça sayısal olarak karşılaştırır ("2.10.0" > "2.9.1");
+// baştaki "v" ve "-beta" gibi ekler yok sayılır
+func compareVersions(a, b string) int {
+	pa, pb := versionParts(a), versionParts(b)
+	for i := 0; i < len(pa) || i < len(pb); i++ {
+		var x, y int
+		if i < len(pa) {
+			x = pa[i]
+		}
+		if i < len(pb) {
+			y = pb[i]
+		}
+		if x != y {
+			if x > y {
+				return 1
+			}
+			return -1
+		}
+	}
+	return 0
+}
+
+// versionParts sürüm numarasını sayısal parçalarına ayırır
+func versionParts(version string) []int {
+	version = strings.TrimPrefix(strings.TrimSpace(strings.ToLower(version)), "v")
+	if i := strings.IndexAny(version, "-+ "); i >= 0 {
+		version = version[:i]
+	}
+	var parts []int
+	for _, part := range strings.Split(version, ".") {
+		n, _ := strconv.Atoi(part)
+		parts = append(parts, n)
+	}
+	return parts
+}
@@ -0,0 +1,354 @@
+package services
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"sort"
+	"strconv"
+	"time"
+
+	"agri-management-api/internal/models"
+)
+
+// ErrChartNotFound tanımsız grafik
+var ErrChartNotFound = errors.New("chart not found")
+
+// ErrInvalidChartParameter grafik parametresi tanıma uymuyor
+var ErrInvalidChartParameter = errors.New("invalid chart parameter")
+
+// chartMonthsParameter aylık grafiklerde gösterilecek ay sayısı
+var chartMonthsParameter = models.ChartParameter{
+	Name: "months", Type: models.ChartParamInteger, Default: "12", Min: intPtr(1), Max: intPtr(36),
+	Description: "Gösterilecek ay sayısı (içinde bulunulan ay dahil)",
+}
+
+// chartForecastParameter aylık grafiğe eklenecek tahmin ayı sayısı
+var chartForecastParameter = models.ChartParameter{
+	Name: "forecastMonths", Type: models.ChartParamInteger, Default: "0", Min: intPtr(0), Max: intPtr(MaxForecastHorizon),
+	Description: "Güven aralıklı tahmin serisinin ay sayısı (0: tahmin yok)",
+}
+
+// chartConfigs dashboard'da gösterilebilecek grafikler; genel grafikler (schema ChartData) chartDataQueries
+// içindeki sorguyla /dashboard/charts/{id} üzerinden sunulur
+var chartConfigs = []models.ChartConfig{
+	{
+		ID: "income-expense", Title: "Gelir-Gider", Description: "Son 12 periyodun gelir, gider ve kâr serileri",
+		Type: models.ChartTypeLine, Endpoint: "/dashboard/charts/income-expense", Schema: "IncomeExpenseChart", Unit: "TRY",
+		Parameters: []models.ChartParameter{
+			{Name: "period", Type: models.ChartParamEnum, Default: "month", Options: []string{"month", "quarter", "year"}, Description: "Periyot"},
+			chartForecastParameter,
+		},
+	},
+	{
+		ID: "production", Title: "Üretim Kategorileri", Description: "Aktif üretim kayıtlarının kategori dağılımı",
+		Type: models.ChartTypePie, Endpoint: "/dashboard/charts/production", Schema: "ProductionChart",
+		Parameters: []models.ChartParameter{},
+	},
+	{
+		ID: "milk-production", Title: "Süt Üretimi", Description: "Aylık toplam süt üretimi",
+		Type: models.ChartTypeBar, Endpoint: "/dashboard/charts/milk-production", Schema: "ChartData", Unit: "L",
+		Parameters: []models.ChartParameter{
+			chartMonthsParameter,
+			chartForecastParameter,
+			{Name: "livestockId", Type: models.ChartParamString, Description: "Yalnızca bu hayvanın süt üretimi (tahmin çiftlik toplamı içindir)"},
+		},
+	},
+	{
+		ID: "livestock-count", Title: "Hayvan Sayısı", Description: "Her ay sonunda çiftlikte bulunan hayvan sayısı, toplam ve türe göre",
+		Type: models.ChartTypeLine, Endpoint: "/dashboard/charts/livestock-count", Schema: "ChartData", Unit: "baş",
+		Parameters: []models.ChartParameter{chartMonthsParameter},
+	},
+	{
+		ID: "land-activity-cost", Title: "Arazi Aktivite Maliyeti", Description: "Arazi aktivitelerinin aylık maliyeti, toplam ve aktivite türüne göre",
+		Type: models.ChartTypeStackedBar, Endpoint: "/dashboard/charts/land-activity-cost", Schema: "ChartData", Unit: "TRY",
+		Parameters: []models.ChartParameter{
+			chartMonthsParameter,
+			{Name: "landId", Type: models.ChartParamString, Description: "Yalnızca bu arazinin aktiviteleri"},
+		},
+	},
+}
+
+// chartQuery genel grafik verisini üretir; months aylık etiketlerin sayısıdır
+type chartQuery func(s *ChartService, farmID string, months []string, params map[string]string) ([]models.ChartSeries, error)
+
+// chartDataQueries genel grafiklerin veri sorguları
+var chartDataQueries = map[string]chartQuery{
+	"milk-production":    (*ChartService).milkProduction,
+	"livestock-count":    (*ChartService).livestockCount,
+	"land-activity-cost": (*ChartService).landActivityCost,
+}
+
+// chartForecastMetrics tahmin serisi eklenebilen genel grafiklerin metrikleri
+var chartForecastMetrics = map[string]string{
+	"milk-production": "milk_total",
+}
+
+// ChartService dashboard grafik tanımlarını ve genel grafik verilerini yönetir
+type ChartService struct {
+	db         *sql.DB
+	timeSeries *TimeSeriesService
+	forecasts  *ForecastService
+}
+
+// NewChartService yeni grafik servisi oluşturur; grafik verileri readDB'den okunur, tahmin kayıtları db'ye yazılır
+func NewChartService(db, readDB *sql.DB) *ChartService {
+	return &ChartService{db: readDB, timeSeries: NewTimeSeriesService(readDB), forecasts: NewForecastService(db)}
+}
+
+// Configs dashboard grafiklerinin tanımlarını döner
+func (s *ChartService) Configs() []models.ChartConfig {
+	return chartConfigs
+}
+
+// Data genel grafiğin verisini parametreleri doğrulayarak üretir; values sorgu parametreleridir
+func (s *ChartService) Data(farmID, chartID string, values func(string) string) (models.ChartData, error) {
+	query, ok := chartDataQueries[chartID]
+	if !ok {
+		return models.ChartData{}, ErrChartNotFound
+	}
+
+	var config models.ChartConfig
+	for _, item := range chartConfigs {
+		if item.ID == chartID {
+			config = item
+		}
+	}
+
+	params, err := chartParams(config.Parameters, values)
+	if err != nil {
+		return models.ChartData{}, err
+	}
+
+	monthCount, _ := strconv.Atoi(params["months"])
+	months := chartMonths(time.Now(), monthCount)
+
+	series, err := query(s, farmID, months, params)
+	if err != nil {
+		return models.ChartData{}, err
+	}
+
+	data := models.ChartData{ChartID: chartID, Unit: config.Unit, Labels: months, Series: series}
+	if forecastMonths, _ := strconv.Atoi(params["forecastMonths"]); forecastMonths > 0 {
+		if data.Forecast, err = s.forecasts.Forecast(farmID, chartForecastMetrics[chartID], forecastMonths, time.Now()); err != nil {
+			return models.ChartData{}, err
+		}
+	}
+
+	return data, nil
+}
+
+// milkProduction aylık toplam süt üretimi; zaman serisi servisinin milk_total metriğiyle aynı kuralları izler
+func (s *ChartService) milkProduction(farmID string, months []string, params map[string]string) ([]models.ChartSeries, error) {
+	from, _ := time.Parse("2006-01", months[0])
+	to, _ := time.Parse("2006-01", months[len(months)-1])
+	series, err := s.timeSeries.Series(farmID, "milk_total", models.BucketMonth, from, to.AddDate(0, 1, -1),
+		map[string]string{"livestockId": params["livestockId"]})
+	if err != nil {
+		return nil, err
+	}
+
+	total := newChartSeries("total", "Toplam", months)
+	for _, point := range series.Points {
+		total.add(point.Bucket, *point.Value)
+	}
+	return []models.ChartSeries{total.series()}, nil
+}
+
+// livestockCount her ay sonunda çiftlikte bulunan hayvan sayısı; giriş tarihi profitability ile aynı kurala
+// göre (edinme, doğum veya kayıt tarihi), çıkış satış veya kesim tarihidir
+func (s *ChartService) livestockCount(farmID string, months []string, _ map[string]string) ([]models.ChartSeries, error) {
+	rows, err := s.db.Query(`
+		SELECT l.type, COALESCE(l.acquisition_type, ?), l.acquisition_date, l.birth_date, l.created_at,
+		       l.sale_date, sr.slaughter_date
+		FROM livestock l
+		LEFT JOIN slaughter_records sr ON sr.livestock_id = l.id
+		WHERE l.user_id = ?
+	`, models.AcquisitionBorn, farmID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	monthEnds := make([]time.Time, len(months))
+	for i, month := range months {
+		start, _ := time.Parse("2006-01", month)
+		monthEnds[i] = start.AddDate(0, 1, 0)
+	}
+
+	total := newChartSeries("total", "Toplam", months)
+	byType := map[string]*chartSeriesBuilder{}
+	for rows.Next() {
+		var animalType, acquisitionType string
+		var acquisitionDate, birthDate, saleDate, slaughterDate sql.NullTime
+		var createdAt time.Time
+		err := rows.Scan(&animalType, &acquisitionType, &acquisitionDate, &birthDate, &createdAt, &saleDate, &slaughterDate)
+		if err != nil {
+			return nil, err
+		}
+
+		entry := createdAt
+		switch {
+		case acquisitionDate.Valid:
+			entry = acquisitionDate.Time
+		case acquisitionType == models.AcquisitionBorn && birthDate.Valid:
+			entry = birthDate.Time
+		}
+		var exit *time.Time
+		switch {
+		case saleDate.Valid:
+			exit = &saleDate.Time
+		case slaughterDate.Valid:
+			exit = &slaughterDate.Time
+		}
+
+		if byType[animalType] == nil {
+			byType[animalType] = newChartSeries(animalType, animalType, months)
+		}
+		for i, end := range monthEnds {
+			if entry.Before(end) && (exit == nil || !exit.Before(end)) {
+				total.add(months[i], 1)
+				byType[animalType].add(months[i], 1)
+			}
+		}
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return append([]models.ChartSeries{total.series()}, sortedSeries(byType)...), nil
+}
+
+// landActivityCost arazi aktivitelerinin aylık maliyeti; aktivite tarihi gerçekleşme, planlanan veya kayıt tarihidir
+func (s *ChartService) landActivityCost(farmID string, months []string, params map[string]string) ([]models.ChartSeries, error) {
+	query := `
+		SELECT strftime('%Y-%m', COALESCE(a.actual_date, a.scheduled_date, a.created_at)) AS month, a.type,
+		       COALESCE(SUM(a.cost), 0)
+		FROM land_activities a
+		JOIN lands l ON l.id = a.land_id
+		WHERE l.user_id = ? AND strftime('%Y-%m', COALESCE(a.actual_date, a.scheduled_date, a.created_at)) >= ?`
+	args := []interface{}{farmID, months[0]}
+	if params["landId"] != "" {
+		query += " AND a.land_id = ?"
+		args = append(args, params["landId"])
+	}
+
+	rows, err := s.db.Query(query+" GROUP BY month, a.type", args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	total := newChartSeries("total", "Toplam", months)
+	byType := map[string]*chartSeriesBuilder{}
+	for rows.Next() {
+		var month, activityType string
+		var cost float64
+		if err := rows.Scan(&month, &activityType, &cost); err != nil {
+			return nil, err
+		}
+		if byType[activityType] == nil {
+			byType[activityType] = newChartSeries(activityType, activityType, months)
+		}
+		total.add(month, cost)
+		byType[activityType].add(month, cost)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return append([]models.ChartSeries{total.series()}, sortedSeries(byType)...), nil
+}
+
+// chartParams sorgu parametrelerini grafik tanımına göre doğrular ve varsayılanlarla doldurur
+func chartParams(definitions []models.ChartParameter, values func(string) string) (map[string]string, error) {
+	params := map[string]string{}
+	for _, definition := range definitions {
+		value := values(definition.Name)
+		if value == "" {
+			value = definition.Default
+		}
+		if value == "" {
+			if definition.Required {
+				return nil, fmt.Errorf("%w: %s", ErrInvalidChartParameter, definition.Name)
+			}
+			continue
+		}
+
+		switch definition.Type {
+		case models.ChartParamInteger:
+			number, err := strconv.Atoi(value)
+			if err != nil || (definition.Min != nil && number < *definition.Min) || (definition.Max != nil && number > *definition.Max) {
+				return nil, fmt.Errorf("%w: %s", ErrInvalidChartParameter, definition.Name)
+			}
+		case models.ChartParamEnum:
+			valid := false
+			for _, option := range definition.Options {
+				valid = valid || option == value
+			}
+			if !valid {
+				return nil, fmt.Errorf("%w: %s", ErrInvalidChartParameter, definition.Name)
+			}
+		}
+		params[definition.Name] = value
+	}
+	return params, nil
+}
+
+// chartMonths içinde bulunulan ay dahil son count ayın YYYY-MM etiketleri, eskiden yeniye
+func chartMonths(now time.Time, count int) []string {
+	start := time.Date(now.Year(), now.Month(), 1, 0, 0, 0, 0, time.UTC).AddDate(0, -(count - 1), 0)
+	months := make([]string, count)
+	for i := range months {
+		months[i] = start.AddDate(0, i, 0).Format("2006-01")
+	}
+	return months
+}
+
+// chartSeriesBuilder ay etiketlerine göre seri değerlerini toplar
+type chartSeriesBuilder struct {
+	key, label string
+	index      map[string]int
+	values     []float64
+}
+
+func newChartSeries(key, label string, months []string) *chartSeriesBuilder {
+	builder := &chartSeriesBuilder{key: key, label: label, index: map[string]int{}, values: make([]float64, len(months))}
+	for i, month := range months {
+		builder.index[month] = i
+	}
+	return builder
+}
+
+// add aralık dışındaki aylar yok sayılır
+func (b *chartSeriesBuilder) add(month string, value float64) {
+	if i, ok := b.index[month]; ok {
+		b.values[i] += value
+	}
+}
+
+func (b *chartSeriesBuilder) series() models.ChartSeries {
+	for i := range b.values {
+		b.values[i] = round2(b.values[i])
+	}
+	return models.ChartSeries{Key: b.key, Label: b.label, Values: b.values}
+}
+
+// sortedSeries kırılım serilerini anahtara göre sıralı döner
+func sortedSeries(builders map[string]*chartSeriesBuilder) []models.ChartSeries {
+	keys := make([]string, 0, len(builders))
+	for key := range builders {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	series := make([]models.ChartSeries, 0, len(keys))
+	for _, key := range keys {
+		series = append(series, builders[key].series())
+	}
+	return series
+}
+
+func intPtr(value int) *int {
+	return &value
+}
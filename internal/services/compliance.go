@@ -0,0 +1,328 @@
+package services
+
+import (
+	"archive/zip"
+	"database/sql"
+	"encoding/csv"
+	"fmt"
+	"io"
+	"path"
+	"strconv"
+	"strings"
+	"time"
+
+	"agri-management-api/internal/models"
+	"agri-management-api/internal/utils"
+)
+
+// ComplianceEvidenceEntity kanıt dosyalarının media_attachments tablosundaki kayıt türü
+const ComplianceEvidenceEntity = "compliance_requirement"
+
+// complianceLogQueries denetim paketine eklenebilecek faaliyet kayıtları; sorgular kullanıcı ve tarih aralığıyla çalışır
+var complianceLogQueries = map[string]struct {
+	header []string
+	query  string
+}{
+	"land_activities": {
+		header: []string{"Tarih", "Arazi", "Tür", "Açıklama", "Gübre (kg)", "Azot (%)", "Maliyet", "Sonuç", "Notlar"},
+		query: `
+			SELECT COALESCE(la.actual_date, la.scheduled_date), l.name, la.type, la.description,
+			       COALESCE(la.fertilizer_kg, ''), COALESCE(la.nitrogen_percent, ''), COALESCE(la.cost, ''),
+			       COALESCE(la.result, ''), COALESCE(la.notes, '')
+			FROM land_activities la JOIN lands l ON la.land_id = l.id
+			WHERE l.user_id = ? AND COALESCE(la.actual_date, la.scheduled_date) >= ? AND COALESCE(la.actual_date, la.scheduled_date) < ?
+			ORDER BY 1`,
+	},
+	"health_records": {
+		header: []string{"Tarih", "Küpe No", "Tür", "Açıklama", "Veteriner", "Maliyet", "Notlar"},
+		query: `
+			SELECT h.date, l.tag_number, h.type, h.description, COALESCE(h.veterinarian, ''), COALESCE(h.cost, ''), COALESCE(h.notes, '')
+			FROM health_records h JOIN livestock l ON h.livestock_id = l.id
+			WHERE l.user_id = ? AND h.date >= ? AND h.date < ?
+			ORDER BY 1`,
+	},
+	"livestock_movements": {
+		header: []string{"Tarih", "Küpe No", "Hareket", "Nereden", "Nereye", "İşletme No", "Neden"},
+		query: `
+			SELECT m.movement_date, l.tag_number, m.movement_type, COALESCE(m.from_location, ''), COALESCE(m.to_location, ''),
+			       COALESCE(m.premises_number, ''), COALESCE(m.reason, '')
+			FROM livestock_movements m JOIN livestock l ON m.livestock_id = l.id
+			WHERE m.user_id = ? AND m.movement_date >= ? AND m.movement_date < ?
+			ORDER BY 1`,
+	},
+	"transactions": {
+		header: []string{"Tarih", "Tür", "Kategori", "Açıklama", "Tutar", "Para Birimi"},
+		query: `
+			SELECT date, type, category, description, amount, COALESCE(currency, 'TRY')
+			FROM transactions
+			WHERE user_id = ? AND date >= ? AND date < ?
+			ORDER BY 1`,
+	},
+}
+
+// complianceStatusLabels durumların denetim paketindeki karşılıkları
+var complianceStatusLabels = map[string]string{
+	models.ComplianceStatusPending:       "Beklemede",
+	models.ComplianceStatusCompliant:     "Uygun",
+	models.ComplianceStatusNonCompliant:  "Uygun Değil",
+	models.ComplianceStatusNotApplicable: "Uygulanamaz",
+}
+
+// ComplianceService kontrol listesi durumlarını, kanıtları ve denetim paketini yönetir
+type ComplianceService struct {
+	db    *sql.DB
+	store MediaStore
+}
+
+// NewComplianceService yeni compliance service oluşturur
+func NewComplianceService(db *sql.DB, store MediaStore) *ComplianceService {
+	return &ComplianceService{db: db, store: store}
+}
+
+// Items kontrol listesinin gereksinimlerini kullanıcının durumları ve kanıtlarıyla birlikte döner
+func (s *ComplianceService) Items(userID string, checklist models.ComplianceChecklist) ([]models.ComplianceItem, models.ComplianceProgress, error) {
+	statuses := map[string]models.ComplianceItem{}
+
+	rows, err := s.db.Query(`
+		SELECT id, requirement_code, status, COALESCE(notes, ''), reviewed_at
+		FROM compliance_statuses WHERE user_id = ? AND checklist_id = ?
+	`, userID, checklist.ID)
+	if err != nil {
+		return nil, models.ComplianceProgress{}, err
+	}
+	for rows.Next() {
+		var item models.ComplianceItem
+		var code string
+		var reviewedAt sql.NullTime
+		if err := rows.Scan(&item.StatusID, &code, &item.Status, &item.Notes, &reviewedAt); err != nil {
+			continue
+		}
+		item.ReviewedAt = utils.NullTimeToPtr(reviewedAt)
+		statuses[code] = item
+	}
+	rows.Close()
+
+	evidence := map[string][]models.MediaAttachment{}
+	rows, err = s.db.Query(`
+		SELECT id, user_id, entity_type, entity_id, kind, filename, COALESCE(content_type, ''), size, created_at
+		FROM media_attachments WHERE user_id = ? AND entity_type = ?
+		ORDER BY created_at
+	`, userID, ComplianceEvidenceEntity)
+	if err != nil {
+		return nil, models.ComplianceProgress{}, err
+	}
+	for rows.Next() {
+		var media models.MediaAttachment
+		err := rows.Scan(&media.ID, &media.UserID, &media.EntityType, &media.EntityID, &media.Kind, &media.Filename,
+			&media.ContentType, &media.Size, &media.CreatedAt)
+		if err != nil {
+			continue
+		}
+		media.TranscriptStatus = models.TranscriptStatusNone
+		evidence[media.EntityID] = append(evidence[media.EntityID], media)
+	}
+	rows.Close()
+
+	items := make([]models.ComplianceItem, 0, len(checklist.Requirements))
+	progress := models.ComplianceProgress{Total: len(checklist.Requirements)}
+
+	for _, requirement := range checklist.Requirements {
+		item, ok := statuses[requirement.Code]
+		if !ok {
+			item.Status = models.ComplianceStatusPending
+		}
+		item.ComplianceRequirement = requirement
+		item.Evidence = evidence[item.StatusID]
+		if item.Evidence == nil {
+			item.Evidence = []models.MediaAttachment{}
+		}
+
+		switch item.Status {
+		case models.ComplianceStatusCompliant:
+			progress.Compliant++
+		case models.ComplianceStatusNonCompliant:
+			progress.NonCompliant++
+		case models.ComplianceStatusNotApplicable:
+			progress.NotApplicable++
+		default:
+			progress.Pending++
+		}
+		if requirement.EvidenceRequired && item.Status != models.ComplianceStatusNotApplicable && len(item.Evidence) == 0 {
+			progress.MissingEvidence++
+		}
+
+		items = append(items, item)
+	}
+
+	// Uygulanamaz gereksinimler orana dahil edilmez
+	if applicable := progress.Total - progress.NotApplicable; applicable > 0 {
+		progress.Percent = round2(float64(progress.Compliant) / float64(applicable) * 100)
+	}
+
+	return items, progress, nil
+}
+
+// WriteBundle denetçiye sunulacak ZIP paketini yazar: gereksinim durumları, kanıt dosyaları ve
+// kontrol listesinin ilgili faaliyet kayıtları tarih aralığına göre eklenir
+func (s *ComplianceService) WriteBundle(w io.Writer, userID string, checklist models.ComplianceChecklist, start, end time.Time) error {
+	items, progress, err := s.Items(userID, checklist)
+	if err != nil {
+		return err
+	}
+
+	archive := zip.NewWriter(w)
+
+	summary := [][]string{
+		{"Kontrol Listesi", checklist.Name},
+		{"Standart", checklist.Standard},
+		{"Dönem", start.Format("2006-01-02") + " - " + end.AddDate(0, 0, -1).Format("2006-01-02")},
+		{"Oluşturulma", time.Now().Format("2006-01-02 15:04")},
+		{"Uyum Oranı (%)", strconv.FormatFloat(progress.Percent, 'f', 2, 64)},
+		{"Eksik Kanıt", strconv.Itoa(progress.MissingEvidence)},
+		{},
+		{"Kod", "Gereksinim", "Kategori", "Durum", "Kanıt Gerekli", "Notlar", "İnceleme Tarihi", "Kanıt Dosyaları"},
+	}
+
+	var evidenceFiles []struct{ name, mediaID string }
+	for _, item := range items {
+		var files []string
+		for _, media := range item.Evidence {
+			name := path.Join("kanitlar", safeBundleName(item.Code), safeBundleName(media.ID[:8]+"-"+media.Filename))
+			files = append(files, name)
+			evidenceFiles = append(evidenceFiles, struct{ name, mediaID string }{name, media.ID})
+		}
+
+		required := "Hayır"
+		if item.EvidenceRequired {
+			required = "Evet"
+		}
+		reviewed := ""
+		if item.ReviewedAt != nil {
+			reviewed = item.ReviewedAt.Format("2006-01-02")
+		}
+
+		summary = append(summary, []string{
+			item.Code, item.Title, item.Category, complianceStatusLabels[item.Status], required, item.Notes, reviewed,
+			strings.Join(files, ", "),
+		})
+	}
+
+	if err := writeBundleCSV(archive, "kontrol-listesi.csv", summary); err != nil {
+		return err
+	}
+
+	for _, file := range evidenceFiles {
+		if err := s.copyEvidence(archive, file.name, file.mediaID, userID); err != nil {
+			return err
+		}
+	}
+
+	for _, source := range checklist.LogSources {
+		log, ok := complianceLogQueries[source]
+		if !ok {
+			continue
+		}
+
+		records, err := s.logRecords(log.query, userID, start, end)
+		if err != nil {
+			return err
+		}
+		if err := writeBundleCSV(archive, path.Join("kayitlar", source+".csv"), append([][]string{log.header}, records...)); err != nil {
+			return err
+		}
+	}
+
+	return archive.Close()
+}
+
+// logRecords faaliyet kaydı sorgusunu çalıştırır ve satırları metin olarak döner
+func (s *ComplianceService) logRecords(query, userID string, start, end time.Time) ([][]string, error) {
+	rows, err := s.db.Query(query, userID, start, end)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	columns, err := rows.Columns()
+	if err != nil {
+		return nil, err
+	}
+
+	var records [][]string
+	for rows.Next() {
+		values := make([]interface{}, len(columns))
+		pointers := make([]interface{}, len(columns))
+		for i := range values {
+			pointers[i] = &values[i]
+		}
+		if err := rows.Scan(pointers...); err != nil {
+			continue
+		}
+
+		record := make([]string, len(columns))
+		for i, value := range values {
+			switch v := value.(type) {
+			case time.Time:
+				record[i] = v.Format("2006-01-02")
+			case []byte:
+				record[i] = string(v)
+			case nil:
+				record[i] = ""
+			default:
+				record[i] = fmt.Sprint(v)
+			}
+		}
+		records = append(records, record)
+	}
+	return records, rows.Err()
+}
+
+// copyEvidence kanıt dosyasını depolamadan pakete kopyalar
+func (s *ComplianceService) copyEvidence(archive *zip.Writer, name, mediaID, userID string) error {
+	var storageKey string
+	err := s.db.QueryRow("SELECT storage_key FROM media_attachments WHERE id = ? AND user_id = ?", mediaID, userID).Scan(&storageKey)
+	if err != nil {
+		return err
+	}
+
+	file, err := s.store.Open(storageKey)
+	if err != nil {
+		// Depolamada bulunamayan dosyalar paketi engellemez
+		return nil
+	}
+	defer file.Close()
+
+	writer, err := archive.Create(name)
+	if err != nil {
+		return err
+	}
+	_, err = io.Copy(writer, file)
+	return err
+}
+
+// writeBundleCSV pakete noktalı virgülle ayrılmış CSV dosyası ekler; hücreler formül olarak yazılmaz
+func writeBundleCSV(archive *zip.Writer, name string, records [][]string) error {
+	file, err := archive.Create(name)
+	if err != nil {
+		return err
+	}
+
+	writer := csv.NewWriter(file)
+	writer.Comma = ';'
+	for _, record := range records {
+		cells := make([]string, len(record))
+		for i, value := range record {
+			cells[i] = SpreadsheetText(value)
+		}
+		if err := writer.Write(cells); err != nil {
+			return err
+		}
+	}
+	writer.Flush()
+	return writer.Error()
+}
+
+// safeBundleName dosya adını paket içinde güvenli hale getirir
+func safeBundleName(name string) string {
+	return strings.NewReplacer("/", "_", "\\", "_", "..", "_").Replace(name)
+}
@@ -0,0 +1,496 @@
+package services
+
+import (
+	"database/sql"
+	"errors"
+	"strings"
+	"time"
+
+	"agri-management-api/internal/models"
+	"agri-management-api/internal/utils"
+)
+
+var (
+	// ErrAllocationRuleNotFound dağıtım kuralı bulunamadı
+	ErrAllocationRuleNotFound = errors.New("dağıtım kuralı bulunamadı")
+	// ErrInvalidAllocationBasis dağıtım anahtarı hedefle kullanılamaz (alan yalnızca arazilerde, hayvan sayısı
+	// yalnızca hayvanlarda)
+	ErrInvalidAllocationBasis = errors.New("dağıtım anahtarı bu hedefle kullanılamaz")
+	// ErrInvalidAllocationPeriod dönem sonu başlangıçtan önce
+	ErrInvalidAllocationPeriod = errors.New("geçersiz dağıtım dönemi")
+	// ErrAllocationPeriodOverlap kural aynı dönemle kesişen başka bir dönem için çalıştırılmış
+	ErrAllocationPeriodOverlap = errors.New("kural bu dönemle kesişen başka bir dönem için çalıştırılmış")
+	// ErrNoAllocationCost dönemde dağıtılacak gider yok
+	ErrNoAllocationCost = errors.New("dönemde dağıtılacak gider yok")
+	// ErrNoAllocationBasis hedeflerin dağıtım anahtarı değerlerinin toplamı sıfır
+	ErrNoAllocationBasis = errors.New("dağıtım anahtarına göre pay alacak hedef yok")
+)
+
+// costAllocationRuleSelect dağıtım kuralı sütunları
+const costAllocationRuleSelect = `
+	SELECT id, name, categories, target_type, basis, COALESCE(animal_type, ''), created_at, updated_at
+	FROM cost_allocation_rules`
+
+// costAllocationSelect dağıtım payı sütunları; a takma adı cost_allocations tablosudur
+const costAllocationSelect = `
+	SELECT a.id, a.rule_id, r.name, date(a.period_start), date(a.period_end), a.target_type, a.target_id,
+	       COALESCE(ln.name, lv.tag_number, ''), a.basis_value, a.share, a.amount, a.created_at
+	FROM cost_allocations a
+	JOIN cost_allocation_rules r ON r.id = a.rule_id
+	LEFT JOIN lands ln ON a.target_type = 'land' AND ln.id = a.target_id
+	LEFT JOIN livestock lv ON a.target_type = 'livestock' AND lv.id = a.target_id`
+
+// allocationTarget dağıtım hedefi ve anahtar değeri
+type allocationTarget struct {
+	id, name string
+	value    float64
+}
+
+// CostAllocationService ortak giderleri (sigorta, elektrik, veteriner çağrı ücreti) kurallarla arazilere ve
+// hayvanlara dağıtır. Her çalıştırma dönemdeki gider işlemlerinin toplamını anahtar değerlerine göre paylaştırır
+// ve payları saklar; arazi ve hayvan karlılığı bu payları maliyete ekler
+type CostAllocationService struct {
+	db *sql.DB
+}
+
+// NewCostAllocationService yeni cost allocation service oluşturur
+func NewCostAllocationService(db *sql.DB) *CostAllocationService {
+	return &CostAllocationService{db: db}
+}
+
+// Rules çiftliğin dağıtım kurallarını döner
+func (s *CostAllocationService) Rules(farmID string) ([]models.CostAllocationRule, error) {
+	rows, err := s.db.Query(costAllocationRuleSelect+" WHERE user_id = ? ORDER BY name", farmID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	rules := []models.CostAllocationRule{}
+	for rows.Next() {
+		rule, err := scanCostAllocationRule(rows)
+		if err != nil {
+			return nil, err
+		}
+		rules = append(rules, rule)
+	}
+	return rules, rows.Err()
+}
+
+// Rule dağıtım kuralını döner
+func (s *CostAllocationService) Rule(farmID, id string) (*models.CostAllocationRule, error) {
+	rule, err := scanCostAllocationRule(s.db.QueryRow(costAllocationRuleSelect+" WHERE id = ? AND user_id = ?", id, farmID))
+	if err == sql.ErrNoRows {
+		return nil, ErrAllocationRuleNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &rule, nil
+}
+
+// CreateRule dağıtım kuralı ekler
+func (s *CostAllocationService) CreateRule(farmID string, req models.CostAllocationRuleRequest) (*models.CostAllocationRule, error) {
+	categories, err := normalizeAllocationRule(&req)
+	if err != nil {
+		return nil, err
+	}
+
+	id := utils.GenerateID()
+	_, err = s.db.Exec(`
+		INSERT INTO cost_allocation_rules (id, user_id, name, categories, target_type, basis, animal_type, created_at, updated_at)
+		VALUES (?, ?, ?, ?, ?, ?, ?, CURRENT_TIMESTAMP, CURRENT_TIMESTAMP)
+	`, id, farmID, req.Name, categories, req.TargetType, req.Basis, req.AnimalType)
+	if err != nil {
+		return nil, err
+	}
+	return s.Rule(farmID, id)
+}
+
+// UpdateRule dağıtım kuralını günceller; önceki çalıştırmaların payları değişmez, dönem yeniden çalıştırılınca
+// yeni kurala göre hesaplanır
+func (s *CostAllocationService) UpdateRule(farmID, id string, req models.CostAllocationRuleRequest) (*models.CostAllocationRule, error) {
+	categories, err := normalizeAllocationRule(&req)
+	if err != nil {
+		return nil, err
+	}
+
+	result, err := s.db.Exec(`
+		UPDATE cost_allocation_rules
+		SET name = ?, categories = ?, target_type = ?, basis = ?, animal_type = ?, updated_at = CURRENT_TIMESTAMP
+		WHERE id = ? AND user_id = ?
+	`, req.Name, categories, req.TargetType, req.Basis, req.AnimalType, id, farmID)
+	if err != nil {
+		return nil, err
+	}
+	if affected, _ := result.RowsAffected(); affected == 0 {
+		return nil, ErrAllocationRuleNotFound
+	}
+	return s.Rule(farmID, id)
+}
+
+// DeleteRule dağıtım kuralını ve tüm paylarını siler
+func (s *CostAllocationService) DeleteRule(farmID, id string) error {
+	tx, err := s.db.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	if _, err := tx.Exec("DELETE FROM cost_allocations WHERE rule_id = ? AND user_id = ?", id, farmID); err != nil {
+		return err
+	}
+	result, err := tx.Exec("DELETE FROM cost_allocation_rules WHERE id = ? AND user_id = ?", id, farmID)
+	if err != nil {
+		return err
+	}
+	if affected, _ := result.RowsAffected(); affected == 0 {
+		return ErrAllocationRuleNotFound
+	}
+	return tx.Commit()
+}
+
+// Run kuralı dönem için çalıştırır: dönemdeki kural kategorilerindeki gider işlemlerinin toplamı hedeflere anahtar
+// değerleriyle orantılı paylaştırılır. Aynı dönem yeniden çalıştırılırsa önceki paylar değiştirilir
+func (s *CostAllocationService) Run(farmID, ruleID string, req models.CostAllocationRunRequest) (*models.CostAllocationRun, error) {
+	rule, err := s.Rule(farmID, ruleID)
+	if err != nil {
+		return nil, err
+	}
+	start, end := req.StartDate, req.EndDate
+	if end < start {
+		return nil, ErrInvalidAllocationPeriod
+	}
+
+	var overlapping int
+	if err := s.db.QueryRow(`
+		SELECT COUNT(*) FROM cost_allocations
+		WHERE user_id = ? AND rule_id = ? AND date(period_start) <= date(?) AND date(period_end) >= date(?)
+		  AND NOT (date(period_start) = date(?) AND date(period_end) = date(?))
+	`, farmID, ruleID, end, start, start, end).Scan(&overlapping); err != nil {
+		return nil, err
+	}
+	if overlapping > 0 {
+		return nil, ErrAllocationPeriodOverlap
+	}
+
+	run := &models.CostAllocationRun{
+		RuleID: rule.ID, RuleName: rule.Name, Basis: rule.Basis, PeriodStart: start, PeriodEnd: end,
+		Allocations: []models.CostAllocation{},
+	}
+
+	placeholders := strings.TrimSuffix(strings.Repeat("?,", len(rule.Categories)), ",")
+	args := []interface{}{farmID, start, end}
+	for _, category := range rule.Categories {
+		args = append(args, category)
+	}
+	if err := s.db.QueryRow(`
+		SELECT COUNT(*), COALESCE(SUM(amount), 0) FROM transactions
+		WHERE user_id = ? AND type = 'expense' AND date(date) BETWEEN ? AND ? AND category IN (`+placeholders+`)
+	`, args...).Scan(&run.TransactionCount, &run.TotalCost); err != nil {
+		return nil, err
+	}
+	run.TotalCost = round2(run.TotalCost)
+	if run.TotalCost <= 0 {
+		return nil, ErrNoAllocationCost
+	}
+
+	targets, err := s.targets(farmID, *rule, start, end)
+	if err != nil {
+		return nil, err
+	}
+	var basisTotal float64
+	for _, target := range targets {
+		basisTotal += target.value
+	}
+	if basisTotal <= 0 {
+		return nil, ErrNoAllocationBasis
+	}
+
+	// Yuvarlama farkı en büyük paya eklenir; payların toplamı gider toplamına eşit kalır
+	largest, allocated := 0, 0.0
+	for _, target := range targets {
+		if target.value <= 0 {
+			continue
+		}
+		allocation := models.CostAllocation{
+			ID: utils.GenerateID(), RuleID: rule.ID, RuleName: rule.Name, PeriodStart: start, PeriodEnd: end,
+			TargetType: rule.TargetType, TargetID: target.id, TargetName: target.name,
+			BasisValue: round2(target.value),
+			Share:      round2(target.value / basisTotal * 100),
+			Amount:     round2(run.TotalCost * target.value / basisTotal),
+		}
+		if len(run.Allocations) == 0 || allocation.Amount > run.Allocations[largest].Amount {
+			largest = len(run.Allocations)
+		}
+		allocated += allocation.Amount
+		run.Allocations = append(run.Allocations, allocation)
+	}
+	run.Allocations[largest].Amount = round2(run.Allocations[largest].Amount + run.TotalCost - allocated)
+
+	tx, err := s.db.Begin()
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	if _, err := tx.Exec(`
+		DELETE FROM cost_allocations WHERE user_id = ? AND rule_id = ? AND date(period_start) = date(?) AND date(period_end) = date(?)
+	`, farmID, ruleID, start, end); err != nil {
+		return nil, err
+	}
+	now := time.Now().UTC()
+	for i, allocation := range run.Allocations {
+		if _, err := tx.Exec(`
+			INSERT INTO cost_allocations (id, user_id, rule_id, period_start, period_end, target_type, target_id,
+			                              basis_value, share, amount, created_at)
+			VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
+		`, allocation.ID, farmID, ruleID, start, end, allocation.TargetType, allocation.TargetID,
+			allocation.BasisValue, allocation.Share, allocation.Amount, now); err != nil {
+			return nil, err
+		}
+		run.Allocations[i].CreatedAt = now
+	}
+	return run, tx.Commit()
+}
+
+// DeleteRun kuralın dönem için hesaplanan paylarını siler
+func (s *CostAllocationService) DeleteRun(farmID, ruleID, start, end string) error {
+	if _, err := s.Rule(farmID, ruleID); err != nil {
+		return err
+	}
+	_, err := s.db.Exec(`
+		DELETE FROM cost_allocations WHERE user_id = ? AND rule_id = ? AND date(period_start) = date(?) AND date(period_end) = date(?)
+	`, farmID, ruleID, start, end)
+	return err
+}
+
+// List dağıtım paylarını kural, hedef ve dönemle kesişme filtreleriyle döner
+func (s *CostAllocationService) List(farmID, ruleID, targetType, targetID string, startDate, endDate *time.Time) ([]models.CostAllocation, error) {
+	query := costAllocationSelect + " WHERE a.user_id = ?"
+	args := []interface{}{farmID}
+	if ruleID != "" {
+		query += " AND a.rule_id = ?"
+		args = append(args, ruleID)
+	}
+	if targetType != "" {
+		query += " AND a.target_type = ?"
+		args = append(args, targetType)
+	}
+	if targetID != "" {
+		query += " AND a.target_id = ?"
+		args = append(args, targetID)
+	}
+	if startDate != nil {
+		query += " AND date(a.period_end) >= ?"
+		args = append(args, startDate.Format("2006-01-02"))
+	}
+	if endDate != nil {
+		query += " AND date(a.period_start) <= ?"
+		args = append(args, endDate.Format("2006-01-02"))
+	}
+
+	rows, err := s.db.Query(query+" ORDER BY a.period_start DESC, r.name, a.amount DESC", args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	allocations := []models.CostAllocation{}
+	for rows.Next() {
+		var allocation models.CostAllocation
+		if err := rows.Scan(&allocation.ID, &allocation.RuleID, &allocation.RuleName, &allocation.PeriodStart,
+			&allocation.PeriodEnd, &allocation.TargetType, &allocation.TargetID, &allocation.TargetName,
+			&allocation.BasisValue, &allocation.Share, &allocation.Amount, &allocation.CreatedAt); err != nil {
+			return nil, err
+		}
+		allocations = append(allocations, allocation)
+	}
+	return allocations, rows.Err()
+}
+
+// AllocatedTo hedefe from-to tarihleri arasında düşen dağıtım payı toplamını döner; dönemi aralıkla kısmen
+// kesişen paylar kesişen gün sayısıyla orantılanır
+func (s *CostAllocationService) AllocatedTo(farmID, targetType, targetID, from, to string) (float64, error) {
+	var amount float64
+	err := s.db.QueryRow(`
+		SELECT COALESCE(SUM(amount
+		       * (MIN(julianday(period_end), julianday(?)) - MAX(julianday(period_start), julianday(?)) + 1)
+		       / (julianday(period_end) - julianday(period_start) + 1)), 0)
+		FROM cost_allocations
+		WHERE user_id = ? AND target_type = ? AND target_id = ? AND date(period_start) <= date(?) AND date(period_end) >= date(?)
+	`, to, from, farmID, targetType, targetID, to, from).Scan(&amount)
+	return round2(amount), err
+}
+
+// AllocatedByEnterprise [from, to] dönemiyle kesişen dağıtım paylarını, hedef arazi veya hayvanın faaliyet
+// koluna göre toplar; faaliyet kolu atanmamış hedeflerin payları dönülmez
+func (s *CostAllocationService) AllocatedByEnterprise(farmID, from, to string) (map[string]float64, error) {
+	rows, err := s.db.Query(`
+		SELECT COALESCE(ln.enterprise_id, lv.enterprise_id),
+		       SUM(a.amount
+		           * (MIN(julianday(a.period_end), julianday(?)) - MAX(julianday(a.period_start), julianday(?)) + 1)
+		           / (julianday(a.period_end) - julianday(a.period_start) + 1))
+		FROM cost_allocations a
+		LEFT JOIN lands ln ON a.target_type = 'land' AND ln.id = a.target_id
+		LEFT JOIN livestock lv ON a.target_type = 'livestock' AND lv.id = a.target_id
+		WHERE a.user_id = ? AND date(a.period_start) <= date(?) AND date(a.period_end) >= date(?)
+		  AND COALESCE(ln.enterprise_id, lv.enterprise_id) IS NOT NULL
+		GROUP BY 1
+	`, to, from, farmID, to, from)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	allocated := map[string]float64{}
+	for rows.Next() {
+		var enterpriseID string
+		var amount float64
+		if err := rows.Scan(&enterpriseID, &amount); err != nil {
+			return nil, err
+		}
+		allocated[enterpriseID] = round2(amount)
+	}
+	return allocated, rows.Err()
+}
+
+// targets kuralın hedeflerini dönemdeki anahtar değerleriyle döner
+func (s *CostAllocationService) targets(farmID string, rule models.CostAllocationRule, start, end string) ([]allocationTarget, error) {
+	if rule.TargetType == models.AllocationTargetLivestock {
+		return s.livestockTargets(farmID, rule, start, end)
+	}
+
+	var query string
+	args := []interface{}{farmID}
+	switch rule.Basis {
+	case models.AllocationBasisArea:
+		query = "SELECT id, name, COALESCE(area, 0) FROM lands WHERE user_id = ? ORDER BY name"
+	case models.AllocationBasisRevenue:
+		query = `
+			SELECT l.id, l.name, COALESCE((
+			    SELECT SUM(ps.subtotal) FROM production_sales ps
+			    JOIN production p ON p.id = ps.production_id
+			    WHERE p.land_id = l.id AND date(ps.sale_date) BETWEEN ? AND ?
+			), 0)
+			FROM lands l WHERE l.user_id = ? ORDER BY l.name`
+		args = []interface{}{start, end, farmID}
+	default:
+		query = "SELECT id, name, 1 FROM lands WHERE user_id = ? ORDER BY name"
+	}
+
+	rows, err := s.db.Query(query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var targets []allocationTarget
+	for rows.Next() {
+		var target allocationTarget
+		if err := rows.Scan(&target.id, &target.name, &target.value); err != nil {
+			return nil, err
+		}
+		targets = append(targets, target)
+	}
+	return targets, rows.Err()
+}
+
+// livestockTargets dönemde sürüde bulunan hayvanları döner. head_count anahtarında değer hayvanın dönemde sürüde
+// kaldığı gün sayısı, revenue anahtarında dönemdeki satış veya kesim geliri, equal anahtarında birdir
+func (s *CostAllocationService) livestockTargets(farmID string, rule models.CostAllocationRule, start, end string) ([]allocationTarget, error) {
+	query := `
+		SELECT l.id, l.tag_number, date(COALESCE(l.acquisition_date, l.birth_date, l.created_at)),
+		       date(COALESCE(l.sale_date, s.slaughter_date, (
+		           SELECT MIN(x.movement_date) FROM livestock_movements x
+		           WHERE x.livestock_id = l.id AND x.movement_type IN (` + exitMovementTypes + `)
+		       ))),
+		       CASE WHEN date(l.sale_date) BETWEEN ? AND ? THEN COALESCE(l.sale_price, 0)
+		            WHEN date(s.slaughter_date) BETWEEN ? AND ? THEN COALESCE(s.price, 0) ELSE 0 END
+		FROM livestock l
+		LEFT JOIN slaughter_records s ON s.livestock_id = l.id
+		WHERE l.user_id = ?`
+	args := []interface{}{start, end, start, end, farmID}
+	if rule.AnimalType != "" {
+		query += " AND lower(l.type) = lower(?)"
+		args = append(args, rule.AnimalType)
+	}
+
+	rows, err := s.db.Query(query+" ORDER BY l.tag_number", args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	periodStart, _ := time.Parse("2006-01-02", start)
+	periodEnd, _ := time.Parse("2006-01-02", end)
+
+	var targets []allocationTarget
+	for rows.Next() {
+		var target allocationTarget
+		var entered, exited sql.NullString
+		var revenue float64
+		if err := rows.Scan(&target.id, &target.name, &entered, &exited, &revenue); err != nil {
+			return nil, err
+		}
+
+		from, to := periodStart, periodEnd
+		if day, err := time.Parse("2006-01-02", entered.String); err == nil && day.After(from) {
+			from = day
+		}
+		if day, err := time.Parse("2006-01-02", exited.String); err == nil && day.Before(to) {
+			to = day
+		}
+		days := int(to.Sub(from).Hours()/24) + 1
+		if days <= 0 {
+			continue
+		}
+
+		switch rule.Basis {
+		case models.AllocationBasisHeadCount:
+			target.value = float64(days)
+		case models.AllocationBasisRevenue:
+			target.value = revenue
+		default:
+			target.value = 1
+		}
+		targets = append(targets, target)
+	}
+	return targets, rows.Err()
+}
+
+// normalizeAllocationRule isteği doğrular, kategorileri tekilleştirir ve saklanacak biçimde döner
+func normalizeAllocationRule(req *models.CostAllocationRuleRequest) (string, error) {
+	req.Name = strings.TrimSpace(req.Name)
+	req.AnimalType = strings.TrimSpace(req.AnimalType)
+	switch {
+	case req.Basis == models.AllocationBasisArea && req.TargetType != models.AllocationTargetLand,
+		req.Basis == models.AllocationBasisHeadCount && req.TargetType != models.AllocationTargetLivestock:
+		return "", ErrInvalidAllocationBasis
+	}
+	if req.TargetType != models.AllocationTargetLivestock {
+		req.AnimalType = ""
+	}
+
+	var categories []string
+	seen := map[string]bool{}
+	for _, category := range req.Categories {
+		category = strings.TrimSpace(category)
+		if category == "" || seen[category] {
+			continue
+		}
+		seen[category] = true
+		categories = append(categories, category)
+	}
+	return strings.Join(categories, ","), nil
+}
+
+// scanCostAllocationRule dağıtım kuralı satırını okur
+func scanCostAllocationRule(row interface{ Scan(...interface{}) error }) (models.CostAllocationRule, error) {
+	var rule models.CostAllocationRule
+	var categories string
+	err := row.Scan(&rule.ID, &rule.Name, &categories, &rule.TargetType, &rule.Basis, &rule.AnimalType,
+		&rule.CreatedAt, &rule.UpdatedAt)
+	rule.Categories = strings.Split(categories, ",")
+	return rule, err
+}
@@ -0,0 +1,394 @@
+package services
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"agri-management-api/internal/models"
+	"agri-management-api/internal/utils"
+)
+
+var (
+	// ErrCropPlanNotFound ekim planı yok veya araziye ait değil
+	ErrCropPlanNotFound = errors.New("ekim planı bulunamadı")
+	// ErrCropPlanLand ekim planının arazisi bulunamadı
+	ErrCropPlanLand = errors.New("arazi bulunamadı")
+	// ErrCropPlanDates hasat tarihi ekimden önce
+	ErrCropPlanDates = errors.New("hasat tarihi ekim tarihinden sonra olmalıdır")
+	// ErrCropPlanOverlap arazide aynı dönemi kapsayan başka bir plan var
+	ErrCropPlanOverlap = errors.New("arazide bu dönemi kapsayan başka bir ekim planı var")
+)
+
+// cropFamilies ekim nöbetinde birlikte değerlendirilen ürün familyaları; ürün adı serbest metin olduğu için
+// Türkçe ve İngilizce karşılıkları birlikte tanımlıdır
+var cropFamilies = map[string][]string{
+	"cereals":    {"wheat", "buğday", "barley", "arpa", "oat", "yulaf", "rye", "çavdar", "triticale", "tritikale", "rice", "çeltik", "corn", "maize", "mısır"},
+	"legumes":    {"chickpea", "nohut", "lentil", "mercimek", "bean", "fasulye", "pea", "bezelye", "soybean", "soya", "alfalfa", "yonca", "vetch", "fiğ", "peanut", "yer fıstığı"},
+	"solanaceae": {"tomato", "domates", "potato", "patates", "pepper", "biber", "eggplant", "patlıcan"},
+	"brassicas":  {"cabbage", "lahana", "canola", "kanola", "rapeseed", "kolza", "cauliflower", "karnabahar", "broccoli", "brokoli"},
+	"cucurbits":  {"cucumber", "salatalık", "hıyar", "melon", "kavun", "watermelon", "karpuz", "squash", "kabak"},
+	"sunflower":  {"sunflower", "ayçiçeği", "ayçiçek"},
+	"beet":       {"sugar beet", "şeker pancarı", "beet", "pancar"},
+	"cotton":     {"cotton", "pamuk"},
+}
+
+// cropFamilyLabels familyaların uyarılarda kullanılan Türkçe adları
+var cropFamilyLabels = map[string]string{
+	"cereals":    "tahıl",
+	"legumes":    "baklagil",
+	"solanaceae": "patlıcangil",
+	"brassicas":  "turpgil",
+	"cucurbits":  "kabakgil",
+	"sunflower":  "ayçiçeği",
+	"beet":       "pancar",
+	"cotton":     "pamuk",
+}
+
+// cropReturnYears hastalık ve zararlı birikimini önlemek için familyanın aynı araziye en erken dönebileceği yıl
+var cropReturnYears = map[string]int{
+	"legumes":    3,
+	"solanaceae": 3,
+	"brassicas":  3,
+	"cucurbits":  3,
+	"sunflower":  4,
+	"beet":       3,
+}
+
+// CropFamily ürün adının ekim nöbeti familyasını döner; en uzun eşleşen ad kullanılır, bilinmeyen ürünlerde boş döner
+func CropFamily(crop string) string {
+	crop = strings.ToLower(strings.TrimSpace(crop))
+	family, matched := "", 0
+	for key, aliases := range cropFamilies {
+		for _, alias := range aliases {
+			if len(alias) > matched && strings.Contains(crop, alias) {
+				family, matched = key, len(alias)
+			}
+		}
+	}
+	return family
+}
+
+// cropPlanSelect ekim planı sütunları
+const cropPlanSelect = `
+	SELECT p.id, p.land_id, l.name, p.season, p.crop, COALESCE(p.variety, ''), date(p.planting_date),
+	       date(p.harvest_date), p.status, COALESCE(p.notes, ''), p.created_at, p.updated_at
+	FROM crop_plans p
+	JOIN lands l ON l.id = p.land_id AND l.user_id = p.user_id`
+
+// CropPlanService arazilerin sezonluk ekim planlarını yönetir ve planları arazinin önceki planlarıyla ekim nöbeti
+// kurallarına göre denetler: aynı ürünün veya aynı familyanın art arda ekilmesi ve familyanın araziye dönüş
+// süresinden önce yeniden ekilmesi uyarı olarak döner; aynı dönemi kapsayan planlar reddedilir
+type CropPlanService struct {
+	db *sql.DB
+}
+
+// NewCropPlanService yeni ekim planı servisi oluşturur
+func NewCropPlanService(db *sql.DB) *CropPlanService {
+	return &CropPlanService{db: db}
+}
+
+// List arazinin ekim planlarını ekim tarihine göre ekim nöbeti uyarılarıyla döner
+func (s *CropPlanService) List(farmID, landID string) ([]models.CropPlan, error) {
+	currentCrop, err := s.landCrop(farmID, landID)
+	if err != nil {
+		return nil, err
+	}
+	plans, err := s.query(cropPlanSelect+" WHERE p.user_id = ? AND p.land_id = ? ORDER BY p.planting_date, p.created_at", farmID, landID)
+	if err != nil {
+		return nil, err
+	}
+	checkRotation(plans, currentCrop)
+	return plans, nil
+}
+
+// ListFarm çiftliğin tüm arazilerindeki ekim planlarını arazi ve ekim tarihine göre döner; season verilirse
+// yalnızca o sezonun, aralık verilirse ekim tarihi [startDate, endDate] içindeki planlar listelenir
+func (s *CropPlanService) ListFarm(farmID, season string, startDate, endDate *time.Time) ([]models.CropPlan, error) {
+	plans, err := s.query(cropPlanSelect+" WHERE p.user_id = ? ORDER BY l.name, p.land_id, p.planting_date, p.created_at", farmID)
+	if err != nil {
+		return nil, err
+	}
+	crops, err := s.landCrops(farmID)
+	if err != nil {
+		return nil, err
+	}
+
+	result := []models.CropPlan{}
+	for start := 0; start < len(plans); {
+		end := start
+		for end < len(plans) && plans[end].LandID == plans[start].LandID {
+			end++
+		}
+		land := plans[start:end]
+		checkRotation(land, crops[land[0].LandID])
+		for _, plan := range land {
+			if season != "" && !strings.EqualFold(plan.Season, season) {
+				continue
+			}
+			if startDate != nil && plan.PlantingDate < startDate.Format("2006-01-02") {
+				continue
+			}
+			if endDate != nil && plan.PlantingDate > endDate.Format("2006-01-02") {
+				continue
+			}
+			result = append(result, plan)
+		}
+		start = end
+	}
+	return result, nil
+}
+
+// Get arazinin ekim planını uyarılarıyla döner
+func (s *CropPlanService) Get(farmID, landID, id string) (*models.CropPlan, error) {
+	plans, err := s.List(farmID, landID)
+	if err != nil {
+		return nil, err
+	}
+	for i := range plans {
+		if plans[i].ID == id {
+			return &plans[i], nil
+		}
+	}
+	return nil, ErrCropPlanNotFound
+}
+
+// Create araziye ekim planı ekler
+func (s *CropPlanService) Create(farmID, landID string, req models.CropPlanRequest) (*models.CropPlan, error) {
+	if _, err := s.landCrop(farmID, landID); err != nil {
+		return nil, err
+	}
+	status := cropPlanStatus(req.Status)
+	if err := s.checkPeriod(farmID, landID, "", status, req); err != nil {
+		return nil, err
+	}
+
+	id := utils.GenerateID()
+	_, err := s.db.Exec(`
+		INSERT INTO crop_plans (id, user_id, land_id, season, crop, variety, planting_date, harvest_date, status, notes,
+		                        created_at, updated_at)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, CURRENT_TIMESTAMP, CURRENT_TIMESTAMP)
+	`, id, farmID, landID, strings.TrimSpace(req.Season), strings.TrimSpace(req.Crop), strings.TrimSpace(req.Variety),
+		req.PlantingDate, utils.StringToNullString(req.HarvestDate), status, req.Notes)
+	if err != nil {
+		return nil, err
+	}
+	return s.Get(farmID, landID, id)
+}
+
+// Update ekim planını günceller
+func (s *CropPlanService) Update(farmID, landID, id string, req models.CropPlanRequest) (*models.CropPlan, error) {
+	if _, err := s.Get(farmID, landID, id); err != nil {
+		return nil, err
+	}
+	status := cropPlanStatus(req.Status)
+	if err := s.checkPeriod(farmID, landID, id, status, req); err != nil {
+		return nil, err
+	}
+
+	_, err := s.db.Exec(`
+		UPDATE crop_plans
+		SET season = ?, crop = ?, variety = ?, planting_date = ?, harvest_date = ?, status = ?, notes = ?,
+		    updated_at = CURRENT_TIMESTAMP
+		WHERE id = ? AND land_id = ? AND user_id = ?
+	`, strings.TrimSpace(req.Season), strings.TrimSpace(req.Crop), strings.TrimSpace(req.Variety), req.PlantingDate,
+		utils.StringToNullString(req.HarvestDate), status, req.Notes, id, landID, farmID)
+	if err != nil {
+		return nil, err
+	}
+	return s.Get(farmID, landID, id)
+}
+
+// Delete ekim planını siler
+func (s *CropPlanService) Delete(farmID, landID, id string) error {
+	result, err := s.db.Exec("DELETE FROM crop_plans WHERE id = ? AND land_id = ? AND user_id = ?", id, landID, farmID)
+	if err != nil {
+		return err
+	}
+	if rows, _ := result.RowsAffected(); rows == 0 {
+		return ErrCropPlanNotFound
+	}
+	return nil
+}
+
+// checkPeriod planın tarihlerini ve arazideki diğer planlarla çakışmasını denetler; iptal edilen planlar çakışmaz
+func (s *CropPlanService) checkPeriod(farmID, landID, id, status string, req models.CropPlanRequest) error {
+	if req.HarvestDate != "" && req.HarvestDate <= req.PlantingDate {
+		return ErrCropPlanDates
+	}
+	if status == models.CropPlanStatusCancelled {
+		return nil
+	}
+
+	start, end := req.PlantingDate, cropPlanHarvestDate(req.PlantingDate, req.HarvestDate)
+	var overlapping bool
+	err := s.db.QueryRow(`
+		SELECT 1 FROM crop_plans
+		WHERE user_id = ? AND land_id = ? AND id != ? AND status != ?
+		  AND date(planting_date) <= date(?)
+		  AND date(COALESCE(harvest_date, date(planting_date, '+`+fmt.Sprint(cropHarvestStageDay)+` days'))) >= date(?)
+		LIMIT 1
+	`, farmID, landID, id, models.CropPlanStatusCancelled, end, start).Scan(&overlapping)
+	if err == sql.ErrNoRows {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	return ErrCropPlanOverlap
+}
+
+// landCrop arazinin çiftliğe ait olduğunu doğrular ve arazideki mevcut ürünü döner
+func (s *CropPlanService) landCrop(farmID, landID string) (string, error) {
+	var crop string
+	err := s.db.QueryRow("SELECT COALESCE(crop, '') FROM lands WHERE id = ? AND user_id = ?", landID, farmID).Scan(&crop)
+	if err == sql.ErrNoRows {
+		return "", ErrCropPlanLand
+	}
+	return crop, err
+}
+
+// landCrops çiftliğin arazilerindeki mevcut ürünleri döner
+func (s *CropPlanService) landCrops(farmID string) (map[string]string, error) {
+	rows, err := s.db.Query("SELECT id, COALESCE(crop, '') FROM lands WHERE user_id = ?", farmID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	crops := map[string]string{}
+	for rows.Next() {
+		var id, crop string
+		if err := rows.Scan(&id, &crop); err != nil {
+			return nil, err
+		}
+		crops[id] = crop
+	}
+	return crops, rows.Err()
+}
+
+// query ekim planlarını okur
+func (s *CropPlanService) query(query string, args ...interface{}) ([]models.CropPlan, error) {
+	rows, err := s.db.Query(query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	plans := []models.CropPlan{}
+	for rows.Next() {
+		var plan models.CropPlan
+		var harvestDate sql.NullString
+		if err := rows.Scan(&plan.ID, &plan.LandID, &plan.LandName, &plan.Season, &plan.Crop, &plan.Variety,
+			&plan.PlantingDate, &harvestDate, &plan.Status, &plan.Notes, &plan.CreatedAt, &plan.UpdatedAt); err != nil {
+			return nil, err
+		}
+		plan.HarvestDate = cropPlanHarvestDate(plan.PlantingDate, harvestDate.String)
+		plan.CropFamily = CropFamily(plan.Crop)
+		plan.Warnings = []models.RotationWarning{}
+		plans = append(plans, plan)
+	}
+	return plans, rows.Err()
+}
+
+// checkRotation bir arazinin ekim tarihine göre sıralı planlarına ekim nöbeti uyarılarını ekler. Henüz ekilmemiş
+// ilk plan arazideki mevcut ürünle karşılaştırılır; ekilmiş ilk planın ürünü zaten mevcut üründür. İptal edilen
+// planlar nöbete dahil edilmez
+func checkRotation(plans []models.CropPlan, currentCrop string) {
+	var history []*models.CropPlan
+	for i := range plans {
+		plan := &plans[i]
+		if plan.Status == models.CropPlanStatusCancelled {
+			continue
+		}
+
+		if len(history) == 0 {
+			if currentCrop != "" && plan.Status == models.CropPlanStatusPlanned {
+				previous := &models.CropPlan{Crop: currentCrop, Season: "mevcut ürün", CropFamily: CropFamily(currentCrop)}
+				if warning, ok := consecutiveWarning(plan, previous); ok {
+					plan.Warnings = append(plan.Warnings, warning)
+				}
+			}
+		} else if warning, ok := consecutiveWarning(plan, history[len(history)-1]); ok {
+			plan.Warnings = append(plan.Warnings, warning)
+		} else if warning, ok := returnIntervalWarning(plan, history); ok {
+			plan.Warnings = append(plan.Warnings, warning)
+		}
+		history = append(history, plan)
+	}
+}
+
+// consecutiveWarning plan önceki planla aynı ürün veya aynı familyadansa uyarı döner
+func consecutiveWarning(plan, previous *models.CropPlan) (models.RotationWarning, bool) {
+	warning := models.RotationWarning{PreviousCrop: previous.Crop, PreviousSeason: previous.Season}
+	if previous.ID != "" {
+		warning.PreviousPlanID = &previous.ID
+	}
+
+	switch {
+	case strings.EqualFold(strings.TrimSpace(plan.Crop), strings.TrimSpace(previous.Crop)):
+		warning.Code = models.RotationWarningSameCrop
+		warning.Message = fmt.Sprintf("%s art arda iki sezon aynı araziye planlandı (önceki: %s)", plan.Crop, previous.Season)
+	case plan.CropFamily != "" && plan.CropFamily == previous.CropFamily:
+		warning.Code = models.RotationWarningSameFamily
+		warning.Message = fmt.Sprintf("%s, önceki sezondaki %s ile aynı %s familyasından; ekim nöbetinde farklı familya önerilir",
+			plan.Crop, previous.Crop, cropFamilyLabels[plan.CropFamily])
+	default:
+		return warning, false
+	}
+	return warning, true
+}
+
+// returnIntervalWarning familyanın araziye dönüş süresi dolmadan aynı familyadan ekilen planlar için uyarı döner
+func returnIntervalWarning(plan *models.CropPlan, history []*models.CropPlan) (models.RotationWarning, bool) {
+	years, ok := cropReturnYears[plan.CropFamily]
+	if !ok {
+		return models.RotationWarning{}, false
+	}
+	planted, err := time.Parse("2006-01-02", plan.PlantingDate)
+	if err != nil {
+		return models.RotationWarning{}, false
+	}
+	limit := planted.AddDate(-years, 0, 0).Format("2006-01-02")
+
+	for i := len(history) - 1; i >= 0; i-- {
+		previous := history[i]
+		if previous.PlantingDate <= limit {
+			break
+		}
+		if previous.CropFamily != plan.CropFamily {
+			continue
+		}
+		return models.RotationWarning{
+			Code: models.RotationWarningReturnInterval,
+			Message: fmt.Sprintf("%s familyası araziye en erken %d yıl sonra dönmelidir; son ekim %s sezonunda (%s)",
+				cropFamilyLabels[plan.CropFamily], years, previous.Season, previous.Crop),
+			PreviousPlanID: &previous.ID,
+			PreviousCrop:   previous.Crop,
+			PreviousSeason: previous.Season,
+		}, true
+	}
+	return models.RotationWarning{}, false
+}
+
+// cropPlanStatus boş durumu planlandı olarak döner
+func cropPlanStatus(status string) string {
+	if status == "" {
+		return models.CropPlanStatusPlanned
+	}
+	return status
+}
+
+// cropPlanHarvestDate planın hasat tarihini döner; girilmemişse ekimden hasat dönemine geçilen gün kullanılır
+func cropPlanHarvestDate(plantingDate, harvestDate string) string {
+	if harvestDate != "" {
+		return harvestDate
+	}
+	planted, err := time.Parse("2006-01-02", plantingDate)
+	if err != nil {
+		return ""
+	}
+	return planted.AddDate(0, 0, cropHarvestStageDay).Format("2006-01-02")
+}
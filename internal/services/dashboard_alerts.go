@@ -0,0 +1,367 @@
+package services
+
+import (
+	"database/sql"
+	"fmt"
+	"sort"
+	"time"
+
+	"agri-management-api/internal/models"
+)
+
+// LowStockRatio kalan stok parti miktarının bu oranına düştüğünde stok düşük sayılır
+const LowStockRatio = 0.1
+
+// Dashboard uyarı eşikleri
+const (
+	// vaccinationCriticalDays bu kadar günden uzun geciken aşılar kritik sayılır
+	vaccinationCriticalDays = 14
+	// documentUrgentDays bitişine bu kadar gün ve daha az kalan dokümanlar yüksek önemlidir
+	documentUrgentDays = 7
+	// cashFlowHorizonMonths nakit projeksiyonunun kapsadığı ay sayısı
+	cashFlowHorizonMonths = 3
+	// weatherAlertWindow hava uyarısı bildirimlerinin güncel sayıldığı süre
+	weatherAlertWindow = 24 * time.Hour
+)
+
+// alertSeverityRank önem derecelerinin sıralama değeri; küçük olan önce gelir
+var alertSeverityRank = map[string]int{
+	models.AlertSeverityCritical: 0,
+	models.AlertSeverityHigh:     1,
+	models.AlertSeverityMedium:   2,
+	models.AlertSeverityLow:      3,
+}
+
+// DashboardAlertService modüllerdeki güncel, aksiyon gerektiren durumları tek listede toplar
+type DashboardAlertService struct {
+	db        *sql.DB
+	forecasts *ForecastService
+}
+
+// NewDashboardAlertService yeni dashboard uyarı servisi oluşturur; kayıtlar okuma veritabanından okunur,
+// tahmin kayıtları birincil veritabanına yazılır
+func NewDashboardAlertService(db, readDB *sql.DB) *DashboardAlertService {
+	return &DashboardAlertService{db: readDB, forecasts: NewForecastService(db)}
+}
+
+// Alerts geciken aşıları, süresi dolan/dolmak üzere olan dokümanları ve çalışan sertifikalarını, düşük stokları,
+// negatife düşen nakit projeksiyonunu ve son 24 saatin hava uyarılarını önem derecesine, aynı derecede tarihe göre sıralı döner
+func (s *DashboardAlertService) Alerts(farmID string, now time.Time) (models.DashboardAlerts, error) {
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC)
+	result := models.DashboardAlerts{
+		BySeverity: map[string]int{},
+		Alerts:     []models.DashboardAlert{},
+	}
+
+	sources := []func(string, time.Time) ([]models.DashboardAlert, error){
+		s.overdueVaccinations,
+		s.expiringDocuments,
+		s.expiringCertifications,
+		s.lowStock,
+		s.cashFlow,
+		func(farmID string, _ time.Time) ([]models.DashboardAlert, error) {
+			return s.weatherAlerts(farmID, now)
+		},
+	}
+	for _, source := range sources {
+		alerts, err := source(farmID, today)
+		if err != nil {
+			return result, err
+		}
+		result.Alerts = append(result.Alerts, alerts...)
+	}
+
+	sort.SliceStable(result.Alerts, func(i, j int) bool {
+		a, b := result.Alerts[i], result.Alerts[j]
+		if alertSeverityRank[a.Severity] != alertSeverityRank[b.Severity] {
+			return alertSeverityRank[a.Severity] < alertSeverityRank[b.Severity]
+		}
+		if a.Date == nil || b.Date == nil {
+			return b.Date == nil && a.Date != nil
+		}
+		return a.Date.Before(*b.Date)
+	})
+	for _, alert := range result.Alerts {
+		result.BySeverity[alert.Severity]++
+	}
+	result.Total = len(result.Alerts)
+	return result, nil
+}
+
+// overdueVaccinations sürüdeki hayvanların, sonrasında aynı türde kayıt girilmemiş ve tarihi geçmiş aşıları
+func (s *DashboardAlertService) overdueVaccinations(farmID string, today time.Time) ([]models.DashboardAlert, error) {
+	rows, err := s.db.Query(`
+		SELECT date(r.next_checkup), COALESCE(r.description, ''), l.id, l.tag_number
+		FROM health_records r
+		JOIN livestock l ON l.id = r.livestock_id
+		WHERE l.user_id = ? AND r.type = ? AND r.next_checkup IS NOT NULL AND date(r.next_checkup) < ?
+		  AND `+inHerdCondition+`
+		  AND NOT EXISTS (
+		      SELECT 1 FROM health_records n
+		      WHERE n.livestock_id = r.livestock_id AND n.type = r.type AND date(n.date) > date(r.date)
+		  )
+	`, farmID, models.ProtocolStepVaccination, today.Format("2006-01-02"))
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var alerts []models.DashboardAlert
+	for rows.Next() {
+		var day, description, animalID, tag string
+		if err := rows.Scan(&day, &description, &animalID, &tag); err != nil {
+			return nil, err
+		}
+		due, err := time.Parse("2006-01-02", day)
+		if err != nil {
+			continue
+		}
+		overdue := int(today.Sub(due).Hours() / 24)
+		severity := models.AlertSeverityHigh
+		if overdue > vaccinationCriticalDays {
+			severity = models.AlertSeverityCritical
+		}
+		message := fmt.Sprintf("Aşı %d gün gecikti", overdue)
+		if description != "" {
+			message = description + " - " + message
+		}
+		alerts = append(alerts, models.DashboardAlert{
+			Kind:     models.DashboardAlertOverdueVaccination,
+			Severity: severity,
+			Title:    "Geciken aşı - " + tag,
+			Message:  message,
+			Date:     &due,
+			Entity:   &models.RelatedEntity{Type: "livestock", ID: animalID, Name: tag},
+		})
+	}
+	return alerts, rows.Err()
+}
+
+// expiringDocuments süresi dolmuş veya hatırlatma süresi içinde dolacak dokümanlar
+func (s *DashboardAlertService) expiringDocuments(farmID string, today time.Time) ([]models.DashboardAlert, error) {
+	rows, err := s.db.Query(`
+		SELECT id, title, date(expiry_date)
+		FROM documents
+		WHERE user_id = ? AND expiry_date IS NOT NULL
+		  AND date(expiry_date) <= date(?, '+' || COALESCE(reminder_days, 30) || ' days')
+	`, farmID, today.Format("2006-01-02"))
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var alerts []models.DashboardAlert
+	for rows.Next() {
+		var id, title, day string
+		if err := rows.Scan(&id, &title, &day); err != nil {
+			return nil, err
+		}
+		expiry, err := time.Parse("2006-01-02", day)
+		if err != nil {
+			continue
+		}
+		remaining := int(expiry.Sub(today).Hours() / 24)
+		alert := models.DashboardAlert{
+			Kind:     models.DashboardAlertDocumentExpiry,
+			Severity: models.AlertSeverityMedium,
+			Title:    "Doküman süresi doluyor - " + title,
+			Message:  fmt.Sprintf("%d gün sonra sona eriyor", remaining),
+			Date:     &expiry,
+			Entity:   &models.RelatedEntity{Type: "document", ID: id, Name: title},
+		}
+		switch {
+		case remaining < 0:
+			alert.Severity = models.AlertSeverityCritical
+			alert.Title = "Doküman süresi doldu - " + title
+			alert.Message = fmt.Sprintf("%d gün önce sona erdi", -remaining)
+		case remaining == 0:
+			alert.Severity = models.AlertSeverityHigh
+			alert.Message = "Bugün sona eriyor"
+		case remaining <= documentUrgentDays:
+			alert.Severity = models.AlertSeverityHigh
+		}
+		alerts = append(alerts, alert)
+	}
+	return alerts, rows.Err()
+}
+
+// expiringCertifications sözleşmesi süren çalışanların süresi dolmuş veya 30 gün içinde dolacak sertifikaları;
+// aynı türde daha geç biten yenilenmiş belgesi olanlar atlanır
+func (s *DashboardAlertService) expiringCertifications(farmID string, today time.Time) ([]models.DashboardAlert, error) {
+	day := today.Format("2006-01-02")
+	rows, err := s.db.Query(`
+		SELECT w.id, w.name, c.type, date(c.expires_at)
+		FROM worker_certifications c
+		JOIN workers w ON w.id = c.worker_id
+		WHERE w.user_id = ? AND c.expires_at IS NOT NULL
+		  AND date(c.expires_at) <= date(?, '+' || ? || ' days')
+		  AND (w.contract_end IS NULL OR date(w.contract_end) >= ?)
+		  AND NOT EXISTS (
+		      SELECT 1 FROM worker_certifications n
+		      WHERE n.worker_id = c.worker_id AND n.type = c.type
+		        AND (n.expires_at IS NULL OR date(n.expires_at) > date(c.expires_at))
+		  )
+	`, farmID, day, certificationExpiringDays, day)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var alerts []models.DashboardAlert
+	for rows.Next() {
+		var workerID, name, certification, expiresAt string
+		if err := rows.Scan(&workerID, &name, &certification, &expiresAt); err != nil {
+			return nil, err
+		}
+		expiry, err := time.Parse("2006-01-02", expiresAt)
+		if err != nil {
+			continue
+		}
+		remaining := int(expiry.Sub(today).Hours() / 24)
+		label := certificationLabels[certification]
+		alert := models.DashboardAlert{
+			Kind:     models.DashboardAlertCertification,
+			Severity: models.AlertSeverityMedium,
+			Title:    "Sertifika süresi doluyor - " + name,
+			Message:  fmt.Sprintf("%s %d gün sonra sona eriyor", label, remaining),
+			Date:     &expiry,
+			Entity:   &models.RelatedEntity{Type: "worker", ID: workerID, Name: name},
+		}
+		switch {
+		case remaining < 0:
+			alert.Severity = models.AlertSeverityHigh
+			alert.Title = "Sertifika süresi doldu - " + name
+			alert.Message = fmt.Sprintf("%s %d gün önce sona erdi", label, -remaining)
+		case remaining <= documentUrgentDays:
+			alert.Severity = models.AlertSeverityHigh
+		}
+		alerts = append(alerts, alert)
+	}
+	return alerts, rows.Err()
+}
+
+// lowStock kalan stoğu parti miktarının %10'una düşmüş, tamamen tükenmemiş ürünler
+func (s *DashboardAlertService) lowStock(farmID string, _ time.Time) ([]models.DashboardAlert, error) {
+	rows, err := s.db.Query(`
+		SELECT id, name, amount, amount - COALESCE(sold_amount, 0) - COALESCE(lost_amount, 0), unit
+		FROM production
+		WHERE user_id = ? AND amount > 0
+		  AND amount - COALESCE(sold_amount, 0) - COALESCE(lost_amount, 0) > 0
+		  AND amount - COALESCE(sold_amount, 0) - COALESCE(lost_amount, 0) <= amount * ?
+	`, farmID, LowStockRatio)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var alerts []models.DashboardAlert
+	for rows.Next() {
+		var id, name, unit string
+		var amount, stock float64
+		if err := rows.Scan(&id, &name, &amount, &stock, &unit); err != nil {
+			return nil, err
+		}
+		alerts = append(alerts, models.DashboardAlert{
+			Kind:     models.DashboardAlertLowStock,
+			Severity: models.AlertSeverityMedium,
+			Title:    "Düşük stok - " + name,
+			Message:  fmt.Sprintf("Kalan stok %s / %s %s", formatReportAmount(stock), formatReportAmount(amount), unit),
+			Entity:   &models.RelatedEntity{Type: "production", ID: id, Name: name},
+		})
+	}
+	return alerts, rows.Err()
+}
+
+// cashFlow bugünkü nakit bakiyesine (hesap açılış bakiyeleri ve tamamlanmış işlemler) önümüzdeki üç ayın
+// gelir ve gider tahminlerini ekler; bakiye negatife düşüyorsa ilk negatif ay için uyarı döner. Bakiye
+// zaten negatifse uyarı kritiktir
+func (s *DashboardAlertService) cashFlow(farmID string, today time.Time) ([]models.DashboardAlert, error) {
+	var balance float64
+	err := s.db.QueryRow(`
+		SELECT COALESCE((SELECT SUM(opening_balance) FROM bank_accounts WHERE user_id = ?), 0) +
+		       COALESCE((SELECT SUM(CASE WHEN type = 'income' THEN amount ELSE -amount END) FROM transactions
+		                 WHERE user_id = ? AND COALESCE(status, 'completed') = 'completed' AND date(date) <= ?), 0)
+	`, farmID, farmID, today.Format("2006-01-02")).Scan(&balance)
+	if err != nil {
+		return nil, err
+	}
+
+	if balance < 0 {
+		return []models.DashboardAlert{{
+			Kind:     models.DashboardAlertNegativeCashFlow,
+			Severity: models.AlertSeverityCritical,
+			Title:    "Nakit bakiyesi negatif",
+			Message:  fmt.Sprintf("Güncel bakiye %s", formatReportAmount(balance)),
+			Date:     &today,
+		}}, nil
+	}
+
+	income, err := s.forecasts.Forecast(farmID, "income_total", cashFlowHorizonMonths, today)
+	if err != nil {
+		return nil, err
+	}
+	expense, err := s.forecasts.Forecast(farmID, "expense_total", cashFlowHorizonMonths, today)
+	if err != nil {
+		return nil, err
+	}
+	if income.History == 0 && expense.History == 0 {
+		return nil, nil
+	}
+
+	projected := balance
+	for i := 0; i < len(income.Points) && i < len(expense.Points); i++ {
+		projected += income.Points[i].Value - expense.Points[i].Value
+		if projected >= 0 {
+			continue
+		}
+		month, err := time.Parse("2006-01", income.Points[i].Period)
+		if err != nil {
+			return nil, nil
+		}
+		return []models.DashboardAlert{{
+			Kind:     models.DashboardAlertNegativeCashFlow,
+			Severity: models.AlertSeverityHigh,
+			Title:    "Nakit akışı negatife dönüyor",
+			Message: fmt.Sprintf("Tahmini bakiye %s ayında %s olacak (güncel bakiye %s)",
+				income.Points[i].Period, formatReportAmount(projected), formatReportAmount(balance)),
+			Date: &month,
+		}}, nil
+	}
+	return nil, nil
+}
+
+// weatherAlerts son 24 saatte gönderilmiş don ve yoğun yağış uyarıları
+func (s *DashboardAlertService) weatherAlerts(farmID string, now time.Time) ([]models.DashboardAlert, error) {
+	rows, err := s.db.Query(`
+		SELECT title, message, created_at, COALESCE(related_entity_type, ''), COALESCE(related_entity_id, ''),
+		       COALESCE(related_entity_name, '')
+		FROM notifications
+		WHERE user_id = ? AND topic = ? AND created_at >= ?
+		ORDER BY created_at DESC
+	`, farmID, models.NotificationTopicWeatherAlert, now.Add(-weatherAlertWindow).UTC().Format("2006-01-02 15:04:05"))
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var alerts []models.DashboardAlert
+	for rows.Next() {
+		var title, message, entityType, entityID, entityName string
+		var createdAt time.Time
+		if err := rows.Scan(&title, &message, &createdAt, &entityType, &entityID, &entityName); err != nil {
+			return nil, err
+		}
+		alert := models.DashboardAlert{
+			Kind:     models.DashboardAlertWeather,
+			Severity: models.AlertSeverityHigh,
+			Title:    title,
+			Message:  message,
+			Date:     &createdAt,
+		}
+		if entityID != "" {
+			alert.Entity = &models.RelatedEntity{Type: entityType, ID: entityID, Name: entityName}
+		}
+		alerts = append(alerts, alert)
+	}
+	return alerts, rows.Err()
+}
@@ -0,0 +1,760 @@
+package services
+
+import (
+	"bytes"
+	"database/sql"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+
+	"agri-management-api/internal/models"
+	"agri-management-api/internal/utils"
+)
+
+// Geçmiş veri içe aktarım profilleri
+const (
+	DataImportProfileGeneric   = "generic"
+	DataImportProfileFarmbrite = "farmbrite"
+)
+
+// maxDataImportRows tek dosyadan içe aktarılabilecek en fazla satır sayısı
+const maxDataImportRows = 5000
+
+var (
+	// ErrEmptyDataImport dosyada veri satırı bulunamadığında döner
+	ErrEmptyDataImport = errors.New("import file contains no rows")
+	// ErrDataImportTooLarge dosya maxDataImportRows sınırını aştığında döner
+	ErrDataImportTooLarge = errors.New("import file has too many rows")
+	// ErrDataImportProfile bilinmeyen içe aktarım profili
+	ErrDataImportProfile = errors.New("unknown import profile")
+	// ErrDataImportEntity profil bu kayıt türünü desteklemiyor
+	ErrDataImportEntity = errors.New("entity not supported by import profile")
+	// ErrDataImportColumns zorunlu alanların sütunları dosyada bulunamadı
+	ErrDataImportColumns = errors.New("required columns not found")
+	// ErrDataImportMapping özel sütun eşlemesinde bilinmeyen alan veya dosyada olmayan sütun var
+	ErrDataImportMapping = errors.New("invalid column mapping")
+	// ErrDataImportNotFound içe aktarım bulunamadı
+	ErrDataImportNotFound = errors.New("import not found")
+	// ErrDataImportClosed içe aktarım zaten onaylanmış veya iptal edilmiş
+	ErrDataImportClosed = errors.New("import already committed or discarded")
+)
+
+// dataImportRequiredFields kayıt türlerinde dosyada sütunu bulunması gereken alanlar
+var dataImportRequiredFields = map[string][]string{
+	models.DataImportEntityAnimals:      {"tagNumber", "type"},
+	models.DataImportEntityLands:        {"name", "area"},
+	models.DataImportEntityTransactions: {"date", "amount"},
+}
+
+// dataImportProfiles desteklenen dışa aktarım formatları. generic bu API'nin belgelenen CSV şemasıdır (İngilizce
+// alan adları veya Türkçe başlıklar); farmbrite Farmbrite'ın hayvan, arazi (location) ve muhasebe dışa aktarımlarıdır
+var dataImportProfiles = []models.DataImportProfile{
+	{
+		Key:         DataImportProfileGeneric,
+		Name:        "Genel CSV",
+		Description: "Alan adları (tagNumber, birthDate...) veya Türkçe başlıklarla hazırlanmış CSV; ayırıcı başlıktan tespit edilir",
+		DateFormats: []string{"2006-01-02", "02.01.2006", "02/01/2006", "2.1.2006"},
+		Columns: map[string]map[string][]string{
+			models.DataImportEntityAnimals: {
+				"tagNumber":    {"tagnumber", "tag_number", "kulak no", "küpe no", "kupe no", "tag"},
+				"type":         {"type", "species", "tür", "tur"},
+				"breed":        {"breed", "ırk", "irk"},
+				"gender":       {"gender", "sex", "cinsiyet"},
+				"birthDate":    {"birthdate", "birth_date", "doğum tarihi", "dogum tarihi"},
+				"weight":       {"weight", "ağırlık", "agirlik"},
+				"healthStatus": {"healthstatus", "health_status", "sağlık durumu", "saglik durumu"},
+				"location":     {"location", "konum"},
+				"mother":       {"mother", "anne", "dam"},
+				"father":       {"father", "baba", "sire"},
+				"notes":        {"notes", "notlar", "not"},
+			},
+			models.DataImportEntityLands: {
+				"name":           {"name", "ad", "arazi", "arazi adı"},
+				"area":           {"area", "alan"},
+				"unit":           {"unit", "birim"},
+				"crop":           {"crop", "ürün", "urun"},
+				"soilType":       {"soiltype", "soil_type", "toprak türü", "toprak turu"},
+				"irrigationType": {"irrigationtype", "irrigation_type", "sulama", "sulama türü"},
+				"address":        {"address", "adres"},
+			},
+			models.DataImportEntityTransactions: {
+				"date":          {"date", "tarih"},
+				"type":          {"type", "tür", "tur"},
+				"category":      {"category", "kategori"},
+				"description":   {"description", "açıklama", "aciklama"},
+				"amount":        {"amount", "tutar"},
+				"currency":      {"currency", "para birimi"},
+				"paymentMethod": {"paymentmethod", "payment_method", "ödeme yöntemi", "odeme yontemi"},
+				"notes":         {"notes", "notlar", "not"},
+			},
+		},
+		Defaults: map[string]map[string]string{
+			models.DataImportEntityLands:        {"unit": "dönüm"},
+			models.DataImportEntityTransactions: {"category": "other", "currency": "TRY"},
+		},
+	},
+	{
+		Key:         DataImportProfileFarmbrite,
+		Name:        "Farmbrite",
+		Description: "Farmbrite Animals, Locations ve Accounting > Transactions CSV dışa aktarımları (ABD tarih biçimi, dönüm yerine acre)",
+		DateFormats: []string{"01/02/2006", "1/2/2006", "2006-01-02", "Jan 2, 2006"},
+		Columns: map[string]map[string][]string{
+			models.DataImportEntityAnimals: {
+				"tagNumber":    {"tag number", "tag #", "tag", "name"},
+				"type":         {"species", "animal type", "type"},
+				"breed":        {"breed"},
+				"gender":       {"sex", "gender"},
+				"birthDate":    {"birth date", "date of birth", "dob"},
+				"weight":       {"current weight", "weight"},
+				"healthStatus": {"health status"},
+				"location":     {"location", "pasture", "pen"},
+				"mother":       {"dam", "mother"},
+				"father":       {"sire", "father"},
+				"notes":        {"notes", "description"},
+			},
+			models.DataImportEntityLands: {
+				"name":           {"location name", "field name", "name"},
+				"area":           {"size", "acreage", "area"},
+				"unit":           {"size unit", "units", "unit"},
+				"crop":           {"current crop", "crop", "planting"},
+				"soilType":       {"soil type"},
+				"irrigationType": {"irrigation", "irrigation type"},
+				"address":        {"address"},
+			},
+			models.DataImportEntityTransactions: {
+				"date":          {"transaction date", "date"},
+				"type":          {"transaction type", "type"},
+				"category":      {"category", "account"},
+				"description":   {"description", "payee", "memo"},
+				"amount":        {"amount", "total"},
+				"currency":      {"currency"},
+				"paymentMethod": {"payment method"},
+				"notes":         {"notes"},
+			},
+		},
+		Defaults: map[string]map[string]string{
+			models.DataImportEntityLands:        {"unit": "acre"},
+			models.DataImportEntityTransactions: {"category": "other", "currency": "USD"},
+		},
+	},
+}
+
+// dataImportSpecies hayvan türü adlarının sistemdeki karşılıkları
+var dataImportSpecies = map[string]string{
+	"cattle": "cattle", "cow": "cattle", "bovine": "cattle", "beef": "cattle", "dairy": "cattle", "sığır": "cattle", "sigir": "cattle", "inek": "cattle",
+	"sheep": "sheep", "ovine": "sheep", "koyun": "sheep",
+	"goat": "goat", "goats": "goat", "caprine": "goat", "keçi": "goat", "keci": "goat",
+	"buffalo": "buffalo", "manda": "buffalo",
+	"horse": "horse", "equine": "horse", "at": "horse",
+	"chicken": "chicken", "chickens": "chicken", "poultry": "chicken", "tavuk": "chicken",
+}
+
+// dataImportGenders cinsiyet ve cinsiyete özgü hayvan adlarının karşılıkları
+var dataImportGenders = map[string]string{
+	"female": "female", "f": "female", "dişi": "female", "disi": "female",
+	"cow": "female", "heifer": "female", "ewe": "female", "doe": "female", "sow": "female", "gilt": "female", "mare": "female", "hen": "female",
+	"male": "male", "m": "male", "erkek": "male",
+	"bull": "male", "steer": "male", "ram": "male", "wether": "male", "buck": "male", "boar": "male", "barrow": "male",
+	"stallion": "male", "gelding": "male", "rooster": "male", "capon": "male",
+}
+
+// dataImportTransactionTypes işlem türü adlarının karşılıkları
+var dataImportTransactionTypes = map[string]string{
+	"income": "income", "revenue": "income", "sale": "income", "deposit": "income", "gelir": "income",
+	"expense": "expense", "cost": "expense", "purchase": "expense", "withdrawal": "expense", "gider": "expense",
+}
+
+// DataImportProfiles desteklenen içe aktarım profillerini döner
+func DataImportProfiles() []models.DataImportProfile {
+	return dataImportProfiles
+}
+
+// findDataImportProfile anahtara göre profili döner
+func findDataImportProfile(key string) (models.DataImportProfile, bool) {
+	for _, profile := range dataImportProfiles {
+		if profile.Key == key {
+			return profile, true
+		}
+	}
+	return models.DataImportProfile{}, false
+}
+
+// DataImportService başka çiftlik yönetimi uygulamalarından geçmiş veri içe aktarımını yönetir
+type DataImportService struct {
+	db *sql.DB
+}
+
+// NewDataImportService yeni data import service oluşturur
+func NewDataImportService(db *sql.DB) *DataImportService {
+	return &DataImportService{db: db}
+}
+
+// Preview dosyayı profil (ve varsa özel sütun eşlemesi) ile okur, her satırı normalleştirip doğrular, mevcut
+// kayıtlarla çakışanları işaretler ve sonucu onaylanmayı bekleyen içe aktarım olarak kaydeder
+func (s *DataImportService) Preview(farmID, accountID, filename, profileKey, entity string, mapping map[string]string, data []byte) (models.DataImport, error) {
+	imp := models.DataImport{
+		Entity:   entity,
+		Profile:  profileKey,
+		Filename: filename,
+		Status:   models.DataImportStatusPreviewed,
+		Summary:  map[string]int{},
+		Rows:     []models.DataImportRow{},
+	}
+
+	profile, ok := findDataImportProfile(profileKey)
+	if !ok {
+		return imp, ErrDataImportProfile
+	}
+	aliases, ok := profile.Columns[entity]
+	if !ok {
+		return imp, ErrDataImportEntity
+	}
+
+	header, records, err := readDataImportCSV(data)
+	if err != nil {
+		return imp, err
+	}
+
+	columns, err := mapDataImportColumns(header, aliases, mapping)
+	if err != nil {
+		return imp, err
+	}
+	var missing []string
+	for _, field := range dataImportRequiredFields[entity] {
+		if _, ok := columns[field]; !ok {
+			missing = append(missing, field)
+		}
+	}
+	if len(missing) > 0 {
+		return imp, fmt.Errorf("%w: %s", ErrDataImportColumns, strings.Join(missing, ", "))
+	}
+
+	imp.Mapping = map[string]string{}
+	used := map[int]bool{}
+	for field, i := range columns {
+		imp.Mapping[field] = header[i]
+		used[i] = true
+	}
+	imp.UnmappedColumns = []string{}
+	for i, name := range header {
+		if !used[i] && name != "" {
+			imp.UnmappedColumns = append(imp.UnmappedColumns, name)
+		}
+	}
+
+	existing, err := s.existingKeys(farmID, entity)
+	if err != nil {
+		return imp, err
+	}
+
+	seen := map[string]bool{}
+	for i, record := range records {
+		values := map[string]string{}
+		for field, column := range columns {
+			if column < len(record) {
+				if value := strings.TrimSpace(record[column]); value != "" {
+					values[field] = value
+				}
+			}
+		}
+		if len(values) == 0 {
+			continue
+		}
+
+		// Başlık 1. satırdır; satır numaraları kullanıcının dosyasıyla eşleşir
+		row := models.DataImportRow{Row: i + 2}
+		row.Values, row.Errors = normalizeDataImportRow(entity, profile, values)
+		key := dataImportKey(entity, row.Values)
+		switch {
+		case len(row.Errors) > 0:
+			row.Action = models.DataImportRowInvalid
+		case existing[key]:
+			row.Action = models.DataImportRowDuplicate
+			row.Errors = []string{"Kayıt zaten mevcut"}
+		case seen[key]:
+			row.Action = models.DataImportRowDuplicate
+			row.Errors = []string{"Aynı kayıt dosyada birden fazla kez var"}
+		default:
+			row.Action = models.DataImportRowCreate
+			seen[key] = true
+		}
+		imp.Summary[row.Action]++
+		imp.Rows = append(imp.Rows, row)
+	}
+	if len(imp.Rows) == 0 {
+		return imp, ErrEmptyDataImport
+	}
+
+	imp.ID = utils.GenerateID()
+	imp.CreatedBy = accountID
+	imp.CreatedAt = time.Now()
+	mappingJSON, _ := json.Marshal(imp.Mapping)
+	unmappedJSON, _ := json.Marshal(imp.UnmappedColumns)
+	summaryJSON, _ := json.Marshal(imp.Summary)
+	rowsJSON, err := json.Marshal(imp.Rows)
+	if err != nil {
+		return imp, err
+	}
+
+	_, err = s.db.Exec(`
+		INSERT INTO data_imports (id, user_id, entity, profile, filename, status, mapping, unmapped_columns,
+		                          summary, row_data, created_by, created_at)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
+	`, imp.ID, farmID, imp.Entity, imp.Profile, imp.Filename, imp.Status, string(mappingJSON), string(unmappedJSON),
+		string(summaryJSON), string(rowsJSON), accountID, imp.CreatedAt)
+	return imp, err
+}
+
+// Commit önizlemesi yapılmış içe aktarımın create satırlarını tek işlemde ekler. Önizlemeden sonra eklenmiş
+// çakışan kayıtlar yeniden kontrol edilir; sonuç satırlara oluşturulan kayıt kimlikleriyle yazılır
+func (s *DataImportService) Commit(farmID, accountID, importID string) (models.DataImport, error) {
+	imp, err := s.Get(farmID, importID)
+	if err != nil {
+		return imp, err
+	}
+	if imp.Status != models.DataImportStatusPreviewed {
+		return imp, ErrDataImportClosed
+	}
+
+	existing, err := s.existingKeys(farmID, imp.Entity)
+	if err != nil {
+		return imp, err
+	}
+
+	tx, err := s.db.Begin()
+	if err != nil {
+		return imp, err
+	}
+	defer tx.Rollback()
+
+	imp.Summary = map[string]int{}
+	for i := range imp.Rows {
+		row := &imp.Rows[i]
+		if row.Action == models.DataImportRowCreate {
+			key := dataImportKey(imp.Entity, row.Values)
+			if existing[key] {
+				row.Action = models.DataImportRowDuplicate
+				row.Errors = []string{"Kayıt önizlemeden sonra eklendi"}
+			} else {
+				recordID, err := insertDataImportRow(tx, farmID, imp.Entity, row.Values)
+				switch {
+				case err == nil:
+					row.Action = models.DataImportRowCreated
+					row.RecordID = recordID
+					existing[key] = true
+				case strings.Contains(err.Error(), "UNIQUE constraint failed"):
+					// Kulak numaraları tüm sistemde benzersizdir
+					row.Action = models.DataImportRowDuplicate
+					row.Errors = []string{"Kulak numarası başka bir işletmede kayıtlı"}
+				default:
+					return imp, fmt.Errorf("row %d: %w", row.Row, err)
+				}
+			}
+		}
+		imp.Summary[row.Action]++
+	}
+
+	now := time.Now()
+	summaryJSON, _ := json.Marshal(imp.Summary)
+	rowsJSON, err := json.Marshal(imp.Rows)
+	if err != nil {
+		return imp, err
+	}
+	result, err := tx.Exec(`
+		UPDATE data_imports SET status = ?, summary = ?, row_data = ?, committed_by = ?, committed_at = ?
+		WHERE id = ? AND user_id = ? AND status = ?
+	`, models.DataImportStatusCommitted, string(summaryJSON), string(rowsJSON), accountID, now,
+		importID, farmID, models.DataImportStatusPreviewed)
+	if err != nil {
+		return imp, err
+	}
+	if affected, _ := result.RowsAffected(); affected == 0 {
+		return imp, ErrDataImportClosed
+	}
+	if err := tx.Commit(); err != nil {
+		return imp, err
+	}
+
+	imp.Status = models.DataImportStatusCommitted
+	imp.CommittedBy = accountID
+	imp.CommittedAt = &now
+	return imp, nil
+}
+
+// Discard onaylanmamış içe aktarımı kayıt eklemeden iptal eder; içe aktarım denetim izi için saklanır
+func (s *DataImportService) Discard(farmID, accountID, importID string) error {
+	result, err := s.db.Exec(`
+		UPDATE data_imports SET status = ?, committed_by = ?, committed_at = CURRENT_TIMESTAMP
+		WHERE id = ? AND user_id = ? AND status = ?
+	`, models.DataImportStatusDiscarded, accountID, importID, farmID, models.DataImportStatusPreviewed)
+	if err != nil {
+		return err
+	}
+	if affected, _ := result.RowsAffected(); affected > 0 {
+		return nil
+	}
+
+	if _, err := s.Get(farmID, importID); err != nil {
+		return err
+	}
+	return ErrDataImportClosed
+}
+
+// dataImportColumns içe aktarım listesi ve detayında okunan sütunlar
+const dataImportColumns = `id, entity, profile, COALESCE(filename, ''), status, mapping, unmapped_columns, summary,
+	COALESCE(created_by, ''), COALESCE(committed_by, ''), created_at, committed_at`
+
+// List içe aktarım geçmişini satır ayrıntıları olmadan en yeniden başlayarak döner
+func (s *DataImportService) List(farmID string) ([]models.DataImport, error) {
+	rows, err := s.db.Query("SELECT "+dataImportColumns+" FROM data_imports WHERE user_id = ? ORDER BY created_at DESC", farmID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	imports := []models.DataImport{}
+	for rows.Next() {
+		imp, err := scanDataImport(rows)
+		if err != nil {
+			return nil, err
+		}
+		imports = append(imports, imp)
+	}
+	return imports, rows.Err()
+}
+
+// Get içe aktarımı satır ayrıntılarıyla döner
+func (s *DataImportService) Get(farmID, importID string) (models.DataImport, error) {
+	var rowsJSON string
+	row := s.db.QueryRow("SELECT "+dataImportColumns+", row_data FROM data_imports WHERE id = ? AND user_id = ?", importID, farmID)
+	imp, err := scanDataImport(row, &rowsJSON)
+	if err == sql.ErrNoRows {
+		return imp, ErrDataImportNotFound
+	}
+	if err != nil {
+		return imp, err
+	}
+	imp.Rows = []models.DataImportRow{}
+	json.Unmarshal([]byte(rowsJSON), &imp.Rows)
+	return imp, nil
+}
+
+// scanDataImport içe aktarım satırını okur; extra sütunlar dataImportColumns'tan sonra okunur
+func scanDataImport(row interface{ Scan(...interface{}) error }, extra ...interface{}) (models.DataImport, error) {
+	var imp models.DataImport
+	var mappingJSON, unmappedJSON, summaryJSON string
+	var committedAt sql.NullTime
+	err := row.Scan(append([]interface{}{&imp.ID, &imp.Entity, &imp.Profile, &imp.Filename, &imp.Status,
+		&mappingJSON, &unmappedJSON, &summaryJSON, &imp.CreatedBy, &imp.CommittedBy, &imp.CreatedAt, &committedAt}, extra...)...)
+	if err != nil {
+		return imp, err
+	}
+	imp.CommittedAt = utils.NullTimeToPtr(committedAt)
+	json.Unmarshal([]byte(mappingJSON), &imp.Mapping)
+	json.Unmarshal([]byte(summaryJSON), &imp.Summary)
+	imp.UnmappedColumns = []string{}
+	json.Unmarshal([]byte(unmappedJSON), &imp.UnmappedColumns)
+	return imp, nil
+}
+
+// readDataImportCSV dosyanın başlık ve veri satırlarını okur; ayırıcı (; , veya sekme) başlık satırından tespit edilir
+func readDataImportCSV(data []byte) ([]string, [][]string, error) {
+	data = bytes.TrimPrefix(data, []byte("\xef\xbb\xbf"))
+
+	headerLine, _, _ := bytes.Cut(data, []byte("\n"))
+	reader := csv.NewReader(bytes.NewReader(data))
+	reader.Comma = ','
+	for _, comma := range []rune{';', '\t'} {
+		if bytes.Count(headerLine, []byte(string(comma))) > bytes.Count(headerLine, []byte(string(reader.Comma))) {
+			reader.Comma = comma
+		}
+	}
+	reader.FieldsPerRecord = -1
+	reader.LazyQuotes = true
+	reader.TrimLeadingSpace = true
+
+	header, err := reader.Read()
+	if err == io.EOF {
+		return nil, nil, ErrEmptyDataImport
+	}
+	if err != nil {
+		return nil, nil, err
+	}
+	for i := range header {
+		header[i] = strings.TrimSpace(header[i])
+	}
+
+	var records [][]string
+	for {
+		record, err := reader.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, nil, err
+		}
+		if len(records) == maxDataImportRows {
+			return nil, nil, ErrDataImportTooLarge
+		}
+		records = append(records, record)
+	}
+	return header, records, nil
+}
+
+// mapDataImportColumns alanları sütun sıralarına eşler. Özel eşlemedeki (alan → sütun başlığı) alanlar profil
+// eşlemesinin yerine geçer; profil eşlemesinde her alan için sırayla ilk bulunan başlık kullanılır
+func mapDataImportColumns(header []string, aliases map[string][]string, mapping map[string]string) (map[string]int, error) {
+	index := map[string]int{}
+	for i, name := range header {
+		name = strings.ToLower(name)
+		if _, ok := index[name]; !ok {
+			index[name] = i
+		}
+	}
+
+	columns := map[string]int{}
+	for field, names := range aliases {
+		for _, name := range names {
+			if i, ok := index[name]; ok {
+				columns[field] = i
+				break
+			}
+		}
+	}
+
+	for field, name := range mapping {
+		if _, ok := aliases[field]; !ok {
+			return nil, fmt.Errorf("%w: unknown field %s", ErrDataImportMapping, field)
+		}
+		if name == "" {
+			delete(columns, field)
+			continue
+		}
+		i, ok := index[strings.ToLower(strings.TrimSpace(name))]
+		if !ok {
+			return nil, fmt.Errorf("%w: column %q not found", ErrDataImportMapping, name)
+		}
+		columns[field] = i
+	}
+
+	return columns, nil
+}
+
+// normalizeDataImportRow profil varsayılanlarını uygular ve değerleri kayıt türünün sistemdeki biçimine çevirir
+func normalizeDataImportRow(entity string, profile models.DataImportProfile, values map[string]string) (map[string]string, []string) {
+	for field, value := range profile.Defaults[entity] {
+		if values[field] == "" {
+			values[field] = value
+		}
+	}
+
+	var errs []string
+	date := func(field string) {
+		if values[field] == "" {
+			return
+		}
+		parsed, ok := parseDataImportDate(values[field], profile.DateFormats)
+		if !ok {
+			errs = append(errs, field+": geçersiz tarih "+strconv.Quote(values[field]))
+			return
+		}
+		values[field] = parsed.Format("2006-01-02")
+	}
+	positive := func(field string) {
+		if values[field] == "" {
+			return
+		}
+		value := parseStatementAmount(values[field])
+		if value <= 0 {
+			errs = append(errs, field+": pozitif sayı olmalı")
+			return
+		}
+		values[field] = strconv.FormatFloat(round2(value), 'f', -1, 64)
+	}
+
+	switch entity {
+	case models.DataImportEntityAnimals:
+		if values["tagNumber"] == "" {
+			errs = append(errs, "tagNumber: zorunlu")
+		}
+		if values["type"] == "" {
+			errs = append(errs, "type: zorunlu")
+		} else if species, ok := dataImportSpecies[strings.ToLower(values["type"])]; ok {
+			values["type"] = species
+		} else {
+			values["type"] = strings.ToLower(values["type"])
+		}
+		if gender := values["gender"]; gender != "" {
+			if value, ok := dataImportGenders[strings.ToLower(gender)]; ok {
+				values["gender"] = value
+			} else {
+				errs = append(errs, "gender: bilinmeyen değer "+strconv.Quote(gender))
+			}
+		}
+		if status := values["healthStatus"]; status != "" {
+			values["healthStatus"] = strings.ReplaceAll(strings.ToLower(status), " ", "_")
+		}
+		date("birthDate")
+		positive("weight")
+
+	case models.DataImportEntityLands:
+		if values["name"] == "" {
+			errs = append(errs, "name: zorunlu")
+		}
+		if values["area"] == "" {
+			errs = append(errs, "area: zorunlu")
+		}
+		positive("area")
+		unit := strings.ToLower(values["unit"])
+		if _, ok := landHectareFactors[unit]; !ok {
+			unit = strings.TrimSuffix(unit, "s")
+		}
+		if _, ok := landHectareFactors[unit]; ok {
+			values["unit"] = unit
+		} else {
+			errs = append(errs, "unit: bilinmeyen alan birimi "+strconv.Quote(values["unit"]))
+		}
+
+	case models.DataImportEntityTransactions:
+		if values["date"] == "" {
+			errs = append(errs, "date: zorunlu")
+		}
+		date("date")
+
+		amount := parseStatementAmount(values["amount"])
+		if amount == 0 {
+			errs = append(errs, "amount: sıfırdan farklı sayı olmalı")
+		}
+		values["amount"] = strconv.FormatFloat(round2(math.Abs(amount)), 'f', -1, 64)
+
+		// Tür sütunu yoksa tutarın işareti kullanılır (eksi tutarlar giderdir)
+		switch txType := strings.ToLower(values["type"]); {
+		case txType == "" && amount < 0:
+			values["type"] = "expense"
+		case txType == "":
+			values["type"] = "income"
+		case dataImportTransactionTypes[txType] != "":
+			values["type"] = dataImportTransactionTypes[txType]
+		default:
+			errs = append(errs, "type: bilinmeyen işlem türü "+strconv.Quote(values["type"]))
+		}
+		values["currency"] = strings.ToUpper(values["currency"])
+		if values["description"] == "" {
+			values["description"] = values["category"]
+		}
+	}
+	return values, errs
+}
+
+// parseDataImportDate tarihi profilin biçimleriyle çözümler; saat içeren değerlerde yalnızca tarih kısmı kullanılır
+func parseDataImportDate(value string, layouts []string) (time.Time, bool) {
+	for _, candidate := range []string{value, strings.Fields(value)[0], strings.SplitN(value, "T", 2)[0]} {
+		for _, layout := range layouts {
+			if date, err := time.Parse(layout, candidate); err == nil {
+				return date, true
+			}
+		}
+	}
+	return time.Time{}, false
+}
+
+// dataImportKey çakışma kontrolünde kullanılan kayıt anahtarı: hayvanlarda kulak numarası, arazilerde ad,
+// işlemlerde tarih, tür, tutar ve açıklama
+func dataImportKey(entity string, values map[string]string) string {
+	switch entity {
+	case models.DataImportEntityAnimals:
+		return values["tagNumber"]
+	case models.DataImportEntityLands:
+		return strings.ToLower(values["name"])
+	default:
+		amount, _ := strconv.ParseFloat(values["amount"], 64)
+		return fmt.Sprintf("%s|%s|%.2f|%s", values["date"], values["type"], amount, strings.ToLower(values["description"]))
+	}
+}
+
+// existingKeys çiftlikteki mevcut kayıtların çakışma anahtarlarını döner
+func (s *DataImportService) existingKeys(farmID, entity string) (map[string]bool, error) {
+	var query string
+	switch entity {
+	case models.DataImportEntityAnimals:
+		query = "SELECT tag_number, '', '', '' FROM livestock WHERE user_id = ?"
+	case models.DataImportEntityLands:
+		query = "SELECT name, '', '', '' FROM lands WHERE user_id = ?"
+	default:
+		query = "SELECT date(date), type, amount, description FROM transactions WHERE user_id = ?"
+	}
+
+	rows, err := s.db.Query(query, farmID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	keys := map[string]bool{}
+	for rows.Next() {
+		var name, txType, amount, description string
+		if err := rows.Scan(&name, &txType, &amount, &description); err != nil {
+			return nil, err
+		}
+		keys[dataImportKey(entity, map[string]string{
+			"tagNumber": name, "name": name, "date": name, "type": txType, "amount": amount, "description": description,
+		})] = true
+	}
+	return keys, rows.Err()
+}
+
+// insertDataImportRow normalleştirilmiş satırı kayıt türünün tablosuna ekler ve kayıt kimliğini döner
+func insertDataImportRow(tx *sql.Tx, farmID, entity string, values map[string]string) (string, error) {
+	id := utils.GenerateID()
+	text := func(field string) interface{} {
+		if values[field] == "" {
+			return nil
+		}
+		return values[field]
+	}
+	number := func(field string) interface{} {
+		if values[field] == "" {
+			return nil
+		}
+		value, _ := strconv.ParseFloat(values[field], 64)
+		return value
+	}
+
+	var err error
+	switch entity {
+	case models.DataImportEntityAnimals:
+		healthStatus := values["healthStatus"]
+		if healthStatus == "" {
+			healthStatus = "healthy"
+		}
+		_, err = tx.Exec(`
+			INSERT INTO livestock (id, user_id, tag_number, type, breed, gender, birth_date, weight, health_status,
+			                       location, mother, father, notes, created_at, updated_at)
+			VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, CURRENT_TIMESTAMP, CURRENT_TIMESTAMP)
+		`, id, farmID, values["tagNumber"], values["type"], values["breed"], values["gender"], text("birthDate"),
+			number("weight"), healthStatus, values["location"], values["mother"], values["father"], values["notes"])
+
+	case models.DataImportEntityLands:
+		_, err = tx.Exec(`
+			INSERT INTO lands (id, user_id, name, area, unit, crop, status, productivity, address, soil_type,
+			                   irrigation_type, land_type, created_at, updated_at)
+			VALUES (?, ?, ?, ?, ?, ?, 'active', 0, ?, ?, ?, ?, CURRENT_TIMESTAMP, CURRENT_TIMESTAMP)
+		`, id, farmID, values["name"], number("area"), values["unit"], values["crop"], values["address"],
+			values["soilType"], values["irrigationType"], models.LandTypeField)
+
+	default:
+		_, err = tx.Exec(`
+			INSERT INTO transactions (id, user_id, type, category, description, amount, currency, date, status,
+			                          payment_method, notes, paid_at, created_at, updated_at)
+			VALUES (?, ?, ?, ?, ?, ?, ?, ?, 'completed', ?, ?, ?, CURRENT_TIMESTAMP, CURRENT_TIMESTAMP)
+		`, id, farmID, values["type"], values["category"], values["description"], number("amount"), values["currency"],
+			values["date"], values["paymentMethod"], values["notes"], values["date"])
+	}
+	return id, err
+}
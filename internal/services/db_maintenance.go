@@ -0,0 +1,387 @@
+package services
+
+import (
+	"database/sql"
+	"errors"
+	"log"
+	"math"
+	"os"
+	"strconv"
+	"sync"
+	"time"
+
+	"agri-management-api/internal/models"
+	"agri-management-api/internal/utils"
+)
+
+// dbVacuumFreeRatio boş sayfaların toplam sayfalara oranı bu eşiğe ulaşınca zamanlanmış bakımda VACUUM çalışır
+const dbVacuumFreeRatio = 0.1
+
+// dbCheckpointInterval WAL dosyasının ana dosyaya aktarıldığı (PASSIVE checkpoint) kontrol aralığı
+const dbCheckpointInterval = 15 * time.Minute
+
+// ErrDatabaseMaintenanceRunning başka bir bakım işi sürerken döner
+var ErrDatabaseMaintenanceRunning = errors.New("database maintenance already running")
+
+// dbMaintenanceMu bakım işlerinin aynı anda yalnızca birinin çalışmasını sağlar
+var dbMaintenanceMu sync.Mutex
+
+// DatabaseMaintenanceService SQLite veritabanının boyutunu raporlar; zamanlanmış ANALYZE, VACUUM ve
+// WAL checkpoint işlerini çalıştırır
+type DatabaseMaintenanceService struct {
+	db *sql.DB
+}
+
+// NewDatabaseMaintenanceService yeni database maintenance service oluşturur
+func NewDatabaseMaintenanceService(db *sql.DB) *DatabaseMaintenanceService {
+	return &DatabaseMaintenanceService{db: db}
+}
+
+// maintenanceHour gecelik bakımın çalıştığı saat (DB_MAINTENANCE_HOUR, sunucu saatiyle 0-23; varsayılan 3)
+func maintenanceHour() int {
+	if hour, err := strconv.Atoi(os.Getenv("DB_MAINTENANCE_HOUR")); err == nil && hour >= 0 && hour < 24 {
+		return hour
+	}
+	return 3
+}
+
+// DatabaseStorageLimitMB sistem bilgisinde gösterilen depolama sınırı (DB_STORAGE_LIMIT_MB; varsayılan 1000)
+func DatabaseStorageLimitMB() float64 {
+	if limit, err := strconv.ParseFloat(os.Getenv("DB_STORAGE_LIMIT_MB"), 64); err == nil && limit > 0 {
+		return limit
+	}
+	return 1000
+}
+
+// StartScheduler 15 dakikada bir WAL checkpoint yapar; her gece bakım saatinde ARCHIVE_AFTER_YEARS tanımlıysa
+// eski kayıtları arşivler, ANALYZE çalıştırır ve boş sayfa oranı eşiği aşmışsa VACUUM ile dosyayı küçültür
+func (s *DatabaseMaintenanceService) StartScheduler() {
+	go func() {
+		ticker := time.NewTicker(dbCheckpointInterval)
+		defer ticker.Stop()
+
+		for range ticker.C {
+			due, err := s.due(time.Now())
+			if err != nil {
+				log.Printf("Veritabanı bakım zamanı okunamadı: %v", err)
+				continue
+			}
+			if !due {
+				if err := s.Checkpoint(); err != nil {
+					log.Printf("WAL checkpoint yapılamadı: %v", err)
+				}
+				continue
+			}
+			if err := NewArchiveService(s.db).RunScheduled(time.Now()); err != nil {
+				log.Printf("Zamanlanmış arşivleme başarısız: %v", err)
+			}
+			if _, err := s.Run("scheduled", "", false); err != nil && !errors.Is(err, ErrDatabaseMaintenanceRunning) {
+				log.Printf("Veritabanı bakımı başarısız: %v", err)
+			}
+		}
+	}()
+}
+
+// due bakım saatindeyken o gün zamanlanmış bakım yapılmadıysa true döner
+func (s *DatabaseMaintenanceService) due(now time.Time) (bool, error) {
+	if now.Hour() != maintenanceHour() {
+		return false, nil
+	}
+	var count int
+	err := s.db.QueryRow(`
+		SELECT COUNT(*) FROM db_maintenance_runs WHERE trigger_type = 'scheduled' AND started_at >= ?
+	`, now.UTC().Add(-20*time.Hour)).Scan(&count)
+	return count == 0, err
+}
+
+// Checkpoint WAL kipinde yazarları bekletmeden (PASSIVE) WAL sayfalarını ana dosyaya aktarır
+func (s *DatabaseMaintenanceService) Checkpoint() error {
+	var mode string
+	if err := s.db.QueryRow("PRAGMA journal_mode").Scan(&mode); err != nil || mode != "wal" {
+		return err
+	}
+	_, err := s.db.Exec("PRAGMA wal_checkpoint(PASSIVE)")
+	return err
+}
+
+// Start bakım işini kaydedip arka planda çalıştırır; başka bir bakım sürüyorsa ErrDatabaseMaintenanceRunning döner
+func (s *DatabaseMaintenanceService) Start(requestedBy string, forceVacuum bool) (models.DatabaseMaintenanceRun, error) {
+	if !dbMaintenanceMu.TryLock() {
+		return models.DatabaseMaintenanceRun{}, ErrDatabaseMaintenanceRunning
+	}
+
+	run, err := s.create("manual", requestedBy)
+	if err != nil {
+		dbMaintenanceMu.Unlock()
+		return run, err
+	}
+
+	go func() {
+		defer dbMaintenanceMu.Unlock()
+		if err := s.execute(&run, forceVacuum); err != nil {
+			log.Printf("Veritabanı bakımı başarısız: %v", err)
+		}
+	}()
+	return run, nil
+}
+
+// Run bakım işini çalıştırıp sonucunu döner
+func (s *DatabaseMaintenanceService) Run(trigger, requestedBy string, forceVacuum bool) (models.DatabaseMaintenanceRun, error) {
+	if !dbMaintenanceMu.TryLock() {
+		return models.DatabaseMaintenanceRun{}, ErrDatabaseMaintenanceRunning
+	}
+	defer dbMaintenanceMu.Unlock()
+
+	run, err := s.create(trigger, requestedBy)
+	if err != nil {
+		return run, err
+	}
+	err = s.execute(&run, forceVacuum)
+	return run, err
+}
+
+// create bakım işi kaydını oluşturur
+func (s *DatabaseMaintenanceService) create(trigger, requestedBy string) (models.DatabaseMaintenanceRun, error) {
+	run := models.DatabaseMaintenanceRun{
+		ID:          utils.GenerateID(),
+		Trigger:     trigger,
+		Status:      models.DatabaseMaintenanceRunning,
+		RequestedBy: requestedBy,
+		StartedAt:   time.Now().UTC(),
+	}
+	_, err := s.db.Exec(`
+		INSERT INTO db_maintenance_runs (id, trigger_type, status, requested_by, started_at)
+		VALUES (?, ?, ?, ?, ?)
+	`, run.ID, run.Trigger, run.Status, utils.StringToNullString(requestedBy), run.StartedAt)
+	return run, err
+}
+
+// execute ANALYZE ile sorgu planlayıcısının istatistiklerini yeniler, boş sayfa oranı eşiği aşmışsa veya
+// istenmişse VACUUM ile dosyayı küçültür ve WAL dosyasını sıfırlar (TRUNCATE checkpoint). Sonuç kayda yazılır
+func (s *DatabaseMaintenanceService) execute(run *models.DatabaseMaintenanceRun, forceVacuum bool) error {
+	started := time.Now()
+	err := func() error {
+		before, err := s.Stats()
+		if err != nil {
+			return err
+		}
+		run.SizeBeforeBytes = before.TotalSizeBytes
+		run.FreePagesBefore = before.FreePages
+
+		if _, err := s.db.Exec("ANALYZE"); err != nil {
+			return err
+		}
+		run.Analyzed = true
+
+		if forceVacuum || before.FreeRatio >= dbVacuumFreeRatio {
+			if _, err := s.db.Exec("VACUUM"); err != nil {
+				return err
+			}
+			run.Vacuumed = true
+		}
+
+		if before.JournalMode == "wal" {
+			var busy, logPages, checkpointed int
+			if err := s.db.QueryRow("PRAGMA wal_checkpoint(TRUNCATE)").Scan(&busy, &logPages, &checkpointed); err != nil {
+				return err
+			}
+			run.Checkpointed = busy == 0
+		}
+
+		after, err := s.Stats()
+		if err != nil {
+			return err
+		}
+		run.SizeAfterBytes = after.TotalSizeBytes
+		run.ReclaimedBytes = run.SizeBeforeBytes - run.SizeAfterBytes
+		return nil
+	}()
+
+	finished := time.Now().UTC()
+	run.FinishedAt = &finished
+	run.DurationMs = time.Since(started).Milliseconds()
+	run.Status = models.DatabaseMaintenanceCompleted
+	if err != nil {
+		run.Status = models.DatabaseMaintenanceFailed
+		run.Error = err.Error()
+	}
+
+	_, saveErr := s.db.Exec(`
+		UPDATE db_maintenance_runs SET status = ?, analyzed = ?, vacuumed = ?, checkpointed = ?, free_pages_before = ?,
+		    size_before = ?, size_after = ?, duration_ms = ?, error = ?, finished_at = ?
+		WHERE id = ?
+	`, run.Status, run.Analyzed, run.Vacuumed, run.Checkpointed, run.FreePagesBefore, run.SizeBeforeBytes,
+		run.SizeAfterBytes, run.DurationMs, utils.StringToNullString(run.Error), finished, run.ID)
+	if err != nil {
+		return err
+	}
+	return saveErr
+}
+
+// FailInterrupted sunucu kapanırken yarım kalan bakım işlerini başarısız olarak kapatır
+func (s *DatabaseMaintenanceService) FailInterrupted() error {
+	_, err := s.db.Exec(`
+		UPDATE db_maintenance_runs SET status = ?, error = 'sunucu yeniden başlatıldı', finished_at = ? WHERE status = ?
+	`, models.DatabaseMaintenanceFailed, time.Now().UTC(), models.DatabaseMaintenanceRunning)
+	return err
+}
+
+// Stats veritabanı dosyasının ve WAL dosyasının diskteki boyutunu, sayfa bilgilerini ve son bakım zamanlarını döner
+func (s *DatabaseMaintenanceService) Stats() (models.DatabaseStats, error) {
+	var stats models.DatabaseStats
+
+	rows, err := s.db.Query("PRAGMA database_list")
+	if err != nil {
+		return stats, err
+	}
+	var path string
+	for rows.Next() {
+		var seq int
+		var name, file string
+		if err := rows.Scan(&seq, &name, &file); err == nil && name == "main" {
+			path = file
+		}
+	}
+	rows.Close()
+
+	if path != "" {
+		if info, err := os.Stat(path); err == nil {
+			stats.FileSizeBytes = info.Size()
+		}
+		if info, err := os.Stat(path + "-wal"); err == nil {
+			stats.WALSizeBytes = info.Size()
+		}
+	}
+	stats.TotalSizeBytes = stats.FileSizeBytes + stats.WALSizeBytes
+
+	if err := s.db.QueryRow("PRAGMA journal_mode").Scan(&stats.JournalMode); err != nil {
+		return stats, err
+	}
+	if err := s.db.QueryRow("PRAGMA page_size").Scan(&stats.PageSize); err != nil {
+		return stats, err
+	}
+	if err := s.db.QueryRow("PRAGMA page_count").Scan(&stats.PageCount); err != nil {
+		return stats, err
+	}
+	if err := s.db.QueryRow("PRAGMA freelist_count").Scan(&stats.FreePages); err != nil {
+		return stats, err
+	}
+	if stats.PageCount > 0 {
+		stats.FreeRatio = math.Round(float64(stats.FreePages)/float64(stats.PageCount)*10000) / 10000
+	}
+
+	stats.LastVacuumAt, err = s.lastRun("vacuumed")
+	if err != nil {
+		return stats, err
+	}
+	stats.LastAnalyzeAt, err = s.lastRun("analyzed")
+	return stats, err
+}
+
+// lastRun verilen adımı tamamlamış son bakım işinin bitiş zamanını döner; yoksa nil
+func (s *DatabaseMaintenanceService) lastRun(step string) (*time.Time, error) {
+	var finishedAt sql.NullTime
+	err := s.db.QueryRow(`
+		SELECT finished_at FROM db_maintenance_runs WHERE ` + step + ` = TRUE AND finished_at IS NOT NULL
+		ORDER BY finished_at DESC LIMIT 1
+	`).Scan(&finishedAt)
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return utils.NullTimeToPtr(finishedAt), nil
+}
+
+// TableCounts veritabanındaki tüm tabloların kayıt sayılarını döner
+func (s *DatabaseMaintenanceService) TableCounts() (map[string]int, error) {
+	rows, err := s.db.Query("SELECT name FROM sqlite_master WHERE type = 'table' AND name NOT LIKE 'sqlite_%' ORDER BY name")
+	if err != nil {
+		return nil, err
+	}
+	var tables []string
+	for rows.Next() {
+		var name string
+		if err := rows.Scan(&name); err == nil {
+			tables = append(tables, name)
+		}
+	}
+	rows.Close()
+
+	counts := map[string]int{}
+	for _, table := range tables {
+		var count int
+		if err := s.db.QueryRow(`SELECT COUNT(*) FROM "` + table + `"`).Scan(&count); err != nil {
+			return nil, err
+		}
+		counts[table] = count
+	}
+	return counts, nil
+}
+
+// FarmTableCounts çiftliğin yedeğe alınan tablolardaki kayıt sayılarını döner; boş tablolar dahil edilmez
+func (s *DatabaseMaintenanceService) FarmTableCounts(farmID string) (map[string]int, error) {
+	counts := map[string]int{}
+	for _, group := range backupGroups {
+		for _, table := range group.tables {
+			query := "SELECT COUNT(*) FROM " + table.name + " WHERE user_id = ?"
+			if table.parent != "" {
+				query = "SELECT COUNT(*) FROM " + table.name + " t JOIN " + table.parent + " p ON p.id = t." +
+					table.parentKey + " WHERE p.user_id = ?"
+			}
+			var count int
+			if err := s.db.QueryRow(query, farmID).Scan(&count); err != nil {
+				return nil, err
+			}
+			if count > 0 {
+				counts[table.name] = count
+			}
+		}
+	}
+	return counts, nil
+}
+
+// Report veritabanı boyutunu, tablo kayıt sayılarını ve son 20 bakım işini döner
+func (s *DatabaseMaintenanceService) Report() (models.DatabaseMaintenanceReport, error) {
+	var report models.DatabaseMaintenanceReport
+	var err error
+
+	if report.Database, err = s.Stats(); err != nil {
+		return report, err
+	}
+	if report.TableCounts, err = s.TableCounts(); err != nil {
+		return report, err
+	}
+
+	rows, err := s.db.Query(`
+		SELECT id, trigger_type, status, analyzed, vacuumed, checkpointed, free_pages_before, size_before, size_after,
+		       duration_ms, error, requested_by, started_at, finished_at
+		FROM db_maintenance_runs ORDER BY started_at DESC LIMIT 20
+	`)
+	if err != nil {
+		return report, err
+	}
+	defer rows.Close()
+
+	report.Runs = []models.DatabaseMaintenanceRun{}
+	for rows.Next() {
+		var run models.DatabaseMaintenanceRun
+		var errMessage, requestedBy sql.NullString
+		var finishedAt sql.NullTime
+		err := rows.Scan(&run.ID, &run.Trigger, &run.Status, &run.Analyzed, &run.Vacuumed, &run.Checkpointed,
+			&run.FreePagesBefore, &run.SizeBeforeBytes, &run.SizeAfterBytes, &run.DurationMs, &errMessage,
+			&requestedBy, &run.StartedAt, &finishedAt)
+		if err != nil {
+			continue
+		}
+		run.Error = errMessage.String
+		run.RequestedBy = requestedBy.String
+		run.FinishedAt = utils.NullTimeToPtr(finishedAt)
+		if run.Status == models.DatabaseMaintenanceCompleted {
+			run.ReclaimedBytes = run.SizeBeforeBytes - run.SizeAfterBytes
+		}
+		report.Runs = append(report.Runs, run)
+	}
+	return report, rows.Err()
+}
@@ -0,0 +1,411 @@
+package services
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"log"
+	"os"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"agri-management-api/internal/models"
+	"agri-management-api/internal/utils"
+)
+
+var (
+	// ErrDemoAccountNotFound sandbox hesabı bulunamadı
+	ErrDemoAccountNotFound = errors.New("demo account not found")
+	// ErrDemoEmailExists e-posta adresi başka bir hesapta kullanılıyor
+	ErrDemoEmailExists = errors.New("email already in use")
+)
+
+// demoResetCheckInterval gecelik sıfırlama zamanının kontrol aralığı
+const demoResetCheckInterval = time.Hour
+
+// demoKeptTables sıfırlamada silinmeyen, user_id sütunlu tablolar
+var demoKeptTables = map[string]bool{"demo_accounts": true}
+
+// demoResetMu zamanlanmış ve elle başlatılan sıfırlamaları sıraya koyar
+var demoResetMu sync.Mutex
+
+// demoResetHour sandbox hesaplarının sıfırlandığı saat (DEMO_RESET_HOUR, sunucu saatiyle 0-23; varsayılan 4)
+func demoResetHour() int {
+	if hour, err := strconv.Atoi(os.Getenv("DEMO_RESET_HOUR")); err == nil && hour >= 0 && hour < 24 {
+		return hour
+	}
+	return 4
+}
+
+// DemoAccountService sandbox hesaplarını oluşturur ve çiftlik verilerini örnek veri üreteciyle sıfırlar
+type DemoAccountService struct {
+	db *sql.DB
+}
+
+// NewDemoAccountService yeni demo account service oluşturur
+func NewDemoAccountService(db *sql.DB) *DemoAccountService {
+	return &DemoAccountService{db: db}
+}
+
+// demoAccountSelect sandbox hesabı sorgusu
+const demoAccountSelect = `
+	SELECT d.user_id, d.name, u.email, d.farm_name, COALESCE(d.location, ''), COALESCE(d.records, ''),
+	       d.last_reset_at, COALESCE(d.last_error, ''), d.created_at
+	FROM demo_accounts d
+	JOIN users u ON u.id = d.user_id`
+
+// scanDemoAccount sandbox hesabı satırını okur
+func scanDemoAccount(scanner interface{ Scan(...interface{}) error }) (models.DemoAccount, error) {
+	var account models.DemoAccount
+	var records string
+	var lastReset sql.NullTime
+	if err := scanner.Scan(&account.ID, &account.Name, &account.Email, &account.FarmName, &account.Location, &records,
+		&lastReset, &account.LastError, &account.CreatedAt); err != nil {
+		return account, err
+	}
+	account.Records = map[string]int{}
+	if records != "" {
+		utils.FromJSON(records, &account.Records)
+	}
+	if lastReset.Valid {
+		account.LastResetAt = &lastReset.Time
+	}
+	return account, nil
+}
+
+// List sandbox hesaplarını oluşturulma sırasıyla döner
+func (s *DemoAccountService) List() ([]models.DemoAccount, error) {
+	rows, err := s.db.Query(demoAccountSelect + " ORDER BY d.created_at")
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	accounts := []models.DemoAccount{}
+	for rows.Next() {
+		account, err := scanDemoAccount(rows)
+		if err != nil {
+			return nil, err
+		}
+		accounts = append(accounts, account)
+	}
+	return accounts, rows.Err()
+}
+
+// Get sandbox hesabını döner
+func (s *DemoAccountService) Get(id string) (*models.DemoAccount, error) {
+	account, err := scanDemoAccount(s.db.QueryRow(demoAccountSelect+" WHERE d.user_id = ?", id))
+	if err == sql.ErrNoRows {
+		return nil, ErrDemoAccountNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &account, nil
+}
+
+// Create demo rolünde hesap açar ve çiftliğini örnek verilerle doldurur
+func (s *DemoAccountService) Create(adminID string, req models.DemoAccountRequest) (*models.DemoAccount, error) {
+	email := strings.TrimSpace(req.Email)
+	var existing string
+	err := s.db.QueryRow("SELECT id FROM users WHERE email = ?", email).Scan(&existing)
+	if err == nil {
+		return nil, ErrDemoEmailExists
+	}
+	if err != sql.ErrNoRows {
+		return nil, err
+	}
+
+	hashed, err := utils.HashPassword(req.Password)
+	if err != nil {
+		return nil, err
+	}
+
+	id := utils.GenerateID()
+	tx, err := s.db.Begin()
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	if _, err := tx.Exec(`
+		INSERT INTO users (id, name, email, password, avatar, role, farm_name, location, is_verified, created_at, updated_at)
+		VALUES (?, ?, ?, ?, '', ?, ?, ?, TRUE, CURRENT_TIMESTAMP, CURRENT_TIMESTAMP)
+	`, id, req.Name, email, hashed, models.RoleDemo, req.FarmName, req.Location); err != nil {
+		return nil, err
+	}
+	if _, err := tx.Exec(`
+		INSERT INTO demo_accounts (user_id, name, farm_name, location, created_by, created_at)
+		VALUES (?, ?, ?, ?, ?, CURRENT_TIMESTAMP)
+	`, id, req.Name, req.FarmName, req.Location, adminID); err != nil {
+		return nil, err
+	}
+	if err := tx.Commit(); err != nil {
+		return nil, err
+	}
+
+	return s.Reset(id)
+}
+
+// Reset hesabın tüm çiftlik verilerini siler, profilini ilk haline getirir ve örnek verileri yeniden üretir.
+// Başarısız sıfırlama hesabın son hatası olarak kaydedilir
+func (s *DemoAccountService) Reset(id string) (*models.DemoAccount, error) {
+	account, err := s.Get(id)
+	if err != nil {
+		return nil, err
+	}
+
+	demoResetMu.Lock()
+	records, err := s.reset(*account, time.Now())
+	demoResetMu.Unlock()
+	if err != nil {
+		if _, updateErr := s.db.Exec("UPDATE demo_accounts SET last_error = ? WHERE user_id = ?", err.Error(), id); updateErr != nil {
+			log.Printf("Sandbox hesabı %s için sıfırlama hatası kaydedilemedi: %v", id, updateErr)
+		}
+		return nil, err
+	}
+
+	now := time.Now().UTC()
+	account.Records, account.LastResetAt, account.LastError = records, &now, ""
+	return account, nil
+}
+
+// reset sıfırlamayı tek işlemde yapar
+func (s *DemoAccountService) reset(account models.DemoAccount, now time.Time) (map[string]int, error) {
+	tx, err := s.db.Begin()
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	if err := wipeAccountData(tx, account.ID); err != nil {
+		return nil, err
+	}
+	if _, err := tx.Exec(`
+		UPDATE users SET name = ?, farm_name = ?, location = ?, avatar = '', updated_at = CURRENT_TIMESTAMP WHERE id = ?
+	`, account.Name, account.FarmName, account.Location, account.ID); err != nil {
+		return nil, err
+	}
+
+	records, err := seedDemoFarm(tx, account.ID, now)
+	if err != nil {
+		return nil, err
+	}
+	counts, err := utils.ToJSON(records)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := tx.Exec(`
+		UPDATE demo_accounts SET records = ?, last_reset_at = ?, last_error = NULL WHERE user_id = ?
+	`, counts, now.UTC(), account.ID); err != nil {
+		return nil, err
+	}
+	return records, tx.Commit()
+}
+
+// Delete sandbox hesabını çiftlik verileriyle birlikte siler
+func (s *DemoAccountService) Delete(id string) error {
+	if _, err := s.Get(id); err != nil {
+		return err
+	}
+
+	demoResetMu.Lock()
+	defer demoResetMu.Unlock()
+
+	tx, err := s.db.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	if err := wipeAccountData(tx, id); err != nil {
+		return err
+	}
+	if _, err := tx.Exec("DELETE FROM demo_accounts WHERE user_id = ?", id); err != nil {
+		return err
+	}
+	if _, err := tx.Exec("DELETE FROM users WHERE id = ? AND role = ?", id, models.RoleDemo); err != nil {
+		return err
+	}
+	return tx.Commit()
+}
+
+// StartScheduler saatlik kontrolle sandbox hesaplarını her gece sıfırlama saatinde sıfırlar
+func (s *DemoAccountService) StartScheduler() {
+	go func() {
+		ticker := time.NewTicker(demoResetCheckInterval)
+		defer ticker.Stop()
+
+		for range ticker.C {
+			if err := s.ResetDue(time.Now()); err != nil {
+				log.Printf("Sandbox hesapları sıfırlanamadı: %v", err)
+			}
+		}
+	}()
+}
+
+// ResetDue sıfırlama saatindeyken o gün sıfırlanmamış sandbox hesaplarını sıfırlar
+func (s *DemoAccountService) ResetDue(now time.Time) error {
+	if now.Hour() != demoResetHour() {
+		return nil
+	}
+
+	rows, err := s.db.Query("SELECT user_id FROM demo_accounts WHERE last_reset_at IS NULL OR last_reset_at < ?",
+		now.UTC().Add(-20*time.Hour))
+	if err != nil {
+		return err
+	}
+	var ids []string
+	for rows.Next() {
+		var id string
+		if err := rows.Scan(&id); err != nil {
+			rows.Close()
+			return err
+		}
+		ids = append(ids, id)
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return err
+	}
+
+	for _, id := range ids {
+		if _, err := s.Reset(id); err != nil {
+			log.Printf("Sandbox hesabı %s sıfırlanamadı: %v", id, err)
+		}
+	}
+	return nil
+}
+
+// IsDemoAccount hesabın sandbox hesabı olup olmadığını döner; sandbox hesaplarına gerçek bildirim gönderilmez
+func IsDemoAccount(db *sql.DB, accountID string) (bool, error) {
+	var role string
+	err := db.QueryRow("SELECT COALESCE(role, '') FROM users WHERE id = ?", accountID).Scan(&role)
+	if err == sql.ErrNoRows {
+		return false, nil
+	}
+	return role == models.RoleDemo, err
+}
+
+// accountChildTable user_id sütunu olmayıp çiftliğe üst tablosu üzerinden bağlanan tablo
+type accountChildTable struct {
+	name, column, parent, parentColumn string
+}
+
+// wipeAccountData hesabın ve sahip olduğu diğer çiftliklerin tüm kayıtlarını siler. Silinecek tablolar şemadan
+// bulunur: user_id sütunu olan tablolar ile bunlara yabancı anahtarla (veya yedek gruplarında üst tabloyla)
+// bağlı alt tablolar. Sistem kayıtlarının (user_id NULL) ve demoKeptTables tablolarının satırları silinmez
+func wipeAccountData(tx *sql.Tx, accountID string) error {
+	owned, children, err := accountTables(tx)
+	if err != nil {
+		return err
+	}
+
+	farmIDs := []string{accountID}
+	rows, err := tx.Query("SELECT id FROM farms WHERE user_id = ? AND id != ?", accountID, accountID)
+	if err != nil {
+		return err
+	}
+	for rows.Next() {
+		var id string
+		if err := rows.Scan(&id); err != nil {
+			rows.Close()
+			return err
+		}
+		farmIDs = append(farmIDs, id)
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return err
+	}
+
+	// Ek çiftliklerin kayıtları, çiftlik satırları hesapla birlikte silinmeden önce silinir
+	for i := len(farmIDs) - 1; i >= 0; i-- {
+		for _, child := range children {
+			if _, err := tx.Exec("DELETE FROM "+child.name+" WHERE "+child.column+" IN (SELECT "+child.parentColumn+
+				" FROM "+child.parent+" WHERE user_id = ?)", farmIDs[i]); err != nil {
+				return fmt.Errorf("%s: %w", child.name, err)
+			}
+		}
+		for _, table := range owned {
+			if _, err := tx.Exec("DELETE FROM "+table+" WHERE user_id = ?", farmIDs[i]); err != nil {
+				return fmt.Errorf("%s: %w", table, err)
+			}
+		}
+	}
+	return nil
+}
+
+// accountTables kullanıcıya ait tabloları ve alt tablolarını şemadan okur
+func accountTables(tx *sql.Tx) ([]string, []accountChildTable, error) {
+	rows, err := tx.Query("SELECT name FROM sqlite_master WHERE type = 'table' AND name NOT LIKE 'sqlite_%' ORDER BY name")
+	if err != nil {
+		return nil, nil, err
+	}
+	var names []string
+	for rows.Next() {
+		var name string
+		if err := rows.Scan(&name); err != nil {
+			rows.Close()
+			return nil, nil, err
+		}
+		names = append(names, name)
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return nil, nil, err
+	}
+
+	var owned []string
+	ownedSet := map[string]bool{}
+	var candidates []accountChildTable
+	for _, name := range names {
+		var hasUserID bool
+		if err := tx.QueryRow("SELECT COUNT(*) > 0 FROM pragma_table_info(?) WHERE name = 'user_id'", name).Scan(&hasUserID); err != nil {
+			return nil, nil, err
+		}
+		if hasUserID {
+			if !demoKeptTables[name] {
+				owned = append(owned, name)
+				ownedSet[name] = true
+			}
+			continue
+		}
+
+		fkRows, err := tx.Query(`SELECT "table", "from", COALESCE("to", 'id') FROM pragma_foreign_key_list(?)`, name)
+		if err != nil {
+			return nil, nil, err
+		}
+		for fkRows.Next() {
+			child := accountChildTable{name: name}
+			if err := fkRows.Scan(&child.parent, &child.column, &child.parentColumn); err != nil {
+				fkRows.Close()
+				return nil, nil, err
+			}
+			candidates = append(candidates, child)
+		}
+		fkRows.Close()
+	}
+
+	// Yabancı anahtar tanımı olmayan alt tablolar yedek gruplarındaki üst tablo bilgisinden alınır
+	for _, group := range backupGroups {
+		for _, table := range group.tables {
+			if table.parent != "" {
+				candidates = append(candidates, accountChildTable{name: table.name, column: table.parentKey,
+					parent: table.parent, parentColumn: "id"})
+			}
+		}
+	}
+
+	var children []accountChildTable
+	seen := map[accountChildTable]bool{}
+	for _, child := range candidates {
+		if ownedSet[child.parent] && !ownedSet[child.name] && !seen[child] {
+			seen[child] = true
+			children = append(children, child)
+		}
+	}
+	return owned, children, nil
+}
@@ -0,0 +1,285 @@
+package services
+
+import (
+	"database/sql"
+	"fmt"
+	"math/rand"
+	"strings"
+	"time"
+
+	"agri-management-api/internal/utils"
+)
+
+// demoSeed örnek veri üretecinin sabit tohumu; her sıfırlamada aynı çiftlik üretilir
+const demoSeed = 20250601
+
+// demoSeeder sandbox hesabının çiftliğine örnek veriler yazar. Tarihler sıfırlama gününe göre verilir;
+// böylece takvim, hatırlatmalar ve dönem raporları her gün güncel görünür
+type demoSeeder struct {
+	tx      *sql.Tx
+	farmID  string
+	today   time.Time
+	now     time.Time
+	rng     *rand.Rand
+	records map[string]int
+	err     error
+}
+
+// seedDemoFarm örnek arazi, hayvan, üretim, finans, müşteri ve takvim kayıtlarını oluşturur ve tablo başına
+// eklenen kayıt sayılarını döner
+func seedDemoFarm(tx *sql.Tx, farmID string, now time.Time) (map[string]int, error) {
+	now = now.UTC()
+	s := &demoSeeder{
+		tx:      tx,
+		farmID:  farmID,
+		today:   time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC),
+		now:     now,
+		rng:     rand.New(rand.NewSource(demoSeed)),
+		records: map[string]int{},
+	}
+
+	lands := s.lands()
+	s.livestock()
+	s.production(lands)
+	s.transactions()
+	s.customers()
+	s.events(lands)
+	return s.records, s.err
+}
+
+// insert satırı ekler; ilk hatadan sonra yeni satır eklenmez
+func (s *demoSeeder) insert(table, columns string, args ...interface{}) {
+	if s.err != nil {
+		return
+	}
+	placeholders := strings.TrimSuffix(strings.Repeat("?, ", len(args)), ", ")
+	if _, err := s.tx.Exec("INSERT INTO "+table+" ("+columns+") VALUES ("+placeholders+")", args...); err != nil {
+		s.err = fmt.Errorf("%s: %w", table, err)
+		return
+	}
+	s.records[table]++
+}
+
+// encrypt değeri şifrelenen sütun için şifreler; hata ilk hata olarak tutulur
+func (s *demoSeeder) encrypt(column EncryptedColumn, value string) string {
+	encrypted, err := EncryptField(column, value)
+	if err != nil && s.err == nil {
+		s.err = fmt.Errorf("%s: %w", column.Name(), err)
+	}
+	return encrypted
+}
+
+// day bugünden offset gün sonrası (negatifse öncesi)
+func (s *demoSeeder) day(offset int) time.Time {
+	return s.today.AddDate(0, 0, offset)
+}
+
+// vary değeri ±oran kadar rastgele değiştirip iki basamağa yuvarlar
+func (s *demoSeeder) vary(value, ratio float64) float64 {
+	return round2(value * (1 + ratio*(2*s.rng.Float64()-1)))
+}
+
+// demoLand örnek arazi
+type demoLand struct {
+	id, name, crop string
+	area           float64
+}
+
+// lands tarla, bahçe ve serayı ekim, gübreleme, sulama ve hasat faaliyetleriyle oluşturur
+func (s *demoSeeder) lands() []demoLand {
+	lands := []struct {
+		name, crop, landType, soil, irrigation string
+		area, lat, lng                         float64
+		harvested                              bool
+	}{
+		{"Kuzey Tarla", "Buğday", "field", "Killi-tınlı", "Yağmurlama", 45, 37.8921, 32.4713, true},
+		{"Güney Tarla", "Mısır", "field", "Tınlı", "Damla", 30, 37.8702, 32.4855, false},
+		{"Ova Bahçe", "Domates", "field", "Kumlu-tınlı", "Damla", 12, 37.8815, 32.5012, false},
+		{"Sera 1", "Salatalık", "greenhouse", "Torf karışımı", "Damla", 2, 37.8790, 32.4950, false},
+	}
+
+	var created []demoLand
+	for _, land := range lands {
+		id := utils.GenerateID()
+		lastActivity := s.day(-6)
+		if land.harvested {
+			lastActivity = s.day(-25)
+		}
+		s.insert("lands", `id, user_id, name, area, unit, crop, status, last_activity, productivity, latitude, longitude,
+			address, soil_type, irrigation_type, land_type, created_at, updated_at`,
+			id, s.farmID, land.name, land.area, "dönüm", land.crop, "active", lastActivity, s.vary(80, 0.15),
+			land.lat, land.lng, "Meram, Konya", land.soil, land.irrigation, land.landType, s.now, s.now)
+
+		s.activity(id, "planting", land.crop+" ekimi", s.day(-160), s.vary(land.area*120, 0.1), nil, nil)
+		s.activity(id, "fertilizing", "Taban gübresi (DAP)", s.day(-150), s.vary(land.area*90, 0.1), land.area*20, nil)
+		s.activity(id, "fertilizing", "Üst gübre (Üre)", s.day(-95), s.vary(land.area*70, 0.1), land.area*15, nil)
+		for offset := -140; offset <= -6; offset += 21 {
+			if land.harvested && offset > -30 {
+				break
+			}
+			s.activity(id, "irrigation", "Sulama", s.day(offset), s.vary(land.area*12, 0.2), nil, land.area*40)
+		}
+		if land.harvested {
+			s.activity(id, "harvest", land.crop+" hasadı", s.day(-25), s.vary(land.area*150, 0.1), nil, nil)
+		} else {
+			s.insert("land_activities", "id, land_id, type, description, scheduled_date, notes, result, created_at",
+				utils.GenerateID(), id, "irrigation", "Planlı sulama", s.day(3), "", "", s.now)
+		}
+		created = append(created, demoLand{id: id, name: land.name, crop: land.crop, area: land.area})
+	}
+	return created
+}
+
+// activity tamamlanmış arazi faaliyeti ekler
+func (s *demoSeeder) activity(landID, activityType, description string, date time.Time, cost float64, fertilizerKg, waterVolume interface{}) {
+	s.insert("land_activities", `id, land_id, type, description, scheduled_date, actual_date, notes, cost, result,
+		fertilizer_kg, water_volume, created_at`,
+		utils.GenerateID(), landID, activityType, description, date, date, "", cost, "", fertilizerKg, waterVolume, s.now)
+}
+
+// livestock sığır ve koyunları tartım, sağlık ve süt kayıtlarıyla oluşturur. Küpe numaraları tüm hesaplarda
+// benzersiz olduğundan çiftlik kimliğinden türetilir
+func (s *demoSeeder) livestock() {
+	animals := []struct {
+		kind, breed, gender, location string
+		ageMonths                     int
+		weight                        float64
+		milking                       bool
+	}{
+		{"cattle", "Holstein", "female", "Ahır 1", 52, 610, true},
+		{"cattle", "Holstein", "female", "Ahır 1", 44, 585, true},
+		{"cattle", "Holstein", "female", "Ahır 1", 38, 560, true},
+		{"cattle", "Simental", "female", "Ahır 1", 60, 640, true},
+		{"cattle", "Simental", "male", "Ahır 2", 20, 480, false},
+		{"cattle", "Holstein", "female", "Buzağı bölmesi", 5, 160, false},
+		{"sheep", "Merinos", "female", "Ağıl", 30, 62, false},
+		{"sheep", "Merinos", "female", "Ağıl", 26, 58, false},
+		{"sheep", "Merinos", "female", "Ağıl", 18, 51, false},
+		{"sheep", "Merinos", "male", "Ağıl", 36, 85, false},
+	}
+
+	prefix := strings.ToUpper(strings.ReplaceAll(s.farmID, "-", ""))
+	if len(prefix) > 8 {
+		prefix = prefix[:8]
+	}
+	for i, animal := range animals {
+		id := utils.GenerateID()
+		weight := s.vary(animal.weight, 0.05)
+		s.insert("livestock", `id, user_id, tag_number, type, breed, gender, birth_date, weight, health_status,
+			location, mother, father, acquisition_type, notes, created_at, updated_at`,
+			id, s.farmID, fmt.Sprintf("DEMO-%s-%02d", prefix, i+1), animal.kind, animal.breed, animal.gender,
+			s.today.AddDate(0, -animal.ageMonths, 0), weight, "healthy", animal.location, "", "", "born", "", s.now, s.now)
+
+		// Son altı ayın aylık tartımları bugünkü ağırlığa doğru artar
+		for month := 5; month >= 0; month-- {
+			gain := 1 - float64(month)*0.015
+			if animal.ageMonths < 12 {
+				gain = 1 - float64(month)*0.12
+			}
+			s.insert("weight_records", "id, livestock_id, weight, record_date, notes, created_at",
+				utils.GenerateID(), id, round2(weight*gain), s.today.AddDate(0, -month, -3), "", s.now)
+		}
+
+		vaccine := "Şap aşısı"
+		if animal.kind == "sheep" {
+			vaccine = "Koyun çiçeği aşısı"
+		}
+		s.insert("health_records", `id, livestock_id, type, description, date, veterinarian, cost, notes, next_checkup,
+			created_at`, utils.GenerateID(), id, "vaccination", vaccine, s.day(-170), "Vet. Hek. Ayşe Demir",
+			s.vary(150, 0.1), "", s.day(10+i), s.now)
+		s.insert("health_records", `id, livestock_id, type, description, date, veterinarian, cost, notes, next_checkup,
+			created_at`, utils.GenerateID(), id, "checkup", "Rutin kontrol", s.day(-40), "Vet. Hek. Ayşe Demir",
+			s.vary(80, 0.1), "", nil, s.now)
+
+		if animal.milking {
+			for offset := -29; offset <= 0; offset++ {
+				s.insert("milk_production", "id, livestock_id, date, amount, quality, notes, created_at",
+					utils.GenerateID(), id, s.day(offset), s.vary(24, 0.12), "A", "", s.now)
+			}
+		}
+	}
+}
+
+// production hasat edilen buğdayı ve son ayın sütünü üretim partisi olarak ekler
+func (s *demoSeeder) production(lands []demoLand) {
+	s.insert("production", `id, user_id, land_id, name, category, amount, unit, harvest_date, quality, storage_location,
+		status, price, sold_amount, notes, created_at, updated_at`,
+		utils.GenerateID(), s.farmID, lands[0].id, "Buğday", "grains", s.vary(lands[0].area*420, 0.05), "kg",
+		s.day(-25), "1. sınıf", "Depo 1", "active", 9.5, 8000, "", s.now, s.now)
+	s.insert("production", `id, user_id, land_id, name, category, amount, unit, harvest_date, quality, storage_location,
+		status, price, sold_amount, notes, created_at, updated_at`,
+		utils.GenerateID(), s.farmID, nil, "Çiğ Süt", "dairy", s.vary(2900, 0.05), "litre",
+		s.day(0), "A", "Soğutma tankı", "active", 16.5, 2400, "", s.now, s.now)
+}
+
+// transactions son on iki ayın gelir ve giderlerini, biri vadesi geçmiş iki bekleyen ödemeyle ekler
+func (s *demoSeeder) transactions() {
+	for month := 11; month >= 0; month-- {
+		date := s.today.AddDate(0, -month, 0)
+		date = time.Date(date.Year(), date.Month(), 5, 0, 0, 0, 0, time.UTC)
+		if date.After(s.today) {
+			date = s.today
+		}
+		s.transaction("income", "Süt Satışı", "Aylık süt teslimatı - Konya Süt Kooperatifi", s.vary(48000, 0.08), date, "bank_transfer")
+		s.transaction("expense", "Yem", "Karma yem ve saman", s.vary(21000, 0.1), date.AddDate(0, 0, 3), "bank_transfer")
+		s.transaction("expense", "Elektrik", "Ahır ve sulama pompası elektriği", s.vary(3800, 0.2), date.AddDate(0, 0, 10), "bank_transfer")
+		s.transaction("expense", "İşçilik", "Sürekli işçi maaşı", 22000, date.AddDate(0, 0, 1), "bank_transfer")
+	}
+	s.transaction("expense", "Tohum", "Buğday ve mısır tohumu", s.vary(38000, 0.05), s.day(-165), "bank_transfer")
+	s.transaction("expense", "Gübre", "DAP ve üre gübresi", s.vary(42000, 0.05), s.day(-152), "credit_card")
+	s.transaction("expense", "Veteriner", "Aşılama ve rutin kontroller", s.vary(2300, 0.05), s.day(-40), "cash")
+	s.transaction("income", "Ürün Satışı", "Buğday satışı - 8 ton", 76000, s.day(-18), "bank_transfer")
+
+	s.insert("transactions", `id, user_id, type, category, description, amount, currency, date, status, payment_method,
+		receipt, notes, due_date, created_at, updated_at`,
+		utils.GenerateID(), s.farmID, "expense", "Akaryakıt", "Traktör mazotu (vadeli)", 14500.0, "TRY", s.day(-35),
+		"pending", "bank_transfer", "", "", s.day(-5), s.now, s.now)
+	s.insert("transactions", `id, user_id, type, category, description, amount, currency, date, status, payment_method,
+		receipt, notes, due_date, created_at, updated_at`,
+		utils.GenerateID(), s.farmID, "expense", "Sigorta", "TARSİM ürün sigortası", 9800.0, "TRY", s.day(-10),
+		"pending", "bank_transfer", "", "", s.day(20), s.now, s.now)
+}
+
+// transaction tamamlanmış işlem ekler
+func (s *demoSeeder) transaction(kind, category, description string, amount float64, date time.Time, method string) {
+	if date.After(s.today) {
+		return
+	}
+	s.insert("transactions", `id, user_id, type, category, description, amount, currency, date, status, payment_method,
+		receipt, notes, paid_at, created_at, updated_at`,
+		utils.GenerateID(), s.farmID, kind, category, description, amount, "TRY", date, "completed", method, "", "",
+		date, s.now, s.now)
+}
+
+// customers süt ve tahıl alıcılarını ekler
+func (s *demoSeeder) customers() {
+	s.insert("customers", "id, user_id, name, customer_type, phone, email, notes, created_at, updated_at",
+		utils.GenerateID(), s.farmID, "Konya Süt Kooperatifi", "company", s.encrypt(ColumnCustomerPhone, "0332 000 00 00"), "siparis@example.com",
+		"Aylık süt teslimatı", s.now, s.now)
+	s.insert("customers", "id, user_id, name, customer_type, phone, email, notes, created_at, updated_at",
+		utils.GenerateID(), s.farmID, "Anadolu Un Fabrikası", "company", s.encrypt(ColumnCustomerPhone, "0332 000 00 01"), "alim@example.com",
+		"Buğday alıcısı", s.now, s.now)
+}
+
+// events önümüzdeki günlerin aşılama, sulama ve bakım etkinliklerini ekler
+func (s *demoSeeder) events(lands []demoLand) {
+	events := []struct {
+		title, description, kind, priority, entityType, entityID string
+		offset                                                   int
+	}{
+		{"Sürü aşılaması", "Şap aşısı tekrar dozu", "health", "high", "", "", 10},
+		{"Güney Tarla sulaması", "Damla sulama, 6 saat", "irrigation", "medium", "land", lands[1].id, 3},
+		{"Traktör periyodik bakımı", "Yağ ve filtre değişimi", "maintenance", "medium", "", "", 7},
+		{"Mısır hasadı", "Güney Tarla hasat planı", "harvest", "high", "land", lands[1].id, 35},
+	}
+	for _, event := range events {
+		var entityType, entityID interface{}
+		if event.entityType != "" {
+			entityType, entityID = event.entityType, event.entityID
+		}
+		s.insert("events", `id, user_id, title, description, type, start_date, is_all_day, status, priority, location,
+			related_entity_type, related_entity_id, created_at, updated_at`,
+			utils.GenerateID(), s.farmID, event.title, event.description, event.kind, s.day(event.offset), true,
+			"pending", event.priority, "", entityType, entityID, s.now, s.now)
+	}
+}
@@ -0,0 +1,302 @@
+package services
+
+import (
+	"database/sql"
+	"log"
+	"math"
+	"time"
+
+	"agri-management-api/internal/models"
+	"agri-management-api/internal/utils"
+)
+
+// depreciationCategory amortisman giderlerinin finans kategorisi
+const depreciationCategory = "Amortisman"
+
+// depreciationPeriodLayout amortisman dönemi biçimi (YYYY-MM)
+const depreciationPeriodLayout = "2006-01"
+
+// DepreciationService duran varlık amortismanını hesaplar ve aylık giderleri finansa işler
+type DepreciationService struct {
+	db *sql.DB
+}
+
+// NewDepreciationService yeni depreciation service oluşturur
+func NewDepreciationService(db *sql.DB) *DepreciationService {
+	return &DepreciationService{db: db}
+}
+
+// fixedAssetSelect duran varlık sütunları
+const fixedAssetSelect = `
+	SELECT id, user_id, name, category, purchase_date, cost, COALESCE(salvage_value, 0), useful_life_months,
+	       method, COALESCE(declining_factor, 2), COALESCE(status, 'active'), disposed_at, hourly_rate,
+	       COALESCE(notes, ''), created_at, updated_at
+	FROM fixed_assets
+`
+
+// scanFixedAsset duran varlık satırını okur
+func scanFixedAsset(row interface{ Scan(...interface{}) error }) (models.FixedAsset, error) {
+	var asset models.FixedAsset
+	var purchaseDate, disposedAt sql.NullTime
+	var hourlyRate sql.NullFloat64
+
+	err := row.Scan(
+		&asset.ID, &asset.UserID, &asset.Name, &asset.Category, &purchaseDate, &asset.Cost, &asset.SalvageValue,
+		&asset.UsefulLifeMonths, &asset.Method, &asset.DecliningFactor, &asset.Status, &disposedAt, &hourlyRate,
+		&asset.Notes, &asset.CreatedAt, &asset.UpdatedAt,
+	)
+	if err != nil {
+		return asset, err
+	}
+
+	asset.PurchaseDate = utils.NullTimeToPtr(purchaseDate)
+	asset.DisposedAt = utils.NullTimeToPtr(disposedAt)
+	asset.HourlyRate = utils.NullFloat64ToPtr(hourlyRate)
+	return asset, nil
+}
+
+// Asset kullanıcının duran varlığını birikmiş amortisman ve net defter değeriyle getirir
+func (s *DepreciationService) Asset(userID, assetID string) (models.FixedAsset, error) {
+	asset, err := scanFixedAsset(s.db.QueryRow(fixedAssetSelect+" WHERE id = ? AND user_id = ?", assetID, userID))
+	if err != nil {
+		return asset, err
+	}
+	asset.AccumulatedDepreciation, asset.NetBookValue = ValueAt(asset, time.Now())
+	return asset, nil
+}
+
+// Assets kullanıcının duran varlıklarını verilen tarihteki değerleriyle listeler; boş filtreler uygulanmaz
+func (s *DepreciationService) Assets(userID, category, status string, at time.Time) ([]models.FixedAsset, error) {
+	query := fixedAssetSelect + " WHERE user_id = ?"
+	args := []interface{}{userID}
+	if category != "" {
+		query += " AND category = ?"
+		args = append(args, category)
+	}
+	if status != "" {
+		query += " AND status = ?"
+		args = append(args, status)
+	}
+
+	rows, err := s.db.Query(query+" ORDER BY purchase_date DESC, name", args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	assets := []models.FixedAsset{}
+	for rows.Next() {
+		asset, err := scanFixedAsset(rows)
+		if err != nil {
+			continue
+		}
+		asset.AccumulatedDepreciation, asset.NetBookValue = ValueAt(asset, at)
+		assets = append(assets, asset)
+	}
+	return assets, rows.Err()
+}
+
+// Schedule varlığın satın alındığı aydan başlayarak ömrü boyunca aylık amortisman tablosunu hesaplar;
+// elden çıkarılan varlıklarda tablo elden çıkarma ayında biter
+func Schedule(asset models.FixedAsset) []models.DepreciationEntry {
+	if asset.PurchaseDate == nil || asset.UsefulLifeMonths <= 0 {
+		return nil
+	}
+
+	depreciable := asset.Cost - asset.SalvageValue
+	if depreciable <= 0 {
+		return []models.DepreciationEntry{}
+	}
+
+	factor := asset.DecliningFactor
+	if factor <= 0 {
+		factor = 2
+	}
+	monthlyRate := factor / float64(asset.UsefulLifeMonths)
+
+	start := time.Date(asset.PurchaseDate.Year(), asset.PurchaseDate.Month(), 1, 0, 0, 0, 0, time.UTC)
+	var lastPeriod string
+	if asset.DisposedAt != nil {
+		lastPeriod = asset.DisposedAt.Format(depreciationPeriodLayout)
+	}
+
+	entries := make([]models.DepreciationEntry, 0, asset.UsefulLifeMonths)
+	bookValue := asset.Cost
+	accumulated := 0.0
+
+	for month := 0; month < asset.UsefulLifeMonths; month++ {
+		period := start.AddDate(0, month, 0).Format(depreciationPeriodLayout)
+		if lastPeriod != "" && period > lastPeriod {
+			break
+		}
+
+		remainingMonths := asset.UsefulLifeMonths - month
+		straightLine := (bookValue - asset.SalvageValue) / float64(remainingMonths)
+
+		amount := straightLine
+		if asset.Method == models.DepreciationDecliningBalance {
+			// Azalan bakiyeler yöntemi; doğrusal tutar daha yüksek olduğunda doğrusala geçilir
+			amount = math.Max(bookValue*monthlyRate, straightLine)
+		}
+		if month == asset.UsefulLifeMonths-1 || bookValue-amount < asset.SalvageValue {
+			amount = bookValue - asset.SalvageValue
+		}
+
+		amount = round2(amount)
+		bookValue = round2(bookValue - amount)
+		accumulated = round2(accumulated + amount)
+
+		entries = append(entries, models.DepreciationEntry{
+			Period:      period,
+			Amount:      amount,
+			Accumulated: accumulated,
+			BookValue:   bookValue,
+		})
+	}
+
+	return entries
+}
+
+// ValueAt varlığın verilen tarihin ayı dahil birikmiş amortismanını ve net defter değerini döner
+func ValueAt(asset models.FixedAsset, date time.Time) (accumulated, bookValue float64) {
+	period := date.Format(depreciationPeriodLayout)
+	bookValue = asset.Cost
+
+	for _, entry := range Schedule(asset) {
+		if entry.Period > period {
+			break
+		}
+		accumulated, bookValue = entry.Accumulated, entry.BookValue
+	}
+
+	return accumulated, bookValue
+}
+
+// PostedPeriods varlığın finansa işlenmiş amortisman dönemlerini döner
+func (s *DepreciationService) PostedPeriods(userID, assetID string) (map[string]bool, error) {
+	rows, err := s.db.Query("SELECT period FROM depreciation_postings WHERE asset_id = ? AND user_id = ?", assetID, userID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	periods := map[string]bool{}
+	for rows.Next() {
+		var period string
+		if err := rows.Scan(&period); err != nil {
+			continue
+		}
+		periods[period] = true
+	}
+	return periods, rows.Err()
+}
+
+// StartPoster tamamlanan ayın amortisman giderlerini günlük kontrolle tüm kullanıcılar için finansa işler
+func (s *DepreciationService) StartPoster() {
+	go func() {
+		ticker := time.NewTicker(24 * time.Hour)
+		defer ticker.Stop()
+
+		for {
+			period := time.Now().AddDate(0, -1, 0).Format(depreciationPeriodLayout)
+			if _, err := s.PostPeriod("", period); err != nil {
+				log.Printf("Amortisman giderleri işlenemedi: %v", err)
+			}
+			<-ticker.C
+		}
+	}()
+}
+
+// PostPeriod dönemin amortisman giderlerini daha önce işlenmemiş varlıklar için gider işlemi olarak kaydeder;
+// userID boşsa tüm kullanıcıların varlıkları işlenir
+func (s *DepreciationService) PostPeriod(userID, period string) (models.DepreciationPostResult, error) {
+	result := models.DepreciationPostResult{Period: period, Transactions: []string{}}
+
+	periodStart, err := time.Parse(depreciationPeriodLayout, period)
+	if err != nil {
+		return result, err
+	}
+	periodEnd := periodStart.AddDate(0, 1, -1)
+
+	query := fixedAssetSelect + " WHERE purchase_date < ?"
+	args := []interface{}{periodStart.AddDate(0, 1, 0)}
+	if userID != "" {
+		query += " AND user_id = ?"
+		args = append(args, userID)
+	}
+
+	rows, err := s.db.Query(query, args...)
+	if err != nil {
+		return result, err
+	}
+
+	var assets []models.FixedAsset
+	for rows.Next() {
+		asset, err := scanFixedAsset(rows)
+		if err != nil {
+			continue
+		}
+		assets = append(assets, asset)
+	}
+	rows.Close()
+
+	for _, asset := range assets {
+		var entry *models.DepreciationEntry
+		for _, candidate := range Schedule(asset) {
+			if candidate.Period == period {
+				entry = &candidate
+				break
+			}
+		}
+		if entry == nil || entry.Amount <= 0 {
+			continue
+		}
+
+		posted, err := s.postEntry(asset, *entry, periodEnd)
+		if err != nil {
+			return result, err
+		}
+		if posted == "" {
+			result.AlreadyPosted++
+			continue
+		}
+
+		result.Posted++
+		result.TotalAmount = round2(result.TotalAmount + entry.Amount)
+		result.Transactions = append(result.Transactions, posted)
+	}
+
+	return result, nil
+}
+
+// postEntry dönem satırını gider işlemi olarak kaydeder; dönem daha önce işlendiyse boş döner
+func (s *DepreciationService) postEntry(asset models.FixedAsset, entry models.DepreciationEntry, date time.Time) (string, error) {
+	tx, err := s.db.Begin()
+	if err != nil {
+		return "", err
+	}
+	defer tx.Rollback()
+
+	transactionID := utils.GenerateID()
+	result, err := tx.Exec(`
+		INSERT OR IGNORE INTO depreciation_postings (id, asset_id, user_id, period, amount, transaction_id, created_at)
+		VALUES (?, ?, ?, ?, ?, ?, CURRENT_TIMESTAMP)
+	`, utils.GenerateID(), asset.ID, asset.UserID, entry.Period, entry.Amount, transactionID)
+	if err != nil {
+		return "", err
+	}
+	if rowsAffected, _ := result.RowsAffected(); rowsAffected == 0 {
+		return "", nil
+	}
+
+	_, err = tx.Exec(`
+		INSERT INTO transactions (id, user_id, type, category, description, amount, currency,
+		                         date, status, payment_method, receipt, notes, created_at, updated_at)
+		VALUES (?, ?, 'expense', ?, ?, ?, 'TRY', ?, 'completed', '', '', '', CURRENT_TIMESTAMP, CURRENT_TIMESTAMP)
+	`, transactionID, asset.UserID, depreciationCategory, "Amortisman - "+asset.Name+" ("+entry.Period+")", entry.Amount, date)
+	if err != nil {
+		return "", err
+	}
+
+	return transactionID, tx.Commit()
+}
@@ -0,0 +1,136 @@
+package services
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"mime/multipart"
+	"net/http"
+	"net/textproto"
+	"os"
+	"sort"
+	"time"
+
+	"agri-management-api/internal/models"
+)
+
+// ErrDiagnosisDisabled görsel teşhis sağlayıcısı yapılandırılmadığında döner
+var ErrDiagnosisDisabled = errors.New("diagnosis provider not configured")
+
+// maxDiagnosisCandidates sağlayıcıdan dönen en fazla aday sayısı
+const maxDiagnosisCandidates = 5
+
+// DiseaseIdentifier bitki veya hayvan fotoğrafından olası zararlı/hastalık teşhislerini döndüren sağlayıcı arayüzü
+type DiseaseIdentifier interface {
+	Name() string
+	Identify(ctx context.Context, subjectType, filename, contentType string, image []byte) ([]models.DiagnosisCandidate, error)
+}
+
+// NewDiseaseIdentifier DIAGNOSIS_PROVIDER ortam değişkenine göre sağlayıcı oluşturur; yapılandırılmamışsa nil döner
+func NewDiseaseIdentifier() DiseaseIdentifier {
+	switch os.Getenv("DIAGNOSIS_PROVIDER") {
+	case "http":
+		return &HTTPDiseaseIdentifier{
+			endpoint: os.Getenv("DIAGNOSIS_ENDPOINT"),
+			apiKey:   os.Getenv("DIAGNOSIS_API_KEY"),
+			client:   &http.Client{Timeout: 60 * time.Second},
+		}
+	}
+	return nil
+}
+
+// HTTPDiseaseIdentifier fotoğrafı multipart olarak (file, subjectType) DIAGNOSIS_ENDPOINT adresine gönderir;
+// uç noktanın {"candidates": [{"name", "category", "confidence", "description"}]} biçiminde yanıt vermesi beklenir
+type HTTPDiseaseIdentifier struct {
+	endpoint string
+	apiKey   string
+	client   *http.Client
+}
+
+// Name sağlayıcı adı
+func (d *HTTPDiseaseIdentifier) Name() string {
+	return "http"
+}
+
+// Identify fotoğrafı sağlayıcıya gönderir ve adayları güven değerine göre azalan sırada döner
+func (d *HTTPDiseaseIdentifier) Identify(ctx context.Context, subjectType, filename, contentType string, image []byte) ([]models.DiagnosisCandidate, error) {
+	var body bytes.Buffer
+	writer := multipart.NewWriter(&body)
+
+	header := make(textproto.MIMEHeader)
+	header.Set("Content-Disposition", fmt.Sprintf(`form-data; name="file"; filename=%q`, filename))
+	header.Set("Content-Type", contentType)
+	part, err := writer.CreatePart(header)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := part.Write(image); err != nil {
+		return nil, err
+	}
+	writer.WriteField("subjectType", subjectType)
+	if err := writer.Close(); err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, d.endpoint, &body)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", writer.FormDataContentType())
+	if d.apiKey != "" {
+		req.Header.Set("Authorization", "Bearer "+d.apiKey)
+	}
+
+	resp, err := d.client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		message, _ := io.ReadAll(io.LimitReader(resp.Body, 512))
+		return nil, fmt.Errorf("diagnosis failed: %s: %s", resp.Status, bytes.TrimSpace(message))
+	}
+
+	var result struct {
+		Candidates []models.DiagnosisCandidate `json:"candidates"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		return nil, err
+	}
+
+	return normalizeCandidates(result.Candidates), nil
+}
+
+// normalizeCandidates adsız adayları atar, kategori ve güven değerini sınırlar, adayları güvene göre sıralayıp ilk 5'i döner
+func normalizeCandidates(candidates []models.DiagnosisCandidate) []models.DiagnosisCandidate {
+	normalized := []models.DiagnosisCandidate{}
+	for _, candidate := range candidates {
+		if candidate.Name == "" {
+			continue
+		}
+		switch candidate.Category {
+		case models.ObservationCategoryPest, models.ObservationCategoryDisease, models.ObservationCategoryDeficiency:
+		default:
+			candidate.Category = models.ObservationCategoryOther
+		}
+		if candidate.Confidence < 0 {
+			candidate.Confidence = 0
+		}
+		if candidate.Confidence > 1 {
+			candidate.Confidence = 1
+		}
+		normalized = append(normalized, candidate)
+	}
+
+	sort.SliceStable(normalized, func(i, j int) bool {
+		return normalized[i].Confidence > normalized[j].Confidence
+	})
+	if len(normalized) > maxDiagnosisCandidates {
+		normalized = normalized[:maxDiagnosisCandidates]
+	}
+	return normalized
+}
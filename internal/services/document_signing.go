@@ -0,0 +1,302 @@
+package services
+
+import (
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/x509"
+	"database/sql"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"log"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	"agri-management-api/internal/models"
+	"agri-management-api/internal/utils"
+)
+
+// DocumentSignatureAlgorithm belge imzalarında kullanılan algoritma
+const DocumentSignatureAlgorithm = "Ed25519"
+
+var (
+	// ErrDocumentSigningDisabled imza anahtarı yüklenmeden belge imzalanmak istendiğinde döner
+	ErrDocumentSigningDisabled = errors.New("belge imzalama anahtarı yüklenmedi")
+	// ErrDocumentSignatureInvalid doğrulamaya gönderilen imza base64 kodlu Ed25519 imzası olmadığında döner
+	ErrDocumentSignatureInvalid = errors.New("imza base64 kodlu 64 baytlık Ed25519 imzası olmalı")
+)
+
+// signingKey belgeleri imzalayan etkin sunucu anahtarı; açık anahtarlar veritabanında saklanır, böylece anahtar
+// değiştiğinde de eski belgeler doğrulanabilir
+var signingKey struct {
+	mu      sync.RWMutex
+	id      string
+	private ed25519.PrivateKey
+}
+
+// DocumentSigningService uyum belgeleri ve pasaport gibi dışa aktarımları sunucu anahtarıyla imzalar ve doğrular
+type DocumentSigningService struct {
+	db *sql.DB
+}
+
+// NewDocumentSigningService yeni document signing service oluşturur
+func NewDocumentSigningService(db *sql.DB) *DocumentSigningService {
+	return &DocumentSigningService{db: db}
+}
+
+// Init EXPORT_SIGNING_KEY ortam değişkenindeki imza anahtarını ("kimlik:base64 32 bayt Ed25519 tohumu") yükler ve
+// açık anahtarını yayımlar; önceki anahtarlar emekliye ayrılır ama doğrulamada kullanılmaya devam eder. Değişken
+// tanımlı değilse her açılışta yeni bir anahtar üretilir
+func (s *DocumentSigningService) Init() error {
+	keyID, private, err := parseSigningKey(os.Getenv("EXPORT_SIGNING_KEY"))
+	if err != nil {
+		return err
+	}
+	if private == nil {
+		_, private, err = ed25519.GenerateKey(rand.Reader)
+		if err != nil {
+			return err
+		}
+		keyID = "auto-" + utils.GenerateID()[:8]
+		log.Println("⚠️  EXPORT_SIGNING_KEY tanımlı değil, belgeler bu açılışa özel üretilen anahtarla imzalanıyor")
+	}
+
+	public := base64.StdEncoding.EncodeToString(private.Public().(ed25519.PublicKey))
+	var stored string
+	err = s.db.QueryRow("SELECT public_key FROM document_signing_keys WHERE id = ?", keyID).Scan(&stored)
+	switch {
+	case err == sql.ErrNoRows:
+	case err != nil:
+		return err
+	case stored != public:
+		return fmt.Errorf("EXPORT_SIGNING_KEY geçersiz: %s kimliği farklı bir anahtar için kullanılmış", keyID)
+	}
+
+	tx, err := s.db.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	_, err = tx.Exec(`
+		INSERT OR IGNORE INTO document_signing_keys (id, algorithm, public_key, created_at) VALUES (?, ?, ?, CURRENT_TIMESTAMP)
+	`, keyID, DocumentSignatureAlgorithm, public)
+	if err != nil {
+		return err
+	}
+	if _, err := tx.Exec("UPDATE document_signing_keys SET retired_at = NULL WHERE id = ?", keyID); err != nil {
+		return err
+	}
+	_, err = tx.Exec("UPDATE document_signing_keys SET retired_at = ? WHERE id != ? AND retired_at IS NULL", time.Now().UTC(), keyID)
+	if err != nil {
+		return err
+	}
+	if err := tx.Commit(); err != nil {
+		return err
+	}
+
+	signingKey.mu.Lock()
+	signingKey.id, signingKey.private = keyID, private
+	signingKey.mu.Unlock()
+	return nil
+}
+
+// parseSigningKey "kimlik:base64" biçimindeki imza anahtarını çözer; değer boşsa nil döner
+func parseSigningKey(raw string) (string, ed25519.PrivateKey, error) {
+	raw = strings.TrimSpace(raw)
+	if raw == "" {
+		return "", nil, nil
+	}
+	id, encoded, ok := strings.Cut(raw, ":")
+	if !ok || id == "" {
+		return "", nil, errors.New("EXPORT_SIGNING_KEY geçersiz: anahtar kimlik:base64 biçiminde olmalı")
+	}
+	seed, err := base64.StdEncoding.DecodeString(encoded)
+	if err != nil || len(seed) != ed25519.SeedSize {
+		return "", nil, fmt.Errorf("EXPORT_SIGNING_KEY geçersiz: %s anahtarı base64 kodlu 32 bayt olmalı", id)
+	}
+	return id, ed25519.NewKeyFromSeed(seed), nil
+}
+
+// Sign belgeyi etkin anahtarla imzalar ve imzayı, belgeyi üretildiği haliyle doğrulayabilmek için kaydeder
+func (s *DocumentSigningService) Sign(farmID, documentType, documentID, filename, contentType string, data []byte) (models.DocumentSignature, error) {
+	signingKey.mu.RLock()
+	keyID, private := signingKey.id, signingKey.private
+	signingKey.mu.RUnlock()
+	if private == nil {
+		return models.DocumentSignature{}, ErrDocumentSigningDisabled
+	}
+
+	digest := sha256.Sum256(data)
+	signature := models.DocumentSignature{
+		ID:           utils.GenerateID(),
+		DocumentType: documentType,
+		DocumentID:   documentID,
+		Filename:     filename,
+		ContentType:  contentType,
+		Size:         int64(len(data)),
+		SHA256:       hex.EncodeToString(digest[:]),
+		Algorithm:    DocumentSignatureAlgorithm,
+		KeyID:        keyID,
+		Signature:    base64.StdEncoding.EncodeToString(ed25519.Sign(private, data)),
+		CreatedAt:    time.Now().UTC(),
+	}
+
+	_, err := s.db.Exec(`
+		INSERT INTO document_signatures (
+			id, user_id, document_type, document_id, filename, content_type, size, sha256, key_id, signature, created_at
+		) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
+	`, signature.ID, farmID, signature.DocumentType, utils.StringToNullString(signature.DocumentID), signature.Filename,
+		signature.ContentType, signature.Size, signature.SHA256, signature.KeyID, signature.Signature, signature.CreatedAt)
+	return signature, err
+}
+
+// documentSignatureSelect imza sütunları
+const documentSignatureSelect = `
+	SELECT s.id, s.document_type, COALESCE(s.document_id, ''), s.filename, s.content_type, s.size, s.sha256,
+	       k.algorithm, s.key_id, s.signature, s.created_at
+	FROM document_signatures s
+	JOIN document_signing_keys k ON k.id = s.key_id
+`
+
+// scanDocumentSignature imza satırını okur
+func scanDocumentSignature(row interface{ Scan(...interface{}) error }) (models.DocumentSignature, error) {
+	var signature models.DocumentSignature
+	err := row.Scan(
+		&signature.ID, &signature.DocumentType, &signature.DocumentID, &signature.Filename, &signature.ContentType,
+		&signature.Size, &signature.SHA256, &signature.Algorithm, &signature.KeyID, &signature.Signature, &signature.CreatedAt,
+	)
+	return signature, err
+}
+
+// Signatures çiftliğin imzalanan belgelerini yeniden eskiye sayfalı döner; documentType verilirse o türle sınırlanır
+func (s *DocumentSigningService) Signatures(farmID, documentType string, page, limit int) (models.DocumentSignatureListResponse, error) {
+	where := " WHERE s.user_id = ?"
+	args := []interface{}{farmID}
+	if documentType != "" {
+		where += " AND s.document_type = ?"
+		args = append(args, documentType)
+	}
+
+	response := models.DocumentSignatureListResponse{Signatures: []models.DocumentSignature{}}
+	var total int
+	if err := s.db.QueryRow("SELECT COUNT(*) FROM document_signatures s"+where, args...).Scan(&total); err != nil {
+		return response, err
+	}
+	response.Pagination = utils.CalculatePagination(page, limit, total)
+
+	rows, err := s.db.Query(documentSignatureSelect+where+" ORDER BY s.created_at DESC LIMIT ? OFFSET ?",
+		append(args, limit, (page-1)*limit)...)
+	if err != nil {
+		return response, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		signature, err := scanDocumentSignature(rows)
+		if err != nil {
+			return response, err
+		}
+		response.Signatures = append(response.Signatures, signature)
+	}
+	return response, rows.Err()
+}
+
+// Keys imzaları doğrulamak için yayımlanan açık anahtarları etkin anahtar önce olacak şekilde döner
+func (s *DocumentSigningService) Keys() ([]models.DocumentSigningKey, error) {
+	rows, err := s.db.Query(`
+		SELECT id, algorithm, public_key, retired_at IS NULL, created_at, retired_at
+		FROM document_signing_keys
+		ORDER BY retired_at IS NULL DESC, created_at DESC
+	`)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	keys := []models.DocumentSigningKey{}
+	for rows.Next() {
+		var key models.DocumentSigningKey
+		var retiredAt sql.NullTime
+		if err := rows.Scan(&key.ID, &key.Algorithm, &key.PublicKey, &key.Active, &key.CreatedAt, &retiredAt); err != nil {
+			return nil, err
+		}
+		if retiredAt.Valid {
+			key.RetiredAt = &retiredAt.Time
+		}
+		if public, err := base64.StdEncoding.DecodeString(key.PublicKey); err == nil {
+			if der, err := x509.MarshalPKIXPublicKey(ed25519.PublicKey(public)); err == nil {
+				key.PublicKeyPEM = string(pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: der}))
+			}
+		}
+		keys = append(keys, key)
+	}
+	return keys, rows.Err()
+}
+
+// Verify belgenin bu sunucuda üretilip üretilmediğini ve sonradan değiştirilip değiştirilmediğini denetler.
+// Belge özetiyle kayıtlı imza bulunur ve imza açık anahtarla belgenin baytları üzerinde doğrulanır; signature
+// (alıcının elindeki ayrık imza, base64) verilirse o imza da doğrulanır. Henüz çiftlik kaydı oluşmamış varsayılan
+// çiftliklerde profildeki çiftlik adı döner
+func (s *DocumentSigningService) Verify(data []byte, signature string) (models.DocumentVerification, error) {
+	digest := sha256.Sum256(data)
+	result := models.DocumentVerification{SHA256: hex.EncodeToString(digest[:])}
+
+	var provided []byte
+	if signature = strings.TrimSpace(signature); signature != "" {
+		decoded, err := base64.StdEncoding.DecodeString(signature)
+		if err != nil || len(decoded) != ed25519.SignatureSize {
+			return result, ErrDocumentSignatureInvalid
+		}
+		provided = decoded
+	}
+
+	var farmName, publicKey string
+	row := s.db.QueryRow(`
+		SELECT s.id, s.document_type, COALESCE(s.document_id, ''), s.filename, s.content_type, s.size, s.sha256,
+		       k.algorithm, s.key_id, s.signature, s.created_at, COALESCE(f.name, NULLIF(o.farm_name, ''), o.name, ''), k.public_key
+		FROM document_signatures s
+		JOIN document_signing_keys k ON k.id = s.key_id
+		LEFT JOIN farms f ON f.id = s.user_id
+		LEFT JOIN users o ON o.id = s.user_id
+		WHERE s.sha256 = ?
+		ORDER BY s.created_at DESC
+		LIMIT 1
+	`, result.SHA256)
+	var record models.DocumentSignature
+	err := row.Scan(
+		&record.ID, &record.DocumentType, &record.DocumentID, &record.Filename, &record.ContentType, &record.Size,
+		&record.SHA256, &record.Algorithm, &record.KeyID, &record.Signature, &record.CreatedAt, &farmName, &publicKey,
+	)
+	if err == sql.ErrNoRows {
+		result.Reason = "Belge bu sunucuda imzalanmamış veya imzalandıktan sonra değiştirilmiş"
+		return result, nil
+	}
+	if err != nil {
+		return result, err
+	}
+
+	public, err := base64.StdEncoding.DecodeString(publicKey)
+	if err != nil || len(public) != ed25519.PublicKeySize {
+		return result, fmt.Errorf("%s imza anahtarı okunamadı", record.KeyID)
+	}
+	stored, err := base64.StdEncoding.DecodeString(record.Signature)
+	if err != nil || !ed25519.Verify(public, data, stored) {
+		result.Reason = "Kayıtlı imza belgeyle eşleşmiyor"
+		return result, nil
+	}
+	if provided != nil && !ed25519.Verify(public, data, provided) {
+		result.Reason = "Gönderilen imza belgeyle eşleşmiyor"
+		return result, nil
+	}
+
+	result.Valid = true
+	result.FarmName = farmName
+	result.Signature = &record
+	return result, nil
+}
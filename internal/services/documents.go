@@ -0,0 +1,199 @@
+package services
+
+import (
+	"archive/zip"
+	"bytes"
+	"compress/zlib"
+	"encoding/xml"
+	"io"
+	"regexp"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+)
+
+// maxDocumentText dokümandan saklanacak en fazla metin uzunluğu (karakter)
+const maxDocumentText = 200000
+
+// docxContentType Word dokümanlarının içerik türü
+const docxContentType = "application/vnd.openxmlformats-officedocument.wordprocessingml.document"
+
+var (
+	pdfStreamPattern = regexp.MustCompile(`(?s)stream\r?\n(.*?)\r?\nendstream`)
+	pdfTextPattern   = regexp.MustCompile(`(?s)BT(.*?)ET`)
+	whitespace       = regexp.MustCompile(`\s+`)
+)
+
+// ExtractDocumentText aramada kullanılmak üzere dokümanın metnini çıkarır; düz metin, PDF ve
+// Word (docx) dosyaları desteklenir, taranmış görüntüler için boş döner
+func ExtractDocumentText(contentType, filename string, data []byte) string {
+	contentType = strings.ToLower(strings.TrimSpace(strings.Split(contentType, ";")[0]))
+	filename = strings.ToLower(filename)
+
+	var text string
+	switch {
+	case strings.HasPrefix(contentType, "text/"), contentType == "application/json":
+		if utf8.Valid(data) {
+			text = string(data)
+		}
+	case contentType == "application/pdf" || strings.HasSuffix(filename, ".pdf"):
+		text = extractPDFText(data)
+	case contentType == docxContentType || strings.HasSuffix(filename, ".docx"):
+		text = extractDocxText(data)
+	}
+
+	text = strings.TrimSpace(whitespace.ReplaceAllString(text, " "))
+	if utf8.RuneCountInString(text) > maxDocumentText {
+		text = string([]rune(text)[:maxDocumentText])
+	}
+	return text
+}
+
+// DocumentSnippet metinde ilk eşleşen terimin çevresindeki kısa bölümü döner
+func DocumentSnippet(text string, terms []string) string {
+	lower := strings.ToLower(text)
+	for _, term := range terms {
+		index := strings.Index(lower, strings.ToLower(term))
+		if index < 0 {
+			continue
+		}
+
+		start, end := index-60, index+len(term)+60
+		if start < 0 {
+			start = 0
+		}
+		if end > len(text) {
+			end = len(text)
+		}
+		// Çok baytlı karakterlerin ortasından kesilmemesi için sınırlar karakter başına çekilir
+		for start > 0 && !utf8.RuneStart(text[start]) {
+			start--
+		}
+		for end < len(text) && !utf8.RuneStart(text[end]) {
+			end++
+		}
+
+		snippet := strings.TrimSpace(text[start:end])
+		if start > 0 {
+			snippet = "…" + snippet
+		}
+		if end < len(text) {
+			snippet += "…"
+		}
+		return snippet
+	}
+	return ""
+}
+
+// extractPDFText PDF içerik akışlarındaki metin nesnelerinden (Tj, TJ) metni okur
+func extractPDFText(data []byte) string {
+	var out strings.Builder
+
+	for _, match := range pdfStreamPattern.FindAllSubmatch(data, -1) {
+		content := match[1]
+		if reader, err := zlib.NewReader(bytes.NewReader(content)); err == nil {
+			if inflated, err := io.ReadAll(reader); err == nil || len(inflated) > 0 {
+				content = inflated
+			}
+			reader.Close()
+		}
+
+		for _, block := range pdfTextPattern.FindAllSubmatch(content, -1) {
+			out.WriteString(pdfStrings(block[1]))
+			out.WriteByte('\n')
+		}
+	}
+
+	return out.String()
+}
+
+// pdfStrings metin nesnesindeki parantezli dizgeleri kaçış karakterlerini çözerek birleştirir
+func pdfStrings(block []byte) string {
+	var out strings.Builder
+	depth := 0
+
+	for i := 0; i < len(block); i++ {
+		b := block[i]
+		switch {
+		case b == '(':
+			if depth > 0 {
+				out.WriteByte(b)
+			}
+			depth++
+		case b == ')' && depth > 0:
+			depth--
+			if depth > 0 {
+				out.WriteByte(b)
+			}
+		case b == '\\' && depth > 0 && i+1 < len(block):
+			i++
+			switch block[i] {
+			case 'n', 'r':
+				out.WriteByte(' ')
+			case 't':
+				out.WriteByte('\t')
+			default:
+				out.WriteByte(block[i])
+			}
+		case depth > 0:
+			out.WriteByte(b)
+		case b == '\'' || b == '"':
+			out.WriteByte(' ')
+		case b == '-' && i+1 < len(block) && block[i+1] >= '0' && block[i+1] <= '9':
+			// TJ dizisindeki büyük boşluk ayarları kelime arası olarak yorumlanır
+			j := i + 1
+			for j < len(block) && (block[j] >= '0' && block[j] <= '9' || block[j] == '.') {
+				j++
+			}
+			if gap, err := strconv.ParseFloat(string(block[i+1:j]), 64); err == nil && gap >= 150 {
+				out.WriteByte(' ')
+			}
+			i = j - 1
+		case b == 'T' && i+1 < len(block) && strings.IndexByte("*dD", block[i+1]) >= 0:
+			// Satır/konum değiştiren operatörler kelimeleri ayırır
+			out.WriteByte(' ')
+		}
+	}
+
+	return out.String()
+}
+
+// extractDocxText Word dokümanının gövdesindeki paragrafları okur
+func extractDocxText(data []byte) string {
+	archive, err := zip.NewReader(bytes.NewReader(data), int64(len(data)))
+	if err != nil {
+		return ""
+	}
+
+	for _, file := range archive.File {
+		if file.Name != "word/document.xml" {
+			continue
+		}
+
+		reader, err := file.Open()
+		if err != nil {
+			return ""
+		}
+		defer reader.Close()
+
+		var out strings.Builder
+		decoder := xml.NewDecoder(io.LimitReader(reader, 50<<20))
+		for {
+			token, err := decoder.Token()
+			if err != nil {
+				break
+			}
+			switch t := token.(type) {
+			case xml.CharData:
+				out.Write(t)
+			case xml.EndElement:
+				if t.Name.Local == "p" || t.Name.Local == "tab" {
+					out.WriteByte(' ')
+				}
+			}
+		}
+		return out.String()
+	}
+
+	return ""
+}
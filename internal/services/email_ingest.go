@@ -0,0 +1,149 @@
+package services
+
+import (
+	"net/mail"
+	"os"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"agri-management-api/internal/utils"
+)
+
+// E-posta metninden tutar, tarih ve para birimi çıkarmak için kullanılan desenler;
+// tutar önce toplam/tutar gibi anahtar kelimelerin yanında, bulunamazsa para birimi işaretinin yanında aranır
+var (
+	inboundLabeledAmountPattern  = regexp.MustCompile(`(?i)(genel toplam|toplam|tutar|ödenecek|total|amount)\D{0,20}?(\d{1,3}(?:[.,\s]\d{3})+(?:[.,]\d{1,2})?|\d+(?:[.,]\d{1,2})?)`)
+	inboundCurrencyAmountPattern = regexp.MustCompile(`(?i)(?:(₺|\$|€|TL|TRY|USD|EUR)\s?(\d{1,3}(?:[.,]\d{3})+(?:[.,]\d{1,2})?|\d+(?:[.,]\d{1,2})?))|(?:(\d{1,3}(?:[.,]\d{3})+(?:[.,]\d{1,2})?|\d+(?:[.,]\d{1,2})?)\s?(₺|\$|€|TL|TRY|USD|EUR)\b)`)
+	inboundDatePattern           = regexp.MustCompile(`\b(\d{1,2})[./-](\d{1,2})[./-](\d{4})\b|\b(\d{4})-(\d{2})-(\d{2})\b`)
+	inboundCurrencyPattern       = regexp.MustCompile(`(?i)(₺|\$|€|\bTL\b|\bTRY\b|\bUSD\b|\bEUR\b)`)
+	inboundSubjectPrefixPattern  = regexp.MustCompile(`(?i)^\s*((fwd?|fw|ilt|ilet|tr|re|ynt)\s*:\s*)+`)
+)
+
+// inboundCurrencies e-postadaki para birimi işaretlerinin ISO kodları
+var inboundCurrencies = map[string]string{
+	"₺": "TRY", "TL": "TRY", "TRY": "TRY",
+	"$": "USD", "USD": "USD",
+	"€": "EUR", "EUR": "EUR",
+}
+
+// ReceiptGuess iletilen e-postadan çıkarılan işlem bilgileri; bulunamayan alanlar sıfır değerde kalır
+type ReceiptGuess struct {
+	Description string
+	Amount      float64
+	Currency    string
+	Date        *time.Time
+}
+
+// InboundEmailDomain iletim adreslerinin alan adını INBOUND_EMAIL_DOMAIN ortam değişkeninden döner
+func InboundEmailDomain() string {
+	if domain := os.Getenv("INBOUND_EMAIL_DOMAIN"); domain != "" {
+		return strings.ToLower(domain)
+	}
+	return "inbox.agri-management.local"
+}
+
+// InboundEmailAddress iletim anahtarından e-posta adresi oluşturur
+func InboundEmailAddress(token string) string {
+	return token + "@" + InboundEmailDomain()
+}
+
+// NewInboundEmailToken tahmin edilemeyen yeni bir iletim anahtarı üretir
+func NewInboundEmailToken() string {
+	return "fis-" + strings.ReplaceAll(utils.GenerateID(), "-", "")[:16]
+}
+
+// InboundEmailTokens alıcı listesindeki adreslerden iletim anahtarlarını çıkarır; "anahtar+etiket" biçimindeki etiketler atlanır
+func InboundEmailTokens(recipients string) []string {
+	var addresses []string
+	if list, err := mail.ParseAddressList(recipients); err == nil {
+		for _, address := range list {
+			addresses = append(addresses, address.Address)
+		}
+	} else {
+		addresses = strings.FieldsFunc(recipients, func(r rune) bool { return r == ',' || r == ';' || r == ' ' })
+	}
+
+	var tokens []string
+	for _, address := range addresses {
+		local, _, found := strings.Cut(strings.ToLower(strings.Trim(address, "<> ")), "@")
+		if !found || local == "" {
+			continue
+		}
+		local, _, _ = strings.Cut(local, "+")
+		tokens = append(tokens, local)
+	}
+	return tokens
+}
+
+// ParseReceiptEmail iletilen e-postanın konu ve metninden tutar, para birimi, tarih ve açıklamayı tahmin eder
+func ParseReceiptEmail(subject, body string) ReceiptGuess {
+	guess := ReceiptGuess{Description: strings.TrimSpace(inboundSubjectPrefixPattern.ReplaceAllString(subject, ""))}
+	text := subject + "\n" + body
+
+	if match := inboundLabeledAmountPattern.FindStringSubmatch(text); match != nil {
+		guess.Amount = parseLocalizedAmount(match[2])
+	}
+	if match := inboundCurrencyAmountPattern.FindStringSubmatch(text); match != nil {
+		if guess.Amount == 0 {
+			guess.Amount = parseLocalizedAmount(match[2] + match[3])
+		}
+	}
+	if match := inboundCurrencyPattern.FindString(text); match != "" {
+		guess.Currency = inboundCurrencies[strings.ToUpper(match)]
+	}
+
+	if match := inboundDatePattern.FindStringSubmatch(text); match != nil {
+		var date time.Time
+		var err error
+		if match[1] != "" {
+			date, err = time.Parse("2-1-2006", match[1]+"-"+match[2]+"-"+match[3])
+		} else {
+			date, err = time.Parse("2006-01-02", match[4]+"-"+match[5]+"-"+match[6])
+		}
+		if err == nil {
+			guess.Date = &date
+		}
+	}
+
+	return guess
+}
+
+// parseLocalizedAmount Türkçe (1.234,56) ve İngilizce (1,234.56) yazılmış tutarları sayıya çevirir
+func parseLocalizedAmount(value string) float64 {
+	value = strings.ReplaceAll(strings.TrimSpace(value), " ", "")
+	separator := strings.LastIndex(value, ".")
+	if comma := strings.LastIndex(value, ","); comma > separator {
+		separator = comma
+	}
+
+	// İki farklı ayırıcı birlikte kullanılmışsa sondaki ondalık ayırıcıdır; tek tür ayırıcıdan sonra
+	// tam üç hane varsa binlik ayırıcı kabul edilir
+	decimal := -1
+	if separator >= 0 {
+		other := ","
+		if value[separator] == ',' {
+			other = "."
+		}
+		if strings.Contains(value[:separator], other) || len(value)-separator-1 != 3 {
+			decimal = separator
+		}
+	}
+
+	var digits strings.Builder
+	for i, r := range value {
+		switch {
+		case r >= '0' && r <= '9':
+			digits.WriteRune(r)
+		case i == decimal:
+			digits.WriteRune('.')
+		}
+	}
+
+	amount, err := strconv.ParseFloat(digits.String(), 64)
+	if err != nil {
+		return 0
+	}
+	return round2(amount)
+}
@@ -0,0 +1,329 @@
+package services
+
+import (
+	"database/sql"
+	"errors"
+	"sort"
+	"strings"
+	"time"
+
+	"agri-management-api/internal/models"
+	"agri-management-api/internal/utils"
+)
+
+var (
+	// ErrEnterpriseNotFound faaliyet kolu bulunamadı
+	ErrEnterpriseNotFound = errors.New("faaliyet kolu bulunamadı")
+	// ErrEnterpriseExists aynı adla faaliyet kolu zaten tanımlı
+	ErrEnterpriseExists = errors.New("faaliyet kolu zaten tanımlı")
+)
+
+// enterpriseSelect faaliyet kolu sütunları ve kola atanmış kayıt sayıları; hayvan sayısı sürüde bulunanları sayar
+const enterpriseSelect = `
+	SELECT e.id, e.name, e.type, COALESCE(e.description, ''),
+	       (SELECT COUNT(*) FROM lands WHERE enterprise_id = e.id),
+	       (SELECT COUNT(*) FROM livestock l WHERE l.enterprise_id = e.id AND ` + inHerdCondition + `),
+	       (SELECT COUNT(*) FROM production WHERE enterprise_id = e.id),
+	       e.created_at, e.updated_at
+	FROM enterprises e`
+
+// transactionEnterprise işlemin faaliyet kolu; işleme doğrudan atanmış kol yoksa işlemi oluşturan üretim satışının
+// ürününden veya arazisinden, hayvan alış/satış, kesim ve hayvan maliyeti kaydının hayvanından, hasat ekibi
+// ödemesinin arazisinden türetilir. t takma adı transactions tablosudur
+const transactionEnterprise = `
+	COALESCE(t.enterprise_id,
+	         (SELECT COALESCE(p.enterprise_id, pl.enterprise_id)
+	          FROM production_sales ps
+	          JOIN production p ON p.id = ps.production_id
+	          LEFT JOIN lands pl ON pl.id = p.land_id
+	          WHERE ps.transaction_id = t.id LIMIT 1),
+	         (SELECT enterprise_id FROM livestock
+	          WHERE user_id = t.user_id AND (sale_transaction_id = t.id OR purchase_transaction_id = t.id) LIMIT 1),
+	         (SELECT lv.enterprise_id FROM slaughter_records sr JOIN livestock lv ON lv.id = sr.livestock_id
+	          WHERE sr.transaction_id = t.id LIMIT 1),
+	         (SELECT lv.enterprise_id FROM livestock_costs lc JOIN livestock lv ON lv.id = lc.livestock_id
+	          WHERE lc.transaction_id = t.id LIMIT 1),
+	         (SELECT ln.enterprise_id FROM harvest_crew_entries hc
+	          JOIN land_activities la ON la.id = hc.activity_id
+	          JOIN lands ln ON ln.id = la.land_id
+	          WHERE hc.transaction_id = t.id LIMIT 1))`
+
+// EnterpriseService çiftliğin faaliyet kollarını (süt, besi, bitkisel üretim, kanatlı) yönetir ve kol bazında
+// kâr/zarar hesaplar. Gelir ve giderler işlemin atandığı veya bağlı kayıtlarından türetilen kola yazılır; ortak
+// gider dağıtımlarında kolun arazi ve hayvanlarına düşen paylar kolun maliyetine eklenir
+type EnterpriseService struct {
+	db          *sql.DB
+	allocations *CostAllocationService
+}
+
+// NewEnterpriseService yeni faaliyet kolu servisi oluşturur
+func NewEnterpriseService(db *sql.DB) *EnterpriseService {
+	return &EnterpriseService{db: db, allocations: NewCostAllocationService(db)}
+}
+
+// List çiftliğin faaliyet kollarını atanmış kayıt sayılarıyla döner
+func (s *EnterpriseService) List(farmID string) ([]models.Enterprise, error) {
+	rows, err := s.db.Query(enterpriseSelect+" WHERE e.user_id = ? ORDER BY e.name", farmID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	enterprises := []models.Enterprise{}
+	for rows.Next() {
+		enterprise, err := scanEnterprise(rows)
+		if err != nil {
+			return nil, err
+		}
+		enterprises = append(enterprises, enterprise)
+	}
+	return enterprises, rows.Err()
+}
+
+// Get faaliyet kolunu döner
+func (s *EnterpriseService) Get(farmID, id string) (*models.Enterprise, error) {
+	enterprise, err := scanEnterprise(s.db.QueryRow(enterpriseSelect+" WHERE e.id = ? AND e.user_id = ?", id, farmID))
+	if err == sql.ErrNoRows {
+		return nil, ErrEnterpriseNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &enterprise, nil
+}
+
+// Exists faaliyet kolunun çiftliğe ait olup olmadığını döner
+func (s *EnterpriseService) Exists(farmID, id string) (bool, error) {
+	var exists bool
+	err := s.db.QueryRow("SELECT 1 FROM enterprises WHERE id = ? AND user_id = ?", id, farmID).Scan(&exists)
+	if err == sql.ErrNoRows {
+		return false, nil
+	}
+	return exists, err
+}
+
+// Create faaliyet kolu ekler
+func (s *EnterpriseService) Create(farmID string, req models.EnterpriseRequest) (*models.Enterprise, error) {
+	name := strings.TrimSpace(req.Name)
+	if err := s.checkName(farmID, "", name); err != nil {
+		return nil, err
+	}
+
+	id := utils.GenerateID()
+	_, err := s.db.Exec(`
+		INSERT INTO enterprises (id, user_id, name, type, description, created_at, updated_at)
+		VALUES (?, ?, ?, ?, ?, CURRENT_TIMESTAMP, CURRENT_TIMESTAMP)
+	`, id, farmID, name, req.Type, req.Description)
+	if err != nil {
+		return nil, err
+	}
+	return s.Get(farmID, id)
+}
+
+// Update faaliyet kolunu günceller
+func (s *EnterpriseService) Update(farmID, id string, req models.EnterpriseRequest) (*models.Enterprise, error) {
+	name := strings.TrimSpace(req.Name)
+	if err := s.checkName(farmID, id, name); err != nil {
+		return nil, err
+	}
+
+	result, err := s.db.Exec(`
+		UPDATE enterprises SET name = ?, type = ?, description = ?, updated_at = CURRENT_TIMESTAMP
+		WHERE id = ? AND user_id = ?
+	`, name, req.Type, req.Description, id, farmID)
+	if err != nil {
+		return nil, err
+	}
+	if rows, _ := result.RowsAffected(); rows == 0 {
+		return nil, ErrEnterpriseNotFound
+	}
+	return s.Get(farmID, id)
+}
+
+// Delete faaliyet kolunu siler; kola atanmış hayvan, arazi, üretim ve işlemlerin ataması kaldırılır
+func (s *EnterpriseService) Delete(farmID, id string) error {
+	tx, err := s.db.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	result, err := tx.Exec("DELETE FROM enterprises WHERE id = ? AND user_id = ?", id, farmID)
+	if err != nil {
+		return err
+	}
+	if rows, _ := result.RowsAffected(); rows == 0 {
+		return ErrEnterpriseNotFound
+	}
+	for _, table := range []string{"livestock", "lands", "production", "transactions"} {
+		if _, err := tx.Exec("UPDATE "+table+" SET enterprise_id = NULL WHERE enterprise_id = ? AND user_id = ?", id, farmID); err != nil {
+			return err
+		}
+	}
+	return tx.Commit()
+}
+
+// PnL faaliyet kollarının [start, end] dönemindeki kâr/zararını kâr sırasıyla döner
+func (s *EnterpriseService) PnL(farmID string, start, end time.Time) (*models.EnterprisePnLReport, error) {
+	enterprises, err := s.List(farmID)
+	if err != nil {
+		return nil, err
+	}
+
+	from, to := start.Format("2006-01-02"), end.Format("2006-01-02")
+	lines := make(map[string]*models.EnterprisePnL, len(enterprises))
+	report := &models.EnterprisePnLReport{
+		StartDate:   from,
+		EndDate:     to,
+		Enterprises: make([]models.EnterprisePnL, 0, len(enterprises)),
+		Unassigned:  models.EnterprisePnL{Name: "Atanmamış"},
+	}
+	for _, enterprise := range enterprises {
+		id := enterprise.ID
+		lines[id] = &models.EnterprisePnL{EnterpriseID: &id, Name: enterprise.Name, Type: enterprise.Type}
+	}
+
+	rows, err := s.db.Query(`
+		SELECT `+transactionEnterprise+`, t.type, COALESCE(t.category, ''), SUM(t.amount)
+		FROM transactions t
+		WHERE t.user_id = ? AND date(t.date) >= ? AND date(t.date) <= ?
+		GROUP BY 1, 2, 3
+	`, farmID, from, to)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var enterpriseID sql.NullString
+		var kind, category string
+		var amount float64
+		if err := rows.Scan(&enterpriseID, &kind, &category, &amount); err != nil {
+			return nil, err
+		}
+		line := &report.Unassigned
+		if enterpriseID.Valid && lines[enterpriseID.String] != nil {
+			line = lines[enterpriseID.String]
+		}
+		switch kind {
+		case "income":
+			line.Income += amount
+			line.IncomeByCategory = addCategoryAmount(line.IncomeByCategory, category, amount)
+		case "expense":
+			line.Expense += amount
+			line.ExpenseByCategory = addCategoryAmount(line.ExpenseByCategory, category, amount)
+		}
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	allocated, err := s.allocations.AllocatedByEnterprise(farmID, from, to)
+	if err != nil {
+		return nil, err
+	}
+	for id, amount := range allocated {
+		if line := lines[id]; line != nil {
+			line.AllocatedCost = amount
+			// Dağıtılan tutar atanmamış ortak giderlerden gelir; kola yazılan kısım atanmamış satırdan düşülür
+			report.Unassigned.AllocatedCost -= amount
+		}
+	}
+
+	for _, enterprise := range enterprises {
+		line := lines[enterprise.ID]
+		finishEnterprisePnL(line)
+		report.TotalIncome += line.Income
+		report.Enterprises = append(report.Enterprises, *line)
+	}
+	finishEnterprisePnL(&report.Unassigned)
+	report.TotalIncome = round2(report.TotalIncome + report.Unassigned.Income)
+
+	var totalCost float64
+	for i := range report.Enterprises {
+		line := &report.Enterprises[i]
+		totalCost += line.Expense + line.AllocatedCost
+		line.ShareOfIncome = share(line.Income, report.TotalIncome)
+	}
+	report.Unassigned.ShareOfIncome = share(report.Unassigned.Income, report.TotalIncome)
+	report.TotalCost = round2(totalCost + report.Unassigned.Expense + report.Unassigned.AllocatedCost)
+	report.TotalProfit = round2(report.TotalIncome - report.TotalCost)
+
+	sort.SliceStable(report.Enterprises, func(i, j int) bool {
+		return report.Enterprises[i].Profit > report.Enterprises[j].Profit
+	})
+	return report, nil
+}
+
+// EnterprisePnL tek faaliyet kolunun [start, end] dönemindeki kâr/zararını kategori dökümüyle döner
+func (s *EnterpriseService) EnterprisePnL(farmID, id string, start, end time.Time) (*models.EnterprisePnL, error) {
+	if _, err := s.Get(farmID, id); err != nil {
+		return nil, err
+	}
+	report, err := s.PnL(farmID, start, end)
+	if err != nil {
+		return nil, err
+	}
+	for _, line := range report.Enterprises {
+		if line.EnterpriseID != nil && *line.EnterpriseID == id {
+			return &line, nil
+		}
+	}
+	return nil, ErrEnterpriseNotFound
+}
+
+// checkName aynı adla başka faaliyet kolu olup olmadığını denetler
+func (s *EnterpriseService) checkName(farmID, id, name string) error {
+	var exists bool
+	err := s.db.QueryRow("SELECT 1 FROM enterprises WHERE user_id = ? AND name = ? AND id != ?", farmID, name, id).Scan(&exists)
+	if err == sql.ErrNoRows {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	return ErrEnterpriseExists
+}
+
+// finishEnterprisePnL tutarları yuvarlar, kâr ve marjı hesaplar, kategori dökümünü tutara göre sıralar
+func finishEnterprisePnL(line *models.EnterprisePnL) {
+	line.Income = round2(line.Income)
+	line.Expense = round2(line.Expense)
+	line.AllocatedCost = round2(line.AllocatedCost)
+	line.Profit = round2(line.Income - line.Expense - line.AllocatedCost)
+	line.Margin = share(line.Profit, line.Income)
+	for _, items := range [][]models.CategoryAmount{line.IncomeByCategory, line.ExpenseByCategory} {
+		for i := range items {
+			items[i].Amount = round2(items[i].Amount)
+		}
+		sort.SliceStable(items, func(i, j int) bool { return items[i].Amount > items[j].Amount })
+	}
+}
+
+// addCategoryAmount kategori dökümüne tutar ekler
+func addCategoryAmount(items []models.CategoryAmount, category string, amount float64) []models.CategoryAmount {
+	for i := range items {
+		if items[i].Category == category {
+			items[i].Amount += amount
+			return items
+		}
+	}
+	return append(items, models.CategoryAmount{Category: category, Amount: amount})
+}
+
+// share part'ın total içindeki yüzdesi; total sıfırsa 0
+func share(part, total float64) float64 {
+	if total == 0 {
+		return 0
+	}
+	return round2(part / total * 100)
+}
+
+// scanEnterprise faaliyet kolu satırını okur
+func scanEnterprise(row interface{ Scan(...interface{}) error }) (models.Enterprise, error) {
+	var enterprise models.Enterprise
+	err := row.Scan(&enterprise.ID, &enterprise.Name, &enterprise.Type, &enterprise.Description,
+		&enterprise.LandCount, &enterprise.LivestockCount, &enterprise.ProductionCount,
+		&enterprise.CreatedAt, &enterprise.UpdatedAt)
+	return enterprise, err
+}
@@ -0,0 +1,799 @@
+package services
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"log"
+	"strconv"
+	"strings"
+	"time"
+
+	"agri-management-api/internal/models"
+	"agri-management-api/internal/utils"
+)
+
+// harvestWindowDays ekimden hesaplanan hasat penceresinin uzunluğu
+const harvestWindowDays = 30
+
+// inseminationRecordTypes beklenen doğum tarihinin hesaplandığı tohumlama/aşım sağlık kaydı türleri
+var inseminationRecordTypes = []string{"insemination", "breeding", "mating", "tohumlama", "aşım"}
+
+// gestationDays hayvan türüne göre ortalama gebelik süresi (gün)
+var gestationDays = map[string]int{
+	"cattle":  283,
+	"buffalo": 310,
+	"sheep":   150,
+	"goat":    150,
+	"horse":   340,
+	"pig":     114,
+}
+
+// ErrEventRuleNotFound bilinmeyen kural anahtarı
+var ErrEventRuleNotFound = errors.New("etkinlik kuralı bulunamadı")
+
+// ruleEvent kuralın bir kayıttan ürettiği etkinlik
+type ruleEvent struct {
+	key         string
+	title       string
+	description string
+	start       time.Time
+	end         *time.Time
+	priority    string
+	entity      models.RelatedEntity
+}
+
+// eventRule kayıtlardaki tarihlerden etkinlik üreten kural; generate bugünden itibaren başlayan etkinlikleri döner
+type eventRule struct {
+	key         string
+	name        string
+	description string
+	source      string
+	eventType   string
+	generate    func(s *EventRuleService, farmID string, today time.Time) ([]ruleEvent, error)
+}
+
+// eventRules kural kataloğu; sıra listeleme sırasıdır
+var eventRules = []eventRule{
+	{
+		key:         models.EventRuleExpectedBirth,
+		name:        "Beklenen doğumlar",
+		description: "Üreme kayıtlarındaki açık gebeliklerin beklenen doğum tarihi; üreme kaydı olmayan tohumlama/aşım sağlık kayıtlarında hayvan türünün gebelik süresiyle hesaplanır",
+		source:      "breeding_records",
+		eventType:   "breeding",
+		generate:    (*EventRuleService).expectedBirths,
+	},
+	{
+		key:         models.EventRuleHealthCheckup,
+		name:        "Sağlık kontrolleri",
+		description: "Sağlık kayıtlarındaki sonraki kontrol tarihi; aynı türde daha yeni kaydı olanlar hariç",
+		source:      "health_records",
+		eventType:   "health",
+		generate:    (*EventRuleService).healthCheckups,
+	},
+	{
+		key:         models.EventRulePlannedPlanting,
+		name:        "Planlanan ekimler",
+		description: "Ekim planlarındaki henüz ekilmemiş ürünlerin planlanan ekim günü",
+		source:      "crop_plans",
+		eventType:   "planting",
+		generate:    (*EventRuleService).plannedPlantings,
+	},
+	{
+		key:         models.EventRuleHarvestWindow,
+		name:        "Hasat pencereleri",
+		description: "Arazideki son ekimden hasat dönemine kadar geçen süreyle hesaplanan hasat penceresi; ekimden sonra hasat yapılmışsa oluşturulmaz. Ekim planlarında planlanan hasat tarihi kullanılır; plana karşılık gelen ekim aktivitesi için ayrıca pencere oluşturulmaz",
+		source:      "land_activities, crop_plans",
+		eventType:   "harvest",
+		generate:    (*EventRuleService).harvestWindows,
+	},
+	{
+		key:         models.EventRuleInstallmentDue,
+		name:        "Taksit ve ödeme vadeleri",
+		description: "Vade tarihi girilmiş bekleyen gider işlemleri (kredi taksitleri, vadeli alımlar)",
+		source:      "transactions",
+		eventType:   "finance",
+		generate:    (*EventRuleService).installmentDues,
+	},
+}
+
+// findEventRule anahtarı verilen kuralı döner
+func findEventRule(key string) (eventRule, bool) {
+	for _, rule := range eventRules {
+		if rule.key == key {
+			return rule, true
+		}
+	}
+	return eventRule{}, false
+}
+
+// ruleSelected kuralın çalıştırılacak kurallar arasında olup olmadığını döner
+func ruleSelected(keys []string, key string) bool {
+	for _, selected := range keys {
+		if selected == key {
+			return true
+		}
+	}
+	return false
+}
+
+// isActivityKind serbest metin aktivite türünün öneri kurallarındaki türe karşılık gelip gelmediğini döner
+func isActivityKind(activityType, kind string) bool {
+	activityType = strings.ToLower(strings.TrimSpace(activityType))
+	for _, alias := range activityAliases[kind] {
+		if activityType == alias {
+			return true
+		}
+	}
+	return false
+}
+
+// EventRuleService modül kayıtlarındaki önemli tarihlerden (beklenen doğum, sağlık kontrolü, hasat penceresi,
+// taksit vadesi) takvim etkinliklerini kurallarla oluşturur ve kaynak kayıt değiştikçe günceller.
+// Oluşturulan etkinlikler generated_events tablosunda kaynak kayda bağlanır: kullanıcının sildiği etkinlik
+// aynı tarih için yeniden oluşturulmaz, düzenlediği etkinlik kilitlenir ve kural tarafından değiştirilmez
+type EventRuleService struct {
+	db    *sql.DB
+	farms *FarmService
+}
+
+// NewEventRuleService yeni event rule service oluşturur
+func NewEventRuleService(db *sql.DB) *EventRuleService {
+	return &EventRuleService{db: db, farms: NewFarmService(db)}
+}
+
+// StartGenerator tüm çiftliklerin kurallarını saatlik olarak arka planda çalıştırır; elle veya içe aktarmayla
+// değişen kayıtlar bu sayede takvime yansır
+func (s *EventRuleService) StartGenerator() {
+	go func() {
+		ticker := time.NewTicker(time.Hour)
+		defer ticker.Stop()
+
+		for {
+			if err := s.SyncAll(); err != nil {
+				log.Printf("Otomatik etkinlikler oluşturulamadı: %v", err)
+			}
+			<-ticker.C
+		}
+	}()
+}
+
+// SyncAll tüm çiftliklerin açık kurallarını çalıştırır
+func (s *EventRuleService) SyncAll() error {
+	rows, err := s.db.Query("SELECT id FROM farms UNION SELECT id FROM users")
+	if err != nil {
+		return err
+	}
+
+	var farmIDs []string
+	for rows.Next() {
+		var farmID string
+		if err := rows.Scan(&farmID); err != nil {
+			continue
+		}
+		farmIDs = append(farmIDs, farmID)
+	}
+	rows.Close()
+
+	for _, farmID := range farmIDs {
+		if _, err := s.Sync(farmID); err != nil {
+			log.Printf("Otomatik etkinlikler oluşturulamadı (%s): %v", farmID, err)
+		}
+	}
+	return nil
+}
+
+// Rules kural kataloğunu çiftlikteki açık/kapalı durumu ve yaklaşan etkinlik sayısıyla döner
+func (s *EventRuleService) Rules(farmID string) ([]models.EventRule, error) {
+	enabled, err := s.enabledRules(farmID)
+	if err != nil {
+		return nil, err
+	}
+
+	counts := map[string]int{}
+	rows, err := s.db.Query(`
+		SELECT g.rule, COUNT(*) FROM generated_events g
+		JOIN events e ON e.id = g.event_id AND e.user_id = g.user_id
+		WHERE g.user_id = ? AND e.status = 'pending' AND date(e.start_date) >= date('now')
+		GROUP BY g.rule
+	`, farmID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var rule string
+		var count int
+		if err := rows.Scan(&rule, &count); err != nil {
+			return nil, err
+		}
+		counts[rule] = count
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	result := make([]models.EventRule, 0, len(eventRules))
+	for _, rule := range eventRules {
+		result = append(result, models.EventRule{
+			Key:            rule.key,
+			Name:           rule.name,
+			Description:    rule.description,
+			Source:         rule.source,
+			EventType:      rule.eventType,
+			Enabled:        enabled[rule.key],
+			UpcomingEvents: counts[rule.key],
+		})
+	}
+	return result, nil
+}
+
+// SetEnabled kuralı çiftlik ayarlarında açar veya kapatır ve kuralı hemen çalıştırır; kapatılan kuralın
+// bekleyen yaklaşan etkinlikleri kaldırılır
+func (s *EventRuleService) SetEnabled(farmID, key string, enabled bool) (*models.EventRuleSyncResult, error) {
+	if _, ok := findEventRule(key); !ok {
+		return nil, ErrEventRuleNotFound
+	}
+
+	settings, err := s.farms.Settings(farmID)
+	if err != nil {
+		return nil, err
+	}
+	if settings.EventRules == nil {
+		settings.EventRules = map[string]bool{}
+	}
+	settings.EventRules[key] = enabled
+	if err := s.farms.SaveSettings(farmID, settings); err != nil {
+		return nil, err
+	}
+
+	return s.Sync(farmID, key)
+}
+
+// Sync verilen kuralları (boşsa tümünü) çiftlik için çalıştırır. Açık kuralların ürettiği etkinlikler
+// oluşturulur veya güncellenir, kaynağı kalmayan bekleyen yaklaşan etkinlikler kaldırılır; kapalı kuralların
+// bekleyen yaklaşan etkinlikleri silinir. Geçmiş ve tamamlanmış etkinliklere dokunulmaz
+func (s *EventRuleService) Sync(farmID string, keys ...string) (*models.EventRuleSyncResult, error) {
+	enabled, err := s.enabledRules(farmID)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &models.EventRuleSyncResult{}
+	today := time.Now().UTC().Truncate(24 * time.Hour)
+	for _, rule := range eventRules {
+		if len(keys) > 0 && !ruleSelected(keys, rule.key) {
+			continue
+		}
+
+		var candidates []ruleEvent
+		if enabled[rule.key] {
+			candidates, err = rule.generate(s, farmID, today)
+			if err != nil {
+				return nil, fmt.Errorf("%s: %w", rule.key, err)
+			}
+		}
+		if err := s.apply(farmID, rule, candidates, today, result); err != nil {
+			return nil, fmt.Errorf("%s: %w", rule.key, err)
+		}
+	}
+	return result, nil
+}
+
+// SyncQuietly kaydı değiştiren istekten sonra ilgili kuralları çalıştırır; hata yalnızca loglanır
+func (s *EventRuleService) SyncQuietly(farmID string, keys ...string) {
+	if _, err := s.Sync(farmID, keys...); err != nil {
+		log.Printf("Otomatik etkinlikler güncellenemedi (%s): %v", farmID, err)
+	}
+}
+
+// Lock kullanıcının düzenlediği etkinliği kilitler; kilitli etkinlik kurallar tarafından güncellenmez ve kaldırılmaz
+func (s *EventRuleService) Lock(farmID, eventID string) error {
+	_, err := s.db.Exec("UPDATE generated_events SET locked = TRUE, updated_at = CURRENT_TIMESTAMP WHERE event_id = ? AND user_id = ?", eventID, farmID)
+	return err
+}
+
+// enabledRules çiftlik ayarlarına göre kuralların açık olup olmadığını döner; ayarlanmamış kurallar açıktır
+func (s *EventRuleService) enabledRules(farmID string) (map[string]bool, error) {
+	settings, err := s.farms.Settings(farmID)
+	if err != nil {
+		return nil, err
+	}
+
+	enabled := map[string]bool{}
+	for _, rule := range eventRules {
+		value, ok := settings.EventRules[rule.key]
+		enabled[rule.key] = !ok || value
+	}
+	return enabled, nil
+}
+
+// generatedEvent kuralın daha önce oluşturduğu etkinliğin bağlantısı
+type generatedEvent struct {
+	eventID string
+	start   time.Time
+	locked  bool
+}
+
+// apply kuralın ürettiği etkinlikleri takvimle eşitler
+func (s *EventRuleService) apply(farmID string, rule eventRule, candidates []ruleEvent, today time.Time, result *models.EventRuleSyncResult) error {
+	rows, err := s.db.Query(`
+		SELECT source_key, event_id, start_date, COALESCE(locked, FALSE)
+		FROM generated_events WHERE user_id = ? AND rule = ?
+	`, farmID, rule.key)
+	if err != nil {
+		return err
+	}
+	existing := map[string]generatedEvent{}
+	for rows.Next() {
+		var key string
+		var link generatedEvent
+		if err := rows.Scan(&key, &link.eventID, &link.start, &link.locked); err != nil {
+			rows.Close()
+			return err
+		}
+		existing[key] = link
+	}
+	rows.Close()
+
+	seen := map[string]bool{}
+	for _, candidate := range candidates {
+		seen[candidate.key] = true
+		link, linked := existing[candidate.key]
+		if linked && link.locked {
+			continue
+		}
+
+		if linked {
+			var status string
+			err := s.db.QueryRow("SELECT status FROM events WHERE id = ? AND user_id = ?", link.eventID, farmID).Scan(&status)
+			switch {
+			case err == sql.ErrNoRows:
+				// Kullanıcı etkinliği silmiş; tarih değişmediyse yeniden oluşturulmaz
+				if link.start.Equal(candidate.start) {
+					continue
+				}
+			case err != nil:
+				return err
+			case status != "pending":
+				continue
+			default:
+				updated, err := s.update(farmID, link.eventID, rule, candidate)
+				if err != nil {
+					return err
+				}
+				if updated {
+					result.Updated++
+				}
+				continue
+			}
+		}
+
+		if err := s.create(farmID, rule, candidate); err != nil {
+			return err
+		}
+		result.Created++
+	}
+
+	for key, link := range existing {
+		if seen[key] || link.locked || link.start.Before(today) {
+			continue
+		}
+		removed, err := s.db.Exec("DELETE FROM events WHERE id = ? AND user_id = ? AND status = 'pending'", link.eventID, farmID)
+		if err != nil {
+			return err
+		}
+		if count, _ := removed.RowsAffected(); count > 0 {
+			result.Removed++
+		}
+		if _, err := s.db.Exec("DELETE FROM generated_events WHERE user_id = ? AND rule = ? AND source_key = ?", farmID, rule.key, key); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// create etkinliği oluşturur ve kaynak kayda bağlar
+func (s *EventRuleService) create(farmID string, rule eventRule, candidate ruleEvent) error {
+	tx, err := s.db.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	eventID := utils.GenerateID()
+	_, err = tx.Exec(`
+		INSERT INTO events (id, user_id, title, description, type, start_date, end_date, is_all_day, status, priority,
+		                   related_entity_type, related_entity_id, created_at, updated_at)
+		VALUES (?, ?, ?, ?, ?, ?, ?, TRUE, 'pending', ?, ?, ?, CURRENT_TIMESTAMP, CURRENT_TIMESTAMP)
+	`, eventID, farmID, candidate.title, candidate.description, rule.eventType, candidate.start, candidate.end,
+		candidate.priority, candidate.entity.Type, candidate.entity.ID)
+	if err != nil {
+		return err
+	}
+
+	_, err = tx.Exec(`
+		INSERT INTO generated_events (user_id, rule, source_key, event_id, start_date, locked, created_at, updated_at)
+		VALUES (?, ?, ?, ?, ?, FALSE, CURRENT_TIMESTAMP, CURRENT_TIMESTAMP)
+		ON CONFLICT (user_id, rule, source_key) DO UPDATE SET event_id = excluded.event_id, start_date = excluded.start_date,
+		    updated_at = CURRENT_TIMESTAMP
+	`, farmID, rule.key, candidate.key, eventID, candidate.start)
+	if err != nil {
+		return err
+	}
+	return tx.Commit()
+}
+
+// update bekleyen etkinliği kaynak kayıttaki değerlerle günceller; değişiklik varsa true döner
+func (s *EventRuleService) update(farmID, eventID string, rule eventRule, candidate ruleEvent) (bool, error) {
+	result, err := s.db.Exec(`
+		UPDATE events SET title = ?, description = ?, start_date = ?, end_date = ?, priority = ?, updated_at = CURRENT_TIMESTAMP
+		WHERE id = ? AND user_id = ? AND status = 'pending'
+		  AND (title IS NOT ? OR description IS NOT ? OR datetime(start_date) IS NOT datetime(?)
+		       OR datetime(end_date) IS NOT datetime(?) OR priority IS NOT ?)
+	`, candidate.title, candidate.description, candidate.start, candidate.end, candidate.priority, eventID, farmID,
+		candidate.title, candidate.description, candidate.start, candidate.end, candidate.priority)
+	if err != nil {
+		return false, err
+	}
+	updated, _ := result.RowsAffected()
+
+	_, err = s.db.Exec(`
+		UPDATE generated_events SET start_date = ?, updated_at = CURRENT_TIMESTAMP
+		WHERE user_id = ? AND rule = ? AND source_key = ?
+	`, candidate.start, farmID, rule.key, candidate.key)
+	return updated > 0, err
+}
+
+// expectedBirths hayvanın son üreme kaydındaki açık gebelikten beklenen doğumları üretir; üreme kaydından daha
+// yeni tohumlama/aşım sağlık kaydı olan hayvanlarda doğum tarihi türün gebelik süresiyle hesaplanır
+func (s *EventRuleService) expectedBirths(farmID string, today time.Time) ([]ruleEvent, error) {
+	events, lastService, err := s.breedingBirths(farmID, today)
+	if err != nil {
+		return nil, err
+	}
+
+	placeholders := strings.TrimSuffix(strings.Repeat("?,", len(inseminationRecordTypes)), ",")
+	args := []interface{}{farmID}
+	for _, recordType := range inseminationRecordTypes {
+		args = append(args, recordType)
+	}
+
+	rows, err := s.db.Query(`
+		SELECT r.id, r.date, l.id, l.tag_number, l.type
+		FROM health_records r
+		JOIN livestock l ON l.id = r.livestock_id
+		WHERE l.user_id = ? AND l.sale_date IS NULL AND lower(r.type) IN (`+placeholders+`)
+		ORDER BY r.date DESC, r.created_at DESC
+	`, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	latest := map[string]bool{}
+	for rows.Next() {
+		var recordID, animalID, tag, animalType string
+		var date time.Time
+		if err := rows.Scan(&recordID, &date, &animalID, &tag, &animalType); err != nil {
+			return nil, err
+		}
+		if latest[animalID] {
+			continue
+		}
+		latest[animalID] = true
+		if serviceDate, ok := lastService[animalID]; ok && !date.After(serviceDate) {
+			continue
+		}
+
+		days, ok := gestationDays[strings.ToLower(animalType)]
+		if !ok {
+			continue
+		}
+		due := date.UTC().Truncate(24*time.Hour).AddDate(0, 0, days)
+		if due.Before(today) {
+			continue
+		}
+
+		events = append(events, ruleEvent{
+			key:         recordID,
+			title:       "Beklenen doğum - " + tag,
+			description: fmt.Sprintf("%s tarihli tohumlama/aşım kaydına göre %d günlük gebelik süresiyle hesaplandı", date.Format("02.01.2006"), days),
+			start:       due,
+			priority:    "high",
+			entity:      models.RelatedEntity{Type: "livestock", ID: animalID, Name: tag},
+		})
+	}
+	return events, rows.Err()
+}
+
+// breedingBirths hayvanların son üreme kaydı gebelik bekleniyor veya doğrulanmışsa beklenen doğum etkinliği üretir;
+// son üreme kaydının tohumlama tarihini hayvan bazında döner
+func (s *EventRuleService) breedingBirths(farmID string, today time.Time) ([]ruleEvent, map[string]time.Time, error) {
+	rows, err := s.db.Query(`
+		SELECT b.id, b.service_date, b.pregnancy_status, b.expected_birth_date, l.id, l.tag_number
+		FROM breeding_records b
+		JOIN livestock l ON l.id = b.livestock_id
+		WHERE l.user_id = ? AND l.sale_date IS NULL
+		ORDER BY b.service_date DESC, b.created_at DESC
+	`, farmID)
+	if err != nil {
+		return nil, nil, err
+	}
+	defer rows.Close()
+
+	var events []ruleEvent
+	lastService := map[string]time.Time{}
+	for rows.Next() {
+		var recordID, status, animalID, tag string
+		var serviceDate time.Time
+		var expected sql.NullTime
+		if err := rows.Scan(&recordID, &serviceDate, &status, &expected, &animalID, &tag); err != nil {
+			return nil, nil, err
+		}
+		if _, ok := lastService[animalID]; ok {
+			continue
+		}
+		lastService[animalID] = serviceDate
+		if !expected.Valid || !ruleSelected(openPregnancyStatuses, status) {
+			continue
+		}
+
+		due := expected.Time.UTC().Truncate(24 * time.Hour)
+		if due.Before(today) {
+			continue
+		}
+		description := serviceDate.Format("02.01.2006") + " tarihli tohumlama/aşım"
+		if status == models.PregnancyStatusConfirmed {
+			description += ", gebelik doğrulandı"
+		}
+		events = append(events, ruleEvent{
+			key:         recordID,
+			title:       "Beklenen doğum - " + tag,
+			description: description,
+			start:       due,
+			priority:    "high",
+			entity:      models.RelatedEntity{Type: "livestock", ID: animalID, Name: tag},
+		})
+	}
+	return events, lastService, rows.Err()
+}
+
+// healthCheckups sağlık kayıtlarındaki sonraki kontrol tarihlerinden etkinlik üretir
+func (s *EventRuleService) healthCheckups(farmID string, today time.Time) ([]ruleEvent, error) {
+	rows, err := s.db.Query(`
+		SELECT r.id, r.type, COALESCE(r.description, ''), r.next_checkup, l.id, l.tag_number
+		FROM health_records r
+		JOIN livestock l ON l.id = r.livestock_id
+		WHERE l.user_id = ? AND l.sale_date IS NULL AND r.next_checkup IS NOT NULL AND date(r.next_checkup) >= ?
+		  AND NOT EXISTS (
+		      SELECT 1 FROM health_records n
+		      WHERE n.livestock_id = r.livestock_id AND n.type = r.type AND date(n.date) > date(r.date)
+		  )
+	`, farmID, today.Format("2006-01-02"))
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var events []ruleEvent
+	for rows.Next() {
+		var recordID, recordType, description, animalID, tag string
+		var nextCheckup time.Time
+		if err := rows.Scan(&recordID, &recordType, &description, &nextCheckup, &animalID, &tag); err != nil {
+			return nil, err
+		}
+		events = append(events, ruleEvent{
+			key:         recordID,
+			title:       "Sağlık kontrolü - " + tag,
+			description: strings.TrimSpace(recordType + ": " + description),
+			start:       nextCheckup.UTC().Truncate(24 * time.Hour),
+			priority:    "medium",
+			entity:      models.RelatedEntity{Type: "livestock", ID: animalID, Name: tag},
+		})
+	}
+	return events, rows.Err()
+}
+
+// harvestWindows arazideki son ekimden hasat dönemine geçiş gününden başlayan hasat pencereleri ve ekim planlarının
+// planlanan hasatlarını üretir; ekim tarihi bir plana yakın olan ekim aktivitesi için ayrıca pencere üretilmez
+func (s *EventRuleService) harvestWindows(farmID string, today time.Time) ([]ruleEvent, error) {
+	events, planned, err := s.plannedHarvests(farmID, today)
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := s.db.Query(`
+		SELECT a.id, a.type, date(COALESCE(a.actual_date, a.scheduled_date)), l.id, l.name
+		FROM land_activities a
+		JOIN lands l ON l.id = a.land_id
+		WHERE l.user_id = ? AND COALESCE(a.actual_date, a.scheduled_date) IS NOT NULL
+		ORDER BY COALESCE(a.actual_date, a.scheduled_date) DESC
+	`, farmID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	done := map[string]bool{}
+	harvested := map[string]bool{}
+	for rows.Next() {
+		var activityID, activityType, day, landID, landName string
+		if err := rows.Scan(&activityID, &activityType, &day, &landID, &landName); err != nil {
+			return nil, err
+		}
+		planted, err := time.Parse("2006-01-02", day)
+		if err != nil {
+			continue
+		}
+		if done[landID] {
+			continue
+		}
+		if IsHarvestActivity(activityType) {
+			harvested[landID] = true
+			continue
+		}
+		if !isActivityKind(activityType, ActivityPlanting) {
+			continue
+		}
+		done[landID] = true
+		if harvested[landID] || plannedNear(planned[landID], planted) {
+			continue
+		}
+
+		start := planted.AddDate(0, 0, cropHarvestStageDay)
+		end := start.AddDate(0, 0, harvestWindowDays)
+		if end.Before(today) {
+			continue
+		}
+
+		events = append(events, ruleEvent{
+			key:         activityID,
+			title:       "Hasat penceresi - " + landName,
+			description: fmt.Sprintf("%s tarihli ekime göre hasat dönemi", planted.Format("02.01.2006")),
+			start:       start,
+			end:         &end,
+			priority:    "medium",
+			entity:      models.RelatedEntity{Type: "land", ID: landID, Name: landName},
+		})
+	}
+	return events, rows.Err()
+}
+
+// plannedPlantings ekim planlarındaki planlanan ekimlerden etkinlik üretir
+func (s *EventRuleService) plannedPlantings(farmID string, today time.Time) ([]ruleEvent, error) {
+	rows, err := s.db.Query(`
+		SELECT p.id, p.season, p.crop, COALESCE(p.variety, ''), date(p.planting_date), l.id, l.name
+		FROM crop_plans p
+		JOIN lands l ON l.id = p.land_id AND l.user_id = p.user_id
+		WHERE p.user_id = ? AND p.status = ? AND date(p.planting_date) >= ?
+	`, farmID, models.CropPlanStatusPlanned, today.Format("2006-01-02"))
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var events []ruleEvent
+	for rows.Next() {
+		var planID, season, crop, variety, day, landID, landName string
+		if err := rows.Scan(&planID, &season, &crop, &variety, &day, &landID, &landName); err != nil {
+			return nil, err
+		}
+		planting, err := time.Parse("2006-01-02", day)
+		if err != nil {
+			continue
+		}
+		description := season + " sezonu ekim planı: " + crop
+		if variety != "" {
+			description += " (" + variety + ")"
+		}
+		events = append(events, ruleEvent{
+			key:         planID,
+			title:       "Ekim - " + landName + ": " + crop,
+			description: description,
+			start:       planting,
+			priority:    "medium",
+			entity:      models.RelatedEntity{Type: "land", ID: landID, Name: landName},
+		})
+	}
+	return events, rows.Err()
+}
+
+// plannedHarvests ekilmeyi veya hasadı bekleyen ekim planlarından hasat etkinlikleri üretir. Hasat tarihi girilen
+// planlarda etkinlik o gündür; girilmeyenlerde ekimden hesaplanan hasat penceresi kullanılır. Planların ekim
+// tarihlerini arazi bazında döner
+func (s *EventRuleService) plannedHarvests(farmID string, today time.Time) ([]ruleEvent, map[string][]time.Time, error) {
+	rows, err := s.db.Query(`
+		SELECT p.id, p.season, p.crop, date(p.planting_date), date(p.harvest_date), l.id, l.name
+		FROM crop_plans p
+		JOIN lands l ON l.id = p.land_id AND l.user_id = p.user_id
+		WHERE p.user_id = ? AND p.status IN (?, ?)
+	`, farmID, models.CropPlanStatusPlanned, models.CropPlanStatusPlanted)
+	if err != nil {
+		return nil, nil, err
+	}
+	defer rows.Close()
+
+	var events []ruleEvent
+	planned := map[string][]time.Time{}
+	for rows.Next() {
+		var planID, season, crop, day, landID, landName string
+		var harvestDay sql.NullString
+		if err := rows.Scan(&planID, &season, &crop, &day, &harvestDay, &landID, &landName); err != nil {
+			return nil, nil, err
+		}
+		planting, err := time.Parse("2006-01-02", day)
+		if err != nil {
+			continue
+		}
+		planned[landID] = append(planned[landID], planting)
+
+		start := planting.AddDate(0, 0, cropHarvestStageDay)
+		end := start.AddDate(0, 0, harvestWindowDays)
+		window := &end
+		if harvest, err := time.Parse("2006-01-02", harvestDay.String); err == nil {
+			start, end, window = harvest, harvest, nil
+		}
+		if end.Before(today) {
+			continue
+		}
+
+		events = append(events, ruleEvent{
+			key:         "crop_plan:" + planID,
+			title:       "Hasat - " + landName + ": " + crop,
+			description: fmt.Sprintf("%s sezonu ekim planına göre hasat (%s tarihli ekim)", season, planting.Format("02.01.2006")),
+			start:       start,
+			end:         window,
+			priority:    "medium",
+			entity:      models.RelatedEntity{Type: "land", ID: landID, Name: landName},
+		})
+	}
+	return events, planned, rows.Err()
+}
+
+// plannedNear ekim tarihinin arazideki bir ekim planına hasat penceresi süresinden daha yakın olup olmadığını döner
+func plannedNear(plantings []time.Time, planted time.Time) bool {
+	for _, planting := range plantings {
+		days := planted.Sub(planting).Hours() / 24
+		if days > -harvestWindowDays && days < harvestWindowDays {
+			return true
+		}
+	}
+	return false
+}
+
+// installmentDues vade tarihi girilmiş bekleyen gider işlemlerinden ödeme günü etkinlikleri üretir
+func (s *EventRuleService) installmentDues(farmID string, today time.Time) ([]ruleEvent, error) {
+	rows, err := s.db.Query(`
+		SELECT id, COALESCE(description, ''), category, amount, COALESCE(NULLIF(currency, ''), 'TRY'), due_date
+		FROM transactions
+		WHERE user_id = ? AND type = 'expense' AND status = 'pending' AND due_date IS NOT NULL AND date(due_date) >= ?
+	`, farmID, today.Format("2006-01-02"))
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var events []ruleEvent
+	for rows.Next() {
+		var id, description, category, currency string
+		var amount float64
+		var due time.Time
+		if err := rows.Scan(&id, &description, &category, &amount, &currency, &due); err != nil {
+			return nil, err
+		}
+		name := description
+		if name == "" {
+			name = category
+		}
+		events = append(events, ruleEvent{
+			key:         id,
+			title:       "Ödeme vadesi - " + name,
+			description: fmt.Sprintf("%s: %s %s", category, strconv.FormatFloat(amount, 'f', 2, 64), currency),
+			start:       due.UTC().Truncate(24 * time.Hour),
+			priority:    "high",
+			entity:      models.RelatedEntity{Type: "transaction", ID: id, Name: name},
+		})
+	}
+	return events, rows.Err()
+}
@@ -0,0 +1,283 @@
+package services
+
+import (
+	"database/sql"
+	"sort"
+	"time"
+
+	"agri-management-api/internal/models"
+	"agri-management-api/internal/utils"
+)
+
+// FarmService hesaba bağlı çiftlikleri ve çiftlik bazlı ayarları yönetir.
+// Varsayılan çiftliğin kimliği hesap kimliğiyle aynıdır; böylece mevcut kayıtlar varsayılan çiftliğe aittir.
+// Diğer çiftliklerin kayıtları user_id sütununda çiftlik kimliğiyle saklanır
+type FarmService struct {
+	db *sql.DB
+}
+
+// NewFarmService yeni farm service oluşturur
+func NewFarmService(db *sql.DB) *FarmService {
+	return &FarmService{db: db}
+}
+
+// DefaultSettings çiftlik ayarları girilmemişse kullanılan varsayılan ayarlar
+func DefaultSettings() models.Settings {
+	return models.Settings{
+		General: models.GeneralSettings{
+			Language:           "tr",
+			Currency:           "TRY",
+			DateFormat:         "DD/MM/YYYY",
+			TimeFormat:         TimeFormat24H,
+			DecimalSeparator:   ",",
+			ThousandsSeparator: ".",
+			Units: models.UnitSettings{
+				Area:   "dönüm",
+				Weight: "kg",
+				Volume: "litre",
+			},
+		},
+		Notifications: models.NotificationSettings{
+			Push:  true,
+			Email: true,
+			SMS:   false,
+		},
+		Privacy: models.PrivacySettings{
+			LocationSharing: true,
+			DataAnalytics:   true,
+			PersonalizedAds: false,
+		},
+		Backup: models.BackupSettings{
+			AutoBackup:      true,
+			BackupFrequency: "weekly",
+			CloudStorage:    true,
+			RetentionCount:  10,
+			RetentionDays:   90,
+		},
+		Fiscal: models.FiscalSettings{
+			YearStartMonth: 1,
+			Periods:        []models.FiscalPeriod{},
+		},
+		LandArea: models.LandAreaSettings{
+			TolerancePercent: DefaultLandAreaTolerancePercent,
+		},
+	}
+}
+
+// EnsureDefaultFarm hesabın varsayılan çiftliğini profildeki çiftlik adı ve konumla yoksa oluşturur
+func (s *FarmService) EnsureDefaultFarm(accountID string) error {
+	_, err := s.db.Exec(`
+		INSERT OR IGNORE INTO farms (id, user_id, name, location, is_default, created_at, updated_at)
+		SELECT id, id, COALESCE(NULLIF(farm_name, ''), name), COALESCE(location, ''), TRUE, created_at, CURRENT_TIMESTAMP
+		FROM users WHERE id = ?
+	`, accountID)
+	return err
+}
+
+// Owns çiftliğin hesaba ait olup olmadığını döner
+func (s *FarmService) Owns(accountID, farmID string) (bool, error) {
+	var exists bool
+	err := s.db.QueryRow("SELECT 1 FROM farms WHERE id = ? AND user_id = ?", farmID, accountID).Scan(&exists)
+	if err == sql.ErrNoRows {
+		return false, nil
+	}
+	return exists, err
+}
+
+// Settings çiftliğin ayarlarını döner; kayıtlı ayar yoksa varsayılanlar kullanılır
+func (s *FarmService) Settings(farmID string) (models.Settings, error) {
+	settings := DefaultSettings()
+
+	var raw sql.NullString
+	err := s.db.QueryRow("SELECT settings FROM farms WHERE id = ?", farmID).Scan(&raw)
+	if err != nil && err != sql.ErrNoRows {
+		return settings, err
+	}
+	if raw.Valid && raw.String != "" {
+		if err := utils.FromJSON(raw.String, &settings); err != nil {
+			return DefaultSettings(), nil
+		}
+	}
+	return settings, nil
+}
+
+// SaveSettings çiftliğin ayarlarını kaydeder
+func (s *FarmService) SaveSettings(farmID string, settings models.Settings) error {
+	raw, err := utils.ToJSON(settings)
+	if err != nil {
+		return err
+	}
+	_, err = s.db.Exec("UPDATE farms SET settings = ?, updated_at = CURRENT_TIMESTAMP WHERE id = ?", raw, farmID)
+	return err
+}
+
+// Summary çiftlik seçicide gösterilen özet istatistikleri hesaplar
+func (s *FarmService) Summary(farmID string) (models.FarmSummary, error) {
+	var summary models.FarmSummary
+
+	hectares, err := NewCarbonService(s.db).hectares(farmID)
+	if err != nil {
+		return summary, err
+	}
+	summary.Hectares = round2(hectares)
+
+	now := time.Now()
+	monthStart := time.Date(now.Year(), now.Month(), 1, 0, 0, 0, 0, time.UTC)
+
+	err = s.db.QueryRow(`
+		SELECT (SELECT COUNT(*) FROM lands WHERE user_id = ?),
+		       (SELECT COUNT(*) FROM livestock WHERE user_id = ? AND sale_date IS NULL),
+		       (SELECT COUNT(*) FROM production WHERE user_id = ? AND status = 'active'),
+		       (SELECT COALESCE(SUM(amount), 0) FROM transactions WHERE user_id = ? AND type = 'income' AND date >= ?),
+		       (SELECT COALESCE(SUM(amount), 0) FROM transactions WHERE user_id = ? AND type = 'expense' AND date >= ?),
+		       (SELECT COUNT(*) FROM notifications WHERE user_id = ? AND is_read = FALSE)
+	`, farmID, farmID, farmID, farmID, monthStart, farmID, monthStart, farmID).Scan(
+		&summary.Lands, &summary.Livestock, &summary.ActiveProductions,
+		&summary.MonthIncome, &summary.MonthExpense, &summary.UnreadNotifications,
+	)
+	if err != nil {
+		return summary, err
+	}
+
+	summary.MonthIncome = round2(summary.MonthIncome)
+	summary.MonthExpense = round2(summary.MonthExpense)
+	summary.MonthNet = round2(summary.MonthIncome - summary.MonthExpense)
+	return summary, nil
+}
+
+// Compare hesabın tüm çiftliklerini [startDate, endDate] dönemindeki üretim, gelir-gider ve kârlılık
+// metrikleriyle karşılaştırır; çiftlikler net kâra göre sıralanır
+func (s *FarmService) Compare(accountID string, startDate, endDate time.Time) (models.FarmComparison, error) {
+	comparison := models.FarmComparison{StartDate: startDate, EndDate: endDate, Farms: []models.FarmComparisonItem{}}
+	start, end := startDate.Format("2006-01-02"), endDate.Format("2006-01-02")
+
+	rows, err := s.db.Query("SELECT id, name, is_default FROM farms WHERE user_id = ? ORDER BY is_default DESC, name", accountID)
+	if err != nil {
+		return comparison, err
+	}
+	for rows.Next() {
+		var item models.FarmComparisonItem
+		if err := rows.Scan(&item.FarmID, &item.FarmName, &item.IsDefault); err != nil {
+			rows.Close()
+			return comparison, err
+		}
+		comparison.Farms = append(comparison.Farms, item)
+	}
+	rows.Close()
+
+	for i := range comparison.Farms {
+		if err := s.fillComparison(&comparison.Farms[i], start, end); err != nil {
+			return comparison, err
+		}
+	}
+
+	totals := &comparison.Totals
+	for _, item := range comparison.Farms {
+		totals.Hectares += item.Hectares
+		totals.ProductionValue += item.ProductionValue
+		totals.Income += item.Income
+		totals.Expense += item.Expense
+	}
+	totals.NetProfit = totals.Income - totals.Expense
+	if totals.Income > 0 {
+		totals.ProfitMargin = round2(totals.NetProfit / totals.Income * 100)
+		for i := range comparison.Farms {
+			comparison.Farms[i].ShareOfTotalIncome = round2(comparison.Farms[i].Income / totals.Income * 100)
+		}
+	}
+	totals.Hectares = round2(totals.Hectares)
+	totals.ProductionValue = round2(totals.ProductionValue)
+	totals.Income = round2(totals.Income)
+	totals.Expense = round2(totals.Expense)
+	totals.NetProfit = round2(totals.NetProfit)
+
+	sort.SliceStable(comparison.Farms, func(i, j int) bool {
+		return comparison.Farms[i].ProfitPerHectare > comparison.Farms[j].ProfitPerHectare
+	})
+	for i := range comparison.Farms {
+		comparison.Farms[i].ProfitPerHectareRank = i + 1
+	}
+	sort.SliceStable(comparison.Farms, func(i, j int) bool {
+		return comparison.Farms[i].NetProfit > comparison.Farms[j].NetProfit
+	})
+	for i := range comparison.Farms {
+		comparison.Farms[i].ProfitRank = i + 1
+	}
+
+	return comparison, nil
+}
+
+// fillComparison çiftliğin dönem metriklerini hesaplar; hasat tarihi olmayan üretim kayıtları oluşturulma tarihine göre dönemlenir
+func (s *FarmService) fillComparison(item *models.FarmComparisonItem, start, end string) error {
+	hectares, err := NewCarbonService(s.db).hectares(item.FarmID)
+	if err != nil {
+		return err
+	}
+	item.Hectares = round2(hectares)
+
+	item.ProductionByUnit = map[string]float64{}
+	rows, err := s.db.Query(`
+		SELECT unit, COUNT(*), COALESCE(SUM(amount), 0), COALESCE(SUM(amount * COALESCE(price, 0)), 0)
+		FROM production
+		WHERE user_id = ? AND date(COALESCE(harvest_date, created_at)) BETWEEN ? AND ?
+		GROUP BY unit
+	`, item.FarmID, start, end)
+	if err != nil {
+		return err
+	}
+	for rows.Next() {
+		var unit string
+		var count int
+		var amount, value float64
+		if err := rows.Scan(&unit, &count, &amount, &value); err != nil {
+			rows.Close()
+			return err
+		}
+		item.ProductionRecords += count
+		item.ProductionByUnit[unit] = round2(amount)
+		item.ProductionValue += value
+	}
+	rows.Close()
+	item.ProductionValue = round2(item.ProductionValue)
+
+	item.ExpenseByCategory = []models.CategoryAmount{}
+	rows, err = s.db.Query(`
+		SELECT type, category, SUM(amount)
+		FROM transactions
+		WHERE user_id = ? AND date(date) BETWEEN ? AND ?
+		GROUP BY type, category
+		ORDER BY SUM(amount) DESC
+	`, item.FarmID, start, end)
+	if err != nil {
+		return err
+	}
+	for rows.Next() {
+		var txType, category string
+		var amount float64
+		if err := rows.Scan(&txType, &category, &amount); err != nil {
+			rows.Close()
+			return err
+		}
+		switch txType {
+		case "income":
+			item.Income += amount
+		case "expense":
+			item.Expense += amount
+			item.ExpenseByCategory = append(item.ExpenseByCategory, models.CategoryAmount{Category: category, Amount: round2(amount)})
+		}
+	}
+	rows.Close()
+
+	item.NetProfit = item.Income - item.Expense
+	if item.Income > 0 {
+		item.ProfitMargin = round2(item.NetProfit / item.Income * 100)
+	}
+	if hectares > 0 {
+		item.CostPerHectare = round2(item.Expense / hectares)
+		item.ProfitPerHectare = round2(item.NetProfit / hectares)
+	}
+	item.Income = round2(item.Income)
+	item.Expense = round2(item.Expense)
+	item.NetProfit = round2(item.NetProfit)
+	return nil
+}
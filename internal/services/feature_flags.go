@@ -0,0 +1,158 @@
+package services
+
+import (
+	"database/sql"
+	"hash/fnv"
+	"os"
+	"strconv"
+	"strings"
+
+	"agri-management-api/internal/models"
+)
+
+// Özellik bayrağı anahtarları
+const (
+	FlagMockWeather = "mock_weather"
+	FlagMockReports = "mock_reports"
+)
+
+// defaultFlags kod içinde tanımlı varsayılan bayraklar
+var defaultFlags = []models.FeatureFlag{
+	{
+		Key:             FlagMockWeather,
+		Description:     "Hava durumu sağlayıcısına ulaşılamadığında örnek veri döndürür",
+		Enabled:         true,
+		Deprecated:      true,
+		DeprecationNote: "Örnek hava durumu verisi kaldırılacak, gerçek sağlayıcı verisi kullanılmalı",
+	},
+	{
+		Key:             FlagMockReports,
+		Description:     "Rapor uç noktalarında örnek veri döndürür",
+		Enabled:         true,
+		Deprecated:      true,
+		DeprecationNote: "Örnek rapor verisi kaldırılacak, gerçek rapor motoru kullanılmalı",
+	},
+}
+
+// FeatureFlagService özellik bayraklarını ortam değişkenleri ve veritabanından çözer
+type FeatureFlagService struct {
+	db          *sql.DB
+	environment string
+}
+
+// NewFeatureFlagService yeni feature flag servisi oluşturur
+func NewFeatureFlagService(db *sql.DB) *FeatureFlagService {
+	environment := os.Getenv("ENV")
+	if environment == "" {
+		environment = "development"
+	}
+
+	return &FeatureFlagService{
+		db:          db,
+		environment: environment,
+	}
+}
+
+// IsEnabled bayrağın kullanıcı için açık olup olmadığını döner
+func (s *FeatureFlagService) IsEnabled(key, userID string) bool {
+	for _, flag := range s.List(userID) {
+		if flag.Key == key {
+			return flag.Enabled
+		}
+	}
+	return false
+}
+
+// Get tek bir bayrağın kullanıcı için çözülmüş durumunu döner
+func (s *FeatureFlagService) Get(key, userID string) (models.FeatureFlag, bool) {
+	for _, flag := range s.List(userID) {
+		if flag.Key == key {
+			return flag, true
+		}
+	}
+	return models.FeatureFlag{}, false
+}
+
+// List tüm bayrakların kullanıcı için çözülmüş durumlarını döner
+// Öncelik sırası: varsayılan < ortam değişkeni (FEATURE_<KEY>) < veritabanı; ortam değişkeni yalnızca
+// veritabanında tanımı olmayan bayrakların varsayılanını değiştirir, kohort dağıtımlarını ezmez
+func (s *FeatureFlagService) List(userID string) []models.FeatureFlag {
+	flags := make([]models.FeatureFlag, 0, len(defaultFlags))
+	index := map[string]int{}
+
+	for _, flag := range defaultFlags {
+		flag.Source = "default"
+		if value := os.Getenv("FEATURE_" + strings.ToUpper(flag.Key)); value != "" {
+			if enabled, err := strconv.ParseBool(value); err == nil {
+				flag.Enabled = enabled
+				flag.Source = "env"
+			}
+		}
+		index[flag.Key] = len(flags)
+		flags = append(flags, flag)
+	}
+
+	// Veritabanı tanımları (tablo yoksa varsayılanlarla devam edilir)
+	rows, err := s.db.Query(`
+		SELECT key, COALESCE(description, ''), enabled, COALESCE(environments, ''),
+		       rollout_percentage, deprecated, COALESCE(deprecation_note, '')
+		FROM feature_flags
+	`)
+	if err == nil {
+		defer rows.Close()
+		for rows.Next() {
+			var flag models.FeatureFlag
+			var environments string
+			var rollout int
+
+			err := rows.Scan(&flag.Key, &flag.Description, &flag.Enabled, &environments,
+				&rollout, &flag.Deprecated, &flag.DeprecationNote)
+			if err != nil {
+				continue
+			}
+
+			flag.Enabled = flag.Enabled && s.matchesEnvironment(environments) && inRollout(flag.Key, userID, rollout)
+			flag.Source = "db"
+
+			if i, exists := index[flag.Key]; exists {
+				if flag.Description == "" {
+					flag.Description = flags[i].Description
+				}
+				flags[i] = flag
+			} else {
+				index[flag.Key] = len(flags)
+				flags = append(flags, flag)
+			}
+		}
+	}
+
+	return flags
+}
+
+// matchesEnvironment bayrağın mevcut ortamda geçerli olup olmadığını kontrol eder
+func (s *FeatureFlagService) matchesEnvironment(environments string) bool {
+	if strings.TrimSpace(environments) == "" {
+		return true
+	}
+
+	for _, env := range strings.Split(environments, ",") {
+		if strings.TrimSpace(env) == s.environment {
+			return true
+		}
+	}
+	return false
+}
+
+// inRollout kullanıcının yüzdelik kohorta dahil olup olmadığını hesaplar
+func inRollout(key, userID string, percentage int) bool {
+	if percentage >= 100 {
+		return true
+	}
+	if percentage <= 0 || userID == "" {
+		return false
+	}
+
+	h := fnv.New32a()
+	h.Write([]byte(key + ":" + userID))
+	return int(h.Sum32()%100) < percentage
+}
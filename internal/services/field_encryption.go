@@ -0,0 +1,529 @@
+package services
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"database/sql"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	"agri-management-api/internal/models"
+	"agri-management-api/internal/utils"
+)
+
+// encryptedValuePrefix şifrelenmiş alan değerlerinin başlangıcı; biçim enc:v1:<veri anahtarı>:<base64(nonce|şifreli metin)>
+const encryptedValuePrefix = "enc:v1:"
+
+// EncryptedColumn uygulama düzeyinde şifrelenerek saklanan sütun
+type EncryptedColumn struct {
+	Table  string
+	Column string
+}
+
+// Name sütunun tablo.sütun biçimindeki adı; şifreli metin bu ada bağlanır (ek doğrulanmış veri)
+func (c EncryptedColumn) Name() string {
+	return c.Table + "." + c.Column
+}
+
+// Şifrelenen sütunlar
+var (
+	ColumnBankAccountNumber   = EncryptedColumn{"bank_accounts", "account_number"}
+	ColumnBuyerTaxID          = EncryptedColumn{"production_sales", "buyer_tax_id"}
+	ColumnBuyerAddress        = EncryptedColumn{"production_sales", "buyer_address"}
+	ColumnCustomerTaxID       = EncryptedColumn{"customers", "tax_id"}
+	ColumnCustomerAddress     = EncryptedColumn{"customers", "address"}
+	ColumnCustomerPhone       = EncryptedColumn{"customers", "phone"}
+	ColumnWorkerPhone         = EncryptedColumn{"workers", "phone"}
+	ColumnInvoiceBuyerTaxID   = EncryptedColumn{"invoices", "buyer_tax_id"}
+	ColumnInvoiceBuyerAddress = EncryptedColumn{"invoices", "buyer_address"}
+)
+
+// EncryptedColumns şifrelenen tüm sütunlar; anahtar döndürme ve ilk şifreleme bu listeyi dolaşır
+var EncryptedColumns = []EncryptedColumn{
+	ColumnBankAccountNumber, ColumnBuyerTaxID, ColumnBuyerAddress, ColumnCustomerTaxID, ColumnCustomerAddress,
+	ColumnCustomerPhone, ColumnInvoiceBuyerTaxID, ColumnInvoiceBuyerAddress, ColumnWorkerPhone,
+}
+
+var (
+	// ErrFieldEncryptionDisabled ana anahtar tanımlanmadığında döner
+	ErrFieldEncryptionDisabled = errors.New("alan şifreleme kapalı: FIELD_ENCRYPTION_KEYS tanımlı değil")
+	// ErrFieldKeyUnavailable değeri çözecek veri veya ana anahtar bulunamadığında döner
+	ErrFieldKeyUnavailable = errors.New("şifre çözme anahtarı bulunamadı")
+)
+
+// fieldKeys ana anahtarlar (KEK) ve bunlarla sarılarak saklanan veri anahtarları (DEK); alanlar etkin veri
+// anahtarıyla şifrelenir. Ana anahtar döndürmek yalnızca veri anahtarlarını yeniden sarar, veri anahtarı
+// döndürmek değerleri yeni anahtarla yeniden şifreler
+var fieldKeys struct {
+	mu        sync.RWMutex
+	masters   map[string][]byte
+	masterIDs []string
+	data      map[string]cipher.AEAD
+	activeDEK string
+}
+
+// FieldEncryptionService alan şifreleme anahtarlarını yönetir
+type FieldEncryptionService struct {
+	db *sql.DB
+}
+
+// NewFieldEncryptionService yeni field encryption service oluşturur
+func NewFieldEncryptionService(db *sql.DB) *FieldEncryptionService {
+	return &FieldEncryptionService{db: db}
+}
+
+// Init FIELD_ENCRYPTION_KEYS ortam değişkenindeki ana anahtarları (virgülle ayrılmış "kimlik:base64 32 bayt",
+// ilki etkin) ve saklanan veri anahtarlarını yükler; veri anahtarı yoksa oluşturur ve şifrelenmemiş değerleri
+// şifreler. Değişken tanımlı değilse değerler şifrelenmeden saklanır
+func (s *FieldEncryptionService) Init() error {
+	masters, masterIDs, err := parseMasterKeys(os.Getenv("FIELD_ENCRYPTION_KEYS"))
+	if err != nil {
+		return err
+	}
+
+	fieldKeys.mu.Lock()
+	fieldKeys.masters = masters
+	fieldKeys.masterIDs = masterIDs
+	fieldKeys.data = map[string]cipher.AEAD{}
+	fieldKeys.activeDEK = ""
+	fieldKeys.mu.Unlock()
+
+	if len(masterIDs) == 0 {
+		log.Println("⚠️  FIELD_ENCRYPTION_KEYS tanımlı değil, hassas alanlar şifrelenmeden saklanıyor")
+		return nil
+	}
+
+	if err := s.loadDataKeys(); err != nil {
+		return err
+	}
+	if !FieldEncryptionEnabled() {
+		if _, err := s.RotateDataKey(); err != nil {
+			return err
+		}
+	}
+
+	encrypted, err := s.EncryptPlaintext()
+	if encrypted > 0 {
+		log.Printf("🔒 %d şifrelenmemiş hassas alan değeri şifrelendi", encrypted)
+	}
+	return err
+}
+
+// FieldEncryptionEnabled alan şifrelemenin açık olup olmadığını döner
+func FieldEncryptionEnabled() bool {
+	fieldKeys.mu.RLock()
+	defer fieldKeys.mu.RUnlock()
+	return fieldKeys.activeDEK != ""
+}
+
+// EncryptField değeri etkin veri anahtarıyla sütuna bağlı olarak şifreler; şifreleme kapalıysa veya değer
+// boşsa ya da zaten şifreliyse değer olduğu gibi döner
+func EncryptField(column EncryptedColumn, value string) (string, error) {
+	if value == "" || strings.HasPrefix(value, encryptedValuePrefix) {
+		return value, nil
+	}
+
+	fieldKeys.mu.RLock()
+	keyID := fieldKeys.activeDEK
+	aead := fieldKeys.data[keyID]
+	fieldKeys.mu.RUnlock()
+	if keyID == "" {
+		return value, nil
+	}
+
+	nonce := make([]byte, aead.NonceSize())
+	if _, err := io.ReadFull(rand.Reader, nonce); err != nil {
+		return "", err
+	}
+	sealed := aead.Seal(nonce, nonce, []byte(value), []byte(column.Name()))
+	return encryptedValuePrefix + keyID + ":" + base64.StdEncoding.EncodeToString(sealed), nil
+}
+
+// DecryptField şifreli değeri çözer; şifrelenmemiş değerler olduğu gibi döner
+func DecryptField(column EncryptedColumn, value string) (string, error) {
+	if !strings.HasPrefix(value, encryptedValuePrefix) {
+		return value, nil
+	}
+
+	keyID, payload, ok := strings.Cut(strings.TrimPrefix(value, encryptedValuePrefix), ":")
+	if !ok {
+		return "", fmt.Errorf("%w: geçersiz şifreli değer", ErrFieldKeyUnavailable)
+	}
+
+	fieldKeys.mu.RLock()
+	aead := fieldKeys.data[keyID]
+	fieldKeys.mu.RUnlock()
+	if aead == nil {
+		return "", fmt.Errorf("%w: veri anahtarı %s", ErrFieldKeyUnavailable, keyID)
+	}
+
+	sealed, err := base64.StdEncoding.DecodeString(payload)
+	if err != nil || len(sealed) < aead.NonceSize() {
+		return "", fmt.Errorf("%w: geçersiz şifreli değer", ErrFieldKeyUnavailable)
+	}
+	plain, err := aead.Open(nil, sealed[:aead.NonceSize()], sealed[aead.NonceSize():], []byte(column.Name()))
+	if err != nil {
+		return "", fmt.Errorf("%w: değer çözülemedi", ErrFieldKeyUnavailable)
+	}
+	return string(plain), nil
+}
+
+// RevealField değeri okuma yanıtları için çözer; çözülemezse boş döner ve hatayı günlüğe yazar
+func RevealField(column EncryptedColumn, value string) string {
+	plain, err := DecryptField(column, value)
+	if err != nil {
+		log.Printf("%s çözülemedi: %v", column.Name(), err)
+		return ""
+	}
+	return plain
+}
+
+// Status etkin anahtarları ve sütunlardaki şifreli/şifresiz değer sayılarını döner
+func (s *FieldEncryptionService) Status() (models.FieldEncryptionStatus, error) {
+	fieldKeys.mu.RLock()
+	status := models.FieldEncryptionStatus{
+		Enabled:         fieldKeys.activeDEK != "",
+		ActiveDataKey:   fieldKeys.activeDEK,
+		MasterKeys:      append([]string{}, fieldKeys.masterIDs...),
+		DataKeys:        []models.FieldEncryptionKey{},
+		Columns:         []models.EncryptedColumnStatus{},
+		ActiveMasterKey: firstOrEmpty(fieldKeys.masterIDs),
+	}
+	fieldKeys.mu.RUnlock()
+
+	rows, err := s.db.Query("SELECT id, master_key_id, created_at, retired_at FROM encryption_keys ORDER BY created_at")
+	if err != nil {
+		return status, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var key models.FieldEncryptionKey
+		var retiredAt sql.NullTime
+		if err := rows.Scan(&key.ID, &key.MasterKeyID, &key.CreatedAt, &retiredAt); err != nil {
+			return status, err
+		}
+		key.RetiredAt = utils.NullTimeToPtr(retiredAt)
+		key.Active = key.ID == status.ActiveDataKey
+		status.DataKeys = append(status.DataKeys, key)
+	}
+	if err := rows.Err(); err != nil {
+		return status, err
+	}
+
+	for _, column := range EncryptedColumns {
+		columnStatus := models.EncryptedColumnStatus{Column: column.Name()}
+		err := s.db.QueryRow(fmt.Sprintf(`
+			SELECT COUNT(*), COALESCE(SUM(CASE WHEN %[1]s LIKE 'enc:v1:%%' THEN 0 ELSE 1 END), 0),
+			       COALESCE(SUM(CASE WHEN %[1]s LIKE ? THEN 1 ELSE 0 END), 0)
+			FROM %[2]s WHERE %[1]s IS NOT NULL AND %[1]s != ''
+		`, column.Column, column.Table), encryptedValuePrefix+status.ActiveDataKey+":%").
+			Scan(&columnStatus.Values, &columnStatus.Plaintext, &columnStatus.ActiveKey)
+		if err != nil {
+			return status, err
+		}
+		status.Columns = append(status.Columns, columnStatus)
+	}
+	return status, nil
+}
+
+// Rotate veri anahtarlarını etkin ana anahtarla yeniden sarar, yeni bir veri anahtarı oluşturur ve tüm
+// değerleri yeni anahtarla yeniden şifreler; eski veri anahtarları emekliye ayrılır ama çözme için saklanır
+func (s *FieldEncryptionService) Rotate() (models.FieldEncryptionRotation, error) {
+	var rotation models.FieldEncryptionRotation
+	fieldKeys.mu.RLock()
+	configured := len(fieldKeys.masterIDs) > 0
+	fieldKeys.mu.RUnlock()
+	if !configured {
+		return rotation, ErrFieldEncryptionDisabled
+	}
+
+	rewrapped, err := s.rewrapDataKeys()
+	if err != nil {
+		return rotation, err
+	}
+	rotation.RewrappedKeys = rewrapped
+
+	keyID, err := s.RotateDataKey()
+	if err != nil {
+		return rotation, err
+	}
+	rotation.DataKeyID = keyID
+
+	rotation.ReencryptedValues, err = s.reencryptAll(func(value string) bool {
+		return !strings.HasPrefix(value, encryptedValuePrefix+keyID+":")
+	})
+	return rotation, err
+}
+
+// RotateDataKey yeni bir veri anahtarı oluşturup etkin ana anahtarla sararak saklar ve etkin yapar;
+// mevcut değerler yeniden şifrelenmez
+func (s *FieldEncryptionService) RotateDataKey() (string, error) {
+	fieldKeys.mu.RLock()
+	masterID := firstOrEmpty(fieldKeys.masterIDs)
+	master := fieldKeys.masters[masterID]
+	fieldKeys.mu.RUnlock()
+	if masterID == "" {
+		return "", ErrFieldEncryptionDisabled
+	}
+
+	dataKey := make([]byte, 32)
+	if _, err := io.ReadFull(rand.Reader, dataKey); err != nil {
+		return "", err
+	}
+	wrapped, err := wrapKey(master, dataKey)
+	if err != nil {
+		return "", err
+	}
+	aead, err := newAEAD(dataKey)
+	if err != nil {
+		return "", err
+	}
+
+	keyID := utils.GenerateID()[:8]
+	tx, err := s.db.Begin()
+	if err != nil {
+		return "", err
+	}
+	defer tx.Rollback()
+
+	if _, err := tx.Exec("UPDATE encryption_keys SET retired_at = ? WHERE retired_at IS NULL", time.Now().UTC()); err != nil {
+		return "", err
+	}
+	_, err = tx.Exec(`
+		INSERT INTO encryption_keys (id, master_key_id, wrapped_key, created_at) VALUES (?, ?, ?, CURRENT_TIMESTAMP)
+	`, keyID, masterID, wrapped)
+	if err != nil {
+		return "", err
+	}
+	if err := tx.Commit(); err != nil {
+		return "", err
+	}
+
+	fieldKeys.mu.Lock()
+	fieldKeys.data[keyID] = aead
+	fieldKeys.activeDEK = keyID
+	fieldKeys.mu.Unlock()
+	return keyID, nil
+}
+
+// EncryptPlaintext şifrelenmemiş değerleri etkin veri anahtarıyla şifreler ve şifrelenen değer sayısını döner
+func (s *FieldEncryptionService) EncryptPlaintext() (int, error) {
+	return s.reencryptAll(func(value string) bool {
+		return !strings.HasPrefix(value, encryptedValuePrefix)
+	})
+}
+
+// loadDataKeys saklanan veri anahtarlarını ana anahtarlarla açar; en yeni emekliye ayrılmamış anahtar etkin olur
+func (s *FieldEncryptionService) loadDataKeys() error {
+	rows, err := s.db.Query("SELECT id, master_key_id, wrapped_key, retired_at IS NULL FROM encryption_keys ORDER BY created_at")
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	fieldKeys.mu.Lock()
+	defer fieldKeys.mu.Unlock()
+	for rows.Next() {
+		var id, masterID, wrapped string
+		var active bool
+		if err := rows.Scan(&id, &masterID, &wrapped, &active); err != nil {
+			return err
+		}
+
+		master, ok := fieldKeys.masters[masterID]
+		if !ok {
+			return fmt.Errorf("%w: %s veri anahtarını saran ana anahtar %s FIELD_ENCRYPTION_KEYS içinde yok", ErrFieldKeyUnavailable, id, masterID)
+		}
+		dataKey, err := unwrapKey(master, wrapped)
+		if err != nil {
+			return fmt.Errorf("%s veri anahtarı açılamadı: %w", id, err)
+		}
+		aead, err := newAEAD(dataKey)
+		if err != nil {
+			return err
+		}
+		fieldKeys.data[id] = aead
+		if active {
+			fieldKeys.activeDEK = id
+		}
+	}
+	return rows.Err()
+}
+
+// rewrapDataKeys etkin ana anahtarla sarılmamış veri anahtarlarını yeniden sarar; böylece eski ana anahtar
+// ortam değişkeninden kaldırılabilir
+func (s *FieldEncryptionService) rewrapDataKeys() (int, error) {
+	fieldKeys.mu.RLock()
+	masterID := firstOrEmpty(fieldKeys.masterIDs)
+	masters := fieldKeys.masters
+	fieldKeys.mu.RUnlock()
+
+	rows, err := s.db.Query("SELECT id, master_key_id, wrapped_key FROM encryption_keys WHERE master_key_id != ?", masterID)
+	if err != nil {
+		return 0, err
+	}
+	type rewrap struct{ id, wrapped string }
+	var updates []rewrap
+	for rows.Next() {
+		var id, oldMasterID, wrapped string
+		if err := rows.Scan(&id, &oldMasterID, &wrapped); err != nil {
+			rows.Close()
+			return 0, err
+		}
+		dataKey, err := unwrapKey(masters[oldMasterID], wrapped)
+		if err != nil {
+			rows.Close()
+			return 0, fmt.Errorf("%s veri anahtarı açılamadı: %w", id, err)
+		}
+		rewrapped, err := wrapKey(masters[masterID], dataKey)
+		if err != nil {
+			rows.Close()
+			return 0, err
+		}
+		updates = append(updates, rewrap{id, rewrapped})
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return 0, err
+	}
+
+	for _, update := range updates {
+		_, err := s.db.Exec("UPDATE encryption_keys SET master_key_id = ?, wrapped_key = ? WHERE id = ?", masterID, update.wrapped, update.id)
+		if err != nil {
+			return 0, err
+		}
+	}
+	return len(updates), nil
+}
+
+// reencryptAll needsUpdate koşulunu sağlayan değerleri çözüp etkin veri anahtarıyla yeniden şifreler.
+// Bu sırada kullanıcı tarafından değiştirilen değerlerin üzerine yazılmaz
+func (s *FieldEncryptionService) reencryptAll(needsUpdate func(value string) bool) (int, error) {
+	total := 0
+	for _, column := range EncryptedColumns {
+		rows, err := s.db.Query(fmt.Sprintf("SELECT id, %s FROM %s WHERE %[1]s IS NOT NULL AND %[1]s != ''", column.Column, column.Table))
+		if err != nil {
+			return total, err
+		}
+		type reencryption struct{ id, old, new string }
+		var updates []reencryption
+		for rows.Next() {
+			var id, value string
+			if err := rows.Scan(&id, &value); err != nil {
+				rows.Close()
+				return total, err
+			}
+			if !needsUpdate(value) {
+				continue
+			}
+			plain, err := DecryptField(column, value)
+			if err != nil {
+				rows.Close()
+				return total, fmt.Errorf("%s (%s): %w", column.Name(), id, err)
+			}
+			encrypted, err := EncryptField(column, plain)
+			if err != nil {
+				rows.Close()
+				return total, err
+			}
+			updates = append(updates, reencryption{id, value, encrypted})
+		}
+		rows.Close()
+		if err := rows.Err(); err != nil {
+			return total, err
+		}
+
+		for _, update := range updates {
+			result, err := s.db.Exec(fmt.Sprintf("UPDATE %s SET %s = ? WHERE id = ? AND %[2]s = ?", column.Table, column.Column),
+				update.new, update.id, update.old)
+			if err != nil {
+				return total, err
+			}
+			if affected, _ := result.RowsAffected(); affected > 0 {
+				total++
+			}
+		}
+	}
+	return total, nil
+}
+
+// parseMasterKeys "kimlik:base64" biçimindeki ana anahtarları okur; ilk anahtar etkindir
+func parseMasterKeys(raw string) (map[string][]byte, []string, error) {
+	masters := map[string][]byte{}
+	var ids []string
+	for _, entry := range strings.Split(raw, ",") {
+		entry = strings.TrimSpace(entry)
+		if entry == "" {
+			continue
+		}
+		id, encoded, ok := strings.Cut(entry, ":")
+		if !ok || id == "" {
+			return nil, nil, fmt.Errorf("FIELD_ENCRYPTION_KEYS geçersiz: anahtarlar kimlik:base64 biçiminde olmalı")
+		}
+		key, err := base64.StdEncoding.DecodeString(encoded)
+		if err != nil || len(key) != 32 {
+			return nil, nil, fmt.Errorf("FIELD_ENCRYPTION_KEYS geçersiz: %s anahtarı base64 kodlu 32 bayt olmalı", id)
+		}
+		if _, exists := masters[id]; exists {
+			return nil, nil, fmt.Errorf("FIELD_ENCRYPTION_KEYS geçersiz: %s kimliği birden fazla kez kullanılmış", id)
+		}
+		masters[id] = key
+		ids = append(ids, id)
+	}
+	return masters, ids, nil
+}
+
+// wrapKey veri anahtarını ana anahtarla şifreler
+func wrapKey(master, dataKey []byte) (string, error) {
+	aead, err := newAEAD(master)
+	if err != nil {
+		return "", err
+	}
+	nonce := make([]byte, aead.NonceSize())
+	if _, err := io.ReadFull(rand.Reader, nonce); err != nil {
+		return "", err
+	}
+	return base64.StdEncoding.EncodeToString(aead.Seal(nonce, nonce, dataKey, nil)), nil
+}
+
+// unwrapKey ana anahtarla şifrelenmiş veri anahtarını açar
+func unwrapKey(master []byte, wrapped string) ([]byte, error) {
+	if master == nil {
+		return nil, ErrFieldKeyUnavailable
+	}
+	aead, err := newAEAD(master)
+	if err != nil {
+		return nil, err
+	}
+	sealed, err := base64.StdEncoding.DecodeString(wrapped)
+	if err != nil || len(sealed) < aead.NonceSize() {
+		return nil, ErrFieldKeyUnavailable
+	}
+	return aead.Open(nil, sealed[:aead.NonceSize()], sealed[aead.NonceSize():], nil)
+}
+
+// newAEAD 32 baytlık anahtar için AES-256-GCM oluşturur
+func newAEAD(key []byte) (cipher.AEAD, error) {
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+	return cipher.NewGCM(block)
+}
+
+// firstOrEmpty listenin ilk elemanını döner
+func firstOrEmpty(values []string) string {
+	if len(values) == 0 {
+		return ""
+	}
+	return values[0]
+}
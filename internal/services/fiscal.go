@@ -0,0 +1,154 @@
+package services
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"agri-management-api/internal/models"
+)
+
+// Yerleşik periyotlar; kullanıcı tanımlı dönemler bu kodları kullanamaz
+const (
+	PeriodMonth   = "month"
+	PeriodQuarter = "quarter"
+	PeriodYear    = "year"
+)
+
+// ErrUnknownPeriod periyot ne yerleşik ne de kullanıcı tanımlı bir dönemse döner
+var ErrUnknownPeriod = errors.New("unknown period")
+
+// builtinPeriodNames yerleşik periyotların görünen adları
+var builtinPeriodNames = map[string]string{
+	PeriodMonth:   "Ay",
+	PeriodQuarter: "Mali çeyrek",
+	PeriodYear:    "Mali yıl",
+}
+
+// FiscalCalendar mali yıl başlangıcı ve kullanıcı tanımlı dönemlerle periyot aralıklarını hesaplar
+type FiscalCalendar struct {
+	yearStart time.Month
+	periods   []models.FiscalPeriod
+}
+
+// NewFiscalCalendar ayarlardan mali takvim oluşturur; ayar girilmemişse takvim yılı kullanılır
+func NewFiscalCalendar(settings models.FiscalSettings) FiscalCalendar {
+	calendar := FiscalCalendar{yearStart: time.January, periods: settings.Periods}
+	if settings.YearStartMonth >= 1 && settings.YearStartMonth <= 12 {
+		calendar.yearStart = time.Month(settings.YearStartMonth)
+	}
+	return calendar
+}
+
+// FiscalCalendar çiftliğin ayarlarındaki mali takvimi döner
+func (s *FarmService) FiscalCalendar(farmID string) (FiscalCalendar, error) {
+	settings, err := s.Settings(farmID)
+	return NewFiscalCalendar(settings.Fiscal), err
+}
+
+// NormalizeFiscalSettings mali takvim ayarlarını doğrular ve boş alanları varsayılanlarla doldurur
+func NormalizeFiscalSettings(settings *models.FiscalSettings) error {
+	if settings.YearStartMonth == 0 {
+		settings.YearStartMonth = 1
+	}
+	if settings.YearStartMonth < 1 || settings.YearStartMonth > 12 {
+		return errors.New("mali yıl başlangıç ayı 1-12 arasında olmalı")
+	}
+	if settings.Periods == nil {
+		settings.Periods = []models.FiscalPeriod{}
+	}
+
+	seen := map[string]bool{}
+	for i := range settings.Periods {
+		period := &settings.Periods[i]
+		period.Code = strings.ToLower(strings.TrimSpace(period.Code))
+		if period.Code == "" {
+			return errors.New("dönem kodu gerekli")
+		}
+		if _, builtin := builtinPeriodNames[period.Code]; builtin || period.Code == "all" || seen[period.Code] {
+			return fmt.Errorf("dönem kodu kullanılamaz: %s", period.Code)
+		}
+		seen[period.Code] = true
+
+		if period.StartDay == 0 {
+			period.StartDay = 1
+		}
+		if period.Months == 0 {
+			period.Months = 12
+		}
+		if period.StartMonth < 1 || period.StartMonth > 12 {
+			return fmt.Errorf("%s: başlangıç ayı 1-12 arasında olmalı", period.Code)
+		}
+		if period.StartDay < 1 || period.StartDay > 28 {
+			return fmt.Errorf("%s: başlangıç günü 1-28 arasında olmalı", period.Code)
+		}
+		if period.Months < 1 || period.Months > 12 {
+			return fmt.Errorf("%s: dönem uzunluğu 1-12 ay arasında olmalı", period.Code)
+		}
+		if period.Name == "" {
+			period.Name = period.Code
+		}
+	}
+	return nil
+}
+
+// Range ref tarihini içeren periyodun aralığını döner; kullanıcı tanımlı dönemlerde ref'ten önce başlayan son dönem kullanılır
+func (cal FiscalCalendar) Range(period string, ref time.Time) (models.PeriodRange, error) {
+	return cal.Window(period, ref, 1)
+}
+
+// Window ref tarihini içeren periyotla biten count ardışık periyodun toplam aralığını döner
+func (cal FiscalCalendar) Window(period string, ref time.Time, count int) (models.PeriodRange, error) {
+	if count < 1 {
+		count = 1
+	}
+
+	var name string
+	var anchorMonth time.Month
+	anchorDay, step, length := 1, 0, 0
+	switch period {
+	case PeriodMonth:
+		name, anchorMonth, step, length = builtinPeriodNames[period], time.January, 1, 1
+	case PeriodQuarter:
+		name, anchorMonth, step, length = builtinPeriodNames[period], cal.yearStart, 3, 3
+	case PeriodYear:
+		name, anchorMonth, step, length = builtinPeriodNames[period], cal.yearStart, 12, 12
+	default:
+		for _, custom := range cal.periods {
+			if custom.Code == period {
+				name, anchorMonth, anchorDay, step, length = custom.Name, time.Month(custom.StartMonth), custom.StartDay, 12, custom.Months
+				break
+			}
+		}
+		if step == 0 {
+			return models.PeriodRange{}, ErrUnknownPeriod
+		}
+	}
+
+	day := time.Date(ref.Year(), ref.Month(), ref.Day(), 0, 0, 0, 0, time.UTC)
+	start := time.Date(day.Year()+1, anchorMonth, anchorDay, 0, 0, 0, 0, time.UTC)
+	for start.After(day) {
+		start = start.AddDate(0, -step, 0)
+	}
+	end := start.AddDate(0, length, 0)
+	start = start.AddDate(0, -step*(count-1), 0)
+
+	return models.PeriodRange{Period: period, Name: name, StartDate: start, EndDate: end}, nil
+}
+
+// Ranges ref tarihini içeren yerleşik ve kullanıcı tanımlı tüm periyotların aralıklarını döner
+func (cal FiscalCalendar) Ranges(ref time.Time) []models.PeriodRange {
+	codes := []string{PeriodMonth, PeriodQuarter, PeriodYear}
+	for _, custom := range cal.periods {
+		codes = append(codes, custom.Code)
+	}
+
+	ranges := make([]models.PeriodRange, 0, len(codes))
+	for _, code := range codes {
+		if r, err := cal.Window(code, ref, 1); err == nil {
+			ranges = append(ranges, r)
+		}
+	}
+	return ranges
+}
@@ -0,0 +1,266 @@
+package services
+
+import (
+	"database/sql"
+	"errors"
+	"math"
+	"time"
+
+	"agri-management-api/internal/models"
+	"agri-management-api/internal/utils"
+)
+
+// ErrUnsupportedForecastMetric metrik için tahmin yapılmıyor
+var ErrUnsupportedForecastMetric = errors.New("unsupported forecast metric")
+
+// forecastMetrics tahmin yapılan aylık metrikler
+var forecastMetrics = map[string]bool{
+	"income_total":  true,
+	"expense_total": true,
+	"milk_total":    true,
+}
+
+// Tahmin parametreleri
+const (
+	// forecastHistoryMonths modele verilen en fazla geçmiş ay sayısı
+	forecastHistoryMonths = 36
+	// forecastSeasonLength mevsimsellik periyodu (ay)
+	forecastSeasonLength = 12
+	// forecastMovingAverageWindow yeterli geçmiş yokken kullanılan hareketli ortalama penceresi
+	forecastMovingAverageWindow = 3
+	// MaxForecastHorizon en fazla tahmin edilebilen ay sayısı
+	MaxForecastHorizon = 12
+	// Holt-Winters yumuşatma katsayıları (seviye, eğilim, mevsim)
+	holtWintersAlpha = 0.3
+	holtWintersBeta  = 0.1
+	holtWintersGamma = 0.2
+	// forecastBandZ %95 güven aralığı için z değeri
+	forecastBandZ = 1.96
+)
+
+// ForecastService aylık gelir, gider ve süt üretimi için tahmin üretir ve tahminleri gerçekleşen
+// değerlerle karşılaştırmak üzere saklar
+type ForecastService struct {
+	db         *sql.DB
+	timeSeries *TimeSeriesService
+}
+
+// NewForecastService yeni tahmin servisi oluşturur
+func NewForecastService(db *sql.DB) *ForecastService {
+	return &ForecastService{db: db, timeSeries: NewTimeSeriesService(db)}
+}
+
+// Forecast içinde bulunulan aydan başlayarak horizon ay için tahmin üretir. 24 aydan uzun geçmişte
+// Holt-Winters (toplamsal), daha kısa geçmişte 3 aylık hareketli ortalama kullanılır. Geçmişi olan metriklerde
+// gelecek aylar için ilk üretilen tahmin saklanır; gerçekleşmiş ayların saklı tahminleri doğruluk ölçümünde kullanılır
+func (s *ForecastService) Forecast(farmID, metric string, horizon int, now time.Time) (*models.ForecastSeries, error) {
+	if !forecastMetrics[metric] {
+		return nil, ErrUnsupportedForecastMetric
+	}
+	if horizon < 1 || horizon > MaxForecastHorizon {
+		horizon = 3
+	}
+
+	current := bucketStart(now, models.BucketMonth)
+	series, err := s.timeSeries.Series(farmID, metric, models.BucketMonth,
+		current.AddDate(0, -forecastHistoryMonths, 0), current.AddDate(0, 0, -1), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	// Veri girilmeye başlanmadan önceki boş aylar modeli sıfıra çekmesin
+	values := make([]float64, 0, len(series.Points))
+	for _, point := range series.Points {
+		if len(values) == 0 && point.Count == 0 {
+			continue
+		}
+		values = append(values, *point.Value)
+	}
+
+	method := models.ForecastMovingAverage
+	var predictions []float64
+	var rmse float64
+	if len(values) >= 2*forecastSeasonLength {
+		method = models.ForecastHoltWinters
+		predictions, rmse = holtWinters(values, horizon)
+	} else {
+		predictions, rmse = movingAverage(values, horizon)
+	}
+
+	forecast := &models.ForecastSeries{
+		Metric:  metric,
+		Unit:    series.Unit,
+		Method:  method,
+		History: len(values),
+		Points:  make([]models.ForecastPoint, horizon),
+	}
+	for h := range predictions {
+		band := forecastBandZ * rmse * math.Sqrt(float64(h+1))
+		value := math.Max(predictions[h], 0)
+		forecast.Points[h] = models.ForecastPoint{
+			Period: current.AddDate(0, h, 0).Format("2006-01"),
+			Value:  round2(value),
+			Lower:  round2(math.Max(value-band, 0)),
+			Upper:  round2(value + band),
+		}
+	}
+
+	if forecast.History > 0 {
+		if err := s.store(farmID, forecast); err != nil {
+			return nil, err
+		}
+	}
+
+	accuracy, err := s.accuracy(farmID, metric, series)
+	if err != nil {
+		return nil, err
+	}
+	forecast.Accuracy = accuracy
+
+	return forecast, nil
+}
+
+// store gelecek ayların tahminlerini kaydeder; ay için daha önce tahmin varsa ilk tahmin korunur
+func (s *ForecastService) store(farmID string, forecast *models.ForecastSeries) error {
+	for _, point := range forecast.Points {
+		_, err := s.db.Exec(`
+			INSERT OR IGNORE INTO metric_forecasts (id, user_id, metric, period, method, value, lower_bound, upper_bound)
+			VALUES (?, ?, ?, ?, ?, ?, ?, ?)
+		`, utils.GenerateID(), farmID, forecast.Metric, point.Period, forecast.Method, point.Value, point.Lower, point.Upper)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// accuracy gerçekleşmiş ayların saklı tahminlerini aylık seriyle karşılaştırır
+func (s *ForecastService) accuracy(farmID, metric string, series models.TimeSeries) (*models.ForecastAccuracy, error) {
+	actuals := map[string]float64{}
+	for _, point := range series.Points {
+		actuals[point.Bucket] = *point.Value
+	}
+
+	rows, err := s.db.Query(`
+		SELECT period, value, lower_bound, upper_bound
+		FROM metric_forecasts
+		WHERE user_id = ? AND metric = ?
+		ORDER BY period
+	`, farmID, metric)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	accuracy := &models.ForecastAccuracy{Evaluations: []models.ForecastEvaluation{}}
+	var absErrors, percentErrors float64
+	var percentCount, withinBand int
+	for rows.Next() {
+		var evaluation models.ForecastEvaluation
+		if err := rows.Scan(&evaluation.Period, &evaluation.Forecast, &evaluation.Lower, &evaluation.Upper); err != nil {
+			return nil, err
+		}
+		actual, ok := actuals[evaluation.Period]
+		if !ok {
+			continue
+		}
+
+		evaluation.Actual = actual
+		evaluation.Error = round2(actual - evaluation.Forecast)
+		accuracy.Evaluations = append(accuracy.Evaluations, evaluation)
+
+		absErrors += math.Abs(evaluation.Error)
+		if actual != 0 {
+			percentErrors += math.Abs(evaluation.Error / actual)
+			percentCount++
+		}
+		if actual >= evaluation.Lower && actual <= evaluation.Upper {
+			withinBand++
+		}
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	accuracy.Evaluated = len(accuracy.Evaluations)
+	if accuracy.Evaluated > 0 {
+		accuracy.MAE = round2(absErrors / float64(accuracy.Evaluated))
+		accuracy.WithinBand = round2(float64(withinBand) / float64(accuracy.Evaluated) * 100)
+	}
+	if percentCount > 0 {
+		mape := round2(percentErrors / float64(percentCount) * 100)
+		accuracy.MAPE = &mape
+	}
+	return accuracy, nil
+}
+
+// holtWinters toplamsal Holt-Winters ile horizon adım tahmin eder; ikinci değer bir adım sonrası
+// tahminlerin kök ortalama kare hatasıdır. values en az iki mevsim uzunluğunda olmalıdır
+func holtWinters(values []float64, horizon int) ([]float64, float64) {
+	m := forecastSeasonLength
+	var firstMean, secondMean float64
+	for i := 0; i < m; i++ {
+		firstMean += values[i] / float64(m)
+		secondMean += values[m+i] / float64(m)
+	}
+
+	level := firstMean
+	trend := (secondMean - firstMean) / float64(m)
+	seasonal := make([]float64, len(values))
+	for i := 0; i < m; i++ {
+		seasonal[i] = values[i] - firstMean
+	}
+
+	var squared float64
+	for t := m; t < len(values); t++ {
+		predicted := level + trend + seasonal[t-m]
+		squared += (values[t] - predicted) * (values[t] - predicted)
+
+		previousLevel := level
+		level = holtWintersAlpha*(values[t]-seasonal[t-m]) + (1-holtWintersAlpha)*(level+trend)
+		trend = holtWintersBeta*(level-previousLevel) + (1-holtWintersBeta)*trend
+		seasonal[t] = holtWintersGamma*(values[t]-level) + (1-holtWintersGamma)*seasonal[t-m]
+	}
+
+	predictions := make([]float64, horizon)
+	n := len(values)
+	for h := 1; h <= horizon; h++ {
+		predictions[h-1] = level + float64(h)*trend + seasonal[n-m+(h-1)%m]
+	}
+	return predictions, math.Sqrt(squared / float64(n-m))
+}
+
+// movingAverage son 3 ayın ortalamasını düz olarak ileri taşır; geçmiş yoksa tahmin 0'dır
+func movingAverage(values []float64, horizon int) ([]float64, float64) {
+	predictions := make([]float64, horizon)
+	if len(values) == 0 {
+		return predictions, 0
+	}
+
+	window := forecastMovingAverageWindow
+	if len(values) < window {
+		window = len(values)
+	}
+	var mean float64
+	for _, value := range values[len(values)-window:] {
+		mean += value / float64(window)
+	}
+	for h := range predictions {
+		predictions[h] = mean
+	}
+
+	var squared float64
+	var count int
+	for t := forecastMovingAverageWindow; t < len(values); t++ {
+		var predicted float64
+		for _, value := range values[t-forecastMovingAverageWindow : t] {
+			predicted += value / forecastMovingAverageWindow
+		}
+		squared += (values[t] - predicted) * (values[t] - predicted)
+		count++
+	}
+	if count == 0 {
+		return predictions, 0
+	}
+	return predictions, math.Sqrt(squared / float64(count))
+}
@@ -0,0 +1,289 @@
+package services
+
+import (
+	"database/sql"
+	"errors"
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"agri-management-api/internal/models"
+)
+
+// Ayarlardaki saat biçimleri
+const (
+	TimeFormat24H = "24H"
+	TimeFormat12H = "12H"
+)
+
+// ThousandsSeparatorNone basamakların gruplanmadığını belirten binlik ayırıcı ayarı
+const ThousandsSeparatorNone = "none"
+
+var (
+	// ErrDateFormat tarih biçimi gün (D, DD), ay (M, MM) ve yıl (YY, YYYY) alanlarını birer kez içermeli
+	ErrDateFormat = errors.New("tarih biçimi gün (DD), ay (MM) ve yılı (YYYY) birer kez içermeli; ayırıcı olarak . / - veya boşluk kullanılabilir")
+	// ErrTimeFormat desteklenmeyen saat biçimi
+	ErrTimeFormat = errors.New("saat biçimi 24H veya 12H olmalı")
+	// ErrNumberSeparators desteklenmeyen veya aynı olan ondalık ve binlik ayırıcılar
+	ErrNumberSeparators = errors.New("ondalık ayırıcı , veya . olmalı; binlik ayırıcı . , boşluk veya none olmalı ve ondalık ayırıcıdan farklı olmalı")
+)
+
+// dateFormatTokens ayarlardaki tarih biçimi alanlarının Go düzenindeki karşılıkları; uzun alanlar önce denenir
+var dateFormatTokens = []struct{ token, layout string }{
+	{"YYYY", "2006"}, {"YY", "06"}, {"MM", "01"}, {"DD", "02"}, {"M", "1"}, {"D", "2"},
+}
+
+// timeFormatLayouts saat biçimlerinin Go düzenleri
+var timeFormatLayouts = map[string]string{
+	TimeFormat24H: "15:04",
+	TimeFormat12H: "03:04 PM",
+}
+
+// currencySymbols para birimi kodlarının simgeleri; PDF yazı tipinde ₺ bulunmadığından TRY için TL kullanılır
+var currencySymbols = map[string]string{
+	"TRY": "TL",
+	"USD": "$",
+	"EUR": "€",
+	"GBP": "£",
+}
+
+// NormalizeGeneralSettings genel ayarlardaki tarih, saat ve sayı biçimlerini doğrular; boş alanları varsayılanlarla,
+// boş binlik ayırıcıyı ondalık ayırıcıya göre (, için ., . için ,) doldurur
+func NormalizeGeneralSettings(settings *models.GeneralSettings) error {
+	defaults := DefaultSettings().General
+
+	settings.DateFormat = strings.ToUpper(strings.TrimSpace(settings.DateFormat))
+	if settings.DateFormat == "" {
+		settings.DateFormat = defaults.DateFormat
+	}
+	if _, err := dateLayout(settings.DateFormat); err != nil {
+		return err
+	}
+
+	settings.TimeFormat = strings.ToUpper(strings.TrimSpace(settings.TimeFormat))
+	if settings.TimeFormat == "" {
+		settings.TimeFormat = defaults.TimeFormat
+	}
+	if _, ok := timeFormatLayouts[settings.TimeFormat]; !ok {
+		return ErrTimeFormat
+	}
+
+	if settings.DecimalSeparator == "" {
+		settings.DecimalSeparator = defaults.DecimalSeparator
+	}
+	if settings.ThousandsSeparator == "" {
+		settings.ThousandsSeparator = "."
+		if settings.DecimalSeparator == "." {
+			settings.ThousandsSeparator = ","
+		}
+	}
+	if !validSeparators(settings.DecimalSeparator, settings.ThousandsSeparator) {
+		return ErrNumberSeparators
+	}
+
+	settings.CurrencySymbol = strings.TrimSpace(settings.CurrencySymbol)
+	return nil
+}
+
+// validSeparators ondalık ve binlik ayırıcıların desteklenen ve birbirinden farklı değerler olup olmadığını döner
+func validSeparators(decimal, thousands string) bool {
+	if decimal != "," && decimal != "." {
+		return false
+	}
+	switch thousands {
+	case ThousandsSeparatorNone, " ":
+		return true
+	case ",", ".":
+		return thousands != decimal
+	}
+	return false
+}
+
+// dateLayout ayarlardaki tarih biçimini (ör. DD/MM/YYYY) Go tarih düzenine çevirir
+func dateLayout(format string) (string, error) {
+	var layout strings.Builder
+	seen := map[byte]bool{}
+	for rest := format; rest != ""; {
+		matched := false
+		for _, field := range dateFormatTokens {
+			if strings.HasPrefix(rest, field.token) {
+				if seen[field.token[0]] {
+					return "", ErrDateFormat
+				}
+				seen[field.token[0]] = true
+				layout.WriteString(field.layout)
+				rest = rest[len(field.token):]
+				matched = true
+				break
+			}
+		}
+		if matched {
+			continue
+		}
+
+		r, size := utf8.DecodeRuneInString(rest)
+		if !strings.ContainsRune("./- ", r) {
+			return "", ErrDateFormat
+		}
+		layout.WriteRune(r)
+		rest = rest[size:]
+	}
+	if !seen['Y'] || !seen['M'] || !seen['D'] {
+		return "", ErrDateFormat
+	}
+	return layout.String(), nil
+}
+
+// Formatter dışa aktarım ve raporlardaki tarih, saat, sayı ve tutarları çiftlik ayarlarındaki biçimlerle yazar
+type Formatter struct {
+	dateLayout string
+	timeLayout string
+	decimal    string
+	thousands  string
+	currency   string
+	symbol     string
+	// spreadsheet tutarlar simgesiz yazılır
+	spreadsheet bool
+}
+
+// NewFormatter genel ayarlardan biçimlendirici oluşturur; geçersiz biçimler yerine varsayılanlar kullanılır
+func NewFormatter(settings models.GeneralSettings) Formatter {
+	if err := NormalizeGeneralSettings(&settings); err != nil {
+		defaults := DefaultSettings().General
+		defaults.Currency, defaults.CurrencySymbol = settings.Currency, settings.CurrencySymbol
+		settings = defaults
+	}
+
+	layout, _ := dateLayout(settings.DateFormat)
+	f := Formatter{
+		dateLayout: layout,
+		timeLayout: timeFormatLayouts[settings.TimeFormat],
+		decimal:    settings.DecimalSeparator,
+		thousands:  settings.ThousandsSeparator,
+		currency:   strings.ToUpper(settings.Currency),
+		symbol:     settings.CurrencySymbol,
+	}
+	if f.thousands == ThousandsSeparatorNone {
+		f.thousands = ""
+	}
+	return f
+}
+
+// Spreadsheet sayıları ayırıcısız ve noktalı ondalıkla yazan kopyayı döner; XLSX hücreleri sayı olarak kalır ve
+// Excel bunları kullanıcının bölge ayarlarıyla gösterir
+func (f Formatter) Spreadsheet() Formatter {
+	f.decimal, f.thousands, f.spreadsheet = ".", "", true
+	return f
+}
+
+// Date tarihi ayarlardaki biçimde yazar
+func (f Formatter) Date(value time.Time) string {
+	return value.Format(f.dateLayout)
+}
+
+// DateTime tarihi ve saati ayarlardaki biçimlerde yazar
+func (f Formatter) DateTime(value time.Time) string {
+	return value.Format(f.dateLayout + " " + f.timeLayout)
+}
+
+// Day YYYY-MM-DD tarihini ayarlardaki biçimde yazar; tarih okunamazsa olduğu gibi döner
+func (f Formatter) Day(day string) string {
+	date, err := time.Parse("2006-01-02", day)
+	if err != nil {
+		return day
+	}
+	return f.Date(date)
+}
+
+// OptionalDate boş olabilen tarihi yazar
+func (f Formatter) OptionalDate(value *time.Time) string {
+	if value == nil {
+		return ""
+	}
+	return f.Date(*value)
+}
+
+// Number sayıyı verilen ondalık basamakla (-1: gerektiği kadar) ayarlardaki ayırıcılarla yazar
+func (f Formatter) Number(value float64, decimals int) string {
+	text := strconv.FormatFloat(value, 'f', decimals, 64)
+	if f.decimal == "." && f.thousands == "" {
+		return text
+	}
+
+	sign := ""
+	if strings.HasPrefix(text, "-") {
+		sign, text = "-", text[1:]
+	}
+	integer, fraction, _ := strings.Cut(text, ".")
+
+	var b strings.Builder
+	b.WriteString(sign)
+	for i, digit := range integer {
+		if i > 0 && (len(integer)-i)%3 == 0 {
+			b.WriteString(f.thousands)
+		}
+		b.WriteRune(digit)
+	}
+	if fraction != "" {
+		b.WriteString(f.decimal)
+		b.WriteString(fraction)
+	}
+	return b.String()
+}
+
+// Amount tutarı iki ondalıkla yazar
+func (f Formatter) Amount(value float64) string {
+	return f.Number(value, 2)
+}
+
+// Money tutarı para biriminin simgesiyle (ör. 1.250,00 TL) yazar. Çiftliğin para biriminde ayarlardaki simge,
+// diğer para birimlerinde bilinen simge veya para birimi kodu kullanılır; elektronik tabloda yalnızca sayı yazılır
+func (f Formatter) Money(value float64, currency string) string {
+	amount := f.Amount(value)
+	if f.spreadsheet {
+		return amount
+	}
+	if symbol := f.Symbol(currency); symbol != "" {
+		return amount + " " + symbol
+	}
+	return amount
+}
+
+// Symbol para biriminin simgesini döner; boş para birimi çiftliğin para birimi sayılır
+func (f Formatter) Symbol(currency string) string {
+	currency = strings.ToUpper(strings.TrimSpace(currency))
+	if currency == "" || currency == f.currency {
+		if f.symbol != "" {
+			return f.symbol
+		}
+		currency = f.currency
+	}
+	if symbol, ok := currencySymbols[currency]; ok {
+		return symbol
+	}
+	return currency
+}
+
+// FormattingService çiftlik ayarlarından dışa aktarım ve rapor biçimlendiricisini oluşturur
+type FormattingService struct {
+	farms *FarmService
+}
+
+// NewFormattingService yeni biçimlendirme servisi oluşturur
+func NewFormattingService(db *sql.DB) *FormattingService {
+	return &FormattingService{farms: NewFarmService(db)}
+}
+
+// Formatter çiftliğin biçimlendiricisini döner; XLSX dosyaları için sayılar elektronik tablo biçiminde yazılır
+func (s *FormattingService) Formatter(farmID, format string) (Formatter, error) {
+	settings, err := s.farms.Settings(farmID)
+	if err != nil {
+		return Formatter{}, err
+	}
+	formatter := NewFormatter(settings.General)
+	if format == ReportFormatXLSX {
+		formatter = formatter.Spreadsheet()
+	}
+	return formatter, nil
+}
@@ -0,0 +1,435 @@
+package services
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"agri-management-api/internal/models"
+	"agri-management-api/internal/utils"
+)
+
+// CostRatePieceRate hasat ekibinin parça başı ücretinden oluşan işçilik kalemi
+const CostRatePieceRate = "piece_rate"
+
+// harvestPayrollCategory ekip ödemeleri için oluşturulan gider işlemlerinin kategorisi
+const harvestPayrollCategory = "İşçilik"
+
+// defaultHarvestUnit birimi verilmeyen ekip kayıtlarının birimi
+const defaultHarvestUnit = "kg"
+
+// harvestActivityTypes ekip ve parça başı ödeme kaydedilebilen serbest metin aktivite türleri
+var harvestActivityTypes = []string{"harvest", "harvesting", "hasat"}
+
+var (
+	// ErrNotHarvestActivity aktivite hasat türünde değil
+	ErrNotHarvestActivity = errors.New("aktivite hasat türünde değil")
+	// ErrHarvestCrewPaid ödemesi kaydedilmiş ekip kayıtları değiştirilemez
+	ErrHarvestCrewPaid = errors.New("ödemesi kaydedilmiş ekip kayıtları değiştirilemez")
+)
+
+// harvestCrewSelect ekip kaydı sütunları
+const harvestCrewSelect = `
+	SELECT id, activity_id, worker_name, quantity, unit, piece_rate, amount, COALESCE(notes, ''),
+	       transaction_id, paid_at, created_at
+	FROM harvest_crew_entries`
+
+// IsHarvestActivity aktivite türünün hasat olup olmadığını döner
+func IsHarvestActivity(activityType string) bool {
+	activityType = strings.ToLower(strings.TrimSpace(activityType))
+	for _, alias := range harvestActivityTypes {
+		if activityType == alias {
+			return true
+		}
+	}
+	return false
+}
+
+// HarvestPayrollService hasat aktivitelerinde ekip üyelerinin topladığı miktarları ve parça başı ücretleri
+// kaydeder, ödemeleri gider işlemi olarak oluşturur ve işçi bazında ödeme özeti çıkarır
+type HarvestPayrollService struct {
+	db *sql.DB
+}
+
+// NewHarvestPayrollService yeni harvest payroll service oluşturur
+func NewHarvestPayrollService(db *sql.DB) *HarvestPayrollService {
+	return &HarvestPayrollService{db: db}
+}
+
+// Crew aktivitenin ekip kayıtlarını toplamlarla döner
+func (s *HarvestPayrollService) Crew(farmID, activityID string) (models.HarvestCrew, error) {
+	crew := models.HarvestCrew{ActivityID: activityID, Entries: []models.HarvestCrewEntry{}}
+	if _, _, err := s.harvestActivity(farmID, activityID); err != nil {
+		return crew, err
+	}
+
+	rows, err := s.db.Query(harvestCrewSelect+" WHERE activity_id = ? AND user_id = ? ORDER BY worker_name, rowid", activityID, farmID)
+	if err != nil {
+		return crew, err
+	}
+	defer rows.Close()
+
+	workers := map[string]bool{}
+	for rows.Next() {
+		entry, err := scanHarvestCrewEntry(rows)
+		if err != nil {
+			return crew, err
+		}
+		workers[strings.ToLower(entry.WorkerName)] = true
+		crew.TotalQuantity += entry.Quantity
+		crew.TotalAmount += entry.Amount
+		if entry.TransactionID != nil {
+			crew.PaidAmount += entry.Amount
+		}
+		crew.Entries = append(crew.Entries, entry)
+	}
+
+	crew.Workers = len(workers)
+	crew.TotalQuantity = round2(crew.TotalQuantity)
+	crew.TotalAmount = round2(crew.TotalAmount)
+	crew.PaidAmount = round2(crew.PaidAmount)
+	crew.UnpaidAmount = round2(crew.TotalAmount - crew.PaidAmount)
+	return crew, rows.Err()
+}
+
+// Replace aktivitenin ekip kayıtlarını verilen listeyle değiştirir ve tutarları hesaplar. Aktivitenin parça başı
+// işçilik maliyet kalemleri işçi bazında yeniden yazılır ve aktivitenin maliyeti kalemlerin toplamı olur.
+// Ödemesi kaydedilmiş kayıt varsa liste değiştirilemez
+func (s *HarvestPayrollService) Replace(farmID, activityID string, entries []models.HarvestCrewEntry) (models.HarvestCrew, error) {
+	if _, _, err := s.harvestActivity(farmID, activityID); err != nil {
+		return models.HarvestCrew{}, err
+	}
+
+	tx, err := s.db.Begin()
+	if err != nil {
+		return models.HarvestCrew{}, err
+	}
+	defer tx.Rollback()
+
+	var paid int
+	err = tx.QueryRow(`
+		SELECT COUNT(*) FROM harvest_crew_entries
+		WHERE activity_id = ? AND user_id = ? AND transaction_id IS NOT NULL
+	`, activityID, farmID).Scan(&paid)
+	if err != nil {
+		return models.HarvestCrew{}, err
+	}
+	if paid > 0 {
+		return models.HarvestCrew{}, ErrHarvestCrewPaid
+	}
+
+	if _, err := tx.Exec("DELETE FROM harvest_crew_entries WHERE activity_id = ? AND user_id = ?", activityID, farmID); err != nil {
+		return models.HarvestCrew{}, err
+	}
+	_, err = tx.Exec("DELETE FROM land_activity_cost_items WHERE activity_id = ? AND user_id = ? AND rate_source = ?",
+		activityID, farmID, CostRatePieceRate)
+	if err != nil {
+		return models.HarvestCrew{}, err
+	}
+
+	// İşçilik kalemleri işçi, birim ve ücret bazında birleştirilir
+	type laborKey struct {
+		worker, unit string
+		rate         float64
+	}
+	labor := map[laborKey]float64{}
+	var keys []laborKey
+
+	for _, entry := range entries {
+		entry.WorkerName = strings.TrimSpace(entry.WorkerName)
+		entry.Unit = strings.TrimSpace(entry.Unit)
+		if entry.Unit == "" {
+			entry.Unit = defaultHarvestUnit
+		}
+		entry.Amount = round2(entry.Quantity * entry.PieceRate)
+
+		_, err := tx.Exec(`
+			INSERT INTO harvest_crew_entries (id, user_id, activity_id, worker_name, quantity, unit, piece_rate, amount,
+			                                  notes, created_at)
+			VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, CURRENT_TIMESTAMP)
+		`, utils.GenerateID(), farmID, activityID, entry.WorkerName, entry.Quantity, entry.Unit, entry.PieceRate,
+			entry.Amount, entry.Notes)
+		if err != nil {
+			return models.HarvestCrew{}, err
+		}
+
+		key := laborKey{worker: entry.WorkerName, unit: entry.Unit, rate: entry.PieceRate}
+		if _, ok := labor[key]; !ok {
+			keys = append(keys, key)
+		}
+		labor[key] += entry.Quantity
+	}
+
+	for _, key := range keys {
+		quantity := labor[key]
+		_, err := tx.Exec(`
+			INSERT INTO land_activity_cost_items (id, user_id, activity_id, category, description, quantity, unit,
+			                                      unit_rate, rate_source, amount, created_at)
+			VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, CURRENT_TIMESTAMP)
+		`, utils.GenerateID(), farmID, activityID, models.LandCostLabor, "Hasat ekibi - "+key.worker, quantity, key.unit,
+			key.rate, CostRatePieceRate, round2(quantity*key.rate))
+		if err != nil {
+			return models.HarvestCrew{}, err
+		}
+	}
+
+	// Kalem dökümü varsa aktivitenin maliyeti kalemlerin toplamıdır
+	_, err = tx.Exec(`
+		UPDATE land_activities
+		SET cost = (SELECT ROUND(SUM(amount), 2) FROM land_activity_cost_items WHERE activity_id = ? AND user_id = ?)
+		WHERE id = ? AND land_id IN (SELECT id FROM lands WHERE user_id = ?)
+		  AND EXISTS (SELECT 1 FROM land_activity_cost_items WHERE activity_id = ? AND user_id = ?)
+	`, activityID, farmID, activityID, farmID, activityID, farmID)
+	if err != nil {
+		return models.HarvestCrew{}, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return models.HarvestCrew{}, err
+	}
+	return s.Crew(farmID, activityID)
+}
+
+// PostPayroll ödemesi kaydedilmemiş ekip kayıtları için işçi başına birer gider işlemi oluşturur ve kayıtları
+// işlemlere bağlar. Tutarı sıfır olan kayıtlar atlanır
+func (s *HarvestPayrollService) PostPayroll(farmID, activityID string, req models.HarvestPayrollPostRequest) (models.HarvestPayrollPosting, error) {
+	result := models.HarvestPayrollPosting{ActivityID: activityID, Transactions: []string{}}
+
+	landName, activityDate, err := s.harvestActivity(farmID, activityID)
+	if err != nil {
+		return result, err
+	}
+
+	date := time.Now()
+	if req.Date != nil {
+		date = *req.Date
+	} else if activityDate != nil {
+		date = *activityDate
+	}
+
+	tx, err := s.db.Begin()
+	if err != nil {
+		return result, err
+	}
+	defer tx.Rollback()
+
+	rows, err := tx.Query(`
+		SELECT id, worker_name, quantity, unit, piece_rate, amount
+		FROM harvest_crew_entries
+		WHERE activity_id = ? AND user_id = ? AND transaction_id IS NULL AND amount > 0
+		ORDER BY worker_name, rowid
+	`, activityID, farmID)
+	if err != nil {
+		return result, err
+	}
+
+	// Aynı işçinin kayıtları tek ödemede toplanır
+	type workerPay struct {
+		name    string
+		entries []string
+		lines   []string
+		amount  float64
+	}
+	byWorker := map[string]*workerPay{}
+	var order []string
+	for rows.Next() {
+		var id, name, unit string
+		var quantity, rate, amount float64
+		if err := rows.Scan(&id, &name, &quantity, &unit, &rate, &amount); err != nil {
+			rows.Close()
+			return result, err
+		}
+		key := strings.ToLower(name)
+		pay := byWorker[key]
+		if pay == nil {
+			pay = &workerPay{name: name}
+			byWorker[key] = pay
+			order = append(order, key)
+		}
+		pay.entries = append(pay.entries, id)
+		pay.lines = append(pay.lines, fmt.Sprintf("%s %s x %s", formatPayrollNumber(quantity), unit, formatPayrollNumber(rate)))
+		pay.amount += amount
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return result, err
+	}
+
+	for _, key := range order {
+		pay := byWorker[key]
+		pay.amount = round2(pay.amount)
+		transactionID := utils.GenerateID()
+		_, err := tx.Exec(`
+			INSERT INTO transactions (id, user_id, type, category, description, amount, currency,
+			                         date, status, payment_method, receipt, notes, created_at, updated_at)
+			VALUES (?, ?, 'expense', ?, ?, ?, 'TRY', ?, 'completed', ?, '', ?, CURRENT_TIMESTAMP, CURRENT_TIMESTAMP)
+		`, transactionID, farmID, harvestPayrollCategory, "Hasat ödemesi - "+pay.name+" ("+landName+")", pay.amount,
+			date, req.PaymentMethod, strings.Join(pay.lines, ", "))
+		if err != nil {
+			return result, err
+		}
+
+		for _, entryID := range pay.entries {
+			_, err := tx.Exec(`
+				UPDATE harvest_crew_entries SET transaction_id = ?, paid_at = CURRENT_TIMESTAMP
+				WHERE id = ? AND user_id = ?
+			`, transactionID, entryID, farmID)
+			if err != nil {
+				return result, err
+			}
+		}
+
+		result.Posted++
+		result.TotalAmount = round2(result.TotalAmount + pay.amount)
+		result.Transactions = append(result.Transactions, transactionID)
+	}
+
+	return result, tx.Commit()
+}
+
+// Summary tarihi (gerçekleşme, planlanan veya kayıt) aralıktaki hasat aktivitelerinin ekip kayıtlarını işçi ve
+// birim bazında toplar
+func (s *HarvestPayrollService) Summary(farmID string, startDate, endDate *time.Time) (models.HarvestPayrollSummary, error) {
+	summary := models.HarvestPayrollSummary{Workers: []models.HarvestPayrollWorker{}}
+
+	from, to := "0001-01-01", "9999-12-31"
+	if startDate != nil {
+		from = startDate.Format("2006-01-02")
+		summary.StartDate = from
+	}
+	if endDate != nil {
+		to = endDate.Format("2006-01-02")
+		summary.EndDate = to
+	}
+
+	rows, err := s.db.Query(`
+		SELECT e.activity_id, e.worker_name, e.unit, e.quantity, e.amount, e.transaction_id IS NOT NULL
+		FROM harvest_crew_entries e
+		JOIN land_activities a ON a.id = e.activity_id
+		WHERE e.user_id = ? AND date(COALESCE(a.actual_date, a.scheduled_date, a.created_at)) BETWEEN ? AND ?
+		ORDER BY e.worker_name, e.unit
+	`, farmID, from, to)
+	if err != nil {
+		return summary, err
+	}
+	defer rows.Close()
+
+	activities := map[string]bool{}
+	workers := map[string]*models.HarvestPayrollWorker{}
+	workerActivities := map[string]map[string]bool{}
+	var keys []string
+	for rows.Next() {
+		var activityID, name, unit string
+		var quantity, amount float64
+		var paid bool
+		if err := rows.Scan(&activityID, &name, &unit, &quantity, &amount, &paid); err != nil {
+			return summary, err
+		}
+
+		key := strings.ToLower(name) + "\x00" + unit
+		worker := workers[key]
+		if worker == nil {
+			worker = &models.HarvestPayrollWorker{WorkerName: name, Unit: unit}
+			workers[key] = worker
+			workerActivities[key] = map[string]bool{}
+			keys = append(keys, key)
+		}
+		workerActivities[key][activityID] = true
+		activities[activityID] = true
+
+		worker.Quantity += quantity
+		worker.Amount += amount
+		if paid {
+			worker.PaidAmount += amount
+		}
+	}
+	if err := rows.Err(); err != nil {
+		return summary, err
+	}
+
+	sort.Strings(keys)
+	for _, key := range keys {
+		worker := workers[key]
+		worker.Activities = len(workerActivities[key])
+		worker.Quantity = round2(worker.Quantity)
+		worker.Amount = round2(worker.Amount)
+		worker.PaidAmount = round2(worker.PaidAmount)
+		worker.UnpaidAmount = round2(worker.Amount - worker.PaidAmount)
+
+		summary.TotalAmount += worker.Amount
+		summary.PaidAmount += worker.PaidAmount
+		summary.Workers = append(summary.Workers, *worker)
+	}
+
+	summary.Activities = len(activities)
+	summary.TotalAmount = round2(summary.TotalAmount)
+	summary.PaidAmount = round2(summary.PaidAmount)
+	summary.UnpaidAmount = round2(summary.TotalAmount - summary.PaidAmount)
+	return summary, nil
+}
+
+// PayrollRecords ödeme özetini başlık satırı ve toplam satırıyla dışa aktarım satırlarına çevirir; işçi adı ve
+// birim formül olarak yazılmaz
+func PayrollRecords(summary models.HarvestPayrollSummary, f Formatter) [][]string {
+	records := [][]string{{"İşçi", "Birim", "Aktivite Sayısı", "Miktar", "Tutar", "Ödenen", "Ödenmemiş"}}
+	for _, worker := range summary.Workers {
+		records = append(records, []string{
+			SpreadsheetText(worker.WorkerName),
+			SpreadsheetText(worker.Unit),
+			strconv.Itoa(worker.Activities),
+			f.Number(round2(worker.Quantity), -1),
+			f.Amount(worker.Amount),
+			f.Amount(worker.PaidAmount),
+			f.Amount(worker.UnpaidAmount),
+		})
+	}
+	return append(records, []string{
+		"Toplam", "", strconv.Itoa(summary.Activities), "",
+		f.Amount(summary.TotalAmount),
+		f.Amount(summary.PaidAmount),
+		f.Amount(summary.UnpaidAmount),
+	})
+}
+
+// harvestActivity aktivitenin çiftliğin bir arazisine ait hasat aktivitesi olduğunu doğrular; arazi adını ve
+// gerçekleşme tarihini döner
+func (s *HarvestPayrollService) harvestActivity(farmID, activityID string) (string, *time.Time, error) {
+	var landName, activityType string
+	var actualDate sql.NullTime
+	err := s.db.QueryRow(`
+		SELECT l.name, a.type, a.actual_date
+		FROM land_activities a JOIN lands l ON l.id = a.land_id
+		WHERE a.id = ? AND l.user_id = ?
+	`, activityID, farmID).Scan(&landName, &activityType, &actualDate)
+	if err == sql.ErrNoRows {
+		return "", nil, ErrLandActivityNotFound
+	}
+	if err != nil {
+		return "", nil, err
+	}
+	if !IsHarvestActivity(activityType) {
+		return "", nil, ErrNotHarvestActivity
+	}
+	return landName, utils.NullTimeToPtr(actualDate), nil
+}
+
+// formatPayrollNumber sayıyı gereksiz ondalık basamaklar olmadan yazar
+func formatPayrollNumber(v float64) string {
+	return strconv.FormatFloat(round2(v), 'f', -1, 64)
+}
+
+// scanHarvestCrewEntry ekip kaydı satırını okur
+func scanHarvestCrewEntry(row interface{ Scan(...interface{}) error }) (models.HarvestCrewEntry, error) {
+	var entry models.HarvestCrewEntry
+	var transactionID sql.NullString
+	var paidAt sql.NullTime
+	err := row.Scan(&entry.ID, &entry.ActivityID, &entry.WorkerName, &entry.Quantity, &entry.Unit, &entry.PieceRate,
+		&entry.Amount, &entry.Notes, &transactionID, &paidAt, &entry.CreatedAt)
+	entry.TransactionID = utils.NullStringToPtr(transactionID)
+	entry.PaidAt = utils.NullTimeToPtr(paidAt)
+	return entry, err
+}
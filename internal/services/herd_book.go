@@ -0,0 +1,522 @@
+package services
+
+import (
+	"database/sql"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"io"
+	"slices"
+	"strings"
+	"time"
+
+	"agri-management-api/internal/models"
+	"agri-management-api/internal/utils"
+)
+
+// Soy kütüğü belgesi formatları
+const (
+	HerdBookFormatPDF = "pdf"
+	HerdBookFormatCSV = "csv"
+)
+
+// defaultHerdBookGenerations şablonda verilmezse belgede gösterilen kuşak sayısı
+const defaultHerdBookGenerations = 3
+
+var (
+	// ErrHerdBookTemplateNotFound şablon yok veya çiftliklerin kullanımına kapalı
+	ErrHerdBookTemplateNotFound = errors.New("herd book template not found")
+	// ErrHerdBookAnimalNotFound seçilen hayvanlardan biri çiftlikte yok
+	ErrHerdBookAnimalNotFound = errors.New("animal not found")
+	// ErrHerdBookSpecies seçilen hayvanlardan biri şablonun türünde değil
+	ErrHerdBookSpecies = errors.New("şablon yalnızca kendi türündeki hayvanlar için kullanılabilir")
+)
+
+// defaultHerdBookFields şablonda alan verilmezse belgede gösterilen alanlar
+var defaultHerdBookFields = []string{
+	models.HerdBookFieldTagNumber, models.HerdBookFieldBreed, models.HerdBookFieldGender,
+	models.HerdBookFieldBirthDate, models.HerdBookFieldFather, models.HerdBookFieldMother,
+	models.HerdBookFieldOwner, models.HerdBookFieldPremisesNumber,
+}
+
+// herdBookFieldLabels alanların birlik adı verilmediğinde kullanılan Türkçe adları
+var herdBookFieldLabels = map[string]string{
+	models.HerdBookFieldTagNumber:      "Küpe No",
+	models.HerdBookFieldSpecies:        "Tür",
+	models.HerdBookFieldBreed:          "Irk",
+	models.HerdBookFieldGender:         "Cinsiyet",
+	models.HerdBookFieldBirthDate:      "Doğum Tarihi",
+	models.HerdBookFieldWeight:         "Ağırlık",
+	models.HerdBookFieldLocation:       "Bulunduğu Yer",
+	models.HerdBookFieldMother:         "Anne Küpe No",
+	models.HerdBookFieldFather:         "Baba Küpe No",
+	models.HerdBookFieldFarm:           "İşletme",
+	models.HerdBookFieldOwner:          "Yetiştirici",
+	models.HerdBookFieldPremisesNumber: "İşletme No",
+	models.HerdBookFieldNotes:          "Açıklama",
+}
+
+// HerdBookService yöneticinin tanımladığı birlik şablonlarını ve seçilen hayvanların soy kayıtlarından
+// birlik biçiminde soy kütüğü/tescil belgelerini yönetir
+type HerdBookService struct {
+	db         *sql.DB
+	passports  *AnimalPassportService
+	breeds     *LivestockBreedService
+	categories *CategoryService
+}
+
+// NewHerdBookService yeni soy kütüğü servisi oluşturur
+func NewHerdBookService(db *sql.DB) *HerdBookService {
+	return &HerdBookService{
+		db:         db,
+		passports:  NewAnimalPassportService(db),
+		breeds:     NewLivestockBreedService(db),
+		categories: NewCategoryService(db),
+	}
+}
+
+// herdBookTemplateSelect şablon sütunları
+const herdBookTemplateSelect = `
+	SELECT id, association, title, COALESCE(species, ''), format, generations, COALESCE(fields, ''),
+	       COALESCE(declaration, ''), active, created_at, updated_at
+	FROM herd_book_templates`
+
+// scanHerdBookTemplate şablon satırını okur
+func scanHerdBookTemplate(scanner interface{ Scan(...interface{}) error }) (models.HerdBookTemplate, error) {
+	var template models.HerdBookTemplate
+	var fields string
+	err := scanner.Scan(&template.ID, &template.Association, &template.Title, &template.Species, &template.Format,
+		&template.Generations, &fields, &template.Declaration, &template.Active, &template.CreatedAt, &template.UpdatedAt)
+	if err != nil {
+		return template, err
+	}
+	if json.Unmarshal([]byte(fields), &template.Fields) != nil || len(template.Fields) == 0 {
+		template.Fields = []models.HerdBookField{}
+		for _, key := range defaultHerdBookFields {
+			template.Fields = append(template.Fields, models.HerdBookField{Key: key})
+		}
+	}
+	for i, field := range template.Fields {
+		if field.Label == "" {
+			template.Fields[i].Label = herdBookFieldLabels[field.Key]
+		}
+	}
+	return template, nil
+}
+
+// Templates şablonları birlik ve başlığa göre listeler; activeOnly çiftliklerin kullanabildiği şablonlarla,
+// species verilirse o türe ve tüm türlere açık şablonlarla sınırlar
+func (s *HerdBookService) Templates(activeOnly bool, species string) ([]models.HerdBookTemplate, error) {
+	query := herdBookTemplateSelect + " WHERE 1 = 1"
+	var args []interface{}
+	if activeOnly {
+		query += " AND active = TRUE"
+	}
+	if species != "" {
+		query += " AND COALESCE(species, '') IN ('', ?)"
+		args = append(args, species)
+	}
+	query += " ORDER BY association, title"
+
+	rows, err := s.db.Query(query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	templates := []models.HerdBookTemplate{}
+	for rows.Next() {
+		template, err := scanHerdBookTemplate(rows)
+		if err != nil {
+			return nil, err
+		}
+		templates = append(templates, template)
+	}
+	return templates, rows.Err()
+}
+
+// Template şablonu döner
+func (s *HerdBookService) Template(id string) (models.HerdBookTemplate, error) {
+	template, err := scanHerdBookTemplate(s.db.QueryRow(herdBookTemplateSelect+" WHERE id = ?", id))
+	if err == sql.ErrNoRows {
+		return template, ErrHerdBookTemplateNotFound
+	}
+	return template, err
+}
+
+// CreateTemplate şablon ekler; format verilmezse pdf, kuşak sayısı verilmezse 3 kullanılır
+func (s *HerdBookService) CreateTemplate(req models.HerdBookTemplateRequest) (models.HerdBookTemplate, error) {
+	id := utils.GenerateID()
+	if err := s.saveTemplate(id, req, true); err != nil {
+		return models.HerdBookTemplate{}, err
+	}
+	return s.Template(id)
+}
+
+// UpdateTemplate şablonu günceller; active verilmezse şablonun kullanım durumu korunur
+func (s *HerdBookService) UpdateTemplate(id string, req models.HerdBookTemplateRequest) (models.HerdBookTemplate, error) {
+	if err := s.saveTemplate(id, req, false); err != nil {
+		return models.HerdBookTemplate{}, err
+	}
+	return s.Template(id)
+}
+
+// saveTemplate şablonu ekler veya günceller
+func (s *HerdBookService) saveTemplate(id string, req models.HerdBookTemplateRequest, create bool) error {
+	species := strings.ToLower(strings.TrimSpace(req.Species))
+	if species != "" && !slices.Contains(s.breeds.SpeciesKeys(""), species) {
+		return ErrUnknownSpecies
+	}
+	format := req.Format
+	if format == "" {
+		format = HerdBookFormatPDF
+	}
+	generations := defaultHerdBookGenerations
+	if req.Generations != nil {
+		generations = *req.Generations
+	}
+	fields := []models.HerdBookField{}
+	for _, field := range req.Fields {
+		fields = append(fields, models.HerdBookField{Key: field.Key, Label: strings.TrimSpace(field.Label)})
+	}
+	encoded, _ := json.Marshal(fields)
+
+	if create {
+		active := req.Active == nil || *req.Active
+		_, err := s.db.Exec(`
+			INSERT INTO herd_book_templates (id, association, title, species, format, generations, fields, declaration,
+			                                 active, created_at, updated_at)
+			VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, CURRENT_TIMESTAMP, CURRENT_TIMESTAMP)
+		`, id, strings.TrimSpace(req.Association), strings.TrimSpace(req.Title), species, format, generations,
+			string(encoded), strings.TrimSpace(req.Declaration), active)
+		return err
+	}
+
+	result, err := s.db.Exec(`
+		UPDATE herd_book_templates
+		SET association = ?, title = ?, species = ?, format = ?, generations = ?, fields = ?, declaration = ?,
+		    active = COALESCE(?, active), updated_at = CURRENT_TIMESTAMP
+		WHERE id = ?
+	`, strings.TrimSpace(req.Association), strings.TrimSpace(req.Title), species, format, generations,
+		string(encoded), strings.TrimSpace(req.Declaration), req.Active, id)
+	if err != nil {
+		return err
+	}
+	if affected, _ := result.RowsAffected(); affected == 0 {
+		return ErrHerdBookTemplateNotFound
+	}
+	return nil
+}
+
+// DeleteTemplate şablonu siler
+func (s *HerdBookService) DeleteTemplate(id string) error {
+	result, err := s.db.Exec("DELETE FROM herd_book_templates WHERE id = ?", id)
+	if err != nil {
+		return err
+	}
+	if affected, _ := result.RowsAffected(); affected == 0 {
+		return ErrHerdBookTemplateNotFound
+	}
+	return nil
+}
+
+// herdBookAnimal belgedeki hayvanın kaydı
+type herdBookAnimal struct {
+	id, tagNumber, species, breed, gender string
+	location, mother, father, notes       string
+	birthDate                             sql.NullTime
+	weight                                sql.NullFloat64
+}
+
+// Entries seçilen hayvanların şablon alanlarını ve şablonun kuşak sayısı kadar atalarını istek sırasıyla
+// döner. Bulunamayan hayvanlar ErrHerdBookAnimalNotFound, şablonun türünde olmayanlar ErrHerdBookSpecies
+// hatasıyla küpe numaraları döner
+func (s *HerdBookService) Entries(farmID string, template models.HerdBookTemplate, req models.HerdBookRequest, f Formatter) ([]models.HerdBookEntry, []string, error) {
+	var farm, owner string
+	s.db.QueryRow(`
+		SELECT COALESCE((SELECT name FROM farms WHERE id = ?), u.farm_name, ''), u.name
+		FROM users u WHERE u.id = COALESCE((SELECT user_id FROM farms WHERE id = ?), ?)
+	`, farmID, farmID, farmID).Scan(&farm, &owner)
+
+	speciesLabels := map[string]string{}
+	if categories, err := s.categories.List(farmID, models.CategoryDomainLivestock); err == nil {
+		for _, category := range categories {
+			speciesLabels[category.Key] = category.Label
+		}
+	}
+
+	var missing, mismatched []string
+	animals := make([]herdBookAnimal, 0, len(req.AnimalIDs))
+	for _, id := range req.AnimalIDs {
+		var animal herdBookAnimal
+		err := s.db.QueryRow(`
+			SELECT id, tag_number, type, COALESCE(breed, ''), COALESCE(gender, ''), birth_date, weight,
+			       COALESCE(location, ''), COALESCE(mother, ''), COALESCE(father, ''), COALESCE(notes, '')
+			FROM livestock WHERE id = ? AND user_id = ?
+		`, id, farmID).Scan(&animal.id, &animal.tagNumber, &animal.species, &animal.breed, &animal.gender,
+			&animal.birthDate, &animal.weight, &animal.location, &animal.mother, &animal.father, &animal.notes)
+		if err == sql.ErrNoRows {
+			missing = append(missing, id)
+			continue
+		}
+		if err != nil {
+			return nil, nil, err
+		}
+		if template.Species != "" && animal.species != template.Species {
+			mismatched = append(mismatched, animal.tagNumber)
+		}
+		animals = append(animals, animal)
+	}
+	if len(missing) > 0 {
+		return nil, missing, ErrHerdBookAnimalNotFound
+	}
+	if len(mismatched) > 0 {
+		return nil, mismatched, ErrHerdBookSpecies
+	}
+
+	entries := make([]models.HerdBookEntry, 0, len(animals))
+	for _, animal := range animals {
+		values := make([]string, 0, len(template.Fields))
+		for _, field := range template.Fields {
+			values = append(values, herdBookValue(field.Key, animal, speciesLabels, farm, owner, req.PremisesNumber, f))
+		}
+		entries = append(entries, models.HerdBookEntry{
+			AnimalID:  animal.id,
+			TagNumber: animal.tagNumber,
+			Values:    values,
+			Ancestors: s.ancestors(farmID, animal, template.Generations),
+		})
+	}
+	return entries, nil, nil
+}
+
+// herdBookValue hayvanın alan değerini belgeye yazılacak biçimde döner
+func herdBookValue(key string, animal herdBookAnimal, speciesLabels map[string]string, farm, owner, premisesNumber string, f Formatter) string {
+	switch key {
+	case models.HerdBookFieldTagNumber:
+		return animal.tagNumber
+	case models.HerdBookFieldSpecies:
+		return passportLabel(speciesLabels, animal.species)
+	case models.HerdBookFieldBreed:
+		return animal.breed
+	case models.HerdBookFieldGender:
+		return passportLabel(passportGenderLabels, animal.gender)
+	case models.HerdBookFieldBirthDate:
+		return f.OptionalDate(utils.NullTimeToPtr(animal.birthDate))
+	case models.HerdBookFieldWeight:
+		if !animal.weight.Valid {
+			return ""
+		}
+		return f.Number(animal.weight.Float64, 1) + " kg"
+	case models.HerdBookFieldLocation:
+		return animal.location
+	case models.HerdBookFieldMother:
+		return animal.mother
+	case models.HerdBookFieldFather:
+		return animal.father
+	case models.HerdBookFieldFarm:
+		return farm
+	case models.HerdBookFieldOwner:
+		return owner
+	case models.HerdBookFieldPremisesNumber:
+		return strings.TrimSpace(premisesNumber)
+	case models.HerdBookFieldNotes:
+		return animal.notes
+	}
+	return ""
+}
+
+// ancestors hayvanın atalarını kuşak kuşak babadan (S) önce anneden (D) olmak üzere döner; bilinmeyen
+// ataların ataları da bilinmiyor olarak yazılır
+func (s *HerdBookService) ancestors(farmID string, animal herdBookAnimal, generations int) []models.HerdBookAncestor {
+	type lineage struct {
+		code    string
+		parents [2]string
+	}
+	current := []lineage{{parents: [2]string{animal.mother, animal.father}}}
+
+	var ancestors []models.HerdBookAncestor
+	for generation := 1; generation <= generations; generation++ {
+		var next []lineage
+		for _, child := range current {
+			for _, side := range []struct {
+				code string
+				tag  string
+			}{{"S", child.parents[1]}, {"D", child.parents[0]}} {
+				code := child.code + side.code
+				ancestor, parents := s.passports.ancestor(farmID, side.tag)
+				ancestors = append(ancestors, models.HerdBookAncestor{Code: code, Label: herdBookAncestorLabel(code), Animal: ancestor})
+				next = append(next, lineage{code: code, parents: parents})
+			}
+		}
+		current = next
+	}
+	return ancestors
+}
+
+// herdBookAncestorLabel soy yolunu Türkçe yazar: S Baba, SD Babanın annesi, DSS Annenin babasının babası
+func herdBookAncestorLabel(code string) string {
+	words := make([]string, 0, len(code))
+	for i, side := range code {
+		last := i == len(code)-1
+		switch {
+		case i == 0 && side == 'S' && last:
+			words = append(words, "Baba")
+		case i == 0 && side == 'S':
+			words = append(words, "Babanın")
+		case i == 0 && last:
+			words = append(words, "Anne")
+		case i == 0:
+			words = append(words, "Annenin")
+		case side == 'S' && last:
+			words = append(words, "babası")
+		case side == 'S':
+			words = append(words, "babasının")
+		case last:
+			words = append(words, "annesi")
+		default:
+			words = append(words, "annesinin")
+		}
+	}
+	return strings.Join(words, " ")
+}
+
+// WriteHerdBookCSV her hayvanı bir satırda şablon alanları ve ataların küpe numarası, ırkı ve doğum tarihiyle yazar;
+// kullanıcının girdiği metinler formül olarak yazılmaz
+func WriteHerdBookCSV(w io.Writer, template models.HerdBookTemplate, entries []models.HerdBookEntry, f Formatter) error {
+	writer := csv.NewWriter(w)
+
+	header := make([]string, 0, len(template.Fields))
+	for _, field := range template.Fields {
+		header = append(header, SpreadsheetText(field.Label))
+	}
+	if len(entries) > 0 {
+		for _, ancestor := range entries[0].Ancestors {
+			header = append(header, ancestor.Code+" Küpe No", ancestor.Code+" Irk", ancestor.Code+" Doğum Tarihi")
+		}
+	}
+	if err := writer.Write(header); err != nil {
+		return err
+	}
+
+	for _, entry := range entries {
+		record := make([]string, 0, len(entry.Values)+3*len(entry.Ancestors))
+		for _, value := range entry.Values {
+			record = append(record, SpreadsheetText(value))
+		}
+		for _, ancestor := range entry.Ancestors {
+			if ancestor.Animal == nil {
+				record = append(record, "", "", "")
+				continue
+			}
+			record = append(record, SpreadsheetText(ancestor.Animal.TagNumber), SpreadsheetText(ancestor.Animal.Breed),
+				f.OptionalDate(ancestor.Animal.BirthDate))
+		}
+		if err := writer.Write(record); err != nil {
+			return err
+		}
+	}
+
+	writer.Flush()
+	return writer.Error()
+}
+
+// WriteHerdBookPDF her hayvan için bir sayfa yazar: birlik ve belge başlığı, şablon alanları, soy kütüğü tablosu,
+// birliğin beyan metni ve yetiştirici/birlik imza alanları
+func WriteHerdBookPDF(w io.Writer, template models.HerdBookTemplate, entries []models.HerdBookEntry, f Formatter, now time.Time) error {
+	doc := NewPDFDocument()
+	right := PDFPageWidth - passportMargin
+	contentWidth := right - passportMargin
+
+	for page, entry := range entries {
+		if page > 0 {
+			doc.AddPage()
+		}
+
+		// Başlık
+		doc.TextFit(passportMargin, 58, contentWidth, 11, false, template.Association)
+		doc.TextFit(passportMargin, 80, contentWidth-150, 18, true, strings.ToUpper(template.Title))
+		doc.TextRight(right, 80, 14, true, entry.TagNumber)
+		doc.TextRight(right, 94, 8, false, "Düzenleme tarihi: "+f.Date(now))
+		doc.Line(passportMargin, 102, right, 102, 1.5, 0)
+
+		// Hayvan bilgileri
+		columnWidth := contentWidth / 2
+		y := 120.0
+		for i, field := range template.Fields {
+			x := passportMargin + float64(i%2)*columnWidth
+			row := y + float64(i/2)*30
+			value := entry.Values[i]
+			if value == "" {
+				value = "-"
+			}
+			doc.Text(x, row, 8, false, field.Label)
+			doc.TextFit(x, row+13, columnWidth-10, 10.5, true, value)
+		}
+		y += float64((len(template.Fields)+1)/2)*30 + 6
+
+		// Soy kütüğü
+		y = passportSection(doc, y, "Soy Kütüğü")
+		rows := make([][]string, 0, len(entry.Ancestors))
+		for _, ancestor := range entry.Ancestors {
+			if ancestor.Animal == nil {
+				rows = append(rows, []string{ancestor.Code, ancestor.Label, "Bilinmiyor", "", ""})
+				continue
+			}
+			rows = append(rows, []string{
+				ancestor.Code, ancestor.Label, ancestor.Animal.TagNumber, ancestor.Animal.Breed,
+				f.OptionalDate(ancestor.Animal.BirthDate),
+			})
+		}
+		y = passportTable(doc, y, []passportColumn{
+			{"Kod", 40}, {"Ata", 190}, {"Küpe No", 120}, {"Irk", 85}, {"Doğum Tarihi", contentWidth - 435},
+		}, rows, len(rows), "Soy kaydı bulunmuyor", "ata")
+
+		// Beyan
+		if template.Declaration != "" {
+			y += 14
+			for _, line := range herdBookWrap(template.Declaration, contentWidth, 8.5) {
+				doc.Text(passportMargin, y, 8.5, false, line)
+				y += 12
+			}
+		}
+
+		// İmzalar
+		signatureTop := passportFooterTop - 50
+		if y+20 > signatureTop {
+			signatureTop = y + 20
+		}
+		signatureWidth := 180.0
+		for i, label := range []string{"Yetiştirici", "Birlik Onayı"} {
+			x := passportMargin + float64(i)*(contentWidth-signatureWidth)
+			doc.Line(x, signatureTop+30, x+signatureWidth, signatureTop+30, 0.5, 0.4)
+			doc.Text(x, signatureTop+42, 8, false, label)
+		}
+
+		// Alt bilgi
+		doc.Line(passportMargin, passportFooterTop+6, right, passportFooterTop+6, 0.5, 0.6)
+		doc.TextFit(passportMargin, passportFooterTop+18, contentWidth-150, 7, false,
+			"Bu belge çiftlik kayıtlarından "+f.DateTime(now)+" tarihinde oluşturulmuştur.")
+		doc.TextRight(right, passportFooterTop+18, 7, false, "Hayvan ID: "+entry.AnimalID)
+	}
+
+	return doc.Write(w)
+}
+
+// herdBookWrap metni verilen genişliğe sığan satırlara böler
+func herdBookWrap(text string, width, size float64) []string {
+	var lines []string
+	for _, paragraph := range strings.Split(text, "\n") {
+		line := ""
+		for _, word := range strings.Fields(paragraph) {
+			candidate := strings.TrimSpace(line + " " + word)
+			if line != "" && PDFTextWidth(candidate, size, false) > width {
+				lines = append(lines, line)
+				candidate = word
+			}
+			line = candidate
+		}
+		lines = append(lines, line)
+	}
+	return lines
+}
@@ -0,0 +1,215 @@
+package services
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"agri-management-api/internal/models"
+	"agri-management-api/internal/utils"
+)
+
+// Geçmişi tutulan varlık türleri
+const (
+	HistoryEntityLivestock = "livestock"
+	HistoryEntityLand      = "land"
+)
+
+// historyFields varlık türüne göre tablo ve izlenen alanlar (JSON alan adı -> sütun)
+var historyFields = map[string]struct {
+	table   string
+	columns [][2]string
+}{
+	HistoryEntityLivestock: {
+		table: "livestock",
+		columns: [][2]string{
+			{"tagNumber", "tag_number"}, {"type", "type"}, {"breed", "breed"}, {"gender", "gender"},
+			{"birthDate", "birth_date"}, {"weight", "weight"}, {"healthStatus", "health_status"},
+			{"location", "location"}, {"mother", "mother"}, {"father", "father"}, {"notes", "notes"},
+			{"enterpriseId", "enterprise_id"},
+		},
+	},
+	HistoryEntityLand: {
+		table: "lands",
+		columns: [][2]string{
+			{"name", "name"}, {"area", "area"}, {"unit", "unit"}, {"crop", "crop"}, {"status", "status"},
+			{"productivity", "productivity"}, {"latitude", "latitude"}, {"longitude", "longitude"},
+			{"address", "address"}, {"soilType", "soil_type"}, {"irrigationType", "irrigation_type"},
+			{"parcelBlock", "parcel_block"}, {"parcelNumber", "parcel_number"}, {"enterpriseId", "enterprise_id"},
+		},
+	},
+}
+
+// Executor *sql.DB ve *sql.Tx tarafından karşılanan sorgu arayüzü
+type Executor interface {
+	Exec(query string, args ...interface{}) (sql.Result, error)
+	QueryRow(query string, args ...interface{}) *sql.Row
+}
+
+// ChangeHistoryService varlıkların alan bazında değişiklik geçmişini tutar
+type ChangeHistoryService struct {
+	db *sql.DB
+}
+
+// NewChangeHistoryService yeni change history service oluşturur
+func NewChangeHistoryService(db *sql.DB) *ChangeHistoryService {
+	return &ChangeHistoryService{db: db}
+}
+
+// Snapshot çiftliğe ait varlığın izlenen alanlarının güncel değerlerini döner
+func (s *ChangeHistoryService) Snapshot(exec Executor, entityType, farmID, entityID string) (map[string]string, error) {
+	definition, ok := historyFields[entityType]
+	if !ok {
+		return nil, fmt.Errorf("unknown history entity type %q", entityType)
+	}
+
+	columns := make([]string, len(definition.columns))
+	values := make([]interface{}, len(definition.columns))
+	pointers := make([]interface{}, len(definition.columns))
+	for i, column := range definition.columns {
+		columns[i] = column[1]
+		pointers[i] = &values[i]
+	}
+
+	err := exec.QueryRow(
+		"SELECT "+strings.Join(columns, ", ")+" FROM "+definition.table+" WHERE id = ? AND user_id = ?", entityID, farmID,
+	).Scan(pointers...)
+	if err != nil {
+		return nil, err
+	}
+
+	snapshot := make(map[string]string, len(values))
+	for i, column := range definition.columns {
+		snapshot[column[0]] = historyValue(values[i])
+	}
+	return snapshot, nil
+}
+
+// Record önceki ve sonraki görüntü arasındaki farkları çiftliğin tek bir değişiklik seti olarak kaydeder
+func (s *ChangeHistoryService) Record(exec Executor, entityType, farmID, entityID, changedBy string, before, after map[string]string) error {
+	definition, ok := historyFields[entityType]
+	if !ok {
+		return fmt.Errorf("unknown history entity type %q", entityType)
+	}
+
+	changeSetID := utils.GenerateID()
+	for _, column := range definition.columns {
+		field := column[0]
+		if before[field] == after[field] {
+			continue
+		}
+
+		_, err := exec.Exec(`
+			INSERT INTO entity_changes (id, user_id, change_set_id, entity_type, entity_id, field, old_value, new_value, changed_by, changed_at)
+			VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, CURRENT_TIMESTAMP)
+		`, utils.GenerateID(), farmID, changeSetID, entityType, entityID, field, before[field], after[field], changedBy)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// Track güncellemeyi çalıştırır ve öncesi/sonrası arasındaki farkları kaydeder; varlık yoksa yalnızca güncelleme çalışır
+func (s *ChangeHistoryService) Track(exec Executor, entityType, farmID, entityID, changedBy string, update func() error) error {
+	before, err := s.Snapshot(exec, entityType, farmID, entityID)
+	if err != nil && !errors.Is(err, sql.ErrNoRows) {
+		return err
+	}
+
+	if err := update(); err != nil {
+		return err
+	}
+	if before == nil {
+		return nil
+	}
+
+	after, err := s.Snapshot(exec, entityType, farmID, entityID)
+	if err != nil {
+		return err
+	}
+	return s.Record(exec, entityType, farmID, entityID, changedBy, before, after)
+}
+
+// RemoveEntity silinen varlığın değişiklik geçmişini siler
+func (s *ChangeHistoryService) RemoveEntity(farmID, entityType, entityID string) error {
+	_, err := s.db.Exec(
+		"DELETE FROM entity_changes WHERE user_id = ? AND entity_type = ? AND entity_id = ?", farmID, entityType, entityID,
+	)
+	return err
+}
+
+// History çiftliğe ait varlığın değişikliklerini en yeniden eskiye sayfalı olarak döner; field boş değilse
+// yalnızca o alan listelenir
+func (s *ChangeHistoryService) History(farmID, entityType, entityID, field string, page, limit int) ([]models.EntityChange, int, error) {
+	whereClause := "WHERE ec.user_id = ? AND ec.entity_type = ? AND ec.entity_id = ?"
+	args := []interface{}{farmID, entityType, entityID}
+	if field != "" {
+		whereClause += " AND ec.field = ?"
+		args = append(args, field)
+	}
+
+	var total int
+	if err := s.db.QueryRow("SELECT COUNT(*) FROM entity_changes ec "+whereClause, args...).Scan(&total); err != nil {
+		return nil, 0, err
+	}
+
+	args = append(args, limit, (page-1)*limit)
+	rows, err := s.db.Query(`
+		SELECT ec.id, ec.change_set_id, ec.entity_type, ec.entity_id, ec.field,
+		       COALESCE(ec.old_value, ''), COALESCE(ec.new_value, ''), ec.changed_by, COALESCE(u.name, ''), ec.changed_at
+		FROM entity_changes ec
+		LEFT JOIN users u ON u.id = COALESCE((SELECT f.user_id FROM farms f WHERE f.id = ec.changed_by), ec.changed_by)
+		`+whereClause+`
+		ORDER BY ec.changed_at DESC, ec.rowid DESC
+		LIMIT ? OFFSET ?
+	`, args...)
+	if err != nil {
+		return nil, 0, err
+	}
+	defer rows.Close()
+
+	changes := []models.EntityChange{}
+	for rows.Next() {
+		var change models.EntityChange
+		err := rows.Scan(
+			&change.ID, &change.ChangeSetID, &change.EntityType, &change.EntityID, &change.Field,
+			&change.OldValue, &change.NewValue, &change.ChangedBy.ID, &change.ChangedBy.Name, &change.ChangedAt,
+		)
+		if err != nil {
+			continue
+		}
+		changes = append(changes, change)
+	}
+
+	return changes, total, nil
+}
+
+// historyValue sütun değerini karşılaştırılabilir metne çevirir
+func historyValue(value interface{}) string {
+	switch v := value.(type) {
+	case nil:
+		return ""
+	case []byte:
+		return string(v)
+	case string:
+		return v
+	case int64:
+		return strconv.FormatInt(v, 10)
+	case float64:
+		return strconv.FormatFloat(v, 'f', -1, 64)
+	case bool:
+		return strconv.FormatBool(v)
+	case time.Time:
+		if v.Hour() == 0 && v.Minute() == 0 && v.Second() == 0 {
+			return v.Format("2006-01-02")
+		}
+		return v.Format(time.RFC3339)
+	default:
+		return fmt.Sprint(v)
+	}
+}
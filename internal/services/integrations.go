@@ -0,0 +1,326 @@
+package services
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"database/sql"
+	"encoding/hex"
+	"errors"
+	"time"
+
+	"agri-management-api/internal/models"
+	"agri-management-api/internal/utils"
+)
+
+// IntegrationKeyPrefix entegrasyon anahtarlarının başlangıcı; sızan anahtarların taramalarda tanınmasını sağlar
+const IntegrationKeyPrefix = "agk_"
+
+// Tetikleyici sayfa boyutları
+const (
+	DefaultIntegrationLimit = 50
+	MaxIntegrationLimit     = 100
+)
+
+// ErrIntegrationKeyNotFound anahtar bulunamadı veya iptal edilmiş
+var ErrIntegrationKeyNotFound = errors.New("integration key not found")
+
+// integrationTriggers tanımlı yoklama tetikleyicileri; örnek öğeler dokümantasyonla aynı alanları taşır
+var integrationTriggers = []models.IntegrationTrigger{
+	{
+		Key:         "new-transactions",
+		Name:        "Yeni Finans İşlemi",
+		Description: "Gelir veya gider işlemi eklendiğinde tetiklenir",
+		Route:       "/api/v1/integrations/new-transactions",
+		Sample: models.IntegrationTransaction{
+			ID: "7c1e5f0a-2b7d-4d0e-9a55-1f0c2d3e4b5a", Type: "expense", Category: "feed", Description: "Yem alımı",
+			Amount: 1250.5, Currency: "TRY", Date: "2025-03-01", Status: "completed", PaymentMethod: "bank_transfer",
+			CreatedAt: time.Date(2025, 3, 1, 9, 30, 0, 0, time.UTC),
+			Meta:      models.IntegrationTriggerMeta{ID: "7c1e5f0a-2b7d-4d0e-9a55-1f0c2d3e4b5a", Timestamp: 1740821400},
+		},
+	},
+	{
+		Key:         "new-animals",
+		Name:        "Yeni Hayvan",
+		Description: "Sürüye hayvan eklendiğinde tetiklenir",
+		Route:       "/api/v1/integrations/new-animals",
+		Sample: models.IntegrationAnimal{
+			ID: "0b6f3c2e-8d41-4c55-a2f1-6e7d8c9b0a12", TagNumber: "TR4100012345", Type: "cattle", Breed: "Holstein",
+			Gender: "female", BirthDate: "2025-02-14", HealthStatus: "healthy", Location: "Ahır 1",
+			CreatedAt: time.Date(2025, 3, 1, 9, 30, 0, 0, time.UTC),
+			Meta:      models.IntegrationTriggerMeta{ID: "0b6f3c2e-8d41-4c55-a2f1-6e7d8c9b0a12", Timestamp: 1740821400},
+		},
+	},
+	{
+		Key:         "new-events",
+		Name:        "Yeni Takvim Etkinliği",
+		Description: "Takvime etkinlik eklendiğinde tetiklenir",
+		Route:       "/api/v1/integrations/new-events",
+		Sample: models.IntegrationEvent{
+			ID: "5a9d2e7b-3c1f-4b8a-9e6d-2f4a6c8e0b1d", Title: "Sulama - Kuzey Tarla", Type: "irrigation",
+			StartDate: time.Date(2025, 3, 2, 0, 0, 0, 0, time.UTC), IsAllDay: true, Status: "pending", Priority: "high",
+			CreatedAt: time.Date(2025, 3, 1, 9, 30, 0, 0, time.UTC),
+			Meta:      models.IntegrationTriggerMeta{ID: "5a9d2e7b-3c1f-4b8a-9e6d-2f4a6c8e0b1d", Timestamp: 1740821400},
+		},
+	},
+	{
+		Key:         "new-harvests",
+		Name:        "Yeni Hasat",
+		Description: "Üretim (hasat) kaydı eklendiğinde tetiklenir",
+		Route:       "/api/v1/integrations/new-harvests",
+		Sample: models.IntegrationHarvest{
+			ID: "e3b0c442-98fc-4c14-9afb-f4c8996fb924", Name: "Buğday", Category: "grains", Amount: 4200, Unit: "kg",
+			HarvestDate: "2025-07-10", LandName: "Kuzey Tarla",
+			CreatedAt: time.Date(2025, 7, 10, 16, 0, 0, 0, time.UTC),
+			Meta:      models.IntegrationTriggerMeta{ID: "e3b0c442-98fc-4c14-9afb-f4c8996fb924", Timestamp: 1752163200},
+		},
+	},
+}
+
+// IntegrationTriggers tanımlı tetikleyicileri örnek öğeleriyle döner
+func IntegrationTriggers() []models.IntegrationTrigger {
+	return integrationTriggers
+}
+
+// IntegrationService otomasyon araçları için API anahtarlarını ve yoklama tetikleyicilerini yönetir
+type IntegrationService struct {
+	db *sql.DB
+}
+
+// NewIntegrationService yeni integration service oluşturur
+func NewIntegrationService(db *sql.DB) *IntegrationService {
+	return &IntegrationService{db: db}
+}
+
+// hashIntegrationKey anahtarın saklanan SHA-256 özetini döner; anahtarın kendisi saklanmaz
+func hashIntegrationKey(key string) string {
+	sum := sha256.Sum256([]byte(key))
+	return hex.EncodeToString(sum[:])
+}
+
+// CreateKey çiftlik için yeni anahtar üretir; anahtar yalnızca bu yanıtta döner
+func (s *IntegrationService) CreateKey(farmID, accountID, name string) (models.IntegrationKey, error) {
+	secret := make([]byte, 20)
+	if _, err := rand.Read(secret); err != nil {
+		return models.IntegrationKey{}, err
+	}
+
+	key := models.IntegrationKey{
+		ID:        utils.GenerateID(),
+		Name:      name,
+		Key:       IntegrationKeyPrefix + hex.EncodeToString(secret),
+		CreatedBy: accountID,
+		CreatedAt: time.Now(),
+	}
+	key.Prefix = key.Key[:len(IntegrationKeyPrefix)+6]
+
+	_, err := s.db.Exec(`
+		INSERT INTO integration_keys (id, user_id, name, key_prefix, key_hash, created_by, created_at)
+		VALUES (?, ?, ?, ?, ?, ?, ?)
+	`, key.ID, farmID, key.Name, key.Prefix, hashIntegrationKey(key.Key), accountID, key.CreatedAt)
+	return key, err
+}
+
+// ListKeys çiftliğin iptal edilmemiş anahtarlarını döner
+func (s *IntegrationService) ListKeys(farmID string) ([]models.IntegrationKey, error) {
+	rows, err := s.db.Query(`
+		SELECT id, name, key_prefix, COALESCE(created_by, ''), last_used_at, created_at
+		FROM integration_keys
+		WHERE user_id = ? AND revoked_at IS NULL
+		ORDER BY created_at DESC
+	`, farmID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	keys := []models.IntegrationKey{}
+	for rows.Next() {
+		var key models.IntegrationKey
+		var lastUsed sql.NullTime
+		if err := rows.Scan(&key.ID, &key.Name, &key.Prefix, &key.CreatedBy, &lastUsed, &key.CreatedAt); err != nil {
+			return nil, err
+		}
+		key.LastUsedAt = utils.NullTimeToPtr(lastUsed)
+		keys = append(keys, key)
+	}
+	return keys, rows.Err()
+}
+
+// RevokeKey anahtarı iptal eder; iptal edilen anahtarla yapılan istekler reddedilir
+func (s *IntegrationService) RevokeKey(farmID, keyID string) error {
+	result, err := s.db.Exec(`
+		UPDATE integration_keys SET revoked_at = CURRENT_TIMESTAMP
+		WHERE id = ? AND user_id = ? AND revoked_at IS NULL
+	`, keyID, farmID)
+	if err != nil {
+		return err
+	}
+	if affected, _ := result.RowsAffected(); affected == 0 {
+		return ErrIntegrationKeyNotFound
+	}
+	return nil
+}
+
+// Authenticate anahtarın bağlı olduğu çiftliği, anahtarı oluşturan hesabı ve anahtar adını döner; son kullanım
+// zamanı güncellenir
+func (s *IntegrationService) Authenticate(key string) (farmID, accountID, keyName string, err error) {
+	var keyID string
+	err = s.db.QueryRow(`
+		SELECT id, user_id, COALESCE(created_by, user_id), name
+		FROM integration_keys
+		WHERE key_hash = ? AND revoked_at IS NULL
+	`, hashIntegrationKey(key)).Scan(&keyID, &farmID, &accountID, &keyName)
+	if err == sql.ErrNoRows {
+		return "", "", "", ErrIntegrationKeyNotFound
+	}
+	if err != nil {
+		return "", "", "", err
+	}
+
+	s.db.Exec("UPDATE integration_keys SET last_used_at = CURRENT_TIMESTAMP WHERE id = ?", keyID)
+	return farmID, accountID, keyName, nil
+}
+
+// Account anahtarın bağlı olduğu çiftliğin adını döner
+func (s *IntegrationService) Account(farmID, keyName string) (models.IntegrationAccount, error) {
+	account := models.IntegrationAccount{FarmID: farmID, KeyName: keyName}
+	err := s.db.QueryRow("SELECT name FROM farms WHERE id = ?", farmID).Scan(&account.FarmName)
+	if err == sql.ErrNoRows {
+		err = s.db.QueryRow("SELECT COALESCE(NULLIF(farm_name, ''), name) FROM users WHERE id = ?", farmID).Scan(&account.FarmName)
+	}
+	return account, err
+}
+
+// triggerMeta IFTTT'nin beklediği öğe meta bilgisini oluşturur
+func triggerMeta(id string, createdAt time.Time) models.IntegrationTriggerMeta {
+	return models.IntegrationTriggerMeta{ID: id, Timestamp: createdAt.Unix()}
+}
+
+// sinceArgs since verilmişse oluşturulma zamanı filtresini ve argümanını döner
+func sinceArgs(column string, since *time.Time) (string, []interface{}) {
+	if since == nil {
+		return "", nil
+	}
+	return " AND datetime(" + column + ") > datetime(?)", []interface{}{since.UTC().Format("2006-01-02 15:04:05")}
+}
+
+// Transactions since'ten sonra eklenen işlemleri en yeniden başlayarak döner
+func (s *IntegrationService) Transactions(farmID string, since *time.Time, limit int) ([]models.IntegrationTransaction, error) {
+	filter, args := sinceArgs("created_at", since)
+	rows, err := s.db.Query(`
+		SELECT id, type, category, description, amount, COALESCE(NULLIF(currency, ''), 'TRY'), date(date),
+		       COALESCE(status, 'completed'), COALESCE(payment_method, ''), created_at
+		FROM transactions
+		WHERE user_id = ?`+filter+`
+		ORDER BY created_at DESC, id DESC
+		LIMIT ?
+	`, append(append([]interface{}{farmID}, args...), limit)...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	items := []models.IntegrationTransaction{}
+	for rows.Next() {
+		var item models.IntegrationTransaction
+		err := rows.Scan(&item.ID, &item.Type, &item.Category, &item.Description, &item.Amount, &item.Currency,
+			&item.Date, &item.Status, &item.PaymentMethod, &item.CreatedAt)
+		if err != nil {
+			return nil, err
+		}
+		item.Meta = triggerMeta(item.ID, item.CreatedAt)
+		items = append(items, item)
+	}
+	return items, rows.Err()
+}
+
+// Animals since'ten sonra eklenen hayvanları en yeniden başlayarak döner
+func (s *IntegrationService) Animals(farmID string, since *time.Time, limit int) ([]models.IntegrationAnimal, error) {
+	filter, args := sinceArgs("created_at", since)
+	rows, err := s.db.Query(`
+		SELECT id, tag_number, type, COALESCE(breed, ''), COALESCE(gender, ''), COALESCE(date(birth_date), ''),
+		       COALESCE(NULLIF(health_status, ''), 'healthy'), COALESCE(location, ''), created_at
+		FROM livestock
+		WHERE user_id = ?`+filter+`
+		ORDER BY created_at DESC, id DESC
+		LIMIT ?
+	`, append(append([]interface{}{farmID}, args...), limit)...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	items := []models.IntegrationAnimal{}
+	for rows.Next() {
+		var item models.IntegrationAnimal
+		err := rows.Scan(&item.ID, &item.TagNumber, &item.Type, &item.Breed, &item.Gender, &item.BirthDate,
+			&item.HealthStatus, &item.Location, &item.CreatedAt)
+		if err != nil {
+			return nil, err
+		}
+		item.Meta = triggerMeta(item.ID, item.CreatedAt)
+		items = append(items, item)
+	}
+	return items, rows.Err()
+}
+
+// Events since'ten sonra eklenen takvim etkinliklerini en yeniden başlayarak döner
+func (s *IntegrationService) Events(farmID string, since *time.Time, limit int) ([]models.IntegrationEvent, error) {
+	filter, args := sinceArgs("created_at", since)
+	rows, err := s.db.Query(`
+		SELECT id, title, type, start_date, COALESCE(is_all_day, FALSE), COALESCE(status, 'pending'),
+		       COALESCE(priority, 'medium'), COALESCE(location, ''), created_at
+		FROM events
+		WHERE user_id = ?`+filter+`
+		ORDER BY created_at DESC, id DESC
+		LIMIT ?
+	`, append(append([]interface{}{farmID}, args...), limit)...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	items := []models.IntegrationEvent{}
+	for rows.Next() {
+		var item models.IntegrationEvent
+		err := rows.Scan(&item.ID, &item.Title, &item.Type, &item.StartDate, &item.IsAllDay, &item.Status,
+			&item.Priority, &item.Location, &item.CreatedAt)
+		if err != nil {
+			return nil, err
+		}
+		item.Meta = triggerMeta(item.ID, item.CreatedAt)
+		items = append(items, item)
+	}
+	return items, rows.Err()
+}
+
+// Harvests since'ten sonra eklenen üretim kayıtlarını arazi adlarıyla en yeniden başlayarak döner
+func (s *IntegrationService) Harvests(farmID string, since *time.Time, limit int) ([]models.IntegrationHarvest, error) {
+	filter, args := sinceArgs("p.created_at", since)
+	rows, err := s.db.Query(`
+		SELECT p.id, p.name, p.category, p.amount, p.unit, COALESCE(date(p.harvest_date), ''),
+		       COALESCE(l.name, ''), p.created_at
+		FROM production p
+		LEFT JOIN lands l ON l.id = p.land_id AND l.user_id = p.user_id
+		WHERE p.user_id = ?`+filter+`
+		ORDER BY p.created_at DESC, p.id DESC
+		LIMIT ?
+	`, append(append([]interface{}{farmID}, args...), limit)...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	items := []models.IntegrationHarvest{}
+	for rows.Next() {
+		var item models.IntegrationHarvest
+		err := rows.Scan(&item.ID, &item.Name, &item.Category, &item.Amount, &item.Unit, &item.HarvestDate,
+			&item.LandName, &item.CreatedAt)
+		if err != nil {
+			return nil, err
+		}
+		item.Meta = triggerMeta(item.ID, item.CreatedAt)
+		items = append(items, item)
+	}
+	return items, rows.Err()
+}
@@ -0,0 +1,625 @@
+package services
+
+import (
+	"bytes"
+	"database/sql"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"agri-management-api/internal/models"
+	"agri-management-api/internal/utils"
+)
+
+// Fatura hataları
+var (
+	ErrInvoiceNotFound = errors.New("invoice not found")
+	// ErrInvoiceSource faturanın kaynağı olarak işlemler ve sipariş birlikte verildi veya hiçbiri verilmedi
+	ErrInvoiceSource = errors.New("transactionIds veya salesOrderId'den yalnızca biri verilmeli")
+	// ErrInvoiceTransactionNotFound faturalanacak işlemlerden biri bulunamadı
+	ErrInvoiceTransactionNotFound = errors.New("transaction not found")
+	// ErrInvoiceNotBillable gider veya iptal edilmiş işlem faturalanamaz
+	ErrInvoiceNotBillable = errors.New("yalnızca iptal edilmemiş gelir işlemleri faturalandırılabilir")
+	// ErrInvoiceCurrencyMismatch faturadaki işlemlerin para birimleri farklı
+	ErrInvoiceCurrencyMismatch = errors.New("faturadaki işlemler aynı para biriminde olmalı")
+	// ErrInvoiceAlreadyInvoiced işlemin veya siparişin faturası daha önce kesilmiş
+	ErrInvoiceAlreadyInvoiced = errors.New("already invoiced")
+	// ErrInvoiceOrderCancelled iptal edilmiş siparişin faturası kesilemez
+	ErrInvoiceOrderCancelled = errors.New("iptal edilmiş sipariş faturalandırılamaz")
+	// ErrInvoiceInvalidDueDate vade tarihi fatura tarihinden önce
+	ErrInvoiceInvalidDueDate = errors.New("Vade tarihi fatura tarihinden önce olamaz")
+)
+
+// InvoiceService gelir işlemlerinden veya satış siparişlerinden numaralı fatura keser. Faturanın PDF'i medya
+// deposunda saklanır ve fatura numarası bağlı işlemlerin fiş numarası olur
+type InvoiceService struct {
+	db      *sql.DB
+	store   MediaStore
+	formats *FormattingService
+	sales   *SalesService
+}
+
+// NewInvoiceService yeni invoice service oluşturur
+func NewInvoiceService(db *sql.DB) *InvoiceService {
+	return &InvoiceService{
+		db:      db,
+		store:   NewMediaStore(),
+		formats: NewFormattingService(db),
+		sales:   NewSalesService(db),
+	}
+}
+
+// invoiceStorageKey faturanın PDF dosyasının depolama anahtarı
+func invoiceStorageKey(farmID, invoiceID string) string {
+	return "invoices/" + farmID + "/" + invoiceID + ".pdf"
+}
+
+// invoiceSelect fatura sütunları
+const invoiceSelect = `
+	SELECT id, invoice_number, sales_order_id, customer_id, COALESCE(buyer_name, ''), COALESCE(buyer_tax_id, ''),
+	       COALESCE(buyer_address, ''), issue_date, due_date, currency, subtotal, tax_amount, total, lines,
+	       COALESCE(notes, ''), created_at
+	FROM invoices`
+
+// scanInvoice fatura satırını okur; alıcının vergi numarası ve adresi çözülür
+func scanInvoice(scanner interface{ Scan(...interface{}) error }) (models.Invoice, error) {
+	var invoice models.Invoice
+	var salesOrderID, customerID sql.NullString
+	var dueDate sql.NullTime
+	var lines string
+	err := scanner.Scan(&invoice.ID, &invoice.InvoiceNumber, &salesOrderID, &customerID, &invoice.BuyerName,
+		&invoice.BuyerTaxID, &invoice.BuyerAddress, &invoice.IssueDate, &dueDate, &invoice.Currency,
+		&invoice.Subtotal, &invoice.TaxAmount, &invoice.Total, &lines, &invoice.Notes, &invoice.CreatedAt)
+	if err != nil {
+		return invoice, err
+	}
+
+	if salesOrderID.Valid {
+		invoice.SalesOrderID = &salesOrderID.String
+	}
+	if customerID.Valid {
+		invoice.CustomerID = &customerID.String
+	}
+	if dueDate.Valid {
+		invoice.DueDate = &dueDate.Time
+	}
+	invoice.BuyerTaxID = RevealField(ColumnInvoiceBuyerTaxID, invoice.BuyerTaxID)
+	invoice.BuyerAddress = RevealField(ColumnInvoiceBuyerAddress, invoice.BuyerAddress)
+	if err := utils.FromJSON(lines, &invoice.Lines); err != nil {
+		return invoice, err
+	}
+	invoice.TransactionIDs = []string{}
+	invoice.DownloadURL = "/api/v1/finance/invoices/" + invoice.ID + "/pdf"
+	return invoice, nil
+}
+
+// transactionIDs faturaya bağlı işlemleri okur
+func (s *InvoiceService) transactionIDs(farmID string, invoice *models.Invoice) error {
+	rows, err := s.db.Query("SELECT transaction_id FROM invoice_transactions WHERE invoice_id = ? AND user_id = ? ORDER BY rowid",
+		invoice.ID, farmID)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var id string
+		if err := rows.Scan(&id); err != nil {
+			return err
+		}
+		invoice.TransactionIDs = append(invoice.TransactionIDs, id)
+	}
+	return rows.Err()
+}
+
+// Invoices çiftliğin faturalarını yeniden eskiye sayfalı döner
+func (s *InvoiceService) Invoices(farmID string, filter models.InvoiceFilter) (models.InvoiceListResponse, error) {
+	where := " WHERE user_id = ?"
+	args := []interface{}{farmID}
+	if filter.CustomerID != "" {
+		where += " AND customer_id = ?"
+		args = append(args, filter.CustomerID)
+	}
+	if filter.StartDate != nil {
+		where += " AND date(issue_date) >= ?"
+		args = append(args, filter.StartDate.Format("2006-01-02"))
+	}
+	if filter.EndDate != nil {
+		where += " AND date(issue_date) <= ?"
+		args = append(args, filter.EndDate.Format("2006-01-02"))
+	}
+
+	response := models.InvoiceListResponse{Invoices: []models.Invoice{}}
+	var total int
+	if err := s.db.QueryRow("SELECT COUNT(*) FROM invoices"+where, args...).Scan(&total); err != nil {
+		return response, err
+	}
+	response.Pagination = utils.CalculatePagination(filter.Page, filter.Limit, total)
+
+	rows, err := s.db.Query(invoiceSelect+where+" ORDER BY issue_date DESC, invoice_number DESC LIMIT ? OFFSET ?",
+		append(args, filter.Limit, (filter.Page-1)*filter.Limit)...)
+	if err != nil {
+		return response, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		invoice, err := scanInvoice(rows)
+		if err != nil {
+			return response, err
+		}
+		response.Invoices = append(response.Invoices, invoice)
+	}
+	if err := rows.Err(); err != nil {
+		return response, err
+	}
+	rows.Close()
+
+	for i := range response.Invoices {
+		if err := s.transactionIDs(farmID, &response.Invoices[i]); err != nil {
+			return response, err
+		}
+	}
+	return response, nil
+}
+
+// Invoice çiftliğin faturasını bağlı işlemleriyle döner
+func (s *InvoiceService) Invoice(farmID, id string) (*models.Invoice, error) {
+	invoice, err := scanInvoice(s.db.QueryRow(invoiceSelect+" WHERE id = ? AND user_id = ?", id, farmID))
+	if err == sql.ErrNoRows {
+		return nil, ErrInvoiceNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	if err := s.transactionIDs(farmID, &invoice); err != nil {
+		return nil, err
+	}
+	return &invoice, nil
+}
+
+// File faturanın saklanan PDF dosyasını açar
+func (s *InvoiceService) File(farmID, id string) (*models.Invoice, io.ReadCloser, error) {
+	invoice, err := s.Invoice(farmID, id)
+	if err != nil {
+		return nil, nil, err
+	}
+	file, err := s.store.Open(invoiceStorageKey(farmID, invoice.ID))
+	if err != nil {
+		return invoice, nil, err
+	}
+	return invoice, file, nil
+}
+
+// Create gelir işlemlerinden veya satış siparişinden fatura keser. Fatura numarası çiftliğin o yıldaki sıradaki
+// numarasıdır (FT-2024-0001); PDF saklanır ve numara bağlı işlemlerin fiş numarasına yazılır. Henüz ödenmemiş
+// siparişin faturası, sipariş ödendiğinde oluşturulan gelir işlemine bağlanır. Bulunamayan, faturalanamayan veya
+// faturası kesilmiş işlemlerin kimlikleri hatayla birlikte döner
+func (s *InvoiceService) Create(farmID string, req models.InvoiceRequest) (*models.Invoice, []string, error) {
+	seen := map[string]bool{}
+	ids := []string{}
+	for _, id := range req.TransactionIDs {
+		if id = strings.TrimSpace(id); id != "" && !seen[id] {
+			seen[id] = true
+			ids = append(ids, id)
+		}
+	}
+	req.SalesOrderID = strings.TrimSpace(req.SalesOrderID)
+	if (len(ids) > 0) == (req.SalesOrderID != "") {
+		return nil, nil, ErrInvoiceSource
+	}
+
+	now := time.Now()
+	invoice := models.Invoice{ID: utils.GenerateID(), IssueDate: now, DueDate: req.DueDate, Notes: strings.TrimSpace(req.Notes)}
+	if req.IssueDate != nil {
+		invoice.IssueDate = *req.IssueDate
+	}
+	if invoice.DueDate != nil && invoice.DueDate.Before(invoice.IssueDate.Truncate(24*time.Hour)) {
+		return nil, nil, ErrInvoiceInvalidDueDate
+	}
+
+	var details []string
+	var err error
+	if req.SalesOrderID != "" {
+		details, err = s.fromOrder(farmID, req.SalesOrderID, &invoice)
+	} else {
+		details, err = s.fromTransactions(farmID, ids, req.CustomerID, &invoice)
+	}
+	if err != nil {
+		return nil, details, err
+	}
+	if len(invoice.TransactionIDs) > 0 {
+		if invoiced, err := s.invoicedTransactions(farmID, invoice.TransactionIDs); err != nil || len(invoiced) > 0 {
+			if err == nil {
+				err = ErrInvoiceAlreadyInvoiced
+			}
+			return nil, invoiced, err
+		}
+	}
+
+	for _, line := range invoice.Lines {
+		invoice.Subtotal += line.Subtotal
+		invoice.TaxAmount += line.TaxAmount
+		invoice.Total += line.Total
+	}
+	invoice.Subtotal, invoice.TaxAmount, invoice.Total = round2(invoice.Subtotal), round2(invoice.TaxAmount), round2(invoice.Total)
+
+	if err := s.save(farmID, &invoice); err != nil {
+		return nil, nil, err
+	}
+	created, err := s.Invoice(farmID, invoice.ID)
+	return created, nil, err
+}
+
+// fromOrder siparişin kalemini, müşterisini ve ödenmişse gelir işlemini faturaya ekler
+func (s *InvoiceService) fromOrder(farmID, orderID string, invoice *models.Invoice) ([]string, error) {
+	order, err := s.sales.Order(farmID, orderID)
+	if err != nil {
+		return nil, err
+	}
+	if order.PaymentStatus == models.SalesOrderCancelled {
+		return nil, ErrInvoiceOrderCancelled
+	}
+
+	var number string
+	err = s.db.QueryRow("SELECT invoice_number FROM invoices WHERE sales_order_id = ? AND user_id = ?", order.ID, farmID).Scan(&number)
+	if err == nil {
+		return []string{number}, ErrInvoiceAlreadyInvoiced
+	}
+	if err != sql.ErrNoRows {
+		return nil, err
+	}
+
+	if err := s.setBuyer(farmID, order.CustomerID, invoice); err != nil {
+		return nil, err
+	}
+	invoice.SalesOrderID = &order.ID
+	invoice.Currency = order.Currency
+	line := models.InvoiceLine{
+		Description: order.ProductName + " (" + order.OrderNumber + ")",
+		Quantity:    order.Quantity,
+		Unit:        order.Unit,
+		UnitPrice:   order.UnitPrice,
+		Subtotal:    order.Subtotal,
+		TaxRate:     order.TaxRate,
+		TaxAmount:   order.TaxAmount,
+		Total:       order.Total,
+	}
+	if order.TransactionID != nil {
+		line.TransactionID = *order.TransactionID
+		invoice.TransactionIDs = []string{*order.TransactionID}
+	}
+	invoice.Lines = []models.InvoiceLine{line}
+	if invoice.DueDate == nil {
+		invoice.DueDate = order.DueDate
+	}
+	return nil, nil
+}
+
+// fromTransactions gelir işlemlerini istenen sırayla faturanın kalemleri yapar; tutarlar vergi dahil kabul edilir
+func (s *InvoiceService) fromTransactions(farmID string, ids []string, customerID string, invoice *models.Invoice) ([]string, error) {
+	args := []interface{}{farmID}
+	for _, id := range ids {
+		args = append(args, id)
+	}
+	rows, err := s.db.Query(`
+		SELECT id, type, COALESCE(status, ''), COALESCE(category, ''), COALESCE(description, ''), amount, COALESCE(NULLIF(currency, ''), 'TRY')
+		FROM transactions
+		WHERE user_id = ? AND id IN (?`+strings.Repeat(", ?", len(ids)-1)+`)
+	`, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	type billed struct {
+		kind, status, currency string
+		line                   models.InvoiceLine
+	}
+	found := map[string]billed{}
+	for rows.Next() {
+		var id, category string
+		var item billed
+		if err := rows.Scan(&id, &item.kind, &item.status, &category, &item.line.Description, &item.line.UnitPrice, &item.currency); err != nil {
+			return nil, err
+		}
+		if item.line.Description == "" {
+			item.line.Description = category
+		}
+		item.line.Quantity = 1
+		item.line.UnitPrice = round2(item.line.UnitPrice)
+		item.line.Subtotal, item.line.Total = item.line.UnitPrice, item.line.UnitPrice
+		item.line.TransactionID = id
+		found[id] = item
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	var missing, notBillable []string
+	for _, id := range ids {
+		item, ok := found[id]
+		switch {
+		case !ok:
+			missing = append(missing, id)
+		case item.kind != "income" || item.status == "cancelled":
+			notBillable = append(notBillable, id)
+		}
+	}
+	if len(missing) > 0 {
+		return missing, ErrInvoiceTransactionNotFound
+	}
+	if len(notBillable) > 0 {
+		return notBillable, ErrInvoiceNotBillable
+	}
+
+	invoice.Currency = found[ids[0]].currency
+	for _, id := range ids {
+		item := found[id]
+		if item.currency != invoice.Currency {
+			return []string{invoice.Currency, item.currency}, ErrInvoiceCurrencyMismatch
+		}
+		invoice.Lines = append(invoice.Lines, item.line)
+		invoice.TransactionIDs = append(invoice.TransactionIDs, id)
+	}
+
+	if customerID = strings.TrimSpace(customerID); customerID != "" {
+		return nil, s.setBuyer(farmID, customerID, invoice)
+	}
+	return nil, nil
+}
+
+// setBuyer müşterinin bilgilerini faturanın alıcısı yapar
+func (s *InvoiceService) setBuyer(farmID, customerID string, invoice *models.Invoice) error {
+	customer, err := s.sales.Customer(farmID, customerID)
+	if err != nil {
+		return err
+	}
+	invoice.CustomerID = &customer.ID
+	invoice.BuyerName, invoice.BuyerTaxID, invoice.BuyerAddress = customer.Name, customer.TaxID, customer.Address
+	return nil
+}
+
+// TransactionInvoice işlemin bağlı olduğu faturanın numarasını döner; faturası yoksa boş döner
+func (s *InvoiceService) TransactionInvoice(farmID, transactionID string) (string, error) {
+	var number string
+	err := s.db.QueryRow(`SELECT i.invoice_number FROM invoice_transactions it
+		JOIN invoices i ON i.id = it.invoice_id AND i.user_id = it.user_id
+		WHERE it.user_id = ? AND it.transaction_id = ?`, farmID, transactionID).Scan(&number)
+	if err == sql.ErrNoRows {
+		return "", nil
+	}
+	return number, err
+}
+
+// invoicedTransactions faturası daha önce kesilmiş işlemleri döner; üretim satışında satış faturası (SF-2024-0001)
+// kesilmiş gelir işlemleri de faturalanmış sayılır, aksi halde fiş numarası yeni faturayla ezilirdi
+func (s *InvoiceService) invoicedTransactions(farmID string, ids []string) ([]string, error) {
+	placeholders := "?" + strings.Repeat(", ?", len(ids)-1)
+	args := []interface{}{farmID}
+	for _, id := range ids {
+		args = append(args, id)
+	}
+	args = append(args, args...)
+	rows, err := s.db.Query(`
+		SELECT transaction_id FROM invoice_transactions WHERE user_id = ? AND transaction_id IN (`+placeholders+`)
+		UNION
+		SELECT transaction_id FROM production_sales
+		WHERE user_id = ? AND transaction_id IN (`+placeholders+`) AND invoice_number IS NOT NULL
+	`, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var invoiced []string
+	for rows.Next() {
+		var id string
+		if err := rows.Scan(&id); err != nil {
+			return nil, err
+		}
+		invoiced = append(invoiced, id)
+	}
+	return invoiced, rows.Err()
+}
+
+// save faturaya numara verir, PDF'ini oluşturup saklar, faturayı kaydeder ve numarayı bağlı işlemlerin fiş
+// numarasına yazar
+func (s *InvoiceService) save(farmID string, invoice *models.Invoice) error {
+	formatter, err := s.formats.Formatter(farmID, "pdf")
+	if err != nil {
+		return err
+	}
+
+	tx, err := s.db.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	invoice.InvoiceNumber, err = nextYearlyNumber(tx, "invoices", "invoice_number", farmID, fmt.Sprintf("FT-%d-", invoice.IssueDate.Year()))
+	if err != nil {
+		return err
+	}
+
+	var seller invoiceSeller
+	tx.QueryRow(`
+		SELECT COALESCE((SELECT name FROM farms WHERE id = ?), NULLIF((SELECT farm_name FROM users WHERE id = ?), ''), ''),
+		       COALESCE((SELECT location FROM farms WHERE id = ?), (SELECT location FROM users WHERE id = ?), '')
+	`, farmID, farmID, farmID, farmID).Scan(&seller.name, &seller.location)
+	if invoice.SalesOrderID != nil {
+		tx.QueryRow("SELECT order_number FROM sales_orders WHERE id = ? AND user_id = ?", *invoice.SalesOrderID, farmID).Scan(&seller.orderNumber)
+	}
+
+	var buf bytes.Buffer
+	if err := writeInvoicePDF(&buf, formatter, *invoice, seller); err != nil {
+		return err
+	}
+
+	taxID, err := EncryptField(ColumnInvoiceBuyerTaxID, invoice.BuyerTaxID)
+	if err != nil {
+		return err
+	}
+	address, err := EncryptField(ColumnInvoiceBuyerAddress, invoice.BuyerAddress)
+	if err != nil {
+		return err
+	}
+	lines, err := utils.ToJSON(invoice.Lines)
+	if err != nil {
+		return err
+	}
+
+	filePath := invoiceStorageKey(farmID, invoice.ID)
+	_, err = tx.Exec(`
+		INSERT INTO invoices (id, user_id, invoice_number, sales_order_id, customer_id, buyer_name, buyer_tax_id,
+		                      buyer_address, issue_date, due_date, currency, subtotal, tax_amount, total, lines, notes,
+		                      file_path, created_at)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, CURRENT_TIMESTAMP)
+	`, invoice.ID, farmID, invoice.InvoiceNumber, invoice.SalesOrderID, invoice.CustomerID, invoice.BuyerName, taxID,
+		address, invoice.IssueDate, invoice.DueDate, invoice.Currency, invoice.Subtotal, invoice.TaxAmount, invoice.Total,
+		lines, invoice.Notes, filePath)
+	if err != nil {
+		return err
+	}
+
+	for _, id := range invoice.TransactionIDs {
+		if _, err := tx.Exec("INSERT INTO invoice_transactions (invoice_id, transaction_id, user_id) VALUES (?, ?, ?)", invoice.ID, id, farmID); err != nil {
+			return err
+		}
+		_, err := tx.Exec("UPDATE transactions SET receipt = ?, updated_at = CURRENT_TIMESTAMP WHERE id = ? AND user_id = ?",
+			invoice.InvoiceNumber, id, farmID)
+		if err != nil {
+			return err
+		}
+	}
+
+	if _, err := s.store.Save(filePath, &buf); err != nil {
+		return err
+	}
+	if err := tx.Commit(); err != nil {
+		s.store.Delete(filePath)
+		return err
+	}
+	return nil
+}
+
+// invoiceSeller faturayı kesen çiftliğin bilgileri
+type invoiceSeller struct {
+	name, location, orderNumber string
+}
+
+// writeInvoicePDF faturayı A4 PDF olarak yazar: satıcı ve alıcı bilgileri, kalemler ve KDV dökümüyle toplamlar
+func writeInvoicePDF(w io.Writer, f Formatter, invoice models.Invoice, seller invoiceSeller) error {
+	doc := NewPDFDocument()
+	right := PDFPageWidth - reportMargin
+	contentWidth := right - reportMargin
+
+	doc.Text(reportMargin, 60, 20, true, "FATURA")
+	doc.TextRight(right, 56, 10, true, "No: "+invoice.InvoiceNumber)
+	doc.TextRight(right, 70, 9, false, "Tarih: "+f.Date(invoice.IssueDate))
+	if invoice.DueDate != nil {
+		doc.TextRight(right, 84, 9, false, "Vade: "+f.Date(*invoice.DueDate))
+	}
+	doc.Line(reportMargin, 94, right, 94, 1.5, 0)
+
+	half := contentWidth/2 - 10
+	doc.Text(reportMargin, 112, 8, true, "SATICI")
+	doc.TextFit(reportMargin, 126, half, 10, true, seller.name)
+	doc.TextFit(reportMargin, 140, half, 9, false, seller.location)
+
+	buyerX := reportMargin + contentWidth/2 + 10
+	doc.Text(buyerX, 112, 8, true, "ALICI")
+	buyer := invoice.BuyerName
+	if buyer == "" {
+		buyer = "-"
+	}
+	doc.TextFit(buyerX, 126, half, 10, true, buyer)
+	y := 140.0
+	if invoice.BuyerTaxID != "" {
+		doc.TextFit(buyerX, y, half, 9, false, "VKN/TCKN: "+invoice.BuyerTaxID)
+		y += 14
+	}
+	if invoice.BuyerAddress != "" {
+		doc.TextFit(buyerX, y, half, 9, false, invoice.BuyerAddress)
+	}
+	if seller.orderNumber != "" {
+		doc.Text(reportMargin, 154, 9, false, "Sipariş No: "+seller.orderNumber)
+	}
+
+	columns := []reportColumn{
+		{title: "Açıklama", width: 220},
+		{title: "Miktar", width: 70, numeric: true},
+		{title: "Birim Fiyat", width: 80, numeric: true},
+		{title: "KDV %", width: 45, numeric: true},
+		{title: "KDV", width: 70, numeric: true},
+		{title: "Tutar", width: 85, numeric: true},
+	}
+	var totalWidth float64
+	for _, column := range columns {
+		totalWidth += column.width
+	}
+	widths := make([]float64, len(columns))
+	for i, column := range columns {
+		widths[i] = column.width / totalWidth * contentWidth
+	}
+	row := func(y float64, bold bool, values []string) {
+		x := reportMargin
+		for i, column := range columns {
+			reportCell(doc, x, y+11, widths[i], 8, bold, column, values[i])
+			x += widths[i]
+		}
+	}
+	header := func(y float64) float64 {
+		doc.FillRect(reportMargin, y, contentWidth, reportRowHeight+2, 0.9)
+		titles := make([]string, len(columns))
+		for i, column := range columns {
+			titles[i] = column.title
+		}
+		row(y, true, titles)
+		return y + reportRowHeight + 2
+	}
+
+	y = header(180)
+	page := 1
+	for _, line := range invoice.Lines {
+		if y+reportRowHeight > PDFPageHeight-reportMargin-80 {
+			doc.TextRight(right, PDFPageHeight-20, 8, false, fmt.Sprintf("Sayfa %d", page))
+			doc.AddPage()
+			page++
+			y = header(reportMargin)
+		}
+		quantity := f.Number(line.Quantity, 2)
+		if line.Unit != "" {
+			quantity += " " + line.Unit
+		}
+		row(y, false, []string{
+			line.Description, quantity, f.Amount(line.UnitPrice), f.Number(line.TaxRate, 2),
+			f.Amount(line.TaxAmount), f.Amount(line.Total),
+		})
+		doc.Line(reportMargin, y+reportRowHeight, right, y+reportRowHeight, 0.25, 0.8)
+		y += reportRowHeight
+	}
+
+	y += 16
+	totals := [][2]string{
+		{"Ara Toplam", f.Money(invoice.Subtotal, invoice.Currency)},
+		{"KDV", f.Money(invoice.TaxAmount, invoice.Currency)},
+		{"Genel Toplam", f.Money(invoice.Total, invoice.Currency)},
+	}
+	for i, total := range totals {
+		bold := i == len(totals)-1
+		doc.TextRight(right-110, y, 9, bold, total[0]+":")
+		doc.TextRight(right, y, 9, bold, total[1])
+		y += 14
+	}
+
+	if invoice.Notes != "" {
+		doc.Text(reportMargin, y+10, 8, true, "Notlar")
+		doc.TextFit(reportMargin, y+24, contentWidth, 8, false, invoice.Notes)
+	}
+	doc.TextRight(right, PDFPageHeight-20, 8, false, fmt.Sprintf("Sayfa %d", page))
+
+	return doc.Write(w)
+}
@@ -0,0 +1,481 @@
+package services
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"log"
+	"math"
+	"strings"
+	"time"
+
+	"agri-management-api/internal/models"
+)
+
+// Sulama takvimi hesaplama sabitleri
+const (
+	// IrrigationForecastDays takvimin varsayılan gün sayısı; sağlayıcı en fazla 5 günlük tahmin verir
+	IrrigationForecastDays = 5
+	// irrigationHistoryDays son sulamadan bu yana su açığı en fazla bu kadar günlük gözlemle hesaplanır
+	irrigationHistoryDays = 14
+	// effectiveRainfallMin bu miktarın altındaki yağış toprağa ulaşmadan buharlaştığı için hesaba katılmaz (mm)
+	effectiveRainfallMin = 2.0
+	// effectiveRainfallFactor yağışın kök bölgesinde tutulan oranı
+	effectiveRainfallFactor = 0.8
+	// irrigationPostponeChance ertesi gün bu olasılık (%) ve üzerinde yağış bekleniyorsa sulama ertelenir
+	irrigationPostponeChance = 60
+	// irrigationReminderInterval sulama hatırlatmalarının kontrol aralığı
+	irrigationReminderInterval = 6 * time.Hour
+	// IrrigationForecastMock sağlayıcıya ulaşılamadığında örnek tahminle hesaplanan takvimin kaynağı
+	IrrigationForecastMock = "mock"
+)
+
+var (
+	// ErrIrrigationLand arazi bulunamadı
+	ErrIrrigationLand = errors.New("irrigation land not found")
+	// ErrIrrigationNotIrrigated arazinin sulama türü girilmemiş veya kuru tarım yapılıyor
+	ErrIrrigationNotIrrigated = errors.New("arazide sulama yapılmıyor; arazinin sulama türünü (irrigationType) girin")
+	// ErrIrrigationLocation hava tahmini için arazinin konumu gerekli
+	ErrIrrigationLocation = errors.New("hava tahmini için arazinin enlem ve boylamı girilmelidir")
+	// ErrIrrigationForecast hava tahmini sağlayıcıdan alınamadı
+	ErrIrrigationForecast = errors.New("hava tahmini alınamadı")
+)
+
+// irrigationSoilWater toprak sınıflarının kök bölgesinde bitkinin zorlanmadan alabildiği su miktarı (mm)
+var irrigationSoilWater = map[string]float64{
+	models.SoilClassSandy: 20,
+	models.SoilClassLoam:  40,
+	models.SoilClassClay:  55,
+}
+
+// soilClassKeywords serbest metin toprak türlerinde aranan ifadeler; killi topraklar önce denetlenir
+var soilClassKeywords = []struct {
+	class    string
+	keywords []string
+}{
+	{models.SoilClassClay, []string{"clay", "kil"}},
+	{models.SoilClassSandy, []string{"sand", "kum"}},
+	{models.SoilClassLoam, []string{"loam", "tın", "tin", "silt", "humus"}},
+}
+
+// irrigationMethod sulama yönteminin su uygulama randımanı ve sulamanın başladığı su açığı oranı; damla
+// sulamada toprak daha nemli tutulduğundan kolay alınabilir suyun yarısı tükenince sulanır
+type irrigationMethod struct {
+	efficiency float64
+	threshold  float64
+}
+
+// irrigationMethods sulama yöntemlerinin parametreleri
+var irrigationMethods = map[string]irrigationMethod{
+	models.IrrigationMethodDrip:      {efficiency: 0.9, threshold: 0.5},
+	models.IrrigationMethodSprinkler: {efficiency: 0.75, threshold: 1},
+	models.IrrigationMethodSurface:   {efficiency: 0.6, threshold: 1},
+	models.IrrigationMethodOther:     {efficiency: 0.7, threshold: 1},
+}
+
+// irrigationMethodKeywords serbest metin sulama türlerinde aranan ifadeler
+var irrigationMethodKeywords = []struct {
+	method   string
+	keywords []string
+}{
+	{models.IrrigationMethodDrip, []string{"drip", "damla"}},
+	{models.IrrigationMethodSprinkler, []string{"sprinkler", "yağmurlama", "yagmurlama", "pivot"}},
+	{models.IrrigationMethodSurface, []string{"surface", "flood", "furrow", "salma", "karık", "karik", "tava"}},
+}
+
+// rainfedIrrigationTypes sulanmayan arazileri belirten sulama türleri
+var rainfedIrrigationTypes = map[string]bool{
+	"": true, "none": true, "rainfed": true, "dry": true, "yok": true, "kuru": true, "kıraç": true, "kirac": true,
+}
+
+// cropCoefficients gelişim evrelerine göre ürün katsayısı (Kc); evre bilinmiyorsa referans tüketim kullanılır
+var cropCoefficients = map[string]float64{
+	models.CropStageUnknown:     1,
+	models.CropStageGermination: 0.5,
+	models.CropStageVegetative:  0.8,
+	models.CropStageFlowering:   1.1,
+	models.CropStageMaturity:    0.85,
+	models.CropStageHarvest:     0.6,
+}
+
+// irrigationLand takvimin hesaplandığı arazi
+type irrigationLand struct {
+	id, name, crop      string
+	soilType            string
+	irrigationType      string
+	area                float64
+	unit                string
+	latitude, longitude sql.NullFloat64
+}
+
+// IrrigationService arazinin toprak türü, sulama yöntemi, son sulaması ve hava tahmininden günlük su
+// dengesiyle sulama takvimi hesaplar ve sulama günü gelen araziler için hatırlatma gönderir
+type IrrigationService struct {
+	db              *sql.DB
+	weather         *WeatherHistoryService
+	recommendations *LandRecommendationService
+	notifications   *NotificationService
+}
+
+// NewIrrigationService yeni sulama servisi oluşturur
+func NewIrrigationService(db *sql.DB) *IrrigationService {
+	return &IrrigationService{
+		db:              db,
+		weather:         NewWeatherHistoryService(db),
+		recommendations: NewLandRecommendationService(db),
+		notifications:   NewNotificationService(db),
+	}
+}
+
+// Schedule arazinin önümüzdeki günler için sulama takvimini döner. Tahmin sağlayıcıdan alınır; sağlayıcı
+// yapılandırılmamışsa veya yanıt vermezse mock true iken örnek tahmin kullanılır
+func (s *IrrigationService) Schedule(farmID, landID string, days int, mock bool, now time.Time) (*models.IrrigationSchedule, error) {
+	land, err := s.land(farmID, landID)
+	if err != nil {
+		return nil, err
+	}
+	if err := irrigable(land); err != nil {
+		return nil, err
+	}
+
+	source := models.WeatherSourceProvider
+	forecast, err := FetchWeatherForecast(land.latitude.Float64, land.longitude.Float64, days)
+	if err != nil {
+		if !mock {
+			return nil, fmt.Errorf("%w: %v", ErrIrrigationForecast, err)
+		}
+		source = IrrigationForecastMock
+		forecast = MockWeatherForecast(days)
+	}
+	return s.schedule(farmID, land, forecast, source, now)
+}
+
+// StartReminders sulama günü gelen araziler için hatırlatmaları düzenli aralıklarla gönderir; hava tahmin
+// sağlayıcısı yapılandırılmamışsa başlamaz
+func (s *IrrigationService) StartReminders() {
+	if !WeatherProviderConfigured() {
+		log.Println("Hava durumu sağlayıcısı yapılandırılmamış, sulama hatırlatmaları gönderilmeyecek")
+		return
+	}
+
+	go func() {
+		ticker := time.NewTicker(irrigationReminderInterval)
+		defer ticker.Stop()
+
+		for {
+			if err := s.RemindAll(time.Now()); err != nil {
+				log.Printf("Sulama hatırlatmaları gönderilemedi: %v", err)
+			}
+			<-ticker.C
+		}
+	}()
+}
+
+// RemindAll konumu ve sulama türü girilmiş tüm aktif arazilerin takvimini hesaplar; bugün sulanması gereken
+// araziler için arazi ve gün başına bir hatırlatma gönderir
+func (s *IrrigationService) RemindAll(now time.Time) error {
+	rows, err := s.db.Query(`
+		SELECT id, user_id FROM lands
+		WHERE latitude IS NOT NULL AND longitude IS NOT NULL AND COALESCE(status, 'active') != 'inactive'
+		  AND COALESCE(irrigation_type, '') != ''
+	`)
+	if err != nil {
+		return err
+	}
+
+	type landRef struct{ id, farmID string }
+	var lands []landRef
+	for rows.Next() {
+		var ref landRef
+		if err := rows.Scan(&ref.id, &ref.farmID); err != nil {
+			continue
+		}
+		lands = append(lands, ref)
+	}
+	rows.Close()
+
+	today := now.Format("2006-01-02")
+	var reminders []Notification
+	for _, ref := range lands {
+		land, err := s.land(ref.farmID, ref.id)
+		if err != nil || irrigable(land) != nil {
+			continue
+		}
+		forecast, err := FetchWeatherForecast(land.latitude.Float64, land.longitude.Float64, IrrigationForecastDays)
+		if err != nil {
+			log.Printf("Arazi %s için hava tahmini alınamadı: %v", land.id, err)
+			continue
+		}
+		schedule, err := s.schedule(ref.farmID, land, forecast, models.WeatherSourceProvider, now)
+		if err != nil {
+			log.Printf("Arazi %s için sulama takvimi hesaplanamadı: %v", land.id, err)
+			continue
+		}
+
+		next := schedule.NextIrrigation
+		if next == nil || next.Date != today {
+			continue
+		}
+		reminders = append(reminders, Notification{
+			UserID:   ref.farmID,
+			Template: "irrigation_due",
+			Type:     "reminder",
+			Priority: "medium",
+			Topic:    models.NotificationTopicIrrigationDue,
+			Entity:   &models.RelatedEntity{Type: "land", ID: land.id, Name: land.name},
+			Params: map[string]interface{}{
+				"date":      next.Date,
+				"amount":    next.Amount,
+				"volume":    next.Volume,
+				"depletion": next.Depletion,
+			},
+			DedupeKey: "irrigation:" + land.id + ":" + next.Date,
+		})
+	}
+
+	_, err = s.notifications.CreateBatch(reminders)
+	return err
+}
+
+// land araziyi okur
+func (s *IrrigationService) land(farmID, landID string) (irrigationLand, error) {
+	var land irrigationLand
+	err := s.db.QueryRow(`
+		SELECT id, name, COALESCE(crop, ''), COALESCE(soil_type, ''), COALESCE(irrigation_type, ''), area, unit,
+		       latitude, longitude
+		FROM lands WHERE id = ? AND user_id = ?
+	`, landID, farmID).Scan(&land.id, &land.name, &land.crop, &land.soilType, &land.irrigationType, &land.area, &land.unit,
+		&land.latitude, &land.longitude)
+	if err == sql.ErrNoRows {
+		return land, ErrIrrigationLand
+	}
+	return land, err
+}
+
+// irrigable arazinin sulama takvimi hesaplanabilir mi
+func irrigable(land irrigationLand) error {
+	if rainfedIrrigationTypes[strings.ToLower(strings.TrimSpace(land.irrigationType))] {
+		return ErrIrrigationNotIrrigated
+	}
+	if !land.latitude.Valid || !land.longitude.Valid {
+		return ErrIrrigationLocation
+	}
+	return nil
+}
+
+// schedule tahmin günlerinde su dengesini yürütür. Başlangıç su açığı son sulamadan bugüne kadarki hava
+// gözlemlerinden hesaplanır; gözlemi olmayan günlerde tahminin ilk gününün tüketimi kullanılır
+func (s *IrrigationService) schedule(farmID string, land irrigationLand, forecast []models.WeatherForecast, source string, now time.Time) (*models.IrrigationSchedule, error) {
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC)
+	latitude := land.latitude.Float64
+
+	schedule := &models.IrrigationSchedule{
+		LandID:         land.id,
+		LandName:       land.name,
+		Crop:           land.crop,
+		SoilType:       land.soilType,
+		IrrigationType: land.irrigationType,
+		ForecastSource: source,
+		Assumptions:    []string{},
+		Days:           []models.IrrigationDay{},
+	}
+
+	schedule.SoilClass = soilClass(land.soilType)
+	if schedule.SoilClass == "" {
+		schedule.SoilClass = models.SoilClassLoam
+		schedule.Assumptions = append(schedule.Assumptions, "Toprak türü tanınmadı; tınlı toprak varsayıldı")
+	}
+	schedule.IrrigationMethod = irrigationMethodOf(land.irrigationType)
+	method := irrigationMethods[schedule.IrrigationMethod]
+	schedule.Efficiency = method.efficiency
+	schedule.ReadilyAvailableWater = irrigationSoilWater[schedule.SoilClass]
+	schedule.Threshold = round2(schedule.ReadilyAvailableWater * method.threshold)
+
+	factor, ok := landHectareFactors[strings.ToLower(strings.TrimSpace(land.unit))]
+	if !ok {
+		factor = landHectareFactors["dönüm"]
+	}
+	schedule.AreaM2 = round2(land.area * factor * 10000)
+
+	last, err := s.recommendations.lastActivities(farmID, land.id, today)
+	if err != nil {
+		return nil, err
+	}
+	schedule.Stage = models.CropStageUnknown
+	if planted, ok := last[ActivityPlanting]; ok {
+		schedule.Stage = cropStage(int(today.Sub(planted).Hours() / 24))
+	} else {
+		schedule.Assumptions = append(schedule.Assumptions, "Ekim tarihi bilinmiyor; ürün katsayısı 1 alındı")
+	}
+	kc := cropCoefficients[schedule.Stage]
+	schedule.CropCoefficient = kc
+
+	var upcoming []models.WeatherForecast
+	for _, day := range forecast {
+		if day.Date >= today.Format("2006-01-02") {
+			upcoming = append(upcoming, day)
+		}
+	}
+	if len(upcoming) == 0 {
+		return schedule, nil
+	}
+	fallbackET := referenceEvapotranspiration(latitude, today, upcoming[0].MinTemp, upcoming[0].MaxTemp) * kc
+
+	depletion := schedule.ReadilyAvailableWater / 2
+	if irrigated, ok := last[ActivityIrrigation]; ok {
+		schedule.LastIrrigatedOn = irrigated.Format("2006-01-02")
+		start := irrigated.AddDate(0, 0, 1)
+		if earliest := today.AddDate(0, 0, -irrigationHistoryDays); start.Before(earliest) {
+			start = earliest
+		}
+		if depletion, err = s.historicDepletion(farmID, land.id, latitude, kc, fallbackET, start, today.AddDate(0, 0, -1)); err != nil {
+			return nil, err
+		}
+		depletion = math.Min(depletion, schedule.ReadilyAvailableWater*1.5)
+	} else {
+		schedule.Assumptions = append(schedule.Assumptions, "Kayıtlı sulama yok; kolay alınabilir suyun yarısının tükendiği varsayıldı")
+	}
+	schedule.InitialDepletion = round1(depletion)
+
+	for i, forecastDay := range upcoming {
+		date, err := time.Parse("2006-01-02", forecastDay.Date)
+		if err != nil {
+			continue
+		}
+		et := referenceEvapotranspiration(latitude, date, forecastDay.MinTemp, forecastDay.MaxTemp) * kc
+		rain := effectiveRainfall(forecastDay.Rainfall)
+		depletion = math.Max(0, depletion+et-rain)
+
+		day := models.IrrigationDay{
+			Date:               forecastDay.Date,
+			MinTemp:            forecastDay.MinTemp,
+			MaxTemp:            forecastDay.MaxTemp,
+			Rainfall:           forecastDay.Rainfall,
+			RainChance:         forecastDay.RainChance,
+			Evapotranspiration: round1(et),
+			EffectiveRainfall:  round1(rain),
+			Depletion:          round1(depletion),
+		}
+
+		if depletion >= schedule.Threshold {
+			var next *models.WeatherForecast
+			if i+1 < len(upcoming) {
+				next = &upcoming[i+1]
+			}
+			if next != nil && next.RainChance >= irrigationPostponeChance && effectiveRainfall(next.Rainfall) >= depletion/2 &&
+				depletion < schedule.ReadilyAvailableWater {
+				day.Reason = fmt.Sprintf("Su açığı %.1f mm; ertesi gün %%%.0f olasılıkla %.1f mm yağış beklendiğinden sulama ertelendi",
+					depletion, next.RainChance, next.Rainfall)
+			} else {
+				day.Irrigate = true
+				day.Amount = round1(depletion / method.efficiency)
+				day.Volume = round1(depletion / method.efficiency / 1000 * schedule.AreaM2)
+				day.Reason = fmt.Sprintf("Su açığı %.1f mm ile sulama eşiğine (%.1f mm) ulaştı", depletion, schedule.Threshold)
+				day.Action = &models.Action{
+					Key:    "schedule",
+					Label:  "Takvime Ekle",
+					Type:   models.ActionTypeAPI,
+					Route:  "/api/v1/lands/" + land.id + "/recommendations/schedule",
+					Method: "POST",
+					Payload: map[string]interface{}{
+						"activityType": ActivityIrrigation,
+						"date":         day.Date,
+						"priority":     models.RecommendationPriorityMedium,
+						"notes":        fmt.Sprintf("%.1f mm (%.1f m³)", day.Amount, day.Volume),
+					},
+				}
+				depletion = 0
+			}
+		}
+
+		schedule.Days = append(schedule.Days, day)
+		if day.Irrigate && schedule.NextIrrigation == nil {
+			next := day
+			schedule.NextIrrigation = &next
+		}
+	}
+	return schedule, nil
+}
+
+// historicDepletion [start, end] günlerinde gözlenen hava ile biriken su açığını hesaplar
+func (s *IrrigationService) historicDepletion(farmID, landID string, latitude, kc, fallbackET float64, start, end time.Time) (float64, error) {
+	if end.Before(start) {
+		return 0, nil
+	}
+	observations, err := s.weather.dailyObservations(farmID, landID, start, end)
+	if err != nil {
+		return 0, err
+	}
+	byDate := map[string]models.WeatherObservation{}
+	for _, observation := range observations {
+		byDate[observation.Date] = observation
+	}
+
+	depletion := 0.0
+	for day := start; !day.After(end); day = day.AddDate(0, 0, 1) {
+		et, rain := fallbackET, 0.0
+		if observation, ok := byDate[day.Format("2006-01-02")]; ok {
+			if observation.MinTemp != nil && observation.MaxTemp != nil {
+				et = referenceEvapotranspiration(latitude, day, *observation.MinTemp, *observation.MaxTemp) * kc
+			}
+			if observation.Rainfall != nil {
+				rain = effectiveRainfall(*observation.Rainfall)
+			}
+		}
+		depletion = math.Max(0, depletion+et-rain)
+	}
+	return depletion, nil
+}
+
+// referenceEvapotranspiration Hargreaves yöntemiyle referans bitki su tüketimini (ET0, mm/gün) hesaplar;
+// atmosfer dışı radyasyon enlem ve yılın gününden FAO-56 eşitlikleriyle bulunur
+func referenceEvapotranspiration(latitude float64, date time.Time, minTemp, maxTemp float64) float64 {
+	j := float64(date.YearDay())
+	phi := latitude * math.Pi / 180
+	dr := 1 + 0.033*math.Cos(2*math.Pi*j/365)
+	delta := 0.409 * math.Sin(2*math.Pi*j/365-1.39)
+	ws := math.Acos(math.Max(-1, math.Min(1, -math.Tan(phi)*math.Tan(delta))))
+	ra := 24 * 60 / math.Pi * 0.082 * dr * (ws*math.Sin(phi)*math.Sin(delta) + math.Cos(phi)*math.Cos(delta)*math.Sin(ws))
+
+	mean := (minTemp + maxTemp) / 2
+	return math.Max(0, 0.0023*(mean+17.8)*math.Sqrt(math.Max(maxTemp-minTemp, 0))*ra*0.408)
+}
+
+// effectiveRainfall yağışın kök bölgesine ulaşan kısmı (mm)
+func effectiveRainfall(rainfall float64) float64 {
+	if rainfall < effectiveRainfallMin {
+		return 0
+	}
+	return rainfall * effectiveRainfallFactor
+}
+
+// soilClass serbest metin toprak türünün sınıfını döner; tanınmazsa boş döner
+func soilClass(soilType string) string {
+	value := strings.ToLower(soilType)
+	for _, candidate := range soilClassKeywords {
+		for _, keyword := range candidate.keywords {
+			if strings.Contains(value, keyword) {
+				return candidate.class
+			}
+		}
+	}
+	return ""
+}
+
+// irrigationMethodOf serbest metin sulama türünün yöntemini döner; tanınmayan türler other sayılır
+func irrigationMethodOf(irrigationType string) string {
+	value := strings.ToLower(irrigationType)
+	for _, candidate := range irrigationMethodKeywords {
+		for _, keyword := range candidate.keywords {
+			if strings.Contains(value, keyword) {
+				return candidate.method
+			}
+		}
+	}
+	return models.IrrigationMethodOther
+}
+
+// round1 değeri bir ondalığa yuvarlar
+func round1(value float64) float64 {
+	return math.Round(value*10) / 10
+}
@@ -0,0 +1,217 @@
+package services
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"log"
+	"os"
+	"runtime/debug"
+	"strconv"
+	"time"
+
+	"agri-management-api/internal/models"
+	"agri-management-api/internal/utils"
+)
+
+// defaultJobWorkers JOB_WORKERS verilmezse çalışan işçi sayısı
+const defaultJobWorkers = 2
+
+// maxJobAttempts sunucu yeniden başlarken yarım kalan işin en fazla deneme sayısı
+const maxJobAttempts = 3
+
+// jobPollInterval işçilerin uyandırılmadan kuyruğu kontrol etme aralığı
+const jobPollInterval = 30 * time.Second
+
+// ErrJobNotFound arka plan işi bulunamadığında döner
+var ErrJobNotFound = errors.New("iş bulunamadı")
+
+// jobWake kuyruğa iş eklendiğinde boştaki işçiyi uyandırır
+var jobWake = make(chan struct{}, 1)
+
+// jobSelect arka plan işlerini okuyan sorgu
+const jobSelect = `
+	SELECT id, type, status, attempts, result_id, error, created_at, started_at, finished_at
+	FROM jobs`
+
+// JobService uzun süren işleri (ör. büyük raporlar) jobs tablosunda kuyruğa alır ve işçi goroutine'leriyle
+// arka planda çalıştırır; istek iş kimliğiyle hemen döner
+type JobService struct {
+	db *sql.DB
+}
+
+// NewJobService yeni iş kuyruğu servisi oluşturur
+func NewJobService(db *sql.DB) *JobService {
+	return &JobService{db: db}
+}
+
+// Enqueue işi çiftlik adına sıraya alır ve boştaki işçiyi uyandırır; payload işin türüne göre JSON'a çevrilir
+func (s *JobService) Enqueue(farmID, jobType string, payload interface{}) (models.Job, error) {
+	payloadJSON, err := utils.ToJSON(payload)
+	if err != nil {
+		return models.Job{}, err
+	}
+
+	id := utils.GenerateID()
+	_, err = s.db.Exec(`
+		INSERT INTO jobs (id, user_id, type, status, payload, created_at) VALUES (?, ?, ?, ?, ?, ?)
+	`, id, farmID, jobType, models.JobStatusQueued, payloadJSON, time.Now().UTC())
+	if err != nil {
+		return models.Job{}, err
+	}
+
+	select {
+	case jobWake <- struct{}{}:
+	default:
+	}
+	return s.Job(farmID, id)
+}
+
+// Job çiftliğin işini durumuyla döner
+func (s *JobService) Job(farmID, id string) (models.Job, error) {
+	job, err := scanJob(s.db.QueryRow(jobSelect+" WHERE id = ? AND user_id = ?", id, farmID))
+	if err == sql.ErrNoRows {
+		return job, ErrJobNotFound
+	}
+	return job, err
+}
+
+// StartWorkers yarım kalan işleri yeniden sıraya alır ve JOB_WORKERS (varsayılan 2) işçiyi başlatır
+func (s *JobService) StartWorkers() {
+	if err := s.RequeueInterrupted(); err != nil {
+		log.Printf("Yarım kalan işler yeniden sıraya alınamadı: %v", err)
+	}
+
+	workers := defaultJobWorkers
+	if n, err := strconv.Atoi(os.Getenv("JOB_WORKERS")); err == nil && n > 0 {
+		workers = n
+	}
+	for i := 0; i < workers; i++ {
+		go s.work()
+	}
+}
+
+// RequeueInterrupted sunucu kapanırken çalışan işleri yeniden sıraya alır; deneme hakkı biten işler başarısız olur
+func (s *JobService) RequeueInterrupted() error {
+	now := time.Now().UTC()
+	if _, err := s.db.Exec(`
+		UPDATE jobs SET status = ?, error = ?, finished_at = ? WHERE status = ? AND attempts >= ?
+	`, models.JobStatusFailed, "sunucu yeniden başlatıldı", now, models.JobStatusRunning, maxJobAttempts); err != nil {
+		return err
+	}
+	_, err := s.db.Exec(`
+		UPDATE jobs SET status = ?, started_at = NULL WHERE status = ?
+	`, models.JobStatusQueued, models.JobStatusRunning)
+	return err
+}
+
+// work kuyruk boşalana kadar işleri sırayla çalıştırır, sonra yeni iş veya bir sonraki kontrol için bekler
+func (s *JobService) work() {
+	ticker := time.NewTicker(jobPollInterval)
+	defer ticker.Stop()
+
+	for {
+		for {
+			job, farmID, payload, ok, err := s.claim()
+			if err != nil {
+				log.Printf("Kuyruktan iş alınamadı: %v", err)
+				break
+			}
+			if !ok {
+				break
+			}
+			s.run(job, farmID, payload)
+		}
+
+		select {
+		case <-jobWake:
+		case <-ticker.C:
+		}
+	}
+}
+
+// claim sıradaki en eski işi çalışıyor olarak işaretleyip alır; başka işçi aynı işi önce aldıysa sonrakini dener
+func (s *JobService) claim() (models.Job, string, string, bool, error) {
+	for {
+		var id, farmID, payload string
+		err := s.db.QueryRow(`
+			SELECT id, user_id, payload FROM jobs WHERE status = ? ORDER BY created_at, rowid LIMIT 1
+		`, models.JobStatusQueued).Scan(&id, &farmID, &payload)
+		if err == sql.ErrNoRows {
+			return models.Job{}, "", "", false, nil
+		}
+		if err != nil {
+			return models.Job{}, "", "", false, err
+		}
+
+		result, err := s.db.Exec(`
+			UPDATE jobs SET status = ?, attempts = attempts + 1, started_at = ? WHERE id = ? AND status = ?
+		`, models.JobStatusRunning, time.Now().UTC(), id, models.JobStatusQueued)
+		if err != nil {
+			return models.Job{}, "", "", false, err
+		}
+		if claimed, _ := result.RowsAffected(); claimed == 0 {
+			continue
+		}
+
+		job, err := s.Job(farmID, id)
+		return job, farmID, payload, err == nil, err
+	}
+}
+
+// run işi türüne göre çalıştırır ve sonucunu kaydeder
+func (s *JobService) run(job models.Job, farmID, payload string) {
+	resultID, err := s.execute(job, farmID, payload)
+
+	now := time.Now().UTC()
+	if err != nil {
+		log.Printf("Arka plan işi başarısız (%s, %s): %v", job.Type, job.ID, err)
+		_, err = s.db.Exec(`
+			UPDATE jobs SET status = ?, error = ?, finished_at = ? WHERE id = ? AND user_id = ?
+		`, models.JobStatusFailed, err.Error(), now, job.ID, farmID)
+	} else {
+		_, err = s.db.Exec(`
+			UPDATE jobs SET status = ?, result_id = ?, error = NULL, finished_at = ? WHERE id = ? AND user_id = ?
+		`, models.JobStatusCompleted, utils.StringToNullString(resultID), now, job.ID, farmID)
+	}
+	if err != nil {
+		log.Printf("Arka plan işi sonucu kaydedilemedi (%s): %v", job.ID, err)
+	}
+}
+
+// execute işi türüne göre çalıştırır; işte oluşan panic işçiyi düşürmez. Panic mesajı ve yığın izi sunucu
+// günlüğüne yazılır, işin durumunda yalnızca kısa bir hata mesajı görünür
+func (s *JobService) execute(job models.Job, farmID, payload string) (resultID string, err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			log.Printf("Arka plan işi beklenmedik şekilde durdu (%s, %s): %v\n%s", job.Type, job.ID, r, debug.Stack())
+			err = errors.New("iş beklenmedik şekilde durdu")
+		}
+	}()
+
+	switch job.Type {
+	case models.JobTypeReport:
+		return NewReportService(s.db).runJob(farmID, job, payload)
+	case models.JobTypeArchive:
+		return NewArchiveService(s.db).runJob(farmID, job, payload)
+	default:
+		return "", fmt.Errorf("bilinmeyen iş türü %q", job.Type)
+	}
+}
+
+// scanJob iş satırını okur
+func scanJob(row interface{ Scan(...interface{}) error }) (models.Job, error) {
+	var job models.Job
+	var resultID, jobError sql.NullString
+	var startedAt, finishedAt sql.NullTime
+	err := row.Scan(&job.ID, &job.Type, &job.Status, &job.Attempts, &resultID, &jobError,
+		&job.CreatedAt, &startedAt, &finishedAt)
+	if err != nil {
+		return job, err
+	}
+	job.ResultID = utils.NullStringToPtr(resultID)
+	job.Error = utils.NullStringToPtr(jobError)
+	job.StartedAt = utils.NullTimeToPtr(startedAt)
+	job.FinishedAt = utils.NullTimeToPtr(finishedAt)
+	return job, nil
+}
@@ -0,0 +1,183 @@
+package services
+
+import (
+	"database/sql"
+	"log"
+	"time"
+
+	"agri-management-api/internal/models"
+	"agri-management-api/internal/utils"
+)
+
+// kpiSnapshotSelect KPI anlık görüntülerini okuyan sorgu
+const kpiSnapshotSelect = `
+	SELECT id, snapshot_date, herd_size, stock_value, cash_balance, total_area, created_at
+	FROM kpi_snapshots`
+
+// KPISnapshotService çiftlik KPI'larının günlük anlık görüntülerini alır ve okur
+type KPISnapshotService struct {
+	db *sql.DB
+}
+
+// NewKPISnapshotService yeni KPI snapshot service oluşturur
+func NewKPISnapshotService(db *sql.DB) *KPISnapshotService {
+	return &KPISnapshotService{db: db}
+}
+
+// StartRecorder saatlik kontrolle o gün henüz anlık görüntüsü alınmamış çiftliklerin KPI'larını kaydeder;
+// böylece her çiftlik için gece yarısından sonraki ilk kontrolde günde bir görüntü alınır
+func (s *KPISnapshotService) StartRecorder() {
+	go func() {
+		ticker := time.NewTicker(time.Hour)
+		defer ticker.Stop()
+
+		for {
+			if err := s.CaptureAll(time.Now()); err != nil {
+				log.Printf("KPI anlık görüntüleri alınamadı: %v", err)
+			}
+			<-ticker.C
+		}
+	}()
+}
+
+// CaptureAll günün anlık görüntüsü olmayan tüm çiftliklerin KPI'larını kaydeder
+func (s *KPISnapshotService) CaptureAll(now time.Time) error {
+	day := now.UTC().Format("2006-01-02")
+	rows, err := s.db.Query(`
+		SELECT f.id FROM farms f
+		WHERE NOT EXISTS (SELECT 1 FROM kpi_snapshots k WHERE k.user_id = f.id AND k.snapshot_date = ?)
+	`, day)
+	if err != nil {
+		return err
+	}
+
+	var farmIDs []string
+	for rows.Next() {
+		var farmID string
+		if err := rows.Scan(&farmID); err != nil {
+			continue
+		}
+		farmIDs = append(farmIDs, farmID)
+	}
+	rows.Close()
+
+	for _, farmID := range farmIDs {
+		if _, err := s.Capture(farmID, now); err != nil {
+			log.Printf("KPI anlık görüntüsü alınamadı (%s): %v", farmID, err)
+		}
+	}
+	return nil
+}
+
+// Capture çiftliğin güncel KPI'larını hesaplayıp günün anlık görüntüsü olarak kaydeder; aynı gün
+// tekrar alınırsa günün görüntüsü güncellenir. Sürü büyüklüğü satılmamış, kesilmemiş ve ölmemiş hayvanları,
+// stok değeri kalan ürün miktarının satış fiyatıyla (yoksa birim maliyetle) çarpımını, nakit bakiyesi banka
+// hesaplarının açılış bakiyeleriyle tamamlanmış gelir ve giderleri, toplam alan aktif arazileri kapsar
+func (s *KPISnapshotService) Capture(farmID string, now time.Time) (models.KPISnapshot, error) {
+	snapshot := models.KPISnapshot{SnapshotDate: now.UTC().Format("2006-01-02")}
+
+	err := s.db.QueryRow(`
+		SELECT COUNT(*) FROM livestock l
+		WHERE l.user_id = ? AND l.sale_date IS NULL
+		  AND NOT EXISTS (
+		      SELECT 1 FROM livestock_movements m
+		      WHERE m.user_id = ? AND m.livestock_id = l.id AND m.movement_type IN ('sale', 'death', 'slaughter')
+		  )
+	`, farmID, farmID).Scan(&snapshot.HerdSize)
+	if err != nil {
+		return snapshot, err
+	}
+
+	err = s.db.QueryRow(`
+		SELECT COALESCE(SUM(MAX(amount - COALESCE(sold_amount, 0) - COALESCE(lost_amount, 0), 0) * COALESCE(price, unit_cost, 0)), 0)
+		FROM production WHERE user_id = ?
+	`, farmID).Scan(&snapshot.StockValue)
+	if err != nil {
+		return snapshot, err
+	}
+
+	var openingBalance, netCash float64
+	err = s.db.QueryRow("SELECT COALESCE(SUM(opening_balance), 0) FROM bank_accounts WHERE user_id = ?", farmID).Scan(&openingBalance)
+	if err != nil {
+		return snapshot, err
+	}
+	err = s.db.QueryRow(`
+		SELECT COALESCE(SUM(CASE WHEN type = 'income' THEN amount ELSE -amount END), 0)
+		FROM transactions
+		WHERE user_id = ? AND COALESCE(status, 'completed') = 'completed' AND date(date) <= ?
+	`, farmID, snapshot.SnapshotDate).Scan(&netCash)
+	if err != nil {
+		return snapshot, err
+	}
+	snapshot.CashBalance = round2(openingBalance + netCash)
+
+	err = s.db.QueryRow("SELECT COALESCE(SUM(area), 0) FROM lands WHERE user_id = ? AND status = 'active'", farmID).Scan(&snapshot.TotalArea)
+	if err != nil {
+		return snapshot, err
+	}
+	snapshot.StockValue = round2(snapshot.StockValue)
+	snapshot.TotalArea = round2(snapshot.TotalArea)
+
+	_, err = s.db.Exec(`
+		INSERT INTO kpi_snapshots (id, user_id, snapshot_date, herd_size, stock_value, cash_balance, total_area, created_at)
+		VALUES (?, ?, ?, ?, ?, ?, ?, CURRENT_TIMESTAMP)
+		ON CONFLICT (user_id, snapshot_date) DO UPDATE SET
+		    herd_size = excluded.herd_size, stock_value = excluded.stock_value,
+		    cash_balance = excluded.cash_balance, total_area = excluded.total_area, created_at = excluded.created_at
+	`, utils.GenerateID(), farmID, snapshot.SnapshotDate, snapshot.HerdSize, snapshot.StockValue,
+		snapshot.CashBalance, snapshot.TotalArea)
+	if err != nil {
+		return snapshot, err
+	}
+
+	row := s.db.QueryRow(kpiSnapshotSelect+" WHERE user_id = ? AND snapshot_date = ?", farmID, snapshot.SnapshotDate)
+	return scanKPISnapshot(row)
+}
+
+// List from-to aralığındaki (iki uç dahil) anlık görüntüleri tarihe göre sıralı döner
+func (s *KPISnapshotService) List(farmID string, from, to time.Time) ([]models.KPISnapshot, error) {
+	rows, err := s.db.Query(kpiSnapshotSelect+`
+		WHERE user_id = ? AND snapshot_date BETWEEN ? AND ?
+		ORDER BY snapshot_date
+	`, farmID, from.Format("2006-01-02"), to.Format("2006-01-02"))
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	snapshots := []models.KPISnapshot{}
+	for rows.Next() {
+		snapshot, err := scanKPISnapshot(rows)
+		if err != nil {
+			continue
+		}
+		snapshots = append(snapshots, snapshot)
+	}
+	return snapshots, rows.Err()
+}
+
+// OnOrBefore verilen gün veya öncesindeki en yakın anlık görüntüyü döner; yoksa nil
+func (s *KPISnapshotService) OnOrBefore(farmID string, day time.Time) (*models.KPISnapshot, error) {
+	row := s.db.QueryRow(kpiSnapshotSelect+`
+		WHERE user_id = ? AND snapshot_date <= ?
+		ORDER BY snapshot_date DESC LIMIT 1
+	`, farmID, day.Format("2006-01-02"))
+	snapshot, err := scanKPISnapshot(row)
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &snapshot, nil
+}
+
+// scanKPISnapshot anlık görüntü satırını okur
+func scanKPISnapshot(row interface{ Scan(...interface{}) error }) (models.KPISnapshot, error) {
+	var snapshot models.KPISnapshot
+	var snapshotDate time.Time
+	err := row.Scan(&snapshot.ID, &snapshotDate, &snapshot.HerdSize, &snapshot.StockValue,
+		&snapshot.CashBalance, &snapshot.TotalArea, &snapshot.CreatedAt)
+	snapshot.SnapshotDate = snapshotDate.Format("2006-01-02")
+	return snapshot, err
+}
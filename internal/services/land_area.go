@@ -0,0 +1,250 @@
+package services
+
+import (
+	"database/sql"
+	"errors"
+	"math"
+	"sort"
+	"strings"
+
+	"agri-management-api/internal/models"
+	"agri-management-api/internal/utils"
+)
+
+// DefaultLandAreaTolerancePercent girilen alan ile sınırdan hesaplanan alan arasında varsayılan olarak kabul edilen fark
+const DefaultLandAreaTolerancePercent = 5.0
+
+// ErrLandAreaLandNotFound düzeltilecek arazilerden biri bulunamadığında döner
+var ErrLandAreaLandNotFound = errors.New("land not found")
+
+// ErrLandAreaNoBoundary düzeltilecek arazilerden birinin sınırı kayıtlı olmadığında döner
+var ErrLandAreaNoBoundary = errors.New("land boundary not set")
+
+// LandAreaService arazilerin girilen alanını sınır geometrisinden hesaplanan alanla doğrular ve düzeltir
+type LandAreaService struct {
+	db      *sql.DB
+	farms   *FarmService
+	history *ChangeHistoryService
+}
+
+// NewLandAreaService yeni land area service oluşturur
+func NewLandAreaService(db *sql.DB) *LandAreaService {
+	return &LandAreaService{
+		db:      db,
+		farms:   NewFarmService(db),
+		history: NewChangeHistoryService(db),
+	}
+}
+
+// NormalizeLandAreaSettings alan doğrulama ayarlarını doğrular; tolerans girilmemişse varsayılan kullanılır
+func NormalizeLandAreaSettings(settings *models.LandAreaSettings) error {
+	if settings.TolerancePercent < 0 || settings.TolerancePercent > 100 {
+		return errors.New("alan farkı toleransı 0-100 arasında olmalı")
+	}
+	if settings.TolerancePercent == 0 {
+		settings.TolerancePercent = DefaultLandAreaTolerancePercent
+	}
+	return nil
+}
+
+// CheckLandArea girilen alanı sınırdan hesaplanan alanla karşılaştırır. Fark hesaplanan alana göre yüzde olarak
+// verilir ve toleransı aşarsa işaretlenir; sınırdan alan hesaplanamıyorsa (ör. çizgi gibi dar poligon) işaretlenmez
+func CheckLandArea(area float64, unit string, boundary models.GeoPolygon, tolerance float64) models.LandAreaCheck {
+	areaM2 := PolygonArea(boundary)
+	check := models.LandAreaCheck{
+		Area:             area,
+		Unit:             unit,
+		GeometryAreaM2:   areaM2,
+		GeometryArea:     AreaInUnit(areaM2, unit),
+		TolerancePercent: tolerance,
+	}
+	if check.GeometryArea <= 0 {
+		return check
+	}
+
+	check.DifferencePercent = round2(math.Abs(area-check.GeometryArea) / check.GeometryArea * 100)
+	check.Flagged = check.DifferencePercent > tolerance
+	return check
+}
+
+// Settings çiftliğin alan doğrulama ayarlarını döner
+func (s *LandAreaService) Settings(farmID string) (models.LandAreaSettings, error) {
+	settings, err := s.farms.Settings(farmID)
+	if err != nil {
+		return settings.LandArea, err
+	}
+	if err := NormalizeLandAreaSettings(&settings.LandArea); err != nil {
+		return DefaultSettings().LandArea, nil
+	}
+	return settings.LandArea, nil
+}
+
+// Check arazinin alanını çiftliğin toleransıyla doğrular; sınırı olmayan arazilerde nil döner
+func (s *LandAreaService) Check(farmID string, land models.Land) (*models.LandAreaCheck, error) {
+	if land.Boundary == nil {
+		return nil, nil
+	}
+	settings, err := s.Settings(farmID)
+	if err != nil {
+		return nil, err
+	}
+
+	check := CheckLandArea(land.Area, land.Unit, *land.Boundary, settings.TolerancePercent)
+	check.LandID, check.LandName = land.ID, land.Name
+	return &check, nil
+}
+
+// landAreaRow alan doğrulaması için okunan arazi
+type landAreaRow struct {
+	item     models.LandDataQualityItem
+	boundary *models.GeoPolygon
+}
+
+// lands çiftliğin arazilerini ada göre sıralı okur; landIDs verilirse yalnızca o araziler okunur
+func (s *LandAreaService) lands(farmID string, landIDs []string) ([]landAreaRow, error) {
+	query := "SELECT id, name, area, unit, COALESCE(boundary, '') FROM lands WHERE user_id = ?"
+	args := []interface{}{farmID}
+	if len(landIDs) > 0 {
+		query += " AND id IN (?" + strings.Repeat(", ?", len(landIDs)-1) + ")"
+		for _, id := range landIDs {
+			args = append(args, id)
+		}
+	}
+
+	rows, err := s.db.Query(query+" ORDER BY name, id", args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var lands []landAreaRow
+	for rows.Next() {
+		var row landAreaRow
+		var boundaryJSON string
+		if err := rows.Scan(&row.item.LandID, &row.item.LandName, &row.item.Area, &row.item.Unit, &boundaryJSON); err != nil {
+			return nil, err
+		}
+		if boundaryJSON != "" {
+			var boundary models.GeoPolygon
+			if err := utils.FromJSON(boundaryJSON, &boundary); err == nil && len(boundary.Coordinates) > 0 {
+				row.boundary = &boundary
+			}
+		}
+		lands = append(lands, row)
+	}
+	return lands, rows.Err()
+}
+
+// Report çiftliğin arazi veri kalitesi raporunu hazırlar: sınırı kayıtlı olmayan araziler ile girilen alanı
+// sınırdan hesaplanan alandan toleranstan fazla farklı olan araziler listelenir
+func (s *LandAreaService) Report(farmID string) (models.LandDataQualityReport, error) {
+	settings, err := s.Settings(farmID)
+	if err != nil {
+		return models.LandDataQualityReport{}, err
+	}
+	report := models.LandDataQualityReport{
+		TolerancePercent:  settings.TolerancePercent,
+		AutoCorrect:       settings.AutoCorrect,
+		MissingBoundary:   []models.LandDataQualityItem{},
+		AreaDiscrepancies: []models.LandAreaCheck{},
+	}
+
+	lands, err := s.lands(farmID, nil)
+	if err != nil {
+		return report, err
+	}
+	report.TotalLands = len(lands)
+
+	for _, land := range lands {
+		if land.boundary == nil {
+			report.MissingBoundary = append(report.MissingBoundary, land.item)
+			continue
+		}
+		report.CheckedLands++
+
+		check := CheckLandArea(land.item.Area, land.item.Unit, *land.boundary, settings.TolerancePercent)
+		if check.Flagged {
+			check.LandID, check.LandName = land.item.LandID, land.item.LandName
+			report.AreaDiscrepancies = append(report.AreaDiscrepancies, check)
+		}
+	}
+
+	sort.SliceStable(report.AreaDiscrepancies, func(i, j int) bool {
+		return report.AreaDiscrepancies[i].DifferencePercent > report.AreaDiscrepancies[j].DifferencePercent
+	})
+	return report, nil
+}
+
+// Correct arazilerin alanını sınırdan hesaplanan alanla değiştirir ve değişikliği arazi geçmişine kaydeder.
+// landIDs boşsa farkı toleransı aşan tüm araziler, verilirse farkı olan seçili araziler düzeltilir; bulunamayan
+// veya sınırı olmayan arazilerin kimlikleri hatayla birlikte döner
+func (s *LandAreaService) Correct(farmID, changedBy string, landIDs []string) ([]models.LandAreaCheck, []string, error) {
+	settings, err := s.Settings(farmID)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	seen := map[string]bool{}
+	ids := []string{}
+	for _, id := range landIDs {
+		if id = strings.TrimSpace(id); id != "" && !seen[id] {
+			seen[id] = true
+			ids = append(ids, id)
+		}
+	}
+	landIDs = ids
+
+	lands, err := s.lands(farmID, landIDs)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if len(landIDs) > 0 {
+		found := make(map[string]bool, len(lands))
+		var missingBoundary []string
+		for _, land := range lands {
+			found[land.item.LandID] = true
+			if land.boundary == nil {
+				missingBoundary = append(missingBoundary, land.item.LandID)
+			}
+		}
+		var missing []string
+		for _, id := range landIDs {
+			if !found[id] {
+				missing = append(missing, id)
+			}
+		}
+		if len(missing) > 0 {
+			return nil, missing, ErrLandAreaLandNotFound
+		}
+		if len(missingBoundary) > 0 {
+			return nil, missingBoundary, ErrLandAreaNoBoundary
+		}
+	}
+
+	corrected := []models.LandAreaCheck{}
+	for _, land := range lands {
+		if land.boundary == nil {
+			continue
+		}
+		check := CheckLandArea(land.item.Area, land.item.Unit, *land.boundary, settings.TolerancePercent)
+		if check.GeometryArea <= 0 || check.GeometryArea == check.Area || len(landIDs) == 0 && !check.Flagged {
+			continue
+		}
+
+		err := s.history.Track(s.db, HistoryEntityLand, farmID, land.item.LandID, changedBy, func() error {
+			_, err := s.db.Exec("UPDATE lands SET area = ?, updated_at = CURRENT_TIMESTAMP WHERE id = ? AND user_id = ?",
+				check.GeometryArea, land.item.LandID, farmID)
+			return err
+		})
+		if err != nil {
+			return corrected, nil, err
+		}
+
+		previous := check.Area
+		check.LandID, check.LandName = land.item.LandID, land.item.LandName
+		check.Area, check.PreviousArea, check.Corrected = check.GeometryArea, &previous, true
+		corrected = append(corrected, check)
+	}
+	return corrected, nil, nil
+}
@@ -0,0 +1,418 @@
+package services
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"agri-management-api/internal/models"
+	"agri-management-api/internal/utils"
+)
+
+// Maliyet kalemi birim ücretinin kaynağı
+const (
+	CostRateManual         = "manual"
+	CostRateInventoryCost  = "inventory_cost"
+	CostRateInventoryPrice = "inventory_price"
+	CostRateAsset          = "asset_rate"
+	CostRateLaborDefault   = "labor_rate"
+	CostRateMachineDefault = "machine_rate"
+)
+
+var (
+	// ErrLandActivityNotFound aktivite yok veya çiftliğin arazisine ait değil
+	ErrLandActivityNotFound = errors.New("arazi aktivitesi bulunamadı")
+	// ErrInvalidCostItem maliyet kalemi değerlenemedi (kaynak bulunamadı veya ücret belirlenemedi)
+	ErrInvalidCostItem = errors.New("geçersiz maliyet kalemi")
+)
+
+// landCostItemSelect maliyet kalemi sütunları
+const landCostItemSelect = `
+	SELECT id, activity_id, category, COALESCE(production_id, ''), COALESCE(asset_id, ''), COALESCE(description, ''),
+	       quantity, COALESCE(unit, ''), unit_rate, rate_source, amount, created_at
+	FROM land_activity_cost_items`
+
+// LandCostService arazi aktivitesi maliyetlerini girdi, işçilik ve makine kalemlerine ayırır, değerler ve
+// arazi bazında karlılığı hesaplar
+type LandCostService struct {
+	db          *sql.DB
+	farms       *FarmService
+	water       *WaterQuotaService
+	allocations *CostAllocationService
+}
+
+// NewLandCostService yeni land cost service oluşturur
+func NewLandCostService(db *sql.DB) *LandCostService {
+	return &LandCostService{db: db, farms: NewFarmService(db), water: NewWaterQuotaService(db),
+		allocations: NewCostAllocationService(db)}
+}
+
+// Value kalemleri doğrular ve birim ücret verilmeyenleri değerler: girdiler stoktaki ürünün birim maliyetiyle
+// (yoksa fiyatıyla), makine saatleri makinenin saatlik ücretiyle, işçilik ve kalan makine saatleri çiftlik
+// ayarlarındaki varsayılan ücretlerle. Değerlenmiş kalemleri ve toplam tutarı döner
+func (s *LandCostService) Value(farmID string, items []models.LandActivityCostItem) ([]models.LandActivityCostItem, float64, error) {
+	if len(items) == 0 {
+		return []models.LandActivityCostItem{}, 0, nil
+	}
+
+	settings, err := s.farms.Settings(farmID)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	valued := make([]models.LandActivityCostItem, 0, len(items))
+	total := 0.0
+	for i, item := range items {
+		item.Description = strings.TrimSpace(item.Description)
+		item.Unit = strings.TrimSpace(item.Unit)
+
+		var rate float64
+		var source string
+		switch item.Category {
+		case models.LandCostInput:
+			item.AssetID = ""
+			rate, source, err = s.inputRate(farmID, &item)
+		case models.LandCostLabor:
+			item.ProductionID, item.AssetID = "", ""
+			rate, source, err = defaultRate(item.UnitRate, settings.Costing.LaborHourlyRate, CostRateLaborDefault, "işçilik")
+			if item.Unit == "" {
+				item.Unit = "saat"
+			}
+		case models.LandCostMachinery:
+			item.ProductionID = ""
+			rate, source, err = s.machineRate(farmID, &item, settings.Costing.MachineHourlyRate)
+			if item.Unit == "" {
+				item.Unit = "saat"
+			}
+		default:
+			err = fmt.Errorf("%w: bilinmeyen kalem türü %q", ErrInvalidCostItem, item.Category)
+		}
+		if err != nil {
+			return nil, 0, fmt.Errorf("%d. kalem: %w", i+1, err)
+		}
+
+		item.UnitRate = &rate
+		item.RateSource = source
+		item.Amount = round2(item.Quantity * rate)
+		total += item.Amount
+		valued = append(valued, item)
+	}
+
+	return valued, round2(total), nil
+}
+
+// inputRate girdi kaleminin birim ücretini belirler; stoktaki ürün verilmişse birim ve açıklama üründen tamamlanır
+func (s *LandCostService) inputRate(farmID string, item *models.LandActivityCostItem) (float64, string, error) {
+	if item.ProductionID == "" {
+		if item.UnitRate == nil {
+			return 0, "", fmt.Errorf("%w: stok kaydı olmayan girdi için birim ücret gerekli", ErrInvalidCostItem)
+		}
+		if item.Description == "" {
+			return 0, "", fmt.Errorf("%w: stok kaydı olmayan girdi için açıklama gerekli", ErrInvalidCostItem)
+		}
+		return *item.UnitRate, CostRateManual, nil
+	}
+
+	var name, unit string
+	var unitCost, price sql.NullFloat64
+	err := s.db.QueryRow("SELECT name, unit, unit_cost, price FROM production WHERE id = ? AND user_id = ?",
+		item.ProductionID, farmID).Scan(&name, &unit, &unitCost, &price)
+	if err == sql.ErrNoRows {
+		return 0, "", fmt.Errorf("%w: stok kaydı bulunamadı", ErrInvalidCostItem)
+	}
+	if err != nil {
+		return 0, "", err
+	}
+
+	if item.Description == "" {
+		item.Description = name
+	}
+	if item.Unit == "" {
+		item.Unit = unit
+	}
+
+	switch {
+	case item.UnitRate != nil:
+		return *item.UnitRate, CostRateManual, nil
+	case unitCost.Valid:
+		return unitCost.Float64, CostRateInventoryCost, nil
+	case price.Valid:
+		return price.Float64, CostRateInventoryPrice, nil
+	}
+	return 0, "", fmt.Errorf("%w: %s için birim maliyet veya fiyat yok, birim ücret girin", ErrInvalidCostItem, name)
+}
+
+// machineRate makine kaleminin saatlik ücretini belirler; makine verilmişse açıklama makine adından tamamlanır
+func (s *LandCostService) machineRate(farmID string, item *models.LandActivityCostItem, fallback float64) (float64, string, error) {
+	if item.AssetID == "" {
+		return defaultRate(item.UnitRate, fallback, CostRateMachineDefault, "makine")
+	}
+
+	var name string
+	var hourlyRate sql.NullFloat64
+	err := s.db.QueryRow("SELECT name, hourly_rate FROM fixed_assets WHERE id = ? AND user_id = ?",
+		item.AssetID, farmID).Scan(&name, &hourlyRate)
+	if err == sql.ErrNoRows {
+		return 0, "", fmt.Errorf("%w: makine bulunamadı", ErrInvalidCostItem)
+	}
+	if err != nil {
+		return 0, "", err
+	}
+
+	if item.Description == "" {
+		item.Description = name
+	}
+	if item.UnitRate == nil && hourlyRate.Valid {
+		return hourlyRate.Float64, CostRateAsset, nil
+	}
+	return defaultRate(item.UnitRate, fallback, CostRateMachineDefault, "makine")
+}
+
+// defaultRate girilen ücreti, yoksa ayarlardaki varsayılan saatlik ücreti döner
+func defaultRate(rate *float64, fallback float64, source, label string) (float64, string, error) {
+	if rate != nil {
+		return *rate, CostRateManual, nil
+	}
+	if fallback > 0 {
+		return fallback, source, nil
+	}
+	return 0, "", fmt.Errorf("%w: %s ücreti girilmedi ve ayarlarda varsayılan saatlik ücret yok", ErrInvalidCostItem, label)
+}
+
+// Replace aktivitenin maliyet kalemlerini değerleyip mevcut kalemlerin yerine kaydeder ve aktivitenin maliyetini
+// kalemlerin toplamına eşitler. Boş liste kalem dökümünü kaldırır; aktivitenin maliyeti değişmez
+func (s *LandCostService) Replace(farmID, activityID string, items []models.LandActivityCostItem) (models.LandActivityCostBreakdown, error) {
+	if err := s.ensureActivity(farmID, activityID); err != nil {
+		return models.LandActivityCostBreakdown{}, err
+	}
+
+	valued, total, err := s.Value(farmID, items)
+	if err != nil {
+		return models.LandActivityCostBreakdown{}, err
+	}
+	if err := s.Save(farmID, activityID, valued, total); err != nil {
+		return models.LandActivityCostBreakdown{}, err
+	}
+	return s.Breakdown(farmID, activityID)
+}
+
+// Save değerlenmiş kalemleri aktivitenin mevcut kalemlerinin yerine tek işlemde yazar; kalem varsa aktivitenin
+// maliyeti toplam olarak güncellenir
+func (s *LandCostService) Save(farmID, activityID string, items []models.LandActivityCostItem, total float64) error {
+	tx, err := s.db.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	if _, err := tx.Exec("DELETE FROM land_activity_cost_items WHERE activity_id = ? AND user_id = ?", activityID, farmID); err != nil {
+		return err
+	}
+
+	for _, item := range items {
+		_, err := tx.Exec(`
+			INSERT INTO land_activity_cost_items (id, user_id, activity_id, category, production_id, asset_id, description,
+			                                      quantity, unit, unit_rate, rate_source, amount, created_at)
+			VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, CURRENT_TIMESTAMP)
+		`, utils.GenerateID(), farmID, activityID, item.Category, utils.StringToNullString(item.ProductionID),
+			utils.StringToNullString(item.AssetID), item.Description, item.Quantity, item.Unit, *item.UnitRate,
+			item.RateSource, item.Amount)
+		if err != nil {
+			return err
+		}
+	}
+
+	if len(items) > 0 {
+		_, err := tx.Exec(`
+			UPDATE land_activities SET cost = ?
+			WHERE id = ? AND land_id IN (SELECT id FROM lands WHERE user_id = ?)
+		`, total, activityID, farmID)
+		if err != nil {
+			return err
+		}
+	}
+
+	return tx.Commit()
+}
+
+// Breakdown aktivitenin maliyet kalemlerini tür bazında toplamlarla döner
+func (s *LandCostService) Breakdown(farmID, activityID string) (models.LandActivityCostBreakdown, error) {
+	breakdown := models.LandActivityCostBreakdown{ActivityID: activityID, Items: []models.LandActivityCostItem{}}
+	if err := s.ensureActivity(farmID, activityID); err != nil {
+		return breakdown, err
+	}
+
+	rows, err := s.db.Query(landCostItemSelect+" WHERE activity_id = ? AND user_id = ? ORDER BY created_at, rowid", activityID, farmID)
+	if err != nil {
+		return breakdown, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		item, err := scanLandCostItem(rows)
+		if err != nil {
+			return breakdown, err
+		}
+		switch item.Category {
+		case models.LandCostInput:
+			breakdown.Input += item.Amount
+		case models.LandCostLabor:
+			breakdown.Labor += item.Amount
+		case models.LandCostMachinery:
+			breakdown.Machinery += item.Amount
+		}
+		breakdown.Items = append(breakdown.Items, item)
+	}
+
+	breakdown.Input = round2(breakdown.Input)
+	breakdown.Labor = round2(breakdown.Labor)
+	breakdown.Machinery = round2(breakdown.Machinery)
+	breakdown.Total = round2(breakdown.Input + breakdown.Labor + breakdown.Machinery)
+	return breakdown, rows.Err()
+}
+
+// LandItems arazinin tüm aktivitelerinin maliyet kalemlerini aktivite kimliğine göre gruplar
+func (s *LandCostService) LandItems(farmID, landID string) (map[string][]models.LandActivityCostItem, error) {
+	rows, err := s.db.Query(landCostItemSelect+`
+		WHERE user_id = ? AND activity_id IN (SELECT id FROM land_activities WHERE land_id = ?)
+		ORDER BY created_at, rowid
+	`, farmID, landID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	items := map[string][]models.LandActivityCostItem{}
+	for rows.Next() {
+		item, err := scanLandCostItem(rows)
+		if err != nil {
+			return nil, err
+		}
+		items[item.ActivityID] = append(items[item.ActivityID], item)
+	}
+	return items, rows.Err()
+}
+
+// Profitability arazinin dönem içindeki karlılığını hesaplar. Gelir arazide üretilen ürünlerin vergisiz satış
+// tutarlarıdır; maliyetler aktivite tarihine (gerçekleşme, planlanan veya kayıt) göre dönemdeki aktivitelerden
+// gelir. Kalemlere ayrılmış aktiviteler girdi, işçilik ve makine olarak, ayrılmamışlar tek tutar olarak sayılır.
+// Dağıtım kurallarıyla araziye paylaştırılan ortak giderler dönemle kesişen gün sayısıyla orantılanarak eklenir.
+// Sulama suyu hacmi, kota fiyatlarıyla su maliyeti ve kota kullanımı ayrıca döner; su maliyeti toplam maliyete eklenmez
+func (s *LandCostService) Profitability(farmID, landID string, startDate, endDate *time.Time) (models.LandProfitability, error) {
+	result := models.LandProfitability{LandID: landID}
+
+	var area sql.NullFloat64
+	err := s.db.QueryRow("SELECT name, area FROM lands WHERE id = ? AND user_id = ?", landID, farmID).Scan(&result.LandName, &area)
+	if err != nil {
+		return result, err
+	}
+	result.Area = area.Float64
+
+	from, to := "0001-01-01", "9999-12-31"
+	if startDate != nil {
+		from = startDate.Format("2006-01-02")
+		result.StartDate = from
+	}
+	if endDate != nil {
+		to = endDate.Format("2006-01-02")
+		result.EndDate = to
+	}
+
+	err = s.db.QueryRow(`
+		SELECT COALESCE(SUM(ps.subtotal), 0)
+		FROM production_sales ps
+		JOIN production p ON p.id = ps.production_id
+		WHERE ps.user_id = ? AND p.land_id = ? AND date(ps.sale_date) BETWEEN ? AND ?
+	`, farmID, landID, from, to).Scan(&result.Revenue)
+	if err != nil {
+		return result, err
+	}
+
+	activityDate := "date(COALESCE(a.actual_date, a.scheduled_date, a.created_at))"
+	err = s.db.QueryRow(`
+		SELECT COUNT(*),
+		       COUNT(CASE WHEN EXISTS (SELECT 1 FROM land_activity_cost_items i WHERE i.activity_id = a.id) THEN 1 END),
+		       COALESCE(SUM(CASE WHEN NOT EXISTS (SELECT 1 FROM land_activity_cost_items i WHERE i.activity_id = a.id)
+		                         THEN a.cost END), 0)
+		FROM land_activities a
+		JOIN lands l ON l.id = a.land_id
+		WHERE l.user_id = ? AND a.land_id = ? AND `+activityDate+` BETWEEN ? AND ?
+	`, farmID, landID, from, to).Scan(&result.ActivityCount, &result.ItemizedCount, &result.UnitemizedCost)
+	if err != nil {
+		return result, err
+	}
+
+	rows, err := s.db.Query(`
+		SELECT i.category, COALESCE(SUM(i.amount), 0)
+		FROM land_activity_cost_items i
+		JOIN land_activities a ON a.id = i.activity_id
+		WHERE i.user_id = ? AND a.land_id = ? AND `+activityDate+` BETWEEN ? AND ?
+		GROUP BY i.category
+	`, farmID, landID, from, to)
+	if err != nil {
+		return result, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var category string
+		var amount float64
+		if err := rows.Scan(&category, &amount); err != nil {
+			return result, err
+		}
+		switch category {
+		case models.LandCostInput:
+			result.InputCost = round2(amount)
+		case models.LandCostLabor:
+			result.LaborCost = round2(amount)
+		case models.LandCostMachinery:
+			result.MachineryCost = round2(amount)
+		}
+	}
+	if err := rows.Err(); err != nil {
+		return result, err
+	}
+
+	if result.Water, err = s.water.LandWater(farmID, landID, from, to); err != nil {
+		return result, err
+	}
+	if result.AllocatedCost, err = s.allocations.AllocatedTo(farmID, models.AllocationTargetLand, landID, from, to); err != nil {
+		return result, err
+	}
+
+	result.Revenue = round2(result.Revenue)
+	result.UnitemizedCost = round2(result.UnitemizedCost)
+	result.TotalCost = round2(result.InputCost + result.LaborCost + result.MachineryCost + result.UnitemizedCost +
+		result.AllocatedCost)
+	result.Profit = round2(result.Revenue - result.TotalCost)
+	if result.Area > 0 {
+		perArea := round2(result.Profit / result.Area)
+		result.ProfitPerArea = &perArea
+	}
+	return result, nil
+}
+
+// ensureActivity aktivitenin çiftliğin bir arazisine ait olduğunu doğrular
+func (s *LandCostService) ensureActivity(farmID, activityID string) error {
+	var exists int
+	err := s.db.QueryRow(`
+		SELECT 1 FROM land_activities a JOIN lands l ON l.id = a.land_id
+		WHERE a.id = ? AND l.user_id = ?
+	`, activityID, farmID).Scan(&exists)
+	if err == sql.ErrNoRows {
+		return ErrLandActivityNotFound
+	}
+	return err
+}
+
+// scanLandCostItem maliyet kalemi satırını okur
+func scanLandCostItem(row interface{ Scan(...interface{}) error }) (models.LandActivityCostItem, error) {
+	var item models.LandActivityCostItem
+	var rate float64
+	err := row.Scan(&item.ID, &item.ActivityID, &item.Category, &item.ProductionID, &item.AssetID, &item.Description,
+		&item.Quantity, &item.Unit, &rate, &item.RateSource, &item.Amount, &item.CreatedAt)
+	item.UnitRate = &rate
+	return item, err
+}
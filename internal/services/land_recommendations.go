@@ -0,0 +1,448 @@
+package services
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"agri-management-api/internal/models"
+	"agri-management-api/internal/utils"
+)
+
+// Arazi önerisi hataları
+var (
+	// ErrRecommendationActivity önerilebilen aktivitelerden biri değil
+	ErrRecommendationActivity = errors.New("unsupported recommendation activity")
+	// ErrRecommendationLand arazi bulunamadı veya çiftliğe ait değil
+	ErrRecommendationLand = errors.New("land not found")
+)
+
+// Önerilen aktivite türleri; arazi aktivitelerinin type alanıyla eşleşir
+const (
+	ActivityPlanting    = "planting"
+	ActivityFertilizing = "fertilizing"
+	ActivityIrrigation  = "irrigation"
+	ActivityScouting    = "scouting"
+)
+
+// recommendationWeatherDays önerilerde dikkate alınan son günlerin sayısı
+const recommendationWeatherDays = 7
+
+// activityAliases serbest metin aktivite türlerinin öneri kurallarındaki karşılıkları
+var activityAliases = map[string][]string{
+	ActivityPlanting:    {"planting", "sowing", "seeding", "ekim", "dikim"},
+	ActivityFertilizing: {"fertilizing", "fertilization", "fertilizer", "gübreleme"},
+	ActivityIrrigation:  {"irrigation", "watering", "sulama"},
+	ActivityScouting:    {"scouting", "inspection", "pest_control", "spraying", "ilaçlama", "kontrol"},
+}
+
+// recommendationActivities takvime eklenebilen önerilen aktivitelerin etkinlik başlıkları
+var recommendationActivities = map[string]string{
+	ActivityFertilizing: "Gübreleme",
+	ActivityIrrigation:  "Sulama",
+	ActivityScouting:    "Zararlı ve hastalık kontrolü",
+}
+
+// cropStageLabels gelişim evrelerinin Türkçe adları
+var cropStageLabels = map[string]string{
+	models.CropStageUnknown:     "Bilinmiyor",
+	models.CropStageGermination: "Çimlenme",
+	models.CropStageVegetative:  "Vejetatif gelişme",
+	models.CropStageFlowering:   "Çiçeklenme",
+	models.CropStageMaturity:    "Olgunlaşma",
+	models.CropStageHarvest:     "Hasat dönemi",
+}
+
+// RecommendationActivityTitle takvime eklenebilen aktivitenin başlığını döner
+func RecommendationActivityTitle(activityType string) (string, bool) {
+	title, ok := recommendationActivities[activityType]
+	return title, ok
+}
+
+// cropHarvestStageDay ekimden sonra hasat döneminin başladığı gün
+const cropHarvestStageDay = 131
+
+// cropStage ekimden bu yana geçen güne göre gelişim evresini döner
+func cropStage(days int) string {
+	switch {
+	case days <= 14:
+		return models.CropStageGermination
+	case days <= 45:
+		return models.CropStageVegetative
+	case days <= 90:
+		return models.CropStageFlowering
+	case days < cropHarvestStageDay:
+		return models.CropStageMaturity
+	default:
+		return models.CropStageHarvest
+	}
+}
+
+// LandRecommendationService arazi başına gelişim evresi, son aktiviteler ve son günlerin hava gözlemlerinden
+// sonraki aktiviteleri (gübreleme, sulama, zararlı kontrolü) önerir. Hava tahmin sağlayıcısı olmadığından
+// kurallar arazinin son yedi günlük sağlayıcı ve kullanıcı gözlemlerini kullanır
+type LandRecommendationService struct {
+	db      *sql.DB
+	weather *WeatherHistoryService
+}
+
+// NewLandRecommendationService yeni arazi öneri servisi oluşturur
+func NewLandRecommendationService(db *sql.DB) *LandRecommendationService {
+	return &LandRecommendationService{db: db, weather: NewWeatherHistoryService(db)}
+}
+
+// Recommendations çiftliğin aktif arazileri için önerileri döner; landID verilirse yalnızca o arazi için
+func (s *LandRecommendationService) Recommendations(farmID, landID string, now time.Time) ([]models.LandRecommendations, error) {
+	query := "SELECT id, name, COALESCE(crop, '') FROM lands WHERE user_id = ? AND COALESCE(status, 'active') != 'inactive'"
+	args := []interface{}{farmID}
+	if landID != "" {
+		query += " AND id = ?"
+		args = append(args, landID)
+	}
+
+	rows, err := s.db.Query(query+" ORDER BY name", args...)
+	if err != nil {
+		return nil, err
+	}
+	var lands []models.LandRecommendations
+	for rows.Next() {
+		var land models.LandRecommendations
+		if err := rows.Scan(&land.LandID, &land.LandName, &land.Crop); err != nil {
+			rows.Close()
+			return nil, err
+		}
+		lands = append(lands, land)
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC)
+	result := make([]models.LandRecommendations, 0, len(lands))
+	for _, land := range lands {
+		if err := s.recommend(farmID, &land, today); err != nil {
+			return nil, err
+		}
+		result = append(result, land)
+	}
+	return result, nil
+}
+
+// recommend arazinin durumunu doldurur ve kuralları uygular
+func (s *LandRecommendationService) recommend(farmID string, land *models.LandRecommendations, today time.Time) error {
+	last, err := s.lastActivities(farmID, land.LandID, today)
+	if err != nil {
+		return err
+	}
+
+	land.Stage = models.CropStageUnknown
+	if planted, ok := last[ActivityPlanting]; ok && !planted.After(today) {
+		days := int(today.Sub(planted).Hours() / 24)
+		land.PlantedOn = planted.Format("2006-01-02")
+		land.DaysSincePlanting = &days
+		land.Stage = cropStage(days)
+	}
+	land.StageLabel = cropStageLabels[land.Stage]
+
+	observations, err := s.weather.dailyObservations(farmID, land.LandID, today.AddDate(0, 0, -(recommendationWeatherDays-1)), today)
+	if err != nil {
+		return err
+	}
+	if len(observations) > 0 {
+		summary := summarizeWeather(observations)
+		land.Weather = &models.RecommendationWeather{
+			Days:        len(observations),
+			Rainfall:    summary.TotalRainfall,
+			AvgTemp:     summary.AvgTemp,
+			MaxTemp:     summary.MaxTemp,
+			AvgHumidity: averageHumidity(observations),
+		}
+	}
+
+	openIssues := 0
+	s.db.QueryRow(`
+		SELECT COUNT(*) FROM pest_disease_observations
+		WHERE user_id = ? AND land_id = ? AND status != ? AND date(observed_on) >= ?
+	`, farmID, land.LandID, models.ObservationStatusDismissed, today.AddDate(0, 0, -30).Format("2006-01-02")).Scan(&openIssues)
+
+	land.Recommendations = []models.LandRecommendation{}
+	growing := land.Stage != models.CropStageUnknown && land.Stage != models.CropStageHarvest
+
+	if growing {
+		if rec, ok := irrigationRecommendation(land, last, today); ok {
+			land.Recommendations = append(land.Recommendations, rec)
+		}
+		if rec, ok := fertilizingRecommendation(land, last, today); ok {
+			land.Recommendations = append(land.Recommendations, rec)
+		}
+	}
+	if rec, ok := scoutingRecommendation(land, last, today, openIssues); ok {
+		land.Recommendations = append(land.Recommendations, rec)
+	}
+
+	for i := range land.Recommendations {
+		rec := &land.Recommendations[i]
+		rec.Action = models.Action{
+			Key:    "schedule",
+			Label:  "Takvime Ekle",
+			Type:   models.ActionTypeAPI,
+			Route:  "/api/v1/lands/" + land.LandID + "/recommendations/schedule",
+			Method: "POST",
+			Payload: map[string]interface{}{
+				"activityType": rec.ActivityType,
+				"date":         rec.SuggestedDate,
+				"priority":     rec.Priority,
+			},
+		}
+	}
+	return nil
+}
+
+// Schedule önerilen aktiviteyi araziye bağlı tüm gün süren bir takvim etkinliği olarak ekler; tarih
+// verilmezse bugün kullanılır
+func (s *LandRecommendationService) Schedule(farmID, landID string, req models.ScheduleRecommendationRequest, now time.Time) (models.Event, error) {
+	activityTitle, ok := RecommendationActivityTitle(req.ActivityType)
+	if !ok {
+		return models.Event{}, ErrRecommendationActivity
+	}
+
+	var landName string
+	err := s.db.QueryRow("SELECT name FROM lands WHERE id = ? AND user_id = ?", landID, farmID).Scan(&landName)
+	if err == sql.ErrNoRows {
+		return models.Event{}, ErrRecommendationLand
+	}
+	if err != nil {
+		return models.Event{}, err
+	}
+
+	date := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC)
+	if req.Date != "" {
+		if date, err = time.Parse("2006-01-02", req.Date); err != nil {
+			return models.Event{}, err
+		}
+	}
+
+	title := strings.TrimSpace(req.Title)
+	if title == "" {
+		title = activityTitle + " - " + landName
+	}
+	priority := req.Priority
+	if priority == "" {
+		priority = models.RecommendationPriorityMedium
+	}
+
+	event := models.Event{
+		ID:            utils.GenerateID(),
+		UserID:        farmID,
+		Title:         title,
+		Description:   strings.TrimSpace(req.Notes),
+		Type:          req.ActivityType,
+		StartDate:     &date,
+		IsAllDay:      true,
+		Status:        "pending",
+		Priority:      priority,
+		RelatedEntity: &models.RelatedEntity{Type: "land", ID: landID, Name: landName},
+		Reminders:     []models.Reminder{},
+		CreatedAt:     now,
+		UpdatedAt:     now,
+	}
+	_, err = s.db.Exec(`
+		INSERT INTO events (id, user_id, title, description, type, start_date, is_all_day, status, priority,
+		                   related_entity_type, related_entity_id, created_at, updated_at)
+		VALUES (?, ?, ?, ?, ?, ?, TRUE, 'pending', ?, 'land', ?, ?, ?)
+	`, event.ID, farmID, event.Title, event.Description, event.Type, date, priority, landID, now, now)
+	if err != nil {
+		return models.Event{}, err
+	}
+	return event, nil
+}
+
+// irrigationRecommendation yağışsız geçen günlerde ve son sulamanın üzerinden en az beş gün geçtiğinde sulama önerir
+func irrigationRecommendation(land *models.LandRecommendations, last map[string]time.Time, today time.Time) (models.LandRecommendation, bool) {
+	since, done := daysSince(last, ActivityIrrigation, today)
+	if done && since < 5 {
+		return models.LandRecommendation{}, false
+	}
+
+	rec := models.LandRecommendation{
+		Key:           "irrigate",
+		ActivityType:  ActivityIrrigation,
+		Title:         "Sulama yapın",
+		Priority:      models.RecommendationPriorityMedium,
+		SuggestedDate: today.Format("2006-01-02"),
+		LastDoneOn:    lastDoneOn(last, ActivityIrrigation),
+	}
+
+	weather := land.Weather
+	if weather == nil {
+		if done && since < 7 {
+			return models.LandRecommendation{}, false
+		}
+		rec.Priority = models.RecommendationPriorityLow
+		rec.Reason = fmt.Sprintf("%s evresinde; son günlere ait hava gözlemi yok, toprak nemini kontrol edip sulamayı planlayın", land.StageLabel)
+		return rec, true
+	}
+	if weather.Rainfall >= 10 {
+		return models.LandRecommendation{}, false
+	}
+
+	rec.Reason = fmt.Sprintf("Son %d günde %.1f mm yağış düştü; ürün %s evresinde", weather.Days, weather.Rainfall, strings.ToLower(land.StageLabel))
+	if weather.Rainfall < 3 && weather.MaxTemp != nil && *weather.MaxTemp >= 30 {
+		rec.Priority = models.RecommendationPriorityHigh
+		rec.Reason += fmt.Sprintf(" ve sıcaklık %.0f °C'ye ulaştı", *weather.MaxTemp)
+	}
+	return rec, true
+}
+
+// fertilizingRecommendation vejetatif gelişme ve çiçeklenmede ekimden sonra hiç gübre verilmediyse veya son
+// gübrelemenin üzerinden 30 gün geçtiyse gübreleme önerir; yakın zamanda yoğun yağış olduysa iki gün erteler
+func fertilizingRecommendation(land *models.LandRecommendations, last map[string]time.Time, today time.Time) (models.LandRecommendation, bool) {
+	if land.Stage != models.CropStageVegetative && land.Stage != models.CropStageFlowering {
+		return models.LandRecommendation{}, false
+	}
+
+	fertilized, done := last[ActivityFertilizing]
+	planted := last[ActivityPlanting]
+	if done && !fertilized.Before(planted) && today.Sub(fertilized).Hours()/24 < 30 {
+		return models.LandRecommendation{}, false
+	}
+
+	rec := models.LandRecommendation{
+		Key:           "fertilize",
+		ActivityType:  ActivityFertilizing,
+		Title:         "Gübreleme yapın",
+		Priority:      models.RecommendationPriorityMedium,
+		SuggestedDate: today.Format("2006-01-02"),
+		LastDoneOn:    lastDoneOn(last, ActivityFertilizing),
+	}
+	if !done || fertilized.Before(planted) {
+		rec.Reason = fmt.Sprintf("Ekimden bu yana %d gün geçti ve henüz gübreleme yapılmadı", *land.DaysSincePlanting)
+		if land.Stage == models.CropStageVegetative {
+			rec.Priority = models.RecommendationPriorityHigh
+		}
+	} else {
+		rec.Reason = fmt.Sprintf("Son gübrelemenin üzerinden %d gün geçti; ürün %s evresinde", int(today.Sub(fertilized).Hours()/24), strings.ToLower(land.StageLabel))
+	}
+
+	if land.Weather != nil && land.Weather.Rainfall >= 20 {
+		rec.SuggestedDate = today.AddDate(0, 0, 2).Format("2006-01-02")
+		rec.Reason += fmt.Sprintf("; son günlerdeki %.1f mm yağış nedeniyle yıkanmayı önlemek için iki gün sonra uygulayın", land.Weather.Rainfall)
+	}
+	return rec, true
+}
+
+// scoutingRecommendation son kontrolün üzerinden 14 gün geçtiyse zararlı ve hastalık kontrolü önerir; açık
+// gözlemler veya nemli ve ılık hava (mantar hastalığı riski) önceliği yükseltir
+func scoutingRecommendation(land *models.LandRecommendations, last map[string]time.Time, today time.Time, openIssues int) (models.LandRecommendation, bool) {
+	since, done := daysSince(last, ActivityScouting, today)
+	if land.Stage == models.CropStageUnknown && openIssues == 0 {
+		return models.LandRecommendation{}, false
+	}
+	if done && since < 14 && openIssues == 0 {
+		return models.LandRecommendation{}, false
+	}
+	if done && since < 3 {
+		return models.LandRecommendation{}, false
+	}
+
+	rec := models.LandRecommendation{
+		Key:           "scout",
+		ActivityType:  ActivityScouting,
+		Title:         "Zararlı ve hastalık kontrolü yapın",
+		Priority:      models.RecommendationPriorityLow,
+		SuggestedDate: today.Format("2006-01-02"),
+		LastDoneOn:    lastDoneOn(last, ActivityScouting),
+		Reason:        "Son 14 günde tarla kontrolü kaydedilmedi",
+	}
+	if done {
+		rec.Reason = fmt.Sprintf("Son kontrolün üzerinden %d gün geçti", since)
+	}
+
+	weather := land.Weather
+	if weather != nil && weather.AvgHumidity != nil && weather.AvgTemp != nil &&
+		*weather.AvgHumidity >= 75 && *weather.AvgTemp >= 15 && *weather.AvgTemp <= 30 {
+		rec.Priority = models.RecommendationPriorityMedium
+		rec.Reason += fmt.Sprintf("; nemli (%%%.0f) ve ılık hava mantar hastalığı riskini artırıyor", *weather.AvgHumidity)
+	}
+	if openIssues > 0 {
+		rec.Priority = models.RecommendationPriorityHigh
+		rec.Reason += fmt.Sprintf("; son 30 günde %d açık zararlı/hastalık gözlemi var", openIssues)
+	}
+	return rec, true
+}
+
+// lastActivities arazinin aktivite türlerine göre en son gerçekleşen (yoksa planlanan ve geçmiş) tarihlerini döner
+func (s *LandRecommendationService) lastActivities(farmID, landID string, today time.Time) (map[string]time.Time, error) {
+	rows, err := s.db.Query(`
+		SELECT LOWER(a.type), MAX(date(COALESCE(a.actual_date, a.scheduled_date)))
+		FROM land_activities a
+		JOIN lands l ON l.id = a.land_id
+		WHERE a.land_id = ? AND l.user_id = ? AND COALESCE(a.actual_date, a.scheduled_date) IS NOT NULL
+		  AND date(COALESCE(a.actual_date, a.scheduled_date)) <= ?
+		GROUP BY LOWER(a.type)
+	`, landID, farmID, today.Format("2006-01-02"))
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	last := map[string]time.Time{}
+	for rows.Next() {
+		var activityType string
+		var day sql.NullString
+		if err := rows.Scan(&activityType, &day); err != nil {
+			return nil, err
+		}
+		date, err := time.Parse("2006-01-02", day.String)
+		if err != nil {
+			continue
+		}
+		for kind, aliases := range activityAliases {
+			for _, alias := range aliases {
+				if activityType == alias {
+					if current, ok := last[kind]; !ok || date.After(current) {
+						last[kind] = date
+					}
+				}
+			}
+		}
+	}
+	return last, rows.Err()
+}
+
+// daysSince aktivitenin son yapılışından bu yana geçen gün sayısını döner
+func daysSince(last map[string]time.Time, kind string, today time.Time) (int, bool) {
+	date, ok := last[kind]
+	if !ok {
+		return 0, false
+	}
+	return int(today.Sub(date).Hours() / 24), true
+}
+
+// lastDoneOn aktivitenin son yapıldığı günü döner
+func lastDoneOn(last map[string]time.Time, kind string) string {
+	if date, ok := last[kind]; ok {
+		return date.Format("2006-01-02")
+	}
+	return ""
+}
+
+// averageHumidity gözlemlerin ortalama nemini döner
+func averageHumidity(observations []models.WeatherObservation) *float64 {
+	var total float64
+	count := 0
+	for _, observation := range observations {
+		if observation.Humidity != nil {
+			total += *observation.Humidity
+			count++
+		}
+	}
+	if count == 0 {
+		return nil
+	}
+	average := round2(total / float64(count))
+	return &average
+}
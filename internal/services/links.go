@@ -0,0 +1,145 @@
+package services
+
+import (
+	"strings"
+
+	"agri-management-api/internal/models"
+)
+
+// APIBasePath ilişki bağlantılarının üretildiği API kök yolu
+const APIBasePath = "/api/v1"
+
+// linkTemplate detay yanıtındaki ilişki bağlantısı; href içindeki {id} kaydın kimliğiyle değiştirilir,
+// method yalnızca GET dışındaki işlemler için doldurulur
+type linkTemplate struct {
+	rel    string
+	href   string
+	method string
+}
+
+// entityLinkTemplates kayıt türlerine göre detay yanıtlarında dönen bağlantılar; ilk bağlantı her zaman self'tir
+var entityLinkTemplates = map[string][]linkTemplate{
+	"livestock": {
+		{rel: "self", href: "/livestock/{id}"},
+		{rel: "healthRecords", href: "/livestock/{id}/health-records"},
+		{rel: "movements", href: "/livestock/{id}/movements"},
+		{rel: "costs", href: "/livestock/{id}/costs"},
+		{rel: "profitability", href: "/livestock/{id}/profitability"},
+		{rel: "slaughter", href: "/livestock/{id}/slaughter"},
+		{rel: "history", href: "/livestock/{id}/history"},
+		{rel: "notes", href: "/notes/livestock/{id}"},
+		{rel: "documents", href: "/documents?entityType=livestock&entityId={id}"},
+		{rel: "voiceNotes", href: "/media/voice-notes?entityType=livestock&entityId={id}"},
+		{rel: "related", href: "/links/livestock/{id}"},
+	},
+	"land": {
+		{rel: "self", href: "/lands/{id}"},
+		{rel: "activities", href: "/lands/{id}/activities"},
+		{rel: "history", href: "/lands/{id}/history"},
+		{rel: "weatherHistory", href: "/lands/{id}/weather-history"},
+		{rel: "weatherObservations", href: "/lands/{id}/weather-observations"},
+		{rel: "notes", href: "/notes/land/{id}"},
+		{rel: "documents", href: "/documents?entityType=land&entityId={id}"},
+		{rel: "voiceNotes", href: "/media/voice-notes?entityType=land&entityId={id}"},
+		{rel: "related", href: "/links/land/{id}"},
+	},
+	"production": {
+		{rel: "self", href: "/production/{id}"},
+		{rel: "sales", href: "/production/{id}/sales"},
+		{rel: "sell", href: "/production/{id}/sell", method: "POST"},
+		{rel: "losses", href: "/production/{id}/losses"},
+		{rel: "notes", href: "/notes/production/{id}"},
+		{rel: "related", href: "/links/production/{id}"},
+	},
+	"transaction": {
+		{rel: "self", href: "/finance/transactions/{id}"},
+		{rel: "pay", href: "/finance/transactions/{id}/pay", method: "PATCH"},
+		{rel: "notes", href: "/notes/transaction/{id}"},
+		{rel: "related", href: "/links/transaction/{id}"},
+	},
+	"greenhouse": {
+		{rel: "self", href: "/greenhouses/{id}"},
+		{rel: "land", href: "/lands/{id}"},
+		{rel: "readings", href: "/greenhouses/{id}/readings"},
+		{rel: "alerts", href: "/greenhouses/{id}/alerts"},
+		{rel: "production", href: "/greenhouses/{id}/production"},
+	},
+	"hive": {
+		{rel: "self", href: "/hives/{id}"},
+		{rel: "inspections", href: "/hives/{id}/inspections"},
+		{rel: "harvests", href: "/hives/{id}/harvests"},
+		{rel: "treatments", href: "/hives/{id}/treatments"},
+		{rel: "notes", href: "/notes/hive/{id}"},
+		{rel: "related", href: "/links/hive/{id}"},
+	},
+	"pond": {
+		{rel: "self", href: "/ponds/{id}"},
+		{rel: "batches", href: "/ponds/{id}/batches"},
+		{rel: "notes", href: "/notes/pond/{id}"},
+		{rel: "related", href: "/links/pond/{id}"},
+	},
+	"fish_batch": {
+		{rel: "self", href: "/fish-batches/{id}"},
+		{rel: "records", href: "/fish-batches/{id}/records"},
+		{rel: "harvest", href: "/fish-batches/{id}/harvest", method: "POST"},
+		{rel: "notes", href: "/notes/fish_batch/{id}"},
+		{rel: "related", href: "/links/fish_batch/{id}"},
+	},
+	"asset": {
+		{rel: "self", href: "/assets/{id}"},
+		{rel: "schedule", href: "/assets/{id}/schedule"},
+		{rel: "dispose", href: "/assets/{id}/dispose", method: "PATCH"},
+		{rel: "notes", href: "/notes/asset/{id}"},
+		{rel: "documents", href: "/documents?entityType=asset&entityId={id}"},
+		{rel: "related", href: "/links/asset/{id}"},
+	},
+	"document": {
+		{rel: "self", href: "/documents/{id}"},
+		{rel: "file", href: "/documents/{id}/file"},
+		{rel: "related", href: "/links/document/{id}"},
+	},
+	"utility_meter": {
+		{rel: "self", href: "/utilities/meters/{id}"},
+		{rel: "readings", href: "/utilities/meters/{id}/readings"},
+		{rel: "related", href: "/links/utility_meter/{id}"},
+	},
+	"event": {
+		{rel: "self", href: "/calendar/events/{id}"},
+		{rel: "related", href: "/links/event/{id}"},
+	},
+	"vet_visit": {
+		{rel: "self", href: "/vet-visits/{id}"},
+		{rel: "cancel", href: "/vet-visits/{id}/cancel", method: "PATCH"},
+	},
+}
+
+// RelatedLink detay yanıtına eklenen başka bir kayda bağlantı (ör. üretimin arazisi); kimlik boşsa atlanır
+type RelatedLink struct {
+	Rel        string
+	EntityType string
+	ID         *string
+}
+
+// EntityLinks kaydın self ve ilişkili kaynak bağlantılarını üretir; related ile verilen kayıtlar kendi
+// türlerinin self bağlantısıyla eklenir
+func EntityLinks(entityType, id string, related ...RelatedLink) models.Links {
+	links := models.Links{}
+	for _, template := range entityLinkTemplates[entityType] {
+		links[template.rel] = models.Link{Href: expandLink(template.href, id), Method: template.method}
+	}
+
+	for _, item := range related {
+		if item.ID == nil || *item.ID == "" {
+			continue
+		}
+		if templates, ok := entityLinkTemplates[item.EntityType]; ok {
+			links[item.Rel] = models.Link{Href: expandLink(templates[0].href, *item.ID)}
+		}
+	}
+	return links
+}
+
+// expandLink bağlantı şablonunu API kök yoluyla birlikte kayıt kimliğine açar
+func expandLink(href, id string) string {
+	return APIBasePath + strings.ReplaceAll(href, "{id}", id)
+}
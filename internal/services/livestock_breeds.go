@@ -0,0 +1,226 @@
+package services
+
+import (
+	"database/sql"
+	"errors"
+	"slices"
+	"strings"
+	"time"
+
+	"agri-management-api/internal/models"
+	"agri-management-api/internal/utils"
+)
+
+var (
+	// ErrUnknownSpecies hayvan türü tür listesinde tanımlı değil
+	ErrUnknownSpecies = errors.New("tanımsız hayvan türü")
+	// ErrUnknownBreed ırk, türün ırk listesinde tanımlı değil
+	ErrUnknownBreed = errors.New("tanımsız ırk")
+	// ErrBreedExists ırk tür için zaten tanımlı
+	ErrBreedExists = errors.New("ırk zaten tanımlı")
+	// ErrBreedNotFound ırk bulunamadı veya sistem ırkı olduğu için silinemez
+	ErrBreedNotFound = errors.New("ırk bulunamadı")
+	// ErrSpeciesExists sistem türü zaten tanımlı
+	ErrSpeciesExists = errors.New("tür zaten tanımlı")
+)
+
+// LivestockBreedService hayvan türleri (livestock kategorileri) ve ırklarından oluşan başvuru listesini yönetir.
+// Sistem türleri ve ırkları yönetici tarafından, çiftliğe özel türler kategori kayıt defterinden, çiftliğe
+// özel ırklar bu servisle eklenir
+type LivestockBreedService struct {
+	db         *sql.DB
+	categories *CategoryService
+}
+
+// NewLivestockBreedService yeni livestock breed service oluşturur
+func NewLivestockBreedService(db *sql.DB) *LivestockBreedService {
+	return &LivestockBreedService{db: db, categories: NewCategoryService(db)}
+}
+
+// Species çiftliğin görebildiği türleri sistem ve çiftlik ırklarıyla döner
+func (s *LivestockBreedService) Species(farmID string) ([]models.LivestockSpecies, error) {
+	categories, err := s.categories.List(farmID, models.CategoryDomainLivestock)
+	if err != nil {
+		return nil, err
+	}
+	breeds, err := s.breeds(farmID, "")
+	if err != nil {
+		return nil, err
+	}
+
+	bySpecies := map[string][]models.LivestockBreed{}
+	for _, breed := range breeds {
+		bySpecies[breed.Species] = append(bySpecies[breed.Species], breed)
+	}
+
+	species := make([]models.LivestockSpecies, 0, len(categories))
+	for _, category := range categories {
+		item := models.LivestockSpecies{
+			Key:      category.Key,
+			Label:    category.Label,
+			Icon:     category.Icon,
+			Color:    category.Color,
+			IsSystem: category.IsSystem,
+			Breeds:   bySpecies[category.Key],
+		}
+		if item.Breeds == nil {
+			item.Breeds = []models.LivestockBreed{}
+		}
+		species = append(species, item)
+	}
+	return species, nil
+}
+
+// SpeciesKeys çiftliğin görebildiği tür anahtarları
+func (s *LivestockBreedService) SpeciesKeys(farmID string) []string {
+	categories, _ := s.categories.List(farmID, models.CategoryDomainLivestock)
+	keys := make([]string, 0, len(categories))
+	for _, category := range categories {
+		keys = append(keys, category.Key)
+	}
+	return keys
+}
+
+// BreedNames türün çiftlikte geçerli ırk adları
+func (s *LivestockBreedService) BreedNames(farmID, species string) []string {
+	breeds, _ := s.breeds(farmID, species)
+	names := make([]string, 0, len(breeds))
+	for _, breed := range breeds {
+		names = append(names, breed.Name)
+	}
+	return names
+}
+
+// Validate türün tanımlı olduğunu ve ırkın türün ırk listesinde bulunduğunu doğrular; ırk listedeki yazımıyla
+// döner. Irk listesi boş olan türlerde (ör. çiftliğin eklediği yeni türler) her ırk kabul edilir
+func (s *LivestockBreedService) Validate(farmID, species, breed string) (string, error) {
+	species = strings.TrimSpace(species)
+	breed = strings.TrimSpace(breed)
+
+	if !slices.Contains(s.SpeciesKeys(farmID), species) {
+		return breed, ErrUnknownSpecies
+	}
+
+	breeds, err := s.breeds(farmID, species)
+	if err != nil {
+		return breed, err
+	}
+	if len(breeds) == 0 {
+		return breed, nil
+	}
+	for _, known := range breeds {
+		if strings.EqualFold(known.Name, breed) {
+			return known.Name, nil
+		}
+	}
+	return breed, ErrUnknownBreed
+}
+
+// AddBreed türe ırk ekler; farmID boşsa tüm çiftliklerde görünen sistem ırkı eklenir
+func (s *LivestockBreedService) AddBreed(farmID string, req models.LivestockBreedRequest) (models.LivestockBreed, error) {
+	species := strings.TrimSpace(req.Species)
+	name := strings.TrimSpace(req.Name)
+
+	if !slices.Contains(s.SpeciesKeys(farmID), species) {
+		return models.LivestockBreed{}, ErrUnknownSpecies
+	}
+	breeds, err := s.breeds(farmID, species)
+	if err != nil {
+		return models.LivestockBreed{}, err
+	}
+	for _, known := range breeds {
+		if strings.EqualFold(known.Name, name) {
+			return models.LivestockBreed{}, ErrBreedExists
+		}
+	}
+
+	breed := models.LivestockBreed{
+		ID:        utils.GenerateID(),
+		Species:   species,
+		Name:      name,
+		IsSystem:  farmID == "",
+		CreatedAt: time.Now().UTC(),
+	}
+	var owner interface{}
+	if farmID == "" {
+		breed.ID = "system:" + species + ":" + name
+	} else {
+		owner = farmID
+	}
+	_, err = s.db.Exec(`
+		INSERT INTO livestock_breeds (id, user_id, species, name, created_at) VALUES (?, ?, ?, ?, ?)
+	`, breed.ID, owner, breed.Species, breed.Name, breed.CreatedAt)
+	return breed, err
+}
+
+// DeleteBreed çiftliğin eklediği ırkı siler; sistem ırkları silinemez. Bu ırktaki hayvanlar değişmez
+func (s *LivestockBreedService) DeleteBreed(farmID, breedID string) error {
+	result, err := s.db.Exec("DELETE FROM livestock_breeds WHERE id = ? AND user_id = ?", breedID, farmID)
+	if err != nil {
+		return err
+	}
+	if rows, _ := result.RowsAffected(); rows == 0 {
+		return ErrBreedNotFound
+	}
+	return nil
+}
+
+// AddSystemSpecies tüm çiftliklerde görünen yeni sistem türü ekler
+func (s *LivestockBreedService) AddSystemSpecies(req models.LivestockSpeciesRequest) (models.LivestockSpecies, error) {
+	key := strings.ToLower(strings.TrimSpace(req.Key))
+	id := "system:" + models.CategoryDomainLivestock + ":" + key
+
+	var exists bool
+	if s.db.QueryRow("SELECT 1 FROM categories WHERE id = ?", id).Scan(&exists) == nil {
+		return models.LivestockSpecies{}, ErrSpeciesExists
+	}
+
+	_, err := s.db.Exec(`
+		INSERT INTO categories (id, user_id, domain, key, label, icon, color, sort_order, created_at, updated_at)
+		VALUES (?, NULL, ?, ?, ?, ?, ?, ?, CURRENT_TIMESTAMP, CURRENT_TIMESTAMP)
+	`, id, models.CategoryDomainLivestock, key, req.Label, req.Icon, req.Color, req.SortOrder)
+	if err != nil {
+		return models.LivestockSpecies{}, err
+	}
+
+	return models.LivestockSpecies{
+		Key:      key,
+		Label:    req.Label,
+		Icon:     req.Icon,
+		Color:    req.Color,
+		IsSystem: true,
+		Breeds:   []models.LivestockBreed{},
+	}, nil
+}
+
+// breeds çiftliğin görebildiği ırklar; species boşsa tüm türlerin ırkları döner
+func (s *LivestockBreedService) breeds(farmID, species string) ([]models.LivestockBreed, error) {
+	query := `
+		SELECT id, user_id, species, name, created_at
+		FROM livestock_breeds
+		WHERE (user_id IS NULL OR user_id = ?)`
+	args := []interface{}{farmID}
+	if species != "" {
+		query += " AND species = ?"
+		args = append(args, species)
+	}
+	query += " ORDER BY species, user_id IS NOT NULL, name"
+
+	rows, err := s.db.Query(query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var breeds []models.LivestockBreed
+	for rows.Next() {
+		var breed models.LivestockBreed
+		var owner sql.NullString
+		if err := rows.Scan(&breed.ID, &owner, &breed.Species, &breed.Name, &breed.CreatedAt); err != nil {
+			return nil, err
+		}
+		breed.IsSystem = !owner.Valid
+		breeds = append(breeds, breed)
+	}
+	return breeds, rows.Err()
+}
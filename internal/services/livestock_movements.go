@@ -0,0 +1,317 @@
+package services
+
+import (
+	"database/sql"
+	"errors"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"agri-management-api/internal/models"
+	"agri-management-api/internal/utils"
+)
+
+// MovementTypeTransfer çiftlik içinde konumlar arası veya başka işletmeye nakil
+const MovementTypeTransfer = "transfer"
+
+// exitMovementTypes hayvanı sürüden çıkaran hareket tipleri; bu hareketlerin varış yeri alıcıdır, konum değildir
+const exitMovementTypes = "'sale', 'death', 'slaughter'"
+
+// inHerdCondition sürüde bulunan (satılmamış, ölmemiş, kesilmemiş) hayvan koşulu; l takma adı livestock tablosudur
+const inHerdCondition = `l.sale_date IS NULL
+	  AND NOT EXISTS (
+	      SELECT 1 FROM livestock_movements x
+	      WHERE x.livestock_id = l.id AND x.movement_type IN (` + exitMovementTypes + `)
+	  )`
+
+var (
+	// ErrLivestockNotFound hayvan bulunamadı
+	ErrLivestockNotFound = errors.New("hayvan bulunamadı")
+	// ErrMovementDestinationRequired nakil hareketinde varış yeri zorunludur
+	ErrMovementDestinationRequired = errors.New("nakil hareketinde varış yeri zorunludur")
+	// ErrMovementSameLocation çıkış ve varış yeri aynı olamaz
+	ErrMovementSameLocation = errors.New("çıkış ve varış yeri aynı olamaz")
+)
+
+// LivestockMovementService hayvan hareketlerini kaydeder, güncel konumu hareketlerden türetir ve konum
+// doluluk ve izlenebilirlik raporlarını üretir
+type LivestockMovementService struct {
+	db *sql.DB
+}
+
+// NewLivestockMovementService yeni hayvan hareketi servisi oluşturur
+func NewLivestockMovementService(db *sql.DB) *LivestockMovementService {
+	return &LivestockMovementService{db: db}
+}
+
+// Record hareketi kaydeder ve hayvanın konum alanını hareketlerden yeniden türetir. Çıkış yeri verilmeyen
+// nakillerde hayvanın hareket tarihindeki konumu kullanılır
+func (s *LivestockMovementService) Record(farmID, animalID string, movement models.LivestockMovement) (models.LivestockMovement, error) {
+	movement.FromLocation = strings.TrimSpace(movement.FromLocation)
+	movement.ToLocation = strings.TrimSpace(movement.ToLocation)
+	if movement.MovementType == MovementTypeTransfer && movement.ToLocation == "" {
+		return movement, ErrMovementDestinationRequired
+	}
+
+	tx, err := s.db.Begin()
+	if err != nil {
+		return movement, err
+	}
+	defer tx.Rollback()
+
+	var location string
+	err = tx.QueryRow("SELECT COALESCE(location, '') FROM livestock WHERE id = ? AND user_id = ?", animalID, farmID).Scan(&location)
+	if err == sql.ErrNoRows {
+		return movement, ErrLivestockNotFound
+	}
+	if err != nil {
+		return movement, err
+	}
+
+	if movement.FromLocation == "" && movement.MovementType != "birth" && movement.MovementType != "purchase" {
+		movement.FromLocation, err = locationAt(tx, farmID, animalID, movement.MovementDate, location)
+		if err != nil {
+			return movement, err
+		}
+	}
+	if movement.MovementType == MovementTypeTransfer && movement.FromLocation == movement.ToLocation {
+		return movement, ErrMovementSameLocation
+	}
+
+	movement.ID = utils.GenerateID()
+	movement.LivestockID = animalID
+	_, err = tx.Exec(`
+		INSERT INTO livestock_movements (id, livestock_id, user_id, movement_type, movement_date,
+		                                 from_location, to_location, premises_number, reason, notes, created_at)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, CURRENT_TIMESTAMP)
+	`, movement.ID, animalID, farmID, movement.MovementType, movement.MovementDate, movement.FromLocation,
+		movement.ToLocation, movement.PremisesNumber, movement.Reason, movement.Notes)
+	if err != nil {
+		return movement, err
+	}
+
+	if err := syncCurrentLocation(tx, farmID, animalID); err != nil {
+		return movement, err
+	}
+	if err := tx.QueryRow("SELECT created_at FROM livestock_movements WHERE id = ? AND user_id = ?", movement.ID, farmID).Scan(&movement.CreatedAt); err != nil {
+		return movement, err
+	}
+
+	return movement, tx.Commit()
+}
+
+// RecordRelocation hayvan kaydında konum elle değiştirildiğinde değişikliği bugünkü tarihli bir nakil
+// hareketi olarak geçmişe yazar
+func (s *LivestockMovementService) RecordRelocation(farmID, animalID, from, to string) error {
+	today := time.Now().UTC().Truncate(24 * time.Hour)
+	_, err := s.db.Exec(`
+		INSERT INTO livestock_movements (id, livestock_id, user_id, movement_type, movement_date,
+		                                 from_location, to_location, reason, created_at)
+		VALUES (?, ?, ?, ?, ?, ?, ?, 'Konum güncellendi', CURRENT_TIMESTAMP)
+	`, utils.GenerateID(), animalID, farmID, MovementTypeTransfer, today, from, to)
+	return err
+}
+
+// locationAt hayvanın verilen tarihteki konumunu son konum hareketinden bulur; tarihten önce hareket yoksa
+// sonraki ilk hareketin çıkış yeri, o da yoksa hayvan kaydındaki konum döner
+func locationAt(tx *sql.Tx, farmID, animalID string, date *time.Time, fallback string) (string, error) {
+	day := "9999-12-31"
+	if date != nil {
+		day = date.Format("2006-01-02")
+	}
+
+	var location string
+	err := tx.QueryRow(`
+		SELECT to_location FROM livestock_movements
+		WHERE livestock_id = ? AND user_id = ? AND COALESCE(to_location, '') <> ''
+		  AND movement_type NOT IN (`+exitMovementTypes+`) AND date(movement_date) <= ?
+		ORDER BY date(movement_date) DESC, created_at DESC LIMIT 1
+	`, animalID, farmID, day).Scan(&location)
+	if err != sql.ErrNoRows {
+		return location, err
+	}
+
+	err = tx.QueryRow(`
+		SELECT from_location FROM livestock_movements
+		WHERE livestock_id = ? AND user_id = ? AND COALESCE(from_location, '') <> ''
+		  AND movement_type NOT IN ('birth', 'purchase') AND date(movement_date) > ?
+		ORDER BY date(movement_date), created_at LIMIT 1
+	`, animalID, farmID, day).Scan(&location)
+	if err == sql.ErrNoRows {
+		return fallback, nil
+	}
+	return location, err
+}
+
+// syncCurrentLocation hayvanın konum alanını varış yeri olan en son (çıkış dışı) hareketten türetir;
+// konum hareketi olmayan hayvanların konumu değiştirilmez
+func syncCurrentLocation(tx *sql.Tx, farmID, animalID string) error {
+	_, err := tx.Exec(`
+		UPDATE livestock SET location = (
+		    SELECT to_location FROM livestock_movements
+		    WHERE livestock_id = livestock.id AND COALESCE(to_location, '') <> ''
+		      AND movement_type NOT IN (`+exitMovementTypes+`)
+		    ORDER BY date(movement_date) DESC, created_at DESC LIMIT 1
+		), updated_at = CURRENT_TIMESTAMP
+		WHERE id = ? AND user_id = ? AND EXISTS (
+		    SELECT 1 FROM livestock_movements
+		    WHERE livestock_id = livestock.id AND COALESCE(to_location, '') <> ''
+		      AND movement_type NOT IN (`+exitMovementTypes+`)
+		)
+	`, animalID, farmID)
+	return err
+}
+
+// Occupancy sürüdeki hayvanları konuma ve türe göre sayar; konumu boş hayvanlar boş konum satırında
+// toplanır. Son giriş, konuma yapılan en son hareketin tarihidir
+func (s *LivestockMovementService) Occupancy(farmID string) ([]models.LivestockLocationOccupancy, error) {
+	rows, err := s.db.Query(`
+		SELECT COALESCE(l.location, ''), l.type, COUNT(*)
+		FROM livestock l
+		WHERE l.user_id = ? AND `+inHerdCondition+`
+		GROUP BY COALESCE(l.location, ''), l.type
+	`, farmID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	locations := map[string]*models.LivestockLocationOccupancy{}
+	for rows.Next() {
+		var location, animalType string
+		var count int
+		if err := rows.Scan(&location, &animalType, &count); err != nil {
+			return nil, err
+		}
+		occupancy, ok := locations[location]
+		if !ok {
+			occupancy = &models.LivestockLocationOccupancy{Location: location, ByType: map[string]int{}}
+			locations[location] = occupancy
+		}
+		occupancy.Animals += count
+		occupancy.ByType[animalType] += count
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	arrivals, err := s.db.Query(`
+		SELECT to_location, MAX(date(movement_date))
+		FROM livestock_movements
+		WHERE user_id = ? AND COALESCE(to_location, '') <> '' AND movement_type NOT IN (`+exitMovementTypes+`)
+		GROUP BY to_location
+	`, farmID)
+	if err != nil {
+		return nil, err
+	}
+	defer arrivals.Close()
+
+	for arrivals.Next() {
+		var location, day string
+		if err := arrivals.Scan(&location, &day); err != nil {
+			return nil, err
+		}
+		if occupancy, ok := locations[location]; ok {
+			if date, err := time.Parse("2006-01-02", day); err == nil {
+				occupancy.LastArrival = &date
+			}
+		}
+	}
+	if err := arrivals.Err(); err != nil {
+		return nil, err
+	}
+
+	result := make([]models.LivestockLocationOccupancy, 0, len(locations))
+	for _, occupancy := range locations {
+		result = append(result, *occupancy)
+	}
+	sort.Slice(result, func(i, j int) bool {
+		if result[i].Location == "" || result[j].Location == "" {
+			return result[j].Location == ""
+		}
+		return result[i].Location < result[j].Location
+	})
+	return result, nil
+}
+
+// Report tarih aralığındaki hareketleri eskiden yeniye listeler. Konum verilirse çıkış veya varış yeri
+// konum olan hareketler, tip verilirse yalnızca o tipteki hareketler döner
+func (s *LivestockMovementService) Report(farmID string, startDate, endDate *time.Time, location, movementType string) (models.LivestockMovementReport, error) {
+	report := models.LivestockMovementReport{
+		Location:  location,
+		ByType:    map[string]int{},
+		Movements: []models.LivestockMovementReportRow{},
+	}
+
+	from, to := "0001-01-01", "9999-12-31"
+	if startDate != nil {
+		from = startDate.Format("2006-01-02")
+		report.StartDate = from
+	}
+	if endDate != nil {
+		to = endDate.Format("2006-01-02")
+		report.EndDate = to
+	}
+
+	query := `
+		SELECT m.id, m.livestock_id, m.movement_type, m.movement_date, COALESCE(m.from_location, ''),
+		       COALESCE(m.to_location, ''), COALESCE(m.premises_number, ''), COALESCE(m.reason, ''),
+		       COALESCE(m.notes, ''), m.created_at, COALESCE(l.tag_number, ''), COALESCE(l.type, '')
+		FROM livestock_movements m
+		LEFT JOIN livestock l ON l.id = m.livestock_id
+		WHERE m.user_id = ? AND date(m.movement_date) BETWEEN ? AND ?`
+	args := []interface{}{farmID, from, to}
+	if location != "" {
+		query += " AND (m.from_location = ? OR m.to_location = ?)"
+		args = append(args, location, location)
+	}
+	if movementType != "" {
+		query += " AND m.movement_type = ?"
+		args = append(args, movementType)
+	}
+	query += " ORDER BY date(m.movement_date), m.created_at"
+
+	rows, err := s.db.Query(query, args...)
+	if err != nil {
+		return report, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var row models.LivestockMovementReportRow
+		var movementDate sql.NullTime
+		err := rows.Scan(
+			&row.ID, &row.LivestockID, &row.MovementType, &movementDate, &row.FromLocation,
+			&row.ToLocation, &row.PremisesNumber, &row.Reason, &row.Notes, &row.CreatedAt,
+			&row.TagNumber, &row.AnimalType,
+		)
+		if err != nil {
+			return report, err
+		}
+		row.MovementDate = utils.NullTimeToPtr(movementDate)
+		report.Movements = append(report.Movements, row)
+		report.ByType[row.MovementType]++
+	}
+	report.Total = len(report.Movements)
+	return report, rows.Err()
+}
+
+// MovementRecords hareket raporunu dışa aktarım satırlarına çevirir
+func MovementRecords(report models.LivestockMovementReport, f Formatter) [][]string {
+	records := [][]string{{"Tarih", "Küpe No", "Tür", "Hareket", "Çıkış Yeri", "Varış Yeri", "İşletme No", "Neden", "Not"}}
+	for _, movement := range report.Movements {
+		records = append(records, []string{
+			f.OptionalDate(movement.MovementDate),
+			movement.TagNumber,
+			movement.AnimalType,
+			passportLabel(passportMovementLabels, movement.MovementType),
+			movement.FromLocation,
+			movement.ToLocation,
+			movement.PremisesNumber,
+			movement.Reason,
+			movement.Notes,
+		})
+	}
+	return append(records, []string{"Toplam", strconv.Itoa(report.Total), "", "", "", "", "", "", ""})
+}
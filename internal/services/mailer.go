@@ -0,0 +1,66 @@
+package services
+
+import (
+	"fmt"
+	"mime"
+	"net"
+	"net/smtp"
+	"os"
+	"strings"
+	"time"
+)
+
+// Mailer e-posta gönderen sağlayıcı arayüzü
+type Mailer interface {
+	Send(to, subject, body string) error
+}
+
+// NewMailer SMTP_HOST ortam değişkenine göre SMTP göndericisi oluşturur; yapılandırılmamışsa nil döner
+func NewMailer() Mailer {
+	host := os.Getenv("SMTP_HOST")
+	if host == "" {
+		return nil
+	}
+	port := os.Getenv("SMTP_PORT")
+	if port == "" {
+		port = "587"
+	}
+	from := os.Getenv("SMTP_FROM")
+	if from == "" {
+		from = os.Getenv("SMTP_USERNAME")
+	}
+	return &SMTPMailer{
+		addr:     net.JoinHostPort(host, port),
+		host:     host,
+		username: os.Getenv("SMTP_USERNAME"),
+		password: os.Getenv("SMTP_PASSWORD"),
+		from:     from,
+	}
+}
+
+// SMTPMailer düz metin e-postaları SMTP sunucusu üzerinden gönderir; sunucu destekliyorsa STARTTLS kullanılır
+type SMTPMailer struct {
+	addr, host         string
+	username, password string
+	from               string
+}
+
+// Send e-postayı gönderir
+func (m *SMTPMailer) Send(to, subject, body string) error {
+	var auth smtp.Auth
+	if m.username != "" {
+		auth = smtp.PlainAuth("", m.username, m.password, m.host)
+	}
+
+	var msg strings.Builder
+	fmt.Fprintf(&msg, "From: %s\r\n", m.from)
+	fmt.Fprintf(&msg, "To: %s\r\n", to)
+	fmt.Fprintf(&msg, "Subject: %s\r\n", mime.QEncoding.Encode("utf-8", subject))
+	fmt.Fprintf(&msg, "Date: %s\r\n", time.Now().Format(time.RFC1123Z))
+	msg.WriteString("MIME-Version: 1.0\r\n")
+	msg.WriteString("Content-Type: text/plain; charset=utf-8\r\n")
+	msg.WriteString("Content-Transfer-Encoding: 8bit\r\n\r\n")
+	msg.WriteString(strings.ReplaceAll(body, "\n", "\r\n"))
+
+	return smtp.SendMail(m.addr, auth, m.from, []string{to}, []byte(msg.String()))
+}
@@ -0,0 +1,250 @@
+package services
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"agri-management-api/internal/models"
+	"agri-management-api/internal/utils"
+)
+
+// DefaultMaintenanceMessage bakım penceresine mesaj yazılmadığında kullanıcılara gösterilen metin
+const DefaultMaintenanceMessage = "Sistem bakımda. Bu sürede kayıtlar görüntülenebilir ancak değiştirilemez."
+
+// maintenanceCacheTTL her yazma isteğinde veritabanına gidilmemesi için durumun bellekte tutulduğu süre
+const maintenanceCacheTTL = 5 * time.Second
+
+var (
+	// ErrInvalidMaintenanceWindow bakım penceresi zamanları geçersiz olduğunda döner
+	ErrInvalidMaintenanceWindow = errors.New("geçersiz bakım penceresi")
+	// ErrMaintenanceWindowNotFound bakım penceresi bulunamadığında döner
+	ErrMaintenanceWindowNotFound = errors.New("bakım penceresi bulunamadı")
+)
+
+// maintenanceWindowSelect bakım pencerelerini okuyan sorgu
+const maintenanceWindowSelect = `
+	SELECT id, COALESCE(message, ''), starts_at, ends_at, COALESCE(created_by, ''), created_at
+	FROM maintenance_windows`
+
+// maintenanceCache middleware ve yönetim uç noktalarının paylaştığı güncel bakım penceresi
+var maintenanceCache struct {
+	mu        sync.Mutex
+	window    *models.MaintenanceWindow
+	checkedAt time.Time
+}
+
+// MaintenanceService bakım modunu ve planlanmış bakım pencerelerini yönetir
+type MaintenanceService struct {
+	db *sql.DB
+}
+
+// NewMaintenanceService yeni maintenance service oluşturur
+func NewMaintenanceService(db *sql.DB) *MaintenanceService {
+	return &MaintenanceService{db: db}
+}
+
+// Active şu an süren bakım penceresini döner; bakım yoksa nil. Sonuç kısa süre bellekte tutulur
+func (s *MaintenanceService) Active() (*models.MaintenanceWindow, error) {
+	maintenanceCache.mu.Lock()
+	defer maintenanceCache.mu.Unlock()
+
+	now := time.Now()
+	if !maintenanceCache.checkedAt.IsZero() && now.Sub(maintenanceCache.checkedAt) < maintenanceCacheTTL {
+		if maintenanceCache.window == nil || maintenanceCache.window.EndsAt == nil || maintenanceCache.window.EndsAt.After(now) {
+			return maintenanceCache.window, nil
+		}
+	}
+
+	window, err := s.current(now)
+	if err != nil {
+		return nil, err
+	}
+	maintenanceCache.window = window
+	maintenanceCache.checkedAt = now
+	return window, nil
+}
+
+// Status bakım modunun güncel durumunu ve henüz başlamamış pencereleri döner
+func (s *MaintenanceService) Status() (models.MaintenanceStatus, error) {
+	status := models.MaintenanceStatus{Upcoming: []models.MaintenanceWindow{}}
+	now := time.Now()
+
+	current, err := s.current(now)
+	if err != nil {
+		return status, err
+	}
+	status.Active = current != nil
+	status.Current = current
+
+	rows, err := s.db.Query(maintenanceWindowSelect+" WHERE starts_at > ? ORDER BY starts_at", now.UTC())
+	if err != nil {
+		return status, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		window, err := scanMaintenanceWindow(rows)
+		if err != nil {
+			continue
+		}
+		status.Upcoming = append(status.Upcoming, window)
+	}
+	return status, rows.Err()
+}
+
+// Windows son pencereleri (bitmiş olanlar dahil) başlangıç zamanına göre yeniden eskiye döner
+func (s *MaintenanceService) Windows(limit int) ([]models.MaintenanceWindow, error) {
+	rows, err := s.db.Query(maintenanceWindowSelect+" ORDER BY starts_at DESC LIMIT ?", limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	now := time.Now()
+	windows := []models.MaintenanceWindow{}
+	for rows.Next() {
+		window, err := scanMaintenanceWindow(rows)
+		if err != nil {
+			continue
+		}
+		window.Active = windowActive(window, now)
+		windows = append(windows, window)
+	}
+	return windows, rows.Err()
+}
+
+// Enable bakım modunu hemen açar. Süren bir bakım varsa yenisi açılmaz; mesajı ve tahmini bitişi güncellenir
+func (s *MaintenanceService) Enable(adminID, message string, expectedEndAt *time.Time) (*models.MaintenanceWindow, error) {
+	now := time.Now().UTC()
+	if expectedEndAt != nil && !expectedEndAt.After(now) {
+		return nil, fmt.Errorf("%w: tahmini bitiş zamanı gelecekte olmalı", ErrInvalidMaintenanceWindow)
+	}
+	expectedEndAt = utcTimePtr(expectedEndAt)
+	defer invalidateMaintenanceCache()
+
+	current, err := s.current(now)
+	if err != nil {
+		return nil, err
+	}
+	if current != nil {
+		_, err = s.db.Exec("UPDATE maintenance_windows SET message = ?, ends_at = ? WHERE id = ?",
+			message, expectedEndAt, current.ID)
+		if err != nil {
+			return nil, err
+		}
+		return s.window(current.ID)
+	}
+
+	id := utils.GenerateID()
+	_, err = s.db.Exec(`
+		INSERT INTO maintenance_windows (id, message, starts_at, ends_at, created_by, created_at)
+		VALUES (?, ?, ?, ?, ?, CURRENT_TIMESTAMP)
+	`, id, message, now, expectedEndAt, adminID)
+	if err != nil {
+		return nil, err
+	}
+	return s.window(id)
+}
+
+// Disable süren tüm bakım pencerelerini şimdi bitirir; planlanmış pencereler etkilenmez
+func (s *MaintenanceService) Disable() error {
+	defer invalidateMaintenanceCache()
+
+	now := time.Now().UTC()
+	_, err := s.db.Exec(`
+		UPDATE maintenance_windows SET ends_at = ?
+		WHERE starts_at <= ? AND (ends_at IS NULL OR ends_at > ?)
+	`, now, now, now)
+	return err
+}
+
+// Schedule ileri tarihli bakım penceresi planlar; pencere başlangıç zamanında kendiliğinden devreye girer
+func (s *MaintenanceService) Schedule(adminID string, req models.MaintenanceWindowRequest) (*models.MaintenanceWindow, error) {
+	startsAt, endsAt := req.StartsAt.UTC(), req.EndsAt.UTC()
+	if !endsAt.After(startsAt) {
+		return nil, fmt.Errorf("%w: bitiş zamanı başlangıçtan sonra olmalı", ErrInvalidMaintenanceWindow)
+	}
+	if !endsAt.After(time.Now()) {
+		return nil, fmt.Errorf("%w: bitiş zamanı gelecekte olmalı", ErrInvalidMaintenanceWindow)
+	}
+	defer invalidateMaintenanceCache()
+
+	id := utils.GenerateID()
+	_, err := s.db.Exec(`
+		INSERT INTO maintenance_windows (id, message, starts_at, ends_at, created_by, created_at)
+		VALUES (?, ?, ?, ?, ?, CURRENT_TIMESTAMP)
+	`, id, req.Message, startsAt, endsAt, adminID)
+	if err != nil {
+		return nil, err
+	}
+	return s.window(id)
+}
+
+// Delete bakım penceresini siler; süren bir pencere silinirse bakım hemen sona erer
+func (s *MaintenanceService) Delete(id string) error {
+	defer invalidateMaintenanceCache()
+
+	result, err := s.db.Exec("DELETE FROM maintenance_windows WHERE id = ?", id)
+	if err != nil {
+		return err
+	}
+	if affected, _ := result.RowsAffected(); affected == 0 {
+		return ErrMaintenanceWindowNotFound
+	}
+	return nil
+}
+
+// current verilen anda süren bakım penceresini döner; birden fazlaysa en geç biteni (süresiz olanı önce) seçer
+func (s *MaintenanceService) current(now time.Time) (*models.MaintenanceWindow, error) {
+	now = now.UTC()
+	row := s.db.QueryRow(maintenanceWindowSelect+`
+		WHERE starts_at <= ? AND (ends_at IS NULL OR ends_at > ?)
+		ORDER BY ends_at IS NULL DESC, ends_at DESC LIMIT 1
+	`, now, now)
+	window, err := scanMaintenanceWindow(row)
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	window.Active = true
+	return &window, nil
+}
+
+// window tek bir bakım penceresini döner
+func (s *MaintenanceService) window(id string) (*models.MaintenanceWindow, error) {
+	window, err := scanMaintenanceWindow(s.db.QueryRow(maintenanceWindowSelect+" WHERE id = ?", id))
+	if err == sql.ErrNoRows {
+		return nil, ErrMaintenanceWindowNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	window.Active = windowActive(window, time.Now())
+	return &window, nil
+}
+
+// windowActive pencerenin verilen anda sürüp sürmediğini döner
+func windowActive(window models.MaintenanceWindow, now time.Time) bool {
+	return !window.StartsAt.After(now) && (window.EndsAt == nil || window.EndsAt.After(now))
+}
+
+// invalidateMaintenanceCache bellekteki bakım durumunu geçersiz kılar
+func invalidateMaintenanceCache() {
+	maintenanceCache.mu.Lock()
+	maintenanceCache.checkedAt = time.Time{}
+	maintenanceCache.mu.Unlock()
+}
+
+// scanMaintenanceWindow bakım penceresi satırını okur
+func scanMaintenanceWindow(row interface{ Scan(...interface{}) error }) (models.MaintenanceWindow, error) {
+	var window models.MaintenanceWindow
+	var endsAt sql.NullTime
+	err := row.Scan(&window.ID, &window.Message, &window.StartsAt, &endsAt, &window.CreatedBy, &window.CreatedAt)
+	window.EndsAt = utils.NullTimeToPtr(endsAt)
+	return window, err
+}
@@ -0,0 +1,480 @@
+package services
+
+import (
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"sort"
+	"strings"
+	"time"
+
+	"agri-management-api/internal/models"
+)
+
+// ErrMarketPriceFeedDisabled piyasa fiyatı servisi yapılandırılmadığında döner
+var ErrMarketPriceFeedDisabled = errors.New("market price feed not configured")
+
+var marketPriceClient = &http.Client{Timeout: 15 * time.Second}
+
+// MarketPriceFeedConfigured piyasa fiyatı servisinin adresi MARKET_PRICE_FEED_URL ile tanımlı mı
+func MarketPriceFeedConfigured() bool {
+	return os.Getenv("MARKET_PRICE_FEED_URL") != ""
+}
+
+// MarketPriceKey ürün adlarını büyük/küçük harf ve boşluk farkı gözetmeden eşleştirmek için anahtar üretir
+func MarketPriceKey(product string) string {
+	return strings.Join(strings.Fields(strings.ToLower(product)), " ")
+}
+
+// FetchMarketPrices MARKET_PRICE_FEED_URL adresinden ürünlerin verilen tarihten sonraki piyasa fiyatlarını alır.
+// Servise her ürün için product, başlangıç için from (YYYY-MM-DD) parametresi gönderilir; yanıt
+// {product, category, price, unit, currency, date} nesnelerinden oluşan dizi ya da bu diziyi data alanında taşıyan nesne olmalıdır.
+// MARKET_PRICE_FEED_API_KEY tanımlıysa Bearer anahtarı olarak eklenir
+func FetchMarketPrices(products []string, since time.Time) ([]models.MarketPrice, error) {
+	if !MarketPriceFeedConfigured() {
+		return nil, ErrMarketPriceFeedDisabled
+	}
+
+	endpoint, err := url.Parse(os.Getenv("MARKET_PRICE_FEED_URL"))
+	if err != nil {
+		return nil, err
+	}
+	query := endpoint.Query()
+	for _, product := range products {
+		query.Add("product", product)
+	}
+	query.Set("from", since.Format("2006-01-02"))
+	endpoint.RawQuery = query.Encode()
+
+	req, err := http.NewRequest(http.MethodGet, endpoint.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Accept", "application/json")
+	if apiKey := os.Getenv("MARKET_PRICE_FEED_API_KEY"); apiKey != "" {
+		req.Header.Set("Authorization", "Bearer "+apiKey)
+	}
+
+	resp, err := marketPriceClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("market price feed returned %d: %s", resp.StatusCode, body)
+	}
+
+	type feedPrice struct {
+		Product  string  `json:"product"`
+		Category string  `json:"category"`
+		Price    float64 `json:"price"`
+		Unit     string  `json:"unit"`
+		Currency string  `json:"currency"`
+		Date     string  `json:"date"`
+	}
+	var items []feedPrice
+	if err := json.Unmarshal(body, &items); err != nil {
+		var wrapped struct {
+			Data []feedPrice `json:"data"`
+		}
+		if err := json.Unmarshal(body, &wrapped); err != nil {
+			return nil, err
+		}
+		items = wrapped.Data
+	}
+
+	prices := make([]models.MarketPrice, 0, len(items))
+	for _, item := range items {
+		if item.Product == "" || item.Price <= 0 {
+			continue
+		}
+		date, err := time.Parse("2006-01-02", item.Date)
+		if err != nil {
+			if date, err = time.Parse(time.RFC3339, item.Date); err != nil {
+				continue
+			}
+		}
+		if item.Currency == "" {
+			item.Currency = "TRY"
+		}
+		prices = append(prices, models.MarketPrice{
+			Product:  item.Product,
+			Category: item.Category,
+			Price:    item.Price,
+			Unit:     item.Unit,
+			Currency: strings.ToUpper(item.Currency),
+			Date:     time.Date(date.Year(), date.Month(), date.Day(), 0, 0, 0, 0, time.UTC),
+			Source:   "feed",
+		})
+	}
+	return prices, nil
+}
+
+// PriceHistoryService üretim satışlarından gerçekleşen fiyatları, piyasa fiyatlarıyla farkını ve kayıplar dahil marjı hesaplar
+type PriceHistoryService struct {
+	db *sql.DB
+}
+
+// NewPriceHistoryService yeni price history service oluşturur
+func NewPriceHistoryService(db *sql.DB) *PriceHistoryService {
+	return &PriceHistoryService{db: db}
+}
+
+// priceSale fiyat analizinde kullanılan satış satırı
+type priceSale struct {
+	product  string
+	category string
+	unit     string
+	currency string
+	date     time.Time
+	quantity float64
+	revenue  float64
+	unitCost sql.NullFloat64
+}
+
+// priceLoss fiyat analizinde kullanılan kayıp satırı
+type priceLoss struct {
+	product  string
+	category string
+	unit     string
+	date     time.Time
+	quantity float64
+	unitCost sql.NullFloat64
+}
+
+// priceBucket bir ürünün bir aydaki satış, kayıp ve piyasa fiyatı toplamları
+type priceBucket struct {
+	quantity       float64
+	revenue        float64
+	costedQuantity float64
+	costedRevenue  float64
+	cost           float64
+	lossQuantity   float64
+	lossCost       float64
+	marketSum      float64
+	marketCount    int
+}
+
+// add satışı toplamlara ekler; birim maliyeti bilinmeyen satışlar marj hesabına girmez
+func (b *priceBucket) add(sale priceSale) {
+	b.quantity += sale.quantity
+	b.revenue += sale.revenue
+	if sale.unitCost.Valid {
+		b.costedQuantity += sale.quantity
+		b.costedRevenue += sale.revenue
+		b.cost += sale.quantity * sale.unitCost.Float64
+	}
+}
+
+// addLoss kaybı toplamlara ekler; birim maliyeti bilinen kayıpların maliyeti marjdan düşülür
+func (b *priceBucket) addLoss(loss priceLoss) {
+	b.lossQuantity += loss.quantity
+	if loss.unitCost.Valid {
+		b.lossCost += loss.quantity * loss.unitCost.Float64
+	}
+}
+
+// merge başka bir toplamı bu toplama ekler
+func (b *priceBucket) merge(other *priceBucket) {
+	b.quantity += other.quantity
+	b.revenue += other.revenue
+	b.costedQuantity += other.costedQuantity
+	b.costedRevenue += other.costedRevenue
+	b.cost += other.cost
+	b.lossQuantity += other.lossQuantity
+	b.lossCost += other.lossCost
+	b.marketSum += other.marketSum
+	b.marketCount += other.marketCount
+}
+
+func (b *priceBucket) realizedPrice() *float64 {
+	if b.quantity <= 0 {
+		return nil
+	}
+	value := round2(b.revenue / b.quantity)
+	return &value
+}
+
+func (b *priceBucket) marketPrice() *float64 {
+	if b.marketCount == 0 {
+		return nil
+	}
+	value := round2(b.marketSum / float64(b.marketCount))
+	return &value
+}
+
+func (b *priceBucket) priceDifference() *float64 {
+	if b.quantity <= 0 || b.marketCount == 0 || b.marketSum <= 0 {
+		return nil
+	}
+	market := b.marketSum / float64(b.marketCount)
+	value := round2((b.revenue/b.quantity - market) / market * 100)
+	return &value
+}
+
+func (b *priceBucket) marginPercent() *float64 {
+	if b.costedRevenue <= 0 {
+		return nil
+	}
+	value := round2((b.costedRevenue - b.cost - b.lossCost) / b.costedRevenue * 100)
+	return &value
+}
+
+// sales kullanıcının dahil tarih aralığındaki üretim satışlarını döner; tutar vergi hariç ara toplamdır
+func (s *PriceHistoryService) sales(userID string, startDate, endDate time.Time) ([]priceSale, error) {
+	rows, err := s.db.Query(`
+		SELECT p.name, p.category, COALESCE(s.unit, ''), s.currency, s.sale_date, s.quantity, s.subtotal, p.unit_cost
+		FROM production_sales s
+		JOIN production p ON p.id = s.production_id
+		WHERE s.user_id = ? AND s.sale_date >= ? AND s.sale_date < ?
+		ORDER BY s.sale_date
+	`, userID, startDate, endDate.AddDate(0, 0, 1))
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var sales []priceSale
+	for rows.Next() {
+		var sale priceSale
+		if err := rows.Scan(&sale.product, &sale.category, &sale.unit, &sale.currency, &sale.date,
+			&sale.quantity, &sale.revenue, &sale.unitCost); err != nil {
+			return nil, err
+		}
+		sales = append(sales, sale)
+	}
+	return sales, rows.Err()
+}
+
+// losses kullanıcının dahil tarih aralığındaki üretim kayıplarını döner
+func (s *PriceHistoryService) losses(userID string, startDate, endDate time.Time) ([]priceLoss, error) {
+	rows, err := s.db.Query(`
+		SELECT p.name, p.category, COALESCE(p.unit, ''), l.loss_date, l.quantity, p.unit_cost
+		FROM production_losses l
+		JOIN production p ON p.id = l.production_id
+		WHERE l.user_id = ? AND l.loss_date >= ? AND l.loss_date < ?
+		ORDER BY l.loss_date
+	`, userID, startDate, endDate.AddDate(0, 0, 1))
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var losses []priceLoss
+	for rows.Next() {
+		var loss priceLoss
+		if err := rows.Scan(&loss.product, &loss.category, &loss.unit, &loss.date, &loss.quantity, &loss.unitCost); err != nil {
+			return nil, err
+		}
+		losses = append(losses, loss)
+	}
+	return losses, rows.Err()
+}
+
+// History ürün bazında aylık gerçekleşen satış fiyatı, aynı ayın ortalama piyasa fiyatı ve marjı döner.
+// Ürünler ada göre (büyük/küçük harf duyarsız) ve para birimine göre gruplanır; kayıplar ürünün ilk para birimli grubuna eklenir.
+// product ve category boşsa tüm ürünler döner
+func (s *PriceHistoryService) History(userID, product, category string, startDate, endDate time.Time) ([]models.ProductPriceHistory, error) {
+	sales, err := s.sales(userID, startDate, endDate)
+	if err != nil {
+		return nil, err
+	}
+
+	productKey := MarketPriceKey(product)
+	matches := func(name, itemCategory string) bool {
+		return (productKey == "" || MarketPriceKey(name) == productKey) &&
+			(category == "" || strings.EqualFold(itemCategory, category))
+	}
+
+	histories := map[string]*models.ProductPriceHistory{}
+	buckets := map[string]map[string]*priceBucket{}
+	entry := func(name, itemCategory, unit, currency string) (*models.ProductPriceHistory, map[string]*priceBucket) {
+		key := MarketPriceKey(name) + "|" + currency
+		if histories[key] == nil {
+			histories[key] = &models.ProductPriceHistory{Product: name, Category: itemCategory, Unit: unit, Currency: currency}
+			buckets[key] = map[string]*priceBucket{}
+		}
+		history := histories[key]
+		if history.Category == "" {
+			history.Category = itemCategory
+		}
+		if history.Unit == "" {
+			history.Unit = unit
+		}
+		return history, buckets[key]
+	}
+	bucket := func(months map[string]*priceBucket, date time.Time) *priceBucket {
+		period := date.Format("2006-01")
+		if months[period] == nil {
+			months[period] = &priceBucket{}
+		}
+		return months[period]
+	}
+
+	for _, sale := range sales {
+		if !matches(sale.product, sale.category) {
+			continue
+		}
+		_, months := entry(sale.product, sale.category, sale.unit, sale.currency)
+		bucket(months, sale.date).add(sale)
+	}
+
+	losses, err := s.losses(userID, startDate, endDate)
+	if err != nil {
+		return nil, err
+	}
+	for _, loss := range losses {
+		if !matches(loss.product, loss.category) {
+			continue
+		}
+		currency := "TRY"
+		for key, history := range histories {
+			if strings.HasPrefix(key, MarketPriceKey(loss.product)+"|") {
+				currency = history.Currency
+				break
+			}
+		}
+		_, months := entry(loss.product, loss.category, loss.unit, currency)
+		bucket(months, loss.date).addLoss(loss)
+	}
+
+	rows, err := s.db.Query(`
+		SELECT product, COALESCE(category, ''), COALESCE(unit, ''), currency, price_date, price
+		FROM market_prices
+		WHERE user_id = ? AND price_date >= ? AND price_date < ?
+		ORDER BY price_date
+	`, userID, startDate, endDate.AddDate(0, 0, 1))
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var name, itemCategory, unit, currency string
+		var date time.Time
+		var price float64
+		if err := rows.Scan(&name, &itemCategory, &unit, &currency, &date, &price); err != nil {
+			return nil, err
+		}
+		if !matches(name, itemCategory) {
+			continue
+		}
+		_, months := entry(name, itemCategory, unit, currency)
+		month := bucket(months, date)
+		month.marketSum += price
+		month.marketCount++
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	result := make([]models.ProductPriceHistory, 0, len(histories))
+	for key, history := range histories {
+		periods := make([]string, 0, len(buckets[key]))
+		for period := range buckets[key] {
+			periods = append(periods, period)
+		}
+		sort.Strings(periods)
+
+		var total priceBucket
+		history.Points = make([]models.ProductPricePoint, 0, len(periods))
+		for _, period := range periods {
+			month := buckets[key][period]
+			total.merge(month)
+			history.Points = append(history.Points, models.ProductPricePoint{
+				Period:          period,
+				QuantitySold:    round2(month.quantity),
+				Revenue:         round2(month.revenue),
+				RealizedPrice:   month.realizedPrice(),
+				MarketPrice:     month.marketPrice(),
+				PriceDifference: month.priceDifference(),
+				LossQuantity:    round2(month.lossQuantity),
+				LossCost:        round2(month.lossCost),
+				MarginPercent:   month.marginPercent(),
+			})
+		}
+
+		history.QuantitySold = round2(total.quantity)
+		history.Revenue = round2(total.revenue)
+		history.AverageRealizedPrice = total.realizedPrice()
+		history.AverageMarketPrice = total.marketPrice()
+		history.PriceDifference = total.priceDifference()
+		history.LossQuantity = round2(total.lossQuantity)
+		history.LossCost = round2(total.lossCost)
+		history.MarginPercent = total.marginPercent()
+		if total.costedQuantity > 0 {
+			value := round2(total.cost / total.costedQuantity)
+			history.AverageUnitCost = &value
+		}
+		result = append(result, *history)
+	}
+
+	sort.Slice(result, func(i, j int) bool {
+		if result[i].Revenue != result[j].Revenue {
+			return result[i].Revenue > result[j].Revenue
+		}
+		return result[i].Product < result[j].Product
+	})
+	return result, nil
+}
+
+// MarginTrend son months ayın aylık satış gelirini, satış ve kayıp maliyetini ve marjını; ayrıca dönem toplam gelirini ve marjını döner
+func (s *PriceHistoryService) MarginTrend(userID string, months int) ([]models.ProductionMarginPoint, float64, *float64, error) {
+	now := time.Now()
+	startDate := time.Date(now.Year(), now.Month(), 1, 0, 0, 0, 0, time.UTC).AddDate(0, -(months - 1), 0)
+
+	sales, err := s.sales(userID, startDate, now)
+	if err != nil {
+		return nil, 0, nil, err
+	}
+
+	buckets := map[string]*priceBucket{}
+	for _, sale := range sales {
+		period := sale.date.Format("2006-01")
+		if buckets[period] == nil {
+			buckets[period] = &priceBucket{}
+		}
+		buckets[period].add(sale)
+	}
+
+	losses, err := s.losses(userID, startDate, now)
+	if err != nil {
+		return nil, 0, nil, err
+	}
+	for _, loss := range losses {
+		period := loss.date.Format("2006-01")
+		if buckets[period] == nil {
+			buckets[period] = &priceBucket{}
+		}
+		buckets[period].addLoss(loss)
+	}
+
+	var total priceBucket
+	trend := make([]models.ProductionMarginPoint, 0, months)
+	for i := 0; i < months; i++ {
+		period := startDate.AddDate(0, i, 0).Format("2006-01")
+		month := buckets[period]
+		if month == nil {
+			month = &priceBucket{}
+		}
+		total.merge(month)
+		trend = append(trend, models.ProductionMarginPoint{
+			Period:        period,
+			Revenue:       round2(month.revenue),
+			Cost:          round2(month.cost),
+			LossCost:      round2(month.lossCost),
+			MarginPercent: month.marginPercent(),
+		})
+	}
+	return trend, round2(total.revenue), total.marginPercent(), nil
+}
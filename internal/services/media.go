@@ -0,0 +1,92 @@
+package services
+
+import (
+	"errors"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// ErrInvalidMediaKey depolama anahtarı geçersiz olduğunda döner
+var ErrInvalidMediaKey = errors.New("invalid media key")
+
+// MediaStore yüklenen dosyaların saklandığı depolama arayüzü
+type MediaStore interface {
+	Save(key string, r io.Reader) (int64, error)
+	Open(key string) (io.ReadCloser, error)
+	Delete(key string) error
+}
+
+// LocalMediaStore dosyaları yerel dizinde saklar
+type LocalMediaStore struct {
+	root string
+}
+
+// NewMediaStore MEDIA_DIR ortam değişkenine göre depolama oluşturur
+func NewMediaStore() MediaStore {
+	root := os.Getenv("MEDIA_DIR")
+	if root == "" {
+		root = "./uploads"
+	}
+	return &LocalMediaStore{root: root}
+}
+
+// Save dosyayı verilen anahtarla kaydeder
+func (s *LocalMediaStore) Save(key string, r io.Reader) (int64, error) {
+	path, err := s.path(key)
+	if err != nil {
+		return 0, err
+	}
+
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return 0, err
+	}
+
+	file, err := os.Create(path)
+	if err != nil {
+		return 0, err
+	}
+	defer file.Close()
+
+	return io.Copy(file, r)
+}
+
+// Open kayıtlı dosyayı okumak için açar
+func (s *LocalMediaStore) Open(key string) (io.ReadCloser, error) {
+	path, err := s.path(key)
+	if err != nil {
+		return nil, err
+	}
+	return os.Open(path)
+}
+
+// Delete kayıtlı dosyayı siler
+func (s *LocalMediaStore) Delete(key string) error {
+	path, err := s.path(key)
+	if err != nil {
+		return err
+	}
+	if err := os.Remove(path); err != nil && !os.IsNotExist(err) {
+		return err
+	}
+	return nil
+}
+
+// path anahtarı kök dizin altında güvenli bir dosya yoluna çevirir
+func (s *LocalMediaStore) path(key string) (string, error) {
+	path, ok := storePath(s.root, key)
+	if !ok {
+		return "", ErrInvalidMediaKey
+	}
+	return path, nil
+}
+
+// storePath depolama anahtarını kök dizin altında kalan bir dosya yoluna çevirir; boş veya .. içeren anahtarlar
+// kök dışına çıkabileceği için reddedilir
+func storePath(root, key string) (string, bool) {
+	if key == "" || strings.Contains(key, "..") {
+		return "", false
+	}
+	return filepath.Join(root, filepath.Clean("/"+key)), true
+}
@@ -0,0 +1,396 @@
+package services
+
+import (
+	"database/sql"
+	"embed"
+	"errors"
+	"fmt"
+	"io/fs"
+	"path"
+	"sort"
+	"strconv"
+	"strings"
+	"text/template"
+	"time"
+
+	"agri-management-api/internal/models"
+	"agri-management-api/internal/utils"
+)
+
+// Şablon hataları
+var (
+	// ErrMessageTemplateNotFound istenen kanal ve anahtar için hiçbir dilde şablon yoksa döner
+	ErrMessageTemplateNotFound = errors.New("message template not found")
+	// ErrMessageTemplateInvalid şablon ayrıştırılamadığında veya verilerle doldurulamadığında döner
+	ErrMessageTemplateInvalid = errors.New("invalid message template")
+)
+
+// DefaultMessageLanguage çiftliğin dilinde şablon yoksa kullanılan dil
+const DefaultMessageLanguage = "tr"
+
+// messageTemplateFiles dosyadaki hazır şablonlar; yol biçimi <kanal>/<anahtar>.<dil>.tmpl, her dosya
+// "title" ve "body" şablonlarını tanımlar
+//
+//go:embed message_templates
+var messageTemplateFiles embed.FS
+
+// messageTemplateSamples önizlemede veri verilmezse kullanılan örnek veriler
+var messageTemplateSamples = map[string]map[string]interface{}{
+	"notification/backup_failed":                {"entity": "Yeşil Vadi Çiftliği", "error": "depolama erişilemiyor"},
+	"notification/backup_failed_admin":          {"entity": "Yeşil Vadi Çiftliği", "farmId": "3f2a9c1e", "trigger": "scheduled", "storage": "s3", "error": "s3 PUT: 403 Forbidden AccessDenied"},
+	"notification/event_reminder":               {"entity": "Buzağı Aşısı", "start": "2024-05-10T09:30:00Z", "allDay": false},
+	"notification/health_checkup":               {"entity": "TR-001", "date": "2024-05-10"},
+	"notification/vaccination_due":              {"entity": "TR-001", "date": "2024-05-10", "vaccine": "Şap"},
+	"notification/inventory_low":                {"entity": "Buğday", "stock": 120.5, "amount": 2000, "unit": "kg"},
+	"notification/report_failed":                {"entity": "Finansal Rapor", "error": "veritabanı kilitli"},
+	"notification/report_ready":                 {"entity": "Finansal Rapor - 2024-Q1", "format": "PDF"},
+	"notification/support_ticket_reply":         {"entity": "Senkronizasyon hatası", "status": "in_progress"},
+	"notification/support_ticket_status":        {"entity": "Senkronizasyon hatası", "status": "resolved"},
+	"notification/weather_frost":                {"entity": "Kuzey Tarla", "temperature": -2.4},
+	"notification/weather_heavy_rain":           {"entity": "Kuzey Tarla", "rainfall": 14.2},
+	"notification/water_quota_warning":          {"entity": "2024 Sulama Sezonu", "percent": 82.5, "used": 4125, "volume": 5000},
+	"notification/water_quota_exceeded":         {"entity": "2024 Sulama Sezonu", "percent": 104.2, "used": 5210, "volume": 5000},
+	"notification/worker_certification_missing": {"entity": "Mehmet Yılmaz", "activity": "ilaçlama", "date": "2024-05-10", "contractInactive": false, "certifications": []string{"pesticide_applicator"}},
+	"notification/irrigation_due":               {"entity": "Kuzey Tarla", "date": "2024-07-12", "amount": 42.5, "volume": 425, "depletion": 38.3},
+	"notification/weather_daily_summary":        {"entity": "Yayla Merası", "date": "2024-05-10", "condition": "Parçalı bulutlu", "minTemp": 6.5, "maxTemp": 18.2, "rainChance": 40, "rainfall": 1.2, "windSpeed": 12.4},
+	"notification/weather_forecast_frost":       {"entity": "Yayla Merası", "date": "2024-05-11", "value": -1.8},
+	"notification/weather_forecast_heavy_rain":  {"entity": "Yayla Merası", "date": "2024-05-11", "value": 32.5},
+	"email/notification":                        {"farm": "Yeşil Vadi Çiftliği", "name": "Ahmet", "title": "Stok Azaldı", "message": "Buğday stoğu 120,5 kg kaldı."},
+}
+
+// MessageTemplateRegistry bildirim ve e-posta metinlerini Go şablonlarıyla üretir. Şablonlar dosyalardan
+// yüklenir; yöneticinin veritabanına kaydettiği şablonlar aynı kanal, anahtar ve dildeki dosyanın yerine geçer.
+// Dil çiftlik ayarlarındaki general.language değeridir; o dilde şablon yoksa varsayılan dile (tr) düşülür
+type MessageTemplateRegistry struct {
+	db *sql.DB
+}
+
+// NewMessageTemplateRegistry yeni şablon kayıt defteri oluşturur
+func NewMessageTemplateRegistry(db *sql.DB) *MessageTemplateRegistry {
+	return &MessageTemplateRegistry{db: db}
+}
+
+// Language çiftliğin mesaj dilini döner
+func (r *MessageTemplateRegistry) Language(farmID string) string {
+	settings, err := NewFarmService(r.db).Settings(farmID)
+	if err != nil || settings.General.Language == "" {
+		return DefaultMessageLanguage
+	}
+	return settings.General.Language
+}
+
+// Render şablonu verilen dilde, yoksa varsayılan dilde doldurur
+func (r *MessageTemplateRegistry) Render(channel, key, language string, data map[string]interface{}) (models.RenderedMessage, error) {
+	for _, candidate := range []string{language, DefaultMessageLanguage} {
+		tmpl, err := r.lookup(channel, key, candidate)
+		if errors.Is(err, ErrMessageTemplateNotFound) {
+			continue
+		}
+		if err != nil {
+			return models.RenderedMessage{}, err
+		}
+		return renderMessageTemplate(tmpl, data)
+	}
+	return models.RenderedMessage{}, ErrMessageTemplateNotFound
+}
+
+// Preview şablonu önizler; başlık ve metin verilirse kaydedilmemiş taslak, verilmezse kayıtlı şablon
+// kullanılır. Veri verilmezse anahtarın örnek verileri kullanılır
+func (r *MessageTemplateRegistry) Preview(req models.MessageTemplatePreviewRequest) (models.RenderedMessage, error) {
+	data := req.Data
+	if data == nil {
+		data = messageTemplateSamples[req.Channel+"/"+req.Key]
+	}
+
+	if req.Title == nil && req.Body == nil {
+		return r.Render(req.Channel, req.Key, req.Language, data)
+	}
+
+	tmpl, err := r.lookup(req.Channel, req.Key, req.Language)
+	if err != nil && !errors.Is(err, ErrMessageTemplateNotFound) {
+		return models.RenderedMessage{}, err
+	}
+	if err == nil {
+		req.Title = coalesceTemplateText(req.Title, tmpl.Title)
+		req.Body = coalesceTemplateText(req.Body, tmpl.Body)
+	}
+
+	draft := models.MessageTemplate{Channel: req.Channel, Key: req.Key, Language: req.Language, Source: "draft"}
+	if req.Title != nil {
+		draft.Title = *req.Title
+	}
+	if req.Body != nil {
+		draft.Body = *req.Body
+	}
+	return renderMessageTemplate(draft, data)
+}
+
+// Templates dosyadaki ve veritabanındaki tüm şablonları kanal, anahtar ve dile göre sıralı döner;
+// veritabanındaki şablonlar dosyadakileri gizler
+func (r *MessageTemplateRegistry) Templates() ([]models.MessageTemplate, error) {
+	byID := map[string]models.MessageTemplate{}
+	files, err := fileMessageTemplates()
+	if err != nil {
+		return nil, err
+	}
+	for _, tmpl := range files {
+		byID[tmpl.Channel+"/"+tmpl.Key+"/"+tmpl.Language] = tmpl
+	}
+
+	rows, err := r.db.Query(`
+		SELECT channel, template_key, language, title, body, updated_at
+		FROM message_templates
+	`)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		tmpl := models.MessageTemplate{Source: models.MessageTemplateSourceDatabase}
+		var updatedAt time.Time
+		if err := rows.Scan(&tmpl.Channel, &tmpl.Key, &tmpl.Language, &tmpl.Title, &tmpl.Body, &updatedAt); err != nil {
+			return nil, err
+		}
+		tmpl.UpdatedAt = &updatedAt
+		byID[tmpl.Channel+"/"+tmpl.Key+"/"+tmpl.Language] = tmpl
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	templates := make([]models.MessageTemplate, 0, len(byID))
+	for _, tmpl := range byID {
+		templates = append(templates, tmpl)
+	}
+	sort.Slice(templates, func(i, j int) bool {
+		a, b := templates[i], templates[j]
+		if a.Channel != b.Channel {
+			return a.Channel < b.Channel
+		}
+		if a.Key != b.Key {
+			return a.Key < b.Key
+		}
+		return a.Language < b.Language
+	})
+	return templates, nil
+}
+
+// Save şablonu veritabanına kaydeder; yalnızca dosyada en az bir dili bulunan anahtarlar için yeni dil
+// eklenebilir veya mevcut metin değiştirilebilir
+func (r *MessageTemplateRegistry) Save(channel, key, language, title, body, accountID string) (models.MessageTemplate, error) {
+	if !knownMessageTemplate(channel, key) {
+		return models.MessageTemplate{}, ErrMessageTemplateNotFound
+	}
+
+	tmpl := models.MessageTemplate{
+		Channel:  channel,
+		Key:      key,
+		Language: language,
+		Title:    title,
+		Body:     body,
+		Source:   models.MessageTemplateSourceDatabase,
+	}
+	if _, err := parseMessageTemplate(tmpl); err != nil {
+		return tmpl, err
+	}
+
+	now := time.Now()
+	_, err := r.db.Exec(`
+		INSERT INTO message_templates (id, channel, template_key, language, title, body, updated_by, created_at, updated_at)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?)
+		ON CONFLICT (channel, template_key, language)
+		DO UPDATE SET title = excluded.title, body = excluded.body, updated_by = excluded.updated_by,
+		              updated_at = excluded.updated_at
+	`, utils.GenerateID(), channel, key, language, title, body, accountID, now, now)
+	if err != nil {
+		return tmpl, err
+	}
+	tmpl.UpdatedAt = &now
+	return tmpl, nil
+}
+
+// Delete veritabanındaki şablonu siler; varsa dosyadaki şablon yeniden kullanılır
+func (r *MessageTemplateRegistry) Delete(channel, key, language string) error {
+	result, err := r.db.Exec(`
+		DELETE FROM message_templates WHERE channel = ? AND template_key = ? AND language = ?
+	`, channel, key, language)
+	if err != nil {
+		return err
+	}
+	if affected, _ := result.RowsAffected(); affected == 0 {
+		return ErrMessageTemplateNotFound
+	}
+	return nil
+}
+
+// lookup şablonu önce veritabanında, sonra dosyalarda arar
+func (r *MessageTemplateRegistry) lookup(channel, key, language string) (models.MessageTemplate, error) {
+	tmpl := models.MessageTemplate{Channel: channel, Key: key, Language: language, Source: models.MessageTemplateSourceDatabase}
+	var updatedAt time.Time
+	err := r.db.QueryRow(`
+		SELECT title, body, updated_at FROM message_templates
+		WHERE channel = ? AND template_key = ? AND language = ?
+	`, channel, key, language).Scan(&tmpl.Title, &tmpl.Body, &updatedAt)
+	if err == nil {
+		tmpl.UpdatedAt = &updatedAt
+		return tmpl, nil
+	}
+	if err != sql.ErrNoRows {
+		return tmpl, err
+	}
+
+	content, err := messageTemplateFiles.ReadFile(messageTemplatePath(channel, key, language))
+	if err != nil {
+		return tmpl, ErrMessageTemplateNotFound
+	}
+	return fileMessageTemplate(channel, key, language, string(content))
+}
+
+// renderMessageTemplate şablonu verilerle doldurur; eksik veri alanı hata sayılır
+func renderMessageTemplate(tmpl models.MessageTemplate, data map[string]interface{}) (models.RenderedMessage, error) {
+	parsed, err := parseMessageTemplate(tmpl)
+	if err != nil {
+		return models.RenderedMessage{}, err
+	}
+
+	rendered := models.RenderedMessage{Channel: tmpl.Channel, Key: tmpl.Key, Language: tmpl.Language, Source: tmpl.Source}
+	for name, target := range map[string]*string{"title": &rendered.Title, "body": &rendered.Body} {
+		var b strings.Builder
+		if err := parsed.ExecuteTemplate(&b, name, data); err != nil {
+			return rendered, fmt.Errorf("%w: %v", ErrMessageTemplateInvalid, err)
+		}
+		*target = strings.TrimSpace(b.String())
+	}
+	return rendered, nil
+}
+
+// parseMessageTemplate başlık ve metni dilin biçimlendirme fonksiyonlarıyla ayrıştırır
+func parseMessageTemplate(tmpl models.MessageTemplate) (*template.Template, error) {
+	parsed := template.New(tmpl.Key).Funcs(messageTemplateFuncs(tmpl.Language)).Option("missingkey=error")
+	for name, text := range map[string]string{"title": tmpl.Title, "body": tmpl.Body} {
+		if _, err := parsed.New(name).Parse(text); err != nil {
+			return nil, fmt.Errorf("%w: %v", ErrMessageTemplateInvalid, err)
+		}
+	}
+	return parsed, nil
+}
+
+// messageTemplateFuncs şablonlarda kullanılabilen fonksiyonlar: date, datetime ve number değeri dile göre biçimlendirir
+func messageTemplateFuncs(language string) template.FuncMap {
+	dateLayout, dateTimeLayout, decimal := "02.01.2006", "02.01.2006 15:04", ","
+	if language != DefaultMessageLanguage {
+		dateLayout, dateTimeLayout, decimal = "2006-01-02", "2006-01-02 15:04", "."
+	}
+
+	return template.FuncMap{
+		"date": func(value interface{}) string {
+			return formatTemplateTime(value, dateLayout)
+		},
+		"datetime": func(value interface{}) string {
+			return formatTemplateTime(value, dateTimeLayout)
+		},
+		"number": func(value interface{}) string {
+			var number float64
+			switch v := value.(type) {
+			case float64:
+				number = v
+			case int:
+				number = float64(v)
+			case string:
+				parsed, err := strconv.ParseFloat(v, 64)
+				if err != nil {
+					return v
+				}
+				number = parsed
+			default:
+				return fmt.Sprint(v)
+			}
+			text := strings.TrimRight(strings.TrimRight(strconv.FormatFloat(number, 'f', 2, 64), "0"), ".")
+			return strings.Replace(text, ".", decimal, 1)
+		},
+	}
+}
+
+// formatTemplateTime zaman değerini veya RFC3339/YYYY-MM-DD metnini biçimlendirir
+func formatTemplateTime(value interface{}, layout string) string {
+	switch v := value.(type) {
+	case time.Time:
+		return v.Format(layout)
+	case *time.Time:
+		if v == nil {
+			return ""
+		}
+		return v.Format(layout)
+	case string:
+		for _, input := range []string{time.RFC3339, "2006-01-02"} {
+			if parsed, err := time.Parse(input, v); err == nil {
+				return parsed.Format(layout)
+			}
+		}
+		return v
+	default:
+		return fmt.Sprint(v)
+	}
+}
+
+// fileMessageTemplates dosyadaki tüm şablonları döner
+func fileMessageTemplates() ([]models.MessageTemplate, error) {
+	var templates []models.MessageTemplate
+	err := fs.WalkDir(messageTemplateFiles, "message_templates", func(filePath string, entry fs.DirEntry, err error) error {
+		if err != nil || entry.IsDir() {
+			return err
+		}
+		parts := strings.Split(strings.TrimSuffix(path.Base(filePath), ".tmpl"), ".")
+		if len(parts) != 2 {
+			return nil
+		}
+		content, err := messageTemplateFiles.ReadFile(filePath)
+		if err != nil {
+			return err
+		}
+		tmpl, err := fileMessageTemplate(path.Base(path.Dir(filePath)), parts[0], parts[1], string(content))
+		if err != nil {
+			return err
+		}
+		templates = append(templates, tmpl)
+		return nil
+	})
+	return templates, err
+}
+
+// fileMessageTemplate dosyadaki "title" ve "body" tanımlarını ayırır
+func fileMessageTemplate(channel, key, language, content string) (models.MessageTemplate, error) {
+	tmpl := models.MessageTemplate{Channel: channel, Key: key, Language: language, Source: models.MessageTemplateSourceFile}
+	parsed, err := template.New(key).Funcs(messageTemplateFuncs(language)).Parse(content)
+	if err != nil {
+		return tmpl, fmt.Errorf("%w: %s: %v", ErrMessageTemplateInvalid, messageTemplatePath(channel, key, language), err)
+	}
+	for name, target := range map[string]*string{"title": &tmpl.Title, "body": &tmpl.Body} {
+		defined := parsed.Lookup(name)
+		if defined == nil || defined.Tree == nil {
+			return tmpl, fmt.Errorf("%w: %s: missing %q", ErrMessageTemplateInvalid, messageTemplatePath(channel, key, language), name)
+		}
+		*target = defined.Tree.Root.String()
+	}
+	return tmpl, nil
+}
+
+// knownMessageTemplate anahtarın dosyada en az bir dilde tanımlı olup olmadığını döner
+func knownMessageTemplate(channel, key string) bool {
+	matches, _ := fs.Glob(messageTemplateFiles, path.Join("message_templates", channel, key+".*.tmpl"))
+	return len(matches) > 0
+}
+
+// messageTemplatePath şablon dosyasının yolu
+func messageTemplatePath(channel, key, language string) string {
+	return path.Join("message_templates", channel, key+"."+language+".tmpl")
+}
+
+// coalesceTemplateText taslakta verilmeyen alanı kayıtlı şablondan tamamlar
+func coalesceTemplateText(value *string, fallback string) *string {
+	if value != nil {
+		return value
+	}
+	return &fallback
+}
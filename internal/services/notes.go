@@ -0,0 +1,289 @@
+package services
+
+import (
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"regexp"
+	"strings"
+	"time"
+
+	"agri-management-api/internal/models"
+	"agri-management-api/internal/utils"
+)
+
+// ErrNoteEntityNotFound not eklenmek istenen kayıt bulunamadı
+var ErrNoteEntityNotFound = errors.New("note entity not found")
+
+// ErrNoteInvalidMention etiketlenen kullanıcı çiftlik üyesi değil
+var ErrNoteInvalidMention = errors.New("mentioned user is not a farm member")
+
+// ErrNoteInvalidAttachment eklenen medya veya doküman bulunamadı
+var ErrNoteInvalidAttachment = errors.New("note attachment not found")
+
+// noteEntity not eklenebilen kayıt türünün tablosu; notes sütunu olan tablolarda son not bu alana yazılır
+type noteEntity struct {
+	table      string
+	notes      bool
+	nameColumn string
+}
+
+// noteEntities not zaman çizelgesi olan kayıt türleri
+var noteEntities = map[string]noteEntity{
+	"livestock":   {table: "livestock", notes: true, nameColumn: "tag_number"},
+	"land":        {table: "lands", nameColumn: "name"},
+	"production":  {table: "production", notes: true, nameColumn: "name"},
+	"transaction": {table: "transactions", notes: true, nameColumn: "description"},
+	"asset":       {table: "fixed_assets", notes: true, nameColumn: "name"},
+	"hive":        {table: "hives", notes: true, nameColumn: "name"},
+	"pond":        {table: "ponds", notes: true, nameColumn: "name"},
+	"fish_batch":  {table: "fish_batches", notes: true, nameColumn: "species"},
+}
+
+// noteAttachmentOwnership nota bağlanabilen eklerin adı ve sahiplik sorguları
+var noteAttachmentOwnership = map[string]string{
+	"media":    "SELECT filename FROM media_attachments WHERE id = ? AND user_id = ?",
+	"document": "SELECT title FROM documents WHERE id = ? AND user_id = ?",
+}
+
+// mentionPattern not metnindeki @eposta-adı etiketleri
+var mentionPattern = regexp.MustCompile(`(?:^|\s)@([\p{L}0-9._+-]+)`)
+
+// NoteService kayıtların not zaman çizelgesini ve etiketlemeleri yönetir
+type NoteService struct {
+	db *sql.DB
+}
+
+// NewNoteService yeni not servisi oluşturur
+func NewNoteService(db *sql.DB) *NoteService {
+	return &NoteService{db: db}
+}
+
+// SupportsEntity kayıt türünün not zaman çizelgesi olup olmadığını döner
+func SupportsEntity(entityType string) bool {
+	_, ok := noteEntities[entityType]
+	return ok
+}
+
+// EntityName kaydın çiftliğe ait olduğunu doğrular ve bildirimlerde kullanılacak adını döner
+func (s *NoteService) EntityName(farmID, entityType, entityID string) (string, error) {
+	entity := noteEntities[entityType]
+	var name sql.NullString
+	err := s.db.QueryRow("SELECT "+entity.nameColumn+" FROM "+entity.table+" WHERE id = ? AND user_id = ?",
+		entityID, farmID).Scan(&name)
+	if err == sql.ErrNoRows {
+		return "", ErrNoteEntityNotFound
+	}
+	return name.String, err
+}
+
+// Members çiftlik notlarında etiketlenebilen kullanıcıları döner: çiftlik sahibi, çiftliğe ziyaret
+// planlanmış veterinerler ve onay vermiş kooperatif bağlantıları
+func (s *NoteService) Members(farmID string) ([]models.FarmMember, error) {
+	ownerID := farmID
+	s.db.QueryRow("SELECT user_id FROM farms WHERE id = ?", farmID).Scan(&ownerID)
+
+	rows, err := s.db.Query(`
+		SELECT id, name, email, 'owner' FROM users WHERE id = ?
+		UNION ALL
+		SELECT u.id, u.name, u.email, 'veterinarian' FROM vet_visits v JOIN users u ON u.id = v.veterinarian_id
+		WHERE v.farmer_id = ? AND v.status IN (?, ?)
+		UNION ALL
+		SELECT u.id, u.name, u.email, 'cooperative' FROM cooperative_memberships cm JOIN users u ON u.id = cm.cooperative_admin_id
+		WHERE cm.member_user_id = ? AND cm.consent_status = 'granted'
+		UNION ALL
+		SELECT u.id, u.name, u.email, 'cooperative' FROM cooperative_memberships cm JOIN users u ON u.id = cm.member_user_id
+		WHERE cm.cooperative_admin_id = ? AND cm.consent_status = 'granted'
+	`, ownerID, ownerID, models.VetVisitConfirmed, models.VetVisitCompleted, ownerID, ownerID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	members := []models.FarmMember{}
+	seen := map[string]bool{}
+	for rows.Next() {
+		var member models.FarmMember
+		if err := rows.Scan(&member.UserID, &member.Name, &member.Email, &member.Role); err != nil {
+			return nil, err
+		}
+		if seen[member.UserID] {
+			continue
+		}
+		seen[member.UserID] = true
+		member.Handle = strings.ToLower(strings.SplitN(member.Email, "@", 2)[0])
+		members = append(members, member)
+	}
+	return members, rows.Err()
+}
+
+// List kaydın notlarını en yeniden eskiye döner; zaman çizelgesi boşsa eski notes alanı ilk not olarak aktarılır
+func (s *NoteService) List(farmID, entityType, entityID string) ([]models.EntityNote, error) {
+	if err := s.importLegacyNote(farmID, entityType, entityID); err != nil {
+		return nil, err
+	}
+
+	rows, err := s.db.Query(`
+		SELECT n.id, n.entity_type, n.entity_id, n.author_id, COALESCE(u.name, ''), n.text, n.mentions, n.attachments,
+		       n.legacy, n.created_at
+		FROM entity_notes n
+		LEFT JOIN users u ON u.id = n.author_id
+		WHERE n.user_id = ? AND n.entity_type = ? AND n.entity_id = ?
+		ORDER BY n.created_at DESC
+	`, farmID, entityType, entityID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	notes := []models.EntityNote{}
+	for rows.Next() {
+		var note models.EntityNote
+		var mentions, attachments string
+		err := rows.Scan(&note.ID, &note.EntityType, &note.EntityID, &note.AuthorID, &note.AuthorName, &note.Text,
+			&mentions, &attachments, &note.Legacy, &note.CreatedAt)
+		if err != nil {
+			return nil, err
+		}
+		note.Mentions = []models.FarmMember{}
+		note.Attachments = []models.NoteAttachment{}
+		json.Unmarshal([]byte(mentions), &note.Mentions)
+		json.Unmarshal([]byte(attachments), &note.Attachments)
+		notes = append(notes, note)
+	}
+	return notes, rows.Err()
+}
+
+// Create kayda not ekler, etiketleri ve ekleri doğrular ve kaydın notes alanını son notla günceller
+func (s *NoteService) Create(farmID, authorID, entityType, entityID string, req models.EntityNoteRequest) (models.EntityNote, error) {
+	note := models.EntityNote{
+		ID:          utils.GenerateID(),
+		EntityType:  entityType,
+		EntityID:    entityID,
+		AuthorID:    authorID,
+		Text:        strings.TrimSpace(req.Text),
+		Mentions:    []models.FarmMember{},
+		Attachments: []models.NoteAttachment{},
+		CreatedAt:   time.Now(),
+	}
+
+	if err := s.importLegacyNote(farmID, entityType, entityID); err != nil {
+		return note, err
+	}
+
+	members, err := s.Members(farmID)
+	if err != nil {
+		return note, err
+	}
+	note.Mentions, err = resolveMentions(members, req.Mentions, note.Text)
+	if err != nil {
+		return note, err
+	}
+	for _, member := range members {
+		if member.UserID == authorID {
+			note.AuthorName = member.Name
+		}
+	}
+
+	for _, attachment := range req.Attachments {
+		var name string
+		err := s.db.QueryRow(noteAttachmentOwnership[attachment.Type], attachment.ID, farmID).Scan(&name)
+		if err != nil {
+			return note, ErrNoteInvalidAttachment
+		}
+		note.Attachments = append(note.Attachments, models.NoteAttachment{Type: attachment.Type, ID: attachment.ID, Name: name})
+	}
+
+	mentions, _ := json.Marshal(note.Mentions)
+	attachments, _ := json.Marshal(note.Attachments)
+
+	tx, err := s.db.Begin()
+	if err != nil {
+		return note, err
+	}
+	defer tx.Rollback()
+
+	_, err = tx.Exec(`
+		INSERT INTO entity_notes (id, user_id, entity_type, entity_id, author_id, text, mentions, attachments, created_at)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?)
+	`, note.ID, farmID, entityType, entityID, authorID, note.Text, string(mentions), string(attachments), note.CreatedAt)
+	if err != nil {
+		return note, err
+	}
+
+	// Eski istemciler için kaydın notes alanı son notu gösterir
+	if entity := noteEntities[entityType]; entity.notes {
+		_, err := tx.Exec("UPDATE "+entity.table+" SET notes = ?, updated_at = CURRENT_TIMESTAMP WHERE id = ? AND user_id = ?",
+			note.Text, entityID, farmID)
+		if err != nil {
+			return note, err
+		}
+	}
+
+	return note, tx.Commit()
+}
+
+// importLegacyNote zaman çizelgesi olmayan kaydın dolu notes alanını ilk not olarak aktarır
+func (s *NoteService) importLegacyNote(farmID, entityType, entityID string) error {
+	entity := noteEntities[entityType]
+	if !entity.notes {
+		return nil
+	}
+
+	var exists bool
+	s.db.QueryRow("SELECT 1 FROM entity_notes WHERE entity_type = ? AND entity_id = ? AND user_id = ? LIMIT 1",
+		entityType, entityID, farmID).Scan(&exists)
+	if exists {
+		return nil
+	}
+
+	var notes sql.NullString
+	var createdAt time.Time
+	err := s.db.QueryRow("SELECT notes, updated_at FROM "+entity.table+" WHERE id = ? AND user_id = ?", entityID, farmID).Scan(&notes, &createdAt)
+	if err != nil || strings.TrimSpace(notes.String) == "" {
+		return nil
+	}
+
+	authorID := farmID
+	s.db.QueryRow("SELECT user_id FROM farms WHERE id = ?", farmID).Scan(&authorID)
+
+	_, err = s.db.Exec(`
+		INSERT INTO entity_notes (id, user_id, entity_type, entity_id, author_id, text, legacy, created_at)
+		VALUES (?, ?, ?, ?, ?, ?, TRUE, ?)
+	`, utils.GenerateID(), farmID, entityType, entityID, authorID, strings.TrimSpace(notes.String), createdAt)
+	return err
+}
+
+// resolveMentions istekteki kullanıcı ID'lerini ve metindeki @eposta-adı etiketlerini çiftlik üyelerine çözer;
+// üye olmayan ID hata döner, eşleşmeyen metin etiketleri yok sayılır
+func resolveMentions(members []models.FarmMember, userIDs []string, text string) ([]models.FarmMember, error) {
+	byID := map[string]models.FarmMember{}
+	byHandle := map[string]models.FarmMember{}
+	for _, member := range members {
+		byID[member.UserID] = member
+		byHandle[member.Handle] = member
+	}
+
+	mentions := []models.FarmMember{}
+	seen := map[string]bool{}
+	add := func(member models.FarmMember) {
+		if !seen[member.UserID] {
+			seen[member.UserID] = true
+			mentions = append(mentions, models.FarmMember{UserID: member.UserID, Name: member.Name, Handle: member.Handle})
+		}
+	}
+
+	for _, userID := range userIDs {
+		member, ok := byID[userID]
+		if !ok {
+			return nil, ErrNoteInvalidMention
+		}
+		add(member)
+	}
+	for _, match := range mentionPattern.FindAllStringSubmatch(text, -1) {
+		if member, ok := byHandle[strings.ToLower(strings.TrimRight(match[1], "."))]; ok {
+			add(member)
+		}
+	}
+	return mentions, nil
+}
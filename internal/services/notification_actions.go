@@ -0,0 +1,314 @@
+package services
+
+import (
+	"strings"
+
+	"agri-management-api/internal/models"
+)
+
+// notificationActionCatalog bildirim konularına göre mobil uygulamanın göstereceği aksiyonlar;
+// route alanındaki {id} ilişkili varlığın ID'si ile doldurulur
+var notificationActionCatalog = []models.NotificationActionTemplate{
+	{
+		Topic:       models.NotificationTopicWelcome,
+		Description: "Yeni kullanıcıya hoş geldin bildirimi",
+		Actions: []models.Action{
+			{Key: "open_dashboard", Label: "Panoyu Aç", Type: models.ActionTypeNavigate, Route: "/dashboard"},
+		},
+	},
+	{
+		Topic:       models.NotificationTopicVaccinationDue,
+		EntityType:  "livestock",
+		Description: "Hayvanın aşı zamanı geldi",
+		Actions: []models.Action{
+			{Key: "record_vaccination", Label: "Aşı Kaydet", Type: models.ActionTypeNavigate, Route: "/livestock/{id}/health/new",
+				Payload: map[string]interface{}{"type": "vaccination"}},
+			{Key: "view_animal", Label: "Hayvanı Görüntüle", Type: models.ActionTypeNavigate, Route: "/livestock/{id}"},
+		},
+	},
+	{
+		Topic:       models.NotificationTopicHealthAlert,
+		EntityType:  "livestock",
+		Description: "Hayvanın sağlık durumu dikkat gerektiriyor",
+		Actions: []models.Action{
+			{Key: "record_treatment", Label: "Tedavi Kaydet", Type: models.ActionTypeNavigate, Route: "/livestock/{id}/health/new",
+				Payload: map[string]interface{}{"type": "treatment"}},
+			{Key: "view_animal", Label: "Hayvanı Görüntüle", Type: models.ActionTypeNavigate, Route: "/livestock/{id}"},
+		},
+	},
+	{
+		Topic:       models.NotificationTopicEventReminder,
+		EntityType:  "event",
+		Description: "Takvim etkinliği hatırlatması",
+		Actions: []models.Action{
+			{Key: "view_event", Label: "Etkinliği Görüntüle", Type: models.ActionTypeNavigate, Route: "/calendar/events/{id}"},
+			{Key: "complete_event", Label: "Tamamlandı", Type: models.ActionTypeAPI, Route: "/api/v1/calendar/events/{id}/status",
+				Method: "PATCH", Payload: map[string]interface{}{"status": "completed"}},
+		},
+	},
+	{
+		Topic:       models.NotificationTopicWeatherAlert,
+		EntityType:  "land",
+		Description: "Arazi için hava durumu uyarısı",
+		Actions: []models.Action{
+			{Key: "view_weather", Label: "Hava Geçmişini Gör", Type: models.ActionTypeNavigate, Route: "/lands/{id}/weather"},
+			{Key: "dismiss", Label: "Kapat", Type: models.ActionTypeDismiss},
+		},
+	},
+	{
+		Topic:       models.NotificationTopicRegistryMismatch,
+		Description: "Resmi kayıt içe aktarımında uyuşmazlık bulundu",
+		Actions: []models.Action{
+			{Key: "review_registry", Label: "Uyuşmazlıkları İncele", Type: models.ActionTypeNavigate, Route: "/livestock/registry/import"},
+			{Key: "dismiss", Label: "Kapat", Type: models.ActionTypeDismiss},
+		},
+	},
+	{
+		Topic:       models.NotificationTopicCooperativeInvitation,
+		EntityType:  "cooperative_membership",
+		Description: "Kooperatif veri paylaşım daveti",
+		Actions: []models.Action{
+			{Key: "approve_invitation", Label: "Onayla", Type: models.ActionTypeAPI, Route: "/api/v1/cooperative/invitations/{id}/consent",
+				Method: "PATCH", Payload: map[string]interface{}{"consent": true}},
+			{Key: "reject_invitation", Label: "Reddet", Type: models.ActionTypeAPI, Route: "/api/v1/cooperative/invitations/{id}/consent",
+				Method: "PATCH", Payload: map[string]interface{}{"consent": false}},
+			{Key: "view_invitations", Label: "Davetleri Görüntüle", Type: models.ActionTypeNavigate, Route: "/cooperative/invitations"},
+		},
+	},
+	{
+		Topic:       models.NotificationTopicVetVisit,
+		EntityType:  "vet_visit",
+		Description: "Veteriner ziyaret talebi, onayı veya hatırlatması",
+		Actions: []models.Action{
+			{Key: "view_visit", Label: "Ziyareti Görüntüle", Type: models.ActionTypeNavigate, Route: "/vet-visits/{id}"},
+		},
+	},
+	{
+		Topic:       models.NotificationTopicConsumptionSpike,
+		EntityType:  "utility_meter",
+		Description: "Sayaç tüketiminde olağandışı artış",
+		Actions: []models.Action{
+			{Key: "view_meter", Label: "Sayacı Görüntüle", Type: models.ActionTypeNavigate, Route: "/utilities/meters/{id}"},
+			{Key: "dismiss", Label: "Kapat", Type: models.ActionTypeDismiss},
+		},
+	},
+	{
+		Topic:       models.NotificationTopicDocumentExpiry,
+		EntityType:  "document",
+		Description: "Doküman geçerlilik süresi doluyor veya doldu",
+		Actions: []models.Action{
+			{Key: "view_document", Label: "Dokümanı Görüntüle", Type: models.ActionTypeNavigate, Route: "/documents/{id}"},
+			{Key: "dismiss", Label: "Kapat", Type: models.ActionTypeDismiss},
+		},
+	},
+	{
+		Topic:       models.NotificationTopicPaymentOverdue,
+		EntityType:  "transaction",
+		Description: "Bekleyen ödemenin vadesi geçti",
+		Actions: []models.Action{
+			{Key: "view_transaction", Label: "İşlemi Görüntüle", Type: models.ActionTypeNavigate, Route: "/finance/transactions/{id}"},
+			{Key: "mark_paid", Label: "Ödendi", Type: models.ActionTypeAPI, Route: "/api/v1/finance/transactions/{id}/pay", Method: "PATCH"},
+		},
+	},
+	{
+		Topic:       models.NotificationTopicTransactionDraft,
+		EntityType:  "transaction_draft",
+		Description: "E-postayla iletilen fiş onay bekliyor",
+		Actions: []models.Action{
+			{Key: "review_draft", Label: "Taslağı İncele", Type: models.ActionTypeNavigate, Route: "/finance/drafts/{id}"},
+			{Key: "approve_draft", Label: "Onayla", Type: models.ActionTypeAPI, Route: "/api/v1/finance/drafts/{id}/approve", Method: "POST"},
+		},
+	},
+	{
+		Topic:       models.NotificationTopicGreenhouseClimate,
+		EntityType:  "greenhouse",
+		Description: "Sera sıcaklığı veya nemi hedef aralığın dışında",
+		Actions: []models.Action{
+			{Key: "view_greenhouse", Label: "Serayı Görüntüle", Type: models.ActionTypeNavigate, Route: "/greenhouses/{id}"},
+			{Key: "view_readings", Label: "Ölçümleri Gör", Type: models.ActionTypeNavigate, Route: "/greenhouses/{id}/readings"},
+		},
+	},
+	{
+		Topic:       models.NotificationTopicHiveTreatmentDue,
+		EntityType:  "hive",
+		Description: "Kovan tedavisinin zamanı geldi veya varroa eşiği aşıldı",
+		Actions: []models.Action{
+			{Key: "record_treatment", Label: "Tedavi Kaydet", Type: models.ActionTypeNavigate, Route: "/hives/{id}/treatments/new"},
+			{Key: "view_hive", Label: "Kovanı Görüntüle", Type: models.ActionTypeNavigate, Route: "/hives/{id}"},
+		},
+	},
+	{
+		Topic:       models.NotificationTopicMetricAnomaly,
+		EntityType:  "metric",
+		Description: "Anahtar metrikte olağandışı değer (gider artışı, süt düşüşü, uzun süre kayıt girilmemesi)",
+		Actions: []models.Action{
+			{Key: "view_analytics", Label: "Analizi Gör", Type: models.ActionTypeNavigate, Route: "/analytics/{id}"},
+			{Key: "open_dashboard", Label: "Panoyu Aç", Type: models.ActionTypeNavigate, Route: "/dashboard"},
+		},
+	},
+	{
+		Topic:       models.NotificationTopicInventoryLow,
+		EntityType:  "production",
+		Description: "Üretim partisinin kalan stoğu azaldı",
+		Actions: []models.Action{
+			{Key: "view_production", Label: "Stoğu Görüntüle", Type: models.ActionTypeNavigate, Route: "/production/{id}"},
+			{Key: "dismiss", Label: "Kapat", Type: models.ActionTypeDismiss},
+		},
+	},
+	{
+		Topic:       models.NotificationTopicSupportTicket,
+		EntityType:  "support_ticket",
+		Description: "Destek talebi yanıtlandı veya durumu değişti",
+		Actions: []models.Action{
+			{Key: "view_ticket", Label: "Talebi Görüntüle", Type: models.ActionTypeNavigate, Route: "/support/tickets/{id}"},
+			{Key: "dismiss", Label: "Kapat", Type: models.ActionTypeDismiss},
+		},
+	},
+	{
+		Topic:       models.NotificationTopicBackupFailed,
+		EntityType:  "backup",
+		Description: "Zamanlanmış yedekleme başarısız oldu",
+		Actions: []models.Action{
+			{Key: "retry_backup", Label: "Şimdi Yedekle", Type: models.ActionTypeAPI, Route: "/api/v1/settings/backup", Method: "POST"},
+			{Key: "view_backups", Label: "Yedekleri Görüntüle", Type: models.ActionTypeNavigate, Route: "/settings/backups"},
+		},
+	},
+	{
+		Topic:       models.NotificationTopicBackupFailedAdmin,
+		EntityType:  "backup",
+		Description: "Bir çiftliğin yedeklemesi başarısız oldu (yönetici)",
+		Actions: []models.Action{
+			{Key: "view_failed_backups", Label: "Başarısız Yedekleri Gör", Type: models.ActionTypeNavigate, Route: "/admin/db/backups?status=failed"},
+		},
+	},
+	{
+		Topic:       models.NotificationTopicWaterQuota,
+		EntityType:  "water_quota",
+		Description: "Sulama suyu kullanımı kota uyarı eşiğine ulaştı veya kotayı aştı",
+		Actions: []models.Action{
+			{Key: "view_quota", Label: "Kotayı Görüntüle", Type: models.ActionTypeNavigate, Route: "/water-quotas/{id}"},
+			{Key: "dismiss", Label: "Kapat", Type: models.ActionTypeDismiss},
+		},
+	},
+	{
+		Topic:       models.NotificationTopicReportReady,
+		EntityType:  "report",
+		Description: "Arka planda oluşturulan rapor indirilmeye hazır",
+		Actions: []models.Action{
+			{Key: "download_report", Label: "Raporu İndir", Type: models.ActionTypeNavigate, Route: "/reports/{id}/download"},
+			{Key: "view_reports", Label: "Raporları Görüntüle", Type: models.ActionTypeNavigate, Route: "/reports"},
+		},
+	},
+	{
+		Topic:       models.NotificationTopicReportFailed,
+		EntityType:  "report_job",
+		Description: "Arka planda rapor oluşturma başarısız oldu",
+		Actions: []models.Action{
+			{Key: "view_reports", Label: "Raporları Görüntüle", Type: models.ActionTypeNavigate, Route: "/reports"},
+			{Key: "dismiss", Label: "Kapat", Type: models.ActionTypeDismiss},
+		},
+	}, {
+		Topic:       models.NotificationTopicWorkerCertification,
+		EntityType:  "worker",
+		Description: "Aktiviteye sözleşmesi veya gerekli belgesi geçerli olmayan çalışan atandı",
+		Actions: []models.Action{
+			{Key: "view_worker", Label: "Çalışanı Görüntüle", Type: models.ActionTypeNavigate, Route: "/workers/{id}"},
+			{Key: "dismiss", Label: "Kapat", Type: models.ActionTypeDismiss},
+		},
+	},
+	{
+		Topic:       models.NotificationTopicAccountantAccess,
+		EntityType:  "farm",
+		Description: "Bir çiftlik hesaba muhasebeci olarak finans kayıtlarına salt okunur erişim verdi",
+		Actions: []models.Action{
+			{Key: "view_farms", Label: "Çiftlikleri Görüntüle", Type: models.ActionTypeNavigate, Route: "/accountants/farms"},
+			{Key: "dismiss", Label: "Kapat", Type: models.ActionTypeDismiss},
+		},
+	},
+	{
+		Topic:       models.NotificationTopicIrrigationDue,
+		EntityType:  "land",
+		Description: "Arazinin su açığı sulama eşiğine ulaştı",
+		Actions: []models.Action{
+			{Key: "view_schedule", Label: "Sulama Takvimini Görüntüle", Type: models.ActionTypeNavigate, Route: "/lands/{id}/irrigation-schedule"},
+			{Key: "dismiss", Label: "Kapat", Type: models.ActionTypeDismiss},
+		},
+	},
+	{
+		Topic:       models.NotificationTopicWeatherLocation,
+		EntityType:  "weather_location",
+		Description: "Kayıtlı konumun günlük hava tahmini özeti veya don/yoğun yağış beklentisi",
+		Actions: []models.Action{
+			{Key: "view_forecast", Label: "Tahmini Görüntüle", Type: models.ActionTypeNavigate, Route: "/weather/locations/{id}/forecast"},
+			{Key: "dismiss", Label: "Kapat", Type: models.ActionTypeDismiss},
+		},
+	},
+}
+
+// entityRoutes ilişkili varlık türlerine göre varsayılan "Görüntüle" ekranı
+var entityRoutes = map[string]string{
+	"livestock":         "/livestock/{id}",
+	"land":              "/lands/{id}",
+	"production":        "/production/{id}",
+	"transaction":       "/finance/transactions/{id}",
+	"transaction_draft": "/finance/drafts/{id}",
+	"event":             "/calendar/events/{id}",
+	"vet_visit":         "/vet-visits/{id}",
+	"utility_meter":     "/utilities/meters/{id}",
+	"document":          "/documents/{id}",
+	"greenhouse":        "/greenhouses/{id}",
+	"hive":              "/hives/{id}",
+	"asset":             "/assets/{id}",
+	"pond":              "/ponds/{id}",
+	"fish_batch":        "/fish-batches/{id}",
+	"water_quota":       "/water-quotas/{id}",
+	"worker":            "/workers/{id}",
+	"weather_location":  "/weather/locations/{id}",
+}
+
+// NotificationActionCatalog tüm bildirim konularının aksiyon tanımlarını döner
+func NotificationActionCatalog() []models.NotificationActionTemplate {
+	return notificationActionCatalog
+}
+
+// NotificationActions bildirim konusu ve ilişkili varlık için aksiyonları oluşturur;
+// tanımsız konularda varlık ekranına, varlık yoksa bildirim listesine yönlendirir
+func NotificationActions(topic string, entity *models.RelatedEntity) []models.Action {
+	entityID := ""
+	if entity != nil {
+		entityID = entity.ID
+	}
+
+	for _, template := range notificationActionCatalog {
+		if template.Topic != topic {
+			continue
+		}
+
+		actions := make([]models.Action, 0, len(template.Actions))
+		for _, action := range template.Actions {
+			if strings.Contains(action.Route, "{id}") {
+				if entityID == "" {
+					continue
+				}
+				action.Route = strings.ReplaceAll(action.Route, "{id}", entityID)
+			}
+			actions = append(actions, action)
+		}
+		if len(actions) > 0 {
+			return actions
+		}
+		break
+	}
+
+	if entity != nil {
+		if route, ok := entityRoutes[entity.Type]; ok && entityID != "" {
+			return []models.Action{
+				{Key: "view", Label: "Görüntüle", Type: models.ActionTypeNavigate, Route: strings.ReplaceAll(route, "{id}", entityID)},
+			}
+		}
+	}
+
+	return []models.Action{
+		{Key: "view", Label: "Görüntüle", Type: models.ActionTypeNavigate, Route: "/notifications"},
+	}
+}
@@ -0,0 +1,202 @@
+package services
+
+import (
+	"database/sql"
+	"fmt"
+	"strings"
+	"time"
+
+	"agri-management-api/internal/models"
+	"agri-management-api/internal/utils"
+)
+
+// defaultNotificationDedupeWindow tekilleştirme anahtarı olan bildirimlerin tekrar gönderilmediği varsayılan süre
+const defaultNotificationDedupeWindow = 24 * time.Hour
+
+// Notification oluşturulacak bildirim. Template verilirse başlık ve metin "notification" kanalındaki şablondan
+// alıcı çiftliğin dilinde üretilir; şablon verileri Params ve ilişkili varlığın adını taşıyan "entity" alanıdır.
+// Template verilmezse Title ve Message içindeki {anahtar} yer tutucuları Params ile, {entity} ise ilişkili
+// varlığın adıyla doldurulur; time.Time değerleri GG.AA.YYYY biçiminde yazılır.
+// DedupeKey verilirse aynı kullanıcıya aynı anahtarla DedupeWindow (varsayılan 24 saat) içinde
+// oluşturulmuş bildirim varsa yenisi oluşturulmaz
+type Notification struct {
+	UserID       string
+	Template     string
+	Title        string
+	Message      string
+	Type         string
+	Priority     string
+	Topic        string
+	Entity       *models.RelatedEntity
+	Params       map[string]interface{}
+	DedupeKey    string
+	DedupeWindow time.Duration
+}
+
+// NotificationService modüllerin bildirim oluşturduğu ortak servis; bildirimlere konu ve varlığa göre
+// aksiyon kataloğundaki aksiyonları ekler
+type NotificationService struct {
+	db        *sql.DB
+	templates *MessageTemplateRegistry
+}
+
+// NewNotificationService yeni bildirim servisi oluşturur
+func NewNotificationService(db *sql.DB) *NotificationService {
+	return &NotificationService{db: db, templates: NewMessageTemplateRegistry(db)}
+}
+
+// Create tek bildirim oluşturur; tekilleştirme nedeniyle atlanırsa false döner
+func (s *NotificationService) Create(notification Notification) (bool, error) {
+	created, err := s.CreateBatch([]Notification{notification})
+	return created > 0, err
+}
+
+// CreateBatch bildirimleri tek işlemde oluşturur ve oluşturulan bildirim sayısını döner; aynı toplu
+// istekteki tekrarlanan anahtarlar da tekilleştirilir
+func (s *NotificationService) CreateBatch(notifications []Notification) (int, error) {
+	if len(notifications) == 0 {
+		return 0, nil
+	}
+
+	notifications = append([]Notification(nil), notifications...)
+	languages := map[string]string{}
+	for i := range notifications {
+		if err := s.render(&notifications[i], languages); err != nil {
+			return 0, err
+		}
+	}
+
+	tx, err := s.db.Begin()
+	if err != nil {
+		return 0, err
+	}
+	defer tx.Rollback()
+
+	created := 0
+	for _, notification := range notifications {
+		ok, err := insertNotification(tx, notification)
+		if err != nil {
+			return 0, err
+		}
+		if ok {
+			created++
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return 0, err
+	}
+	return created, nil
+}
+
+// render şablonlu bildirimin başlık ve metnini alıcı çiftliğin dilinde üretir; diller çiftlik başına bir kez okunur
+func (s *NotificationService) render(notification *Notification, languages map[string]string) error {
+	if notification.Template == "" {
+		return nil
+	}
+
+	language, ok := languages[notification.UserID]
+	if !ok {
+		language = s.templates.Language(notification.UserID)
+		languages[notification.UserID] = language
+	}
+
+	data := make(map[string]interface{}, len(notification.Params)+1)
+	for key, value := range notification.Params {
+		data[key] = value
+	}
+	if notification.Entity != nil {
+		data["entity"] = notification.Entity.Name
+	}
+
+	rendered, err := s.templates.Render(models.MessageChannelNotification, notification.Template, language, data)
+	if err != nil {
+		return fmt.Errorf("notification template %s: %w", notification.Template, err)
+	}
+	notification.Title, notification.Message, notification.Params = rendered.Title, rendered.Body, nil
+	return nil
+}
+
+// insertNotification yer tutucuları doldurur, tekilleştirme kontrolünü yapar ve bildirimi ekler
+func insertNotification(tx *sql.Tx, notification Notification) (bool, error) {
+	if notification.Topic == "" {
+		notification.Topic = models.NotificationTopicGeneral
+	}
+	if notification.Type == "" {
+		notification.Type = "info"
+	}
+	if notification.Priority == "" {
+		notification.Priority = "medium"
+	}
+
+	if notification.DedupeKey != "" {
+		window := notification.DedupeWindow
+		if window <= 0 {
+			window = defaultNotificationDedupeWindow
+		}
+		var exists bool
+		err := tx.QueryRow(`
+			SELECT 1 FROM notifications
+			WHERE user_id = ? AND dedupe_key = ? AND created_at >= datetime('now', ?)
+			LIMIT 1
+		`, notification.UserID, notification.DedupeKey, fmt.Sprintf("-%d seconds", int(window.Seconds()))).Scan(&exists)
+		if err == nil {
+			return false, nil
+		}
+		if err != sql.ErrNoRows {
+			return false, err
+		}
+	}
+
+	entity := notification.Entity
+	title := RenderNotificationTemplate(notification.Title, notification.Params, entity)
+	message := RenderNotificationTemplate(notification.Message, notification.Params, entity)
+	actions, _ := utils.ToJSON(NotificationActions(notification.Topic, entity))
+
+	var entityType, entityID, entityName interface{}
+	if entity != nil {
+		entityType, entityID, entityName = entity.Type, entity.ID, entity.Name
+	}
+
+	_, err := tx.Exec(`
+		INSERT INTO notifications (id, user_id, title, message, type, priority, is_read, topic,
+		                           related_entity_type, related_entity_id, related_entity_name, actions, dedupe_key, created_at)
+		VALUES (?, ?, ?, ?, ?, ?, false, ?, ?, ?, ?, ?, ?, CURRENT_TIMESTAMP)
+	`, utils.GenerateID(), notification.UserID, title, message, notification.Type, notification.Priority,
+		notification.Topic, entityType, entityID, entityName, actions, utils.StringToNullString(notification.DedupeKey))
+	return err == nil, err
+}
+
+// RenderNotificationTemplate metindeki {anahtar} yer tutucularını doldurur; {entity} ilişkili varlığın adıdır.
+// Bilinmeyen yer tutucular olduğu gibi bırakılır
+func RenderNotificationTemplate(template string, params map[string]interface{}, entity *models.RelatedEntity) string {
+	if !strings.Contains(template, "{") {
+		return template
+	}
+
+	replacements := make([]string, 0, 2*len(params)+2)
+	if entity != nil {
+		replacements = append(replacements, "{entity}", entity.Name)
+	}
+	for key, value := range params {
+		replacements = append(replacements, "{"+key+"}", formatNotificationValue(value))
+	}
+	return strings.NewReplacer(replacements...).Replace(template)
+}
+
+// formatNotificationValue yer tutucu değerini metne çevirir
+func formatNotificationValue(value interface{}) string {
+	switch v := value.(type) {
+	case time.Time:
+		return v.Format("02.01.2006")
+	case *time.Time:
+		if v == nil {
+			return ""
+		}
+		return v.Format("02.01.2006")
+	case float64:
+		return fmt.Sprintf("%.2f", v)
+	default:
+		return fmt.Sprint(v)
+	}
+}
@@ -0,0 +1,315 @@
+package services
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"net/http"
+	"net/url"
+	"os"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"agri-management-api/internal/models"
+)
+
+// ErrParcelProviderDisabled kadastro sorgu sağlayıcısı yapılandırılmadığında döner
+var ErrParcelProviderDisabled = errors.New("parcel lookup provider not configured")
+
+// ErrParcelNotFound sağlayıcı parseli bulamadığında döner
+var ErrParcelNotFound = errors.New("parcel not found")
+
+// ErrParcelCodeRequired sağlayıcı sorgu için mahalle kodu gerektirdiğinde döner
+var ErrParcelCodeRequired = errors.New("neighborhood code required by parcel provider")
+
+// tkgmParcelEndpoint TKGM Parsel Sorgu servisinin mahalle kodu, ada ve parsel ile sorgulanan adresi
+const tkgmParcelEndpoint = "https://cbsapi.tkgm.gov.tr/megsiswebapi.v3/api/parsel/{neighborhoodCode}/{block}/{parcel}"
+
+var (
+	parcelBlockPattern  = regexp.MustCompile(`^\d{1,6}$`)
+	parcelNumberPattern = regexp.MustCompile(`^[1-9]\d{0,5}$`)
+	parcelCodePattern   = regexp.MustCompile(`^\d{1,10}$`)
+)
+
+// NormalizeParcel kadastro bilgisini boşluklardan arındırır ve ada/parsel biçimini doğrular;
+// "101/7" biçiminde girilen parsel ada ve parsel olarak ayrılır. Hata mesajı kullanıcıya gösterilir
+func NormalizeParcel(parcel *models.LandParcel) error {
+	parcel.Province = strings.TrimSpace(parcel.Province)
+	parcel.District = strings.TrimSpace(parcel.District)
+	parcel.Neighborhood = strings.TrimSpace(parcel.Neighborhood)
+	parcel.NeighborhoodCode = strings.TrimSpace(parcel.NeighborhoodCode)
+	parcel.Block = strings.TrimSpace(parcel.Block)
+	parcel.Parcel = strings.TrimSpace(parcel.Parcel)
+
+	if parcel.Block == "" {
+		if block, number, ok := strings.Cut(parcel.Parcel, "/"); ok {
+			parcel.Block, parcel.Parcel = strings.TrimSpace(block), strings.TrimSpace(number)
+		}
+	}
+
+	if !parcelBlockPattern.MatchString(parcel.Block) {
+		return errors.New("Ada numarası 0-999999 arasında bir sayı olmalı")
+	}
+	if !parcelNumberPattern.MatchString(parcel.Parcel) {
+		return errors.New("Parsel numarası 1-999999 arasında bir sayı olmalı")
+	}
+	if parcel.NeighborhoodCode != "" && !parcelCodePattern.MatchString(parcel.NeighborhoodCode) {
+		return errors.New("Mahalle kodu sayısal olmalı")
+	}
+	if parcel.NeighborhoodCode == "" && (parcel.Province == "" || parcel.District == "" || parcel.Neighborhood == "") {
+		return errors.New("İl, ilçe ve mahalle/köy ya da mahalle kodu gerekli")
+	}
+
+	// Ada ve parsel numaraları baştaki sıfırlar olmadan saklanır
+	parcel.Block = strings.TrimLeft(parcel.Block, "0")
+	if parcel.Block == "" {
+		parcel.Block = "0"
+	}
+	return nil
+}
+
+// ValidatePolygon arazi sınırının kapalı ve geçerli koordinatlardan oluşan bir GeoJSON Polygon olduğunu doğrular
+func ValidatePolygon(polygon *models.GeoPolygon) error {
+	if polygon.Type != "Polygon" {
+		return errors.New("Sınır GeoJSON Polygon olmalı")
+	}
+	if len(polygon.Coordinates) == 0 {
+		return errors.New("Sınır koordinatları gerekli")
+	}
+
+	for _, ring := range polygon.Coordinates {
+		if len(ring) < 4 {
+			return errors.New("Sınır en az dört noktadan oluşmalı")
+		}
+		for _, point := range ring {
+			if len(point) < 2 || point[0] < -180 || point[0] > 180 || point[1] < -90 || point[1] > 90 {
+				return errors.New("Sınır koordinatları [boylam, enlem] biçiminde olmalı")
+			}
+		}
+		first, last := ring[0], ring[len(ring)-1]
+		if first[0] != last[0] || first[1] != last[1] {
+			return errors.New("Sınırın ilk ve son noktası aynı olmalı")
+		}
+	}
+	return nil
+}
+
+// PolygonCentroid sınırın dış halkasındaki noktaların ortalamasını döner
+func PolygonCentroid(polygon models.GeoPolygon) models.Location {
+	ring := polygon.Coordinates[0]
+	points := ring[:len(ring)-1]
+
+	var lat, lon float64
+	for _, point := range points {
+		lon += point[0]
+		lat += point[1]
+	}
+	return models.Location{Latitude: lat / float64(len(points)), Longitude: lon / float64(len(points))}
+}
+
+// PolygonArea sınırın yaklaşık alanını metrekare olarak hesaplar (küresel yaklaşım, iç halkalar düşülür)
+func PolygonArea(polygon models.GeoPolygon) float64 {
+	const earthRadius = 6378137.0
+
+	var area float64
+	for i, ring := range polygon.Coordinates {
+		var sum float64
+		for j := 0; j < len(ring)-1; j++ {
+			p1, p2 := ring[j], ring[j+1]
+			sum += (p2[0] - p1[0]) * math.Pi / 180 * (2 + math.Sin(p1[1]*math.Pi/180) + math.Sin(p2[1]*math.Pi/180))
+		}
+		ringArea := math.Abs(sum * earthRadius * earthRadius / 2)
+		if i == 0 {
+			area += ringArea
+		} else {
+			area -= ringArea
+		}
+	}
+	return round2(area)
+}
+
+// AreaInUnit metrekare alanı arazi birimine çevirir; bilinmeyen birimler dönüm kabul edilir
+func AreaInUnit(areaM2 float64, unit string) float64 {
+	factor, ok := landHectareFactors[strings.ToLower(strings.TrimSpace(unit))]
+	if !ok {
+		factor = landHectareFactors["dönüm"]
+	}
+	return round2(areaM2 * 0.0001 / factor)
+}
+
+// ParcelProvider resmi kadastro kaydından parsel geometrisini getiren sağlayıcı arayüzü
+type ParcelProvider interface {
+	Lookup(ctx context.Context, parcel models.LandParcel) (*models.ParcelLookupResult, error)
+}
+
+// NewParcelProvider PARCEL_PROVIDER ortam değişkenine göre sağlayıcı oluşturur; yapılandırılmamışsa nil döner.
+// "tkgm" TKGM Parsel Sorgu servisini, "geojson" PARCEL_LOOKUP_URL adresindeki GeoJSON Feature dönen servisi kullanır
+func NewParcelProvider() ParcelProvider {
+	endpoint := os.Getenv("PARCEL_LOOKUP_URL")
+
+	switch os.Getenv("PARCEL_PROVIDER") {
+	case "tkgm":
+		if endpoint == "" {
+			endpoint = tkgmParcelEndpoint
+		}
+		return &GeoJSONParcelProvider{name: "tkgm", endpoint: endpoint, client: &http.Client{Timeout: 15 * time.Second}}
+	case "geojson":
+		if endpoint == "" {
+			return nil
+		}
+		return &GeoJSONParcelProvider{
+			name:     "geojson",
+			endpoint: endpoint,
+			apiKey:   os.Getenv("PARCEL_API_KEY"),
+			client:   &http.Client{Timeout: 15 * time.Second},
+		}
+	}
+	return nil
+}
+
+// GeoJSONParcelProvider adres şablonundaki {province}, {district}, {neighborhood}, {neighborhoodCode},
+// {block} ve {parcel} alanlarını doldurarak parseli GeoJSON Feature olarak sorgular
+type GeoJSONParcelProvider struct {
+	name     string
+	endpoint string
+	apiKey   string
+	client   *http.Client
+}
+
+// Lookup parselin sınırını, alanını ve niteliğini getirir
+func (p *GeoJSONParcelProvider) Lookup(ctx context.Context, parcel models.LandParcel) (*models.ParcelLookupResult, error) {
+	if strings.Contains(p.endpoint, "{neighborhoodCode}") && parcel.NeighborhoodCode == "" {
+		return nil, ErrParcelCodeRequired
+	}
+
+	endpoint := strings.NewReplacer(
+		"{province}", url.PathEscape(parcel.Province),
+		"{district}", url.PathEscape(parcel.District),
+		"{neighborhood}", url.PathEscape(parcel.Neighborhood),
+		"{neighborhoodCode}", url.PathEscape(parcel.NeighborhoodCode),
+		"{block}", url.PathEscape(parcel.Block),
+		"{parcel}", url.PathEscape(parcel.Parcel),
+	).Replace(p.endpoint)
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, endpoint, nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Accept", "application/json")
+	if p.apiKey != "" {
+		req.Header.Set("Authorization", "Bearer "+p.apiKey)
+	}
+
+	resp, err := p.client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode == http.StatusNotFound || resp.StatusCode == http.StatusNoContent {
+		return nil, ErrParcelNotFound
+	}
+	if resp.StatusCode != http.StatusOK {
+		message, _ := io.ReadAll(io.LimitReader(resp.Body, 512))
+		return nil, fmt.Errorf("parcel lookup failed: %s: %s", resp.Status, bytes.TrimSpace(message))
+	}
+
+	var feature struct {
+		Geometry *struct {
+			Type        string          `json:"type"`
+			Coordinates json.RawMessage `json:"coordinates"`
+		} `json:"geometry"`
+		Properties map[string]interface{} `json:"properties"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&feature); err != nil {
+		return nil, err
+	}
+	if feature.Geometry == nil {
+		return nil, ErrParcelNotFound
+	}
+
+	boundary := models.GeoPolygon{Type: "Polygon"}
+	switch feature.Geometry.Type {
+	case "Polygon":
+		err = json.Unmarshal(feature.Geometry.Coordinates, &boundary.Coordinates)
+	case "MultiPolygon":
+		// Çok parçalı parsellerde en büyük parça sınır olarak alınır
+		var polygons [][][][]float64
+		if err = json.Unmarshal(feature.Geometry.Coordinates, &polygons); err == nil {
+			var largest float64
+			for _, coordinates := range polygons {
+				candidate := models.GeoPolygon{Type: "Polygon", Coordinates: coordinates}
+				if area := PolygonArea(candidate); area > largest {
+					largest, boundary = area, candidate
+				}
+			}
+		}
+	default:
+		return nil, fmt.Errorf("unsupported parcel geometry: %s", feature.Geometry.Type)
+	}
+	if err != nil {
+		return nil, err
+	}
+	if err := ValidatePolygon(&boundary); err != nil {
+		return nil, fmt.Errorf("invalid parcel geometry: %w", err)
+	}
+
+	result := &models.ParcelLookupResult{
+		Parcel:   parcel,
+		Boundary: boundary,
+		AreaM2:   parcelProperty(feature.Properties, "alan", "area"),
+		Centroid: PolygonCentroid(boundary),
+		Source:   p.name,
+	}
+	if result.AreaM2 == 0 {
+		result.AreaM2 = PolygonArea(boundary)
+	}
+	if landUse, ok := propertyValue(feature.Properties, "nitelik", "landUse").(string); ok {
+		result.LandUse = landUse
+	}
+	if result.Parcel.Province == "" {
+		result.Parcel.Province, _ = propertyValue(feature.Properties, "ilAd", "province").(string)
+	}
+	if result.Parcel.District == "" {
+		result.Parcel.District, _ = propertyValue(feature.Properties, "ilceAd", "district").(string)
+	}
+	if result.Parcel.Neighborhood == "" {
+		result.Parcel.Neighborhood, _ = propertyValue(feature.Properties, "mahalleAd", "neighborhood").(string)
+	}
+
+	return result, nil
+}
+
+// propertyValue özelliklerde verilen anahtarlardan ilk bulunanı büyük/küçük harf ayrımı olmadan döner
+func propertyValue(properties map[string]interface{}, keys ...string) interface{} {
+	for _, key := range keys {
+		for name, value := range properties {
+			if strings.EqualFold(name, key) {
+				return value
+			}
+		}
+	}
+	return nil
+}
+
+// parcelProperty sayısal özelliği okur; "1.234,56" gibi Türkçe biçimli metinler de çözülür
+func parcelProperty(properties map[string]interface{}, keys ...string) float64 {
+	switch value := propertyValue(properties, keys...).(type) {
+	case float64:
+		return value
+	case string:
+		value = strings.TrimSpace(value)
+		if strings.Contains(value, ",") {
+			value = strings.ReplaceAll(strings.ReplaceAll(value, ".", ""), ",", ".")
+		}
+		number, _ := strconv.ParseFloat(value, 64)
+		return number
+	}
+	return 0
+}
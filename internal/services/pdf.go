@@ -0,0 +1,345 @@
+package services
+
+import (
+	"bytes"
+	"compress/zlib"
+	"fmt"
+	"image"
+	"image/color"
+	_ "image/gif"
+	_ "image/jpeg"
+	_ "image/png"
+	"io"
+	"strconv"
+	"strings"
+)
+
+// A4 sayfa boyutu (pt)
+const (
+	PDFPageWidth  = 595.28
+	PDFPageHeight = 841.89
+)
+
+// pdfFontEncoding Helvetica'yı Türkçe karakterleri içeren Windows-1254 düzenine çeviren kodlama sözlüğü
+const pdfFontEncoding = "<< /Type /Encoding /BaseEncoding /WinAnsiEncoding " +
+	"/Differences [208 /Gbreve 221 /Idotaccent 222 /Scedilla 240 /gbreve 253 /dotlessi 254 /scedilla] >>"
+
+// pdfSpecialBytes Latin-1 dışında kalan karakterlerin Windows-1254 karşılıkları
+var pdfSpecialBytes = map[rune]byte{
+	'Ğ': 0xD0, 'İ': 0xDD, 'Ş': 0xDE, 'ğ': 0xF0, 'ı': 0xFD, 'ş': 0xFE,
+	'€': 0x80, '…': 0x85, '•': 0x95, '–': 0x96, '—': 0x97,
+	'‘': 0x91, '’': 0x92, '“': 0x93, '”': 0x94,
+}
+
+// pdfLatin1Replaced Windows-1254'te Türkçe harflere ayrılan Latin-1 kodları
+var pdfLatin1Replaced = map[rune]bool{
+	'Ð': true, 'Ý': true, 'Þ': true, 'ð': true, 'ý': true, 'þ': true,
+}
+
+// Helvetica ve Helvetica-Bold karakter genişlikleri (1/1000 em, 32-126 arası)
+var (
+	pdfHelveticaWidths = [95]int{
+		278, 278, 355, 556, 556, 889, 667, 191, 333, 333, 389, 584, 278, 333, 278, 278,
+		556, 556, 556, 556, 556, 556, 556, 556, 556, 556, 278, 278, 584, 584, 584, 556,
+		1015, 667, 667, 722, 722, 667, 611, 778, 722, 278, 500, 667, 556, 833, 722, 778,
+		667, 778, 722, 667, 611, 722, 667, 944, 667, 667, 611, 278, 278, 278, 469, 556,
+		333, 556, 556, 500, 556, 556, 278, 556, 556, 222, 222, 500, 222, 833, 556, 556,
+		556, 556, 333, 500, 278, 556, 500, 722, 500, 500, 500, 334, 260, 334, 584,
+	}
+	pdfHelveticaBoldWidths = [95]int{
+		278, 333, 474, 556, 556, 889, 722, 238, 333, 333, 389, 584, 278, 333, 278, 278,
+		556, 556, 556, 556, 556, 556, 556, 556, 556, 556, 333, 333, 584, 584, 584, 611,
+		975, 722, 722, 722, 722, 667, 611, 778, 722, 278, 556, 722, 611, 833, 722, 778,
+		667, 778, 722, 667, 611, 722, 667, 944, 667, 667, 611, 333, 278, 333, 584, 556,
+		333, 556, 611, 556, 611, 556, 333, 611, 611, 278, 278, 556, 278, 889, 611, 611,
+		611, 611, 389, 556, 333, 611, 556, 778, 556, 556, 500, 389, 280, 389, 584,
+	}
+)
+
+// pdfImage belgeye gömülen görsel
+type pdfImage struct {
+	width, height int
+	colorSpace    string
+	filter        string
+	data          []byte
+}
+
+// PDFDocument harici kütüphane kullanmadan basit A4 PDF belgeleri üretir. Koordinatlar sayfanın sol üst
+// köşesinden pt cinsindendir; metinler Türkçe karakter destekli Helvetica ile yazılır
+type PDFDocument struct {
+	pages  []*bytes.Buffer
+	images []pdfImage
+}
+
+// NewPDFDocument tek boş sayfalı yeni PDF belgesi oluşturur
+func NewPDFDocument() *PDFDocument {
+	doc := &PDFDocument{}
+	doc.AddPage()
+	return doc
+}
+
+// AddPage belgeye yeni sayfa ekler; sonraki çizimler bu sayfaya yapılır
+func (d *PDFDocument) AddPage() {
+	d.pages = append(d.pages, &bytes.Buffer{})
+}
+
+// page çizim yapılan geçerli sayfa
+func (d *PDFDocument) page() *bytes.Buffer {
+	return d.pages[len(d.pages)-1]
+}
+
+// Text metni verilen noktaya (taban çizgisi) yazar
+func (d *PDFDocument) Text(x, y, size float64, bold bool, text string) {
+	font := "F1"
+	if bold {
+		font = "F2"
+	}
+	fmt.Fprintf(d.page(), "BT /%s %s Tf %s %s Td (%s) Tj ET\n",
+		font, pdfNumber(size), pdfNumber(x), pdfNumber(PDFPageHeight-y), pdfEscape(pdfEncode(text)))
+}
+
+// TextFit metni genişliğe sığmayacaksa sonunu "…" ile kısaltarak yazar
+func (d *PDFDocument) TextFit(x, y, width, size float64, bold bool, text string) {
+	d.Text(x, y, size, bold, PDFTruncate(text, width, size, bold))
+}
+
+// TextRight metni sağ kenarı verilen noktaya hizalanacak şekilde yazar
+func (d *PDFDocument) TextRight(x, y, size float64, bold bool, text string) {
+	d.Text(x-PDFTextWidth(text, size, bold), y, size, bold, text)
+}
+
+// Line iki nokta arasına verilen kalınlıkta gri tonlu çizgi çizer (0 siyah, 1 beyaz)
+func (d *PDFDocument) Line(x1, y1, x2, y2, width, gray float64) {
+	fmt.Fprintf(d.page(), "q %s G %s w %s %s m %s %s l S Q\n", pdfNumber(gray), pdfNumber(width),
+		pdfNumber(x1), pdfNumber(PDFPageHeight-y1), pdfNumber(x2), pdfNumber(PDFPageHeight-y2))
+}
+
+// Rect dikdörtgenin kenarlarını çizer
+func (d *PDFDocument) Rect(x, y, w, h, width, gray float64) {
+	fmt.Fprintf(d.page(), "q %s G %s w %s %s %s %s re S Q\n", pdfNumber(gray), pdfNumber(width),
+		pdfNumber(x), pdfNumber(PDFPageHeight-y-h), pdfNumber(w), pdfNumber(h))
+}
+
+// FillRect dikdörtgeni gri tonla doldurur
+func (d *PDFDocument) FillRect(x, y, w, h, gray float64) {
+	fmt.Fprintf(d.page(), "q %s g %s %s %s %s re f Q\n", pdfNumber(gray),
+		pdfNumber(x), pdfNumber(PDFPageHeight-y-h), pdfNumber(w), pdfNumber(h))
+}
+
+// Image JPEG, PNG veya GIF görseli kutuya oranını koruyarak ortalar. JPEG'ler yeniden sıkıştırılmadan
+// gömülür, diğer biçimler RGB'ye çevrilip sıkıştırılır
+func (d *PDFDocument) Image(data []byte, x, y, w, h float64) error {
+	img, err := pdfDecodeImage(data)
+	if err != nil {
+		return err
+	}
+	d.images = append(d.images, img)
+
+	scale := w / float64(img.width)
+	if hs := h / float64(img.height); hs < scale {
+		scale = hs
+	}
+	drawW, drawH := float64(img.width)*scale, float64(img.height)*scale
+	left := x + (w-drawW)/2
+	bottom := PDFPageHeight - y - h + (h-drawH)/2
+
+	fmt.Fprintf(d.page(), "q %s 0 0 %s %s %s cm /Im%d Do Q\n",
+		pdfNumber(drawW), pdfNumber(drawH), pdfNumber(left), pdfNumber(bottom), len(d.images))
+	return nil
+}
+
+// Write belgeyi PDF olarak yazar
+func (d *PDFDocument) Write(w io.Writer) error {
+	var buf bytes.Buffer
+	var offsets []int
+
+	object := func(body string, stream []byte) {
+		offsets = append(offsets, buf.Len())
+		fmt.Fprintf(&buf, "%d 0 obj\n%s\n", len(offsets), body)
+		if stream != nil {
+			buf.WriteString("stream\n")
+			buf.Write(stream)
+			buf.WriteString("\nendstream\n")
+		}
+		buf.WriteString("endobj\n")
+	}
+
+	// Nesne sırası: katalog, sayfa ağacı, iki yazı tipi, görseller, ardından her sayfa için sayfa ve içerik
+	firstImage := 5
+	firstPage := firstImage + len(d.images)
+
+	kids := make([]string, len(d.pages))
+	for i := range d.pages {
+		kids[i] = fmt.Sprintf("%d 0 R", firstPage+i*2)
+	}
+
+	var xobjects strings.Builder
+	for i := range d.images {
+		fmt.Fprintf(&xobjects, " /Im%d %d 0 R", i+1, firstImage+i)
+	}
+	resources := "<< /Font << /F1 3 0 R /F2 4 0 R >>"
+	if xobjects.Len() > 0 {
+		resources += " /XObject <<" + xobjects.String() + " >>"
+	}
+	resources += " >>"
+
+	buf.WriteString("%PDF-1.4\n%\xe2\xe3\xcf\xd3\n")
+	object("<< /Type /Catalog /Pages 2 0 R >>", nil)
+	object(fmt.Sprintf("<< /Type /Pages /Kids [%s] /Count %d >>", strings.Join(kids, " "), len(d.pages)), nil)
+	object("<< /Type /Font /Subtype /Type1 /BaseFont /Helvetica /Encoding "+pdfFontEncoding+" >>", nil)
+	object("<< /Type /Font /Subtype /Type1 /BaseFont /Helvetica-Bold /Encoding "+pdfFontEncoding+" >>", nil)
+
+	for _, img := range d.images {
+		object(fmt.Sprintf("<< /Type /XObject /Subtype /Image /Width %d /Height %d /ColorSpace /%s /BitsPerComponent 8 /Filter /%s /Length %d >>",
+			img.width, img.height, img.colorSpace, img.filter, len(img.data)), img.data)
+	}
+
+	for i, page := range d.pages {
+		object(fmt.Sprintf("<< /Type /Page /Parent 2 0 R /MediaBox [0 0 %s %s] /Resources %s /Contents %d 0 R >>",
+			pdfNumber(PDFPageWidth), pdfNumber(PDFPageHeight), resources, firstPage+i*2+1), nil)
+
+		content, err := pdfDeflate(page.Bytes())
+		if err != nil {
+			return err
+		}
+		object(fmt.Sprintf("<< /Filter /FlateDecode /Length %d >>", len(content)), content)
+	}
+
+	xref := buf.Len()
+	fmt.Fprintf(&buf, "xref\n0 %d\n0000000000 65535 f \n", len(offsets)+1)
+	for _, offset := range offsets {
+		fmt.Fprintf(&buf, "%010d 00000 n \n", offset)
+	}
+	fmt.Fprintf(&buf, "trailer\n<< /Size %d /Root 1 0 R >>\nstartxref\n%d\n%%%%EOF\n", len(offsets)+1, xref)
+
+	_, err := w.Write(buf.Bytes())
+	return err
+}
+
+// PDFTextWidth metnin verilen yazı boyutundaki yaklaşık genişliği (pt)
+func PDFTextWidth(text string, size float64, bold bool) float64 {
+	widths := &pdfHelveticaWidths
+	if bold {
+		widths = &pdfHelveticaBoldWidths
+	}
+
+	total := 0
+	for _, r := range text {
+		switch {
+		case r >= 32 && r <= 126:
+			total += widths[r-32]
+		case r == 'ı' || r == 'İ':
+			total += 278
+		case r == '…':
+			total += 1000
+		case r == 'Ç' || r == 'Ğ' || r == 'Ö' || r == 'Ş' || r == 'Ü':
+			total += 722
+		default:
+			total += 556
+		}
+	}
+	return float64(total) * size / 1000
+}
+
+// PDFTruncate metni genişliğe sığacak şekilde sonunu "…" ile kısaltır
+func PDFTruncate(text string, width, size float64, bold bool) string {
+	if PDFTextWidth(text, size, bold) <= width {
+		return text
+	}
+	runes := []rune(text)
+	for len(runes) > 0 {
+		runes = runes[:len(runes)-1]
+		candidate := strings.TrimSpace(string(runes)) + "…"
+		if PDFTextWidth(candidate, size, bold) <= width {
+			return candidate
+		}
+	}
+	return ""
+}
+
+// pdfEncode metni yazı tipi kodlamasına (Windows-1254) çevirir; karşılığı olmayan karakterler "?" olur
+func pdfEncode(text string) []byte {
+	out := make([]byte, 0, len(text))
+	for _, r := range text {
+		switch b, ok := pdfSpecialBytes[r]; {
+		case ok:
+			out = append(out, b)
+		case r == '\n' || r == '\r' || r == '\t':
+			out = append(out, ' ')
+		case r < 0x80 || (r >= 0xA0 && r <= 0xFF && !pdfLatin1Replaced[r]):
+			out = append(out, byte(r))
+		default:
+			out = append(out, '?')
+		}
+	}
+	return out
+}
+
+// pdfEscape PDF metin dizesindeki özel karakterleri kaçırır
+func pdfEscape(text []byte) string {
+	var b strings.Builder
+	for _, c := range text {
+		if c == '(' || c == ')' || c == '\\' {
+			b.WriteByte('\\')
+		}
+		b.WriteByte(c)
+	}
+	return b.String()
+}
+
+// pdfNumber sayıyı gereksiz ondalıklar olmadan yazar
+func pdfNumber(value float64) string {
+	return strconv.FormatFloat(value, 'f', -1, 64)
+}
+
+// pdfDeflate veriyi FlateDecode ile sıkıştırır
+func pdfDeflate(data []byte) ([]byte, error) {
+	var buf bytes.Buffer
+	writer := zlib.NewWriter(&buf)
+	if _, err := writer.Write(data); err != nil {
+		return nil, err
+	}
+	if err := writer.Close(); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// pdfDecodeImage görseli PDF'e gömülecek biçime çevirir
+func pdfDecodeImage(data []byte) (pdfImage, error) {
+	config, format, err := image.DecodeConfig(bytes.NewReader(data))
+	if err != nil {
+		return pdfImage{}, err
+	}
+
+	if format == "jpeg" {
+		switch config.ColorModel {
+		case color.YCbCrModel:
+			return pdfImage{width: config.Width, height: config.Height, colorSpace: "DeviceRGB", filter: "DCTDecode", data: data}, nil
+		case color.GrayModel:
+			return pdfImage{width: config.Width, height: config.Height, colorSpace: "DeviceGray", filter: "DCTDecode", data: data}, nil
+		}
+	}
+
+	img, _, err := image.Decode(bytes.NewReader(data))
+	if err != nil {
+		return pdfImage{}, err
+	}
+
+	bounds := img.Bounds()
+	pixels := make([]byte, 0, bounds.Dx()*bounds.Dy()*3)
+	for y := bounds.Min.Y; y < bounds.Max.Y; y++ {
+		for x := bounds.Min.X; x < bounds.Max.X; x++ {
+			r, g, b, a := img.At(x, y).RGBA()
+			// Saydam pikseller beyaz zemin üzerine yerleştirilir
+			white := 0xffff - a
+			pixels = append(pixels, byte((r+white)>>8), byte((g+white)>>8), byte((b+white)>>8))
+		}
+	}
+
+	compressed, err := pdfDeflate(pixels)
+	if err != nil {
+		return pdfImage{}, err
+	}
+	return pdfImage{width: bounds.Dx(), height: bounds.Dy(), colorSpace: "DeviceRGB", filter: "FlateDecode", data: compressed}, nil
+}
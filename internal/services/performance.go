@@ -0,0 +1,341 @@
+package services
+
+import (
+	"errors"
+	"math"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"agri-management-api/internal/models"
+)
+
+// performanceRetention bellekte tutulan istek ölçümlerinin süresi; en uzun seçilebilir pencere
+const performanceRetention = 24 * time.Hour
+
+// defaultPerformanceWindow pencere verilmezse kullanılan süre
+const defaultPerformanceWindow = "1h"
+
+// Varsayılan SLO hedefleri; SLO_LATENCY_P95_MS, SLO_ERROR_RATE ve SLO_MIN_REQUESTS ile değiştirilir
+const (
+	defaultSLOLatencyP95Ms = 500
+	defaultSLOErrorRate    = 1.0
+	defaultSLOMinRequests  = 20
+)
+
+// ErrInvalidPerformanceWindow desteklenmeyen pencere
+var ErrInvalidPerformanceWindow = errors.New("invalid performance window")
+
+// performanceWindows seçilebilir pencereler
+var performanceWindows = map[string]time.Duration{
+	"5m":  5 * time.Minute,
+	"15m": 15 * time.Minute,
+	"1h":  time.Hour,
+	"6h":  6 * time.Hour,
+	"24h": 24 * time.Hour,
+}
+
+// latencyBuckets gecikme histogramının üst sınırları (ms); %20 aralıklarla 1 ms'den 60 saniyeye kadar
+var latencyBuckets = func() []float64 {
+	var bounds []float64
+	for bound := 1.0; bound < 60000; bound *= 1.2 {
+		bounds = append(bounds, math.Round(bound*100)/100)
+	}
+	return append(bounds, 60000)
+}()
+
+// latencyHistogram bir rotanın bir dakikadaki istek sayısı, hata sayısı ve gecikme dağılımı
+type latencyHistogram struct {
+	requests int64
+	errors   int64
+	totalMs  float64
+	maxMs    float64
+	buckets  []int64 // son eleman 60 saniyeyi aşan istekler
+}
+
+func newLatencyHistogram() *latencyHistogram {
+	return &latencyHistogram{buckets: make([]int64, len(latencyBuckets)+1)}
+}
+
+func (h *latencyHistogram) observe(ms float64, failed bool) {
+	h.requests++
+	if failed {
+		h.errors++
+	}
+	h.totalMs += ms
+	if ms > h.maxMs {
+		h.maxMs = ms
+	}
+	h.buckets[sort.SearchFloat64s(latencyBuckets, ms)]++
+}
+
+func (h *latencyHistogram) merge(other *latencyHistogram) {
+	h.requests += other.requests
+	h.errors += other.errors
+	h.totalMs += other.totalMs
+	if other.maxMs > h.maxMs {
+		h.maxMs = other.maxMs
+	}
+	for i, count := range other.buckets {
+		h.buckets[i] += count
+	}
+}
+
+// percentile yüzdelik değeri histogram kovası içinde doğrusal olarak tahmin eder
+func (h *latencyHistogram) percentile(p float64) float64 {
+	if h.requests == 0 {
+		return 0
+	}
+	rank := p / 100 * float64(h.requests)
+	var seen int64
+	for i, count := range h.buckets {
+		if count == 0 {
+			continue
+		}
+		if float64(seen+count) >= rank {
+			lower := 0.0
+			if i > 0 {
+				lower = latencyBuckets[i-1]
+			}
+			upper := h.maxMs
+			if i < len(latencyBuckets) && latencyBuckets[i] < upper {
+				upper = latencyBuckets[i]
+			}
+			value := lower + (upper-lower)*(rank-float64(seen))/float64(count)
+			return math.Round(math.Min(value, h.maxMs)*100) / 100
+		}
+		seen += count
+	}
+	return h.maxMs
+}
+
+// requestMetrics rotaların dakikalık gecikme histogramları; sunucu yeniden başlatılınca sıfırlanır
+var requestMetrics = struct {
+	mu     sync.Mutex
+	routes map[string]map[int64]*latencyHistogram // "METHOD /rota" -> dakika -> histogram
+	pruned int64
+}{routes: map[string]map[int64]*latencyHistogram{}}
+
+// RecordRequestMetric tamamlanan isteğin süresini rotanın dakikalık histogramına ekler; 5xx yanıtlar hata sayılır
+func RecordRequestMetric(method, route string, status int, latency time.Duration, at time.Time) {
+	minute := at.Unix() / 60
+	ms := float64(latency.Microseconds()) / 1000
+	key := method + " " + route
+
+	requestMetrics.mu.Lock()
+	defer requestMetrics.mu.Unlock()
+
+	minutes := requestMetrics.routes[key]
+	if minutes == nil {
+		minutes = map[int64]*latencyHistogram{}
+		requestMetrics.routes[key] = minutes
+	}
+	histogram := minutes[minute]
+	if histogram == nil {
+		histogram = newLatencyHistogram()
+		minutes[minute] = histogram
+	}
+	histogram.observe(ms, status >= 500)
+
+	if minute != requestMetrics.pruned {
+		requestMetrics.pruned = minute
+		prunePerformanceMetrics(minute - int64(performanceRetention/time.Minute))
+	}
+}
+
+// prunePerformanceMetrics saklama süresinden eski dakikaları siler; kilit tutulurken çağrılmalıdır
+func prunePerformanceMetrics(oldest int64) {
+	for key, minutes := range requestMetrics.routes {
+		for minute := range minutes {
+			if minute < oldest {
+				delete(minutes, minute)
+			}
+		}
+		if len(minutes) == 0 {
+			delete(requestMetrics.routes, key)
+		}
+	}
+}
+
+// sloTarget bir rota (veya rota öneki) için gecikme ve hata oranı hedefi
+type sloTarget struct {
+	pattern   string
+	latencyMs float64
+	errorRate float64
+}
+
+// sloDefaults SLO_LATENCY_P95_MS, SLO_ERROR_RATE ve SLO_MIN_REQUESTS ortam değişkenlerini okur
+func sloDefaults() (sloTarget, int64) {
+	target := sloTarget{latencyMs: defaultSLOLatencyP95Ms, errorRate: defaultSLOErrorRate}
+	if value, err := strconv.ParseFloat(os.Getenv("SLO_LATENCY_P95_MS"), 64); err == nil && value > 0 {
+		target.latencyMs = value
+	}
+	if value, err := strconv.ParseFloat(os.Getenv("SLO_ERROR_RATE"), 64); err == nil && value >= 0 {
+		target.errorRate = value
+	}
+	minRequests := int64(defaultSLOMinRequests)
+	if value, err := strconv.ParseInt(os.Getenv("SLO_MIN_REQUESTS"), 10, 64); err == nil && value > 0 {
+		minRequests = value
+	}
+	return target, minRequests
+}
+
+// sloOverrides SLO_ROUTE_TARGETS değişkenindeki rota hedeflerini okur. Biçim virgülle ayrılmış
+// "GET /api/v1/reports/*=2000:5" girdileridir (p95 ms ve isteğe bağlı hata oranı yüzdesi); * ile biten rotalar önektir
+func sloOverrides(defaults sloTarget) []sloTarget {
+	var overrides []sloTarget
+	for _, entry := range strings.Split(os.Getenv("SLO_ROUTE_TARGETS"), ",") {
+		pattern, values, ok := strings.Cut(strings.TrimSpace(entry), "=")
+		if !ok || strings.TrimSpace(pattern) == "" {
+			continue
+		}
+		target := defaults
+		target.pattern = strings.Join(strings.Fields(pattern), " ")
+		latency, errorRate, hasErrorRate := strings.Cut(values, ":")
+		if value, err := strconv.ParseFloat(strings.TrimSpace(latency), 64); err == nil && value > 0 {
+			target.latencyMs = value
+		}
+		if hasErrorRate {
+			if value, err := strconv.ParseFloat(strings.TrimSpace(errorRate), 64); err == nil && value >= 0 {
+				target.errorRate = value
+			}
+		}
+		overrides = append(overrides, target)
+	}
+	// En uzun (en özel) desen önce eşleşir
+	sort.SliceStable(overrides, func(i, j int) bool {
+		return len(overrides[i].pattern) > len(overrides[j].pattern)
+	})
+	return overrides
+}
+
+// matchSLO rotanın hedefini döner; eşleşen tanım yoksa varsayılan hedef kullanılır
+func matchSLO(key string, defaults sloTarget, overrides []sloTarget) sloTarget {
+	for _, target := range overrides {
+		if prefix, ok := strings.CutSuffix(target.pattern, "*"); ok {
+			if strings.HasPrefix(key, prefix) {
+				return target
+			}
+		} else if key == target.pattern {
+			return target
+		}
+	}
+	return defaults
+}
+
+// PerformanceReport son pencere içindeki istekleri rota bazında özetler ve SLO'yu aşan rotaları işaretler.
+// Rotalar önce SLO ihlaline, sonra istek sayısına göre sıralanır
+func PerformanceReport(window string, now time.Time) (*models.PerformanceReport, error) {
+	if window == "" {
+		window = defaultPerformanceWindow
+	}
+	duration, ok := performanceWindows[window]
+	if !ok {
+		return nil, ErrInvalidPerformanceWindow
+	}
+
+	defaults, minRequests := sloDefaults()
+	overrides := sloOverrides(defaults)
+
+	last := now.Unix() / 60
+	first := last - int64(duration/time.Minute) + 1
+
+	totals := newLatencyHistogram()
+	histograms := map[string]*latencyHistogram{}
+
+	requestMetrics.mu.Lock()
+	for key, minutes := range requestMetrics.routes {
+		for minute, histogram := range minutes {
+			if minute < first || minute > last {
+				continue
+			}
+			merged := histograms[key]
+			if merged == nil {
+				merged = newLatencyHistogram()
+				histograms[key] = merged
+			}
+			merged.merge(histogram)
+			totals.merge(histogram)
+		}
+	}
+	requestMetrics.mu.Unlock()
+
+	report := &models.PerformanceReport{
+		Window: window,
+		From:   time.Unix(first*60, 0).UTC(),
+		To:     now.UTC(),
+		SLO: models.PerformanceSLO{
+			LatencyP95Ms: defaults.latencyMs,
+			ErrorRate:    defaults.errorRate,
+			MinRequests:  minRequests,
+		},
+		Summary: performanceStats(totals),
+		Routes:  make([]models.RoutePerformance, 0, len(histograms)),
+	}
+
+	for key, histogram := range histograms {
+		method, route, _ := strings.Cut(key, " ")
+		target := matchSLO(key, defaults, overrides)
+		stats := performanceStats(histogram)
+
+		entry := models.RoutePerformance{
+			Method:           method,
+			Route:            route,
+			PerformanceStats: stats,
+			SLO: models.PerformanceSLO{
+				LatencyP95Ms: target.latencyMs,
+				ErrorRate:    target.errorRate,
+				MinRequests:  minRequests,
+			},
+			Breaches: []string{},
+		}
+		if stats.Requests >= minRequests {
+			if stats.P95Ms > target.latencyMs {
+				entry.Breaches = append(entry.Breaches, models.SLOBreachLatency)
+			}
+			if stats.ErrorRate > target.errorRate {
+				entry.Breaches = append(entry.Breaches, models.SLOBreachErrorRate)
+			}
+		}
+		entry.Breaching = len(entry.Breaches) > 0
+		if entry.Breaching {
+			report.BreachingRoutes++
+		}
+		report.Routes = append(report.Routes, entry)
+	}
+
+	sort.Slice(report.Routes, func(i, j int) bool {
+		a, b := report.Routes[i], report.Routes[j]
+		if a.Breaching != b.Breaching {
+			return a.Breaching
+		}
+		if a.Requests != b.Requests {
+			return a.Requests > b.Requests
+		}
+		if a.Route != b.Route {
+			return a.Route < b.Route
+		}
+		return a.Method < b.Method
+	})
+	return report, nil
+}
+
+// performanceStats histogramdan yüzdelikleri ve hata oranını hesaplar
+func performanceStats(h *latencyHistogram) models.PerformanceStats {
+	stats := models.PerformanceStats{
+		Requests: h.requests,
+		Errors:   h.errors,
+		P50Ms:    h.percentile(50),
+		P95Ms:    h.percentile(95),
+		P99Ms:    h.percentile(99),
+		MaxMs:    h.maxMs,
+	}
+	if h.requests > 0 {
+		stats.AvgMs = math.Round(h.totalMs/float64(h.requests)*100) / 100
+		stats.ErrorRate = math.Round(float64(h.errors)/float64(h.requests)*10000) / 100
+	}
+	return stats
+}
@@ -0,0 +1,198 @@
+package services
+
+import (
+	"database/sql"
+	"time"
+
+	"agri-management-api/internal/models"
+	"agri-management-api/internal/utils"
+)
+
+// ProfitabilityService hayvan bazında maliyet esası ve karlılığı hesaplar
+type ProfitabilityService struct {
+	db          *sql.DB
+	allocations *CostAllocationService
+}
+
+// NewProfitabilityService yeni profitability service oluşturur
+func NewProfitabilityService(db *sql.DB) *ProfitabilityService {
+	return &ProfitabilityService{db: db, allocations: NewCostAllocationService(db)}
+}
+
+// profitabilitySelect hayvanın edinme, satış ve kesim bilgilerini seçen sorgu
+const profitabilitySelect = `
+	SELECT l.id, l.tag_number, l.type, COALESCE(l.breed, ''), l.birth_date, l.created_at,
+	       COALESCE(l.acquisition_type, 'born'), l.acquisition_date, COALESCE(l.purchase_price, 0),
+	       COALESCE(l.purchase_currency, 'TRY'), COALESCE(l.seller, ''), COALESCE(l.purchase_transaction_id, ''),
+	       l.sale_date, l.sale_price, COALESCE(l.buyer, ''),
+	       s.slaughter_date, s.price, COALESCE(s.buyer, '')
+	FROM livestock l
+	LEFT JOIN slaughter_records s ON s.livestock_id = l.id
+`
+
+// Animal hayvanın maliyet esasını ve satıldıysa karlılığını döner
+func (s *ProfitabilityService) Animal(userID, animalID string) (models.AnimalProfitability, error) {
+	item, err := s.scan(s.db.QueryRow(profitabilitySelect+" WHERE l.id = ? AND l.user_id = ?", animalID, userID))
+	if err != nil {
+		return item, err
+	}
+	return item, s.fillCosts(userID, &item)
+}
+
+// Sold satış veya kesimle çıkan hayvanların karlılığını tarih aralığına göre döner
+func (s *ProfitabilityService) Sold(userID string, startDate, endDate *time.Time) (models.ProfitabilitySummary, error) {
+	summary := models.ProfitabilitySummary{Animals: []models.AnimalProfitability{}}
+
+	rows, err := s.db.Query(profitabilitySelect+`
+		WHERE l.user_id = ? AND (l.sale_date IS NOT NULL OR s.id IS NOT NULL)
+		ORDER BY COALESCE(l.sale_date, s.slaughter_date) DESC
+	`, userID)
+	if err != nil {
+		return summary, err
+	}
+
+	var items []models.AnimalProfitability
+	for rows.Next() {
+		item, err := s.scan(rows)
+		if err != nil {
+			continue
+		}
+		if startDate != nil && item.Exit.Date.Before(*startDate) {
+			continue
+		}
+		if endDate != nil && !item.Exit.Date.Before(endDate.AddDate(0, 0, 1)) {
+			continue
+		}
+		items = append(items, item)
+	}
+	rows.Close()
+
+	for _, item := range items {
+		if err := s.fillCosts(userID, &item); err != nil {
+			return summary, err
+		}
+
+		summary.AnimalCount++
+		summary.TotalCost += item.CostBasis.Total
+		summary.TotalRevenue += item.Exit.Revenue
+		summary.Animals = append(summary.Animals, item)
+	}
+
+	summary.TotalProfit = round2(summary.TotalRevenue - summary.TotalCost)
+	summary.TotalCost = round2(summary.TotalCost)
+	summary.TotalRevenue = round2(summary.TotalRevenue)
+	if summary.AnimalCount > 0 {
+		summary.AvgProfit = round2(summary.TotalProfit / float64(summary.AnimalCount))
+	}
+
+	return summary, nil
+}
+
+// scan hayvan satırını okur; satış kaydı yoksa kesim kaydı çıkış olarak kullanılır
+func (s *ProfitabilityService) scan(row interface{ Scan(...interface{}) error }) (models.AnimalProfitability, error) {
+	var item models.AnimalProfitability
+	var birthDate, acquisitionDate, saleDate, slaughterDate sql.NullTime
+	var salePrice, slaughterPrice sql.NullFloat64
+	var createdAt time.Time
+	var saleBuyer, slaughterBuyer string
+
+	err := row.Scan(
+		&item.LivestockID, &item.TagNumber, &item.Type, &item.Breed, &birthDate, &createdAt,
+		&item.Acquisition.Type, &acquisitionDate, &item.Acquisition.Price,
+		&item.Acquisition.Currency, &item.Acquisition.Seller, &item.Acquisition.TransactionID,
+		&saleDate, &salePrice, &saleBuyer,
+		&slaughterDate, &slaughterPrice, &slaughterBuyer,
+	)
+	if err != nil {
+		return item, err
+	}
+
+	// Edinme tarihi girilmemişse çiftlikte doğanlarda doğum tarihi, diğerlerinde kayıt tarihi kullanılır
+	item.Acquisition.Date = utils.NullTimeToPtr(acquisitionDate)
+	if item.Acquisition.Date == nil {
+		if item.Acquisition.Type == models.AcquisitionBorn && birthDate.Valid {
+			item.Acquisition.Date = &birthDate.Time
+		} else {
+			item.Acquisition.Date = &createdAt
+		}
+	}
+
+	switch {
+	case saleDate.Valid:
+		item.Exit = &models.AnimalExit{Type: "sale", Date: &saleDate.Time, Revenue: salePrice.Float64, Buyer: saleBuyer}
+	case slaughterDate.Valid:
+		item.Exit = &models.AnimalExit{Type: "slaughter", Date: &slaughterDate.Time, Revenue: slaughterPrice.Float64, Buyer: slaughterBuyer}
+	}
+	item.Sold = item.Exit != nil
+
+	end := time.Now()
+	if item.Exit != nil {
+		end = *item.Exit.Date
+	}
+	days := int(end.Sub(*item.Acquisition.Date).Hours() / 24)
+	if days >= 0 {
+		item.DaysOnFarm = &days
+	}
+
+	return item, nil
+}
+
+// fillCosts maliyet esasını hesaplar ve hayvan satıldıysa kar ile marjı doldurur
+func (s *ProfitabilityService) fillCosts(userID string, item *models.AnimalProfitability) error {
+	item.CostBasis.Purchase = item.Acquisition.Price
+
+	rows, err := s.db.Query(`
+		SELECT type, COALESCE(SUM(amount), 0) FROM livestock_costs WHERE livestock_id = ? AND user_id = ? GROUP BY type
+	`, item.LivestockID, userID)
+	if err != nil {
+		return err
+	}
+	for rows.Next() {
+		var costType string
+		var amount float64
+		if err := rows.Scan(&costType, &amount); err != nil {
+			continue
+		}
+		switch costType {
+		case "feed":
+			item.CostBasis.Feed += amount
+		case "health":
+			item.CostBasis.Health += amount
+		default:
+			item.CostBasis.Other += amount
+		}
+	}
+	rows.Close()
+
+	var healthRecordCost float64
+	if err := s.db.QueryRow(`
+		SELECT COALESCE(SUM(cost), 0) FROM health_records WHERE livestock_id = ? AND `+farmLivestockCondition+`
+	`, item.LivestockID, userID).Scan(&healthRecordCost); err != nil {
+		return err
+	}
+	item.CostBasis.Health += healthRecordCost
+
+	overhead, err := s.allocations.AllocatedTo(userID, models.AllocationTargetLivestock, item.LivestockID, "0001-01-01", "9999-12-31")
+	if err != nil {
+		return err
+	}
+	item.CostBasis.Overhead = overhead
+
+	item.CostBasis.Purchase = round2(item.CostBasis.Purchase)
+	item.CostBasis.Feed = round2(item.CostBasis.Feed)
+	item.CostBasis.Health = round2(item.CostBasis.Health)
+	item.CostBasis.Other = round2(item.CostBasis.Other)
+	item.CostBasis.Total = round2(item.CostBasis.Purchase + item.CostBasis.Feed + item.CostBasis.Health + item.CostBasis.Other +
+		item.CostBasis.Overhead)
+
+	if item.Exit != nil {
+		profit := round2(item.Exit.Revenue - item.CostBasis.Total)
+		item.Profit = &profit
+		if item.Exit.Revenue > 0 {
+			margin := round2(profit / item.Exit.Revenue * 100)
+			item.MarginPercent = &margin
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,382 @@
+package services
+
+import (
+	"database/sql"
+	"errors"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"agri-management-api/internal/models"
+	"agri-management-api/internal/utils"
+)
+
+var (
+	// ErrPublicProfileNotFound profil yok ya da yayında değil
+	ErrPublicProfileNotFound = errors.New("herkese açık profil bulunamadı")
+	// ErrPublicProfileSlug profil adresi geçersiz
+	ErrPublicProfileSlug = errors.New("profil adresi 3-60 karakter olmalı ve yalnızca küçük harf, rakam ve tire içermelidir")
+	// ErrPublicProfileSlugTaken profil adresi başka bir çiftliğin profilinde kullanılıyor
+	ErrPublicProfileSlugTaken = errors.New("profil adresi başka bir çiftlik tarafından kullanılıyor")
+	// ErrPublicProfilePhoto profile eklenen fotoğraf çiftliğe ait değil
+	ErrPublicProfilePhoto = errors.New("fotoğraf bulunamadı")
+)
+
+// publicProfilePhotoPath yayınlanan fotoğrafların oturum açmadan indirildiği adres
+const publicProfilePhotoPath = "/api/v1/public/farms/"
+
+var (
+	// publicSlugPattern profil adresi: tireyle ayrılmış küçük harf ve rakam grupları
+	publicSlugPattern = regexp.MustCompile(`^[a-z0-9]+(?:-[a-z0-9]+)*$`)
+	// publicSlugSeparators profil adresinde tireye çevrilen karakterler
+	publicSlugSeparators = regexp.MustCompile(`[^a-z0-9]+`)
+	// publicSlugReplacer Türkçe karakterleri profil adresi için ASCII karşılıklarına çevirir
+	publicSlugReplacer = strings.NewReplacer(
+		"ç", "c", "ğ", "g", "ı", "i", "ö", "o", "ş", "s", "ü", "u",
+		"Ç", "c", "Ğ", "g", "İ", "i", "Ö", "o", "Ş", "s", "Ü", "u",
+	)
+)
+
+// publicProfileSelect profil sütunları; çiftlik adı çiftlik kaydından okunur
+const publicProfileSelect = `
+	SELECT p.user_id, p.slug, p.enabled, COALESCE(f.name, ''), COALESCE(p.description, ''), COALESCE(p.products, '[]'),
+	       COALESCE(p.location_area, ''), COALESCE(p.photo_ids, '[]'), p.published_at, p.updated_at
+	FROM farm_public_profiles p
+	JOIN farms f ON f.id = p.user_id`
+
+// PublicProfileService çiftliklerin herkese açık profillerini yönetir. Profil çiftlik sahibinin açıkça
+// yayınladığı alanlarla (ad, ürünler, konum bölgesi, fotoğraflar) sınırlıdır; kayıtlara erişim vermez
+type PublicProfileService struct {
+	db *sql.DB
+}
+
+// NewPublicProfileService yeni herkese açık profil servisi oluşturur
+func NewPublicProfileService(db *sql.DB) *PublicProfileService {
+	return &PublicProfileService{db: db}
+}
+
+// Get çiftliğin profilini yayında olup olmadığına bakmadan döner
+func (s *PublicProfileService) Get(farmID string) (*models.FarmPublicProfile, error) {
+	profiles, err := s.query(publicProfileSelect+" WHERE p.user_id = ?", farmID)
+	if err != nil {
+		return nil, err
+	}
+	if len(profiles) == 0 {
+		return nil, ErrPublicProfileNotFound
+	}
+	return &profiles[0], nil
+}
+
+// Draft profili olmayan çiftlik için yayınlanmamış taslak profil döner; adres çiftlik adından, konum
+// bölgesi çiftliğin konumundan önerilir
+func (s *PublicProfileService) Draft(farmID string) (*models.FarmPublicProfile, error) {
+	name, location, err := s.farm(farmID)
+	if err != nil {
+		return nil, err
+	}
+	slug, err := s.availableSlug(farmID, name)
+	if err != nil {
+		return nil, err
+	}
+	return &models.FarmPublicProfile{
+		Slug:         slug,
+		FarmName:     name,
+		Products:     []string{},
+		LocationArea: location,
+		Photos:       []models.FarmPublicPhoto{},
+	}, nil
+}
+
+// Save çiftliğin profilini oluşturur veya günceller. Profil ilk kez yayına alındığında publishedAt
+// atanır; yayından kaldırıldığında silinir
+func (s *PublicProfileService) Save(farmID string, req models.FarmPublicProfileRequest) (*models.FarmPublicProfile, error) {
+	name, location, err := s.farm(farmID)
+	if err != nil {
+		return nil, err
+	}
+
+	slug := strings.ToLower(strings.TrimSpace(req.Slug))
+	if slug == "" {
+		if existing, err := s.Get(farmID); err == nil {
+			slug = existing.Slug
+		} else if !errors.Is(err, ErrPublicProfileNotFound) {
+			return nil, err
+		} else if slug, err = s.availableSlug(farmID, name); err != nil {
+			return nil, err
+		}
+	} else {
+		if len(slug) < 3 || len(slug) > 60 || !publicSlugPattern.MatchString(slug) {
+			return nil, ErrPublicProfileSlug
+		}
+		taken, err := s.slugTaken(farmID, slug)
+		if err != nil {
+			return nil, err
+		}
+		if taken {
+			return nil, ErrPublicProfileSlugTaken
+		}
+	}
+
+	products := []string{}
+	seen := map[string]bool{}
+	for _, product := range req.Products {
+		product = strings.TrimSpace(product)
+		if product == "" || seen[strings.ToLower(product)] {
+			continue
+		}
+		seen[strings.ToLower(product)] = true
+		products = append(products, product)
+	}
+
+	photoIDs := []string{}
+	seen = map[string]bool{}
+	for _, id := range req.PhotoIDs {
+		if seen[id] {
+			continue
+		}
+		var exists bool
+		err := s.db.QueryRow("SELECT 1 FROM media_attachments WHERE id = ? AND user_id = ? AND kind = 'image'", id, farmID).Scan(&exists)
+		if err == sql.ErrNoRows {
+			return nil, ErrPublicProfilePhoto
+		}
+		if err != nil {
+			return nil, err
+		}
+		seen[id] = true
+		photoIDs = append(photoIDs, id)
+	}
+
+	locationArea := strings.TrimSpace(req.LocationArea)
+	if locationArea == "" {
+		locationArea = location
+	}
+
+	productsJSON, err := utils.ToJSON(products)
+	if err != nil {
+		return nil, err
+	}
+	photosJSON, err := utils.ToJSON(photoIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	if _, err := s.db.Exec(`
+		INSERT INTO farm_public_profiles (user_id, slug, enabled, description, products, location_area, photo_ids,
+		                                  published_at, created_at, updated_at)
+		VALUES (?, ?, ?, ?, ?, ?, ?, CASE WHEN ? THEN CURRENT_TIMESTAMP END, CURRENT_TIMESTAMP, CURRENT_TIMESTAMP)
+		ON CONFLICT(user_id) DO UPDATE SET
+			slug = excluded.slug, enabled = excluded.enabled, description = excluded.description,
+			products = excluded.products, location_area = excluded.location_area, photo_ids = excluded.photo_ids,
+			published_at = CASE WHEN excluded.enabled THEN COALESCE(farm_public_profiles.published_at, CURRENT_TIMESTAMP) END,
+			updated_at = CURRENT_TIMESTAMP
+	`, farmID, slug, req.Enabled, strings.TrimSpace(req.Description), productsJSON, locationArea, photosJSON, req.Enabled); err != nil {
+		if strings.Contains(err.Error(), "UNIQUE constraint failed: farm_public_profiles.slug") {
+			return nil, ErrPublicProfileSlugTaken
+		}
+		return nil, err
+	}
+	return s.Get(farmID)
+}
+
+// Delete çiftliğin profilini siler; profil adresi başka çiftliklerin kullanımına açılır
+func (s *PublicProfileService) Delete(farmID string) error {
+	result, err := s.db.Exec("DELETE FROM farm_public_profiles WHERE user_id = ?", farmID)
+	if err != nil {
+		return err
+	}
+	if rows, _ := result.RowsAffected(); rows == 0 {
+		return ErrPublicProfileNotFound
+	}
+	return nil
+}
+
+// Published yayındaki profili adresinden döner
+func (s *PublicProfileService) Published(slug string) (*models.FarmPublicProfile, error) {
+	profiles, err := s.query(publicProfileSelect+" WHERE p.slug = ? AND p.enabled", strings.ToLower(slug))
+	if err != nil {
+		return nil, err
+	}
+	if len(profiles) == 0 {
+		return nil, ErrPublicProfileNotFound
+	}
+	return &profiles[0], nil
+}
+
+// Directory yayındaki profilleri en son yayınlanandan başlayarak sayfalı döner; product ürün adında,
+// location konum bölgesinde büyük/küçük harf ayırmadan aranır
+func (s *PublicProfileService) Directory(product, location string, page, limit int) ([]models.FarmPublicProfile, int, error) {
+	where := " WHERE p.enabled"
+	args := []interface{}{}
+	if product = strings.TrimSpace(product); product != "" {
+		where += " AND EXISTS (SELECT 1 FROM json_each(p.products) WHERE lower(json_each.value) LIKE ?)"
+		args = append(args, "%"+strings.ToLower(product)+"%")
+	}
+	if location = strings.TrimSpace(location); location != "" {
+		where += " AND lower(p.location_area) LIKE ?"
+		args = append(args, "%"+strings.ToLower(location)+"%")
+	}
+
+	var total int
+	if err := s.db.QueryRow("SELECT COUNT(*) FROM farm_public_profiles p"+where, args...).Scan(&total); err != nil {
+		return nil, 0, err
+	}
+
+	profiles, err := s.query(publicProfileSelect+where+" ORDER BY p.published_at DESC, p.slug LIMIT ? OFFSET ?",
+		append(args, limit, (page-1)*limit)...)
+	if err != nil {
+		return nil, 0, err
+	}
+	return profiles, total, nil
+}
+
+// PublishedPhoto yayındaki profilde gösterilen fotoğrafın medya kaydını döner; profile eklenmemiş
+// fotoğraflar bulunamadı sayılır
+func (s *PublicProfileService) PublishedPhoto(slug, photoID string) (*models.MediaAttachment, string, error) {
+	var farmID, raw string
+	err := s.db.QueryRow(`
+		SELECT user_id, COALESCE(photo_ids, '[]') FROM farm_public_profiles WHERE slug = ? AND enabled
+	`, strings.ToLower(slug)).Scan(&farmID, &raw)
+	if err == sql.ErrNoRows {
+		return nil, "", ErrPublicProfileNotFound
+	}
+	if err != nil {
+		return nil, "", err
+	}
+
+	var photoIDs []string
+	utils.FromJSON(raw, &photoIDs)
+	listed := false
+	for _, id := range photoIDs {
+		listed = listed || id == photoID
+	}
+	if !listed {
+		return nil, "", ErrPublicProfilePhoto
+	}
+
+	var media models.MediaAttachment
+	var storageKey string
+	err = s.db.QueryRow(`
+		SELECT id, filename, COALESCE(content_type, 'application/octet-stream'), size, storage_key
+		FROM media_attachments WHERE id = ? AND user_id = ? AND kind = 'image'
+	`, photoID, farmID).Scan(&media.ID, &media.Filename, &media.ContentType, &media.Size, &storageKey)
+	if err == sql.ErrNoRows {
+		return nil, "", ErrPublicProfilePhoto
+	}
+	if err != nil {
+		return nil, "", err
+	}
+	return &media, storageKey, nil
+}
+
+// farm çiftliğin adını ve konumunu döner
+func (s *PublicProfileService) farm(farmID string) (string, string, error) {
+	var name, location string
+	err := s.db.QueryRow("SELECT name, COALESCE(location, '') FROM farms WHERE id = ?", farmID).Scan(&name, &location)
+	return name, location, err
+}
+
+// availableSlug çiftlik adından boşta olan bir profil adresi üretir; adres alınmışsa sonuna sayı eklenir
+func (s *PublicProfileService) availableSlug(farmID, name string) (string, error) {
+	base := strings.Trim(publicSlugSeparators.ReplaceAllString(strings.ToLower(publicSlugReplacer.Replace(name)), "-"), "-")
+	if len(base) > 50 {
+		base = strings.TrimRight(base[:50], "-")
+	}
+	if len(base) < 3 {
+		base = strings.Trim("ciftlik-"+base, "-")
+	}
+
+	slug := base
+	for i := 2; ; i++ {
+		taken, err := s.slugTaken(farmID, slug)
+		if err != nil || !taken {
+			return slug, err
+		}
+		slug = base + "-" + strconv.Itoa(i)
+	}
+}
+
+// slugTaken profil adresinin başka bir çiftliğin profilinde kullanılıp kullanılmadığını döner
+func (s *PublicProfileService) slugTaken(farmID, slug string) (bool, error) {
+	var exists bool
+	err := s.db.QueryRow("SELECT 1 FROM farm_public_profiles WHERE slug = ? AND user_id <> ?", slug, farmID).Scan(&exists)
+	if err == sql.ErrNoRows {
+		return false, nil
+	}
+	return exists, err
+}
+
+// query profilleri okur ve yalnızca hâlâ çiftliğe ait olan fotoğrafları profilin sırasıyla ekler
+func (s *PublicProfileService) query(query string, args ...interface{}) ([]models.FarmPublicProfile, error) {
+	rows, err := s.db.Query(query, args...)
+	if err != nil {
+		return nil, err
+	}
+
+	type profileRow struct {
+		farmID   string
+		photoIDs []string
+	}
+	profiles := []models.FarmPublicProfile{}
+	var profileRows []profileRow
+	for rows.Next() {
+		var profile models.FarmPublicProfile
+		var farmID, products, photos string
+		var publishedAt, updatedAt sql.NullTime
+		if err := rows.Scan(&farmID, &profile.Slug, &profile.Enabled, &profile.FarmName, &profile.Description, &products,
+			&profile.LocationArea, &photos, &publishedAt, &updatedAt); err != nil {
+			rows.Close()
+			return nil, err
+		}
+		profile.Products = []string{}
+		utils.FromJSON(products, &profile.Products)
+		profile.PublishedAt = utils.NullTimeToPtr(publishedAt)
+		profile.UpdatedAt = utils.NullTimeToPtr(updatedAt)
+
+		row := profileRow{farmID: farmID}
+		utils.FromJSON(photos, &row.photoIDs)
+		profiles = append(profiles, profile)
+		profileRows = append(profileRows, row)
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	for i, row := range profileRows {
+		profiles[i].Photos = []models.FarmPublicPhoto{}
+		if len(row.photoIDs) == 0 {
+			continue
+		}
+		placeholders := strings.TrimSuffix(strings.Repeat("?,", len(row.photoIDs)), ",")
+		args := []interface{}{row.farmID}
+		for _, id := range row.photoIDs {
+			args = append(args, id)
+		}
+		photoRows, err := s.db.Query(`
+			SELECT id FROM media_attachments WHERE user_id = ? AND kind = 'image' AND id IN (`+placeholders+`)
+		`, args...)
+		if err != nil {
+			return nil, err
+		}
+		existing := map[string]bool{}
+		for photoRows.Next() {
+			var id string
+			if err := photoRows.Scan(&id); err != nil {
+				photoRows.Close()
+				return nil, err
+			}
+			existing[id] = true
+		}
+		photoRows.Close()
+
+		for _, id := range row.photoIDs {
+			if existing[id] {
+				profiles[i].Photos = append(profiles[i].Photos, models.FarmPublicPhoto{
+					ID:  id,
+					URL: publicProfilePhotoPath + profiles[i].Slug + "/photos/" + id,
+				})
+			}
+		}
+	}
+	return profiles, nil
+}
@@ -0,0 +1,420 @@
+package services
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"log"
+	"math"
+	"sync"
+	"time"
+
+	"agri-management-api/internal/models"
+	"agri-management-api/internal/utils"
+)
+
+// Yeniden hesaplanabilen türetilmiş değerler
+const (
+	// RecalcLandProductivity arazilerin son 12 aydaki hasat verimi (alan birimi başına miktar) ve son aktivite tarihi
+	RecalcLandProductivity = "land_productivity"
+	// RecalcProductionStock ürünlerin satış ve kayıp kayıtlarından toplanan satılan/kaybedilen miktarları
+	RecalcProductionStock = "production_stock"
+	// RecalcKPISnapshots çiftliğin günün KPI anlık görüntüsü
+	RecalcKPISnapshots = "kpi_snapshots"
+)
+
+// RecalculationTargets yeniden hesaplama hedefleri, çalıştırılma sırasıyla
+var RecalculationTargets = []string{RecalcProductionStock, RecalcLandProductivity, RecalcKPISnapshots}
+
+// maxRecalculationErrors iş kaydında saklanan en fazla hata mesajı sayısı
+const maxRecalculationErrors = 20
+
+var (
+	// ErrInvalidRecalculation yeniden hesaplama isteği geçersiz olduğunda döner
+	ErrInvalidRecalculation = errors.New("geçersiz yeniden hesaplama isteği")
+	// ErrRecalculationJobNotFound yeniden hesaplama işi bulunamadığında döner
+	ErrRecalculationJobNotFound = errors.New("yeniden hesaplama işi bulunamadı")
+)
+
+// recalculationMu aynı verilerin eşzamanlı yeniden hesaplanmaması için işleri sırayla çalıştırır
+var recalculationMu sync.Mutex
+
+// recalculationJobSelect yeniden hesaplama işlerini okuyan sorgu
+const recalculationJobSelect = `
+	SELECT id, targets, farm_id, status, total, processed, failed, updated_records, COALESCE(errors, ''),
+	       COALESCE(requested_by, ''), created_at, started_at, finished_at
+	FROM recalculation_jobs`
+
+// RecalculationService veri aktarımları veya hata düzeltmelerinden sonra türetilmiş değerleri
+// bir çiftlik ya da tüm çiftlikler için arka planda yeniden hesaplar
+type RecalculationService struct {
+	db *sql.DB
+}
+
+// NewRecalculationService yeni recalculation service oluşturur
+func NewRecalculationService(db *sql.DB) *RecalculationService {
+	return &RecalculationService{db: db}
+}
+
+// Start işi kaydeder ve arka planda çalıştırır; iş ilerlemesi Job ile izlenir
+func (s *RecalculationService) Start(requestedBy string, req models.RecalculationRequest) (models.RecalculationJob, error) {
+	job, farmIDs, err := s.Create(requestedBy, req)
+	if err != nil {
+		return job, err
+	}
+
+	go s.Execute(job, farmIDs, nil)
+	return job, nil
+}
+
+// Create isteği doğrular, etkilenecek çiftlikleri belirler ve işi sıraya alınmış olarak kaydeder
+func (s *RecalculationService) Create(requestedBy string, req models.RecalculationRequest) (models.RecalculationJob, []string, error) {
+	job := models.RecalculationJob{Status: models.RecalculationQueued, RequestedBy: requestedBy, Errors: []string{}}
+
+	targets, err := normalizeRecalculationTargets(req.Targets)
+	if err != nil {
+		return job, nil, err
+	}
+	job.Targets = targets
+
+	farmIDs, err := s.farmIDs(req.FarmID)
+	if err != nil {
+		return job, nil, err
+	}
+	if req.FarmID != "" {
+		job.FarmID = &req.FarmID
+	}
+	job.Total = len(farmIDs) * len(targets)
+
+	job.ID = utils.GenerateID()
+	targetsJSON, _ := utils.ToJSON(targets)
+	_, err = s.db.Exec(`
+		INSERT INTO recalculation_jobs (id, targets, farm_id, status, total, requested_by, created_at)
+		VALUES (?, ?, ?, ?, ?, ?, CURRENT_TIMESTAMP)
+	`, job.ID, targetsJSON, job.FarmID, job.Status, job.Total, requestedBy)
+	if err != nil {
+		return job, nil, err
+	}
+
+	job, err = s.Job(job.ID)
+	return job, farmIDs, err
+}
+
+// Execute işi çalıştırır: her çiftlik için hedefleri sırayla yeniden hesaplar ve her adımda ilerlemeyi kaydeder.
+// Bir çiftlikteki hata işi durdurmaz; hata sayılır ve mesajı işe eklenir. onProgress verilirse her adımdan sonra çağrılır
+func (s *RecalculationService) Execute(job models.RecalculationJob, farmIDs []string, onProgress func(models.RecalculationJob)) models.RecalculationJob {
+	recalculationMu.Lock()
+	defer recalculationMu.Unlock()
+
+	now := time.Now().UTC()
+	job.Status = models.RecalculationRunning
+	job.StartedAt = &now
+	s.saveProgress(&job)
+
+	for _, farmID := range farmIDs {
+		for _, target := range job.Targets {
+			updated, err := s.Recalculate(target, farmID)
+			job.Processed++
+			job.UpdatedRecords += updated
+			if err != nil {
+				job.Failed++
+				if len(job.Errors) < maxRecalculationErrors {
+					job.Errors = append(job.Errors, fmt.Sprintf("%s (%s): %v", target, farmID, err))
+				}
+				log.Printf("Yeniden hesaplama başarısız (%s, %s): %v", target, farmID, err)
+			}
+			s.saveProgress(&job)
+			if onProgress != nil {
+				onProgress(job)
+			}
+		}
+	}
+
+	finished := time.Now().UTC()
+	job.Status = models.RecalculationCompleted
+	if job.Total > 0 && job.Failed == job.Total {
+		job.Status = models.RecalculationFailed
+	}
+	job.FinishedAt = &finished
+	s.saveProgress(&job)
+	return job
+}
+
+// Recalculate bir çiftliğin tek bir hedefini yeniden hesaplar ve değişen kayıt sayısını döner
+func (s *RecalculationService) Recalculate(target, farmID string) (int, error) {
+	switch target {
+	case RecalcLandProductivity:
+		return s.recalculateLands(farmID, time.Now())
+	case RecalcProductionStock:
+		return s.recalculateProductionStock(farmID)
+	case RecalcKPISnapshots:
+		if _, err := NewKPISnapshotService(s.db).Capture(farmID, time.Now()); err != nil {
+			return 0, err
+		}
+		return 1, nil
+	}
+	return 0, fmt.Errorf("%w: bilinmeyen hedef %q", ErrInvalidRecalculation, target)
+}
+
+// FailInterrupted sunucu yeniden başladığında yarım kalan işleri başarısız olarak kapatır
+func (s *RecalculationService) FailInterrupted() error {
+	_, err := s.db.Exec(`
+		UPDATE recalculation_jobs SET status = ?, finished_at = ?
+		WHERE status IN (?, ?)
+	`, models.RecalculationFailed, time.Now().UTC(), models.RecalculationQueued, models.RecalculationRunning)
+	return err
+}
+
+// Job tek bir işi ilerlemesiyle döner
+func (s *RecalculationService) Job(id string) (models.RecalculationJob, error) {
+	job, err := scanRecalculationJob(s.db.QueryRow(recalculationJobSelect+" WHERE id = ?", id))
+	if err == sql.ErrNoRows {
+		return job, ErrRecalculationJobNotFound
+	}
+	return job, err
+}
+
+// Jobs işleri yeniden eskiye sayfalı döner
+func (s *RecalculationService) Jobs(page, limit int) ([]models.RecalculationJob, int, error) {
+	var total int
+	if err := s.db.QueryRow("SELECT COUNT(*) FROM recalculation_jobs").Scan(&total); err != nil {
+		return nil, 0, err
+	}
+
+	rows, err := s.db.Query(recalculationJobSelect+" ORDER BY created_at DESC, rowid DESC LIMIT ? OFFSET ?", limit, (page-1)*limit)
+	if err != nil {
+		return nil, 0, err
+	}
+	defer rows.Close()
+
+	jobs := []models.RecalculationJob{}
+	for rows.Next() {
+		job, err := scanRecalculationJob(rows)
+		if err != nil {
+			continue
+		}
+		jobs = append(jobs, job)
+	}
+	return jobs, total, rows.Err()
+}
+
+// recalculateLands arazilerin son aktivite tarihini aktivitelerden, verimini son 12 ayda araziye bağlı hasat
+// edilen ürün miktarının alana bölümünden hesaplar. Hasat kaydı olmayan arazilerde elle girilen verim korunur
+func (s *RecalculationService) recalculateLands(farmID string, now time.Time) (int, error) {
+	since := now.AddDate(-1, 0, 0).Format("2006-01-02")
+	rows, err := s.db.Query(`
+		SELECT l.id, l.area, COALESCE(l.productivity, 0), COALESCE(date(l.last_activity), ''),
+		       (SELECT COALESCE(date(MAX(COALESCE(a.actual_date, a.scheduled_date))), '')
+		        FROM land_activities a WHERE a.land_id = l.id),
+		       (SELECT COUNT(*) FROM production p
+		        WHERE p.user_id = ? AND p.land_id = l.id AND COALESCE(p.harvest_date, date(p.created_at)) >= ?),
+		       (SELECT COALESCE(SUM(p.amount), 0) FROM production p
+		        WHERE p.user_id = ? AND p.land_id = l.id AND COALESCE(p.harvest_date, date(p.created_at)) >= ?)
+		FROM lands l WHERE l.user_id = ?
+	`, farmID, since, farmID, since, farmID)
+	if err != nil {
+		return 0, err
+	}
+
+	type landUpdate struct {
+		id           string
+		productivity float64
+		lastActivity interface{}
+	}
+	var updates []landUpdate
+	for rows.Next() {
+		var id, lastActivity, latestActivity string
+		var area, productivity, harvested float64
+		var harvests int
+		if err := rows.Scan(&id, &area, &productivity, &lastActivity, &latestActivity, &harvests, &harvested); err != nil {
+			rows.Close()
+			return 0, err
+		}
+
+		update := landUpdate{id: id, productivity: productivity, lastActivity: utils.StringToNullString(lastActivity)}
+		if harvests > 0 && area > 0 {
+			update.productivity = round2(harvested / area)
+		}
+		if latestActivity != "" {
+			update.lastActivity = latestActivity
+		}
+		if math.Abs(update.productivity-productivity) < 0.005 && (latestActivity == "" || latestActivity == lastActivity) {
+			continue
+		}
+		updates = append(updates, update)
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return 0, err
+	}
+
+	for _, update := range updates {
+		_, err := s.db.Exec(`
+			UPDATE lands SET productivity = ?, last_activity = ?, updated_at = CURRENT_TIMESTAMP
+			WHERE id = ? AND user_id = ?
+		`, update.productivity, update.lastActivity, update.id, farmID)
+		if err != nil {
+			return 0, err
+		}
+	}
+	return len(updates), nil
+}
+
+// recalculateProductionStock ürünlerin satılan ve kaybedilen miktarlarını satış, iptal edilmemiş sipariş ve kayıp
+// kayıtlarından yeniden toplar
+func (s *RecalculationService) recalculateProductionStock(farmID string) (int, error) {
+	rows, err := s.db.Query(`
+		SELECT p.id, COALESCE(p.sold_amount, 0), COALESCE(p.lost_amount, 0),
+		       (SELECT COALESCE(SUM(quantity), 0) FROM production_sales s WHERE s.user_id = ? AND s.production_id = p.id) +
+		       (SELECT COALESCE(SUM(quantity), 0) FROM sales_orders o
+		        WHERE o.user_id = ? AND o.production_id = p.id AND o.payment_status != 'cancelled'),
+		       (SELECT COALESCE(SUM(quantity), 0) FROM production_losses x WHERE x.user_id = ? AND x.production_id = p.id)
+		FROM production p WHERE p.user_id = ?
+	`, farmID, farmID, farmID, farmID)
+	if err != nil {
+		return 0, err
+	}
+
+	type stockUpdate struct {
+		id         string
+		sold, lost float64
+	}
+	var updates []stockUpdate
+	for rows.Next() {
+		var update stockUpdate
+		var sold, lost float64
+		if err := rows.Scan(&update.id, &sold, &lost, &update.sold, &update.lost); err != nil {
+			rows.Close()
+			return 0, err
+		}
+		if math.Abs(update.sold-sold) < 1e-9 && math.Abs(update.lost-lost) < 1e-9 {
+			continue
+		}
+		updates = append(updates, update)
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return 0, err
+	}
+
+	for _, update := range updates {
+		_, err := s.db.Exec(`
+			UPDATE production SET sold_amount = ?, lost_amount = ?, updated_at = CURRENT_TIMESTAMP
+			WHERE id = ? AND user_id = ?
+		`, update.sold, update.lost, update.id, farmID)
+		if err != nil {
+			return 0, err
+		}
+	}
+	return len(updates), nil
+}
+
+// farmIDs yeniden hesaplanacak çiftlikleri döner; farmID verilirse çiftliğin var olduğu doğrulanır.
+// Varsayılan çiftlikler ilk kullanımda oluşturulduğundan hesap kimlikleri de çiftlik olarak sayılır
+func (s *RecalculationService) farmIDs(farmID string) ([]string, error) {
+	if farmID != "" {
+		var exists int
+		err := s.db.QueryRow("SELECT 1 FROM farms WHERE id = ? UNION SELECT 1 FROM users WHERE id = ?", farmID, farmID).Scan(&exists)
+		if err == sql.ErrNoRows {
+			return nil, fmt.Errorf("%w: çiftlik bulunamadı", ErrInvalidRecalculation)
+		}
+		if err != nil {
+			return nil, err
+		}
+		return []string{farmID}, nil
+	}
+
+	rows, err := s.db.Query("SELECT id FROM users UNION SELECT id FROM farms")
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var ids []string
+	for rows.Next() {
+		var id string
+		if err := rows.Scan(&id); err != nil {
+			return nil, err
+		}
+		ids = append(ids, id)
+	}
+	return ids, rows.Err()
+}
+
+// saveProgress işin durumunu ve ilerlemesini kaydeder
+func (s *RecalculationService) saveProgress(job *models.RecalculationJob) {
+	errorsJSON, _ := utils.ToJSON(job.Errors)
+	_, err := s.db.Exec(`
+		UPDATE recalculation_jobs
+		SET status = ?, processed = ?, failed = ?, updated_records = ?, errors = ?, started_at = ?, finished_at = ?
+		WHERE id = ?
+	`, job.Status, job.Processed, job.Failed, job.UpdatedRecords, errorsJSON, job.StartedAt, job.FinishedAt, job.ID)
+	if err != nil {
+		log.Printf("Yeniden hesaplama ilerlemesi kaydedilemedi (%s): %v", job.ID, err)
+	}
+	job.Progress = recalculationProgress(job.Processed, job.Total)
+}
+
+// normalizeRecalculationTargets hedefleri doğrular ve çalıştırma sırasına dizer; boşsa tüm hedefler döner
+func normalizeRecalculationTargets(targets []string) ([]string, error) {
+	if len(targets) == 0 {
+		return RecalculationTargets, nil
+	}
+
+	requested := map[string]bool{}
+	for _, target := range targets {
+		known := false
+		for _, candidate := range RecalculationTargets {
+			if target == candidate {
+				known = true
+			}
+		}
+		if !known {
+			return nil, fmt.Errorf("%w: bilinmeyen hedef %q", ErrInvalidRecalculation, target)
+		}
+		requested[target] = true
+	}
+
+	var ordered []string
+	for _, target := range RecalculationTargets {
+		if requested[target] {
+			ordered = append(ordered, target)
+		}
+	}
+	return ordered, nil
+}
+
+// recalculationProgress tamamlanan adımların yüzdesi
+func recalculationProgress(processed, total int) float64 {
+	if total == 0 {
+		return 100
+	}
+	return round2(float64(processed) / float64(total) * 100)
+}
+
+// scanRecalculationJob yeniden hesaplama işi satırını okur
+func scanRecalculationJob(row interface{ Scan(...interface{}) error }) (models.RecalculationJob, error) {
+	var job models.RecalculationJob
+	var targets, jobErrors string
+	var farmID sql.NullString
+	var startedAt, finishedAt sql.NullTime
+	err := row.Scan(&job.ID, &targets, &farmID, &job.Status, &job.Total, &job.Processed, &job.Failed,
+		&job.UpdatedRecords, &jobErrors, &job.RequestedBy, &job.CreatedAt, &startedAt, &finishedAt)
+	if err != nil {
+		return job, err
+	}
+
+	job.Targets = []string{}
+	utils.FromJSON(targets, &job.Targets)
+	job.Errors = []string{}
+	if jobErrors != "" {
+		utils.FromJSON(jobErrors, &job.Errors)
+	}
+	if farmID.Valid {
+		job.FarmID = &farmID.String
+	}
+	job.StartedAt = utils.NullTimeToPtr(startedAt)
+	job.FinishedAt = utils.NullTimeToPtr(finishedAt)
+	job.Progress = recalculationProgress(job.Processed, job.Total)
+	return job, nil
+}
@@ -0,0 +1,199 @@
+package services
+
+import (
+	"database/sql"
+	"errors"
+	"sort"
+	"strings"
+	"time"
+
+	"agri-management-api/internal/models"
+	"agri-management-api/internal/utils"
+)
+
+// Kayıt bağlantısı hataları
+var (
+	// ErrRecordLinkEntityNotFound bağlanmak istenen kayıt bulunamadı veya çiftliğe ait değil
+	ErrRecordLinkEntityNotFound = errors.New("linked record not found")
+	// ErrRecordLinkSelf kayıt kendisine bağlanamaz
+	ErrRecordLinkSelf = errors.New("record cannot be linked to itself")
+	// ErrRecordLinkExists iki kayıt arasında aynı ilişki zaten var
+	ErrRecordLinkExists = errors.New("record link already exists")
+	// ErrRecordLinkNotFound silinmek istenen bağlantı bulunamadı
+	ErrRecordLinkNotFound = errors.New("record link not found")
+)
+
+// linkableEntities birbirine bağlanabilen kayıt türlerinin adını ve çiftlik sahipliğini doğrulayan sorgular
+var linkableEntities = map[string]string{
+	"livestock":     "SELECT tag_number FROM livestock WHERE id = ? AND user_id = ?",
+	"land":          "SELECT name FROM lands WHERE id = ? AND user_id = ?",
+	"land_activity": "SELECT a.description FROM land_activities a JOIN lands l ON l.id = a.land_id WHERE a.id = ? AND l.user_id = ?",
+	"production":    "SELECT name FROM production WHERE id = ? AND user_id = ?",
+	"transaction":   "SELECT description FROM transactions WHERE id = ? AND user_id = ?",
+	"event":         "SELECT title FROM events WHERE id = ? AND user_id = ?",
+	"asset":         "SELECT name FROM fixed_assets WHERE id = ? AND user_id = ?",
+	"document":      "SELECT title FROM documents WHERE id = ? AND user_id = ?",
+	"utility_meter": "SELECT name FROM utility_meters WHERE id = ? AND user_id = ?",
+	"hive":          "SELECT name FROM hives WHERE id = ? AND user_id = ?",
+	"pond":          "SELECT name FROM ponds WHERE id = ? AND user_id = ?",
+	"fish_batch":    "SELECT species FROM fish_batches WHERE id = ? AND user_id = ?",
+}
+
+// LinkableEntityTypes bağlanabilen kayıt türlerini alfabetik sırayla döner
+func LinkableEntityTypes() []string {
+	types := make([]string, 0, len(linkableEntities))
+	for entityType := range linkableEntities {
+		types = append(types, entityType)
+	}
+	sort.Strings(types)
+	return types
+}
+
+// IsLinkableEntity kayıt türünün başka kayıtlara bağlanıp bağlanamayacağını döner
+func IsLinkableEntity(entityType string) bool {
+	_, ok := linkableEntities[entityType]
+	return ok
+}
+
+// RecordLinkService kayıtlar arasındaki serbest ilişkileri (ör. gider ↔ arazi aktivitesi, etkinlik ↔ ekipman)
+// yönetir. Bağlantılar yönsüzdür; her iki kaydın bağlantı listesinde de görünür
+type RecordLinkService struct {
+	db *sql.DB
+}
+
+// NewRecordLinkService yeni kayıt bağlantısı servisi oluşturur
+func NewRecordLinkService(db *sql.DB) *RecordLinkService {
+	return &RecordLinkService{db: db}
+}
+
+// EntityName kaydın çiftliğe ait olduğunu doğrular ve adını döner
+func (s *RecordLinkService) EntityName(farmID, entityType, entityID string) (string, error) {
+	query, ok := linkableEntities[entityType]
+	if !ok {
+		return "", ErrRecordLinkEntityNotFound
+	}
+
+	var name sql.NullString
+	err := s.db.QueryRow(query, entityID, farmID).Scan(&name)
+	if err == sql.ErrNoRows {
+		return "", ErrRecordLinkEntityNotFound
+	}
+	return name.String, err
+}
+
+// Create iki kaydı birbirine bağlar; aynı iki kayıt arasında aynı ilişki yalnızca bir kez kaydedilir
+func (s *RecordLinkService) Create(farmID, accountID string, req models.CreateRecordLinkRequest) (models.RecordLink, error) {
+	if req.SourceType == req.TargetType && req.SourceID == req.TargetID {
+		return models.RecordLink{}, ErrRecordLinkSelf
+	}
+
+	sourceName, err := s.EntityName(farmID, req.SourceType, req.SourceID)
+	if err != nil {
+		return models.RecordLink{}, err
+	}
+	targetName, err := s.EntityName(farmID, req.TargetType, req.TargetID)
+	if err != nil {
+		return models.RecordLink{}, err
+	}
+
+	relation := strings.TrimSpace(req.Relation)
+	if relation == "" {
+		relation = models.RecordLinkRelated
+	}
+
+	var exists int
+	s.db.QueryRow(`
+		SELECT 1 FROM record_links
+		WHERE user_id = ? AND relation = ? AND (
+			(source_type = ? AND source_id = ? AND target_type = ? AND target_id = ?) OR
+			(source_type = ? AND source_id = ? AND target_type = ? AND target_id = ?)
+		)
+	`, farmID, relation, req.SourceType, req.SourceID, req.TargetType, req.TargetID,
+		req.TargetType, req.TargetID, req.SourceType, req.SourceID).Scan(&exists)
+	if exists == 1 {
+		return models.RecordLink{}, ErrRecordLinkExists
+	}
+
+	link := models.RecordLink{
+		ID:        utils.GenerateID(),
+		Relation:  relation,
+		Source:    models.RelatedEntity{Type: req.SourceType, ID: req.SourceID, Name: sourceName},
+		Target:    models.RelatedEntity{Type: req.TargetType, ID: req.TargetID, Name: targetName},
+		Note:      strings.TrimSpace(req.Note),
+		CreatedBy: accountID,
+		CreatedAt: time.Now(),
+	}
+	_, err = s.db.Exec(`
+		INSERT INTO record_links (id, user_id, source_type, source_id, target_type, target_id, relation, note, created_by, created_at)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
+	`, link.ID, farmID, req.SourceType, req.SourceID, req.TargetType, req.TargetID, relation, link.Note, accountID, link.CreatedAt)
+	if err != nil {
+		return models.RecordLink{}, err
+	}
+	return link, nil
+}
+
+// List kaydın her iki yöndeki bağlantılarını en yeniden eskiye döner; bağlı kaydın adı güncel haliyle okunur
+func (s *RecordLinkService) List(farmID, entityType, entityID string) ([]models.RecordLink, error) {
+	rows, err := s.db.Query(`
+		SELECT id, source_type, source_id, target_type, target_id, relation, COALESCE(note, ''), created_by, created_at
+		FROM record_links
+		WHERE user_id = ? AND ((source_type = ? AND source_id = ?) OR (target_type = ? AND target_id = ?))
+		ORDER BY created_at DESC
+	`, farmID, entityType, entityID, entityType, entityID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	links := []models.RecordLink{}
+	for rows.Next() {
+		var link models.RecordLink
+		err := rows.Scan(&link.ID, &link.Source.Type, &link.Source.ID, &link.Target.Type, &link.Target.ID,
+			&link.Relation, &link.Note, &link.CreatedBy, &link.CreatedAt)
+		if err != nil {
+			return nil, err
+		}
+		links = append(links, link)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	for i := range links {
+		links[i].Source.Name, _ = s.EntityName(farmID, links[i].Source.Type, links[i].Source.ID)
+		links[i].Target.Name, _ = s.EntityName(farmID, links[i].Target.Type, links[i].Target.ID)
+	}
+	return links, nil
+}
+
+// Count kaydın bağlantı sayısını döner
+func (s *RecordLinkService) Count(farmID, entityType, entityID string) (int, error) {
+	var count int
+	err := s.db.QueryRow(`
+		SELECT COUNT(*) FROM record_links
+		WHERE user_id = ? AND ((source_type = ? AND source_id = ?) OR (target_type = ? AND target_id = ?))
+	`, farmID, entityType, entityID, entityType, entityID).Scan(&count)
+	return count, err
+}
+
+// Delete bağlantıyı siler
+func (s *RecordLinkService) Delete(farmID, linkID string) error {
+	result, err := s.db.Exec("DELETE FROM record_links WHERE id = ? AND user_id = ?", linkID, farmID)
+	if err != nil {
+		return err
+	}
+	if affected, _ := result.RowsAffected(); affected == 0 {
+		return ErrRecordLinkNotFound
+	}
+	return nil
+}
+
+// RemoveEntity silinen kaydın tüm bağlantılarını kaldırır
+func (s *RecordLinkService) RemoveEntity(farmID, entityType, entityID string) error {
+	_, err := s.db.Exec(`
+		DELETE FROM record_links
+		WHERE user_id = ? AND ((source_type = ? AND source_id = ?) OR (target_type = ? AND target_id = ?))
+	`, farmID, entityType, entityID, entityType, entityID)
+	return err
+}
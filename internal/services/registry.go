@@ -0,0 +1,293 @@
+package services
+
+import (
+	"database/sql"
+	"encoding/csv"
+	"encoding/xml"
+	"io"
+	"strings"
+	"time"
+
+	"agri-management-api/internal/models"
+	"agri-management-api/internal/utils"
+)
+
+// Resmi kayıt sistemi (TÜRKVET) dışa aktarım formatları
+const (
+	RegistryFormatCSV = "csv"
+	RegistryFormatXML = "xml"
+)
+
+// registryDateLayout TÜRKVET dosyalarında kullanılan tarih formatı
+const registryDateLayout = "02.01.2006"
+
+// registryCSVHeader her satır bir hareket kaydıdır; hareketi olmayan hayvanlar tek satırla yazılır
+var registryCSVHeader = []string{
+	"KULAK_NO", "TUR", "IRK", "CINSIYET", "DOGUM_TARIHI", "ANA_KULAK_NO", "BABA_KULAK_NO",
+	"ISLETME_NO", "HAREKET_TIPI", "HAREKET_TARIHI", "CIKIS_YERI", "VARIS_YERI", "KARSI_ISLETME_NO", "HAREKET_NEDENI",
+}
+
+// Hayvan türü kodları
+var registrySpeciesCodes = map[string]string{
+	"cattle":  "SIGIR",
+	"buffalo": "MANDA",
+	"sheep":   "KOYUN",
+	"goat":    "KECI",
+	"horse":   "AT",
+	"chicken": "TAVUK",
+}
+
+// Cinsiyet kodları
+var registryGenderCodes = map[string]string{
+	"female": "DISI",
+	"male":   "ERKEK",
+}
+
+// Hareket tipi kodları
+var registryMovementCodes = map[string]string{
+	"birth":     "DOGUM",
+	"purchase":  "GIRIS",
+	"sale":      "SATIS",
+	"transfer":  "NAKIL",
+	"death":     "OLUM",
+	"slaughter": "KESIM",
+}
+
+// MovementTypes desteklenen hayvan hareket tipleri
+func MovementTypes() []string {
+	return []string{"birth", "purchase", "sale", "transfer", "death", "slaughter"}
+}
+
+// IsValidMovementType hareket tipinin desteklenip desteklenmediğini kontrol eder
+func IsValidMovementType(movementType string) bool {
+	_, ok := registryMovementCodes[movementType]
+	return ok
+}
+
+// RegistryService resmi hayvan kayıt sistemi dosyalarını üretir
+type RegistryService struct {
+	db      *sql.DB
+	history *ChangeHistoryService
+}
+
+// NewRegistryService yeni registry service oluşturur
+func NewRegistryService(db *sql.DB) *RegistryService {
+	return &RegistryService{
+		db:      db,
+		history: NewChangeHistoryService(db),
+	}
+}
+
+// Animals kullanıcının hayvanlarını hareket geçmişiyle birlikte kayıt formatında getirir
+func (s *RegistryService) Animals(userID, premisesNumber string) ([]models.RegistryAnimal, error) {
+	rows, err := s.db.Query(`
+		SELECT id, tag_number, type, COALESCE(breed, ''), COALESCE(gender, ''), birth_date,
+		       COALESCE(mother, ''), COALESCE(father, '')
+		FROM livestock WHERE user_id = ?
+		ORDER BY tag_number
+	`, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	var ids []string
+	var animals []models.RegistryAnimal
+	for rows.Next() {
+		var id string
+		var animal models.RegistryAnimal
+		var birthDate sql.NullTime
+
+		err := rows.Scan(
+			&id, &animal.TagNumber, &animal.Species, &animal.Breed, &animal.Gender, &birthDate,
+			&animal.MotherTag, &animal.FatherTag,
+		)
+		if err != nil {
+			continue
+		}
+
+		animal.BirthDate = utils.NullTimeToPtr(birthDate)
+		animal.PremisesNumber = premisesNumber
+		animal.Movements = []models.LivestockMovement{}
+
+		ids = append(ids, id)
+		animals = append(animals, animal)
+	}
+	rows.Close()
+
+	// Hareketleri hayvanlara eşle
+	index := make(map[string]int, len(ids))
+	for i, id := range ids {
+		index[id] = i
+	}
+
+	movementRows, err := s.db.Query(`
+		SELECT id, livestock_id, movement_type, movement_date, COALESCE(from_location, ''),
+		       COALESCE(to_location, ''), COALESCE(premises_number, ''), COALESCE(reason, ''),
+		       COALESCE(notes, ''), created_at
+		FROM livestock_movements WHERE user_id = ?
+		ORDER BY movement_date, created_at
+	`, userID)
+	if err != nil {
+		return nil, err
+	}
+	defer movementRows.Close()
+
+	for movementRows.Next() {
+		var movement models.LivestockMovement
+		var movementDate sql.NullTime
+
+		err := movementRows.Scan(
+			&movement.ID, &movement.LivestockID, &movement.MovementType, &movementDate,
+			&movement.FromLocation, &movement.ToLocation, &movement.PremisesNumber,
+			&movement.Reason, &movement.Notes, &movement.CreatedAt,
+		)
+		if err != nil {
+			continue
+		}
+
+		movement.MovementDate = utils.NullTimeToPtr(movementDate)
+		if i, ok := index[movement.LivestockID]; ok {
+			animals[i].Movements = append(animals[i].Movements, movement)
+		}
+	}
+
+	return animals, nil
+}
+
+// WriteRegistryCSV hayvanları TÜRKVET uyumlu noktalı virgül ayrımlı CSV olarak yazar; kullanıcının girdiği
+// metinler formül olarak yazılmaz
+func WriteRegistryCSV(w io.Writer, animals []models.RegistryAnimal) error {
+	writer := csv.NewWriter(w)
+	writer.Comma = ';'
+
+	if err := writer.Write(registryCSVHeader); err != nil {
+		return err
+	}
+
+	for _, animal := range animals {
+		base := []string{
+			SpreadsheetText(animal.TagNumber),
+			SpreadsheetText(registryCode(registrySpeciesCodes, animal.Species)),
+			SpreadsheetText(animal.Breed),
+			SpreadsheetText(registryCode(registryGenderCodes, animal.Gender)),
+			formatRegistryDate(animal.BirthDate),
+			SpreadsheetText(animal.MotherTag),
+			SpreadsheetText(animal.FatherTag),
+			SpreadsheetText(animal.PremisesNumber),
+		}
+
+		if len(animal.Movements) == 0 {
+			if err := writer.Write(append(base, "", "", "", "", "", "")); err != nil {
+				return err
+			}
+			continue
+		}
+
+		for _, movement := range animal.Movements {
+			record := append(append([]string{}, base...),
+				SpreadsheetText(registryCode(registryMovementCodes, movement.MovementType)),
+				formatRegistryDate(movement.MovementDate),
+				SpreadsheetText(movement.FromLocation),
+				SpreadsheetText(movement.ToLocation),
+				SpreadsheetText(movement.PremisesNumber),
+				SpreadsheetText(movement.Reason),
+			)
+			if err := writer.Write(record); err != nil {
+				return err
+			}
+		}
+	}
+
+	writer.Flush()
+	return writer.Error()
+}
+
+// registryXML TÜRKVET XML kök elemanı
+type registryXML struct {
+	XMLName        xml.Name            `xml:"HayvanKayitlari"`
+	PremisesNumber string              `xml:"isletmeNo,attr"`
+	GeneratedAt    string              `xml:"olusturmaTarihi,attr"`
+	Animals        []registryAnimalXML `xml:"Hayvan"`
+}
+
+type registryAnimalXML struct {
+	TagNumber string                `xml:"KulakNo"`
+	Species   string                `xml:"Tur"`
+	Breed     string                `xml:"Irk,omitempty"`
+	Gender    string                `xml:"Cinsiyet,omitempty"`
+	BirthDate string                `xml:"DogumTarihi,omitempty"`
+	MotherTag string                `xml:"AnaKulakNo,omitempty"`
+	FatherTag string                `xml:"BabaKulakNo,omitempty"`
+	Movements []registryMovementXML `xml:"Hareketler>Hareket,omitempty"`
+}
+
+type registryMovementXML struct {
+	Type           string `xml:"Tip"`
+	Date           string `xml:"Tarih"`
+	FromLocation   string `xml:"CikisYeri,omitempty"`
+	ToLocation     string `xml:"VarisYeri,omitempty"`
+	PremisesNumber string `xml:"KarsiIsletmeNo,omitempty"`
+	Reason         string `xml:"Neden,omitempty"`
+}
+
+// WriteRegistryXML hayvanları TÜRKVET uyumlu XML olarak yazar
+func WriteRegistryXML(w io.Writer, premisesNumber string, generatedAt time.Time, animals []models.RegistryAnimal) error {
+	doc := registryXML{
+		PremisesNumber: premisesNumber,
+		GeneratedAt:    generatedAt.Format(registryDateLayout),
+	}
+
+	for _, animal := range animals {
+		item := registryAnimalXML{
+			TagNumber: animal.TagNumber,
+			Species:   registryCode(registrySpeciesCodes, animal.Species),
+			Breed:     animal.Breed,
+			Gender:    registryCode(registryGenderCodes, animal.Gender),
+			BirthDate: formatRegistryDate(animal.BirthDate),
+			MotherTag: animal.MotherTag,
+			FatherTag: animal.FatherTag,
+		}
+
+		for _, movement := range animal.Movements {
+			item.Movements = append(item.Movements, registryMovementXML{
+				Type:           registryCode(registryMovementCodes, movement.MovementType),
+				Date:           formatRegistryDate(movement.MovementDate),
+				FromLocation:   movement.FromLocation,
+				ToLocation:     movement.ToLocation,
+				PremisesNumber: movement.PremisesNumber,
+				Reason:         movement.Reason,
+			})
+		}
+
+		doc.Animals = append(doc.Animals, item)
+	}
+
+	if _, err := io.WriteString(w, xml.Header); err != nil {
+		return err
+	}
+
+	encoder := xml.NewEncoder(w)
+	encoder.Indent("", "  ")
+	if err := encoder.Encode(doc); err != nil {
+		return err
+	}
+
+	_, err := io.WriteString(w, "\n")
+	return err
+}
+
+// registryCode iç değeri resmi koda çevirir; bilinmeyen değerler büyük harfle aynen yazılır
+func registryCode(codes map[string]string, value string) string {
+	if code, ok := codes[strings.ToLower(value)]; ok {
+		return code
+	}
+	return strings.ToUpper(value)
+}
+
+// formatRegistryDate tarihi kayıt formatında yazar
+func formatRegistryDate(t *time.Time) string {
+	if t == nil {
+		return ""
+	}
+	return t.Format(registryDateLayout)
+}
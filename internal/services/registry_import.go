@@ -0,0 +1,469 @@
+package services
+
+import (
+	"bytes"
+	"database/sql"
+	"encoding/csv"
+	"encoding/xml"
+	"errors"
+	"io"
+	"strings"
+	"time"
+
+	"agri-management-api/internal/models"
+	"agri-management-api/internal/utils"
+)
+
+// İçe aktarım önizlemesindeki aksiyonlar
+const (
+	RegistryActionCreate        = "create"
+	RegistryActionUpdate        = "update"
+	RegistryActionUnchanged     = "unchanged"
+	RegistryActionMismatch      = "mismatch"
+	RegistryActionMissing       = "missing"
+	RegistryActionNotInRegistry = "not_in_registry"
+	RegistryActionInvalid       = "invalid"
+)
+
+// ErrEmptyRegistryFile kayıt dosyasında hayvan bulunamadığında döner
+var ErrEmptyRegistryFile = errors.New("registry file contains no animals")
+
+// ParseRegistryFile CSV veya XML formatındaki resmi kayıt dosyasını çözümler
+func ParseRegistryFile(data []byte, format string) ([]models.RegistryAnimal, error) {
+	data = bytes.TrimPrefix(data, []byte("\xef\xbb\xbf"))
+
+	if format == "" {
+		format = RegistryFormatCSV
+		if bytes.HasPrefix(bytes.TrimSpace(data), []byte("<")) {
+			format = RegistryFormatXML
+		}
+	}
+
+	var animals []models.RegistryAnimal
+	var err error
+	if format == RegistryFormatXML {
+		animals, err = parseRegistryXML(data)
+	} else {
+		animals, err = parseRegistryCSV(data)
+	}
+
+	if err != nil {
+		return nil, err
+	}
+	if len(animals) == 0 {
+		return nil, ErrEmptyRegistryFile
+	}
+	return animals, nil
+}
+
+// parseRegistryCSV hareket satırlarını kulak numarasına göre gruplayarak okur
+func parseRegistryCSV(data []byte) ([]models.RegistryAnimal, error) {
+	reader := csv.NewReader(bytes.NewReader(data))
+	reader.Comma = ';'
+	reader.FieldsPerRecord = -1
+	reader.LazyQuotes = true
+	reader.TrimLeadingSpace = true
+
+	header, err := reader.Read()
+	if err != nil {
+		return nil, err
+	}
+
+	columns := make(map[string]int, len(header))
+	for i, name := range header {
+		columns[strings.ToUpper(strings.TrimSpace(name))] = i
+	}
+	if _, ok := columns["KULAK_NO"]; !ok {
+		return nil, errors.New("KULAK_NO column not found")
+	}
+
+	field := func(record []string, name string) string {
+		i, ok := columns[name]
+		if !ok || i >= len(record) {
+			return ""
+		}
+		return strings.TrimSpace(record[i])
+	}
+
+	var animals []models.RegistryAnimal
+	index := map[string]int{}
+
+	for {
+		record, err := reader.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		tag := field(record, "KULAK_NO")
+		if tag == "" {
+			continue
+		}
+
+		i, ok := index[tag]
+		if !ok {
+			animals = append(animals, models.RegistryAnimal{
+				TagNumber:      tag,
+				Species:        registryValue(registrySpeciesCodes, field(record, "TUR")),
+				Breed:          field(record, "IRK"),
+				Gender:         registryValue(registryGenderCodes, field(record, "CINSIYET")),
+				BirthDate:      parseRegistryDate(field(record, "DOGUM_TARIHI")),
+				MotherTag:      field(record, "ANA_KULAK_NO"),
+				FatherTag:      field(record, "BABA_KULAK_NO"),
+				PremisesNumber: field(record, "ISLETME_NO"),
+				Movements:      []models.LivestockMovement{},
+			})
+			i = len(animals) - 1
+			index[tag] = i
+		}
+
+		if movementType := field(record, "HAREKET_TIPI"); movementType != "" {
+			animals[i].Movements = append(animals[i].Movements, models.LivestockMovement{
+				MovementType:   registryValue(registryMovementCodes, movementType),
+				MovementDate:   parseRegistryDate(field(record, "HAREKET_TARIHI")),
+				FromLocation:   field(record, "CIKIS_YERI"),
+				ToLocation:     field(record, "VARIS_YERI"),
+				PremisesNumber: field(record, "KARSI_ISLETME_NO"),
+				Reason:         field(record, "HAREKET_NEDENI"),
+			})
+		}
+	}
+
+	return animals, nil
+}
+
+// parseRegistryXML TÜRKVET XML dosyasını okur
+func parseRegistryXML(data []byte) ([]models.RegistryAnimal, error) {
+	var doc registryXML
+	if err := xml.Unmarshal(data, &doc); err != nil {
+		return nil, err
+	}
+
+	var animals []models.RegistryAnimal
+	for _, item := range doc.Animals {
+		animal := models.RegistryAnimal{
+			TagNumber:      strings.TrimSpace(item.TagNumber),
+			Species:        registryValue(registrySpeciesCodes, item.Species),
+			Breed:          strings.TrimSpace(item.Breed),
+			Gender:         registryValue(registryGenderCodes, item.Gender),
+			BirthDate:      parseRegistryDate(item.BirthDate),
+			MotherTag:      strings.TrimSpace(item.MotherTag),
+			FatherTag:      strings.TrimSpace(item.FatherTag),
+			PremisesNumber: doc.PremisesNumber,
+			Movements:      []models.LivestockMovement{},
+		}
+
+		for _, movement := range item.Movements {
+			animal.Movements = append(animal.Movements, models.LivestockMovement{
+				MovementType:   registryValue(registryMovementCodes, movement.Type),
+				MovementDate:   parseRegistryDate(movement.Date),
+				FromLocation:   movement.FromLocation,
+				ToLocation:     movement.ToLocation,
+				PremisesNumber: movement.PremisesNumber,
+				Reason:         movement.Reason,
+			})
+		}
+
+		animals = append(animals, animal)
+	}
+
+	return animals, nil
+}
+
+// existingAnimal mutabakat için mevcut hayvan bilgisi
+type existingAnimal struct {
+	id        string
+	species   string
+	breed     string
+	gender    string
+	birthDate *time.Time
+	mother    string
+	father    string
+	movements map[string]bool
+}
+
+// ImportRegistry kayıt dosyasını mevcut hayvanlarla karşılaştırır; commit true ise değişiklikleri uygular
+func (s *RegistryService) ImportRegistry(userID string, animals []models.RegistryAnimal, mode string, commit bool) (models.RegistryImportResult, error) {
+	result := models.RegistryImportResult{
+		Mode:    mode,
+		Summary: map[string]int{},
+		Items:   []models.RegistryImportItem{},
+	}
+
+	existing, err := s.existingAnimals(userID)
+	if err != nil {
+		return result, err
+	}
+
+	var tx *sql.Tx
+	if commit && mode != models.RegistryImportModeFlag {
+		tx, err = s.db.Begin()
+		if err != nil {
+			return result, err
+		}
+		defer tx.Rollback()
+	}
+
+	seen := map[string]bool{}
+	for _, animal := range animals {
+		item := models.RegistryImportItem{TagNumber: animal.TagNumber}
+		seen[animal.TagNumber] = true
+
+		if animal.TagNumber == "" || animal.Species == "" {
+			item.Action = RegistryActionInvalid
+			item.Message = "Kulak numarası ve tür zorunludur"
+			result.Items = append(result.Items, item)
+			result.Summary[item.Action]++
+			continue
+		}
+
+		current, ok := existing[animal.TagNumber]
+		if !ok {
+			item.NewMovements = len(animal.Movements)
+			if mode == models.RegistryImportModeFlag {
+				item.Action = RegistryActionMissing
+				item.Message = "Hayvan resmi kayıtta var, çiftlik kayıtlarında yok"
+			} else {
+				item.Action = RegistryActionCreate
+				if tx != nil {
+					item.LivestockID, err = s.createFromRegistry(tx, userID, animal)
+					if err != nil {
+						return result, err
+					}
+				}
+			}
+
+			result.Items = append(result.Items, item)
+			result.Summary[item.Action]++
+			continue
+		}
+
+		item.LivestockID = current.id
+		item.Changes = registryChanges(current, animal)
+
+		var newMovements []models.LivestockMovement
+		for _, movement := range animal.Movements {
+			if !current.movements[movementKey(movement.MovementType, movement.MovementDate)] {
+				newMovements = append(newMovements, movement)
+			}
+		}
+		item.NewMovements = len(newMovements)
+
+		switch {
+		case len(item.Changes) == 0:
+			item.Action = RegistryActionUnchanged
+		case mode == models.RegistryImportModeUpdate:
+			item.Action = RegistryActionUpdate
+		default:
+			item.Action = RegistryActionMismatch
+			item.Message = "Resmi kayıt ile çiftlik kaydı uyuşmuyor"
+		}
+
+		if tx != nil {
+			if item.Action == RegistryActionUpdate {
+				if err := s.updateFromRegistry(tx, userID, current.id, animal); err != nil {
+					return result, err
+				}
+			}
+			if item.Action != RegistryActionMismatch {
+				if err := s.insertMovements(tx, userID, current.id, newMovements); err != nil {
+					return result, err
+				}
+			}
+		}
+
+		result.Items = append(result.Items, item)
+		result.Summary[item.Action]++
+	}
+
+	// Mutabakat modunda kayıtta olmayan çiftlik hayvanları da raporlanır
+	if mode == models.RegistryImportModeFlag {
+		for tag, current := range existing {
+			if seen[tag] {
+				continue
+			}
+			result.Items = append(result.Items, models.RegistryImportItem{
+				TagNumber:   tag,
+				LivestockID: current.id,
+				Action:      RegistryActionNotInRegistry,
+				Message:     "Hayvan çiftlik kayıtlarında var, resmi kayıtta yok",
+			})
+			result.Summary[RegistryActionNotInRegistry]++
+		}
+	}
+
+	if tx != nil {
+		if err := tx.Commit(); err != nil {
+			return result, err
+		}
+		result.Committed = true
+	}
+
+	return result, nil
+}
+
+// existingAnimals kullanıcının hayvanlarını kulak numarasına göre getirir
+func (s *RegistryService) existingAnimals(userID string) (map[string]*existingAnimal, error) {
+	rows, err := s.db.Query(`
+		SELECT id, tag_number, type, COALESCE(breed, ''), COALESCE(gender, ''), birth_date,
+		       COALESCE(mother, ''), COALESCE(father, '')
+		FROM livestock WHERE user_id = ?
+	`, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	animals := map[string]*existingAnimal{}
+	byID := map[string]*existingAnimal{}
+	for rows.Next() {
+		var tag string
+		var birthDate sql.NullTime
+		animal := &existingAnimal{movements: map[string]bool{}}
+
+		err := rows.Scan(
+			&animal.id, &tag, &animal.species, &animal.breed, &animal.gender, &birthDate,
+			&animal.mother, &animal.father,
+		)
+		if err != nil {
+			continue
+		}
+
+		animal.birthDate = utils.NullTimeToPtr(birthDate)
+		animals[tag] = animal
+		byID[animal.id] = animal
+	}
+	rows.Close()
+
+	movementRows, err := s.db.Query(`
+		SELECT livestock_id, movement_type, movement_date FROM livestock_movements WHERE user_id = ?
+	`, userID)
+	if err != nil {
+		return nil, err
+	}
+	defer movementRows.Close()
+
+	for movementRows.Next() {
+		var livestockID, movementType string
+		var movementDate sql.NullTime
+		if err := movementRows.Scan(&livestockID, &movementType, &movementDate); err != nil {
+			continue
+		}
+		if animal, ok := byID[livestockID]; ok {
+			animal.movements[movementKey(movementType, utils.NullTimeToPtr(movementDate))] = true
+		}
+	}
+
+	return animals, nil
+}
+
+// createFromRegistry kayıt dosyasındaki hayvanı oluşturur
+func (s *RegistryService) createFromRegistry(tx *sql.Tx, userID string, animal models.RegistryAnimal) (string, error) {
+	animalID := utils.GenerateID()
+	_, err := tx.Exec(`
+		INSERT INTO livestock (id, user_id, tag_number, type, breed, gender, birth_date,
+		                      health_status, mother, father, notes, created_at, updated_at)
+		VALUES (?, ?, ?, ?, ?, ?, ?, 'healthy', ?, ?, ?, CURRENT_TIMESTAMP, CURRENT_TIMESTAMP)
+	`, animalID, userID, animal.TagNumber, animal.Species, animal.Breed, animal.Gender, animal.BirthDate,
+		animal.MotherTag, animal.FatherTag, "Resmi kayıttan içe aktarıldı")
+	if err != nil {
+		return "", err
+	}
+
+	return animalID, s.insertMovements(tx, userID, animalID, animal.Movements)
+}
+
+// updateFromRegistry mevcut hayvanı kayıt dosyasındaki boş olmayan alanlarla günceller
+func (s *RegistryService) updateFromRegistry(tx *sql.Tx, userID, animalID string, animal models.RegistryAnimal) error {
+	return s.history.Track(tx, HistoryEntityLivestock, userID, animalID, userID, func() error {
+		_, err := tx.Exec(`
+			UPDATE livestock SET
+				type = ?,
+				breed = COALESCE(NULLIF(?, ''), breed),
+				gender = COALESCE(NULLIF(?, ''), gender),
+				birth_date = COALESCE(?, birth_date),
+				mother = COALESCE(NULLIF(?, ''), mother),
+				father = COALESCE(NULLIF(?, ''), father),
+				updated_at = CURRENT_TIMESTAMP
+			WHERE id = ? AND user_id = ?
+		`, animal.Species, animal.Breed, animal.Gender, animal.BirthDate, animal.MotherTag, animal.FatherTag, animalID, userID)
+		return err
+	})
+}
+
+// insertMovements hareket kayıtlarını ekler
+func (s *RegistryService) insertMovements(tx *sql.Tx, userID, animalID string, movements []models.LivestockMovement) error {
+	for _, movement := range movements {
+		if movement.MovementDate == nil || !IsValidMovementType(movement.MovementType) {
+			continue
+		}
+
+		_, err := tx.Exec(`
+			INSERT INTO livestock_movements (id, livestock_id, user_id, movement_type, movement_date,
+			                                 from_location, to_location, premises_number, reason, created_at)
+			VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, CURRENT_TIMESTAMP)
+		`, utils.GenerateID(), animalID, userID, movement.MovementType, movement.MovementDate,
+			movement.FromLocation, movement.ToLocation, movement.PremisesNumber, movement.Reason)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// registryChanges mevcut kayıt ile dosyadaki değerleri karşılaştırır; dosyada boş olan alanlar atlanır
+func registryChanges(current *existingAnimal, animal models.RegistryAnimal) []models.RegistryFieldChange {
+	var changes []models.RegistryFieldChange
+
+	compare := func(field, currentValue, incoming string) {
+		if incoming == "" || strings.EqualFold(currentValue, incoming) {
+			return
+		}
+		changes = append(changes, models.RegistryFieldChange{Field: field, Current: currentValue, Incoming: incoming})
+	}
+
+	compare("type", current.species, animal.Species)
+	compare("breed", current.breed, animal.Breed)
+	compare("gender", current.gender, animal.Gender)
+	compare("birthDate", dateKey(current.birthDate), dateKey(animal.BirthDate))
+	compare("mother", current.mother, animal.MotherTag)
+	compare("father", current.father, animal.FatherTag)
+
+	return changes
+}
+
+// registryValue resmi kodu iç değere çevirir; bilinmeyen kodlar küçük harfle aynen kullanılır
+func registryValue(codes map[string]string, code string) string {
+	code = strings.ToUpper(strings.TrimSpace(code))
+	for value, registryCode := range codes {
+		if registryCode == code {
+			return value
+		}
+	}
+	return strings.ToLower(code)
+}
+
+// parseRegistryDate kayıt formatındaki veya ISO formatındaki tarihi çözümler
+func parseRegistryDate(value string) *time.Time {
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return nil
+	}
+	if t, err := time.Parse(registryDateLayout, value); err == nil {
+		return &t
+	}
+	t, _ := utils.ParseTime(value)
+	return t
+}
+
+func movementKey(movementType string, date *time.Time) string {
+	return movementType + "|" + dateKey(date)
+}
+
+func dateKey(t *time.Time) string {
+	if t == nil {
+		return ""
+	}
+	return t.Format("2006-01-02")
+}
@@ -0,0 +1,795 @@
+package services
+
+import (
+	"bytes"
+	"database/sql"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"agri-management-api/internal/models"
+	"agri-management-api/internal/utils"
+)
+
+// Rapor türleri
+const (
+	ReportTypeFinancial  = "financial"
+	ReportTypeProduction = "production"
+	ReportTypeLivestock  = "livestock"
+	ReportTypeLand       = "land"
+)
+
+// Rapor dosya formatları
+const (
+	ReportFormatPDF  = "pdf"
+	ReportFormatXLSX = "xlsx"
+	ReportFormatCSV  = "csv"
+)
+
+// Rapor durumları
+const (
+	ReportStatusCompleted = "completed"
+)
+
+// reportContentTypes rapor formatlarının indirme içerik türleri
+var reportContentTypes = map[string]string{
+	ReportFormatPDF:  "application/pdf",
+	ReportFormatXLSX: "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet",
+	ReportFormatCSV:  "text/csv; charset=utf-8",
+}
+
+// reportFormatAliases istemcilerin kullandığı eski format adları
+var reportFormatAliases = map[string]string{"excel": ReportFormatXLSX}
+
+// reportTitles rapor türlerinin başlıkları
+var reportTitles = map[string]string{
+	ReportTypeFinancial:  "Finansal Rapor",
+	ReportTypeProduction: "Üretim Raporu",
+	ReportTypeLivestock:  "Hayvancılık Raporu",
+	ReportTypeLand:       "Arazi Raporu",
+}
+
+// reportDescriptions rapor türlerinin açıklamaları
+var reportDescriptions = map[string]string{
+	ReportTypeFinancial:  "Dönemdeki gelir ve gider işlemleri, para birimi bazında toplamlar",
+	ReportTypeProduction: "Dönemde hasat edilen ürünler, miktar ve değerleri",
+	ReportTypeLivestock:  "Hayvan listesi, sağlık durumu ve dönemdeki giriş/çıkışlar",
+	ReportTypeLand:       "Araziler, dönemdeki aktivite sayıları ve maliyetleri",
+}
+
+// PDF rapor yerleşimi (pt)
+const (
+	reportMargin    = 40.0
+	reportRowHeight = 15.0
+)
+
+var (
+	// ErrReportNotFound rapor dosyası bulunamadı
+	ErrReportNotFound = errors.New("rapor bulunamadı")
+	// ErrInvalidReportType desteklenmeyen rapor türü
+	ErrInvalidReportType = errors.New("geçersiz rapor türü")
+	// ErrInvalidReportFormat desteklenmeyen rapor formatı
+	ErrInvalidReportFormat = errors.New("geçersiz rapor formatı")
+)
+
+// reportColumn rapor tablosu sütunu; genişlik PDF'te sütunlar arasında oransal paylaştırılır
+type reportColumn struct {
+	title   string
+	width   float64
+	numeric bool
+}
+
+// reportTable rapor içeriği: özet satırları ve kayıt tablosu
+type reportTable struct {
+	columns []reportColumn
+	rows    [][]string
+	summary [][2]string
+}
+
+// ReportTypes desteklenen rapor türleri
+func ReportTypes() []string {
+	return []string{ReportTypeFinancial, ReportTypeProduction, ReportTypeLivestock, ReportTypeLand}
+}
+
+// IsValidReportType rapor türünün desteklenip desteklenmediğini kontrol eder
+func IsValidReportType(reportType string) bool {
+	_, ok := reportTitles[reportType]
+	return ok
+}
+
+// ReportFormats desteklenen rapor formatları
+func ReportFormats() []string {
+	return []string{ReportFormatPDF, ReportFormatXLSX, ReportFormatCSV}
+}
+
+// NormalizeReportFormat formatı küçük harfe çevirir ve eski adları (excel) karşılığına dönüştürür
+func NormalizeReportFormat(format string) string {
+	format = strings.ToLower(strings.TrimSpace(format))
+	if alias, ok := reportFormatAliases[format]; ok {
+		return alias
+	}
+	return format
+}
+
+// ReportContentType rapor formatının HTTP içerik türü
+func ReportContentType(format string) string {
+	if contentType, ok := reportContentTypes[format]; ok {
+		return contentType
+	}
+	return "application/octet-stream"
+}
+
+// ReportService çiftlik kayıtlarından PDF, XLSX ve CSV raporları üretir ve dosyaları depolamada saklar
+type ReportService struct {
+	db      *sql.DB
+	store   MediaStore
+	formats *FormattingService
+}
+
+// NewReportService yeni rapor servisi oluşturur
+func NewReportService(db *sql.DB) *ReportService {
+	return &ReportService{db: db, store: NewMediaStore(), formats: NewFormattingService(db)}
+}
+
+// reportJobPayload kuyruktaki rapor işinin isteği; dönem istek anında mali takvime göre çözülür
+type reportJobPayload struct {
+	Request   models.ReportRequest `json:"request"`
+	StartDate string               `json:"startDate"`
+	EndDate   string               `json:"endDate"`
+}
+
+// Enqueue rapor türünü ve formatını doğrular ve raporun oluşturulmasını arka plan işi olarak sıraya alır
+func (s *ReportService) Enqueue(farmID string, req models.ReportRequest, startDate, endDate time.Time) (models.Job, error) {
+	if _, ok := reportContentTypes[NormalizeReportFormat(req.Format)]; !ok {
+		return models.Job{}, ErrInvalidReportFormat
+	}
+	if !IsValidReportType(req.Type) {
+		return models.Job{}, ErrInvalidReportType
+	}
+
+	return NewJobService(s.db).Enqueue(farmID, models.JobTypeReport, reportJobPayload{
+		Request:   req,
+		StartDate: startDate.Format("2006-01-02"),
+		EndDate:   endDate.Format("2006-01-02"),
+	})
+}
+
+// runJob kuyruktaki rapor işini çalıştırır, sonucu çiftliğe bildirir ve oluşturulan raporun kimliğini döner
+func (s *ReportService) runJob(farmID string, job models.Job, payload string) (string, error) {
+	var p reportJobPayload
+	if err := utils.FromJSON(payload, &p); err != nil {
+		return "", err
+	}
+	startDate, err := time.Parse("2006-01-02", p.StartDate)
+	if err != nil {
+		return "", err
+	}
+	endDate, err := time.Parse("2006-01-02", p.EndDate)
+	if err != nil {
+		return "", err
+	}
+
+	report, err := s.Generate(farmID, p.Request, startDate, endDate)
+	notification := Notification{
+		UserID:   farmID,
+		Template: "report_ready",
+		Type:     "info",
+		Priority: "low",
+		Topic:    models.NotificationTopicReportReady,
+		Entity:   &models.RelatedEntity{Type: "report", ID: report.ID, Name: report.Title},
+		Params:   map[string]interface{}{"format": strings.ToUpper(report.Format)},
+	}
+	if err != nil {
+		notification = Notification{
+			UserID:   farmID,
+			Template: "report_failed",
+			Type:     "error",
+			Priority: "medium",
+			Topic:    models.NotificationTopicReportFailed,
+			Entity:   &models.RelatedEntity{Type: "report_job", ID: job.ID, Name: reportTitles[p.Request.Type]},
+			Params:   map[string]interface{}{"error": err.Error()},
+		}
+	}
+	if _, notifyErr := NewNotificationService(s.db).Create(notification); notifyErr != nil {
+		log.Printf("Rapor bildirimi oluşturulamadı: %v", notifyErr)
+	}
+
+	if err != nil {
+		return "", err
+	}
+	return report.ID, nil
+}
+
+// Generate [startDate, endDate] dönemi için raporu oluşturur ve dosyasını kaydeder. Kategoriler verilirse
+// finansal raporda işlem kategorisine, üretim raporunda ürün kategorisine, hayvancılık raporunda hayvan
+// türüne göre filtrelenir. Tarih, saat ve sayılar çiftlik ayarlarındaki biçimlerle yazılır
+func (s *ReportService) Generate(farmID string, req models.ReportRequest, startDate, endDate time.Time) (models.Report, error) {
+	format := NormalizeReportFormat(req.Format)
+	if _, ok := reportContentTypes[format]; !ok {
+		return models.Report{}, ErrInvalidReportFormat
+	}
+	title, ok := reportTitles[req.Type]
+	if !ok {
+		return models.Report{}, ErrInvalidReportType
+	}
+
+	period := req.Period
+	if req.StartDate != "" || req.EndDate != "" || period == "" {
+		period = startDate.Format("2006-01-02") + " / " + endDate.Format("2006-01-02")
+	}
+
+	formatter, err := s.formats.Formatter(farmID, format)
+	if err != nil {
+		return models.Report{}, err
+	}
+	table, err := s.buildTable(formatter, farmID, req.Type, startDate, endDate, req.Categories)
+	if err != nil {
+		return models.Report{}, err
+	}
+
+	now := time.Now().UTC().Truncate(time.Second)
+	report := models.Report{
+		ID:            utils.GenerateID(),
+		Title:         title + " - " + period,
+		Type:          req.Type,
+		Description:   reportDescriptions[req.Type],
+		GeneratedDate: now.Format("2006-01-02T15:04:05Z"),
+		Period:        period,
+		Format:        format,
+		Status:        ReportStatusCompleted,
+		Parameters: &models.ReportParameters{
+			StartDate:     startDate.Format("2006-01-02"),
+			EndDate:       endDate.Format("2006-01-02"),
+			IncludeCharts: req.IncludeCharts,
+			Categories:    req.Categories,
+		},
+	}
+	report.DownloadURL = "/api/v1/reports/" + report.ID + "/download"
+
+	var buf bytes.Buffer
+	switch format {
+	case ReportFormatPDF:
+		var farmName string
+		s.db.QueryRow(`
+			SELECT COALESCE((SELECT name FROM farms WHERE id = ?), (SELECT farm_name FROM users WHERE id = ?), '')
+		`, farmID, farmID).Scan(&farmName)
+		err = writeReportPDF(&buf, formatter, report, farmName, table, now)
+	default:
+		err = WriteCalendarExport(&buf, format, title, table.records())
+	}
+	if err != nil {
+		return report, err
+	}
+
+	filePath := reportStorageKey(farmID, report.ID, format)
+	if _, err := s.store.Save(filePath, &buf); err != nil {
+		return report, err
+	}
+
+	categories, _ := utils.ToJSON(req.Categories)
+	_, err = s.db.Exec(`
+		INSERT INTO reports (id, user_id, title, type, description, period, start_date, end_date, format,
+		                     include_charts, categories, file_path, status, created_at)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
+	`, report.ID, farmID, report.Title, report.Type, report.Description, report.Period,
+		report.Parameters.StartDate, report.Parameters.EndDate, report.Format, req.IncludeCharts,
+		categories, filePath, report.Status, now)
+	if err != nil {
+		s.store.Delete(filePath)
+		return report, err
+	}
+	return report, nil
+}
+
+// reportSelect rapor kayıtlarının ortak sorgusu
+const reportSelect = `
+	SELECT id, title, type, COALESCE(description, ''), period, date(start_date), date(end_date), format,
+	       include_charts, COALESCE(categories, ''), status, created_at
+	FROM reports`
+
+// Report çiftliğin tek raporunu döner
+func (s *ReportService) Report(farmID, reportID string) (models.Report, error) {
+	report, err := scanReport(s.db.QueryRow(reportSelect+" WHERE id = ? AND user_id = ?", reportID, farmID))
+	if err == sql.ErrNoRows {
+		return report, ErrReportNotFound
+	}
+	return report, err
+}
+
+// Reports çiftliğin raporlarını en yeniden eskiye sayfalı listeler; boş tür ve dönem filtre uygulamaz
+func (s *ReportService) Reports(farmID, reportType, period string, page, limit int) ([]models.Report, int, error) {
+	where := " WHERE user_id = ?"
+	args := []interface{}{farmID}
+	if reportType != "" {
+		where += " AND type = ?"
+		args = append(args, reportType)
+	}
+	if period != "" {
+		where += " AND period = ?"
+		args = append(args, period)
+	}
+
+	var total int
+	if err := s.db.QueryRow("SELECT COUNT(*) FROM reports"+where, args...).Scan(&total); err != nil {
+		return nil, 0, err
+	}
+
+	rows, err := s.db.Query(reportSelect+where+" ORDER BY created_at DESC, id LIMIT ? OFFSET ?",
+		append(args, limit, (page-1)*limit)...)
+	if err != nil {
+		return nil, 0, err
+	}
+	defer rows.Close()
+
+	reports := []models.Report{}
+	for rows.Next() {
+		report, err := scanReport(rows)
+		if err != nil {
+			return nil, 0, err
+		}
+		reports = append(reports, report)
+	}
+	return reports, total, rows.Err()
+}
+
+// scanReport rapor satırını okur
+func scanReport(row interface{ Scan(...interface{}) error }) (models.Report, error) {
+	var report models.Report
+	var params models.ReportParameters
+	var categories string
+	var createdAt time.Time
+	err := row.Scan(&report.ID, &report.Title, &report.Type, &report.Description, &report.Period,
+		&params.StartDate, &params.EndDate, &report.Format, &params.IncludeCharts, &categories,
+		&report.Status, &createdAt)
+	if err != nil {
+		return report, err
+	}
+	if categories != "" {
+		utils.FromJSON(categories, &params.Categories)
+	}
+	report.Parameters = &params
+	report.GeneratedDate = createdAt.UTC().Format("2006-01-02T15:04:05Z")
+	report.DownloadURL = "/api/v1/reports/" + report.ID + "/download"
+	return report, nil
+}
+
+// Open raporun kayıtlı dosyasını ve formatını döner
+func (s *ReportService) Open(farmID, reportID string) (io.ReadCloser, string, error) {
+	var format string
+	var filePath sql.NullString
+	err := s.db.QueryRow(`
+		SELECT format, file_path FROM reports WHERE id = ? AND user_id = ? AND status = ?
+	`, reportID, farmID, ReportStatusCompleted).Scan(&format, &filePath)
+	if err == sql.ErrNoRows || (err == nil && !filePath.Valid) {
+		return nil, "", ErrReportNotFound
+	}
+	if err != nil {
+		return nil, "", err
+	}
+
+	file, err := s.store.Open(filePath.String)
+	if os.IsNotExist(err) {
+		return nil, "", ErrReportNotFound
+	}
+	if err != nil {
+		return nil, "", err
+	}
+	return file, format, nil
+}
+
+// reportStorageKey rapor dosyasının depolama anahtarı
+func reportStorageKey(farmID, reportID, format string) string {
+	return "reports/" + farmID + "/" + reportID + "." + format
+}
+
+// buildTable rapor türüne göre tabloyu oluşturur
+func (s *ReportService) buildTable(f Formatter, farmID, reportType string, startDate, endDate time.Time, categories []string) (reportTable, error) {
+	from, to := startDate.Format("2006-01-02"), endDate.Format("2006-01-02")
+	switch reportType {
+	case ReportTypeFinancial:
+		return s.financialTable(f, farmID, from, to, categories)
+	case ReportTypeProduction:
+		return s.productionTable(f, farmID, from, to, categories)
+	case ReportTypeLivestock:
+		return s.livestockTable(f, farmID, from, to, categories)
+	default:
+		return s.landTable(f, farmID, from, to)
+	}
+}
+
+// inClause kategori filtresi için "AND column IN (?, ...)" koşulu üretir
+func inClause(column string, values []string) (string, []interface{}) {
+	if len(values) == 0 {
+		return "", nil
+	}
+	args := make([]interface{}, len(values))
+	for i, value := range values {
+		args[i] = value
+	}
+	return " AND " + column + " IN (?" + strings.Repeat(", ?", len(values)-1) + ")", args
+}
+
+// financialTable dönemdeki işlemler; toplamlar tamamlanmış işlemlerden para birimi bazında hesaplanır
+func (s *ReportService) financialTable(f Formatter, farmID, from, to string, categories []string) (reportTable, error) {
+	table := reportTable{columns: []reportColumn{
+		{"Tarih", 1.1, false}, {"Tür", 0.8, false}, {"Kategori", 1.4, false}, {"Açıklama", 2.6, false},
+		{"Tutar", 1.2, true}, {"Para Birimi", 0.9, false}, {"Durum", 1.0, false},
+	}}
+
+	filter, filterArgs := inClause("category", categories)
+	rows, err := s.db.Query(`
+		SELECT date(date), type, category, description, amount, COALESCE(NULLIF(currency, ''), 'TRY'),
+		       COALESCE(NULLIF(status, ''), 'completed')
+		FROM transactions
+		WHERE user_id = ? AND date(date) BETWEEN ? AND ?`+filter+`
+		ORDER BY date(date), created_at
+	`, append([]interface{}{farmID, from, to}, filterArgs...)...)
+	if err != nil {
+		return table, err
+	}
+	defer rows.Close()
+
+	income, expense := map[string]float64{}, map[string]float64{}
+	for rows.Next() {
+		var day, txType, category, description, currency, status string
+		var amount float64
+		if err := rows.Scan(&day, &txType, &category, &description, &amount, &currency, &status); err != nil {
+			return table, err
+		}
+		if status == "completed" {
+			if txType == "income" {
+				income[currency] += amount
+			} else {
+				expense[currency] += amount
+			}
+		}
+		table.rows = append(table.rows, []string{
+			f.Day(day), reportLabel(txType), category, description, f.Amount(amount), currency, reportLabel(status),
+		})
+	}
+	if err := rows.Err(); err != nil {
+		return table, err
+	}
+
+	table.summary = append(table.summary, [2]string{"İşlem sayısı", strconv.Itoa(len(table.rows))})
+	for _, currency := range reportKeys(income, expense) {
+		table.summary = append(table.summary,
+			[2]string{"Toplam gelir (" + currency + ")", f.Amount(income[currency])},
+			[2]string{"Toplam gider (" + currency + ")", f.Amount(expense[currency])},
+			[2]string{"Net (" + currency + ")", f.Amount(income[currency] - expense[currency])},
+		)
+	}
+	return table, nil
+}
+
+// productionTable hasat tarihi (yoksa kayıt tarihi) dönemdeki üretim kayıtları
+func (s *ReportService) productionTable(f Formatter, farmID, from, to string, categories []string) (reportTable, error) {
+	table := reportTable{columns: []reportColumn{
+		{"Tarih", 1.1, false}, {"Ürün", 1.6, false}, {"Kategori", 1.2, false}, {"Arazi", 1.4, false},
+		{"Miktar", 1.0, true}, {"Birim", 0.7, false}, {"Kalite", 0.9, false}, {"Değer", 1.1, true},
+	}}
+
+	filter, filterArgs := inClause("p.category", categories)
+	rows, err := s.db.Query(`
+		SELECT date(COALESCE(p.harvest_date, p.created_at)), p.name, p.category, COALESCE(l.name, ''),
+		       p.amount, p.unit, COALESCE(p.quality, ''), p.amount * COALESCE(p.price, 0)
+		FROM production p
+		LEFT JOIN lands l ON l.id = p.land_id
+		WHERE p.user_id = ? AND date(COALESCE(p.harvest_date, p.created_at)) BETWEEN ? AND ?`+filter+`
+		ORDER BY date(COALESCE(p.harvest_date, p.created_at)), p.created_at
+	`, append([]interface{}{farmID, from, to}, filterArgs...)...)
+	if err != nil {
+		return table, err
+	}
+	defer rows.Close()
+
+	amounts := map[string]float64{}
+	var totalValue float64
+	for rows.Next() {
+		var day, name, category, land, unit, quality string
+		var amount, value float64
+		if err := rows.Scan(&day, &name, &category, &land, &amount, &unit, &quality, &value); err != nil {
+			return table, err
+		}
+		amounts[unit] += amount
+		totalValue += value
+		table.rows = append(table.rows, []string{
+			f.Day(day), name, category, land, f.Amount(amount), unit, quality, f.Amount(value),
+		})
+	}
+	if err := rows.Err(); err != nil {
+		return table, err
+	}
+
+	table.summary = append(table.summary, [2]string{"Üretim kaydı", strconv.Itoa(len(table.rows))})
+	for _, unit := range reportKeys(amounts) {
+		table.summary = append(table.summary, [2]string{"Toplam miktar (" + unit + ")", f.Amount(amounts[unit])})
+	}
+	table.summary = append(table.summary, [2]string{"Toplam değer", f.Money(totalValue, "")})
+	return table, nil
+}
+
+// livestockTable hayvan listesi; özet sürüdeki hayvan sayısını ve dönemdeki giriş, çıkış ve sağlık kayıtlarını içerir
+func (s *ReportService) livestockTable(f Formatter, farmID, from, to string, types []string) (reportTable, error) {
+	table := reportTable{columns: []reportColumn{
+		{"Küpe No", 1.2, false}, {"Tür", 0.9, false}, {"Irk", 1.2, false}, {"Cinsiyet", 0.8, false},
+		{"Doğum Tarihi", 1.1, false}, {"Ağırlık (kg)", 1.0, true}, {"Sağlık", 0.9, false}, {"Konum", 1.2, false},
+		{"Durum", 0.9, false},
+	}}
+
+	filter, filterArgs := inClause("l.type", types)
+	rows, err := s.db.Query(`
+		SELECT l.tag_number, l.type, COALESCE(l.breed, ''), COALESCE(l.gender, ''), COALESCE(date(l.birth_date), ''),
+		       l.weight, COALESCE(l.health_status, ''), COALESCE(l.location, ''),
+		       CASE WHEN `+inHerdCondition+` THEN 1 ELSE 0 END
+		FROM livestock l
+		WHERE l.user_id = ?`+filter+`
+		ORDER BY l.type, l.tag_number
+	`, append([]interface{}{farmID}, filterArgs...)...)
+	if err != nil {
+		return table, err
+	}
+	defer rows.Close()
+
+	inHerd := 0
+	for rows.Next() {
+		var tag, animalType, breed, gender, birthDate, health, location string
+		var weight sql.NullFloat64
+		var active bool
+		if err := rows.Scan(&tag, &animalType, &breed, &gender, &birthDate, &weight, &health, &location, &active); err != nil {
+			return table, err
+		}
+		status := "Çıkış yaptı"
+		if active {
+			status = "Sürüde"
+			inHerd++
+		}
+		weightText := ""
+		if weight.Valid {
+			weightText = f.Amount(weight.Float64)
+		}
+		table.rows = append(table.rows, []string{
+			tag, animalType, breed, passportLabel(passportGenderLabels, gender), f.Day(birthDate), weightText,
+			passportLabel(passportHealthLabels, health), location, status,
+		})
+	}
+	if err := rows.Err(); err != nil {
+		return table, err
+	}
+
+	var entries, exits, healthRecords int
+	err = s.db.QueryRow(`
+		SELECT COALESCE(SUM(movement_type IN ('birth', 'purchase')), 0),
+		       COALESCE(SUM(movement_type IN (`+exitMovementTypes+`)), 0)
+		FROM livestock_movements
+		WHERE user_id = ? AND date(movement_date) BETWEEN ? AND ?
+	`, farmID, from, to).Scan(&entries, &exits)
+	if err != nil {
+		return table, err
+	}
+	err = s.db.QueryRow(`
+		SELECT COUNT(*) FROM health_records h JOIN livestock l ON l.id = h.livestock_id
+		WHERE l.user_id = ? AND date(h.date) BETWEEN ? AND ?
+	`, farmID, from, to).Scan(&healthRecords)
+	if err != nil {
+		return table, err
+	}
+
+	table.summary = [][2]string{
+		{"Sürüdeki hayvan", strconv.Itoa(inHerd)},
+		{"Dönemde giriş (doğum, alım)", strconv.Itoa(entries)},
+		{"Dönemde çıkış (satış, ölüm, kesim)", strconv.Itoa(exits)},
+		{"Dönemde sağlık kaydı", strconv.Itoa(healthRecords)},
+	}
+	return table, nil
+}
+
+// landTable araziler ve dönemdeki (gerçekleşme, planlanan veya kayıt tarihine göre) aktiviteleri
+func (s *ReportService) landTable(f Formatter, farmID, from, to string) (reportTable, error) {
+	table := reportTable{columns: []reportColumn{
+		{"Arazi", 1.6, false}, {"Alan", 0.8, true}, {"Birim", 0.7, false}, {"Ürün", 1.1, false},
+		{"Toprak", 1.0, false}, {"Sulama", 1.0, false}, {"Durum", 0.8, false}, {"Aktivite", 0.8, true},
+		{"Maliyet", 1.0, true},
+	}}
+
+	rows, err := s.db.Query(`
+		SELECT l.name, l.area, l.unit, COALESCE(l.crop, ''), COALESCE(l.soil_type, ''), COALESCE(l.irrigation_type, ''),
+		       COALESCE(l.status, ''), COUNT(a.id), COALESCE(SUM(a.cost), 0)
+		FROM lands l
+		LEFT JOIN land_activities a ON a.land_id = l.id
+		     AND date(COALESCE(a.actual_date, a.scheduled_date, a.created_at)) BETWEEN ? AND ?
+		WHERE l.user_id = ?
+		GROUP BY l.id
+		ORDER BY l.name
+	`, from, to, farmID)
+	if err != nil {
+		return table, err
+	}
+	defer rows.Close()
+
+	areas := map[string]float64{}
+	var activities int
+	var cost float64
+	for rows.Next() {
+		var name, unit, crop, soil, irrigation, status string
+		var area, landCost float64
+		var landActivities int
+		if err := rows.Scan(&name, &area, &unit, &crop, &soil, &irrigation, &status, &landActivities, &landCost); err != nil {
+			return table, err
+		}
+		areas[unit] += area
+		activities += landActivities
+		cost += landCost
+		table.rows = append(table.rows, []string{
+			name, f.Amount(area), unit, crop, soil, irrigation, reportLabel(status),
+			strconv.Itoa(landActivities), f.Amount(landCost),
+		})
+	}
+	if err := rows.Err(); err != nil {
+		return table, err
+	}
+
+	table.summary = append(table.summary, [2]string{"Arazi sayısı", strconv.Itoa(len(table.rows))})
+	for _, unit := range reportKeys(areas) {
+		table.summary = append(table.summary, [2]string{"Toplam alan (" + unit + ")", f.Amount(areas[unit])})
+	}
+	table.summary = append(table.summary,
+		[2]string{"Dönemde aktivite", strconv.Itoa(activities)},
+		[2]string{"Dönem aktivite maliyeti", f.Money(cost, "")},
+	)
+	return table, nil
+}
+
+// records tabloyu CSV/XLSX satırlarına çevirir; özet satırları tablodan bir boş satırla ayrılarak sona eklenir.
+// Sayı olmayan sütunlar ve özet başlıkları formül olarak yazılmaz
+func (t reportTable) records() [][]string {
+	header := make([]string, len(t.columns))
+	for i, column := range t.columns {
+		header[i] = column.title
+	}
+	records := [][]string{header}
+	for _, row := range t.rows {
+		record := make([]string, len(row))
+		for i, value := range row {
+			if i < len(t.columns) && !t.columns[i].numeric {
+				value = SpreadsheetText(value)
+			}
+			record[i] = value
+		}
+		records = append(records, record)
+	}
+	if len(t.summary) > 0 {
+		records = append(records, []string{})
+		for _, line := range t.summary {
+			records = append(records, []string{SpreadsheetText(line[0]), line[1]})
+		}
+	}
+	return records
+}
+
+// writeReportPDF raporu başlık, özet ve sayfalara bölünen kayıt tablosuyla A4 PDF olarak yazar
+func writeReportPDF(w io.Writer, f Formatter, report models.Report, farmName string, table reportTable, generatedAt time.Time) error {
+	doc := NewPDFDocument()
+	right := PDFPageWidth - reportMargin
+	contentWidth := right - reportMargin
+
+	doc.Text(reportMargin, 60, 18, true, reportTitles[report.Type])
+	doc.TextFit(reportMargin, 76, contentWidth/2, 10, false, farmName)
+	period := report.Period
+	if report.Parameters != nil && period == report.Parameters.StartDate+" / "+report.Parameters.EndDate {
+		period = f.Day(report.Parameters.StartDate) + " / " + f.Day(report.Parameters.EndDate)
+	}
+	doc.TextRight(right, 60, 10, false, "Dönem: "+period)
+	doc.TextRight(right, 76, 9, false, "Oluşturma: "+f.DateTime(generatedAt))
+	doc.Line(reportMargin, 86, right, 86, 1.5, 0)
+
+	y := 104.0
+	for i, line := range table.summary {
+		x := reportMargin + float64(i%2)*contentWidth/2
+		doc.Text(x, y, 9, false, line[0]+":")
+		doc.TextRight(x+contentWidth/2-12, y, 9, true, line[1])
+		if i%2 == 1 || i == len(table.summary)-1 {
+			y += 14
+		}
+	}
+	y += 10
+
+	var totalWidth float64
+	for _, column := range table.columns {
+		totalWidth += column.width
+	}
+	widths := make([]float64, len(table.columns))
+	for i, column := range table.columns {
+		widths[i] = column.width / totalWidth * contentWidth
+	}
+
+	header := func(y float64) float64 {
+		doc.FillRect(reportMargin, y, contentWidth, reportRowHeight+2, 0.9)
+		x := reportMargin
+		for i, column := range table.columns {
+			reportCell(doc, x, y+11, widths[i], 8, true, column, column.title)
+			x += widths[i]
+		}
+		return y + reportRowHeight + 2
+	}
+
+	y = header(y)
+	if len(table.rows) == 0 {
+		doc.Text(reportMargin+4, y+11, 9, false, "Dönemde kayıt bulunmuyor")
+	}
+	page := 1
+	for _, row := range table.rows {
+		if y+reportRowHeight > PDFPageHeight-reportMargin {
+			doc.TextRight(right, PDFPageHeight-20, 8, false, fmt.Sprintf("Sayfa %d", page))
+			doc.AddPage()
+			page++
+			y = header(reportMargin)
+		}
+		x := reportMargin
+		for i, column := range table.columns {
+			reportCell(doc, x, y+11, widths[i], 8, false, column, row[i])
+			x += widths[i]
+		}
+		doc.Line(reportMargin, y+reportRowHeight, right, y+reportRowHeight, 0.25, 0.8)
+		y += reportRowHeight
+	}
+	doc.TextRight(right, PDFPageHeight-20, 8, false, fmt.Sprintf("Sayfa %d", page))
+
+	return doc.Write(w)
+}
+
+// reportCell hücre metnini sütun genişliğine sığdırarak yazar; sayısal sütunlar sağa yaslanır
+func reportCell(doc *PDFDocument, x, y, width, size float64, bold bool, column reportColumn, text string) {
+	text = PDFTruncate(text, width-8, size, bold)
+	if column.numeric {
+		doc.TextRight(x+width-4, y, size, bold, text)
+		return
+	}
+	doc.Text(x+4, y, size, bold, text)
+}
+
+// reportLabels işlem türü ve durum değerlerinin rapor etiketleri
+var reportLabels = map[string]string{
+	"income":    "Gelir",
+	"expense":   "Gider",
+	"completed": "Tamamlandı",
+	"pending":   "Bekliyor",
+	"cancelled": "İptal",
+	"active":    "Aktif",
+	"inactive":  "Pasif",
+}
+
+// reportLabel değerin rapor etiketi; etiketi olmayan değerler olduğu gibi döner
+func reportLabel(value string) string {
+	if label, ok := reportLabels[value]; ok {
+		return label
+	}
+	return value
+}
+
+// formatReportAmount tutarı iki ondalıkla yazar; bildirim ve uyarı metinlerinde kullanılır
+func formatReportAmount(value float64) string {
+	return strconv.FormatFloat(value, 'f', 2, 64)
+}
+
+// reportKeys haritalardaki anahtarları (para birimi veya birim) sıralı döner
+func reportKeys(maps ...map[string]float64) []string {
+	seen := map[string]bool{}
+	var keys []string
+	for _, values := range maps {
+		for key := range values {
+			if !seen[key] {
+				seen[key] = true
+				keys = append(keys, key)
+			}
+		}
+	}
+	sort.Strings(keys)
+	return keys
+}
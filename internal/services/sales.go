@@ -0,0 +1,606 @@
+package services
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"agri-management-api/internal/models"
+	"agri-management-api/internal/utils"
+)
+
+// SalesOrderCategory ödenen siparişlerden oluşturulan gelir işlemlerinin kategorisi; üretimden satışlarla aynıdır
+const SalesOrderCategory = "Ürün Satışı"
+
+// Satış hataları
+var (
+	ErrCustomerNotFound   = errors.New("customer not found")
+	ErrSalesOrderNotFound = errors.New("sales order not found")
+	// ErrSalesProductionNotFound siparişteki üretim partisi bulunamadı
+	ErrSalesProductionNotFound = errors.New("production not found")
+	// ErrSalesInsufficientStock sipariş miktarı üretim partisinin kalan stoğundan fazla
+	ErrSalesInsufficientStock = errors.New("insufficient stock")
+	// ErrSalesOrderClosed ödenmiş veya iptal edilmiş sipariş değiştirilemez
+	ErrSalesOrderClosed = errors.New("sales order is closed")
+	// ErrSalesOrderPaid ödenmiş sipariş silinemez
+	ErrSalesOrderPaid = errors.New("sales order is paid")
+	// ErrCustomerHasOrders siparişi olan müşteri silinemez
+	ErrCustomerHasOrders = errors.New("customer has orders")
+	// ErrSalesOrderInvoiced faturası kesilmiş sipariş değiştirilemez, iptal edilemez ve silinemez
+	ErrSalesOrderInvoiced = errors.New("sales order is invoiced")
+	// ErrSalesInvalidDueDate vade tarihi sipariş tarihinden önce
+	ErrSalesInvalidDueDate = errors.New("Vade tarihi sipariş tarihinden önce olamaz")
+)
+
+// SalesService müşterileri ve müşterilere üretim partilerinden yapılan satış siparişlerini yönetir. Sipariş
+// miktarı kaydedilince partinin stoğundan düşülür, iptal edilince geri eklenir; ödendi olarak işaretlenen sipariş
+// için finans modülünde gelir işlemi oluşturulur
+type SalesService struct {
+	db *sql.DB
+}
+
+// NewSalesService yeni sales service oluşturur
+func NewSalesService(db *sql.DB) *SalesService {
+	return &SalesService{db: db}
+}
+
+// customerSelect müşteri sütunları ve iptal edilmemiş siparişlerden hesaplanan özetler
+const customerSelect = `
+	SELECT c.id, c.name, c.customer_type, COALESCE(c.tax_id, ''), COALESCE(c.phone, ''), COALESCE(c.email, ''),
+	       COALESCE(c.address, ''), COALESCE(c.notes, ''), c.created_at, c.updated_at,
+	       COUNT(o.id), COALESCE(SUM(o.total), 0),
+	       COALESCE(SUM(CASE WHEN o.payment_status = 'pending' THEN o.total ELSE 0 END), 0)
+	FROM customers c
+	LEFT JOIN sales_orders o ON o.customer_id = c.id AND o.payment_status != 'cancelled'`
+
+// scanCustomer müşteri satırını okur; vergi numarası, telefon ve adres çözülür
+func scanCustomer(scanner interface{ Scan(...interface{}) error }) (models.Customer, error) {
+	var customer models.Customer
+	err := scanner.Scan(&customer.ID, &customer.Name, &customer.Type, &customer.TaxID, &customer.Phone, &customer.Email,
+		&customer.Address, &customer.Notes, &customer.CreatedAt, &customer.UpdatedAt,
+		&customer.OrderCount, &customer.TotalSales, &customer.Outstanding)
+	if err != nil {
+		return customer, err
+	}
+	customer.TotalSales = round2(customer.TotalSales)
+	customer.Outstanding = round2(customer.Outstanding)
+	customer.TaxID = RevealField(ColumnCustomerTaxID, customer.TaxID)
+	customer.Phone = RevealField(ColumnCustomerPhone, customer.Phone)
+	customer.Address = RevealField(ColumnCustomerAddress, customer.Address)
+	return customer, nil
+}
+
+// Customers çiftliğin müşterilerini ada göre listeler; search verilirse ad, telefon veya e-postada aranır.
+// Telefon şifreli saklandığından arama çözülmüş değerler üzerinde yapılır
+func (s *SalesService) Customers(farmID, search string) ([]models.Customer, error) {
+	rows, err := s.db.Query(customerSelect+" WHERE c.user_id = ? GROUP BY c.id ORDER BY c.name COLLATE NOCASE", farmID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	search = strings.ToLower(strings.TrimSpace(search))
+	customers := []models.Customer{}
+	for rows.Next() {
+		customer, err := scanCustomer(rows)
+		if err != nil {
+			return nil, err
+		}
+		if search != "" && !strings.Contains(strings.ToLower(customer.Name), search) &&
+			!strings.Contains(customer.Phone, search) && !strings.Contains(strings.ToLower(customer.Email), search) {
+			continue
+		}
+		customers = append(customers, customer)
+	}
+	return customers, rows.Err()
+}
+
+// Customer çiftliğin müşterisini döner
+func (s *SalesService) Customer(farmID, id string) (*models.Customer, error) {
+	customer, err := scanCustomer(s.db.QueryRow(customerSelect+" WHERE c.id = ? AND c.user_id = ? GROUP BY c.id", id, farmID))
+	if err == sql.ErrNoRows {
+		return nil, ErrCustomerNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &customer, nil
+}
+
+// customerValues müşteri isteğini veritabanı değerlerine çevirir; vergi numarası, telefon ve adres şifrelenir
+func customerValues(req models.CustomerRequest) ([]interface{}, error) {
+	if req.Type == "" {
+		req.Type = models.CustomerTypeIndividual
+	}
+	taxID, err := EncryptField(ColumnCustomerTaxID, strings.TrimSpace(req.TaxID))
+	if err != nil {
+		return nil, err
+	}
+	phone, err := EncryptField(ColumnCustomerPhone, strings.TrimSpace(req.Phone))
+	if err != nil {
+		return nil, err
+	}
+	address, err := EncryptField(ColumnCustomerAddress, strings.TrimSpace(req.Address))
+	if err != nil {
+		return nil, err
+	}
+	return []interface{}{
+		strings.TrimSpace(req.Name), req.Type, taxID, phone,
+		strings.ToLower(strings.TrimSpace(req.Email)), address, strings.TrimSpace(req.Notes),
+	}, nil
+}
+
+// CreateCustomer müşteri ekler
+func (s *SalesService) CreateCustomer(farmID string, req models.CustomerRequest) (*models.Customer, error) {
+	values, err := customerValues(req)
+	if err != nil {
+		return nil, err
+	}
+
+	id := utils.GenerateID()
+	_, err = s.db.Exec(`
+		INSERT INTO customers (id, user_id, name, customer_type, tax_id, phone, email, address, notes, created_at, updated_at)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, CURRENT_TIMESTAMP, CURRENT_TIMESTAMP)
+	`, append([]interface{}{id, farmID}, values...)...)
+	if err != nil {
+		return nil, err
+	}
+	return s.Customer(farmID, id)
+}
+
+// UpdateCustomer müşteriyi günceller
+func (s *SalesService) UpdateCustomer(farmID, id string, req models.CustomerRequest) (*models.Customer, error) {
+	values, err := customerValues(req)
+	if err != nil {
+		return nil, err
+	}
+
+	result, err := s.db.Exec(`
+		UPDATE customers
+		SET name = ?, customer_type = ?, tax_id = ?, phone = ?, email = ?, address = ?, notes = ?, updated_at = CURRENT_TIMESTAMP
+		WHERE id = ? AND user_id = ?
+	`, append(values, id, farmID)...)
+	if err != nil {
+		return nil, err
+	}
+	if affected, _ := result.RowsAffected(); affected == 0 {
+		return nil, ErrCustomerNotFound
+	}
+	return s.Customer(farmID, id)
+}
+
+// DeleteCustomer müşteriyi siler; siparişi olan müşteri silinemez
+func (s *SalesService) DeleteCustomer(farmID, id string) error {
+	var orders int
+	if err := s.db.QueryRow("SELECT COUNT(*) FROM sales_orders WHERE customer_id = ? AND user_id = ?", id, farmID).Scan(&orders); err != nil {
+		return err
+	}
+	if orders > 0 {
+		return ErrCustomerHasOrders
+	}
+
+	result, err := s.db.Exec("DELETE FROM customers WHERE id = ? AND user_id = ?", id, farmID)
+	if err != nil {
+		return err
+	}
+	if affected, _ := result.RowsAffected(); affected == 0 {
+		return ErrCustomerNotFound
+	}
+	return nil
+}
+
+// salesOrderSelect sipariş sütunları
+const salesOrderSelect = `
+	SELECT o.id, o.order_number, o.customer_id, COALESCE(c.name, ''), o.production_id, COALESCE(p.name, ''), o.order_date,
+	       o.quantity, COALESCE(o.unit, ''), o.unit_price, o.subtotal, o.tax_rate, o.tax_amount, o.total, o.currency,
+	       o.payment_status, o.due_date, o.paid_at, COALESCE(o.payment_method, ''), o.transaction_id, COALESCE(o.notes, ''),
+	       o.created_at, o.updated_at
+	FROM sales_orders o
+	LEFT JOIN customers c ON c.id = o.customer_id
+	LEFT JOIN production p ON p.id = o.production_id`
+
+// scanSalesOrder sipariş satırını okur
+func scanSalesOrder(scanner interface{ Scan(...interface{}) error }) (models.SalesOrder, error) {
+	var order models.SalesOrder
+	var dueDate, paidAt sql.NullTime
+	var transactionID sql.NullString
+	err := scanner.Scan(&order.ID, &order.OrderNumber, &order.CustomerID, &order.CustomerName, &order.ProductionID,
+		&order.ProductName, &order.Date, &order.Quantity, &order.Unit, &order.UnitPrice, &order.Subtotal, &order.TaxRate,
+		&order.TaxAmount, &order.Total, &order.Currency, &order.PaymentStatus, &dueDate, &paidAt, &order.PaymentMethod,
+		&transactionID, &order.Notes, &order.CreatedAt, &order.UpdatedAt)
+	if err != nil {
+		return order, err
+	}
+	order.DueDate = utils.NullTimeToPtr(dueDate)
+	order.PaidAt = utils.NullTimeToPtr(paidAt)
+	if transactionID.Valid {
+		order.TransactionID = &transactionID.String
+	}
+	return order, nil
+}
+
+// Orders siparişleri filtreleyerek tarihe göre yeniden eskiye sayfalı listeler
+func (s *SalesService) Orders(farmID string, filter models.SalesOrderFilter) (models.SalesOrderListResponse, error) {
+	where := " WHERE o.user_id = ?"
+	args := []interface{}{farmID}
+	if filter.CustomerID != "" {
+		where += " AND o.customer_id = ?"
+		args = append(args, filter.CustomerID)
+	}
+	if filter.ProductionID != "" {
+		where += " AND o.production_id = ?"
+		args = append(args, filter.ProductionID)
+	}
+	if filter.PaymentStatus != "" {
+		where += " AND o.payment_status = ?"
+		args = append(args, filter.PaymentStatus)
+	}
+	if filter.StartDate != nil {
+		where += " AND date(o.order_date) >= ?"
+		args = append(args, filter.StartDate.Format("2006-01-02"))
+	}
+	if filter.EndDate != nil {
+		where += " AND date(o.order_date) <= ?"
+		args = append(args, filter.EndDate.Format("2006-01-02"))
+	}
+
+	response := models.SalesOrderListResponse{Orders: []models.SalesOrder{}}
+	var total int
+	if err := s.db.QueryRow("SELECT COUNT(*) FROM sales_orders o"+where, args...).Scan(&total); err != nil {
+		return response, err
+	}
+	response.Pagination = utils.CalculatePagination(filter.Page, filter.Limit, total)
+
+	rows, err := s.db.Query(salesOrderSelect+where+" ORDER BY o.order_date DESC, o.created_at DESC LIMIT ? OFFSET ?",
+		append(args, filter.Limit, (filter.Page-1)*filter.Limit)...)
+	if err != nil {
+		return response, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		order, err := scanSalesOrder(rows)
+		if err != nil {
+			return response, err
+		}
+		response.Orders = append(response.Orders, order)
+	}
+	return response, rows.Err()
+}
+
+// Order çiftliğin siparişini döner
+func (s *SalesService) Order(farmID, id string) (*models.SalesOrder, error) {
+	order, err := scanSalesOrder(s.db.QueryRow(salesOrderSelect+" WHERE o.id = ? AND o.user_id = ?", id, farmID))
+	if err == sql.ErrNoRows {
+		return nil, ErrSalesOrderNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &order, nil
+}
+
+// salesOrderLine siparişin tutarlarını hesaplar
+func salesOrderLine(req models.SalesOrderRequest) (date time.Time, currency string, subtotal, taxAmount float64, err error) {
+	date = time.Now()
+	if req.Date != nil {
+		date = *req.Date
+	}
+	if req.DueDate != nil && req.DueDate.Before(date.Truncate(24*time.Hour)) {
+		return date, "", 0, 0, ErrSalesInvalidDueDate
+	}
+	currency = strings.ToUpper(strings.TrimSpace(req.Currency))
+	if currency == "" {
+		currency = "TRY"
+	}
+	subtotal = round2(req.Quantity * req.UnitPrice)
+	taxAmount = round2(subtotal * req.TaxRate / 100)
+	return date, currency, subtotal, taxAmount, nil
+}
+
+// reserveStock sipariş miktarını üretim partisinin stoğundan düşer; stok tükenirse parti satıldı olur.
+// Partinin birimini döner
+func reserveStock(tx *sql.Tx, farmID, productionID string, quantity float64) (string, error) {
+	var unit string
+	var stock float64
+	err := tx.QueryRow(`
+		SELECT COALESCE(unit, ''), amount - COALESCE(sold_amount, 0) - COALESCE(lost_amount, 0)
+		FROM production WHERE id = ? AND user_id = ?
+	`, productionID, farmID).Scan(&unit, &stock)
+	if err == sql.ErrNoRows {
+		return "", ErrSalesProductionNotFound
+	}
+	if err != nil {
+		return "", err
+	}
+	if quantity > stock+0.000001 {
+		return "", ErrSalesInsufficientStock
+	}
+
+	_, err = tx.Exec(`
+		UPDATE production
+		SET sold_amount = COALESCE(sold_amount, 0) + ?,
+		    status = CASE WHEN amount - COALESCE(sold_amount, 0) - COALESCE(lost_amount, 0) - ? <= 0.000001 THEN 'sold' ELSE status END,
+		    updated_at = CURRENT_TIMESTAMP
+		WHERE id = ? AND user_id = ?
+	`, quantity, quantity, productionID, farmID)
+	return unit, err
+}
+
+// releaseStock iptal edilen veya silinen siparişin miktarını üretim partisinin stoğuna geri ekler
+func releaseStock(tx *sql.Tx, farmID, productionID string, quantity float64) error {
+	_, err := tx.Exec(`
+		UPDATE production
+		SET sold_amount = MAX(COALESCE(sold_amount, 0) - ?, 0),
+		    status = CASE WHEN status = 'sold' THEN 'active' ELSE status END,
+		    updated_at = CURRENT_TIMESTAMP
+		WHERE id = ? AND user_id = ?
+	`, quantity, productionID, farmID)
+	return err
+}
+
+// customerExists müşterinin çiftliğe ait olduğunu doğrular
+func (s *SalesService) customerExists(tx *sql.Tx, farmID, customerID string) error {
+	var exists bool
+	err := tx.QueryRow("SELECT 1 FROM customers WHERE id = ? AND user_id = ?", customerID, farmID).Scan(&exists)
+	if err == sql.ErrNoRows {
+		return ErrCustomerNotFound
+	}
+	return err
+}
+
+// orderInvoice siparişten kesilen faturanın kimliğini döner; fatura yoksa boş döner
+func (s *SalesService) orderInvoice(farmID, orderID string) (string, error) {
+	var invoiceID string
+	err := s.db.QueryRow("SELECT id FROM invoices WHERE sales_order_id = ? AND user_id = ?", orderID, farmID).Scan(&invoiceID)
+	if err == sql.ErrNoRows {
+		return "", nil
+	}
+	return invoiceID, err
+}
+
+// nextSalesOrderNumber çiftliğin o yıldaki sıradaki sipariş numarasını üretir (SS-2024-0001)
+func nextSalesOrderNumber(tx *sql.Tx, farmID string, year int) (string, error) {
+	return nextYearlyNumber(tx, "sales_orders", "order_number", farmID, fmt.Sprintf("SS-%d-", year))
+}
+
+// nextYearlyNumber tablodaki prefix ile başlayan en büyük numaranın bir fazlasını döner (ör. SS-2024-0001);
+// sıra numarası sayı olarak karşılaştırılır, 9999'dan sonra SS-2024-10000 gelir. table ve column sabit değerlerdir
+func nextYearlyNumber(tx *sql.Tx, table, column, farmID, prefix string) (string, error) {
+	var last sql.NullInt64
+	err := tx.QueryRow("SELECT MAX(CAST(SUBSTR("+column+", ?) AS INTEGER)) FROM "+table+" WHERE user_id = ? AND "+column+" LIKE ?",
+		len(prefix)+1, farmID, prefix+"%").Scan(&last)
+	if err != nil {
+		return "", err
+	}
+
+	return fmt.Sprintf("%s%04d", prefix, last.Int64+1), nil
+}
+
+// CreateOrder sipariş kaydeder ve miktarı üretim partisinin stoğundan düşer; sipariş ödenmemiş olarak başlar
+func (s *SalesService) CreateOrder(farmID string, req models.SalesOrderRequest) (*models.SalesOrder, error) {
+	date, currency, subtotal, taxAmount, err := salesOrderLine(req)
+	if err != nil {
+		return nil, err
+	}
+
+	tx, err := s.db.Begin()
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	if err := s.customerExists(tx, farmID, req.CustomerID); err != nil {
+		return nil, err
+	}
+	unit, err := reserveStock(tx, farmID, req.ProductionID, req.Quantity)
+	if err != nil {
+		return nil, err
+	}
+	orderNumber, err := nextSalesOrderNumber(tx, farmID, date.Year())
+	if err != nil {
+		return nil, err
+	}
+
+	id := utils.GenerateID()
+	_, err = tx.Exec(`
+		INSERT INTO sales_orders (id, user_id, order_number, customer_id, production_id, order_date, quantity, unit,
+		                          unit_price, subtotal, tax_rate, tax_amount, total, currency, payment_status, due_date,
+		                          notes, created_at, updated_at)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, CURRENT_TIMESTAMP, CURRENT_TIMESTAMP)
+	`, id, farmID, orderNumber, req.CustomerID, req.ProductionID, date, req.Quantity, unit, req.UnitPrice, subtotal,
+		req.TaxRate, taxAmount, round2(subtotal+taxAmount), currency, models.SalesOrderPending, req.DueDate,
+		strings.TrimSpace(req.Notes))
+	if err != nil {
+		return nil, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, err
+	}
+	return s.Order(farmID, id)
+}
+
+// UpdateOrder ödenmemiş ve faturası kesilmemiş siparişi günceller; eski miktar partiye geri eklenip yeni miktar düşülür
+func (s *SalesService) UpdateOrder(farmID, id string, req models.SalesOrderRequest) (*models.SalesOrder, error) {
+	current, err := s.Order(farmID, id)
+	if err != nil {
+		return nil, err
+	}
+	if current.PaymentStatus != models.SalesOrderPending {
+		return nil, ErrSalesOrderClosed
+	}
+	if invoice, err := s.orderInvoice(farmID, id); err != nil || invoice != "" {
+		if err == nil {
+			err = ErrSalesOrderInvoiced
+		}
+		return nil, err
+	}
+	if req.Date == nil {
+		req.Date = &current.Date
+	}
+	date, currency, subtotal, taxAmount, err := salesOrderLine(req)
+	if err != nil {
+		return nil, err
+	}
+
+	tx, err := s.db.Begin()
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	if err := s.customerExists(tx, farmID, req.CustomerID); err != nil {
+		return nil, err
+	}
+	if err := releaseStock(tx, farmID, current.ProductionID, current.Quantity); err != nil {
+		return nil, err
+	}
+	unit, err := reserveStock(tx, farmID, req.ProductionID, req.Quantity)
+	if err != nil {
+		return nil, err
+	}
+
+	// Durum koşulu eşzamanlı ödeme işaretlemesine karşı güncellemeyle birlikte kontrol edilir
+	result, err := tx.Exec(`
+		UPDATE sales_orders
+		SET customer_id = ?, production_id = ?, order_date = ?, quantity = ?, unit = ?, unit_price = ?, subtotal = ?,
+		    tax_rate = ?, tax_amount = ?, total = ?, currency = ?, due_date = ?, notes = ?, updated_at = CURRENT_TIMESTAMP
+		WHERE id = ? AND user_id = ? AND payment_status = ?
+	`, req.CustomerID, req.ProductionID, date, req.Quantity, unit, req.UnitPrice, subtotal, req.TaxRate, taxAmount,
+		round2(subtotal+taxAmount), currency, req.DueDate, strings.TrimSpace(req.Notes), id, farmID, models.SalesOrderPending)
+	if err != nil {
+		return nil, err
+	}
+	if affected, _ := result.RowsAffected(); affected == 0 {
+		return nil, ErrSalesOrderClosed
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, err
+	}
+	return s.Order(farmID, id)
+}
+
+// UpdateOrderStatus ödenmemiş siparişi ödendi veya iptal olarak işaretler. Ödenen sipariş için ödeme tarihli,
+// sipariş numarasının (faturası kesilmişse fatura numarasının) fiş numarası olduğu tamamlanmış gelir işlemi
+// oluşturulur; iptal edilen siparişin miktarı üretim partisinin stoğuna geri eklenir. Ödenmiş ve iptal edilmiş
+// siparişlerin durumu değiştirilemez, faturası kesilmiş sipariş iptal edilemez
+func (s *SalesService) UpdateOrderStatus(farmID, id string, req models.SalesOrderStatusRequest) (*models.SalesOrder, error) {
+	order, err := s.Order(farmID, id)
+	if err != nil {
+		return nil, err
+	}
+	if order.PaymentStatus != models.SalesOrderPending {
+		return nil, ErrSalesOrderClosed
+	}
+	invoiceID, err := s.orderInvoice(farmID, id)
+	if err != nil {
+		return nil, err
+	}
+	if invoiceID != "" && req.PaymentStatus == models.SalesOrderCancelled {
+		return nil, ErrSalesOrderInvoiced
+	}
+
+	tx, err := s.db.Begin()
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	var transactionID interface{}
+	var paidAt interface{}
+	if req.PaymentStatus == models.SalesOrderPaid {
+		paid := time.Now()
+		if req.PaidAt != nil {
+			paid = *req.PaidAt
+		}
+		paidAt = paid
+
+		// Faturası kesilmiş siparişte fiş numarası fatura numarasıdır ve işlem faturaya bağlanır
+		receipt := order.OrderNumber
+		if invoiceID != "" {
+			if err := tx.QueryRow("SELECT invoice_number FROM invoices WHERE id = ? AND user_id = ?", invoiceID, farmID).Scan(&receipt); err != nil {
+				return nil, err
+			}
+		}
+
+		txID := utils.GenerateID()
+		transactionID = txID
+		description := fmt.Sprintf("%s satışı - %s %s (%s)", order.ProductName, formatPayrollNumber(order.Quantity), order.Unit, order.CustomerName)
+		_, err := tx.Exec(`
+			INSERT INTO transactions (id, user_id, type, category, description, amount, currency,
+			                         date, status, payment_method, receipt, notes, paid_at, created_at, updated_at)
+			VALUES (?, ?, 'income', ?, ?, ?, ?, ?, 'completed', ?, ?, ?, ?, CURRENT_TIMESTAMP, CURRENT_TIMESTAMP)
+		`, txID, farmID, SalesOrderCategory, description, order.Total, order.Currency, paid, req.PaymentMethod,
+			receipt, order.Notes, paid)
+		if err != nil {
+			return nil, err
+		}
+		if invoiceID != "" {
+			_, err := tx.Exec("INSERT INTO invoice_transactions (invoice_id, transaction_id, user_id) VALUES (?, ?, ?)", invoiceID, txID, farmID)
+			if err != nil {
+				return nil, err
+			}
+		}
+	} else if err := releaseStock(tx, farmID, order.ProductionID, order.Quantity); err != nil {
+		return nil, err
+	}
+
+	result, err := tx.Exec(`
+		UPDATE sales_orders
+		SET payment_status = ?, paid_at = ?, payment_method = ?, transaction_id = ?, updated_at = CURRENT_TIMESTAMP
+		WHERE id = ? AND user_id = ? AND payment_status = ?
+	`, req.PaymentStatus, paidAt, req.PaymentMethod, transactionID, id, farmID, models.SalesOrderPending)
+	if err != nil {
+		return nil, err
+	}
+	if affected, _ := result.RowsAffected(); affected == 0 {
+		return nil, ErrSalesOrderClosed
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, err
+	}
+	return s.Order(farmID, id)
+}
+
+// DeleteOrder ödenmemiş veya iptal edilmiş siparişi siler; ödenmemiş siparişin miktarı stoğa geri eklenir.
+// Gelir işlemi oluşturulmuş ödenmiş siparişler ve faturası kesilmiş siparişler silinemez
+func (s *SalesService) DeleteOrder(farmID, id string) error {
+	order, err := s.Order(farmID, id)
+	if err != nil {
+		return err
+	}
+	if order.PaymentStatus == models.SalesOrderPaid {
+		return ErrSalesOrderPaid
+	}
+	if invoice, err := s.orderInvoice(farmID, id); err != nil || invoice != "" {
+		if err == nil {
+			err = ErrSalesOrderInvoiced
+		}
+		return err
+	}
+
+	tx, err := s.db.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	result, err := tx.Exec("DELETE FROM sales_orders WHERE id = ? AND user_id = ? AND payment_status = ?", id, farmID, order.PaymentStatus)
+	if err != nil {
+		return err
+	}
+	if affected, _ := result.RowsAffected(); affected == 0 {
+		return ErrSalesOrderClosed
+	}
+	if order.PaymentStatus == models.SalesOrderPending {
+		if err := releaseStock(tx, farmID, order.ProductionID, order.Quantity); err != nil {
+			return err
+		}
+	}
+	return tx.Commit()
+}
@@ -0,0 +1,597 @@
+package services
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"agri-management-api/internal/models"
+	"agri-management-api/internal/utils"
+)
+
+// ScoutingPointEntity gözlem noktası fotoğraflarının media_attachments tablosundaki kayıt türü
+const ScoutingPointEntity = "scouting_point"
+
+// defaultScoutingTaskType görev türü verilmeyen dönüştürmelerde oluşturulan arazi aktivitesinin türü
+const defaultScoutingTaskType = "scouting"
+
+// scoutingSeverityRank en yüksek şiddeti belirlemek için seviye sıralaması
+var scoutingSeverityRank = map[string]int{
+	models.ScoutingSeverityLow:    1,
+	models.ScoutingSeverityMedium: 2,
+	models.ScoutingSeverityHigh:   3,
+}
+
+var (
+	// ErrScoutingSessionNotFound keşif turu yok veya çiftliğe ait değil
+	ErrScoutingSessionNotFound = errors.New("keşif turu bulunamadı")
+	// ErrScoutingPointNotFound gözlem noktası yok veya keşif turuna ait değil
+	ErrScoutingPointNotFound = errors.New("gözlem noktası bulunamadı")
+	// ErrScoutingLand keşif turunun arazisi bulunamadı
+	ErrScoutingLand = errors.New("arazi bulunamadı")
+	// ErrScoutingPointConverted nokta aynı türde bir kayda zaten dönüştürülmüş
+	ErrScoutingPointConverted = errors.New("gözlem noktası zaten dönüştürülmüş")
+	// ErrScoutingIssueName zararlı/hastalık gözlemi için ad verilmemiş
+	ErrScoutingIssueName = errors.New("gözlem adı gerekli")
+)
+
+// scoutingSessionSelect keşif turu sütunları; nokta sayısı alt sorguyla hesaplanır
+const scoutingSessionSelect = `
+	SELECT s.id, s.land_id, l.name, date(s.scouted_on), COALESCE(s.scout_name, ''), COALESCE(s.notes, ''),
+	       (SELECT COUNT(*) FROM scouting_points p WHERE p.session_id = s.id), s.created_at, s.updated_at
+	FROM scouting_sessions s
+	JOIN lands l ON l.id = s.land_id AND l.user_id = s.user_id`
+
+// scoutingPointSelect gözlem noktası sütunları
+const scoutingPointSelect = `
+	SELECT p.id, p.session_id, p.latitude, p.longitude, p.severity, COALESCE(p.note, ''), p.issue_id, p.task_id, p.created_at
+	FROM scouting_points p`
+
+// ScoutingService arazi keşif turlarını, konumlu gözlem noktalarını, harita katmanını ve noktaların
+// zararlı/hastalık gözlemine veya arazi görevine dönüştürülmesini yönetir
+type ScoutingService struct {
+	db *sql.DB
+}
+
+// NewScoutingService yeni scouting service oluşturur
+func NewScoutingService(db *sql.DB) *ScoutingService {
+	return &ScoutingService{db: db}
+}
+
+// List arazinin keşif turlarını en yeniden eskiye nokta sayısı ve en yüksek şiddetle döner
+func (s *ScoutingService) List(farmID, landID string) ([]models.ScoutingSession, error) {
+	if err := s.checkLand(farmID, landID); err != nil {
+		return nil, err
+	}
+
+	sessions, err := s.querySessions(scoutingSessionSelect+" WHERE s.user_id = ? AND s.land_id = ? ORDER BY s.scouted_on DESC, s.created_at DESC", farmID, landID)
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := s.db.Query(`
+		SELECT p.session_id, p.severity FROM scouting_points p
+		JOIN scouting_sessions s ON s.id = p.session_id
+		WHERE p.user_id = ? AND s.land_id = ?
+	`, farmID, landID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	maxSeverity := map[string]string{}
+	for rows.Next() {
+		var sessionID, severity string
+		if err := rows.Scan(&sessionID, &severity); err != nil {
+			return nil, err
+		}
+		if scoutingSeverityRank[severity] > scoutingSeverityRank[maxSeverity[sessionID]] {
+			maxSeverity[sessionID] = severity
+		}
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	for i := range sessions {
+		sessions[i].MaxSeverity = maxSeverity[sessions[i].ID]
+	}
+	return sessions, nil
+}
+
+// Get keşif turunu noktaları ve nokta fotoğraflarıyla döner
+func (s *ScoutingService) Get(farmID, id string) (*models.ScoutingSession, error) {
+	sessions, err := s.querySessions(scoutingSessionSelect+" WHERE s.id = ? AND s.user_id = ?", id, farmID)
+	if err != nil {
+		return nil, err
+	}
+	if len(sessions) == 0 {
+		return nil, ErrScoutingSessionNotFound
+	}
+	session := sessions[0]
+
+	session.Points, err = s.queryPoints(farmID, scoutingPointSelect+" WHERE p.session_id = ? AND p.user_id = ? ORDER BY p.created_at, p.id", id, farmID)
+	if err != nil {
+		return nil, err
+	}
+	for _, point := range session.Points {
+		if scoutingSeverityRank[point.Severity] > scoutingSeverityRank[session.MaxSeverity] {
+			session.MaxSeverity = point.Severity
+		}
+	}
+	return &session, nil
+}
+
+// Create araziye keşif turu ve istekteki gözlem noktalarını ekler
+func (s *ScoutingService) Create(farmID, landID string, req models.ScoutingSessionRequest) (*models.ScoutingSession, error) {
+	if err := s.checkLand(farmID, landID); err != nil {
+		return nil, err
+	}
+
+	tx, err := s.db.Begin()
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	id := utils.GenerateID()
+	_, err = tx.Exec(`
+		INSERT INTO scouting_sessions (id, user_id, land_id, scouted_on, scout_name, notes, created_at, updated_at)
+		VALUES (?, ?, ?, ?, ?, ?, CURRENT_TIMESTAMP, CURRENT_TIMESTAMP)
+	`, id, farmID, landID, req.ScoutedOn, strings.TrimSpace(req.ScoutName), req.Notes)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, point := range req.Points {
+		if _, err := insertScoutingPoint(tx, farmID, id, point); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, err
+	}
+	return s.Get(farmID, id)
+}
+
+// Update keşif turunun tarihini, keşifçisini ve notlarını günceller; noktalar ayrı uçlarla yönetilir
+func (s *ScoutingService) Update(farmID, id string, req models.ScoutingSessionRequest) (*models.ScoutingSession, error) {
+	result, err := s.db.Exec(`
+		UPDATE scouting_sessions SET scouted_on = ?, scout_name = ?, notes = ?, updated_at = CURRENT_TIMESTAMP
+		WHERE id = ? AND user_id = ?
+	`, req.ScoutedOn, strings.TrimSpace(req.ScoutName), req.Notes, id, farmID)
+	if err != nil {
+		return nil, err
+	}
+	if rowsAffected, _ := result.RowsAffected(); rowsAffected == 0 {
+		return nil, ErrScoutingSessionNotFound
+	}
+	return s.Get(farmID, id)
+}
+
+// Delete keşif turunu ve noktalarını siler; noktalardan oluşturulan gözlem ve görevler korunur
+func (s *ScoutingService) Delete(farmID, id string) error {
+	result, err := s.db.Exec("DELETE FROM scouting_sessions WHERE id = ? AND user_id = ?", id, farmID)
+	if err != nil {
+		return err
+	}
+	if rowsAffected, _ := result.RowsAffected(); rowsAffected == 0 {
+		return ErrScoutingSessionNotFound
+	}
+	_, err = s.db.Exec("DELETE FROM scouting_points WHERE session_id = ? AND user_id = ?", id, farmID)
+	return err
+}
+
+// AddPoint keşif turuna gözlem noktası ekler
+func (s *ScoutingService) AddPoint(farmID, sessionID string, req models.ScoutingPointRequest) (*models.ScoutingPoint, error) {
+	if _, _, err := s.sessionInfo(farmID, sessionID); err != nil {
+		return nil, err
+	}
+
+	id, err := insertScoutingPoint(s.db, farmID, sessionID, req)
+	if err != nil {
+		return nil, err
+	}
+	s.touch(farmID, sessionID)
+	return s.getPoint(farmID, sessionID, id)
+}
+
+// UpdatePoint gözlem noktasının konumunu, şiddetini ve notunu günceller
+func (s *ScoutingService) UpdatePoint(farmID, sessionID, pointID string, req models.ScoutingPointRequest) (*models.ScoutingPoint, error) {
+	result, err := s.db.Exec(`
+		UPDATE scouting_points SET latitude = ?, longitude = ?, severity = ?, note = ?
+		WHERE id = ? AND session_id = ? AND user_id = ?
+	`, *req.Latitude, *req.Longitude, req.Severity, req.Note, pointID, sessionID, farmID)
+	if err != nil {
+		return nil, err
+	}
+	if rowsAffected, _ := result.RowsAffected(); rowsAffected == 0 {
+		return nil, ErrScoutingPointNotFound
+	}
+	s.touch(farmID, sessionID)
+	return s.getPoint(farmID, sessionID, pointID)
+}
+
+// DeletePoint gözlem noktasını siler
+func (s *ScoutingService) DeletePoint(farmID, sessionID, pointID string) error {
+	result, err := s.db.Exec("DELETE FROM scouting_points WHERE id = ? AND session_id = ? AND user_id = ?", pointID, sessionID, farmID)
+	if err != nil {
+		return err
+	}
+	if rowsAffected, _ := result.RowsAffected(); rowsAffected == 0 {
+		return ErrScoutingPointNotFound
+	}
+	s.touch(farmID, sessionID)
+	return nil
+}
+
+// Convert gözlem noktasından zararlı/hastalık gözlemi (issue) veya arazi görevi (task) oluşturur ve kaydı noktaya bağlar.
+// Gözlem keşif tarihiyle, noktanın ilk fotoğrafıyla ve "scouting" kaynağıyla oluşturulur; görev planlanmış
+// arazi aktivitesi olarak eklenir
+func (s *ScoutingService) Convert(farmID, sessionID, pointID string, req models.ScoutingConvertRequest) (*models.ScoutingConversion, error) {
+	landID, scoutedOn, err := s.sessionInfo(farmID, sessionID)
+	if err != nil {
+		return nil, err
+	}
+	point, err := s.getPoint(farmID, sessionID, pointID)
+	if err != nil {
+		return nil, err
+	}
+
+	var recordID string
+	switch req.Target {
+	case models.ScoutingTargetIssue:
+		if point.IssueID != nil {
+			return nil, ErrScoutingPointConverted
+		}
+		name := strings.TrimSpace(req.Name)
+		if name == "" {
+			return nil, ErrScoutingIssueName
+		}
+		category := req.Category
+		if category == "" {
+			category = models.ObservationCategoryOther
+		}
+		notes := point.Note
+		if req.Description != "" {
+			notes = req.Description
+		}
+		var photoID interface{}
+		if len(point.PhotoIDs) > 0 {
+			photoID = point.PhotoIDs[0]
+		}
+
+		recordID = utils.GenerateID()
+		_, err = s.db.Exec(`
+			INSERT INTO pest_disease_observations (id, user_id, subject_type, land_id, name, category, status, source,
+			                                       photo_id, candidates, observed_on, notes, created_at, updated_at)
+			VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, '[]', ?, ?, CURRENT_TIMESTAMP, CURRENT_TIMESTAMP)
+		`, recordID, farmID, models.DiagnosisSubjectPlant, landID, name, category, models.ObservationStatusSuspected,
+			models.ObservationSourceScouting, photoID, scoutedOn, notes)
+		if err != nil {
+			return nil, err
+		}
+		_, err = s.db.Exec("UPDATE scouting_points SET issue_id = ? WHERE id = ? AND user_id = ?", recordID, pointID, farmID)
+
+	case models.ScoutingTargetTask:
+		if point.TaskID != nil {
+			return nil, ErrScoutingPointConverted
+		}
+		taskType := strings.TrimSpace(req.Type)
+		if taskType == "" {
+			taskType = defaultScoutingTaskType
+		}
+		description := req.Description
+		if description == "" {
+			description = fmt.Sprintf("Keşif bulgusu (%s, %.6f, %.6f)", point.Severity, point.Latitude, point.Longitude)
+			if point.Note != "" {
+				description += ": " + point.Note
+			}
+		}
+		dueDate := req.DueDate
+		if dueDate == "" {
+			dueDate = scoutedOn
+		}
+		scheduled, _ := time.Parse("2006-01-02", dueDate)
+
+		recordID = utils.GenerateID()
+		_, err = s.db.Exec(`
+			INSERT INTO land_activities (id, land_id, type, description, scheduled_date, notes, result, created_at)
+			VALUES (?, ?, ?, ?, ?, ?, '', CURRENT_TIMESTAMP)
+		`, recordID, landID, taskType, description, scheduled, point.Note)
+		if err != nil {
+			return nil, err
+		}
+		_, err = s.db.Exec("UPDATE scouting_points SET task_id = ? WHERE id = ? AND user_id = ?", recordID, pointID, farmID)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	point, err = s.getPoint(farmID, sessionID, pointID)
+	if err != nil {
+		return nil, err
+	}
+	return &models.ScoutingConversion{Target: req.Target, RecordID: recordID, Point: *point}, nil
+}
+
+// Map arazinin gözlem noktalarını harita katmanı olarak GeoJSON FeatureCollection biçiminde döner. Noktalar
+// [boylam, enlem] sırasındaki Point geometrileridir; arazinin sınırı kayıtlıysa "land_boundary" türünde Polygon
+// olarak eklenir ve her noktanın sınır içinde olup olmadığı insideBoundary özelliğinde belirtilir
+func (s *ScoutingService) Map(farmID, landID, sessionID string, from, to *time.Time) (*models.GeoFeatureCollection, error) {
+	var landName, boundaryJSON string
+	err := s.db.QueryRow("SELECT name, COALESCE(boundary, '') FROM lands WHERE id = ? AND user_id = ?", landID, farmID).Scan(&landName, &boundaryJSON)
+	if err == sql.ErrNoRows {
+		return nil, ErrScoutingLand
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	collection := &models.GeoFeatureCollection{Type: "FeatureCollection", Features: []models.GeoFeature{}}
+	var boundary *models.GeoPolygon
+	if boundaryJSON != "" {
+		var polygon models.GeoPolygon
+		if err := utils.FromJSON(boundaryJSON, &polygon); err == nil && len(polygon.Coordinates) > 0 {
+			boundary = &polygon
+			collection.Features = append(collection.Features, models.GeoFeature{
+				Type:     "Feature",
+				Geometry: polygon,
+				Properties: map[string]interface{}{
+					"kind":   "land_boundary",
+					"landId": landID,
+					"name":   landName,
+				},
+			})
+		}
+	}
+
+	query := `
+		SELECT p.id, p.session_id, date(s.scouted_on), COALESCE(s.scout_name, ''), p.latitude, p.longitude, p.severity,
+		       COALESCE(p.note, ''), p.issue_id, p.task_id
+		FROM scouting_points p
+		JOIN scouting_sessions s ON s.id = p.session_id
+		WHERE p.user_id = ? AND s.land_id = ?`
+	args := []interface{}{farmID, landID}
+	if sessionID != "" {
+		query += " AND s.id = ?"
+		args = append(args, sessionID)
+	}
+	if from != nil {
+		query += " AND date(s.scouted_on) >= ?"
+		args = append(args, from.Format("2006-01-02"))
+	}
+	if to != nil {
+		query += " AND date(s.scouted_on) <= ?"
+		args = append(args, to.Format("2006-01-02"))
+	}
+	query += " ORDER BY s.scouted_on, p.created_at"
+
+	rows, err := s.db.Query(query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var pointIDs []string
+	for rows.Next() {
+		var id, session, scoutedOn, scoutName, severity, note string
+		var latitude, longitude float64
+		var issueID, taskID sql.NullString
+		if err := rows.Scan(&id, &session, &scoutedOn, &scoutName, &latitude, &longitude, &severity, &note, &issueID, &taskID); err != nil {
+			return nil, err
+		}
+
+		properties := map[string]interface{}{
+			"kind":      "scouting_point",
+			"pointId":   id,
+			"sessionId": session,
+			"scoutedOn": scoutedOn,
+			"scoutName": scoutName,
+			"severity":  severity,
+			"note":      note,
+			"issueId":   utils.NullStringToPtr(issueID),
+			"taskId":    utils.NullStringToPtr(taskID),
+			"photoIds":  []string{},
+		}
+		if boundary != nil {
+			properties["insideBoundary"] = pointInPolygon(longitude, latitude, *boundary)
+		}
+		collection.Features = append(collection.Features, models.GeoFeature{
+			Type: "Feature",
+			Geometry: map[string]interface{}{
+				"type":        "Point",
+				"coordinates": []float64{longitude, latitude},
+			},
+			Properties: properties,
+		})
+		pointIDs = append(pointIDs, id)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	photos, err := s.pointPhotos(farmID, pointIDs)
+	if err != nil {
+		return nil, err
+	}
+	for _, feature := range collection.Features {
+		if id, ok := feature.Properties["pointId"].(string); ok && len(photos[id]) > 0 {
+			feature.Properties["photoIds"] = photos[id]
+		}
+	}
+	return collection, nil
+}
+
+// checkLand arazinin çiftliğe ait olduğunu doğrular
+func (s *ScoutingService) checkLand(farmID, landID string) error {
+	var exists bool
+	err := s.db.QueryRow("SELECT 1 FROM lands WHERE id = ? AND user_id = ?", landID, farmID).Scan(&exists)
+	if err == sql.ErrNoRows {
+		return ErrScoutingLand
+	}
+	return err
+}
+
+// sessionInfo keşif turunun arazisini ve tarihini döner
+func (s *ScoutingService) sessionInfo(farmID, sessionID string) (string, string, error) {
+	var landID, scoutedOn string
+	err := s.db.QueryRow("SELECT land_id, date(scouted_on) FROM scouting_sessions WHERE id = ? AND user_id = ?", sessionID, farmID).Scan(&landID, &scoutedOn)
+	if err == sql.ErrNoRows {
+		return "", "", ErrScoutingSessionNotFound
+	}
+	return landID, scoutedOn, err
+}
+
+// touch keşif turunun güncellenme zamanını yeniler
+func (s *ScoutingService) touch(farmID, sessionID string) {
+	s.db.Exec("UPDATE scouting_sessions SET updated_at = CURRENT_TIMESTAMP WHERE id = ? AND user_id = ?", sessionID, farmID)
+}
+
+// getPoint keşif turundaki noktayı fotoğraflarıyla döner
+func (s *ScoutingService) getPoint(farmID, sessionID, pointID string) (*models.ScoutingPoint, error) {
+	points, err := s.queryPoints(farmID, scoutingPointSelect+" WHERE p.id = ? AND p.session_id = ? AND p.user_id = ?", pointID, sessionID, farmID)
+	if err != nil {
+		return nil, err
+	}
+	if len(points) == 0 {
+		return nil, ErrScoutingPointNotFound
+	}
+	return &points[0], nil
+}
+
+// querySessions keşif turu satırlarını okur
+func (s *ScoutingService) querySessions(query string, args ...interface{}) ([]models.ScoutingSession, error) {
+	rows, err := s.db.Query(query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	sessions := []models.ScoutingSession{}
+	for rows.Next() {
+		var session models.ScoutingSession
+		if err := rows.Scan(&session.ID, &session.LandID, &session.LandName, &session.ScoutedOn, &session.ScoutName,
+			&session.Notes, &session.PointCount, &session.CreatedAt, &session.UpdatedAt); err != nil {
+			return nil, err
+		}
+		sessions = append(sessions, session)
+	}
+	return sessions, rows.Err()
+}
+
+// queryPoints gözlem noktası satırlarını okur ve fotoğraf kimliklerini ekler
+func (s *ScoutingService) queryPoints(farmID, query string, args ...interface{}) ([]models.ScoutingPoint, error) {
+	rows, err := s.db.Query(query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	points := []models.ScoutingPoint{}
+	var ids []string
+	for rows.Next() {
+		var point models.ScoutingPoint
+		var issueID, taskID sql.NullString
+		if err := rows.Scan(&point.ID, &point.SessionID, &point.Latitude, &point.Longitude, &point.Severity, &point.Note,
+			&issueID, &taskID, &point.CreatedAt); err != nil {
+			return nil, err
+		}
+		point.IssueID = utils.NullStringToPtr(issueID)
+		point.TaskID = utils.NullStringToPtr(taskID)
+		points = append(points, point)
+		ids = append(ids, point.ID)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	photos, err := s.pointPhotos(farmID, ids)
+	if err != nil {
+		return nil, err
+	}
+	for i := range points {
+		points[i].PhotoIDs = photos[points[i].ID]
+		if points[i].PhotoIDs == nil {
+			points[i].PhotoIDs = []string{}
+		}
+	}
+	return points, nil
+}
+
+// pointPhotos noktalara eklenmiş fotoğrafların kimliklerini yükleme sırasıyla döner
+func (s *ScoutingService) pointPhotos(farmID string, pointIDs []string) (map[string][]string, error) {
+	photos := map[string][]string{}
+	if len(pointIDs) == 0 {
+		return photos, nil
+	}
+
+	placeholders := strings.TrimSuffix(strings.Repeat("?,", len(pointIDs)), ",")
+	args := []interface{}{farmID, ScoutingPointEntity}
+	for _, id := range pointIDs {
+		args = append(args, id)
+	}
+	rows, err := s.db.Query(`
+		SELECT entity_id, id FROM media_attachments
+		WHERE user_id = ? AND entity_type = ? AND kind = 'image' AND entity_id IN (`+placeholders+`)
+		ORDER BY created_at, id
+	`, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var pointID, mediaID string
+		if err := rows.Scan(&pointID, &mediaID); err != nil {
+			return nil, err
+		}
+		photos[pointID] = append(photos[pointID], mediaID)
+	}
+	return photos, rows.Err()
+}
+
+// insertScoutingPoint gözlem noktası ekler
+func insertScoutingPoint(exec interface {
+	Exec(query string, args ...interface{}) (sql.Result, error)
+}, farmID, sessionID string, req models.ScoutingPointRequest) (string, error) {
+	id := utils.GenerateID()
+	_, err := exec.Exec(`
+		INSERT INTO scouting_points (id, user_id, session_id, latitude, longitude, severity, note, created_at)
+		VALUES (?, ?, ?, ?, ?, ?, ?, CURRENT_TIMESTAMP)
+	`, id, farmID, sessionID, *req.Latitude, *req.Longitude, req.Severity, req.Note)
+	return id, err
+}
+
+// pointInPolygon noktanın poligonun dış halkası içinde ve deliklerin dışında olup olmadığını ışın yöntemiyle belirler
+func pointInPolygon(x, y float64, polygon models.GeoPolygon) bool {
+	inside := false
+	for ringIndex, ring := range polygon.Coordinates {
+		if ringContains(x, y, ring) {
+			if ringIndex == 0 {
+				inside = true
+			} else {
+				return false
+			}
+		}
+	}
+	return inside
+}
+
+// ringContains noktanın kapalı halka içinde olup olmadığını döner
+func ringContains(x, y float64, ring [][]float64) bool {
+	contains := false
+	for i, j := 0, len(ring)-1; i < len(ring); j, i = i, i+1 {
+		if len(ring[i]) < 2 || len(ring[j]) < 2 {
+			continue
+		}
+		xi, yi, xj, yj := ring[i][0], ring[i][1], ring[j][0], ring[j][1]
+		if (yi > y) != (yj > y) && x < (xj-xi)*(y-yi)/(yj-yi)+xi {
+			contains = !contains
+		}
+	}
+	return contains
+}
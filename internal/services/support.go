@@ -0,0 +1,382 @@
+package services
+
+import (
+	"database/sql"
+	"errors"
+	"log"
+	"strings"
+	"sync"
+
+	"agri-management-api/internal/models"
+	"agri-management-api/internal/utils"
+)
+
+// recentRequestCapacity hesap başına bellekte tutulan son istek sayısı
+const recentRequestCapacity = 20
+
+// maxSupportRequestIDs talepte istemcinin gönderebileceği istek kimliği sayısı
+const maxSupportRequestIDs = 50
+
+// ErrSupportTicketNotFound talep yok veya kullanıcıya ait değil
+var ErrSupportTicketNotFound = errors.New("support ticket not found")
+
+// requestTrail hesapların son API isteklerini tutar; sunucu yeniden başlatılınca sıfırlanır
+var requestTrail = struct {
+	mu      sync.Mutex
+	entries map[string][]models.RecentRequest
+}{entries: map[string][]models.RecentRequest{}}
+
+// RecordRequest hesabın tamamlanan isteğini son istekler listesine ekler; en eski istekler düşürülür
+func RecordRequest(accountID string, request models.RecentRequest) {
+	requestTrail.mu.Lock()
+	defer requestTrail.mu.Unlock()
+
+	entries := append(requestTrail.entries[accountID], request)
+	if len(entries) > recentRequestCapacity {
+		entries = entries[len(entries)-recentRequestCapacity:]
+	}
+	requestTrail.entries[accountID] = entries
+}
+
+// RecentRequests hesabın son isteklerini en yeniden eskiye doğru döner
+func RecentRequests(accountID string) []models.RecentRequest {
+	requestTrail.mu.Lock()
+	defer requestTrail.mu.Unlock()
+
+	entries := requestTrail.entries[accountID]
+	recent := make([]models.RecentRequest, 0, len(entries))
+	for i := len(entries) - 1; i >= 0; i-- {
+		recent = append(recent, entries[i])
+	}
+	return recent
+}
+
+// supportTicketSelect destek talebi sütunları
+const supportTicketSelect = `
+	SELECT t.id, t.account_id, t.user_id, COALESCE(u.email, ''), COALESCE(t.subject, ''), t.message, t.category,
+	       t.status, COALESCE(t.app_version, ''), COALESCE(t.device_info, ''), COALESCE(t.request_ids, ''),
+	       COALESCE(t.recent_requests, ''), COALESCE(t.screenshot_id, ''), t.resolved_at, t.created_at, t.updated_at
+	FROM support_tickets t
+	LEFT JOIN users u ON u.id = t.account_id`
+
+// SupportService kullanıcı destek taleplerini, yanıtlarını ve durum bildirimlerini yönetir
+type SupportService struct {
+	db            *sql.DB
+	notifications *NotificationService
+}
+
+// NewSupportService yeni support service oluşturur
+func NewSupportService(db *sql.DB) *SupportService {
+	return &SupportService{db: db, notifications: NewNotificationService(db)}
+}
+
+// Create talebi hesabın son istekleriyle birlikte kaydeder; istemcinin bildirdiği istek kimlikleri tekilleştirilir
+func (s *SupportService) Create(farmID, accountID string, req models.SupportTicketRequest) (models.SupportTicket, error) {
+	category := req.Category
+	if category == "" {
+		category = "other"
+	}
+
+	deviceInfo, err := utils.ToJSON(req.Device)
+	if err != nil {
+		return models.SupportTicket{}, err
+	}
+	requestIDs, err := utils.ToJSON(uniqueRequestIDs(req.RequestIDs))
+	if err != nil {
+		return models.SupportTicket{}, err
+	}
+	recentRequests, err := utils.ToJSON(RecentRequests(accountID))
+	if err != nil {
+		return models.SupportTicket{}, err
+	}
+
+	ticketID := utils.GenerateID()
+	_, err = s.db.Exec(`
+		INSERT INTO support_tickets (id, user_id, account_id, subject, message, category, status, app_version,
+		                             device_info, request_ids, recent_requests, created_at, updated_at)
+		VALUES (?, ?, ?, ?, ?, ?, 'open', ?, ?, ?, ?, CURRENT_TIMESTAMP, CURRENT_TIMESTAMP)
+	`, ticketID, farmID, accountID, strings.TrimSpace(req.Subject), strings.TrimSpace(req.Message), category,
+		strings.TrimSpace(req.AppVersion), deviceInfo, requestIDs, recentRequests)
+	if err != nil {
+		return models.SupportTicket{}, err
+	}
+
+	return s.Ticket(farmID, ticketID)
+}
+
+// AttachScreenshot kaydedilmiş ekran görüntüsünü talebe bağlar
+func (s *SupportService) AttachScreenshot(farmID, ticketID, mediaID string) error {
+	_, err := s.db.Exec("UPDATE support_tickets SET screenshot_id = ? WHERE id = ? AND user_id = ?", mediaID, ticketID, farmID)
+	return err
+}
+
+// Ticket çiftliğin talebini mesajlarıyla getirir
+func (s *SupportService) Ticket(farmID, ticketID string) (models.SupportTicket, error) {
+	ticket, err := scanSupportTicket(s.db.QueryRow(supportTicketSelect+" WHERE t.id = ? AND t.user_id = ?", ticketID, farmID))
+	if err == sql.ErrNoRows {
+		return ticket, ErrSupportTicketNotFound
+	}
+	if err != nil {
+		return ticket, err
+	}
+	ticket.Messages, err = s.messages(ticket.FarmID, ticketID)
+	return ticket, err
+}
+
+// Tickets çiftliğin taleplerini en yeniden eskiye sayfalı listeler
+func (s *SupportService) Tickets(farmID string, page, limit int) ([]models.SupportTicket, int, error) {
+	var total int
+	if err := s.db.QueryRow("SELECT COUNT(*) FROM support_tickets WHERE user_id = ?", farmID).Scan(&total); err != nil {
+		return nil, 0, err
+	}
+
+	rows, err := s.db.Query(supportTicketSelect+`
+		WHERE t.user_id = ?
+		ORDER BY t.created_at DESC LIMIT ? OFFSET ?
+	`, farmID, limit, (page-1)*limit)
+	if err != nil {
+		return nil, 0, err
+	}
+	tickets, err := scanSupportTickets(rows)
+	return tickets, total, err
+}
+
+// OpenCount çiftliğin çözülmemiş talep sayısını döner
+func (s *SupportService) OpenCount(farmID string) (int, error) {
+	var count int
+	err := s.db.QueryRow(`
+		SELECT COUNT(*) FROM support_tickets WHERE user_id = ? AND status IN ('open', 'in_progress')
+	`, farmID).Scan(&count)
+	return count, err
+}
+
+// AddUserMessage kullanıcının talebe yazdığı mesajı ekler; çözülmüş veya kapatılmış talep yeniden açılır
+func (s *SupportService) AddUserMessage(farmID, accountID, ticketID, message string) (models.SupportTicket, error) {
+	result, err := s.db.Exec(`
+		UPDATE support_tickets SET status = 'open', resolved_at = NULL, updated_at = CURRENT_TIMESTAMP
+		WHERE id = ? AND user_id = ?
+	`, ticketID, farmID)
+	if err != nil {
+		return models.SupportTicket{}, err
+	}
+	if rows, _ := result.RowsAffected(); rows == 0 {
+		return models.SupportTicket{}, ErrSupportTicketNotFound
+	}
+
+	if err := s.insertMessage(ticketID, accountID, models.SupportAuthorUser, message); err != nil {
+		return models.SupportTicket{}, err
+	}
+	return s.Ticket(farmID, ticketID)
+}
+
+// AllTickets tüm çiftliklerin taleplerini son güncellemeye göre sayfalı listeler; boş durum filtresi uygulanmaz.
+// Yalnızca yönetici uç noktalarında kullanılır
+func (s *SupportService) AllTickets(status string, page, limit int) ([]models.SupportTicket, int, error) {
+	where, args := "", []interface{}{}
+	if status != "" {
+		where, args = " WHERE t.status = ?", append(args, status)
+	}
+
+	var total int
+	if err := s.db.QueryRow("SELECT COUNT(*) FROM support_tickets t"+where, args...).Scan(&total); err != nil {
+		return nil, 0, err
+	}
+
+	rows, err := s.db.Query(supportTicketSelect+where+" ORDER BY t.updated_at DESC LIMIT ? OFFSET ?",
+		append(args, limit, (page-1)*limit)...)
+	if err != nil {
+		return nil, 0, err
+	}
+	tickets, err := scanSupportTickets(rows)
+	return tickets, total, err
+}
+
+// AdminTicket herhangi bir çiftliğin talebini mesajlarıyla getirir; yalnızca yönetici uç noktalarında kullanılır
+func (s *SupportService) AdminTicket(ticketID string) (models.SupportTicket, error) {
+	ticket, err := scanSupportTicket(s.db.QueryRow(supportTicketSelect+" WHERE t.id = ?", ticketID))
+	if err == sql.ErrNoRows {
+		return ticket, ErrSupportTicketNotFound
+	}
+	if err != nil {
+		return ticket, err
+	}
+	ticket.Messages, err = s.messages(ticket.FarmID, ticketID)
+	return ticket, err
+}
+
+// Respond destek ekibinin yanıtını ekler ve durum verilmişse talebin durumunu değiştirir; verilmemişse açık talep
+// işleme alınır. Kullanıcıya yanıt bildirimi gönderilir
+func (s *SupportService) Respond(ticketID, adminID, message, status string) (models.SupportTicket, error) {
+	ticket, err := s.AdminTicket(ticketID)
+	if err != nil {
+		return ticket, err
+	}
+
+	if status == "" && ticket.Status == models.SupportTicketOpen {
+		status = models.SupportTicketInProgress
+	}
+	if err := s.insertMessage(ticketID, adminID, models.SupportAuthorSupport, message); err != nil {
+		return ticket, err
+	}
+	if status != "" && status != ticket.Status {
+		if err := s.setStatus(ticketID, status); err != nil {
+			return ticket, err
+		}
+	} else if _, err := s.db.Exec("UPDATE support_tickets SET updated_at = CURRENT_TIMESTAMP WHERE id = ?", ticketID); err != nil {
+		return ticket, err
+	}
+
+	s.notify(ticket, "support_ticket_reply", status)
+	return s.AdminTicket(ticketID)
+}
+
+// UpdateStatus talebin durumunu değiştirir ve durum değiştiyse kullanıcıya bildirim gönderir
+func (s *SupportService) UpdateStatus(ticketID, status string) (models.SupportTicket, error) {
+	ticket, err := s.AdminTicket(ticketID)
+	if err != nil {
+		return ticket, err
+	}
+	if ticket.Status == status {
+		return ticket, nil
+	}
+
+	if err := s.setStatus(ticketID, status); err != nil {
+		return ticket, err
+	}
+	s.notify(ticket, "support_ticket_status", status)
+	return s.AdminTicket(ticketID)
+}
+
+// setStatus durumu yazar; çözülen veya kapatılan talebin çözülme zamanı tutulur
+func (s *SupportService) setStatus(ticketID, status string) error {
+	_, err := s.db.Exec(`
+		UPDATE support_tickets
+		SET status = ?,
+		    resolved_at = CASE WHEN ? IN ('resolved', 'closed') THEN COALESCE(resolved_at, CURRENT_TIMESTAMP) END,
+		    updated_at = CURRENT_TIMESTAMP
+		WHERE id = ?
+	`, status, status, ticketID)
+	return err
+}
+
+// notify talep sahibinin çiftliğine yanıt veya durum bildirimi gönderir; bildirim hatası işlemi engellemez
+func (s *SupportService) notify(ticket models.SupportTicket, template, status string) {
+	if status == "" {
+		status = ticket.Status
+	}
+	name := ticket.Subject
+	if name == "" {
+		name = ticket.Message
+		if runes := []rune(name); len(runes) > 60 {
+			name = string(runes[:60]) + "…"
+		}
+	}
+
+	_, err := s.notifications.Create(Notification{
+		UserID:   ticket.FarmID,
+		Template: template,
+		Type:     "info",
+		Priority: "medium",
+		Topic:    models.NotificationTopicSupportTicket,
+		Entity:   &models.RelatedEntity{Type: "support_ticket", ID: ticket.ID, Name: name},
+		Params:   map[string]interface{}{"status": status},
+	})
+	if err != nil {
+		log.Printf("Destek talebi bildirimi oluşturulamadı: %v", err)
+	}
+}
+
+// insertMessage talebe mesaj ekler
+func (s *SupportService) insertMessage(ticketID, authorID, role, message string) error {
+	_, err := s.db.Exec(`
+		INSERT INTO support_ticket_messages (id, ticket_id, author_id, author_role, message, created_at)
+		VALUES (?, ?, ?, ?, ?, CURRENT_TIMESTAMP)
+	`, utils.GenerateID(), ticketID, authorID, role, strings.TrimSpace(message))
+	return err
+}
+
+// messages talebin mesajlarını gönderim sırasıyla döner
+func (s *SupportService) messages(farmID, ticketID string) ([]models.SupportTicketMessage, error) {
+	rows, err := s.db.Query(`
+		SELECT m.id, m.ticket_id, m.author_id, m.author_role, m.message, m.created_at
+		FROM support_ticket_messages m
+		JOIN support_tickets t ON t.id = m.ticket_id
+		WHERE m.ticket_id = ? AND t.user_id = ?
+		ORDER BY m.created_at, m.rowid
+	`, ticketID, farmID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	messages := []models.SupportTicketMessage{}
+	for rows.Next() {
+		var message models.SupportTicketMessage
+		if err := rows.Scan(&message.ID, &message.TicketID, &message.AuthorID, &message.AuthorRole,
+			&message.Message, &message.CreatedAt); err != nil {
+			return nil, err
+		}
+		messages = append(messages, message)
+	}
+	return messages, rows.Err()
+}
+
+// uniqueRequestIDs boş ve tekrarlanan istek kimliklerini atar, sayıyı sınırlar
+func uniqueRequestIDs(ids []string) []string {
+	seen := map[string]bool{}
+	unique := []string{}
+	for _, id := range ids {
+		for _, part := range strings.Split(id, ",") {
+			part = strings.TrimSpace(part)
+			if part == "" || seen[part] || len(unique) >= maxSupportRequestIDs {
+				continue
+			}
+			seen[part] = true
+			unique = append(unique, part)
+		}
+	}
+	return unique
+}
+
+// scanSupportTickets talep satırlarını okur ve satırları kapatır
+func scanSupportTickets(rows *sql.Rows) ([]models.SupportTicket, error) {
+	defer rows.Close()
+
+	tickets := []models.SupportTicket{}
+	for rows.Next() {
+		ticket, err := scanSupportTicket(rows)
+		if err != nil {
+			return nil, err
+		}
+		tickets = append(tickets, ticket)
+	}
+	return tickets, rows.Err()
+}
+
+// scanSupportTicket talep satırını okur; JSON sütunları çözülür
+func scanSupportTicket(row interface{ Scan(...interface{}) error }) (models.SupportTicket, error) {
+	var ticket models.SupportTicket
+	var deviceInfo, requestIDs, recentRequests string
+	var resolvedAt sql.NullTime
+
+	err := row.Scan(&ticket.ID, &ticket.AccountID, &ticket.FarmID, &ticket.AccountEmail, &ticket.Subject,
+		&ticket.Message, &ticket.Category, &ticket.Status, &ticket.AppVersion, &deviceInfo, &requestIDs,
+		&recentRequests, &ticket.ScreenshotID, &resolvedAt, &ticket.CreatedAt, &ticket.UpdatedAt)
+	if err != nil {
+		return ticket, err
+	}
+
+	ticket.ResolvedAt = utils.NullTimeToPtr(resolvedAt)
+	ticket.RequestIDs = []string{}
+	ticket.RecentRequests = []models.RecentRequest{}
+	if deviceInfo != "" {
+		utils.FromJSON(deviceInfo, &ticket.Device)
+	}
+	if requestIDs != "" {
+		utils.FromJSON(requestIDs, &ticket.RequestIDs)
+	}
+	if recentRequests != "" {
+		utils.FromJSON(recentRequests, &ticket.RecentRequests)
+	}
+	return ticket, nil
+}
@@ -0,0 +1,357 @@
+package services
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"agri-management-api/internal/models"
+)
+
+// ErrUnknownMetric tanımsız zaman serisi metriği
+var ErrUnknownMetric = errors.New("unknown time series metric")
+
+// ErrInvalidBucket desteklenmeyen kova türü
+var ErrInvalidBucket = errors.New("invalid time series bucket")
+
+// ErrInvalidTimeSeriesRange tarih aralığı geçersiz veya çok fazla kova içeriyor
+var ErrInvalidTimeSeriesRange = errors.New("invalid time series range")
+
+// maxTimeSeriesBuckets tek sorguda üretilebilecek en fazla kova sayısı
+const maxTimeSeriesBuckets = 1000
+
+// defaultTimeSeriesBuckets from verilmediğinde geriye doğru gösterilen kova sayısı (içinde bulunulan dahil)
+const defaultTimeSeriesBuckets = 12
+
+// timeSeriesMetric metriğin tanımı ve günlük toplamları dönen sorgusu; sorgu (gün, toplam, adet) satırları
+// döner, argümanları sırasıyla çiftlik, başlangıç ve bitiş günüdür, %s filtre koşullarıyla doldurulur
+type timeSeriesMetric struct {
+	models.TimeSeriesMetric
+	query   string
+	filters map[string]string
+}
+
+// timeSeriesMetrics zaman serisi olarak sorgulanabilen metrikler
+var timeSeriesMetrics = []timeSeriesMetric{
+	{
+		TimeSeriesMetric: models.TimeSeriesMetric{Key: "milk_total", Label: "Süt Üretimi", Unit: "L", Aggregation: models.AggregationSum},
+		query: `
+			SELECT date(m.date) AS day, SUM(m.amount), COUNT(*)
+			FROM milk_production m
+			JOIN livestock l ON l.id = m.livestock_id
+			WHERE l.user_id = ? AND date(m.date) BETWEEN ? AND ?%s
+			GROUP BY day`,
+		filters: map[string]string{"livestockId": "m.livestock_id = ?"},
+	},
+	{
+		TimeSeriesMetric: models.TimeSeriesMetric{Key: "eggs_total", Label: "Yumurta Üretimi", Unit: "adet", Aggregation: models.AggregationSum},
+		query: `
+			SELECT date(COALESCE(p.harvest_date, p.created_at)) AS day, SUM(p.amount), COUNT(*)
+			FROM production p
+			WHERE p.user_id = ? AND date(COALESCE(p.harvest_date, p.created_at)) BETWEEN ? AND ?
+			  AND (p.category = 'eggs' OR LOWER(p.name) LIKE '%%yumurta%%' OR LOWER(p.name) LIKE '%%egg%%')%s
+			GROUP BY day`,
+		filters: map[string]string{"landId": "p.land_id = ?"},
+	},
+	{
+		TimeSeriesMetric: models.TimeSeriesMetric{Key: "income_total", Label: "Gelir", Unit: "TRY", Aggregation: models.AggregationSum},
+		query: `
+			SELECT date(t.date) AS day, SUM(t.amount), COUNT(*)
+			FROM transactions t
+			WHERE t.user_id = ? AND date(t.date) BETWEEN ? AND ? AND t.type = 'income'%s
+			GROUP BY day`,
+		filters: map[string]string{"category": "t.category = ?"},
+	},
+	{
+		TimeSeriesMetric: models.TimeSeriesMetric{Key: "expense_total", Label: "Gider", Unit: "TRY", Aggregation: models.AggregationSum},
+		query: `
+			SELECT date(t.date) AS day, SUM(t.amount), COUNT(*)
+			FROM transactions t
+			WHERE t.user_id = ? AND date(t.date) BETWEEN ? AND ? AND t.type = 'expense'%s
+			GROUP BY day`,
+		filters: map[string]string{"category": "t.category = ?"},
+	},
+	{
+		// Hayvan ağırlıkları tartım kayıtlarından okunur
+		TimeSeriesMetric: models.TimeSeriesMetric{Key: "livestock_weight_avg", Label: "Ortalama Hayvan Ağırlığı", Unit: "kg", Aggregation: models.AggregationAvg},
+		query: `
+			SELECT date(w.record_date) AS day, SUM(w.weight), COUNT(*)
+			FROM weight_records w
+			JOIN livestock l ON l.id = w.livestock_id
+			WHERE l.user_id = ? AND date(w.record_date) BETWEEN ? AND ?%s
+			GROUP BY day`,
+		filters: map[string]string{"livestockId": "w.livestock_id = ?", "type": "l.type = ?"},
+	},
+	{
+		TimeSeriesMetric: models.TimeSeriesMetric{Key: "fish_weight_avg", Label: "Ortalama Balık Ağırlığı", Unit: "g", Aggregation: models.AggregationAvg},
+		query: `
+			SELECT date(r.record_date) AS day, SUM(r.avg_weight), COUNT(*)
+			FROM fish_batch_records r
+			WHERE r.user_id = ? AND date(r.record_date) BETWEEN ? AND ?
+			  AND r.record_type = '` + models.FishRecordSampling + `' AND r.avg_weight IS NOT NULL%s
+			GROUP BY day`,
+		filters: map[string]string{"fishBatchId": "r.batch_id = ?"},
+	},
+	{
+		// Sürü büyüklüğü, stok değeri, nakit bakiyesi ve toplam alan gecelik KPI anlık görüntülerinden okunur;
+		// kayıtlar sonradan düzenlense de geçmiş değerler değişmez
+		TimeSeriesMetric: models.TimeSeriesMetric{Key: "herd_size", Label: "Sürü Büyüklüğü", Unit: "baş", Aggregation: models.AggregationAvg},
+		query:            kpiSnapshotSeriesQuery("herd_size"),
+	},
+	{
+		TimeSeriesMetric: models.TimeSeriesMetric{Key: "stock_value", Label: "Stok Değeri", Unit: "TRY", Aggregation: models.AggregationAvg},
+		query:            kpiSnapshotSeriesQuery("stock_value"),
+	},
+	{
+		TimeSeriesMetric: models.TimeSeriesMetric{Key: "cash_balance", Label: "Nakit Bakiyesi", Unit: "TRY", Aggregation: models.AggregationAvg},
+		query:            kpiSnapshotSeriesQuery("cash_balance"),
+	},
+	{
+		TimeSeriesMetric: models.TimeSeriesMetric{Key: "total_area", Label: "Toplam Alan", Unit: "dönüm", Aggregation: models.AggregationAvg},
+		query:            kpiSnapshotSeriesQuery("total_area"),
+	},
+	{
+		// Her arazinin günlük yağışı tercih ettiği kaynaktan alınır ve araziler arasında ortalanır, böylece birden
+		// fazla arazi yağışı katlamaz
+		TimeSeriesMetric: models.TimeSeriesMetric{Key: "rainfall_total", Label: "Yağış", Unit: "mm", Aggregation: models.AggregationSum},
+		query: `
+			SELECT day, SUM(rainfall), COUNT(*)
+			FROM (
+				SELECT date(w.observed_on) AS day, AVG(w.rainfall) AS rainfall
+				FROM ` + preferredWeatherObservations("rainfall") + ` w
+				WHERE date(w.observed_on) BETWEEN ? AND ? AND w.rainfall IS NOT NULL%s
+				GROUP BY day
+			)
+			GROUP BY day`,
+		filters: map[string]string{"landId": "w.land_id = ?"},
+	},
+}
+
+// kpiSnapshotSeriesQuery KPI anlık görüntüsü sütununun günlük değerlerini dönen zaman serisi sorgusu
+func kpiSnapshotSeriesQuery(column string) string {
+	return `
+			SELECT date(k.snapshot_date) AS day, SUM(k.` + column + `), COUNT(*)
+			FROM kpi_snapshots k
+			WHERE k.user_id = ? AND date(k.snapshot_date) BETWEEN ? AND ?%s
+			GROUP BY day`
+}
+
+// TimeSeriesService metrikleri ortak kova ve boşluk doldurma kurallarıyla zaman serisine çevirir;
+// dashboard grafikleri ve raporlar aynı servisi kullanır
+type TimeSeriesService struct {
+	db *sql.DB
+}
+
+// NewTimeSeriesService yeni zaman serisi servisi oluşturur
+func NewTimeSeriesService(db *sql.DB) *TimeSeriesService {
+	return &TimeSeriesService{db: db}
+}
+
+// Metrics tanımlı metrikleri döner
+func (s *TimeSeriesService) Metrics() []models.TimeSeriesMetric {
+	metrics := make([]models.TimeSeriesMetric, len(timeSeriesMetrics))
+	for i, metric := range timeSeriesMetrics {
+		metrics[i] = metric.TimeSeriesMetric
+		metrics[i].Filters = sortedKeys(metric.filters)
+	}
+	return metrics
+}
+
+// DefaultRange to verilmediğinde bugün, from verilmediğinde to dahil son 12 kovanın başlangıcıdır
+func (s *TimeSeriesService) DefaultRange(bucket string, from, to *time.Time) (time.Time, time.Time, error) {
+	if !validBucket(bucket) {
+		return time.Time{}, time.Time{}, ErrInvalidBucket
+	}
+
+	end := time.Now().UTC().Truncate(24 * time.Hour)
+	if to != nil {
+		end = *to
+	}
+	start := bucketStart(end, bucket)
+	for i := 1; i < defaultTimeSeriesBuckets; i++ {
+		start = bucketStart(start.AddDate(0, 0, -1), bucket)
+	}
+	if from != nil {
+		start = *from
+	}
+	return start, end, nil
+}
+
+// Series metriği from-to aralığında (iki uç dahil) kovalara böler; filters metriğin desteklediği filtrelerdir
+func (s *TimeSeriesService) Series(farmID, metricKey, bucket string, from, to time.Time, filters map[string]string) (models.TimeSeries, error) {
+	var metric *timeSeriesMetric
+	for i := range timeSeriesMetrics {
+		if timeSeriesMetrics[i].Key == metricKey {
+			metric = &timeSeriesMetrics[i]
+		}
+	}
+	if metric == nil {
+		return models.TimeSeries{}, ErrUnknownMetric
+	}
+	if !validBucket(bucket) {
+		return models.TimeSeries{}, ErrInvalidBucket
+	}
+	if to.Before(from) {
+		return models.TimeSeries{}, fmt.Errorf("%w: to is before from", ErrInvalidTimeSeriesRange)
+	}
+
+	points, index, err := bucketPoints(from, to, bucket)
+	if err != nil {
+		return models.TimeSeries{}, err
+	}
+
+	var clause strings.Builder
+	args := []interface{}{farmID, from.Format("2006-01-02"), to.Format("2006-01-02")}
+	for _, name := range sortedKeys(metric.filters) {
+		if value := filters[name]; value != "" {
+			clause.WriteString(" AND " + metric.filters[name])
+			args = append(args, value)
+		}
+	}
+
+	rows, err := s.db.Query(fmt.Sprintf(metric.query, clause.String()), args...)
+	if err != nil {
+		return models.TimeSeries{}, err
+	}
+	defer rows.Close()
+
+	sums := make([]float64, len(points))
+	var totalSum float64
+	var totalCount int
+	for rows.Next() {
+		var day string
+		var sum float64
+		var count int
+		if err := rows.Scan(&day, &sum, &count); err != nil {
+			return models.TimeSeries{}, err
+		}
+		date, err := time.Parse("2006-01-02", day)
+		if err != nil {
+			continue
+		}
+		i, ok := index[bucketStart(date, bucket)]
+		if !ok {
+			continue
+		}
+		sums[i] += sum
+		points[i].Count += count
+		totalSum += sum
+		totalCount += count
+	}
+	if err := rows.Err(); err != nil {
+		return models.TimeSeries{}, err
+	}
+
+	for i := range points {
+		points[i].Value = aggregateValue(metric.Aggregation, sums[i], points[i].Count)
+	}
+
+	return models.TimeSeries{
+		Metric:      metric.Key,
+		Unit:        metric.Unit,
+		Aggregation: metric.Aggregation,
+		Bucket:      bucket,
+		From:        from.Format("2006-01-02"),
+		To:          to.Format("2006-01-02"),
+		Total:       aggregateValue(metric.Aggregation, totalSum, totalCount),
+		Points:      points,
+	}, nil
+}
+
+// aggregateValue sum metriklerinde toplamı, avg metriklerinde ortalamayı döner; kaydı olmayan avg kovası null'dır
+func aggregateValue(aggregation string, sum float64, count int) *float64 {
+	if aggregation == models.AggregationAvg {
+		if count == 0 {
+			return nil
+		}
+		sum /= float64(count)
+	}
+	value := round2(sum)
+	return &value
+}
+
+// bucketPoints from ve to'yu kapsayan tüm kovaları boş değerlerle üretir; index kova başlangıcından sıraya eşler
+func bucketPoints(from, to time.Time, bucket string) ([]models.TimeSeriesPoint, map[time.Time]int, error) {
+	points := []models.TimeSeriesPoint{}
+	index := map[time.Time]int{}
+	for start := bucketStart(from, bucket); !start.After(to); start = nextBucket(start, bucket) {
+		if len(points) == maxTimeSeriesBuckets {
+			return nil, nil, fmt.Errorf("%w: more than %d buckets", ErrInvalidTimeSeriesRange, maxTimeSeriesBuckets)
+		}
+		index[start] = len(points)
+		points = append(points, models.TimeSeriesPoint{
+			Bucket: bucketLabel(start, bucket),
+			Start:  start.Format("2006-01-02"),
+			End:    nextBucket(start, bucket).AddDate(0, 0, -1).Format("2006-01-02"),
+		})
+	}
+	return points, index, nil
+}
+
+// sortedKeys filtre adlarını sıralı döner
+func sortedKeys(values map[string]string) []string {
+	keys := make([]string, 0, len(values))
+	for key := range values {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+func validBucket(bucket string) bool {
+	switch bucket {
+	case models.BucketDay, models.BucketWeek, models.BucketMonth, models.BucketQuarter, models.BucketYear:
+		return true
+	}
+	return false
+}
+
+// bucketStart tarihin içinde bulunduğu kovanın ilk günü; haftalar ISO 8601'e göre pazartesi başlar
+func bucketStart(t time.Time, bucket string) time.Time {
+	day := time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+	switch bucket {
+	case models.BucketWeek:
+		return day.AddDate(0, 0, -((int(day.Weekday()) + 6) % 7))
+	case models.BucketMonth:
+		return time.Date(day.Year(), day.Month(), 1, 0, 0, 0, 0, time.UTC)
+	case models.BucketQuarter:
+		return time.Date(day.Year(), day.Month()-(day.Month()-1)%3, 1, 0, 0, 0, 0, time.UTC)
+	case models.BucketYear:
+		return time.Date(day.Year(), 1, 1, 0, 0, 0, 0, time.UTC)
+	}
+	return day
+}
+
+func nextBucket(start time.Time, bucket string) time.Time {
+	switch bucket {
+	case models.BucketWeek:
+		return start.AddDate(0, 0, 7)
+	case models.BucketMonth:
+		return start.AddDate(0, 1, 0)
+	case models.BucketQuarter:
+		return start.AddDate(0, 3, 0)
+	case models.BucketYear:
+		return start.AddDate(1, 0, 0)
+	}
+	return start.AddDate(0, 0, 1)
+}
+
+// bucketLabel kova etiketi: 2026-10-16, 2026-W42, 2026-10, 2026-Q4 veya 2026
+func bucketLabel(start time.Time, bucket string) string {
+	switch bucket {
+	case models.BucketWeek:
+		year, week := start.ISOWeek()
+		return fmt.Sprintf("%d-W%02d", year, week)
+	case models.BucketMonth:
+		return start.Format("2006-01")
+	case models.BucketQuarter:
+		return strconv.Itoa(start.Year()) + "-Q" + strconv.Itoa(int(start.Month()-1)/3+1)
+	case models.BucketYear:
+		return strconv.Itoa(start.Year())
+	}
+	return start.Format("2006-01-02")
+}
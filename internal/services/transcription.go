@@ -0,0 +1,104 @@
+package services
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"mime/multipart"
+	"net/http"
+	"os"
+	"time"
+)
+
+// ErrTranscriptionDisabled konuşma-metin sağlayıcısı yapılandırılmadığında döner
+var ErrTranscriptionDisabled = errors.New("speech-to-text provider not configured")
+
+// Transcriber ses kaydını metne çeviren sağlayıcı arayüzü
+type Transcriber interface {
+	Transcribe(ctx context.Context, filename string, audio []byte) (string, error)
+}
+
+// NewTranscriber STT_PROVIDER ortam değişkenine göre sağlayıcı oluşturur; yapılandırılmamışsa nil döner
+func NewTranscriber() Transcriber {
+	switch os.Getenv("STT_PROVIDER") {
+	case "whisper":
+		endpoint := os.Getenv("STT_ENDPOINT")
+		if endpoint == "" {
+			endpoint = "https://api.openai.com/v1/audio/transcriptions"
+		}
+		model := os.Getenv("STT_MODEL")
+		if model == "" {
+			model = "whisper-1"
+		}
+		return &WhisperTranscriber{
+			endpoint: endpoint,
+			apiKey:   os.Getenv("STT_API_KEY"),
+			model:    model,
+			language: os.Getenv("STT_LANGUAGE"),
+			client:   &http.Client{Timeout: 60 * time.Second},
+		}
+	}
+	return nil
+}
+
+// WhisperTranscriber OpenAI uyumlu /audio/transcriptions uç noktasını kullanır
+type WhisperTranscriber struct {
+	endpoint string
+	apiKey   string
+	model    string
+	language string
+	client   *http.Client
+}
+
+// Transcribe ses dosyasını sağlayıcıya gönderir ve metni döner
+func (t *WhisperTranscriber) Transcribe(ctx context.Context, filename string, audio []byte) (string, error) {
+	var body bytes.Buffer
+	writer := multipart.NewWriter(&body)
+
+	part, err := writer.CreateFormFile("file", filename)
+	if err != nil {
+		return "", err
+	}
+	if _, err := part.Write(audio); err != nil {
+		return "", err
+	}
+	writer.WriteField("model", t.model)
+	if t.language != "" {
+		writer.WriteField("language", t.language)
+	}
+	if err := writer.Close(); err != nil {
+		return "", err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, t.endpoint, &body)
+	if err != nil {
+		return "", err
+	}
+	req.Header.Set("Content-Type", writer.FormDataContentType())
+	if t.apiKey != "" {
+		req.Header.Set("Authorization", "Bearer "+t.apiKey)
+	}
+
+	resp, err := t.client.Do(req)
+	if err != nil {
+		return "", err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		message, _ := io.ReadAll(io.LimitReader(resp.Body, 512))
+		return "", fmt.Errorf("transcription failed: %s: %s", resp.Status, bytes.TrimSpace(message))
+	}
+
+	var result struct {
+		Text string `json:"text"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		return "", err
+	}
+
+	return result.Text, nil
+}
@@ -0,0 +1,369 @@
+package services
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"log"
+	"strings"
+	"time"
+
+	"agri-management-api/internal/models"
+	"agri-management-api/internal/utils"
+)
+
+// vaccinationReminderDays aşı tarihine kaç gün kala hatırlatma gönderileceği
+const vaccinationReminderDays = 3
+
+// ErrVaccinationNotFound aşı takvimi kaydı bulunamadı
+var ErrVaccinationNotFound = errors.New("aşı kaydı bulunamadı")
+
+// vaccinationSelect aşı takvimi sütunları; v takma adı vaccinations, l takma adı livestock tablosudur
+const vaccinationSelect = `
+	SELECT v.id, v.livestock_id, l.tag_number, l.type, v.vaccine, COALESCE(v.dose, ''), date(v.due_date),
+	       date(v.administered_date), COALESCE(v.batch_number, ''), COALESCE(v.veterinarian, ''),
+	       COALESCE(v.notes, ''), v.health_record_id, v.created_at, v.updated_at
+	FROM vaccinations v
+	JOIN livestock l ON l.id = v.livestock_id`
+
+// farmLivestockCondition hayvana bağlı alt tablo satırının çiftliğin hayvanına ait olma koşulu; çiftlik kimliği
+// parametre olarak verilir
+const farmLivestockCondition = "livestock_id IN (SELECT id FROM livestock WHERE user_id = ?)"
+
+// overdueVaccinationCondition hayvanın yapılmamış ve tarihi geçmiş aşısı olma koşulu; l takma adı livestock
+// tablosudur, bugünün tarihi parametre olarak verilir
+const overdueVaccinationCondition = `EXISTS (
+	      SELECT 1 FROM vaccinations o
+	      WHERE o.livestock_id = l.id AND o.administered_date IS NULL AND date(o.due_date) < date(?)
+	  )`
+
+// VaccinationService hayvanların aşı takvimini yönetir. Yapılan aşılar sağlık kayıtlarına işlenir; tarihi geçmiş
+// aşısı olan hayvanların sağlık durumu vaccination_needed olarak türetilir
+type VaccinationService struct {
+	db            *sql.DB
+	notifications *NotificationService
+}
+
+// NewVaccinationService yeni aşı takvimi servisi oluşturur
+func NewVaccinationService(db *sql.DB) *VaccinationService {
+	return &VaccinationService{db: db, notifications: NewNotificationService(db)}
+}
+
+// List hayvanın aşı takvimini tarih sırasıyla döner
+func (s *VaccinationService) List(farmID, animalID string) ([]models.Vaccination, error) {
+	if err := s.checkAnimal(farmID, animalID); err != nil {
+		return nil, err
+	}
+	return s.query(vaccinationSelect+`
+		WHERE v.livestock_id = ? AND l.user_id = ?
+		ORDER BY v.due_date, v.created_at
+	`, animalID, farmID)
+}
+
+// Get hayvanın aşı takvimi kaydını döner
+func (s *VaccinationService) Get(farmID, animalID, id string) (*models.Vaccination, error) {
+	vaccinations, err := s.query(vaccinationSelect+`
+		WHERE v.id = ? AND v.livestock_id = ? AND l.user_id = ?
+	`, id, animalID, farmID)
+	if err != nil {
+		return nil, err
+	}
+	if len(vaccinations) == 0 {
+		return nil, ErrVaccinationNotFound
+	}
+	return &vaccinations[0], nil
+}
+
+// Upcoming sürüdeki hayvanların önümüzdeki days gün içinde yapılması gereken ve tarihi geçmiş yapılmamış
+// aşılarını tarih sırasıyla döner
+func (s *VaccinationService) Upcoming(farmID string, days int, today time.Time) ([]models.Vaccination, error) {
+	return s.query(vaccinationSelect+`
+		WHERE l.user_id = ? AND v.administered_date IS NULL AND date(v.due_date) <= date(?, ?)
+		  AND `+inHerdCondition+`
+		ORDER BY v.due_date, l.tag_number
+	`, farmID, today.Format("2006-01-02"), fmt.Sprintf("+%d days", days))
+}
+
+// Create hayvana aşı planlar; administeredDate verilirse aşı yapılmış olarak kaydedilir
+func (s *VaccinationService) Create(farmID, animalID string, req models.VaccinationRequest) (*models.Vaccination, error) {
+	if err := s.checkAnimal(farmID, animalID); err != nil {
+		return nil, err
+	}
+
+	tx, err := s.db.Begin()
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	id := utils.GenerateID()
+	if _, err := tx.Exec(`
+		INSERT INTO vaccinations (id, livestock_id, vaccine, dose, due_date, administered_date, batch_number,
+		                          veterinarian, notes, created_at, updated_at)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, CURRENT_TIMESTAMP, CURRENT_TIMESTAMP)
+	`, id, animalID, strings.TrimSpace(req.Vaccine), req.Dose, req.DueDate, req.AdministeredDate, req.BatchNumber,
+		req.Veterinarian, req.Notes); err != nil {
+		return nil, err
+	}
+	if err := syncVaccinationRecord(tx, farmID, id, animalID, nil, req); err != nil {
+		return nil, err
+	}
+	if err := tx.Commit(); err != nil {
+		return nil, err
+	}
+
+	if err := s.SyncHealthStatus(farmID); err != nil {
+		return nil, err
+	}
+	return s.Get(farmID, animalID, id)
+}
+
+// Update aşı takvimi kaydını günceller; yapılma tarihi girilen, değişen veya kaldırılan aşının sağlık kaydı
+// buna göre eklenir, güncellenir veya silinir
+func (s *VaccinationService) Update(farmID, animalID, id string, req models.VaccinationRequest) (*models.Vaccination, error) {
+	current, err := s.Get(farmID, animalID, id)
+	if err != nil {
+		return nil, err
+	}
+
+	tx, err := s.db.Begin()
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	if _, err := tx.Exec(`
+		UPDATE vaccinations
+		SET vaccine = ?, dose = ?, due_date = ?, administered_date = ?, batch_number = ?, veterinarian = ?,
+		    notes = ?, updated_at = CURRENT_TIMESTAMP
+		WHERE id = ? AND `+farmLivestockCondition+`
+	`, strings.TrimSpace(req.Vaccine), req.Dose, req.DueDate, req.AdministeredDate, req.BatchNumber,
+		req.Veterinarian, req.Notes, id, farmID); err != nil {
+		return nil, err
+	}
+	if err := syncVaccinationRecord(tx, farmID, id, animalID, current.HealthRecordID, req); err != nil {
+		return nil, err
+	}
+	if err := tx.Commit(); err != nil {
+		return nil, err
+	}
+
+	if err := s.SyncHealthStatus(farmID); err != nil {
+		return nil, err
+	}
+	return s.Get(farmID, animalID, id)
+}
+
+// Delete aşı takvimi kaydını ve aşı yapıldıysa bağlı sağlık kaydını siler
+func (s *VaccinationService) Delete(farmID, animalID, id string) error {
+	current, err := s.Get(farmID, animalID, id)
+	if err != nil {
+		return err
+	}
+
+	tx, err := s.db.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	if _, err := tx.Exec(`
+		DELETE FROM vaccinations WHERE id = ? AND `+farmLivestockCondition+`
+	`, id, farmID); err != nil {
+		return err
+	}
+	if current.HealthRecordID != nil {
+		if _, err := tx.Exec(`
+			DELETE FROM health_records WHERE id = ? AND `+farmLivestockCondition+`
+		`, *current.HealthRecordID, farmID); err != nil {
+			return err
+		}
+	}
+	if err := tx.Commit(); err != nil {
+		return err
+	}
+	return s.SyncHealthStatus(farmID)
+}
+
+// SyncHealthStatus çiftliğin hayvanlarının sağlık durumunu aşı takviminden türetir
+func (s *VaccinationService) SyncHealthStatus(farmID string) error {
+	return s.syncHealthStatus("l.user_id = ? AND ", []interface{}{farmID}, time.Now().UTC())
+}
+
+// StartScheduler sağlık durumlarını ve yaklaşan aşı hatırlatmalarını saatlik olarak arka planda günceller;
+// tarihi geçen aşılar kullanıcı işlem yapmasa da hayvanın durumuna yansır
+func (s *VaccinationService) StartScheduler() {
+	go func() {
+		ticker := time.NewTicker(time.Hour)
+		defer ticker.Stop()
+
+		for {
+			now := time.Now().UTC()
+			if err := s.syncHealthStatus("", nil, now); err != nil {
+				log.Printf("Aşı takvimine göre sağlık durumları güncellenemedi: %v", err)
+			}
+			if err := s.sendReminders(now); err != nil {
+				log.Printf("Aşı hatırlatmaları gönderilemedi: %v", err)
+			}
+			<-ticker.C
+		}
+	}()
+}
+
+// syncHealthStatus tarihi geçmiş aşısı olan sürüdeki sağlıklı hayvanları vaccination_needed, aşısı kalmayanları
+// yeniden healthy yapar; hasta veya gebe gibi elle girilen durumlar değişmez. scope boşsa tüm çiftlikler güncellenir
+func (s *VaccinationService) syncHealthStatus(scope string, args []interface{}, now time.Time) error {
+	today := now.Format("2006-01-02")
+
+	if _, err := s.db.Exec(`
+		UPDATE livestock SET health_status = ?, updated_at = CURRENT_TIMESTAMP
+		WHERE id IN (
+		    SELECT l.id FROM livestock l
+		    WHERE `+scope+`COALESCE(l.health_status, '') IN ('', ?)
+		      AND `+inHerdCondition+`
+		      AND `+overdueVaccinationCondition+`
+		)
+	`, append(append([]interface{}{models.HealthStatusVaccinationNeeded}, args...),
+		models.HealthStatusHealthy, today)...); err != nil {
+		return err
+	}
+
+	_, err := s.db.Exec(`
+		UPDATE livestock SET health_status = ?, updated_at = CURRENT_TIMESTAMP
+		WHERE id IN (
+		    SELECT l.id FROM livestock l
+		    WHERE `+scope+`l.health_status = ?
+		      AND NOT `+overdueVaccinationCondition+`
+		)
+	`, append(append([]interface{}{models.HealthStatusHealthy}, args...),
+		models.HealthStatusVaccinationNeeded, today)...)
+	return err
+}
+
+// sendReminders tarihi 3 gün içinde olan yapılmamış aşılar için hatırlatma gönderir; her aşı-tarih çifti bir kez
+// hatırlatılır
+func (s *VaccinationService) sendReminders(now time.Time) error {
+	today := now.Format("2006-01-02")
+	rows, err := s.db.Query(`
+		SELECT v.id, v.vaccine, date(v.due_date), l.id, l.user_id, l.tag_number
+		FROM vaccinations v
+		JOIN livestock l ON l.id = v.livestock_id
+		WHERE v.administered_date IS NULL AND date(v.due_date) BETWEEN date(?) AND date(?, ?)
+		  AND `+inHerdCondition+`
+	`, today, today, fmt.Sprintf("+%d days", vaccinationReminderDays))
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	var reminders []Notification
+	for rows.Next() {
+		var id, vaccine, dueDate, animalID, farmID, tagNumber string
+		if err := rows.Scan(&id, &vaccine, &dueDate, &animalID, &farmID, &tagNumber); err != nil {
+			return err
+		}
+		reminders = append(reminders, Notification{
+			UserID:       farmID,
+			Template:     "vaccination_due",
+			Type:         "reminder",
+			Priority:     "high",
+			Topic:        models.NotificationTopicVaccinationDue,
+			Entity:       &models.RelatedEntity{Type: "livestock", ID: animalID, Name: tagNumber},
+			Params:       map[string]interface{}{"date": dueDate, "vaccine": vaccine},
+			DedupeKey:    "vaccination:" + id + ":" + dueDate,
+			DedupeWindow: (vaccinationReminderDays + 1) * 24 * time.Hour,
+		})
+	}
+	if err := rows.Err(); err != nil {
+		return err
+	}
+
+	_, err = s.notifications.CreateBatch(reminders)
+	return err
+}
+
+// checkAnimal hayvanın çiftliğe ait olduğunu doğrular
+func (s *VaccinationService) checkAnimal(farmID, animalID string) error {
+	var exists bool
+	if err := s.db.QueryRow("SELECT 1 FROM livestock WHERE id = ? AND user_id = ?", animalID, farmID).Scan(&exists); err != nil {
+		return ErrLivestockNotFound
+	}
+	return nil
+}
+
+// query aşı takvimi satırlarını okur ve durumlarını hesaplar
+func (s *VaccinationService) query(query string, args ...interface{}) ([]models.Vaccination, error) {
+	rows, err := s.db.Query(query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	now := time.Now().UTC()
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC)
+
+	vaccinations := []models.Vaccination{}
+	for rows.Next() {
+		var vaccination models.Vaccination
+		var administered, healthRecordID sql.NullString
+		if err := rows.Scan(&vaccination.ID, &vaccination.AnimalID, &vaccination.TagNumber, &vaccination.AnimalType,
+			&vaccination.Vaccine, &vaccination.Dose, &vaccination.DueDate, &administered, &vaccination.BatchNumber,
+			&vaccination.Veterinarian, &vaccination.Notes, &healthRecordID, &vaccination.CreatedAt,
+			&vaccination.UpdatedAt); err != nil {
+			return nil, err
+		}
+		vaccination.AdministeredDate = utils.NullStringToPtr(administered)
+		vaccination.HealthRecordID = utils.NullStringToPtr(healthRecordID)
+
+		vaccination.Status = models.VaccinationStatusAdministered
+		if !administered.Valid {
+			vaccination.Status = models.VaccinationStatusScheduled
+			if due, err := time.Parse("2006-01-02", vaccination.DueDate); err == nil {
+				days := int(due.Sub(today).Hours() / 24)
+				vaccination.DaysUntilDue = &days
+				if days < 0 {
+					vaccination.Status = models.VaccinationStatusOverdue
+				}
+			}
+		}
+		vaccinations = append(vaccinations, vaccination)
+	}
+	return vaccinations, rows.Err()
+}
+
+// syncVaccinationRecord aşının sağlık kaydını yapılma tarihine göre ekler, günceller veya siler
+func syncVaccinationRecord(tx *sql.Tx, farmID, vaccinationID, animalID string, recordID *string, req models.VaccinationRequest) error {
+	description := strings.TrimSpace(req.Vaccine)
+	if req.Dose != "" {
+		description += " - " + req.Dose
+	}
+
+	switch {
+	case req.AdministeredDate != nil && recordID == nil:
+		id := utils.GenerateID()
+		if _, err := tx.Exec(`
+			INSERT INTO health_records (id, livestock_id, type, description, date, veterinarian, notes, created_at)
+			VALUES (?, ?, ?, ?, ?, ?, ?, CURRENT_TIMESTAMP)
+		`, id, animalID, models.ProtocolStepVaccination, description, *req.AdministeredDate, req.Veterinarian,
+			req.Notes); err != nil {
+			return err
+		}
+		_, err := tx.Exec("UPDATE vaccinations SET health_record_id = ? WHERE id = ? AND "+farmLivestockCondition,
+			id, vaccinationID, farmID)
+		return err
+	case req.AdministeredDate != nil:
+		_, err := tx.Exec(`
+			UPDATE health_records SET description = ?, date = ?, veterinarian = ?, notes = ?
+			WHERE id = ? AND `+farmLivestockCondition+`
+		`, description, *req.AdministeredDate, req.Veterinarian, req.Notes, *recordID, farmID)
+		return err
+	case recordID != nil:
+		if _, err := tx.Exec("DELETE FROM health_records WHERE id = ? AND "+farmLivestockCondition, *recordID, farmID); err != nil {
+			return err
+		}
+		_, err := tx.Exec("UPDATE vaccinations SET health_record_id = NULL WHERE id = ? AND "+farmLivestockCondition,
+			vaccinationID, farmID)
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,392 @@
+package services
+
+import (
+	"database/sql"
+	"errors"
+	"math"
+	"time"
+
+	"agri-management-api/internal/models"
+	"agri-management-api/internal/utils"
+)
+
+// defaultWaterQuotaWarningPercent uyarı eşiği verilmeyen kotalarda kullanılan kullanım yüzdesi
+const defaultWaterQuotaWarningPercent = 80
+
+// Kota uyarı seviyeleri; alert_level sütununda en son bildirilen seviye tutulur
+const (
+	waterQuotaLevelOK = iota
+	waterQuotaLevelWarning
+	waterQuotaLevelExceeded
+)
+
+// waterActivityDate sulama aktivitesinin kullanım tarihi
+const waterActivityDate = "date(COALESCE(a.actual_date, a.created_at))"
+
+// waterActivityConsumed yalnızca gerçekleşmiş (veya tarih planlanmadan kaydedilmiş) sulamalar kotadan düşülür
+const waterActivityConsumed = "a.water_volume > 0 AND (a.actual_date IS NOT NULL OR a.scheduled_date IS NULL)"
+
+var (
+	// ErrWaterQuotaNotFound kota yok veya çiftliğe ait değil
+	ErrWaterQuotaNotFound = errors.New("water quota not found")
+	// ErrWaterQuotaLand kotanın arazisi bulunamadı
+	ErrWaterQuotaLand = errors.New("water quota land not found")
+	// ErrInvalidWaterQuotaSeason sezon bitişi başlangıçtan önce
+	ErrInvalidWaterQuotaSeason = errors.New("invalid water quota season")
+)
+
+// waterQuotaSelect kota sütunları
+const waterQuotaSelect = `
+	SELECT q.id, q.land_id, COALESCE(l.name, ''), q.name, date(q.season_start), date(q.season_end), q.volume,
+	       COALESCE(q.unit_price, 0), COALESCE(q.warning_percent, 80), COALESCE(q.notes, ''), q.created_at, q.updated_at
+	FROM water_quotas q
+	LEFT JOIN lands l ON l.id = q.land_id AND l.user_id = q.user_id`
+
+// WaterQuotaService arazi ve çiftlik bazında sezonluk su tahsislerini, sulama aktivitelerinden düşülen kullanımı
+// ve kota eşiği uyarılarını yönetir
+type WaterQuotaService struct {
+	db            *sql.DB
+	notifications *NotificationService
+}
+
+// NewWaterQuotaService yeni water quota service oluşturur
+func NewWaterQuotaService(db *sql.DB) *WaterQuotaService {
+	return &WaterQuotaService{db: db, notifications: NewNotificationService(db)}
+}
+
+// List çiftliğin kotalarını kullanım durumlarıyla döner; landID verilirse araziyi kapsayan (arazi ve çiftlik) kotalar
+func (s *WaterQuotaService) List(farmID, landID string) ([]models.WaterQuota, error) {
+	query := waterQuotaSelect + " WHERE q.user_id = ?"
+	args := []interface{}{farmID}
+	if landID != "" {
+		query += " AND (q.land_id = ? OR q.land_id IS NULL)"
+		args = append(args, landID)
+	}
+	query += " ORDER BY q.season_start DESC, q.name"
+
+	quotas, err := s.query(query, args...)
+	if err != nil {
+		return nil, err
+	}
+	for i := range quotas {
+		if err := s.fillUsage(farmID, &quotas[i]); err != nil {
+			return nil, err
+		}
+	}
+	return quotas, nil
+}
+
+// Get kotayı kullanım durumuyla döner
+func (s *WaterQuotaService) Get(farmID, id string) (*models.WaterQuota, error) {
+	quotas, err := s.query(waterQuotaSelect+" WHERE q.id = ? AND q.user_id = ?", id, farmID)
+	if err != nil {
+		return nil, err
+	}
+	if len(quotas) == 0 {
+		return nil, ErrWaterQuotaNotFound
+	}
+	quota := quotas[0]
+	if err := s.fillUsage(farmID, &quota); err != nil {
+		return nil, err
+	}
+	return &quota, nil
+}
+
+// Create yeni kota ekler; mevcut kullanım eşiği aşıyorsa bildirim gönderilir
+func (s *WaterQuotaService) Create(farmID string, req models.WaterQuotaRequest) (*models.WaterQuota, error) {
+	landID, warning, err := s.validate(farmID, req)
+	if err != nil {
+		return nil, err
+	}
+
+	id := utils.GenerateID()
+	_, err = s.db.Exec(`
+		INSERT INTO water_quotas (id, user_id, land_id, name, season_start, season_end, volume, unit_price,
+		                          warning_percent, alert_level, notes, created_at, updated_at)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, 0, ?, CURRENT_TIMESTAMP, CURRENT_TIMESTAMP)
+	`, id, farmID, landID, req.Name, req.SeasonStart, req.SeasonEnd, req.Volume, req.UnitPrice, warning, req.Notes)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.checkQuotas(farmID, "q.id = ?", id); err != nil {
+		return nil, err
+	}
+	return s.Get(farmID, id)
+}
+
+// Update kotayı günceller. Uyarı seviyesi yeni kullanım durumuna göre yeniden değerlendirilir; artan kota
+// sonrasında eşik tekrar aşılırsa yeniden bildirilir
+func (s *WaterQuotaService) Update(farmID, id string, req models.WaterQuotaRequest) (*models.WaterQuota, error) {
+	landID, warning, err := s.validate(farmID, req)
+	if err != nil {
+		return nil, err
+	}
+
+	result, err := s.db.Exec(`
+		UPDATE water_quotas
+		SET land_id = ?, name = ?, season_start = ?, season_end = ?, volume = ?, unit_price = ?, warning_percent = ?,
+		    notes = ?, updated_at = CURRENT_TIMESTAMP
+		WHERE id = ? AND user_id = ?
+	`, landID, req.Name, req.SeasonStart, req.SeasonEnd, req.Volume, req.UnitPrice, warning, req.Notes, id, farmID)
+	if err != nil {
+		return nil, err
+	}
+	if rowsAffected, _ := result.RowsAffected(); rowsAffected == 0 {
+		return nil, ErrWaterQuotaNotFound
+	}
+
+	if err := s.checkQuotas(farmID, "q.id = ?", id); err != nil {
+		return nil, err
+	}
+	return s.Get(farmID, id)
+}
+
+// Delete kotayı siler
+func (s *WaterQuotaService) Delete(farmID, id string) error {
+	result, err := s.db.Exec("DELETE FROM water_quotas WHERE id = ? AND user_id = ?", id, farmID)
+	if err != nil {
+		return err
+	}
+	if rowsAffected, _ := result.RowsAffected(); rowsAffected == 0 {
+		return ErrWaterQuotaNotFound
+	}
+	return nil
+}
+
+// CheckLand arazinin verilen gündeki sulamasından etkilenen kotaları (arazi ve çiftlik kotaları) değerlendirir;
+// uyarı eşiğine yaklaşan veya aşılan kotalar için bildirim gönderilir
+func (s *WaterQuotaService) CheckLand(farmID, landID string, day time.Time) error {
+	return s.checkQuotas(farmID, "(q.land_id = ? OR q.land_id IS NULL) AND ? BETWEEN date(q.season_start) AND date(q.season_end)",
+		landID, day.Format("2006-01-02"))
+}
+
+// LandWater arazinin dönemdeki sulama suyunu ve maliyetini hesaplar. Her sulama, tarihini kapsayan arazi kotasının
+// (yoksa çiftlik kotasının) birim fiyatıyla değerlenir; kotası olmayan hacim unquotedVolume alanında döner
+func (s *WaterQuotaService) LandWater(farmID, landID string, from, to string) (models.LandWaterUsage, error) {
+	usage := models.LandWaterUsage{Quotas: []models.WaterQuota{}}
+
+	var exists int
+	err := s.db.QueryRow("SELECT 1 FROM lands WHERE id = ? AND user_id = ?", landID, farmID).Scan(&exists)
+	if err == sql.ErrNoRows {
+		return usage, ErrWaterQuotaLand
+	}
+	if err != nil {
+		return usage, err
+	}
+
+	quotas, err := s.query(waterQuotaSelect+`
+		WHERE q.user_id = ? AND (q.land_id = ? OR q.land_id IS NULL)
+		  AND date(q.season_start) <= ? AND date(q.season_end) >= ?
+		ORDER BY q.land_id IS NULL, q.season_start
+	`, farmID, landID, to, from)
+	if err != nil {
+		return usage, err
+	}
+
+	rows, err := s.db.Query(`
+		SELECT `+waterActivityDate+`, a.water_volume
+		FROM land_activities a JOIN lands l ON l.id = a.land_id
+		WHERE l.user_id = ? AND a.land_id = ? AND `+waterActivityConsumed+`
+		  AND `+waterActivityDate+` BETWEEN ? AND ?
+	`, farmID, landID, from, to)
+	if err != nil {
+		return usage, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var day string
+		var volume float64
+		if err := rows.Scan(&day, &volume); err != nil {
+			return usage, err
+		}
+		usage.Irrigation++
+		usage.Volume += volume
+
+		// Arazi kotaları sıralamada önce gelir
+		priced := false
+		for _, quota := range quotas {
+			if day >= quota.SeasonStart && day <= quota.SeasonEnd {
+				usage.Cost += volume * quota.UnitPrice
+				priced = true
+				break
+			}
+		}
+		if !priced {
+			usage.Unquoted += volume
+		}
+	}
+	if err := rows.Err(); err != nil {
+		return usage, err
+	}
+
+	for i := range quotas {
+		if err := s.fillUsage(farmID, &quotas[i]); err != nil {
+			return usage, err
+		}
+	}
+	usage.Quotas = quotas
+	usage.Volume = round2(usage.Volume)
+	usage.Cost = round2(usage.Cost)
+	usage.Unquoted = round2(usage.Unquoted)
+	return usage, nil
+}
+
+// validate isteği doğrular; arazi kimliğini ve uyarı eşiğini döner
+func (s *WaterQuotaService) validate(farmID string, req models.WaterQuotaRequest) (sql.NullString, float64, error) {
+	if req.SeasonEnd < req.SeasonStart {
+		return sql.NullString{}, 0, ErrInvalidWaterQuotaSeason
+	}
+
+	var landID sql.NullString
+	if req.LandID != nil && *req.LandID != "" {
+		var exists int
+		err := s.db.QueryRow("SELECT 1 FROM lands WHERE id = ? AND user_id = ?", *req.LandID, farmID).Scan(&exists)
+		if err == sql.ErrNoRows {
+			return landID, 0, ErrWaterQuotaLand
+		}
+		if err != nil {
+			return landID, 0, err
+		}
+		landID = utils.StringToNullString(*req.LandID)
+	}
+
+	warning := float64(defaultWaterQuotaWarningPercent)
+	if req.WarningPercent != nil {
+		warning = *req.WarningPercent
+	}
+	return landID, warning, nil
+}
+
+// fillUsage kotanın sezon içindeki kullanımını, kalan hacmi, kullanım oranını ve su maliyetini hesaplar
+func (s *WaterQuotaService) fillUsage(farmID string, quota *models.WaterQuota) error {
+	query := `
+		SELECT COALESCE(SUM(a.water_volume), 0)
+		FROM land_activities a JOIN lands l ON l.id = a.land_id
+		WHERE l.user_id = ? AND ` + waterActivityConsumed + ` AND ` + waterActivityDate + ` BETWEEN ? AND ?`
+	args := []interface{}{farmID, quota.SeasonStart, quota.SeasonEnd}
+	if quota.LandID != nil {
+		query += " AND a.land_id = ?"
+		args = append(args, *quota.LandID)
+	}
+
+	var used float64
+	if err := s.db.QueryRow(query, args...).Scan(&used); err != nil {
+		return err
+	}
+
+	quota.Used = round2(used)
+	quota.Remaining = round2(math.Max(quota.Volume-used, 0))
+	quota.WaterCost = round2(used * quota.UnitPrice)
+	if quota.Volume > 0 {
+		quota.Utilization = round2(used / quota.Volume * 100)
+	}
+	switch waterQuotaLevel(*quota) {
+	case waterQuotaLevelExceeded:
+		quota.Status = models.WaterQuotaExceeded
+	case waterQuotaLevelWarning:
+		quota.Status = models.WaterQuotaWarning
+	default:
+		quota.Status = models.WaterQuotaOK
+	}
+	return nil
+}
+
+// waterQuotaLevel kullanım oranının uyarı seviyesi
+func waterQuotaLevel(quota models.WaterQuota) int {
+	switch {
+	case quota.Utilization >= 100:
+		return waterQuotaLevelExceeded
+	case quota.Utilization >= quota.WarningPercent:
+		return waterQuotaLevelWarning
+	}
+	return waterQuotaLevelOK
+}
+
+// checkQuotas koşula uyan kotaların seviyesini günceller; seviye son bildirilenden yükseldiyse bildirim gönderir.
+// Kullanım düştüğünde seviye sessizce düşürülür, böylece eşik yeniden aşılınca tekrar bildirilir
+func (s *WaterQuotaService) checkQuotas(farmID, condition string, args ...interface{}) error {
+	rows, err := s.db.Query(`
+		SELECT q.id, COALESCE(q.alert_level, 0) FROM water_quotas q
+		WHERE q.user_id = ? AND `+condition, append([]interface{}{farmID}, args...)...)
+	if err != nil {
+		return err
+	}
+	levels := map[string]int{}
+	for rows.Next() {
+		var id string
+		var level int
+		if err := rows.Scan(&id, &level); err != nil {
+			rows.Close()
+			return err
+		}
+		levels[id] = level
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return err
+	}
+
+	var alerts []Notification
+	for id, notified := range levels {
+		quota, err := s.Get(farmID, id)
+		if err != nil {
+			return err
+		}
+		level := waterQuotaLevel(*quota)
+		if level == notified {
+			continue
+		}
+		if _, err := s.db.Exec("UPDATE water_quotas SET alert_level = ? WHERE id = ? AND user_id = ?", level, id, farmID); err != nil {
+			return err
+		}
+		if level < notified {
+			continue
+		}
+
+		template, priority := "water_quota_warning", "medium"
+		if level == waterQuotaLevelExceeded {
+			template, priority = "water_quota_exceeded", "high"
+		}
+		alerts = append(alerts, Notification{
+			UserID:   farmID,
+			Template: template,
+			Type:     "alert",
+			Priority: priority,
+			Topic:    models.NotificationTopicWaterQuota,
+			Entity:   &models.RelatedEntity{Type: "water_quota", ID: quota.ID, Name: quota.Name},
+			Params: map[string]interface{}{
+				"percent": quota.Utilization,
+				"used":    quota.Used,
+				"volume":  quota.Volume,
+			},
+		})
+	}
+
+	_, err = s.notifications.CreateBatch(alerts)
+	return err
+}
+
+// query kota satırlarını okur
+func (s *WaterQuotaService) query(query string, args ...interface{}) ([]models.WaterQuota, error) {
+	rows, err := s.db.Query(query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	quotas := []models.WaterQuota{}
+	for rows.Next() {
+		var quota models.WaterQuota
+		var landID sql.NullString
+		err := rows.Scan(&quota.ID, &landID, &quota.LandName, &quota.Name, &quota.SeasonStart, &quota.SeasonEnd,
+			&quota.Volume, &quota.UnitPrice, &quota.WarningPercent, &quota.Notes, &quota.CreatedAt, &quota.UpdatedAt)
+		if err != nil {
+			return nil, err
+		}
+		quota.LandID = utils.NullStringToPtr(landID)
+		quotas = append(quotas, quota)
+	}
+	return quotas, rows.Err()
+}
@@ -0,0 +1,223 @@
+package services
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"net/http"
+	"os"
+	"time"
+
+	"agri-management-api/internal/models"
+)
+
+// ErrWeatherProviderDisabled hava durumu sağlayıcısı yapılandırılmadığında döner
+var ErrWeatherProviderDisabled = errors.New("weather provider not configured")
+
+var weatherClient = &http.Client{Timeout: 10 * time.Second}
+
+// WeatherProviderConfigured hava durumu sağlayıcısının API anahtarı tanımlı mı
+func WeatherProviderConfigured() bool {
+	return os.Getenv("OPENWEATHER_API_KEY") != ""
+}
+
+// FetchCurrentWeather OpenWeatherMap'ten güncel hava durumunu alır; API anahtarı OPENWEATHER_API_KEY ortam değişkeninden okunur
+func FetchCurrentWeather(lat, lon float64) (*models.Weather, error) {
+	if !WeatherProviderConfigured() {
+		return nil, ErrWeatherProviderDisabled
+	}
+	apiKey := os.Getenv("OPENWEATHER_API_KEY")
+
+	url := fmt.Sprintf("https://api.openweathermap.org/data/2.5/weather?lat=%f&lon=%f&appid=%s&units=metric&lang=tr", lat, lon, apiKey)
+
+	resp, err := weatherClient.Get(url)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("weather provider returned %d: %s", resp.StatusCode, body)
+	}
+
+	var apiResponse struct {
+		Name string `json:"name"`
+		Main struct {
+			Temp     float64 `json:"temp"`
+			Humidity float64 `json:"humidity"`
+			Pressure float64 `json:"pressure"`
+		} `json:"main"`
+		Weather []struct {
+			Main        string `json:"main"`
+			Description string `json:"description"`
+			Icon        string `json:"icon"`
+		} `json:"weather"`
+		Wind struct {
+			Speed float64 `json:"speed"`
+			Deg   float64 `json:"deg"`
+		} `json:"wind"`
+		Rain struct {
+			OneHour float64 `json:"1h"`
+		} `json:"rain"`
+		Visibility int `json:"visibility"`
+	}
+
+	if err := json.Unmarshal(body, &apiResponse); err != nil {
+		return nil, err
+	}
+
+	weather := &models.Weather{
+		Location:      apiResponse.Name,
+		Temperature:   apiResponse.Main.Temp,
+		Humidity:      apiResponse.Main.Humidity,
+		WindSpeed:     apiResponse.Wind.Speed,
+		WindDirection: windDirection(apiResponse.Wind.Deg),
+		Pressure:      apiResponse.Main.Pressure,
+		Visibility:    float64(apiResponse.Visibility) / 1000, // m to km
+		UVIndex:       5.0,                                    // Mock value
+		Rainfall:      apiResponse.Rain.OneHour,
+		LastUpdated:   time.Now().Format("2006-01-02T15:04:05Z"),
+	}
+	if len(apiResponse.Weather) > 0 {
+		weather.Condition = apiResponse.Weather[0].Description
+		weather.Icon = apiResponse.Weather[0].Icon
+	}
+
+	return weather, nil
+}
+
+// FetchWeatherForecast OpenWeatherMap'in 3 saatlik tahminlerini bugünden başlayarak günlük tahminlere
+// toplar; sağlayıcı en fazla 5 günlük tahmin verdiğinden days bu sayıyla sınırlanır. Yağış olasılığı günün en
+// yüksek olasılığı, yağış miktarı günün toplam beklenen yağışıdır (mm)
+func FetchWeatherForecast(lat, lon float64, days int) ([]models.WeatherForecast, error) {
+	if !WeatherProviderConfigured() {
+		return nil, ErrWeatherProviderDisabled
+	}
+	apiKey := os.Getenv("OPENWEATHER_API_KEY")
+
+	url := fmt.Sprintf("https://api.openweathermap.org/data/2.5/forecast?lat=%f&lon=%f&appid=%s&units=metric&lang=tr", lat, lon, apiKey)
+
+	resp, err := weatherClient.Get(url)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("weather provider returned %d: %s", resp.StatusCode, body)
+	}
+
+	var apiResponse struct {
+		List []struct {
+			Dt   int64 `json:"dt"`
+			Main struct {
+				TempMin  float64 `json:"temp_min"`
+				TempMax  float64 `json:"temp_max"`
+				Humidity float64 `json:"humidity"`
+			} `json:"main"`
+			Weather []struct {
+				Description string `json:"description"`
+				Icon        string `json:"icon"`
+			} `json:"weather"`
+			Wind struct {
+				Speed float64 `json:"speed"`
+			} `json:"wind"`
+			Pop  float64 `json:"pop"`
+			Rain struct {
+				ThreeHours float64 `json:"3h"`
+			} `json:"rain"`
+		} `json:"list"`
+		City struct {
+			Timezone int `json:"timezone"`
+		} `json:"city"`
+	}
+
+	if err := json.Unmarshal(body, &apiResponse); err != nil {
+		return nil, err
+	}
+
+	// Tahminler konumun yerel gününe göre toplanır
+	zone := time.FixedZone("", apiResponse.City.Timezone)
+	var forecast []models.WeatherForecast
+	samples := map[string]int{}
+	for _, item := range apiResponse.List {
+		at := time.Unix(item.Dt, 0).In(zone)
+		date := at.Format("2006-01-02")
+
+		if len(forecast) == 0 || forecast[len(forecast)-1].Date != date {
+			if len(forecast) == days {
+				break
+			}
+			forecast = append(forecast, models.WeatherForecast{Date: date, MinTemp: item.Main.TempMin, MaxTemp: item.Main.TempMax})
+		}
+		day := &forecast[len(forecast)-1]
+		n := float64(samples[date])
+		samples[date]++
+
+		day.MinTemp = math.Min(day.MinTemp, item.Main.TempMin)
+		day.MaxTemp = math.Max(day.MaxTemp, item.Main.TempMax)
+		day.Humidity = (day.Humidity*n + item.Main.Humidity) / (n + 1)
+		day.WindSpeed = math.Max(day.WindSpeed, item.Wind.Speed*3.6) // m/s to km/h
+		day.RainChance = math.Max(day.RainChance, item.Pop*100)
+		day.Rainfall += item.Rain.ThreeHours
+		// Gün ortasına en yakın ölçümün durumu günün durumu olarak gösterilir
+		if len(item.Weather) > 0 && (day.Condition == "" || at.Hour() <= 13) {
+			day.Condition = item.Weather[0].Description
+			day.Icon = item.Weather[0].Icon
+		}
+	}
+
+	for i := range forecast {
+		forecast[i].Humidity = math.Round(forecast[i].Humidity)
+		forecast[i].WindSpeed = math.Round(forecast[i].WindSpeed*10) / 10
+		forecast[i].RainChance = math.Round(forecast[i].RainChance)
+		forecast[i].Rainfall = math.Round(forecast[i].Rainfall*10) / 10
+	}
+	return forecast, nil
+}
+
+// MockWeatherForecast mock_weather bayrağı açıkken sağlayıcı yerine kullanılan örnek tahmin; yarından başlar
+func MockWeatherForecast(days int) []models.WeatherForecast {
+	var forecast []models.WeatherForecast
+
+	conditions := []string{"Güneşli", "Parçalı bulutlu", "Bulutlu", "Hafif yağmur", "Güneşli"}
+	icons := []string{"01d", "02d", "03d", "10d", "01d"}
+	rainfall := []float64{0, 0, 0.5, 6, 0}
+
+	for i := 0; i < days; i++ {
+		date := time.Now().AddDate(0, 0, i+1)
+
+		forecast = append(forecast, models.WeatherForecast{
+			Date:       date.Format("2006-01-02"),
+			MinTemp:    15.0 + float64(i%3),
+			MaxTemp:    25.0 + float64(i%5),
+			Condition:  conditions[i%len(conditions)],
+			Icon:       icons[i%len(icons)],
+			Humidity:   60.0 + float64(i%20),
+			RainChance: float64((i * 15) % 80),
+			Rainfall:   rainfall[i%len(rainfall)],
+			WindSpeed:  8.0 + float64(i%10),
+		})
+	}
+
+	return forecast
+}
+
+// windDirection rüzgar derecesini yön olarak çevirir
+func windDirection(deg float64) string {
+	directions := []string{"K", "KKD", "KD", "DKD", "D", "DGD", "GD", "GGD", "G", "GGB", "GB", "BGB", "B", "BBK", "BK", "KBK"}
+	index := int((deg + 11.25) / 22.5)
+	return directions[index%16]
+}
@@ -0,0 +1,542 @@
+package services
+
+import (
+	"database/sql"
+	"log"
+	"math"
+	"sort"
+	"strings"
+	"time"
+
+	"agri-management-api/internal/models"
+	"agri-management-api/internal/utils"
+)
+
+// rainyDayThreshold yağışlı gün sayılması için gereken en az yağış (mm)
+const rainyDayThreshold = 1.0
+
+// Hava durumu uyarı eşikleri
+const (
+	// frostAlertTemperature bu sıcaklık ve altında don uyarısı gönderilir (°C)
+	frostAlertTemperature = 0.0
+	// heavyRainAlertRainfall saatlik ölçümde bu yağış ve üzerinde yoğun yağış uyarısı gönderilir (mm)
+	heavyRainAlertRainfall = 10.0
+)
+
+// WeatherHistoryService arazi konumları için günlük hava gözlemlerini saklar ve analiz eder
+type WeatherHistoryService struct {
+	db            *sql.DB
+	notifications *NotificationService
+}
+
+// NewWeatherHistoryService yeni weather history service oluşturur
+func NewWeatherHistoryService(db *sql.DB) *WeatherHistoryService {
+	return &WeatherHistoryService{db: db, notifications: NewNotificationService(db)}
+}
+
+// StartCollector konumu olan arazilerin hava durumunu saatlik olarak toplar; sağlayıcı yapılandırılmamışsa başlamaz
+func (s *WeatherHistoryService) StartCollector() {
+	if !WeatherProviderConfigured() {
+		log.Println("Hava durumu sağlayıcısı yapılandırılmamış, hava geçmişi toplanmayacak")
+		return
+	}
+
+	go func() {
+		ticker := time.NewTicker(time.Hour)
+		defer ticker.Stop()
+
+		for {
+			if err := s.CollectAll(); err != nil {
+				log.Printf("Hava geçmişi toplanamadı: %v", err)
+			}
+			<-ticker.C
+		}
+	}()
+}
+
+// CollectAll konumu olan tüm araziler için güncel hava durumunu günlük gözleme işler ve don veya
+// yoğun yağış ölçülen araziler için günde en fazla bir uyarı gönderir. Eşleştirilen istasyonu sağlayıcıdan önce
+// gelen ve istasyonu son saatlerde ölçüm gönderen arazilerin uyarıları istasyon ölçümlerinden üretilir
+func (s *WeatherHistoryService) CollectAll() error {
+	rows, err := s.db.Query(`
+		SELECT l.id, l.user_id, l.name, l.latitude, l.longitude, COALESCE(l.weather_sources, ''), s.last_reading_at
+		FROM lands l
+		LEFT JOIN weather_stations s ON s.id = l.weather_station_id
+		WHERE l.latitude IS NOT NULL AND l.longitude IS NOT NULL AND l.status != 'inactive'
+	`)
+	if err != nil {
+		return err
+	}
+
+	type landLocation struct {
+		id, userID, name string
+		lat, lon         float64
+		stationAlerts    bool
+	}
+	var lands []landLocation
+	for rows.Next() {
+		var land landLocation
+		var sources string
+		var lastReading sql.NullTime
+		if err := rows.Scan(&land.id, &land.userID, &land.name, &land.lat, &land.lon, &sources, &lastReading); err != nil {
+			continue
+		}
+		land.stationAlerts = lastReading.Valid && time.Since(lastReading.Time) < stationFreshness &&
+			sourcePreferred(parseWeatherSources(sources), models.WeatherSourceStation, models.WeatherSourceProvider)
+		lands = append(lands, land)
+	}
+	rows.Close()
+
+	var alerts []Notification
+	for _, land := range lands {
+		weather, err := FetchCurrentWeather(land.lat, land.lon)
+		if err != nil {
+			log.Printf("Arazi %s için hava durumu alınamadı: %v", land.id, err)
+			continue
+		}
+		if err := s.RecordSample(land.id, land.userID, time.Now(), weather); err != nil {
+			log.Printf("Arazi %s için hava gözlemi kaydedilemedi: %v", land.id, err)
+		}
+		if !land.stationAlerts {
+			alerts = append(alerts, weatherAlerts(land.id, land.userID, land.name, weather)...)
+		}
+	}
+
+	if _, err := s.notifications.CreateBatch(alerts); err != nil {
+		return err
+	}
+	return nil
+}
+
+// weatherAlerts ölçümdeki don ve yoğun yağış uyarılarını üretir; aynı arazi ve uyarı türü için günde bir kez gönderilir
+func weatherAlerts(landID, userID, landName string, weather *models.Weather) []Notification {
+	entity := &models.RelatedEntity{Type: "land", ID: landID, Name: landName}
+	params := map[string]interface{}{
+		"temperature": weather.Temperature,
+		"rainfall":    weather.Rainfall,
+	}
+
+	var alerts []Notification
+	if weather.Temperature <= frostAlertTemperature {
+		alerts = append(alerts, Notification{
+			UserID:    userID,
+			Template:  "weather_frost",
+			Type:      "alert",
+			Priority:  "high",
+			Topic:     models.NotificationTopicWeatherAlert,
+			Entity:    entity,
+			Params:    params,
+			DedupeKey: "weather:frost:" + landID,
+		})
+	}
+	if weather.Rainfall >= heavyRainAlertRainfall {
+		alerts = append(alerts, Notification{
+			UserID:    userID,
+			Template:  "weather_heavy_rain",
+			Type:      "alert",
+			Priority:  "high",
+			Topic:     models.NotificationTopicWeatherAlert,
+			Entity:    entity,
+			Params:    params,
+			DedupeKey: "weather:heavy_rain:" + landID,
+		})
+	}
+	return alerts
+}
+
+// RecordSample saatlik sağlayıcı ölçümünü günün gözlemiyle birleştirir
+func (s *WeatherHistoryService) RecordSample(landID, userID string, at time.Time, weather *models.Weather) error {
+	_, err := s.db.Exec(`
+		INSERT INTO weather_observations
+			(id, land_id, user_id, observed_on, source, min_temp, max_temp, avg_temp, rainfall, humidity, samples, created_at, updated_at)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, 1, CURRENT_TIMESTAMP, CURRENT_TIMESTAMP)
+		ON CONFLICT (land_id, observed_on, source) DO UPDATE SET
+			min_temp = MIN(min_temp, excluded.min_temp),
+			max_temp = MAX(max_temp, excluded.max_temp),
+			avg_temp = (avg_temp * samples + excluded.avg_temp) / (samples + 1),
+			rainfall = COALESCE(rainfall, 0) + excluded.rainfall,
+			humidity = (humidity * samples + excluded.humidity) / (samples + 1),
+			samples = samples + 1,
+			updated_at = CURRENT_TIMESTAMP
+	`, utils.GenerateID(), landID, userID, at.Format("2006-01-02"), models.WeatherSourceProvider,
+		weather.Temperature, weather.Temperature, weather.Temperature, weather.Rainfall, weather.Humidity)
+	return err
+}
+
+// SaveManual kullanıcı gözlemlerini kaydeder; aynı gün için önceki kullanıcı gözleminin yerine geçer
+func (s *WeatherHistoryService) SaveManual(landID, userID string, requests []models.WeatherObservationRequest) ([]models.WeatherObservation, error) {
+	tx, err := s.db.Begin()
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	for _, req := range requests {
+		var avgTemp *float64
+		if req.MinTemp != nil && req.MaxTemp != nil {
+			avg := (*req.MinTemp + *req.MaxTemp) / 2
+			avgTemp = &avg
+		}
+
+		_, err := tx.Exec(`
+			INSERT INTO weather_observations
+				(id, land_id, user_id, observed_on, source, min_temp, max_temp, avg_temp, rainfall, humidity, notes, samples, created_at, updated_at)
+			VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, 1, CURRENT_TIMESTAMP, CURRENT_TIMESTAMP)
+			ON CONFLICT (land_id, observed_on, source) DO UPDATE SET
+				min_temp = excluded.min_temp,
+				max_temp = excluded.max_temp,
+				avg_temp = excluded.avg_temp,
+				rainfall = excluded.rainfall,
+				humidity = excluded.humidity,
+				notes = excluded.notes,
+				updated_at = CURRENT_TIMESTAMP
+		`, utils.GenerateID(), landID, userID, req.Date, models.WeatherSourceManual,
+			req.MinTemp, req.MaxTemp, avgTemp, req.Rainfall, req.Humidity, req.Notes)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, err
+	}
+
+	dates := make([]interface{}, 0, len(requests)+2)
+	placeholders := make([]string, 0, len(requests))
+	dates = append(dates, userID, landID)
+	for _, req := range requests {
+		dates = append(dates, req.Date)
+		placeholders = append(placeholders, "?")
+	}
+
+	rows, err := s.db.Query(weatherObservationSelect+`
+		WHERE user_id = ? AND land_id = ? AND source = 'manual' AND date(observed_on) IN (`+strings.Join(placeholders, ", ")+`)
+		ORDER BY observed_on
+	`, dates...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	return scanWeatherObservations(rows), nil
+}
+
+// Observations arazinin ham gözlem kayıtlarını döner; source boşsa tüm kaynaklar listelenir
+func (s *WeatherHistoryService) Observations(farmID, landID, source string, start, end time.Time) ([]models.WeatherObservation, error) {
+	query := weatherObservationSelect + " WHERE user_id = ? AND land_id = ? AND date(observed_on) BETWEEN ? AND ?"
+	args := []interface{}{farmID, landID, start.Format("2006-01-02"), end.Format("2006-01-02")}
+	if source != "" {
+		query += " AND source = ?"
+		args = append(args, source)
+	}
+
+	rows, err := s.db.Query(query+" ORDER BY observed_on, source", args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	return scanWeatherObservations(rows), nil
+}
+
+// DeleteManual kullanıcı gözlemini siler; sağlayıcı gözlemleri silinemez
+func (s *WeatherHistoryService) DeleteManual(farmID, landID, observationID string) (bool, error) {
+	result, err := s.db.Exec(`
+		DELETE FROM weather_observations WHERE id = ? AND land_id = ? AND user_id = ? AND source = 'manual'
+	`, observationID, landID, farmID)
+	if err != nil {
+		return false, err
+	}
+
+	rowsAffected, _ := result.RowsAffected()
+	return rowsAffected > 0, nil
+}
+
+// History tarih aralığındaki gözlemleri, özetini ve geçen yılın aynı dönemiyle karşılaştırmasını döner
+func (s *WeatherHistoryService) History(farmID, landID string, start, end time.Time) (*models.WeatherHistory, error) {
+	observations, err := s.dailyObservations(farmID, landID, start, end)
+	if err != nil {
+		return nil, err
+	}
+
+	history := &models.WeatherHistory{
+		LandID:       landID,
+		StartDate:    start.Format("2006-01-02"),
+		EndDate:      end.Format("2006-01-02"),
+		Observations: observations,
+		Summary:      summarizeWeather(observations),
+	}
+
+	lastYear, err := s.dailyObservations(farmID, landID, start.AddDate(-1, 0, 0), end.AddDate(-1, 0, 0))
+	if err != nil {
+		return nil, err
+	}
+	if len(lastYear) > 0 {
+		summary := summarizeWeather(lastYear)
+		history.LastYear = &summary
+		history.Comparison = compareWeather(history.Summary, summary)
+	}
+
+	return history, nil
+}
+
+// weatherObservationSelect gözlem satırlarını okuyan ortak sorgu başlangıcı
+const weatherObservationSelect = `
+	SELECT id, land_id, date(observed_on), source, min_temp, max_temp, avg_temp,
+	       rainfall, humidity, COALESCE(notes, ''), created_at, updated_at
+	FROM weather_observations`
+
+// stationFreshness istasyonun güncel sayılması için son ölçümünün en fazla bu kadar eski olması gerekir
+const stationFreshness = 3 * time.Hour
+
+// parseWeatherSources arazinin kayıtlı kaynak sırasını okur; boşsa varsayılan sıra kullanılır
+func parseWeatherSources(value string) []string {
+	var sources []string
+	for _, source := range strings.Split(value, ",") {
+		if source = strings.TrimSpace(source); source != "" {
+			sources = append(sources, source)
+		}
+	}
+	if len(sources) == 0 {
+		return append([]string(nil), models.DefaultWeatherSources...)
+	}
+	return sources
+}
+
+// sourcePreferred kaynak sırada yer alıyor ve diğer kaynaktan önce geliyorsa true döner
+func sourcePreferred(sources []string, source, other string) bool {
+	for _, s := range sources {
+		switch s {
+		case source:
+			return true
+		case other:
+			return false
+		}
+	}
+	return false
+}
+
+// sourceRank gözlem kaynağının arazinin tercih sırasındaki yeri; kullanıcı gözlemleri her zaman önce gelir,
+// sırada olmayan kaynaklar için -1 döner
+func sourceRank(sources []string, source string) int {
+	if source == models.WeatherSourceManual {
+		return 0
+	}
+	for i, s := range sources {
+		if s == source {
+			return i + 1
+		}
+	}
+	return -1
+}
+
+// weatherSourceRankSQL sourceRank ile aynı sıralamayı SQL'de hesaplar; l arazi, w gözlem tablosudur
+const weatherSourceRankSQL = `CASE WHEN w.source = 'manual' THEN 0
+	ELSE instr(',' || COALESCE(NULLIF(l.weather_sources, ''), 'station,provider') || ',', ',' || w.source || ',') END`
+
+// preferredWeatherObservations her arazi ve gün için tercih sırasında ilk gelen kaynağın gözlemini seçen alt sorgu;
+// ilk parametre çiftlik kimliğidir. column verilirse o değeri boş olan gözlemler sona bırakılır
+func preferredWeatherObservations(column string) string {
+	order := weatherSourceRankSQL
+	if column != "" {
+		order = "w." + column + " IS NULL, " + order
+	}
+	return `(
+		SELECT * FROM (
+			SELECT w.*, ROW_NUMBER() OVER (PARTITION BY w.land_id, date(w.observed_on) ORDER BY ` + order + `) AS preference
+			FROM weather_observations w
+			JOIN lands l ON l.id = w.land_id
+			WHERE w.user_id = ? AND (w.source = 'manual' OR (` + weatherSourceRankSQL + `) > 0)
+		) WHERE preference = 1
+	)`
+}
+
+// landWeatherSources arazinin hava verisi kaynak sırasını döner
+func (s *WeatherHistoryService) landWeatherSources(farmID, landID string) ([]string, error) {
+	var sources sql.NullString
+	err := s.db.QueryRow("SELECT weather_sources FROM lands WHERE id = ? AND user_id = ?", landID, farmID).Scan(&sources)
+	if err != nil && err != sql.ErrNoRows {
+		return nil, err
+	}
+	return parseWeatherSources(sources.String), nil
+}
+
+// dailyObservations gözlemleri güne göre birleştirir ve kümülatif yağışı hesaplar; kullanıcının ölçtüğü değerler
+// önce gelir, eksik değerler arazinin kaynak sırasına göre (ör. istasyon, sonra sağlayıcı) tamamlanır
+func (s *WeatherHistoryService) dailyObservations(farmID, landID string, start, end time.Time) ([]models.WeatherObservation, error) {
+	raw, err := s.Observations(farmID, landID, "", start, end)
+	if err != nil {
+		return nil, err
+	}
+	sources, err := s.landWeatherSources(farmID, landID)
+	if err != nil {
+		return nil, err
+	}
+
+	byDate := map[string][]models.WeatherObservation{}
+	var dates []string
+	for _, observation := range raw {
+		if sourceRank(sources, observation.Source) < 0 {
+			continue
+		}
+		if _, ok := byDate[observation.Date]; !ok {
+			dates = append(dates, observation.Date)
+		}
+		byDate[observation.Date] = append(byDate[observation.Date], observation)
+	}
+
+	observations := make([]models.WeatherObservation, 0, len(dates))
+	for _, date := range dates {
+		day := byDate[date]
+		sort.SliceStable(day, func(i, j int) bool {
+			return sourceRank(sources, day[i].Source) < sourceRank(sources, day[j].Source)
+		})
+		merged := day[0]
+		for _, observation := range day[1:] {
+			merged = mergeWeatherObservations(merged, observation)
+		}
+		observations = append(observations, merged)
+	}
+
+	var cumulative float64
+	for i := range observations {
+		if observations[i].Rainfall != nil {
+			cumulative += *observations[i].Rainfall
+		}
+		observations[i].CumulativeRainfall = round2(cumulative)
+	}
+
+	return observations, nil
+}
+
+// mergeWeatherObservations aynı güne ait iki gözlemi birleştirir; preferred gözlemin değerleri korunur,
+// eksik değerler diğer gözlemden tamamlanır
+func mergeWeatherObservations(preferred, other models.WeatherObservation) models.WeatherObservation {
+	merged := preferred
+	merged.Source = models.WeatherSourceMerged
+	if merged.MinTemp == nil {
+		merged.MinTemp = other.MinTemp
+	}
+	if merged.MaxTemp == nil {
+		merged.MaxTemp = other.MaxTemp
+	}
+	if merged.AvgTemp == nil {
+		merged.AvgTemp = other.AvgTemp
+	}
+	if merged.Rainfall == nil {
+		merged.Rainfall = other.Rainfall
+	}
+	if merged.Humidity == nil {
+		merged.Humidity = other.Humidity
+	}
+
+	return merged
+}
+
+// scanWeatherObservations gözlem satırlarını okur
+func scanWeatherObservations(rows *sql.Rows) []models.WeatherObservation {
+	observations := []models.WeatherObservation{}
+	for rows.Next() {
+		var observation models.WeatherObservation
+		var minTemp, maxTemp, avgTemp, rainfall, humidity sql.NullFloat64
+
+		err := rows.Scan(
+			&observation.ID, &observation.LandID, &observation.Date, &observation.Source,
+			&minTemp, &maxTemp, &avgTemp, &rainfall, &humidity, &observation.Notes,
+			&observation.CreatedAt, &observation.UpdatedAt,
+		)
+		if err != nil {
+			continue
+		}
+
+		observation.MinTemp = utils.NullFloat64ToPtr(minTemp)
+		observation.MaxTemp = utils.NullFloat64ToPtr(maxTemp)
+		observation.AvgTemp = utils.NullFloat64ToPtr(avgTemp)
+		observation.Rainfall = utils.NullFloat64ToPtr(rainfall)
+		observation.Humidity = utils.NullFloat64ToPtr(humidity)
+		observations = append(observations, observation)
+	}
+
+	return observations
+}
+
+// summarizeWeather gözlemlerden yağış toplamı, sıcaklık uçları, don günleri ve en uzun kurak dönemi hesaplar
+func summarizeWeather(observations []models.WeatherObservation) models.WeatherPeriodSummary {
+	summary := models.WeatherPeriodSummary{Days: len(observations)}
+	if len(observations) == 0 {
+		return summary
+	}
+
+	var tempTotal float64
+	var tempDays int
+	drySpell := 0
+	for _, observation := range observations {
+		if observation.Rainfall != nil {
+			summary.TotalRainfall += *observation.Rainfall
+
+			if *observation.Rainfall >= rainyDayThreshold {
+				summary.RainyDays++
+				drySpell = 0
+			} else {
+				drySpell++
+				if drySpell > summary.LongestDrySpell {
+					summary.LongestDrySpell = drySpell
+				}
+			}
+		}
+
+		if observation.AvgTemp != nil {
+			tempTotal += *observation.AvgTemp
+			tempDays++
+		}
+
+		if observation.MinTemp != nil {
+			if *observation.MinTemp <= 0 {
+				summary.FrostDays++
+			}
+			if summary.MinTemp == nil || *observation.MinTemp < *summary.MinTemp {
+				minTemp := *observation.MinTemp
+				summary.MinTemp = &minTemp
+				summary.MinTempDate = observation.Date
+			}
+		}
+
+		if observation.MaxTemp != nil && (summary.MaxTemp == nil || *observation.MaxTemp > *summary.MaxTemp) {
+			maxTemp := *observation.MaxTemp
+			summary.MaxTemp = &maxTemp
+			summary.MaxTempDate = observation.Date
+		}
+	}
+
+	summary.TotalRainfall = round2(summary.TotalRainfall)
+	if tempDays > 0 {
+		avgTemp := round2(tempTotal / float64(tempDays))
+		summary.AvgTemp = &avgTemp
+	}
+
+	return summary
+}
+
+// compareWeather dönem özetini geçen yılın özetiyle karşılaştırır
+func compareWeather(current, lastYear models.WeatherPeriodSummary) *models.WeatherComparison {
+	comparison := &models.WeatherComparison{
+		RainfallDiff:  round2(current.TotalRainfall - lastYear.TotalRainfall),
+		FrostDaysDiff: current.FrostDays - lastYear.FrostDays,
+	}
+
+	if lastYear.TotalRainfall > 0 {
+		change := round2(comparison.RainfallDiff / lastYear.TotalRainfall * 100)
+		comparison.RainfallChangePercent = &change
+	}
+	if current.AvgTemp != nil && lastYear.AvgTemp != nil {
+		diff := round2(*current.AvgTemp - *lastYear.AvgTemp)
+		comparison.AvgTempDiff = &diff
+	}
+
+	return comparison
+}
+
+// round2 değeri iki ondalık basamağa yuvarlar
+func round2(v float64) float64 {
+	return math.Round(v*100) / 100
+}
@@ -0,0 +1,472 @@
+package services
+
+import (
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"strings"
+	"time"
+	_ "time/tzdata"
+
+	"agri-management-api/internal/models"
+	"agri-management-api/internal/utils"
+)
+
+// Kayıtlı konum bildirimi sabitleri
+const (
+	// WeatherLocationForecastDays konum tahmininin varsayılan gün sayısı
+	WeatherLocationForecastDays = 5
+	// weatherLocationAlertDays bildirimlerde bugünden başlayarak bu kadar günün tahmini uyarı için denetlenir
+	weatherLocationAlertDays = 2
+	// heavyRainForecastRainfall günlük tahminde bu yağış ve üzerinde yoğun yağış uyarısı verilir (mm)
+	heavyRainForecastRainfall = 20.0
+	// weatherDeliveryInterval teslim saati gelen konumların kontrol aralığı
+	weatherDeliveryInterval = 10 * time.Minute
+	// defaultWeatherDeliveryHour abonelikte saat verilmezse bildirimlerin gönderildiği yerel saat
+	defaultWeatherDeliveryHour = 7
+	// defaultWeatherTimezone abonelikte saat dilimi verilmezse kullanılan saat dilimi
+	defaultWeatherTimezone = "Europe/Istanbul"
+	// WeatherLocationForecastMock sağlayıcıya ulaşılamadığında döndürülen örnek tahminin kaynağı
+	WeatherLocationForecastMock = "mock"
+)
+
+// Kayıtlı konum hataları
+var (
+	ErrWeatherLocationNotFound = errors.New("weather location not found")
+	// ErrWeatherLocationTimezone saat dilimi IANA adı olmalı
+	ErrWeatherLocationTimezone = errors.New("saat dilimi geçersiz; IANA adı kullanın (ör. Europe/Istanbul)")
+	// ErrWeatherLocationForecast hava tahmini sağlayıcıdan alınamadı
+	ErrWeatherLocationForecast = errors.New("hava tahmini alınamadı")
+)
+
+// WeatherLocationService arazi dışındaki kayıtlı konumları yönetir; abone olunan konumlar için günlük tahmin
+// özetini ve don/yoğun yağış uyarılarını konumun saat dilimindeki teslim saatinde bildirim ve e-postayla gönderir
+type WeatherLocationService struct {
+	db            *sql.DB
+	farms         *FarmService
+	notifications *NotificationService
+	templates     *MessageTemplateRegistry
+	mailer        Mailer
+}
+
+// NewWeatherLocationService yeni kayıtlı konum servisi oluşturur
+func NewWeatherLocationService(db *sql.DB) *WeatherLocationService {
+	return &WeatherLocationService{
+		db:            db,
+		farms:         NewFarmService(db),
+		notifications: NewNotificationService(db),
+		templates:     NewMessageTemplateRegistry(db),
+		mailer:        NewMailer(),
+	}
+}
+
+// weatherLocationSelect konum sütunları
+const weatherLocationSelect = `
+	SELECT id, user_id, name, latitude, longitude, COALESCE(notes, ''), daily_summary, alerts, delivery_hour, timezone,
+	       COALESCE(channels, ''), last_delivered_on, created_at, updated_at
+	FROM weather_locations`
+
+// scanWeatherLocation konum satırını ve çiftliğini okur
+func scanWeatherLocation(scanner interface{ Scan(...interface{}) error }) (models.WeatherLocation, string, error) {
+	var location models.WeatherLocation
+	var farmID, channels string
+	var lastDelivered sql.NullString
+	err := scanner.Scan(&location.ID, &farmID, &location.Name, &location.Latitude, &location.Longitude, &location.Notes,
+		&location.Subscription.DailySummary, &location.Subscription.Alerts, &location.Subscription.DeliveryHour,
+		&location.Subscription.Timezone, &channels, &lastDelivered, &location.CreatedAt, &location.UpdatedAt)
+	if err != nil {
+		return location, "", err
+	}
+	location.Subscription.Channels = parseWeatherChannels(channels)
+	if lastDelivered.Valid {
+		day := lastDelivered.String
+		if len(day) > 10 {
+			day = day[:10]
+		}
+		location.LastDeliveredOn = &day
+	}
+	return location, farmID, nil
+}
+
+// parseWeatherChannels kayıtlı kanal listesini çözer; boş veya okunamayan değer push kanalı sayılır
+func parseWeatherChannels(value string) []string {
+	var channels []string
+	if err := json.Unmarshal([]byte(value), &channels); err != nil || channels == nil {
+		return []string{models.WeatherChannelPush}
+	}
+	return channels
+}
+
+// List çiftliğin kayıtlı konumlarını ada göre listeler
+func (s *WeatherLocationService) List(farmID string) ([]models.WeatherLocation, error) {
+	rows, err := s.db.Query(weatherLocationSelect+" WHERE user_id = ? ORDER BY name", farmID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	locations := []models.WeatherLocation{}
+	for rows.Next() {
+		location, _, err := scanWeatherLocation(rows)
+		if err != nil {
+			return nil, err
+		}
+		locations = append(locations, location)
+	}
+	return locations, rows.Err()
+}
+
+// Get çiftliğin kayıtlı konumunu döner
+func (s *WeatherLocationService) Get(farmID, id string) (*models.WeatherLocation, error) {
+	location, _, err := scanWeatherLocation(s.db.QueryRow(weatherLocationSelect+" WHERE id = ? AND user_id = ?", id, farmID))
+	if err == sql.ErrNoRows {
+		return nil, ErrWeatherLocationNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &location, nil
+}
+
+// Create konum ekler; verilmeyen abonelik alanlarına varsayılanlar uygulanır
+func (s *WeatherLocationService) Create(farmID string, req models.WeatherLocationRequest) (*models.WeatherLocation, error) {
+	subscription, err := weatherSubscription(models.WeatherSubscription{
+		DailySummary: true,
+		Alerts:       true,
+		DeliveryHour: defaultWeatherDeliveryHour,
+		Timezone:     defaultWeatherTimezone,
+		Channels:     []string{models.WeatherChannelPush},
+	}, req)
+	if err != nil {
+		return nil, err
+	}
+	channels, _ := json.Marshal(subscription.Channels)
+
+	id := utils.GenerateID()
+	_, err = s.db.Exec(`
+		INSERT INTO weather_locations (id, user_id, name, latitude, longitude, notes, daily_summary, alerts, delivery_hour,
+		                               timezone, channels, created_at, updated_at)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, CURRENT_TIMESTAMP, CURRENT_TIMESTAMP)
+	`, id, farmID, strings.TrimSpace(req.Name), *req.Latitude, *req.Longitude, strings.TrimSpace(req.Notes),
+		subscription.DailySummary, subscription.Alerts, subscription.DeliveryHour, subscription.Timezone, string(channels))
+	if err != nil {
+		return nil, err
+	}
+	return s.Get(farmID, id)
+}
+
+// Update konumu günceller; verilmeyen abonelik alanları korunur. Teslim saati veya saat dilimi değişirse
+// bugünün bildirimi yeni saatte yeniden gönderilebilir
+func (s *WeatherLocationService) Update(farmID, id string, req models.WeatherLocationRequest) (*models.WeatherLocation, error) {
+	current, err := s.Get(farmID, id)
+	if err != nil {
+		return nil, err
+	}
+	subscription, err := weatherSubscription(current.Subscription, req)
+	if err != nil {
+		return nil, err
+	}
+	channels, _ := json.Marshal(subscription.Channels)
+
+	lastDelivered := current.LastDeliveredOn
+	if subscription.DeliveryHour != current.Subscription.DeliveryHour || subscription.Timezone != current.Subscription.Timezone {
+		lastDelivered = nil
+	}
+
+	_, err = s.db.Exec(`
+		UPDATE weather_locations
+		SET name = ?, latitude = ?, longitude = ?, notes = ?, daily_summary = ?, alerts = ?, delivery_hour = ?,
+		    timezone = ?, channels = ?, last_delivered_on = ?, updated_at = CURRENT_TIMESTAMP
+		WHERE id = ? AND user_id = ?
+	`, strings.TrimSpace(req.Name), *req.Latitude, *req.Longitude, strings.TrimSpace(req.Notes),
+		subscription.DailySummary, subscription.Alerts, subscription.DeliveryHour, subscription.Timezone, string(channels),
+		lastDelivered, id, farmID)
+	if err != nil {
+		return nil, err
+	}
+	return s.Get(farmID, id)
+}
+
+// Delete konumu siler
+func (s *WeatherLocationService) Delete(farmID, id string) error {
+	result, err := s.db.Exec("DELETE FROM weather_locations WHERE id = ? AND user_id = ?", id, farmID)
+	if err != nil {
+		return err
+	}
+	if affected, _ := result.RowsAffected(); affected == 0 {
+		return ErrWeatherLocationNotFound
+	}
+	return nil
+}
+
+// weatherSubscription istekteki abonelik alanlarını mevcut aboneliğe uygular; saat dilimini doğrular ve
+// tekrarlanan kanalları çıkarır
+func weatherSubscription(subscription models.WeatherSubscription, req models.WeatherLocationRequest) (models.WeatherSubscription, error) {
+	if req.DailySummary != nil {
+		subscription.DailySummary = *req.DailySummary
+	}
+	if req.Alerts != nil {
+		subscription.Alerts = *req.Alerts
+	}
+	if req.DeliveryHour != nil {
+		subscription.DeliveryHour = *req.DeliveryHour
+	}
+	if timezone := strings.TrimSpace(req.Timezone); timezone != "" {
+		if _, err := time.LoadLocation(timezone); err != nil || timezone == "Local" {
+			return subscription, ErrWeatherLocationTimezone
+		}
+		subscription.Timezone = timezone
+	}
+	if req.Channels != nil {
+		channels := []string{}
+		seen := map[string]bool{}
+		for _, channel := range req.Channels {
+			if !seen[channel] {
+				seen[channel] = true
+				channels = append(channels, channel)
+			}
+		}
+		subscription.Channels = channels
+	}
+	return subscription, nil
+}
+
+// Forecast kayıtlı konumun günlük tahminini ve önümüzdeki günlerin uyarılarını döner. Tahmin sağlayıcıdan
+// alınır; sağlayıcı yapılandırılmamışsa veya yanıt vermezse mock true iken örnek tahmin kullanılır
+func (s *WeatherLocationService) Forecast(farmID, id string, days int, mock bool) (*models.WeatherLocationForecast, error) {
+	location, err := s.Get(farmID, id)
+	if err != nil {
+		return nil, err
+	}
+
+	source := models.WeatherSourceProvider
+	forecast, err := FetchWeatherForecast(location.Latitude, location.Longitude, days)
+	if err != nil {
+		if !mock {
+			return nil, fmt.Errorf("%w: %v", ErrWeatherLocationForecast, err)
+		}
+		source = WeatherLocationForecastMock
+		forecast = MockWeatherForecast(days)
+	}
+
+	return &models.WeatherLocationForecast{
+		Location:       *location,
+		ForecastSource: source,
+		Forecast:       forecast,
+		Alerts:         forecastAlerts(forecast, len(forecast)),
+	}, nil
+}
+
+// forecastAlerts tahminin ilk days gününde don (en düşük sıcaklık eşikte veya altında) ve yoğun yağış
+// (günlük yağış eşikte veya üzerinde) uyarılarını üretir
+func forecastAlerts(forecast []models.WeatherForecast, days int) []models.WeatherDailyAlert {
+	alerts := []models.WeatherDailyAlert{}
+	for i, day := range forecast {
+		if i >= days {
+			break
+		}
+		if day.MinTemp <= frostAlertTemperature {
+			alerts = append(alerts, models.WeatherDailyAlert{Date: day.Date, Type: "frost", Value: day.MinTemp})
+		}
+		if day.Rainfall >= heavyRainForecastRainfall {
+			alerts = append(alerts, models.WeatherDailyAlert{Date: day.Date, Type: "heavy_rain", Value: day.Rainfall})
+		}
+	}
+	return alerts
+}
+
+// StartDelivery teslim saati gelen konumların bildirimlerini düzenli aralıklarla gönderir; hava tahmin
+// sağlayıcısı yapılandırılmamışsa başlamaz
+func (s *WeatherLocationService) StartDelivery() {
+	if !WeatherProviderConfigured() {
+		log.Println("Hava durumu sağlayıcısı yapılandırılmamış, kayıtlı konum bildirimleri gönderilmeyecek")
+		return
+	}
+
+	go func() {
+		ticker := time.NewTicker(weatherDeliveryInterval)
+		defer ticker.Stop()
+
+		for {
+			if err := s.DeliverDue(time.Now()); err != nil {
+				log.Printf("Kayıtlı konum bildirimleri gönderilemedi: %v", err)
+			}
+			<-ticker.C
+		}
+	}()
+}
+
+// DeliverDue yerel saati teslim saatine ulaşmış ve bugün bildirimi gönderilmemiş abone konumlar için günlük
+// özeti ve uyarıları gönderir. Tahmini alınamayan konumlar sonraki kontrolde yeniden denenir
+func (s *WeatherLocationService) DeliverDue(now time.Time) error {
+	rows, err := s.db.Query(weatherLocationSelect + " WHERE daily_summary = TRUE OR alerts = TRUE")
+	if err != nil {
+		return err
+	}
+
+	type dueLocation struct {
+		location models.WeatherLocation
+		farmID   string
+		day      string
+	}
+	var due []dueLocation
+	for rows.Next() {
+		location, farmID, err := scanWeatherLocation(rows)
+		if err != nil {
+			continue
+		}
+		zone, err := time.LoadLocation(location.Subscription.Timezone)
+		if err != nil {
+			zone, _ = time.LoadLocation(defaultWeatherTimezone)
+		}
+		local := now.In(zone)
+		day := local.Format("2006-01-02")
+		if local.Hour() < location.Subscription.DeliveryHour ||
+			(location.LastDeliveredOn != nil && *location.LastDeliveredOn == day) {
+			continue
+		}
+		due = append(due, dueLocation{location: location, farmID: farmID, day: day})
+	}
+	rows.Close()
+
+	for _, item := range due {
+		forecast, err := FetchWeatherForecast(item.location.Latitude, item.location.Longitude, weatherLocationAlertDays)
+		if err != nil {
+			log.Printf("Konum %s için hava tahmini alınamadı: %v", item.location.ID, err)
+			continue
+		}
+		if err := s.deliver(item.farmID, item.location, item.day, forecast); err != nil {
+			log.Printf("Konum %s için bildirim gönderilemedi: %v", item.location.ID, err)
+			continue
+		}
+		if _, err := s.db.Exec("UPDATE weather_locations SET last_delivered_on = ? WHERE id = ? AND user_id = ?",
+			item.day, item.location.ID, item.farmID); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// deliver konumun günlük özetini ve uyarılarını aboneliğin kanallarından gönderir
+func (s *WeatherLocationService) deliver(farmID string, location models.WeatherLocation, day string, forecast []models.WeatherForecast) error {
+	notifications := weatherLocationNotifications(farmID, location, day, forecast)
+	if len(notifications) == 0 {
+		return nil
+	}
+
+	for _, channel := range location.Subscription.Channels {
+		switch channel {
+		case models.WeatherChannelPush:
+			if _, err := s.notifications.CreateBatch(notifications); err != nil {
+				return err
+			}
+		case models.WeatherChannelEmail:
+			if err := s.email(farmID, notifications); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// weatherLocationNotifications konumun uyarılarını ve istenmişse günlük özetini üretir; uyarılar özetten önce gelir
+func weatherLocationNotifications(farmID string, location models.WeatherLocation, day string, forecast []models.WeatherForecast) []Notification {
+	entity := &models.RelatedEntity{Type: "weather_location", ID: location.ID, Name: location.Name}
+
+	var notifications []Notification
+	if location.Subscription.Alerts {
+		for _, alert := range forecastAlerts(forecast, weatherLocationAlertDays) {
+			notifications = append(notifications, Notification{
+				UserID:    farmID,
+				Template:  "weather_forecast_" + alert.Type,
+				Type:      "alert",
+				Priority:  "high",
+				Topic:     models.NotificationTopicWeatherLocation,
+				Entity:    entity,
+				Params:    map[string]interface{}{"date": alert.Date, "value": alert.Value},
+				DedupeKey: "weather_location:" + alert.Type + ":" + location.ID + ":" + alert.Date,
+			})
+		}
+	}
+	if location.Subscription.DailySummary && len(forecast) > 0 {
+		today := forecast[0]
+		notifications = append(notifications, Notification{
+			UserID:   farmID,
+			Template: "weather_daily_summary",
+			Type:     "info",
+			Priority: "low",
+			Topic:    models.NotificationTopicWeatherLocation,
+			Entity:   entity,
+			Params: map[string]interface{}{
+				"date":       day,
+				"condition":  today.Condition,
+				"minTemp":    today.MinTemp,
+				"maxTemp":    today.MaxTemp,
+				"rainChance": today.RainChance,
+				"rainfall":   today.Rainfall,
+				"windSpeed":  today.WindSpeed,
+			},
+			DedupeKey: "weather_location:summary:" + location.ID + ":" + day,
+		})
+	}
+	return notifications
+}
+
+// email bildirimleri çiftlik sahibine tek e-postada gönderir; SMTP yapılandırılmamışsa, çiftliğin
+// e-posta bildirimleri kapalıysa veya çiftlik sandbox hesabına aitse gönderilmez
+func (s *WeatherLocationService) email(farmID string, notifications []Notification) error {
+	if s.mailer == nil {
+		return nil
+	}
+	settings, err := s.farms.Settings(farmID)
+	if err != nil {
+		return err
+	}
+	if !settings.Notifications.Email {
+		return nil
+	}
+
+	var accountID, farm, name, address string
+	err = s.db.QueryRow(`
+		SELECT u.id, COALESCE((SELECT name FROM farms WHERE id = ?), u.farm_name, ''), u.name, u.email
+		FROM users u WHERE u.id = COALESCE((SELECT user_id FROM farms WHERE id = ?), ?)
+	`, farmID, farmID, farmID).Scan(&accountID, &farm, &name, &address)
+	if err != nil {
+		return err
+	}
+	// Sandbox hesaplarının adresleri paylaşılan veya uydurma adreslerdir; gerçek e-posta gönderilmez
+	if demo, err := IsDemoAccount(s.db, accountID); err != nil || demo {
+		return err
+	}
+
+	language := s.templates.Language(farmID)
+	var title string
+	var messages []string
+	for _, notification := range notifications {
+		data := map[string]interface{}{"entity": notification.Entity.Name}
+		for key, value := range notification.Params {
+			data[key] = value
+		}
+		rendered, err := s.templates.Render(models.MessageChannelNotification, notification.Template, language, data)
+		if err != nil {
+			return err
+		}
+		if title == "" {
+			title = rendered.Title
+		}
+		messages = append(messages, rendered.Body)
+	}
+
+	message, err := s.templates.Render(models.MessageChannelEmail, "notification", language, map[string]interface{}{
+		"farm":    farm,
+		"name":    name,
+		"title":   title,
+		"message": strings.Join(messages, "\n\n"),
+	})
+	if err != nil {
+		return err
+	}
+	return s.mailer.Send(address, message.Title, message.Body)
+}
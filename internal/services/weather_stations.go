@@ -0,0 +1,451 @@
+package services
+
+import (
+	"database/sql"
+	"errors"
+	"math"
+	"sort"
+	"strings"
+	"time"
+
+	"agri-management-api/internal/models"
+	"agri-management-api/internal/utils"
+)
+
+// maxWeatherStationReadings listelenebilecek en fazla istasyon ölçümü
+const maxWeatherStationReadings = 5000
+
+// Hava istasyonu hataları
+var (
+	ErrWeatherStationNotFound = errors.New("weather station not found")
+	ErrInvalidStationToken    = errors.New("invalid weather station token")
+	ErrWeatherLandNotFound    = errors.New("land not found")
+)
+
+// WeatherStationService çiftliğin hava istasyonlarını, istasyon ölçümlerini ve arazilerin hava verisi
+// kaynaklarını yönetir. İstasyon ölçümleri eşleştirilen arazilerin günlük gözlemlerine station kaynağıyla işlenir
+type WeatherStationService struct {
+	db            *sql.DB
+	notifications *NotificationService
+}
+
+// NewWeatherStationService yeni hava istasyonu servisi oluşturur
+func NewWeatherStationService(db *sql.DB) *WeatherStationService {
+	return &WeatherStationService{db: db, notifications: NewNotificationService(db)}
+}
+
+// weatherStationSelect istasyon sütunları ve eşleştirilmiş arazi sayısı
+const weatherStationSelect = `
+	SELECT s.id, s.name, s.station_type, s.latitude, s.longitude, s.last_reading_at, s.created_at, s.updated_at,
+	       (SELECT COUNT(*) FROM lands l WHERE l.user_id = s.user_id AND l.weather_station_id = s.id)
+	FROM weather_stations s`
+
+// scanWeatherStation istasyon satırını okur
+func scanWeatherStation(scanner interface{ Scan(...interface{}) error }) (models.WeatherStation, error) {
+	var station models.WeatherStation
+	var latitude, longitude sql.NullFloat64
+	var lastReading sql.NullTime
+	err := scanner.Scan(&station.ID, &station.Name, &station.Type, &latitude, &longitude, &lastReading,
+		&station.CreatedAt, &station.UpdatedAt, &station.PairedLands)
+	if err != nil {
+		return station, err
+	}
+	station.Latitude = utils.NullFloat64ToPtr(latitude)
+	station.Longitude = utils.NullFloat64ToPtr(longitude)
+	station.LastReadingAt = utils.NullTimeToPtr(lastReading)
+	return station, nil
+}
+
+// List çiftliğin istasyonlarını ada göre listeler
+func (s *WeatherStationService) List(farmID string) ([]models.WeatherStation, error) {
+	rows, err := s.db.Query(weatherStationSelect+" WHERE s.user_id = ? ORDER BY s.name", farmID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	stations := []models.WeatherStation{}
+	for rows.Next() {
+		station, err := scanWeatherStation(rows)
+		if err != nil {
+			return nil, err
+		}
+		stations = append(stations, station)
+	}
+	return stations, rows.Err()
+}
+
+// Get çiftliğin istasyonunu döner
+func (s *WeatherStationService) Get(farmID, id string) (*models.WeatherStation, error) {
+	station, err := scanWeatherStation(s.db.QueryRow(weatherStationSelect+" WHERE s.id = ? AND s.user_id = ?", id, farmID))
+	if err == sql.ErrNoRows {
+		return nil, ErrWeatherStationNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &station, nil
+}
+
+// Create istasyon ekler; dönen anahtar istasyonun ölçüm gönderirken kullandığı X-Sensor-Token değeridir ve
+// yalnızca bu yanıtta gösterilir
+func (s *WeatherStationService) Create(farmID string, req models.WeatherStationRequest) (*models.WeatherStation, error) {
+	id := utils.GenerateID()
+	token := "wst-" + strings.ReplaceAll(utils.GenerateID(), "-", "")
+	_, err := s.db.Exec(`
+		INSERT INTO weather_stations (id, user_id, name, station_type, latitude, longitude, token, created_at, updated_at)
+		VALUES (?, ?, ?, ?, ?, ?, ?, CURRENT_TIMESTAMP, CURRENT_TIMESTAMP)
+	`, id, farmID, strings.TrimSpace(req.Name), weatherStationType(req.Type), req.Latitude, req.Longitude, token)
+	if err != nil {
+		return nil, err
+	}
+
+	station, err := s.Get(farmID, id)
+	if err != nil {
+		return nil, err
+	}
+	station.Token = token
+	return station, nil
+}
+
+// Update istasyonun adını, türünü ve konumunu günceller
+func (s *WeatherStationService) Update(farmID, id string, req models.WeatherStationRequest) (*models.WeatherStation, error) {
+	result, err := s.db.Exec(`
+		UPDATE weather_stations SET name = ?, station_type = ?, latitude = ?, longitude = ?, updated_at = CURRENT_TIMESTAMP
+		WHERE id = ? AND user_id = ?
+	`, strings.TrimSpace(req.Name), weatherStationType(req.Type), req.Latitude, req.Longitude, id, farmID)
+	if err != nil {
+		return nil, err
+	}
+	if affected, _ := result.RowsAffected(); affected == 0 {
+		return nil, ErrWeatherStationNotFound
+	}
+	return s.Get(farmID, id)
+}
+
+// Delete istasyonu ve ölçümlerini siler; eşleştirilmiş arazilerin istasyon gözlemleri kaldırılır ve araziler
+// sıradaki kaynağa (ör. sağlayıcı) döner
+func (s *WeatherStationService) Delete(farmID, id string) error {
+	tx, err := s.db.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	result, err := tx.Exec("DELETE FROM weather_stations WHERE id = ? AND user_id = ?", id, farmID)
+	if err != nil {
+		return err
+	}
+	if affected, _ := result.RowsAffected(); affected == 0 {
+		return ErrWeatherStationNotFound
+	}
+
+	statements := []string{
+		`DELETE FROM weather_observations WHERE user_id = ? AND source = 'station'
+		 AND land_id IN (SELECT id FROM lands WHERE user_id = ? AND weather_station_id = ?)`,
+		"UPDATE lands SET weather_station_id = NULL WHERE user_id = ? AND weather_station_id = ?",
+		"DELETE FROM weather_station_readings WHERE user_id = ? AND station_id = ?",
+	}
+	args := [][]interface{}{{farmID, farmID, id}, {farmID, id}, {farmID, id}}
+	for i, statement := range statements {
+		if _, err := tx.Exec(statement, args[i]...); err != nil {
+			return err
+		}
+	}
+
+	return tx.Commit()
+}
+
+// Authenticate istasyon anahtarıyla istasyonu ve çiftliğini bulur
+func (s *WeatherStationService) Authenticate(token string) (stationID, farmID string, err error) {
+	if token == "" {
+		return "", "", ErrInvalidStationToken
+	}
+	err = s.db.QueryRow("SELECT id, user_id FROM weather_stations WHERE token = ?", token).Scan(&stationID, &farmID)
+	if err == sql.ErrNoRows {
+		return "", "", ErrInvalidStationToken
+	}
+	return stationID, farmID, err
+}
+
+// RecordReading istasyon ölçümünü kaydeder, eşleştirilmiş arazilerin o günkü istasyon gözlemini ölçümlerden yeniden
+// hesaplar ve istasyonu sağlayıcıdan önce tercih eden arazilere don ve yoğun yağış uyarısı gönderir
+func (s *WeatherStationService) RecordReading(farmID, stationID string, req models.WeatherStationReadingRequest) (*models.WeatherStationReading, error) {
+	reading := models.WeatherStationReading{
+		ID:          utils.GenerateID(),
+		StationID:   stationID,
+		RecordedAt:  time.Now().UTC(),
+		Temperature: req.Temperature,
+		Humidity:    req.Humidity,
+		Rainfall:    req.Rainfall,
+		CreatedAt:   time.Now().UTC(),
+	}
+	if req.RecordedAt != nil {
+		reading.RecordedAt = req.RecordedAt.UTC()
+	}
+
+	_, err := s.db.Exec(`
+		INSERT INTO weather_station_readings (id, user_id, station_id, recorded_at, temperature, humidity, rainfall, created_at)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?)
+	`, reading.ID, farmID, stationID, reading.RecordedAt, reading.Temperature, reading.Humidity, reading.Rainfall, reading.CreatedAt)
+	if err != nil {
+		return nil, err
+	}
+	_, err = s.db.Exec(`
+		UPDATE weather_stations SET last_reading_at = MAX(COALESCE(last_reading_at, ?), ?)
+		WHERE id = ? AND user_id = ?
+	`, reading.RecordedAt, reading.RecordedAt, stationID, farmID)
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := s.db.Query(`
+		SELECT id, name, COALESCE(weather_sources, '') FROM lands WHERE user_id = ? AND weather_station_id = ?
+	`, farmID, stationID)
+	if err != nil {
+		return nil, err
+	}
+	type pairedLand struct{ id, name, sources string }
+	var lands []pairedLand
+	for rows.Next() {
+		var land pairedLand
+		if err := rows.Scan(&land.id, &land.name, &land.sources); err != nil {
+			rows.Close()
+			return nil, err
+		}
+		lands = append(lands, land)
+	}
+	rows.Close()
+
+	day := reading.RecordedAt.Format("2006-01-02")
+	var alerts []Notification
+	for _, land := range lands {
+		if err := s.rebuildObservations(farmID, land.id, stationID, day); err != nil {
+			return nil, err
+		}
+		fresh := time.Since(reading.RecordedAt) < stationFreshness
+		if fresh && reading.Temperature != nil &&
+			sourcePreferred(parseWeatherSources(land.sources), models.WeatherSourceStation, models.WeatherSourceProvider) {
+			weather := &models.Weather{Temperature: *reading.Temperature}
+			if reading.Rainfall != nil {
+				weather.Rainfall = *reading.Rainfall
+			}
+			alerts = append(alerts, weatherAlerts(land.id, farmID, land.name, weather)...)
+		}
+	}
+	if _, err := s.notifications.CreateBatch(alerts); err != nil {
+		return nil, err
+	}
+
+	return &reading, nil
+}
+
+// rebuildObservations arazinin istasyon gözlemlerini istasyon ölçümlerinden yeniden hesaplar; day boşsa tüm günler
+// yeniden yazılır. Günlük yağış ölçümlerin toplamı, sıcaklık ve nem ortalamasıdır
+func (s *WeatherStationService) rebuildObservations(farmID, landID, stationID, day string) error {
+	dayFilter := ""
+	args := []interface{}{farmID, landID}
+	if day != "" {
+		dayFilter = " AND date(observed_on) = ?"
+		args = append(args, day)
+	}
+	if _, err := s.db.Exec("DELETE FROM weather_observations WHERE user_id = ? AND land_id = ? AND source = 'station'"+dayFilter, args...); err != nil {
+		return err
+	}
+
+	args = []interface{}{farmID, stationID}
+	readingFilter := ""
+	if day != "" {
+		readingFilter = " AND date(recorded_at) = ?"
+		args = append(args, day)
+	}
+	rows, err := s.db.Query(`
+		SELECT date(recorded_at), MIN(temperature), MAX(temperature), AVG(temperature), SUM(rainfall), AVG(humidity), COUNT(*)
+		FROM weather_station_readings
+		WHERE user_id = ? AND station_id = ?`+readingFilter+`
+		GROUP BY date(recorded_at)
+	`, args...)
+	if err != nil {
+		return err
+	}
+	type dailyReading struct {
+		date                                          string
+		minTemp, maxTemp, avgTemp, rainfall, humidity sql.NullFloat64
+		samples                                       int
+	}
+	var days []dailyReading
+	for rows.Next() {
+		var d dailyReading
+		if err := rows.Scan(&d.date, &d.minTemp, &d.maxTemp, &d.avgTemp, &d.rainfall, &d.humidity, &d.samples); err != nil {
+			rows.Close()
+			return err
+		}
+		days = append(days, d)
+	}
+	rows.Close()
+
+	for _, d := range days {
+		_, err := s.db.Exec(`
+			INSERT INTO weather_observations
+				(id, land_id, user_id, observed_on, source, min_temp, max_temp, avg_temp, rainfall, humidity, samples, created_at, updated_at)
+			VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, CURRENT_TIMESTAMP, CURRENT_TIMESTAMP)
+		`, utils.GenerateID(), landID, farmID, d.date, models.WeatherSourceStation,
+			d.minTemp, d.maxTemp, d.avgTemp, d.rainfall, d.humidity, d.samples)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Readings istasyonun tarih aralığındaki ölçümlerini en yeniden eskiye listeler
+func (s *WeatherStationService) Readings(farmID, stationID string, start, end time.Time, limit int) ([]models.WeatherStationReading, error) {
+	if _, err := s.Get(farmID, stationID); err != nil {
+		return nil, err
+	}
+	if limit <= 0 || limit > maxWeatherStationReadings {
+		limit = maxWeatherStationReadings
+	}
+
+	rows, err := s.db.Query(`
+		SELECT id, station_id, recorded_at, temperature, humidity, rainfall, created_at
+		FROM weather_station_readings
+		WHERE user_id = ? AND station_id = ? AND date(recorded_at) BETWEEN ? AND ?
+		ORDER BY recorded_at DESC
+		LIMIT ?
+	`, farmID, stationID, start.Format("2006-01-02"), end.Format("2006-01-02"), limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	readings := []models.WeatherStationReading{}
+	for rows.Next() {
+		var reading models.WeatherStationReading
+		var temperature, humidity, rainfall sql.NullFloat64
+		if err := rows.Scan(&reading.ID, &reading.StationID, &reading.RecordedAt, &temperature, &humidity, &rainfall, &reading.CreatedAt); err != nil {
+			return nil, err
+		}
+		reading.Temperature = utils.NullFloat64ToPtr(temperature)
+		reading.Humidity = utils.NullFloat64ToPtr(humidity)
+		reading.Rainfall = utils.NullFloat64ToPtr(rainfall)
+		readings = append(readings, reading)
+	}
+	return readings, rows.Err()
+}
+
+// LandSources arazinin eşleştirilmiş istasyonunu, kaynak sırasını, şu anda veri sağlayan kaynağı ve çiftliğin
+// istasyonlarını araziye uzaklığa göre döner
+func (s *WeatherStationService) LandSources(farmID, landID string) (*models.LandWeatherSources, error) {
+	var stationID, sources sql.NullString
+	var latitude, longitude sql.NullFloat64
+	err := s.db.QueryRow(`
+		SELECT weather_station_id, weather_sources, latitude, longitude FROM lands WHERE id = ? AND user_id = ?
+	`, landID, farmID).Scan(&stationID, &sources, &latitude, &longitude)
+	if err == sql.ErrNoRows {
+		return nil, ErrWeatherLandNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	stations, err := s.List(farmID)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &models.LandWeatherSources{
+		LandID:         landID,
+		Sources:        parseWeatherSources(sources.String),
+		NearbyStations: []models.WeatherStation{},
+	}
+	hasLocation := latitude.Valid && longitude.Valid && (latitude.Float64 != 0 || longitude.Float64 != 0)
+	for _, station := range stations {
+		if hasLocation && station.Latitude != nil && station.Longitude != nil {
+			distance := round2(distanceKm(latitude.Float64, longitude.Float64, *station.Latitude, *station.Longitude))
+			station.DistanceKm = &distance
+		}
+		if station.ID == stationID.String {
+			paired := station
+			result.Station = &paired
+		}
+		result.NearbyStations = append(result.NearbyStations, station)
+	}
+	sort.SliceStable(result.NearbyStations, func(i, j int) bool {
+		a, b := result.NearbyStations[i].DistanceKm, result.NearbyStations[j].DistanceKm
+		if a == nil || b == nil {
+			return a != nil
+		}
+		return *a < *b
+	})
+
+	for _, source := range result.Sources {
+		if source == models.WeatherSourceStation && result.Station != nil && result.Station.LastReadingAt != nil &&
+			time.Since(*result.Station.LastReadingAt) < stationFreshness {
+			result.ActiveSource = source
+			break
+		}
+		if source == models.WeatherSourceProvider && WeatherProviderConfigured() {
+			result.ActiveSource = source
+			break
+		}
+	}
+	return result, nil
+}
+
+// SetLandSources araziyi istasyonla eşleştirir veya eşleştirmeyi kaldırır ve kaynak sırasını kaydeder; istasyon
+// değişirse arazinin istasyon gözlemleri yeni istasyonun ölçümlerinden yeniden oluşturulur
+func (s *WeatherStationService) SetLandSources(farmID, landID string, req models.LandWeatherSourcesRequest) (*models.LandWeatherSources, error) {
+	var current sql.NullString
+	err := s.db.QueryRow("SELECT weather_station_id FROM lands WHERE id = ? AND user_id = ?", landID, farmID).Scan(&current)
+	if err == sql.ErrNoRows {
+		return nil, ErrWeatherLandNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if req.Sources != nil {
+		if _, err := s.db.Exec("UPDATE lands SET weather_sources = ?, updated_at = CURRENT_TIMESTAMP WHERE id = ? AND user_id = ?",
+			strings.Join(req.Sources, ","), landID, farmID); err != nil {
+			return nil, err
+		}
+	}
+
+	if req.StationID != nil && *req.StationID != current.String {
+		stationID := strings.TrimSpace(*req.StationID)
+		if stationID != "" {
+			if _, err := s.Get(farmID, stationID); err != nil {
+				return nil, err
+			}
+		}
+		if _, err := s.db.Exec("UPDATE lands SET weather_station_id = ?, updated_at = CURRENT_TIMESTAMP WHERE id = ? AND user_id = ?",
+			utils.StringToNullString(stationID), landID, farmID); err != nil {
+			return nil, err
+		}
+		if err := s.rebuildObservations(farmID, landID, stationID, ""); err != nil {
+			return nil, err
+		}
+	}
+
+	return s.LandSources(farmID, landID)
+}
+
+// weatherStationType boş türü own olarak kabul eder
+func weatherStationType(value string) string {
+	if value == "" {
+		return models.WeatherStationOwn
+	}
+	return value
+}
+
+// distanceKm iki koordinat arasındaki büyük çember uzaklığı (km)
+func distanceKm(lat1, lon1, lat2, lon2 float64) float64 {
+	const earthRadiusKm = 6371.0
+	toRad := func(deg float64) float64 { return deg * math.Pi / 180 }
+	dLat := toRad(lat2 - lat1)
+	dLon := toRad(lon2 - lon1)
+	a := math.Sin(dLat/2)*math.Sin(dLat/2) +
+		math.Cos(toRad(lat1))*math.Cos(toRad(lat2))*math.Sin(dLon/2)*math.Sin(dLon/2)
+	return 2 * earthRadiusKm * math.Asin(math.Sqrt(a))
+}
@@ -0,0 +1,231 @@
+package services
+
+import (
+	"database/sql"
+	"errors"
+	"math"
+	"time"
+
+	"agri-management-api/internal/models"
+	"agri-management-api/internal/utils"
+)
+
+// ErrWeightRecordNotFound tartım kaydı bulunamadı
+var ErrWeightRecordNotFound = errors.New("tartım kaydı bulunamadı")
+
+// WeightService hayvanların tartım geçmişini ve büyüme eğrisini yönetir. Hayvanın ağırlık alanı her zaman en son
+// tartımı gösterir; ağırlığı değişen hayvan için tartım kaydı eklenir
+type WeightService struct {
+	db      *sql.DB
+	history *ChangeHistoryService
+}
+
+// NewWeightService yeni tartım servisi oluşturur
+func NewWeightService(db *sql.DB) *WeightService {
+	return &WeightService{db: db, history: NewChangeHistoryService(db)}
+}
+
+// List hayvanın tartımlarını tarih sırasıyla, önceki tartımdan bu yana günlük artışla döner
+func (s *WeightService) List(farmID, animalID string) ([]models.WeightRecord, error) {
+	if _, err := s.animal(farmID, animalID); err != nil {
+		return nil, err
+	}
+	return s.records(farmID, animalID, nil, nil)
+}
+
+// Create hayvana tartım kaydı ekler; en son tartım ise hayvanın ağırlığı güncellenir
+func (s *WeightService) Create(farmID, animalID string, req models.WeightRecordRequest) (*models.WeightRecord, error) {
+	if _, err := s.animal(farmID, animalID); err != nil {
+		return nil, err
+	}
+
+	recordDate := req.RecordDate
+	if recordDate == "" {
+		recordDate = time.Now().UTC().Format("2006-01-02")
+	}
+
+	id := utils.GenerateID()
+	if _, err := s.db.Exec(`
+		INSERT INTO weight_records (id, livestock_id, weight, record_date, notes, created_at)
+		VALUES (?, ?, ?, ?, ?, CURRENT_TIMESTAMP)
+	`, id, animalID, req.Weight, recordDate, req.Notes); err != nil {
+		return nil, err
+	}
+	if err := s.syncAnimalWeight(farmID, animalID); err != nil {
+		return nil, err
+	}
+
+	records, err := s.records(farmID, animalID, nil, nil)
+	if err != nil {
+		return nil, err
+	}
+	for i := range records {
+		if records[i].ID == id {
+			return &records[i], nil
+		}
+	}
+	return nil, ErrWeightRecordNotFound
+}
+
+// Delete tartım kaydını siler; hayvanın ağırlığı kalan en son tartıma göre güncellenir
+func (s *WeightService) Delete(farmID, animalID, id string) error {
+	result, err := s.db.Exec(`
+		DELETE FROM weight_records WHERE id = ? AND livestock_id = ? AND `+farmLivestockCondition+`
+	`, id, animalID, farmID)
+	if err != nil {
+		return err
+	}
+	if rows, _ := result.RowsAffected(); rows == 0 {
+		return ErrWeightRecordNotFound
+	}
+	return s.syncAnimalWeight(farmID, animalID)
+}
+
+// RecordCurrent hayvanın ağırlık alanı en son tartımdan farklıysa bugünün tarihiyle tartım kaydı ekler; hayvan
+// ekleme ve güncellemede girilen ağırlığın geçmişte kaybolmaması için çağrılır
+func (s *WeightService) RecordCurrent(farmID, animalID string) error {
+	_, err := s.db.Exec(`
+		INSERT INTO weight_records (id, livestock_id, weight, record_date, notes, created_at)
+		SELECT ?, l.id, l.weight, date('now'), '', CURRENT_TIMESTAMP
+		FROM livestock l
+		WHERE l.id = ? AND l.user_id = ? AND l.weight > 0
+		  AND l.weight IS NOT (
+		      SELECT w.weight FROM weight_records w
+		      WHERE w.livestock_id = l.id
+		      ORDER BY w.record_date DESC, w.created_at DESC LIMIT 1
+		  )
+	`, utils.GenerateID(), animalID, farmID)
+	return err
+}
+
+// GrowthCurve hayvanın [startDate, endDate] aralığındaki tartımlarından büyüme eğrisini döner
+func (s *WeightService) GrowthCurve(farmID, animalID string, startDate, endDate *time.Time) (*models.GrowthCurve, error) {
+	animal, err := s.animal(farmID, animalID)
+	if err != nil {
+		return nil, err
+	}
+	records, err := s.records(farmID, animalID, startDate, endDate)
+	if err != nil {
+		return nil, err
+	}
+
+	curve := &models.GrowthCurve{
+		AnimalID:  animal.ID,
+		TagNumber: animal.TagNumber,
+		Type:      animal.Type,
+		BirthDate: animal.BirthDate,
+		Points:    make([]models.GrowthPoint, 0, len(records)),
+	}
+	for _, record := range records {
+		point := models.GrowthPoint{Date: record.RecordDate, Weight: record.Weight, DailyGain: record.DailyGain}
+		if animal.BirthDate != nil {
+			if day, err := time.Parse("2006-01-02", record.RecordDate); err == nil {
+				age := int(day.Sub(animal.BirthDate.UTC().Truncate(24*time.Hour)).Hours() / 24)
+				point.AgeDays = &age
+			}
+		}
+		curve.Points = append(curve.Points, point)
+	}
+	if len(records) == 0 {
+		return curve, nil
+	}
+
+	first, last := records[0], records[len(records)-1]
+	curve.StartWeight = &first.Weight
+	curve.CurrentWeight = &last.Weight
+	curve.TotalGain = round2(last.Weight - first.Weight)
+	curve.AverageDailyGain = dailyGain(first, last)
+	return curve, nil
+}
+
+// syncAnimalWeight hayvanın ağırlık alanını en son tartıma eşitler ve değişikliği geçmişe kaydeder
+func (s *WeightService) syncAnimalWeight(farmID, animalID string) error {
+	return s.history.Track(s.db, HistoryEntityLivestock, farmID, animalID, farmID, func() error {
+		_, err := s.db.Exec(`
+			UPDATE livestock
+			SET weight = (
+			        SELECT w.weight FROM weight_records w
+			        WHERE w.livestock_id = livestock.id
+			        ORDER BY w.record_date DESC, w.created_at DESC LIMIT 1
+			    ),
+			    updated_at = CURRENT_TIMESTAMP
+			WHERE id = ? AND user_id = ?
+			  AND EXISTS (SELECT 1 FROM weight_records w WHERE w.livestock_id = livestock.id)
+		`, animalID, farmID)
+		return err
+	})
+}
+
+// animal çiftliğin hayvanını döner
+func (s *WeightService) animal(farmID, animalID string) (*models.Livestock, error) {
+	var animal models.Livestock
+	var birthDate sql.NullTime
+	err := s.db.QueryRow(`
+		SELECT id, tag_number, type, birth_date FROM livestock WHERE id = ? AND user_id = ?
+	`, animalID, farmID).Scan(&animal.ID, &animal.TagNumber, &animal.Type, &birthDate)
+	if err == sql.ErrNoRows {
+		return nil, ErrLivestockNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	animal.BirthDate = utils.NullTimeToPtr(birthDate)
+	return &animal, nil
+}
+
+// records hayvanın tartımlarını tarih sırasıyla okur ve önceki tartıma göre günlük artışı hesaplar; aralık
+// verilirse aralık dışındaki tartımlar yalnızca ilk noktanın artışı için kullanılır
+func (s *WeightService) records(farmID, animalID string, startDate, endDate *time.Time) ([]models.WeightRecord, error) {
+	rows, err := s.db.Query(`
+		SELECT id, livestock_id, weight, date(record_date), COALESCE(notes, ''), created_at
+		FROM weight_records
+		WHERE livestock_id = ? AND `+farmLivestockCondition+`
+		ORDER BY record_date, created_at
+	`, animalID, farmID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	records := []models.WeightRecord{}
+	var previous *models.WeightRecord
+	for rows.Next() {
+		var record models.WeightRecord
+		if err := rows.Scan(&record.ID, &record.AnimalID, &record.Weight, &record.RecordDate, &record.Notes,
+			&record.CreatedAt); err != nil {
+			return nil, err
+		}
+		if previous != nil {
+			record.DailyGain = dailyGain(*previous, record)
+		}
+		current := record
+		previous = &current
+
+		if startDate != nil && record.RecordDate < startDate.Format("2006-01-02") {
+			continue
+		}
+		if endDate != nil && record.RecordDate > endDate.Format("2006-01-02") {
+			continue
+		}
+		records = append(records, record)
+	}
+	return records, rows.Err()
+}
+
+// dailyGain iki tartım arasındaki günlük ortalama ağırlık artışı (kg/gün); aynı gündeki tartımlar için nil
+func dailyGain(from, to models.WeightRecord) *float64 {
+	start, err := time.Parse("2006-01-02", from.RecordDate)
+	if err != nil {
+		return nil
+	}
+	end, err := time.Parse("2006-01-02", to.RecordDate)
+	if err != nil {
+		return nil
+	}
+	days := end.Sub(start).Hours() / 24
+	if days <= 0 {
+		return nil
+	}
+	gain := math.Round((to.Weight-from.Weight)/days*1000) / 1000
+	return &gain
+}
@@ -0,0 +1,463 @@
+package services
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"agri-management-api/internal/models"
+	"agri-management-api/internal/utils"
+)
+
+// certificationExpiringDays bitişine bu kadar gün ve daha az kalan sertifikalar yakında dolacak sayılır
+const certificationExpiringDays = 30
+
+var (
+	// ErrWorkerNotFound çalışan bulunamadı
+	ErrWorkerNotFound = errors.New("çalışan bulunamadı")
+	// ErrCertificationNotFound sertifika bulunamadı
+	ErrCertificationNotFound = errors.New("sertifika bulunamadı")
+	// ErrInvalidContractPeriod sözleşme bitişi başlangıçtan önce
+	ErrInvalidContractPeriod = errors.New("sözleşme bitişi başlangıçtan önce olamaz")
+	// ErrActivityNotFound arazi aktivitesi bulunamadı
+	ErrActivityNotFound = errors.New("aktivite bulunamadı")
+)
+
+// certificationRequirements aktivite türlerinin gerektirdiği belgeler; aktivite türü serbest metin olduğu için
+// Türkçe ve İngilizce karşılıkları birlikte tanımlıdır
+var certificationRequirements = map[string][]string{
+	models.CertificationPesticideApplicator: {"spraying", "pest_control", "pesticide", "herbicide", "fungicide", "ilaçlama", "zirai ilaçlama"},
+	models.CertificationDrivingLicense:      {"transport", "tillage", "plowing", "machinery", "nakliye", "sürüm", "toprak işleme"},
+}
+
+// certificationLabels sertifika türlerinin Türkçe adları
+var certificationLabels = map[string]string{
+	models.CertificationPesticideApplicator: "zirai ilaç uygulama belgesi",
+	models.CertificationDrivingLicense:      "sürücü belgesi",
+	models.CertificationOther:               "belge",
+}
+
+// workerSelect çalışan sütunları
+const workerSelect = `
+	SELECT id, name, COALESCE(role, ''), COALESCE(phone, ''), contract_type, date(contract_start),
+	       date(contract_end), COALESCE(notes, ''), created_at, updated_at
+	FROM workers`
+
+// WorkerService çiftlik çalışanlarını, sözleşme dönemlerini ve sertifikalarını yönetir; arazi aktivitesine
+// atanan çalışanın aktivite tarihinde sözleşmesinin ve aktivitenin gerektirdiği belgenin geçerli olup olmadığını
+// denetler
+type WorkerService struct {
+	db            *sql.DB
+	notifications *NotificationService
+}
+
+// NewWorkerService yeni çalışan servisi oluşturur
+func NewWorkerService(db *sql.DB) *WorkerService {
+	return &WorkerService{db: db, notifications: NewNotificationService(db)}
+}
+
+// List çiftliğin çalışanlarını sertifikalarıyla döner; contractType verilirse yalnızca o sözleşme türü,
+// activeOn verilirse o gün sözleşmesi süren çalışanlar listelenir
+func (s *WorkerService) List(farmID, contractType string, activeOn *time.Time) ([]models.Worker, error) {
+	query := workerSelect + " WHERE user_id = ?"
+	args := []interface{}{farmID}
+	if contractType != "" {
+		query += " AND contract_type = ?"
+		args = append(args, contractType)
+	}
+	if activeOn != nil {
+		day := activeOn.Format("2006-01-02")
+		query += " AND date(contract_start) <= ? AND (contract_end IS NULL OR date(contract_end) >= ?)"
+		args = append(args, day, day)
+	}
+	query += " ORDER BY name"
+
+	rows, err := s.db.Query(query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	workers := []models.Worker{}
+	for rows.Next() {
+		worker, err := scanWorker(rows)
+		if err != nil {
+			return nil, err
+		}
+		workers = append(workers, worker)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	certifications, err := s.certifications(farmID, "")
+	if err != nil {
+		return nil, err
+	}
+	today := time.Now().UTC()
+	for i := range workers {
+		workers[i].ContractActive = contractCovers(workers[i], today)
+		workers[i].Certifications = certificationsOf(certifications, workers[i].ID)
+	}
+	return workers, nil
+}
+
+// Get çalışanı sertifikalarıyla döner
+func (s *WorkerService) Get(farmID, id string) (*models.Worker, error) {
+	worker, err := scanWorker(s.db.QueryRow(workerSelect+" WHERE id = ? AND user_id = ?", id, farmID))
+	if err == sql.ErrNoRows {
+		return nil, ErrWorkerNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	certifications, err := s.certifications(farmID, id)
+	if err != nil {
+		return nil, err
+	}
+	worker.ContractActive = contractCovers(worker, time.Now().UTC())
+	worker.Certifications = certificationsOf(certifications, id)
+	return &worker, nil
+}
+
+// Create çalışan ekler
+func (s *WorkerService) Create(farmID string, req models.WorkerRequest) (*models.Worker, error) {
+	if err := validateContractPeriod(req); err != nil {
+		return nil, err
+	}
+
+	phone, err := EncryptField(ColumnWorkerPhone, strings.TrimSpace(req.Phone))
+	if err != nil {
+		return nil, err
+	}
+
+	id := utils.GenerateID()
+	_, err = s.db.Exec(`
+		INSERT INTO workers (id, user_id, name, role, phone, contract_type, contract_start, contract_end, notes,
+		                     created_at, updated_at)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, CURRENT_TIMESTAMP, CURRENT_TIMESTAMP)
+	`, id, farmID, strings.TrimSpace(req.Name), req.Role, phone, req.ContractType, req.ContractStart,
+		req.ContractEnd, req.Notes)
+	if err != nil {
+		return nil, err
+	}
+	return s.Get(farmID, id)
+}
+
+// Update çalışanın bilgilerini ve sözleşme dönemini günceller
+func (s *WorkerService) Update(farmID, id string, req models.WorkerRequest) (*models.Worker, error) {
+	if err := validateContractPeriod(req); err != nil {
+		return nil, err
+	}
+
+	phone, err := EncryptField(ColumnWorkerPhone, strings.TrimSpace(req.Phone))
+	if err != nil {
+		return nil, err
+	}
+
+	result, err := s.db.Exec(`
+		UPDATE workers
+		SET name = ?, role = ?, phone = ?, contract_type = ?, contract_start = ?, contract_end = ?, notes = ?,
+		    updated_at = CURRENT_TIMESTAMP
+		WHERE id = ? AND user_id = ?
+	`, strings.TrimSpace(req.Name), req.Role, phone, req.ContractType, req.ContractStart, req.ContractEnd,
+		req.Notes, id, farmID)
+	if err != nil {
+		return nil, err
+	}
+	if rows, _ := result.RowsAffected(); rows == 0 {
+		return nil, ErrWorkerNotFound
+	}
+	return s.Get(farmID, id)
+}
+
+// Delete çalışanı sertifikalarıyla siler; çalışanın atandığı aktivitelerin ataması kaldırılır
+func (s *WorkerService) Delete(farmID, id string) error {
+	tx, err := s.db.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	result, err := tx.Exec("DELETE FROM workers WHERE id = ? AND user_id = ?", id, farmID)
+	if err != nil {
+		return err
+	}
+	if rows, _ := result.RowsAffected(); rows == 0 {
+		return ErrWorkerNotFound
+	}
+	if _, err := tx.Exec("DELETE FROM worker_certifications WHERE worker_id = ? AND user_id = ?", id, farmID); err != nil {
+		return err
+	}
+	if _, err := tx.Exec(`
+		UPDATE land_activities SET assigned_worker_id = NULL
+		WHERE assigned_worker_id = ? AND land_id IN (SELECT id FROM lands WHERE user_id = ?)
+	`, id, farmID); err != nil {
+		return err
+	}
+	return tx.Commit()
+}
+
+// AddCertification çalışana sertifika ekler; yenilenen belge yeni kayıt olarak eklenir, geçerlilik denetiminde
+// aynı türdeki en geç biten belge kullanılır
+func (s *WorkerService) AddCertification(farmID, workerID string, req models.WorkerCertificationRequest) (*models.Worker, error) {
+	var exists bool
+	if err := s.db.QueryRow("SELECT 1 FROM workers WHERE id = ? AND user_id = ?", workerID, farmID).Scan(&exists); err != nil {
+		return nil, ErrWorkerNotFound
+	}
+
+	_, err := s.db.Exec(`
+		INSERT INTO worker_certifications (id, user_id, worker_id, type, number, issued_at, expires_at, created_at)
+		VALUES (?, ?, ?, ?, ?, ?, ?, CURRENT_TIMESTAMP)
+	`, utils.GenerateID(), farmID, workerID, req.Type, req.Number, req.IssuedAt, req.ExpiresAt)
+	if err != nil {
+		return nil, err
+	}
+	return s.Get(farmID, workerID)
+}
+
+// DeleteCertification çalışanın sertifikasını siler
+func (s *WorkerService) DeleteCertification(farmID, workerID, certificationID string) error {
+	result, err := s.db.Exec(`
+		DELETE FROM worker_certifications WHERE id = ? AND worker_id = ? AND user_id = ?
+	`, certificationID, workerID, farmID)
+	if err != nil {
+		return err
+	}
+	if rows, _ := result.RowsAffected(); rows == 0 {
+		return ErrCertificationNotFound
+	}
+	return nil
+}
+
+// RequiredCertifications aktivite türünün gerektirdiği sertifika türleri
+func RequiredCertifications(activityType string) []string {
+	activityType = strings.ToLower(strings.TrimSpace(activityType))
+	var required []string
+	for _, certification := range []string{models.CertificationPesticideApplicator, models.CertificationDrivingLicense} {
+		for _, alias := range certificationRequirements[certification] {
+			if activityType == alias {
+				required = append(required, certification)
+				break
+			}
+		}
+	}
+	return required
+}
+
+// CheckAssignment çalışanın verilen günde sözleşmesinin sürdüğünü ve aktivite türünün gerektirdiği belgelerin
+// geçerli olduğunu denetler; sorun yoksa boş liste döner
+func (s *WorkerService) CheckAssignment(farmID, workerID, activityType string, day time.Time) ([]models.WorkerAssignmentIssue, error) {
+	worker, err := s.Get(farmID, workerID)
+	if err != nil {
+		return nil, err
+	}
+
+	issues := []models.WorkerAssignmentIssue{}
+	if !contractCovers(*worker, day) {
+		issues = append(issues, models.WorkerAssignmentIssue{
+			Code:    models.AssignmentIssueContractInactive,
+			Message: fmt.Sprintf("%s tarihinde çalışanın sözleşmesi yok", day.Format("02.01.2006")),
+		})
+	}
+
+	for _, required := range RequiredCertifications(activityType) {
+		held, valid := false, false
+		for _, certification := range worker.Certifications {
+			if certification.Type != required {
+				continue
+			}
+			held = true
+			if certification.ExpiresAt == nil || *certification.ExpiresAt >= day.Format("2006-01-02") {
+				valid = true
+				break
+			}
+		}
+
+		label := certificationLabels[required]
+		switch {
+		case !held:
+			issues = append(issues, models.WorkerAssignmentIssue{
+				Code:          models.AssignmentIssueCertificationMissing,
+				Certification: required,
+				Message:       "Çalışanın " + label + " yok",
+			})
+		case !valid:
+			issues = append(issues, models.WorkerAssignmentIssue{
+				Code:          models.AssignmentIssueCertificationExpired,
+				Certification: required,
+				Message:       fmt.Sprintf("Çalışanın %s %s tarihinde geçersiz", label, day.Format("02.01.2006")),
+			})
+		}
+	}
+	return issues, nil
+}
+
+// Assign arazi aktivitesini çalışana atar veya workerID nil ise atamayı kaldırır. Aktivite tarihinde (planlanan,
+// yoksa gerçekleşen, o da yoksa bugün) çalışanın sözleşmesi veya gerekli belgesi geçerli değilse atama yine yapılır,
+// sorunlar döner ve çiftliğe bildirim gönderilir
+func (s *WorkerService) Assign(farmID, landID, activityID string, workerID *string) (models.ActivityAssignment, error) {
+	assignment := models.ActivityAssignment{ActivityID: activityID, WorkerID: workerID, Issues: []models.WorkerAssignmentIssue{}}
+
+	var activityType, description string
+	var scheduledDate, actualDate sql.NullTime
+	err := s.db.QueryRow(`
+		SELECT a.type, COALESCE(a.description, ''), a.scheduled_date, a.actual_date
+		FROM land_activities a JOIN lands l ON l.id = a.land_id
+		WHERE a.id = ? AND a.land_id = ? AND l.user_id = ?
+	`, activityID, landID, farmID).Scan(&activityType, &description, &scheduledDate, &actualDate)
+	if err == sql.ErrNoRows {
+		return assignment, ErrActivityNotFound
+	}
+	if err != nil {
+		return assignment, err
+	}
+
+	var worker *models.Worker
+	if workerID != nil {
+		if worker, err = s.Get(farmID, *workerID); err != nil {
+			return assignment, err
+		}
+	}
+
+	if _, err := s.db.Exec(`
+		UPDATE land_activities SET assigned_worker_id = ?
+		WHERE id = ? AND land_id IN (SELECT id FROM lands WHERE user_id = ?)
+	`, workerID, activityID, farmID); err != nil {
+		return assignment, err
+	}
+	if worker == nil {
+		return assignment, nil
+	}
+
+	day := time.Now().UTC()
+	switch {
+	case scheduledDate.Valid:
+		day = scheduledDate.Time
+	case actualDate.Valid:
+		day = actualDate.Time
+	}
+	if assignment.Issues, err = s.CheckAssignment(farmID, worker.ID, activityType, day); err != nil {
+		return assignment, err
+	}
+	if len(assignment.Issues) > 0 {
+		err = s.notifyIssues(farmID, *worker, activityID, activityType, day, assignment.Issues)
+	}
+	return assignment, err
+}
+
+// notifyIssues yeterliliği eksik çalışanın aktiviteye atandığını bildirir; aynı atama için günde bir bildirim gönderilir
+func (s *WorkerService) notifyIssues(farmID string, worker models.Worker, activityID, activityType string, day time.Time, issues []models.WorkerAssignmentIssue) error {
+	contractInactive := false
+	certifications := []string{}
+	for _, issue := range issues {
+		if issue.Code == models.AssignmentIssueContractInactive {
+			contractInactive = true
+		} else {
+			certifications = append(certifications, issue.Certification)
+		}
+	}
+
+	_, err := s.notifications.Create(Notification{
+		UserID:    farmID,
+		Template:  "worker_certification_missing",
+		Type:      "warning",
+		Priority:  "high",
+		Topic:     models.NotificationTopicWorkerCertification,
+		Entity:    &models.RelatedEntity{Type: "worker", ID: worker.ID, Name: worker.Name},
+		DedupeKey: "worker_assignment:" + activityID + ":" + worker.ID,
+		Params: map[string]interface{}{
+			"activity":         activityType,
+			"date":             day.Format("2006-01-02"),
+			"contractInactive": contractInactive,
+			"certifications":   certifications,
+		},
+	})
+	return err
+}
+
+// certifications çiftliğin sertifikaları; workerID verilirse yalnızca o çalışanınkiler
+func (s *WorkerService) certifications(farmID, workerID string) ([]models.WorkerCertification, error) {
+	query := `
+		SELECT id, worker_id, type, COALESCE(number, ''), date(issued_at), date(expires_at), created_at
+		FROM worker_certifications WHERE user_id = ?`
+	args := []interface{}{farmID}
+	if workerID != "" {
+		query += " AND worker_id = ?"
+		args = append(args, workerID)
+	}
+	query += " ORDER BY type, expires_at IS NULL DESC, expires_at DESC"
+
+	rows, err := s.db.Query(query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	today := time.Now().UTC()
+	today = time.Date(today.Year(), today.Month(), today.Day(), 0, 0, 0, 0, time.UTC)
+
+	var certifications []models.WorkerCertification
+	for rows.Next() {
+		var certification models.WorkerCertification
+		var issuedAt, expiresAt sql.NullString
+		if err := rows.Scan(&certification.ID, &certification.WorkerID, &certification.Type, &certification.Number,
+			&issuedAt, &expiresAt, &certification.CreatedAt); err != nil {
+			return nil, err
+		}
+		certification.IssuedAt = utils.NullStringToPtr(issuedAt)
+		certification.ExpiresAt = utils.NullStringToPtr(expiresAt)
+		certification.Status = models.CertificationStatusValid
+		if expiry, err := time.Parse("2006-01-02", expiresAt.String); expiresAt.Valid && err == nil {
+			days := int(expiry.Sub(today).Hours() / 24)
+			certification.DaysToExpiry = &days
+			switch {
+			case days < 0:
+				certification.Status = models.CertificationStatusExpired
+			case days <= certificationExpiringDays:
+				certification.Status = models.CertificationStatusExpiring
+			}
+		}
+		certifications = append(certifications, certification)
+	}
+	return certifications, rows.Err()
+}
+
+// validateContractPeriod sözleşme bitişinin başlangıçtan önce olmadığını denetler
+func validateContractPeriod(req models.WorkerRequest) error {
+	if req.ContractEnd != nil && *req.ContractEnd < req.ContractStart {
+		return ErrInvalidContractPeriod
+	}
+	return nil
+}
+
+// contractCovers günün çalışanın sözleşme dönemi içinde olup olmadığını döner
+func contractCovers(worker models.Worker, day time.Time) bool {
+	date := day.Format("2006-01-02")
+	return worker.ContractStart <= date && (worker.ContractEnd == nil || *worker.ContractEnd >= date)
+}
+
+// certificationsOf çalışanın sertifikaları
+func certificationsOf(certifications []models.WorkerCertification, workerID string) []models.WorkerCertification {
+	result := []models.WorkerCertification{}
+	for _, certification := range certifications {
+		if certification.WorkerID == workerID {
+			result = append(result, certification)
+		}
+	}
+	return result
+}
+
+// scanWorker çalışan satırını okur; telefon çözülür
+func scanWorker(row interface{ Scan(...interface{}) error }) (models.Worker, error) {
+	var worker models.Worker
+	var contractEnd sql.NullString
+	err := row.Scan(&worker.ID, &worker.Name, &worker.Role, &worker.Phone, &worker.ContractType,
+		&worker.ContractStart, &contractEnd, &worker.Notes, &worker.CreatedAt, &worker.UpdatedAt)
+	worker.ContractEnd = utils.NullStringToPtr(contractEnd)
+	worker.Phone = RevealField(ColumnWorkerPhone, worker.Phone)
+	return worker, err
+}
@@ -0,0 +1,165 @@
+package services
+
+import (
+	"archive/zip"
+	"encoding/xml"
+	"io"
+	"strconv"
+	"strings"
+)
+
+// xlsxStaticParts tek sayfalık bir Excel çalışma kitabının sayfa dışındaki sabit parçaları
+var xlsxStaticParts = []struct{ name, content string }{
+	{"[Content_Types].xml", `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<Types xmlns="http://schemas.openxmlformats.org/package/2006/content-types">` +
+		`<Default Extension="rels" ContentType="application/vnd.openxmlformats-package.relationships+xml"/>` +
+		`<Default Extension="xml" ContentType="application/xml"/>` +
+		`<Override PartName="/xl/workbook.xml" ContentType="application/vnd.openxmlformats-officedocument.spreadsheetml.sheet.main+xml"/>` +
+		`<Override PartName="/xl/worksheets/sheet1.xml" ContentType="application/vnd.openxmlformats-officedocument.spreadsheetml.worksheet+xml"/>` +
+		`<Override PartName="/xl/styles.xml" ContentType="application/vnd.openxmlformats-officedocument.spreadsheetml.styles+xml"/>` +
+		`</Types>`},
+	{"_rels/.rels", `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<Relationships xmlns="http://schemas.openxmlformats.org/package/2006/relationships">` +
+		`<Relationship Id="rId1" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/officeDocument" Target="xl/workbook.xml"/>` +
+		`</Relationships>`},
+	{"xl/_rels/workbook.xml.rels", `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<Relationships xmlns="http://schemas.openxmlformats.org/package/2006/relationships">` +
+		`<Relationship Id="rId1" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/worksheet" Target="worksheets/sheet1.xml"/>` +
+		`<Relationship Id="rId2" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/styles" Target="styles.xml"/>` +
+		`</Relationships>`},
+	{"xl/styles.xml", `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<styleSheet xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main">` +
+		`<fonts count="2"><font><sz val="11"/><name val="Calibri"/></font><font><b/><sz val="11"/><name val="Calibri"/></font></fonts>` +
+		`<fills count="2"><fill><patternFill patternType="none"/></fill><fill><patternFill patternType="gray125"/></fill></fills>` +
+		`<borders count="1"><border><left/><right/><top/><bottom/><diagonal/></border></borders>` +
+		`<cellStyleXfs count="1"><xf numFmtId="0" fontId="0" fillId="0" borderId="0"/></cellStyleXfs>` +
+		`<cellXfs count="2"><xf numFmtId="0" fontId="0" fillId="0" borderId="0" xfId="0"/>` +
+		`<xf numFmtId="0" fontId="1" fillId="0" borderId="0" xfId="0" applyFont="1"/></cellXfs>` +
+		`</styleSheet>`},
+}
+
+// WriteXLSX kayıtları tek sayfalık bir Excel (XLSX) çalışma kitabı olarak yazar. İlk satır kalın başlık
+// satırıdır; sayı olarak okunabilen hücreler (baştaki sıfırlar korunarak) sayı, diğerleri metin olarak yazılır
+func WriteXLSX(w io.Writer, sheetName string, records [][]string) error {
+	archive := zip.NewWriter(w)
+
+	for _, part := range xlsxStaticParts {
+		file, err := archive.Create(part.name)
+		if err != nil {
+			return err
+		}
+		if _, err := io.WriteString(file, part.content); err != nil {
+			return err
+		}
+	}
+
+	workbook, err := archive.Create("xl/workbook.xml")
+	if err != nil {
+		return err
+	}
+	_, err = io.WriteString(workbook, `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<workbook xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main" xmlns:r="http://schemas.openxmlformats.org/officeDocument/2006/relationships">`+
+		`<sheets><sheet name="`+xlsxEscape(xlsxSheetName(sheetName))+`" sheetId="1" r:id="rId1"/></sheets></workbook>`)
+	if err != nil {
+		return err
+	}
+
+	sheet, err := archive.Create("xl/worksheets/sheet1.xml")
+	if err != nil {
+		return err
+	}
+	if err := writeXLSXSheet(sheet, records); err != nil {
+		return err
+	}
+
+	return archive.Close()
+}
+
+// writeXLSXSheet sayfa verisini satır satır yazar
+func writeXLSXSheet(w io.Writer, records [][]string) error {
+	var b strings.Builder
+	b.WriteString(`<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<worksheet xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main">`)
+	if len(records) > 0 {
+		b.WriteString(`<sheetViews><sheetView workbookViewId="0"><pane ySplit="1" topLeftCell="A2" activePane="bottomLeft" state="frozen"/></sheetView></sheetViews>`)
+	}
+	b.WriteString(`<sheetData>`)
+
+	for i, record := range records {
+		row := strconv.Itoa(i + 1)
+		b.WriteString(`<row r="` + row + `">`)
+		for j, value := range record {
+			ref := xlsxColumn(j) + row
+			style := ""
+			if i == 0 {
+				style = ` s="1"`
+			}
+			if i > 0 && xlsxNumeric(value) {
+				b.WriteString(`<c r="` + ref + `"` + style + `><v>` + value + `</v></c>`)
+				continue
+			}
+			b.WriteString(`<c r="` + ref + `"` + style + ` t="inlineStr"><is><t xml:space="preserve">` + xlsxEscape(value) + `</t></is></c>`)
+		}
+		b.WriteString(`</row>`)
+	}
+
+	b.WriteString(`</sheetData></worksheet>`)
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+// xlsxColumn sıfırdan başlayan sütun sırasını Excel sütun harfine (A, B, ..., AA) çevirir
+func xlsxColumn(index int) string {
+	name := ""
+	for index >= 0 {
+		name = string(rune('A'+index%26)) + name
+		index = index/26 - 1
+	}
+	return name
+}
+
+// xlsxNumeric değerin sayı hücresi olarak yazılıp yazılamayacağını döner; 0012 gibi baştaki sıfırı olan
+// kimlikler metin olarak kalır
+func xlsxNumeric(value string) bool {
+	if value == "" {
+		return false
+	}
+	if _, err := strconv.ParseFloat(value, 64); err != nil {
+		return false
+	}
+	digits := strings.TrimPrefix(value, "-")
+	return !(len(digits) > 1 && digits[0] == '0' && digits[1] != '.') && !strings.ContainsAny(value, "eEnN")
+}
+
+// xlsxSheetName Excel'in sayfa adı kurallarına (en fazla 31 karakter, []:*?/\ yok) uyan ad döner
+func xlsxSheetName(name string) string {
+	name = strings.Map(func(r rune) rune {
+		if strings.ContainsRune(`[]:*?/\`, r) {
+			return '-'
+		}
+		return r
+	}, name)
+	if runes := []rune(name); len(runes) > 31 {
+		name = string(runes[:31])
+	}
+	if name == "" {
+		return "Sayfa1"
+	}
+	return name
+}
+
+// xlsxEscape metni XML içinde güvenle kullanılacak şekilde kaçışlar
+func xlsxEscape(value string) string {
+	var b strings.Builder
+	xml.EscapeText(&b, []byte(value))
+	return b.String()
+}
+
+// SpreadsheetText kullanıcıdan gelen metni CSV ve XLSX hücrelerinde formül olarak çalışmayacak hale getirir;
+// =, +, -, @, sekme veya satır başı ile başlayan metnin başına ' eklenir
+func SpreadsheetText(value string) string {
+	if value != "" && strings.ContainsRune("=+-@\t\r", rune(value[0])) {
+		return "'" + value
+	}
+	return value
+}
@@ -5,7 +5,10 @@ import (
 	"encoding/json"
 	"errors"
 	"math/rand"
+	"mime"
 	"net/http"
+	"reflect"
+	"sort"
 	"strconv"
 	"strings"
 	"time"
@@ -66,6 +69,19 @@ func ParsePagination(c *gin.Context) (page, limit int) {
 	return page, limit
 }
 
+// ParseSort sortBy ve sortOrder parametrelerini izin verilen kolonlara göre ORDER BY ifadesine çevirir
+func ParseSort(c *gin.Context, allowed map[string]string, defaultSort string) string {
+	column, ok := allowed[c.Query("sortBy")]
+	if !ok {
+		return defaultSort
+	}
+
+	if strings.ToLower(c.Query("sortOrder")) == "asc" {
+		return column + " ASC"
+	}
+	return column + " DESC"
+}
+
 // CalculatePagination sayfalama bilgilerini hesaplar
 func CalculatePagination(page, limit, total int) models.Pagination {
 	totalPages := (total + limit - 1) / limit
@@ -81,11 +97,95 @@ func CalculatePagination(page, limit, total int) models.Pagination {
 	}
 }
 
+// EnvelopeQuery yanıtın APIResponse zarfına sarılıp sarılmayacağını belirleyen sorgu parametresi (?envelope=false)
+const EnvelopeQuery = "envelope"
+
+// DBTimingKey DEBUG_DB_TIMING açıkken isteğin veritabanı süresini veren fonksiyonun gin context anahtarı
+const DBTimingKey = "db_timing"
+
+// RawProfile zarfsız yanıt isteyen Accept profili (Accept: application/json; profile="raw")
+const RawProfile = "raw"
+
+// WantsRawResponse isteğin APIResponse zarfı olmadan yalın kaynak istediğini döner; envelope sorgu
+// parametresi Accept başlığındaki profile değerinden önceliklidir
+func WantsRawResponse(c *gin.Context) bool {
+	if envelope, ok := c.GetQuery(EnvelopeQuery); ok {
+		raw, err := strconv.ParseBool(envelope)
+		return err == nil && !raw
+	}
+
+	for _, accept := range strings.Split(c.GetHeader("Accept"), ",") {
+		_, params, err := mime.ParseMediaType(strings.TrimSpace(accept))
+		if err == nil && params["profile"] == RawProfile {
+			return true
+		}
+	}
+	return false
+}
+
 // SuccessResponse başarılı API yanıtı oluşturur
 func SuccessResponse(c *gin.Context, data interface{}, message string) {
+	SuccessResponseWithStatus(c, http.StatusOK, data, message)
+}
+
+// CreatedResponse oluşturulan kaynak için 201 yanıtı oluşturur
+func CreatedResponse(c *gin.Context, data interface{}, message string) {
+	SuccessResponseWithStatus(c, http.StatusCreated, data, message)
+}
+
+// SuccessResponseWithStatus başarılı API yanıtını verilen durum koduyla oluşturur; zarfsız modda yalnızca
+// kaynak döner, kaynak yoksa 204 No Content yazılır
+func SuccessResponseWithStatus(c *gin.Context, statusCode int, data interface{}, message string) {
+	if WantsRawResponse(c) {
+		if data == nil {
+			c.Status(http.StatusNoContent)
+			return
+		}
+		responseCacheHint(c, statusCode, data)
+		c.JSON(statusCode, data)
+		return
+	}
+
+	c.JSON(statusCode, successEnvelope(c, statusCode, data, message))
+}
+
+// DetailResponse kayıt detayını ilişki bağlantılarıyla döner; zarfsız modda bağlantılar Link başlığında
+// (RFC 8288) gönderilir
+func DetailResponse(c *gin.Context, data interface{}, links models.Links, message string) {
+	if WantsRawResponse(c) {
+		if header := LinkHeader(links); header != "" {
+			c.Header("Link", header)
+		}
+		responseCacheHint(c, http.StatusOK, data)
+		c.JSON(http.StatusOK, data)
+		return
+	}
+
+	response := successEnvelope(c, http.StatusOK, data, message)
+	response.Links = links
+	c.JSON(http.StatusOK, response)
+}
+
+// LinkHeader bağlantıları ilişki adına göre sıralı Link başlığı değerine çevirir
+func LinkHeader(links models.Links) string {
+	rels := make([]string, 0, len(links))
+	for rel := range links {
+		rels = append(rels, rel)
+	}
+	sort.Strings(rels)
+
+	values := make([]string, 0, len(rels))
+	for _, rel := range rels {
+		values = append(values, "<"+links[rel].Href+`>; rel="`+rel+`"`)
+	}
+	return strings.Join(values, ", ")
+}
+
+// successEnvelope başarılı yanıt zarfını meta bilgileriyle oluşturur
+func successEnvelope(c *gin.Context, statusCode int, data interface{}, message string) models.APIResponse {
 	requestID, _ := c.Get("request_id")
 
-	response := models.APIResponse{
+	return models.APIResponse{
 		Success: true,
 		Data:    data,
 		Message: message,
@@ -93,14 +193,152 @@ func SuccessResponse(c *gin.Context, data interface{}, message string) {
 			Timestamp: time.Now().Format(time.RFC3339),
 			Version:   "1.0",
 			RequestID: requestID.(string),
+			DB:        requestDBTiming(c),
+			Cache:     responseCacheHint(c, statusCode, data),
 		},
 	}
+}
+
+// requestDBTiming isteğin o ana kadarki veritabanı süresini döner; süre ölçümü açık değilse nil döner
+func requestDBTiming(c *gin.Context) *models.DBTiming {
+	value, ok := c.Get(DBTimingKey)
+	if !ok {
+		return nil
+	}
+	timing := value.(func() models.DBTiming)()
+	return &timing
+}
 
-	c.JSON(http.StatusOK, response)
+// CacheHintKey isteğin rotasına göre belirlenen önbellek ipucunun (*models.CacheHint) gin context anahtarı
+const CacheHintKey = "cache_hint"
+
+// lastModifiedDepth yanıt verisinde son değişiklik zamanı aranırken inilen en fazla seviye
+const lastModifiedDepth = 4
+
+// responseCacheHint başarılı GET yanıtının önbellek ipucunu yanıttaki kayıtların son değişiklik zamanıyla
+// tamamlar ve Cache-Control ile Last-Modified başlıklarını yazar; handler Cache-Control başlığını kendisi
+// belirlediyse korunur. İpucu yoksa veya yanıt 200 değilse nil döner
+func responseCacheHint(c *gin.Context, statusCode int, data interface{}) *models.CacheHint {
+	value, ok := c.Get(CacheHintKey)
+	if !ok || statusCode != http.StatusOK {
+		return nil
+	}
+
+	hint := *value.(*models.CacheHint)
+	if !hint.NoStore {
+		hint.LastModified = lastModified(data)
+	}
+
+	if c.Writer.Header().Get("Cache-Control") == "" {
+		c.Header("Cache-Control", CacheControl(hint))
+	}
+	if hint.LastModified != nil {
+		c.Header("Last-Modified", hint.LastModified.Format(http.TimeFormat))
+	}
+	return &hint
+}
+
+// CacheControl önbellek ipucunu Cache-Control başlığı değerine çevirir
+func CacheControl(hint models.CacheHint) string {
+	if hint.NoStore {
+		return "no-store"
+	}
+	scope := "private"
+	if hint.Resource == "public" {
+		scope = "public"
+	}
+	return scope + ", max-age=" + strconv.Itoa(hint.MaxAge) + ", stale-while-revalidate=" + strconv.Itoa(hint.StaleWhileRevalidate)
 }
 
-// ErrorResponse hata API yanıtı oluşturur
+// lastModified yanıt verisindeki kayıtların UpdatedAt (yoksa CreatedAt) alanlarının en yenisini saniyeye
+// yuvarlanmış olarak döner; zaman alanı bulunmazsa nil döner
+func lastModified(data interface{}) *time.Time {
+	var latest time.Time
+	walkLastModified(reflect.ValueOf(data), 0, &latest)
+	if latest.IsZero() {
+		return nil
+	}
+	latest = latest.UTC().Truncate(time.Second)
+	return &latest
+}
+
+// walkLastModified yapı, liste ve map değerlerinde lastModifiedDepth seviyeye kadar inerek en yeni
+// değişiklik zamanını latest'e yazar
+func walkLastModified(v reflect.Value, depth int, latest *time.Time) {
+	if depth > lastModifiedDepth {
+		return
+	}
+	for v.Kind() == reflect.Interface || v.Kind() == reflect.Pointer {
+		if v.IsNil() {
+			return
+		}
+		v = v.Elem()
+	}
+
+	switch v.Kind() {
+	case reflect.Struct:
+		if v.Type() == reflect.TypeOf(time.Time{}) {
+			return
+		}
+		for _, name := range []string{"UpdatedAt", "CreatedAt"} {
+			if t, ok := timeField(v, name); ok {
+				if t.After(*latest) {
+					*latest = t
+				}
+				break
+			}
+		}
+		for i := 0; i < v.NumField(); i++ {
+			if v.Type().Field(i).IsExported() {
+				walkLastModified(v.Field(i), depth+1, latest)
+			}
+		}
+	case reflect.Slice, reflect.Array:
+		switch v.Type().Elem().Kind() {
+		case reflect.Struct, reflect.Pointer, reflect.Interface, reflect.Map, reflect.Slice:
+			for i := 0; i < v.Len(); i++ {
+				walkLastModified(v.Index(i), depth+1, latest)
+			}
+		}
+	case reflect.Map:
+		iter := v.MapRange()
+		for iter.Next() {
+			walkLastModified(iter.Value(), depth+1, latest)
+		}
+	}
+}
+
+// timeField yapının time.Time veya *time.Time türündeki dolu alanını döner
+func timeField(v reflect.Value, name string) (time.Time, bool) {
+	field := v.FieldByName(name)
+	if !field.IsValid() || !field.CanInterface() {
+		return time.Time{}, false
+	}
+	if field.Kind() == reflect.Pointer {
+		if field.IsNil() {
+			return time.Time{}, false
+		}
+		field = field.Elem()
+	}
+	t, ok := field.Interface().(time.Time)
+	return t, ok && !t.IsZero()
+}
+
+// ErrorResponse hata API yanıtı oluşturur; zarfsız modda RFC 7807 problem+json döner
 func ErrorResponse(c *gin.Context, statusCode int, code, message string, details interface{}) {
+	if WantsRawResponse(c) {
+		c.Header("Content-Type", "application/problem+json; charset=utf-8")
+		c.JSON(statusCode, models.ProblemDetails{
+			Type:     "about:blank",
+			Title:    message,
+			Status:   statusCode,
+			Code:     code,
+			Details:  details,
+			Instance: c.Request.URL.Path,
+		})
+		return
+	}
+
 	requestID, _ := c.Get("request_id")
 
 	response := models.APIResponse{
@@ -114,13 +352,15 @@ func ErrorResponse(c *gin.Context, statusCode int, code, message string, details
 			Timestamp: time.Now().Format(time.RFC3339),
 			Version:   "1.0",
 			RequestID: requestID.(string),
+			DB:        requestDBTiming(c),
 		},
 	}
 
 	c.JSON(statusCode, response)
 }
 
-// GetUserID context'ten kullanıcı ID'sini alır
+// GetUserID context'ten kullanıcı ID'sini alır; çiftlik kapsamındaki isteklerde seçili çiftliğin
+// kayıtlarının sahibi olan kimliği döner (varsayılan çiftlikte hesap kimliğiyle aynıdır)
 func GetUserID(c *gin.Context) (string, error) {
 	userID, exists := c.Get("user_id")
 	if !exists {
@@ -129,6 +369,22 @@ func GetUserID(c *gin.Context) (string, error) {
 	return userID.(string), nil
 }
 
+// GetAccountID context'ten seçili çiftlikten bağımsız olarak oturum açan hesabın ID'sini alır
+func GetAccountID(c *gin.Context) (string, error) {
+	if accountID, exists := c.Get("account_id"); exists {
+		return accountID.(string), nil
+	}
+	return GetUserID(c)
+}
+
+// GetFarmID context'ten seçili çiftliğin ID'sini alır; çiftlik kapsamı yoksa hesabın varsayılan çiftliğini döner
+func GetFarmID(c *gin.Context) (string, error) {
+	if farmID, exists := c.Get("farm_id"); exists {
+		return farmID.(string), nil
+	}
+	return GetAccountID(c)
+}
+
 // GetUserEmail context'ten kullanıcı email'ini alır
 func GetUserEmail(c *gin.Context) (string, error) {
 	userEmail, exists := c.Get("user_email")
@@ -254,3 +510,9 @@ func ToJSON(v interface{}) (string, error) {
 func FromJSON(data string, v interface{}) error {
 	return json.Unmarshal([]byte(data), v)
 }
+
+// MarkDeprecated yanıta kullanımdan kaldırma başlıklarını ekler
+func MarkDeprecated(c *gin.Context, note string) {
+	c.Header("Deprecation", "true")
+	c.Header("Warning", `299 - "`+note+`"`)
+}